@@ -13,13 +13,26 @@ func (m *Manager) AggregationLoop(ctx context.Context) {
 		m.logger.Error("error while getting store height", "error", err)
 		return
 	}
+	// blocks produced with asynchronous execution before a restart are executed before producing blocks
+	// synchronously
+	if !m.asyncExecution() && height > m.GetLastState().LastBlockHeight {
+		if err := m.executeOrderedBlocks(ctx); err != nil {
+			m.logger.Error("failed to execute blocks", "error", err)
+			return
+		}
+	}
 	var delay time.Duration
 
 	if height < initialHeight {
-		delay = time.Until(m.genesis.GenesisDAStartTime.Add(m.config.Node.BlockTime.Duration))
+		delay = time.Until(m.genesis.GenesisDAStartTime.Add(m.blockTime()))
 	} else {
 		lastBlockTime := m.getLastBlockTime()
-		delay = time.Until(lastBlockTime.Add(m.config.Node.BlockTime.Duration))
+		delay = time.Until(lastBlockTime.Add(m.blockTime()))
+	}
+	// with slot alignment, the first block is produced at the start of a slot as well
+	if m.config.Node.SlotAlignment {
+		delay = max(delay, 0)
+		delay += untilNextSlot(time.Now().Add(delay), m.blockTime())
 	}
 
 	if delay > 0 {
@@ -33,15 +46,22 @@ func (m *Manager) AggregationLoop(ctx context.Context) {
 	blockTimer := time.NewTimer(0)
 	defer blockTimer.Stop()
 
-	// Lazy Aggregator mode.
-	// In Lazy Aggregator mode, blocks are built only when there are
-	// transactions or every LazyBlockTime.
-	if m.config.Node.LazyMode {
-		m.lazyAggregationLoop(ctx, blockTimer)
-		return
+	// The aggregation loops return when lazy mode is switched at runtime,
+	// and aggregation continues in the other mode.
+	for {
+		// Lazy Aggregator mode.
+		// In Lazy Aggregator mode, blocks are built only when there are
+		// transactions or every LazyBlockTime.
+		if m.lazyMode() {
+			m.lazyAggregationLoop(ctx, blockTimer)
+		} else {
+			m.normalAggregationLoop(ctx, blockTimer)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		m.logger.Info("switched aggregation mode", "lazy", m.lazyMode())
 	}
-
-	m.normalAggregationLoop(ctx, blockTimer)
 }
 
 func (m *Manager) lazyAggregationLoop(ctx context.Context, blockTimer *time.Timer) {
@@ -56,37 +76,54 @@ func (m *Manager) lazyAggregationLoop(ctx context.Context, blockTimer *time.Time
 
 		case <-lazyTimer.C:
 			m.logger.Debug("Lazy timer triggered block production")
-			m.produceBlock(ctx, "lazy_timer", lazyTimer, blockTimer)
+			if m.produceBlock(ctx, "lazy_timer", lazyTimer, blockTimer) {
+				m.txsAvailable = m.txsPending()
+			}
 
 		case <-blockTimer.C:
 			if m.txsAvailable {
-				m.produceBlock(ctx, "block_timer", lazyTimer, blockTimer)
-				m.txsAvailable = false
+				if m.produceBlock(ctx, "block_timer", lazyTimer, blockTimer) {
+					m.txsAvailable = m.txsPending()
+				}
 			} else {
 				// Ensure we keep ticking even when there are no txs
-				blockTimer.Reset(m.config.Node.BlockTime.Duration)
+				blockTimer.Reset(m.blockDelay(time.Now(), m.blockTime()))
 			}
 		case <-m.txNotifyCh:
 			m.txsAvailable = true
+		case <-m.runtimeConfigCh:
+			if !m.lazyMode() {
+				return
+			}
 		}
 	}
 }
 
-// produceBlock handles the common logic for producing a block and resetting timers
-func (m *Manager) produceBlock(ctx context.Context, mode string, lazyTimer, blockTimer *time.Timer) {
+// txsPending reports whether transactions left out of the last block wait for inclusion, i.e. transactions
+// deferred by the block limits or pending forced inclusion transactions, so that lazy aggregation produces the
+// next block on the block timer instead of waiting for new transactions or the lazy timer.
+func (m *Manager) txsPending() bool {
+	return len(m.deferredTxs) > 0 || len(m.pendingForcedTxs()) > 0
+}
+
+// produceBlock handles the common logic for producing a block and resetting timers. It reports whether a block
+// was published, or attempted to, i.e. false while block production is paused.
+func (m *Manager) produceBlock(ctx context.Context, mode string, lazyTimer, blockTimer *time.Timer) bool {
 	// Record the start time
 	start := time.Now()
 
 	// Attempt to publish the block
-	if err := m.publishBlock(ctx); err != nil && ctx.Err() == nil {
+	published, err := m.publishUnlessPaused(ctx)
+	if err != nil && ctx.Err() == nil {
 		m.logger.Error("error while publishing block", "mode", mode, "error", err)
-	} else {
+	} else if published {
 		m.logger.Debug("Successfully published block", "mode", mode)
 	}
 
 	// Reset both timers for the next aggregation window
-	lazyTimer.Reset(getRemainingSleep(start, m.config.Node.LazyBlockInterval.Duration))
-	blockTimer.Reset(getRemainingSleep(start, m.config.Node.BlockTime.Duration))
+	lazyTimer.Reset(m.blockDelay(start, m.lazyBlockInterval()))
+	blockTimer.Reset(m.blockDelay(start, m.nextBlockTime()))
+	return published
 }
 
 func (m *Manager) normalAggregationLoop(ctx context.Context, blockTimer *time.Timer) {
@@ -98,18 +135,23 @@ func (m *Manager) normalAggregationLoop(ctx context.Context, blockTimer *time.Ti
 			// Define the start time for the block production period
 			start := time.Now()
 
-			if err := m.publishBlock(ctx); err != nil && ctx.Err() == nil {
+			if _, err := m.publishUnlessPaused(ctx); err != nil && ctx.Err() == nil {
 				m.logger.Error("error while publishing block", "error", err)
 			}
 			// Reset the blockTimer to signal the next block production
 			// period based on the block time.
-			blockTimer.Reset(getRemainingSleep(start, m.config.Node.BlockTime.Duration))
+			blockTimer.Reset(m.blockDelay(start, m.nextBlockTime()))
 
 		case <-m.txNotifyCh:
 			// Transaction notifications are intentionally ignored in normal mode
 			// to avoid triggering block production outside the scheduled intervals.
 			// We just update the txsAvailable flag for tracking purposes
 			m.txsAvailable = true
+
+		case <-m.runtimeConfigCh:
+			if m.lazyMode() {
+				return
+			}
 		}
 	}
 }
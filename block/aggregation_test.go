@@ -57,7 +57,8 @@ func TestAggregationLoop_Normal_BasicInterval(t *testing.T) {
 			InitialHeight: 1,
 		},
 		lastState: types.State{
-			LastBlockTime: time.Now().Add(-blockTime),
+			LastBlockHeight: 1,
+			LastBlockTime:   time.Now().Add(-blockTime),
 		},
 		lastStateMtx: &sync.RWMutex{},
 		metrics:      NopMetrics(),
@@ -155,7 +156,8 @@ func TestAggregationLoop_Normal_PublishBlockError(t *testing.T) {
 			InitialHeight: 1,
 		},
 		lastState: types.State{
-			LastBlockTime: time.Now().Add(-blockTime),
+			LastBlockHeight: 1,
+			LastBlockTime:   time.Now().Add(-blockTime),
 		},
 		lastStateMtx: &sync.RWMutex{},
 		metrics:      NopMetrics(),
@@ -0,0 +1,127 @@
+package block
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+)
+
+const (
+	// backfillWindow is the number of DA heights retrieved concurrently by the backfill worker.
+	backfillWindow = 16
+
+	// backfillLagThreshold is the number of blocks the node must lag behind the header store of the p2p
+	// header sync service before it starts backfilling from the DA layer.
+	backfillLagThreshold = 100
+
+	// backfillStallBlocks is the number of DA block times without a new block after which p2p is considered
+	// partitioned and the node starts backfilling from the DA layer.
+	backfillStallBlocks = 10
+)
+
+// BackfillLoop retrieves headers and block data from the DA layer by ranges of DA heights when the node
+// falls far behind the chain or stops receiving blocks over p2p, or continuously if DA retrieval is
+// preferred over p2p. DA heights of a range are requested concurrently, at most DA.BackfillRateLimit per
+// second, and processed in order.
+func (m *Manager) BackfillLoop(ctx context.Context) {
+	interval := time.Second
+	if m.config.DA.BackfillRateLimit > 0 {
+		interval = time.Duration(float64(time.Second) / m.config.DA.BackfillRateLimit)
+	}
+	limiter := time.NewTicker(interval)
+	defer limiter.Stop()
+
+	ticker := time.NewTicker(m.config.DA.BlockTime.Duration)
+	defer ticker.Stop()
+
+	lastHeight, err := m.store.Height(ctx)
+	if err != nil {
+		m.logger.Error("failed to get store height for BackfillLoop", "error", err)
+		return
+	}
+	lastProgress := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		height, err := m.store.Height(ctx)
+		if err != nil {
+			m.logger.Error("failed to get store height", "error", err)
+			continue
+		}
+		if height > lastHeight {
+			lastHeight, lastProgress = height, time.Now()
+		}
+		if !m.needsBackfill(height, time.Since(lastProgress)) {
+			continue
+		}
+		// keep retrieving ranges until the DA height catches up with the DA layer
+		for ctx.Err() == nil {
+			caughtUp, err := m.backfillRange(ctx, limiter.C)
+			if err != nil && ctx.Err() == nil {
+				m.logger.Error("failed to backfill from DA", "daHeight", m.daHeight.Load(), "error", err)
+			}
+			if caughtUp || err != nil {
+				break
+			}
+		}
+	}
+}
+
+// needsBackfill reports whether the node should backfill from the DA layer, given the store height and the
+// time elapsed since the last new block.
+func (m *Manager) needsBackfill(height uint64, sinceLastBlock time.Duration) bool {
+	if m.config.DA.PreferRetrieval {
+		return true
+	}
+	if m.headerStore != nil && m.headerStore.Height() >= height+backfillLagThreshold {
+		return true
+	}
+	return sinceLastBlock >= backfillStallBlocks*m.config.DA.BlockTime.Duration
+}
+
+// backfillRange retrieves the next backfillWindow DA heights concurrently, waiting for limiter before each
+// request, and processes the heights in order. It returns true if the DA layer does not have all heights of
+// the range yet.
+func (m *Manager) backfillRange(ctx context.Context, limiter <-chan time.Time) (bool, error) {
+	m.retrieveMtx.Lock()
+	defer m.retrieveMtx.Unlock()
+
+	start := m.daHeight.Load()
+	results := make([]coreda.ResultRetrieve, backfillWindow)
+	errs := make([]error, backfillWindow)
+	var wg sync.WaitGroup
+	for i := range backfillWindow {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-limiter:
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = m.fetchBlobs(ctx, start+uint64(i))
+		}()
+	}
+	wg.Wait()
+
+	for i := range backfillWindow {
+		daHeight := start + uint64(i)
+		if err := errs[i]; err != nil {
+			if m.areAllErrorsHeightFromFuture(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		if results[i].Code != coreda.StatusNotFound {
+			m.logger.Debug("backfilled potential data", "n", len(results[i].Data), "daHeight", daHeight)
+			m.processBlobs(ctx, results[i].Data, results[i].IDs, daHeight)
+		}
+		m.daHeight.Store(daHeight + 1)
+	}
+	return false, nil
+}
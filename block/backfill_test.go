@@ -0,0 +1,73 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/types"
+)
+
+// TestBackfillRange verifies that a range of DA heights is retrieved and processed in order, up to the first
+// height not yet available on the DA layer.
+func TestBackfillRange(t *testing.T) {
+	t.Parallel()
+	startDAHeight := uint64(20)
+	manager, mockDAClient, _, _, _, _, cancel := setupManagerForRetrieverTest(t, startDAHeight)
+	defer cancel()
+
+	header, err := types.GetRandomSignedHeaderCustom(&types.HeaderConfig{Height: 7, Signer: manager.signer}, manager.genesis.ChainID)
+	require.NoError(t, err)
+	header.ProposerAddress = manager.genesis.ProposerAddress
+	headerProto, err := header.ToProto()
+	require.NoError(t, err)
+	headerBytes, err := proto.Marshal(headerProto)
+	require.NoError(t, err)
+
+	// the header was published at DA height 23, heights up to 25 are available
+	isHeight := func(h uint64) func(uint64) bool { return func(height uint64) bool { return height == h } }
+	mockDAClient.On("GetIDs", mock.Anything, mock.MatchedBy(isHeight(23)), mock.Anything).Return(&coreda.GetIDsResult{
+		IDs:       []coreda.ID{[]byte("id")},
+		Timestamp: time.Now(),
+	}, nil).Once()
+	mockDAClient.On("Get", mock.Anything, []coreda.ID{[]byte("id")}, mock.Anything).Return([]coreda.Blob{headerBytes}, nil).Once()
+	mockDAClient.On("GetIDs", mock.Anything, mock.MatchedBy(func(h uint64) bool { return h != 23 && h <= 25 }), mock.Anything).
+		Return(nil, coreda.ErrBlobNotFound)
+	mockDAClient.On("GetIDs", mock.Anything, mock.MatchedBy(func(h uint64) bool { return h > 25 }), mock.Anything).
+		Return(nil, ErrHeightFromFutureStr)
+
+	limiter := make(chan time.Time, backfillWindow)
+	for range backfillWindow {
+		limiter <- time.Now()
+	}
+	caughtUp, err := manager.backfillRange(context.Background(), limiter)
+	require.NoError(t, err)
+	assert.True(t, caughtUp)
+	assert.Equal(t, uint64(26), manager.daHeight.Load())
+
+	select {
+	case event := <-manager.headerInCh:
+		assert.Equal(t, header.Height(), event.Header.Height())
+		assert.Equal(t, uint64(23), event.DAHeight)
+	default:
+		t.Fatal("expected header event not received")
+	}
+}
+
+func TestNeedsBackfill(t *testing.T) {
+	t.Parallel()
+	manager, _, _, _, _, _, cancel := setupManagerForRetrieverTest(t, 1)
+	defer cancel()
+
+	assert.False(t, manager.needsBackfill(1, time.Second), "node is in sync")
+	assert.True(t, manager.needsBackfill(1, backfillStallBlocks*manager.config.DA.BlockTime.Duration), "no blocks received over p2p")
+
+	manager.config.DA.PreferRetrieval = true
+	assert.True(t, manager.needsBackfill(1, 0))
+}
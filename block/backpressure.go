@@ -0,0 +1,61 @@
+package block
+
+import (
+	"fmt"
+	"time"
+)
+
+// daBacklog returns the number of headers waiting for DA submission, and whether it exceeds the DA backlog
+// threshold. Only aggregators apply backpressure, other nodes do not submit headers.
+func (m *Manager) daBacklog() (uint64, bool) {
+	threshold := m.config.Node.DABacklogThreshold
+	if threshold == 0 || !m.config.Node.Aggregator || m.pendingHeaders == nil {
+		return 0, false
+	}
+	backlog := m.pendingHeaders.numPendingHeaders()
+	return backlog, backlog > threshold
+}
+
+// nextBlockTime returns the time between the last block and the next block produced by the aggregator. Above the
+// DA backlog threshold, the block time is scaled by the ratio of the backlog to the threshold, so that block
+// production slows down as the DA layer falls behind, until MaxPendingHeaders pauses it. A warning is logged
+// when backpressure starts and stops applying.
+func (m *Manager) nextBlockTime() time.Duration {
+	blockTime := m.blockTime()
+	if m.config.Node.DABacklogThreshold == 0 {
+		// the threshold may have been disabled while backpressure applied
+		if m.daBackpressure.Swap(false) {
+			m.metrics.DABackpressure.Set(0)
+			m.logger.Info("DA backlog threshold disabled, resuming block production")
+		}
+		return blockTime
+	}
+	backlog, over := m.daBacklog()
+	m.metrics.DABacklog.Set(float64(backlog))
+	if over != m.daBackpressure.Swap(over) {
+		if over {
+			m.metrics.DABackpressure.Set(1)
+			m.logger.Warn("DA submission backlog exceeds threshold, slowing down block production and rejecting transactions",
+				"pendingHeaders", backlog, "threshold", m.config.Node.DABacklogThreshold)
+		} else {
+			m.metrics.DABackpressure.Set(0)
+			m.logger.Info("DA submission backlog drained, resuming block production", "pendingHeaders", backlog)
+		}
+	}
+	if !over {
+		return blockTime
+	}
+	return time.Duration(float64(blockTime) * float64(backlog) / float64(m.config.Node.DABacklogThreshold))
+}
+
+// CheckDABacklog returns an error wrapping ErrDABacklog while the number of headers waiting for DA submission
+// exceeds the DA backlog threshold. It is called for the transactions submitted to the node, which are rejected
+// until the backlog drains.
+func (m *Manager) CheckDABacklog() error {
+	backlog, over := m.daBacklog()
+	if !over {
+		return nil
+	}
+	m.metrics.DABacklogRejectedTxs.Add(1)
+	return fmt.Errorf("%w: %d headers pending DA submission, threshold %d", ErrDABacklog, backlog, m.config.Node.DABacklogThreshold)
+}
@@ -0,0 +1,53 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+)
+
+// TestDABacklogBackpressure verifies that block production slows down in proportion to the DA submission
+// backlog and that transactions are rejected while the backlog exceeds its threshold.
+func TestDABacklogBackpressure(t *testing.T) {
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	headers, _ := saveSignedBlocks(t, s, 4)
+
+	m, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	m.config.Node.BlockTime.Duration = time.Second
+
+	// backpressure is disabled without threshold
+	assert.Equal(t, time.Second, m.nextBlockTime())
+	assert.NoError(t, m.CheckDABacklog())
+
+	// only aggregators apply backpressure
+	m.config.Node.DABacklogThreshold = 2
+	assert.Equal(t, time.Second, m.nextBlockTime())
+	assert.NoError(t, m.CheckDABacklog())
+
+	m.config.Node.Aggregator = true
+	assert.Equal(t, 2*time.Second, m.nextBlockTime())
+	assert.True(t, m.daBackpressure.Load())
+	assert.ErrorIs(t, m.CheckDABacklog(), ErrDABacklog)
+
+	// the backlog drains below the threshold
+	m.pendingHeaders.setLastSubmittedHeight(context.Background(), 2)
+	assert.Equal(t, time.Second, m.nextBlockTime())
+	assert.False(t, m.daBackpressure.Load())
+	assert.NoError(t, m.CheckDABacklog())
+
+	// disabling the threshold while backpressure applies resets it
+	m.config.Node.DABacklogThreshold = 1
+	assert.Equal(t, 2*time.Second, m.nextBlockTime())
+	assert.True(t, m.daBackpressure.Load())
+	m.config.Node.DABacklogThreshold = 0
+	assert.Equal(t, time.Second, m.nextBlockTime())
+	assert.False(t, m.daBackpressure.Load())
+}
@@ -0,0 +1,155 @@
+package block
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/types"
+)
+
+// BasedLoop derives the blocks of the chain from the DA layer in based sequencing mode. The DA namespace is
+// read in order, and the batches posted at each DA height are executed as one block, made of their
+// transactions in DA order. DA heights without batches produce no block. As block ordering and block times
+// derive from the DA layer only, every node derives the same chain and no aggregator is needed.
+func (m *Manager) BasedLoop(ctx context.Context) {
+	ticker := time.NewTicker(m.config.DA.BlockTime.Duration)
+	defer ticker.Stop()
+	for {
+		if err := m.deriveBasedBlocks(ctx); err != nil && ctx.Err() == nil {
+			m.logger.Error("failed to derive blocks from DA", "daHeight", m.daHeight.Load(), "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deriveBasedBlocks executes the batches of all DA heights available since the last call.
+func (m *Manager) deriveBasedBlocks(ctx context.Context) error {
+	for ctx.Err() == nil {
+		daHeight := m.daHeight.Load()
+		res, err := m.fetchBlobs(ctx, daHeight)
+		if err != nil {
+			if m.areAllErrorsHeightFromFuture(err) {
+				return nil
+			}
+			return err
+		}
+		var txs types.Txs
+		for _, bz := range res.Data {
+			bz, ok := m.openBlob(bz, daHeight)
+			if !ok {
+				continue
+			}
+			// headers posted by a former aggregator are not part of the based chain
+			if m.decodeHeaderBlob(bz) != nil {
+				continue
+			}
+			if data := decodeBatchBlob(bz); data != nil {
+				txs = append(txs, data.Txs...)
+			}
+		}
+		if len(txs) > 0 {
+			if err := m.applyBasedBlock(ctx, daHeight, res.Timestamp, txs); err != nil {
+				return err
+			}
+		}
+		m.daHeight.Store(daHeight + 1)
+	}
+	return ctx.Err()
+}
+
+// applyBasedBlock executes the transactions posted at the given DA height as the next block. The block is
+// not signed: it is DA-included by construction, and any node can derive it again from the DA layer.
+func (m *Manager) applyBasedBlock(ctx context.Context, daHeight uint64, daTime time.Time, txs types.Txs) error {
+	height, err := m.store.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("error while getting store height: %w", err)
+	}
+	newHeight := height + 1
+
+	var (
+		lastHeaderHash types.Hash
+		lastDataHash   types.Hash
+	)
+	if newHeight > m.genesis.InitialHeight {
+		lastHeader, lastData, err := m.store.GetBlockData(ctx, height)
+		if err != nil {
+			return fmt.Errorf("error while loading last block: %w, height: %d", err, height)
+		}
+		lastHeaderHash = lastHeader.Hash()
+		lastDataHash = lastData.Hash()
+	}
+
+	// DA block times are expected to increase, but block times must never go backwards
+	blockTime := daTime
+	if lastBlockTime := m.getLastBlockTime(); blockTime.Before(lastBlockTime) {
+		blockTime = lastBlockTime
+	}
+
+	lastState := m.GetLastState()
+	header := &types.SignedHeader{
+		Header: types.Header{
+			Version: types.Version{
+				Block: lastState.Version.Block,
+				App:   lastState.Version.App,
+			},
+			BaseHeader: types.BaseHeader{
+				ChainID: lastState.ChainID,
+				Height:  newHeight,
+				Time:    uint64(blockTime.UnixNano()), //nolint:gosec // DA block times are after the unix epoch
+			},
+			LastHeaderHash: lastHeaderHash,
+			ConsensusHash:  make(types.Hash, 32),
+			AppHash:        lastState.AppHash,
+		},
+	}
+	// based blocks commit to the DA block they are derived from
+	if reader, ok := coreda.Implements[coreda.BlockReader](m.da); ok && daHeight > 0 {
+		hash, err := reader.BlockHash(ctx, daHeight)
+		if err != nil {
+			return fmt.Errorf("failed to get hash of DA block %d: %w", daHeight, err)
+		}
+		header.DABlockHeight, header.DABlockHash = daHeight, hash
+	}
+	data := &types.Data{Txs: txs}
+	header.DataHash = data.DACommitment()
+
+	m.logger.Info("Applying block derived from DA", "height", newHeight, "daHeight", daHeight, "num_tx", len(txs))
+	newState, err := m.applyBlock(ctx, header, data)
+	if err != nil {
+		return fmt.Errorf("failed to apply block %d: %w", newHeight, err)
+	}
+	data.Metadata = &types.Metadata{
+		ChainID:      header.ChainID(),
+		Height:       newHeight,
+		Time:         header.BaseHeader.Time,
+		LastDataHash: lastDataHash,
+	}
+
+	if err := m.store.SaveBlockData(ctx, header, data, &header.Signature); err != nil {
+		return SaveBlockError{err}
+	}
+	if err := m.store.SetHeight(ctx, newHeight); err != nil {
+		return err
+	}
+	newState.DAHeight = daHeight + 1
+	if err := m.updateState(ctx, newState); err != nil {
+		return err
+	}
+
+	m.headerCache.SetDAIncluded(header.Hash().String())
+	m.dataCache.SetDAIncluded(data.DACommitment().String())
+	m.sendNonBlockingSignalToDAIncluderCh()
+
+	m.scheduleSnapshot(newState)
+	m.recordMetrics(data)
+	m.markForcedTxsIncluded(ctx, newHeight, data.Txs)
+	m.resolvePreconfirmations(ctx, newHeight, data.Txs)
+	m.publishNewBlock(header, data)
+	return nil
+}
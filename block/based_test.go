@@ -0,0 +1,110 @@
+package block
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/cache"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/test/mocks"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+func TestBasedSequencing(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+
+	daHeight := &atomic.Uint64{}
+	daHeight.Store(5)
+	genesisTime := time.Unix(1000, 0).UTC()
+	m := &Manager{
+		store:        s,
+		config:       config.DefaultConfig,
+		genesis:      genesis.Genesis{ChainID: "test-chain", InitialHeight: 1},
+		lastState:    types.State{ChainID: "test-chain", InitialHeight: 1, LastBlockTime: genesisTime, AppHash: []byte{1, 2, 3}},
+		lastStateMtx: &sync.RWMutex{},
+		daHeight:     daHeight,
+		headerCache:  cache.NewCache[types.SignedHeader](),
+		dataCache:    cache.NewCache[types.Data](),
+		daIncluderCh: make(chan struct{}, 1),
+		exec:         coreexecutor.NewDummyExecutor(),
+		logger:       log.NewNopLogger(),
+		metrics:      NopMetrics(),
+	}
+	m.sequencing, err = newSequencingStrategy(m, config.SequencingModeBased)
+	require.NoError(t, err)
+	assert.Equal(t, config.SequencingModeBased, m.Sequencing().Mode())
+
+	batch := func(txs ...string) coreda.Blob {
+		batchPb := &pb.Batch{}
+		for _, tx := range txs {
+			batchPb.Txs = append(batchPb.Txs, []byte(tx))
+		}
+		bz, err := proto.Marshal(batchPb)
+		require.NoError(t, err)
+		return bz
+	}
+	compressed, err := types.CompressBlob(types.BlobCodecGzip, batch("tx3"))
+	require.NoError(t, err)
+
+	mockDA := mocks.NewDA(t)
+	ids := []coreda.ID{[]byte("b1"), []byte("b2")}
+	mockDA.On("GetIDs", mock.Anything, uint64(5), mock.Anything).Return(&coreda.GetIDsResult{IDs: ids, Timestamp: genesisTime.Add(time.Minute)}, nil).Once()
+	mockDA.On("Get", mock.Anything, ids, mock.Anything).Return([]coreda.Blob{batch("tx1", "tx2"), compressed}, nil).Once()
+	mockDA.On("GetIDs", mock.Anything, uint64(6), mock.Anything).Return(nil, coreda.ErrBlobNotFound).Once()
+	// a DA block time earlier than the last block time does not move block time backwards
+	mockDA.On("GetIDs", mock.Anything, uint64(7), mock.Anything).Return(&coreda.GetIDsResult{IDs: []coreda.ID{[]byte("b3")}, Timestamp: genesisTime}, nil).Once()
+	mockDA.On("Get", mock.Anything, []coreda.ID{[]byte("b3")}, mock.Anything).Return([]coreda.Blob{batch("tx4")}, nil).Once()
+	mockDA.On("GetIDs", mock.Anything, uint64(8), mock.Anything).Return(nil, ErrHeightFromFutureStr).Once()
+	m.da = mockDA
+
+	require.NoError(t, m.deriveBasedBlocks(ctx))
+
+	height, err := s.Height(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), height)
+
+	header1, data1, err := s.GetBlockData(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{types.Tx("tx1"), types.Tx("tx2"), types.Tx("tx3")}, data1.Txs)
+	assert.True(t, genesisTime.Add(time.Minute).Equal(header1.Time()))
+
+	header2, data2, err := s.GetBlockData(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, types.Txs{types.Tx("tx4")}, data2.Txs)
+	assert.Equal(t, header1.Hash(), header2.LastHeaderHash)
+	assert.True(t, header1.Time().Equal(header2.Time()))
+
+	// blocks derived from the DA layer are DA-included
+	included, err := m.IsDAIncluded(ctx, 2)
+	require.NoError(t, err)
+	assert.True(t, included)
+	assert.Equal(t, uint64(8), m.daHeight.Load())
+	assert.Equal(t, uint64(8), m.GetLastState().DAHeight)
+}
+
+func TestNewSequencingStrategy(t *testing.T) {
+	m := &Manager{}
+	strategy, err := newSequencingStrategy(m, "")
+	require.NoError(t, err)
+	assert.Equal(t, config.SequencingModeAggregator, strategy.Mode())
+
+	_, err = newSequencingStrategy(m, "unknown")
+	assert.Error(t, err)
+}
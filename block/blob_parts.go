@@ -0,0 +1,67 @@
+package block
+
+import (
+	"context"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/types"
+)
+
+// minMaxBlobSize is the lower bound of the maximum blob size when it is reduced after blobs were rejected as too
+// big by the DA layer.
+const minMaxBlobSize = 1024
+
+// maxBlobSizeFor returns the maximum size of the blobs submitted through the DA client: the lowest of the
+// configured maximum blob size, the maximum blob size reported by the DA client if it implements
+// coreda.BlobSizer, and the reduced maximum blob size after blobs were rejected as too big. It returns 0 if no
+// limit is known.
+func (m *Manager) maxBlobSizeFor(ctx context.Context, da coreda.DA) uint64 {
+	limit := m.config.DA.MaxBlobSize
+	if sizer, ok := coreda.Implements[coreda.BlobSizer](da); ok {
+		if daLimit, err := sizer.MaxBlobSize(ctx); err != nil {
+			m.logger.Debug("failed to get maximum blob size of DA layer", "error", err)
+		} else if daLimit > 0 && (limit == 0 || daLimit < limit) {
+			limit = daLimit
+		}
+	}
+	if reduced := m.reducedMaxBlobSize.Load(); reduced > 0 && (limit == 0 || reduced < limit) {
+		limit = reduced
+	}
+	return limit
+}
+
+// reduceMaxBlobSize halves the maximum size of the submitted blobs after a blob of the given size was rejected as
+// too big by the DA layer, so that the next attempt splits it into smaller parts.
+func (m *Manager) reduceMaxBlobSize(rejectedSize int) {
+	limit := max(uint64(rejectedSize)/2, minMaxBlobSize) //nolint:gosec // blob sizes are positive
+	for {
+		reduced := m.reducedMaxBlobSize.Load()
+		if reduced > 0 && reduced <= limit {
+			return
+		}
+		if m.reducedMaxBlobSize.CompareAndSwap(reduced, limit) {
+			m.logger.Info("reduced maximum DA blob size", "maxBlobSize", limit)
+			return
+		}
+	}
+}
+
+// assembleBlobPart adds a part of a blob split across several DA blobs, and returns the blobs decoded from the
+// reassembled blob once all its parts were retrieved. The decoded blobs share the DA ID of the last part.
+func (m *Manager) assembleBlobPart(blob daBlob, daHeight uint64) []daBlob {
+	assembled, ok, err := m.blobAssembler.Add(*blob.part)
+	if err != nil {
+		m.logger.Debug("dropping blob part", "daHeight", daHeight, "error", err)
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	m.logger.Debug("reassembled split blob", "daHeight", daHeight, "parts", blob.part.Total, "size", len(assembled))
+	return m.decodeBlobs([][]byte{assembled}, [][]byte{blob.id}, daHeight)
+}
+
+// splitBatchBlob splits an encoded batch exceeding the maximum blob size of the DA client into parts.
+func (m *Manager) splitBatchBlob(ctx context.Context, da coreda.DA, blob []byte) ([][]byte, error) {
+	return types.SplitBlob(blob, m.maxBlobSizeFor(ctx, da))
+}
@@ -0,0 +1,106 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/types"
+)
+
+// submittedBlobs returns the blobs submitted to a dummy DA layer, in DA height order.
+func submittedBlobs(t *testing.T, da *coreda.DummyDA) [][]byte {
+	t.Helper()
+	ctx := context.Background()
+	var blobs [][]byte
+	for height := uint64(0); ; height++ {
+		res, err := da.GetIDs(ctx, height, nil)
+		require.NoError(t, err)
+		if len(res.IDs) == 0 {
+			return blobs
+		}
+		got, err := da.Get(ctx, res.IDs, nil)
+		require.NoError(t, err)
+		blobs = append(blobs, got...)
+	}
+}
+
+// TestSubmitBatchToDA_SplitLargeBatch verifies that a batch exceeding the maximum blob size of the DA layer is split
+// across several blobs, reassembled by the retriever.
+func TestSubmitBatchToDA_SplitLargeBatch(t *testing.T) {
+	tests := []struct {
+		name string
+		// da hides the maximum blob size of the dummy DA layer if false
+		reportsMaxBlobSize bool
+		maxBlobSize        uint64
+	}{
+		{name: "maximum blob size of the DA layer", reportsMaxBlobSize: true},
+		{name: "configured maximum blob size", reportsMaxBlobSize: true, maxBlobSize: 1500},
+		{name: "blobs rejected as too big", reportsMaxBlobSize: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dummyDA := coreda.NewDummyDA(4096, 0, 0)
+			var da coreda.DA = dummyDA
+			if !tt.reportsMaxBlobSize {
+				da = struct{ coreda.DA }{dummyDA}
+			}
+			m, mockStore := getManager(t, da, 1, 0)
+			mockStore.On("SetMetadata", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+			m.config.DA = config.DAConfig{BlockTime: config.DurationWrapper{Duration: time.Millisecond}, MaxBlobSize: tt.maxBlobSize}
+			m.daIncluderCh = make(chan struct{}, 1)
+
+			txs := [][]byte{types.GetRandomBytes(6000), types.GetRandomBytes(6000)}
+			require.NoError(t, m.submitBatchToDA(ctx, coresequencer.Batch{Transactions: txs}))
+
+			data := &types.Data{Txs: types.Txs{txs[0], txs[1]}}
+			dataHash := data.DACommitment().String()
+			assert.True(t, m.DataCache().IsDAIncluded(dataHash))
+			_, ok := m.getDAPointer(dataHash)
+			assert.True(t, ok)
+
+			blobs := submittedBlobs(t, dummyDA)
+			var parts int
+			for _, blob := range blobs {
+				if _, ok, _ := types.ParseBlobPart(blob); ok {
+					parts++
+				}
+				if tt.maxBlobSize > 0 {
+					assert.LessOrEqual(t, uint64(len(blob)), tt.maxBlobSize)
+				}
+			}
+			assert.Greater(t, parts, 2)
+
+			// the retriever reassembles the batch from its parts
+			daHeight := uint64(10)
+			r, mockDAClient, _, _, _, dataCache, cancel := setupManagerForRetrieverTest(t, daHeight)
+			defer cancel()
+			ids := make([]coreda.ID, len(blobs))
+			for i := range blobs {
+				ids[i] = []byte{byte(i)}
+			}
+			mockDAClient.On("GetIDs", mock.Anything, daHeight, mock.Anything).Return(&coreda.GetIDsResult{IDs: ids}, nil).Once()
+			mockDAClient.On("Get", mock.Anything, ids, mock.Anything).Return(blobs, nil).Once()
+			require.NoError(t, r.processNextDAHeaderAndData(ctx))
+
+			select {
+			case event := <-r.dataInCh:
+				assert.Equal(t, data.Txs, event.Data.Txs)
+				assert.True(t, dataCache.IsDAIncluded(dataHash))
+				pointer, ok := r.getDAPointer(dataHash)
+				require.True(t, ok)
+				assert.Equal(t, []byte(ids[len(ids)-1]), pointer.ID)
+			case <-time.After(100 * time.Millisecond):
+				t.Fatal("Expected block data event not received")
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package block
+
+// ConfirmationStatus is the confirmation tier of a block.
+type ConfirmationStatus int
+
+const (
+	// ConfirmationPending means the block is not known to be signed by the sequencer yet.
+	ConfirmationPending ConfirmationStatus = iota
+	// ConfirmationSoftConfirmed means the block is signed by the sequencer, but not included in the DA layer yet.
+	ConfirmationSoftConfirmed
+	// ConfirmationAttested means the block is attested by a quorum of the attester set, but not included in the
+	// DA layer yet.
+	ConfirmationAttested
+	// ConfirmationDAFinalized means the block is included in the DA layer.
+	ConfirmationDAFinalized
+)
+
+// String returns the name of the confirmation status.
+func (s ConfirmationStatus) String() string {
+	switch s {
+	case ConfirmationSoftConfirmed:
+		return "soft_confirmed"
+	case ConfirmationAttested:
+		return "attested"
+	case ConfirmationDAFinalized:
+		return "da_finalized"
+	default:
+		return "pending"
+	}
+}
+
+// GetBlockConfirmationStatus returns the confirmation tier of the block at the given height.
+// The soft-confirmed height is advanced by produced blocks and by the header sync service, the
+// attested height by the attestation service and the DA included height by the DAIncluderLoop.
+func (m *Manager) GetBlockConfirmationStatus(height uint64) ConfirmationStatus {
+	switch {
+	case height == 0:
+		return ConfirmationPending
+	case height <= m.GetDAIncludedHeight():
+		return ConfirmationDAFinalized
+	case height <= m.GetAttestedHeight():
+		return ConfirmationAttested
+	case height <= m.GetSoftConfirmedHeight():
+		return ConfirmationSoftConfirmed
+	default:
+		return ConfirmationPending
+	}
+}
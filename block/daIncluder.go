@@ -1,59 +1,138 @@
 package block
 
 import (
+	"cmp"
 	"context"
 	"encoding/binary"
 	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rollkit/rollkit/pkg/failpoint"
+	"github.com/rollkit/rollkit/types"
 )
 
 // DAIncluderLoop is responsible for advancing the DAIncludedHeight by checking if blocks after the current height
 // have both their header and data marked as DA-included in the caches. If so, it calls setDAIncludedHeight.
+// Every DA block time, it also checks that the latest DA included blocks were not removed by a DA reorg, see
+// checkDAReorg.
 func (m *Manager) DAIncluderLoop(ctx context.Context) {
+	var reorgCheck <-chan time.Time
+	if m.config.DA.ReorgCheckDepth > 0 {
+		ticker := time.NewTicker(m.config.DA.BlockTime.Duration)
+		defer ticker.Stop()
+		reorgCheck = ticker.C
+	}
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-m.daIncluderCh:
 			// proceed to check for DA inclusion
+		case <-reorgCheck:
+			if err := m.checkDAReorg(ctx); err != nil {
+				m.logger.Error("failed to check for DA reorgs", "error", err)
+			}
+		}
+		m.advanceDAIncludedHeight(ctx)
+		if err := m.saveDAInclusionCache(ctx); err != nil {
+			m.logger.Error("failed to save DA inclusion cache", "error", err)
 		}
+	}
+}
+
+// advanceDAIncludedHeight advances the DA included height over the consecutive blocks whose header and data
+// are marked as DA-included in the caches. Aggregators posting in epochs advance it over whole epochs, once all
+// the blocks of an epoch are DA-included.
+func (m *Manager) advanceDAIncludedHeight(ctx context.Context) {
+	for {
 		currentDAIncluded := m.GetDAIncludedHeight()
-		for {
-			nextHeight := currentDAIncluded + 1
-			daIncluded, err := m.IsDAIncluded(ctx, nextHeight)
-			if err != nil {
-				// No more blocks to check at this time
-				m.logger.Debug("no more blocks to check at this time", "height", nextHeight, "error", err)
-				break
-			}
-			if daIncluded {
-				// Both header and data are DA-included, so we can advance the height
-				if err := m.incrementDAIncludedHeight(ctx); err != nil {
-					panic(fmt.Errorf("error while incrementing DA included height: %w", err))
-				}
-				currentDAIncluded = nextHeight
-			} else {
-				// Stop at the first block that is not DA-included
-				break
+		end := currentDAIncluded + 1
+		if m.epochs != nil {
+			end = m.epochs.nextEnd(currentDAIncluded, m.pendingHeaders.GetLastSubmittedHeight())
+			if end <= currentDAIncluded {
+				return
 			}
 		}
+		start := currentDAIncluded + 1
+		// the blocks below a restored state snapshot are not in the store, the DA included height advances to the
+		// snapshot block once it is DA included
+		if start < m.storeBaseHeight {
+			start = m.storeBaseHeight
+			end = max(end, start)
+		}
+		if !m.includeBlocks(ctx, start, end) {
+			return
+		}
 	}
 }
 
+// includeBlocks advances the DA included height to end if the blocks from start to end all have their header and
+// data marked as DA-included in the caches, and reports whether it did.
+func (m *Manager) includeBlocks(ctx context.Context, start, end uint64) bool {
+	// blocks which are not applied yet cannot be finalized, e.g. the genesis block saved by full nodes before
+	// they sync it
+	if end > m.GetLastState().LastBlockHeight {
+		return false
+	}
+	headers := make([]*types.SignedHeader, 0, end-start+1)
+	datas := make([]*types.Data, 0, end-start+1)
+	for height := start; height <= end; height++ {
+		header, data, err := m.store.GetBlockData(ctx, height)
+		if err != nil {
+			// No more blocks to check at this time
+			m.logger.Debug("no more blocks to check at this time", "height", height, "error", err)
+			return false
+		}
+		if !m.isDAIncluded(header, data) {
+			// Stop at the first block that is not DA-included
+			return false
+		}
+		headers = append(headers, header)
+		datas = append(datas, data)
+	}
+	for i, header := range headers {
+		if err := m.saveDAInclusion(ctx, header, datas[i]); err != nil {
+			// DA inclusion proofs of the block are unavailable, which must not halt the node
+			m.logger.Error("failed to save DA inclusion", "height", header.Height(), "error", err)
+		}
+		// Both header and data are DA-included, so we can advance the height
+		if err := m.advanceDAIncludedHeightTo(ctx, header.Height()); err != nil {
+			panic(fmt.Errorf("error while incrementing DA included height: %w", err))
+		}
+	}
+	return true
+}
+
 // incrementDAIncludedHeight sets the DA included height in the store
 // It returns an error if the DA included height is not set.
 func (m *Manager) incrementDAIncludedHeight(ctx context.Context) error {
+	return m.advanceDAIncludedHeightTo(ctx, m.GetDAIncludedHeight()+1)
+}
+
+// advanceDAIncludedHeightTo finalizes the block at newHeight in the executor and sets the DA included height to it.
+// The block of a restored state snapshot is not finalized, as the restored executor state is final.
+func (m *Manager) advanceDAIncludedHeightTo(ctx context.Context, newHeight uint64) error {
 	currentHeight := m.GetDAIncludedHeight()
-	newHeight := currentHeight + 1
-	m.logger.Debug("setting final", "height", newHeight)
-	err := m.exec.SetFinal(ctx, newHeight)
-	if err != nil {
-		m.logger.Error("failed to set final", "height", newHeight, "error", err)
+	if err := failpoint.Inject(ctx, failpoint.DAIncludedHeight); err != nil {
 		return err
 	}
+	if newHeight != m.storeBaseHeight {
+		m.logger.Debug("setting final", "height", newHeight)
+		err := m.retryExecutor(ctx, "SetFinal", func(ctx context.Context) error {
+			return m.exec.SetFinal(ctx, newHeight)
+		})
+		if err != nil {
+			m.logger.Error("failed to set final", "height", newHeight, "error", err)
+			return err
+		}
+	}
 	heightBytes := make([]byte, 8)
 	binary.LittleEndian.PutUint64(heightBytes, newHeight)
 	m.logger.Debug("setting DA included height", "height", newHeight)
-	err = m.store.SetMetadata(ctx, DAIncludedHeightKey, heightBytes)
+	err := m.store.SetMetadata(ctx, DAIncludedHeightKey, heightBytes)
 	if err != nil {
 		m.logger.Error("failed to set DA included height", "height", newHeight, "error", err)
 		return err
@@ -61,5 +140,92 @@ func (m *Manager) incrementDAIncludedHeight(ctx context.Context) error {
 	if !m.daIncludedHeight.CompareAndSwap(currentHeight, newHeight) {
 		return fmt.Errorf("failed to set DA included height: %d", newHeight)
 	}
+	m.recordDAInclusionLag(m.GetLastState().LastBlockHeight)
+	m.events.publish(Event{Type: EventDAIncluded, Height: newHeight})
 	return nil
 }
+
+// recordDAInclusionLag records the DA included height and how far it is behind the given latest block height.
+func (m *Manager) recordDAInclusionLag(latestHeight uint64) {
+	daIncludedHeight := m.GetDAIncludedHeight()
+	m.metrics.DAIncludedHeight.Set(float64(daIncludedHeight))
+	var lag uint64
+	if latestHeight > daIncludedHeight {
+		lag = latestHeight - daIncludedHeight
+	}
+	m.metrics.DAInclusionLag.Set(float64(lag))
+}
+
+// daInclusionTracker records which DA backends included an item when the manager submits
+// through a coreda.QuorumSubmitter, so that the item is only marked as DA included once the
+// multiplexer quorum of backends has included it.
+type daInclusionTracker struct {
+	mu       sync.Mutex
+	quorum   int
+	included map[string]*trackedInclusion
+	seq      uint64
+}
+
+// trackedInclusion holds the DA backends which included an item. seq orders the items by their last
+// inclusion.
+type trackedInclusion struct {
+	backends map[int]struct{}
+	seq      uint64
+}
+
+// maxTrackedInclusions bounds the number of items tracked until they reach the quorum. Once the bound is
+// exceeded, the items included least recently are forgotten, which are those whose submission was abandoned.
+const maxTrackedInclusions = 1 << 14
+
+func newDAInclusionTracker(quorum int) *daInclusionTracker {
+	return &daInclusionTracker{
+		quorum:   quorum,
+		included: make(map[string]*trackedInclusion),
+	}
+}
+
+// markIncluded records that hash was included by the given backends and reports whether
+// the quorum has been reached.
+func (t *daInclusionTracker) markIncluded(hash string, backends []int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	seen, ok := t.included[hash]
+	if !ok {
+		seen = &trackedInclusion{backends: make(map[int]struct{})}
+		t.included[hash] = seen
+	}
+	t.seq++
+	seen.seq = t.seq
+	for _, b := range backends {
+		seen.backends[b] = struct{}{}
+	}
+	if len(seen.backends) >= t.quorum {
+		delete(t.included, hash)
+		return true
+	}
+	if len(t.included) > maxTrackedInclusions {
+		t.evict()
+	}
+	return false
+}
+
+// evict forgets the items included least recently, down to 3/4 of maxTrackedInclusions. The item just
+// included is the most recent one and is kept.
+func (t *daInclusionTracker) evict() {
+	hashes := slices.Collect(maps.Keys(t.included))
+	slices.SortFunc(hashes, func(a, b string) int {
+		return cmp.Compare(t.included[a].seq, t.included[b].seq)
+	})
+	for _, hash := range hashes[:len(hashes)-maxTrackedInclusions*3/4] {
+		delete(t.included, hash)
+	}
+}
+
+// daQuorumReached records that hash was included by the given DA backends and reports whether
+// it can be marked as DA included. It always returns true unless the DA client is a coreda.QuorumSubmitter.
+func (m *Manager) daQuorumReached(hash string, backends []int) bool {
+	if m.daInclusion == nil {
+		return true
+	}
+	return m.daInclusion.markIncluded(hash, backends)
+}
@@ -3,20 +3,79 @@ package block
 import (
 	"context"
 	"encoding/binary"
+	"fmt"
+	"math"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"cosmossdk.io/log"
+	"github.com/go-kit/kit/metrics/generic"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
 	"github.com/rollkit/rollkit/pkg/cache"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
 	"github.com/rollkit/rollkit/test/mocks"
 	"github.com/rollkit/rollkit/types"
 )
 
-// newTestManager creates a Manager with mocked Store and Executor for testing DAIncluder logic.
-func newTestManager(t *testing.T) (*Manager, *mocks.Store, *mocks.Executor, *MockLogger) {
+// testManagerOption customizes the Manager created by newTestManager.
+type testManagerOption func(t *testing.T, m *Manager)
+
+// withStore replaces the mocked Store, e.g. by an in-memory store.
+func withStore(s store.Store) testManagerOption {
+	return func(t *testing.T, m *Manager) { m.store = s }
+}
+
+// withExecutor replaces the mocked Executor.
+func withExecutor(exec coreexecutor.Executor) testManagerOption {
+	return func(t *testing.T, m *Manager) { m.exec = exec }
+}
+
+// withConfig sets the config, zero by default.
+func withConfig(cfg config.Config) testManagerOption {
+	return func(t *testing.T, m *Manager) { m.config = cfg }
+}
+
+// withGenesis sets the genesis, zero by default.
+func withGenesis(gen genesis.Genesis) testManagerOption {
+	return func(t *testing.T, m *Manager) { m.genesis = gen }
+}
+
+// withLastState replaces the last state, in which all the blocks in the store are applied by default.
+func withLastState(state types.State) testManagerOption {
+	return func(t *testing.T, m *Manager) { m.lastState = state }
+}
+
+// withPendingHeaders tracks the headers pending DA submission in the store, set by a previous withStore.
+func withPendingHeaders() testManagerOption {
+	return func(t *testing.T, m *Manager) {
+		pendingHeaders, err := NewPendingHeaders(m.store, log.NewNopLogger())
+		require.NoError(t, err)
+		m.pendingHeaders = pendingHeaders
+	}
+}
+
+// withDAHeight sets the next DA height to retrieve.
+func withDAHeight(daHeight uint64) testManagerOption {
+	return func(t *testing.T, m *Manager) { m.daHeight.Store(daHeight) }
+}
+
+// withDA sets the DA client, nil by default.
+func withDA(da coreda.DA) testManagerOption {
+	return func(t *testing.T, m *Manager) { m.da = da }
+}
+
+// newTestManager creates a Manager with mocked Store and Executor for testing DAIncluder logic. Options replace the
+// mocks or set other fields of the Manager.
+func newTestManager(t *testing.T, opts ...testManagerOption) (*Manager, *mocks.Store, *mocks.Executor, *MockLogger) {
 	store := mocks.NewStore(t)
 	exec := mocks.NewExecutor(t)
 	logger := new(MockLogger)
@@ -24,6 +83,9 @@ func newTestManager(t *testing.T) (*Manager, *mocks.Store, *mocks.Executor, *Moc
 	logger.On("Info", mock.Anything, mock.Anything).Maybe()
 	logger.On("Warn", mock.Anything, mock.Anything).Maybe()
 	logger.On("Error", mock.Anything, mock.Anything).Maybe()
+	// the DA inclusion cache is persisted after every check
+	store.On("Height", mock.Anything).Return(uint64(0), nil).Maybe()
+	store.On("SetMetadata", mock.Anything, DAInclusionCacheKey, mock.Anything).Return(nil).Maybe()
 	m := &Manager{
 		store:        store,
 		headerCache:  cache.NewCache[types.SignedHeader](),
@@ -31,9 +93,16 @@ func newTestManager(t *testing.T) (*Manager, *mocks.Store, *mocks.Executor, *Moc
 		daIncluderCh: make(chan struct{}, 1),
 		logger:       logger,
 		exec:         exec,
+		daHeight:     new(atomic.Uint64),
+		gasPricer:    newGasPricer(-1, 0),
 		lastStateMtx: &sync.RWMutex{},
 		metrics:      NopMetrics(),
 	}
+	// all the blocks in the store are applied
+	m.lastState.LastBlockHeight = math.MaxUint64
+	for _, opt := range opts {
+		opt(t, m)
+	}
 	return m, store, exec, logger
 }
 
@@ -110,6 +179,23 @@ func TestDAIncluderLoop_StopsWhenHeaderNotDAIncluded(t *testing.T) {
 	store.AssertExpectations(t)
 }
 
+// TestDAIncluderLoop_StopsAtUnappliedBlock verifies that the DAIncluderLoop does not advance the height over
+// blocks which are DA-included but not applied yet, such as the genesis block saved by full nodes before syncing.
+func TestDAIncluderLoop_StopsAtUnappliedBlock(t *testing.T) {
+	t.Parallel()
+	m, _, exec, _ := newTestManager(t)
+	m.lastState.LastBlockHeight = 0
+
+	header, data := types.GetRandomBlock(1, 0, "testchain")
+	m.headerCache.SetDAIncluded(header.Hash().String())
+	m.dataCache.SetDAIncluded(data.DACommitment().String())
+
+	m.advanceDAIncludedHeight(context.Background())
+
+	assert.Equal(t, uint64(0), m.GetDAIncludedHeight())
+	exec.AssertNotCalled(t, "SetFinal", mock.Anything, mock.Anything)
+}
+
 // TestDAIncluderLoop_StopsWhenDataNotDAIncluded verifies that the DAIncluderLoop does not advance the height
 // if the data for the next block is not marked as DA-included in the cache.
 func TestDAIncluderLoop_StopsWhenDataNotDAIncluded(t *testing.T) {
@@ -371,3 +457,55 @@ func TestDAIncluderLoop_DoesNotAdvanceWhenDataHashIsEmptyAndHeaderNotDAIncluded(
 // because the atomic value is always read at the start of the function, and there is no way to
 // inject a failure or race another goroutine reliably in a unit test. To test this path, the code
 // would need to be refactored to allow injection or mocking of the atomic value.
+
+// TestDAQuorumReached_RequiresQuorumOfBackends verifies that items submitted through a DA multiplexer are only
+// considered DA included once the configured quorum of backends has included them, possibly across retries.
+func TestDAQuorumReached_RequiresQuorumOfBackends(t *testing.T) {
+	t.Parallel()
+	m, _, _, _ := newTestManager(t)
+	assert.True(t, m.daQuorumReached("hash", nil), "without a multiplexer inclusion is immediate")
+
+	m.daInclusion = newDAInclusionTracker(2)
+	assert.False(t, m.daQuorumReached("hash", []int{0}))
+	assert.False(t, m.daQuorumReached("hash", []int{0}), "same backend must not count twice")
+	assert.True(t, m.daQuorumReached("hash", []int{1}))
+	assert.False(t, m.daQuorumReached("other", []int{2}))
+	assert.True(t, m.daQuorumReached("other", []int{0, 1}))
+}
+
+// TestDAQuorumReached_ForgetsAbandonedItems verifies that the items tracked until they reach the quorum are
+// bounded, forgetting the items included least recently.
+func TestDAQuorumReached_ForgetsAbandonedItems(t *testing.T) {
+	t.Parallel()
+	m, _, _, _ := newTestManager(t)
+	m.daInclusion = newDAInclusionTracker(2)
+	assert.False(t, m.daQuorumReached("retried", []int{0}))
+	for i := range maxTrackedInclusions - 1 {
+		assert.False(t, m.daQuorumReached(fmt.Sprintf("abandoned-%d", i), []int{0}))
+	}
+	assert.False(t, m.daQuorumReached("retried", []int{0}))
+	assert.False(t, m.daQuorumReached("last", []int{0}))
+
+	assert.LessOrEqual(t, len(m.daInclusion.included), maxTrackedInclusions*3/4)
+	assert.True(t, m.daQuorumReached("retried", []int{1}), "recently included item must be kept")
+	assert.True(t, m.daQuorumReached("last", []int{1}), "item exceeding the bound must be kept")
+	assert.False(t, m.daQuorumReached("abandoned-0", []int{1}), "abandoned item must be forgotten")
+}
+
+// TestRecordDAInclusionLag verifies that the DA inclusion lag is the number of blocks not DA included yet.
+func TestRecordDAInclusionLag(t *testing.T) {
+	t.Parallel()
+	m, _, _, _ := newTestManager(t)
+	daIncludedHeight, lag := generic.NewGauge("da_included_height"), generic.NewGauge("da_inclusion_lag")
+	m.metrics.DAIncludedHeight, m.metrics.DAInclusionLag = daIncludedHeight, lag
+
+	m.daIncludedHeight.Store(4)
+	m.recordDAInclusionLag(10)
+	assert.Equal(t, float64(4), daIncludedHeight.Value())
+	assert.Equal(t, float64(6), lag.Value())
+
+	// a syncing node may learn about DA inclusion before applying the blocks
+	m.daIncludedHeight.Store(12)
+	m.recordDAInclusionLag(10)
+	assert.Equal(t, float64(0), lag.Value())
+}
@@ -0,0 +1,182 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// ErrDAAuditMismatch is returned when the blobs including a DA included block are no longer retrievable from the
+// DA layer or do not match the DA metadata stored for the block.
+var ErrDAAuditMismatch = errors.New("DA audit mismatch")
+
+// DAAuditLoop re-verifies a random sample of DA.AuditSampleSize DA included blocks against the DA layer every
+// DA.AuditInterval, to detect silent DA data loss or corruption of the local store. Unlike checkDAReorg, which
+// checks the latest DA included blocks, it samples all the DA included blocks down to the pruned height, and only
+// reports discrepancies: they are logged as errors and counted in metrics.
+func (m *Manager) DAAuditLoop(ctx context.Context) {
+	interval := m.config.DA.AuditInterval.Duration
+	if interval == 0 || m.config.DA.AuditSampleSize == 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := m.auditDAInclusion(ctx); err != nil && ctx.Err() == nil {
+			m.logger.Error("DA audit failed", "error", err)
+		}
+	}
+}
+
+// auditDAInclusion re-verifies a random sample of the DA included blocks against the DA layer. Blocks whose DA
+// blobs are unknown, e.g. blocks DA included before the node recorded DA pointers, are skipped, and blocks which
+// cannot be audited, e.g. on transient DA errors, are logged and skipped.
+func (m *Manager) auditDAInclusion(ctx context.Context) error {
+	daIncluded := m.GetDAIncludedHeight()
+	pruned, err := store.GetPrunedHeight(ctx, m.store)
+	if err != nil {
+		return err
+	}
+	start := max(m.genesis.InitialHeight, pruned+1, 1)
+	if daIncluded < start {
+		return nil
+	}
+	for _, height := range sampleHeights(start, daIncluded, m.config.DA.AuditSampleSize) {
+		err := m.auditDABlock(ctx, height)
+		switch {
+		case errors.Is(err, ErrDAInclusionUnknown):
+			continue
+		case errors.Is(err, ErrDAAuditMismatch):
+			m.metrics.DAAuditMismatches.Add(1)
+			m.logger.Error("DA audit found a DA included block not matching the DA layer", "height", height, "error", err)
+		case err != nil:
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// transient DA or store errors only leave the block unaudited until it is sampled again
+			m.logger.Warn("failed to audit DA included block", "height", height, "error", err)
+			continue
+		}
+		m.metrics.DAAuditedBlocks.Add(1)
+	}
+	return nil
+}
+
+// sampleHeights returns up to n distinct random heights between start and end, inclusive.
+func sampleHeights(start, end, n uint64) []uint64 {
+	count := end - start + 1
+	if n >= count {
+		heights := make([]uint64, 0, count)
+		for height := start; height <= end; height++ {
+			heights = append(heights, height)
+		}
+		return heights
+	}
+	sampled := make(map[uint64]struct{}, n)
+	heights := make([]uint64, 0, n)
+	for uint64(len(heights)) < n {
+		height := start + rand.Uint64N(count)
+		if _, ok := sampled[height]; !ok {
+			sampled[height] = struct{}{}
+			heights = append(heights, height)
+		}
+	}
+	return heights
+}
+
+// auditDABlock checks that the blobs including the header and data of the DA included block at the given height
+// are still retrievable from the DA layer, at the DA height stored for them, match their stored commitment and
+// decode to the stored header and to a batch matching its data commitment.
+func (m *Manager) auditDABlock(ctx context.Context, height uint64) error {
+	metadata, err := m.loadDAMetadata(ctx, height)
+	if err != nil {
+		return err
+	}
+	header, _, err := m.store.GetBlockData(ctx, height)
+	if err != nil {
+		return fmt.Errorf("failed to load block %d: %w", height, err)
+	}
+	headerDA, err := m.headerDA(height)
+	if err != nil {
+		return err
+	}
+	includesHeader := func(bz []byte) bool {
+		var headerPb pb.SignedHeader
+		var decoded types.SignedHeader
+		return proto.Unmarshal(bz, &headerPb) == nil && decoded.FromProto(&headerPb) == nil &&
+			bytes.Equal(decoded.Hash(), header.Hash())
+	}
+	if err := auditDABlob(ctx, headerDA, metadata.Header, includesHeader); err != nil {
+		return fmt.Errorf("header blob: %w", err)
+	}
+	if metadata.Data != nil {
+		includesData := func(bz []byte) bool {
+			data := decodeBatchBlob(bz)
+			return data != nil && bytes.Equal(data.DACommitment(), header.DataHash)
+		}
+		if err := auditDABlob(ctx, m.dataDAClient(), *metadata.Data, includesData); err != nil {
+			return fmt.Errorf("data blob: %w", err)
+		}
+	}
+	return nil
+}
+
+// auditDABlob checks that the blob is still retrievable from the DA layer, that its ID locates it at the DA height
+// stored for it if the DA layer uses the Rollkit ID format, that its commitment matches the stored one, and that
+// one of the blobs decoded from it is accepted by includes. Parts of split blobs are only checked against their
+// commitment, since the other parts are needed to decode them.
+func auditDABlob(ctx context.Context, da coreda.DA, blob types.DABlobMetadata, includes func([]byte) bool) error {
+	blobs, err := da.Get(ctx, [][]byte{blob.ID}, []byte(blob.Namespace))
+	if errors.Is(err, coreda.ErrBlobNotFound) || (err == nil && len(blobs) == 0) {
+		return fmt.Errorf("%w: blob %X at DA height %d is not retrievable", ErrDAAuditMismatch, blob.ID, blob.DAHeight)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get blob %X at DA height %d: %w", blob.ID, blob.DAHeight, err)
+	}
+	if len(blobs) != 1 {
+		return fmt.Errorf("expected 1 blob, got %d", len(blobs))
+	}
+	if daHeight, _, err := coreda.SplitID(blob.ID); err == nil && daHeight != blob.DAHeight {
+		return fmt.Errorf("%w: blob %X is at DA height %d, not at the stored DA height %d", ErrDAAuditMismatch, blob.ID, daHeight, blob.DAHeight)
+	}
+	if len(blob.Commitment) > 0 {
+		commitments, err := da.Commit(ctx, blobs, []byte(blob.Namespace))
+		if err != nil {
+			return fmt.Errorf("failed to compute commitment of blob %X: %w", blob.ID, err)
+		}
+		if len(commitments) != 1 || !bytes.Equal(commitments[0], blob.Commitment) {
+			return fmt.Errorf("%w: commitment of blob %X at DA height %d differs from the stored commitment %X", ErrDAAuditMismatch, blob.ID, blob.DAHeight, blob.Commitment)
+		}
+	}
+	if _, ok, _ := types.ParseBlobPart(blobs[0]); ok {
+		return nil
+	}
+	payload, err := unsealBlob(blobs[0])
+	if err != nil {
+		return fmt.Errorf("%w: blob %X at DA height %d: %w", ErrDAAuditMismatch, blob.ID, blob.DAHeight, err)
+	}
+	bundled, err := types.UnbundleBlob(payload)
+	if err != nil {
+		return fmt.Errorf("%w: blob %X at DA height %d: %w", ErrDAAuditMismatch, blob.ID, blob.DAHeight, err)
+	}
+	if !slices.ContainsFunc(bundled, includes) {
+		return fmt.Errorf("%w: blob %X at DA height %d does not include the stored block", ErrDAAuditMismatch, blob.ID, blob.DAHeight)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestAuditDAInclusion(t *testing.T) {
+	ctx := context.Background()
+	m, _, _ := setupDAReorgTest(t)
+	m.config.DA.AuditSampleSize = 10
+	require.NoError(t, m.auditDAInclusion(ctx))
+	for height := uint64(1); height <= 3; height++ {
+		require.NoError(t, m.auditDABlock(ctx, height))
+	}
+
+	// corrupt corrupts the DA metadata of block 3 stored by the node
+	corrupt := func(update func(metadata *types.DAMetadata)) {
+		metadata, err := m.store.GetDAMetadata(ctx, 3)
+		require.NoError(t, err)
+		update(metadata)
+		require.NoError(t, m.store.SetDAMetadata(ctx, metadata))
+	}
+	corrupt(func(metadata *types.DAMetadata) { metadata.Data.Commitment = []byte("corrupted") })
+	assert.ErrorIs(t, m.auditDABlock(ctx, 3), ErrDAAuditMismatch, "commitment differs")
+	corrupt(func(metadata *types.DAMetadata) { metadata.Header.DAHeight = 7 })
+	assert.ErrorIs(t, m.auditDABlock(ctx, 3), ErrDAAuditMismatch, "DA height differs")
+	corrupt(func(metadata *types.DAMetadata) { metadata.Header.ID = []byte("unknown blob") })
+	assert.ErrorIs(t, m.auditDABlock(ctx, 3), ErrDAAuditMismatch, "blob not retrievable")
+
+	// blobs are decoded and compared with the stored block
+	metadata, err := m.store.GetDAMetadata(ctx, 1)
+	require.NoError(t, err)
+	assert.ErrorIs(t, auditDABlob(ctx, m.da, metadata.Header, func([]byte) bool { return false }), ErrDAAuditMismatch, "blob content differs")
+	header, data, err := m.store.GetBlockData(ctx, 2)
+	require.NoError(t, err)
+	header.BaseHeader.Time++
+	require.NoError(t, m.store.SaveBlockData(ctx, header, data, &header.Signature))
+	assert.ErrorIs(t, m.auditDABlock(ctx, 2), ErrDAAuditMismatch, "header differs")
+
+	// transient DA errors are not mismatches, and the sample goes on
+	m.da.(*reorgDA).getErr = errors.New("DA unavailable")
+	err = m.auditDABlock(ctx, 1)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrDAAuditMismatch)
+	require.NoError(t, m.auditDAInclusion(ctx))
+	m.da.(*reorgDA).getErr = nil
+
+	// mismatches are reported, not returned
+	require.NoError(t, m.auditDAInclusion(ctx))
+	require.NoError(t, m.auditDABlock(ctx, 1))
+
+	// blocks above the DA included height are not audited
+	assert.ErrorIs(t, m.auditDABlock(ctx, 4), ErrNotDAIncluded)
+}
+
+func TestSampleHeights(t *testing.T) {
+	assert.Equal(t, []uint64{3, 4, 5}, sampleHeights(3, 5, 10))
+
+	heights := sampleHeights(1, 100, 10)
+	require.Len(t, heights, 10)
+	sampled := make(map[uint64]bool)
+	for _, height := range heights {
+		assert.False(t, sampled[height], "height %d sampled twice", height)
+		assert.True(t, height >= 1 && height <= 100)
+		sampled[height] = true
+	}
+}
@@ -0,0 +1,67 @@
+package block
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+// DAChainStats are the statistics of the blobs of a chain retrieved from the DA namespaces of the node. Blobs of
+// other chains are found in namespaces shared with them, see DAConfig.SharedNamespace.
+type DAChainStats struct {
+	ChainID string
+	// Blobs is the number of blobs of the chain retrieved
+	Blobs uint64
+	// Bytes is the total size in bytes of the blobs of the chain retrieved
+	Bytes uint64
+	// LastDAHeight is the last DA height at which a blob of the chain was retrieved
+	LastDAHeight uint64
+}
+
+// daChainTracker accounts for the blobs retrieved per chain. Its zero value is ready to use.
+type daChainTracker struct {
+	mu     sync.Mutex
+	chains map[string]*DAChainStats
+}
+
+// record accounts for a blob of the chain retrieved at the DA height.
+func (t *daChainTracker) record(chainID string, size int, daHeight uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.chains == nil {
+		t.chains = make(map[string]*DAChainStats)
+	}
+	stats, ok := t.chains[chainID]
+	if !ok {
+		stats = &DAChainStats{ChainID: chainID}
+		t.chains[chainID] = stats
+	}
+	stats.Blobs++
+	stats.Bytes += uint64(size) //nolint:gosec // blob sizes are positive
+	stats.LastDAHeight = max(stats.LastDAHeight, daHeight)
+}
+
+// stats returns the statistics of the chains, ordered by chain ID.
+func (t *daChainTracker) stats() []DAChainStats {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	stats := make([]DAChainStats, 0, len(t.chains))
+	for _, s := range t.chains {
+		stats = append(stats, *s)
+	}
+	slices.SortFunc(stats, func(a, b DAChainStats) int { return cmp.Compare(a.ChainID, b.ChainID) })
+	return stats
+}
+
+// recordDAChainBlob accounts for a blob of the chain retrieved at the DA height, in the statistics and metrics.
+func (m *Manager) recordDAChainBlob(chainID string, size int, daHeight uint64) {
+	m.daChains.record(chainID, size, daHeight)
+	m.metrics.DARetrievedBlobs.With("chain_id", chainID).Add(1)
+	m.metrics.DARetrievedBytes.With("chain_id", chainID).Add(float64(size))
+}
+
+// GetDAChainStats returns the statistics of the blobs retrieved per chain since the node started, including the
+// chain of the node.
+func (m *Manager) GetDAChainStats() []DAChainStats {
+	return m.daChains.stats()
+}
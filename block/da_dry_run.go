@@ -0,0 +1,274 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// ErrInvalidBlobEncoding is returned when a blob simulated by a DA dry run does not decode back to what was encoded.
+var ErrInvalidBlobEncoding = errors.New("blob does not decode to the encoded headers or batch")
+
+// DABlobEstimate is the simulated DA submission of the header or the data of a block.
+type DABlobEstimate struct {
+	// BlobSizes are the sizes in bytes of the blobs submitted, more than one for data split into parts.
+	BlobSizes []uint64
+	// Bytes is the total size of the blobs.
+	Bytes uint64
+	// Fees is the estimated DA fees of the blobs, in units of the gas price.
+	Fees float64
+}
+
+// DADryRun is the simulated DA submission of a block: the blobs of its header and data encoded as they are
+// submitted, their estimated DA fees, and the rules of the DA layer the blobs break.
+type DADryRun struct {
+	Height uint64
+	Header DABlobEstimate
+	// Data is nil if the data of the block is not submitted, for blocks without transactions or in
+	// commitments-only mode.
+	Data *DABlobEstimate
+	// GasPrice is the gas price the blobs would be submitted with.
+	GasPrice float64
+	// MaxBlobSize is the maximum size of the blobs accepted by the DA layer, 0 if unknown.
+	MaxBlobSize uint64
+	// Fees is the estimated DA fees of the header and data, in units of the gas price.
+	Fees float64
+	// Errors are the reasons the DA layer or syncing nodes would reject the blobs, empty if they are valid.
+	Errors []error
+}
+
+// DryRunDASubmission simulates the DA submission of the block at the given height without broadcasting it: its
+// header and data are encoded into blobs as the submission loops encode them, the blobs are checked against the
+// maximum blob size of the DA layer and decoded back as syncing nodes decode them, and their DA fees are estimated
+// at the current gas price. Blocks are simulated as if they were submitted on their own, even if headers are
+// bundled or posted in epochs.
+func (m *Manager) DryRunDASubmission(ctx context.Context, height uint64) (*DADryRun, error) {
+	header, data, err := m.store.GetBlockData(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load block %d: %w", height, err)
+	}
+	headerDA, err := m.headerDA(height)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice, err := m.resolveGasPrice(ctx, m.gasPricer.price())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DA gas price: %w", err)
+	}
+	dryRun := &DADryRun{Height: height, GasPrice: gasPrice, MaxBlobSize: m.maxBlobSizeFor(ctx, headerDA)}
+	dryRun.Header, dryRun.Errors = m.dryRunHeaders(ctx, headerDA, []*types.SignedHeader{header}, gasPrice)
+	dryRun.Fees = dryRun.Header.Fees
+	if m.dac == nil && len(data.Txs) > 0 {
+		batch := coresequencer.Batch{Transactions: make([][]byte, len(data.Txs))}
+		for i, tx := range data.Txs {
+			batch.Transactions[i] = tx
+		}
+		estimate, errs := m.dryRunBatches(ctx, []coresequencer.Batch{batch}, gasPrice)
+		dryRun.Data = &estimate
+		dryRun.Fees += estimate.Fees
+		dryRun.Errors = append(dryRun.Errors, errs...)
+	}
+	return dryRun, nil
+}
+
+// dryRunHeaders encodes the headers into blobs as submitHeadersToDA does, and returns the estimate of their DA
+// submission and the reasons they would be rejected.
+func (m *Manager) dryRunHeaders(ctx context.Context, da coreda.DA, headers []*types.SignedHeader, gasPrice float64) (DABlobEstimate, []error) {
+	blobs, blobSizes, err := m.headerBlobs(headers)
+	if err != nil {
+		return DABlobEstimate{}, []error{err}
+	}
+	estimate, errs := m.estimateBlobs(ctx, da, blobs, gasPrice)
+	for i, blob := range blobs {
+		encoded := headers[:blobSizes[i]]
+		headers = headers[blobSizes[i]:]
+		if err := checkHeaderBlob(blob, encoded); err != nil {
+			errs = append(errs, fmt.Errorf("header blob %d: %w", i, err))
+		}
+	}
+	return estimate, errs
+}
+
+// dryRunBatches encodes the batches into blobs as submitBatchesToDA does, splitting the batches exceeding the
+// maximum blob size into parts, and returns the estimate of their DA submission and the reasons they would be
+// rejected.
+func (m *Manager) dryRunBatches(ctx context.Context, batches []coresequencer.Batch, gasPrice float64) (DABlobEstimate, []error) {
+	var (
+		blobs [][]byte
+		errs  []error
+	)
+	for i, batch := range batches {
+		batchBz, err := proto.Marshal(&pb.Batch{Txs: batch.Transactions})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to marshal batch: %w", err))
+			continue
+		}
+		sealed, err := m.sealBlob(batchBz)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to compress batch: %w", err))
+			continue
+		}
+		parts, err := m.splitBatchBlob(ctx, m.dataDAClient(), sealed)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to split batch: %w", err))
+			continue
+		}
+		if err := checkBatchBlobs(parts, batch); err != nil {
+			errs = append(errs, fmt.Errorf("batch %d: %w", i, err))
+		}
+		blobs = append(blobs, parts...)
+	}
+	estimate, sizeErrs := m.estimateBlobs(ctx, m.dataDAClient(), blobs, gasPrice)
+	return estimate, append(errs, sizeErrs...)
+}
+
+// estimateBlobs returns the sizes and estimated DA fees of the blobs, and an error for every blob exceeding the
+// maximum blob size of the DA client.
+func (m *Manager) estimateBlobs(ctx context.Context, da coreda.DA, blobs [][]byte, gasPrice float64) (DABlobEstimate, []error) {
+	var (
+		estimate    DABlobEstimate
+		errs        []error
+		maxBlobSize = m.maxBlobSizeFor(ctx, da)
+	)
+	for i, blob := range blobs {
+		size := uint64(len(blob))
+		estimate.BlobSizes = append(estimate.BlobSizes, size)
+		estimate.Bytes += size
+		if maxBlobSize > 0 && size > maxBlobSize {
+			errs = append(errs, fmt.Errorf("blob %d: %w: %d bytes, maximum %d", i, coreda.ErrBlobSizeOverLimit, size, maxBlobSize))
+		}
+	}
+	estimate.Fees = m.estimateDAFees(estimate.Bytes, gasPrice)
+	return estimate, errs
+}
+
+// checkHeaderBlob checks that a header blob decodes back to the headers encoded in it.
+func checkHeaderBlob(blob []byte, headers []*types.SignedHeader) error {
+	payload, err := unsealBlob(blob)
+	if err != nil {
+		return err
+	}
+	bundled, err := types.UnbundleBlob(payload)
+	if err != nil {
+		return err
+	}
+	if len(bundled) != len(headers) {
+		return fmt.Errorf("%w: %d headers decoded, %d encoded", ErrInvalidBlobEncoding, len(bundled), len(headers))
+	}
+	for i, bz := range bundled {
+		var headerPb pb.SignedHeader
+		if err := proto.Unmarshal(bz, &headerPb); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidBlobEncoding, err)
+		}
+		var header types.SignedHeader
+		if err := header.FromProto(&headerPb); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidBlobEncoding, err)
+		}
+		if !bytes.Equal(header.Hash(), headers[i].Hash()) {
+			return fmt.Errorf("%w: header %d decodes to another header", ErrInvalidBlobEncoding, headers[i].Height())
+		}
+	}
+	return nil
+}
+
+// checkBatchBlobs checks that the blob parts of a batch reassemble and decode back to the batch.
+func checkBatchBlobs(parts [][]byte, batch coresequencer.Batch) error {
+	blob := parts[0]
+	if len(parts) > 1 {
+		var (
+			assembler types.BlobAssembler
+			assembled bool
+		)
+		for _, bz := range parts {
+			part, ok, err := types.ParseBlobPart(bz)
+			if !ok {
+				return fmt.Errorf("%w: batch split into a blob which is not a part", ErrInvalidBlobEncoding)
+			}
+			if err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidBlobEncoding, err)
+			}
+			if blob, assembled, err = assembler.Add(part); err != nil {
+				return fmt.Errorf("%w: %w", ErrInvalidBlobEncoding, err)
+			}
+		}
+		if !assembled {
+			return fmt.Errorf("%w: blob parts do not reassemble the batch", ErrInvalidBlobEncoding)
+		}
+	}
+	payload, err := unsealBlob(blob)
+	if err != nil {
+		return err
+	}
+	decoded := decodeBatchBlob(payload)
+	if decoded == nil || len(decoded.Txs) != len(batch.Transactions) {
+		return fmt.Errorf("%w: batch decodes to another batch", ErrInvalidBlobEncoding)
+	}
+	for i, tx := range decoded.Txs {
+		if !bytes.Equal(tx, batch.Transactions[i]) {
+			return fmt.Errorf("%w: batch decodes to another batch", ErrInvalidBlobEncoding)
+		}
+	}
+	return nil
+}
+
+// unsealBlob returns the payload of a blob created by sealBlob, unwrapping its envelope or decompressing it.
+func unsealBlob(blob []byte) ([]byte, error) {
+	env, ok, err := types.OpenBlob(blob)
+	if !ok {
+		if blob, err = types.DecompressBlob(blob); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidBlobEncoding, err)
+		}
+		return blob, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBlobEncoding, err)
+	}
+	return env.Payload, nil
+}
+
+// dryRunPendingHeaders simulates the DA submission of the pending headers not simulated yet, when DA.DryRun is
+// set. The headers stay pending, since they are not submitted.
+func (m *Manager) dryRunPendingHeaders(ctx context.Context, da coreda.DA, headers []*types.SignedHeader) {
+	simulated := m.dryRunHeight.Load()
+	for len(headers) > 0 && headers[0].Height() <= simulated {
+		headers = headers[1:]
+	}
+	if len(headers) == 0 {
+		return
+	}
+	gasPrice, err := m.resolveGasPrice(ctx, m.gasPricer.price())
+	if err != nil {
+		m.logger.Error("failed to get DA gas price, DA fees are not estimated", "error", err)
+	}
+	estimate, errs := m.dryRunHeaders(ctx, da, headers, gasPrice)
+	m.logDryRun("headers", len(headers), estimate, gasPrice, errs)
+	m.dryRunHeight.Store(headers[len(headers)-1].Height())
+}
+
+// dryRunPendingBatches simulates the DA submission of batches, when DA.DryRun is set. The batches are dropped
+// from the queue like submitted batches.
+func (m *Manager) dryRunPendingBatches(ctx context.Context, batches []coresequencer.Batch) {
+	gasPrice, err := m.resolveGasPrice(ctx, m.gasPricer.price())
+	if err != nil {
+		m.logger.Error("failed to get DA gas price, DA fees are not estimated", "error", err)
+	}
+	estimate, errs := m.dryRunBatches(ctx, batches, gasPrice)
+	m.logDryRun("batches", len(batches), estimate, gasPrice, errs)
+}
+
+// logDryRun logs the simulated DA submission of headers or batches.
+func (m *Manager) logDryRun(kind string, count int, estimate DABlobEstimate, gasPrice float64, errs []error) {
+	if err := errors.Join(errs...); err != nil {
+		m.logger.Error("DA submission dry run found invalid blobs", "kind", kind, "count", count, "error", err)
+	}
+	m.logger.Info("DA submission dry run", "kind", kind, "count", count, "blobs", len(estimate.BlobSizes),
+		"bytes", estimate.Bytes, "gasPrice", gasPrice, "estimatedFees", estimate.Fees)
+}
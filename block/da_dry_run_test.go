@@ -0,0 +1,100 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// TestDryRunDASubmission verifies that the DA submission of a block is simulated without submitting its blobs,
+// estimating their DA fees and reporting the blobs which would be rejected.
+func TestDryRunDASubmission(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	headers, _ := saveSignedBlocks(t, s, 1)
+
+	dummyDA := coreda.NewDummyDA(4096, 0, 0)
+	m, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	m.da = dummyDA
+	m.gasPricer = newGasPricer(2, 0)
+	m.config.DA.GasPerByte = 8
+
+	dryRun, err := m.DryRunDASubmission(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, dryRun.Errors)
+	assert.Equal(t, uint64(1), dryRun.Height)
+	assert.Equal(t, 2.0, dryRun.GasPrice)
+	assert.Equal(t, uint64(4096), dryRun.MaxBlobSize)
+	require.Len(t, dryRun.Header.BlobSizes, 1)
+	assert.Equal(t, float64(2*8*dryRun.Header.Bytes), dryRun.Header.Fees)
+	require.NotNil(t, dryRun.Data)
+	require.Len(t, dryRun.Data.BlobSizes, 1)
+	assert.Equal(t, dryRun.Header.Fees+dryRun.Data.Fees, dryRun.Fees)
+	assert.Empty(t, submittedBlobs(t, dummyDA))
+
+	// blobs exceeding the maximum blob size are reported
+	m.config.DA.MaxBlobSize = dryRun.Header.Bytes - 1
+	dryRun, err = m.DryRunDASubmission(ctx, 1)
+	require.NoError(t, err)
+	require.NotEmpty(t, dryRun.Errors)
+	assert.ErrorIs(t, dryRun.Errors[0], coreda.ErrBlobSizeOverLimit)
+
+	_, err = m.DryRunDASubmission(ctx, 2)
+	assert.ErrorIs(t, err, ds.ErrNotFound)
+}
+
+// TestDryRunBatches verifies that batches exceeding the maximum blob size are simulated in parts which reassemble
+// into the batch.
+func TestDryRunBatches(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	headers, _ := saveSignedBlocks(t, s, 1)
+
+	m, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	m.da = coreda.NewDummyDA(4096, 0, 0)
+	m.config.DA.GasPerByte = 1
+
+	batch := coresequencer.Batch{Transactions: [][]byte{types.GetRandomBytes(6000), types.GetRandomBytes(6000)}}
+	estimate, errs := m.dryRunBatches(ctx, []coresequencer.Batch{batch}, 1)
+	assert.Empty(t, errs)
+	assert.Greater(t, len(estimate.BlobSizes), 2)
+	for _, size := range estimate.BlobSizes {
+		assert.LessOrEqual(t, size, uint64(4096))
+	}
+	assert.Equal(t, float64(estimate.Bytes), estimate.Fees)
+}
+
+// TestSubmitHeadersToDA_DryRun verifies that the headers pending DA submission are only simulated once in dry-run
+// mode, and stay pending.
+func TestSubmitHeadersToDA_DryRun(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	headers, _ := saveSignedBlocks(t, s, 2)
+
+	dummyDA := coreda.NewDummyDA(4096, 0, 0)
+	m, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	m.da = dummyDA
+	m.gasPricer = newGasPricer(1, 0)
+	m.config.DA.DryRun = true
+
+	require.NoError(t, m.submitHeadersToDA(ctx))
+	assert.Equal(t, uint64(2), m.dryRunHeight.Load())
+	assert.Empty(t, submittedBlobs(t, dummyDA))
+	assert.Equal(t, uint64(0), m.pendingHeaders.GetLastSubmittedHeight())
+	assert.False(t, m.pendingHeaders.isEmpty())
+}
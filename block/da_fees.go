@@ -0,0 +1,199 @@
+package block
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+)
+
+// DAFeesKey is the key used for persisting the DA fees paid by the node in store.
+const DAFeesKey = "da-fees"
+
+// daFeeHistoryDays is the number of days whose DA fees are kept.
+const daFeeHistoryDays = 30
+
+// daFeeDayFormat is the format of the UTC days the DA fees are accounted for.
+const daFeeDayFormat = time.DateOnly
+
+// DAFees are the DA fees paid for the blobs submitted during a period, in units of the gas price.
+type DAFees struct {
+	Fees  float64 `json:"fees"`
+	Blobs uint64  `json:"blobs"`
+	Bytes uint64  `json:"bytes"`
+}
+
+// DailyDAFees are the DA fees paid during a UTC day.
+type DailyDAFees struct {
+	// Day is the UTC date, formatted as 2006-01-02.
+	Day string `json:"day"`
+	DAFees
+}
+
+// DAFeeReport is the DA fees paid by the node and its DA fee budget.
+type DAFeeReport struct {
+	Total DAFees
+	Today DAFees
+	// Days are the DA fees of the last days with DA submissions, oldest first.
+	Days []DailyDAFees
+	// DailyBudget is the DA fees that may be paid per UTC day, 0 if there is no budget.
+	DailyBudget float64
+	// Paused reports whether DA submissions are paused because the fees paid today reached the daily budget.
+	Paused bool
+}
+
+// daFeeRecord is the persisted form of the DA fees paid by the node.
+type daFeeRecord struct {
+	Total DAFees        `json:"total"`
+	Days  []DailyDAFees `json:"days"`
+}
+
+// daFeeLedger accounts for the DA fees paid by the node, so that DA submissions pause once the daily budget is
+// spent. Its zero value is ready to use.
+type daFeeLedger struct {
+	mu     sync.Mutex
+	record daFeeRecord
+	paused bool
+}
+
+// today returns the DA fees paid during the given day.
+func (l *daFeeLedger) today(day string) DAFees {
+	if n := len(l.record.Days); n > 0 && l.record.Days[n-1].Day == day {
+		return l.record.Days[n-1].DAFees
+	}
+	return DAFees{}
+}
+
+// add accounts for DA fees paid during the given day, and returns the DA fees paid during the day.
+func (l *daFeeLedger) add(day string, fees DAFees) DAFees {
+	l.record.Total.Fees += fees.Fees
+	l.record.Total.Blobs += fees.Blobs
+	l.record.Total.Bytes += fees.Bytes
+	if n := len(l.record.Days); n == 0 || l.record.Days[n-1].Day != day {
+		l.record.Days = append(l.record.Days, DailyDAFees{Day: day})
+		if len(l.record.Days) > daFeeHistoryDays {
+			l.record.Days = l.record.Days[len(l.record.Days)-daFeeHistoryDays:]
+		}
+	}
+	today := &l.record.Days[len(l.record.Days)-1].DAFees
+	today.Fees += fees.Fees
+	today.Blobs += fees.Blobs
+	today.Bytes += fees.Bytes
+	return *today
+}
+
+// loadDAFees restores the DA fees persisted in store.
+func (m *Manager) loadDAFees(ctx context.Context) error {
+	raw, err := m.store.GetMetadata(ctx, DAFeesKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load DA fees: %w", err)
+	}
+	var record daFeeRecord
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return fmt.Errorf("failed to decode DA fees: %w", err)
+	}
+	m.daFees.mu.Lock()
+	defer m.daFees.mu.Unlock()
+	m.daFees.record = record
+	m.metrics.DAFeesToday.Set(m.daFees.today(time.Now().UTC().Format(daFeeDayFormat)).Fees)
+	return nil
+}
+
+// recordDAFees accounts for the DA fees paid for blobs submitted with the given gas price, estimated as the size
+// of the blobs times DA.GasPerByte times the gas price. The gas price of the DA layer is used if the gas price is
+// determined by the DA layer. DA fees are not accounted if DA.GasPerByte is 0.
+func (m *Manager) recordDAFees(ctx context.Context, blobs [][]byte, gasPrice float64) {
+	if len(blobs) == 0 || m.config.DA.GasPerByte == 0 {
+		return
+	}
+	gasPrice, err := m.resolveGasPrice(ctx, gasPrice)
+	if err != nil {
+		m.logger.Error("failed to get DA gas price, DA fees are not accounted", "error", err)
+	}
+	fees := DAFees{Blobs: uint64(len(blobs))}
+	for _, blob := range blobs {
+		fees.Bytes += uint64(len(blob))
+	}
+	fees.Fees = m.estimateDAFees(fees.Bytes, gasPrice)
+
+	m.daFees.mu.Lock()
+	defer m.daFees.mu.Unlock()
+	today := m.daFees.add(time.Now().UTC().Format(daFeeDayFormat), fees)
+	m.metrics.DAFees.Add(fees.Fees)
+	m.metrics.DAFeesToday.Set(today.Fees)
+	raw, err := json.Marshal(m.daFees.record)
+	if err != nil {
+		m.logger.Error("failed to encode DA fees", "error", err)
+		return
+	}
+	if err := m.store.SetMetadata(ctx, DAFeesKey, raw); err != nil {
+		m.logger.Error("failed to save DA fees", "error", err)
+	}
+}
+
+// resolveGasPrice returns the gas price blobs submitted with the given gas price pay: the gas price of the DA layer
+// if the gas price is determined by the DA layer, or 0 if it cannot be told.
+func (m *Manager) resolveGasPrice(ctx context.Context, gasPrice float64) (float64, error) {
+	if gasPrice >= 0 {
+		return gasPrice, nil
+	}
+	if m.da == nil {
+		return 0, nil
+	}
+	daGasPrice, err := m.da.GasPrice(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return max(daGasPrice, 0), nil
+}
+
+// estimateDAFees returns the DA fees of blobs of the given total size submitted with the gas price.
+func (m *Manager) estimateDAFees(bytes uint64, gasPrice float64) float64 {
+	return gasPrice * float64(m.config.DA.GasPerByte) * float64(bytes)
+}
+
+// daBudgetExhausted reports whether the DA fees paid during the current UTC day reached the daily budget, in
+// which case DA submissions pause until the next day or until the budget is raised. An alert is logged and
+// EventDABudgetExhausted is published when submissions pause.
+func (m *Manager) daBudgetExhausted() bool {
+	budget := m.daDailyBudget()
+	m.daFees.mu.Lock()
+	defer m.daFees.mu.Unlock()
+	today := m.daFees.today(time.Now().UTC().Format(daFeeDayFormat))
+	exhausted := budget > 0 && today.Fees >= budget
+	if exhausted == m.daFees.paused {
+		return exhausted
+	}
+	m.daFees.paused = exhausted
+	if exhausted {
+		m.metrics.DABudgetExhausted.Set(1)
+		m.logger.Error("DA fee budget exhausted, pausing DA submissions until the next day", "fees", today.Fees, "dailyBudget", budget)
+		m.events.publish(Event{Type: EventDABudgetExhausted})
+	} else {
+		m.metrics.DABudgetExhausted.Set(0)
+		m.logger.Info("resuming DA submissions", "fees", today.Fees, "dailyBudget", budget)
+	}
+	return exhausted
+}
+
+// GetDAFees returns the DA fees paid by the node and its DA fee budget.
+func (m *Manager) GetDAFees() DAFeeReport {
+	budget := m.daDailyBudget()
+	m.daFees.mu.Lock()
+	defer m.daFees.mu.Unlock()
+	today := m.daFees.today(time.Now().UTC().Format(daFeeDayFormat))
+	return DAFeeReport{
+		Total:       m.daFees.record.Total,
+		Today:       today,
+		Days:        append([]DailyDAFees(nil), m.daFees.record.Days...),
+		DailyBudget: budget,
+		Paused:      budget > 0 && today.Fees >= budget,
+	}
+}
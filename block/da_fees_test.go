@@ -0,0 +1,90 @@
+package block
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/test/mocks"
+)
+
+// TestDAFees verifies that the DA fees of successful submissions are accounted and persisted, and that DA
+// submissions pause once the fees paid during the day reach the daily budget.
+func TestDAFees(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	headers, _ := saveSignedBlocks(t, s, 1)
+
+	blobs := [][]byte{[]byte("blob1"), []byte("blob22")}
+	mockDA := mocks.NewDA(t)
+	mockDA.On("GasMultiplier", mock.Anything).Return(1.0, nil).Maybe()
+	mockDA.On("SubmitWithOptions", mock.Anything, blobs, mock.Anything, mock.Anything, mock.Anything).
+		Return([]coreda.ID{[]byte("id1"), []byte("id2")}, nil)
+
+	m, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	m.da = mockDA
+	m.config.DA.GasPerByte = 8
+	m.config.DA.DailyBudget = 300
+	events, cancel := m.Subscribe(1)
+	defer cancel()
+
+	assert.False(t, m.daBudgetExhausted())
+	res, _ := m.submitToDA(ctx, mockDA, blobs, 2)
+	require.Equal(t, coreda.StatusSuccess, res.Code)
+	report := m.GetDAFees()
+	assert.Equal(t, DAFees{Fees: 2 * 8 * 11, Blobs: 2, Bytes: 11}, report.Total)
+	assert.Equal(t, report.Total, report.Today)
+	require.Len(t, report.Days, 1)
+	assert.Equal(t, time.Now().UTC().Format(time.DateOnly), report.Days[0].Day)
+	assert.False(t, report.Paused)
+	assert.False(t, m.daBudgetExhausted())
+
+	// the submission exceeding the budget is completed, and the following ones pause
+	res, _ = m.submitToDA(ctx, mockDA, blobs, 2)
+	require.Equal(t, coreda.StatusSuccess, res.Code)
+	assert.True(t, m.daBudgetExhausted())
+	assert.True(t, m.GetDAFees().Paused)
+	select {
+	case event := <-events:
+		assert.Equal(t, EventDABudgetExhausted, event.Type)
+	default:
+		require.Fail(t, "budget exhaustion was not published")
+	}
+	// the pending header is not submitted on shutdown
+	require.NoError(t, m.DrainDASubmissions(ctx))
+
+	// raising the budget resumes submissions
+	m.config.DA.DailyBudget = 1000
+	assert.False(t, m.daBudgetExhausted())
+
+	// the DA fees are restored after a restart
+	restarted, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	require.NoError(t, restarted.loadDAFees(ctx))
+	assert.Equal(t, DAFees{Fees: 2 * 2 * 8 * 11, Blobs: 4, Bytes: 22}, restarted.GetDAFees().Total)
+}
+
+func TestDAFeeLedger(t *testing.T) {
+	var ledger daFeeLedger
+	for day := 1; day <= daFeeHistoryDays+2; day++ {
+		ledger.add(fmt.Sprintf("day-%d", day), DAFees{Fees: 1, Blobs: 1, Bytes: 10})
+	}
+	today := ledger.add(fmt.Sprintf("day-%d", daFeeHistoryDays+2), DAFees{Fees: 2, Blobs: 1, Bytes: 5})
+	assert.Equal(t, DAFees{Fees: 3, Blobs: 2, Bytes: 15}, today)
+	assert.Equal(t, today, ledger.today(fmt.Sprintf("day-%d", daFeeHistoryDays+2)))
+	assert.Equal(t, DAFees{}, ledger.today("day-100"))
+
+	// only the last days are kept, the total covers all days
+	require.Len(t, ledger.record.Days, daFeeHistoryDays)
+	assert.Equal(t, "day-3", ledger.record.Days[0].Day)
+	assert.Equal(t, DAFees{Fees: daFeeHistoryDays + 4, Blobs: daFeeHistoryDays + 3, Bytes: 10*(daFeeHistoryDays+2) + 5}, ledger.record.Total)
+}
@@ -0,0 +1,222 @@
+package block
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	ds "github.com/ipfs/go-datastore"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/types"
+)
+
+// DAInclusionKeyPrefix is the prefix of the keys under which the DA pointers of DA included blocks were
+// persisted in store, before they were persisted as DA metadata with their namespace and commitment.
+const DAInclusionKeyPrefix = "da-inclusion"
+
+var (
+	// ErrNotDAIncluded is returned when requesting the DA inclusion proof of a block above the DA included height.
+	ErrNotDAIncluded = errors.New("block is not DA included")
+	// ErrDAInclusionUnknown is returned when the DA blobs including a DA included block are not known by the
+	// node, e.g. for blocks DA included before the node recorded DA pointers.
+	ErrDAInclusionUnknown = errors.New("DA blobs including the block are unknown")
+)
+
+// daPointer locates a blob in the DA layer.
+type daPointer struct {
+	DAHeight uint64 `json:"da_height"`
+	ID       []byte `json:"id"`
+}
+
+// daInclusion holds the DA pointers of the header and data of a DA included block, as persisted under
+// DAInclusionKeyPrefix. Data is nil for blocks without transactions, whose data is not submitted to the DA layer.
+type daInclusion struct {
+	Header daPointer  `json:"header"`
+	Data   *daPointer `json:"data,omitempty"`
+}
+
+// DABlobProof proves the inclusion of a blob in the DA layer.
+type DABlobProof struct {
+	DAHeight  uint64
+	Namespace string
+	// ID identifies the blob in the DA layer
+	ID         []byte
+	Commitment []byte
+	// Proof is the inclusion proof returned by the DA layer for the blob
+	Proof []byte
+}
+
+// DAInclusionProof proves that the header and data of a block are included in the DA layer. Data is nil for
+// blocks without transactions, whose data is not submitted to the DA layer.
+type DAInclusionProof struct {
+	Height uint64
+	Header DABlobProof
+	Data   *DABlobProof
+}
+
+// daInclusionKey returns the key of the DA pointers of the block at the given height.
+func daInclusionKey(height uint64) string {
+	return DAInclusionKeyPrefix + "/" + strconv.FormatUint(height, 10)
+}
+
+// setDAPointer records the DA blob including the header or data with the given hash. Blobs without ID are not
+// recorded.
+func (m *Manager) setDAPointer(hash string, daHeight uint64, id []byte) {
+	if len(id) == 0 {
+		return
+	}
+	m.daPointers.Store(hash, daPointer{DAHeight: daHeight, ID: id})
+}
+
+// getDAPointer returns the DA blob including the header or data with the given hash.
+func (m *Manager) getDAPointer(hash string) (daPointer, bool) {
+	pointer, ok := m.daPointers.Load(hash)
+	if !ok {
+		return daPointer{}, false
+	}
+	return pointer.(daPointer), true
+}
+
+// saveDAInclusion persists the DA blobs including a block when it becomes DA included, with their namespace and
+// commitment. Nothing is persisted if the DA blobs including the block are not known, e.g. for blocks derived
+// from the DA layer in based sequencing.
+func (m *Manager) saveDAInclusion(ctx context.Context, header *types.SignedHeader, data *types.Data) error {
+	height := header.Height()
+	headerHash := header.Hash().String()
+	headerPointer, ok := m.getDAPointer(headerHash)
+	if !ok {
+		m.logger.Debug("DA blob including header unknown", "height", height)
+		return nil
+	}
+	metadata := &types.DAMetadata{
+		Height: height,
+		Header: newDABlobMetadata(m.headerNamespace(height), headerPointer),
+	}
+	dataCommitment := data.DACommitment()
+	dataHash := dataCommitment.String()
+	if !bytes.Equal(dataCommitment, dataHashForEmptyTxs) {
+		dataPointer, ok := m.getDAPointer(dataHash)
+		if !ok {
+			m.logger.Debug("DA blob including data unknown", "height", height)
+			return nil
+		}
+		blob := newDABlobMetadata(m.dataNamespace(), dataPointer)
+		metadata.Data = &blob
+	}
+	if err := m.store.SetDAMetadata(ctx, metadata); err != nil {
+		return fmt.Errorf("failed to save DA inclusion of block %d: %w", height, err)
+	}
+	m.daPointers.Delete(headerHash)
+	m.daPointers.Delete(dataHash)
+	return nil
+}
+
+// GetDAInclusionProof returns the DA blobs including the header and data of the DA included block at the given
+// height, along with their inclusion proofs fetched from the DA layer, so that external verifiers can confirm
+// that the block is DA included.
+func (m *Manager) GetDAInclusionProof(ctx context.Context, height uint64) (*DAInclusionProof, error) {
+	metadata, err := m.loadDAMetadata(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	headerDA, err := m.headerDA(height)
+	if err != nil {
+		return nil, err
+	}
+	proof := &DAInclusionProof{Height: height}
+	if proof.Header, err = m.proveDABlob(ctx, headerDA, metadata.Header); err != nil {
+		return nil, fmt.Errorf("failed to get inclusion proof of header %d: %w", height, err)
+	}
+	if metadata.Data != nil {
+		data, err := m.proveDABlob(ctx, m.dataDAClient(), *metadata.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get inclusion proof of data %d: %w", height, err)
+		}
+		proof.Data = &data
+	}
+	return proof, nil
+}
+
+// loadDAMetadata loads the DA blobs including the DA included block at the given height. Blocks DA included
+// before DA metadata was stored are read from their DA pointers, located in the configured namespaces.
+func (m *Manager) loadDAMetadata(ctx context.Context, height uint64) (*types.DAMetadata, error) {
+	if height == 0 || height > m.GetDAIncludedHeight() {
+		return nil, fmt.Errorf("%w: height %d is above the DA included height %d", ErrNotDAIncluded, height, m.GetDAIncludedHeight())
+	}
+	metadata, err := m.store.GetDAMetadata(ctx, height)
+	if err == nil {
+		return metadata, nil
+	}
+	if !errors.Is(err, ds.ErrNotFound) {
+		return nil, fmt.Errorf("failed to load DA inclusion of block %d: %w", height, err)
+	}
+
+	bz, err := m.store.GetMetadata(ctx, daInclusionKey(height))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, fmt.Errorf("%w: height %d", ErrDAInclusionUnknown, height)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load DA inclusion of block %d: %w", height, err)
+	}
+	var inclusion daInclusion
+	if err := json.Unmarshal(bz, &inclusion); err != nil {
+		return nil, fmt.Errorf("failed to decode DA inclusion of block %d: %w", height, err)
+	}
+	metadata = &types.DAMetadata{
+		Height: height,
+		Header: newDABlobMetadata(cmp.Or(m.config.DA.HeaderNamespace, m.config.DA.Namespace), inclusion.Header),
+	}
+	if inclusion.Data != nil {
+		data := newDABlobMetadata(m.dataNamespace(), *inclusion.Data)
+		metadata.Data = &data
+	}
+	return metadata, nil
+}
+
+// headerNamespace returns the DA namespace of the header at the given height, which may be changed by an upgrade.
+func (m *Manager) headerNamespace(height uint64) string {
+	return cmp.Or(m.protocol(height).namespace, m.config.DA.HeaderNamespace, m.config.DA.Namespace)
+}
+
+// dataNamespace returns the DA namespace of block data.
+func (m *Manager) dataNamespace() string {
+	return cmp.Or(m.config.DA.DataNamespace, m.config.DA.Namespace)
+}
+
+// newDABlobMetadata returns the metadata of a blob of the namespace.
+func newDABlobMetadata(namespace string, pointer daPointer) types.DABlobMetadata {
+	blob := types.DABlobMetadata{
+		DAHeight:  pointer.DAHeight,
+		Namespace: namespace,
+		ID:        pointer.ID,
+	}
+	// IDs of DA layers using the Rollkit ID format embed the commitment of the blob
+	if _, commitment, err := coreda.SplitID(pointer.ID); err == nil {
+		blob.Commitment = commitment
+	}
+	return blob
+}
+
+// proveDABlob fetches the inclusion proof of the blob from the DA layer.
+func (m *Manager) proveDABlob(ctx context.Context, da coreda.DA, blob types.DABlobMetadata) (DABlobProof, error) {
+	proofs, err := da.GetProofs(ctx, [][]byte{blob.ID}, []byte(blob.Namespace))
+	if err != nil {
+		return DABlobProof{}, err
+	}
+	if len(proofs) != 1 {
+		return DABlobProof{}, fmt.Errorf("expected 1 proof, got %d", len(proofs))
+	}
+	return DABlobProof{
+		DAHeight:   blob.DAHeight,
+		Namespace:  blob.Namespace,
+		ID:         blob.ID,
+		Commitment: blob.Commitment,
+		Proof:      proofs[0],
+	}, nil
+}
@@ -0,0 +1,155 @@
+package block
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/cache"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/test/mocks"
+	"github.com/rollkit/rollkit/types"
+)
+
+// setupDAInclusionTest returns a manager with blocks 1 to 3 submitted to the DA layer, not DA included yet.
+// Block 1 has transactions, block 2 is empty and block 3 was found on DA before pointers were recorded.
+func setupDAInclusionTest(t *testing.T) (*Manager, *coreda.DummyDA, []*types.SignedHeader) {
+	t.Helper()
+	ctx := context.Background()
+	da := coreda.NewDummyDA(100_000, 0, 0)
+	s := newRotationTestStore(t)
+	conf := config.DefaultConfig
+	conf.DA.Namespace = "rollkit"
+	exec := mocks.NewExecutor(t)
+	exec.On("SetFinal", mock.Anything, mock.Anything).Return(nil)
+	m := &Manager{
+		config:       conf,
+		store:        s,
+		da:           da,
+		exec:         exec,
+		headerCache:  cache.NewCache[types.SignedHeader](),
+		dataCache:    cache.NewCache[types.Data](),
+		daIncluderCh: make(chan struct{}, 1),
+		logger:       log.NewNopLogger(),
+		lastStateMtx: &sync.RWMutex{},
+		metrics:      NopMetrics(),
+	}
+
+	headers := make([]*types.SignedHeader, 4)
+	for h := uint64(1); h <= 3; h++ {
+		nTxs := 2
+		if h == 2 {
+			nTxs = 0
+		}
+		header, data := types.GetRandomBlock(h, nTxs, "testchain")
+		require.NoError(t, s.SaveBlockData(ctx, header, data, &header.Signature))
+		require.NoError(t, s.SetHeight(ctx, h))
+		m.SetLastState(types.State{LastBlockHeight: h})
+		headers[h] = header
+
+		headerBz, err := header.MarshalBinary()
+		require.NoError(t, err)
+		blobs := [][]byte{headerBz}
+		if nTxs > 0 {
+			dataBz, err := data.MarshalBinary()
+			require.NoError(t, err)
+			blobs = append(blobs, dataBz)
+		}
+		ids, err := da.Submit(ctx, blobs, 0, nil)
+		require.NoError(t, err)
+		if h < 3 {
+			m.setDAPointer(header.Hash().String(), h, ids[0])
+			if nTxs > 0 {
+				m.setDAPointer(data.DACommitment().String(), h, ids[1])
+			}
+		}
+		m.headerCache.SetDAIncluded(header.Hash().String())
+		m.dataCache.SetDAIncluded(data.DACommitment().String())
+	}
+	return m, da, headers
+}
+
+func TestDAInclusionProof(t *testing.T) {
+	ctx := context.Background()
+	m, da, headers := setupDAInclusionTest(t)
+
+	_, err := m.GetDAInclusionProof(ctx, 1)
+	assert.ErrorIs(t, err, ErrNotDAIncluded)
+
+	m.advanceDAIncludedHeight(ctx)
+	require.Equal(t, uint64(3), m.GetDAIncludedHeight())
+
+	proof, err := m.GetDAInclusionProof(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), proof.Height)
+	assert.Equal(t, uint64(1), proof.Header.DAHeight)
+	assert.Equal(t, "rollkit", proof.Header.Namespace)
+	require.NotNil(t, proof.Data)
+	ids := [][]byte{proof.Header.ID, proof.Data.ID}
+	valid, err := da.Validate(ctx, ids, [][]byte{proof.Header.Proof, proof.Data.Proof}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, true}, valid)
+	_, commitment, err := coreda.SplitID(proof.Header.ID)
+	require.NoError(t, err)
+	assert.Equal(t, commitment, proof.Header.Commitment)
+
+	// the data of empty blocks is not submitted to DA
+	proof, err = m.GetDAInclusionProof(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), proof.Header.DAHeight)
+	assert.Nil(t, proof.Data)
+
+	_, err = m.GetDAInclusionProof(ctx, 3)
+	assert.ErrorIs(t, err, ErrDAInclusionUnknown)
+	_, err = m.GetDAInclusionProof(ctx, 4)
+	assert.ErrorIs(t, err, ErrNotDAIncluded)
+
+	// pointers are released once persisted
+	_, ok := m.getDAPointer(headers[1].Hash().String())
+	assert.False(t, ok)
+}
+
+func TestSaveDAInclusion_Metadata(t *testing.T) {
+	ctx := context.Background()
+	m, _, _ := setupDAInclusionTest(t)
+	m.config.DA.DataNamespace = "0c0d"
+	m.advanceDAIncludedHeight(ctx)
+	require.Equal(t, uint64(3), m.GetDAIncludedHeight())
+
+	metadata, err := m.store.GetDAMetadata(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), metadata.Height)
+	assert.Equal(t, uint64(1), metadata.Header.DAHeight)
+	assert.Equal(t, "rollkit", metadata.Header.Namespace)
+	_, commitment, err := coreda.SplitID(metadata.Header.ID)
+	require.NoError(t, err)
+	assert.Equal(t, commitment, metadata.Header.Commitment)
+	require.NotNil(t, metadata.Data)
+	assert.Equal(t, "0c0d", metadata.Data.Namespace)
+	assert.NotEmpty(t, metadata.Data.Commitment)
+
+	metadata, err = m.store.GetDAMetadata(ctx, 2)
+	require.NoError(t, err)
+	assert.Nil(t, metadata.Data)
+	_, err = m.store.GetDAMetadata(ctx, 3)
+	assert.ErrorIs(t, err, ds.ErrNotFound)
+
+	// DA pointers persisted before DA metadata are still loaded
+	legacy, err := json.Marshal(daInclusion{Header: daPointer{DAHeight: 3, ID: []byte{3}}})
+	require.NoError(t, err)
+	require.NoError(t, m.store.SetMetadata(ctx, daInclusionKey(3), legacy))
+	metadata, err = m.loadDAMetadata(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, &types.DAMetadata{
+		Height: 3,
+		Header: types.DABlobMetadata{DAHeight: 3, Namespace: "rollkit", ID: []byte{3}},
+	}, metadata)
+}
@@ -0,0 +1,169 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// daBlobsKey identifies the blobs of a namespace at a DA height.
+type daBlobsKey struct {
+	namespace string
+	daHeight  uint64
+}
+
+// checkDAReorg checks that the blobs including the latest DA.ReorgCheckDepth DA included blocks are still in the
+// DA layer. If the blobs of a block disappeared in a DA reorg, the DA included height is rolled back below it. It
+// is called by the DAIncluderLoop, so that the DA included height is not advanced meanwhile.
+//
+// A blob is only missing if the DA layer returns the IDs at its DA height without its ID, as ErrBlobNotFound may
+// come from a DA node lagging behind. Reads of a coreda.Multiplexer are pinned to the backend which issued the
+// IDs, see coreda.Multiplexer. The DA included height is only rolled back once the next check confirms that the
+// same blob is still missing, so that a single inconsistent answer of the DA layer does not trigger a rollback.
+//
+// Blocks whose DA blobs are unknown, e.g. blocks DA included before the node recorded DA pointers, and pruned
+// blocks are not checked.
+func (m *Manager) checkDAReorg(ctx context.Context) error {
+	depth := m.config.DA.ReorgCheckDepth
+	daIncluded := m.GetDAIncludedHeight()
+	if depth == 0 || daIncluded == 0 {
+		return nil
+	}
+	start := uint64(1)
+	if daIncluded > depth {
+		start = daIncluded - depth + 1
+	}
+	pruned, err := store.GetPrunedHeight(ctx, m.store)
+	if err != nil {
+		return err
+	}
+	start = max(start, pruned+1)
+
+	ids := make(map[daBlobsKey]map[string]bool)
+	for height := start; height <= daIncluded; height++ {
+		metadata, err := m.loadDAMetadata(ctx, height)
+		if errors.Is(err, ErrDAInclusionUnknown) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		headerDA, err := m.headerDA(height)
+		if err != nil {
+			return err
+		}
+		missing := metadata.Header
+		found, err := hasDABlob(ctx, ids, headerDA, "header/"+m.protocol(height).namespace, metadata.Header)
+		if err == nil && found && metadata.Data != nil {
+			missing = *metadata.Data
+			found, err = hasDABlob(ctx, ids, m.dataDAClient(), "data", *metadata.Data)
+		}
+		if err != nil {
+			return err
+		}
+		if !found {
+			suspect := fmt.Sprintf("%d/%x", missing.DAHeight, missing.ID)
+			if m.daReorgSuspect != suspect {
+				m.daReorgSuspect = suspect
+				m.logger.Info("blob of DA included block missing from the DA layer, checking it again before rolling back",
+					"height", height, "daHeight", missing.DAHeight)
+				return nil
+			}
+			m.daReorgSuspect = ""
+			m.logger.Warn("DA reorg detected, blob of DA included block disappeared from the DA layer",
+				"height", height, "daHeight", missing.DAHeight)
+			return m.rollbackDAIncludedHeight(ctx, height-1, missing.DAHeight)
+		}
+	}
+	m.daReorgSuspect = ""
+	return nil
+}
+
+// hasDABlob reports whether the blob is still at its DA height. The IDs of the blobs at a DA height are only
+// requested once per check, and cached in ids under the given namespace. DA heights whose blobs were not found
+// are cached as nil, and their blobs are reported to be there, as the DA layer may not have caught up yet.
+func hasDABlob(ctx context.Context, ids map[daBlobsKey]map[string]bool, da coreda.DA, namespace string, blob types.DABlobMetadata) (bool, error) {
+	key := daBlobsKey{namespace: namespace, daHeight: blob.DAHeight}
+	if _, ok := ids[key]; !ok {
+		res, err := da.GetIDs(ctx, blob.DAHeight, nil)
+		if errors.Is(err, coreda.ErrBlobNotFound) || (err == nil && res == nil) {
+			ids[key] = nil
+			return true, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to get IDs at DA height %d: %w", blob.DAHeight, err)
+		}
+		ids[key] = make(map[string]bool)
+		for _, id := range res.IDs {
+			ids[key][string(id)] = true
+		}
+	}
+	if ids[key] == nil {
+		return true, nil
+	}
+	return ids[key][string(blob.ID)], nil
+}
+
+// rollbackDAIncludedHeight rolls the DA included height back to height after a DA reorg removed the blobs of the
+// block above it from daHeight. The blocks above height are no longer DA included until their blobs are retrieved
+// from the DA layer again: the DA height of the retriever is rolled back to daHeight, and aggregators submit the
+// headers and batches of the blocks again.
+//
+// The executor is not notified, as blocks cannot be unfinalized.
+func (m *Manager) rollbackDAIncludedHeight(ctx context.Context, height, daHeight uint64) error {
+	daIncluded := m.GetDAIncludedHeight()
+	resubmit := m.config.Node.Aggregator && m.config.Node.SequencingMode != config.SequencingModeBased
+	var batches []coresequencer.Batch
+	for h := height + 1; h <= daIncluded; h++ {
+		header, data, err := m.store.GetBlockData(ctx, h)
+		if err != nil {
+			return fmt.Errorf("failed to load block %d: %w", h, err)
+		}
+		m.headerCache.DeleteDAIncluded(header.Hash().String())
+		m.dataCache.DeleteDAIncluded(data.DACommitment().String())
+		if resubmit && len(data.Txs) > 0 {
+			batch := coresequencer.Batch{Transactions: make([][]byte, len(data.Txs))}
+			for i, tx := range data.Txs {
+				batch.Transactions[i] = tx
+			}
+			batches = append(batches, batch)
+		}
+	}
+
+	if err := setHeightMetadata(ctx, m.store, DAIncludedHeightKey, height); err != nil {
+		return err
+	}
+	m.daIncludedHeight.Store(height)
+	m.retrieveMtx.Lock()
+	if daHeight < m.daHeight.Load() {
+		m.daHeight.Store(daHeight)
+	}
+	m.retrieveMtx.Unlock()
+	if resubmit {
+		if height < m.pendingHeaders.GetLastSubmittedHeight() {
+			if err := m.pendingHeaders.resetLastSubmittedHeight(ctx, height); err != nil {
+				return err
+			}
+		}
+		for _, batch := range batches {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case m.batchSubmissionChan <- batch:
+			}
+		}
+	}
+
+	m.metrics.DAReorgs.Add(1)
+	m.recordDAInclusionLag(m.GetLastState().LastBlockHeight)
+	m.events.publish(Event{Type: EventDAReorg, Height: height, DAHeight: daHeight})
+	m.logger.Warn("rolled back DA included height after DA reorg", "height", height, "previousHeight", daIncluded,
+		"daHeight", daHeight, "resubmit", resubmit)
+	return nil
+}
@@ -0,0 +1,227 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/cache"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/test/mocks"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// reorgDA is a DA layer whose DA heights can be replaced by a reorg.
+type reorgDA struct {
+	*coreda.DummyDA
+	mu       sync.Mutex
+	reorged  map[uint64]bool
+	getIDErr error
+	getErr   error
+}
+
+func (d *reorgDA) reorg(daHeight uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.reorged[daHeight] = true
+}
+
+func (d *reorgDA) GetIDs(ctx context.Context, height uint64, namespace []byte) (*coreda.GetIDsResult, error) {
+	d.mu.Lock()
+	reorged, err := d.reorged[height], d.getIDErr
+	d.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if reorged {
+		return &coreda.GetIDsResult{IDs: []coreda.ID{[]byte("reorged")}}, nil
+	}
+	return d.DummyDA.GetIDs(ctx, height, namespace)
+}
+
+func (d *reorgDA) Get(ctx context.Context, ids []coreda.ID, namespace []byte) ([]coreda.Blob, error) {
+	d.mu.Lock()
+	err := d.getErr
+	d.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return d.DummyDA.Get(ctx, ids, namespace)
+}
+
+// setupDAReorgTest returns an aggregator with blocks 1 to 3 DA included, each submitted at its own DA height.
+// Block 2 is empty, so that its data is not submitted to DA.
+func setupDAReorgTest(t *testing.T) (*Manager, *reorgDA, []*types.SignedHeader) {
+	t.Helper()
+	ctx := context.Background()
+	da := &reorgDA{DummyDA: coreda.NewDummyDA(100_000, 0, 0), reorged: make(map[uint64]bool)}
+	s := newRotationTestStore(t)
+	conf := config.DefaultConfig
+	conf.Node.Aggregator = true
+	exec := mocks.NewExecutor(t)
+	exec.On("SetFinal", mock.Anything, mock.Anything).Return(nil)
+	pendingHeaders, err := NewPendingHeaders(s, log.NewNopLogger())
+	require.NoError(t, err)
+	m := &Manager{
+		config:              conf,
+		store:               s,
+		da:                  da,
+		exec:                exec,
+		headerCache:         cache.NewCache[types.SignedHeader](),
+		dataCache:           cache.NewCache[types.Data](),
+		daIncluderCh:        make(chan struct{}, 1),
+		daHeight:            new(atomic.Uint64),
+		pendingHeaders:      pendingHeaders,
+		batchSubmissionChan: make(chan coresequencer.Batch, 10),
+		logger:              log.NewNopLogger(),
+		lastStateMtx:        &sync.RWMutex{},
+		metrics:             NopMetrics(),
+	}
+
+	headers := make([]*types.SignedHeader, 4)
+	for h := uint64(1); h <= 3; h++ {
+		nTxs := 2
+		if h == 2 {
+			nTxs = 0
+		}
+		header, data := types.GetRandomBlock(h, nTxs, "testchain")
+		require.NoError(t, s.SaveBlockData(ctx, header, data, &header.Signature))
+		require.NoError(t, s.SetHeight(ctx, h))
+		m.SetLastState(types.State{LastBlockHeight: h})
+		headers[h] = header
+
+		headerBz, err := header.MarshalBinary()
+		require.NoError(t, err)
+		blobs := [][]byte{headerBz}
+		if nTxs > 0 {
+			txs := make([][]byte, len(data.Txs))
+			for i, tx := range data.Txs {
+				txs[i] = tx
+			}
+			dataBz, err := proto.Marshal(&pb.Batch{Txs: txs})
+			require.NoError(t, err)
+			blobs = append(blobs, dataBz)
+		}
+		ids, err := da.Submit(ctx, blobs, 0, nil)
+		require.NoError(t, err)
+		daHeight, _, err := coreda.SplitID(ids[0])
+		require.NoError(t, err)
+		m.setDAPointer(header.Hash().String(), daHeight, ids[0])
+		m.headerCache.SetDAIncluded(header.Hash().String())
+		if nTxs > 0 {
+			m.setDAPointer(data.DACommitment().String(), daHeight, ids[1])
+			m.dataCache.SetDAIncluded(data.DACommitment().String())
+		}
+	}
+	m.pendingHeaders.markSubmitted(ctx, headers[1:])
+	m.advanceDAIncludedHeight(ctx)
+	require.Equal(t, uint64(3), m.GetDAIncludedHeight())
+	m.daHeight.Store(10)
+	return m, da, headers
+}
+
+func TestCheckDAReorg(t *testing.T) {
+	ctx := context.Background()
+	m, da, headers := setupDAReorgTest(t)
+	events, unsubscribe := m.Subscribe(10)
+	defer unsubscribe()
+
+	// nothing happens while the blobs are in the DA layer
+	require.NoError(t, m.checkDAReorg(ctx))
+	assert.Equal(t, uint64(3), m.GetDAIncludedHeight())
+
+	// DA errors are not mistaken for reorgs, nor are blobs not found by a DA node lagging behind
+	da.getIDErr = errors.New("DA unavailable")
+	require.ErrorIs(t, m.checkDAReorg(ctx), da.getIDErr)
+	assert.Equal(t, uint64(3), m.GetDAIncludedHeight())
+	da.getIDErr = coreda.ErrBlobNotFound
+	for range 2 {
+		require.NoError(t, m.checkDAReorg(ctx))
+	}
+	assert.Equal(t, uint64(3), m.GetDAIncludedHeight())
+	da.getIDErr = nil
+
+	// a blob missing once is checked again
+	da.reorg(1)
+	require.NoError(t, m.checkDAReorg(ctx))
+	assert.Equal(t, uint64(3), m.GetDAIncludedHeight())
+	da.reorged[1] = false
+	require.NoError(t, m.checkDAReorg(ctx))
+	assert.Equal(t, uint64(3), m.GetDAIncludedHeight())
+
+	// the blobs of block 2 disappear for two checks, block 1 stays DA included
+	da.reorg(1)
+	require.NoError(t, m.checkDAReorg(ctx))
+	assert.Equal(t, uint64(3), m.GetDAIncludedHeight())
+	require.NoError(t, m.checkDAReorg(ctx))
+	assert.Equal(t, uint64(1), m.GetDAIncludedHeight())
+	assert.Equal(t, ConfirmationDAFinalized, m.GetBlockConfirmationStatus(1))
+	assert.NotEqual(t, ConfirmationDAFinalized, m.GetBlockConfirmationStatus(2))
+	assert.True(t, m.headerCache.IsDAIncluded(headers[1].Hash().String()))
+	assert.False(t, m.headerCache.IsDAIncluded(headers[2].Hash().String()))
+	assert.False(t, m.headerCache.IsDAIncluded(headers[3].Hash().String()))
+	height, err := getHeightMetadata(ctx, m.store, DAIncludedHeightKey)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), height)
+
+	event := <-events
+	assert.Equal(t, Event{Type: EventDAReorg, Height: 1, DAHeight: 1}, event)
+	// the retriever scans the reorged DA heights again
+	assert.Equal(t, uint64(1), m.daHeight.Load())
+
+	// the aggregator submits the headers and the non-empty batches of the blocks again
+	assert.Equal(t, uint64(1), m.pendingHeaders.GetLastSubmittedHeight())
+	require.Len(t, m.batchSubmissionChan, 1)
+	batch := <-m.batchSubmissionChan
+	_, data, err := m.store.GetBlockData(ctx, 3)
+	require.NoError(t, err)
+	assert.Len(t, batch.Transactions, len(data.Txs))
+
+	// the blocks are DA included again once their blobs are found again
+	m.headerCache.SetDAIncluded(headers[2].Hash().String())
+	m.headerCache.SetDAIncluded(headers[3].Hash().String())
+	m.dataCache.SetDAIncluded(data.DACommitment().String())
+	m.advanceDAIncludedHeight(ctx)
+	assert.Equal(t, uint64(3), m.GetDAIncludedHeight())
+}
+
+func TestCheckDAReorgDepth(t *testing.T) {
+	ctx := context.Background()
+	m, da, _ := setupDAReorgTest(t)
+
+	// only the latest DA included blocks are checked
+	m.config.DA.ReorgCheckDepth = 2
+	da.reorg(0)
+	for range 2 {
+		require.NoError(t, m.checkDAReorg(ctx))
+	}
+	assert.Equal(t, uint64(3), m.GetDAIncludedHeight())
+
+	m.config.DA.ReorgCheckDepth = 0
+	da.reorg(2)
+	for range 2 {
+		require.NoError(t, m.checkDAReorg(ctx))
+	}
+	assert.Equal(t, uint64(3), m.GetDAIncludedHeight())
+
+	// full nodes do not submit blocks
+	m.config.DA.ReorgCheckDepth = 2
+	m.config.Node.Aggregator = false
+	for range 2 {
+		require.NoError(t, m.checkDAReorg(ctx))
+	}
+	assert.Equal(t, uint64(2), m.GetDAIncludedHeight())
+	assert.Equal(t, uint64(3), m.pendingHeaders.GetLastSubmittedHeight())
+	assert.Empty(t, m.batchSubmissionChan)
+}
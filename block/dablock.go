@@ -0,0 +1,143 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/types"
+)
+
+// DABlockLoop tracks the latest DA block if the DA client implements coreda.BlockReader. The headers of the blocks
+// created by the node when it is the aggregator commit to the latest DA block observed, see nextDABlock.
+func (m *Manager) DABlockLoop(ctx context.Context) {
+	reader, ok := coreda.Implements[coreda.BlockReader](m.da)
+	if !ok {
+		return
+	}
+	ticker := time.NewTicker(m.config.DA.BlockTime.Duration)
+	defer ticker.Stop()
+	for {
+		if err := m.observeDABlock(ctx, reader); err != nil && ctx.Err() == nil {
+			m.logger.Error("failed to get latest DA block", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// observeDABlock records the latest DA block, unless a higher DA block was already observed.
+func (m *Manager) observeDABlock(ctx context.Context, reader coreda.BlockReader) error {
+	latest, err := reader.LatestBlock(ctx)
+	if err != nil {
+		return err
+	}
+	if latest == nil || latest.Height == 0 {
+		return nil
+	}
+	height, hash := latest.Height, latest.Hash
+	if len(hash) == 0 || len(hash) > types.MaxDABlockHashSize {
+		return fmt.Errorf("%w: DA layer returned a hash of %d bytes for DA height %d", types.ErrInvalidDABlock, len(hash), height)
+	}
+	for {
+		observed := m.daBlock.Load()
+		if observed != nil && observed.Height >= height {
+			return nil
+		}
+		if m.daBlock.CompareAndSwap(observed, &coreexecutor.DABlock{Height: height, Hash: hash}) {
+			m.observeDAHeight(height)
+			return nil
+		}
+	}
+}
+
+// nextDABlock returns the DA block the header of the block at the given height commits to: the latest DA block
+// observed, or the DA block of the previous header if no higher DA block was observed since, so that the DA
+// heights of the headers never decrease. Headers commit to no DA block if the DA client does not implement
+// coreda.BlockReader.
+func (m *Manager) nextDABlock(ctx context.Context, height uint64) (coreexecutor.DABlock, error) {
+	if _, ok := coreda.Implements[coreda.BlockReader](m.da); !ok {
+		return coreexecutor.DABlock{}, nil
+	}
+	var daBlock coreexecutor.DABlock
+	if observed := m.daBlock.Load(); observed != nil {
+		daBlock = *observed
+	}
+	if height <= m.genesis.InitialHeight {
+		return daBlock, nil
+	}
+	prev, _, err := m.store.GetBlockData(ctx, height-1)
+	if err != nil {
+		return coreexecutor.DABlock{}, fmt.Errorf("error while loading last block: %w, height: %d", err, height-1)
+	}
+	if daBlock.Height <= prev.DABlockHeight {
+		return coreexecutor.DABlock{Height: prev.DABlockHeight, Hash: prev.DABlockHash}, nil
+	}
+	return daBlock, nil
+}
+
+// validateDABlock checks the DA block committed to in a header: its DA height is not lower than in the previous
+// header, with the same hash if equal, and a higher DA block matches the DA layer if the DA client implements
+// coreda.BlockReader. Nodes whose DA client does not implement it only check headers committing to a DA block.
+// Headers of blocks produced by the node commit to a DA block read from the DA layer by the node, see
+// nextDABlock, and are not checked against the DA layer again.
+func (m *Manager) validateDABlock(ctx context.Context, header *types.SignedHeader, produced bool) error {
+	reader, ok := coreda.Implements[coreda.BlockReader](m.da)
+	if !ok && header.DABlockHeight == 0 {
+		return nil
+	}
+	if header.Height() > m.genesis.InitialHeight {
+		prev, _, err := m.store.GetBlockData(ctx, header.Height()-1)
+		switch {
+		case errors.Is(err, ds.ErrNotFound):
+			// the previous block is not stored on nodes started from a snapshot
+		case err != nil:
+			return fmt.Errorf("error while loading last block: %w, height: %d", err, header.Height()-1)
+		case header.DABlockHeight < prev.DABlockHeight:
+			return fmt.Errorf("%w: DA height %d is lower than DA height %d of the previous header", types.ErrInvalidDABlock, header.DABlockHeight, prev.DABlockHeight)
+		case header.DABlockHeight == prev.DABlockHeight:
+			if !bytes.Equal(header.DABlockHash, prev.DABlockHash) {
+				return fmt.Errorf("%w: hash %X of DA height %d differs from the previous header", types.ErrInvalidDABlock, header.DABlockHash, header.DABlockHeight)
+			}
+			return nil
+		}
+	}
+	if !ok || produced || header.DABlockHeight == 0 {
+		return nil
+	}
+	hash, err := reader.BlockHash(ctx, header.DABlockHeight)
+	if errors.Is(err, coreda.ErrFutureHeight) {
+		// the block is validated again once the DA client caught up with the DA height
+		return fmt.Errorf("DA height %d committed to in header %d not reached yet: %w", header.DABlockHeight, header.Height(), err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get hash of DA height %d committed to in header %d: %w", header.DABlockHeight, header.Height(), err)
+	}
+	if !bytes.Equal(hash, header.DABlockHash) {
+		return fmt.Errorf("%w: hash %X of DA height %d differs from hash %X in the DA layer", types.ErrInvalidDABlock, header.DABlockHash, header.DABlockHeight, hash)
+	}
+	return nil
+}
+
+// setDABlock passes the DA block committed to in a header to an executor implementing
+// coreexecutor.DABlockReceiver, before the block is executed.
+func setDABlock(ctx context.Context, exec coreexecutor.Executor, header *types.SignedHeader) error {
+	receiver, ok := exec.(coreexecutor.DABlockReceiver)
+	if !ok {
+		return nil
+	}
+	daBlock := coreexecutor.DABlock{Height: header.DABlockHeight, Hash: header.DABlockHash}
+	if err := receiver.SetDABlock(ctx, header.Height(), daBlock); err != nil {
+		return fmt.Errorf("failed to set DA block: %w", err)
+	}
+	return nil
+}
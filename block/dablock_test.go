@@ -0,0 +1,145 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/test/mocks"
+	"github.com/rollkit/rollkit/types"
+)
+
+// daBlockExecutor records the DA blocks set before executing blocks.
+type daBlockExecutor struct {
+	coreexecutor.Executor
+	daBlocks map[uint64]coreexecutor.DABlock
+}
+
+func (e *daBlockExecutor) SetDABlock(ctx context.Context, blockHeight uint64, daBlock coreexecutor.DABlock) error {
+	e.daBlocks[blockHeight] = daBlock
+	return nil
+}
+
+// newDummyDA returns a DummyDA with the given number of DA blocks.
+func newDummyDA(t *testing.T, daBlocks int) *coreda.DummyDA {
+	t.Helper()
+	dummyDA := coreda.NewDummyDA(1024, 0, 0)
+	for range daBlocks {
+		_, err := dummyDA.Submit(t.Context(), []coreda.Blob{[]byte("blob")}, 0, nil)
+		require.NoError(t, err)
+	}
+	return dummyDA
+}
+
+// saveDABlockHeader stores a block at the given height whose header commits to the given DA block.
+func saveDABlockHeader(t *testing.T, m *Manager, height uint64, daHeight uint64, daHash []byte) {
+	t.Helper()
+	header := &types.SignedHeader{Header: types.Header{
+		BaseHeader:    types.BaseHeader{ChainID: "test", Height: height},
+		DABlockHeight: daHeight,
+		DABlockHash:   daHash,
+	}}
+	require.NoError(t, m.store.SaveBlockData(t.Context(), header, &types.Data{}, &types.Signature{}))
+}
+
+func TestNextDABlock(t *testing.T) {
+	ctx := t.Context()
+	dummyDA := newDummyDA(t, 3)
+	m, _, _, _ := newTestManager(t, withDA(dummyDA), withStore(store.New(store.NewMemoryKVStore())),
+		withGenesis(genesis.Genesis{ChainID: "test", InitialHeight: 1}))
+
+	// no DA block is committed to before one is observed
+	daBlock, err := m.nextDABlock(ctx, 1)
+	require.NoError(t, err)
+	assert.Zero(t, daBlock)
+
+	require.NoError(t, m.observeDABlock(ctx, dummyDA))
+	hash, err := dummyDA.BlockHash(ctx, 2)
+	require.NoError(t, err)
+	daBlock, err = m.nextDABlock(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, coreexecutor.DABlock{Height: 2, Hash: hash}, daBlock)
+	assert.Equal(t, uint64(2), m.daHeadHeight.Load())
+
+	// the DA height never decreases, e.g. after a restart
+	prevHash := types.GetRandomBytes(32)
+	saveDABlockHeader(t, m, 1, 5, prevHash)
+	daBlock, err = m.nextDABlock(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, coreexecutor.DABlock{Height: 5, Hash: prevHash}, daBlock)
+
+	// headers of nodes whose DA client does not report DA blocks commit to none
+	daBlock, err = (&Manager{da: mocks.NewDA(t)}).nextDABlock(ctx, 2)
+	require.NoError(t, err)
+	assert.Zero(t, daBlock)
+}
+
+func TestValidateDABlock(t *testing.T) {
+	ctx := t.Context()
+	dummyDA := newDummyDA(t, 4)
+	m, _, _, _ := newTestManager(t, withDA(dummyDA), withStore(store.New(store.NewMemoryKVStore())),
+		withGenesis(genesis.Genesis{ChainID: "test", InitialHeight: 1}))
+	hash1, err := dummyDA.BlockHash(ctx, 1)
+	require.NoError(t, err)
+	hash3, err := dummyDA.BlockHash(ctx, 3)
+	require.NoError(t, err)
+	saveDABlockHeader(t, m, 1, 1, hash1)
+
+	// validate validates a header at height 2 committing to the DA block
+	validate := func(daHeight uint64, daHash []byte, produced bool) error {
+		header := &types.SignedHeader{Header: types.Header{
+			BaseHeader:    types.BaseHeader{Height: 2},
+			DABlockHeight: daHeight,
+			DABlockHash:   daHash,
+		}}
+		return m.validateDABlock(ctx, header, produced)
+	}
+	require.NoError(t, validate(1, hash1, false), "same DA block as the previous header")
+	require.NoError(t, validate(3, hash3, false), "higher DA block")
+	assert.ErrorIs(t, validate(0, nil, false), types.ErrInvalidDABlock, "lower DA height")
+	assert.ErrorIs(t, validate(1, hash3, false), types.ErrInvalidDABlock, "hash differs from the previous header")
+	assert.ErrorIs(t, validate(3, hash1, false), types.ErrInvalidDABlock, "hash differs from the DA layer")
+	assert.ErrorIs(t, validate(4, hash3, false), coreda.ErrFutureHeight, "DA height not reached")
+
+	// blocks produced by the node are not checked against the DA layer
+	require.NoError(t, validate(3, hash1, true), "produced block")
+	assert.ErrorIs(t, validate(0, nil, true), types.ErrInvalidDABlock, "produced block with lower DA height")
+
+	// failures of the DA layer fail the validation
+	m.da = &failingBlockReader{DummyDA: dummyDA}
+	assert.ErrorIs(t, validate(3, hash3, false), errDABlockUnavailable)
+}
+
+var errDABlockUnavailable = errors.New("DA block unavailable")
+
+// failingBlockReader is a DummyDA failing to return the hashes of DA blocks.
+type failingBlockReader struct {
+	*coreda.DummyDA
+}
+
+func (f *failingBlockReader) BlockHash(ctx context.Context, height uint64) ([]byte, error) {
+	return nil, errDABlockUnavailable
+}
+
+func TestSetDABlock(t *testing.T) {
+	ctx := t.Context()
+	header := &types.SignedHeader{Header: types.Header{
+		BaseHeader:    types.BaseHeader{Height: 7},
+		DABlockHeight: 3,
+		DABlockHash:   types.GetRandomBytes(32),
+	}}
+
+	exec := &daBlockExecutor{Executor: coreexecutor.NewDummyExecutor(), daBlocks: make(map[uint64]coreexecutor.DABlock)}
+	require.NoError(t, setDABlock(ctx, exec, header))
+	assert.Equal(t, coreexecutor.DABlock{Height: 3, Hash: header.DABlockHash}, exec.daBlocks[7])
+
+	// executors not receiving DA blocks are left unchanged
+	require.NoError(t, setDABlock(ctx, coreexecutor.NewDummyExecutor(), header))
+}
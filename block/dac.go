@@ -0,0 +1,46 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// DACertifier is the data availability committee of a chain in commitments-only mode, storing the data of blocks
+// in place of the DA layer.
+type DACertifier interface {
+	// Certify stores the data of the block on the committee members and returns their attestations, the DA
+	// certificate of the header.
+	Certify(ctx context.Context, header *types.SignedHeader, data *types.Data) ([]types.DACAttestation, error)
+	// DataFetcher fetches block data from the committee members.
+	DataFetcher
+}
+
+// SetDAC switches the manager to commitments-only mode: only headers are submitted to the DA layer, the aggregator
+// certifies the data of the blocks it produces with the committee, and missing block data is fetched from the
+// committee. The data of blocks whose header carries a DA certificate is considered DA included.
+func (m *Manager) SetDAC(dac DACertifier) {
+	m.dac = dac
+	m.dataFetcher = dac
+}
+
+// certifyData attaches the DA certificate of the committee to the header of a produced block. Blocks without
+// transactions need no certificate.
+func (m *Manager) certifyData(ctx context.Context, header *types.SignedHeader, data *types.Data) error {
+	if m.dac == nil || bytes.Equal(header.DataHash, dataHashForEmptyTxs) {
+		return nil
+	}
+	certificate, err := m.dac.Certify(ctx, header, data)
+	if err != nil {
+		return fmt.Errorf("failed to certify block data: %w", err)
+	}
+	header.DACertificate = certificate
+	return nil
+}
+
+// dacCertified reports whether the data of the block is made available by the committee.
+func (m *Manager) dacCertified(header *types.SignedHeader) bool {
+	return m.dac != nil && len(header.DACertificate) > 0
+}
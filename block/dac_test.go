@@ -0,0 +1,61 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// testDAC is a DACertifier attesting and serving the data of any block.
+type testDAC struct {
+	peerData
+	certified int
+}
+
+func (d *testDAC) Certify(_ context.Context, header *types.SignedHeader, data *types.Data) ([]types.DACAttestation, error) {
+	d.certified++
+	d.peerData[header.Height()] = data
+	return []types.DACAttestation{{Signature: []byte("attestation")}}, nil
+}
+
+func TestCommitmentsOnlyMode(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	headers, data := saveSignedBlocks(t, s, 1)
+	m, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	dac := &testDAC{peerData: peerData{}}
+	m.SetDAC(dac)
+	assert.Equal(t, dac, m.dataFetcher)
+
+	require.NoError(t, m.certifyData(ctx, headers[0], data[0]))
+	assert.Len(t, headers[0].DACertificate, 1)
+	fetched, err := m.dataFetcher.Fetch(ctx, headers[0])
+	require.NoError(t, err)
+	assert.Equal(t, data[0], fetched)
+
+	// blocks without transactions are not certified
+	empty, emptyData := types.GetRandomBlock(2, 0, "test-chain")
+	require.Equal(t, dataHashForEmptyTxs, []byte(empty.DataHash))
+	require.NoError(t, m.certifyData(ctx, empty, emptyData))
+	assert.Empty(t, empty.DACertificate)
+	assert.Equal(t, 1, dac.certified)
+
+	// the data of certified blocks is DA included once their header is
+	assert.False(t, m.isDAIncluded(headers[0], data[0]))
+	m.headerCache.SetDAIncluded(headers[0].Hash().String())
+	assert.True(t, m.isDAIncluded(headers[0], data[0]))
+
+	// batches are not submitted to the DA layer
+	m.queueBatch(ctx, coresequencer.Batch{Transactions: [][]byte{[]byte("tx")}})
+	_, ok := m.nextPendingBatch()
+	assert.False(t, ok)
+}
@@ -0,0 +1,82 @@
+package block
+
+import (
+	"context"
+	"time"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// maxDataFetchBatch is the maximum number of consecutive blocks whose data is fetched from peers at once.
+const maxDataFetchBatch = 16
+
+// DataFetcher fetches the data of a block from peers, verified against the header of the block.
+type DataFetcher interface {
+	Fetch(ctx context.Context, header *types.SignedHeader) (*types.Data, error)
+}
+
+// SetDataFetcher sets the fetcher used by DataFetchLoop to request missing block data from peers.
+func (m *Manager) SetDataFetcher(f DataFetcher) {
+	m.dataFetcher = f
+}
+
+// DataFetchLoop requests from peers the data of blocks whose header was received but whose data was neither
+// gossiped nor retrieved from the DA layer. Syncing relies on the data being gossiped, which nodes joining after
+// the data was gossiped missed. When syncing is stalled on missing data for P2P.BlockDataFetchDelay, the data
+// of the next blocks with a known header is fetched from peers and synced as if it was gossiped.
+func (m *Manager) DataFetchLoop(ctx context.Context) {
+	delay := m.config.P2P.BlockDataFetchDelay.Duration
+	if m.dataFetcher == nil || delay == 0 {
+		return
+	}
+	ticker := time.NewTicker(delay)
+	defer ticker.Stop()
+
+	var stalledHeight uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		height, err := m.store.Height(ctx)
+		if err != nil {
+			m.logger.Error("error while getting store height", "error", err)
+			continue
+		}
+		if !m.missingData(height + 1) {
+			stalledHeight = 0
+			continue
+		}
+		// data is only fetched once syncing was stalled on the same height for a whole delay
+		if stalledHeight != height+1 {
+			stalledHeight = height + 1
+			continue
+		}
+		m.fetchMissingData(ctx, height+1)
+	}
+}
+
+// missingData reports whether the header of the block at height was received but not its data.
+func (m *Manager) missingData(height uint64) bool {
+	return m.headerCache.GetItem(height) != nil && m.dataCache.GetItem(height) == nil
+}
+
+// fetchMissingData fetches the missing data of consecutive blocks from height on, and passes it to SyncLoop.
+func (m *Manager) fetchMissingData(ctx context.Context, height uint64) {
+	for end := height + maxDataFetchBatch; height < end && m.missingData(height); height++ {
+		header := m.headerCache.GetItem(height)
+		data, err := m.dataFetcher.Fetch(ctx, header)
+		if err != nil {
+			m.logger.Info("failed to fetch block data from peers", "height", height, "error", err)
+			return
+		}
+		m.logger.Debug("block data fetched from peers", "height", height)
+		select {
+		case <-ctx.Done():
+			return
+		case m.dataInCh <- NewDataEvent{data, m.daHeight.Load()}:
+			m.metrics.SyncDataReceived.With("source", syncSourceP2P).Add(1)
+		}
+	}
+}
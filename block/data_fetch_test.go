@@ -0,0 +1,60 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// peerData is a DataFetcher serving the data of the blocks it knows of.
+type peerData map[uint64]*types.Data
+
+func (p peerData) Fetch(_ context.Context, header *types.SignedHeader) (*types.Data, error) {
+	data, ok := p[header.Height()]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return data, nil
+}
+
+// TestFetchMissingData verifies that the data of consecutive blocks whose header was received is fetched from
+// peers and passed to SyncLoop, until the data of a block cannot be fetched.
+func TestFetchMissingData(t *testing.T) {
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	headers, _ := saveSignedBlocks(t, s, 1)
+
+	m, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	m.dataInCh = make(chan NewDataEvent, maxDataFetchBatch)
+	m.daHeight = &atomic.Uint64{}
+	served := peerData{}
+	for height := uint64(2); height <= 4; height++ {
+		header, data := types.GetRandomBlock(height, 1, "test-chain")
+		m.headerCache.SetItem(height, header)
+		if height < 4 {
+			served[height] = data
+		}
+	}
+	// the data of block 3 was gossiped already
+	m.dataCache.SetItem(3, served[3])
+	m.SetDataFetcher(served)
+
+	assert.True(t, m.missingData(2))
+	assert.False(t, m.missingData(3))
+	m.fetchMissingData(context.Background(), 2)
+	require.Len(t, m.dataInCh, 1)
+	assert.Equal(t, served[2], (<-m.dataInCh).Data)
+
+	// block 4 is not served by peers
+	m.fetchMissingData(context.Background(), 4)
+	assert.Empty(t, m.dataInCh)
+}
@@ -0,0 +1,96 @@
+package block
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rollkit/rollkit/pkg/config"
+)
+
+// epochSchedule schedules the DA submissions of an aggregator posting to the DA layer in epochs: the headers and
+// block data of the blocks produced since the previous epoch are posted together once DA.EpochBlocks blocks were
+// produced, or DA.EpochTime elapsed, rather than every DA block time. The DA included height advances over whole
+// epochs.
+type epochSchedule struct {
+	// blocks is the number of blocks closing an epoch, 0 to only close epochs after interval
+	blocks uint64
+	// interval is the maximum duration of an epoch, 0 to only close epochs after blocks blocks
+	interval time.Duration
+
+	mu sync.Mutex
+	// lastClosed is the time the previous epoch was closed
+	lastClosed time.Time
+	// ends holds the last heights of the posted epochs above the DA included height, in increasing order
+	ends []uint64
+	// batchesDue is set when an epoch was closed and its block data was not submitted yet
+	batchesDue bool
+	// closed signals the batch submission loop that an epoch was closed
+	closed chan struct{}
+}
+
+// newEpochSchedule returns the epoch schedule configured for the DA layer, or nil if epochs are disabled.
+func newEpochSchedule(conf config.DAConfig) *epochSchedule {
+	if conf.EpochBlocks == 0 && conf.EpochTime.Duration <= 0 {
+		return nil
+	}
+	return &epochSchedule{
+		blocks:     conf.EpochBlocks,
+		interval:   conf.EpochTime.Duration,
+		lastClosed: time.Now(),
+		closed:     make(chan struct{}, 1),
+	}
+}
+
+// due reports whether an epoch of the given number of blocks waiting for DA submission is to be closed.
+func (e *epochSchedule) due(blocks uint64, now time.Time) bool {
+	if blocks == 0 {
+		return false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return (e.blocks > 0 && blocks >= e.blocks) || (e.interval > 0 && now.Sub(e.lastClosed) >= e.interval)
+}
+
+// close records that an epoch was closed, and notifies the batch submission loop that its block data is due.
+func (e *epochSchedule) close(now time.Time) {
+	e.mu.Lock()
+	e.lastClosed = now
+	e.batchesDue = true
+	e.mu.Unlock()
+	select {
+	case e.closed <- struct{}{}:
+	default:
+	}
+}
+
+// takeBatchesDue reports whether the block data of a closed epoch is due for DA submission, and resets it.
+func (e *epochSchedule) takeBatchesDue() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	due := e.batchesDue
+	e.batchesDue = false
+	return due
+}
+
+// posted records the last height of an epoch whose headers were submitted to the DA layer.
+func (e *epochSchedule) posted(end uint64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if i, found := slices.BinarySearch(e.ends, end); !found {
+		e.ends = slices.Insert(e.ends, i, end)
+	}
+}
+
+// nextEnd returns the last height of the epoch following the DA included height. Epochs posted before the node
+// restarted are not recorded, their blocks up to the last submitted height form a single epoch.
+func (e *epochSchedule) nextEnd(daIncludedHeight, lastSubmittedHeight uint64) uint64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	i, _ := slices.BinarySearch(e.ends, daIncludedHeight+1)
+	e.ends = e.ends[i:]
+	if len(e.ends) == 0 || (e.ends[0] > lastSubmittedHeight && lastSubmittedHeight > daIncludedHeight) {
+		return lastSubmittedHeight
+	}
+	return e.ends[0]
+}
@@ -0,0 +1,135 @@
+package block
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestEpochSchedule(t *testing.T) {
+	assert.Nil(t, newEpochSchedule(config.DAConfig{}))
+
+	e := newEpochSchedule(config.DAConfig{EpochBlocks: 10, EpochTime: config.DurationWrapper{Duration: time.Minute}})
+	require.NotNil(t, e)
+	now := time.Now()
+	assert.False(t, e.due(0, now.Add(time.Hour)))
+	assert.False(t, e.due(9, now))
+	assert.True(t, e.due(10, now))
+	assert.True(t, e.due(1, now.Add(time.Minute)))
+
+	// closing an epoch restarts its duration and makes its block data due
+	assert.False(t, e.takeBatchesDue())
+	e.close(now.Add(time.Minute))
+	assert.False(t, e.due(1, now.Add(time.Minute)))
+	assert.True(t, e.takeBatchesDue())
+	assert.False(t, e.takeBatchesDue())
+	select {
+	case <-e.closed:
+	default:
+		t.Fatal("closed epoch not signaled")
+	}
+
+	blocksOnly := newEpochSchedule(config.DAConfig{EpochBlocks: 10})
+	assert.False(t, blocksOnly.due(9, now.Add(time.Hour)))
+	timeOnly := newEpochSchedule(config.DAConfig{EpochTime: config.DurationWrapper{Duration: time.Minute}})
+	assert.False(t, timeOnly.due(1000, now))
+}
+
+func TestEpochSchedule_NextEnd(t *testing.T) {
+	e := newEpochSchedule(config.DAConfig{EpochBlocks: 10})
+
+	// epochs posted before a restart end at the last submitted height
+	assert.Equal(t, uint64(7), e.nextEnd(0, 7))
+	assert.Equal(t, uint64(0), e.nextEnd(0, 0))
+
+	// epochs completing out of order
+	e.posted(20)
+	assert.Equal(t, uint64(20), e.nextEnd(0, 0))
+	e.posted(10)
+	assert.Equal(t, uint64(10), e.nextEnd(0, 20))
+	assert.Equal(t, uint64(20), e.nextEnd(10, 20))
+
+	// blocks submitted before a restart precede the epochs posted after it
+	e.posted(30)
+	assert.Equal(t, uint64(25), e.nextEnd(20, 25))
+	assert.Equal(t, uint64(30), e.nextEnd(25, 30))
+	assert.Equal(t, uint64(30), e.nextEnd(30, 30))
+}
+
+// TestAdvanceDAIncludedHeight_Epochs verifies that the DA included height advances over whole epochs.
+func TestAdvanceDAIncludedHeight_Epochs(t *testing.T) {
+	ctx := context.Background()
+	m, store, exec, _ := newTestManager(t)
+	m.pendingHeaders = &PendingHeaders{}
+	m.epochs = newEpochSchedule(config.DAConfig{EpochBlocks: 2})
+	m.daIncludedHeight.Store(4)
+	m.pendingHeaders.lastSubmittedHeight.Store(6)
+	m.epochs.posted(6)
+
+	headers := make(map[uint64]*types.SignedHeader)
+	for height := uint64(5); height <= 6; height++ {
+		header, data := types.GetRandomBlock(height, 1, "testchain")
+		headers[height] = header
+		m.dataCache.SetDAIncluded(data.DACommitment().String())
+		store.On("GetBlockData", mock.Anything, height).Return(header, data, nil)
+	}
+
+	// the epoch is not DA included until all its blocks are
+	m.headerCache.SetDAIncluded(headers[5].Hash().String())
+	m.advanceDAIncludedHeight(ctx)
+	assert.Equal(t, uint64(4), m.GetDAIncludedHeight())
+
+	m.headerCache.SetDAIncluded(headers[6].Hash().String())
+	for height := uint64(5); height <= 6; height++ {
+		heightBytes := make([]byte, 8)
+		binary.LittleEndian.PutUint64(heightBytes, height)
+		store.On("SetMetadata", mock.Anything, DAIncludedHeightKey, heightBytes).Return(nil).Once()
+		exec.On("SetFinal", mock.Anything, height).Return(nil).Once()
+	}
+	m.advanceDAIncludedHeight(ctx)
+	assert.Equal(t, uint64(6), m.GetDAIncludedHeight())
+	exec.AssertExpectations(t)
+}
+
+// TestSubmitBatchesToDA_SingleSubmission verifies that the batches of an epoch are submitted together.
+func TestSubmitBatchesToDA_SingleSubmission(t *testing.T) {
+	ctx := context.Background()
+	dummyDA := coreda.NewDummyDA(100_000, 0, 0)
+	m, mockStore := getManager(t, dummyDA, 1, 0)
+	mockStore.On("SetMetadata", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.config.DA = config.DAConfig{BlockTime: config.DurationWrapper{Duration: time.Millisecond}}
+	m.daIncluderCh = make(chan struct{}, 1)
+
+	batches := []coresequencer.Batch{
+		{Transactions: [][]byte{[]byte("tx1")}},
+		{Transactions: [][]byte{[]byte("tx2"), []byte("tx3")}},
+	}
+	require.NoError(t, m.submitBatchesToDA(ctx, batches))
+
+	// both batches are in the first DA height, each in its own blob
+	assert.Len(t, submittedBlobs(t, dummyDA), 2)
+	res, err := dummyDA.GetIDs(ctx, 0, nil)
+	require.NoError(t, err)
+	require.Len(t, res.IDs, 2)
+	for i, batch := range batches {
+		data := &types.Data{}
+		for _, tx := range batch.Transactions {
+			data.Txs = append(data.Txs, tx)
+		}
+		dataHash := data.DACommitment().String()
+		assert.True(t, m.DataCache().IsDAIncluded(dataHash))
+		pointer, ok := m.getDAPointer(dataHash)
+		require.True(t, ok)
+		assert.Equal(t, []byte(res.IDs[i]), pointer.ID)
+	}
+}
@@ -18,6 +18,9 @@ var (
 
 	// ErrHeightFromFutureStr is the error message for height from future returned by da
 	ErrHeightFromFutureStr = errors.New("given height is from the future")
+
+	// ErrDABacklog is returned for transactions submitted while too many headers wait for DA submission
+	ErrDABacklog = errors.New("DA submission backlog")
 )
 
 // SaveBlockError is returned on failure to save block data
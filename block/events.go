@@ -0,0 +1,197 @@
+package block
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rollkit/rollkit/pkg/mempool"
+	"github.com/rollkit/rollkit/pkg/txindex"
+	"github.com/rollkit/rollkit/types"
+)
+
+// EventType identifies the kind of an Event published by the Manager.
+type EventType string
+
+const (
+	// EventNewBlock is published when a block is applied to the local state, or produced by an aggregator
+	// executing blocks asynchronously.
+	EventNewBlock EventType = "new_block"
+	// EventSoftConfirmed is published when the soft-confirmed height advances, i.e. when headers signed
+	// by the sequencer are known up to that height, before they are included in the DA layer.
+	EventSoftConfirmed EventType = "soft_confirmed"
+	// EventDAIncluded is published when the DA included height advances.
+	EventDAIncluded EventType = "da_included"
+	// EventDAReorg is published when blobs of DA included blocks disappeared from the DA layer, and the DA
+	// included height was rolled back to Height.
+	EventDAReorg EventType = "da_reorg"
+	// EventBlockProduced is published by aggregators when they produced a block, after EventNewBlock.
+	EventBlockProduced EventType = "block_produced"
+	// EventBlockExecuted is published by aggregators executing blocks asynchronously when a produced block was
+	// executed.
+	EventBlockExecuted EventType = "block_executed"
+	// EventBlobSubmitted is published when headers of blocks up to Height were submitted to the DA layer at
+	// DAHeight. Submissions may complete out of order.
+	EventBlobSubmitted EventType = "blob_submitted"
+	// EventSyncCaughtUp is published once by non-aggregator nodes when they caught up with the chain after
+	// starting: all DA heights up to the DA head were retrieved, and the blocks known from the p2p network
+	// were applied.
+	EventSyncCaughtUp EventType = "sync_caught_up"
+	// EventDABudgetExhausted is published when DA submissions pause because the DA fees paid during the current
+	// UTC day reached the daily budget.
+	EventDABudgetExhausted EventType = "da_budget_exhausted"
+	// EventAttested is published when the attested height advances, i.e. when a quorum of the attester set
+	// attested the block at that height.
+	EventAttested EventType = "attested"
+)
+
+// Event is published by the Manager to its subscribers.
+type Event struct {
+	Type   EventType
+	Height uint64
+	// Hash, Time and NumTxs are only set for EventNewBlock, EventBlockProduced and EventBlockExecuted.
+	Hash   types.Hash
+	Time   time.Time
+	NumTxs int
+	// DAHeight is only set for EventBlobSubmitted, and for EventDAReorg to the DA height of the first blob which
+	// disappeared.
+	DAHeight uint64
+}
+
+// eventBus fans out Manager events to subscribers. Its zero value is ready to use.
+type eventBus struct {
+	mu   sync.Mutex
+	subs map[chan Event]struct{}
+}
+
+// subscribe registers a subscriber with a channel of the given buffer size.
+func (b *eventBus) subscribe(buffer int) chan Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs == nil {
+		b.subs = make(map[chan Event]struct{})
+	}
+	ch := make(chan Event, buffer)
+	b.subs[ch] = struct{}{}
+	return ch
+}
+
+func (b *eventBus) unsubscribe(ch chan Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subs[ch]; ok {
+		delete(b.subs, ch)
+		close(ch)
+	}
+}
+
+// publish sends the event to all subscribers without blocking. Subscribers that do not keep up
+// are dropped and their channel is closed, rather than silently missing events.
+func (b *eventBus) publish(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- e:
+		default:
+			delete(b.subs, ch)
+			close(ch)
+		}
+	}
+}
+
+// Subscribe returns a channel receiving the events published by the Manager, and a function to
+// cancel the subscription. The channel is closed when the subscription is canceled, or when the
+// subscriber falls more than buffer events behind.
+func (m *Manager) Subscribe(buffer int) (<-chan Event, func()) {
+	ch := m.events.subscribe(buffer)
+	return ch, func() { m.events.unsubscribe(ch) }
+}
+
+// GetSoftConfirmedHeight returns the height up to which headers signed by the sequencer are known.
+func (m *Manager) GetSoftConfirmedHeight() uint64 {
+	return m.softConfirmedHeight.Load()
+}
+
+// setSoftConfirmedHeight advances the soft-confirmed height, it never decreases.
+func (m *Manager) setSoftConfirmedHeight(height uint64) {
+	for {
+		current := m.softConfirmedHeight.Load()
+		if height <= current {
+			return
+		}
+		if m.softConfirmedHeight.CompareAndSwap(current, height) {
+			m.events.publish(Event{Type: EventSoftConfirmed, Height: height})
+			return
+		}
+	}
+}
+
+// GetAttestedHeight returns the height up to which blocks are attested by a quorum of the attester set.
+func (m *Manager) GetAttestedHeight() uint64 {
+	return m.attestedHeight.Load()
+}
+
+// SetAttestedHeight advances the attested height, it never decreases. It is called by the attestation service.
+func (m *Manager) SetAttestedHeight(height uint64) {
+	for {
+		current := m.attestedHeight.Load()
+		if height <= current {
+			return
+		}
+		if m.attestedHeight.CompareAndSwap(current, height) {
+			m.events.publish(Event{Type: EventAttested, Height: height})
+			return
+		}
+	}
+}
+
+// SetTxIndexer sets the indexer notified of applied blocks. Indexing runs in the background and never
+// delays block production or sync.
+func (m *Manager) SetTxIndexer(indexer *txindex.Indexer) {
+	m.txIndexer = indexer
+}
+
+// SetMempool sets the mempool from which the transactions of applied blocks are removed.
+func (m *Manager) SetMempool(mp *mempool.Mempool) {
+	m.mempool = mp
+}
+
+// publishNewBlock notifies subscribers, the tx indexer and the mempool that the given block was applied.
+func (m *Manager) publishNewBlock(header *types.SignedHeader, data *types.Data) {
+	if m.txIndexer != nil {
+		m.txIndexer.Notify()
+	}
+	if m.mempool != nil {
+		m.mempool.Update(data.Txs)
+	}
+	m.events.publish(newBlockEvent(EventNewBlock, header, data))
+	m.setSoftConfirmedHeight(header.Height())
+}
+
+// newBlockEvent returns the event of the given type describing the block.
+func newBlockEvent(eventType EventType, header *types.SignedHeader, data *types.Data) Event {
+	return Event{
+		Type:   eventType,
+		Height: header.Height(),
+		Hash:   header.Hash(),
+		Time:   header.Time(),
+		NumTxs: len(data.Txs),
+	}
+}
+
+// checkSyncCaughtUp publishes EventSyncCaughtUp the first time the node caught up with the chain, i.e. once the
+// DA layer was retrieved up to its head and the blocks known from the p2p network were applied.
+func (m *Manager) checkSyncCaughtUp(ctx context.Context) {
+	if m.config.Node.Aggregator || !m.daRetrievalCaughtUp.Load() || m.syncCaughtUp.Load() {
+		return
+	}
+	height, err := m.store.Height(ctx)
+	if err != nil || (m.headerStore != nil && m.headerStore.Height() > height) {
+		return
+	}
+	if m.syncCaughtUp.CompareAndSwap(false, true) {
+		m.logger.Info("caught up with the chain", "height", height)
+		m.events.publish(Event{Type: EventSyncCaughtUp, Height: height})
+	}
+}
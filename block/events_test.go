@@ -0,0 +1,120 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestManagerEvents(t *testing.T) {
+	m := &Manager{}
+	events, cancel := m.Subscribe(10)
+
+	header, data := types.GetRandomBlock(5, 3, "events-chain")
+	m.publishNewBlock(header, data)
+
+	e := <-events
+	assert.Equal(t, EventNewBlock, e.Type)
+	assert.Equal(t, uint64(5), e.Height)
+	assert.Equal(t, header.Hash(), e.Hash)
+	assert.Equal(t, 3, e.NumTxs)
+	e = <-events
+	assert.Equal(t, Event{Type: EventSoftConfirmed, Height: 5}, e)
+	assert.Equal(t, uint64(5), m.GetSoftConfirmedHeight())
+
+	// the soft-confirmed height never decreases
+	m.setSoftConfirmedHeight(4)
+	m.setSoftConfirmedHeight(5)
+	assert.Equal(t, uint64(5), m.GetSoftConfirmedHeight())
+	assert.Empty(t, events)
+
+	cancel()
+	_, ok := <-events
+	assert.False(t, ok)
+	cancel()
+}
+
+func TestManagerEventsSlowSubscriber(t *testing.T) {
+	m := &Manager{}
+	slow, cancel := m.Subscribe(1)
+	defer cancel()
+	fast, cancelFast := m.Subscribe(10)
+	defer cancelFast()
+
+	m.events.publish(Event{Type: EventDAIncluded, Height: 1})
+	m.events.publish(Event{Type: EventDAIncluded, Height: 2})
+
+	// the slow subscriber is dropped instead of silently missing events
+	e, ok := <-slow
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), e.Height)
+	_, ok = <-slow
+	assert.False(t, ok)
+
+	assert.Len(t, fast, 2)
+}
+
+func TestManagerSyncCaughtUpEvent(t *testing.T) {
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	m := &Manager{store: store.New(kv), logger: log.NewNopLogger()}
+	events, cancel := m.Subscribe(10)
+	defer cancel()
+	ctx := context.Background()
+
+	// the node is not caught up before the DA layer was retrieved up to its head
+	m.checkSyncCaughtUp(ctx)
+	assert.Empty(t, events)
+
+	m.daRetrievalCaughtUp.Store(true)
+	m.checkSyncCaughtUp(ctx)
+	assert.Equal(t, Event{Type: EventSyncCaughtUp, Height: 0}, <-events)
+
+	// the event is only published once
+	m.checkSyncCaughtUp(ctx)
+	assert.Empty(t, events)
+
+	// aggregators do not sync
+	aggregator := &Manager{store: m.store, logger: m.logger}
+	aggregator.config.Node.Aggregator = true
+	aggregator.daRetrievalCaughtUp.Store(true)
+	aggEvents, cancelAgg := aggregator.Subscribe(10)
+	defer cancelAgg()
+	aggregator.checkSyncCaughtUp(ctx)
+	assert.Empty(t, aggEvents)
+}
+
+func TestGetBlockConfirmationStatus(t *testing.T) {
+	m := &Manager{}
+	assert.Equal(t, ConfirmationPending, m.GetBlockConfirmationStatus(0))
+	assert.Equal(t, ConfirmationPending, m.GetBlockConfirmationStatus(1))
+
+	m.setSoftConfirmedHeight(5)
+	m.daIncludedHeight.Store(3)
+	assert.Equal(t, ConfirmationDAFinalized, m.GetBlockConfirmationStatus(1))
+	assert.Equal(t, ConfirmationDAFinalized, m.GetBlockConfirmationStatus(3))
+	assert.Equal(t, ConfirmationSoftConfirmed, m.GetBlockConfirmationStatus(4))
+	assert.Equal(t, ConfirmationSoftConfirmed, m.GetBlockConfirmationStatus(5))
+	assert.Equal(t, ConfirmationPending, m.GetBlockConfirmationStatus(6))
+	assert.Equal(t, "soft_confirmed", ConfirmationSoftConfirmed.String())
+
+	events, cancel := m.Subscribe(10)
+	defer cancel()
+	m.SetAttestedHeight(4)
+	assert.Equal(t, Event{Type: EventAttested, Height: 4}, <-events)
+	assert.Equal(t, ConfirmationDAFinalized, m.GetBlockConfirmationStatus(3))
+	assert.Equal(t, ConfirmationAttested, m.GetBlockConfirmationStatus(4))
+	assert.Equal(t, ConfirmationSoftConfirmed, m.GetBlockConfirmationStatus(5))
+	assert.Equal(t, "attested", ConfirmationAttested.String())
+
+	// the attested height never decreases
+	m.SetAttestedHeight(2)
+	assert.Equal(t, uint64(4), m.GetAttestedHeight())
+	assert.Empty(t, events)
+}
@@ -0,0 +1,142 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// stateRootKeyPrefix is the prefix of the store metadata keys of the state roots after the executed blocks.
+const stateRootKeyPrefix = "state-root"
+
+// stateRootKey returns the store metadata key of the state root after the block at the given height.
+func stateRootKey(height uint64) string {
+	return stateRootKeyPrefix + "/" + strconv.FormatUint(height, 10)
+}
+
+// asyncExecution reports whether the aggregator produces blocks before the previous blocks are executed. The
+// ordered blocks are executed in order by ExecutionLoop.
+func (m *Manager) asyncExecution() bool {
+	return m.config.Node.Aggregator && m.config.Node.AsyncExecution
+}
+
+// keepStateRoots reports whether the state roots after the executed blocks are persisted. Aggregators
+// executing blocks asynchronously attach them to later headers, and nodes checking state roots or running a
+// shadow executor look up the state roots committed to by headers with an execution lag.
+func (m *Manager) keepStateRoots() bool {
+	return m.asyncExecution() || m.config.Node.FraudProofs || m.shadowExec != nil
+}
+
+// loadStateRoot returns the state root after the block at the given height, and false if it was not kept.
+func (m *Manager) loadStateRoot(ctx context.Context, height uint64) (types.Hash, bool, error) {
+	if !m.keepStateRoots() {
+		return nil, false, nil
+	}
+	bz, err := m.store.GetMetadata(ctx, stateRootKey(height))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load state root of block %d: %w", height, err)
+	}
+	return bz, true, nil
+}
+
+// ExecutionLoop executes the blocks produced with asynchronous execution in order, until the context is
+// canceled. Blocks left unexecuted when the node stopped are executed first.
+func (m *Manager) ExecutionLoop(ctx context.Context) {
+	// execution is retried every block time after a failure, even if no block is produced meanwhile
+	ticker := time.NewTicker(m.blockTime())
+	defer ticker.Stop()
+	for {
+		if err := m.executeOrderedBlocks(ctx); err != nil && ctx.Err() == nil {
+			m.logger.Error("failed to execute blocks", "height", m.GetLastState().LastBlockHeight+1, "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.executeCh:
+		case <-ticker.C:
+		}
+	}
+}
+
+// sendNonBlockingSignalToExecuteCh notifies ExecutionLoop that a block was ordered.
+func (m *Manager) sendNonBlockingSignalToExecuteCh() {
+	select {
+	case m.executeCh <- struct{}{}:
+	default:
+	}
+}
+
+// executeOrderedBlocks executes the blocks of the store which were ordered but not executed yet.
+func (m *Manager) executeOrderedBlocks(ctx context.Context) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if m.fraudProof.Load() != nil {
+			return ErrHaltedByFraudProof
+		}
+		height, err := m.store.Height(ctx)
+		if err != nil {
+			return fmt.Errorf("error while getting store height: %w", err)
+		}
+		executed := m.GetLastState().LastBlockHeight
+		if executed >= height {
+			m.metrics.ExecutionLag.Set(0)
+			return nil
+		}
+		m.metrics.ExecutionLag.Set(float64(height - executed))
+		if err := m.executeBlock(ctx, executed+1); err != nil {
+			return err
+		}
+	}
+}
+
+// executeBlock executes the ordered block at the given height, which follows the last executed block, and
+// updates the state.
+func (m *Manager) executeBlock(ctx context.Context, height uint64) error {
+	header, data, err := m.store.GetBlockData(ctx, height)
+	if err != nil {
+		return fmt.Errorf("failed to load block %d: %w", height, err)
+	}
+	prevStateRoot := m.GetLastState().AppHash
+	newState, err := m.applyBlock(ctx, header, data)
+	if err != nil {
+		if ctx.Err() != nil {
+			return err
+		}
+		// the block is already ordered and published, the node halts as in synchronous execution
+		panic(fmt.Errorf("failed to execute block %d: %w", height, err))
+	}
+	newState.DAHeight = m.daHeight.Load()
+	if err := m.updateState(ctx, newState); err != nil {
+		return err
+	}
+	m.scheduleSnapshot(newState)
+	m.requestProof(ctx, header, prevStateRoot, newState.AppHash)
+	if m.txIndexer != nil {
+		m.txIndexer.Notify()
+	}
+	m.events.publish(newBlockEvent(EventBlockExecuted, header, data))
+	return nil
+}
+
+// validateOrderedBlock validates a block produced with asynchronous execution. The block is not validated
+// against the last state, which may not include the previous blocks yet.
+func (m *Manager) validateOrderedBlock(header *types.SignedHeader, data *types.Data) error {
+	if err := header.Validate(); err != nil {
+		return fmt.Errorf("invalid header: %w", err)
+	}
+	if err := types.Validate(header, data); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	return nil
+}
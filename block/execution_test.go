@@ -0,0 +1,119 @@
+package block
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/test/mocks"
+	"github.com/rollkit/rollkit/types"
+)
+
+// TestAsyncExecution verifies that an aggregator executing blocks asynchronously produces blocks before the
+// previous blocks are executed, and that the headers commit to the state root of the last executed block.
+func TestAsyncExecution(t *testing.T) {
+	ctx := t.Context()
+	privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	signer, err := noop.NewNoopSigner(privKey)
+	require.NoError(t, err)
+	proposer, err := signer.GetAddress()
+	require.NoError(t, err)
+	gen := genesis.NewGenesis("async-chain", 1, time.Now().Add(-time.Hour), proposer)
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+
+	exec := coreexecutor.NewDummyExecutor()
+	stateRoot, _, err := exec.InitChain(t.Context(), gen.GenesisDAStartTime, gen.InitialHeight, gen.ChainID)
+	require.NoError(t, err)
+
+	seq := mocks.NewSequencer(t)
+	seq.On("GetNextBatch", mock.Anything, mock.Anything).Return(func(context.Context, coresequencer.GetNextBatchRequest) (*coresequencer.GetNextBatchResponse, error) {
+		return &coresequencer.GetNextBatchResponse{
+			Batch:     &coresequencer.Batch{Transactions: [][]byte{[]byte(time.Now().String())}},
+			Timestamp: time.Now(),
+		}, nil
+	}).Maybe()
+
+	cfg := config.DefaultConfig
+	cfg.Node.Aggregator = true
+	cfg.Node.AsyncExecution = true
+	cfg.Node.MaxExecutionLag = 4
+	m, _, _, _ := newTestManager(t, withStore(s), withExecutor(exec), withConfig(cfg), withGenesis(gen), withPendingHeaders(),
+		withLastState(types.State{
+			Version:       types.InitStateVersion,
+			ChainID:       gen.ChainID,
+			InitialHeight: gen.InitialHeight,
+			LastBlockTime: gen.GenesisDAStartTime,
+			AppHash:       stateRoot,
+		}))
+	m.sequencer = seq
+	m.signer = signer
+	m.HeaderCh = make(chan *types.SignedHeader, 10)
+	m.DataCh = make(chan *types.Data, 10)
+	m.executeCh = make(chan struct{}, 1)
+	m.publishBlock = m.publishBlockInternal
+	genesisRoot := m.GetLastState().AppHash
+
+	var headers []*types.SignedHeader
+	for range 3 {
+		require.NoError(t, m.publishBlock(ctx))
+		headers = append(headers, <-m.HeaderCh)
+	}
+	// the blocks are ordered but not executed
+	assert.Equal(t, uint64(0), m.GetLastState().LastBlockHeight)
+	for i, header := range headers {
+		assert.Equal(t, uint64(i), header.ExecutionLag)
+		assert.Equal(t, uint64(0), header.AppHashHeight())
+		assert.Equal(t, types.Hash(genesisRoot), header.AppHash)
+	}
+
+	// the node stops producing blocks when too many blocks wait for execution
+	require.NoError(t, m.publishBlock(ctx))
+	<-m.HeaderCh
+	assert.ErrorContains(t, m.publishBlock(ctx), "blocks waiting for execution [4] reached limit [4]")
+
+	require.NoError(t, m.executeOrderedBlocks(ctx))
+	assert.Equal(t, uint64(4), m.GetLastState().LastBlockHeight)
+	assert.NotEqual(t, genesisRoot, m.GetLastState().AppHash)
+	select {
+	case <-m.executeCh:
+	default:
+		require.Fail(t, "execution loop was not notified")
+	}
+
+	// the next header commits to the state root of the last executed block
+	require.NoError(t, m.publishBlock(ctx))
+	header := <-m.HeaderCh
+	assert.Equal(t, uint64(0), header.ExecutionLag)
+	assert.Equal(t, types.Hash(m.GetLastState().AppHash), header.AppHash)
+
+	// the state roots of the executed blocks are kept to check headers with an execution lag
+	for height := uint64(1); height <= 4; height++ {
+		root, found, err := m.loadStateRoot(ctx, height)
+		require.NoError(t, err)
+		require.True(t, found)
+		stateRoot, err := m.stateRootAt(ctx, height)
+		require.NoError(t, err)
+		assert.Equal(t, root, stateRoot)
+	}
+	require.NoError(t, m.executeOrderedBlocks(ctx))
+	require.NoError(t, m.checkStateRoot(ctx, header))
+	lagging := *headers[2]
+	lagging.AppHash = []byte("invalid_app_hash")
+	require.ErrorIs(t, m.checkStateRoot(ctx, &lagging), ErrHaltedByFraudProof)
+}
@@ -0,0 +1,69 @@
+//go:build failpoints
+
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/failpoint"
+	"github.com/rollkit/rollkit/test/mocks"
+)
+
+// TestFailpoint_DAIncludedHeight verifies that the DA includer crashes when the DA included height cannot be
+// incremented, and resumes from the last DA included height on restart.
+func TestFailpoint_DAIncludedHeight(t *testing.T) {
+	t.Cleanup(failpoint.Reset)
+	ctx := context.Background()
+	m, _, _ := setupDAInclusionTest(t)
+
+	failpoint.Enable(failpoint.DAIncludedHeight, failpoint.Action{Err: failpoint.ErrInjected, Times: 1})
+	assert.Panics(t, func() { m.advanceDAIncludedHeight(ctx) })
+	assert.Equal(t, uint64(0), m.GetDAIncludedHeight())
+
+	m.advanceDAIncludedHeight(ctx)
+	assert.Equal(t, uint64(3), m.GetDAIncludedHeight())
+	metadata, err := m.store.GetDAMetadata(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), metadata.Header.DAHeight)
+}
+
+// TestFailpoint_DASubmitTimeout verifies that headers are submitted once DA submissions stop timing out.
+func TestFailpoint_DASubmitTimeout(t *testing.T) {
+	t.Cleanup(failpoint.Reset)
+	ctx := context.Background()
+	m, _, _ := setupDAInclusionTest(t)
+	// the headers were posted to another DA layer by the setup
+	m.da = coreda.NewDummyDA(100_000, 0, 0)
+	m.config.DA.BlockTime.Duration = 10 * time.Millisecond
+	m.gasPricer = newGasPricer(-1, 0)
+	var err error
+	m.pendingHeaders, err = NewPendingHeaders(m.store, m.logger)
+	require.NoError(t, err)
+
+	failpoint.Enable(failpoint.DASubmit, failpoint.Action{Err: coreda.ErrContextDeadline, Times: 2})
+	require.NoError(t, m.submitHeadersToDA(ctx))
+	assert.Equal(t, 2, failpoint.Triggered(failpoint.DASubmit))
+	pending, err := m.pendingHeaders.getPendingHeaders(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	m.advanceDAIncludedHeight(ctx)
+	assert.Equal(t, uint64(3), m.GetDAIncludedHeight())
+}
+
+// TestFailpoint_ExecuteTxs verifies that executor errors are injected before the executor is called.
+func TestFailpoint_ExecuteTxs(t *testing.T) {
+	t.Cleanup(failpoint.Reset)
+	exec := mocks.NewExecutor(t)
+
+	failpoint.Enable(failpoint.ExecuteTxs, failpoint.Action{Err: failpoint.ErrInjected})
+	_, _, err := executeTxs(context.Background(), exec, [][]byte{{1}}, 1, time.Now(), nil)
+	assert.ErrorIs(t, err, failpoint.ErrInjected)
+	exec.AssertNotCalled(t, "ExecuteTxs")
+}
@@ -0,0 +1,271 @@
+package block
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/types"
+)
+
+// ForcedInclusionStateKey is the key used for persisting the forced inclusion state in store.
+const ForcedInclusionStateKey = "forced-inclusion"
+
+// forcedTx is a transaction posted to the forced inclusion namespace and not included in a block yet.
+type forcedTx struct {
+	Tx []byte `json:"tx"`
+	// Deadline is the height of the last block allowed to include the transaction.
+	Deadline uint64 `json:"deadline"`
+	// Missed is set once the deadline passed, so that a missed deadline is only reported once.
+	Missed bool `json:"missed,omitempty"`
+}
+
+// forcedInclusionState is the persisted state of the forced inclusion lane.
+type forcedInclusionState struct {
+	// DAHeight is the next DA height to scan for forced inclusion transactions.
+	DAHeight uint64     `json:"da_height"`
+	Pending  []forcedTx `json:"pending"`
+}
+
+// forcedInclusion tracks the transactions posted to the forced inclusion namespace until they are
+// included in a block.
+type forcedInclusion struct {
+	da coreda.DA
+
+	mu    sync.Mutex
+	state forcedInclusionState
+}
+
+// EnableForcedInclusion enables the forced inclusion lane. Transactions posted by users to the DA
+// layer through forcedDA are tracked until they are included in a block: the aggregator includes
+// them in the next block it produces, and every node reports transactions that are not included
+// within DA.ForcedInclusionDeadline blocks. The first time it is enabled, the forced inclusion
+// namespace is scanned from the DA height of the last state.
+func (m *Manager) EnableForcedInclusion(ctx context.Context, forcedDA coreda.DA) error {
+	state := forcedInclusionState{DAHeight: m.daHeight.Load()}
+	raw, err := m.store.GetMetadata(ctx, ForcedInclusionStateKey)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return fmt.Errorf("failed to load forced inclusion state: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(raw, &state); err != nil {
+			return fmt.Errorf("failed to decode forced inclusion state: %w", err)
+		}
+	}
+	m.forcedInclusion = &forcedInclusion{da: forcedDA, state: state}
+	m.metrics.ForcedTxsPending.Set(float64(len(state.Pending)))
+	return nil
+}
+
+// ForcedInclusionRetrieveLoop scans the forced inclusion namespace of the DA layer for new transactions.
+func (m *Manager) ForcedInclusionRetrieveLoop(ctx context.Context) {
+	if m.forcedInclusion == nil {
+		return
+	}
+	ticker := time.NewTicker(m.config.DA.BlockTime.Duration)
+	defer ticker.Stop()
+	for {
+		if err := m.retrieveForcedTxs(ctx); err != nil && ctx.Err() == nil {
+			m.logger.Error("failed to retrieve forced inclusion transactions", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// retrieveForcedTxs adds the transactions of all DA heights available since the last call to the pending transactions.
+func (m *Manager) retrieveForcedTxs(ctx context.Context) error {
+	fi := m.forcedInclusion
+	for ctx.Err() == nil {
+		fi.mu.Lock()
+		daHeight := fi.state.DAHeight
+		fi.mu.Unlock()
+
+		blobs, err := m.fetchForcedTxs(ctx, daHeight)
+		if err != nil {
+			if errors.Is(err, coreda.ErrFutureHeight) || strings.Contains(err.Error(), ErrHeightFromFutureStr.Error()) {
+				return nil
+			}
+			return err
+		}
+		height, err := m.store.Height(ctx)
+		if err != nil {
+			return err
+		}
+		deadline := height + m.config.DA.ForcedInclusionDeadline
+		var included map[string]struct{}
+		if len(blobs) > 0 {
+			// a syncing node may apply the block including a transaction before it scans the DA height
+			included = m.txsInRecentBlocks(ctx, height)
+		}
+
+		added := 0
+		fi.mu.Lock()
+		for _, blob := range blobs {
+			if _, ok := included[string(blob)]; ok || len(blob) == 0 {
+				continue
+			}
+			fi.state.Pending = append(fi.state.Pending, forcedTx{Tx: blob, Deadline: deadline})
+			added++
+		}
+		fi.state.DAHeight = daHeight + 1
+		err = m.saveForcedInclusionState(ctx)
+		fi.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		if len(blobs) > 0 {
+			m.logger.Info("retrieved forced inclusion transactions", "daHeight", daHeight, "count", len(blobs), "deadline", deadline)
+		}
+		if added > 0 {
+			// trigger block production in lazy aggregation mode
+			m.NotifyNewTransactions()
+		}
+	}
+	return ctx.Err()
+}
+
+// txsInRecentBlocks returns the transactions of the last DA.ForcedInclusionDeadline blocks up to height.
+func (m *Manager) txsInRecentBlocks(ctx context.Context, height uint64) map[string]struct{} {
+	txs := make(map[string]struct{})
+	from := m.genesis.InitialHeight
+	if height > m.config.DA.ForcedInclusionDeadline+from {
+		from = height - m.config.DA.ForcedInclusionDeadline
+	}
+	for h := from; h <= height; h++ {
+		_, data, err := m.store.GetBlockData(ctx, h)
+		if err != nil {
+			// pruned or not synced yet
+			continue
+		}
+		for _, tx := range data.Txs {
+			txs[string(tx)] = struct{}{}
+		}
+	}
+	return txs
+}
+
+func (m *Manager) fetchForcedTxs(ctx context.Context, daHeight uint64) ([]coreda.Blob, error) {
+	da := m.forcedInclusion.da
+	res, err := da.GetIDs(ctx, daHeight, nil)
+	if errors.Is(err, coreda.ErrBlobNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if res == nil || len(res.IDs) == 0 {
+		return nil, nil
+	}
+	return da.Get(ctx, res.IDs, nil)
+}
+
+// pendingForcedTxs returns the forced inclusion transactions not included in a block yet.
+func (m *Manager) pendingForcedTxs() [][]byte {
+	if m.forcedInclusion == nil {
+		return nil
+	}
+	fi := m.forcedInclusion
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	txs := make([][]byte, len(fi.state.Pending))
+	for i, tx := range fi.state.Pending {
+		txs[i] = tx.Tx
+	}
+	return txs
+}
+
+// addForcedTxs prepends the pending forced inclusion transactions to the batch retrieved from the
+// sequencer. A block is produced for the forced transactions even if the sequencer has no batch.
+func (m *Manager) addForcedTxs(batchData *BatchData, err error) (*BatchData, error) {
+	if err != nil && !errors.Is(err, ErrNoBatch) {
+		return batchData, err
+	}
+	forced := m.pendingForcedTxs()
+	if len(forced) == 0 {
+		return batchData, err
+	}
+	if batchData == nil {
+		batchData = &BatchData{Batch: &coresequencer.Batch{}, Time: time.Now()}
+	}
+	txs := make([][]byte, 0, len(forced)+len(batchData.Transactions))
+	txs = append(txs, forced...)
+	txs = append(txs, batchData.Transactions...)
+	m.logger.Debug("including forced transactions", "count", len(forced))
+	return &BatchData{
+		Batch: &coresequencer.Batch{Transactions: txs},
+		Time:  batchData.Time,
+		Data:  batchData.Data,
+	}, nil
+}
+
+// markForcedTxsIncluded removes the forced inclusion transactions included in the block at the given
+// height, and reports pending transactions whose deadline passed.
+func (m *Manager) markForcedTxsIncluded(ctx context.Context, height uint64, txs types.Txs) {
+	if m.forcedInclusion == nil {
+		return
+	}
+	fi := m.forcedInclusion
+	fi.mu.Lock()
+	defer fi.mu.Unlock()
+	if len(fi.state.Pending) == 0 {
+		return
+	}
+
+	inBlock := make(map[string]struct{}, len(txs))
+	for _, tx := range txs {
+		inBlock[string(tx)] = struct{}{}
+	}
+	var (
+		pending = fi.state.Pending[:0]
+		changed bool
+	)
+	for _, tx := range fi.state.Pending {
+		if _, ok := inBlock[string(tx.Tx)]; ok {
+			m.metrics.ForcedTxsIncluded.Add(1)
+			changed = true
+			continue
+		}
+		if height > tx.Deadline && !tx.Missed {
+			tx.Missed = true
+			m.metrics.ForcedTxsMissedDeadline.Add(1)
+			m.logger.Error("forced inclusion transaction not included before its deadline, the sequencer may be censoring it",
+				"txHash", fmt.Sprintf("%X", sha256.Sum256(tx.Tx)), "deadline", tx.Deadline, "height", height)
+			changed = true
+		}
+		pending = append(pending, tx)
+	}
+	fi.state.Pending = pending
+	m.metrics.ForcedTxsPending.Set(float64(len(pending)))
+	if !changed {
+		return
+	}
+	if err := m.saveForcedInclusionState(ctx); err != nil {
+		m.logger.Error("failed to save forced inclusion state", "error", err)
+	}
+}
+
+// saveForcedInclusionState persists the forced inclusion state. It must be called with the forced inclusion mutex held.
+func (m *Manager) saveForcedInclusionState(ctx context.Context) error {
+	bz, err := json.Marshal(m.forcedInclusion.state)
+	if err != nil {
+		return err
+	}
+	if err := m.store.SetMetadata(ctx, ForcedInclusionStateKey, bz); err != nil {
+		return fmt.Errorf("failed to save forced inclusion state: %w", err)
+	}
+	m.metrics.ForcedTxsPending.Set(float64(len(m.forcedInclusion.state.Pending)))
+	return nil
+}
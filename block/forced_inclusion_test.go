@@ -0,0 +1,107 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	rollmocks "github.com/rollkit/rollkit/test/mocks"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestForcedInclusion(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	cfg := config.DefaultConfig
+	cfg.DA.ForcedInclusionDeadline = 2
+	opts := []testManagerOption{withStore(s), withConfig(cfg), withGenesis(genesis.Genesis{InitialHeight: 1}), withDAHeight(5)}
+	m, _, _, _ := newTestManager(t, opts...)
+
+	tx1, tx2 := []byte("forced-1"), []byte("forced-2")
+	forcedDA := rollmocks.NewDA(t)
+	ids := []coreda.ID{[]byte("id1"), []byte("id2")}
+	forcedDA.On("GetIDs", mock.Anything, uint64(5), mock.Anything).Return(&coreda.GetIDsResult{IDs: ids}, nil).Once()
+	forcedDA.On("Get", mock.Anything, ids, mock.Anything).Return([]coreda.Blob{tx1, tx2}, nil).Once()
+	forcedDA.On("GetIDs", mock.Anything, uint64(6), mock.Anything).Return(nil, coreda.ErrBlobNotFound).Once()
+	forcedDA.On("GetIDs", mock.Anything, uint64(7), mock.Anything).Return(nil, coreda.ErrFutureHeight).Once()
+
+	// scanning starts at the DA height of the last state
+	require.NoError(t, m.EnableForcedInclusion(ctx, forcedDA))
+	require.NoError(t, m.retrieveForcedTxs(ctx))
+	assert.Equal(t, [][]byte{tx1, tx2}, m.pendingForcedTxs())
+
+	// the state is persisted
+	restarted, _, _, _ := newTestManager(t, opts...)
+	require.NoError(t, restarted.EnableForcedInclusion(ctx, forcedDA))
+	assert.Equal(t, uint64(7), restarted.forcedInclusion.state.DAHeight)
+	assert.Equal(t, [][]byte{tx1, tx2}, restarted.pendingForcedTxs())
+
+	t.Run("forced txs are added to batches", func(t *testing.T) {
+		batch, err := m.addForcedTxs(nil, ErrNoBatch)
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{tx1, tx2}, batch.Transactions)
+
+		seqBatch := &BatchData{Batch: &coresequencer.Batch{Transactions: [][]byte{[]byte("tx")}}, Time: time.Now()}
+		batch, err = m.addForcedTxs(seqBatch, nil)
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{tx1, tx2, []byte("tx")}, batch.Transactions)
+		assert.Len(t, seqBatch.Transactions, 1)
+
+		seqErr := errors.New("sequencer error")
+		_, err = m.addForcedTxs(nil, seqErr)
+		assert.ErrorIs(t, err, seqErr)
+	})
+
+	t.Run("included txs are removed and missed deadlines reported", func(t *testing.T) {
+		m.markForcedTxsIncluded(ctx, 2, types.Txs{tx1})
+		assert.Equal(t, [][]byte{tx2}, m.pendingForcedTxs())
+		assert.False(t, m.forcedInclusion.state.Pending[0].Missed)
+
+		m.markForcedTxsIncluded(ctx, 3, types.Txs{[]byte("other")})
+		assert.True(t, m.forcedInclusion.state.Pending[0].Missed)
+
+		m.markForcedTxsIncluded(ctx, 4, types.Txs{tx2})
+		assert.Empty(t, m.pendingForcedTxs())
+		batch, err := m.addForcedTxs(nil, ErrNoBatch)
+		assert.ErrorIs(t, err, ErrNoBatch)
+		assert.Nil(t, batch)
+	})
+}
+
+func TestForcedInclusionSkipsIncludedTxs(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	cfg := config.DefaultConfig
+	cfg.DA.ForcedInclusionDeadline = 2
+	opts := []testManagerOption{withStore(s), withConfig(cfg), withGenesis(genesis.Genesis{InitialHeight: 1}), withDAHeight(5)}
+	m, _, _, _ := newTestManager(t, opts...)
+
+	// the block including the forced tx was synced before the DA height was scanned
+	header, data := types.GetRandomBlock(1, 1, "forced-chain")
+	require.NoError(t, s.SaveBlockData(ctx, header, data, &header.Signature))
+	require.NoError(t, s.SetHeight(ctx, 1))
+
+	forcedDA := rollmocks.NewDA(t)
+	ids := []coreda.ID{[]byte("id1")}
+	forcedDA.On("GetIDs", mock.Anything, uint64(5), mock.Anything).Return(&coreda.GetIDsResult{IDs: ids}, nil).Once()
+	forcedDA.On("Get", mock.Anything, ids, mock.Anything).Return([]coreda.Blob{data.Txs[0]}, nil).Once()
+	forcedDA.On("GetIDs", mock.Anything, uint64(6), mock.Anything).Return(nil, coreda.ErrFutureHeight).Once()
+
+	require.NoError(t, m.EnableForcedInclusion(ctx, forcedDA))
+	require.NoError(t, m.retrieveForcedTxs(ctx))
+	assert.Empty(t, m.pendingForcedTxs())
+}
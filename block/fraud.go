@@ -0,0 +1,147 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// FraudProofKey is the key used for persisting the fraud proof which halted the node in store.
+const FraudProofKey = "fraud-proof"
+
+// ErrHaltedByFraudProof is returned when blocks are not synced or produced because the node was halted by a
+// valid fraud proof.
+var ErrHaltedByFraudProof = errors.New("node halted by a fraud proof")
+
+// loadFraudProof restores the fraud proof which halted the node before it stopped, so that it stays halted.
+func (m *Manager) loadFraudProof(ctx context.Context) error {
+	bz, err := m.store.GetMetadata(ctx, FraudProofKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load fraud proof: %w", err)
+	}
+	proof := new(types.FraudProof)
+	if err := proof.UnmarshalBinary(bz); err != nil {
+		return fmt.Errorf("failed to decode fraud proof: %w", err)
+	}
+	m.fraudProof.Store(proof)
+	m.logger.Error("node is halted by a fraud proof", "height", proof.DisputedHeight(),
+		"committedAppHash", proof.Header.AppHash, "expectedAppHash", proof.ExpectedAppHash)
+	return nil
+}
+
+// FraudProof returns the fraud proof which halted the node, or nil if the node is not halted.
+func (m *Manager) FraudProof() *types.FraudProof {
+	return m.fraudProof.Load()
+}
+
+// checkStateRoot checks the state root committed to by the header against the state root computed by the
+// node when executing the previous block, or an earlier block if the header has an execution lag. On mismatch,
+// the node halts and the fraud proof is sent to FraudProofCh to be gossiped.
+func (m *Manager) checkStateRoot(ctx context.Context, header *types.SignedHeader) error {
+	if header.Height() <= m.genesis.InitialHeight {
+		return nil
+	}
+	expected, err := m.stateRootAt(ctx, header.AppHashHeight())
+	if err != nil {
+		// state roots of blocks executed before fraud proofs were enabled are not kept
+		m.logger.Error("cannot check state root", "height", header.Height(), "executionLag", header.ExecutionLag, "error", err)
+		return nil
+	}
+	if bytes.Equal(header.AppHash, expected) {
+		return nil
+	}
+	proof := &types.FraudProof{Header: header, ExpectedAppHash: expected}
+	m.halt(ctx, proof)
+	select {
+	case m.FraudProofCh <- proof:
+	default:
+		m.logger.Error("fraud proof channel is full, not gossiping fraud proof")
+	}
+	return ErrHaltedByFraudProof
+}
+
+// VerifyFraudProof verifies that the fraud proof is signed by the proposer, and that the expected state root
+// matches the state root computed by the node for the disputed block. Fraud proofs for blocks not executed by
+// the node yet cannot be verified.
+func (m *Manager) VerifyFraudProof(ctx context.Context, proof *types.FraudProof) error {
+	if err := proof.ValidateBasic(); err != nil {
+		return err
+	}
+	if !bytes.Equal(proof.Header.ProposerAddress, m.ProposerAt(proof.Header.Height())) {
+		return fmt.Errorf("%w: header is not signed by the proposer", types.ErrInvalidFraudProof)
+	}
+	if proof.Header.ChainID() != m.genesis.ChainID {
+		return fmt.Errorf("%w: chain ID mismatch: expected %s, got %s", types.ErrInvalidFraudProof, m.genesis.ChainID, proof.Header.ChainID())
+	}
+	height := proof.DisputedHeight()
+	appHash, err := m.stateRootAt(ctx, height)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(appHash, proof.ExpectedAppHash) {
+		return fmt.Errorf("%w: expected app hash %x does not match app hash %x at height %d",
+			types.ErrInvalidFraudProof, proof.ExpectedAppHash, appHash, height)
+	}
+	return nil
+}
+
+// HandleFraudProof verifies a fraud proof received from a peer, and halts the node if it is valid.
+func (m *Manager) HandleFraudProof(ctx context.Context, proof *types.FraudProof) error {
+	if m.fraudProof.Load() != nil {
+		return nil
+	}
+	if err := m.VerifyFraudProof(ctx, proof); err != nil {
+		return err
+	}
+	m.halt(ctx, proof)
+	return nil
+}
+
+// stateRootAt returns the state root computed by the node after executing the block at the given height.
+func (m *Manager) stateRootAt(ctx context.Context, height uint64) (types.Hash, error) {
+	lastState := m.GetLastState()
+	if lastState.LastBlockHeight < height {
+		return nil, fmt.Errorf("%w: block %d is not executed yet", types.ErrUnverifiableFraudProof, height)
+	}
+	if lastState.LastBlockHeight == height {
+		return lastState.AppHash, nil
+	}
+	if stateRoot, found, err := m.loadStateRoot(ctx, height); err != nil || found {
+		return stateRoot, err
+	}
+	// the state roots committed to by the headers of the blocks applied by the node were checked
+	header, _, err := m.store.GetBlockData(ctx, height+1)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", types.ErrUnverifiableFraudProof, err)
+	}
+	if header.ExecutionLag > 0 {
+		return nil, fmt.Errorf("%w: state root of block %d is unknown", types.ErrUnverifiableFraudProof, height)
+	}
+	return header.AppHash, nil
+}
+
+// halt stops the node from syncing and producing blocks on top of the invalid state root. The blocks
+// built on it are never applied, and the fraud proof is persisted so that the node stays halted on restart.
+func (m *Manager) halt(ctx context.Context, proof *types.FraudProof) {
+	if !m.fraudProof.CompareAndSwap(nil, proof) {
+		return
+	}
+	m.logger.Error("invalid state transition, halting node", "height", proof.DisputedHeight(),
+		"committedAppHash", proof.Header.AppHash, "expectedAppHash", proof.ExpectedAppHash)
+	bz, err := proof.MarshalBinary()
+	if err != nil {
+		m.logger.Error("failed to encode fraud proof", "error", err)
+		return
+	}
+	if err := m.store.SetMetadata(ctx, FraudProofKey, bz); err != nil {
+		m.logger.Error("failed to save fraud proof", "error", err)
+	}
+}
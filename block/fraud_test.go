@@ -0,0 +1,123 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+const fraudTestChainID = "fraud-test"
+
+// TestCheckStateRoot verifies that a node syncing a header committing to an invalid state root halts and
+// generates a fraud proof.
+func TestCheckStateRoot(t *testing.T) {
+	ctx := context.Background()
+	header, data, _ := types.GenerateRandomBlockCustomWithAppHash(&types.BlockConfig{Height: 11, NTxs: 1}, fraudTestChainID, []byte("invalid_app_hash"))
+	cfg := config.DefaultConfig
+	cfg.Node.FraudProofs = true
+	opts := []testManagerOption{
+		withConfig(cfg),
+		withGenesis(genesis.Genesis{ChainID: fraudTestChainID, InitialHeight: 1, ProposerAddress: header.ProposerAddress}),
+		withLastState(types.State{ChainID: fraudTestChainID, LastBlockHeight: 10, AppHash: []byte("app_hash")}),
+	}
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	m, _, _, _ := newTestManager(t, append(opts, withStore(store.New(kv)))...)
+	m.FraudProofCh = make(chan *types.FraudProof, 1)
+	require.NoError(t, m.store.SetHeight(ctx, 10))
+	m.headerCache.SetItem(11, header)
+	m.dataCache.SetItem(11, data)
+
+	require.ErrorIs(t, m.trySyncNextBlock(ctx, 0), ErrHaltedByFraudProof)
+	var proof *types.FraudProof
+	select {
+	case proof = <-m.FraudProofCh:
+	default:
+		require.Fail(t, "fraud proof was not generated")
+	}
+	assert.Equal(t, header, proof.Header)
+	assert.Equal(t, types.Hash("app_hash"), proof.ExpectedAppHash)
+	assert.Equal(t, uint64(10), proof.DisputedHeight())
+	require.NoError(t, m.VerifyFraudProof(ctx, proof))
+
+	// the block is not applied, and the node stays halted on restart
+	height, err := m.store.Height(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), height)
+	require.ErrorIs(t, m.publishBlockInternal(ctx), ErrHaltedByFraudProof)
+
+	restarted, _, _, _ := newTestManager(t, append(opts, withStore(m.store))...)
+	require.NoError(t, restarted.loadFraudProof(ctx))
+	require.NotNil(t, restarted.FraudProof())
+	assert.Equal(t, proof.ExpectedAppHash, restarted.FraudProof().ExpectedAppHash)
+}
+
+func TestVerifyFraudProof(t *testing.T) {
+	ctx := context.Background()
+	header, _, _ := types.GenerateRandomBlockCustomWithAppHash(&types.BlockConfig{Height: 11, NTxs: 1}, fraudTestChainID, []byte("invalid_app_hash"))
+	proof := &types.FraudProof{Header: header, ExpectedAppHash: []byte("app_hash")}
+	proposer := header.ProposerAddress
+	cfg := config.DefaultConfig
+	cfg.Node.FraudProofs = true
+	// newManager returns a manager of the chain whose last state is at the given height and state root
+	newManager := func(t *testing.T, proposer []byte, height uint64, appHash []byte) *Manager {
+		kv, err := store.NewDefaultInMemoryKVStore()
+		require.NoError(t, err)
+		m, _, _, _ := newTestManager(t,
+			withStore(store.New(kv)),
+			withConfig(cfg),
+			withGenesis(genesis.Genesis{ChainID: fraudTestChainID, InitialHeight: 1, ProposerAddress: proposer}),
+			withLastState(types.State{ChainID: fraudTestChainID, LastBlockHeight: height, AppHash: appHash}),
+		)
+		return m
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		m := newManager(t, proposer, 10, []byte("app_hash"))
+		require.NoError(t, m.HandleFraudProof(ctx, proof))
+		assert.Same(t, proof, m.FraudProof())
+	})
+
+	t.Run("disputed block not executed", func(t *testing.T) {
+		m := newManager(t, proposer, 9, []byte("app_hash_9"))
+		require.ErrorIs(t, m.VerifyFraudProof(ctx, proof), types.ErrUnverifiableFraudProof)
+		require.Error(t, m.HandleFraudProof(ctx, proof))
+		assert.Nil(t, m.FraudProof())
+	})
+
+	t.Run("different state root", func(t *testing.T) {
+		m := newManager(t, proposer, 10, []byte("other_app_hash"))
+		require.ErrorIs(t, m.VerifyFraudProof(ctx, proof), types.ErrInvalidFraudProof)
+	})
+
+	t.Run("applied blocks", func(t *testing.T) {
+		// the node applied another header at the same height, committing to the state root it computed
+		m := newManager(t, proposer, 12, []byte("app_hash_12"))
+		applied, data, _ := types.GenerateRandomBlockCustomWithAppHash(&types.BlockConfig{Height: 11, NTxs: 1}, fraudTestChainID, []byte("app_hash"))
+		require.NoError(t, m.store.SaveBlockData(ctx, applied, data, &applied.Signature))
+		require.NoError(t, m.VerifyFraudProof(ctx, proof))
+
+		// the header of the proof is the header applied by the node
+		require.NoError(t, m.store.SaveBlockData(ctx, header, data, &header.Signature))
+		require.ErrorIs(t, m.VerifyFraudProof(ctx, proof), types.ErrInvalidFraudProof)
+	})
+
+	t.Run("not signed by the proposer", func(t *testing.T) {
+		m := newManager(t, []byte("other_proposer"), 10, []byte("app_hash"))
+		require.ErrorIs(t, m.VerifyFraudProof(ctx, proof), types.ErrInvalidFraudProof)
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		m := newManager(t, proposer, 10, []byte("app_hash"))
+		tampered := *header
+		tampered.AppHash = []byte("other_invalid_app_hash")
+		require.ErrorIs(t, m.VerifyFraudProof(ctx, &types.FraudProof{Header: &tampered, ExpectedAppHash: []byte("app_hash")}), types.ErrInvalidFraudProof)
+	})
+}
@@ -0,0 +1,119 @@
+package block
+
+import (
+	"context"
+	"sync"
+)
+
+// gasPricer tracks the effective DA gas price shared by all DA submissions, in the spirit of EIP-1559:
+// the price starts at the configured floor, is bumped by the gas multiplier whenever a submission is
+// rejected because the mempool is full or the price too low, and decays back towards the floor after
+// successful submissions. A floor of -1 lets the DA layer determine the gas price, and is never adjusted.
+type gasPricer struct {
+	mu      sync.Mutex
+	floor   float64
+	max     float64 // 0 means unbounded
+	current float64
+}
+
+func newGasPricer(floor, max float64) *gasPricer {
+	return &gasPricer{floor: floor, max: max, current: floor}
+}
+
+// price returns the effective gas price.
+func (p *gasPricer) price() float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.current
+}
+
+// bump increases the effective gas price by the multiplier, up to the maximum gas price.
+func (p *gasPricer) bump(multiplier float64) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.floor < 0 || multiplier <= 1 {
+		return p.current
+	}
+	p.current *= multiplier
+	if p.max > 0 && p.current > p.max {
+		p.current = p.max
+	}
+	return p.current
+}
+
+// decay decreases the effective gas price by the multiplier, down to the floor.
+func (p *gasPricer) decay(multiplier float64) float64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.floor < 0 || multiplier <= 1 {
+		return p.current
+	}
+	p.current = max(p.current/multiplier, p.floor)
+	return p.current
+}
+
+// setLimits changes the floor and the maximum of the gas price, clamping the effective gas price between them.
+func (p *gasPricer) setLimits(floor, max float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.floor, p.max = floor, max
+	if floor < 0 || p.current < floor {
+		p.current = floor
+	}
+	if max > 0 && p.current > max {
+		p.current = max
+	}
+}
+
+// automatic reports whether the gas price is determined by the DA layer.
+func (p *gasPricer) automatic() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.floor < 0
+}
+
+// escalated reports whether the effective gas price is above the floor.
+func (p *gasPricer) escalated() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.current > p.floor
+}
+
+// GetDAGasPrice returns the effective gas price used for DA submissions, -1 if it is determined by the DA layer.
+func (m *Manager) GetDAGasPrice() float64 {
+	return m.gasPricer.price()
+}
+
+// daGasMultiplier returns the multiplier used to adjust the gas price: the configured multiplier, or the
+// one advertised by the DA layer if none is configured.
+func (m *Manager) daGasMultiplier(ctx context.Context) float64 {
+	if m.gasMultiplier > 0 {
+		return m.gasMultiplier
+	}
+	multiplier, err := m.da.GasMultiplier(ctx)
+	if err != nil {
+		m.logger.Error("failed to get gas multiplier", "error", err)
+		return 0
+	}
+	return multiplier
+}
+
+// bumpGasPrice escalates the DA gas price after a submission was rejected as underpriced.
+func (m *Manager) bumpGasPrice(ctx context.Context) float64 {
+	if m.gasPricer.automatic() {
+		return m.gasPricer.price()
+	}
+	gasPrice := m.gasPricer.bump(m.daGasMultiplier(ctx))
+	m.metrics.DAGasPrice.Set(gasPrice)
+	return gasPrice
+}
+
+// decayGasPrice lowers the DA gas price towards the floor after a successful submission.
+func (m *Manager) decayGasPrice(ctx context.Context) float64 {
+	if !m.gasPricer.escalated() {
+		return m.gasPricer.price()
+	}
+	gasPrice := m.gasPricer.decay(m.daGasMultiplier(ctx))
+	m.metrics.DAGasPrice.Set(gasPrice)
+	return gasPrice
+}
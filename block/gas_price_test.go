@@ -0,0 +1,79 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/test/mocks"
+)
+
+func TestGasPricer(t *testing.T) {
+	p := newGasPricer(1, 5)
+	assert.Equal(t, 2.0, p.bump(2))
+	assert.Equal(t, 4.0, p.bump(2))
+	// capped by the maximum gas price
+	assert.Equal(t, 5.0, p.bump(2))
+	assert.True(t, p.escalated())
+
+	assert.Equal(t, 2.5, p.decay(2))
+	assert.Equal(t, 1.25, p.decay(2))
+	// never below the floor
+	assert.Equal(t, 1.0, p.decay(2))
+	assert.False(t, p.escalated())
+
+	// no escalation without a multiplier
+	assert.Equal(t, 1.0, p.bump(0))
+
+	// the gas price determined by the DA layer is never adjusted
+	auto := newGasPricer(-1, 0)
+	assert.Equal(t, -1.0, auto.bump(2))
+	assert.Equal(t, -1.0, auto.decay(2))
+
+	// changing the limits clamps the effective gas price
+	assert.Equal(t, 4.0, p.bump(4))
+	p.setLimits(1, 3)
+	assert.Equal(t, 3.0, p.price())
+	p.setLimits(3.5, 0)
+	assert.Equal(t, 3.5, p.price())
+	p.setLimits(-1, 0)
+	assert.True(t, p.automatic())
+	assert.Equal(t, -1.0, p.price())
+	auto.setLimits(2, 0)
+	assert.False(t, auto.automatic())
+	assert.Equal(t, 2.0, auto.price())
+}
+
+// TestSubmitBatchToDA_GasPriceEscalation verifies that the gas price is bumped when the DA layer rejects a submission
+// as underpriced, and that the escalated price is shared with later submissions until it decays.
+func TestSubmitBatchToDA_GasPriceEscalation(t *testing.T) {
+	mockDA := mocks.NewDA(t)
+	m, _ := getManager(t, mockDA, 1, 2)
+	m.config.DA = config.DAConfig{BlockTime: config.DurationWrapper{Duration: time.Millisecond}, MempoolTTL: 1}
+	m.daIncluderCh = make(chan struct{}, 1)
+	ctx := context.Background()
+
+	mockDA.On("MaxBlobSize", mock.Anything).Return(uint64(0), nil).Maybe()
+	mockDA.On("SubmitWithOptions", mock.Anything, mock.Anything, 1.0, mock.Anything, mock.Anything).
+		Return(nil, coreda.ErrTxUnderpriced).Once()
+	mockDA.On("SubmitWithOptions", mock.Anything, mock.Anything, 2.0, mock.Anything, mock.Anything).
+		Return(nil, coreda.ErrTxUnderpriced).Once()
+	mockDA.On("SubmitWithOptions", mock.Anything, mock.Anything, 4.0, mock.Anything, mock.Anything).
+		Return([]coreda.ID{[]byte("id")}, nil).Once()
+
+	require.NoError(t, m.submitBatchToDA(ctx, coresequencer.Batch{Transactions: [][]byte{[]byte("tx")}}))
+	assert.Equal(t, 2.0, m.GetDAGasPrice())
+
+	// the next submission starts from the decayed gas price
+	mockDA.On("SubmitWithOptions", mock.Anything, mock.Anything, 2.0, mock.Anything, mock.Anything).
+		Return([]coreda.ID{[]byte("id")}, nil).Once()
+	require.NoError(t, m.submitBatchToDA(ctx, coresequencer.Batch{Transactions: [][]byte{[]byte("tx")}}))
+	assert.Equal(t, 1.0, m.GetDAGasPrice())
+}
@@ -0,0 +1,88 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/txindex"
+	"github.com/rollkit/rollkit/types"
+)
+
+// ErrInvalidLanes is returned for blocks whose transactions do not follow the ordering of the executor, see
+// coreexecutor.TxOrderer.
+var ErrInvalidLanes = errors.New("transactions do not follow the lanes of the executor")
+
+// orderTxs lets an executor implementing coreexecutor.TxOrderer order the transactions of a block into lanes. It
+// returns the ordered transactions and the commitment to the lanes set in the header, or the transactions
+// unchanged and no commitment if the executor does not order transactions.
+func (m *Manager) orderTxs(ctx context.Context, height uint64, txs [][]byte) ([][]byte, types.Hash, error) {
+	orderer, ok := m.exec.(coreexecutor.TxOrderer)
+	if !ok || len(txs) == 0 {
+		return txs, nil, nil
+	}
+	ordered, lanes, err := orderer.OrderTxs(ctx, height, txs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to order transactions: %w", err)
+	}
+	if err := checkLanes(txs, ordered, lanes); err != nil {
+		return nil, nil, err
+	}
+	return ordered, types.LanesHash(lanes), nil
+}
+
+// checkLanes checks that the transactions ordered by the executor are the transactions given, and that the lanes
+// cover them.
+func checkLanes(txs, ordered [][]byte, lanes []coreexecutor.Lane) error {
+	var total uint64
+	for _, lane := range lanes {
+		total += lane.NumTxs
+	}
+	if total != uint64(len(ordered)) {
+		return fmt.Errorf("%w: lanes hold %d transactions, %d ordered", ErrInvalidLanes, total, len(ordered))
+	}
+	if len(ordered) != len(txs) {
+		return fmt.Errorf("%w: %d transactions ordered, %d given", ErrInvalidLanes, len(ordered), len(txs))
+	}
+	counts := make(map[string]int, len(txs))
+	for _, tx := range txs {
+		counts[string(tx)]++
+	}
+	for _, tx := range ordered {
+		if counts[string(tx)] == 0 {
+			return fmt.Errorf("%w: transaction %X was not given", ErrInvalidLanes, txindex.TxHash(tx))
+		}
+		counts[string(tx)]--
+	}
+	return nil
+}
+
+// validateLanes checks that the transactions of a block follow the ordering of the executor: ordering them again
+// leaves them unchanged, and the header commits to the same lanes.
+func (m *Manager) validateLanes(ctx context.Context, header *types.SignedHeader, data *types.Data) error {
+	if _, ok := m.exec.(coreexecutor.TxOrderer); !ok {
+		if len(header.LanesHash) > 0 {
+			return fmt.Errorf("%w: header commits to lanes, but the executor does not order transactions", ErrInvalidLanes)
+		}
+		return nil
+	}
+	txs := make([][]byte, len(data.Txs))
+	for i, tx := range data.Txs {
+		txs[i] = tx
+	}
+	ordered, lanesHash, err := m.orderTxs(ctx, header.Height(), txs)
+	if err != nil {
+		return err
+	}
+	for i := range ordered {
+		if !bytes.Equal(ordered[i], txs[i]) {
+			return fmt.Errorf("%w: transaction %d is out of order", ErrInvalidLanes, i)
+		}
+	}
+	if !bytes.Equal(lanesHash, header.LanesHash) {
+		return fmt.Errorf("%w: expected lanes hash %X, got %X", ErrInvalidLanes, lanesHash, header.LanesHash)
+	}
+	return nil
+}
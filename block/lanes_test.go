@@ -0,0 +1,87 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/types"
+)
+
+// oracleFirstExecutor orders the transactions prefixed with "oracle" before the other transactions.
+type oracleFirstExecutor struct {
+	coreexecutor.Executor
+	// drop drops the last transaction, breaking the TxOrderer requirements
+	drop bool
+}
+
+func (e *oracleFirstExecutor) OrderTxs(ctx context.Context, blockHeight uint64, txs [][]byte) ([][]byte, []coreexecutor.Lane, error) {
+	var oracle, user [][]byte
+	for _, tx := range txs {
+		if bytes.HasPrefix(tx, []byte("oracle")) {
+			oracle = append(oracle, tx)
+		} else {
+			user = append(user, tx)
+		}
+	}
+	ordered := append(oracle, user...)
+	lanes := []coreexecutor.Lane{{Name: "oracle", NumTxs: uint64(len(oracle))}, {Name: "user", NumTxs: uint64(len(user))}}
+	if e.drop {
+		ordered = ordered[:len(ordered)-1]
+		lanes[1].NumTxs--
+	}
+	return ordered, lanes, nil
+}
+
+func TestOrderTxs(t *testing.T) {
+	ctx := t.Context()
+	txs := [][]byte{[]byte("tx1"), []byte("oracle1"), []byte("tx2"), []byte("oracle2")}
+	wantLanes := types.LanesHash([]coreexecutor.Lane{{Name: "oracle", NumTxs: 2}, {Name: "user", NumTxs: 2}})
+
+	m := &Manager{exec: &oracleFirstExecutor{}}
+	ordered, lanesHash, err := m.orderTxs(ctx, 1, txs)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("oracle1"), []byte("oracle2"), []byte("tx1"), []byte("tx2")}, ordered)
+	assert.Equal(t, wantLanes, lanesHash)
+
+	// the transactions of executors not ordering them are unchanged
+	unordered, lanesHash, err := (&Manager{exec: coreexecutor.NewDummyExecutor()}).orderTxs(ctx, 1, txs)
+	require.NoError(t, err)
+	assert.Equal(t, txs, unordered)
+	assert.Nil(t, lanesHash)
+
+	// executors may not drop transactions
+	_, _, err = (&Manager{exec: &oracleFirstExecutor{drop: true}}).orderTxs(ctx, 1, txs)
+	assert.ErrorIs(t, err, ErrInvalidLanes)
+}
+
+func TestValidateLanes(t *testing.T) {
+	ctx := t.Context()
+	m := &Manager{exec: &oracleFirstExecutor{}}
+	// validate validates the lanes of a block of the transactions, whose header commits to the lanes hash
+	validate := func(m *Manager, lanesHash types.Hash, txs ...string) error {
+		header := &types.SignedHeader{Header: types.Header{BaseHeader: types.BaseHeader{Height: 1}, LanesHash: lanesHash}}
+		data := &types.Data{}
+		for _, tx := range txs {
+			data.Txs = append(data.Txs, types.Tx(tx))
+		}
+		return m.validateLanes(ctx, header, data)
+	}
+	lanesHash := types.LanesHash([]coreexecutor.Lane{{Name: "oracle", NumTxs: 1}, {Name: "user", NumTxs: 2}})
+
+	require.NoError(t, validate(m, lanesHash, "oracle1", "tx1", "tx2"))
+	require.NoError(t, validate(m, nil), "empty blocks have no lanes")
+	assert.ErrorIs(t, validate(m, lanesHash, "tx1", "oracle1", "tx2"), ErrInvalidLanes, "transactions out of order")
+	otherLanes := types.LanesHash([]coreexecutor.Lane{{Name: "oracle", NumTxs: 2}, {Name: "user", NumTxs: 1}})
+	assert.ErrorIs(t, validate(m, otherLanes, "oracle1", "tx1", "tx2"), ErrInvalidLanes, "lanes mismatch")
+	assert.ErrorIs(t, validate(m, nil, "oracle1", "tx1", "tx2"), ErrInvalidLanes, "missing lanes")
+
+	// blocks of executors not ordering transactions have no lanes
+	unordered := &Manager{exec: coreexecutor.NewDummyExecutor()}
+	require.NoError(t, validate(unordered, nil, "tx1", "oracle1"))
+	assert.ErrorIs(t, validate(unordered, lanesHash, "oracle1", "tx1", "tx2"), ErrInvalidLanes)
+}
@@ -407,3 +407,84 @@ func TestNormalAggregationLoop_TxNotification(t *testing.T) {
 	cancel()
 	wg.Wait()
 }
+
+// TestLazyAggregationLoop_DeferredTxs tests that transactions deferred by a block trigger the next block
+// on the block timer, without waiting for a transaction notification or the lazy timer.
+func TestLazyAggregationLoop_DeferredTxs(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	blockTime := 50 * time.Millisecond
+	lazyTime := time.Minute
+	m, pubMock := setupTestManager(t, blockTime, lazyTime)
+	m.txNotifyCh = make(chan struct{}, 1)
+	m.deferredTxs = [][]byte{[]byte("tx1")}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		blockTimer := time.NewTimer(blockTime)
+		defer blockTimer.Stop()
+		m.lazyAggregationLoop(ctx, blockTimer)
+	}()
+
+	// the initial lazy timer publishes a block, leaving deferred transactions
+	select {
+	case <-pubMock.calls:
+	case <-time.After(100 * time.Millisecond):
+		require.Fail("Initial block was not published")
+	}
+
+	select {
+	case <-pubMock.calls:
+	case <-time.After(blockTime * 4):
+		require.Fail("Block was not published for the deferred transactions")
+	}
+
+	cancel()
+	wg.Wait()
+}
+
+// TestLazyAggregationLoop_SwitchMode tests that the lazy aggregation loop returns when lazy mode is switched off at
+// runtime, and that the new block time is applied.
+func TestLazyAggregationLoop_SwitchMode(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	m, _ := setupTestManager(t, 50*time.Millisecond, time.Hour)
+	m.runtimeConfigCh = make(chan struct{}, 1)
+	m.gasPricer = newGasPricer(-1, 0)
+	m.metrics = NopMetrics()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		blockTimer := time.NewTimer(0)
+		defer blockTimer.Stop()
+		m.lazyAggregationLoop(ctx, blockTimer)
+	}()
+
+	conf := m.config
+	conf.Node.BlockTime.Duration = 20 * time.Millisecond
+	m.UpdateRuntimeConfig(conf)
+	require.Equal(20*time.Millisecond, m.blockTime())
+	select {
+	case <-done:
+		t.Fatal("lazy aggregation loop returned while lazy mode is enabled")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	conf.Node.LazyMode = false
+	m.UpdateRuntimeConfig(conf)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("lazy aggregation loop did not return when lazy mode was switched off")
+	}
+}
@@ -0,0 +1,78 @@
+package block
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// LightClientConsensusState is the consensus state of the rollup at a height, as tracked by light clients of
+// the rollup, e.g. IBC light clients.
+type LightClientConsensusState struct {
+	Height    uint64
+	Timestamp time.Time
+	// Root is the state root committed to by the header. With deferred execution, it is the state root after
+	// the previous block, against which the proofs of the state at the previous height are verified.
+	Root types.Hash
+	// ProposerAddress is the address of the sequencer which signed the header.
+	ProposerAddress []byte
+}
+
+// DABlobPointer locates a blob in the DA layer.
+type DABlobPointer struct {
+	DAHeight  uint64
+	Namespace string
+	// ID identifies the blob in the DA layer
+	ID []byte
+	// Commitment to the blob, empty if the DA layer IDs do not embed it
+	Commitment []byte
+}
+
+// LightClientUpdate is the header update of a DA included block, verified by light clients of the rollup
+// against the public key of the sequencer. The blobs including the header and data in the DA layer let light
+// clients check DA inclusion; inclusion proofs are returned by GetDAInclusionProof.
+type LightClientUpdate struct {
+	ConsensusState LightClientConsensusState
+	// Header is the header signed by the sequencer, carrying the signer and the key rotation, if any.
+	Header *types.SignedHeader
+	// DAHeader locates the blob including the header in the DA layer.
+	DAHeader DABlobPointer
+	// DAData locates the blob including the block data in the DA layer. It is nil for blocks without
+	// transactions, whose data is not submitted to the DA layer.
+	DAData *DABlobPointer
+}
+
+// GetLightClientUpdate returns the header update of the DA included block at the given height, or of the last
+// DA included block if height is 0. Only DA included blocks are returned, so that light clients do not trust
+// blocks which may still be reorganized by the sequencer.
+func (m *Manager) GetLightClientUpdate(ctx context.Context, height uint64) (*LightClientUpdate, error) {
+	if height == 0 {
+		height = m.GetDAIncludedHeight()
+	}
+	metadata, err := m.loadDAMetadata(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	header, _, err := m.store.GetBlockData(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load block %d: %w", height, err)
+	}
+
+	update := &LightClientUpdate{
+		ConsensusState: LightClientConsensusState{
+			Height:          height,
+			Timestamp:       header.Time(),
+			Root:            header.AppHash,
+			ProposerAddress: header.ProposerAddress,
+		},
+		Header:   header,
+		DAHeader: DABlobPointer(metadata.Header),
+	}
+	if metadata.Data != nil {
+		data := DABlobPointer(*metadata.Data)
+		update.DAData = &data
+	}
+	return update, nil
+}
@@ -0,0 +1,49 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+)
+
+func TestLightClientUpdate(t *testing.T) {
+	ctx := context.Background()
+	m, _, headers := setupDAInclusionTest(t)
+
+	_, err := m.GetLightClientUpdate(ctx, 1)
+	assert.ErrorIs(t, err, ErrNotDAIncluded)
+
+	m.advanceDAIncludedHeight(ctx)
+	require.Equal(t, uint64(3), m.GetDAIncludedHeight())
+
+	update, err := m.GetLightClientUpdate(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), update.ConsensusState.Height)
+	assert.Equal(t, headers[1].Time(), update.ConsensusState.Timestamp)
+	assert.Equal(t, headers[1].AppHash, update.ConsensusState.Root)
+	assert.Equal(t, headers[1].ProposerAddress, update.ConsensusState.ProposerAddress)
+	assert.Equal(t, headers[1].Hash(), update.Header.Hash())
+	assert.Equal(t, headers[1].Signature, update.Header.Signature)
+	assert.Equal(t, uint64(1), update.DAHeader.DAHeight)
+	assert.Equal(t, "rollkit", update.DAHeader.Namespace)
+	_, commitment, err := coreda.SplitID(update.DAHeader.ID)
+	require.NoError(t, err)
+	assert.Equal(t, commitment, update.DAHeader.Commitment)
+	require.NotNil(t, update.DAData)
+	assert.Equal(t, uint64(1), update.DAData.DAHeight)
+
+	// the data of empty blocks is not submitted to DA
+	update, err = m.GetLightClientUpdate(ctx, 2)
+	require.NoError(t, err)
+	assert.Nil(t, update.DAData)
+
+	// the DA pointers of block 3 are unknown, so the latest update is not available
+	_, err = m.GetLightClientUpdate(ctx, 0)
+	assert.ErrorIs(t, err, ErrDAInclusionUnknown)
+	_, err = m.GetLightClientUpdate(ctx, 4)
+	assert.ErrorIs(t, err, ErrNotDAIncluded)
+}
@@ -0,0 +1,144 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+)
+
+// DeferredTxsKey is the key used for persisting the transactions deferred to the next block in store.
+const DeferredTxsKey = "deferred-txs"
+
+// loadDeferredTxs restores the transactions deferred to the next block before the node stopped.
+func (m *Manager) loadDeferredTxs(ctx context.Context) {
+	bz, err := m.store.GetMetadata(ctx, DeferredTxsKey)
+	if err != nil {
+		return
+	}
+	txs, err := bytesToBatchData(bz)
+	if err != nil {
+		m.logger.Error("error while converting deferred transactions", "error", err)
+		return
+	}
+	m.deferredTxs = txs
+}
+
+// setDeferredTxs sets and persists the transactions deferred to the next block.
+func (m *Manager) setDeferredTxs(ctx context.Context, txs [][]byte) {
+	if len(txs) == 0 && len(m.deferredTxs) == 0 {
+		return
+	}
+	m.deferredTxs = txs
+	if err := m.store.SetMetadata(ctx, DeferredTxsKey, convertBatchDataToBytes(txs)); err != nil {
+		m.logger.Error("error while setting deferred transactions", "error", err)
+	}
+}
+
+// remainingBlockBytes returns the number of bytes left in the next block for transactions retrieved from the
+// sequencer, after the pending forced inclusion and deferred transactions. It returns 0 if blocks are not
+// limited in size, and false if the next block is already full.
+func (m *Manager) remainingBlockBytes() (uint64, bool) {
+	maxBytes := m.config.Node.MaxBlockBytes
+	if maxBytes == 0 {
+		return 0, true
+	}
+	var used uint64
+	for _, tx := range m.pendingForcedTxs() {
+		used += uint64(len(tx))
+	}
+	for _, tx := range m.deferredTxs {
+		used += uint64(len(tx))
+	}
+	if used >= maxBytes {
+		return 0, false
+	}
+	return maxBytes - used, true
+}
+
+// addDeferredTxs prepends the transactions deferred by the previous block to the batch retrieved from the
+// sequencer. A block is produced for the deferred transactions even if the sequencer has no batch.
+func (m *Manager) addDeferredTxs(batchData *BatchData, err error) (*BatchData, error) {
+	if err != nil && !errors.Is(err, ErrNoBatch) {
+		return batchData, err
+	}
+	if len(m.deferredTxs) == 0 {
+		return batchData, err
+	}
+	if batchData == nil {
+		batchData = &BatchData{Batch: &coresequencer.Batch{}, Time: time.Now()}
+	}
+	txs := make([][]byte, 0, len(m.deferredTxs)+len(batchData.Transactions))
+	txs = append(txs, m.deferredTxs...)
+	txs = append(txs, batchData.Transactions...)
+	return &BatchData{
+		Batch: &coresequencer.Batch{Transactions: txs},
+		Time:  batchData.Time,
+		Data:  batchData.Data,
+	}, nil
+}
+
+// applyBlockLimits keeps the transactions of the batch that fit in a block of at most MaxBlockBytes bytes
+// and MaxBlockGas gas, in order. The remaining transactions are deferred to the next block, except for forced
+// inclusion transactions, which stay pending until they are included. Transactions exceeding the limits on
+// their own can never be included and are dropped.
+func (m *Manager) applyBlockLimits(ctx context.Context, batchData *BatchData) *BatchData {
+	maxBytes, maxGas := m.config.Node.MaxBlockBytes, m.config.Node.MaxBlockGas
+	if batchData == nil || (maxBytes == 0 && maxGas == 0) {
+		return batchData
+	}
+	meter, _ := m.exec.(coreexecutor.GasMeter)
+	forced := make(map[string]struct{})
+	for _, tx := range m.pendingForcedTxs() {
+		forced[string(tx)] = struct{}{}
+	}
+
+	var (
+		included       = make([][]byte, 0, len(batchData.Transactions))
+		deferred       [][]byte
+		usedBytes      uint64
+		usedGas        uint64
+		full           bool
+		deferredForced int
+	)
+	for _, tx := range batchData.Transactions {
+		size := uint64(len(tx))
+		var gas uint64
+		if maxGas != 0 && meter != nil {
+			var err error
+			if gas, err = meter.TxGas(ctx, tx); err != nil {
+				m.logger.Error("dropping transaction, failed to estimate gas", "error", err)
+				continue
+			}
+		}
+		if (maxBytes != 0 && size > maxBytes) || (maxGas != 0 && gas > maxGas) {
+			m.logger.Error("dropping transaction exceeding block limits", "bytes", size, "gas", gas)
+			continue
+		}
+		// once a transaction does not fit, the following ones are deferred too to preserve their order
+		full = full || (maxBytes != 0 && usedBytes+size > maxBytes) || (maxGas != 0 && usedGas+gas > maxGas)
+		if full {
+			if _, ok := forced[string(tx)]; ok {
+				deferredForced++
+				continue
+			}
+			deferred = append(deferred, tx)
+			continue
+		}
+		included = append(included, tx)
+		usedBytes += size
+		usedGas += gas
+	}
+	if len(deferred) > 0 || deferredForced > 0 {
+		m.logger.Info("deferring transactions exceeding block limits to the next block",
+			"included", len(included), "deferred", len(deferred), "deferredForced", deferredForced)
+	}
+	m.setDeferredTxs(ctx, deferred)
+	return &BatchData{
+		Batch: &coresequencer.Batch{Transactions: included},
+		Time:  batchData.Time,
+		Data:  batchData.Data,
+	}
+}
@@ -0,0 +1,97 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/store"
+)
+
+// gasMeterExecutor reports the first byte of a transaction as its gas.
+type gasMeterExecutor struct {
+	coreexecutor.Executor
+}
+
+func (gasMeterExecutor) TxGas(_ context.Context, tx []byte) (uint64, error) {
+	if len(tx) == 0 {
+		return 0, errors.New("empty transaction")
+	}
+	return uint64(tx[0]), nil
+}
+
+func batchOf(txs ...[]byte) *BatchData {
+	return &BatchData{Batch: &coresequencer.Batch{Transactions: txs}, Time: time.Now()}
+}
+
+func TestApplyBlockLimits(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	// newManager returns a manager limiting the size and gas of its blocks
+	newManager := func(t *testing.T, maxBytes, maxGas uint64) *Manager {
+		cfg := config.DefaultConfig
+		cfg.Node.MaxBlockBytes = maxBytes
+		cfg.Node.MaxBlockGas = maxGas
+		m, _, _, _ := newTestManager(t, withStore(s), withConfig(cfg), withExecutor(gasMeterExecutor{}))
+		return m
+	}
+
+	t.Run("bytes", func(t *testing.T) {
+		m := newManager(t, 10, 0)
+		small, medium, large, huge := []byte{1, 1}, []byte{1, 1, 1, 1}, []byte{1, 1, 1, 1, 1, 1}, make([]byte, 11)
+		batch := m.applyBlockLimits(ctx, batchOf(medium, large, huge, small))
+		// once a transaction does not fit, the following ones are deferred to preserve their order
+		assert.Equal(t, [][]byte{medium, large}, batch.Transactions)
+		assert.Equal(t, [][]byte{small}, m.deferredTxs, "transactions exceeding the limit alone are dropped")
+
+		remaining, ok := m.remainingBlockBytes()
+		assert.True(t, ok)
+		assert.Equal(t, uint64(8), remaining)
+
+		// deferred transactions are included first in the next block, and are persisted
+		restarted := newManager(t, 10, 0)
+		restarted.loadDeferredTxs(ctx)
+		batch, err := restarted.addDeferredTxs(nil, ErrNoBatch)
+		require.NoError(t, err)
+		batch = restarted.applyBlockLimits(ctx, batch)
+		assert.Equal(t, [][]byte{small}, batch.Transactions)
+		assert.Empty(t, restarted.deferredTxs)
+	})
+
+	t.Run("gas", func(t *testing.T) {
+		m := newManager(t, 0, 10)
+		batch := m.applyBlockLimits(ctx, batchOf([]byte{4}, []byte{5}, []byte{}, []byte{11}, []byte{2}))
+		assert.Equal(t, [][]byte{{4}, {5}}, batch.Transactions)
+		assert.Equal(t, [][]byte{{2}}, m.deferredTxs)
+
+		_, ok := m.remainingBlockBytes()
+		assert.True(t, ok, "blocks are not limited in size")
+	})
+
+	t.Run("no limits", func(t *testing.T) {
+		m := newManager(t, 0, 0)
+		batch := batchOf(make([]byte, 100))
+		assert.Same(t, batch, m.applyBlockLimits(ctx, batch))
+		assert.Nil(t, m.applyBlockLimits(ctx, nil))
+	})
+
+	t.Run("full block", func(t *testing.T) {
+		m := newManager(t, 4, 0)
+		m.deferredTxs = [][]byte{{1, 1, 1, 1}}
+		_, ok := m.remainingBlockBytes()
+		assert.False(t, ok)
+
+		seqErr := errors.New("sequencer error")
+		_, err := m.addDeferredTxs(nil, seqErr)
+		assert.ErrorIs(t, err, seqErr)
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"cosmossdk.io/log"
 	goheader "github.com/celestiaorg/go-header"
 	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
 	"github.com/libp2p/go-libp2p/core/crypto"
 
 	coreda "github.com/rollkit/rollkit/core/da"
@@ -22,8 +23,11 @@ import (
 	"github.com/rollkit/rollkit/pkg/cache"
 	"github.com/rollkit/rollkit/pkg/config"
 	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/mempool"
 	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/pkg/snapshot"
 	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/pkg/txindex"
 	"github.com/rollkit/rollkit/types"
 )
 
@@ -40,9 +44,8 @@ const (
 	// defaultMempoolTTL is the number of blocks until transaction is dropped from mempool
 	defaultMempoolTTL = 25
 
-	// maxSubmitAttempts defines how many times Rollkit will re-try to publish block to DA layer.
-	// This is temporary solution. It will be removed in future versions.
-	maxSubmitAttempts = 30
+	// daSubmitTimeout is the maximum duration of a single DA submission.
+	daSubmitTimeout = 60 * time.Second
 
 	// Applies to most channels, 100 is a large enough buffer to avoid blocking
 	channelLength = 100
@@ -60,9 +63,6 @@ const (
 var (
 	// dataHashForEmptyTxs to be used while only syncing headers from DA and no p2p to get the Data for no txs scenarios, the syncing can proceed without getting stuck forever.
 	dataHashForEmptyTxs = []byte{110, 52, 11, 156, 255, 179, 122, 152, 156, 165, 68, 230, 187, 120, 10, 44, 120, 144, 29, 63, 179, 55, 56, 118, 133, 17, 163, 6, 23, 175, 160, 29}
-
-	// initialBackoff defines initial value for block submission backoff
-	initialBackoff = 100 * time.Millisecond
 )
 
 // publishBlockFunc defines the function signature for publishing a block.
@@ -101,9 +101,17 @@ type Manager struct {
 	signer signer.Signer
 
 	daHeight *atomic.Uint64
+	// daHeadHeight is the latest DA height seen by the node, see observeDAHeight
+	daHeadHeight atomic.Uint64
+	// daBlock is the latest DA block observed, committed to in the headers created, see DABlockLoop
+	daBlock atomic.Pointer[coreexecutor.DABlock]
+	// retrieveMtx serializes the retrieval of DA heights by RetrieveLoop and BackfillLoop
+	retrieveMtx sync.Mutex
 
 	HeaderCh chan *types.SignedHeader
 	DataCh   chan *types.Data
+	// FraudProofCh receives the fraud proofs generated by the node, to be gossiped
+	FraudProofCh chan *types.FraudProof
 
 	headerInCh  chan NewHeaderEvent
 	headerStore goheader.Store[*types.SignedHeader]
@@ -113,6 +121,13 @@ type Manager struct {
 
 	headerCache *cache.Cache[types.SignedHeader]
 	dataCache   *cache.Cache[types.Data]
+	// daPointers maps the hashes of the headers and data found in the DA layer above the DA included height
+	// to their daPointer, persisted once the block is DA included
+	daPointers sync.Map
+	// daReorgSuspect identifies the DA blob found missing by the last DA reorg check, which is only concluded to
+	// be removed by a DA reorg once the next check confirms it, see checkDAReorg. It is only used by the
+	// DAIncluderLoop.
+	daReorgSuspect string
 
 	// headerStoreCh is used to notify sync goroutine (HeaderStoreRetrieveLoop) that it needs to retrieve headers from headerStore
 	headerStoreCh chan struct{}
@@ -142,11 +157,89 @@ type Manager struct {
 	// in the DA
 	daIncludedHeight atomic.Uint64
 	da               coreda.DA
-	gasPrice         float64
-	gasMultiplier    float64
+	// dataDA is bound to the DA namespace of block data, nil if block data is submitted to the namespace of da
+	dataDA        coreda.DA
+	gasMultiplier float64
+	// gasPricer tracks the effective gas price of DA submissions
+	gasPricer *gasPricer
+	// blobCodec compresses the batches submitted to the DA layer
+	blobCodec types.BlobCodec
+	// reducedMaxBlobSize is the maximum size of submitted blobs after blobs were rejected as too big by the DA
+	// layer, 0 if none was
+	reducedMaxBlobSize atomic.Uint64
+	// dryRunHeight is the height up to which the DA submission of headers was simulated, see DA.DryRun
+	dryRunHeight atomic.Uint64
+	// blobAssembler reassembles the batches split across several DA blobs
+	blobAssembler types.BlobAssembler
+
+	// daInclusion tracks per-backend DA inclusion when submitting through a coreda.QuorumSubmitter
+	daInclusion *daInclusionTracker
+
+	// snapshotStore persists state snapshots served to peers for state sync
+	snapshotStore *snapshot.Store
+	// snapshotCh passes the states at snapshot heights to the SnapshotLoop
+	snapshotCh chan types.State
+	// storeBaseHeight is the height of the first block in the store of a node bootstrapped from a state snapshot
+	// and not DA included yet, the blocks below it are not available, see RestoreSnapshot
+	storeBaseHeight uint64
+	// dataFetcher fetches missing block data from peers, nil if disabled
+	dataFetcher DataFetcher
+	// dac is the data availability committee storing block data in commitments-only mode, nil otherwise
+	dac DACertifier
+
+	// txIndexer indexes the transactions of applied blocks in the background, nil if disabled
+	txIndexer *txindex.Indexer
+	// mempool holds the transactions submitted to the node, which are removed once included in applied blocks
+	mempool *mempool.Mempool
+
+	// submissions tracks the DA submissions whose outcome is unknown
+	submissions submissionTracker
+	// daFees accounts for the DA fees paid by the node
+	daFees daFeeLedger
+	// daChains accounts for the blobs retrieved per chain
+	daChains daChainTracker
+	// daBackpressure is set while the DA submission backlog exceeds its threshold, see nextBlockTime
+	daBackpressure atomic.Bool
+	// production pauses block production of the aggregator, see PauseBlockProduction
+	production productionGate
+
+	// pendingBatches holds the batches waiting for DA submission
+	pendingBatches batchQueue
+	// epochs schedules the DA submissions of aggregators posting in epochs, nil if disabled
+	epochs *epochSchedule
+	// clock sets the timestamps of the blocks produced by aggregators
+	clock *blockClock
+	// watermark guards the signer against signing headers at or below the last signed header, nil if disabled
+	watermark *signer.WatermarkFile
+
+	// uncleanShutdown is set if the node did not record a clean shutdown before it last stopped, see Recover
+	uncleanShutdown bool
+
+	// forcedInclusion tracks transactions posted to the forced inclusion namespace, nil if disabled
+	forcedInclusion *forcedInclusion
+	// preconfs tracks the preconfirmations issued by the aggregator until their transaction is included
+	preconfs preconfirmations
+	// shadowExec re-executes the executed blocks to compare state roots, nil if disabled
+	shadowExec coreexecutor.Executor
+
+	// events publishes block, soft confirmation and DA inclusion events to subscribers
+	events eventBus
+	// daRetrievalCaughtUp is set once all DA heights up to the DA head were retrieved
+	daRetrievalCaughtUp atomic.Bool
+	// syncCaughtUp is set once EventSyncCaughtUp was published
+	syncCaughtUp atomic.Bool
+	// softConfirmedHeight is the height up to which headers signed by the sequencer are known
+	softConfirmedHeight atomic.Uint64
+	// attestedHeight is the height up to which blocks are attested by a quorum of the attester set
+	attestedHeight atomic.Uint64
+
+	// sequencing orders the blocks of the chain, according to the sequencing mode
+	sequencing SequencingStrategy
 
 	sequencer     coresequencer.Sequencer
 	lastBatchData [][]byte
+	// deferredTxs are the transactions that did not fit in the last block, included first in the next block
+	deferredTxs [][]byte
 
 	// publishBlock is the function used to publish blocks. It defaults to
 	// the manager's publishBlock method but can be overridden for testing.
@@ -155,6 +248,30 @@ type Manager struct {
 	// txNotifyCh is used to signal when new transactions are available
 	txNotifyCh chan struct{}
 
+	// executeCh is used to notify ExecutionLoop that a block was produced with asynchronous execution
+	executeCh chan struct{}
+
+	// runtimeMtx guards the runtime parameters of config, which can be changed while the node is running
+	runtimeMtx sync.RWMutex
+	// runtimeConfigCh is used to signal the aggregation loop when the runtime parameters are changed
+	runtimeConfigCh chan struct{}
+
+	// fraudProof is the valid fraud proof which halted the node, nil if the node is not halted
+	fraudProof atomic.Pointer[types.FraudProof]
+
+	// rotationsMtx guards rotations
+	rotationsMtx sync.RWMutex
+	// rotations are the key rotations of the sequencer applied by the manager, ordered by height
+	rotations []*types.KeyRotation
+	// upgradesMtx guards upgrades. It is never locked before rotationsMtx.
+	upgradesMtx sync.RWMutex
+	// upgrades are the upgrades scheduled on the manager, ordered by height
+	upgrades []*types.Upgrade
+	// namespaceDAsMtx guards namespaceDAs
+	namespaceDAsMtx sync.Mutex
+	// namespaceDAs are the DA clients bound to the namespaces set by upgrades, by hex encoded namespace
+	namespaceDAs map[string]coreda.DA
+
 	// batchSubmissionChan is used to submit batches to the sequencer
 	batchSubmissionChan chan coresequencer.Batch
 
@@ -178,6 +295,9 @@ func getInitialState(ctx context.Context, genesis genesis.Genesis, signer signer
 			logger.Error("error while initializing chain", "error", err)
 			return types.State{}, err
 		}
+		if len(genesis.AppHash) > 0 && !bytes.Equal(stateRoot, genesis.AppHash) {
+			return types.State{}, fmt.Errorf("state root %X returned by InitChain does not match the app hash %X of the genesis", stateRoot, genesis.AppHash)
+		}
 
 		// Initialize genesis block explicitly
 		header := types.Header{
@@ -300,11 +420,21 @@ func NewManager(
 		config.Node.LazyBlockInterval.Duration = defaultLazyBlockTime
 	}
 
+	if config.Node.SlotAlignment && config.Node.BlockJitter.Duration >= config.Node.BlockTime.Duration {
+		return nil, fmt.Errorf("block jitter %s must be shorter than the block time %s with slot alignment",
+			config.Node.BlockJitter.Duration, config.Node.BlockTime.Duration)
+	}
+
 	if config.DA.MempoolTTL == 0 {
 		logger.Info("Using default mempool ttl", "MempoolTTL", defaultMempoolTTL)
 		config.DA.MempoolTTL = defaultMempoolTTL
 	}
 
+	blobCodec, err := types.ParseBlobCodec(config.DA.Compression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DA compression: %w", err)
+	}
+
 	pendingHeaders, err := NewPendingHeaders(store, logger)
 	if err != nil {
 		return nil, err
@@ -324,6 +454,7 @@ func NewManager(
 	daH := atomic.Uint64{}
 	daH.Store(s.DAHeight)
 
+	cacheBounds := cache.Bounds{MaxItems: config.Cache.MaxItems, MaxHashes: config.Cache.MaxHashes}
 	agg := &Manager{
 		signer:    signer,
 		config:    config,
@@ -334,6 +465,7 @@ func NewManager(
 		// channels are buffered to avoid blocking on input/output operations, buffer sizes are arbitrary
 		HeaderCh:            make(chan *types.SignedHeader, channelLength),
 		DataCh:              make(chan *types.Data, channelLength),
+		FraudProofCh:        make(chan *types.FraudProof, 1),
 		headerInCh:          make(chan NewHeaderEvent, eventInChLength),
 		dataInCh:            make(chan NewDataEvent, eventInChLength),
 		headerStoreCh:       make(chan struct{}, 1),
@@ -342,26 +474,67 @@ func NewManager(
 		dataStore:           dataStore,
 		lastStateMtx:        new(sync.RWMutex),
 		lastBatchData:       lastBatchData,
-		headerCache:         cache.NewCache[types.SignedHeader](),
-		dataCache:           cache.NewCache[types.Data](),
+		headerCache:         cache.NewBoundedCache[types.SignedHeader](cacheBounds),
+		dataCache:           cache.NewBoundedCache[types.Data](cacheBounds),
 		retrieveCh:          make(chan struct{}, 1),
 		daIncluderCh:        make(chan struct{}, 1),
+		snapshotCh:          make(chan types.State, 1),
 		logger:              logger,
 		txsAvailable:        false,
 		pendingHeaders:      pendingHeaders,
 		metrics:             seqMetrics,
-		sequencer:           sequencer,
+		sequencer:           newRetryingSequencer(sequencer, config.Retry.Sequencer.Policy(), logger),
 		exec:                exec,
 		da:                  da,
-		gasPrice:            gasPrice,
+		gasPricer:           newGasPricer(gasPrice, config.DA.MaxGasPrice),
 		gasMultiplier:       gasMultiplier,
+		blobCodec:           blobCodec,
 		txNotifyCh:          make(chan struct{}, 1), // Non-blocking channel
+		executeCh:           make(chan struct{}, 1),
+		runtimeConfigCh:     make(chan struct{}, 1),
 		batchSubmissionChan: make(chan coresequencer.Batch, eventInChLength),
 	}
+	agg.sequencing, err = newSequencingStrategy(agg, config.Node.SequencingMode)
+	if err != nil {
+		return nil, err
+	}
+	if config.Node.Aggregator {
+		agg.epochs = newEpochSchedule(config.DA)
+		agg.clock = newBlockClock(config.Node, logger)
+	}
+	if mux, ok := da.(coreda.QuorumSubmitter); ok {
+		agg.daInclusion = newDAInclusionTracker(mux.Quorum())
+	}
 	agg.init(ctx)
+	if err := agg.loadShutdownState(ctx); err != nil {
+		return nil, err
+	}
+	if err := agg.loadDAFees(ctx); err != nil {
+		return nil, err
+	}
+	agg.loadDeferredTxs(ctx)
+	if err := agg.loadFraudProof(ctx); err != nil {
+		return nil, err
+	}
+	if err := agg.loadKeyRotations(ctx); err != nil {
+		return nil, err
+	}
+	if err := agg.loadUpgrades(ctx); err != nil {
+		return nil, err
+	}
+	if err := agg.loadPreconfirmations(ctx); err != nil {
+		return nil, err
+	}
+	if err := verifySignerScheme(signer, agg.protocol(s.LastBlockHeight+1).scheme); err != nil {
+		return nil, err
+	}
+	if _, ok := exec.(coreexecutor.GasMeter); config.Node.MaxBlockGas != 0 && !ok {
+		logger.Warn("max block gas is not enforced, the execution client does not report the gas of transactions")
+	}
+	agg.softConfirmedHeight.Store(s.LastBlockHeight)
 	// Set the default publishBlock implementation
 	agg.publishBlock = agg.publishBlockInternal
-	if s, ok := agg.sequencer.(interface {
+	if s, ok := sequencer.(interface {
 		SetBatchSubmissionChan(chan coresequencer.Batch)
 	}); ok {
 		s.SetBatchSubmissionChan(agg.batchSubmissionChan)
@@ -375,7 +548,7 @@ func (m *Manager) PendingHeaders() *PendingHeaders {
 	return m.pendingHeaders
 }
 
-// SeqClient returns the grpc sequencing client.
+// SeqClient returns the sequencing client, retrying the requests to a sequencer that cannot be reached.
 func (m *Manager) SeqClient() coresequencer.Sequencer {
 	return m.sequencer
 }
@@ -392,6 +565,10 @@ func (m *Manager) init(ctx context.Context) {
 	if height, err := m.store.GetMetadata(ctx, DAIncludedHeightKey); err == nil && len(height) == 8 {
 		m.daIncludedHeight.Store(binary.LittleEndian.Uint64(height))
 	}
+	// blocks are only pruned below the DA included height, unless the node was bootstrapped from a state snapshot
+	if pruned, err := store.GetPrunedHeight(ctx, m.store); err == nil && pruned > m.GetDAIncludedHeight() {
+		m.storeBaseHeight = pruned + 1
+	}
 }
 
 // GetDAIncludedHeight returns the rollup height at which all blocks have been
@@ -400,7 +577,33 @@ func (m *Manager) GetDAIncludedHeight() uint64 {
 	return m.daIncludedHeight.Load()
 }
 
+// PrunableHeight returns the highest height whose block data may be pruned from the store.
+// Blocks are only prunable once they are DA included and, for aggregators, submitted to the DA layer.
+func (m *Manager) PrunableHeight() uint64 {
+	height := m.GetDAIncludedHeight()
+	if m.config.Node.Aggregator {
+		height = min(height, m.pendingHeaders.GetLastSubmittedHeight())
+	}
+	return height
+}
+
 // isProposer returns whether or not the manager is a proposer
+// verifySignerScheme checks that the key of the proposer, if any, uses the signature scheme of the chain at the
+// next height.
+func verifySignerScheme(proposer signer.Signer, scheme string) error {
+	if proposer == nil {
+		return nil
+	}
+	pubKey, err := proposer.GetPublic()
+	if err != nil {
+		return err
+	}
+	if keyScheme, err := signer.SchemeOf(pubKey); err != nil || keyScheme != scheme {
+		return fmt.Errorf("signer key does not use the %s signature scheme of the chain", scheme)
+	}
+	return nil
+}
+
 func isProposer(signer signer.Signer, pubkey crypto.PubKey) (bool, error) {
 	if signer == nil {
 		return false, nil
@@ -442,9 +645,14 @@ func (m *Manager) IsDAIncluded(ctx context.Context, height uint64) (bool, error)
 	if err != nil {
 		return false, err
 	}
+	return m.isDAIncluded(header, data), nil
+}
+
+// isDAIncluded returns true if the header and data of a block are marked as DA-included in the caches.
+func (m *Manager) isDAIncluded(header *types.SignedHeader, data *types.Data) bool {
 	headerHash, dataHash := header.Hash(), data.DACommitment()
-	isIncluded := m.headerCache.IsDAIncluded(headerHash.String()) && (bytes.Equal(dataHash, dataHashForEmptyTxs) || m.dataCache.IsDAIncluded(dataHash.String()))
-	return isIncluded, nil
+	return m.headerCache.IsDAIncluded(headerHash.String()) &&
+		(bytes.Equal(dataHash, dataHashForEmptyTxs) || m.dataCache.IsDAIncluded(dataHash.String()) || m.dacCertified(header))
 }
 
 // GetExecutor returns the executor used by the manager.
@@ -461,9 +669,16 @@ func (m *Manager) retrieveBatch(ctx context.Context) (*BatchData, error) {
 		"chainID", m.genesis.ChainID,
 		"lastBatchData", m.lastBatchData)
 
+	// request a batch filling the capacity left in the block
+	maxBytes, ok := m.remainingBlockBytes()
+	if !ok {
+		m.logger.Debug("Block is full with pending transactions, skipping batch retrieval")
+		return nil, ErrNoBatch
+	}
 	req := coresequencer.GetNextBatchRequest{
 		RollupId:      []byte(m.genesis.ChainID),
 		LastBatchData: m.lastBatchData,
+		MaxBytes:      maxBytes,
 	}
 
 	res, err := m.sequencer.GetNextBatch(ctx, req)
@@ -493,8 +708,25 @@ func (m *Manager) retrieveBatch(ctx context.Context) (*BatchData, error) {
 	return nil, ErrNoBatch
 }
 
+// isUsingExpectedSingleSequencer reports whether the header is valid, including by the custom header validators, and
+// signed by the proposer at its height, or by a key the proposer rotated to with a valid key rotation carried by the
+// header and not applied yet.
 func (m *Manager) isUsingExpectedSingleSequencer(header *types.SignedHeader) bool {
-	return bytes.Equal(header.ProposerAddress, m.genesis.ProposerAddress) && header.ValidateBasic() == nil
+	return header.Validate() == nil && m.isExpectedProposer(header)
+}
+
+// isExpectedProposer is isUsingExpectedSingleSequencer for headers already validated.
+func (m *Manager) isExpectedProposer(header *types.SignedHeader) bool {
+	m.rotationsMtx.RLock()
+	defer m.rotationsMtx.RUnlock()
+	if bytes.Equal(header.ProposerAddress, m.proposerAt(header.Height())) {
+		return true
+	}
+	if header.Rotation == nil {
+		return false
+	}
+	known, err := m.verifyKeyRotation(header.Rotation)
+	return err == nil && !known
 }
 
 // publishBlockInternal is the internal implementation for publishing a block.
@@ -506,6 +738,17 @@ func (m *Manager) publishBlockInternal(ctx context.Context) error {
 	default:
 	}
 
+	if m.fraudProof.Load() != nil {
+		return ErrHaltedByFraudProof
+	}
+
+	// blocks are not created while the signer is unavailable, they could not be signed
+	if checker, ok := m.signer.(signer.HealthChecker); ok {
+		if err := checker.Healthy(); err != nil {
+			return fmt.Errorf("refusing to create block: %w", err)
+		}
+	}
+
 	if m.config.Node.MaxPendingHeaders != 0 && m.pendingHeaders.numPendingHeaders() >= m.config.Node.MaxPendingHeaders {
 		return fmt.Errorf("refusing to create block: pending blocks [%d] reached limit [%d]",
 			m.pendingHeaders.numPendingHeaders(), m.config.Node.MaxPendingHeaders)
@@ -525,6 +768,14 @@ func (m *Manager) publishBlockInternal(ctx context.Context) error {
 	}
 
 	newHeight := height + 1
+	if err := m.checkHalt(newHeight); err != nil {
+		return err
+	}
+	if executed := m.GetLastState().LastBlockHeight; m.asyncExecution() {
+		if maxLag := m.config.Node.MaxExecutionLag; maxLag != 0 && height-executed >= maxLag {
+			return fmt.Errorf("refusing to create block: blocks waiting for execution [%d] reached limit [%d]", height-executed, maxLag)
+		}
+	}
 	// this is a special case, when first block is produced - there is no previous commit
 	if newHeight <= m.genesis.InitialHeight {
 		// Special handling for genesis block
@@ -557,7 +808,8 @@ func (m *Manager) publishBlockInternal(ctx context.Context) error {
 		header = pendingHeader
 		data = pendingData
 	} else {
-		batchData, err := m.retrieveBatch(ctx)
+		batchData, err := m.addForcedTxs(m.addDeferredTxs(m.retrieveBatch(ctx)))
+		batchData = m.applyBlockLimits(ctx, batchData)
 		if err != nil {
 			if errors.Is(err, ErrNoBatch) {
 				if batchData == nil {
@@ -569,19 +821,17 @@ func (m *Manager) publishBlockInternal(ctx context.Context) error {
 				return fmt.Errorf("failed to get transactions from batch: %w", err)
 			}
 		} else {
-			if batchData.Before(lastHeaderTime) {
-				return fmt.Errorf("timestamp is not monotonically increasing: %s < %s", batchData.Time, m.getLastBlockTime())
-			}
 			m.logger.Info("Creating and publishing block", "height", newHeight)
 			m.logger.Debug("block info", "num_tx", len(batchData.Transactions))
 		}
+		batchData.Time = m.clock.timestamp(batchData.Time, lastHeaderTime, newHeight)
 
 		header, data, err = m.createBlock(ctx, newHeight, lastSignature, lastHeaderHash, batchData)
 		if err != nil {
 			return err
 		}
 
-		if err = m.store.SaveBlockData(ctx, header, data, &signature); err != nil {
+		if err = m.saveBlockData(ctx, header, data, &signature); err != nil {
 			return SaveBlockError{err}
 		}
 	}
@@ -599,13 +849,17 @@ func (m *Manager) publishBlockInternal(ctx context.Context) error {
 		panic(fmt.Errorf("critical: newly produced header failed validation: %w", err))
 	}
 
-	newState, err := m.applyBlock(ctx, header, data)
-	if err != nil {
-		if ctx.Err() != nil {
-			return err
+	// with asynchronous execution, the block is executed by ExecutionLoop once stored
+	var newState types.State
+	if !m.asyncExecution() {
+		newState, err = m.applyBlock(ctx, header, data)
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			// if call to applyBlock fails, we halt the node, see https://github.com/cometbft/cometbft/pull/496
+			panic(err)
 		}
-		// if call to applyBlock fails, we halt the node, see https://github.com/cometbft/cometbft/pull/496
-		panic(err)
 	}
 
 	// append metadata to Data before validating and saving
@@ -615,8 +869,16 @@ func (m *Manager) publishBlockInternal(ctx context.Context) error {
 		Time:         header.BaseHeader.Time,
 		LastDataHash: lastDataHash,
 	}
+	if err := m.certifyData(ctx, header, data); err != nil {
+		return err
+	}
 	// Validate the created block before storing
-	if err := m.Validate(ctx, header, data); err != nil {
+	if m.asyncExecution() {
+		err = m.validateOrderedBlock(header, data)
+	} else {
+		err = m.validate(ctx, header, data, true)
+	}
+	if err != nil {
 		return fmt.Errorf("failed to validate block: %w", err)
 	}
 
@@ -626,7 +888,7 @@ func (m *Manager) publishBlockInternal(ctx context.Context) error {
 	m.headerCache.SetSeen(headerHash)
 
 	// SaveBlock commits the DB tx
-	err = m.store.SaveBlockData(ctx, header, data, &signature)
+	err = m.saveBlockData(ctx, header, data, &signature)
 	if err != nil {
 		return SaveBlockError{err}
 	}
@@ -637,14 +899,24 @@ func (m *Manager) publishBlockInternal(ctx context.Context) error {
 		return err
 	}
 
-	newState.DAHeight = m.daHeight.Load()
-	// After this call m.lastState is the NEW state returned from ApplyBlock
-	// updateState also commits the DB tx
-	err = m.updateState(ctx, newState)
-	if err != nil {
-		return err
+	if m.asyncExecution() {
+		m.sendNonBlockingSignalToExecuteCh()
+	} else {
+		newState.DAHeight = m.daHeight.Load()
+		// After this call m.lastState is the NEW state returned from ApplyBlock
+		// updateState also commits the DB tx
+		err = m.updateState(ctx, newState)
+		if err != nil {
+			return err
+		}
+		m.scheduleSnapshot(newState)
+		m.requestProof(ctx, header, header.AppHash, newState.AppHash)
 	}
 	m.recordMetrics(data)
+	m.markForcedTxsIncluded(ctx, headerHeight, data.Txs)
+	m.resolvePreconfirmations(ctx, headerHeight, data.Txs)
+	m.publishNewBlock(header, data)
+	m.events.publish(newBlockEvent(EventBlockProduced, header, data))
 	// Check for shut down event prior to sending the header and block to
 	// their respective channels. The reason for checking for the shutdown
 	// event separately is due to the inconsistent nature of the select
@@ -673,17 +945,6 @@ func (m *Manager) recordMetrics(data *types.Data) {
 	m.metrics.CommittedHeight.Set(float64(data.Metadata.Height))
 }
 
-func (m *Manager) exponentialBackoff(backoff time.Duration) time.Duration {
-	backoff *= 2
-	if backoff == 0 {
-		backoff = initialBackoff
-	}
-	if backoff > m.config.DA.BlockTime.Duration {
-		backoff = m.config.DA.BlockTime.Duration
-	}
-	return backoff
-}
-
 func (m *Manager) getLastBlockTime() time.Time {
 	m.lastStateMtx.RLock()
 	defer m.lastStateMtx.RUnlock()
@@ -703,15 +964,27 @@ func (m *Manager) applyBlock(ctx context.Context, header *types.SignedHeader, da
 }
 
 func (m *Manager) Validate(ctx context.Context, header *types.SignedHeader, data *types.Data) error {
+	return m.validate(ctx, header, data, false)
+}
+
+// validate validates a pair of header and data, see Validate. Blocks produced by the node are not checked
+// against the DA layer, see validateDABlock.
+func (m *Manager) validate(ctx context.Context, header *types.SignedHeader, data *types.Data, produced bool) error {
 	m.lastStateMtx.RLock()
 	defer m.lastStateMtx.RUnlock()
-	return m.execValidate(m.lastState, header, data)
+	if err := m.execValidate(m.lastState, header, data); err != nil {
+		return err
+	}
+	if err := m.validateLanes(ctx, header, data); err != nil {
+		return err
+	}
+	return m.validateDABlock(ctx, header, produced)
 }
 
 // execValidate validates a pair of header and data against the last state
 func (m *Manager) execValidate(lastState types.State, header *types.SignedHeader, data *types.Data) error {
-	// Validate the basic structure of the header
-	if err := header.ValidateBasic(); err != nil {
+	// Validate the basic structure of the header, and run the custom header validators
+	if err := header.Validate(); err != nil {
 		return fmt.Errorf("invalid header: %w", err)
 	}
 
@@ -747,7 +1020,7 @@ func (m *Manager) execValidate(lastState types.State, header *types.SignedHeader
 	return nil
 }
 
-func (m *Manager) execCreateBlock(_ context.Context, height uint64, lastSignature *types.Signature, lastHeaderHash types.Hash, lastState types.State, batchData *BatchData) (*types.SignedHeader, *types.Data, error) {
+func (m *Manager) execCreateBlock(ctx context.Context, height uint64, lastSignature *types.Signature, lastHeaderHash types.Hash, lastState types.State, batchData *BatchData) (*types.SignedHeader, *types.Data, error) {
 	// Use when batchData is set to data IDs from the DA layer
 	// batchDataIDs := convertBatchDataToBytes(batchData.Data)
 
@@ -760,13 +1033,34 @@ func (m *Manager) execCreateBlock(_ context.Context, height uint64, lastSignatur
 		return nil, nil, fmt.Errorf("failed to get proposer public key: %w", err)
 	}
 
-	// check that the proposer address is the same as the genesis proposer address
+	// check that the signer is the proposer at this height, which is the genesis proposer until its key is rotated
 	address, err := m.signer.GetAddress()
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get proposer address: %w", err)
 	}
-	if !bytes.Equal(m.genesis.ProposerAddress, address) {
-		return nil, nil, fmt.Errorf("proposer address is not the same as the genesis proposer address %x != %x", address, m.genesis.ProposerAddress)
+	proposer := m.ProposerAt(height)
+	if !bytes.Equal(proposer, address) {
+		return nil, nil, fmt.Errorf("proposer address is not the same as the proposer address at height %d %x != %x", height, address, proposer)
+	}
+	// check that the block follows the upgrades at this height
+	params := m.protocol(height)
+	if scheme, err := signer.SchemeOf(key); err != nil || scheme != params.scheme {
+		return nil, nil, fmt.Errorf("proposer key does not use the %s signature scheme at height %d, the key must be rotated", params.scheme, height)
+	}
+	blockVersion := m.lastState.Version.Block
+	if params.blockVersion != 0 {
+		blockVersion = params.blockVersion
+	}
+	// with asynchronous execution, the header commits to the state root of the last executed block
+	var executionLag uint64
+	if m.asyncExecution() && height > lastState.LastBlockHeight+1 {
+		executionLag = height - 1 - lastState.LastBlockHeight
+	}
+
+	// the header commits to the latest DA block observed
+	daBlock, err := m.nextDABlock(ctx, height)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// Determine if this is an empty block
@@ -775,7 +1069,7 @@ func (m *Manager) execCreateBlock(_ context.Context, height uint64, lastSignatur
 	header := &types.SignedHeader{
 		Header: types.Header{
 			Version: types.Version{
-				Block: m.lastState.Version.Block,
+				Block: blockVersion,
 				App:   m.lastState.Version.App,
 			},
 			BaseHeader: types.BaseHeader{
@@ -787,13 +1081,17 @@ func (m *Manager) execCreateBlock(_ context.Context, height uint64, lastSignatur
 			// DataHash is set at the end of the function
 			ConsensusHash:   make(types.Hash, 32),
 			AppHash:         m.lastState.AppHash,
-			ProposerAddress: m.genesis.ProposerAddress,
+			ProposerAddress: proposer,
+			ExecutionLag:    executionLag,
+			DABlockHeight:   daBlock.Height,
+			DABlockHash:     daBlock.Hash,
 		},
 		Signature: *lastSignature,
 		Signer: types.Signer{
 			PubKey:  key,
-			Address: m.genesis.ProposerAddress,
+			Address: proposer,
 		},
+		Rotation: m.KeyRotationAt(height),
 	}
 
 	// Create block data with appropriate transactions
@@ -803,9 +1101,15 @@ func (m *Manager) execCreateBlock(_ context.Context, height uint64, lastSignatur
 
 	// Only add transactions if this is not an empty block
 	if !isEmpty {
-		blockData.Txs = make(types.Txs, len(batchData.Transactions))
-		for i := range batchData.Transactions {
-			blockData.Txs[i] = types.Tx(batchData.Transactions[i])
+		// the executor may order the transactions into lanes, committed to in the header
+		txs, lanesHash, err := m.orderTxs(ctx, height, batchData.Transactions)
+		if err != nil {
+			return nil, nil, err
+		}
+		header.LanesHash = lanesHash
+		blockData.Txs = make(types.Txs, len(txs))
+		for i := range txs {
+			blockData.Txs[i] = types.Tx(txs[i])
 		}
 		header.DataHash = blockData.DACommitment()
 	} else {
@@ -815,15 +1119,46 @@ func (m *Manager) execCreateBlock(_ context.Context, height uint64, lastSignatur
 	return header, blockData, nil
 }
 
+// retryExecutor calls the executor until the call succeeds, fails with another error than
+// coreexecutor.ErrUnavailable, the context is done, or the executor retry policy allows no more attempts.
+func (m *Manager) retryExecutor(ctx context.Context, method string, call func(ctx context.Context) error) error {
+	policy := m.config.Retry.Executor.Policy()
+	policy.Retryable = func(err error) bool { return errors.Is(err, coreexecutor.ErrUnavailable) }
+	policy.OnRetry = func(attempt int, err error, backoff time.Duration) {
+		m.logger.Warn("executor unavailable, retrying", "method", method, "attempt", attempt, "backoff", backoff, "error", err)
+	}
+	return policy.Do(ctx, call)
+}
+
 func (m *Manager) execApplyBlock(ctx context.Context, lastState types.State, header *types.SignedHeader, data *types.Data) (types.State, error) {
 	rawTxs := make([][]byte, len(data.Txs))
 	for i := range data.Txs {
 		rawTxs[i] = data.Txs[i]
 	}
-	newStateRoot, _, err := m.exec.ExecuteTxs(ctx, rawTxs, header.Height(), header.Time(), lastState.AppHash)
+	var (
+		newStateRoot []byte
+		results      []coreexecutor.TxResult
+	)
+	err := m.retryExecutor(ctx, "ExecuteTxs", func(ctx context.Context) (err error) {
+		if err := setDABlock(ctx, m.exec, header); err != nil {
+			return err
+		}
+		newStateRoot, results, err = executeTxs(ctx, m.exec, rawTxs, header.Height(), header.Time(), lastState.AppHash)
+		return err
+	})
 	if err != nil {
 		return types.State{}, err
 	}
+	if failed := failedTxs(results); failed > 0 {
+		m.metrics.FailedTxs.Add(float64(failed))
+		m.logger.Debug("block includes failed transactions", "height", header.Height(), "failed", failed, "txs", len(rawTxs))
+	}
+	results = m.withTxEvents(ctx, header.Height(), len(rawTxs), results)
+	if results != nil {
+		if err := txindex.SaveTxResults(ctx, m.store, header.Height(), rawTxs, results); err != nil {
+			return types.State{}, err
+		}
+	}
 
 	s, err := lastState.NextState(header, newStateRoot)
 	if err != nil {
@@ -910,9 +1245,18 @@ func (m *Manager) getSignature(header types.Header) (types.Signature, error) {
 	if m.signer == nil {
 		return nil, fmt.Errorf("signer is nil; cannot sign header")
 	}
+	if m.watermark != nil {
+		// rollkit has no consensus rounds, every height is signed in round 0
+		return m.watermark.Sign(m.signer, header.Height(), 0, header.Time(), b)
+	}
 	return m.signer.Sign(b)
 }
 
+// SetSignerWatermark sets the high-watermark consulted before signing headers.
+func (m *Manager) SetSignerWatermark(watermark *signer.WatermarkFile) {
+	m.watermark = watermark
+}
+
 // NotifyNewTransactions signals that new transactions are available for processing
 // This method will be called by the Reaper when it receives new transactions
 func (m *Manager) NotifyNewTransactions() {
@@ -935,3 +1279,16 @@ func (m *Manager) HeaderCache() *cache.Cache[types.SignedHeader] {
 func (m *Manager) DataCache() *cache.Cache[types.Data] {
 	return m.dataCache
 }
+
+// SetCacheSpillStore sets the store to which the header and data caches spill the entries beyond the bounds of
+// the cache configuration, so that they do not grow without bound, e.g. during a long DA outage. The caches are
+// not bounded until it is set.
+func (m *Manager) SetCacheSpillStore(spill ds.Batching) error {
+	if err := m.headerCache.SetSpillStore(namespace.Wrap(spill, ds.NewKey("headers"))); err != nil {
+		return fmt.Errorf("failed to set the spill store of the header cache: %w", err)
+	}
+	if err := m.dataCache.SetSpillStore(namespace.Wrap(spill, ds.NewKey("data"))); err != nil {
+		return fmt.Errorf("failed to set the spill store of the data cache: %w", err)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -41,12 +42,15 @@ func WithinDuration(t *testing.T, expected, actual, tolerance time.Duration) boo
 func getManager(t *testing.T, da da.DA, gasPrice float64, gasMultiplier float64) (*Manager, *mocks.Store) {
 	logger := log.NewTestLogger(t)
 	mockStore := mocks.NewStore(t)
+	// DA submissions are tracked in the store
+	mockStore.On("GetMetadata", mock.Anything, LastSubmissionDAHeightKey).Return(nil, ds.ErrNotFound).Maybe()
+	mockStore.On("SetMetadata", mock.Anything, InFlightSubmissionsKey, mock.Anything).Return(nil).Maybe()
 	m := &Manager{
 		da:            da,
 		headerCache:   cache.NewCache[types.SignedHeader](),
 		dataCache:     cache.NewCache[types.Data](),
 		logger:        logger,
-		gasPrice:      gasPrice,
+		gasPricer:     newGasPricer(gasPrice, 0),
 		gasMultiplier: gasMultiplier,
 		lastStateMtx:  &sync.RWMutex{},
 		metrics:       NopMetrics(),
@@ -84,6 +88,34 @@ func TestInitialStateClean(t *testing.T) {
 	mockExecutor.AssertExpectations(t)
 }
 
+// TestInitialStateImported verifies that getInitialState continues an imported chain from its initial height and
+// checks the state root returned by InitChain against the app hash of the genesis.
+func TestInitialStateImported(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	genesisData, _, _ := types.GetGenesisWithPrivkey("TestInitialStateImported")
+	genesisData.InitialHeight = 1001
+	genesisData.AppHash = []byte("importedAppHash")
+	logger := log.NewTestLogger(t)
+
+	es, _ := store.NewDefaultInMemoryKVStore()
+	mockExecutor := mocks.NewExecutor(t)
+	mockExecutor.On("InitChain", ctx, genesisData.GenesisDAStartTime, genesisData.InitialHeight, genesisData.ChainID).
+		Return([]byte("importedAppHash"), uint64(1000), nil).Once()
+	s, err := getInitialState(ctx, genesisData, nil, store.New(es), mockExecutor, logger)
+	require.NoError(err)
+	require.Equal(uint64(1000), s.LastBlockHeight)
+	require.Equal([]byte("importedAppHash"), s.AppHash)
+
+	es, _ = store.NewDefaultInMemoryKVStore()
+	mockExecutor = mocks.NewExecutor(t)
+	mockExecutor.On("InitChain", ctx, genesisData.GenesisDAStartTime, genesisData.InitialHeight, genesisData.ChainID).
+		Return([]byte("otherAppHash"), uint64(1000), nil).Once()
+	_, err = getInitialState(ctx, genesisData, nil, store.New(es), mockExecutor, logger)
+	require.ErrorContains(err, "does not match the app hash")
+}
+
 // TestInitialStateStored verifies that getInitialState loads existing state from the store and does not call InitChain.
 func TestInitialStateStored(t *testing.T) {
 	require := require.New(t)
@@ -177,6 +209,53 @@ func TestSignVerifySignature(t *testing.T) {
 	}
 }
 
+// TestGetSignature_Watermark verifies that headers at or below the signer watermark are not signed.
+func TestGetSignature_Watermark(t *testing.T) {
+	m, _ := getManager(t, mocks.NewDA(t), -1, -1)
+	privKey, _, err := crypto.GenerateKeyPair(crypto.Ed25519, 256)
+	require.NoError(t, err)
+	m.signer, err = noopsigner.NewNoopSigner(privKey)
+	require.NoError(t, err)
+	watermark, err := signer.LoadWatermarkFile(filepath.Join(t.TempDir(), "signer_watermark.json"))
+	require.NoError(t, err)
+	m.SetSignerWatermark(watermark)
+
+	header := types.Header{BaseHeader: types.BaseHeader{ChainID: "test", Height: 2, Time: 1}}
+	signature, err := m.getSignature(header)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), watermark.Watermark().Height)
+	// the same header is signed again, e.g. after a crash before it was saved
+	again, err := m.getSignature(header)
+	require.NoError(t, err)
+	assert.Equal(t, signature, again)
+
+	header.BaseHeader.Time = 2
+	_, err = m.getSignature(header)
+	assert.ErrorIs(t, err, signer.ErrDoubleSign)
+	header.BaseHeader.Height = 1
+	_, err = m.getSignature(header)
+	assert.ErrorIs(t, err, signer.ErrDoubleSign)
+}
+
+// unhealthySigner is a signer whose key is unavailable.
+type unhealthySigner struct {
+	signer.Signer
+}
+
+func (unhealthySigner) Healthy() error {
+	return errors.New("signer unreachable")
+}
+
+func TestPublishBlockPausedWhileSignerUnhealthy(t *testing.T) {
+	mockDAC := mocks.NewDA(t)
+	m, mockStore := getManager(t, mockDAC, -1, -1)
+	m.signer = unhealthySigner{}
+
+	err := m.publishBlockInternal(context.Background())
+	require.ErrorContains(t, err, "signer unreachable")
+	mockStore.AssertNotCalled(t, "Height", mock.Anything)
+}
+
 func TestIsDAIncluded(t *testing.T) {
 	require := require.New(t)
 	mockDAC := mocks.NewDA(t)
@@ -199,6 +278,19 @@ func TestIsDAIncluded(t *testing.T) {
 	require.True(m.IsDAIncluded(ctx, height))
 }
 
+// TestPrunableHeight verifies that aggregators never allow pruning of blocks that are not yet submitted to DA.
+func TestPrunableHeight(t *testing.T) {
+	require := require.New(t)
+	m, _ := getManager(t, mocks.NewDA(t), -1, -1)
+	m.pendingHeaders = &PendingHeaders{}
+	m.daIncludedHeight.Store(10)
+	require.Equal(uint64(10), m.PrunableHeight())
+
+	m.config.Node.Aggregator = true
+	m.pendingHeaders.lastSubmittedHeight.Store(7)
+	require.Equal(uint64(7), m.PrunableHeight())
+}
+
 // Test_submitBlocksToDA_BlockMarshalErrorCase1 verifies that a marshalling error in the first block prevents all blocks from being submitted.
 func Test_submitBlocksToDA_BlockMarshalErrorCase1(t *testing.T) {
 	chainID := "Test_submitBlocksToDA_BlockMarshalErrorCase1"
@@ -364,3 +456,33 @@ func TestBytesToBatchData(t *testing.T) {
 	assert.Error(err)
 	assert.Contains(err.Error(), "corrupted data")
 }
+
+// TestSetCacheSpillStore verifies that the header and data caches spill the entries beyond their bounds to
+// separate namespaces of the spill store.
+func TestSetCacheSpillStore(t *testing.T) {
+	bounds := cache.Bounds{MaxItems: 1, MaxHashes: 1}
+	m := &Manager{
+		headerCache: cache.NewBoundedCache[types.SignedHeader](bounds),
+		dataCache:   cache.NewBoundedCache[types.Data](bounds),
+	}
+	spill := store.NewMemoryKVStore()
+	require.NoError(t, m.SetCacheSpillStore(spill))
+
+	header, _ := types.GetRandomBlock(1, 2, "TestSetCacheSpillStore")
+	m.headerCache.SetItem(1, header)
+	m.headerCache.SetItem(2, header)
+	m.headerCache.SetDAIncluded("a")
+	m.headerCache.SetDAIncluded("b")
+	m.dataCache.SetDAIncluded("a")
+	m.dataCache.SetDAIncluded("b")
+
+	for _, key := range []string{"/headers/items/height/1", "/headers/da-included/hash/a", "/data/da-included/hash/a"} {
+		found, err := spill.Has(t.Context(), ds.NewKey(key))
+		require.NoError(t, err)
+		assert.True(t, found, key)
+	}
+	assert.Equal(t, header.Hash(), m.headerCache.GetItem(1).Hash())
+	assert.True(t, m.headerCache.IsDAIncluded("a"))
+	assert.True(t, m.dataCache.IsDAIncluded("a"))
+	assert.False(t, m.dataCache.IsDAIncluded("c"))
+}
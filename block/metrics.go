@@ -26,6 +26,88 @@ type Metrics struct {
 	TotalTxs metrics.Gauge
 	// The latest block height.
 	CommittedHeight metrics.Gauge `metrics_name:"latest_block_height"`
+	// Number of transactions included in blocks whose execution failed.
+	FailedTxs metrics.Counter
+	// Number of produced blocks waiting for execution, with asynchronous execution.
+	ExecutionLag metrics.Gauge
+
+	// Number of forced inclusion transactions waiting to be included.
+	ForcedTxsPending metrics.Gauge
+	// Number of forced inclusion transactions included in blocks.
+	ForcedTxsIncluded metrics.Counter
+	// Number of forced inclusion transactions not included before their deadline.
+	ForcedTxsMissedDeadline metrics.Counter
+
+	// Number of preconfirmations issued by the aggregator.
+	PreconfirmationsIssued metrics.Counter
+	// Number of preconfirmations whose transaction was not included yet.
+	PreconfirmationsPending metrics.Gauge
+	// Number of preconfirmations whose transaction was not included by their max height.
+	PreconfirmationViolations metrics.Counter
+
+	// Height of the last block executed by the shadow executor.
+	ShadowHeight metrics.Gauge
+	// Number of blocks whose state root computed by the shadow executor differs from the primary executor.
+	ShadowDivergences metrics.Counter
+
+	// Number of headers submitted to the DA layer without validity proof commitment after the proof deadline.
+	ProofsMissedDeadline metrics.Counter
+
+	// Latency of DA submissions in seconds.
+	DASubmissionDuration metrics.Histogram
+	// Size of the blobs submitted to the DA layer.
+	DABlobSizeBytes metrics.Histogram
+	// Number of DA submission attempts retried after a failure.
+	DASubmissionRetries metrics.Counter
+	// Number of DA submissions failed after all attempts.
+	DASubmissionFailures metrics.Counter
+	// Gas price used for the last DA submission.
+	DAGasPrice metrics.Gauge
+	// The latest DA included block height.
+	DAIncludedHeight metrics.Gauge `metrics_name:"da_included_height"`
+	// Number of blocks between the latest block height and the DA included height.
+	DAInclusionLag metrics.Gauge
+	// Number of DA reorgs which removed the blobs of DA included blocks.
+	DAReorgs metrics.Counter
+	// Number of DA included blocks re-verified against the DA layer by the DA audit.
+	DAAuditedBlocks metrics.Counter
+	// Number of DA included blocks whose blobs failed the DA audit.
+	DAAuditMismatches metrics.Counter
+	// DA fees paid for the submitted blobs, in units of the gas price.
+	DAFees metrics.Counter
+	// DA fees paid during the current UTC day, in units of the gas price.
+	DAFeesToday metrics.Gauge
+	// Whether DA submissions are paused because the DA daily budget is exhausted.
+	DABudgetExhausted metrics.Gauge
+	// Number of headers waiting for DA submission.
+	DABacklog metrics.Gauge
+	// Whether block production slows down and transactions are rejected because the DA backlog exceeds its threshold.
+	DABackpressure metrics.Gauge
+	// Whether block production is paused through the AdminService.
+	BlockProductionPaused metrics.Gauge
+	// Number of transactions rejected because the DA backlog exceeds its threshold.
+	DABacklogRejectedTxs metrics.Counter
+	// Number of transactions of the executor dropped by the reaper because they exceed the limits of the mempool.
+	ReaperDroppedTxs metrics.Counter
+	// Number of blobs retrieved from the DA layer skipped, by reason: envelopes of an unsupported version or of
+	// another chain, or blobs without envelope in shared namespaces.
+	DASkippedBlobs metrics.Counter `metrics_labels:"reason"`
+	// Number of blobs retrieved from the DA layer, by chain ID.
+	DARetrievedBlobs metrics.Counter `metrics_labels:"chain_id"`
+	// Total size in bytes of the blobs retrieved from the DA layer, by chain ID.
+	DARetrievedBytes metrics.Counter `metrics_labels:"chain_id"`
+
+	// Number of headers received by the sync loop, by source: p2p or da.
+	SyncHeadersReceived metrics.Counter `metrics_labels:"source"`
+	// Number of block data received by the sync loop, by source: p2p or da.
+	SyncDataReceived metrics.Counter `metrics_labels:"source"`
+	// Number of headers and blocks failing verification during sync, by source: p2p or da.
+	SyncVerificationFailures metrics.Counter `metrics_labels:"source"`
+	// Latency of saving synced blocks and the resulting state to the store in seconds, by source: p2p or da.
+	SyncStoreWriteDuration metrics.Histogram `metrics_labels:"source"`
+	// Distance between the head of each source and the local head: blocks between the p2p header store height and
+	// the store height for p2p, DA heights between the DA head and the last retrieved DA height for da.
+	SyncHeadGap metrics.Gauge `metrics_labels:"source"`
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -67,6 +149,231 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "latest_block_height",
 			Help:      "The latest block height.",
 		}, labels).With(labelsAndValues...),
+		FailedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "failed_txs",
+			Help:      "Number of transactions included in blocks whose execution failed.",
+		}, labels).With(labelsAndValues...),
+		ExecutionLag: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "execution_lag",
+			Help:      "Number of produced blocks waiting for execution, with asynchronous execution.",
+		}, labels).With(labelsAndValues...),
+		ForcedTxsPending: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "forced_txs_pending",
+			Help:      "Number of forced inclusion transactions waiting to be included.",
+		}, labels).With(labelsAndValues...),
+		ForcedTxsIncluded: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "forced_txs_included",
+			Help:      "Number of forced inclusion transactions included in blocks.",
+		}, labels).With(labelsAndValues...),
+		ForcedTxsMissedDeadline: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "forced_txs_missed_deadline",
+			Help:      "Number of forced inclusion transactions not included before their deadline.",
+		}, labels).With(labelsAndValues...),
+		PreconfirmationsIssued: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "preconfirmations_issued",
+			Help:      "Number of preconfirmations issued by the aggregator.",
+		}, labels).With(labelsAndValues...),
+		PreconfirmationsPending: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "preconfirmations_pending",
+			Help:      "Number of preconfirmations whose transaction was not included yet.",
+		}, labels).With(labelsAndValues...),
+		PreconfirmationViolations: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "preconfirmation_violations",
+			Help:      "Number of preconfirmations whose transaction was not included by their max height.",
+		}, labels).With(labelsAndValues...),
+		ShadowHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "shadow_height",
+			Help:      "Height of the last block executed by the shadow executor.",
+		}, labels).With(labelsAndValues...),
+		ShadowDivergences: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "shadow_divergences",
+			Help:      "Number of blocks whose state root computed by the shadow executor differs from the primary executor.",
+		}, labels).With(labelsAndValues...),
+		ProofsMissedDeadline: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "proofs_missed_deadline",
+			Help:      "Number of headers submitted to the DA layer without validity proof commitment after the proof deadline.",
+		}, labels).With(labelsAndValues...),
+		DASubmissionDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_submission_duration_seconds",
+			Help:      "Latency of DA submissions in seconds.",
+			Buckets:   stdprometheus.ExponentialBucketsRange(0.1, 100, 8),
+		}, labels).With(labelsAndValues...),
+		DABlobSizeBytes: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_blob_size_bytes",
+			Help:      "Size of the blobs submitted to the DA layer.",
+			Buckets:   stdprometheus.ExponentialBucketsRange(1024, 8*1024*1024, 8),
+		}, labels).With(labelsAndValues...),
+		DASubmissionRetries: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_submission_retries",
+			Help:      "Number of DA submission attempts retried after a failure.",
+		}, labels).With(labelsAndValues...),
+		DASubmissionFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_submission_failures",
+			Help:      "Number of DA submissions failed after all attempts.",
+		}, labels).With(labelsAndValues...),
+		DAGasPrice: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_gas_price",
+			Help:      "Gas price used for the last DA submission.",
+		}, labels).With(labelsAndValues...),
+		DAIncludedHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_included_height",
+			Help:      "The latest DA included block height.",
+		}, labels).With(labelsAndValues...),
+		DAInclusionLag: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_inclusion_lag",
+			Help:      "Number of blocks between the latest block height and the DA included height.",
+		}, labels).With(labelsAndValues...),
+		DAReorgs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_reorgs",
+			Help:      "Number of DA reorgs which removed the blobs of DA included blocks.",
+		}, labels).With(labelsAndValues...),
+		DAAuditedBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_audited_blocks",
+			Help:      "Number of DA included blocks re-verified against the DA layer by the DA audit.",
+		}, labels).With(labelsAndValues...),
+		DAAuditMismatches: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_audit_mismatches",
+			Help:      "Number of DA included blocks whose blobs failed the DA audit.",
+		}, labels).With(labelsAndValues...),
+		DAFees: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_fees",
+			Help:      "DA fees paid for the submitted blobs, in units of the gas price.",
+		}, labels).With(labelsAndValues...),
+		DAFeesToday: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_fees_today",
+			Help:      "DA fees paid during the current UTC day, in units of the gas price.",
+		}, labels).With(labelsAndValues...),
+		DABudgetExhausted: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_budget_exhausted",
+			Help:      "Whether DA submissions are paused because the DA daily budget is exhausted.",
+		}, labels).With(labelsAndValues...),
+		DABacklog: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_backlog",
+			Help:      "Number of headers waiting for DA submission.",
+		}, labels).With(labelsAndValues...),
+		DABackpressure: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_backpressure",
+			Help:      "Whether block production slows down and transactions are rejected because the DA backlog exceeds its threshold.",
+		}, labels).With(labelsAndValues...),
+		BlockProductionPaused: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "block_production_paused",
+			Help:      "Whether block production is paused through the AdminService.",
+		}, labels).With(labelsAndValues...),
+		DABacklogRejectedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_backlog_rejected_txs",
+			Help:      "Number of transactions rejected because the DA backlog exceeds its threshold.",
+		}, labels).With(labelsAndValues...),
+		ReaperDroppedTxs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "reaper_dropped_txs",
+			Help:      "Number of transactions of the executor dropped by the reaper because they exceed the limits of the mempool.",
+		}, labels).With(labelsAndValues...),
+		DASkippedBlobs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_skipped_blobs",
+			Help:      "Number of blobs retrieved from the DA layer skipped, by reason.",
+		}, append(labels, "reason")).With(labelsAndValues...),
+		DARetrievedBlobs: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_retrieved_blobs",
+			Help:      "Number of blobs retrieved from the DA layer, by chain ID.",
+		}, append(labels, "chain_id")).With(labelsAndValues...),
+		DARetrievedBytes: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "da_retrieved_bytes",
+			Help:      "Total size in bytes of the blobs retrieved from the DA layer, by chain ID.",
+		}, append(labels, "chain_id")).With(labelsAndValues...),
+		SyncHeadersReceived: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sync_headers_received",
+			Help:      "Number of headers received by the sync loop, by source.",
+		}, append(labels, "source")).With(labelsAndValues...),
+		SyncDataReceived: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sync_data_received",
+			Help:      "Number of block data received by the sync loop, by source.",
+		}, append(labels, "source")).With(labelsAndValues...),
+		SyncVerificationFailures: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sync_verification_failures",
+			Help:      "Number of headers and blocks failing verification during sync, by source.",
+		}, append(labels, "source")).With(labelsAndValues...),
+		SyncStoreWriteDuration: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sync_store_write_duration_seconds",
+			Help:      "Latency of saving synced blocks and the resulting state to the store in seconds, by source.",
+			Buckets:   stdprometheus.ExponentialBucketsRange(0.001, 10, 10),
+		}, append(labels, "source")).With(labelsAndValues...),
+		SyncHeadGap: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "sync_head_gap",
+			Help:      "Distance between the head of each source and the local head, in blocks for p2p and DA heights for da.",
+		}, append(labels, "source")).With(labelsAndValues...),
 	}
 }
 
@@ -78,5 +385,48 @@ func NopMetrics() *Metrics {
 		BlockSizeBytes:  discard.NewGauge(),
 		TotalTxs:        discard.NewGauge(),
 		CommittedHeight: discard.NewGauge(),
+		FailedTxs:       discard.NewCounter(),
+		ExecutionLag:    discard.NewGauge(),
+
+		ForcedTxsPending:        discard.NewGauge(),
+		ForcedTxsIncluded:       discard.NewCounter(),
+		ForcedTxsMissedDeadline: discard.NewCounter(),
+
+		PreconfirmationsIssued:    discard.NewCounter(),
+		PreconfirmationsPending:   discard.NewGauge(),
+		PreconfirmationViolations: discard.NewCounter(),
+
+		ShadowHeight:      discard.NewGauge(),
+		ShadowDivergences: discard.NewCounter(),
+
+		ProofsMissedDeadline: discard.NewCounter(),
+
+		DASubmissionDuration:  discard.NewHistogram(),
+		DABlobSizeBytes:       discard.NewHistogram(),
+		DASubmissionRetries:   discard.NewCounter(),
+		DASubmissionFailures:  discard.NewCounter(),
+		DAGasPrice:            discard.NewGauge(),
+		DAIncludedHeight:      discard.NewGauge(),
+		DAInclusionLag:        discard.NewGauge(),
+		DAReorgs:              discard.NewCounter(),
+		DAAuditedBlocks:       discard.NewCounter(),
+		DAAuditMismatches:     discard.NewCounter(),
+		DAFees:                discard.NewCounter(),
+		DAFeesToday:           discard.NewGauge(),
+		DABudgetExhausted:     discard.NewGauge(),
+		DABacklog:             discard.NewGauge(),
+		DABackpressure:        discard.NewGauge(),
+		BlockProductionPaused: discard.NewGauge(),
+		DABacklogRejectedTxs:  discard.NewCounter(),
+		ReaperDroppedTxs:      discard.NewCounter(),
+		DASkippedBlobs:        discard.NewCounter(),
+		DARetrievedBlobs:      discard.NewCounter(),
+		DARetrievedBytes:      discard.NewCounter(),
+
+		SyncHeadersReceived:      discard.NewCounter(),
+		SyncDataReceived:         discard.NewCounter(),
+		SyncVerificationFailures: discard.NewCounter(),
+		SyncStoreWriteDuration:   discard.NewHistogram(),
+		SyncHeadGap:              discard.NewGauge(),
 	}
 }
@@ -0,0 +1,86 @@
+package block
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// pausePollInterval is the interval at which PauseBlockProduction checks whether the blocks produced before the
+// pause were submitted to the DA layer.
+const pausePollInterval = 100 * time.Millisecond
+
+// productionGate pauses block production of the aggregator.
+type productionGate struct {
+	// mu is held while the aggregation loop publishes a block
+	mu     sync.Mutex
+	paused atomic.Bool
+}
+
+// publishUnlessPaused publishes a block unless block production is paused. It reports whether a block was
+// published, or attempted to.
+func (m *Manager) publishUnlessPaused(ctx context.Context) (bool, error) {
+	m.production.mu.Lock()
+	defer m.production.mu.Unlock()
+	if m.production.paused.Load() {
+		return false, nil
+	}
+	return true, m.publishBlock(ctx)
+}
+
+// PauseBlockProduction pauses block production, e.g. for a maintenance window, until ResumeBlockProduction is
+// called. The block being published, if any, is published first, and the call returns once the headers and
+// batches of the blocks produced before the pause are submitted to the DA layer, or when ctx is done. Block
+// production stays paused if the DA submissions do not complete in time. Submissions are not waited for while
+// they are paused by the DA daily budget or simulated with DA.DryRun.
+func (m *Manager) PauseBlockProduction(ctx context.Context) error {
+	// the aggregation loop holds the lock while it publishes a block, so the block being published is published
+	// before block production pauses
+	m.production.mu.Lock()
+	if !m.production.paused.Swap(true) {
+		m.metrics.BlockProductionPaused.Set(1)
+		m.logger.Info("paused block production, waiting for DA submissions")
+	}
+	m.production.mu.Unlock()
+	if m.config.DA.DryRun {
+		return nil
+	}
+	ticker := time.NewTicker(pausePollInterval)
+	defer ticker.Stop()
+	for !m.daSubmissionsFlushed() {
+		if m.daBudgetExhausted() {
+			m.logger.Warn("block production paused with blocks waiting for DA submission, the DA daily budget is exhausted")
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("block production paused, but blocks are still waiting for DA submission: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+	m.logger.Info("all blocks produced before the pause were submitted to the DA layer")
+	return nil
+}
+
+// ResumeBlockProduction resumes block production paused by PauseBlockProduction.
+func (m *Manager) ResumeBlockProduction() {
+	if m.production.paused.Swap(false) {
+		m.metrics.BlockProductionPaused.Set(0)
+		m.logger.Info("resumed block production")
+	}
+}
+
+// BlockProductionPaused reports whether block production is paused by PauseBlockProduction.
+func (m *Manager) BlockProductionPaused() bool {
+	return m.production.paused.Load()
+}
+
+// daSubmissionsFlushed reports whether no headers or batches wait for DA submission.
+func (m *Manager) daSubmissionsFlushed() bool {
+	if m.pendingHeaders != nil && !m.pendingHeaders.isEmpty() {
+		return false
+	}
+	return m.numPendingBatches() == 0
+}
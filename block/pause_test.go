@@ -0,0 +1,70 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/config"
+)
+
+func TestPauseBlockProduction(t *testing.T) {
+	var published int
+	m, _, _, _ := newTestManager(t)
+	m.publishBlock = func(ctx context.Context) error {
+		published++
+		return nil
+	}
+
+	ok, err := m.publishUnlessPaused(t.Context())
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.NoError(t, m.PauseBlockProduction(t.Context()))
+	assert.True(t, m.BlockProductionPaused())
+	ok, err = m.publishUnlessPaused(t.Context())
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	m.ResumeBlockProduction()
+	assert.False(t, m.BlockProductionPaused())
+	ok, err = m.publishUnlessPaused(t.Context())
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, published)
+}
+
+func TestPauseBlockProduction_WaitsForDASubmissions(t *testing.T) {
+	var published int
+	m, _, _, _ := newTestManager(t)
+	m.publishBlock = func(ctx context.Context) error {
+		published++
+		return nil
+	}
+	m.pendingBatches.batches = []coresequencer.Batch{{Transactions: [][]byte{[]byte("tx")}}}
+
+	// block production stays paused while batches wait for DA submission
+	ctx, cancel := context.WithTimeout(t.Context(), 3*pausePollInterval)
+	defer cancel()
+	require.ErrorIs(t, m.PauseBlockProduction(ctx), context.DeadlineExceeded)
+	assert.True(t, m.BlockProductionPaused())
+
+	// the pause completes once the batches are submitted
+	go func() {
+		time.Sleep(pausePollInterval)
+		m.pendingBatches.mu.Lock()
+		m.pendingBatches.batches = nil
+		m.pendingBatches.mu.Unlock()
+	}()
+	require.NoError(t, m.PauseBlockProduction(t.Context()))
+
+	// submissions simulated with DA.DryRun are not waited for
+	m.pendingBatches.batches = []coresequencer.Batch{{Transactions: [][]byte{[]byte("tx")}}}
+	m.config = config.Config{DA: config.DAConfig{DryRun: true}}
+	require.NoError(t, m.PauseBlockProduction(t.Context()))
+	assert.Zero(t, published)
+}
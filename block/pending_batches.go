@@ -0,0 +1,114 @@
+package block
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	ds "github.com/ipfs/go-datastore"
+
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+)
+
+// PendingBatchesKey is the key used for persisting the batches waiting for DA submission in store.
+const PendingBatchesKey = "pending-batches"
+
+// batchQueue holds the batches received from the sequencer until they are submitted to the DA layer. The queue
+// is persisted on every change, so that batches awaiting DA submission are not lost when the node crashes.
+type batchQueue struct {
+	mu      sync.Mutex
+	batches []coresequencer.Batch
+}
+
+// loadPendingBatches restores the batches that were waiting for DA submission when the node stopped.
+func (m *Manager) loadPendingBatches(ctx context.Context) error {
+	raw, err := m.store.GetMetadata(ctx, PendingBatchesKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load pending batches: %w", err)
+	}
+	var batches []coresequencer.Batch
+	if err := json.Unmarshal(raw, &batches); err != nil {
+		return fmt.Errorf("failed to decode pending batches: %w", err)
+	}
+	m.pendingBatches.mu.Lock()
+	defer m.pendingBatches.mu.Unlock()
+	m.pendingBatches.batches = batches
+	return nil
+}
+
+// savePendingBatches persists the batches waiting for DA submission. It must be called with pendingBatches.mu held.
+func (m *Manager) savePendingBatches(ctx context.Context) {
+	raw, err := json.Marshal(m.pendingBatches.batches)
+	if err != nil {
+		m.logger.Error("failed to encode pending batches", "error", err)
+		return
+	}
+	if err := m.store.SetMetadata(ctx, PendingBatchesKey, raw); err != nil {
+		m.logger.Error("failed to save pending batches", "error", err)
+	}
+}
+
+// queueBatch appends the batch to the batches waiting for DA submission.
+func (m *Manager) queueBatch(ctx context.Context, batch coresequencer.Batch) {
+	// in commitments-only mode, the data of blocks is made available by the committee instead of the DA layer
+	if m.dac != nil {
+		return
+	}
+	m.pendingBatches.mu.Lock()
+	defer m.pendingBatches.mu.Unlock()
+	m.pendingBatches.batches = append(m.pendingBatches.batches, batch)
+	m.savePendingBatches(ctx)
+}
+
+// queueReceivedBatches moves the batches received from the sequencer into the persisted queue.
+func (m *Manager) queueReceivedBatches(ctx context.Context) {
+	for len(m.batchSubmissionChan) > 0 {
+		m.queueBatch(ctx, <-m.batchSubmissionChan)
+	}
+}
+
+// nextPendingBatch returns the oldest batch waiting for DA submission, and false if there is none.
+func (m *Manager) nextPendingBatch() (coresequencer.Batch, bool) {
+	m.pendingBatches.mu.Lock()
+	defer m.pendingBatches.mu.Unlock()
+	if len(m.pendingBatches.batches) == 0 {
+		return coresequencer.Batch{}, false
+	}
+	return m.pendingBatches.batches[0], true
+}
+
+// allPendingBatches returns all the batches waiting for DA submission, oldest first.
+func (m *Manager) allPendingBatches() []coresequencer.Batch {
+	m.pendingBatches.mu.Lock()
+	defer m.pendingBatches.mu.Unlock()
+	return slices.Clone(m.pendingBatches.batches)
+}
+
+// popPendingBatch removes the oldest batch waiting for DA submission, once its submission ended.
+func (m *Manager) popPendingBatch(ctx context.Context) {
+	m.popPendingBatches(ctx, 1)
+}
+
+// popPendingBatches removes the n oldest batches waiting for DA submission, once their submission ended.
+func (m *Manager) popPendingBatches(ctx context.Context, n int) {
+	m.pendingBatches.mu.Lock()
+	defer m.pendingBatches.mu.Unlock()
+	if len(m.pendingBatches.batches) == 0 {
+		return
+	}
+	m.pendingBatches.batches = m.pendingBatches.batches[min(n, len(m.pendingBatches.batches)):]
+	m.savePendingBatches(ctx)
+}
+
+// numPendingBatches returns the number of batches waiting for DA submission.
+func (m *Manager) numPendingBatches() int {
+	m.pendingBatches.mu.Lock()
+	defer m.pendingBatches.mu.Unlock()
+	return len(m.pendingBatches.batches) + len(m.batchSubmissionChan)
+}
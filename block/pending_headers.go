@@ -5,6 +5,8 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"slices"
+	"sync"
 	"sync/atomic"
 
 	"cosmossdk.io/log"
@@ -21,10 +23,10 @@ const LastSubmittedHeightKey = "last-submitted"
 //
 // Important assertions:
 // - headers are safely stored in database before submission to DA
-// - headers are always pushed to DA in order (by height)
+// - headers are handed to DA submissions in order (by height), but concurrent submissions may complete out of order
 // - DA submission of multiple headers is atomic - it's impossible to submit only part of a batch
 //
-// lastSubmittedHeight is updated only after receiving confirmation from DA.
+// lastSubmittedHeight is updated only after receiving confirmation from DA for all headers up to it.
 // Worst case scenario is when headers was successfully submitted to DA, but confirmation was not received (e.g. node was
 // restarted, networking issue occurred). In this case headers are re-submitted to DA (it's extra cost).
 // rollkit is able to skip duplicate headers so this shouldn't affect full nodes.
@@ -35,6 +37,15 @@ type PendingHeaders struct {
 
 	// lastSubmittedHeight holds information about last header successfully submitted to DA
 	lastSubmittedHeight atomic.Uint64
+
+	// mu guards the tracking of the headers handed to in-flight DA submissions
+	mu sync.Mutex
+	// dispatchedHeight is the height of the last header handed to a DA submission
+	dispatchedHeight uint64
+	// released holds the heights of headers handed to DA submissions which did not submit them
+	released []uint64
+	// submitted holds the heights above lastSubmittedHeight of the headers already submitted
+	submitted map[uint64]bool
 }
 
 // NewPendingHeaders returns a new PendingHeaders struct
@@ -88,6 +99,82 @@ func (pb *PendingHeaders) getPendingHeaders(ctx context.Context) ([]*types.Signe
 	return headers, nil
 }
 
+// nextHeaders hands the pending headers not handed to another DA submission yet to a new submission, in order of
+// height. The submission reports the headers it submitted with markSubmitted, and hands the others back with
+// release so that they are handed to a later submission.
+func (pb *PendingHeaders) nextHeaders(ctx context.Context) ([]*types.SignedHeader, error) {
+	height, err := pb.store.Height(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pb.mu.Lock()
+	lastSubmitted := pb.lastSubmittedHeight.Load()
+	var heights []uint64
+	for _, h := range pb.released {
+		if h > lastSubmitted && h <= height {
+			heights = append(heights, h)
+		}
+	}
+	pb.released = nil
+	for h := max(pb.dispatchedHeight, lastSubmitted) + 1; h <= height; h++ {
+		heights = append(heights, h)
+	}
+	pb.dispatchedHeight = max(pb.dispatchedHeight, height)
+	pb.mu.Unlock()
+	slices.Sort(heights)
+
+	headers := make([]*types.SignedHeader, 0, len(heights))
+	for i, h := range heights {
+		header, _, err := pb.store.GetBlockData(ctx, h)
+		if err != nil {
+			pb.releaseHeights(heights[i:])
+			// return as much as possible + error information
+			return headers, err
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+// markSubmitted records the headers submitted to DA, advancing the last submitted height over the heights whose
+// headers were all submitted.
+func (pb *PendingHeaders) markSubmitted(ctx context.Context, headers []*types.SignedHeader) {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+	if pb.submitted == nil {
+		pb.submitted = make(map[uint64]bool)
+	}
+	for _, header := range headers {
+		pb.submitted[header.Height()] = true
+	}
+	lastSubmitted := pb.lastSubmittedHeight.Load()
+	for pb.submitted[lastSubmitted+1] {
+		lastSubmitted++
+	}
+	for h := range pb.submitted {
+		if h <= lastSubmitted {
+			delete(pb.submitted, h)
+		}
+	}
+	pb.setLastSubmittedHeight(ctx, lastSubmitted)
+}
+
+// release hands back headers handed to a DA submission which did not submit them.
+func (pb *PendingHeaders) release(headers []*types.SignedHeader) {
+	heights := make([]uint64, len(headers))
+	for i, header := range headers {
+		heights[i] = header.Height()
+	}
+	pb.releaseHeights(heights)
+}
+
+func (pb *PendingHeaders) releaseHeights(heights []uint64) {
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+	pb.released = append(pb.released, heights...)
+}
+
 func (pb *PendingHeaders) isEmpty() bool {
 	height, err := pb.store.Height(context.Background())
 	if err != nil {
@@ -104,6 +191,27 @@ func (pb *PendingHeaders) numPendingHeaders() uint64 {
 	return height - pb.lastSubmittedHeight.Load()
 }
 
+// numUndispatchedHeaders returns the number of pending headers not handed to a DA submission.
+func (pb *PendingHeaders) numUndispatchedHeaders() uint64 {
+	height, err := pb.store.Height(context.Background())
+	if err != nil {
+		return 0
+	}
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+	lastSubmitted := pb.lastSubmittedHeight.Load()
+	var n uint64
+	if dispatched := max(pb.dispatchedHeight, lastSubmitted); height > dispatched {
+		n = height - dispatched
+	}
+	for _, h := range pb.released {
+		if h > lastSubmitted && h <= height {
+			n++
+		}
+	}
+	return n
+}
+
 func (pb *PendingHeaders) setLastSubmittedHeight(ctx context.Context, newLastSubmittedHeight uint64) {
 	lsh := pb.lastSubmittedHeight.Load()
 
@@ -125,6 +233,20 @@ func (pb *PendingHeaders) setLastSubmittedHeight(ctx context.Context, newLastSub
 	}
 }
 
+// resetLastSubmittedHeight lowers the last submitted height, e.g. if it is above the store height after a crash.
+func (pb *PendingHeaders) resetLastSubmittedHeight(ctx context.Context, height uint64) error {
+	bz := make([]byte, 8)
+	binary.LittleEndian.PutUint64(bz, height)
+	if err := pb.store.SetMetadata(ctx, LastSubmittedHeightKey, bz); err != nil {
+		return fmt.Errorf("failed to reset last submitted height: %w", err)
+	}
+	pb.lastSubmittedHeight.Store(height)
+	pb.mu.Lock()
+	defer pb.mu.Unlock()
+	pb.dispatchedHeight = min(pb.dispatchedHeight, height)
+	return nil
+}
+
 func (pb *PendingHeaders) init() error {
 	raw, err := pb.store.GetMetadata(context.Background(), LastSubmittedHeightKey)
 	if errors.Is(err, ds.ErrNotFound) {
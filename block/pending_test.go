@@ -107,3 +107,38 @@ func checkRequirements(ctx context.Context, t *testing.T, pb *PendingHeaders, nB
 		return blocks[i].Height() < blocks[j].Height()
 	}))
 }
+
+// TestPendingHeadersInFlight verifies that concurrent DA submissions get disjoint headers and that the last
+// submitted height only advances over contiguous submitted heights.
+func TestPendingHeadersInFlight(t *testing.T) {
+	ctx := context.Background()
+	pb := newPendingBlocks(t)
+	fillWithBlockData(ctx, t, pb, "TestPendingHeadersInFlight")
+
+	first, err := pb.nextHeaders(ctx)
+	require.NoError(t, err)
+	require.Len(t, first, numBlocks)
+
+	// all headers are in flight
+	second, err := pb.nextHeaders(ctx)
+	require.NoError(t, err)
+	require.Empty(t, second)
+
+	// the second half is submitted before the first half
+	pb.markSubmitted(ctx, first[testHeight:])
+	require.Equal(t, uint64(0), pb.GetLastSubmittedHeight())
+
+	// the first submission only submitted its first header, the rest is handed to the next submission
+	pb.markSubmitted(ctx, first[:1])
+	require.Equal(t, uint64(1), pb.GetLastSubmittedHeight())
+	pb.release(first[1:testHeight])
+
+	retry, err := pb.nextHeaders(ctx)
+	require.NoError(t, err)
+	require.Len(t, retry, testHeight-1)
+	require.Equal(t, uint64(2), retry[0].Height())
+
+	pb.markSubmitted(ctx, retry)
+	require.Equal(t, uint64(numBlocks), pb.GetLastSubmittedHeight())
+	checkRequirements(ctx, t, pb, 0)
+}
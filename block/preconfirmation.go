@@ -0,0 +1,319 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/pkg/txindex"
+	"github.com/rollkit/rollkit/types"
+)
+
+const (
+	// PreconfirmationsKey is the key used for persisting the preconfirmations waiting for inclusion in store.
+	PreconfirmationsKey = "preconfirmations"
+
+	// PreconfirmationKeyPrefix prefixes the keys of the commitment log, holding the preconfirmations issued by the
+	// aggregator by transaction hash.
+	PreconfirmationKeyPrefix = "preconfirmation/"
+
+	// maxPreconfirmationScan is the maximum number of blocks scanned to verify a preconfirmation whose transaction
+	// is neither in the commitment log nor in the transaction index.
+	maxPreconfirmationScan = 1000
+)
+
+// ErrPreconfirmationsDisabled is returned when preconfirmations are requested from a node which does not issue them.
+var ErrPreconfirmationsDisabled = errors.New("preconfirmations are disabled")
+
+// PreconfirmationStatus is the outcome of the promise made by a preconfirmation.
+type PreconfirmationStatus int
+
+const (
+	// PreconfirmationPending is the status of preconfirmations whose max height was not reached yet.
+	PreconfirmationPending PreconfirmationStatus = iota + 1
+	// PreconfirmationIncluded is the status of preconfirmations whose transaction was included by the max height.
+	PreconfirmationIncluded
+	// PreconfirmationViolated is the status of preconfirmations whose transaction was not included by the max height.
+	PreconfirmationViolated
+)
+
+// PreconfirmationResult is the result of the verification of a preconfirmation.
+type PreconfirmationResult struct {
+	Status PreconfirmationStatus
+	// Height is the height of the block including the transaction, 0 if it was not included.
+	Height uint64
+}
+
+// preconfirmationRecord is an entry of the commitment log.
+type preconfirmationRecord struct {
+	// Preconfirmation is the binary encoding of the signed preconfirmation.
+	Preconfirmation []byte `json:"preconfirmation"`
+	IncludedHeight  uint64 `json:"included_height,omitempty"`
+	Violated        bool   `json:"violated,omitempty"`
+}
+
+// pendingPreconfirmation is a preconfirmation whose transaction was not included in a block yet.
+type pendingPreconfirmation struct {
+	TxHash    []byte `json:"tx_hash"`
+	MaxHeight uint64 `json:"max_height"`
+}
+
+// preconfirmations tracks the preconfirmations issued by the aggregator until their transaction is included.
+type preconfirmations struct {
+	mu      sync.Mutex
+	pending []pendingPreconfirmation
+}
+
+func preconfirmationKey(txHash []byte) string {
+	return PreconfirmationKeyPrefix + hex.EncodeToString(txHash)
+}
+
+// loadPreconfirmations loads the preconfirmations waiting for inclusion, so that broken promises are detected
+// across restarts.
+func (m *Manager) loadPreconfirmations(ctx context.Context) error {
+	bz, err := m.store.GetMetadata(ctx, PreconfirmationsKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load preconfirmations: %w", err)
+	}
+	var pending []pendingPreconfirmation
+	if err := json.Unmarshal(bz, &pending); err != nil {
+		return fmt.Errorf("failed to decode preconfirmations: %w", err)
+	}
+	m.preconfs.mu.Lock()
+	m.preconfs.pending = pending
+	m.preconfs.mu.Unlock()
+	m.metrics.PreconfirmationsPending.Set(float64(len(pending)))
+	return nil
+}
+
+// Preconfirm issues a preconfirmation for a transaction accepted by the aggregator, promising to include it
+// within Node.PreconfirmationHorizon blocks. The preconfirmation is signed by the signer of the aggregator and
+// recorded in the commitment log before it is returned. A transaction accepted again is answered with the
+// preconfirmation issued first.
+func (m *Manager) Preconfirm(ctx context.Context, tx []byte) (*types.Preconfirmation, error) {
+	horizon := m.config.Node.PreconfirmationHorizon
+	if !m.config.Node.Aggregator || horizon == 0 || m.signer == nil {
+		return nil, ErrPreconfirmationsDisabled
+	}
+	txHash := txindex.TxHash(tx)
+
+	m.preconfs.mu.Lock()
+	defer m.preconfs.mu.Unlock()
+	if record, err := m.getPreconfirmationRecord(ctx, txHash); err != nil {
+		return nil, err
+	} else if record != nil && record.IncludedHeight == 0 && !record.Violated {
+		preconf := new(types.Preconfirmation)
+		if err := preconf.UnmarshalBinary(record.Preconfirmation); err != nil {
+			return nil, fmt.Errorf("failed to decode preconfirmation: %w", err)
+		}
+		return preconf, nil
+	}
+
+	height, err := m.store.Height(ctx)
+	if err != nil {
+		return nil, err
+	}
+	preconf, err := types.GetPreconfirmation(m.signer, types.Preconfirmation{
+		ChainID:   m.genesis.ChainID,
+		TxHash:    txHash,
+		Height:    height,
+		MaxHeight: height + horizon,
+		Timestamp: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign preconfirmation: %w", err)
+	}
+	bz, err := preconf.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if err := m.savePreconfirmationRecord(ctx, txHash, &preconfirmationRecord{Preconfirmation: bz}); err != nil {
+		return nil, err
+	}
+	m.preconfs.pending = append(m.preconfs.pending, pendingPreconfirmation{TxHash: txHash, MaxHeight: preconf.MaxHeight})
+	if err := m.savePendingPreconfirmations(ctx); err != nil {
+		return nil, err
+	}
+	m.metrics.PreconfirmationsIssued.Add(1)
+	return preconf, nil
+}
+
+// resolvePreconfirmations records the inclusion of the transactions of the block at the given height whose
+// inclusion was preconfirmed, and reports the preconfirmations whose max height passed without inclusion.
+func (m *Manager) resolvePreconfirmations(ctx context.Context, height uint64, txs types.Txs) {
+	m.preconfs.mu.Lock()
+	defer m.preconfs.mu.Unlock()
+	if len(m.preconfs.pending) == 0 {
+		return
+	}
+
+	inBlock := make(map[string]struct{}, len(txs))
+	for _, tx := range txs {
+		inBlock[string(txindex.TxHash(tx))] = struct{}{}
+	}
+	var (
+		pending = m.preconfs.pending[:0]
+		changed bool
+	)
+	for _, p := range m.preconfs.pending {
+		_, included := inBlock[string(p.TxHash)]
+		if !included && height < p.MaxHeight {
+			pending = append(pending, p)
+			continue
+		}
+		changed = true
+		record, err := m.getPreconfirmationRecord(ctx, p.TxHash)
+		if err != nil || record == nil {
+			m.logger.Error("failed to load preconfirmation", "txHash", fmt.Sprintf("%X", p.TxHash), "error", err)
+			continue
+		}
+		if included {
+			record.IncludedHeight = height
+		} else {
+			record.Violated = true
+			m.metrics.PreconfirmationViolations.Add(1)
+			m.logger.Warn("preconfirmed transaction not included by its max height",
+				"txHash", fmt.Sprintf("%X", p.TxHash), "maxHeight", p.MaxHeight, "height", height)
+		}
+		if err := m.savePreconfirmationRecord(ctx, p.TxHash, record); err != nil {
+			m.logger.Error("failed to save preconfirmation", "error", err)
+		}
+	}
+	m.preconfs.pending = pending
+	if !changed {
+		return
+	}
+	if err := m.savePendingPreconfirmations(ctx); err != nil {
+		m.logger.Error("failed to save preconfirmations", "error", err)
+	}
+}
+
+// VerifyPreconfirmation checks that the preconfirmation was signed for this chain by the proposer of the block
+// following its height, and returns whether the promise it made was kept: whether its transaction was included
+// at or below its max height, or is still pending because the max height was not reached yet.
+func (m *Manager) VerifyPreconfirmation(ctx context.Context, preconf *types.Preconfirmation) (PreconfirmationResult, error) {
+	if err := preconf.ValidateBasic(); err != nil {
+		return PreconfirmationResult{}, err
+	}
+	if preconf.ChainID != m.genesis.ChainID {
+		return PreconfirmationResult{}, fmt.Errorf("%w: chain ID %q does not match %q", types.ErrInvalidPreconfirmation, preconf.ChainID, m.genesis.ChainID)
+	}
+	if !bytes.Equal(preconf.Signer.Address, m.ProposerAt(preconf.Height+1)) {
+		return PreconfirmationResult{}, fmt.Errorf("%w: not signed by the proposer", types.ErrInvalidPreconfirmation)
+	}
+
+	height, err := m.store.Height(ctx)
+	if err != nil {
+		return PreconfirmationResult{}, err
+	}
+	includedHeight, err := m.findIncludedHeight(ctx, preconf, height)
+	if err != nil {
+		return PreconfirmationResult{}, err
+	}
+	switch {
+	case includedHeight != 0 && includedHeight <= preconf.MaxHeight:
+		return PreconfirmationResult{Status: PreconfirmationIncluded, Height: includedHeight}, nil
+	case includedHeight != 0 || height >= preconf.MaxHeight:
+		return PreconfirmationResult{Status: PreconfirmationViolated, Height: includedHeight}, nil
+	default:
+		return PreconfirmationResult{Status: PreconfirmationPending}, nil
+	}
+}
+
+// findIncludedHeight returns the height of the block including the transaction of the preconfirmation after
+// the preconfirmation was issued, or 0 if it was not included up to the given height. The commitment log of
+// the aggregator and the transaction index are used if available, otherwise the blocks are scanned.
+func (m *Manager) findIncludedHeight(ctx context.Context, preconf *types.Preconfirmation, height uint64) (uint64, error) {
+	m.preconfs.mu.Lock()
+	record, err := m.getPreconfirmationRecord(ctx, preconf.TxHash)
+	m.preconfs.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	if record != nil && record.IncludedHeight != 0 {
+		return record.IncludedHeight, nil
+	}
+	if m.txIndexer != nil {
+		res, err := m.txIndexer.TxByHash(ctx, preconf.TxHash)
+		if err == nil && res.Height > preconf.Height {
+			return res.Height, nil
+		}
+		if err != nil && !errors.Is(err, txindex.ErrNotFound) {
+			return 0, err
+		}
+		if m.txIndexer.IndexedHeight() >= height {
+			return 0, nil
+		}
+	}
+
+	to := min(height, preconf.MaxHeight)
+	if to > preconf.Height+maxPreconfirmationScan {
+		return 0, fmt.Errorf("cannot scan %d blocks to verify the preconfirmation without a transaction index", to-preconf.Height)
+	}
+	for h := preconf.Height + 1; h <= to; h++ {
+		_, data, err := m.store.GetBlockData(ctx, h)
+		if err != nil {
+			return 0, fmt.Errorf("failed to load block %d: %w", h, err)
+		}
+		for _, tx := range data.Txs {
+			if bytes.Equal(txindex.TxHash(tx), preconf.TxHash) {
+				return h, nil
+			}
+		}
+	}
+	return 0, nil
+}
+
+// getPreconfirmationRecord returns the entry of the commitment log for the transaction, nil if there is none.
+// It must be called with the preconfirmations mutex held.
+func (m *Manager) getPreconfirmationRecord(ctx context.Context, txHash []byte) (*preconfirmationRecord, error) {
+	bz, err := m.store.GetMetadata(ctx, preconfirmationKey(txHash))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load preconfirmation: %w", err)
+	}
+	record := new(preconfirmationRecord)
+	if err := json.Unmarshal(bz, record); err != nil {
+		return nil, fmt.Errorf("failed to decode preconfirmation: %w", err)
+	}
+	return record, nil
+}
+
+// savePreconfirmationRecord persists an entry of the commitment log. It must be called with the preconfirmations
+// mutex held.
+func (m *Manager) savePreconfirmationRecord(ctx context.Context, txHash []byte, record *preconfirmationRecord) error {
+	bz, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if err := m.store.SetMetadata(ctx, preconfirmationKey(txHash), bz); err != nil {
+		return fmt.Errorf("failed to save preconfirmation: %w", err)
+	}
+	return nil
+}
+
+// savePendingPreconfirmations persists the preconfirmations waiting for inclusion. It must be called with the
+// preconfirmations mutex held.
+func (m *Manager) savePendingPreconfirmations(ctx context.Context) error {
+	bz, err := json.Marshal(m.preconfs.pending)
+	if err != nil {
+		return err
+	}
+	if err := m.store.SetMetadata(ctx, PreconfirmationsKey, bz); err != nil {
+		return fmt.Errorf("failed to save preconfirmations: %w", err)
+	}
+	m.metrics.PreconfirmationsPending.Set(float64(len(m.preconfs.pending)))
+	return nil
+}
@@ -0,0 +1,118 @@
+package block
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// saveBlockWithTxs saves a block of the given transactions at the next height of the store.
+func saveBlockWithTxs(t *testing.T, s store.Store, txs ...[]byte) uint64 {
+	t.Helper()
+	ctx := context.Background()
+	height, err := s.Height(ctx)
+	require.NoError(t, err)
+	header, data := types.GetRandomBlock(height+1, 0, "preconf-chain")
+	for _, tx := range txs {
+		data.Txs = append(data.Txs, tx)
+	}
+	require.NoError(t, s.SaveBlockData(ctx, header, data, &header.Signature))
+	require.NoError(t, s.SetHeight(ctx, height+1))
+	return height + 1
+}
+
+func TestPreconfirmations(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	cfg := config.DefaultConfig
+	cfg.Node.Aggregator = true
+	cfg.Node.PreconfirmationHorizon = 2
+	// newManager returns an aggregator proposing the blocks of the chain, signed with a new key
+	newManager := func() *Manager {
+		privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		require.NoError(t, err)
+		signer, err := noop.NewNoopSigner(privKey)
+		require.NoError(t, err)
+		proposer, err := signer.GetAddress()
+		require.NoError(t, err)
+		m, _, _, _ := newTestManager(t, withStore(s), withConfig(cfg), withGenesis(genesis.NewGenesis("preconf-chain", 1, time.Now(), proposer)))
+		m.signer = signer
+		return m
+	}
+	m := newManager()
+	saveBlockWithTxs(t, s)
+
+	kept, broken := []byte("kept"), []byte("broken")
+	keptPreconf, err := m.Preconfirm(ctx, kept)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), keptPreconf.Height)
+	assert.Equal(t, uint64(3), keptPreconf.MaxHeight)
+	brokenPreconf, err := m.Preconfirm(ctx, broken)
+	require.NoError(t, err)
+
+	// a transaction accepted again is answered with the same preconfirmation
+	again, err := m.Preconfirm(ctx, kept)
+	require.NoError(t, err)
+	assert.Equal(t, keptPreconf.Signature, again.Signature)
+
+	res, err := m.VerifyPreconfirmation(ctx, keptPreconf)
+	require.NoError(t, err)
+	assert.Equal(t, PreconfirmationResult{Status: PreconfirmationPending}, res)
+
+	// the pending preconfirmations are persisted
+	restarted := newManager()
+	require.NoError(t, restarted.loadPreconfirmations(ctx))
+	assert.Len(t, restarted.preconfs.pending, 2)
+
+	height := saveBlockWithTxs(t, s, []byte("other"))
+	m.resolvePreconfirmations(ctx, height, types.Txs{[]byte("other")})
+	assert.Len(t, m.preconfs.pending, 2)
+	// the broken preconfirmation is violated once its max height is reached
+	height = saveBlockWithTxs(t, s, kept)
+	m.resolvePreconfirmations(ctx, height, types.Txs{kept})
+	assert.Empty(t, m.preconfs.pending)
+	record, err := m.getPreconfirmationRecord(ctx, brokenPreconf.TxHash)
+	require.NoError(t, err)
+	assert.True(t, record.Violated)
+	saveBlockWithTxs(t, s, broken)
+
+	res, err = m.VerifyPreconfirmation(ctx, keptPreconf)
+	require.NoError(t, err)
+	assert.Equal(t, PreconfirmationResult{Status: PreconfirmationIncluded, Height: 3}, res)
+
+	// preconfirmations are verified by any node against the proposer of the chain
+	full := newManager()
+	full.genesis = m.genesis
+	res, err = full.VerifyPreconfirmation(ctx, keptPreconf)
+	require.NoError(t, err)
+	assert.Equal(t, PreconfirmationResult{Status: PreconfirmationIncluded, Height: 3}, res)
+	res, err = full.VerifyPreconfirmation(ctx, brokenPreconf)
+	require.NoError(t, err)
+	assert.Equal(t, PreconfirmationResult{Status: PreconfirmationViolated}, res)
+
+	t.Run("preconfirmations not signed by the proposer are invalid", func(t *testing.T) {
+		forged, err := types.GetPreconfirmation(full.signer, *keptPreconf)
+		require.NoError(t, err)
+		_, err = m.VerifyPreconfirmation(ctx, forged)
+		assert.ErrorIs(t, err, types.ErrInvalidPreconfirmation)
+	})
+
+	t.Run("preconfirmations are only issued by aggregators", func(t *testing.T) {
+		full.config.Node.Aggregator = false
+		_, err := full.Preconfirm(ctx, kept)
+		assert.ErrorIs(t, err, ErrPreconfirmationsDisabled)
+	})
+}
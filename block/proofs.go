@@ -0,0 +1,73 @@
+package block
+
+import (
+	"context"
+	"time"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/types"
+)
+
+// requestProof requests the validity proof of a produced block, if the execution client generates validity
+// proofs, from the state root before executing the block to the state root after it. With deferred execution,
+// the header commits to the state root before executing the block, unless it has an execution lag.
+func (m *Manager) requestProof(ctx context.Context, header *types.SignedHeader, prevStateRoot, stateRoot []byte) {
+	prover, ok := m.exec.(coreexecutor.Prover)
+	if !ok {
+		return
+	}
+	if err := prover.RequestProof(ctx, header.Height(), prevStateRoot, stateRoot); err != nil {
+		m.logger.Error("failed to request validity proof", "height", header.Height(), "error", err)
+	}
+}
+
+// attachProofCommitments attaches the commitments to the validity proofs of the blocks to their headers,
+// if the execution client generates validity proofs. Headers are submitted to the DA layer in order, so only
+// the headers preceding the first header still waiting for its proof are returned. Headers whose proof is not
+// ready by the proof deadline are returned without proof commitment.
+func (m *Manager) attachProofCommitments(ctx context.Context, headers []*types.SignedHeader) []*types.SignedHeader {
+	prover, ok := m.exec.(coreexecutor.Prover)
+	if !ok {
+		return headers
+	}
+	deadline := m.config.Node.ProofDeadline.Duration
+	for i, header := range headers {
+		commitment, ready, err := prover.ProofCommitment(ctx, header.Height())
+		if err == nil && ready {
+			header.ProofCommitment = commitment
+			continue
+		}
+		if err != nil {
+			// the proof was not requested before a restart, or proving the block failed
+			m.logger.Error("failed to get validity proof commitment, requesting proof again", "height", header.Height(), "error", err)
+			m.rerequestProof(ctx, prover, header)
+		}
+		if deadline == 0 || time.Since(header.Time()) < deadline {
+			return headers[:i]
+		}
+		m.logger.Error("validity proof not ready before deadline, submitting header without proof commitment",
+			"height", header.Height(), "deadline", deadline)
+		m.metrics.ProofsMissedDeadline.Add(1)
+	}
+	return headers
+}
+
+// rerequestProof requests the validity proof of a block already produced, whose state root is committed to
+// by the header of the next block.
+func (m *Manager) rerequestProof(ctx context.Context, prover coreexecutor.Prover, header *types.SignedHeader) {
+	stateRoot, err := m.stateRootAt(ctx, header.Height())
+	if err != nil {
+		m.logger.Error("failed to get state root of block", "height", header.Height(), "error", err)
+		return
+	}
+	prevStateRoot := header.AppHash
+	if header.ExecutionLag > 0 {
+		if prevStateRoot, err = m.stateRootAt(ctx, header.Height()-1); err != nil {
+			m.logger.Error("failed to get state root of block", "height", header.Height()-1, "error", err)
+			return
+		}
+	}
+	if err := prover.RequestProof(ctx, header.Height(), prevStateRoot, stateRoot); err != nil {
+		m.logger.Error("failed to request validity proof", "height", header.Height(), "error", err)
+	}
+}
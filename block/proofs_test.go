@@ -0,0 +1,76 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// proverExecutor is an execution client generating validity proofs.
+type proverExecutor struct {
+	*coreexecutor.DummyExecutor
+	*coreexecutor.DummyProver
+}
+
+// TestAttachProofCommitments verifies that headers are submitted in order once the validity proofs of their
+// blocks are ready, or without proof commitment after the proof deadline.
+func TestAttachProofCommitments(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	headers, _ := saveSignedBlocks(t, s, 3)
+
+	prover := coreexecutor.NewDummyProver(0)
+	m, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	m.exec = proverExecutor{coreexecutor.NewDummyExecutor(), prover}
+	m.config.Node.ProofDeadline.Duration = time.Hour
+	m.lastState = types.State{LastBlockHeight: 3, AppHash: []byte("app_hash_3")}
+
+	m.requestProof(ctx, headers[0], headers[0].AppHash, headers[1].AppHash)
+	submitted := m.attachProofCommitments(ctx, headers)
+	require.Len(t, submitted, 1)
+	expected, ready, err := prover.ProofCommitment(ctx, 1)
+	require.NoError(t, err)
+	require.True(t, ready)
+	assert.Equal(t, expected, submitted[0].ProofCommitment)
+
+	// the proofs not requested before are requested when the headers are submitted
+	require.Len(t, m.attachProofCommitments(ctx, headers), 2)
+	submitted = m.attachProofCommitments(ctx, headers)
+	require.Len(t, submitted, 3)
+	for _, header := range submitted {
+		assert.NotEmpty(t, header.ProofCommitment)
+	}
+
+	// the proof commitment is submitted along with the header
+	bz, err := submitted[2].MarshalBinary()
+	require.NoError(t, err)
+	var decoded types.SignedHeader
+	require.NoError(t, decoded.UnmarshalBinary(bz))
+	assert.Equal(t, submitted[2].ProofCommitment, decoded.ProofCommitment)
+	assert.Equal(t, submitted[2].Hash(), decoded.Hash())
+
+	t.Run("deadline", func(t *testing.T) {
+		headers, _ := saveSignedBlocks(t, s, 2)
+		m.exec = proverExecutor{coreexecutor.NewDummyExecutor(), coreexecutor.NewDummyProver(time.Hour)}
+		m.requestProof(ctx, headers[0], headers[0].AppHash, headers[1].AppHash)
+		m.requestProof(ctx, headers[1], headers[1].AppHash, []byte("app_hash_2"))
+		require.Empty(t, m.attachProofCommitments(ctx, headers))
+
+		m.config.Node.ProofDeadline.Duration = time.Nanosecond
+		submitted := m.attachProofCommitments(ctx, headers)
+		require.Len(t, submitted, 2)
+		for _, header := range submitted {
+			assert.Empty(t, header.ProofCommitment)
+		}
+	})
+}
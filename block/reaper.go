@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"time"
 
 	"cosmossdk.io/log"
@@ -13,6 +15,7 @@ import (
 
 	coreexecutor "github.com/rollkit/rollkit/core/execution"
 	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/mempool"
 )
 
 const DefaultInterval = 1 * time.Second
@@ -28,6 +31,7 @@ type Reaper struct {
 	ctx       context.Context
 	seenStore ds.Batching
 	manager   *Manager
+	mempool   *mempool.Mempool
 }
 
 // NewReaper creates a new Reaper instance with persistent seenTx storage.
@@ -51,12 +55,23 @@ func (r *Reaper) SetManager(manager *Manager) {
 	r.manager = manager
 }
 
+// SetMempool sets the mempool whose transactions are submitted to the sequencer along with the transactions
+// of the executor. Transactions admitted to the mempool are submitted without waiting for the next interval.
+func (r *Reaper) SetMempool(mp *mempool.Mempool) {
+	r.mempool = mp
+}
+
 // Start begins the reaping process at the specified interval.
 func (r *Reaper) Start(ctx context.Context) {
 	r.ctx = ctx
 	ticker := time.NewTicker(r.interval)
 	defer ticker.Stop()
 
+	var txsAvailable <-chan struct{}
+	if r.mempool != nil {
+		txsAvailable = r.mempool.TxsAvailable()
+	}
+
 	r.logger.Info("Reaper started", "interval", r.interval)
 
 	for {
@@ -66,17 +81,24 @@ func (r *Reaper) Start(ctx context.Context) {
 			return
 		case <-ticker.C:
 			r.SubmitTxs()
+		case <-txsAvailable:
+			r.SubmitTxs()
 		}
 	}
 }
 
-// SubmitTxs retrieves transactions from the executor and submits them to the sequencer.
+// SubmitTxs retrieves transactions from the executor and the mempool, ordered by priority, and submits them
+// to the sequencer. Transactions of the executor exceeding the size and prefix limits of the mempool are dropped,
+// without being marked as seen, so that they are checked again if the limits are raised.
 func (r *Reaper) SubmitTxs() {
 	txs, err := r.exec.GetTxs(r.ctx)
 	if err != nil {
 		r.logger.Error("Reaper failed to get txs from executor", "error", err)
 		return
 	}
+	if r.mempool != nil {
+		txs = append(r.mempool.Txs(), txs...)
+	}
 
 	var newTxs [][]byte
 	for _, tx := range txs {
@@ -87,9 +109,21 @@ func (r *Reaper) SubmitTxs() {
 			r.logger.Error("Failed to check seenStore", "error", err)
 			continue
 		}
-		if !has {
-			newTxs = append(newTxs, tx)
+		if has {
+			continue
+		}
+		// transactions of the executor are checked against the limits of the mempool too, and never submitted
+		// if they exceed them
+		if r.mempool != nil {
+			if err := r.mempool.CheckTxFormat(tx); err != nil {
+				r.logger.Warn("Reaper dropping tx exceeding the mempool limits", "txHash", txHash, "error", err)
+				if r.manager != nil {
+					r.manager.metrics.ReaperDroppedTxs.Add(1)
+				}
+				continue
+			}
 		}
+		newTxs = append(newTxs, tx)
 	}
 
 	if len(newTxs) == 0 {
@@ -125,6 +159,40 @@ func (r *Reaper) SubmitTxs() {
 	r.logger.Debug("Reaper successfully submitted txs")
 }
 
+// SubmitTx submits a single transaction received over RPC to the sequencer, and returns its hash.
+// The transaction is marked as seen, so that it is not submitted again if the executor returns it too.
+// Transactions that were already submitted are not submitted again.
+func (r *Reaper) SubmitTx(ctx context.Context, tx []byte) ([]byte, error) {
+	if len(tx) == 0 {
+		return nil, errors.New("empty transaction")
+	}
+	hash := sha256.Sum256(tx)
+	key := ds.NewKey(hex.EncodeToString(hash[:]))
+	has, err := r.seenStore.Has(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check seenStore: %w", err)
+	}
+	if has {
+		return hash[:], nil
+	}
+
+	_, err = r.sequencer.SubmitRollupBatchTxs(ctx, coresequencer.SubmitRollupBatchTxsRequest{
+		RollupId: sequencing.RollupId(r.chainID),
+		Batch:    &coresequencer.Batch{Transactions: [][]byte{tx}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit tx to sequencer: %w", err)
+	}
+	if err := r.seenStore.Put(ctx, key, []byte{1}); err != nil {
+		r.logger.Error("Failed to persist seen tx", "txHash", key.String(), "error", err)
+	}
+
+	if r.manager != nil {
+		r.manager.NotifyNewTransactions()
+	}
+	return hash[:], nil
+}
+
 func hashTx(tx []byte) string {
 	hash := sha256.Sum256(tx)
 	return hex.EncodeToString(hash[:])
@@ -13,6 +13,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/mempool"
 	testmocks "github.com/rollkit/rollkit/test/mocks"
 )
 
@@ -77,6 +79,68 @@ func TestReaper_SubmitTxs_NoTxs(t *testing.T) {
 	mockSeq.AssertNotCalled(t, "SubmitRollupBatchTxs", mock.Anything, mock.Anything)
 }
 
+// TestReaper_SubmitTxs_Mempool verifies that the Reaper submits the transactions of the mempool before those of
+// the executor, and does not submit them again while they wait for inclusion.
+func TestReaper_SubmitTxs_Mempool(t *testing.T) {
+	t.Parallel()
+
+	mockExec := testmocks.NewExecutor(t)
+	mockSeq := testmocks.NewSequencer(t)
+	store := dsync.MutexWrap(ds.NewMapDatastore())
+	chainID := "test-chain"
+
+	reaper := NewReaper(t.Context(), mockExec, mockSeq, chainID, time.Second, log.NewNopLogger(), store)
+	mp, err := mempool.New(config.MempoolConfig{Size: 10}, mockExec, log.NewNopLogger())
+	require.NoError(t, err)
+	reaper.SetMempool(mp)
+	_, err = mp.Add(t.Context(), []byte("mempool tx"))
+	require.NoError(t, err)
+
+	mockExec.On("GetTxs", mock.Anything).Return([][]byte{[]byte("executor tx")}, nil).Twice()
+	submitReqMatcher := mock.MatchedBy(func(req coresequencer.SubmitRollupBatchTxsRequest) bool {
+		return len(req.Batch.Transactions) == 2 &&
+			string(req.Batch.Transactions[0]) == "mempool tx" && string(req.Batch.Transactions[1]) == "executor tx"
+	})
+	mockSeq.On("SubmitRollupBatchTxs", mock.Anything, submitReqMatcher).Return(&coresequencer.SubmitRollupBatchTxsResponse{}, nil).Once()
+
+	reaper.SubmitTxs()
+	reaper.SubmitTxs()
+
+	mockExec.AssertExpectations(t)
+	mockSeq.AssertExpectations(t)
+}
+
+// TestReaper_SubmitTxs_MempoolLimits verifies that the Reaper drops the transactions of the executor exceeding the
+// limits of the mempool, without marking them as seen.
+func TestReaper_SubmitTxs_MempoolLimits(t *testing.T) {
+	t.Parallel()
+
+	mockExec := testmocks.NewExecutor(t)
+	mockSeq := testmocks.NewSequencer(t)
+	store := dsync.MutexWrap(ds.NewMapDatastore())
+
+	reaper := NewReaper(t.Context(), mockExec, mockSeq, "test-chain", time.Second, log.NewNopLogger(), store)
+	mp, err := mempool.New(config.MempoolConfig{Size: 10, MaxTxBytes: 8}, mockExec, log.NewNopLogger())
+	require.NoError(t, err)
+	reaper.SetMempool(mp)
+
+	mockExec.On("GetTxs", mock.Anything).Return([][]byte{[]byte("small"), []byte("too large tx")}, nil).Once()
+	submitReqMatcher := mock.MatchedBy(func(req coresequencer.SubmitRollupBatchTxsRequest) bool {
+		return len(req.Batch.Transactions) == 1 && string(req.Batch.Transactions[0]) == "small"
+	})
+	mockSeq.On("SubmitRollupBatchTxs", mock.Anything, submitReqMatcher).Return(&coresequencer.SubmitRollupBatchTxsResponse{}, nil).Once()
+
+	reaper.SubmitTxs()
+
+	// dropped transactions are not marked as seen
+	has, err := store.Has(t.Context(), ds.NewKey(hashTx([]byte("too large tx"))))
+	require.NoError(t, err)
+	require.False(t, has)
+
+	mockExec.AssertExpectations(t)
+	mockSeq.AssertExpectations(t)
+}
+
 // TestReaper_TxPersistence_AcrossRestarts verifies that the Reaper persists seen transactions across restarts.
 func TestReaper_TxPersistence_AcrossRestarts(t *testing.T) {
 	t.Parallel()
@@ -130,3 +194,37 @@ func TestReaper_TxPersistence_AcrossRestarts(t *testing.T) {
 	mockExec2.AssertExpectations(t)
 	mockSeq2.AssertNotCalled(t, "SubmitRollupBatchTxs", mock.Anything, mock.Anything)
 }
+
+// TestReaper_SubmitTx verifies that transactions submitted over RPC are submitted to the sequencer once.
+func TestReaper_SubmitTx(t *testing.T) {
+	t.Parallel()
+
+	mockExec := testmocks.NewExecutor(t)
+	mockSeq := testmocks.NewSequencer(t)
+	store := dsync.MutexWrap(ds.NewMapDatastore())
+	chainID := "test-chain"
+
+	reaper := NewReaper(t.Context(), mockExec, mockSeq, chainID, time.Second, log.NewNopLogger(), store)
+
+	tx := []byte("tx1")
+	submitReqMatcher := mock.MatchedBy(func(req coresequencer.SubmitRollupBatchTxsRequest) bool {
+		return string(req.RollupId) == chainID && len(req.Batch.Transactions) == 1 && string(req.Batch.Transactions[0]) == string(tx)
+	})
+	mockSeq.On("SubmitRollupBatchTxs", mock.Anything, submitReqMatcher).Return(&coresequencer.SubmitRollupBatchTxsResponse{}, nil).Once()
+
+	hash, err := reaper.SubmitTx(t.Context(), tx)
+	require.NoError(t, err)
+	expected := sha256.Sum256(tx)
+	require.Equal(t, expected[:], hash)
+
+	// submitting the transaction again, or reaping it from the executor, does not resubmit it
+	_, err = reaper.SubmitTx(t.Context(), tx)
+	require.NoError(t, err)
+	mockExec.On("GetTxs", mock.Anything).Return([][]byte{tx}, nil).Once()
+	reaper.SubmitTxs()
+
+	_, err = reaper.SubmitTx(t.Context(), nil)
+	require.Error(t, err)
+
+	mockSeq.AssertExpectations(t)
+}
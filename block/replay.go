@@ -0,0 +1,290 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/log"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// ErrBlockUnavailable is returned by a ReplaySource when the block at the requested height is not available
+// yet, i.e. the height is above the head of the chain.
+var ErrBlockUnavailable = errors.New("block not available")
+
+// ReplaySource provides the blocks re-executed by Replay, in increasing height order.
+type ReplaySource interface {
+	// GetBlockData returns the header and data of the block at the given height, or ErrBlockUnavailable if the
+	// height is above the head of the chain.
+	GetBlockData(ctx context.Context, height uint64) (*types.SignedHeader, *types.Data, error)
+}
+
+// ReplayStateSource is implemented by the replay sources knowing the state root after the last block, which is
+// not committed to by any header yet.
+type ReplayStateSource interface {
+	// StateAppHash returns the state root after the block at the given height, and false if it is not known.
+	StateAppHash(ctx context.Context, height uint64) (types.Hash, bool, error)
+}
+
+// ReplayDivergence describes the first block whose re-execution led to a state root different from the one
+// committed to by the chain.
+type ReplayDivergence struct {
+	// Height is the height of the diverging block, or the initial height minus one when InitChain diverged.
+	Height uint64
+	// CommittedAppHash is the state root committed to by the chain after the block.
+	CommittedAppHash types.Hash
+	// AppHash is the state root computed by re-executing the block.
+	AppHash types.Hash
+}
+
+// ReplayResult is the outcome of a replay.
+type ReplayResult struct {
+	// LastHeight is the height of the last block re-executed, or the initial height minus one if none was.
+	LastHeight uint64
+	// AppHash is the state root after the last block re-executed.
+	AppHash types.Hash
+	// Divergence is the first divergence found, or nil if all state roots match.
+	Divergence *ReplayDivergence
+}
+
+// Replay re-executes the blocks of the chain from the genesis against a fresh executor, and verifies the state
+// root after every block against the state root committed to by the header of the next block. It stops at the
+// first divergence, after the block at toHeight, or at the head of the chain if toHeight is 0.
+//
+// Headers commit to the state root of the previous block (deferred execution), so the state root after the last
+// block re-executed is only verified if the source implements ReplayStateSource.
+func Replay(ctx context.Context, source ReplaySource, exec coreexecutor.Executor, genesis genesis.Genesis, toHeight uint64, logger log.Logger) (ReplayResult, error) {
+	stateRoot, _, err := exec.InitChain(ctx, genesis.GenesisDAStartTime, genesis.InitialHeight, genesis.ChainID)
+	if err != nil {
+		return ReplayResult{}, fmt.Errorf("failed to initialize chain: %w", err)
+	}
+	state := types.State{
+		ChainID:         genesis.ChainID,
+		InitialHeight:   genesis.InitialHeight,
+		LastBlockHeight: genesis.InitialHeight - 1,
+		LastBlockTime:   genesis.GenesisDAStartTime,
+		AppHash:         stateRoot,
+	}
+	result := ReplayResult{LastHeight: state.LastBlockHeight, AppHash: state.AppHash}
+	if len(genesis.AppHash) > 0 && !bytes.Equal(stateRoot, genesis.AppHash) {
+		result.Divergence = &ReplayDivergence{Height: state.LastBlockHeight, CommittedAppHash: genesis.AppHash, AppHash: stateRoot}
+		return result, nil
+	}
+
+	for height := genesis.InitialHeight; toHeight == 0 || height <= toHeight; height++ {
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		default:
+		}
+		header, data, err := source.GetBlockData(ctx, height)
+		if errors.Is(err, ErrBlockUnavailable) && toHeight == 0 {
+			break
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to get block %d: %w", height, err)
+		}
+		// headers with an execution lag commit to the state root after an earlier block, already compared
+		if header.ExecutionLag == 0 && !bytes.Equal(header.AppHash, state.AppHash) {
+			result.Divergence = &ReplayDivergence{Height: height - 1, CommittedAppHash: header.AppHash, AppHash: state.AppHash}
+			return result, nil
+		}
+		if err := validateReplayedBlock(state, header, data); err != nil {
+			return result, fmt.Errorf("invalid block %d: %w", height, err)
+		}
+		rawTxs := make([][]byte, len(data.Txs))
+		for i := range data.Txs {
+			rawTxs[i] = data.Txs[i]
+		}
+		if err := setDABlock(ctx, exec, header); err != nil {
+			return result, fmt.Errorf("failed to execute block %d: %w", height, err)
+		}
+		stateRoot, _, err := executeTxs(ctx, exec, rawTxs, height, header.Time(), state.AppHash)
+		if err != nil {
+			return result, fmt.Errorf("failed to execute block %d: %w", height, err)
+		}
+		if state, err = state.NextState(header, stateRoot); err != nil {
+			return result, err
+		}
+		result.LastHeight, result.AppHash = height, stateRoot
+		logger.Debug("replayed block", "height", height, "txs", len(rawTxs), "appHash", fmt.Sprintf("%X", stateRoot))
+	}
+
+	if stateSource, ok := source.(ReplayStateSource); ok && result.LastHeight >= genesis.InitialHeight {
+		committed, found, err := stateSource.StateAppHash(ctx, result.LastHeight)
+		if err != nil {
+			return result, err
+		}
+		if found && !bytes.Equal(committed, result.AppHash) {
+			result.Divergence = &ReplayDivergence{Height: result.LastHeight, CommittedAppHash: committed, AppHash: result.AppHash}
+		}
+	}
+	return result, nil
+}
+
+// validateReplayedBlock validates a block against the state it is applied to, like the sync loop does.
+func validateReplayedBlock(state types.State, header *types.SignedHeader, data *types.Data) error {
+	if err := header.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid header: %w", err)
+	}
+	if err := types.Validate(header, data); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	if header.ChainID() != state.ChainID {
+		return fmt.Errorf("chain ID mismatch: expected %s, got %s", state.ChainID, header.ChainID())
+	}
+	if header.Height() != state.LastBlockHeight+1 {
+		return fmt.Errorf("invalid height: expected %d, got %d", state.LastBlockHeight+1, header.Height())
+	}
+	return nil
+}
+
+// storeReplaySource replays the blocks persisted in the store of a node.
+type storeReplaySource struct {
+	store store.Store
+}
+
+// NewStoreReplaySource returns a ReplaySource reading the blocks from the store of a node, e.g. to audit the
+// blocks synced by the node. The state root after the last block is verified against the state in store.
+func NewStoreReplaySource(s store.Store) ReplaySource {
+	return &storeReplaySource{store: s}
+}
+
+// GetBlockData implements ReplaySource
+func (s *storeReplaySource) GetBlockData(ctx context.Context, height uint64) (*types.SignedHeader, *types.Data, error) {
+	storeHeight, err := s.store.Height(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if height > storeHeight {
+		return nil, nil, ErrBlockUnavailable
+	}
+	// blocks below the store height are missing if they were pruned
+	return s.store.GetBlockData(ctx, height)
+}
+
+// StateAppHash implements ReplayStateSource
+func (s *storeReplaySource) StateAppHash(ctx context.Context, height uint64) (types.Hash, bool, error) {
+	state, err := s.store.GetState(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load state: %w", err)
+	}
+	return state.AppHash, state.LastBlockHeight == height, nil
+}
+
+// daReplaySource replays the blocks retrieved from the DA layer, scanning the DA heights forward.
+type daReplaySource struct {
+	// scanner decodes and verifies the blobs like the retrieve loop, and tracks the key rotations
+	scanner  *Manager
+	daHeight uint64
+	caughtUp bool
+	// replayed is the height of the last block returned, headers at or below it are ignored
+	replayed uint64
+	headers  map[uint64]*types.SignedHeader
+	data     map[string]*types.Data
+}
+
+// NewDAReplaySource returns a ReplaySource retrieving the blocks from the DA layer, starting at daStartHeight.
+// dataDA is the DA client bound to the namespace of block data, or nil if block data is submitted to the
+// namespace of headers.
+func NewDAReplaySource(da, dataDA coreda.DA, genesis genesis.Genesis, daStartHeight uint64, logger log.Logger) (ReplaySource, error) {
+	kv, err := store.NewDefaultInMemoryKVStore()
+	if err != nil {
+		return nil, err
+	}
+	return &daReplaySource{
+		scanner: &Manager{
+			store:   store.New(kv),
+			da:      da,
+			dataDA:  dataDA,
+			genesis: genesis,
+			logger:  logger,
+			metrics: NopMetrics(),
+		},
+		daHeight: daStartHeight,
+		headers:  make(map[uint64]*types.SignedHeader),
+		data:     make(map[string]*types.Data),
+	}, nil
+}
+
+// GetBlockData implements ReplaySource
+func (s *daReplaySource) GetBlockData(ctx context.Context, height uint64) (*types.SignedHeader, *types.Data, error) {
+	for {
+		if header, data, ok := s.block(height); ok {
+			if err := s.scanner.checkProposer(ctx, header); err != nil {
+				return nil, nil, err
+			}
+			delete(s.headers, height)
+			s.replayed = height
+			return header, data, nil
+		}
+		if s.caughtUp {
+			return nil, nil, ErrBlockUnavailable
+		}
+		if err := s.scanNextDAHeight(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+}
+
+// block returns the header and data of the block at the given height, if both were retrieved.
+func (s *daReplaySource) block(height uint64) (*types.SignedHeader, *types.Data, bool) {
+	header, ok := s.headers[height]
+	if !ok {
+		return nil, nil, false
+	}
+	if bytes.Equal(header.DataHash, dataHashForEmptyTxs) {
+		return header, &types.Data{Metadata: &types.Metadata{
+			ChainID: header.ChainID(),
+			Height:  header.Height(),
+			Time:    header.BaseHeader.Time,
+		}}, true
+	}
+	data, ok := s.data[header.DataHash.String()]
+	if !ok {
+		return nil, nil, false
+	}
+	delete(s.data, header.DataHash.String())
+	return header, data, true
+}
+
+// scanNextDAHeight retrieves the blobs of the next DA height, and buffers the headers and data found.
+func (s *daReplaySource) scanNextDAHeight(ctx context.Context) error {
+	res, err := s.scanner.fetchBlobs(ctx, s.daHeight)
+	if err != nil {
+		if s.scanner.areAllErrorsHeightFromFuture(err) {
+			s.caughtUp = true
+			return nil
+		}
+		return fmt.Errorf("failed to retrieve DA height %d: %w", s.daHeight, err)
+	}
+	if res.Code != coreda.StatusNotFound {
+		blobs := s.scanner.decodeBlobs(res.Data, res.IDs, s.daHeight)
+		for i := 0; i < len(blobs); i++ {
+			switch blob := blobs[i]; {
+			case blob.rotation != nil:
+				if err := s.scanner.applyKeyRotation(ctx, blob.rotation); err != nil {
+					s.scanner.logger.Debug("ignoring key rotation", "daHeight", s.daHeight, "height", blob.rotation.Height, "error", err)
+				}
+			case blob.header != nil:
+				height := blob.header.Height()
+				if _, seen := s.headers[height]; !seen && height > s.replayed && s.scanner.isExpectedProposer(blob.header) {
+					s.headers[height] = blob.header
+				}
+			case blob.data != nil:
+				s.data[blob.data.DACommitment().String()] = blob.data
+			case blob.part != nil:
+				blobs = append(blobs, s.scanner.assembleBlobPart(blob, s.daHeight)...)
+			}
+		}
+	}
+	s.daHeight++
+	return nil
+}
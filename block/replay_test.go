@@ -0,0 +1,188 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// replayTestChain returns the genesis and the blocks up to the given height executed by a dummy executor, with
+// the header of each block committing to the state root after the previous block, and the final state root.
+func replayTestChain(t *testing.T, height uint64) (genesis.Genesis, []*types.SignedHeader, []*types.Data, []byte) {
+	t.Helper()
+	ctx := context.Background()
+	gen, privKey, _ := types.GetGenesisWithPrivkey(rotationTestChainID)
+	exec := coreexecutor.NewDummyExecutor()
+	stateRoot, _, err := exec.InitChain(ctx, gen.GenesisDAStartTime, gen.InitialHeight, gen.ChainID)
+	require.NoError(t, err)
+	headers := make([]*types.SignedHeader, height+1)
+	data := make([]*types.Data, height+1)
+	for h := uint64(1); h <= height; h++ {
+		headers[h], data[h], _ = types.GenerateRandomBlockCustomWithAppHash(&types.BlockConfig{Height: h, NTxs: 2, PrivKey: privKey}, gen.ChainID, stateRoot)
+		txs := make([][]byte, len(data[h].Txs))
+		for i, tx := range data[h].Txs {
+			txs[i] = tx
+		}
+		stateRoot, _, err = exec.ExecuteTxs(ctx, txs, h, headers[h].Time(), stateRoot)
+		require.NoError(t, err)
+	}
+	return gen, headers, data, stateRoot
+}
+
+// replayTestStore saves the blocks and the final state in a store.
+func replayTestStore(t *testing.T, gen genesis.Genesis, headers []*types.SignedHeader, data []*types.Data, stateRoot []byte) store.Store {
+	t.Helper()
+	ctx := context.Background()
+	s := newRotationTestStore(t)
+	height := uint64(len(headers) - 1)
+	for h := uint64(1); h <= height; h++ {
+		require.NoError(t, s.SaveBlockData(ctx, headers[h], data[h], &headers[h].Signature))
+	}
+	require.NoError(t, s.SetHeight(ctx, height))
+	require.NoError(t, s.UpdateState(ctx, types.State{
+		ChainID:         gen.ChainID,
+		InitialHeight:   gen.InitialHeight,
+		LastBlockHeight: height,
+		LastBlockTime:   headers[height].Time(),
+		AppHash:         stateRoot,
+	}))
+	return s
+}
+
+// divergentExecutor alters the state root after the block at the given height.
+type divergentExecutor struct {
+	*coreexecutor.DummyExecutor
+	height uint64
+}
+
+func (e *divergentExecutor) ExecuteTxs(ctx context.Context, txs [][]byte, blockHeight uint64, timestamp time.Time, prevStateRoot []byte) ([]byte, uint64, error) {
+	if blockHeight == e.height {
+		txs = append(txs, []byte("non-deterministic"))
+	}
+	return e.DummyExecutor.ExecuteTxs(ctx, txs, blockHeight, timestamp, prevStateRoot)
+}
+
+func TestReplayFromStore(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	gen, headers, data, stateRoot := replayTestChain(t, 5)
+	s := replayTestStore(t, gen, headers, data, stateRoot)
+
+	t.Run("matching state roots", func(t *testing.T) {
+		result, err := Replay(ctx, NewStoreReplaySource(s), coreexecutor.NewDummyExecutor(), gen, 0, logger)
+		require.NoError(t, err)
+		assert.Nil(t, result.Divergence)
+		assert.Equal(t, uint64(5), result.LastHeight)
+		assert.Equal(t, types.Hash(stateRoot), result.AppHash)
+	})
+
+	t.Run("up to height", func(t *testing.T) {
+		result, err := Replay(ctx, NewStoreReplaySource(s), coreexecutor.NewDummyExecutor(), gen, 3, logger)
+		require.NoError(t, err)
+		assert.Nil(t, result.Divergence)
+		assert.Equal(t, uint64(3), result.LastHeight)
+
+		_, err = Replay(ctx, NewStoreReplaySource(s), coreexecutor.NewDummyExecutor(), gen, 6, logger)
+		assert.ErrorIs(t, err, ErrBlockUnavailable)
+	})
+
+	t.Run("divergent block", func(t *testing.T) {
+		exec := &divergentExecutor{DummyExecutor: coreexecutor.NewDummyExecutor(), height: 3}
+		result, err := Replay(ctx, NewStoreReplaySource(s), exec, gen, 0, logger)
+		require.NoError(t, err)
+		require.NotNil(t, result.Divergence)
+		assert.Equal(t, uint64(3), result.Divergence.Height)
+		assert.Equal(t, headers[4].AppHash, result.Divergence.CommittedAppHash)
+		assert.NotEqual(t, headers[4].AppHash, result.Divergence.AppHash)
+		assert.Equal(t, uint64(3), result.LastHeight)
+	})
+
+	t.Run("divergent last block", func(t *testing.T) {
+		exec := &divergentExecutor{DummyExecutor: coreexecutor.NewDummyExecutor(), height: 5}
+		result, err := Replay(ctx, NewStoreReplaySource(s), exec, gen, 0, logger)
+		require.NoError(t, err)
+		require.NotNil(t, result.Divergence)
+		assert.Equal(t, uint64(5), result.Divergence.Height)
+		assert.Equal(t, types.Hash(stateRoot), result.Divergence.CommittedAppHash)
+	})
+
+	t.Run("divergent genesis", func(t *testing.T) {
+		gen := gen
+		gen.AppHash = []byte("other genesis state")
+		result, err := Replay(ctx, NewStoreReplaySource(s), coreexecutor.NewDummyExecutor(), gen, 0, logger)
+		require.NoError(t, err)
+		require.NotNil(t, result.Divergence)
+		assert.Equal(t, uint64(0), result.Divergence.Height)
+	})
+}
+
+// headDA is a dummy DA layer returning the height from future error above its last height.
+type headDA struct {
+	*coreda.DummyDA
+	head uint64
+}
+
+func (d *headDA) GetIDs(ctx context.Context, height uint64, namespace []byte) (*coreda.GetIDsResult, error) {
+	if height > d.head {
+		return nil, ErrHeightFromFutureStr
+	}
+	return d.DummyDA.GetIDs(ctx, height, namespace)
+}
+
+func TestReplayFromDA(t *testing.T) {
+	ctx := context.Background()
+	gen, headers, data, _ := replayTestChain(t, 4)
+	da := &headDA{DummyDA: coreda.NewDummyDA(1<<20, 0, 0)}
+
+	submit := func(blobs ...[]byte) {
+		_, err := da.Submit(ctx, blobs, 0, nil)
+		require.NoError(t, err)
+	}
+	batch := func(h uint64) []byte {
+		txs := make([][]byte, len(data[h].Txs))
+		for i, tx := range data[h].Txs {
+			txs[i] = tx
+		}
+		bz, err := proto.Marshal(&pb.Batch{Txs: txs})
+		require.NoError(t, err)
+		return bz
+	}
+	header := func(h uint64) []byte {
+		bz, err := headers[h].MarshalBinary()
+		require.NoError(t, err)
+		return bz
+	}
+	// blocks are spread over DA heights, with data submitted before or after their header and resubmissions
+	submit(header(1), batch(1), header(2))
+	submit(batch(3), batch(2))
+	submit(header(3), header(1))
+	submit(header(4), batch(4))
+	da.head = 3
+
+	source, err := NewDAReplaySource(da, nil, gen, 0, log.NewNopLogger())
+	require.NoError(t, err)
+	result, err := Replay(ctx, source, coreexecutor.NewDummyExecutor(), gen, 0, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Nil(t, result.Divergence)
+	assert.Equal(t, uint64(4), result.LastHeight)
+
+	// the head of the DA layer ends the replay
+	da.head = 2
+	source, err = NewDAReplaySource(da, nil, gen, 0, log.NewNopLogger())
+	require.NoError(t, err)
+	result, err = Replay(ctx, source, coreexecutor.NewDummyExecutor(), gen, 0, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), result.LastHeight)
+}
@@ -21,9 +21,8 @@ const (
 
 // RetrieveLoop is responsible for interacting with DA layer.
 func (m *Manager) RetrieveLoop(ctx context.Context) {
-	// blobsFoundCh is used to track when we successfully found a header so
-	// that we can continue to try and find headers that are in the next DA height.
-	// This enables syncing faster than the DA block time.
+	// blobsFoundCh is used to retry right away when the retrieval was interrupted by the backfill worker, so
+	// that we can continue to retrieve the next DA heights.
 	blobsFoundCh := make(chan struct{}, 1)
 	defer close(blobsFoundCh)
 	for {
@@ -33,21 +32,75 @@ func (m *Manager) RetrieveLoop(ctx context.Context) {
 		case <-m.retrieveCh:
 		case <-blobsFoundCh:
 		}
-		daHeight := m.daHeight.Load()
-		err := m.processNextDAHeaderAndData(ctx)
+		err := m.retrieveDAHeights(ctx)
 		if err != nil && ctx.Err() == nil {
 			// if the requested da height is not yet available, wait silently, otherwise log the error and wait
 			if !m.areAllErrorsHeightFromFuture(err) {
-				m.logger.Error("failed to retrieve data from DALC", "daHeight", daHeight, "errors", err.Error())
+				m.logger.Error("failed to retrieve data from DALC", "daHeight", m.daHeight.Load(), "errors", err.Error())
+			} else if !m.daRetrievalCaughtUp.Swap(true) {
+				m.checkSyncCaughtUp(ctx)
 			}
 			continue
 		}
-		// Signal the blobsFoundCh to try and retrieve the next set of blobs
 		select {
 		case blobsFoundCh <- struct{}{}:
 		default:
 		}
-		m.daHeight.Store(daHeight + 1)
+	}
+}
+
+// retrievedDAHeight holds the blobs retrieved and decoded from a DA height.
+type retrievedDAHeight struct {
+	daHeight uint64
+	blobs    []daBlob
+	err      error
+}
+
+// retrieveDAHeights retrieves the DA heights from the current DA height on, and processes them in order until a
+// DA height cannot be retrieved, e.g. because it is not yet available on the DA layer. Up to DA.RetrieveWorkers
+// DA heights are fetched and decoded concurrently, and buffered until the DA heights before them are processed.
+// It returns nil if the backfill worker advanced the DA height meanwhile.
+func (m *Manager) retrieveDAHeights(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	// in-flight requests are abandoned once a DA height fails
+	defer cancel()
+
+	workers := uint64(max(m.config.DA.RetrieveWorkers, 1))
+	// the channel holds a result for every in-flight request, so that workers never block
+	results := make(chan retrievedDAHeight, workers)
+	// pending is the reassembly buffer of the DA heights retrieved before the DA heights preceding them
+	pending := make(map[uint64]retrievedDAHeight, workers)
+	next := m.daHeight.Load()
+	dispatched := next
+	for {
+		for ; dispatched < next+workers; dispatched++ {
+			go func(daHeight uint64) {
+				blobs, err := m.fetchDAHeight(ctx, daHeight)
+				results <- retrievedDAHeight{daHeight: daHeight, blobs: blobs, err: err}
+			}(dispatched)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case res := <-results:
+			pending[res.daHeight] = res
+		}
+		for res, ok := pending[next]; ok; res, ok = pending[next] {
+			delete(pending, next)
+			if res.err != nil {
+				return res.err
+			}
+			// the backfill worker advances the DA height as well, see BackfillLoop
+			m.retrieveMtx.Lock()
+			if m.daHeight.Load() != next {
+				m.retrieveMtx.Unlock()
+				return nil
+			}
+			m.applyBlobs(ctx, res.blobs, next)
+			next++
+			m.daHeight.Store(next)
+			m.retrieveMtx.Unlock()
+		}
 	}
 }
 
@@ -61,33 +114,32 @@ func (m *Manager) processNextDAHeaderAndData(ctx context.Context) error {
 	}
 
 	daHeight := m.daHeight.Load()
+	blobs, err := m.fetchDAHeight(ctx, daHeight)
+	if err != nil {
+		return err
+	}
+	m.applyBlobs(ctx, blobs, daHeight)
+	return nil
+}
 
+// fetchDAHeight retrieves the blobs of a DA height, retrying on errors, and decodes them.
+func (m *Manager) fetchDAHeight(ctx context.Context, daHeight uint64) ([]daBlob, error) {
 	var err error
 	m.logger.Debug("trying to retrieve data from DA", "daHeight", daHeight)
 	for r := 0; r < dAFetcherRetries; r++ {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return nil, ctx.Err()
 		default:
 		}
 		blobsResp, fetchErr := m.fetchBlobs(ctx, daHeight)
 		if fetchErr == nil {
 			if blobsResp.Code == coreda.StatusNotFound {
 				m.logger.Debug("no blob data found", "daHeight", daHeight, "reason", blobsResp.Message)
-				return nil
+				return nil, nil
 			}
 			m.logger.Debug("retrieved potential data", "n", len(blobsResp.Data), "daHeight", daHeight)
-			for _, bz := range blobsResp.Data {
-				if len(bz) == 0 {
-					m.logger.Debug("ignoring nil or empty blob", "daHeight", daHeight)
-					continue
-				}
-				if m.handlePotentialHeader(ctx, bz, daHeight) {
-					continue
-				}
-				m.handlePotentialBatch(ctx, bz, daHeight)
-			}
-			return nil
+			return m.decodeBlobs(blobsResp.Data, blobsResp.IDs, daHeight), nil
 		}
 
 		// Track the error
@@ -95,62 +147,217 @@ func (m *Manager) processNextDAHeaderAndData(ctx context.Context) error {
 		// Delay before retrying
 		select {
 		case <-ctx.Done():
-			return err
+			return nil, err
 		case <-time.After(100 * time.Millisecond):
 		}
 	}
-	return err
+	return nil, err
+}
+
+// daBlob is a blob retrieved from the DA layer, decoded into either a key rotation, a header or a batch.
+type daBlob struct {
+	// id is the DA ID of the blob, if known
+	id       []byte
+	rotation *types.KeyRotation
+	upgrade  *types.Upgrade
+	header   *types.SignedHeader
+	data     *types.Data
+	// part is a part of a blob split across several DA blobs, decoded once all parts are retrieved
+	part *types.BlobPart
+}
+
+// processBlobs decodes the blobs retrieved from a DA height, applies the key rotations and upgrades found and passes
+// the headers and batches found to the sync loop. ids are the DA IDs of the blobs, if known.
+func (m *Manager) processBlobs(ctx context.Context, blobs [][]byte, ids [][]byte, daHeight uint64) {
+	m.applyBlobs(ctx, m.decodeBlobs(blobs, ids, daHeight), daHeight)
+}
+
+// decodeBlobs decodes the blobs retrieved from a DA height and verifies the headers found, skipping invalid blobs.
+// The blobs bundled in a blob are decoded in order, sharing the DA ID of the bundle. Parts of split blobs are
+// decoded when they are applied, once all parts are retrieved. It does not depend on the state of the sync, so
+// that DA heights can be decoded concurrently.
+func (m *Manager) decodeBlobs(blobs [][]byte, ids [][]byte, daHeight uint64) []daBlob {
+	decoded := make([]daBlob, 0, len(blobs))
+	for i, bz := range blobs {
+		var id []byte
+		if i < len(ids) {
+			id = ids[i]
+		}
+		if len(bz) == 0 {
+			m.logger.Debug("ignoring nil or empty blob", "daHeight", daHeight)
+			continue
+		}
+		if part, ok, err := types.ParseBlobPart(bz); ok {
+			if err != nil {
+				m.logger.Debug("failed to decode blob part", "daHeight", daHeight, "error", err)
+			} else {
+				decoded = append(decoded, daBlob{id: id, part: &part})
+			}
+			continue
+		}
+		bz, ok := m.openBlob(bz, daHeight)
+		if !ok {
+			continue
+		}
+		bundled, err := types.UnbundleBlob(bz)
+		if err != nil {
+			m.logger.Debug("failed to unbundle blob", "daHeight", daHeight, "error", err)
+			continue
+		}
+		for _, bz := range bundled {
+			if blob, ok := m.decodeBlob(bz, daHeight); ok {
+				blob.id = id
+				decoded = append(decoded, blob)
+			}
+		}
+	}
+	return decoded
 }
 
-// handlePotentialHeader tries to decode and process a header. Returns true if successful or skipped, false if not a header.
-func (m *Manager) handlePotentialHeader(ctx context.Context, bz []byte, daHeight uint64) bool {
+// openBlob returns the payload of a blob retrieved from the DA layer, unwrapping its versioned envelope or
+// decompressing it. Returns false for blobs which cannot be decoded, for envelopes of another chain or of a later
+// version, which are counted as skipped, and for blobs without envelope in namespaces shared with other chains.
+// Retrieved blobs are accounted for per chain, see GetDAChainStats.
+func (m *Manager) openBlob(bz []byte, daHeight uint64) ([]byte, bool) {
+	env, ok, err := types.OpenBlob(bz)
+	if !ok {
+		if m.config.DA.SharedNamespace {
+			// the chain of blobs without envelope is unknown
+			m.logger.Debug("skipping blob without envelope in shared namespace", "daHeight", daHeight)
+			m.metrics.DASkippedBlobs.With("reason", "no_envelope").Add(1)
+			return nil, false
+		}
+		m.recordDAChainBlob(m.genesis.ChainID, len(bz), daHeight)
+		bz, err := types.DecompressBlob(bz)
+		if err != nil {
+			m.logger.Debug("failed to decompress blob", "daHeight", daHeight, "error", err)
+			return nil, false
+		}
+		return bz, true
+	}
+	switch {
+	case errors.Is(err, types.ErrUnsupportedBlobVersion):
+		// blobs of a later format are posted by upgraded nodes, the node must be upgraded to decode them
+		m.logger.Info("skipping blob of unsupported envelope version", "daHeight", daHeight, "version", env.Version)
+		m.metrics.DASkippedBlobs.With("reason", "unsupported_version").Add(1)
+		return nil, false
+	case err != nil:
+		m.logger.Debug("failed to open blob envelope", "daHeight", daHeight, "error", err)
+		return nil, false
+	}
+	m.recordDAChainBlob(env.ChainID, len(bz), daHeight)
+	if env.ChainID != m.genesis.ChainID {
+		m.logger.Debug("skipping blob of another chain", "daHeight", daHeight, "chainID", env.ChainID)
+		m.metrics.DASkippedBlobs.With("reason", "other_chain").Add(1)
+		return nil, false
+	}
+	return env.Payload, true
+}
+
+// decodeBlob decodes a blob into a key rotation, an upgrade, a header or a batch. Returns false if the blob is none of them
+// or is invalid.
+func (m *Manager) decodeBlob(bz []byte, daHeight uint64) (daBlob, bool) {
+	if rotation, ok := m.decodeKeyRotation(bz, daHeight); ok {
+		return daBlob{rotation: rotation}, rotation != nil
+	}
+	if upgrade, ok := m.decodeUpgrade(bz, daHeight); ok {
+		return daBlob{upgrade: upgrade}, upgrade != nil
+	}
+	if header, ok := m.decodeHeader(bz); ok {
+		return daBlob{header: header}, header != nil
+	}
+	if data := m.decodeBatch(bz, daHeight); data != nil {
+		return daBlob{data: data}, true
+	}
+	return daBlob{}, false
+}
+
+// applyBlobs applies the key rotations and upgrades and passes the headers and batches decoded from a DA height to the sync
+// loop, in the order of the blobs.
+func (m *Manager) applyBlobs(ctx context.Context, blobs []daBlob, daHeight uint64) {
+	for _, blob := range blobs {
+		switch {
+		case blob.rotation != nil:
+			if err := m.applyKeyRotation(ctx, blob.rotation); err != nil {
+				m.logger.Info("ignoring key rotation", "daHeight", daHeight, "height", blob.rotation.Height, "error", err)
+			}
+		case blob.upgrade != nil:
+			if err := m.applyUpgrade(ctx, blob.upgrade); err != nil {
+				m.logger.Info("ignoring upgrade", "daHeight", daHeight, "name", blob.upgrade.Name, "height", blob.upgrade.Height, "error", err)
+			}
+		case blob.header != nil:
+			m.handleHeader(ctx, blob.header, blob.id, daHeight)
+		case blob.data != nil:
+			m.handleBatch(ctx, blob.data, blob.id, daHeight)
+		case blob.part != nil:
+			m.applyBlobs(ctx, m.assembleBlobPart(blob, daHeight), daHeight)
+		}
+	}
+}
+
+// decodeHeader tries to decode and verify a header. Returns false if not a header, and a nil header if the header
+// is invalid.
+func (m *Manager) decodeHeader(bz []byte) (*types.SignedHeader, bool) {
 	header := new(types.SignedHeader)
 	var headerPb pb.SignedHeader
 	err := proto.Unmarshal(bz, &headerPb)
 	if err != nil {
 		m.logger.Debug("failed to unmarshal header", "error", err)
-		return false
+		return nil, false
 	}
 	err = header.FromProto(&headerPb)
 	if err != nil {
 		// treat as handled, but not valid
 		m.logger.Debug("failed to decode unmarshalled header", "error", err)
-		return true
+		m.metrics.SyncVerificationFailures.With("source", syncSourceDA).Add(1)
+		return nil, true
 	}
+	if err := header.Validate(); err != nil {
+		m.logger.Debug("skipping invalid header", "headerHeight", header.Height(), "error", err)
+		m.metrics.SyncVerificationFailures.With("source", syncSourceDA).Add(1)
+		return nil, true
+	}
+	return header, true
+}
+
+// handleHeader marks a header retrieved from the DA layer as DA included and passes it to the sync loop.
+func (m *Manager) handleHeader(ctx context.Context, header *types.SignedHeader, id []byte, daHeight uint64) {
 	// early validation to reject junk headers
-	if !m.isUsingExpectedSingleSequencer(header) {
+	if !m.isExpectedProposer(header) {
 		m.logger.Debug("skipping header from unexpected sequencer",
 			"headerHeight", header.Height(),
 			"headerHash", header.Hash().String())
-		return true
+		m.metrics.SyncVerificationFailures.With("source", syncSourceDA).Add(1)
+		return
 	}
 	headerHash := header.Hash().String()
+	m.setDAPointer(headerHash, daHeight, id)
 	m.headerCache.SetDAIncluded(headerHash)
 	m.sendNonBlockingSignalToDAIncluderCh()
 	m.logger.Info("header marked as DA included", "headerHeight", header.Height(), "headerHash", headerHash)
 	if !m.headerCache.IsSeen(headerHash) {
 		select {
 		case <-ctx.Done():
-			return true
+			return
 		default:
 			m.logger.Warn("headerInCh backlog full, dropping header", "daHeight", daHeight)
 		}
+		m.metrics.SyncHeadersReceived.With("source", syncSourceDA).Add(1)
 		m.headerInCh <- NewHeaderEvent{header, daHeight}
 	}
-	return true
 }
 
-// handlePotentialBatch tries to decode and process a batch. No return value.
-func (m *Manager) handlePotentialBatch(ctx context.Context, bz []byte, daHeight uint64) {
+// decodeBatch tries to decode a batch. Returns nil if not a batch or if the batch is empty.
+func (m *Manager) decodeBatch(bz []byte, daHeight uint64) *types.Data {
 	var batchPb pb.Batch
 	err := proto.Unmarshal(bz, &batchPb)
 	if err != nil {
 		m.logger.Debug("failed to unmarshal batch", "error", err)
-		return
+		return nil
 	}
 	if len(batchPb.Txs) == 0 {
 		m.logger.Debug("ignoring empty batch", "daHeight", daHeight)
-		return
+		return nil
 	}
 	data := &types.Data{
 		Txs: make(types.Txs, len(batchPb.Txs)),
@@ -158,7 +365,13 @@ func (m *Manager) handlePotentialBatch(ctx context.Context, bz []byte, daHeight
 	for i, tx := range batchPb.Txs {
 		data.Txs[i] = types.Tx(tx)
 	}
+	return data
+}
+
+// handleBatch marks a batch retrieved from the DA layer as DA included and passes it to the sync loop.
+func (m *Manager) handleBatch(ctx context.Context, data *types.Data, id []byte, daHeight uint64) {
 	dataHashStr := data.DACommitment().String()
+	m.setDAPointer(dataHashStr, daHeight, id)
 	m.dataCache.SetDAIncluded(dataHashStr)
 	m.sendNonBlockingSignalToDAIncluderCh()
 	m.logger.Info("batch marked as DA included", "batchHash", dataHashStr, "daHeight", daHeight)
@@ -169,6 +382,7 @@ func (m *Manager) handlePotentialBatch(ctx context.Context, bz []byte, daHeight
 		default:
 			m.logger.Warn("dataInCh backlog full, dropping batch", "daHeight", daHeight)
 		}
+		m.metrics.SyncDataReceived.With("source", syncSourceDA).Add(1)
 		m.dataInCh <- NewDataEvent{data, daHeight}
 	}
 }
@@ -197,15 +411,54 @@ func (m *Manager) areAllErrorsHeightFromFuture(err error) bool {
 	return false
 }
 
-// featchHeaders retrieves blobs from the DA layer
+// SetDataDA sets the DA client bound to the namespace of block data, when block data is not submitted to the
+// namespace of headers.
+func (m *Manager) SetDataDA(dataDA coreda.DA) {
+	m.dataDA = dataDA
+}
+
+// dataDAClient returns the DA client to which block data is submitted.
+func (m *Manager) dataDAClient() coreda.DA {
+	if m.dataDA != nil {
+		return m.dataDA
+	}
+	return m.da
+}
+
+// featchHeaders retrieves blobs from the DA layer, from the namespaces of both headers and block data, and the
+// namespaces set by upgrades
 func (m *Manager) fetchBlobs(ctx context.Context, daHeight uint64) (coreda.ResultRetrieve, error) {
 	var err error
 	ctx, cancel := context.WithTimeout(ctx, dAefetcherTimeout)
 	defer cancel()
 	//TODO: we should maintain the original error instead of creating a new one as we lose context by creating a new error.
 	blobsRes := types.RetrieveWithHelpers(ctx, m.da, m.logger, daHeight)
+	if m.dataDA != nil && blobsRes.Code != coreda.StatusError {
+		blobsRes = mergeRetrieveResults(blobsRes, types.RetrieveWithHelpers(ctx, m.dataDA, m.logger, daHeight))
+	}
+	for _, da := range m.upgradeDAs() {
+		if blobsRes.Code == coreda.StatusError {
+			break
+		}
+		blobsRes = mergeRetrieveResults(blobsRes, types.RetrieveWithHelpers(ctx, da, m.logger, daHeight))
+	}
 	if blobsRes.Code == coreda.StatusError {
 		err = fmt.Errorf("failed to retrieve block: %s", blobsRes.Message)
+	} else {
+		m.observeDAHeight(daHeight)
 	}
 	return blobsRes, err
 }
+
+// mergeRetrieveResults merges the blobs retrieved at the same DA height from two namespaces.
+func mergeRetrieveResults(res, other coreda.ResultRetrieve) coreda.ResultRetrieve {
+	switch {
+	case other.Code == coreda.StatusError, res.Code == coreda.StatusNotFound:
+		return other
+	case other.Code == coreda.StatusNotFound:
+		return res
+	}
+	res.IDs = append(res.IDs, other.IDs...)
+	res.Data = append(res.Data, other.Data...)
+	return res
+}
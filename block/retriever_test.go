@@ -44,6 +44,7 @@ func setupManagerForRetrieverTest(t *testing.T, initialDAHeight uint64) (*Manage
 
 	mockStore.On("GetState", mock.Anything).Return(types.State{DAHeight: initialDAHeight}, nil).Maybe()
 	mockStore.On("SetHeight", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockStore.On("Height", mock.Anything).Return(uint64(0), nil).Maybe()
 	mockStore.On("SetMetadata", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 	mockStore.On("GetMetadata", mock.Anything, DAIncludedHeightKey).Return([]byte{}, ds.ErrNotFound).Maybe()
 
@@ -78,6 +79,7 @@ func setupManagerForRetrieverTest(t *testing.T, initialDAHeight uint64) (*Manage
 		lastStateMtx:  new(sync.RWMutex),
 		da:            mockDAClient,
 		signer:        noopSigner,
+		metrics:       NopMetrics(),
 	}
 	manager.daIncludedHeight.Store(0)
 	manager.daHeight.Store(initialDAHeight)
@@ -758,3 +760,354 @@ func TestRetrieveLoop_DAHeightIncrementsOnlyOnSuccess(t *testing.T) {
 
 	mockDAClient.AssertExpectations(t)
 }
+
+// TestProcessNextDAHeader_CompressedBatch verifies that compressed batches are decompressed before being processed.
+func TestProcessNextDAHeader_CompressedBatch(t *testing.T) {
+	t.Parallel()
+	daHeight := uint64(25)
+	manager, mockDAClient, _, _, _, dataCache, cancel := setupManagerForRetrieverTest(t, daHeight)
+	defer cancel()
+
+	txs := [][]byte{[]byte("compressible tx compressible tx compressible tx"), []byte("compressible tx compressible tx compressible tx")}
+	batchBytes, err := proto.Marshal(&v1.Batch{Txs: txs})
+	require.NoError(t, err)
+	compressed, err := types.CompressBlob(types.BlobCodecZstd, batchBytes)
+	require.NoError(t, err)
+	require.NotEqual(t, batchBytes, compressed)
+
+	mockDAClient.On("GetIDs", mock.Anything, daHeight, mock.Anything).Return(&coreda.GetIDsResult{
+		IDs: []coreda.ID{[]byte("dummy-id")},
+	}, nil).Once()
+	mockDAClient.On("Get", mock.Anything, []coreda.ID{[]byte("dummy-id")}, mock.Anything).Return([]coreda.Blob{compressed}, nil).Once()
+
+	require.NoError(t, manager.processNextDAHeaderAndData(context.Background()))
+
+	select {
+	case dataEvent := <-manager.dataInCh:
+		assert.Equal(t, types.Txs{txs[0], txs[1]}, dataEvent.Data.Txs)
+		assert.True(t, dataCache.IsDAIncluded(dataEvent.Data.DACommitment().String()))
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Expected block data event not received")
+	}
+}
+
+// TestProcessNextDAHeader_HeaderBundle verifies that headers bundled into a blob by the submitter are retrieved and
+// marked as DA included one by one, pointing to the DA ID of the bundle.
+func TestProcessNextDAHeader_HeaderBundle(t *testing.T) {
+	t.Parallel()
+	daHeight := uint64(35)
+	manager, mockDAClient, _, _, headerCache, _, cancel := setupManagerForRetrieverTest(t, daHeight)
+	defer cancel()
+	manager.config.DA.MaxBlocksPerBlob = 3
+	manager.blobCodec = types.BlobCodecZstd
+
+	headers := make([]*types.SignedHeader, 5)
+	for i := range headers {
+		hc := types.HeaderConfig{Height: uint64(i + 1), Signer: manager.signer}
+		header, err := types.GetRandomSignedHeaderCustom(&hc, manager.genesis.ChainID)
+		require.NoError(t, err)
+		header.ProposerAddress = manager.genesis.ProposerAddress
+		headers[i] = header
+	}
+	blobs, sizes, err := manager.headerBlobs(headers)
+	require.NoError(t, err)
+	require.Len(t, blobs, 2)
+	assert.Equal(t, []int{3, 2}, sizes)
+	assert.Equal(t, 3, headersInBlobs(sizes, 1))
+	assert.Equal(t, 5, headersInBlobs(sizes, 2))
+
+	ids := []coreda.ID{[]byte("bundle-1"), []byte("bundle-2")}
+	mockDAClient.On("GetIDs", mock.Anything, daHeight, mock.Anything).Return(&coreda.GetIDsResult{IDs: ids}, nil).Once()
+	mockDAClient.On("Get", mock.Anything, ids, mock.Anything).Return(blobs, nil).Once()
+
+	require.NoError(t, manager.processNextDAHeaderAndData(context.Background()))
+
+	for i, header := range headers {
+		select {
+		case event := <-manager.headerInCh:
+			assert.Equal(t, header.Height(), event.Header.Height())
+			headerHash := event.Header.Hash().String()
+			assert.True(t, headerCache.IsDAIncluded(headerHash))
+			pointer, ok := manager.getDAPointer(headerHash)
+			require.True(t, ok)
+			assert.Equal(t, []byte(ids[i/3]), pointer.ID)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Expected header event %d not received", i+1)
+		}
+	}
+}
+
+// TestProcessNextDAHeader_BlobEnvelopes verifies that headers and batches submitted in versioned envelopes are
+// retrieved, and that envelopes of later versions or of another chain are skipped.
+func TestProcessNextDAHeader_BlobEnvelopes(t *testing.T) {
+	t.Parallel()
+	daHeight := uint64(45)
+	manager, mockDAClient, _, _, headerCache, dataCache, cancel := setupManagerForRetrieverTest(t, daHeight)
+	defer cancel()
+	manager.config.DA.BlobEnvelope = true
+	manager.config.DA.MaxBlocksPerBlob = 2
+	manager.blobCodec = types.BlobCodecZstd
+
+	headers := make([]*types.SignedHeader, 3)
+	for i := range headers {
+		hc := types.HeaderConfig{Height: uint64(i + 1), Signer: manager.signer}
+		header, err := types.GetRandomSignedHeaderCustom(&hc, manager.genesis.ChainID)
+		require.NoError(t, err)
+		header.ProposerAddress = manager.genesis.ProposerAddress
+		headers[i] = header
+	}
+	blobs, _, err := manager.headerBlobs(headers)
+	require.NoError(t, err)
+	require.Len(t, blobs, 2)
+	for _, blob := range blobs {
+		env, ok, err := types.OpenBlob(blob)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, manager.genesis.ChainID, env.ChainID)
+	}
+
+	txs := [][]byte{[]byte("tx1"), []byte("tx2")}
+	batchBytes, err := proto.Marshal(&v1.Batch{Txs: txs})
+	require.NoError(t, err)
+	batch, err := manager.sealBlob(batchBytes)
+	require.NoError(t, err)
+	otherChain, err := types.SealBlob("other-chain", types.BlobCodecNone, batchBytes)
+	require.NoError(t, err)
+	sealed, err := types.SealBlob(manager.genesis.ChainID, types.BlobCodecNone, batchBytes)
+	require.NoError(t, err)
+	// a blob of a later envelope version, whose format is unknown
+	future := append([]byte{}, sealed...)
+	future[4] = types.BlobEnvelopeVersion + 1
+
+	blobs = append(blobs, future, otherChain, batch)
+	ids := make([]coreda.ID, len(blobs))
+	for i := range ids {
+		ids[i] = []byte(fmt.Sprintf("blob-%d", i))
+	}
+	mockDAClient.On("GetIDs", mock.Anything, daHeight, mock.Anything).Return(&coreda.GetIDsResult{IDs: ids}, nil).Once()
+	mockDAClient.On("Get", mock.Anything, ids, mock.Anything).Return(blobs, nil).Once()
+
+	require.NoError(t, manager.processNextDAHeaderAndData(context.Background()))
+
+	for _, header := range headers {
+		select {
+		case event := <-manager.headerInCh:
+			assert.Equal(t, header.Height(), event.Header.Height())
+			assert.True(t, headerCache.IsDAIncluded(event.Header.Hash().String()))
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("Expected header event %d not received", header.Height())
+		}
+	}
+	select {
+	case dataEvent := <-manager.dataInCh:
+		assert.Equal(t, types.Txs{txs[0], txs[1]}, dataEvent.Data.Txs)
+		assert.True(t, dataCache.IsDAIncluded(dataEvent.Data.DACommitment().String()))
+		pointer, ok := manager.getDAPointer(dataEvent.Data.DACommitment().String())
+		require.True(t, ok)
+		assert.Equal(t, []byte(ids[4]), pointer.ID)
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Expected block data event not received")
+	}
+	select {
+	case dataEvent := <-manager.dataInCh:
+		t.Fatalf("unexpected block data event: %v", dataEvent.Data.Txs)
+	default:
+	}
+}
+
+// TestProcessNextDAHeader_SharedNamespace verifies that only the blobs of the chain of the node are retrieved from
+// a namespace shared with other chains, and that the blobs retrieved are accounted for per chain.
+func TestProcessNextDAHeader_SharedNamespace(t *testing.T) {
+	t.Parallel()
+	daHeight := uint64(50)
+	manager, mockDAClient, _, _, _, _, cancel := setupManagerForRetrieverTest(t, daHeight)
+	defer cancel()
+	manager.config.DA.SharedNamespace = true
+
+	txs := [][]byte{[]byte("tx1"), []byte("tx2")}
+	batchBytes, err := proto.Marshal(&v1.Batch{Txs: txs})
+	require.NoError(t, err)
+	// envelopes are always used in shared namespaces
+	batch, err := manager.sealBlob(batchBytes)
+	require.NoError(t, err)
+	env, ok, err := types.OpenBlob(batch)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, manager.genesis.ChainID, env.ChainID)
+
+	otherTxs, err := proto.Marshal(&v1.Batch{Txs: [][]byte{[]byte("other-tx")}})
+	require.NoError(t, err)
+	otherChain, err := types.SealBlob("other-chain", types.BlobCodecNone, otherTxs)
+	require.NoError(t, err)
+	// the chain of blobs without envelope is unknown
+	blobs := [][]byte{otherChain, otherTxs, batch, otherChain}
+	ids := make([]coreda.ID, len(blobs))
+	for i := range ids {
+		ids[i] = []byte(fmt.Sprintf("blob-%d", i))
+	}
+	mockDAClient.On("GetIDs", mock.Anything, daHeight, mock.Anything).Return(&coreda.GetIDsResult{IDs: ids}, nil).Once()
+	mockDAClient.On("Get", mock.Anything, ids, mock.Anything).Return(blobs, nil).Once()
+
+	require.NoError(t, manager.processNextDAHeaderAndData(context.Background()))
+
+	select {
+	case dataEvent := <-manager.dataInCh:
+		assert.Equal(t, types.Txs{txs[0], txs[1]}, dataEvent.Data.Txs)
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Expected block data event not received")
+	}
+	select {
+	case dataEvent := <-manager.dataInCh:
+		t.Fatalf("unexpected block data event: %v", dataEvent.Data.Txs)
+	default:
+	}
+
+	assert.ElementsMatch(t, []DAChainStats{
+		{ChainID: "other-chain", Blobs: 2, Bytes: 2 * uint64(len(otherChain)), LastDAHeight: daHeight},
+		{ChainID: manager.genesis.ChainID, Blobs: 1, Bytes: uint64(len(batch)), LastDAHeight: daHeight},
+	}, manager.GetDAChainStats())
+}
+
+// TestProcessNextDAHeader_SeparateDataNamespace verifies that headers and block data submitted to separate namespaces are both retrieved.
+func TestProcessNextDAHeader_SeparateDataNamespace(t *testing.T) {
+	t.Parallel()
+	daHeight := uint64(30)
+	blockHeight := uint64(100)
+	manager, mockDAClient, _, _, _, _, cancel := setupManagerForRetrieverTest(t, daHeight)
+	defer cancel()
+	mockDataDAClient := rollmocks.NewDA(t)
+	manager.SetDataDA(mockDataDAClient)
+
+	header, err := types.GetRandomSignedHeaderCustom(&types.HeaderConfig{Height: blockHeight, Signer: manager.signer}, manager.genesis.ChainID)
+	require.NoError(t, err)
+	header.ProposerAddress = manager.genesis.ProposerAddress
+	headerProto, err := header.ToProto()
+	require.NoError(t, err)
+	headerBytes, err := proto.Marshal(headerProto)
+	require.NoError(t, err)
+	batchBytes, err := proto.Marshal(&v1.Batch{Txs: [][]byte{[]byte("tx1"), []byte("tx2")}})
+	require.NoError(t, err)
+
+	mockDAClient.On("GetIDs", mock.Anything, daHeight, []byte("placeholder")).Return(&coreda.GetIDsResult{
+		IDs:       []coreda.ID{[]byte("header-id")},
+		Timestamp: time.Now(),
+	}, nil).Once()
+	mockDAClient.On("Get", mock.Anything, []coreda.ID{[]byte("header-id")}, []byte("placeholder")).Return(
+		[]coreda.Blob{headerBytes}, nil,
+	).Once()
+	mockDataDAClient.On("GetIDs", mock.Anything, daHeight, []byte("placeholder")).Return(&coreda.GetIDsResult{
+		IDs:       []coreda.ID{[]byte("data-id")},
+		Timestamp: time.Now(),
+	}, nil).Once()
+	mockDataDAClient.On("Get", mock.Anything, []coreda.ID{[]byte("data-id")}, []byte("placeholder")).Return(
+		[]coreda.Blob{batchBytes}, nil,
+	).Once()
+
+	require.NoError(t, manager.processNextDAHeaderAndData(context.Background()))
+
+	select {
+	case event := <-manager.headerInCh:
+		assert.Equal(t, blockHeight, event.Header.Height())
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Expected header event not received")
+	}
+	select {
+	case event := <-manager.dataInCh:
+		assert.Len(t, event.Data.Txs, 2)
+	case <-time.After(100 * time.Millisecond):
+		t.Fatal("Expected block data event not received")
+	}
+
+	// no block data at the next height does not prevent headers from being retrieved
+	mockDAClient.On("GetIDs", mock.Anything, daHeight+1, []byte("placeholder")).Return(&coreda.GetIDsResult{
+		IDs:       []coreda.ID{[]byte("header-id-2")},
+		Timestamp: time.Now(),
+	}, nil).Once()
+	mockDAClient.On("Get", mock.Anything, []coreda.ID{[]byte("header-id-2")}, []byte("placeholder")).Return(
+		[]coreda.Blob{headerBytes}, nil,
+	).Once()
+	mockDataDAClient.On("GetIDs", mock.Anything, daHeight+1, []byte("placeholder")).Return(nil, coreda.ErrBlobNotFound).Once()
+
+	res, err := manager.fetchBlobs(context.Background(), daHeight+1)
+	require.NoError(t, err)
+	assert.Equal(t, coreda.StatusSuccess, res.Code)
+	assert.Equal(t, [][]byte{headerBytes}, res.Data)
+
+	// failing to retrieve block data fails the retrieval at the height
+	mockDAClient.On("GetIDs", mock.Anything, daHeight+2, []byte("placeholder")).Return(nil, coreda.ErrBlobNotFound).Once()
+	mockDataDAClient.On("GetIDs", mock.Anything, daHeight+2, []byte("placeholder")).Return(nil, errors.New("unavailable")).Once()
+
+	res, err = manager.fetchBlobs(context.Background(), daHeight+2)
+	require.Error(t, err)
+	assert.Equal(t, coreda.StatusError, res.Code)
+
+	mockDAClient.AssertExpectations(t)
+	mockDataDAClient.AssertExpectations(t)
+}
+
+// TestRetrieveDAHeights_Concurrent verifies that DA heights fetched concurrently are applied in order, even when later DA heights are retrieved first.
+func TestRetrieveDAHeights_Concurrent(t *testing.T) {
+	t.Parallel()
+	startDAHeight := uint64(40)
+	manager, mockDAClient, _, _, _, _, cancel := setupManagerForRetrieverTest(t, startDAHeight)
+	defer cancel()
+	manager.config.DA.RetrieveWorkers = 4
+
+	for i := range uint64(3) {
+		daHeight := startDAHeight + i
+		header, err := types.GetRandomSignedHeaderCustom(&types.HeaderConfig{Height: 100 + i, Signer: manager.signer}, manager.genesis.ChainID)
+		require.NoError(t, err)
+		header.ProposerAddress = manager.genesis.ProposerAddress
+		headerProto, err := header.ToProto()
+		require.NoError(t, err)
+		headerBytes, err := proto.Marshal(headerProto)
+		require.NoError(t, err)
+
+		id := []byte(fmt.Sprintf("header-id-%d", i))
+		// earlier DA heights take longer to retrieve
+		mockDAClient.On("GetIDs", mock.Anything, daHeight, mock.Anything).After(time.Duration(3-i)*50*time.Millisecond).Return(&coreda.GetIDsResult{
+			IDs:       []coreda.ID{id},
+			Timestamp: time.Now(),
+		}, nil).Once()
+		mockDAClient.On("Get", mock.Anything, []coreda.ID{id}, mock.Anything).Return([]coreda.Blob{headerBytes}, nil).Once()
+	}
+	futureErr := fmt.Errorf("some error wrapping: %w", ErrHeightFromFutureStr)
+	mockDAClient.On("GetIDs", mock.Anything, mock.Anything, mock.Anything).Return(nil, futureErr).Maybe()
+
+	err := manager.retrieveDAHeights(context.Background())
+	require.Error(t, err)
+	assert.True(t, manager.areAllErrorsHeightFromFuture(err))
+	assert.Equal(t, startDAHeight+3, manager.daHeight.Load())
+
+	for i := range uint64(3) {
+		select {
+		case event := <-manager.headerInCh:
+			assert.Equal(t, startDAHeight+i, event.DAHeight)
+			assert.Equal(t, 100+i, event.Header.Height())
+		default:
+			t.Fatal("Expected header event not received")
+		}
+	}
+}
+
+// TestDecodeHeaderCustomValidators verifies that headers rejected by the custom header validators are skipped.
+func TestDecodeHeaderCustomValidators(t *testing.T) {
+	signerA, _, addrA := newRotationTestSigner(t)
+	m, _, _, _ := newTestManager(t, withStore(newRotationTestStore(t)), withConfig(config.DefaultConfig), withGenesis(rotationTestGenesis(addrA)))
+	bz, err := newRotationTestHeader(t, signerA, 2, nil).MarshalBinary()
+	require.NoError(t, err)
+
+	header, ok := m.decodeHeader(bz)
+	require.True(t, ok)
+	require.NotNil(t, header)
+	assert.True(t, m.isUsingExpectedSingleSequencer(header))
+
+	require.NoError(t, types.HeaderValidators.Register("reject", func(*types.SignedHeader) error {
+		return errors.New("missing commitment")
+	}))
+	t.Cleanup(func() { types.HeaderValidators.Unregister("reject") })
+	header, ok = m.decodeHeader(bz)
+	assert.True(t, ok)
+	assert.Nil(t, header)
+	assert.False(t, m.isUsingExpectedSingleSequencer(newRotationTestHeader(t, signerA, 2, nil)))
+}
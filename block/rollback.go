@@ -0,0 +1,191 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/store"
+)
+
+// ErrUnsafeRollback is returned when rolling back blocks which were already submitted to or included in the DA
+// layer. Such blocks are part of the canonical chain, and rolling them back forks the node off the chain.
+var ErrUnsafeRollback = errors.New("rollback below the DA included height")
+
+// Rollback reverts the chain to the given height, e.g. to recover from a bad upgrade or non-deterministic
+// execution. The executor state is reverted, the blocks above the height are deleted from the store, and the
+// state, DA inclusion cache and DA submission progress are reset. It must be called while the node is stopped.
+//
+// The state root after the block at the height must be known, either kept by the node or committed to by a later
+// header, so that the reverted executor state can be verified. The store is then updated in a single batch.
+//
+// Blocks at or below the DA included height, or already submitted to the DA layer, are only rolled back if
+// force is set.
+func Rollback(ctx context.Context, s store.Store, exec coreexecutor.Executor, height uint64, force bool, logger log.Logger) error {
+	state, err := s.GetState(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load state: %w", err)
+	}
+	storeHeight, err := s.Height(ctx)
+	if err != nil {
+		return err
+	}
+	if height >= storeHeight {
+		return fmt.Errorf("rollback height %d is not below the store height %d", height, storeHeight)
+	}
+	if height < state.InitialHeight {
+		return fmt.Errorf("rollback height %d is below the initial height %d", height, state.InitialHeight)
+	}
+
+	daIncludedHeight, err := getHeightMetadata(ctx, s, DAIncludedHeightKey)
+	if err != nil {
+		return err
+	}
+	lastSubmittedHeight, err := getHeightMetadata(ctx, s, LastSubmittedHeightKey)
+	if err != nil {
+		return err
+	}
+	if !force {
+		if height < daIncludedHeight {
+			return fmt.Errorf("%w: height %d is below the DA included height %d", ErrUnsafeRollback, height, daIncludedHeight)
+		}
+		if height < lastSubmittedHeight {
+			return fmt.Errorf("%w: height %d is below the height %d submitted to DA", ErrUnsafeRollback, height, lastSubmittedHeight)
+		}
+	}
+
+	rollbacker, ok := exec.(coreexecutor.Rollbacker)
+	if !ok {
+		return errors.New("executor does not support rollbacks")
+	}
+	header, _, err := s.GetBlockData(ctx, height)
+	if err != nil {
+		return fmt.Errorf("failed to load block %d: %w", height, err)
+	}
+	expectedRoot, err := committedStateRoot(ctx, s, height, storeHeight)
+	if err != nil {
+		return err
+	}
+	stateRoot, err := rollbacker.Rollback(ctx, height)
+	if err != nil {
+		return fmt.Errorf("failed to roll back execution state: %w", err)
+	}
+	if !bytes.Equal(stateRoot, expectedRoot) {
+		return fmt.Errorf("executor was reverted to height %d, but its state root %x does not match the committed state root %x, the store was not rolled back",
+			height, stateRoot, expectedRoot)
+	}
+
+	metadata := make(map[string][]byte)
+	cache, err := rollbackDAInclusionCache(ctx, s, height, storeHeight)
+	if err != nil {
+		return err
+	}
+	if cache != nil {
+		metadata[DAInclusionCacheKey] = cache
+	}
+	if daIncludedHeight > height {
+		metadata[DAIncludedHeightKey] = encodeHeightMetadata(height)
+	}
+	if lastSubmittedHeight > height {
+		metadata[LastSubmittedHeightKey] = encodeHeightMetadata(height)
+	}
+	for h := height + 1; h <= storeHeight; h++ {
+		metadata[stateRootKey(h)] = nil
+	}
+	state.LastBlockHeight = height
+	state.LastBlockTime = header.Time()
+	state.AppHash = stateRoot
+	if err := s.Rollback(ctx, height, state, metadata); err != nil {
+		return fmt.Errorf("failed to roll back store: %w", err)
+	}
+	logger.Info("rolled back chain", "height", height, "previousHeight", storeHeight, "appHash", fmt.Sprintf("%X", stateRoot))
+	return nil
+}
+
+// committedStateRoot returns the state root after the block at height, as kept by nodes persisting the state
+// roots, or as committed to by the AppHash of a later header in the store.
+func committedStateRoot(ctx context.Context, s store.Store, height, storeHeight uint64) ([]byte, error) {
+	stateRoot, err := s.GetMetadata(ctx, stateRootKey(height))
+	if err == nil {
+		return stateRoot, nil
+	}
+	if !errors.Is(err, ds.ErrNotFound) {
+		return nil, fmt.Errorf("failed to load state root of block %d: %w", height, err)
+	}
+	for h := height + 1; h <= storeHeight; h++ {
+		header, _, err := s.GetBlockData(ctx, h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load block %d: %w", h, err)
+		}
+		if header.AppHashHeight() == height {
+			return header.AppHash, nil
+		}
+	}
+	return nil, fmt.Errorf("state root after block %d is unknown, no header in the store commits to it", height)
+}
+
+// rollbackDAInclusionCache returns the DA inclusion cache persisted on shutdown without the headers and data of
+// the blocks above height, or nil if there is no cache.
+func rollbackDAInclusionCache(ctx context.Context, s store.Store, height, storeHeight uint64) ([]byte, error) {
+	raw, err := s.GetMetadata(ctx, DAInclusionCacheKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load DA inclusion cache: %w", err)
+	}
+	var cache daInclusionCache
+	if err := json.Unmarshal(raw, &cache); err != nil {
+		return nil, fmt.Errorf("failed to decode DA inclusion cache: %w", err)
+	}
+	for h := height + 1; h <= storeHeight; h++ {
+		header, data, err := s.GetBlockData(ctx, h)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load block %d: %w", h, err)
+		}
+		headerHash, dataHash := header.Hash().String(), data.DACommitment().String()
+		cache.Headers = slices.DeleteFunc(cache.Headers, func(hash string) bool { return hash == headerHash })
+		cache.Data = slices.DeleteFunc(cache.Data, func(hash string) bool { return hash == dataHash })
+		delete(cache.Pointers, headerHash)
+		delete(cache.Pointers, dataHash)
+	}
+	return json.Marshal(cache)
+}
+
+// getHeightMetadata returns the height persisted in store under the given key, or 0 if it is not set.
+func getHeightMetadata(ctx context.Context, s store.Store, key string) (uint64, error) {
+	raw, err := s.GetMetadata(ctx, key)
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to load %s: %w", key, err)
+	}
+	if len(raw) != 8 {
+		return 0, fmt.Errorf("invalid length of %s: %d, expected 8", key, len(raw))
+	}
+	return binary.LittleEndian.Uint64(raw), nil
+}
+
+// encodeHeightMetadata encodes the height as persisted by setHeightMetadata.
+func encodeHeightMetadata(height uint64) []byte {
+	heightBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(heightBytes, height)
+	return heightBytes
+}
+
+// setHeightMetadata persists the height in store under the given key.
+func setHeightMetadata(ctx context.Context, s store.Store, key string, height uint64) error {
+	if err := s.SetMetadata(ctx, key, encodeHeightMetadata(height)); err != nil {
+		return fmt.Errorf("failed to set %s: %w", key, err)
+	}
+	return nil
+}
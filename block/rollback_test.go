@@ -0,0 +1,137 @@
+package block
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// setupRollbackStore saves blocks executed by the executor up to the given height, with the header of each
+// block committing to the state root after the previous block.
+func setupRollbackStore(t *testing.T, exec *coreexecutor.DummyExecutor, height uint64) (store.Store, []*types.SignedHeader, [][]byte) {
+	t.Helper()
+	ctx := context.Background()
+	s := newRotationTestStore(t)
+	stateRoot, _, err := exec.InitChain(ctx, time.Now(), 1, rotationTestChainID)
+	require.NoError(t, err)
+	headers := make([]*types.SignedHeader, height+1)
+	stateRoots := [][]byte{stateRoot}
+	for h := uint64(1); h <= height; h++ {
+		header, data := types.GetRandomBlock(h, 1, rotationTestChainID)
+		header.AppHash = stateRoot
+		stateRoot, _, err = exec.ExecuteTxs(ctx, [][]byte{data.Txs[0]}, h, header.Time(), stateRoot)
+		require.NoError(t, err)
+		require.NoError(t, s.SaveBlockData(ctx, header, data, &header.Signature))
+		require.NoError(t, s.SetHeight(ctx, h))
+		headers[h] = header
+		stateRoots = append(stateRoots, stateRoot)
+	}
+	require.NoError(t, s.UpdateState(ctx, types.State{
+		ChainID:         rotationTestChainID,
+		InitialHeight:   1,
+		LastBlockHeight: height,
+		LastBlockTime:   headers[height].Time(),
+		AppHash:         stateRoot,
+	}))
+	return s, headers, stateRoots
+}
+
+func TestRollback(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+	exec := coreexecutor.NewDummyExecutor()
+	s, headers, stateRoots := setupRollbackStore(t, exec, 5)
+	require.NoError(t, setHeightMetadata(ctx, s, DAIncludedHeightKey, 2))
+	require.NoError(t, setHeightMetadata(ctx, s, LastSubmittedHeightKey, 3))
+	cache, err := json.Marshal(daInclusionCache{Headers: []string{headers[3].Hash().String(), headers[4].Hash().String()}})
+	require.NoError(t, err)
+	require.NoError(t, s.SetMetadata(ctx, DAInclusionCacheKey, cache))
+
+	// blocks included in or submitted to DA are only rolled back when forced
+	assert.ErrorIs(t, Rollback(ctx, s, exec, 1, false, logger), ErrUnsafeRollback)
+	assert.ErrorIs(t, Rollback(ctx, s, exec, 2, false, logger), ErrUnsafeRollback)
+	assert.Error(t, Rollback(ctx, s, exec, 5, false, logger))
+	assert.Error(t, Rollback(ctx, s, struct{ coreexecutor.Executor }{exec}, 4, false, logger))
+
+	require.NoError(t, Rollback(ctx, s, exec, 3, false, logger))
+	height, err := s.Height(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), height)
+	state, err := s.GetState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), state.LastBlockHeight)
+	assert.True(t, headers[3].Time().Equal(state.LastBlockTime))
+	assert.Equal(t, stateRoots[3], state.AppHash)
+	_, _, err = s.GetBlockData(ctx, 4)
+	assert.Error(t, err)
+
+	// the rolled back blocks are removed from the DA inclusion cache
+	raw, err := s.GetMetadata(ctx, DAInclusionCacheKey)
+	require.NoError(t, err)
+	var restored daInclusionCache
+	require.NoError(t, json.Unmarshal(raw, &restored))
+	assert.Equal(t, []string{headers[3].Hash().String()}, restored.Headers)
+
+	// forced rollbacks lower the DA included and submitted heights
+	require.NoError(t, Rollback(ctx, s, exec, 1, true, logger))
+	state, err = s.GetState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, stateRoots[1], state.AppHash)
+	daIncludedHeight, err := getHeightMetadata(ctx, s, DAIncludedHeightKey)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), daIncludedHeight)
+	lastSubmittedHeight, err := getHeightMetadata(ctx, s, LastSubmittedHeightKey)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), lastSubmittedHeight)
+}
+
+func TestRollbackVerifiesStateRoot(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewNopLogger()
+
+	// the state root after the block is kept by the node, e.g. an aggregator executing blocks asynchronously
+	exec := coreexecutor.NewDummyExecutor()
+	s, _, stateRoots := setupRollbackStore(t, exec, 5)
+	require.NoError(t, s.SetMetadata(ctx, stateRootKey(2), stateRoots[2]))
+	require.NoError(t, s.SetMetadata(ctx, stateRootKey(3), stateRoots[3]))
+	require.NoError(t, Rollback(ctx, s, exec, 2, false, logger))
+	_, err := s.GetMetadata(ctx, stateRootKey(3))
+	assert.ErrorIs(t, err, ds.ErrNotFound, "state roots of the rolled back blocks are deleted")
+
+	// the state root is committed to by a header produced before the block was executed
+	exec = coreexecutor.NewDummyExecutor()
+	s, headers, stateRoots := setupRollbackStore(t, exec, 5)
+	headers[5].ExecutionLag = 1
+	headers[5].AppHash = stateRoots[3]
+	_, data, err := s.GetBlockData(ctx, 5)
+	require.NoError(t, err)
+	require.NoError(t, s.SaveBlockData(ctx, headers[5], data, &headers[5].Signature))
+	headers[4].ExecutionLag = 1
+	headers[4].AppHash = stateRoots[2]
+	_, data, err = s.GetBlockData(ctx, 4)
+	require.NoError(t, err)
+	require.NoError(t, s.SaveBlockData(ctx, headers[4], data, &headers[4].Signature))
+	require.NoError(t, Rollback(ctx, s, exec, 3, false, logger))
+
+	// the store is not rolled back if the reverted state root does not match
+	exec = coreexecutor.NewDummyExecutor()
+	s, _, _ = setupRollbackStore(t, exec, 5)
+	require.NoError(t, s.SetMetadata(ctx, stateRootKey(3), []byte("other")))
+	assert.ErrorContains(t, Rollback(ctx, s, exec, 3, false, logger), "does not match")
+	height, err := s.Height(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), height)
+	state, err := s.GetState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), state.LastBlockHeight)
+}
@@ -0,0 +1,250 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"google.golang.org/protobuf/proto"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// KeyRotationsKey is the key used for persisting the key rotations of the sequencer in store.
+const KeyRotationsKey = "key-rotations"
+
+// ErrUnexpectedProposer is returned when a header is not signed by the proposer at its height.
+var ErrUnexpectedProposer = errors.New("header not signed by the expected proposer")
+
+// loadKeyRotations restores the key rotations applied before the node stopped. Nodes without rotations
+// follow the proposer of the genesis.
+func (m *Manager) loadKeyRotations(ctx context.Context) error {
+	bz, err := m.store.GetMetadata(ctx, KeyRotationsKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load key rotations: %w", err)
+	}
+	var rotationsPb pb.KeyRotations
+	if err := proto.Unmarshal(bz, &rotationsPb); err != nil {
+		return fmt.Errorf("failed to decode key rotations: %w", err)
+	}
+	rotations := make([]*types.KeyRotation, len(rotationsPb.Rotations))
+	for i, rotationPb := range rotationsPb.Rotations {
+		rotations[i] = new(types.KeyRotation)
+		if err := rotations[i].FromProto(rotationPb); err != nil {
+			return fmt.Errorf("failed to decode key rotation: %w", err)
+		}
+	}
+	m.rotationsMtx.Lock()
+	m.rotations = rotations
+	m.rotationsMtx.Unlock()
+	return nil
+}
+
+// saveKeyRotations persists the key rotations applied by the manager.
+func (m *Manager) saveKeyRotations(ctx context.Context, rotations []*types.KeyRotation) error {
+	rotationsPb := &pb.KeyRotations{Rotations: make([]*pb.KeyRotation, len(rotations))}
+	for i, rotation := range rotations {
+		var err error
+		if rotationsPb.Rotations[i], err = rotation.ToProto(); err != nil {
+			return err
+		}
+	}
+	bz, err := proto.Marshal(rotationsPb)
+	if err != nil {
+		return err
+	}
+	if err := m.store.SetMetadata(ctx, KeyRotationsKey, bz); err != nil {
+		return fmt.Errorf("failed to save key rotations: %w", err)
+	}
+	return nil
+}
+
+// ProposerAt returns the address of the proposer expected to sign the header at the given height: the proposer
+// of the genesis, or the new key of the last key rotation at or below the height.
+func (m *Manager) ProposerAt(height uint64) []byte {
+	m.rotationsMtx.RLock()
+	defer m.rotationsMtx.RUnlock()
+	return m.proposerAt(height)
+}
+
+// KeyRotationAt returns the key rotation authorizing the proposer at the given height, or nil if the proposer
+// of the genesis was not rotated at that height.
+func (m *Manager) KeyRotationAt(height uint64) *types.KeyRotation {
+	m.rotationsMtx.RLock()
+	defer m.rotationsMtx.RUnlock()
+	return m.rotationAt(height)
+}
+
+// proposerAt must be called with rotationsMtx held.
+func (m *Manager) proposerAt(height uint64) []byte {
+	if rotation := m.rotationAt(height); rotation != nil {
+		return rotation.NewProposer()
+	}
+	return m.genesis.ProposerAddress
+}
+
+// rotationAt must be called with rotationsMtx held.
+func (m *Manager) rotationAt(height uint64) *types.KeyRotation {
+	for i := len(m.rotations) - 1; i >= 0; i-- {
+		if m.rotations[i].Height <= height {
+			return m.rotations[i]
+		}
+	}
+	return nil
+}
+
+// verifyKeyRotation checks that the rotation is signed by the proposer it replaces, and follows the rotations
+// applied by the manager. It reports whether the rotation was already applied. It must be called with
+// rotationsMtx held.
+func (m *Manager) verifyKeyRotation(rotation *types.KeyRotation) (bool, error) {
+	if err := rotation.ValidateBasic(); err != nil {
+		return false, err
+	}
+	// the signature scheme is set in genesis and can be changed by upgrades
+	wantScheme := m.protocol(rotation.Height).scheme
+	if scheme, err := signer.SchemeOf(rotation.NewKey); err != nil || scheme != wantScheme {
+		return false, fmt.Errorf("%w: new key does not use the %s signature scheme", types.ErrInvalidKeyRotation, wantScheme)
+	}
+	if rotation.ChainID != m.genesis.ChainID {
+		return false, fmt.Errorf("%w: chain ID mismatch: expected %s, got %s", types.ErrInvalidKeyRotation, m.genesis.ChainID, rotation.ChainID)
+	}
+	if rotation.Height <= m.genesis.InitialHeight {
+		return false, fmt.Errorf("%w: height %d is not after the initial height", types.ErrInvalidKeyRotation, rotation.Height)
+	}
+	for _, applied := range m.rotations {
+		if applied.Height == rotation.Height {
+			if applied.NewKey.Equals(rotation.NewKey) {
+				return true, nil
+			}
+			return false, fmt.Errorf("%w: conflicts with the rotation at height %d", types.ErrInvalidKeyRotation, rotation.Height)
+		}
+	}
+	if n := len(m.rotations); n > 0 && rotation.Height < m.rotations[n-1].Height {
+		return false, fmt.Errorf("%w: height %d precedes the last rotation at height %d", types.ErrInvalidKeyRotation, rotation.Height, m.rotations[n-1].Height)
+	}
+	if proposer := m.proposerAt(rotation.Height); !bytes.Equal(rotation.Signer.Address, proposer) {
+		return false, fmt.Errorf("%w: signed by %X instead of the proposer %X", types.ErrInvalidKeyRotation, rotation.Signer.Address, proposer)
+	}
+	return false, nil
+}
+
+// applyKeyRotation verifies the key rotation and adds it to the proposer schedule. Rotations at heights which
+// are already synced are rejected, as the blocks at these heights were validated against the replaced key.
+func (m *Manager) applyKeyRotation(ctx context.Context, rotation *types.KeyRotation) error {
+	m.rotationsMtx.Lock()
+	defer m.rotationsMtx.Unlock()
+	known, err := m.verifyKeyRotation(rotation)
+	if err != nil || known {
+		return err
+	}
+	height, err := m.store.Height(ctx)
+	if err != nil {
+		return err
+	}
+	if rotation.Height <= height {
+		return fmt.Errorf("%w: height %d is already synced", types.ErrInvalidKeyRotation, rotation.Height)
+	}
+	rotations := append(slices.Clone(m.rotations), rotation)
+	if err := m.saveKeyRotations(ctx, rotations); err != nil {
+		return err
+	}
+	m.rotations = rotations
+	m.logger.Info("sequencer key rotated", "height", rotation.Height,
+		"oldProposer", fmt.Sprintf("%X", rotation.Signer.Address), "newProposer", fmt.Sprintf("%X", rotation.NewProposer()))
+	return nil
+}
+
+// RotateProposerKey rotates the signing key of the sequencer to newKey from the given height on. The rotation
+// is signed by the key of the aggregator, submitted to the DA layer and applied to the proposer schedule.
+// The aggregator stops producing blocks at the rotation height, until it is restarted with the new key.
+func (m *Manager) RotateProposerKey(ctx context.Context, newKey crypto.PubKey, height uint64) (*types.KeyRotation, error) {
+	if m.signer == nil {
+		return nil, errors.New("signer is nil; cannot rotate the sequencer key")
+	}
+	pubKey, err := m.signer.GetPublic()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposer public key: %w", err)
+	}
+	signer, err := types.NewSigner(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	rotation := &types.KeyRotation{ChainID: m.genesis.ChainID, Height: height, NewKey: newKey, Signer: signer}
+	bz, err := rotation.SignBytes()
+	if err != nil {
+		return nil, err
+	}
+	if rotation.Signature, err = m.signer.Sign(bz); err != nil {
+		return nil, fmt.Errorf("failed to sign key rotation: %w", err)
+	}
+
+	storeHeight, err := m.store.Height(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if height <= storeHeight {
+		return nil, fmt.Errorf("%w: height %d is already produced", types.ErrInvalidKeyRotation, height)
+	}
+	m.rotationsMtx.RLock()
+	_, err = m.verifyKeyRotation(rotation)
+	m.rotationsMtx.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	blob, err := rotation.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	gasPrice := m.gasPricer.price()
+	res := types.SubmitWithHelpers(ctx, m.da, m.logger, [][]byte{blob}, gasPrice, nil)
+	if res.Code != coreda.StatusSuccess {
+		return nil, fmt.Errorf("failed to submit key rotation to DA: %s", res.Message)
+	}
+	m.recordDAFees(ctx, [][]byte{blob}, gasPrice)
+	if err := m.applyKeyRotation(ctx, rotation); err != nil {
+		return nil, err
+	}
+	return rotation, nil
+}
+
+// checkProposer applies the key rotation carried by a header about to be synced, and checks that the header is
+// not signed by a key replaced at or before its height. Headers are checked against the proposer when they are
+// received, so this only rejects headers received before a rotation was applied.
+func (m *Manager) checkProposer(ctx context.Context, header *types.SignedHeader) error {
+	if header.Rotation != nil {
+		if err := m.applyKeyRotation(ctx, header.Rotation); err != nil {
+			m.logger.Debug("ignoring key rotation carried by header", "height", header.Height(), "error", err)
+		}
+	}
+	rotation := m.KeyRotationAt(header.Height())
+	if rotation != nil && !bytes.Equal(header.ProposerAddress, rotation.NewProposer()) {
+		return fmt.Errorf("%w: header at height %d signed by %X instead of %X", ErrUnexpectedProposer, header.Height(), header.ProposerAddress, rotation.NewProposer())
+	}
+	return nil
+}
+
+// decodeKeyRotation tries to decode a key rotation. Returns false if the blob is not a key rotation, and a nil key
+// rotation if it cannot be decoded.
+func (m *Manager) decodeKeyRotation(bz []byte, daHeight uint64) (*types.KeyRotation, bool) {
+	var rotationPb pb.KeyRotation
+	if err := proto.Unmarshal(bz, &rotationPb); err != nil || rotationPb.Height == 0 || len(rotationPb.NewPubKey) == 0 {
+		return nil, false
+	}
+	rotation := new(types.KeyRotation)
+	if err := rotation.FromProto(&rotationPb); err != nil {
+		m.logger.Debug("failed to decode key rotation", "daHeight", daHeight, "error", err)
+		return nil, true
+	}
+	return rotation, true
+}
@@ -0,0 +1,161 @@
+package block
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+const rotationTestChainID = "rotation-test"
+
+func newRotationTestSigner(t *testing.T) (signer.Signer, crypto.PubKey, []byte) {
+	t.Helper()
+	pk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	s, err := noop.NewNoopSigner(pk)
+	require.NoError(t, err)
+	address, err := s.GetAddress()
+	require.NoError(t, err)
+	return s, pk.GetPublic(), address
+}
+
+// rotationTestGenesis returns the genesis of the rotation test chain with the given initial proposer.
+func rotationTestGenesis(proposer []byte) genesis.Genesis {
+	return genesis.Genesis{ChainID: rotationTestChainID, InitialHeight: 1, ProposerAddress: proposer}
+}
+
+func newRotationTestStore(t *testing.T) store.Store {
+	t.Helper()
+	return store.New(store.NewMemoryKVStore())
+}
+
+// newRotationTestHeader returns a header at the given height signed by the signer, carrying the rotation.
+func newRotationTestHeader(t *testing.T, s signer.Signer, height uint64, rotation *types.KeyRotation) *types.SignedHeader {
+	t.Helper()
+	header, err := types.GetRandomSignedHeaderCustom(&types.HeaderConfig{Height: height, Signer: s}, rotationTestChainID)
+	require.NoError(t, err)
+	header.Rotation = rotation
+	return header
+}
+
+func TestKeyRotationSchedule(t *testing.T) {
+	ctx := context.Background()
+	signerA, _, addrA := newRotationTestSigner(t)
+	signerB, keyB, addrB := newRotationTestSigner(t)
+	_, keyC, _ := newRotationTestSigner(t)
+	m, _, _, _ := newTestManager(t, withStore(newRotationTestStore(t)), withConfig(config.DefaultConfig), withGenesis(rotationTestGenesis(addrA)))
+
+	rotation, err := types.GetKeyRotation(signerA, keyB, rotationTestChainID, 5)
+	require.NoError(t, err)
+	require.NoError(t, m.applyKeyRotation(ctx, rotation))
+	assert.Equal(t, addrA, m.ProposerAt(4))
+	assert.Equal(t, addrB, m.ProposerAt(5))
+	assert.Equal(t, addrB, m.ProposerAt(100))
+	assert.Nil(t, m.KeyRotationAt(4))
+	assert.Equal(t, rotation, m.KeyRotationAt(7))
+	// applying a known rotation again is a no-op
+	require.NoError(t, m.applyKeyRotation(ctx, rotation))
+
+	invalid := map[string]*types.KeyRotation{}
+	invalid["not signed by the proposer"], err = types.GetKeyRotation(signerA, keyC, rotationTestChainID, 8)
+	require.NoError(t, err)
+	invalid["conflicting"], err = types.GetKeyRotation(signerA, keyC, rotationTestChainID, 5)
+	require.NoError(t, err)
+	invalid["before the last rotation"], err = types.GetKeyRotation(signerB, keyC, rotationTestChainID, 3)
+	require.NoError(t, err)
+	invalid["initial height"], err = types.GetKeyRotation(signerA, keyC, rotationTestChainID, 1)
+	require.NoError(t, err)
+	invalid["other chain"], err = types.GetKeyRotation(signerB, keyC, "other-chain", 8)
+	require.NoError(t, err)
+	_, blsKey, err := signer.GenerateKeyPair(signer.SchemeBLS, rand.Reader)
+	require.NoError(t, err)
+	invalid["other signature scheme"], err = types.GetKeyRotation(signerB, blsKey, rotationTestChainID, 8)
+	require.NoError(t, err)
+	for name, rotation := range invalid {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, m.applyKeyRotation(ctx, rotation), types.ErrInvalidKeyRotation)
+		})
+	}
+
+	// rotations at synced heights are rejected
+	require.NoError(t, m.store.SetHeight(ctx, 10))
+	late, err := types.GetKeyRotation(signerB, keyC, rotationTestChainID, 9)
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.applyKeyRotation(ctx, late), types.ErrInvalidKeyRotation)
+
+	// the schedule is restored on restart
+	restarted, _, _, _ := newTestManager(t, withStore(m.store), withConfig(config.DefaultConfig), withGenesis(rotationTestGenesis(addrA)))
+	require.NoError(t, restarted.loadKeyRotations(ctx))
+	assert.Equal(t, addrA, restarted.ProposerAt(4))
+	assert.Equal(t, addrB, restarted.ProposerAt(5))
+}
+
+func TestIsUsingExpectedSingleSequencerKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	signerA, _, addrA := newRotationTestSigner(t)
+	signerB, keyB, _ := newRotationTestSigner(t)
+	m, _, _, _ := newTestManager(t, withStore(newRotationTestStore(t)), withConfig(config.DefaultConfig), withGenesis(rotationTestGenesis(addrA)))
+	rotation, err := types.GetKeyRotation(signerA, keyB, rotationTestChainID, 5)
+	require.NoError(t, err)
+
+	// headers signed by the new key are only accepted with the rotation until it is applied
+	assert.False(t, m.isUsingExpectedSingleSequencer(newRotationTestHeader(t, signerB, 5, nil)))
+	assert.True(t, m.isUsingExpectedSingleSequencer(newRotationTestHeader(t, signerB, 5, rotation)))
+	assert.True(t, m.isUsingExpectedSingleSequencer(newRotationTestHeader(t, signerA, 5, nil)))
+
+	// syncing a header applies its rotation
+	header := newRotationTestHeader(t, signerB, 5, rotation)
+	require.NoError(t, m.checkProposer(ctx, header))
+	assert.Equal(t, rotation.Height, m.KeyRotationAt(5).Height)
+
+	// after the rotation, headers signed by the replaced key are rejected
+	assert.True(t, m.isUsingExpectedSingleSequencer(newRotationTestHeader(t, signerB, 6, nil)))
+	assert.False(t, m.isUsingExpectedSingleSequencer(newRotationTestHeader(t, signerA, 6, nil)))
+	assert.True(t, m.isUsingExpectedSingleSequencer(newRotationTestHeader(t, signerA, 4, nil)))
+	assert.ErrorIs(t, m.checkProposer(ctx, newRotationTestHeader(t, signerA, 6, nil)), ErrUnexpectedProposer)
+}
+
+func TestRotateProposerKey(t *testing.T) {
+	ctx := context.Background()
+	signerA, _, addrA := newRotationTestSigner(t)
+	_, keyB, addrB := newRotationTestSigner(t)
+	da := coreda.NewDummyDA(100_000, 0, 0)
+	m, _, _, _ := newTestManager(t, withStore(newRotationTestStore(t)), withConfig(config.DefaultConfig), withGenesis(rotationTestGenesis(addrA)))
+	m.signer = signerA
+	m.da = da
+
+	require.NoError(t, m.store.SetHeight(ctx, 3))
+	_, err := m.RotateProposerKey(ctx, keyB, 3)
+	require.ErrorIs(t, err, types.ErrInvalidKeyRotation)
+
+	rotation, err := m.RotateProposerKey(ctx, keyB, 5)
+	require.NoError(t, err)
+	assert.Equal(t, addrB, rotation.NewProposer())
+	assert.Equal(t, addrB, m.ProposerAt(5))
+
+	// the aggregator stops producing blocks with the replaced key at the rotation height
+	_, _, err = m.execCreateBlock(ctx, 5, &types.Signature{}, nil, types.State{}, &BatchData{})
+	assert.ErrorContains(t, err, "proposer address")
+
+	// nodes retrieving the rotation from DA apply it
+	ids, err := da.GetIDs(ctx, 0, nil)
+	require.NoError(t, err)
+	blobs, err := da.Get(ctx, ids.IDs, nil)
+	require.NoError(t, err)
+	follower, _, _, _ := newTestManager(t, withStore(newRotationTestStore(t)), withConfig(config.DefaultConfig), withGenesis(rotationTestGenesis(addrA)))
+	follower.processBlobs(ctx, blobs, nil, 0)
+	assert.Equal(t, addrA, follower.ProposerAt(4))
+	assert.Equal(t, addrB, follower.ProposerAt(5))
+}
@@ -0,0 +1,57 @@
+package block
+
+import (
+	"time"
+
+	"github.com/rollkit/rollkit/pkg/config"
+)
+
+// UpdateRuntimeConfig applies the runtime parameters of the configuration to the running manager: the block
+// time, the lazy aggregation mode and interval, the DA gas price limits and the DA daily budget. Timers pick up the new block times
+// when they are next reset, and switching lazy mode restarts aggregation in the new mode.
+func (m *Manager) UpdateRuntimeConfig(conf config.Config) {
+	m.runtimeMtx.Lock()
+	m.config.Node.BlockTime = conf.Node.BlockTime
+	m.config.Node.LazyMode = conf.Node.LazyMode
+	m.config.Node.LazyBlockInterval = conf.Node.LazyBlockInterval
+	m.config.DA.GasPrice = conf.DA.GasPrice
+	m.config.DA.MaxGasPrice = conf.DA.MaxGasPrice
+	m.config.DA.DailyBudget = conf.DA.DailyBudget
+	m.runtimeMtx.Unlock()
+
+	m.gasPricer.setLimits(conf.DA.GasPrice, conf.DA.MaxGasPrice)
+	m.metrics.DAGasPrice.Set(m.gasPricer.price())
+
+	select {
+	case m.runtimeConfigCh <- struct{}{}:
+	default:
+	}
+}
+
+// blockTime returns the time between blocks produced by the aggregator.
+func (m *Manager) blockTime() time.Duration {
+	m.runtimeMtx.RLock()
+	defer m.runtimeMtx.RUnlock()
+	return m.config.Node.BlockTime.Duration
+}
+
+// lazyBlockInterval returns the maximum interval between blocks in lazy aggregation mode.
+func (m *Manager) lazyBlockInterval() time.Duration {
+	m.runtimeMtx.RLock()
+	defer m.runtimeMtx.RUnlock()
+	return m.config.Node.LazyBlockInterval.Duration
+}
+
+// lazyMode reports whether the aggregator only produces blocks when transactions are available.
+func (m *Manager) lazyMode() bool {
+	m.runtimeMtx.RLock()
+	defer m.runtimeMtx.RUnlock()
+	return m.config.Node.LazyMode
+}
+
+// daDailyBudget returns the DA fees that may be paid per UTC day, 0 if there is no budget.
+func (m *Manager) daDailyBudget() float64 {
+	m.runtimeMtx.RLock()
+	defer m.runtimeMtx.RUnlock()
+	return m.config.DA.DailyBudget
+}
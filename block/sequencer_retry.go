@@ -0,0 +1,63 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"cosmossdk.io/log"
+
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/retry"
+)
+
+// retryingSequencer retries the requests to a sequencer failing with coresequencer.ErrUnavailable, following the
+// sequencer retry policy of the configuration, so that connection failures of a remote sequencer network do not
+// fail block production or transaction submission.
+type retryingSequencer struct {
+	sequencer coresequencer.Sequencer
+	policy    retry.Policy
+	logger    log.Logger
+}
+
+var _ coresequencer.Sequencer = (*retryingSequencer)(nil)
+
+func newRetryingSequencer(sequencer coresequencer.Sequencer, policy retry.Policy, logger log.Logger) *retryingSequencer {
+	policy.Retryable = func(err error) bool { return errors.Is(err, coresequencer.ErrUnavailable) }
+	return &retryingSequencer{
+		sequencer: sequencer,
+		policy:    policy,
+		logger:    logger,
+	}
+}
+
+// SubmitRollupBatchTxs implements the Sequencer interface.
+func (s *retryingSequencer) SubmitRollupBatchTxs(ctx context.Context, req coresequencer.SubmitRollupBatchTxsRequest) (*coresequencer.SubmitRollupBatchTxsResponse, error) {
+	return retrySequencerRequest(ctx, s, "SubmitRollupBatchTxs", func(ctx context.Context) (*coresequencer.SubmitRollupBatchTxsResponse, error) {
+		return s.sequencer.SubmitRollupBatchTxs(ctx, req)
+	})
+}
+
+// GetNextBatch implements the Sequencer interface.
+func (s *retryingSequencer) GetNextBatch(ctx context.Context, req coresequencer.GetNextBatchRequest) (*coresequencer.GetNextBatchResponse, error) {
+	return retrySequencerRequest(ctx, s, "GetNextBatch", func(ctx context.Context) (*coresequencer.GetNextBatchResponse, error) {
+		return s.sequencer.GetNextBatch(ctx, req)
+	})
+}
+
+// VerifyBatch implements the Sequencer interface.
+func (s *retryingSequencer) VerifyBatch(ctx context.Context, req coresequencer.VerifyBatchRequest) (*coresequencer.VerifyBatchResponse, error) {
+	return retrySequencerRequest(ctx, s, "VerifyBatch", func(ctx context.Context) (*coresequencer.VerifyBatchResponse, error) {
+		return s.sequencer.VerifyBatch(ctx, req)
+	})
+}
+
+// retrySequencerRequest sends a request to the sequencer until it succeeds, fails with another error than
+// coresequencer.ErrUnavailable, the context is done, or the retry policy allows no more attempts.
+func retrySequencerRequest[T any](ctx context.Context, s *retryingSequencer, method string, request func(ctx context.Context) (T, error)) (T, error) {
+	policy := s.policy
+	policy.OnRetry = func(attempt int, err error, backoff time.Duration) {
+		s.logger.Warn("sequencer unavailable, retrying", "method", method, "attempt", attempt, "backoff", backoff, "error", err)
+	}
+	return retry.DoValue(ctx, policy, request)
+}
@@ -0,0 +1,71 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/retry"
+)
+
+// flakySequencer fails its first requests with the given error.
+type flakySequencer struct {
+	coresequencer.Sequencer
+	failures int
+	err      error
+	calls    int
+}
+
+func (s *flakySequencer) GetNextBatch(ctx context.Context, req coresequencer.GetNextBatchRequest) (*coresequencer.GetNextBatchResponse, error) {
+	s.calls++
+	if s.calls <= s.failures {
+		return nil, s.err
+	}
+	return s.Sequencer.GetNextBatch(ctx, req)
+}
+
+func TestRetryingSequencer(t *testing.T) {
+	const maxAttempts = 5
+	policy := retry.Policy{InitialBackoff: time.Microsecond, MaxBackoff: time.Millisecond, MaxAttempts: maxAttempts}
+	unavailable := errors.Join(coresequencer.ErrUnavailable, errors.New("connection refused"))
+	invalid := errors.New("invalid rollup id")
+	tests := []struct {
+		name      string
+		failures  int
+		err       error
+		wantCalls int
+		wantErr   error
+	}{
+		{name: "unavailable sequencer retried", failures: 3, err: unavailable, wantCalls: 4},
+		{name: "other errors not retried", failures: 3, err: invalid, wantCalls: 1, wantErr: invalid},
+		{name: "retries exhausted", failures: maxAttempts, err: unavailable, wantCalls: maxAttempts, wantErr: coresequencer.ErrUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flaky := &flakySequencer{Sequencer: coresequencer.NewDummySequencer(), failures: tt.failures, err: tt.err}
+			s := newRetryingSequencer(flaky, policy, log.NewNopLogger())
+
+			_, err := s.GetNextBatch(context.Background(), coresequencer.GetNextBatchRequest{RollupId: []byte("test")})
+			assert.Equal(t, tt.wantCalls, flaky.calls)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+
+	// retries stop when the context is done
+	flaky := &flakySequencer{Sequencer: coresequencer.NewDummySequencer(), failures: maxAttempts, err: unavailable}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := newRetryingSequencer(flaky, retry.Policy{InitialBackoff: time.Hour}, log.NewNopLogger()).GetNextBatch(ctx, coresequencer.GetNextBatchRequest{})
+	require.ErrorIs(t, err, coresequencer.ErrUnavailable)
+	assert.Equal(t, 1, flaky.calls)
+}
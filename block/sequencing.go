@@ -0,0 +1,61 @@
+package block
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rollkit/rollkit/pkg/config"
+)
+
+// SequencingStrategy orders the blocks of the chain, according to the sequencing mode of the node.
+type SequencingStrategy interface {
+	// Mode returns the sequencing mode implemented by the strategy, see config.NodeConfig.SequencingMode.
+	Mode() string
+	// ProduceBlocks orders and executes new blocks until the context is canceled.
+	ProduceBlocks(ctx context.Context)
+}
+
+// newSequencingStrategy returns the strategy implementing the given sequencing mode. The aggregator
+// mode is used if none is set.
+func newSequencingStrategy(m *Manager, mode string) (SequencingStrategy, error) {
+	switch mode {
+	case "", config.SequencingModeAggregator:
+		return aggregatorSequencing{m}, nil
+	case config.SequencingModeBased:
+		return basedSequencing{m}, nil
+	default:
+		return nil, fmt.Errorf("unknown sequencing mode %q", mode)
+	}
+}
+
+// aggregatorSequencing produces blocks from the batches of the sequencer. Blocks are signed by the
+// aggregator and posted to the DA layer by the submission loops.
+type aggregatorSequencing struct {
+	m *Manager
+}
+
+func (s aggregatorSequencing) Mode() string {
+	return config.SequencingModeAggregator
+}
+
+func (s aggregatorSequencing) ProduceBlocks(ctx context.Context) {
+	s.m.AggregationLoop(ctx)
+}
+
+// basedSequencing derives blocks from the batches posted to the DA namespace, see BasedLoop.
+type basedSequencing struct {
+	m *Manager
+}
+
+func (s basedSequencing) Mode() string {
+	return config.SequencingModeBased
+}
+
+func (s basedSequencing) ProduceBlocks(ctx context.Context) {
+	s.m.BasedLoop(ctx)
+}
+
+// Sequencing returns the strategy ordering the blocks of the chain.
+func (m *Manager) Sequencing() SequencingStrategy {
+	return m.sequencing
+}
@@ -0,0 +1,152 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/types"
+)
+
+// ShadowExecutionKey is the key used for persisting the progress of the shadow executor in store.
+const ShadowExecutionKey = "shadow-execution"
+
+// shadowState is the persisted progress of the shadow executor.
+type shadowState struct {
+	// Height is the height of the last block executed by the shadow executor, the initial height minus one
+	// after InitChain.
+	Height uint64 `json:"height"`
+	// AppHash is the state root computed by the shadow executor after the block at Height.
+	AppHash types.Hash `json:"app_hash"`
+}
+
+// SetShadowExecutor sets the executor re-executing the blocks executed by the node, e.g. a newer version of the
+// execution client, to compare its state roots against the state roots of the primary executor.
+func (m *Manager) SetShadowExecutor(exec coreexecutor.Executor) {
+	m.shadowExec = exec
+}
+
+// ShadowExecutionLoop executes the blocks executed by the primary executor on the shadow executor, trailing it by
+// Node.ShadowExecutionDelay blocks, and compares the state root computed by both executors after every block.
+// The shadow executor never affects the state of the node: a divergence is logged and reported in metrics, and
+// shadow execution stops at the first diverging block until the node is restarted.
+func (m *Manager) ShadowExecutionLoop(ctx context.Context) {
+	if m.shadowExec == nil {
+		return
+	}
+	state, err := m.loadShadowState(ctx)
+	if err != nil {
+		m.logger.Error("failed to initialize shadow executor, shadow execution is disabled", "error", err)
+		return
+	}
+	if state.Height == m.genesis.InitialHeight-1 {
+		if expected, err := m.stateRootAt(ctx, state.Height); err == nil && m.shadowDiverged(state.Height, expected, state.AppHash) {
+			return
+		}
+	}
+	ticker := time.NewTicker(m.blockTime())
+	defer ticker.Stop()
+	for {
+		if diverged, err := m.shadowExecute(ctx, &state); diverged {
+			return
+		} else if err != nil && ctx.Err() == nil {
+			m.logger.Error("shadow execution failed", "height", state.Height+1, "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// loadShadowState returns the progress of the shadow executor, initializing the chain on the shadow executor the
+// first time it runs.
+func (m *Manager) loadShadowState(ctx context.Context) (shadowState, error) {
+	var state shadowState
+	bz, err := m.store.GetMetadata(ctx, ShadowExecutionKey)
+	if err == nil {
+		if err := json.Unmarshal(bz, &state); err != nil {
+			return state, fmt.Errorf("failed to decode shadow execution state: %w", err)
+		}
+		return state, nil
+	}
+	if !errors.Is(err, ds.ErrNotFound) {
+		return state, fmt.Errorf("failed to load shadow execution state: %w", err)
+	}
+	stateRoot, _, err := m.shadowExec.InitChain(ctx, m.genesis.GenesisDAStartTime, m.genesis.InitialHeight, m.genesis.ChainID)
+	if err != nil {
+		return state, fmt.Errorf("failed to initialize chain: %w", err)
+	}
+	return shadowState{Height: m.genesis.InitialHeight - 1, AppHash: stateRoot}, nil
+}
+
+// shadowExecute executes the blocks executed by the primary executor since the last call on the shadow executor,
+// up to the shadow execution delay, and compares the state roots. It reports whether the executors diverged.
+func (m *Manager) shadowExecute(ctx context.Context, state *shadowState) (bool, error) {
+	for ctx.Err() == nil {
+		height := state.Height + 1
+		if m.GetLastState().LastBlockHeight < height+m.config.Node.ShadowExecutionDelay {
+			return false, nil
+		}
+		expected, err := m.stateRootAt(ctx, height)
+		if err != nil {
+			return false, err
+		}
+		header, data, err := m.store.GetBlockData(ctx, height)
+		if err != nil {
+			// blocks pruned before the shadow executor executed them cannot be executed anymore
+			return false, fmt.Errorf("failed to load block %d: %w", height, err)
+		}
+		rawTxs := make([][]byte, len(data.Txs))
+		for i := range data.Txs {
+			rawTxs[i] = data.Txs[i]
+		}
+		if err := setDABlock(ctx, m.shadowExec, header); err != nil {
+			return false, fmt.Errorf("failed to execute block %d: %w", height, err)
+		}
+		stateRoot, _, err := executeTxs(ctx, m.shadowExec, rawTxs, height, header.Time(), state.AppHash)
+		if err != nil {
+			return false, fmt.Errorf("failed to execute block %d: %w", height, err)
+		}
+		state.Height, state.AppHash = height, stateRoot
+		if err := m.saveShadowState(ctx, *state); err != nil {
+			return false, err
+		}
+		if m.shadowDiverged(height, expected, stateRoot) {
+			return true, nil
+		}
+		m.metrics.ShadowHeight.Set(float64(height))
+		m.logger.Debug("shadow executed block", "height", height, "appHash", stateRoot)
+	}
+	return false, ctx.Err()
+}
+
+// shadowDiverged reports whether the state root computed by the shadow executor after the block at the given
+// height differs from the state root of the primary executor, and alerts on divergence.
+func (m *Manager) shadowDiverged(height uint64, expected, shadowAppHash types.Hash) bool {
+	if bytes.Equal(expected, shadowAppHash) {
+		return false
+	}
+	m.metrics.ShadowDivergences.Add(1)
+	m.logger.Error("shadow executor diverged from the primary executor, shadow execution stopped",
+		"height", height, "appHash", expected, "shadowAppHash", shadowAppHash)
+	return true
+}
+
+func (m *Manager) saveShadowState(ctx context.Context, state shadowState) error {
+	bz, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := m.store.SetMetadata(ctx, ShadowExecutionKey, bz); err != nil {
+		return fmt.Errorf("failed to save shadow execution state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// divergingExecutor computes a different state root than DummyExecutor from the block at the given height on.
+type divergingExecutor struct {
+	*coreexecutor.DummyExecutor
+	from uint64
+}
+
+func (e *divergingExecutor) ExecuteTxs(ctx context.Context, txs [][]byte, blockHeight uint64, timestamp time.Time, prevStateRoot []byte) ([]byte, uint64, error) {
+	if blockHeight >= e.from {
+		txs = append(txs, []byte("diverging"))
+	}
+	return e.DummyExecutor.ExecuteTxs(ctx, txs, blockHeight, timestamp, prevStateRoot)
+}
+
+// saveExecutedBlocks saves the given number of blocks to the store along with the state roots computed by the
+// primary executor, and returns the last state.
+func saveExecutedBlocks(t *testing.T, s store.Store, blocks uint64) types.State {
+	t.Helper()
+	ctx := context.Background()
+	_, data := saveSignedBlocks(t, s, blocks)
+
+	primary := coreexecutor.NewDummyExecutor()
+	stateRoot, _, err := primary.InitChain(ctx, time.Time{}, 1, "test-chain")
+	require.NoError(t, err)
+	for i, d := range data {
+		stateRoot, _, err = primary.ExecuteTxs(ctx, [][]byte{d.Txs[0]}, uint64(i+1), d.Time(), stateRoot)
+		require.NoError(t, err)
+		require.NoError(t, s.SetMetadata(ctx, stateRootKey(uint64(i+1)), stateRoot))
+	}
+	return types.State{LastBlockHeight: blocks, AppHash: stateRoot}
+}
+
+func TestShadowExecution(t *testing.T) {
+	ctx := context.Background()
+	// newManager returns a manager whose primary executor executed 4 blocks
+	newManager := func(t *testing.T) *Manager {
+		s := store.New(store.NewMemoryKVStore())
+		lastState := saveExecutedBlocks(t, s, 4)
+		m, _, _, _ := newTestManager(t, withStore(s), withConfig(config.DefaultConfig),
+			withGenesis(genesis.Genesis{ChainID: "test-chain", InitialHeight: 1}), withLastState(lastState))
+		return m
+	}
+
+	t.Run("matching state roots", func(t *testing.T) {
+		m := newManager(t)
+		m.SetShadowExecutor(coreexecutor.NewDummyExecutor())
+		m.config.Node.ShadowExecutionDelay = 1
+		state, err := m.loadShadowState(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), state.Height)
+
+		// the shadow executor trails the primary executor by the delay
+		diverged, err := m.shadowExecute(ctx, &state)
+		require.NoError(t, err)
+		assert.False(t, diverged)
+		assert.Equal(t, uint64(3), state.Height)
+
+		// the progress of the shadow executor is persisted
+		m.config.Node.ShadowExecutionDelay = 0
+		restarted, err := m.loadShadowState(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, state, restarted)
+		diverged, err = m.shadowExecute(ctx, &restarted)
+		require.NoError(t, err)
+		assert.False(t, diverged)
+		assert.Equal(t, uint64(4), restarted.Height)
+		assert.Equal(t, types.Hash(m.GetLastState().AppHash), restarted.AppHash)
+	})
+
+	t.Run("diverging state roots", func(t *testing.T) {
+		m := newManager(t)
+		m.SetShadowExecutor(&divergingExecutor{DummyExecutor: coreexecutor.NewDummyExecutor(), from: 3})
+		state, err := m.loadShadowState(ctx)
+		require.NoError(t, err)
+
+		diverged, err := m.shadowExecute(ctx, &state)
+		require.NoError(t, err)
+		assert.True(t, diverged)
+		assert.Equal(t, uint64(3), state.Height)
+	})
+}
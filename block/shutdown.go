@@ -0,0 +1,357 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+const (
+	// CleanShutdownKey is the key used for persisting the clean-shutdown marker in store.
+	CleanShutdownKey = "clean-shutdown"
+
+	// DAInclusionCacheKey is the key used for persisting the DA inclusion caches in store on shutdown.
+	DAInclusionCacheKey = "da-inclusion-cache"
+
+	// LastSubmissionDAHeightKey is the key used for persisting the DA height of the last successful DA submission in store.
+	LastSubmissionDAHeightKey = "last-submission-da-height"
+
+	// maxRecoveryDAHeights bounds the number of DA heights scanned when recovering from an unclean shutdown.
+	maxRecoveryDAHeights = 1000
+)
+
+var cleanShutdownMarker = []byte{1}
+
+// daInclusionCache holds the hashes of the headers and data marked as DA-included above the DA included height,
+// and the DA blobs including them.
+type daInclusionCache struct {
+	Headers  []string             `json:"headers"`
+	Data     []string             `json:"data"`
+	Pointers map[string]daPointer `json:"pointers,omitempty"`
+}
+
+// loadShutdownState detects whether the node recorded a clean shutdown when it last stopped, and restores the
+// in-flight DA submissions, the batches waiting for DA submission and the DA inclusion caches. The marker is
+// cleared, so that a crash is detected on the next start. The restored state is reconciled with the store
+// heights, as the node may have crashed between writes.
+func (m *Manager) loadShutdownState(ctx context.Context) error {
+	marker, err := m.store.GetMetadata(ctx, CleanShutdownKey)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return fmt.Errorf("failed to load clean-shutdown marker: %w", err)
+	}
+	height, err := m.store.Height(ctx)
+	if err != nil {
+		return err
+	}
+	m.uncleanShutdown = height > 0 && !bytes.Equal(marker, cleanShutdownMarker)
+	if err := m.store.SetMetadata(ctx, CleanShutdownKey, []byte{0}); err != nil {
+		return fmt.Errorf("failed to clear clean-shutdown marker: %w", err)
+	}
+	if err := m.loadInFlightSubmissions(ctx); err != nil {
+		return err
+	}
+	if err := m.loadPendingBatches(ctx); err != nil {
+		return err
+	}
+
+	var cache daInclusionCache
+	raw, err := m.store.GetMetadata(ctx, DAInclusionCacheKey)
+	switch {
+	case errors.Is(err, ds.ErrNotFound):
+	case err != nil:
+		return fmt.Errorf("failed to load DA inclusion cache: %w", err)
+	default:
+		if err := json.Unmarshal(raw, &cache); err != nil {
+			return fmt.Errorf("failed to decode DA inclusion cache: %w", err)
+		}
+	}
+	return m.reconcileDAInclusionState(ctx, height, cache)
+}
+
+// reconcileDAInclusionState checks the persisted DA inclusion state against the store height, and restores the
+// entries of the DA inclusion cache belonging to the blocks above the DA included height. Heights beyond the
+// store height are reset to it, and stale cache entries are dropped. The last submitted height is advanced over
+// the headers known to be included in the DA layer, so that they are not submitted again.
+func (m *Manager) reconcileDAInclusionState(ctx context.Context, height uint64, cache daInclusionCache) error {
+	if daIncluded := m.GetDAIncludedHeight(); daIncluded > height {
+		m.logger.Warn("DA included height is above the store height, resetting it", "daIncludedHeight", daIncluded, "height", height)
+		heightBytes := make([]byte, 8)
+		binary.LittleEndian.PutUint64(heightBytes, height)
+		if err := m.store.SetMetadata(ctx, DAIncludedHeightKey, heightBytes); err != nil {
+			return fmt.Errorf("failed to reset DA included height: %w", err)
+		}
+		m.daIncludedHeight.Store(height)
+	}
+	if m.pendingHeaders != nil {
+		if lastSubmitted := m.pendingHeaders.GetLastSubmittedHeight(); lastSubmitted > height {
+			m.logger.Warn("last submitted height is above the store height, resetting it", "lastSubmittedHeight", lastSubmitted, "height", height)
+			if err := m.pendingHeaders.resetLastSubmittedHeight(ctx, height); err != nil {
+				return err
+			}
+		}
+	}
+
+	headers := make(map[string]bool, len(cache.Headers))
+	for _, hash := range cache.Headers {
+		headers[hash] = true
+	}
+	data := make(map[string]bool, len(cache.Data))
+	for _, hash := range cache.Data {
+		data[hash] = true
+	}
+	if len(cache.Headers)+len(cache.Data)+len(cache.Pointers) == 0 {
+		return nil
+	}
+	var lastSubmitted uint64
+	if m.pendingHeaders != nil {
+		lastSubmitted = m.pendingHeaders.GetLastSubmittedHeight()
+	}
+	restored := 0
+	for h := m.GetDAIncludedHeight() + 1; h <= height; h++ {
+		header, blockData, err := m.store.GetBlockData(ctx, h)
+		if err != nil {
+			return fmt.Errorf("failed to load block %d: %w", h, err)
+		}
+		headerHash, dataHash := header.Hash().String(), blockData.DACommitment().String()
+		if headers[headerHash] {
+			m.headerCache.SetDAIncluded(headerHash)
+			delete(headers, headerHash)
+			restored++
+			if m.config.Node.Aggregator && h == lastSubmitted+1 {
+				lastSubmitted = h
+			}
+		}
+		if data[dataHash] {
+			m.dataCache.SetDAIncluded(dataHash)
+			delete(data, dataHash)
+			restored++
+		}
+		for _, hash := range []string{headerHash, dataHash} {
+			if pointer, ok := cache.Pointers[hash]; ok {
+				m.daPointers.Store(hash, pointer)
+			}
+		}
+	}
+	if stale := len(headers) + len(data); stale > 0 {
+		m.logger.Info("dropped stale DA inclusion cache entries", "count", stale)
+	}
+	if m.pendingHeaders != nil && lastSubmitted > m.pendingHeaders.GetLastSubmittedHeight() {
+		m.logger.Info("headers were included in the DA layer above the last submitted height", "lastSubmittedHeight", lastSubmitted)
+		m.pendingHeaders.setLastSubmittedHeight(ctx, lastSubmitted)
+	}
+	if restored > 0 {
+		m.logger.Info("restored DA inclusion cache", "entries", restored)
+	}
+	return nil
+}
+
+// DrainDASubmissions submits the batches and headers still waiting for DA submission. It is called on
+// shutdown by the block producer, once the block production and DA submission loops returned. Nothing is
+// submitted if the DA daily budget is exhausted, the batches and headers are submitted after a restart.
+func (m *Manager) DrainDASubmissions(ctx context.Context) error {
+	// the loops returned, so the pending batches are not consumed concurrently
+	m.queueReceivedBatches(ctx)
+	if m.daBudgetExhausted() {
+		return nil
+	}
+	for {
+		batch, ok := m.nextPendingBatch()
+		if !ok {
+			break
+		}
+		if err := m.submitBatchToDA(ctx, batch); err != nil {
+			return err
+		}
+		m.popPendingBatch(ctx)
+	}
+	if m.pendingHeaders.isEmpty() {
+		return nil
+	}
+	return m.submitHeadersToDA(ctx)
+}
+
+// RecordCleanShutdown advances the DA included height, persists the DA inclusion caches and records a
+// clean-shutdown marker. It is called on shutdown once the Manager loops returned.
+func (m *Manager) RecordCleanShutdown(ctx context.Context) error {
+	m.advanceDAIncludedHeight(ctx)
+	if err := m.saveDAInclusionCache(ctx); err != nil {
+		return err
+	}
+	return m.store.SetMetadata(ctx, CleanShutdownKey, cleanShutdownMarker)
+}
+
+// saveDAInclusionCache persists the entries of the DA inclusion caches belonging to the blocks above the DA
+// included height, so that they survive a crash.
+func (m *Manager) saveDAInclusionCache(ctx context.Context) error {
+	height, err := m.store.Height(ctx)
+	if err != nil {
+		return err
+	}
+	cache := daInclusionCache{Pointers: make(map[string]daPointer)}
+	for h := m.GetDAIncludedHeight() + 1; h <= height; h++ {
+		header, data, err := m.store.GetBlockData(ctx, h)
+		if err != nil {
+			return fmt.Errorf("failed to load block %d: %w", h, err)
+		}
+		if headerHash := header.Hash().String(); m.headerCache.IsDAIncluded(headerHash) {
+			cache.Headers = append(cache.Headers, headerHash)
+		}
+		if dataHash := data.DACommitment().String(); m.dataCache.IsDAIncluded(dataHash) {
+			cache.Data = append(cache.Data, dataHash)
+		}
+		for _, hash := range []string{header.Hash().String(), data.DACommitment().String()} {
+			if pointer, ok := m.getDAPointer(hash); ok {
+				cache.Pointers[hash] = pointer
+			}
+		}
+	}
+	raw, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	if err := m.store.SetMetadata(ctx, DAInclusionCacheKey, raw); err != nil {
+		return fmt.Errorf("failed to save DA inclusion cache: %w", err)
+	}
+	return nil
+}
+
+// Recover reconciles the DA submission state with the DA layer, before the block producer starts.
+// Blobs may have been included in the DA layer without the node recording it, e.g. if it was killed during
+// a submission, or the DA client returned an error although the submission succeeded. After an unclean
+// shutdown, or if submissions with an unknown outcome were recorded, the DA layer is scanned from the
+// lowest DA height at which such blobs may have been included, and the pending headers and batches found
+// are marked as submitted and DA-included instead of being submitted again.
+func (m *Manager) Recover(ctx context.Context) error {
+	startDAHeight, inFlight := m.inFlightDAHeight(nil)
+	if !m.uncleanShutdown && !inFlight {
+		return nil
+	}
+	uncleanShutdown := m.uncleanShutdown
+	m.uncleanShutdown = false
+
+	if uncleanShutdown {
+		raw, err := m.store.GetMetadata(ctx, LastSubmissionDAHeightKey)
+		switch {
+		case errors.Is(err, ds.ErrNotFound):
+		case err != nil:
+			return fmt.Errorf("failed to load last submission DA height: %w", err)
+		case len(raw) != 8:
+			return fmt.Errorf("invalid last submission DA height length: %d", len(raw))
+		default:
+			if lastSubmission := binary.LittleEndian.Uint64(raw); !inFlight || lastSubmission < startDAHeight {
+				startDAHeight, inFlight = lastSubmission, true
+			}
+		}
+	}
+	if !inFlight {
+		m.logger.Info("unclean shutdown detected, but no DA submission was recorded")
+		return nil
+	}
+
+	headers, err := m.pendingHeaders.getPendingHeaders(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get pending headers: %w", err)
+	}
+	pending := make(map[string]uint64, len(headers))
+	for _, header := range headers {
+		pending[header.Hash().String()] = header.Height()
+	}
+
+	m.logger.Info("recovering DA submissions", "uncleanShutdown", uncleanShutdown, "daHeight", startDAHeight, "pendingHeaders", len(pending))
+	found := make(map[uint64]bool)
+	daHeight := startDAHeight
+	for ; daHeight < startDAHeight+maxRecoveryDAHeights; daHeight++ {
+		res, err := m.fetchBlobs(ctx, daHeight)
+		if err != nil {
+			if m.areAllErrorsHeightFromFuture(err) {
+				break
+			}
+			return fmt.Errorf("failed to retrieve blobs at DA height %d: %w", daHeight, err)
+		}
+		for i, bz := range res.Data {
+			var id []byte
+			if i < len(res.IDs) {
+				id = res.IDs[i]
+			}
+			bz, ok := m.openBlob(bz, daHeight)
+			if !ok {
+				continue
+			}
+			bundled, err := types.UnbundleBlob(bz)
+			if err != nil {
+				continue
+			}
+			for _, bz := range bundled {
+				if header := m.decodeHeaderBlob(bz); header != nil {
+					headerHash := header.Hash().String()
+					if height, ok := pending[headerHash]; ok {
+						m.setDAPointer(headerHash, daHeight, id)
+						m.headerCache.SetDAIncluded(headerHash)
+						found[height] = true
+					}
+					continue
+				}
+				if data := decodeBatchBlob(bz); data != nil {
+					dataHash := data.DACommitment().String()
+					m.setDAPointer(dataHash, daHeight, id)
+					m.dataCache.SetDAIncluded(dataHash)
+				}
+			}
+		}
+	}
+
+	lastSubmitted := m.pendingHeaders.GetLastSubmittedHeight()
+	for found[lastSubmitted+1] {
+		lastSubmitted++
+	}
+	m.pendingHeaders.setLastSubmittedHeight(ctx, lastSubmitted)
+	// the blobs of in-flight submissions that were not found were not included, and are submitted again
+	m.clearInFlightSubmissions(ctx)
+	m.sendNonBlockingSignalToDAIncluderCh()
+	m.logger.Info("recovered DA submissions", "scannedUntilDAHeight", daHeight, "recoveredHeaders", len(found), "lastSubmittedHeight", lastSubmitted)
+	return nil
+}
+
+// recordSubmissionDAHeight persists the DA height of a successful submission, from which Recover scans the DA layer.
+func (m *Manager) recordSubmissionDAHeight(ctx context.Context, daHeight uint64) {
+	heightBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(heightBytes, daHeight)
+	if err := m.store.SetMetadata(ctx, LastSubmissionDAHeightKey, heightBytes); err != nil {
+		m.logger.Error("failed to save last submission DA height", "daHeight", daHeight, "error", err)
+	}
+}
+
+// decodeHeaderBlob returns the header signed by the expected sequencer encoded in the blob, or nil.
+func (m *Manager) decodeHeaderBlob(bz []byte) *types.SignedHeader {
+	var headerPb pb.SignedHeader
+	if err := proto.Unmarshal(bz, &headerPb); err != nil {
+		return nil
+	}
+	header := new(types.SignedHeader)
+	if err := header.FromProto(&headerPb); err != nil || !m.isUsingExpectedSingleSequencer(header) {
+		return nil
+	}
+	return header
+}
+
+// decodeBatchBlob returns the data of the non-empty batch encoded in the blob, or nil.
+func decodeBatchBlob(bz []byte) *types.Data {
+	var batchPb pb.Batch
+	if err := proto.Unmarshal(bz, &batchPb); err != nil || len(batchPb.Txs) == 0 {
+		return nil
+	}
+	data := &types.Data{Txs: make(types.Txs, len(batchPb.Txs))}
+	for i, tx := range batchPb.Txs {
+		data.Txs[i] = types.Tx(tx)
+	}
+	return data
+}
@@ -0,0 +1,187 @@
+package block
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/test/mocks"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+func saveSignedBlocks(t *testing.T, s store.Store, n uint64) ([]*types.SignedHeader, []*types.Data) {
+	t.Helper()
+	ctx := context.Background()
+	config := types.BlockConfig{NTxs: 1}
+	var (
+		headers []*types.SignedHeader
+		data    []*types.Data
+	)
+	for height := uint64(1); height <= n; height++ {
+		config.Height = height
+		header, d, _ := types.GenerateRandomBlockCustom(&config, "test-chain")
+		require.NoError(t, s.SaveBlockData(ctx, header, d, &header.Signature))
+		require.NoError(t, s.SetHeight(ctx, height))
+		headers = append(headers, header)
+		data = append(data, d)
+	}
+	return headers, data
+}
+
+func TestRecordCleanShutdown(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	headers, data := saveSignedBlocks(t, s, 2)
+
+	m, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	require.NoError(t, m.loadShutdownState(ctx))
+	// no clean shutdown was recorded for the existing blocks
+	assert.True(t, m.uncleanShutdown)
+
+	// the header of block 2 is DA-included but block 1 is not, so the DA included height does not advance
+	m.headerCache.SetDAIncluded(headers[1].Hash().String())
+	m.dataCache.SetDAIncluded(data[1].DACommitment().String())
+	require.NoError(t, m.RecordCleanShutdown(ctx))
+
+	restarted, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	require.NoError(t, restarted.loadShutdownState(ctx))
+	assert.False(t, restarted.uncleanShutdown)
+	assert.True(t, restarted.headerCache.IsDAIncluded(headers[1].Hash().String()))
+	assert.True(t, restarted.dataCache.IsDAIncluded(data[1].DACommitment().String()))
+	assert.False(t, restarted.headerCache.IsDAIncluded(headers[0].Hash().String()))
+
+	// the marker is cleared on start, so a crash is detected
+	crashed, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	require.NoError(t, crashed.loadShutdownState(ctx))
+	assert.True(t, crashed.uncleanShutdown)
+}
+
+// TestCrashRecovery verifies that the DA inclusion caches and the batches waiting for DA submission are restored
+// after a crash, without a clean shutdown being recorded.
+func TestCrashRecovery(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	headers, data := saveSignedBlocks(t, s, 3)
+
+	m, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	m.config.Node.Aggregator = true
+	require.NoError(t, m.loadShutdownState(ctx))
+
+	// the headers of blocks 1 and 2 were included in the DA layer, but the data of block 1 was not
+	m.headerCache.SetDAIncluded(headers[0].Hash().String())
+	m.headerCache.SetDAIncluded(headers[1].Hash().String())
+	m.dataCache.SetDAIncluded(data[1].DACommitment().String())
+	m.setDAPointer(headers[1].Hash().String(), 7, []byte("id"))
+	require.NoError(t, m.saveDAInclusionCache(ctx))
+	m.queueBatch(ctx, coresequencer.Batch{Transactions: [][]byte{[]byte("tx1")}})
+	m.queueBatch(ctx, coresequencer.Batch{Transactions: [][]byte{[]byte("tx2")}})
+	m.popPendingBatch(ctx)
+
+	crashed, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	crashed.config.Node.Aggregator = true
+	require.NoError(t, crashed.loadShutdownState(ctx))
+	assert.True(t, crashed.uncleanShutdown)
+	assert.True(t, crashed.headerCache.IsDAIncluded(headers[0].Hash().String()))
+	assert.True(t, crashed.headerCache.IsDAIncluded(headers[1].Hash().String()))
+	assert.False(t, crashed.dataCache.IsDAIncluded(data[0].DACommitment().String()))
+	assert.True(t, crashed.dataCache.IsDAIncluded(data[1].DACommitment().String()))
+	pointer, ok := crashed.getDAPointer(headers[1].Hash().String())
+	require.True(t, ok)
+	assert.Equal(t, uint64(7), pointer.DAHeight)
+
+	// the headers included in the DA layer are not submitted again
+	assert.Equal(t, uint64(2), crashed.pendingHeaders.GetLastSubmittedHeight())
+
+	assert.Equal(t, 1, crashed.numPendingBatches())
+	batch, ok := crashed.nextPendingBatch()
+	require.True(t, ok)
+	assert.Equal(t, [][]byte{[]byte("tx2")}, batch.Transactions)
+}
+
+// TestReconcileHeightsAboveStore verifies that persisted heights above the store height are reset on start.
+func TestReconcileHeightsAboveStore(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	headers, _ := saveSignedBlocks(t, s, 3)
+
+	height := make([]byte, 8)
+	binary.LittleEndian.PutUint64(height, 5)
+	require.NoError(t, s.SetMetadata(ctx, DAIncludedHeightKey, height))
+	require.NoError(t, s.SetMetadata(ctx, LastSubmittedHeightKey, height))
+
+	m, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	m.init(ctx)
+	require.NoError(t, m.loadShutdownState(ctx))
+	assert.Equal(t, uint64(3), m.GetDAIncludedHeight())
+	assert.Equal(t, uint64(3), m.pendingHeaders.GetLastSubmittedHeight())
+
+	restarted, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	restarted.init(ctx)
+	assert.Equal(t, uint64(3), restarted.GetDAIncludedHeight())
+	assert.Equal(t, uint64(3), restarted.pendingHeaders.GetLastSubmittedHeight())
+}
+
+// TestRecover verifies that headers included in the DA layer without the node recording it are not submitted again.
+func TestRecover(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	headers, data := saveSignedBlocks(t, s, 3)
+
+	daHeight := make([]byte, 8)
+	binary.LittleEndian.PutUint64(daHeight, 10)
+	require.NoError(t, s.SetMetadata(ctx, LastSubmissionDAHeightKey, daHeight))
+
+	// headers 1 and 2 and the batch of block 1 were submitted when the node was killed
+	blob := func(msg proto.Message) coreda.Blob {
+		bz, err := proto.Marshal(msg)
+		require.NoError(t, err)
+		return bz
+	}
+	var headerBlobs []coreda.Blob
+	for _, header := range headers[:2] {
+		headerPb, err := header.ToProto()
+		require.NoError(t, err)
+		headerBlobs = append(headerBlobs, blob(headerPb))
+	}
+	batchBlob := blob(&pb.Batch{Txs: [][]byte{data[0].Txs[0]}})
+
+	mockDA := mocks.NewDA(t)
+	ids := []coreda.ID{[]byte("h1"), []byte("h2")}
+	mockDA.On("GetIDs", mock.Anything, uint64(10), mock.Anything).Return(&coreda.GetIDsResult{IDs: ids}, nil).Once()
+	mockDA.On("Get", mock.Anything, ids, mock.Anything).Return(headerBlobs, nil).Once()
+	mockDA.On("GetIDs", mock.Anything, uint64(11), mock.Anything).Return(&coreda.GetIDsResult{IDs: []coreda.ID{[]byte("b1")}}, nil).Once()
+	mockDA.On("Get", mock.Anything, []coreda.ID{[]byte("b1")}, mock.Anything).Return([]coreda.Blob{batchBlob}, nil).Once()
+	mockDA.On("GetIDs", mock.Anything, uint64(12), mock.Anything).Return(nil, ErrHeightFromFutureStr).Once()
+
+	m, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	m.da = mockDA
+	require.NoError(t, m.loadShutdownState(ctx))
+	require.True(t, m.uncleanShutdown)
+	require.NoError(t, m.Recover(ctx))
+
+	assert.Equal(t, uint64(2), m.pendingHeaders.GetLastSubmittedHeight())
+	assert.True(t, m.headerCache.IsDAIncluded(headers[1].Hash().String()))
+	assert.False(t, m.headerCache.IsDAIncluded(headers[2].Hash().String()))
+	assert.True(t, m.dataCache.IsDAIncluded(data[0].DACommitment().String()))
+
+	// recovery only runs once
+	require.NoError(t, m.Recover(ctx))
+}
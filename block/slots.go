@@ -0,0 +1,42 @@
+package block
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// blockDelay returns the time to wait before producing the next block of a block production period of the given
+// interval started at start. With slot alignment, the next block is produced at the start of the next wall-clock
+// slot of the interval instead, skipping the slots missed while the previous block was produced. A random jitter
+// of up to BlockJitter is added to the delay.
+func (m *Manager) blockDelay(start time.Time, interval time.Duration) time.Duration {
+	var delay time.Duration
+	if m.config.Node.SlotAlignment {
+		delay = untilNextSlot(time.Now(), interval)
+	} else {
+		delay = getRemainingSleep(start, interval)
+	}
+	return delay + m.blockJitter(interval)
+}
+
+// blockJitter returns a random delay of up to BlockJitter. With slot alignment, the jitter is kept within the
+// slot, so that a single block is produced per slot.
+func (m *Manager) blockJitter(slot time.Duration) time.Duration {
+	jitter := m.config.Node.BlockJitter.Duration
+	if m.config.Node.SlotAlignment {
+		jitter = min(jitter, slot)
+	}
+	if jitter <= 0 {
+		return 0
+	}
+	return rand.N(jitter)
+}
+
+// untilNextSlot returns the time from now until the start of the next wall-clock slot, slots being the multiples
+// of the slot duration since the Unix epoch.
+func untilNextSlot(now time.Time, slot time.Duration) time.Duration {
+	if slot <= 0 {
+		return time.Millisecond
+	}
+	return slot - time.Duration(now.UnixNano()%int64(slot))
+}
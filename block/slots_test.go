@@ -0,0 +1,40 @@
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rollkit/rollkit/pkg/config"
+)
+
+func TestUntilNextSlot(t *testing.T) {
+	slotStart := time.Unix(1700000000, 0)
+	assert.Equal(t, time.Second, untilNextSlot(slotStart, time.Second))
+	assert.Equal(t, 700*time.Millisecond, untilNextSlot(slotStart.Add(300*time.Millisecond), time.Second))
+	// slots are aligned to the multiples of the slot duration since the Unix epoch
+	assert.Equal(t, 2*time.Second, untilNextSlot(slotStart.Add(2*time.Second), 3*time.Second))
+	assert.Equal(t, time.Millisecond, untilNextSlot(slotStart, 0))
+}
+
+// TestBlockDelay verifies that blocks are produced at the start of the next slot with slot alignment, regardless of
+// when the block production period started, and that the jitter stays within its bound.
+func TestBlockDelay(t *testing.T) {
+	m := &Manager{config: config.Config{Node: config.NodeConfig{SlotAlignment: true}}}
+	for range 10 {
+		delay := m.blockDelay(time.Now().Add(-time.Hour), 100*time.Millisecond)
+		assert.Positive(t, delay)
+		assert.LessOrEqual(t, delay, 100*time.Millisecond)
+		assert.Less(t, time.Duration(time.Now().Add(delay).UnixNano()%int64(100*time.Millisecond)), 5*time.Millisecond)
+	}
+
+	m.config.Node.BlockJitter = config.DurationWrapper{Duration: 20 * time.Millisecond}
+	m.config.Node.SlotAlignment = false
+	start := time.Now()
+	for range 10 {
+		delay := m.blockDelay(start, time.Hour)
+		assert.Greater(t, delay, time.Hour-time.Second)
+		assert.Less(t, delay, time.Hour+20*time.Millisecond)
+	}
+}
@@ -0,0 +1,188 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/snapshot"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// SetSnapshotStore sets the store used to persist state snapshots. A snapshot is created every
+// Snapshot.Interval blocks if the executor implements coreexecutor.Snapshotter.
+func (m *Manager) SetSnapshotStore(s *snapshot.Store) {
+	m.snapshotStore = s
+}
+
+// maxSnapshotDAScan bounds the number of DA heights scanned for the header following the snapshot height.
+const maxSnapshotDAScan = 1000
+
+// scheduleSnapshot passes the given state to the SnapshotLoop if it is at a snapshot height. The snapshot is
+// skipped if the previous one is still being created.
+func (m *Manager) scheduleSnapshot(s types.State) {
+	interval := m.config.Snapshot.Interval
+	if m.snapshotStore == nil || interval == 0 || s.LastBlockHeight%interval != 0 {
+		return
+	}
+	if _, ok := m.exec.(coreexecutor.Snapshotter); !ok {
+		return
+	}
+	select {
+	case m.snapshotCh <- s:
+	default:
+		m.logger.Info("skipping state snapshot, previous snapshot is still being created", "height", s.LastBlockHeight)
+	}
+}
+
+// SnapshotLoop creates the state snapshots scheduled by block production and sync, so that creating a snapshot
+// does not delay them. Failing to create a snapshot is logged but does not halt the node.
+func (m *Manager) SnapshotLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case s := <-m.snapshotCh:
+			if err := m.createSnapshot(ctx, s); err != nil && ctx.Err() == nil {
+				m.logger.Error("failed to create state snapshot", "height", s.LastBlockHeight, "error", err)
+			}
+		}
+	}
+}
+
+func (m *Manager) createSnapshot(ctx context.Context, s types.State) error {
+	snapshotter, ok := m.exec.(coreexecutor.Snapshotter)
+	if !ok {
+		return errors.New("executor does not support state snapshots")
+	}
+	height := s.LastBlockHeight
+	appState, err := snapshotter.CreateSnapshot(ctx, height)
+	if err != nil {
+		return fmt.Errorf("failed to create execution snapshot: %w", err)
+	}
+	header, data, err := m.store.GetBlockData(ctx, height)
+	if err != nil {
+		return fmt.Errorf("failed to load block data: %w", err)
+	}
+	snap, err := snapshot.New(s, header, data, appState)
+	if err != nil {
+		return err
+	}
+	if err := m.snapshotStore.Save(ctx, snap); err != nil {
+		return err
+	}
+	m.logger.Info("created state snapshot", "height", height, "chunks", len(snap.Chunks))
+	return nil
+}
+
+// RestoreSnapshot bootstraps a fresh node from a state snapshot. The snapshot is verified against
+// nextHeader, the signed header following the snapshot height, which commits to the state root of
+// the snapshot and must be published to the DA layer. Once restored, the node continues with normal
+// sync from the snapshot height, and the blocks from the snapshot height on are DA included once they
+// are retrieved from the DA layer.
+func (m *Manager) RestoreSnapshot(ctx context.Context, snap *snapshot.Snapshot, nextHeader *types.SignedHeader) error {
+	snapshotter, ok := m.exec.(coreexecutor.Snapshotter)
+	if !ok {
+		return errors.New("executor does not support state snapshots")
+	}
+	height, err := m.store.Height(ctx)
+	if err != nil {
+		return err
+	}
+	if height >= snap.Height {
+		return fmt.Errorf("store height %d is not below snapshot height %d", height, snap.Height)
+	}
+	if err := m.verifySnapshot(snap, nextHeader); err != nil {
+		return fmt.Errorf("invalid snapshot: %w", err)
+	}
+	if err := m.verifyHeaderOnDA(ctx, nextHeader, snap.State.DAHeight); err != nil {
+		return fmt.Errorf("invalid snapshot: %w", err)
+	}
+
+	stateRoot, err := snapshotter.RestoreSnapshot(ctx, snap.Height, snap.AppState())
+	if err != nil {
+		return fmt.Errorf("failed to restore execution snapshot: %w", err)
+	}
+	if !bytes.Equal(stateRoot, snap.State.AppHash) {
+		return fmt.Errorf("restored state root %x does not match snapshot state root %x", stateRoot, snap.State.AppHash)
+	}
+
+	if err := m.store.SaveBlockData(ctx, snap.Header, snap.Data, &snap.Header.Signature); err != nil {
+		return SaveBlockError{err}
+	}
+	if err := m.store.SetHeight(ctx, snap.Height); err != nil {
+		return err
+	}
+	// the blocks below the snapshot are not available, as if they were pruned
+	if err := setHeightMetadata(ctx, m.store, store.PrunedHeightKey, snap.Height-1); err != nil {
+		return err
+	}
+	m.storeBaseHeight = snap.Height
+	if snap.State.DAHeight > m.daHeight.Load() {
+		m.daHeight.Store(snap.State.DAHeight)
+	}
+	if err := m.updateState(ctx, snap.State); err != nil {
+		return err
+	}
+	m.setSoftConfirmedHeight(snap.Height)
+	m.headerCache.SetSeen(snap.Header.Hash().String())
+	if !bytes.Equal(snap.Header.DataHash, dataHashForEmptyTxs) {
+		m.dataCache.SetSeen(snap.Header.DataHash.String())
+	}
+	m.logger.Info("restored state snapshot", "height", snap.Height, "appHash", fmt.Sprintf("%X", snap.State.AppHash))
+	return nil
+}
+
+// verifySnapshot checks that the snapshot belongs to this chain and that its state root is the one
+// committed by the sequencer in the header following the snapshot height.
+func (m *Manager) verifySnapshot(snap *snapshot.Snapshot, nextHeader *types.SignedHeader) error {
+	if snap.State.ChainID != m.genesis.ChainID || snap.State.InitialHeight != m.genesis.InitialHeight {
+		return fmt.Errorf("snapshot of chain %s does not match genesis chain %s", snap.State.ChainID, m.genesis.ChainID)
+	}
+	if !m.isUsingExpectedSingleSequencer(snap.Header) {
+		return errors.New("snapshot header is not signed by the expected sequencer")
+	}
+	if err := types.Validate(snap.Header, snap.Data); err != nil {
+		return fmt.Errorf("snapshot data does not match header: %w", err)
+	}
+	if nextHeader.Height() != snap.Height+1 {
+		return fmt.Errorf("expected header at height %d, got %d", snap.Height+1, nextHeader.Height())
+	}
+	if !m.isUsingExpectedSingleSequencer(nextHeader) {
+		return errors.New("next header is not signed by the expected sequencer")
+	}
+	if !bytes.Equal(nextHeader.LastHeaderHash, snap.Header.Hash()) {
+		return errors.New("next header does not link to snapshot header")
+	}
+	if nextHeader.ExecutionLag > 0 {
+		return fmt.Errorf("next header has an execution lag of %d, snapshot state root cannot be verified", nextHeader.ExecutionLag)
+	}
+	if !bytes.Equal(nextHeader.AppHash, snap.State.AppHash) {
+		return fmt.Errorf("snapshot state root %x does not match committed state root %x", snap.State.AppHash, nextHeader.AppHash)
+	}
+	return nil
+}
+
+// verifyHeaderOnDA checks that the header was published to the DA layer by the expected sequencer, scanning the DA
+// layer from the given DA height, instead of trusting the header received over p2p alone.
+func (m *Manager) verifyHeaderOnDA(ctx context.Context, header *types.SignedHeader, fromDAHeight uint64) error {
+	hash := header.Hash()
+	for daHeight := fromDAHeight; daHeight < fromDAHeight+maxSnapshotDAScan; daHeight++ {
+		res, err := m.fetchBlobs(ctx, daHeight)
+		if m.areAllErrorsHeightFromFuture(err) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to retrieve DA height %d: %w", daHeight, err)
+		}
+		for _, blob := range m.decodeBlobs(res.Data, res.IDs, daHeight) {
+			if blob.header != nil && m.isExpectedProposer(blob.header) && bytes.Equal(blob.header.Hash(), hash) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("header %d was not found on the DA layer from DA height %d", header.Height(), fromDAHeight)
+}
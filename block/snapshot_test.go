@@ -0,0 +1,153 @@
+package block
+
+import (
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/pkg/snapshot"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// TestSnapshotCreateAndRestore verifies that a snapshot created by a node at a snapshot height can be
+// restored by a fresh node, and that the snapshot is verified against the next header published to DA.
+func TestSnapshotCreateAndRestore(t *testing.T) {
+	require := require.New(t)
+	ctx := t.Context()
+	chainID := "snapshot-chain"
+
+	privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(err)
+	signer, err := noop.NewNoopSigner(privKey)
+	require.NoError(err)
+	proposer, err := signer.GetAddress()
+	require.NoError(err)
+	gen := genesis.NewGenesis(chainID, 1, time.Now(), proposer)
+	cfg := config.DefaultConfig
+	cfg.Snapshot.Interval = 2
+	da := coreda.NewDummyDA(100_000, 0, 0)
+	// newNode returns a manager of the given chain with an empty store and its executor
+	newNode := func(t *testing.T, gen genesis.Genesis) (*Manager, *coreexecutor.DummyExecutor) {
+		kv, err := store.NewDefaultInMemoryKVStore()
+		require.NoError(err)
+		exec := coreexecutor.NewDummyExecutor()
+		m, _, _, _ := newTestManager(t, withStore(store.New(kv)), withExecutor(exec), withGenesis(gen), withConfig(cfg), withDA(da))
+		return m, exec
+	}
+
+	source, sourceExec := newNode(t, gen)
+	source.snapshotCh = make(chan types.State, 1)
+	snapshotKV, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	snapshots, err := snapshot.NewStore(snapshotKV, 1)
+	require.NoError(err)
+	source.SetSnapshotStore(snapshots)
+
+	// execute and store block 2
+	header, data, _ := types.GenerateRandomBlockCustomWithAppHash(&types.BlockConfig{Height: 2, NTxs: 2, PrivKey: privKey}, chainID, sourceExec.GetStateRoot())
+	stateRoot, _, err := sourceExec.ExecuteTxs(ctx, [][]byte{data.Txs[0], data.Txs[1]}, 2, header.Time(), header.AppHash)
+	require.NoError(err)
+	require.NoError(source.store.SaveBlockData(ctx, header, data, &header.Signature))
+	state := types.State{
+		Version:         types.InitStateVersion,
+		ChainID:         chainID,
+		InitialHeight:   1,
+		LastBlockHeight: 2,
+		LastBlockTime:   header.Time(),
+		DAHeight:        7,
+		AppHash:         stateRoot,
+	}
+
+	// no snapshot at a height that is not a multiple of the interval
+	state.LastBlockHeight = 1
+	source.scheduleSnapshot(state)
+	require.Empty(source.snapshotCh)
+
+	// snapshots are created by the snapshot loop
+	state.LastBlockHeight = 2
+	source.scheduleSnapshot(state)
+	require.NoError(source.createSnapshot(ctx, <-source.snapshotCh))
+	manifest, err := snapshots.Latest(ctx, 10)
+	require.NoError(err)
+	snap, err := snapshot.FromManifest(manifest)
+	require.NoError(err)
+	chunk, err := snapshots.Chunk(ctx, 2, 0)
+	require.NoError(err)
+	snap.Chunks = [][]byte{chunk}
+
+	// header 3 commits to the state root after block 2
+	nextHeader, err := types.GetRandomSignedHeaderCustom(&types.HeaderConfig{Height: 3, AppHash: stateRoot, Signer: signer}, chainID)
+	require.NoError(err)
+	nextHeader.LastHeaderHash = header.Hash()
+	nextHeader.Signature, err = types.GetSignature(nextHeader.Header, signer)
+	require.NoError(err)
+
+	t.Run("rejects next header not published to DA", func(t *testing.T) {
+		target, _ := newNode(t, gen)
+		assert.ErrorContains(t, target.RestoreSnapshot(ctx, snap, nextHeader), "not found on the DA layer")
+	})
+
+	// header 3 is published after the DA height of the snapshot state
+	for range 8 {
+		_, err := da.Submit(ctx, []coreda.Blob{[]byte("other")}, 0, nil)
+		require.NoError(err)
+	}
+	nextHeaderBz, err := nextHeader.MarshalBinary()
+	require.NoError(err)
+	_, err = da.Submit(ctx, []coreda.Blob{nextHeaderBz}, 0, nil)
+	require.NoError(err)
+
+	t.Run("rejects unrelated state root", func(t *testing.T) {
+		target, _ := newNode(t, gen)
+		badHeader := *nextHeader
+		badHeader.AppHash = types.GetRandomBytes(32)
+		badHeader.Signature, err = types.GetSignature(badHeader.Header, signer)
+		require.NoError(err)
+		assert.Error(t, target.RestoreSnapshot(ctx, snap, &badHeader))
+	})
+
+	t.Run("rejects unexpected sequencer", func(t *testing.T) {
+		other := gen
+		other.ProposerAddress = types.GetRandomBytes(32)
+		target, _ := newNode(t, other)
+		assert.Error(t, target.RestoreSnapshot(ctx, snap, nextHeader))
+	})
+
+	t.Run("restores snapshot", func(t *testing.T) {
+		target, targetExec := newNode(t, gen)
+		require.NoError(target.RestoreSnapshot(ctx, snap, nextHeader))
+
+		height, err := target.store.Height(ctx)
+		require.NoError(err)
+		assert.Equal(t, uint64(2), height)
+		restoredState, err := target.store.GetState(ctx)
+		require.NoError(err)
+		assert.Equal(t, stateRoot, restoredState.AppHash)
+		assert.Equal(t, stateRoot, target.GetLastState().AppHash)
+		assert.Equal(t, stateRoot, targetExec.GetStateRoot())
+		assert.Equal(t, uint64(7), target.daHeight.Load())
+		assert.True(t, target.headerCache.IsSeen(header.Hash().String()))
+
+		// the snapshot block is DA included once retrieved from the DA layer, without finalizing it in the executor
+		assert.Equal(t, uint64(0), target.GetDAIncludedHeight())
+		target.advanceDAIncludedHeight(ctx)
+		assert.Equal(t, uint64(0), target.GetDAIncludedHeight())
+		target.headerCache.SetDAIncluded(header.Hash().String())
+		target.dataCache.SetDAIncluded(data.DACommitment().String())
+		target.advanceDAIncludedHeight(ctx)
+		assert.Equal(t, uint64(2), target.GetDAIncludedHeight())
+
+		// a second restore is rejected as the store is no longer empty
+		assert.Error(t, target.RestoreSnapshot(ctx, snap, nextHeader))
+	})
+}
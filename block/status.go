@@ -0,0 +1,68 @@
+package block
+
+import (
+	"context"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/types"
+)
+
+// observeDAHeight records a DA height known to exist in the DA layer, so that the DA head is tracked without
+// querying the DA layer.
+func (m *Manager) observeDAHeight(daHeight uint64) {
+	for {
+		head := m.daHeadHeight.Load()
+		if daHeight <= head || m.daHeadHeight.CompareAndSwap(head, daHeight) {
+			return
+		}
+	}
+}
+
+// Status returns the sync progress of the node. The number of peers is not known by the manager and left 0.
+func (m *Manager) Status(ctx context.Context) (types.NodeStatus, error) {
+	height, err := m.store.Height(ctx)
+	if err != nil {
+		return types.NodeStatus{}, err
+	}
+	status := types.NodeStatus{
+		Mode:             types.NodeModeFull,
+		Height:           height,
+		DAIncludedHeight: m.GetDAIncludedHeight(),
+		DAHeight:         m.daHeight.Load(),
+		DAHeadHeight:     m.daHeadHeight.Load(),
+		PendingBatches:   uint64(m.numPendingBatches()),
+	}
+	if m.pendingHeaders != nil {
+		status.PendingHeaders = m.pendingHeaders.numPendingHeaders()
+	}
+	switch {
+	case m.config.Node.SequencingMode == config.SequencingModeBased:
+		status.Mode = types.NodeModeBased
+	case m.config.Node.Aggregator:
+		status.Mode = types.NodeModeAggregator
+		status.Paused = m.BlockProductionPaused()
+	}
+	if status.Mode != types.NodeModeAggregator {
+		// DA heights up to the DA head are left to retrieve, or the p2p network has blocks not synced yet
+		status.CatchingUp = status.DAHeadHeight >= status.DAHeight
+		if m.headerStore != nil {
+			if headerHeight := m.headerStore.Height(); headerHeight > height {
+				status.CatchingUp = true
+				status.SyncLag = headerHeight - height
+			}
+		}
+	}
+	if m.da != nil {
+		// the gas price is queried as a lightweight request to check that the DA layer is reachable
+		if _, err := m.da.GasPrice(ctx); err != nil {
+			status.DAError = err.Error()
+		}
+	}
+	if checker, ok := m.exec.(coreexecutor.HealthChecker); ok {
+		if err := checker.Healthy(ctx); err != nil {
+			status.ExecutorError = err.Error()
+		}
+	}
+	return status, nil
+}
@@ -0,0 +1,94 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/types"
+)
+
+// unhealthyExecutor is an executor reporting that it cannot execute blocks.
+type unhealthyExecutor struct {
+	coreexecutor.Executor
+}
+
+func (unhealthyExecutor) Healthy(context.Context) error {
+	return errors.New("engine unreachable")
+}
+
+// unreachableDA is a DA layer that cannot be reached.
+type unreachableDA struct {
+	coreda.DA
+}
+
+func (unreachableDA) GasPrice(context.Context) (float64, error) {
+	return 0, errors.New("connection refused")
+}
+
+func TestStatus(t *testing.T) {
+	ctx := context.Background()
+	s := newRotationTestStore(t)
+	require.NoError(t, s.SetHeight(ctx, 5))
+	daHeight := new(atomic.Uint64)
+	daHeight.Store(10)
+	m := &Manager{
+		config:              config.DefaultConfig,
+		store:               s,
+		exec:                coreexecutor.NewDummyExecutor(),
+		daHeight:            daHeight,
+		batchSubmissionChan: make(chan coresequencer.Batch, 2),
+	}
+	m.daIncludedHeight.Store(3)
+	m.batchSubmissionChan <- coresequencer.Batch{}
+
+	status, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.NodeStatus{
+		Mode:             types.NodeModeFull,
+		Height:           5,
+		DAIncludedHeight: 3,
+		DAHeight:         10,
+		PendingBatches:   1,
+	}, status)
+
+	// the DA head is tracked from the DA heights seen, DA heights up to the DA head are left to retrieve
+	m.observeDAHeight(12)
+	m.observeDAHeight(11)
+	status, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12), status.DAHeadHeight)
+	assert.True(t, status.CatchingUp)
+	daHeight.Store(13)
+	status, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.False(t, status.CatchingUp)
+
+	// aggregators do not catch up, and report the health of their executor
+	m.config.Node.Aggregator = true
+	m.exec = unhealthyExecutor{m.exec}
+	daHeight.Store(1)
+	status, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.NodeModeAggregator, status.Mode)
+	assert.False(t, status.CatchingUp)
+	assert.Equal(t, "engine unreachable", status.ExecutorError)
+
+	// the DA layer is reported unreachable if it cannot be queried
+	m.da = coreda.NewDummyDA(100_000, 0, 0)
+	status, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, status.DAError)
+	m.da = unreachableDA{m.da}
+	status, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "connection refused", status.DAError)
+}
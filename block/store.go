@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/rollkit/rollkit/pkg/failpoint"
 	"github.com/rollkit/rollkit/types"
 )
 
@@ -23,6 +24,10 @@ func (m *Manager) HeaderStoreRetrieveLoop(ctx context.Context) {
 		case <-m.headerStoreCh:
 		}
 		headerStoreHeight := m.headerStore.Height()
+		m.recordSyncHeadGap(syncSourceP2P, headerStoreHeight, m.GetLastState().LastBlockHeight)
+		if failpoint.Inject(ctx, failpoint.P2PRetrieve) != nil {
+			continue
+		}
 		if headerStoreHeight > lastHeaderStoreHeight {
 			headers, err := m.getHeadersFromHeaderStore(ctx, lastHeaderStoreHeight+1, headerStoreHeight)
 			if err != nil {
@@ -30,7 +35,10 @@ func (m *Manager) HeaderStoreRetrieveLoop(ctx context.Context) {
 				continue
 			}
 			daHeight := m.daHeight.Load()
-			for _, header := range headers {
+			// the signatures of the headers are verified in a batch, so that a node catching up does not verify
+			// them one at a time when validating the headers
+			sigErrs := types.VerifySignatures(ctx, headers)
+			for i, header := range headers {
 				// Check for shut down event prior to logging
 				// and sending header to headerInCh. The reason
 				// for checking for the shutdown event
@@ -43,10 +51,13 @@ func (m *Manager) HeaderStoreRetrieveLoop(ctx context.Context) {
 				default:
 				}
 				// early validation to reject junk headers
-				if !m.isUsingExpectedSingleSequencer(header) {
+				if sigErrs[i] != nil || !m.isUsingExpectedSingleSequencer(header) {
+					m.metrics.SyncVerificationFailures.With("source", syncSourceP2P).Add(1)
 					continue
 				}
+				m.setSoftConfirmedHeight(header.Height())
 				m.logger.Debug("header retrieved from p2p header sync", "headerHeight", header.Height(), "daHeight", daHeight)
+				m.metrics.SyncHeadersReceived.With("source", syncSourceP2P).Add(1)
 				m.headerInCh <- NewHeaderEvent{header, daHeight}
 			}
 		}
@@ -70,6 +81,9 @@ func (m *Manager) DataStoreRetrieveLoop(ctx context.Context) {
 		case <-m.dataStoreCh:
 		}
 		dataStoreHeight := m.dataStore.Height()
+		if failpoint.Inject(ctx, failpoint.P2PRetrieve) != nil {
+			continue
+		}
 		if dataStoreHeight > lastDataStoreHeight {
 			data, err := m.getDataFromDataStore(ctx, lastDataStoreHeight+1, dataStoreHeight)
 			if err != nil {
@@ -91,6 +105,7 @@ func (m *Manager) DataStoreRetrieveLoop(ctx context.Context) {
 				}
 				//TODO: remove junk if possible
 				m.logger.Debug("data retrieved from p2p data sync", "dataHeight", d.Metadata.Height, "daHeight", daHeight)
+				m.metrics.SyncDataReceived.With("source", syncSourceP2P).Add(1)
 				m.dataInCh <- NewDataEvent{d, daHeight}
 			}
 		}
@@ -127,3 +142,11 @@ func (m *Manager) getDataFromDataStore(ctx context.Context, startHeight, endHeig
 	}
 	return data, nil
 }
+
+// saveBlockData saves the block to the store, unless the SaveBlock failpoint fails the write.
+func (m *Manager) saveBlockData(ctx context.Context, header *types.SignedHeader, data *types.Data, signature *types.Signature) error {
+	if err := failpoint.Inject(ctx, failpoint.SaveBlock); err != nil {
+		return err
+	}
+	return m.store.SaveBlockData(ctx, header, data, signature)
+}
@@ -13,6 +13,7 @@ import (
 	ds "github.com/ipfs/go-datastore"
 	"github.com/rollkit/rollkit/pkg/config"
 	"github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/pkg/store"
 	// Use existing store mock if available, or define one
 	mocksStore "github.com/rollkit/rollkit/test/mocks"
 	extmocks "github.com/rollkit/rollkit/test/mocks/external"
@@ -57,6 +58,7 @@ func setupManagerForStoreRetrieveTest(t *testing.T) (
 	// Mock initial metadata reads during manager creation if necessary
 	mockStore.On("GetMetadata", mock.Anything, DAIncludedHeightKey).Return(nil, ds.ErrNotFound).Maybe()
 	mockStore.On("GetMetadata", mock.Anything, LastBatchDataKey).Return(nil, ds.ErrNotFound).Maybe()
+	mockStore.On("GetMetadata", mock.Anything, store.PrunedHeightKey).Return(nil, ds.ErrNotFound).Maybe()
 
 	signer, err := noop.NewNoopSigner(pk)
 	require.NoError(t, err)
@@ -75,6 +77,7 @@ func setupManagerForStoreRetrieveTest(t *testing.T) (
 		lastStateMtx:  new(sync.RWMutex),
 		config:        nodeConf,
 		signer:        signer,
+		metrics:       NopMetrics(),
 	}
 	// Initialize daHeight atomic variable
 	m.init(ctx) // Call init to handle potential DAIncludedHeightKey loading
@@ -0,0 +1,109 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/failpoint"
+)
+
+// txChunkSize is the maximum size of the transactions streamed to a StreamingExecutor in a single call. Larger
+// transactions are streamed alone.
+const txChunkSize = 1 << 20 // 1 MiB
+
+// executeTxs executes the transactions of a block and returns the state root after the block. Executors
+// implementing StreamingExecutor receive the transactions in chunks of up to txChunkSize bytes and report the
+// result of each transaction; other executors, and those returning ErrStreamingNotSupported from BeginBlock,
+// execute the block with a single ExecuteTxs call and report no results.
+func executeTxs(ctx context.Context, exec coreexecutor.Executor, txs [][]byte, blockHeight uint64, timestamp time.Time, prevStateRoot []byte) ([]byte, []coreexecutor.TxResult, error) {
+	if err := failpoint.Inject(ctx, failpoint.ExecuteTxs); err != nil {
+		return nil, nil, err
+	}
+	streaming, ok := exec.(coreexecutor.StreamingExecutor)
+	if !ok {
+		stateRoot, _, err := exec.ExecuteTxs(ctx, txs, blockHeight, timestamp, prevStateRoot)
+		return stateRoot, nil, err
+	}
+	execution, err := streaming.BeginBlock(ctx, blockHeight, timestamp, prevStateRoot)
+	if errors.Is(err, coreexecutor.ErrStreamingNotSupported) {
+		stateRoot, _, err := exec.ExecuteTxs(ctx, txs, blockHeight, timestamp, prevStateRoot)
+		return stateRoot, nil, err
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin block execution: %w", err)
+	}
+
+	results, err := streamTxs(ctx, execution, txs)
+	if err != nil {
+		return nil, nil, errors.Join(err, execution.Abort(ctx))
+	}
+	stateRoot, _, err := execution.Commit(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to commit block execution: %w", err)
+	}
+	return stateRoot, results, nil
+}
+
+// streamTxs streams the transactions to the block execution in chunks of up to txChunkSize bytes, and returns
+// the results of all transactions.
+func streamTxs(ctx context.Context, execution coreexecutor.BlockExecution, txs [][]byte) ([]coreexecutor.TxResult, error) {
+	results := make([]coreexecutor.TxResult, 0, len(txs))
+	for start := 0; start < len(txs); {
+		end, size := start+1, len(txs[start])
+		for end < len(txs) && size+len(txs[end]) <= txChunkSize {
+			size += len(txs[end])
+			end++
+		}
+		chunkResults, err := execution.ExecuteTxs(ctx, txs[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute transactions %d to %d: %w", start, end-1, err)
+		}
+		if len(chunkResults) != end-start {
+			return nil, fmt.Errorf("executor returned %d results for %d transactions", len(chunkResults), end-start)
+		}
+		results = append(results, chunkResults...)
+		start = end
+	}
+	return results, nil
+}
+
+// failedTxs returns the number of failed transactions.
+func failedTxs(results []coreexecutor.TxResult) int {
+	failed := 0
+	for _, result := range results {
+		if result.Code != 0 {
+			failed++
+		}
+	}
+	return failed
+}
+
+// withTxEvents attaches the events emitted by the transactions of the block at the given height to their results,
+// for executors implementing EventEmitter, so that the events are persisted with the results. Executors reporting
+// no results get results holding the events only.
+func (m *Manager) withTxEvents(ctx context.Context, height uint64, nTxs int, results []coreexecutor.TxResult) []coreexecutor.TxResult {
+	emitter, ok := m.exec.(coreexecutor.EventEmitter)
+	if !ok || nTxs == 0 {
+		return results
+	}
+	events, err := emitter.TxEvents(ctx, height)
+	if err != nil || len(events) != nTxs {
+		m.logger.Error("failed to get transaction events, saving results without events", "height", height, "events", len(events), "txs", nTxs, "error", err)
+		return results
+	}
+	if results == nil {
+		results = make([]coreexecutor.TxResult, nTxs)
+		for i := range results {
+			results[i].Index = i
+		}
+	}
+	for i := range results {
+		if results[i].Events == nil && results[i].Index < nTxs {
+			results[i].Events = events[results[i].Index]
+		}
+	}
+	return results
+}
@@ -0,0 +1,166 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/test/mocks"
+)
+
+// streamingExecutor executes blocks with testBlockExecution, or with the mocked v1 ExecuteTxs if beginErr is
+// ErrStreamingNotSupported.
+type streamingExecutor struct {
+	*mocks.Executor
+	beginErr error
+	block    *testBlockExecution
+}
+
+func (e *streamingExecutor) BeginBlock(ctx context.Context, blockHeight uint64, timestamp time.Time, prevStateRoot []byte) (coreexecutor.BlockExecution, error) {
+	if e.beginErr != nil {
+		return nil, e.beginErr
+	}
+	return e.block, nil
+}
+
+// testBlockExecution records the chunks of transactions streamed to it, and fails empty transactions.
+type testBlockExecution struct {
+	executeErr error
+	chunks     [][][]byte
+	committed  bool
+	aborted    bool
+}
+
+func (e *testBlockExecution) ExecuteTxs(ctx context.Context, txs [][]byte) ([]coreexecutor.TxResult, error) {
+	if e.executeErr != nil {
+		return nil, e.executeErr
+	}
+	offset := 0
+	for _, chunk := range e.chunks {
+		offset += len(chunk)
+	}
+	e.chunks = append(e.chunks, txs)
+	results := make([]coreexecutor.TxResult, len(txs))
+	for i, tx := range txs {
+		results[i].Index = offset + i
+		if len(tx) == 0 {
+			results[i].Code, results[i].Log = 1, "empty transaction"
+		}
+	}
+	return results, nil
+}
+
+func (e *testBlockExecution) Commit(ctx context.Context) ([]byte, uint64, error) {
+	e.committed = true
+	return []byte("streamedRoot"), 100, nil
+}
+
+func (e *testBlockExecution) Abort(ctx context.Context) error {
+	e.aborted = true
+	return nil
+}
+
+// streamingTestExecutor returns an executor implementing StreamingExecutor whose v1 ExecuteTxs is mocked.
+func streamingTestExecutor(t *testing.T) *streamingExecutor {
+	return &streamingExecutor{Executor: mocks.NewExecutor(t), block: &testBlockExecution{}}
+}
+
+func TestExecuteTxs_Streaming(t *testing.T) {
+	exec := streamingTestExecutor(t)
+	big := bytes.Repeat([]byte{1}, txChunkSize)
+	txs := [][]byte{[]byte("tx1"), {}, []byte("tx3"), big, []byte("tx5")}
+
+	stateRoot, results, err := executeTxs(t.Context(), exec, txs, 1, time.Now(), []byte("prevRoot"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("streamedRoot"), stateRoot)
+	assert.True(t, exec.block.committed)
+	// transactions are streamed in chunks of up to txChunkSize bytes, larger transactions alone
+	assert.Equal(t, [][][]byte{txs[:3], {big}, {[]byte("tx5")}}, exec.block.chunks)
+	require.Len(t, results, len(txs))
+	for i, result := range results {
+		assert.Equal(t, i, result.Index)
+	}
+	assert.Equal(t, 1, failedTxs(results))
+	assert.Equal(t, "empty transaction", results[1].Log)
+}
+
+func TestExecuteTxs_Abort(t *testing.T) {
+	exec := streamingTestExecutor(t)
+	exec.block.executeErr = errors.New("execution client crashed")
+
+	_, _, err := executeTxs(t.Context(), exec, [][]byte{[]byte("tx1")}, 1, time.Now(), []byte("prevRoot"))
+	require.ErrorIs(t, err, exec.block.executeErr)
+	assert.True(t, exec.block.aborted)
+	assert.False(t, exec.block.committed)
+}
+
+func TestExecuteTxs_Fallback(t *testing.T) {
+	txs := [][]byte{[]byte("tx1"), []byte("tx2")}
+	now := time.Now()
+
+	// executors which cannot stream the block execute it with a single ExecuteTxs call
+	exec := streamingTestExecutor(t)
+	exec.beginErr = coreexecutor.ErrStreamingNotSupported
+	exec.Executor.On("ExecuteTxs", t.Context(), txs, uint64(1), now, []byte("prevRoot")).Return([]byte("root"), uint64(100), nil).Once()
+	stateRoot, results, err := executeTxs(t.Context(), exec, txs, 1, now, []byte("prevRoot"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("root"), stateRoot)
+	assert.Nil(t, results)
+	assert.Empty(t, exec.block.chunks)
+
+	// as do executors which do not implement StreamingExecutor
+	v1 := mocks.NewExecutor(t)
+	v1.On("ExecuteTxs", t.Context(), txs, uint64(1), now, []byte("prevRoot")).Return([]byte("root"), uint64(100), nil).Once()
+	stateRoot, results, err = executeTxs(t.Context(), v1, txs, 1, now, []byte("prevRoot"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("root"), stateRoot)
+	assert.Nil(t, results)
+
+	// other errors are not retried
+	exec = streamingTestExecutor(t)
+	exec.beginErr = errors.New("invalid previous state root")
+	_, _, err = executeTxs(t.Context(), exec, txs, 1, now, []byte("prevRoot"))
+	require.ErrorIs(t, err, exec.beginErr)
+}
+
+// eventEmitterExecutor emits an event per transaction, with the block height as sender.
+type eventEmitterExecutor struct {
+	*mocks.Executor
+	txs int
+}
+
+func (e eventEmitterExecutor) TxEvents(_ context.Context, blockHeight uint64) ([][]coreexecutor.Event, error) {
+	events := make([][]coreexecutor.Event, e.txs)
+	for i := range events {
+		events[i] = []coreexecutor.Event{{Type: "transfer", Attributes: []coreexecutor.EventAttribute{{Key: "height", Value: fmt.Sprint(blockHeight)}}}}
+	}
+	return events, nil
+}
+
+func TestWithTxEvents(t *testing.T) {
+	m := &Manager{exec: eventEmitterExecutor{Executor: mocks.NewExecutor(t), txs: 2}, logger: log.NewNopLogger()}
+	event := []coreexecutor.Event{{Type: "transfer", Attributes: []coreexecutor.EventAttribute{{Key: "height", Value: "3"}}}}
+
+	// the events are attached to the results reported by the executor
+	results := m.withTxEvents(t.Context(), 3, 2, []coreexecutor.TxResult{{Index: 0, GasUsed: 10}, {Index: 1, Code: 1}})
+	assert.Equal(t, []coreexecutor.TxResult{{Index: 0, GasUsed: 10, Events: event}, {Index: 1, Code: 1, Events: event}}, results)
+
+	// executors reporting no results get results holding the events only
+	results = m.withTxEvents(t.Context(), 3, 2, nil)
+	assert.Equal(t, []coreexecutor.TxResult{{Index: 0, Events: event}, {Index: 1, Events: event}}, results)
+
+	// events not matching the transactions are dropped
+	assert.Nil(t, m.withTxEvents(t.Context(), 3, 3, nil))
+
+	// executors not emitting events keep their results
+	m.exec = mocks.NewExecutor(t)
+	assert.Nil(t, m.withTxEvents(t.Context(), 3, 2, nil))
+}
@@ -0,0 +1,226 @@
+package block
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	ds "github.com/ipfs/go-datastore"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+)
+
+// InFlightSubmissionsKey is the key used for persisting the DA submissions whose outcome is unknown in store.
+const InFlightSubmissionsKey = "in-flight-submissions"
+
+// inFlightSubmission is a DA submission whose outcome is unknown, because the DA client returned an error or
+// the node stopped during the submission. The blobs may have been included in the DA layer nevertheless.
+type inFlightSubmission struct {
+	// Commitments are the hex encoded SHA-256 hashes of the submitted blobs.
+	Commitments []string `json:"commitments"`
+	// DAHeight is the lowest DA height at which the blobs may have been included.
+	DAHeight uint64 `json:"da_height"`
+}
+
+// submissionTracker keeps track of the in-flight DA submissions, so that blobs included in the DA layer
+// despite a failed submission are detected instead of being submitted again.
+type submissionTracker struct {
+	mu       sync.Mutex
+	inFlight []inFlightSubmission
+}
+
+// blobCommitment returns the commitment identifying a blob in the submission tracker.
+func blobCommitment(blob []byte) string {
+	hash := sha256.Sum256(blob)
+	return hex.EncodeToString(hash[:])
+}
+
+// loadInFlightSubmissions restores the in-flight DA submissions persisted in store.
+func (m *Manager) loadInFlightSubmissions(ctx context.Context) error {
+	raw, err := m.store.GetMetadata(ctx, InFlightSubmissionsKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load in-flight DA submissions: %w", err)
+	}
+	var inFlight []inFlightSubmission
+	if err := json.Unmarshal(raw, &inFlight); err != nil {
+		return fmt.Errorf("failed to decode in-flight DA submissions: %w", err)
+	}
+	m.submissions.mu.Lock()
+	defer m.submissions.mu.Unlock()
+	m.submissions.inFlight = inFlight
+	return nil
+}
+
+// saveInFlightSubmissions persists the in-flight DA submissions. It must be called with submissions.mu held.
+func (m *Manager) saveInFlightSubmissions(ctx context.Context) {
+	raw, err := json.Marshal(m.submissions.inFlight)
+	if err != nil {
+		m.logger.Error("failed to encode in-flight DA submissions", "error", err)
+		return
+	}
+	if err := m.store.SetMetadata(ctx, InFlightSubmissionsKey, raw); err != nil {
+		m.logger.Error("failed to save in-flight DA submissions", "error", err)
+	}
+}
+
+// beginSubmission records the submission of the blobs before they are submitted, with the DA height from
+// which they may be included. Blobs already in flight keep their original DA height.
+func (m *Manager) beginSubmission(ctx context.Context, blobs [][]byte) {
+	m.submissions.mu.Lock()
+	defer m.submissions.mu.Unlock()
+	recorded := make(map[string]bool)
+	for _, s := range m.submissions.inFlight {
+		for _, c := range s.Commitments {
+			recorded[c] = true
+		}
+	}
+	submission := inFlightSubmission{DAHeight: m.lastSubmissionDAHeight(ctx)}
+	for _, blob := range blobs {
+		if c := blobCommitment(blob); !recorded[c] {
+			submission.Commitments = append(submission.Commitments, c)
+		}
+	}
+	if len(submission.Commitments) == 0 {
+		return
+	}
+	m.submissions.inFlight = append(m.submissions.inFlight, submission)
+	m.saveInFlightSubmissions(ctx)
+}
+
+// endSubmission forgets the blobs, once the outcome of their submission is known.
+func (m *Manager) endSubmission(ctx context.Context, blobs [][]byte) {
+	done := make(map[string]bool, len(blobs))
+	for _, blob := range blobs {
+		done[blobCommitment(blob)] = true
+	}
+	m.submissions.mu.Lock()
+	defer m.submissions.mu.Unlock()
+	changed := false
+	inFlight := m.submissions.inFlight[:0]
+	for _, s := range m.submissions.inFlight {
+		commitments := s.Commitments[:0]
+		for _, c := range s.Commitments {
+			if done[c] {
+				changed = true
+				continue
+			}
+			commitments = append(commitments, c)
+		}
+		if len(commitments) > 0 {
+			s.Commitments = commitments
+			inFlight = append(inFlight, s)
+		}
+	}
+	m.submissions.inFlight = inFlight
+	if changed {
+		m.saveInFlightSubmissions(ctx)
+	}
+}
+
+// clearInFlightSubmissions forgets all in-flight submissions.
+func (m *Manager) clearInFlightSubmissions(ctx context.Context) {
+	m.submissions.mu.Lock()
+	defer m.submissions.mu.Unlock()
+	if len(m.submissions.inFlight) == 0 {
+		return
+	}
+	m.submissions.inFlight = nil
+	m.saveInFlightSubmissions(ctx)
+}
+
+// inFlightDAHeight returns the lowest DA height at which blobs of in-flight submissions may have been
+// included, and false if there are no in-flight submissions.
+func (m *Manager) inFlightDAHeight(commitments map[string]bool) (uint64, bool) {
+	m.submissions.mu.Lock()
+	defer m.submissions.mu.Unlock()
+	var (
+		daHeight uint64
+		found    bool
+	)
+	for _, s := range m.submissions.inFlight {
+		for _, c := range s.Commitments {
+			if commitments != nil && !commitments[c] {
+				continue
+			}
+			if !found || s.DAHeight < daHeight {
+				daHeight, found = s.DAHeight, true
+			}
+		}
+	}
+	return daHeight, found
+}
+
+// findSubmittedBlobs looks up the DA layer for blobs of a previous submission whose outcome is unknown. If the
+// first blobs are found, it returns a successful result for them, so that they are not submitted again.
+func (m *Manager) findSubmittedBlobs(ctx context.Context, blobs [][]byte) (coreda.ResultSubmit, bool) {
+	commitments := make(map[string]bool, len(blobs))
+	for _, blob := range blobs {
+		commitments[blobCommitment(blob)] = true
+	}
+	startDAHeight, ok := m.inFlightDAHeight(commitments)
+	if !ok {
+		return coreda.ResultSubmit{}, false
+	}
+
+	included := make(map[string]uint64)
+	for daHeight := startDAHeight; daHeight < startDAHeight+maxRecoveryDAHeights && len(included) < len(commitments); daHeight++ {
+		res, err := m.fetchBlobs(ctx, daHeight)
+		if err != nil {
+			if !m.areAllErrorsHeightFromFuture(err) {
+				m.logger.Error("failed to look up in-flight blobs in DA layer", "daHeight", daHeight, "error", err)
+			}
+			break
+		}
+		for _, blob := range res.Data {
+			if c := blobCommitment(blob); commitments[c] {
+				included[c] = daHeight
+			}
+		}
+	}
+
+	var (
+		count    int
+		daHeight uint64
+	)
+	for ; count < len(blobs); count++ {
+		h, ok := included[blobCommitment(blobs[count])]
+		if !ok {
+			break
+		}
+		daHeight = max(daHeight, h)
+	}
+	if count == 0 {
+		return coreda.ResultSubmit{}, false
+	}
+	m.logger.Info("blobs of a failed DA submission were included in the DA layer, not submitting them again", "count", count, "daHeight", daHeight)
+	m.endSubmission(ctx, blobs[:count])
+	return coreda.ResultSubmit{
+		BaseResult: coreda.BaseResult{
+			Code:           coreda.StatusSuccess,
+			Message:        "blobs already included in the DA layer",
+			SubmittedCount: uint64(count),
+			Height:         daHeight,
+		},
+	}, true
+}
+
+// lastSubmissionDAHeight returns the DA height of the last successful DA submission, or the DA height of the
+// node if no submission was recorded.
+func (m *Manager) lastSubmissionDAHeight(ctx context.Context) uint64 {
+	raw, err := m.store.GetMetadata(ctx, LastSubmissionDAHeightKey)
+	if err != nil || len(raw) != 8 {
+		if m.daHeight == nil {
+			return 0
+		}
+		return m.daHeight.Load()
+	}
+	return binary.LittleEndian.Uint64(raw)
+}
@@ -0,0 +1,98 @@
+package block
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/test/mocks"
+)
+
+// TestSubmitToDA_InFlightBlobsIncluded verifies that blobs of a failed submission that were included in the
+// DA layer nevertheless are not submitted again.
+func TestSubmitToDA_InFlightBlobsIncluded(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	headers, _ := saveSignedBlocks(t, s, 1)
+
+	daHeight := make([]byte, 8)
+	binary.LittleEndian.PutUint64(daHeight, 5)
+	require.NoError(t, s.SetMetadata(ctx, LastSubmissionDAHeightKey, daHeight))
+
+	blobs := [][]byte{[]byte("blob1"), []byte("blob2"), []byte("blob3")}
+	mockDA := mocks.NewDA(t)
+	// the DA client times out, although the first two blobs are included
+	mockDA.On("GasMultiplier", mock.Anything).Return(1.0, nil).Maybe()
+	mockDA.On("SubmitWithOptions", mock.Anything, blobs, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, coreda.ErrTxTimedOut).Once()
+
+	m, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	m.da = mockDA
+	res, _ := m.submitToDA(ctx, mockDA, blobs, 1)
+	require.Equal(t, coreda.StatusNotIncludedInBlock, res.Code)
+
+	// the submission is persisted with the DA height from which the blobs may be included
+	restarted, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	restarted.da = mockDA
+	require.NoError(t, restarted.loadInFlightSubmissions(ctx))
+	startDAHeight, ok := restarted.inFlightDAHeight(nil)
+	require.True(t, ok)
+	assert.Equal(t, uint64(5), startDAHeight)
+
+	ids := []coreda.ID{[]byte("id1"), []byte("id2")}
+	mockDA.On("GetIDs", mock.Anything, uint64(5), mock.Anything).Return(&coreda.GetIDsResult{}, nil).Once()
+	mockDA.On("GetIDs", mock.Anything, uint64(6), mock.Anything).Return(&coreda.GetIDsResult{IDs: ids}, nil).Once()
+	mockDA.On("Get", mock.Anything, ids, mock.Anything).Return([]coreda.Blob{blobs[1], blobs[0]}, nil).Once()
+	mockDA.On("GetIDs", mock.Anything, uint64(7), mock.Anything).Return(nil, ErrHeightFromFutureStr).Once()
+
+	res, _ = restarted.submitToDA(ctx, mockDA, blobs, 1)
+	require.Equal(t, coreda.StatusSuccess, res.Code)
+	assert.Equal(t, uint64(2), res.SubmittedCount)
+	assert.Equal(t, uint64(6), res.Height)
+
+	// only the last blob is still in flight
+	restarted.submissions.mu.Lock()
+	inFlight := restarted.submissions.inFlight
+	restarted.submissions.mu.Unlock()
+	require.Len(t, inFlight, 1)
+	assert.Equal(t, []string{blobCommitment(blobs[2])}, inFlight[0].Commitments)
+
+	mockDA.AssertNumberOfCalls(t, "SubmitWithOptions", 1)
+}
+
+// TestRecover_InFlightSubmissions verifies that in-flight submissions are reconciled on start after a clean shutdown.
+func TestRecover_InFlightSubmissions(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	headers, _ := saveSignedBlocks(t, s, 1)
+
+	m, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	require.NoError(t, m.RecordCleanShutdown(ctx))
+	m.submissions.inFlight = []inFlightSubmission{{Commitments: []string{blobCommitment([]byte("blob"))}, DAHeight: 3}}
+	m.saveInFlightSubmissions(ctx)
+
+	mockDA := mocks.NewDA(t)
+	mockDA.On("GetIDs", mock.Anything, uint64(3), mock.Anything).Return(nil, ErrHeightFromFutureStr).Once()
+
+	restarted, _, _, _ := newTestManager(t, withStore(s), withGenesis(genesis.Genesis{ProposerAddress: headers[0].ProposerAddress}), withPendingHeaders())
+	restarted.da = mockDA
+	require.NoError(t, restarted.loadShutdownState(ctx))
+	require.False(t, restarted.uncleanShutdown)
+	require.NoError(t, restarted.Recover(ctx))
+
+	// blobs not found in the DA layer are submitted again
+	_, ok := restarted.inFlightDAHeight(nil)
+	assert.False(t, ok)
+	require.NoError(t, restarted.Recover(ctx))
+}
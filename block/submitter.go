@@ -3,6 +3,8 @@ package block
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"google.golang.org/protobuf/proto"
@@ -13,34 +15,80 @@ import (
 	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
 )
 
-// HeaderSubmissionLoop is responsible for submitting headers to the DA layer.
+// HeaderSubmissionLoop is responsible for submitting headers to the DA layer. Submissions are pipelined: every
+// DA block time, a submission of the headers produced since the last submission starts, while fewer than
+// DA.MaxSubmissionsInFlight submissions are in flight. Block production never waits for DA round trips, and
+// blocks keep being submitted when a round trip exceeds the DA block time.
+//
+// Aggregators posting in epochs check every block time whether an epoch is due instead, and then submit the
+// headers of the epoch and hand its block data to BatchSubmissionLoop.
 func (m *Manager) HeaderSubmissionLoop(ctx context.Context) {
-	timer := time.NewTicker(m.config.DA.BlockTime.Duration)
+	interval := m.config.DA.BlockTime.Duration
+	if m.epochs != nil {
+		interval = m.config.Node.BlockTime.Duration
+	}
+	timer := time.NewTicker(interval)
 	defer timer.Stop()
+	slots := make(chan struct{}, max(m.config.DA.MaxSubmissionsInFlight, 1))
+	var wg sync.WaitGroup
+	// in-flight submissions complete before the loop returns, see DrainDASubmissions
+	defer wg.Wait()
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-timer.C:
 		}
-		if m.pendingHeaders.isEmpty() {
+		if m.pendingHeaders.isEmpty() || m.daBudgetExhausted() {
 			continue
 		}
-		err := m.submitHeadersToDA(ctx)
-		if err != nil {
-			m.logger.Error("error while submitting header to DA", "error", err)
+		if m.epochs != nil && !m.epochs.due(m.pendingHeaders.numUndispatchedHeaders(), time.Now()) {
+			continue
+		}
+		select {
+		case slots <- struct{}{}:
+		default:
+			m.logger.Debug("maximum number of DA submissions in flight, delaying header submission")
+			continue
+		}
+		if m.epochs != nil {
+			m.epochs.close(time.Now())
 		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if err := m.submitHeadersToDA(ctx); err != nil {
+				m.logger.Error("error while submitting header to DA", "error", err)
+			}
+		}()
 	}
 }
 
+// SyncLastSubmittedHeight marks the headers already included in the DA layer as submitted, so that an
+// aggregator taking over block production from another aggregator does not submit them again.
+func (m *Manager) SyncLastSubmittedHeight(ctx context.Context) {
+	m.pendingHeaders.setLastSubmittedHeight(ctx, m.GetDAIncludedHeight())
+}
+
+// submitHeadersToDA submits the pending headers not handed to another submission yet. Headers which are not
+// submitted are handed to the next submission. Headers are only simulated if DA.DryRun is set, see
+// DryRunDASubmission.
 func (m *Manager) submitHeadersToDA(ctx context.Context) error {
 	submittedAllHeaders := false
 	var backoff time.Duration
-	headersToSubmit, err := m.pendingHeaders.getPendingHeaders(ctx)
+	headers, err := m.pendingHeaders.nextHeaders(ctx)
+	// headers are submitted to the namespace at their height, which may be changed by an upgrade
+	headersToSubmit := m.sameNamespaceHeaders(m.attachProofCommitments(ctx, headers))
+	// headersToSubmit always holds the headers left to submit
+	defer func() {
+		m.pendingHeaders.release(headersToSubmit)
+	}()
+	m.pendingHeaders.release(headers[len(headersToSubmit):])
 	if len(headersToSubmit) == 0 {
 		// There are no pending headers; return because there's nothing to do, but:
 		// - it might be caused by error, then err != nil
-		// - all pending headers are processed, then err == nil
+		// - all pending headers are processed or waiting for validity proofs, then err == nil
 		// whatever the reason, error information is propagated correctly to the caller
 		return err
 	}
@@ -50,229 +98,496 @@ func (m *Manager) submitHeadersToDA(ctx context.Context) error {
 		// The error is logged and normal processing of pending headers continues.
 		m.logger.Error("error while fetching headers pending DA", "err", err)
 	}
+	da, err := m.headerDA(headersToSubmit[0].Height())
+	if err != nil {
+		return err
+	}
+	if m.config.DA.DryRun {
+		m.dryRunPendingHeaders(ctx, da, headersToSubmit)
+		return nil
+	}
 	numSubmittedHeaders := 0
-	attempt := 0
-
-	gasPrice := m.gasPrice
-	initialGasPrice := gasPrice
+	retries := m.config.Retry.DA.Policy().NewBackoff()
 
 daSubmitRetryLoop:
-	for !submittedAllHeaders && attempt < maxSubmitAttempts {
+	for !submittedAllHeaders && !retries.Done() {
 		select {
 		case <-ctx.Done():
 			break daSubmitRetryLoop
 		case <-time.After(backoff):
 		}
+		if retries.Attempts() > 0 {
+			m.metrics.DASubmissionRetries.Add(1)
+		}
 
-		headersBz := make([][]byte, len(headersToSubmit))
-		for i, header := range headersToSubmit {
-			headerPb, err := header.ToProto()
-			if err != nil {
-				// do we drop the header from attempting to be submitted?
-				return fmt.Errorf("failed to transform header to proto: %w", err)
-			}
-			headersBz[i], err = proto.Marshal(headerPb)
-			if err != nil {
-				// do we drop the header from attempting to be submitted?
-				return fmt.Errorf("failed to marshal header: %w", err)
-			}
+		blobs, blobSizes, err := m.headerBlobs(headersToSubmit)
+		if err != nil {
+			// do we drop the header from attempting to be submitted?
+			return err
 		}
 
-		ctx, cancel := context.WithTimeout(ctx, 60*time.Second) //TODO: make this configurable
-		res := types.SubmitWithHelpers(ctx, m.da, m.logger, headersBz, gasPrice, nil)
-		cancel()
+		gasPrice := m.gasPricer.price()
+		res, backends := m.submitToDA(ctx, da, blobs, gasPrice)
 
 		switch res.Code {
 		case coreda.StatusSuccess:
-			m.logger.Info("successfully submitted Rollkit headers to DA layer", "gasPrice", gasPrice, "daHeight", res.Height, "headerCount", res.SubmittedCount)
-			if res.SubmittedCount == uint64(len(headersToSubmit)) {
+			submittedCount := headersInBlobs(blobSizes, res.SubmittedCount)
+			m.logger.Info("successfully submitted Rollkit headers to DA layer", "gasPrice", gasPrice, "daHeight", res.Height, "headerCount", submittedCount, "blobCount", res.SubmittedCount)
+			if submittedCount == len(headersToSubmit) {
 				submittedAllHeaders = true
 			}
-			submittedHeaders, notSubmittedHeaders := headersToSubmit[:res.SubmittedCount], headersToSubmit[res.SubmittedCount:]
+			submittedHeaders, notSubmittedHeaders := headersToSubmit[:submittedCount], headersToSubmit[submittedCount:]
 			numSubmittedHeaders += len(submittedHeaders)
-			for _, header := range submittedHeaders {
-				m.headerCache.SetDAIncluded(header.Hash().String())
+			blob, blobEnd := 0, blobSizes[0]
+			for i, header := range submittedHeaders {
+				if i == blobEnd {
+					blob++
+					blobEnd += blobSizes[blob]
+				}
+				headerHash := header.Hash().String()
+				if blob < len(res.IDs) {
+					m.setDAPointer(headerHash, res.Height, res.IDs[blob])
+				}
+				if m.daQuorumReached(headerHash, backends) {
+					m.headerCache.SetDAIncluded(headerHash)
+				}
+			}
+			if m.epochs != nil && len(submittedHeaders) > 0 {
+				m.epochs.posted(submittedHeaders[len(submittedHeaders)-1].Height())
 			}
-			lastSubmittedHeight := uint64(0)
-			if l := len(submittedHeaders); l > 0 {
-				lastSubmittedHeight = submittedHeaders[l-1].Height()
+			m.pendingHeaders.markSubmitted(ctx, submittedHeaders)
+			if len(submittedHeaders) > 0 {
+				m.events.publish(Event{Type: EventBlobSubmitted, Height: submittedHeaders[len(submittedHeaders)-1].Height(), DAHeight: res.Height})
 			}
-			m.pendingHeaders.setLastSubmittedHeight(ctx, lastSubmittedHeight)
 			headersToSubmit = notSubmittedHeaders
 			m.sendNonBlockingSignalToDAIncluderCh()
 			// reset submission options when successful
 			// scale back gasPrice gradually
 			backoff = 0
-			if m.gasMultiplier > 0 && gasPrice != -1 {
-				gasPrice = gasPrice / m.gasMultiplier
-				gasPrice = max(gasPrice, initialGasPrice)
-			}
+			retries.Reset()
+			gasPrice = m.decayGasPrice(ctx)
 			m.logger.Debug("resetting DA layer submission options", "backoff", backoff, "gasPrice", gasPrice)
-		case coreda.StatusNotIncludedInBlock, coreda.StatusAlreadyInMempool:
-			m.logger.Error("DA layer submission failed", "error", res.Message, "attempt", attempt)
+		case coreda.StatusNotIncludedInBlock, coreda.StatusAlreadyInMempool, coreda.StatusUnderpriced:
+			m.logger.Error("DA layer submission failed", "error", res.Message, "attempt", retries.Attempts())
 			backoff = m.config.DA.BlockTime.Duration * time.Duration(m.config.DA.MempoolTTL) //nolint:gosec
-			if m.gasMultiplier > 0 && gasPrice != -1 {
-				gasPrice = gasPrice * m.gasMultiplier
-			}
+			gasPrice = m.bumpGasPrice(ctx)
 			m.logger.Info("retrying DA layer submission with", "backoff", backoff, "gasPrice", gasPrice)
 		default:
-			m.logger.Error("DA layer submission failed", "error", res.Message, "attempt", attempt)
-			backoff = m.exponentialBackoff(backoff)
+			m.logger.Error("DA layer submission failed", "error", res.Message, "attempt", retries.Attempts())
+			backoff = retries.Next()
+			// some backends of a DA multiplexer may have included the headers even if the quorum was not reached
+			for _, header := range headersToSubmit[:headersInBlobs(blobSizes, res.SubmittedCount)] {
+				if headerHash := header.Hash().String(); m.daQuorumReached(headerHash, backends) {
+					m.headerCache.SetDAIncluded(headerHash)
+				}
+			}
 		}
 
-		attempt += 1
+		retries.Count()
 	}
 
 	if !submittedAllHeaders {
+		m.metrics.DASubmissionFailures.Add(1)
 		return fmt.Errorf(
 			"failed to submit all headers to DA layer, submitted %d headers (%d left) after %d attempts",
 			numSubmittedHeaders,
 			len(headersToSubmit),
-			attempt,
+			retries.Attempts(),
 		)
 	}
 	return nil
 }
 
-// BatchSubmissionLoop is responsible for submitting batches to the DA layer.
+// headerBlobs encodes the headers into DA blobs, bundling up to DA.MaxBlocksPerBlob consecutive headers into a
+// single compressed blob, or all the headers of an epoch when posting in epochs. It returns the blobs and the
+// number of headers encoded in each blob.
+func (m *Manager) headerBlobs(headers []*types.SignedHeader) ([][]byte, []int, error) {
+	headersBz := make([][]byte, len(headers))
+	for i, header := range headers {
+		headerPb, err := header.ToProto()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to transform header to proto: %w", err)
+		}
+		headersBz[i], err = proto.Marshal(headerPb)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to marshal header: %w", err)
+		}
+	}
+
+	perBlob := max(m.config.DA.MaxBlocksPerBlob, 1)
+	if m.epochs != nil {
+		perBlob = max(perBlob, len(headersBz))
+	}
+	blobs := make([][]byte, 0, (len(headersBz)+perBlob-1)/perBlob)
+	sizes := make([]int, 0, cap(blobs))
+	for chunk := range slices.Chunk(headersBz, perBlob) {
+		sizes = append(sizes, len(chunk))
+		if len(chunk) == 1 && !m.blobEnvelopes() {
+			blobs = append(blobs, chunk[0])
+			continue
+		}
+		bz := chunk[0]
+		if len(chunk) > 1 {
+			bz = types.BundleBlobs(chunk)
+		}
+		blob, err := m.sealBlob(bz)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to compress header bundle: %w", err)
+		}
+		blobs = append(blobs, blob)
+	}
+	return blobs, sizes, nil
+}
+
+// sealBlob wraps an encoded header, batch or bundle in a versioned envelope compressed with the blob codec, or
+// only compresses it if envelopes are disabled.
+func (m *Manager) sealBlob(bz []byte) ([]byte, error) {
+	if m.blobEnvelopes() {
+		return types.SealBlob(m.genesis.ChainID, m.blobCodec, bz)
+	}
+	return types.CompressBlob(m.blobCodec, bz)
+}
+
+// blobEnvelopes reports whether blobs are submitted in versioned envelopes. Envelopes are required in DA
+// namespaces shared with other chains, since they record the chain the blobs belong to.
+func (m *Manager) blobEnvelopes() bool {
+	return m.config.DA.BlobEnvelope || m.config.DA.SharedNamespace
+}
+
+// headersInBlobs returns the number of headers encoded in the first n blobs, given the number of headers encoded
+// in each blob.
+func headersInBlobs(sizes []int, n uint64) int {
+	count := 0
+	for _, size := range sizes[:min(n, uint64(len(sizes)))] {
+		count += size
+	}
+	return count
+}
+
+// BatchSubmissionLoop is responsible for submitting batches to the DA layer. The batches received from the
+// sequencer are persisted in the pending batches queue right away, while earlier batches are being submitted.
+// Aggregators posting in epochs submit all the queued batches together once an epoch is closed.
 func (m *Manager) BatchSubmissionLoop(ctx context.Context) {
+	queued := make(chan struct{}, 1)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case batch := <-m.batchSubmissionChan:
+				m.queueBatch(ctx, batch)
+				select {
+				case queued <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+	defer wg.Wait()
+
+	var epochClosed <-chan struct{}
+	if m.epochs != nil {
+		epochClosed = m.epochs.closed
+	}
 	for {
+		if m.daBudgetExhausted() {
+			// the batches stay queued until the budget allows submitting them
+			select {
+			case <-ctx.Done():
+				m.logger.Info("Batch submission loop stopped")
+				return
+			case <-time.After(m.config.DA.BlockTime.Duration):
+			}
+			continue
+		}
+		if batches := m.batchesToSubmit(); len(batches) > 0 {
+			err := m.submitBatchesToDA(ctx, batches)
+			if ctx.Err() != nil {
+				// the batches stay queued, they are submitted by DrainDASubmissions or after a restart
+				m.logger.Info("Batch submission loop stopped")
+				return
+			}
+			if err != nil {
+				m.logger.Error("failed to submit batch to DA", "error", err)
+			}
+			m.popPendingBatches(ctx, len(batches))
+			continue
+		}
 		select {
 		case <-ctx.Done():
 			m.logger.Info("Batch submission loop stopped")
 			return
-		case batch := <-m.batchSubmissionChan:
-			err := m.submitBatchToDA(ctx, batch)
-			if err != nil {
-				m.logger.Error("failed to submit batch to DA", "error", err)
-			}
+		case <-queued:
+		case <-epochClosed:
 		}
 	}
 }
 
-// submitBatchToDA submits a batch of transactions to the Data Availability (DA) layer.
+// batchesToSubmit returns the batches to submit to the DA layer next: the oldest queued batch, or all the queued
+// batches once an epoch is closed when posting in epochs.
+func (m *Manager) batchesToSubmit() []coresequencer.Batch {
+	if m.epochs == nil {
+		if batch, ok := m.nextPendingBatch(); ok {
+			return []coresequencer.Batch{batch}
+		}
+		return nil
+	}
+	if !m.epochs.takeBatchesDue() {
+		return nil
+	}
+	return m.allPendingBatches()
+}
+
+// submitBatchToDA submits a batch of transactions to the Data Availability (DA) layer, see submitBatchesToDA.
+func (m *Manager) submitBatchToDA(ctx context.Context, batch coresequencer.Batch) error {
+	return m.submitBatchesToDA(ctx, []coresequencer.Batch{batch})
+}
+
+// submitBatchesToDA submits batches of transactions to the Data Availability (DA) layer in a single submission.
 // It implements a retry mechanism with exponential backoff and gas price adjustments
 // to handle various failure scenarios.
 //
-// The function attempts to submit a batch multiple times (up to maxSubmitAttempts),
-// handling partial submissions where only some transactions within the batch are accepted.
+// An encoded batch is split into parts when it exceeds the maximum blob size of the DA layer, so that a
+// single large batch does not wedge the submission. The function attempts to submit the parts multiple
+// times (following the DA retry policy of the configuration), handling partial submissions where only some
+// parts are accepted.
 // Different strategies are used based on the response from the DA layer:
-// - On success: Reduces gas price gradually (but not below initial price)
-// - On mempool issues: Increases gas price and uses a longer backoff
-// - On size issues: Reduces the blob size and uses exponential backoff
+// - On success: Reduces gas price gradually (but not below the configured floor)
+// - On mempool or underpriced issues: Increases gas price and uses a longer backoff
+// - On size issues: Reduces the maximum blob size, splits the batches again and uses exponential backoff
 // - On other errors: Uses exponential backoff
 //
-// It returns an error if not all transactions could be submitted after all attempts.
-func (m *Manager) submitBatchToDA(ctx context.Context, batch coresequencer.Batch) error {
-	currentBatch := batch
-	submittedAllTxs := false
-	var backoff time.Duration
-	totalTxCount := len(batch.Transactions)
-	submittedTxCount := 0
-	attempt := 0
+// It returns an error if not all parts could be submitted after all attempts. Batches are only simulated if
+// DA.DryRun is set, see DryRunDASubmission.
+func (m *Manager) submitBatchesToDA(ctx context.Context, batches []coresequencer.Batch) error {
+	if m.config.DA.DryRun {
+		m.dryRunPendingBatches(ctx, batches)
+		return nil
+	}
+	// Convert batches to protobuf and marshal
+	encoded := make([][]byte, len(batches))
+	for i, batch := range batches {
+		batchPb := &pb.Batch{
+			Txs: batch.Transactions,
+		}
+		batchBz, err := proto.Marshal(batchPb)
+		if err != nil {
+			return fmt.Errorf("failed to marshal batch: %w", err)
+		}
+		encoded[i], err = m.sealBlob(batchBz)
+		if err != nil {
+			return fmt.Errorf("failed to compress batch: %w", err)
+		}
+	}
 
-	// Store initial values to be able to reset or compare later
-	initialGasPrice := m.gasPrice
-	gasPrice := initialGasPrice
+	var (
+		// parts holds the blob parts left to submit, and batchParts the number of parts left to submit of the
+		// batches from batches[submitted] on
+		parts      [][]byte
+		batchParts []int
+		submitted  int
+		totalParts int
+		backoff    time.Duration
+		retries    = m.config.Retry.DA.Policy().NewBackoff()
+	)
 
 daSubmitRetryLoop:
-	for !submittedAllTxs && attempt < maxSubmitAttempts {
+	for submitted < len(batches) && !retries.Done() {
 		// Wait for backoff duration or exit if context is done
 		select {
 		case <-ctx.Done():
 			break daSubmitRetryLoop
 		case <-time.After(backoff):
 		}
-
-		// Convert batch to protobuf and marshal
-		batchPb := &pb.Batch{
-			Txs: currentBatch.Transactions,
-		}
-		batchBz, err := proto.Marshal(batchPb)
-		if err != nil {
-			return fmt.Errorf("failed to marshal batch: %w", err)
+		if retries.Attempts() > 0 {
+			m.metrics.DASubmissionRetries.Add(1)
 		}
 
-		// Attempt to submit the batch to the DA layer using the helper function
-		res := types.SubmitWithHelpers(ctx, m.da, m.logger, [][]byte{batchBz}, gasPrice, nil)
-
-		gasMultiplier, multErr := m.da.GasMultiplier(ctx)
-		if multErr != nil {
-			m.logger.Error("failed to get gas multiplier", "error", multErr)
-			gasMultiplier = 0
+		// (Re)split the batches left to submit by the current maximum blob size
+		if parts == nil {
+			for _, batchBz := range encoded[submitted:] {
+				split, err := m.splitBatchBlob(ctx, m.dataDAClient(), batchBz)
+				if err != nil {
+					return fmt.Errorf("failed to split batch: %w", err)
+				}
+				if len(split) > 1 {
+					m.logger.Info("splitting batch across DA blobs", "size", len(batchBz), "parts", len(split))
+				}
+				parts = append(parts, split...)
+				batchParts = append(batchParts, len(split))
+			}
+			totalParts = len(parts)
 		}
 
+		// Attempt to submit the parts to the DA layer using the helper function
+		gasPrice := m.gasPricer.price()
+		res, backends := m.submitToDA(ctx, m.dataDAClient(), parts, gasPrice)
+
 		switch res.Code {
 		case coreda.StatusSuccess:
-			submittedTxs := int(res.SubmittedCount)
-			m.logger.Info("successfully submitted transactions to DA layer",
+			submittedParts := min(int(res.SubmittedCount), len(parts))
+			m.logger.Info("successfully submitted batch to DA layer",
 				"gasPrice", gasPrice,
 				"height", res.Height,
-				"submittedTxs", submittedTxs,
-				"remainingTxs", len(currentBatch.Transactions)-submittedTxs)
-
-			submittedTxCount += submittedTxs
+				"batches", len(batches)-submitted,
+				"submittedParts", submittedParts,
+				"remainingParts", len(parts)-submittedParts)
 
-			// Check if all transactions in the current batch were submitted
-			if submittedTxs == len(currentBatch.Transactions) {
-				submittedAllTxs = true
-			} else {
-				// Update the current batch to contain only the remaining transactions
-				currentBatch.Transactions = currentBatch.Transactions[submittedTxs:]
-			}
+			// Keep only the remaining parts
+			parts = parts[submittedParts:]
 
 			// Reset submission parameters after success
 			backoff = 0
+			retries.Reset()
 
-			// Gradually reduce gas price on success, but not below initial price
-			if gasMultiplier > 0 && gasPrice != 0 {
-				gasPrice = gasPrice / gasMultiplier
-				if gasPrice < initialGasPrice {
-					gasPrice = initialGasPrice
-				}
-			}
+			// Gradually reduce gas price on success, but not below the floor
+			gasPrice = m.decayGasPrice(ctx)
 			m.logger.Debug("resetting DA layer submission options", "backoff", backoff, "gasPrice", gasPrice)
-			// Set DA included in manager's dataCache if all txs submitted and manager is set
-			if submittedAllTxs {
-				data := &types.Data{
-					Txs: make(types.Txs, len(currentBatch.Transactions)),
-				}
-				for i, tx := range currentBatch.Transactions {
-					data.Txs[i] = types.Tx(tx)
-				}
-				m.DataCache().SetDAIncluded(data.DACommitment().String())
+			// Set DA included in manager's dataCache the batches whose parts were all submitted, the data
+			// being retrieved with the last part
+			consumed, included := 0, false
+			for len(batchParts) > 0 && consumed+batchParts[0] <= submittedParts {
+				consumed += batchParts[0]
+				m.setBatchDAIncluded(batches[submitted], res, consumed-1, backends)
+				batchParts = batchParts[1:]
+				submitted++
+				included = true
+			}
+			if len(batchParts) > 0 {
+				batchParts[0] -= submittedParts - consumed
+			}
+			if included {
 				m.sendNonBlockingSignalToDAIncluderCh()
 			}
+			if submitted < len(batches) && submittedParts > 0 {
+				// submissions making progress do not count as attempts, so that all parts of
+				// large batches can be submitted
+				continue
+			}
 
-		case coreda.StatusNotIncludedInBlock, coreda.StatusAlreadyInMempool:
-			m.logger.Error("DA layer submission failed", "error", res.Message, "attempt", attempt)
+		case coreda.StatusNotIncludedInBlock, coreda.StatusAlreadyInMempool, coreda.StatusUnderpriced:
+			m.logger.Error("DA layer submission failed", "error", res.Message, "attempt", retries.Attempts())
 			backoff = m.config.DA.BlockTime.Duration * time.Duration(m.config.DA.MempoolTTL)
-			if gasMultiplier > 0 && gasPrice != 0 {
-				gasPrice = gasPrice * gasMultiplier
-			}
+			gasPrice = m.bumpGasPrice(ctx)
 			m.logger.Info("retrying DA layer submission with", "backoff", backoff, "gasPrice", gasPrice)
 
 		case coreda.StatusTooBig:
-			// Blob size adjustment is handled within DA impl or SubmitWithOptions call
-			// fallthrough to default exponential backoff
-			fallthrough
+			// Split the batches left to submit again into smaller parts, the parts already submitted
+			// of a partially submitted batch are superseded by the new ones
+			m.logger.Error("DA layer submission failed", "error", res.Message, "attempt", retries.Attempts())
+			largest := 0
+			for _, part := range parts {
+				largest = max(largest, len(part))
+			}
+			m.reduceMaxBlobSize(largest)
+			parts, batchParts = nil, nil
+			backoff = retries.Next()
 
 		default:
-			m.logger.Error("DA layer submission failed", "error", res.Message, "attempt", attempt)
-			backoff = m.exponentialBackoff(backoff)
+			m.logger.Error("DA layer submission failed", "error", res.Message, "attempt", retries.Attempts())
+			backoff = retries.Next()
 		}
 
-		attempt++
+		retries.Count()
 	}
 
-	// Return error if not all transactions were submitted after all attempts
-	if !submittedAllTxs {
+	// Return error if not all parts were submitted after all attempts
+	if submitted < len(batches) {
+		m.metrics.DASubmissionFailures.Add(1)
 		return fmt.Errorf(
-			"failed to submit all transactions to DA layer, submitted %d txs (%d left) after %d attempts",
-			submittedTxCount,
-			totalTxCount-submittedTxCount,
-			attempt,
+			"failed to submit all transactions to DA layer, %d of %d blob parts left after %d attempts",
+			len(parts),
+			totalParts,
+			retries.Attempts(),
 		)
 	}
 	return nil
 }
+
+// setBatchDAIncluded marks the data of a batch as DA included, once all its parts were submitted. lastPart is the
+// index in the submission result of the ID of its last part.
+func (m *Manager) setBatchDAIncluded(batch coresequencer.Batch, res coreda.ResultSubmit, lastPart int, backends []int) {
+	data := &types.Data{
+		Txs: make(types.Txs, len(batch.Transactions)),
+	}
+	for i, tx := range batch.Transactions {
+		data.Txs[i] = types.Tx(tx)
+	}
+	dataHash := data.DACommitment().String()
+	if lastPart < len(res.IDs) {
+		m.setDAPointer(dataHash, res.Height, res.IDs[lastPart])
+	}
+	if m.daQuorumReached(dataHash, backends) {
+		m.DataCache().SetDAIncluded(dataHash)
+	}
+}
+
+// submitToDA submits blobs to the DA layer through the DA client bound to their namespace. When the DA client
+// is a coreda.QuorumSubmitter, it also returns the indexes of the backends that accepted the blobs.
+//
+// The submission is not aborted when ctx is canceled: on shutdown, in-flight submissions complete so
+// that their outcome is recorded and the blobs are not submitted again on restart.
+//
+// Submissions are tracked in the store until they succeed: blobs of a previous submission that failed
+// are looked up in the DA layer first, and are not submitted again if they were included nevertheless.
+func (m *Manager) submitToDA(ctx context.Context, da coreda.DA, blobs [][]byte, gasPrice float64) (res coreda.ResultSubmit, backends []int) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), daSubmitTimeout)
+	defer cancel()
+	defer func() {
+		if res.Code == coreda.StatusSuccess && res.Height > 0 {
+			m.recordSubmissionDAHeight(ctx, res.Height)
+			m.observeDAHeight(res.Height)
+		}
+		if res.Code == coreda.StatusSuccess {
+			m.recordDAFees(ctx, blobs[:min(res.SubmittedCount, uint64(len(blobs)))], gasPrice)
+		}
+	}()
+
+	mux, ok := da.(coreda.QuorumSubmitter)
+	if !ok {
+		// the backends including the blobs of a multiplexer cannot be told from the blobs found, so
+		// submissions through a multiplexer are not tracked
+		if res, found := m.findSubmittedBlobs(ctx, blobs); found {
+			return res, nil
+		}
+		m.beginSubmission(ctx, blobs)
+		defer func() {
+			// blobs rejected by the DA layer were not included, other failures leave the outcome unknown
+			switch res.Code {
+			case coreda.StatusSuccess, coreda.StatusTooBig, coreda.StatusUnderpriced, coreda.StatusIncorrectAccountSequence:
+				m.endSubmission(ctx, blobs)
+			}
+		}()
+	}
+
+	m.metrics.DAGasPrice.Set(gasPrice)
+	for _, blob := range blobs {
+		m.metrics.DABlobSizeBytes.Observe(float64(len(blob)))
+	}
+	defer func(start time.Time) {
+		m.metrics.DASubmissionDuration.Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	if !ok {
+		return types.SubmitWithHelpers(ctx, da, m.logger, blobs, gasPrice, nil), nil
+	}
+	recorder := &backendRecorder{QuorumSubmitter: mux}
+	res = types.SubmitWithHelpers(ctx, recorder, m.logger, blobs, gasPrice, nil)
+	return res, recorder.included
+}
+
+// backendRecorder wraps a coreda.QuorumSubmitter to remember which backends accepted the last submission.
+type backendRecorder struct {
+	coreda.QuorumSubmitter
+	included []int
+}
+
+// SubmitWithOptions submits to the backends and records the backends that accepted the blobs.
+func (r *backendRecorder) SubmitWithOptions(ctx context.Context, blobs []coreda.Blob, gasPrice float64, namespace []byte, options []byte) ([]coreda.ID, error) {
+	ids, included, err := r.SubmitToBackends(ctx, blobs, gasPrice, namespace, options)
+	r.included = included
+	return ids, err
+}
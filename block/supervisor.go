@@ -0,0 +1,105 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+)
+
+// ErrLoopsStuck is returned by Supervisor.Wait when loops did not stop before their shutdown deadline.
+var ErrLoopsStuck = errors.New("loops did not stop before their shutdown deadline")
+
+// Supervisor runs the block manager loops and enforces their shutdown deadlines. Loops stop when their context is
+// cancelled, but a loop blocked in a call ignoring its context, e.g. a DA call, would hang the node on shutdown.
+// Loops still running after their deadline are logged by name and abandoned, so that the node can stop.
+type Supervisor struct {
+	timeout  time.Duration
+	timeouts map[string]time.Duration
+	logger   log.Logger
+
+	mtx   sync.Mutex
+	loops []supervisedLoop
+}
+
+type supervisedLoop struct {
+	name string
+	done chan struct{}
+}
+
+// NewSupervisor creates a Supervisor giving loops the timeout to stop once their context is cancelled, unless
+// overridden for a loop in timeouts. A zero timeout waits for the loop indefinitely.
+func NewSupervisor(timeout time.Duration, timeouts map[string]time.Duration, logger log.Logger) *Supervisor {
+	return &Supervisor{
+		timeout:  timeout,
+		timeouts: timeouts,
+		logger:   logger,
+	}
+}
+
+// Go runs the loop identified by name in a new goroutine.
+func (s *Supervisor) Go(ctx context.Context, name string, loop func(context.Context)) {
+	l := supervisedLoop{name: name, done: make(chan struct{})}
+	s.mtx.Lock()
+	s.loops = append(s.loops, l)
+	s.mtx.Unlock()
+	go func() {
+		defer close(l.done)
+		loop(ctx)
+	}()
+}
+
+// Wait waits for the loops to stop, each until its shutdown deadline, which starts when Wait is called. It
+// returns an error wrapping ErrLoopsStuck and naming the loops which did not stop in time, these are left running.
+func (s *Supervisor) Wait() error {
+	s.mtx.Lock()
+	loops := s.loops
+	s.loops = nil
+	s.mtx.Unlock()
+
+	var (
+		wg    sync.WaitGroup
+		mtx   sync.Mutex
+		stuck []string
+	)
+	for _, l := range loops {
+		timeout := s.loopTimeout(l.name)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if timeout == 0 {
+				<-l.done
+				return
+			}
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+			select {
+			case <-l.done:
+			case <-timer.C:
+				s.logger.Error("loop did not stop before its shutdown deadline, abandoning it", "loop", l.name, "timeout", timeout)
+				mtx.Lock()
+				stuck = append(stuck, l.name)
+				mtx.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(stuck) == 0 {
+		return nil
+	}
+	sort.Strings(stuck)
+	return fmt.Errorf("%w: %s", ErrLoopsStuck, strings.Join(stuck, ", "))
+}
+
+func (s *Supervisor) loopTimeout(name string) time.Duration {
+	if timeout, ok := s.timeouts[name]; ok {
+		return timeout
+	}
+	return s.timeout
+}
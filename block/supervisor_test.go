@@ -0,0 +1,49 @@
+package block
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSupervisor verifies that loops stopping on cancellation are waited for, and that loops blocked past their
+// shutdown deadline are reported by name.
+func TestSupervisor(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sup := NewSupervisor(50*time.Millisecond, map[string]time.Duration{"slow": time.Second}, log.NewNopLogger())
+
+	stopped := make(chan struct{})
+	sup.Go(ctx, "fast", func(ctx context.Context) {
+		<-ctx.Done()
+		close(stopped)
+	})
+	sup.Go(ctx, "slow", func(ctx context.Context) {
+		<-ctx.Done()
+		time.Sleep(100 * time.Millisecond)
+	})
+	block := make(chan struct{})
+	defer close(block)
+	sup.Go(ctx, "stuck", func(context.Context) {
+		<-block
+	})
+
+	cancel()
+	start := time.Now()
+	err := sup.Wait()
+	require.ErrorIs(t, err, ErrLoopsStuck)
+	assert.Contains(t, err.Error(), "stuck")
+	assert.NotContains(t, err.Error(), "slow")
+	assert.Less(t, time.Since(start), time.Second)
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("fast loop was not waited for")
+	}
+
+	// loops are only waited for once
+	require.NoError(t, sup.Wait())
+}
@@ -9,19 +9,32 @@ import (
 	"github.com/rollkit/rollkit/types"
 )
 
+const (
+	// syncSourceP2P is the metrics label of headers and data received from peers.
+	syncSourceP2P = "p2p"
+	// syncSourceDA is the metrics label of headers and data retrieved from the DA layer.
+	syncSourceDA = "da"
+)
+
 // SyncLoop is responsible for syncing blocks.
 //
 // SyncLoop processes headers gossiped in P2P network to know what's the latest block height,
-// block data is retrieved from DA layer.
+// block data is retrieved from DA layer. Blocks of the store which were not executed yet, e.g. blocks imported
+// from a chain archive, are executed first, as syncing continues from the store height.
 func (m *Manager) SyncLoop(ctx context.Context) {
+	if err := m.executeOrderedBlocks(ctx); err != nil {
+		m.logger.Error("failed to execute stored blocks", "error", err)
+		return
+	}
 	daTicker := time.NewTicker(m.config.DA.BlockTime.Duration)
 	defer daTicker.Stop()
-	blockTicker := time.NewTicker(m.config.Node.BlockTime.Duration)
+	blockTicker := time.NewTicker(m.blockTime())
 	defer blockTicker.Stop()
 	for {
 		select {
 		case <-daTicker.C:
 			m.sendNonBlockingSignalToRetrieveCh()
+			m.recordSyncHeadGap(syncSourceDA, m.daHeadHeight.Load(), m.daHeight.Load())
 		case <-blockTicker.C:
 			m.sendNonBlockingSignalToHeaderStoreCh()
 			m.sendNonBlockingSignalToDataStoreCh()
@@ -138,6 +151,9 @@ func (m *Manager) trySyncNextBlock(ctx context.Context, daHeight uint64) error {
 			return ctx.Err()
 		default:
 		}
+		if m.fraudProof.Load() != nil {
+			return ErrHaltedByFraudProof
+		}
 		currentHeight, err := m.store.Height(ctx)
 		if err != nil {
 			return err
@@ -155,10 +171,29 @@ func (m *Manager) trySyncNextBlock(ctx context.Context, daHeight uint64) error {
 
 		hHeight := h.Height()
 		m.logger.Info("Syncing header and data", "height", hHeight)
+		if err := m.checkHalt(hHeight); err != nil {
+			return err
+		}
+		source := m.syncSource(h)
+		if err := m.checkProposer(ctx, h); err != nil {
+			m.metrics.SyncVerificationFailures.With("source", source).Add(1)
+			m.headerCache.DeleteItem(currentHeight + 1)
+			return err
+		}
+		if err := m.checkUpgrade(h); err != nil {
+			m.headerCache.DeleteItem(currentHeight + 1)
+			return err
+		}
 		// Validate the received block before applying
 		if err := m.Validate(ctx, h, d); err != nil {
+			m.metrics.SyncVerificationFailures.With("source", source).Add(1)
 			return fmt.Errorf("failed to validate block: %w", err)
 		}
+		if m.config.Node.FraudProofs {
+			if err := m.checkStateRoot(ctx, h); err != nil {
+				return err
+			}
+		}
 		newState, err := m.applyBlock(ctx, h, d)
 		if err != nil {
 			if ctx.Err() != nil {
@@ -167,7 +202,8 @@ func (m *Manager) trySyncNextBlock(ctx context.Context, daHeight uint64) error {
 			// if call to applyBlock fails, we halt the node, see https://github.com/cometbft/cometbft/pull/496
 			panic(fmt.Errorf("failed to ApplyBlock: %w", err))
 		}
-		err = m.store.SaveBlockData(ctx, h, d, &h.Signature)
+		writeStart := time.Now()
+		err = m.saveBlockData(ctx, h, d, &h.Signature)
 		if err != nil {
 			return SaveBlockError{err}
 		}
@@ -182,9 +218,16 @@ func (m *Manager) trySyncNextBlock(ctx context.Context, daHeight uint64) error {
 			newState.DAHeight = daHeight
 		}
 		err = m.updateState(ctx, newState)
+		m.metrics.SyncStoreWriteDuration.With("source", source).Observe(time.Since(writeStart).Seconds())
 		if err != nil {
 			m.logger.Error("failed to save updated state", "error", err)
+		} else {
+			m.scheduleSnapshot(newState)
 		}
+		m.markForcedTxsIncluded(ctx, hHeight, d.Txs)
+		m.resolvePreconfirmations(ctx, hHeight, d.Txs)
+		m.publishNewBlock(h, d)
+		m.checkSyncCaughtUp(ctx)
 		m.headerCache.DeleteItem(currentHeight + 1)
 		m.dataCache.DeleteItem(currentHeight + 1)
 		m.dataCache.DeleteItemByHash(h.DataHash.String())
@@ -195,6 +238,24 @@ func (m *Manager) trySyncNextBlock(ctx context.Context, daHeight uint64) error {
 	}
 }
 
+// syncSource returns the source of a block being synced for metrics: da if its header was retrieved from the DA
+// layer, p2p otherwise.
+func (m *Manager) syncSource(header *types.SignedHeader) string {
+	if m.headerCache.IsDAIncluded(header.Hash().String()) {
+		return syncSourceDA
+	}
+	return syncSourceP2P
+}
+
+// recordSyncHeadGap records the distance between the head of the source and the local head.
+func (m *Manager) recordSyncHeadGap(source string, head, local uint64) {
+	var gap uint64
+	if head > local {
+		gap = head - local
+	}
+	m.metrics.SyncHeadGap.With("source", source).Set(float64(gap))
+}
+
 func (m *Manager) handleEmptyDataHash(ctx context.Context, header *types.Header) bool {
 	headerHeight := header.Height()
 	if bytes.Equal(header.DataHash, dataHashForEmptyTxs) {
@@ -258,11 +319,17 @@ func (m *Manager) updateState(ctx context.Context, s types.State) error {
 	m.logger.Debug("updating state", "newState", s)
 	m.lastStateMtx.Lock()
 	defer m.lastStateMtx.Unlock()
+	if m.keepStateRoots() {
+		if err := m.store.SetMetadata(ctx, stateRootKey(s.LastBlockHeight), s.AppHash); err != nil {
+			return fmt.Errorf("failed to save state root of block %d: %w", s.LastBlockHeight, err)
+		}
+	}
 	err := m.store.UpdateState(ctx, s)
 	if err != nil {
 		return err
 	}
 	m.lastState = s
 	m.metrics.Height.Set(float64(s.LastBlockHeight))
+	m.recordDAInclusionLag(s.LastBlockHeight)
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -11,6 +12,7 @@ import (
 
 	"cosmossdk.io/log"
 	goheaderstore "github.com/celestiaorg/go-header/store"
+	"github.com/go-kit/kit/metrics"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -1054,3 +1056,44 @@ func TestDataCommitmentToHeight_DataAlreadySeen(t *testing.T) {
 }
 
 // --- END dataCommitmentToHeight TESTS ---
+
+// labeledGauge is a gauge recording the values set for every set of label values.
+type labeledGauge struct {
+	values map[string]float64
+	key    string
+}
+
+func (g *labeledGauge) With(labelValues ...string) metrics.Gauge {
+	return &labeledGauge{values: g.values, key: strings.Join(labelValues, ",")}
+}
+
+func (g *labeledGauge) Set(value float64) { g.values[g.key] = value }
+
+func (g *labeledGauge) Add(delta float64) { g.values[g.key] += delta }
+
+// TestRecordSyncHeadGap verifies that the gaps between the heads of the sync sources and the local head are recorded
+// by source.
+func TestRecordSyncHeadGap(t *testing.T) {
+	t.Parallel()
+	m, _, _, _ := newTestManager(t)
+	gaps := &labeledGauge{values: make(map[string]float64)}
+	m.metrics.SyncHeadGap = gaps
+
+	m.recordSyncHeadGap(syncSourceDA, 14, 10)
+	m.recordSyncHeadGap(syncSourceP2P, 20, 5)
+	assert.Equal(t, map[string]float64{"source,da": 4, "source,p2p": 15}, gaps.values)
+
+	// a source behind the local head has no gap
+	m.recordSyncHeadGap(syncSourceP2P, 3, 5)
+	assert.Equal(t, float64(0), gaps.values["source,p2p"])
+}
+
+// TestSyncSource verifies that blocks whose header was retrieved from the DA layer are attributed to the DA layer.
+func TestSyncSource(t *testing.T) {
+	t.Parallel()
+	m, _, _, _ := newTestManager(t)
+	header, _ := types.GetRandomBlock(1, 1, "testchain")
+	assert.Equal(t, syncSourceP2P, m.syncSource(header))
+	m.headerCache.SetDAIncluded(header.Hash().String())
+	assert.Equal(t, syncSourceDA, m.syncSource(header))
+}
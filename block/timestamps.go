@@ -0,0 +1,109 @@
+package block
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/ntp"
+)
+
+// ntpCheckInterval is the interval at which the host clock is checked against the NTP server
+const ntpCheckInterval = time.Minute
+
+// blockClock sets the timestamps of the blocks produced by the aggregator. Timestamps proposed by the sequencer
+// are corrected by the offset of the host clock measured against the NTP server, if any, and raised to the
+// timestamp of the previous block plus minDelta, so that block timestamps strictly increase even if the host clock
+// goes backwards, e.g. across a restart. A nil blockClock trusts the host clock and only keeps timestamps from
+// decreasing.
+type blockClock struct {
+	// minDelta is the minimum difference between the timestamps of consecutive blocks
+	minDelta time.Duration
+	// maxDrift is the drift of the host clock or of proposed timestamps above which warnings are logged, 0 to
+	// disable the warnings
+	maxDrift time.Duration
+	// ntpServer is the address of the NTP server the host clock is checked against, empty to trust the host clock
+	ntpServer string
+	// offset is the last offset of the host clock measured against the NTP server, in nanoseconds
+	offset atomic.Int64
+
+	logger log.Logger
+	// queryOffset measures the offset of the host clock against the NTP server, replaced in tests
+	queryOffset func(ctx context.Context, server string) (time.Duration, error)
+}
+
+// newBlockClock creates the clock of an aggregator.
+func newBlockClock(conf config.NodeConfig, logger log.Logger) *blockClock {
+	return &blockClock{
+		minDelta:    conf.MinBlockTimeDelta.Duration,
+		maxDrift:    conf.MaxClockDrift.Duration,
+		ntpServer:   conf.NTPServer,
+		logger:      logger,
+		queryOffset: ntp.Offset,
+	}
+}
+
+// timestamp returns the timestamp of the block at the given height, for the timestamp proposed by the sequencer
+// and the timestamp of the previous block, zero for the first block.
+func (c *blockClock) timestamp(proposed, previous time.Time, height uint64) time.Time {
+	if c == nil {
+		if proposed.Before(previous) {
+			return previous
+		}
+		return proposed
+	}
+
+	if drift := proposed.Sub(time.Now()); c.maxDrift > 0 && drift.Abs() > c.maxDrift {
+		c.logger.Warn("block timestamp proposed by the sequencer drifts from the host clock",
+			"height", height, "timestamp", proposed, "drift", drift)
+	}
+	ts := proposed.Add(time.Duration(c.offset.Load()))
+	if earliest := previous.Add(c.minDelta); !previous.IsZero() && ts.Before(earliest) {
+		if ts.Before(previous) {
+			c.logger.Warn("block timestamp precedes the previous block timestamp, the clock may have gone backwards",
+				"height", height, "timestamp", ts, "previousTimestamp", previous)
+		}
+		ts = earliest
+	}
+	return ts
+}
+
+// checkOffset measures the offset of the host clock against the NTP server. The previous offset is kept if the
+// server cannot be reached.
+func (c *blockClock) checkOffset(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, ntpCheckInterval/2)
+	defer cancel()
+	offset, err := c.queryOffset(ctx, c.ntpServer)
+	if err != nil {
+		c.logger.Warn("failed to check the clock against the NTP server", "server", c.ntpServer, "error", err)
+		return
+	}
+	c.offset.Store(int64(offset))
+	if c.maxDrift > 0 && offset.Abs() > c.maxDrift {
+		c.logger.Warn("host clock drifts from the NTP server, correcting block timestamps",
+			"server", c.ntpServer, "offset", offset)
+		return
+	}
+	c.logger.Debug("checked the clock against the NTP server", "server", c.ntpServer, "offset", offset)
+}
+
+// ClockCheckLoop periodically checks the host clock of the aggregator against the configured NTP server. It
+// returns immediately if no NTP server is configured.
+func (m *Manager) ClockCheckLoop(ctx context.Context) {
+	if m.clock == nil || m.clock.ntpServer == "" {
+		return
+	}
+	ticker := time.NewTicker(ntpCheckInterval)
+	defer ticker.Stop()
+	for {
+		m.clock.checkOffset(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
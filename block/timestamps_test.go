@@ -0,0 +1,102 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestBlockClock_Timestamp(t *testing.T) {
+	conf := config.DefaultConfig.Node
+	conf.MinBlockTimeDelta = config.DurationWrapper{Duration: time.Millisecond}
+	clock := newBlockClock(conf, log.NewTestLogger(t))
+
+	previous := time.Now()
+	// timestamps after the previous block plus the minimum delta are kept
+	assert.Equal(t, previous.Add(time.Second), clock.timestamp(previous.Add(time.Second), previous, 2))
+	// earlier timestamps, e.g. after the clock went backwards, are raised
+	assert.Equal(t, previous.Add(time.Millisecond), clock.timestamp(previous.Add(-time.Minute), previous, 2))
+	assert.Equal(t, previous.Add(time.Millisecond), clock.timestamp(previous, previous, 2))
+	// the first block has no previous block
+	assert.Equal(t, previous.Add(-time.Minute), clock.timestamp(previous.Add(-time.Minute), time.Time{}, 1))
+
+	// timestamps are corrected by the offset measured against the NTP server
+	clock.offset.Store(int64(-2 * time.Second))
+	assert.Equal(t, previous.Add(8*time.Second), clock.timestamp(previous.Add(10*time.Second), previous, 2))
+	assert.Equal(t, previous.Add(time.Millisecond), clock.timestamp(previous.Add(time.Second), previous, 2))
+
+	// a nil clock only keeps timestamps from decreasing
+	var nilClock *blockClock
+	assert.Equal(t, previous, nilClock.timestamp(previous.Add(-time.Minute), previous, 2))
+	assert.Equal(t, previous.Add(time.Second), nilClock.timestamp(previous.Add(time.Second), previous, 2))
+}
+
+func TestBlockClock_CheckOffset(t *testing.T) {
+	conf := config.DefaultConfig.Node
+	conf.NTPServer = "ntp.example.com"
+	clock := newBlockClock(conf, log.NewTestLogger(t))
+
+	var servers []string
+	offset, err := 3*time.Second, error(nil)
+	clock.queryOffset = func(ctx context.Context, server string) (time.Duration, error) {
+		servers = append(servers, server)
+		return offset, err
+	}
+
+	clock.checkOffset(t.Context())
+	assert.Equal(t, int64(3*time.Second), clock.offset.Load())
+
+	// the previous offset is kept if the server cannot be reached
+	offset, err = 0, errors.New("timeout")
+	clock.checkOffset(t.Context())
+	assert.Equal(t, int64(3*time.Second), clock.offset.Load())
+	assert.Equal(t, []string{"ntp.example.com", "ntp.example.com"}, servers)
+}
+
+// Test_publishBlock_ClampsTimestamp verifies that a block whose timestamp proposed by the sequencer precedes the
+// previous block, e.g. after a restart on a host whose clock went backwards, is produced with a later timestamp.
+func Test_publishBlock_ClampsTimestamp(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	initialHeight := uint64(5)
+	newHeight := initialHeight + 1
+
+	manager, mockStore, mockExec, mockSeq, _, headerCh, _, _ := setupManagerForPublishBlockTest(t, true, initialHeight, 0)
+	manager.lastState.LastBlockHeight = initialHeight
+	manager.clock = newBlockClock(manager.config.Node, manager.logger)
+
+	mockStore.On("Height", t.Context()).Return(initialHeight, nil).Once()
+	mockSignature := types.Signature([]byte{1, 2, 3})
+	mockStore.On("GetSignature", t.Context(), initialHeight).Return(&mockSignature, nil).Once()
+	lastHeader, lastData := types.GetRandomBlock(initialHeight, 5, "testchain")
+	mockStore.On("GetBlockData", t.Context(), initialHeight).Return(lastHeader, lastData, nil).Once()
+	mockStore.On("GetBlockData", t.Context(), newHeight).Return(nil, nil, errors.New("not found")).Once()
+	mockStore.On("SaveBlockData", t.Context(), mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+	mockStore.On("SetHeight", t.Context(), newHeight).Return(nil).Once()
+	mockStore.On("UpdateState", t.Context(), mock.AnythingOfType("types.State")).Return(nil).Once()
+	mockStore.On("SetMetadata", t.Context(), LastBatchDataKey, mock.Anything).Return(nil).Once()
+
+	expectedTime := lastHeader.Time().Add(manager.config.Node.MinBlockTimeDelta.Duration)
+	mockExec.On("ExecuteTxs", t.Context(), mock.Anything, newHeight, expectedTime, manager.lastState.AppHash).Return([]byte("newAppHash"), uint64(100), nil).Once()
+	mockSeq.On("GetNextBatch", t.Context(), mock.Anything).Return(&coresequencer.GetNextBatchResponse{
+		Batch:     &coresequencer.Batch{Transactions: [][]byte{[]byte("tx1")}},
+		Timestamp: lastHeader.Time().Add(-time.Hour),
+	}, nil).Once()
+
+	require.NoError(manager.publishBlock(t.Context()))
+
+	header := <-headerCh
+	assert.Equal(t, expectedTime.UnixNano(), header.Time().UnixNano())
+	assert.Equal(t, expectedTime, manager.GetLastState().LastBlockTime)
+}
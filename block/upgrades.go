@@ -0,0 +1,359 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+
+	ds "github.com/ipfs/go-datastore"
+	"google.golang.org/protobuf/proto"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// UpgradesKey is the key used for persisting the upgrades scheduled on the node in store.
+const UpgradesKey = "upgrades"
+
+var (
+	// ErrUpgradeRequired is returned when blocks are not synced or produced because the node reached the height of
+	// an upgrade its binary does not support, or the configured halt height.
+	ErrUpgradeRequired = errors.New("upgrade required")
+	// ErrUpgradeNotApplied is returned when a header does not follow the upgrades at or below its height, e.g. it
+	// was produced by a sequencer running a binary which was not upgraded.
+	ErrUpgradeNotApplied = errors.New("header does not follow the upgrade at its height")
+)
+
+// protocolParams are the protocol parameters in effect at a height, set by the genesis and the upgrades at or
+// below the height.
+type protocolParams struct {
+	// blockVersion is the block version of the headers, 0 if no upgrade set it
+	blockVersion uint64
+	// namespace is the hex encoded DA namespace of the headers, empty for the configured namespace
+	namespace string
+	// scheme is the signature scheme of the sequencer key
+	scheme string
+}
+
+// loadUpgrades restores the upgrades scheduled before the node stopped.
+func (m *Manager) loadUpgrades(ctx context.Context) error {
+	bz, err := m.store.GetMetadata(ctx, UpgradesKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load upgrades: %w", err)
+	}
+	var upgradesPb pb.Upgrades
+	if err := proto.Unmarshal(bz, &upgradesPb); err != nil {
+		return fmt.Errorf("failed to decode upgrades: %w", err)
+	}
+	upgrades := make([]*types.Upgrade, len(upgradesPb.Upgrades))
+	for i, upgradePb := range upgradesPb.Upgrades {
+		upgrades[i] = new(types.Upgrade)
+		if err := upgrades[i].FromProto(upgradePb); err != nil {
+			return fmt.Errorf("failed to decode upgrade: %w", err)
+		}
+	}
+	m.upgradesMtx.Lock()
+	m.upgrades = upgrades
+	m.upgradesMtx.Unlock()
+	return nil
+}
+
+// saveUpgrades persists the upgrades scheduled on the manager.
+func (m *Manager) saveUpgrades(ctx context.Context, upgrades []*types.Upgrade) error {
+	upgradesPb := &pb.Upgrades{Upgrades: make([]*pb.Upgrade, len(upgrades))}
+	for i, upgrade := range upgrades {
+		var err error
+		if upgradesPb.Upgrades[i], err = upgrade.ToProto(); err != nil {
+			return err
+		}
+	}
+	bz, err := proto.Marshal(upgradesPb)
+	if err != nil {
+		return err
+	}
+	if err := m.store.SetMetadata(ctx, UpgradesKey, bz); err != nil {
+		return fmt.Errorf("failed to save upgrades: %w", err)
+	}
+	return nil
+}
+
+// Upgrades returns the upgrades scheduled on the node, ordered by height.
+func (m *Manager) Upgrades() []*types.Upgrade {
+	m.upgradesMtx.RLock()
+	defer m.upgradesMtx.RUnlock()
+	return slices.Clone(m.upgrades)
+}
+
+// protocol returns the protocol parameters in effect at the given height.
+func (m *Manager) protocol(height uint64) protocolParams {
+	m.upgradesMtx.RLock()
+	defer m.upgradesMtx.RUnlock()
+	return m.protocolAt(height)
+}
+
+// protocolAt must be called with upgradesMtx held.
+func (m *Manager) protocolAt(height uint64) protocolParams {
+	params := protocolParams{scheme: m.genesis.Scheme()}
+	for _, upgrade := range m.upgrades {
+		if upgrade.Height > height {
+			break
+		}
+		if upgrade.BlockVersion != 0 {
+			params.blockVersion = upgrade.BlockVersion
+		}
+		if upgrade.Namespace != "" {
+			params.namespace = upgrade.Namespace
+		}
+		if upgrade.SignatureScheme != "" {
+			params.scheme = upgrade.SignatureScheme
+		}
+	}
+	return params
+}
+
+// verifyUpgrade checks that the upgrade is signed by the given proposer, the proposer before the upgrade height,
+// and follows the upgrades scheduled on the manager. It reports whether the upgrade was already scheduled. It
+// must be called with upgradesMtx held.
+func (m *Manager) verifyUpgrade(upgrade *types.Upgrade, proposer []byte) (bool, error) {
+	if err := upgrade.ValidateBasic(); err != nil {
+		return false, err
+	}
+	if upgrade.ChainID != m.genesis.ChainID {
+		return false, fmt.Errorf("%w: chain ID mismatch: expected %s, got %s", types.ErrInvalidUpgrade, m.genesis.ChainID, upgrade.ChainID)
+	}
+	if upgrade.Height <= m.genesis.InitialHeight {
+		return false, fmt.Errorf("%w: height %d is not after the initial height", types.ErrInvalidUpgrade, upgrade.Height)
+	}
+	for _, scheduled := range m.upgrades {
+		if scheduled.Height == upgrade.Height {
+			if bytes.Equal(scheduled.Signature, upgrade.Signature) {
+				return true, nil
+			}
+			return false, fmt.Errorf("%w: conflicts with the upgrade %s at height %d", types.ErrInvalidUpgrade, scheduled.Name, upgrade.Height)
+		}
+	}
+	if n := len(m.upgrades); n > 0 && upgrade.Height < m.upgrades[n-1].Height {
+		return false, fmt.Errorf("%w: height %d precedes the last upgrade at height %d", types.ErrInvalidUpgrade, upgrade.Height, m.upgrades[n-1].Height)
+	}
+	if current := m.protocolAt(upgrade.Height).blockVersion; upgrade.BlockVersion != 0 && upgrade.BlockVersion < current {
+		return false, fmt.Errorf("%w: block version %d precedes the current block version %d", types.ErrInvalidUpgrade, upgrade.BlockVersion, current)
+	}
+	if _, ok := coreda.Implements[coreda.Namespacer](m.da); upgrade.Namespace != "" && !ok {
+		return false, fmt.Errorf("%w: DA client does not support multiple namespaces", types.ErrInvalidUpgrade)
+	}
+	if !bytes.Equal(upgrade.Signer.Address, proposer) {
+		return false, fmt.Errorf("%w: signed by %X instead of the proposer %X", types.ErrInvalidUpgrade, upgrade.Signer.Address, proposer)
+	}
+	return false, nil
+}
+
+// applyUpgrade verifies the upgrade and schedules it. Upgrades at heights which are already synced are rejected,
+// as the blocks at these heights were validated against the previous protocol parameters.
+func (m *Manager) applyUpgrade(ctx context.Context, upgrade *types.Upgrade) error {
+	// the proposer is looked up before locking upgradesMtx, as the key rotations depend on the upgrades
+	proposer := m.ProposerAt(upgrade.Height - 1)
+	m.upgradesMtx.Lock()
+	defer m.upgradesMtx.Unlock()
+	known, err := m.verifyUpgrade(upgrade, proposer)
+	if err != nil || known {
+		return err
+	}
+	height, err := m.store.Height(ctx)
+	if err != nil {
+		return err
+	}
+	if upgrade.Height <= height {
+		return fmt.Errorf("%w: height %d is already synced", types.ErrInvalidUpgrade, upgrade.Height)
+	}
+	upgrades := append(slices.Clone(m.upgrades), upgrade)
+	if err := m.saveUpgrades(ctx, upgrades); err != nil {
+		return err
+	}
+	m.upgrades = upgrades
+	m.logger.Info("upgrade scheduled", "name", upgrade.Name, "height", upgrade.Height, "blockVersion", upgrade.BlockVersion,
+		"namespace", upgrade.Namespace, "signatureScheme", upgrade.SignatureScheme)
+	if upgrade.BlockVersion > types.MaxBlockVersion {
+		m.logger.Warn("binary does not support the upgrade, the node will halt at the upgrade height",
+			"name", upgrade.Name, "height", upgrade.Height, "blockVersion", upgrade.BlockVersion, "maxBlockVersion", types.MaxBlockVersion)
+	}
+	return nil
+}
+
+// ScheduleUpgrade schedules an upgrade of the chain at upgrade.Height. The upgrade is signed by the key of the
+// aggregator, submitted to the DA layer and applied to the upgrade schedule. Full nodes switch to the protocol
+// parameters of the upgrade at its height, or halt if their binary does not support it.
+func (m *Manager) ScheduleUpgrade(ctx context.Context, upgrade types.Upgrade) (*types.Upgrade, error) {
+	if m.signer == nil {
+		return nil, errors.New("signer is nil; cannot schedule an upgrade")
+	}
+	pubKey, err := m.signer.GetPublic()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proposer public key: %w", err)
+	}
+	upgrade.ChainID = m.genesis.ChainID
+	if upgrade.Signer, err = types.NewSigner(pubKey); err != nil {
+		return nil, err
+	}
+	bz, err := upgrade.SignBytes()
+	if err != nil {
+		return nil, err
+	}
+	if upgrade.Signature, err = m.signer.Sign(bz); err != nil {
+		return nil, fmt.Errorf("failed to sign upgrade: %w", err)
+	}
+
+	storeHeight, err := m.store.Height(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if upgrade.Height <= storeHeight {
+		return nil, fmt.Errorf("%w: height %d is already produced", types.ErrInvalidUpgrade, upgrade.Height)
+	}
+	proposer := m.ProposerAt(upgrade.Height - 1)
+	m.upgradesMtx.RLock()
+	_, err = m.verifyUpgrade(&upgrade, proposer)
+	m.upgradesMtx.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	blob, err := upgrade.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	gasPrice := m.gasPricer.price()
+	res := types.SubmitWithHelpers(ctx, m.da, m.logger, [][]byte{blob}, gasPrice, nil)
+	if res.Code != coreda.StatusSuccess {
+		return nil, fmt.Errorf("failed to submit upgrade to DA: %s", res.Message)
+	}
+	m.recordDAFees(ctx, [][]byte{blob}, gasPrice)
+	if err := m.applyUpgrade(ctx, &upgrade); err != nil {
+		return nil, err
+	}
+	return &upgrade, nil
+}
+
+// checkHalt returns ErrUpgradeRequired if the block at the given height must not be synced or produced by this
+// binary: the height is at or above the configured halt height, or an upgrade at or below the height requires a
+// block version the binary does not support.
+func (m *Manager) checkHalt(height uint64) error {
+	if halt := m.config.Node.HaltHeight; halt != 0 && height >= halt {
+		return fmt.Errorf("%w: halt height %d reached", ErrUpgradeRequired, halt)
+	}
+	m.upgradesMtx.RLock()
+	defer m.upgradesMtx.RUnlock()
+	for _, upgrade := range m.upgrades {
+		if upgrade.Height > height {
+			break
+		}
+		if upgrade.BlockVersion > types.MaxBlockVersion {
+			return fmt.Errorf("%w: upgrade %s at height %d requires block version %d, the binary supports up to %d",
+				ErrUpgradeRequired, upgrade.Name, upgrade.Height, upgrade.BlockVersion, types.MaxBlockVersion)
+		}
+	}
+	return nil
+}
+
+// checkUpgrade checks that a header about to be synced follows the protocol parameters at its height.
+func (m *Manager) checkUpgrade(header *types.SignedHeader) error {
+	params := m.protocol(header.Height())
+	if params.blockVersion != 0 && header.Version.Block != params.blockVersion {
+		return fmt.Errorf("%w: header at height %d has block version %d instead of %d",
+			ErrUpgradeNotApplied, header.Height(), header.Version.Block, params.blockVersion)
+	}
+	if scheme, err := signer.SchemeOf(header.Signer.PubKey); err != nil || scheme != params.scheme {
+		return fmt.Errorf("%w: header at height %d is not signed with a %s key", ErrUpgradeNotApplied, header.Height(), params.scheme)
+	}
+	return nil
+}
+
+// headerDA returns the DA client bound to the namespace of the header at the given height.
+func (m *Manager) headerDA(height uint64) (coreda.DA, error) {
+	namespace := m.protocol(height).namespace
+	if namespace == "" {
+		return m.da, nil
+	}
+	return m.namespaceDA(namespace)
+}
+
+// upgradeDAs returns the DA clients bound to the namespaces set by the upgrades, from which headers are
+// retrieved in addition to the configured namespaces.
+func (m *Manager) upgradeDAs() []coreda.DA {
+	var (
+		das        []coreda.DA
+		namespaces []string
+	)
+	for _, upgrade := range m.Upgrades() {
+		if upgrade.Namespace == "" || slices.Contains(namespaces, upgrade.Namespace) {
+			continue
+		}
+		namespaces = append(namespaces, upgrade.Namespace)
+		da, err := m.namespaceDA(upgrade.Namespace)
+		if err != nil {
+			m.logger.Error("failed to bind DA client to upgrade namespace", "namespace", upgrade.Namespace, "error", err)
+			continue
+		}
+		das = append(das, da)
+	}
+	return das
+}
+
+// namespaceDA returns the DA client bound to the hex encoded namespace, sharing the connection of the DA client
+// of headers.
+func (m *Manager) namespaceDA(namespace string) (coreda.DA, error) {
+	m.namespaceDAsMtx.Lock()
+	defer m.namespaceDAsMtx.Unlock()
+	if da, ok := m.namespaceDAs[namespace]; ok {
+		return da, nil
+	}
+	namespacer, ok := coreda.Implements[coreda.Namespacer](m.da)
+	if !ok {
+		return nil, errors.New("DA client does not support multiple namespaces")
+	}
+	nsBytes, err := hex.DecodeString(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode namespace: %w", err)
+	}
+	if m.namespaceDAs == nil {
+		m.namespaceDAs = make(map[string]coreda.DA)
+	}
+	m.namespaceDAs[namespace] = namespacer.WithNamespace(nsBytes)
+	return m.namespaceDAs[namespace], nil
+}
+
+// sameNamespaceHeaders returns the longest prefix of the headers submitted to the namespace of the first header.
+func (m *Manager) sameNamespaceHeaders(headers []*types.SignedHeader) []*types.SignedHeader {
+	if len(headers) == 0 {
+		return headers
+	}
+	namespace := m.protocol(headers[0].Height()).namespace
+	for i, header := range headers[1:] {
+		if m.protocol(header.Height()).namespace != namespace {
+			return headers[:i+1]
+		}
+	}
+	return headers
+}
+
+// decodeUpgrade tries to decode an upgrade. Returns false if the blob is not an upgrade, and a nil upgrade if it
+// cannot be decoded.
+func (m *Manager) decodeUpgrade(bz []byte, daHeight uint64) (*types.Upgrade, bool) {
+	var upgradePb pb.Upgrade
+	if err := proto.Unmarshal(bz, &upgradePb); err != nil || upgradePb.Height == 0 || upgradePb.Name == "" {
+		return nil, false
+	}
+	upgrade := new(types.Upgrade)
+	if err := upgrade.FromProto(&upgradePb); err != nil {
+		m.logger.Debug("failed to decode upgrade", "daHeight", daHeight, "error", err)
+		return nil, true
+	}
+	return upgrade, true
+}
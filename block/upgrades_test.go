@@ -0,0 +1,186 @@
+package block
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/types"
+)
+
+// namespacedDA is a DA client which can be bound to other namespaces. All namespaces share the blobs of the
+// wrapped client.
+type namespacedDA struct {
+	coreda.DA
+	namespace []byte
+}
+
+func (d *namespacedDA) WithNamespace(namespace []byte) coreda.DA {
+	return &namespacedDA{DA: d.DA, namespace: namespace}
+}
+
+func newTestUpgrade(t *testing.T, s signer.Signer, chainID string, upgrade types.Upgrade) *types.Upgrade {
+	t.Helper()
+	upgrade.ChainID = chainID
+	signed, err := types.GetUpgrade(s, upgrade)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestUpgradeSchedule(t *testing.T) {
+	ctx := context.Background()
+	signerA, _, addrA := newRotationTestSigner(t)
+	signerB, _, _ := newRotationTestSigner(t)
+	m, _, _, _ := newTestManager(t, withStore(newRotationTestStore(t)), withConfig(config.DefaultConfig), withGenesis(rotationTestGenesis(addrA)))
+
+	upgrade := newTestUpgrade(t, signerA, rotationTestChainID, types.Upgrade{Name: "v1", Height: 5, BlockVersion: 1})
+	require.NoError(t, m.applyUpgrade(ctx, upgrade))
+	assert.Equal(t, protocolParams{scheme: signer.SchemeEd25519}, m.protocol(4))
+	assert.Equal(t, protocolParams{blockVersion: 1, scheme: signer.SchemeEd25519}, m.protocol(5))
+	// applying a known upgrade again is a no-op
+	require.NoError(t, m.applyUpgrade(ctx, upgrade))
+	assert.Len(t, m.Upgrades(), 1)
+
+	invalid := map[string]*types.Upgrade{
+		"not signed by the proposer": newTestUpgrade(t, signerB, rotationTestChainID, types.Upgrade{Name: "v2", Height: 8, BlockVersion: 1}),
+		"conflicting":                newTestUpgrade(t, signerA, rotationTestChainID, types.Upgrade{Name: "v2", Height: 5, SignatureScheme: signer.SchemeBLS}),
+		"before the last upgrade":    newTestUpgrade(t, signerA, rotationTestChainID, types.Upgrade{Name: "v0", Height: 3, BlockVersion: 1}),
+		"initial height":             newTestUpgrade(t, signerA, rotationTestChainID, types.Upgrade{Name: "v0", Height: 1, BlockVersion: 1}),
+		"other chain":                newTestUpgrade(t, signerA, "other-chain", types.Upgrade{Name: "v2", Height: 8, BlockVersion: 1}),
+		// the DA client of the manager cannot be bound to other namespaces
+		"namespace": newTestUpgrade(t, signerA, rotationTestChainID, types.Upgrade{Name: "v2", Height: 8, Namespace: "abcd"}),
+	}
+	for name, upgrade := range invalid {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, m.applyUpgrade(ctx, upgrade), types.ErrInvalidUpgrade)
+		})
+	}
+
+	// upgrades at synced heights are rejected
+	require.NoError(t, m.store.SetHeight(ctx, 10))
+	late := newTestUpgrade(t, signerA, rotationTestChainID, types.Upgrade{Name: "v2", Height: 9, BlockVersion: 1})
+	assert.ErrorIs(t, m.applyUpgrade(ctx, late), types.ErrInvalidUpgrade)
+
+	// the schedule is restored on restart
+	restarted, _, _, _ := newTestManager(t, withStore(m.store), withConfig(config.DefaultConfig), withGenesis(rotationTestGenesis(addrA)))
+	require.NoError(t, restarted.loadUpgrades(ctx))
+	assert.Equal(t, uint64(1), restarted.protocol(5).blockVersion)
+	assert.Zero(t, restarted.protocol(4).blockVersion)
+}
+
+func TestCheckHalt(t *testing.T) {
+	ctx := context.Background()
+	signerA, _, addrA := newRotationTestSigner(t)
+	m, _, _, _ := newTestManager(t, withStore(newRotationTestStore(t)), withConfig(config.DefaultConfig), withGenesis(rotationTestGenesis(addrA)))
+
+	m.config.Node.HaltHeight = 10
+	require.NoError(t, m.checkHalt(9))
+	require.ErrorIs(t, m.checkHalt(10), ErrUpgradeRequired)
+
+	// nodes halt at upgrades to block versions their binary does not support
+	m.config.Node.HaltHeight = 0
+	upgrade := newTestUpgrade(t, signerA, rotationTestChainID, types.Upgrade{Name: "v2", Height: 5, BlockVersion: types.MaxBlockVersion + 1})
+	require.NoError(t, m.applyUpgrade(ctx, upgrade))
+	require.NoError(t, m.checkHalt(4))
+	require.ErrorIs(t, m.checkHalt(5), ErrUpgradeRequired)
+	require.ErrorIs(t, m.checkHalt(6), ErrUpgradeRequired)
+}
+
+func TestCheckUpgrade(t *testing.T) {
+	ctx := context.Background()
+	signerA, _, addrA := newRotationTestSigner(t)
+	m, _, _, _ := newTestManager(t, withStore(newRotationTestStore(t)), withConfig(config.DefaultConfig), withGenesis(rotationTestGenesis(addrA)))
+	require.NoError(t, m.applyUpgrade(ctx, newTestUpgrade(t, signerA, rotationTestChainID, types.Upgrade{Name: "v1", Height: 5, BlockVersion: 1})))
+	require.NoError(t, m.applyUpgrade(ctx, newTestUpgrade(t, signerA, rotationTestChainID, types.Upgrade{Name: "bls", Height: 8, SignatureScheme: signer.SchemeBLS})))
+
+	header := newRotationTestHeader(t, signerA, 4, nil)
+	require.NoError(t, m.checkUpgrade(header))
+
+	// from the upgrade height on, headers must have the block version of the upgrade
+	header = newRotationTestHeader(t, signerA, 5, nil)
+	header.Version.Block = 0
+	assert.ErrorIs(t, m.checkUpgrade(header), ErrUpgradeNotApplied)
+	header.Version.Block = 1
+	require.NoError(t, m.checkUpgrade(header))
+
+	// and be signed with a key of the scheme of the upgrade
+	header = newRotationTestHeader(t, signerA, 8, nil)
+	assert.ErrorIs(t, m.checkUpgrade(header), ErrUpgradeNotApplied)
+}
+
+func TestScheduleUpgrade(t *testing.T) {
+	ctx := context.Background()
+	signerA, _, addrA := newRotationTestSigner(t)
+	da := &namespacedDA{DA: coreda.NewDummyDA(100_000, 0, 0)}
+	m, _, _, _ := newTestManager(t, withStore(newRotationTestStore(t)), withConfig(config.DefaultConfig), withGenesis(rotationTestGenesis(addrA)))
+	m.signer = signerA
+	m.da = da
+
+	require.NoError(t, m.store.SetHeight(ctx, 3))
+	_, err := m.ScheduleUpgrade(ctx, types.Upgrade{Name: "v1", Height: 3, BlockVersion: 1})
+	require.ErrorIs(t, err, types.ErrInvalidUpgrade)
+
+	upgrade, err := m.ScheduleUpgrade(ctx, types.Upgrade{Name: "ns", Height: 5, Namespace: "abcd"})
+	require.NoError(t, err)
+	assert.Equal(t, rotationTestChainID, upgrade.ChainID)
+	assert.Equal(t, addrA, upgrade.Signer.Address)
+
+	// headers from the upgrade height on are submitted to the namespace of the upgrade
+	headerDA, err := m.headerDA(4)
+	require.NoError(t, err)
+	assert.Same(t, da, headerDA)
+	headerDA, err = m.headerDA(5)
+	require.NoError(t, err)
+	nsBytes, _ := hex.DecodeString("abcd")
+	assert.Equal(t, nsBytes, headerDA.(*namespacedDA).namespace)
+	assert.Len(t, m.upgradeDAs(), 1)
+
+	headers := make([]*types.SignedHeader, 4)
+	for i := range headers {
+		headers[i] = newRotationTestHeader(t, signerA, uint64(i+3), nil)
+	}
+	assert.Equal(t, headers[:2], m.sameNamespaceHeaders(headers))
+	assert.Equal(t, headers[2:], m.sameNamespaceHeaders(headers[2:]))
+
+	// nodes retrieving the upgrade from DA apply it
+	ids, err := da.GetIDs(ctx, 0, nil)
+	require.NoError(t, err)
+	blobs, err := da.Get(ctx, ids.IDs, nil)
+	require.NoError(t, err)
+	follower, _, _, _ := newTestManager(t, withStore(newRotationTestStore(t)), withConfig(config.DefaultConfig), withGenesis(rotationTestGenesis(addrA)))
+	follower.da = da
+	follower.processBlobs(ctx, blobs, nil, 0)
+	assert.Equal(t, "abcd", follower.protocol(5).namespace)
+	assert.Empty(t, follower.protocol(4).namespace)
+}
+
+func TestDecodeUpgrade(t *testing.T) {
+	signerA, _, addrA := newRotationTestSigner(t)
+	_, keyB, _ := newRotationTestSigner(t)
+	m, _, _, _ := newTestManager(t, withStore(newRotationTestStore(t)), withConfig(config.DefaultConfig), withGenesis(rotationTestGenesis(addrA)))
+
+	upgrade := newTestUpgrade(t, signerA, rotationTestChainID, types.Upgrade{Name: "v1", Height: 5, BlockVersion: 1})
+	bz, err := upgrade.MarshalBinary()
+	require.NoError(t, err)
+	decoded, ok := m.decodeUpgrade(bz, 1)
+	require.True(t, ok)
+	assert.Equal(t, upgrade, decoded)
+
+	// key rotations and headers are not mistaken for upgrades
+	rotation, err := types.GetKeyRotation(signerA, keyB, rotationTestChainID, 5)
+	require.NoError(t, err)
+	bz, err = rotation.MarshalBinary()
+	require.NoError(t, err)
+	_, ok = m.decodeUpgrade(bz, 1)
+	assert.False(t, ok)
+	bz, err = newRotationTestHeader(t, signerA, 5, nil).MarshalBinary()
+	require.NoError(t, err)
+	_, ok = m.decodeUpgrade(bz, 1)
+	assert.False(t, ok)
+}
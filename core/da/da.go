@@ -43,6 +43,79 @@ type DA interface {
 	GasMultiplier(ctx context.Context) (float64, error)
 }
 
+// Namespacer is implemented by DA clients bound to a single namespace. WithNamespace returns a client
+// for another namespace, sharing the connection of the original client.
+type Namespacer interface {
+	WithNamespace(namespace []byte) DA
+}
+
+// BlobSizer is implemented by DA clients reporting the maximum size of a blob accepted by the DA layer.
+type BlobSizer interface {
+	MaxBlobSize(ctx context.Context) (uint64, error)
+}
+
+// Sampler is implemented by DA clients supporting data availability sampling, e.g. DA light clients. Sampling
+// random shares of the erasure coded DA blocks lets nodes check that the data of a DA block was published without
+// downloading it.
+type Sampler interface {
+	// ShareCount returns the number of shares of the erasure coded DA block at the given height.
+	ShareCount(ctx context.Context, height uint64) (uint64, error)
+	// SampleShares retrieves the shares at the given indices of the erasure coded DA block at the given height and
+	// verifies them against the data root of the block. It returns an error wrapping ErrShareUnavailable if a
+	// share cannot be retrieved or fails verification.
+	SampleShares(ctx context.Context, height uint64, indices []uint64) error
+}
+
+// BlockReader is implemented by DA clients reporting the blocks of the DA layer. Sequencers commit to the latest
+// DA block they observed in the headers of the blocks they create, and full nodes check the commitments against
+// the DA layer.
+type BlockReader interface {
+	// LatestBlock returns the latest block of the DA layer, with a zero height if the DA layer has no block yet.
+	LatestBlock(ctx context.Context) (*BlockRef, error)
+	// BlockHash returns the hash of the DA block at the given height. It returns an error wrapping
+	// ErrFutureHeight if the DA layer has no block at the height yet.
+	BlockHash(ctx context.Context, height uint64) ([]byte, error)
+}
+
+// QuorumSubmitter is implemented by DA clients submitting blobs to several DA backends, e.g. Multiplexer.
+type QuorumSubmitter interface {
+	DA
+	// Quorum returns the number of backends that must include a blob for it to be considered included.
+	Quorum() int
+	// SubmitToBackends submits blobs like SubmitWithOptions and also returns the indexes of the backends that
+	// accepted them.
+	SubmitToBackends(ctx context.Context, blobs []Blob, gasPrice float64, namespace []byte, options []byte) ([]ID, []int, error)
+}
+
+// Wrapper is implemented by DA clients wrapping other DA clients, e.g. Multiplexer.
+type Wrapper interface {
+	Unwrap() []DA
+}
+
+// Implements returns the DA client as T if it implements the optional interface T, e.g. Sampler. A DA client
+// implementing Wrapper only implements T if all the DA clients it wraps do.
+func Implements[T any](da DA) (T, bool) {
+	impl, ok := da.(T)
+	if !ok {
+		return impl, false
+	}
+	if wrapper, isWrapper := da.(Wrapper); isWrapper {
+		for _, wrapped := range wrapper.Unwrap() {
+			if _, ok := Implements[T](wrapped); !ok {
+				var zero T
+				return zero, false
+			}
+		}
+	}
+	return impl, true
+}
+
+// BlockRef references a block of the DA layer by its height and hash.
+type BlockRef struct {
+	Height uint64
+	Hash   []byte
+}
+
 // Blob is the data submitted/received from DA interface.
 type Blob = []byte
 
@@ -91,6 +164,8 @@ const (
 	StatusContextDeadline
 	StatusError
 	StatusIncorrectAccountSequence
+	StatusUnderpriced
+	StatusFutureHeight
 )
 
 // BaseResult contains basic information returned by DA layer.
@@ -3,7 +3,9 @@ package da
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 )
@@ -16,6 +18,7 @@ type DummyDA struct {
 	proofs             map[string]Proof
 	blobsByHeight      map[uint64][]ID
 	timestampsByHeight map[uint64]time.Time
+	withheldHeights    map[uint64]bool
 	maxBlobSize        uint64
 	gasPrice           float64
 	gasMultiplier      float64
@@ -29,6 +32,7 @@ func NewDummyDA(maxBlobSize uint64, gasPrice float64, gasMultiplier float64) *Du
 		proofs:             make(map[string]Proof),
 		blobsByHeight:      make(map[uint64][]ID),
 		timestampsByHeight: make(map[uint64]time.Time),
+		withheldHeights:    make(map[uint64]bool),
 		maxBlobSize:        maxBlobSize,
 		gasPrice:           gasPrice,
 		gasMultiplier:      gasMultiplier,
@@ -108,9 +112,9 @@ func (d *DummyDA) Commit(ctx context.Context, blobs []Blob, namespace []byte) ([
 
 	commitments := make([]Commitment, 0, len(blobs))
 	for _, blob := range blobs {
-		// For simplicity, we use the blob itself as the commitment
-		commitment := blob
-		commitments = append(commitments, commitment)
+		// the commitment is the one embedded in the IDs of the submitted blobs
+		commitment := sha256.Sum256(blob)
+		commitments = append(commitments, commitment[:])
 	}
 	return commitments, nil
 }
@@ -187,3 +191,85 @@ func (d *DummyDA) Validate(ctx context.Context, ids []ID, proofs []Proof, namesp
 
 	return results, nil
 }
+
+// dummyShareSize is the size of the shares of the DA blocks of DummyDA.
+const dummyShareSize = 512
+
+// ShareCount returns the number of shares of the DA block at the given height: the shares holding its blobs,
+// doubled by erasure coding.
+func (d *DummyDA) ShareCount(ctx context.Context, height uint64) (uint64, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	ids, ok := d.blobsByHeight[height]
+	if !ok {
+		return 0, ErrFutureHeight
+	}
+	var size uint64
+	for _, id := range ids {
+		size += uint64(len(d.blobs[string(id)]))
+	}
+	return 2 * max((size+dummyShareSize-1)/dummyShareSize, 1), nil
+}
+
+// SampleShares checks that the shares at the given indices of the DA block at the given height are available.
+func (d *DummyDA) SampleShares(ctx context.Context, height uint64, indices []uint64) error {
+	count, err := d.ShareCount(ctx, height)
+	if err != nil {
+		return err
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.withheldHeights[height] {
+		return fmt.Errorf("%w: DA block at height %d is withheld", ErrShareUnavailable, height)
+	}
+	for _, index := range indices {
+		if index >= count {
+			return fmt.Errorf("share index %d out of range, DA block at height %d has %d shares", index, height, count)
+		}
+	}
+	return nil
+}
+
+// WithholdShares makes the shares of the DA block at the given height unavailable to sampling, simulating a DA
+// block whose data was not published.
+func (d *DummyDA) WithholdShares(height uint64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.withheldHeights[height] = true
+}
+
+// LatestBlock returns the latest DA block of DummyDA, the block of the latest submission.
+func (d *DummyDA) LatestBlock(ctx context.Context) (*BlockRef, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if len(d.blobsByHeight) == 0 {
+		return &BlockRef{}, nil
+	}
+	height := uint64(len(d.blobsByHeight)) - 1
+	return &BlockRef{Height: height, Hash: d.blockHash(height)}, nil
+}
+
+// BlockHash returns the hash of the DA block at the given height.
+func (d *DummyDA) BlockHash(ctx context.Context, height uint64) ([]byte, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if _, ok := d.blobsByHeight[height]; !ok {
+		return nil, ErrFutureHeight
+	}
+	return d.blockHash(height), nil
+}
+
+// blockHash derives the hash of the DA block at the given height from its height, time and blob IDs.
+func (d *DummyDA) blockHash(height uint64) []byte {
+	hasher := sha256.New()
+	hasher.Write(binary.BigEndian.AppendUint64(nil, height))
+	hasher.Write(binary.BigEndian.AppendUint64(nil, uint64(d.timestampsByHeight[height].UnixNano()))) //nolint:gosec // timestamps are after 1970
+	for _, id := range d.blobsByHeight[height] {
+		hasher.Write(id)
+	}
+	return hasher.Sum(nil)
+}
@@ -1,7 +1,9 @@
 package da
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"testing"
 )
 
@@ -63,6 +65,11 @@ func TestDummyDA(t *testing.T) {
 	if len(commitments) != len(blobs) {
 		t.Errorf("Expected %d commitments, got %d", len(blobs), len(commitments))
 	}
+	for i, id := range ids {
+		if _, commitment, err := SplitID(id); err != nil || !bytes.Equal(commitment, commitments[i]) {
+			t.Errorf("Expected commitment %d to match the commitment of its ID", i)
+		}
+	}
 
 	// Test GetProofs
 	proofs, err := dummyDA.GetProofs(ctx, ids, nil)
@@ -94,3 +101,68 @@ func TestDummyDA(t *testing.T) {
 		t.Errorf("Expected error for blob exceeding max size, got nil")
 	}
 }
+
+func TestDummyDASampling(t *testing.T) {
+	dummyDA := NewDummyDA(4096, 0, 0)
+	ctx := context.Background()
+
+	if _, err := dummyDA.Submit(ctx, []Blob{make([]byte, 1000)}, 0, nil); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+
+	// 1000 bytes fill 2 shares, doubled by erasure coding
+	count, err := dummyDA.ShareCount(ctx, 0)
+	if err != nil {
+		t.Fatalf("ShareCount failed: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("Expected 4 shares, got %d", count)
+	}
+	if _, err := dummyDA.ShareCount(ctx, 1); !errors.Is(err, ErrFutureHeight) {
+		t.Errorf("Expected ErrFutureHeight, got %v", err)
+	}
+
+	if err := dummyDA.SampleShares(ctx, 0, []uint64{0, 3}); err != nil {
+		t.Errorf("SampleShares failed: %v", err)
+	}
+	if err := dummyDA.SampleShares(ctx, 0, []uint64{4}); err == nil {
+		t.Error("Expected error for share index out of range")
+	}
+
+	dummyDA.WithholdShares(0)
+	if err := dummyDA.SampleShares(ctx, 0, []uint64{0}); !errors.Is(err, ErrShareUnavailable) {
+		t.Errorf("Expected ErrShareUnavailable, got %v", err)
+	}
+}
+
+func TestDummyDABlocks(t *testing.T) {
+	dummyDA := NewDummyDA(4096, 0, 0)
+	ctx := context.Background()
+
+	if block, err := dummyDA.LatestBlock(ctx); err != nil || block.Height != 0 || block.Hash != nil {
+		t.Errorf("Expected no DA block, got %v, error %v", block, err)
+	}
+	for range 2 {
+		if _, err := dummyDA.Submit(ctx, []Blob{[]byte("blob")}, 0, nil); err != nil {
+			t.Fatalf("Submit failed: %v", err)
+		}
+	}
+
+	block, err := dummyDA.LatestBlock(ctx)
+	if err != nil {
+		t.Fatalf("LatestBlock failed: %v", err)
+	}
+	hash := block.Hash
+	if block.Height != 1 || len(hash) != 32 {
+		t.Errorf("Expected DA block 1 with a 32 byte hash, got height %d, hash %X", block.Height, hash)
+	}
+	if got, err := dummyDA.BlockHash(ctx, 1); err != nil || !bytes.Equal(got, hash) {
+		t.Errorf("Expected hash %X, got %X, error %v", hash, got, err)
+	}
+	if got, err := dummyDA.BlockHash(ctx, 0); err != nil || bytes.Equal(got, hash) {
+		t.Errorf("Expected a different hash for DA block 0, got %X, error %v", got, err)
+	}
+	if _, err := dummyDA.BlockHash(ctx, 2); !errors.Is(err, ErrFutureHeight) {
+		t.Errorf("Expected ErrFutureHeight, got %v", err)
+	}
+}
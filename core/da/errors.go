@@ -10,6 +10,8 @@ var (
 	ErrTxTimedOut                 = errors.New("timed out waiting for tx to be included in a block")
 	ErrTxAlreadyInMempool         = errors.New("tx already in mempool")
 	ErrTxIncorrectAccountSequence = errors.New("incorrect account sequence")
+	ErrTxUnderpriced              = errors.New("tx gas price too low")
 	ErrContextDeadline            = errors.New("context deadline")
 	ErrFutureHeight               = errors.New("future height")
+	ErrShareUnavailable           = errors.New("share: unavailable")
 )
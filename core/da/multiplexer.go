@@ -0,0 +1,412 @@
+package da
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ErrQuorumNotReached is returned when fewer DA backends than the configured quorum accepted a submission.
+var ErrQuorumNotReached = errors.New("da quorum not reached")
+
+// Multiplexer wraps several DA clients and exposes them as a single DA.
+//
+// Submissions are sent to the primary backend first and then to the following backends
+// until the configured quorum of backends accepted the blobs. A backend that fails
+// maxFailures consecutive submissions while being primary is demoted and the next
+// backend becomes the primary.
+//
+// IDs and DA heights are only meaningful on the backend that issued them, so reads are
+// pinned to the first backend, the read backend, and never fall back to another backend.
+// Submissions return the IDs assigned by the read backend, so that the DA heights and
+// IDs stored by callers can always be read back.
+type Multiplexer struct {
+	backends    []DA
+	quorum      int
+	maxFailures int
+
+	mu       sync.Mutex
+	primary  int
+	failures []int
+	// accepted holds the backends that accepted blobs, by namespace and blob hash, until the blobs
+	// reached the quorum
+	accepted map[string]*acceptedBlob
+	seq      uint64
+}
+
+// acceptedBlob holds the IDs assigned to a blob by the backends that accepted it. seq orders the blobs by
+// their last acceptance.
+type acceptedBlob struct {
+	ids map[int]ID
+	seq uint64
+}
+
+// readBackend is the index of the backend serving reads.
+const readBackend = 0
+
+var (
+	_ QuorumSubmitter = &Multiplexer{}
+	_ Wrapper         = &Multiplexer{}
+	_ Namespacer      = &Multiplexer{}
+	_ BlobSizer       = &Multiplexer{}
+	_ Sampler         = &Multiplexer{}
+	_ BlockReader     = &Multiplexer{}
+)
+
+// NewMultiplexer creates a Multiplexer over the given backends, the first one being the initial primary.
+func NewMultiplexer(quorum int, maxFailures int, backends ...DA) (*Multiplexer, error) {
+	if len(backends) == 0 {
+		return nil, errors.New("at least one DA backend is required")
+	}
+	if quorum < 1 || quorum > len(backends) {
+		return nil, fmt.Errorf("invalid quorum %d for %d DA backends", quorum, len(backends))
+	}
+	if maxFailures < 1 {
+		return nil, fmt.Errorf("invalid max failures %d, must be at least 1", maxFailures)
+	}
+	return &Multiplexer{
+		backends:    backends,
+		quorum:      quorum,
+		maxFailures: maxFailures,
+		failures:    make([]int, len(backends)),
+		accepted:    make(map[string]*acceptedBlob),
+	}, nil
+}
+
+// Quorum returns the number of backends that must include a blob for it to be considered included.
+func (m *Multiplexer) Quorum() int {
+	return m.quorum
+}
+
+// Unwrap returns the backends. The optional interfaces forwarded by the Multiplexer are only usable if all
+// backends implement them, see Implements.
+func (m *Multiplexer) Unwrap() []DA {
+	return m.backends
+}
+
+// Primary returns the index of the current primary backend.
+func (m *Multiplexer) Primary() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.primary
+}
+
+// order returns backend indexes starting from the current primary.
+func (m *Multiplexer) order() []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	order := make([]int, len(m.backends))
+	for i := range order {
+		order[i] = (m.primary + i) % len(m.backends)
+	}
+	return order
+}
+
+// recordResult updates the failure counter of the given backend and fails over to the next
+// backend when the primary reached the maximum number of consecutive failures.
+func (m *Multiplexer) recordResult(backend int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err == nil {
+		m.failures[backend] = 0
+		return
+	}
+	m.failures[backend]++
+	if backend == m.primary && m.failures[backend] >= m.maxFailures {
+		m.failures[backend] = 0
+		m.primary = (m.primary + 1) % len(m.backends)
+	}
+}
+
+// maxAcceptedBlobs bounds the number of blobs whose accepting backends are remembered until they reach the
+// quorum. Once the bound is exceeded, the blobs accepted least recently are forgotten, which are those of
+// submissions abandoned by the caller.
+const maxAcceptedBlobs = 1 << 14
+
+// SubmitToBackends submits blobs to the backends, starting from the primary, until the quorum is reached.
+// Backends that accepted a blob in a previous submission which did not reach the quorum are not sent it
+// again, so that retrying a submission only submits to the backends that failed. A backend counts towards
+// the quorum once it accepted all the blobs.
+//
+// It returns the IDs assigned by the read backend, which are empty if the read backend did not accept the
+// blobs, and the indexes of all backends that accepted them.
+// ErrQuorumNotReached is returned if fewer than quorum backends accepted.
+func (m *Multiplexer) SubmitToBackends(ctx context.Context, blobs []Blob, gasPrice float64, namespace []byte, options []byte) ([]ID, []int, error) {
+	keys := make([]string, len(blobs))
+	for i, blob := range blobs {
+		hash := sha256.Sum256(blob)
+		keys[i] = string(namespace) + "/" + string(hash[:])
+	}
+	var (
+		included []int
+		errs     error
+	)
+	for _, i := range m.order() {
+		if len(included) == m.quorum {
+			break
+		}
+		pending := m.pendingBlobs(keys, i)
+		if len(pending) > 0 {
+			if ctx.Err() != nil {
+				errs = errors.Join(errs, ctx.Err())
+				break
+			}
+			toSubmit := make([]Blob, len(pending))
+			for j, k := range pending {
+				toSubmit[j] = blobs[k]
+			}
+			backendIDs, err := m.backends[i].SubmitWithOptions(ctx, toSubmit, gasPrice, namespace, options)
+			m.recordResult(i, err)
+			if err != nil {
+				errs = errors.Join(errs, fmt.Errorf("backend %d: %w", i, err))
+				continue
+			}
+			m.accept(keys, pending, i, backendIDs)
+			if len(backendIDs) < len(pending) {
+				errs = errors.Join(errs, fmt.Errorf("backend %d: accepted %d of %d blobs", i, len(backendIDs), len(pending)))
+				continue
+			}
+		}
+		included = append(included, i)
+	}
+	ids := m.acceptedIDs(keys, included)
+	if len(included) < m.quorum {
+		return ids, included, errors.Join(fmt.Errorf("%w: %d of %d backends", ErrQuorumNotReached, len(included), m.quorum), errs)
+	}
+	m.forget(keys)
+	return ids, included, nil
+}
+
+// pendingBlobs returns the indexes of the blobs with the given keys not accepted by the backend yet.
+func (m *Multiplexer) pendingBlobs(keys []string, backend int) []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var pending []int
+	for i, key := range keys {
+		if blob := m.accepted[key]; blob == nil || blob.ids[backend] == nil {
+			pending = append(pending, i)
+		}
+	}
+	return pending
+}
+
+// accept records the IDs assigned by the backend to the blobs at the given indexes. Backends accepting fewer
+// blobs than submitted accepted the first ones.
+func (m *Multiplexer) accept(keys []string, indexes []int, backend int, ids []ID) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.seq++
+	for j, id := range ids[:min(len(ids), len(indexes))] {
+		key := keys[indexes[j]]
+		blob := m.accepted[key]
+		if blob == nil {
+			blob = &acceptedBlob{ids: make(map[int]ID)}
+			m.accepted[key] = blob
+		}
+		blob.ids[backend] = id
+		blob.seq = m.seq
+	}
+	if len(m.accepted) > maxAcceptedBlobs {
+		m.evict(keys)
+	}
+}
+
+// evict forgets the blobs accepted least recently, down to 3/4 of maxAcceptedBlobs, except the blobs with
+// the given keys, which are being submitted.
+func (m *Multiplexer) evict(keys []string) {
+	candidates := make([]string, 0, len(m.accepted))
+	for key := range m.accepted {
+		if !slices.Contains(keys, key) {
+			candidates = append(candidates, key)
+		}
+	}
+	slices.SortFunc(candidates, func(a, b string) int {
+		return cmp.Compare(m.accepted[a].seq, m.accepted[b].seq)
+	})
+	for _, key := range candidates {
+		if len(m.accepted) <= maxAcceptedBlobs*3/4 {
+			return
+		}
+		delete(m.accepted, key)
+	}
+}
+
+// acceptedIDs returns the IDs assigned to the blobs by the read backend, or nil IDs if the read backend is
+// not among the included backends.
+func (m *Multiplexer) acceptedIDs(keys []string, included []int) []ID {
+	if len(included) == 0 {
+		return nil
+	}
+	ids := make([]ID, len(keys))
+	if !slices.Contains(included, readBackend) {
+		return ids
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, key := range keys {
+		if blob := m.accepted[key]; blob != nil {
+			ids[i] = blob.ids[readBackend]
+		}
+	}
+	return ids
+}
+
+// forget drops the accepting backends of blobs which reached the quorum.
+func (m *Multiplexer) forget(keys []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		delete(m.accepted, key)
+	}
+}
+
+// Submit submits the Blobs to the DA backends.
+func (m *Multiplexer) Submit(ctx context.Context, blobs []Blob, gasPrice float64, namespace []byte) ([]ID, error) {
+	return m.SubmitWithOptions(ctx, blobs, gasPrice, namespace, nil)
+}
+
+// SubmitWithOptions submits the Blobs to the DA backends with additional options.
+func (m *Multiplexer) SubmitWithOptions(ctx context.Context, blobs []Blob, gasPrice float64, namespace []byte, options []byte) ([]ID, error) {
+	ids, _, err := m.SubmitToBackends(ctx, blobs, gasPrice, namespace, options)
+	return ids, err
+}
+
+// read calls fn on the primary backend and falls back to the other backends on error. It is only used for
+// reads whose result does not depend on the backend's IDs and heights.
+func read[T any](m *Multiplexer, fn func(DA) (T, error)) (T, error) {
+	var (
+		res  T
+		errs error
+	)
+	for _, i := range m.order() {
+		r, err := fn(m.backends[i])
+		if err == nil {
+			return r, nil
+		}
+		errs = errors.Join(errs, fmt.Errorf("backend %d: %w", i, err))
+	}
+	return res, errs
+}
+
+// pinned calls fn on the read backend only, see Multiplexer.
+func pinned[T any](m *Multiplexer, fn func(DA) (T, error)) (T, error) {
+	res, err := fn(m.backends[readBackend])
+	if err != nil {
+		return res, fmt.Errorf("backend %d: %w", readBackend, err)
+	}
+	return res, nil
+}
+
+// Get returns Blob for each given ID, or an error.
+func (m *Multiplexer) Get(ctx context.Context, ids []ID, namespace []byte) ([]Blob, error) {
+	return pinned(m, func(d DA) ([]Blob, error) { return d.Get(ctx, ids, namespace) })
+}
+
+// GetIDs returns IDs of all Blobs located in DA at given height.
+func (m *Multiplexer) GetIDs(ctx context.Context, height uint64, namespace []byte) (*GetIDsResult, error) {
+	return pinned(m, func(d DA) (*GetIDsResult, error) { return d.GetIDs(ctx, height, namespace) })
+}
+
+// GetProofs returns inclusion Proofs for Blobs specified by their IDs.
+func (m *Multiplexer) GetProofs(ctx context.Context, ids []ID, namespace []byte) ([]Proof, error) {
+	return pinned(m, func(d DA) ([]Proof, error) { return d.GetProofs(ctx, ids, namespace) })
+}
+
+// Commit creates a Commitment for each given Blob.
+func (m *Multiplexer) Commit(ctx context.Context, blobs []Blob, namespace []byte) ([]Commitment, error) {
+	return pinned(m, func(d DA) ([]Commitment, error) { return d.Commit(ctx, blobs, namespace) })
+}
+
+// Validate validates Commitments against the corresponding Proofs.
+func (m *Multiplexer) Validate(ctx context.Context, ids []ID, proofs []Proof, namespace []byte) ([]bool, error) {
+	return pinned(m, func(d DA) ([]bool, error) { return d.Validate(ctx, ids, proofs, namespace) })
+}
+
+// GasPrice returns the gas price of the primary backend.
+func (m *Multiplexer) GasPrice(ctx context.Context) (float64, error) {
+	return read(m, func(d DA) (float64, error) { return d.GasPrice(ctx) })
+}
+
+// GasMultiplier returns the gas multiplier of the primary backend.
+func (m *Multiplexer) GasMultiplier(ctx context.Context) (float64, error) {
+	return read(m, func(d DA) (float64, error) { return d.GasMultiplier(ctx) })
+}
+
+// WithNamespace returns a Multiplexer over the backends bound to the given namespace, starting from the
+// current primary backend.
+func (m *Multiplexer) WithNamespace(namespace []byte) DA {
+	backends := make([]DA, len(m.backends))
+	for i, backend := range m.backends {
+		namespacer, ok := backend.(Namespacer)
+		if !ok {
+			return m
+		}
+		backends[i] = namespacer.WithNamespace(namespace)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &Multiplexer{
+		backends:    backends,
+		quorum:      m.quorum,
+		maxFailures: m.maxFailures,
+		primary:     m.primary,
+		failures:    make([]int, len(backends)),
+		accepted:    make(map[string]*acceptedBlob),
+	}
+}
+
+// MaxBlobSize returns the lowest maximum blob size of the backends, so that blobs are accepted by all of them.
+func (m *Multiplexer) MaxBlobSize(ctx context.Context) (uint64, error) {
+	var size uint64
+	for i, backend := range m.backends {
+		sizer, ok := backend.(BlobSizer)
+		if !ok {
+			return 0, fmt.Errorf("backend %d does not report its maximum blob size", i)
+		}
+		backendSize, err := sizer.MaxBlobSize(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("backend %d: %w", i, err)
+		}
+		if size == 0 || backendSize < size {
+			size = backendSize
+		}
+	}
+	return size, nil
+}
+
+// optional calls fn on the read backend as the optional interface T, see pinned.
+func optional[T, R any](m *Multiplexer, fn func(T) (R, error)) (R, error) {
+	return pinned(m, func(d DA) (R, error) {
+		impl, ok := d.(T)
+		if !ok {
+			var res R
+			return res, fmt.Errorf("%T does not implement %T", d, (*T)(nil))
+		}
+		return fn(impl)
+	})
+}
+
+// ShareCount returns the number of shares of the DA block at the given height on the read backend.
+func (m *Multiplexer) ShareCount(ctx context.Context, height uint64) (uint64, error) {
+	return optional(m, func(s Sampler) (uint64, error) { return s.ShareCount(ctx, height) })
+}
+
+// SampleShares samples shares of the DA block at the given height on the read backend.
+func (m *Multiplexer) SampleShares(ctx context.Context, height uint64, indices []uint64) error {
+	_, err := optional(m, func(s Sampler) (struct{}, error) { return struct{}{}, s.SampleShares(ctx, height, indices) })
+	return err
+}
+
+// LatestBlock returns the latest DA block of the read backend.
+func (m *Multiplexer) LatestBlock(ctx context.Context) (*BlockRef, error) {
+	return optional(m, func(r BlockReader) (*BlockRef, error) { return r.LatestBlock(ctx) })
+}
+
+// BlockHash returns the hash of the DA block at the given height on the read backend.
+func (m *Multiplexer) BlockHash(ctx context.Context, height uint64) ([]byte, error) {
+	return optional(m, func(r BlockReader) ([]byte, error) { return r.BlockHash(ctx, height) })
+}
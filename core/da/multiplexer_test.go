@@ -0,0 +1,278 @@
+package da
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// failingDA is a DA that fails all submissions.
+type failingDA struct {
+	*DummyDA
+	submissions int
+}
+
+func (f *failingDA) SubmitWithOptions(ctx context.Context, blobs []Blob, gasPrice float64, namespace []byte, options []byte) ([]ID, error) {
+	f.submissions++
+	return nil, errors.New("submission failed")
+}
+
+func TestNewMultiplexer_InvalidParams(t *testing.T) {
+	if _, err := NewMultiplexer(1, 1); err == nil {
+		t.Error("expected error without backends")
+	}
+	if _, err := NewMultiplexer(2, 1, NewDummyDA(1024, 0, 0)); err == nil {
+		t.Error("expected error when quorum exceeds number of backends")
+	}
+	if _, err := NewMultiplexer(1, 0, NewDummyDA(1024, 0, 0)); err == nil {
+		t.Error("expected error for zero max failures")
+	}
+}
+
+func TestMultiplexer_FailoverToSecondary(t *testing.T) {
+	ctx := context.Background()
+	primary := &failingDA{DummyDA: NewDummyDA(1024, 0, 0)}
+	secondary := NewDummyDA(1024, 0, 0)
+	mux, err := NewMultiplexer(1, 2, primary, secondary)
+	if err != nil {
+		t.Fatalf("NewMultiplexer failed: %v", err)
+	}
+
+	blobs := []Blob{[]byte("blob")}
+	for i := 0; i < 2; i++ {
+		ids, included, err := mux.SubmitToBackends(ctx, blobs, 0, nil, nil)
+		if err != nil {
+			t.Fatalf("SubmitToBackends failed: %v", err)
+		}
+		if len(ids) != 1 || len(included) != 1 || included[0] != 1 {
+			t.Fatalf("expected blob to be included by secondary only, got ids=%d included=%v", len(ids), included)
+		}
+		// the IDs of the secondary cannot be read back from the read backend
+		if ids[0] != nil {
+			t.Fatalf("expected no ID without the read backend, got %x", ids[0])
+		}
+	}
+	if mux.Primary() != 1 {
+		t.Fatalf("expected failover to backend 1, primary is %d", mux.Primary())
+	}
+
+	// the demoted primary is no longer tried first
+	if _, err := mux.Submit(ctx, blobs, 0, nil); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+	if primary.submissions != 2 {
+		t.Errorf("expected 2 submissions to failed primary, got %d", primary.submissions)
+	}
+}
+
+// unreadableDA is a DA that fails all reads.
+type unreadableDA struct {
+	*DummyDA
+	reads int
+}
+
+func (u *unreadableDA) GetIDs(ctx context.Context, height uint64, namespace []byte) (*GetIDsResult, error) {
+	u.reads++
+	return nil, errors.New("connection refused")
+}
+
+func (u *unreadableDA) Get(ctx context.Context, ids []ID, namespace []byte) ([]Blob, error) {
+	u.reads++
+	return nil, errors.New("connection refused")
+}
+
+func TestMultiplexer_PinnedReads(t *testing.T) {
+	ctx := context.Background()
+	first := &unreadableDA{DummyDA: NewDummyDA(1024, 0, 0)}
+	second := NewDummyDA(1024, 0, 0)
+	mux, err := NewMultiplexer(1, 2, first, second)
+	if err != nil {
+		t.Fatalf("NewMultiplexer failed: %v", err)
+	}
+	if _, err := second.Submit(ctx, []Blob{[]byte("blob")}, 0, nil); err != nil {
+		t.Fatalf("Submit failed: %v", err)
+	}
+
+	// failed reads never fall back to another backend, whose heights and IDs differ
+	for range 3 {
+		if _, err := mux.GetIDs(ctx, 0, nil); err == nil {
+			t.Fatal("expected error from the read backend")
+		}
+		if _, err := mux.Get(ctx, []ID{[]byte("id")}, nil); err == nil {
+			t.Fatal("expected error from the read backend")
+		}
+	}
+	if first.reads != 6 {
+		t.Fatalf("expected all 6 reads on backend 0, got %d", first.reads)
+	}
+}
+
+func TestMultiplexer_AcceptedBound(t *testing.T) {
+	ctx := context.Background()
+	accepting := NewDummyDA(1024, 0, 0)
+	failing := &failingDA{DummyDA: NewDummyDA(1024, 0, 0)}
+	mux, err := NewMultiplexer(2, 5, accepting, failing)
+	if err != nil {
+		t.Fatalf("NewMultiplexer failed: %v", err)
+	}
+
+	// blobs of abandoned submissions
+	for i := range maxAcceptedBlobs {
+		mux.accepted[fmt.Sprintf("abandoned/%d", i)] = &acceptedBlob{ids: map[int]ID{0: ID("id")}}
+	}
+	blobs := []Blob{[]byte("blob")}
+	if _, _, err := mux.SubmitToBackends(ctx, blobs, 0, nil, nil); !errors.Is(err, ErrQuorumNotReached) {
+		t.Fatalf("expected ErrQuorumNotReached, got %v", err)
+	}
+	if len(mux.accepted) > maxAcceptedBlobs*3/4 {
+		t.Fatalf("expected abandoned blobs to be forgotten, %d blobs remembered", len(mux.accepted))
+	}
+
+	// the blob being submitted is remembered, so that the retry does not submit it again
+	if _, _, err := mux.SubmitToBackends(ctx, blobs, 0, nil, nil); !errors.Is(err, ErrQuorumNotReached) {
+		t.Fatalf("expected ErrQuorumNotReached, got %v", err)
+	}
+	res, err := accepting.GetIDs(ctx, 0, nil)
+	if err != nil {
+		t.Fatalf("GetIDs failed: %v", err)
+	}
+	if len(res.IDs) != 1 {
+		t.Errorf("expected the blob to be submitted once to the accepting backend, got %d blobs", len(res.IDs))
+	}
+}
+
+func TestMultiplexer_Quorum(t *testing.T) {
+	ctx := context.Background()
+	failing := &failingDA{DummyDA: NewDummyDA(1024, 0, 0)}
+	mux, err := NewMultiplexer(2, 5, NewDummyDA(1024, 0, 0), failing, NewDummyDA(1024, 0, 0))
+	if err != nil {
+		t.Fatalf("NewMultiplexer failed: %v", err)
+	}
+	_, included, err := mux.SubmitToBackends(ctx, []Blob{[]byte("blob")}, 0, nil, nil)
+	if err != nil {
+		t.Fatalf("SubmitToBackends failed: %v", err)
+	}
+	if len(included) != 2 || included[0] != 0 || included[1] != 2 {
+		t.Errorf("expected backends [0 2], got %v", included)
+	}
+
+	mux, err = NewMultiplexer(2, 5, NewDummyDA(1024, 0, 0), failing)
+	if err != nil {
+		t.Fatalf("NewMultiplexer failed: %v", err)
+	}
+	ids, _, err := mux.SubmitToBackends(ctx, []Blob{[]byte("blob")}, 0, nil, nil)
+	if !errors.Is(err, ErrQuorumNotReached) {
+		t.Fatalf("expected ErrQuorumNotReached, got %v", err)
+	}
+	if len(ids) != 1 {
+		t.Errorf("expected IDs of the accepting backend, got %d", len(ids))
+	}
+}
+
+// flakyDA is a DA that fails the first submissions and counts the blobs submitted.
+type flakyDA struct {
+	*DummyDA
+	failures int
+	blobs    int
+}
+
+func (f *flakyDA) SubmitWithOptions(ctx context.Context, blobs []Blob, gasPrice float64, namespace []byte, options []byte) ([]ID, error) {
+	if f.failures > 0 {
+		f.failures--
+		return nil, errors.New("submission failed")
+	}
+	f.blobs += len(blobs)
+	return f.DummyDA.SubmitWithOptions(ctx, blobs, gasPrice, namespace, options)
+}
+
+func TestMultiplexer_RetrySubmitsToFailedBackends(t *testing.T) {
+	ctx := context.Background()
+	accepting := &flakyDA{DummyDA: NewDummyDA(1024, 0, 0)}
+	flaky := &flakyDA{DummyDA: NewDummyDA(1024, 0, 0), failures: 1}
+	mux, err := NewMultiplexer(2, 5, accepting, flaky)
+	if err != nil {
+		t.Fatalf("NewMultiplexer failed: %v", err)
+	}
+
+	blobs := []Blob{[]byte("blob1"), []byte("blob2")}
+	if _, _, err := mux.SubmitToBackends(ctx, blobs, 0, nil, nil); !errors.Is(err, ErrQuorumNotReached) {
+		t.Fatalf("expected ErrQuorumNotReached, got %v", err)
+	}
+
+	// the retry only submits to the backend which failed
+	ids, included, err := mux.SubmitToBackends(ctx, blobs, 0, nil, nil)
+	if err != nil {
+		t.Fatalf("SubmitToBackends failed: %v", err)
+	}
+	if len(included) != 2 || len(ids) != 2 {
+		t.Fatalf("expected 2 IDs included by 2 backends, got ids=%d included=%v", len(ids), included)
+	}
+	if accepting.blobs != 2 || flaky.blobs != 2 {
+		t.Errorf("expected each backend to receive the blobs once, got %d and %d", accepting.blobs, flaky.blobs)
+	}
+
+	// a new submission of blobs which already reached the quorum submits them again
+	if _, _, err := mux.SubmitToBackends(ctx, blobs, 0, nil, nil); err != nil {
+		t.Fatalf("SubmitToBackends failed: %v", err)
+	}
+	if accepting.blobs != 4 {
+		t.Errorf("expected the blobs to be submitted again, got %d blobs", accepting.blobs)
+	}
+}
+
+// plainDA hides the optional interfaces of the DA it wraps.
+type plainDA struct {
+	DA
+}
+
+func TestMultiplexer_OptionalInterfaces(t *testing.T) {
+	ctx := context.Background()
+	small, large := NewDummyDA(100, 0, 0), NewDummyDA(1000, 0, 0)
+	mux, err := NewMultiplexer(1, 1, large, small)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sizer, ok := Implements[BlobSizer](mux)
+	if !ok {
+		t.Fatal("expected multiplexer over DummyDAs to report the maximum blob size")
+	}
+	if size, err := sizer.MaxBlobSize(ctx); err != nil || size != 100 {
+		t.Fatalf("expected the lowest maximum blob size 100, got %d, %v", size, err)
+	}
+	if _, ok := Implements[Namespacer](mux); ok {
+		t.Fatal("expected multiplexer over DummyDAs not to support namespaces")
+	}
+
+	// the DA blocks are those of the read backend
+	for range 2 {
+		if _, err := large.Submit(ctx, []Blob{[]byte("blob")}, 0, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	reader, ok := Implements[BlockReader](mux)
+	if !ok {
+		t.Fatal("expected multiplexer over DummyDAs to read DA blocks")
+	}
+	block, err := reader.LatestBlock(ctx)
+	if err != nil || block.Height != 1 {
+		t.Fatalf("expected DA height 1 of the read backend, got %v, %v", block, err)
+	}
+	expected, _ := large.BlockHash(ctx, 1)
+	if string(block.Hash) != string(expected) {
+		t.Fatal("expected the DA block hash of the read backend")
+	}
+
+	// interfaces are only usable if all backends implement them
+	mux, err = NewMultiplexer(1, 1, large, &plainDA{small})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := Implements[BlobSizer](mux); ok {
+		t.Fatal("expected multiplexer not to report the maximum blob size of a single backend")
+	}
+	if _, ok := Implements[BlockReader](mux); ok {
+		t.Fatal("expected multiplexer not to read DA blocks of a single backend")
+	}
+}
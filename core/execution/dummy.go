@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha512"
+	"encoding/binary"
 	"fmt"
 	"slices"
 	"sync"
@@ -18,6 +19,7 @@ import (
 type DummyExecutor struct {
 	mu           sync.RWMutex // Add mutex for thread safety
 	stateRoot    []byte
+	finalHeight  uint64
 	pendingRoots map[uint64][]byte
 	maxBytes     uint64
 	injectedTxs  [][]byte
@@ -85,12 +87,64 @@ func (e *DummyExecutor) SetFinal(ctx context.Context, blockHeight uint64) error
 
 	if pending, ok := e.pendingRoots[blockHeight]; ok {
 		e.stateRoot = pending
+		e.finalHeight = blockHeight
 		delete(e.pendingRoots, blockHeight)
 		return nil
 	}
 	return fmt.Errorf("cannot set finalized block at height %d", blockHeight)
 }
 
+// CreateSnapshot returns the state root after executing the block at given height, which is the
+// whole state of the DummyExecutor. Only the state roots of the last finalized block and of the
+// blocks executed after it are known.
+func (e *DummyExecutor) CreateSnapshot(ctx context.Context, blockHeight uint64) ([]byte, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if pending, ok := e.pendingRoots[blockHeight]; ok {
+		return slices.Clone(pending), nil
+	}
+	if blockHeight != e.finalHeight {
+		return nil, fmt.Errorf("state after block %d is no longer available", blockHeight)
+	}
+	return slices.Clone(e.stateRoot), nil
+}
+
+// RestoreSnapshot sets the state root from a snapshot created with CreateSnapshot.
+func (e *DummyExecutor) RestoreSnapshot(ctx context.Context, blockHeight uint64, snapshot []byte) ([]byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(snapshot) == 0 {
+		return nil, fmt.Errorf("empty snapshot at height %d", blockHeight)
+	}
+	e.stateRoot = slices.Clone(snapshot)
+	clear(e.pendingRoots)
+	return e.stateRoot, nil
+}
+
+// Rollback discards the blocks executed above given height and returns the state root after the block at
+// given height. Only the state root of the last finalized block and of the blocks executed after it are known.
+func (e *DummyExecutor) Rollback(ctx context.Context, blockHeight uint64) ([]byte, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var stateRoot []byte
+	if pending, ok := e.pendingRoots[blockHeight]; ok {
+		stateRoot = pending
+	} else if blockHeight == e.finalHeight {
+		stateRoot = e.stateRoot
+	} else {
+		return nil, fmt.Errorf("state root at height %d is not available", blockHeight)
+	}
+	for height := range e.pendingRoots {
+		if height > blockHeight {
+			delete(e.pendingRoots, height)
+		}
+	}
+	return slices.Clone(stateRoot), nil
+}
+
 func (e *DummyExecutor) removeExecutedTxs(txs [][]byte) {
 	e.injectedTxs = slices.DeleteFunc(e.injectedTxs, func(tx []byte) bool {
 		return slices.ContainsFunc(txs, func(t []byte) bool { return bytes.Equal(tx, t) })
@@ -104,3 +158,61 @@ func (e *DummyExecutor) GetStateRoot() []byte {
 
 	return e.stateRoot
 }
+
+//---------------------
+// DummyProver
+//---------------------
+
+// DummyProver is a dummy implementation of the Prover interface for testing. The proof of a block is ready
+// once the proving delay has elapsed after the proof was requested, and is committed to by hashing the
+// state transition of the block.
+type DummyProver struct {
+	mu       sync.Mutex
+	delay    time.Duration
+	requests map[uint64]dummyProofRequest
+}
+
+type dummyProofRequest struct {
+	requestedAt time.Time
+	commitment  []byte
+}
+
+// NewDummyProver creates a new DummyProver generating proofs with the given proving delay.
+func NewDummyProver(delay time.Duration) *DummyProver {
+	return &DummyProver{
+		delay:    delay,
+		requests: make(map[uint64]dummyProofRequest),
+	}
+}
+
+// RequestProof starts proving the block at given height.
+func (p *DummyProver) RequestProof(ctx context.Context, blockHeight uint64, prevStateRoot, stateRoot []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.requests[blockHeight]; ok {
+		return nil
+	}
+	hash := sha512.New()
+	hash.Write(binary.BigEndian.AppendUint64(nil, blockHeight))
+	hash.Write(prevStateRoot)
+	hash.Write(stateRoot)
+	p.requests[blockHeight] = dummyProofRequest{requestedAt: time.Now(), commitment: hash.Sum(nil)}
+	return nil
+}
+
+// ProofCommitment returns the commitment to the proof of the block at given height once the proving delay
+// has elapsed.
+func (p *DummyProver) ProofCommitment(ctx context.Context, blockHeight uint64) ([]byte, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	req, ok := p.requests[blockHeight]
+	if !ok {
+		return nil, false, fmt.Errorf("no proof requested at height %d", blockHeight)
+	}
+	if time.Since(req.requestedAt) < p.delay {
+		return nil, false, nil
+	}
+	return slices.Clone(req.commitment), true, nil
+}
@@ -431,3 +431,110 @@ func TestExecuteTxsWithInvalidPrevStateRoot(t *testing.T) {
 		t.Errorf("Expected pending root to be stored for height %d", blockHeight)
 	}
 }
+
+func TestCreateAndRestoreSnapshot(t *testing.T) {
+	source := NewDummyExecutor()
+	ctx := context.Background()
+
+	stateRoot, _, err := source.ExecuteTxs(ctx, [][]byte{[]byte("tx1")}, 1, time.Now(), source.GetStateRoot())
+	if err != nil {
+		t.Fatalf("ExecuteTxs returned error: %v", err)
+	}
+
+	snapshot, err := source.CreateSnapshot(ctx, 1)
+	if err != nil {
+		t.Fatalf("CreateSnapshot returned error: %v", err)
+	}
+
+	target := NewDummyExecutor()
+	restoredRoot, err := target.RestoreSnapshot(ctx, 1, snapshot)
+	if err != nil {
+		t.Fatalf("RestoreSnapshot returned error: %v", err)
+	}
+	if !bytes.Equal(restoredRoot, stateRoot) {
+		t.Errorf("Expected restored state root %x, got %x", stateRoot, restoredRoot)
+	}
+	if !bytes.Equal(target.GetStateRoot(), stateRoot) {
+		t.Errorf("Expected target state root %x, got %x", stateRoot, target.GetStateRoot())
+	}
+
+	if _, err := target.RestoreSnapshot(ctx, 1, nil); err == nil {
+		t.Error("Expected error when restoring an empty snapshot")
+	}
+}
+
+func TestRollback(t *testing.T) {
+	executor := NewDummyExecutor()
+	ctx := context.Background()
+
+	root1, _, err := executor.ExecuteTxs(ctx, [][]byte{[]byte("tx1")}, 1, time.Now(), executor.GetStateRoot())
+	if err != nil {
+		t.Fatalf("ExecuteTxs returned error: %v", err)
+	}
+	if err := executor.SetFinal(ctx, 1); err != nil {
+		t.Fatalf("SetFinal returned error: %v", err)
+	}
+	root2, _, err := executor.ExecuteTxs(ctx, [][]byte{[]byte("tx2")}, 2, time.Now(), root1)
+	if err != nil {
+		t.Fatalf("ExecuteTxs returned error: %v", err)
+	}
+	if _, _, err := executor.ExecuteTxs(ctx, [][]byte{[]byte("tx3")}, 3, time.Now(), root2); err != nil {
+		t.Fatalf("ExecuteTxs returned error: %v", err)
+	}
+
+	stateRoot, err := executor.Rollback(ctx, 2)
+	if err != nil {
+		t.Fatalf("Rollback returned error: %v", err)
+	}
+	if !bytes.Equal(stateRoot, root2) {
+		t.Errorf("Expected state root %x, got %x", root2, stateRoot)
+	}
+	if err := executor.SetFinal(ctx, 3); err == nil {
+		t.Error("Expected error when finalizing a block discarded by the rollback")
+	}
+
+	stateRoot, err = executor.Rollback(ctx, 1)
+	if err != nil {
+		t.Fatalf("Rollback returned error: %v", err)
+	}
+	if !bytes.Equal(stateRoot, root1) {
+		t.Errorf("Expected state root %x, got %x", root1, stateRoot)
+	}
+	if _, err := executor.Rollback(ctx, 0); err == nil {
+		t.Error("Expected error when rolling back below the last finalized block")
+	}
+}
+
+func TestDummyProver(t *testing.T) {
+	prover := NewDummyProver(50 * time.Millisecond)
+	ctx := context.Background()
+
+	if _, _, err := prover.ProofCommitment(ctx, 1); err == nil {
+		t.Error("Expected error when getting the proof commitment of a block not requested")
+	}
+
+	if err := prover.RequestProof(ctx, 1, []byte{1}, []byte{2}); err != nil {
+		t.Fatalf("RequestProof returned error: %v", err)
+	}
+	if _, ready, err := prover.ProofCommitment(ctx, 1); err != nil || ready {
+		t.Errorf("Expected proof not to be ready before the proving delay, got ready=%v err=%v", ready, err)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	commitment, ready, err := prover.ProofCommitment(ctx, 1)
+	if err != nil || !ready {
+		t.Fatalf("Expected proof to be ready after the proving delay, got ready=%v err=%v", ready, err)
+	}
+	if len(commitment) == 0 {
+		t.Error("Expected non-empty proof commitment")
+	}
+
+	// requesting the proof again does not change it
+	if err := prover.RequestProof(ctx, 1, []byte{3}, []byte{4}); err != nil {
+		t.Fatalf("RequestProof returned error: %v", err)
+	}
+	again, _, _ := prover.ProofCommitment(ctx, 1)
+	if !bytes.Equal(commitment, again) {
+		t.Errorf("Expected proof commitment %x, got %x", commitment, again)
+	}
+}
@@ -2,6 +2,7 @@ package execution
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
@@ -83,3 +84,357 @@ type Executor interface {
 	// - error: Any errors during finalization
 	SetFinal(ctx context.Context, blockHeight uint64) error
 }
+
+// Snapshotter is an optional interface that can be implemented by an Executor to support
+// state sync, allowing new nodes to bootstrap from a snapshot of the execution state instead
+// of replaying all blocks.
+type Snapshotter interface {
+	// CreateSnapshot serializes the execution state after executing the block at the given height.
+	// Requirements:
+	// - May be called while the following blocks are executed, and must then still return the state after
+	//   the block at blockHeight, or an error if that state is no longer available
+	// - Must be deterministic for a given state
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	// - blockHeight: Height of the last executed block
+	//
+	// Returns:
+	// - snapshot: Serialized execution state
+	// - err: Any errors during snapshot creation
+	CreateSnapshot(ctx context.Context, blockHeight uint64) (snapshot []byte, err error)
+
+	// RestoreSnapshot replaces the execution state with the given snapshot.
+	// Requirements:
+	// - Must only be called on a freshly initialized execution client
+	// - Must return the state root of the restored state
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	// - blockHeight: Height of the last block included in the snapshot
+	// - snapshot: Serialized execution state created with CreateSnapshot
+	//
+	// Returns:
+	// - stateRoot: State root of the restored state
+	// - err: Any errors during restoration
+	RestoreSnapshot(ctx context.Context, blockHeight uint64, snapshot []byte) (stateRoot []byte, err error)
+}
+
+// Querier is an optional interface that can be implemented by an Executor to serve queries of the execution
+// state, allowing nodes to expose the state at past heights, e.g. archive nodes serving historical state.
+type Querier interface {
+	// Query returns the result of a query of the execution state after the block at the given height.
+	// Requirements:
+	// - Must not modify the execution state
+	// - Must return an error if the state at blockHeight is not available, e.g. it was pruned
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	// - path: Query path defined by the execution client, e.g. a key of the state
+	// - data: Query parameters, interpreted by the execution client
+	// - blockHeight: Height of the block after which the state is queried
+	//
+	// Returns:
+	// - result: Query result, interpreted by the caller
+	// - err: Any errors during the query
+	Query(ctx context.Context, path string, data []byte, blockHeight uint64) (result []byte, err error)
+}
+
+// Event is an event emitted by the execution layer while executing a transaction.
+type Event struct {
+	Type       string
+	Attributes []EventAttribute
+}
+
+// EventAttribute is a key-value pair attached to an Event.
+type EventAttribute struct {
+	Key   string
+	Value string
+}
+
+// EventEmitter is an optional interface that can be implemented by an Executor to expose the events
+// emitted by transactions, allowing nodes to index transactions by event.
+type EventEmitter interface {
+	// TxEvents returns the events emitted by the transactions of an executed block.
+	// Requirements:
+	// - Must return one entry per transaction, in transaction order
+	// - Must be available once ExecuteTxs returned for blockHeight
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	// - blockHeight: Height of the executed block
+	//
+	// Returns:
+	// - events: Events emitted by each transaction of the block
+	// - err: Any errors during retrieval, e.g. if the events are no longer available
+	TxEvents(ctx context.Context, blockHeight uint64) (events [][]Event, err error)
+}
+
+// GasMeter is an optional interface that can be implemented by an Executor to report the gas required by
+// transactions, allowing the block producer to enforce a maximum amount of gas per block.
+type GasMeter interface {
+	// TxGas returns the gas required to execute the transaction.
+	// Requirements:
+	// - Must be deterministic for a given transaction and state
+	// - Must not modify the execution state
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	// - tx: Transaction to estimate
+	//
+	// Returns:
+	// - gas: Gas required to execute the transaction
+	// - err: Any errors during estimation, e.g. if the transaction is malformed
+	TxGas(ctx context.Context, tx []byte) (gas uint64, err error)
+}
+
+// Prover is an optional interface that can be implemented by an Executor to generate validity proofs for
+// executed blocks, allowing zk-rollups to attach a commitment to the proof of a block to its header before
+// the header is submitted to the DA layer.
+type Prover interface {
+	// RequestProof requests a validity proof for an executed block.
+	// Requirements:
+	// - Must not wait for the proof to be generated
+	// - Must be idempotent for a given block
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	// - blockHeight: Height of the executed block
+	// - prevStateRoot: State root before executing the block
+	// - stateRoot: State root after executing the block
+	//
+	// Returns:
+	// - error: Any errors while requesting the proof
+	RequestProof(ctx context.Context, blockHeight uint64, prevStateRoot, stateRoot []byte) error
+
+	// ProofCommitment returns the commitment to the validity proof of a block.
+	// Requirements:
+	// - Must return ready false until the proof of the block is generated
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	// - blockHeight: Height of the block
+	//
+	// Returns:
+	// - commitment: Commitment to the validity proof of the block
+	// - ready: Whether the proof of the block is generated
+	// - err: Any errors while retrieving the commitment, e.g. if proving the block failed
+	ProofCommitment(ctx context.Context, blockHeight uint64) (commitment []byte, ready bool, err error)
+}
+
+// Rollbacker is an optional interface that can be implemented by an Executor to support rolling
+// the chain back to a prior height, e.g. to recover from a bad upgrade or non-deterministic execution.
+type Rollbacker interface {
+	// Rollback reverts the execution state to the state after executing the block at the given height.
+	// Requirements:
+	// - Must discard the effects of all blocks above blockHeight
+	// - Must return the state root of the reverted state
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	// - blockHeight: Height of the last block to keep
+	//
+	// Returns:
+	// - stateRoot: State root after executing the block at blockHeight
+	// - err: Any errors during rollback, e.g. if the state at blockHeight is no longer available
+	Rollback(ctx context.Context, blockHeight uint64) (stateRoot []byte, err error)
+}
+
+// TxChecker is an optional interface that can be implemented by an Executor to validate transactions before
+// they are admitted to the mempool, and to assign them a priority used to order the mempool.
+type TxChecker interface {
+	// CheckTx validates a transaction against the current execution state without executing it.
+	// Requirements:
+	// - Must not modify the execution state
+	// - Must return an error for transactions which cannot be included in a block
+	// - Must be safe to call concurrently with block execution
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	// - tx: Transaction to validate
+	//
+	// Returns:
+	// - priority: Priority of the transaction; transactions with a higher priority are included first
+	// - err: Reason for rejecting the transaction
+	CheckTx(ctx context.Context, tx []byte) (priority int64, err error)
+}
+
+// HealthChecker is an optional interface that can be implemented by an Executor to report its health, e.g. the
+// reachability of a remote execution client, in the node status.
+type HealthChecker interface {
+	// Healthy checks whether the executor can execute blocks.
+	// Requirements:
+	// - Must return quickly, without executing anything
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	//
+	// Returns:
+	// - error: Reason the executor is unhealthy, nil if it is healthy
+	Healthy(ctx context.Context) error
+}
+
+// Lane is a partition of the transactions of a block, e.g. the oracle transactions included before the user
+// transactions.
+type Lane struct {
+	// Name identifies the lane
+	Name string
+	// NumTxs is the number of transactions of the block in the lane
+	NumTxs uint64
+}
+
+// TxOrderer is an optional interface that can be implemented by an Executor to reorder the transactions of the
+// blocks and partition them into lanes, e.g. to include oracle transactions first. The lanes of every block are
+// committed to in its header, and full nodes validate the ordering of the blocks they sync by ordering their
+// transactions again.
+type TxOrderer interface {
+	// OrderTxs orders the transactions the sequencer is about to include in a block.
+	// Requirements:
+	// - Must be deterministic: the same transactions at the same height are always ordered the same way
+	// - Must be idempotent: ordering transactions already ordered returns them unchanged, with the same lanes
+	// - Must return the transactions given lane by lane, without adding or dropping any
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	// - blockHeight: Height of the block
+	// - txs: Transactions of the block, in the order of the sequencer
+	//
+	// Returns:
+	// - ordered: Transactions of the block, lane by lane
+	// - lanes: Lanes of the block, in order, whose NumTxs sum to the number of transactions
+	// - err: Any errors during ordering
+	OrderTxs(ctx context.Context, blockHeight uint64, txs [][]byte) (ordered [][]byte, lanes []Lane, err error)
+}
+
+// DABlock is the DA block committed to in the header of a block, the latest DA block observed by the sequencer
+// when creating it.
+type DABlock struct {
+	// Height is the height of the DA block, zero if the sequencer did not observe the DA layer
+	Height uint64
+	// Hash is the hash of the DA block, empty if Height is zero
+	Hash []byte
+}
+
+// DABlockReceiver is an optional interface that can be implemented by an Executor to expose the DA layer to the
+// transactions it executes, e.g. as a source of randomness or to verify bridged messages. The DA block committed
+// to in the header of every block is passed to the executor before the block is executed, on the sequencer and
+// on the full nodes.
+type DABlockReceiver interface {
+	// SetDABlock sets the DA block of the block about to be executed.
+	// Requirements:
+	// - Must make the DA block available to the transactions of the block at blockHeight only
+	// - Must be idempotent: the DA block of a block may be set again when its execution is retried
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	// - blockHeight: Height of the block about to be executed
+	// - daBlock: DA block committed to in the header of the block
+	//
+	// Returns:
+	// - error: Any errors while setting the DA block
+	SetDABlock(ctx context.Context, blockHeight uint64, daBlock DABlock) error
+}
+
+// ErrStreamingNotSupported is returned by StreamingExecutor.BeginBlock if the execution client cannot stream the
+// transactions of a block, e.g. a remote client running an older version, in which case the block is executed
+// with Executor.ExecuteTxs.
+var ErrStreamingNotSupported = errors.New("streaming execution not supported")
+
+// ErrUnavailable is returned by executors that cannot reach their execution client, e.g. a remote client whose
+// connection failed. Calls failing with it may be retried.
+var ErrUnavailable = errors.New("executor unavailable")
+
+// TxResult is the result of executing a transaction.
+type TxResult struct {
+	// Index is the index of the transaction in the block
+	Index int
+	// Code is 0 if the transaction succeeded; failed transactions remain in the block without affecting the state
+	Code uint32
+	// Log describes why the transaction failed, if it did
+	Log string
+	// GasUsed is the gas consumed by the transaction
+	GasUsed uint64
+	// Events are the events emitted by the transaction
+	Events []Event
+}
+
+// StreamingExecutor is an optional interface that can be implemented by an Executor to execute the transactions
+// of a block in chunks rather than with a single ExecuteTxs call, allowing blocks larger than a single request
+// and reporting the result of each transaction.
+type StreamingExecutor interface {
+	// BeginBlock starts the execution of a block.
+	// Requirements:
+	// - Must validate state transition against previous state root
+	// - Must not modify the execution state until BlockExecution.Commit is called
+	// - Must return ErrStreamingNotSupported if the block must be executed with ExecuteTxs instead
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	// - blockHeight: Height of block being created (must be > 0)
+	// - timestamp: Block creation time in UTC
+	// - prevStateRoot: Previous block's state root hash
+	//
+	// Returns:
+	// - execution: Execution of the block, to which the transactions are streamed
+	// - err: Any errors while starting the execution
+	BeginBlock(ctx context.Context, blockHeight uint64, timestamp time.Time, prevStateRoot []byte) (execution BlockExecution, err error)
+}
+
+// BlockExecution is the execution of a block started with StreamingExecutor.BeginBlock. Exactly one of Commit and
+// Abort must be called once all transactions are executed.
+type BlockExecution interface {
+	// ExecuteTxs executes the next transactions of the block.
+	// Requirements:
+	// - Must execute the transactions after those of the previous calls, in order
+	// - Must return one result per transaction, in transaction order
+	// - Must report invalid transactions in their result rather than failing the block
+	// - Must maintain deterministic execution
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	// - txs: Next transactions of the block
+	//
+	// Returns:
+	// - results: Result of each transaction, with Index relative to the whole block
+	// - err: Any errors which prevent executing the block
+	ExecuteTxs(ctx context.Context, txs [][]byte) (results []TxResult, err error)
+
+	// Commit applies the executed transactions to the execution state.
+	// Requirements:
+	// - Must apply the effects of all succeeded transactions atomically
+	// - Must respect context cancellation/timeout
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	//
+	// Returns:
+	// - updatedStateRoot: New state root after executing transactions
+	// - maxBytes: Maximum allowed transaction size (may change with protocol updates)
+	// - err: Any errors while committing
+	Commit(ctx context.Context) (updatedStateRoot []byte, maxBytes uint64, err error)
+
+	// Abort discards the executed transactions, leaving the execution state unchanged.
+	// Requirements:
+	// - Must be safe to call after an ExecuteTxs error
+	//
+	// Parameters:
+	// - ctx: Context for timeout/cancellation control
+	//
+	// Returns:
+	// - error: Any errors while discarding the execution
+	Abort(ctx context.Context) error
+}
@@ -4,9 +4,14 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"time"
 )
 
+// ErrUnavailable is returned by sequencers that cannot reach their sequencing network, e.g. a remote sequencer
+// whose connection failed. Requests failing with it may be retried.
+var ErrUnavailable = errors.New("sequencer unavailable")
+
 // Sequencer is a generic interface for a rollup sequencer
 type Sequencer interface {
 	// SubmitRollupBatchTxs submits a batch of transactions from rollup to sequencer
@@ -18,7 +23,7 @@ type Sequencer interface {
 	// GetNextBatch returns the next batch of transactions from sequencer to rollup
 	// RollupId is the unique identifier for the rollup chain
 	// LastBatchHash is the cryptographic hash of the last batch received by the rollup
-	// MaxBytes is the maximum number of bytes to return in the batch
+	// MaxBytes is the maximum number of bytes to return in the batch, 0 for no limit
 	// returns the next batch of transactions and an error if any from the sequencer
 	GetNextBatch(ctx context.Context, req GetNextBatchRequest) (*GetNextBatchResponse, error)
 
@@ -56,7 +56,10 @@ func NewLocalDA(logger log.Logger, opts ...func(*LocalDA) *LocalDA) *LocalDA {
 	return da
 }
 
-var _ coreda.DA = &LocalDA{}
+var (
+	_ coreda.DA          = &LocalDA{}
+	_ coreda.BlockReader = &LocalDA{}
+)
 
 // MaxBlobSize returns the max blob size in bytes.
 func (d *LocalDA) MaxBlobSize(ctx context.Context) (uint64, error) {
@@ -209,6 +212,39 @@ func (d *LocalDA) Validate(ctx context.Context, ids []coreda.ID, proofs []coreda
 	return results, nil
 }
 
+// LatestBlock returns the latest DA block of LocalDA, the block of the latest submission.
+func (d *LocalDA) LatestBlock(ctx context.Context) (*coreda.BlockRef, error) {
+	d.logger.Debug("LatestBlock called")
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.height == 0 {
+		return &coreda.BlockRef{}, nil
+	}
+	return &coreda.BlockRef{Height: d.height, Hash: d.blockHash(d.height)}, nil
+}
+
+// BlockHash returns the hash of the DA block at the given height.
+func (d *LocalDA) BlockHash(ctx context.Context, height uint64) ([]byte, error) {
+	d.logger.Debug("BlockHash called", "height", height)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if height > d.height {
+		return nil, fmt.Errorf("height %d is in the future: %w", height, coreda.ErrFutureHeight)
+	}
+	return d.blockHash(height), nil
+}
+
+// blockHash derives the hash of the DA block at the given height from its height, time and blob IDs.
+func (d *LocalDA) blockHash(height uint64) []byte {
+	hasher := sha256.New()
+	hasher.Write(d.getID(height))
+	hasher.Write(d.getID(uint64(d.timestamps[height].UnixNano()))) //nolint:gosec // timestamps are after 1970
+	for _, kv := range d.data[height] {
+		hasher.Write(kv.key)
+	}
+	return hasher.Sum(nil)
+}
+
 func (d *LocalDA) nextID() []byte {
 	return d.getID(d.height)
 }
@@ -34,9 +34,20 @@ type API struct {
 		SubmitWithOptions func(context.Context, []da.Blob, float64, []byte, []byte) ([]da.ID, error)     `perm:"write"`
 		GasMultiplier     func(context.Context) (float64, error)                                         `perm:"read"`
 		GasPrice          func(context.Context) (float64, error)                                         `perm:"read"`
+		LatestBlock       func(context.Context) (*da.BlockRef, error)                                    `perm:"read"`
+		BlockHash         func(context.Context, uint64) ([]byte, error)                                  `perm:"read"`
 	}
 }
 
+var _ da.BlockReader = &API{}
+
+// WithNamespace returns a client for the given namespace sharing the connection of this client.
+func (api *API) WithNamespace(namespace []byte) da.DA {
+	client := *api
+	client.Namespace = namespace
+	return &client
+}
+
 // Get returns Blob for each given ID, or an error.
 func (api *API) Get(ctx context.Context, ids []da.ID, _ []byte) ([]da.Blob, error) {
 	api.Logger.Debug("Making RPC call", "method", "Get", "num_ids", len(ids), "namespace", string(api.Namespace))
@@ -194,6 +205,48 @@ func (api *API) GasPrice(ctx context.Context) (float64, error) {
 	return res, err
 }
 
+// LatestBlock returns the latest block of the DA layer. Servers whose DA layer does not report its blocks are
+// reported as having no block yet, so that headers commit to no DA block.
+func (api *API) LatestBlock(ctx context.Context) (*da.BlockRef, error) {
+	api.Logger.Debug("Making RPC call", "method", "LatestBlock")
+	res, err := api.Internal.LatestBlock(ctx)
+	if isMethodNotFound(err) {
+		api.Logger.Debug("DA server does not report DA blocks", "method", "LatestBlock")
+		return &da.BlockRef{}, nil
+	}
+	if err != nil {
+		api.Logger.Error("RPC call failed", "method", "LatestBlock", "error", err)
+		return nil, err
+	}
+	if res == nil {
+		res = &da.BlockRef{}
+	}
+	api.Logger.Debug("RPC call successful", "method", "LatestBlock", "height", res.Height)
+	return res, nil
+}
+
+// BlockHash returns the hash of the DA block at the given height.
+func (api *API) BlockHash(ctx context.Context, height uint64) ([]byte, error) {
+	api.Logger.Debug("Making RPC call", "method", "BlockHash", "height", height)
+	res, err := api.Internal.BlockHash(ctx, height)
+	if err != nil {
+		if errors.Is(err, da.ErrFutureHeight) {
+			api.Logger.Debug("RPC call indicates height from the future", "method", "BlockHash", "height", height)
+			return nil, err
+		}
+		api.Logger.Error("RPC call failed", "method", "BlockHash", "error", err)
+		return nil, err
+	}
+	api.Logger.Debug("RPC call successful", "method", "BlockHash", "height", height)
+	return res, nil
+}
+
+// isMethodNotFound reports whether the error is returned by a server not serving the method.
+func isMethodNotFound(err error) bool {
+	var rpcErr *jsonrpc.JSONRPCError
+	return errors.As(err, &rpcErr) && rpcErr.Code == rpcMethodNotFound
+}
+
 // Client is the jsonrpc client
 type Client struct {
 	DA     API
@@ -1,11 +1,16 @@
 package jsonrpc
 
 import (
+	"errors"
+
 	"github.com/filecoin-project/go-jsonrpc"
 
 	coreda "github.com/rollkit/rollkit/core/da"
 )
 
+// rpcMethodNotFound is the JSON-RPC error code of calls to methods the server does not serve.
+const rpcMethodNotFound = jsonrpc.ErrorCode(-32601)
+
 // getKnownErrorsMapping returns a mapping of known error codes to their corresponding error types.
 func getKnownErrorsMapping() jsonrpc.Errors {
 	errs := jsonrpc.NewErrors()
@@ -14,6 +19,42 @@ func getKnownErrorsMapping() jsonrpc.Errors {
 	errs.Register(jsonrpc.ErrorCode(coreda.StatusContextDeadline), &coreda.ErrTxTimedOut)
 	errs.Register(jsonrpc.ErrorCode(coreda.StatusAlreadyInMempool), &coreda.ErrTxAlreadyInMempool)
 	errs.Register(jsonrpc.ErrorCode(coreda.StatusIncorrectAccountSequence), &coreda.ErrTxIncorrectAccountSequence)
+	errs.Register(jsonrpc.ErrorCode(coreda.StatusUnderpriced), &coreda.ErrTxUnderpriced)
 	errs.Register(jsonrpc.ErrorCode(coreda.StatusContextDeadline), &coreda.ErrContextDeadline)
+	errs.Register(jsonrpc.ErrorCode(coreda.StatusFutureHeight), new(*futureHeightError))
 	return errs
 }
+
+// futureHeightError is the error of calls for DA heights from the future. It is sent with the StatusFutureHeight
+// code, so that clients match it with coreda.ErrFutureHeight.
+type futureHeightError struct {
+	msg string
+}
+
+// newRPCError returns the error sent over the RPC for err, keeping the errors of heights from the future
+// matchable by clients.
+func newRPCError(err error) error {
+	if errors.Is(err, coreda.ErrFutureHeight) {
+		return &futureHeightError{msg: err.Error()}
+	}
+	return err
+}
+
+func (e *futureHeightError) Error() string {
+	return e.msg
+}
+
+func (e *futureHeightError) Is(target error) bool {
+	return target == coreda.ErrFutureHeight
+}
+
+// ToJSONRPCError implements jsonrpc.RPCErrorCodec.
+func (e *futureHeightError) ToJSONRPCError() (jsonrpc.JSONRPCError, error) {
+	return jsonrpc.JSONRPCError{Code: jsonrpc.ErrorCode(coreda.StatusFutureHeight), Message: e.msg}, nil
+}
+
+// FromJSONRPCError implements jsonrpc.RPCErrorCodec.
+func (e *futureHeightError) FromJSONRPCError(err jsonrpc.JSONRPCError) error {
+	e.msg = err.Message
+	return nil
+}
@@ -53,6 +53,61 @@ func TestProxy(t *testing.T) {
 	RunDATestSuite(t, &client.DA)
 }
 
+// TestProxyBlockReader tests reading DA blocks through the JSONRPC service.
+func TestProxyBlockReader(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger(t)
+	serve := func(port string, d coreda.DA) *proxy.Client {
+		server := proxy.NewServer(logger, ServerHost, port, d)
+		require.NoError(t, server.Start(ctx))
+		t.Cleanup(func() { require.NoError(t, server.Stop(ctx)) })
+		client, err := proxy.NewClient(ctx, logger, "http://"+ServerHost+":"+port, "", "74657374")
+		require.NoError(t, err)
+		t.Cleanup(client.Close)
+		return client
+	}
+
+	dummy := coreda.NewDummyDA(100_000, 0, 0)
+	client := serve("3451", dummy)
+	for range 2 {
+		_, err := dummy.Submit(ctx, []coreda.Blob{[]byte("blob")}, 0, nil)
+		require.NoError(t, err)
+	}
+	latest, err := client.DA.LatestBlock(ctx)
+	require.NoError(t, err)
+	expected, err := dummy.LatestBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, expected, latest)
+	hash, err := client.DA.BlockHash(ctx, latest.Height)
+	require.NoError(t, err)
+	assert.Equal(t, latest.Hash, hash)
+	_, err = client.DA.BlockHash(ctx, latest.Height+1)
+	assert.ErrorIs(t, err, coreda.ErrFutureHeight)
+
+	// servers whose DA layer does not report DA blocks report no DA block
+	client = serve("3452", struct{ coreda.DA }{dummy})
+	latest, err = client.DA.LatestBlock(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, latest.Height)
+	_, err = client.DA.BlockHash(ctx, 1)
+	assert.Error(t, err)
+
+	// wrapped errors of heights from the future are matched by clients
+	client = serve("3453", futureDA{dummy})
+	_, err = client.DA.GetIDs(ctx, 10, nil)
+	assert.ErrorIs(t, err, coreda.ErrFutureHeight)
+	assert.ErrorContains(t, err, "height 10 is in the future")
+}
+
+// futureDA is a DA layer reporting all heights as from the future.
+type futureDA struct {
+	coreda.DA
+}
+
+func (futureDA) GetIDs(ctx context.Context, height uint64, namespace []byte) (*coreda.GetIDsResult, error) {
+	return nil, fmt.Errorf("height %d is in the future: %w", height, coreda.ErrFutureHeight)
+}
+
 // RunDATestSuite runs all tests against given DA
 func RunDATestSuite(t *testing.T, d coreda.DA) {
 	t.Run("Basic DA test", func(t *testing.T) {
@@ -328,3 +383,25 @@ func TestSubmitWithOptions(t *testing.T) {
 		mockAPI.AssertExpectations(t)
 	})
 }
+
+// TestWithNamespace tests that a client created with WithNamespace uses its own namespace
+func TestWithNamespace(t *testing.T) {
+	ctx := context.Background()
+	forcedNamespace := []byte("forced")
+
+	mockAPI := mocks.NewDA(t)
+	client := &proxy.Client{}
+	client.DA.Internal.GetIDs = mockAPI.GetIDs
+	client.DA.Namespace = []byte("rollup")
+	client.DA.Logger = log.NewTestLogger(t)
+
+	ids := []coreda.ID{[]byte("id1")}
+	mockAPI.On("GetIDs", ctx, uint64(1), forcedNamespace).Return(&coreda.GetIDsResult{IDs: ids}, nil).Once()
+
+	forced := client.DA.WithNamespace(forcedNamespace)
+	res, err := forced.GetIDs(ctx, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, ids, res.IDs)
+	assert.Equal(t, []byte("rollup"), client.DA.Namespace)
+	mockAPI.AssertExpectations(t)
+}
@@ -29,7 +29,8 @@ func (s *Server) RegisterService(namespace string, service interface{}, out inte
 	s.rpc.Register(namespace, service)
 }
 
-// NewServer accepts the host address port and the DA implementation to serve as a jsonrpc service
+// NewServer accepts the host address port and the DA implementation to serve as a jsonrpc service.
+// The optional da.BlockReader methods are served if the DA implementation implements them.
 func NewServer(logger log.Logger, address, port string, DA da.DA) *Server {
 	rpc := jsonrpc.NewServer(jsonrpc.WithServerErrors(getKnownErrorsMapping()))
 	srv := &Server{
@@ -42,11 +43,42 @@ func NewServer(logger log.Logger, address, port string, DA da.DA) *Server {
 		logger: logger,
 	}
 	srv.srv.Handler = http.HandlerFunc(rpc.ServeHTTP)
-	// Wrap the provided DA implementation with the logging decorator
-	srv.RegisterService("da", DA, &API{Logger: logger}) // Register the wrapper
+	var service any = serverDA{DA}
+	if reader, ok := da.Implements[da.BlockReader](DA); ok {
+		service = serverBlockReader{serverDA{DA}, reader}
+	}
+	srv.RegisterService("da", service, &API{Logger: logger}) // Register the wrapper
 	return srv
 }
 
+// serverDA serves a DA implementation, sending the errors of DA heights from the future with their status code.
+type serverDA struct {
+	da.DA
+}
+
+// GetIDs implements da.DA.
+func (s serverDA) GetIDs(ctx context.Context, height uint64, namespace []byte) (*da.GetIDsResult, error) {
+	res, err := s.DA.GetIDs(ctx, height, namespace)
+	return res, newRPCError(err)
+}
+
+// serverBlockReader serves a DA implementation implementing da.BlockReader.
+type serverBlockReader struct {
+	serverDA
+	reader da.BlockReader
+}
+
+// LatestBlock implements da.BlockReader.
+func (s serverBlockReader) LatestBlock(ctx context.Context) (*da.BlockRef, error) {
+	return s.reader.LatestBlock(ctx)
+}
+
+// BlockHash implements da.BlockReader.
+func (s serverBlockReader) BlockHash(ctx context.Context, height uint64) ([]byte, error) {
+	hash, err := s.reader.BlockHash(ctx, height)
+	return hash, newRPCError(err)
+}
+
 // Start starts the RPC Server.
 // This function can be called multiple times concurrently
 // Once started, subsequent calls are a no-op
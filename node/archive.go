@@ -0,0 +1,37 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/pkg/store"
+)
+
+// ExportBlocks writes the blocks persisted in the database of a stopped node from height from to height to, or to
+// the last block if to is 0, to w as a chain archive. See store.ExportBlocks.
+func ExportBlocks(ctx context.Context, database ds.Batching, from, to uint64, w io.Writer) (uint64, error) {
+	s := store.New(newPrefixKV(database, RollkitPrefix))
+	if to == 0 {
+		height, err := s.Height(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get store height: %w", err)
+		}
+		to = height
+	}
+	return to, store.ExportBlocks(ctx, s, from, to, w)
+}
+
+// ImportBlocks saves the blocks of a chain archive to the database of a stopped node. The node executes the
+// imported blocks when it starts, before syncing the following blocks. See store.ImportBlocks.
+func ImportBlocks(ctx context.Context, database ds.Batching, r io.Reader) (store.ImportResult, error) {
+	mainKV := newPrefixKV(database, RollkitPrefix)
+	// the schema version of a new store is saved before importing, so that the imported blocks are not migrated
+	if err := store.Migrate(ctx, mainKV, log.NewNopLogger()); err != nil {
+		return store.ImportResult{}, err
+	}
+	return store.ImportBlocks(ctx, store.New(mainKV), r)
+}
@@ -0,0 +1,350 @@
+// Package benchmark measures the throughput of a rollup node. It runs an aggregator in-process with an in-memory
+// sequencer, a dummy DA layer and a dummy executor, submits transactions at a configured rate through the RPC
+// transaction path, and reports the blocks, transactions and DA bytes per second, and the latencies of
+// transaction and DA inclusion, so that performance regressions can be tracked across versions.
+package benchmark
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+
+	"github.com/rollkit/rollkit/block"
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/node"
+	rollkitconfig "github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/p2p"
+	"github.com/rollkit/rollkit/pkg/p2p/key"
+	grpcsequencer "github.com/rollkit/rollkit/pkg/sequencer/grpc"
+	noopsigner "github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/types"
+)
+
+const (
+	// chainID is the chain ID of the benchmarked rollup
+	chainID = "benchmark"
+	// loadInterval is the interval between two rounds of transaction submissions of the load generator
+	loadInterval = 10 * time.Millisecond
+	// eventBuffer is the number of node events buffered for the benchmark
+	eventBuffer = 10_000
+	// txSeqSize is the size of the sequence number making every transaction unique
+	txSeqSize = 8
+)
+
+// Config configures a benchmark run.
+type Config struct {
+	// Duration is the duration of the load, over which the throughput is measured.
+	Duration time.Duration
+	// DrainTimeout bounds the time waited after the load for the submitted transactions and produced blocks
+	// to be included, so that their latencies are measured.
+	DrainTimeout time.Duration
+	// TxsPerSecond is the rate of transaction submissions.
+	TxsPerSecond int
+	// TxSize is the size in bytes of the submitted transactions, at least 8.
+	TxSize int
+	// BlockTime is the block time of the aggregator.
+	BlockTime time.Duration
+	// DABlockTime is the block time of the DA layer.
+	DABlockTime time.Duration
+	// MaxBlobSize is the maximum blob size of the dummy DA layer.
+	MaxBlobSize uint64
+}
+
+// DefaultConfig returns the default benchmark configuration.
+func DefaultConfig() Config {
+	return Config{
+		Duration:     10 * time.Second,
+		DrainTimeout: 10 * time.Second,
+		TxsPerSecond: 1000,
+		TxSize:       256,
+		BlockTime:    100 * time.Millisecond,
+		DABlockTime:  200 * time.Millisecond,
+		MaxBlobSize:  2 << 20,
+	}
+}
+
+// Validate checks the benchmark configuration.
+func (c Config) Validate() error {
+	if c.Duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+	if c.DrainTimeout < 0 {
+		return errors.New("drain timeout must not be negative")
+	}
+	if c.TxsPerSecond <= 0 {
+		return errors.New("transaction rate must be positive")
+	}
+	if c.TxSize < txSeqSize {
+		return fmt.Errorf("transaction size must be at least %d bytes", txSeqSize)
+	}
+	if c.BlockTime <= 0 || c.DABlockTime <= 0 {
+		return errors.New("block times must be positive")
+	}
+	if c.MaxBlobSize == 0 {
+		return errors.New("maximum blob size must be positive")
+	}
+	return nil
+}
+
+// Latencies summarizes the distribution of measured latencies.
+type Latencies struct {
+	Count int
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// newLatencies returns the distribution of the latencies.
+func newLatencies(latencies []time.Duration) Latencies {
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+	return Latencies{
+		Count: len(sorted),
+		P50:   percentile(sorted, 50),
+		P90:   percentile(sorted, 90),
+		P99:   percentile(sorted, 99),
+		Max:   percentile(sorted, 100),
+	}
+}
+
+// percentile returns the p-th percentile of the sorted latencies, using the nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// String implements fmt.Stringer.
+func (l Latencies) String() string {
+	return fmt.Sprintf("p50=%s p90=%s p99=%s max=%s (n=%d)", l.P50, l.P90, l.P99, l.Max, l.Count)
+}
+
+// Result is the outcome of a benchmark run. Counts and rates are measured over the load duration; latencies also
+// cover the transactions and blocks included while draining.
+type Result struct {
+	Duration time.Duration
+	// SubmittedTxs is the number of transactions admitted by the node, RejectedTxs the number of transactions it
+	// rejected, e.g. because its mempool was full.
+	SubmittedTxs uint64
+	RejectedTxs  uint64
+	// Blocks is the number of produced blocks, Txs the number of included transactions and DABytes the size of
+	// the blobs submitted to the DA layer.
+	Blocks  uint64
+	Txs     uint64
+	DABytes uint64
+
+	BlocksPerSecond  float64
+	TxsPerSecond     float64
+	DABytesPerSecond float64
+
+	// TxLatency is the time from the submission of a transaction to its execution in a block.
+	TxLatency Latencies
+	// DALatency is the time from the production of a block to its inclusion in the DA layer.
+	DALatency Latencies
+	// PendingTxs and PendingBlocks are the transactions not included and the blocks not DA included when the
+	// drain timeout expired.
+	PendingTxs    int
+	PendingBlocks int
+}
+
+// String implements fmt.Stringer.
+func (r Result) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "duration:       %s\n", r.Duration)
+	fmt.Fprintf(&b, "transactions:   %d submitted, %d rejected, %d included\n", r.SubmittedTxs, r.RejectedTxs, r.Txs)
+	fmt.Fprintf(&b, "blocks/sec:     %.2f (%d blocks)\n", r.BlocksPerSecond, r.Blocks)
+	fmt.Fprintf(&b, "txs/sec:        %.2f\n", r.TxsPerSecond)
+	fmt.Fprintf(&b, "DA bytes/sec:   %.0f (%d bytes)\n", r.DABytesPerSecond, r.DABytes)
+	fmt.Fprintf(&b, "tx latency:     %s\n", r.TxLatency)
+	fmt.Fprintf(&b, "DA latency:     %s\n", r.DALatency)
+	if r.PendingTxs > 0 || r.PendingBlocks > 0 {
+		fmt.Fprintf(&b, "pending:        %d txs not included, %d blocks not DA included\n", r.PendingTxs, r.PendingBlocks)
+	}
+	return b.String()
+}
+
+// txSubmitter is implemented by full nodes accepting transactions over RPC.
+type txSubmitter interface {
+	SubmitTx(ctx context.Context, tx []byte) ([]byte, error)
+}
+
+// Run runs a benchmark: it starts an aggregator, waits for its first block, submits transactions for the
+// configured duration and waits for their inclusion before stopping the aggregator.
+func Run(ctx context.Context, conf Config, logger log.Logger) (*Result, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid benchmark configuration: %w", err)
+	}
+
+	rootDir, err := os.MkdirTemp("", "rollkit-benchmark")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create root directory: %w", err)
+	}
+	defer os.RemoveAll(rootDir) //nolint:errcheck // best effort cleanup of a temporary directory
+
+	rec := newRecorder()
+	n, err := newNode(ctx, conf, rootDir, rec, logger)
+	if err != nil {
+		return nil, err
+	}
+	submitter, ok := n.(txSubmitter)
+	if !ok {
+		return nil, errors.New("node does not accept transactions")
+	}
+
+	events, unsubscribe := n.Subscribe(eventBuffer)
+	defer unsubscribe()
+
+	nodeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- n.Run(nodeCtx)
+	}()
+
+	res, err := measure(nodeCtx, conf, submitter, events, rec, runErr)
+	cancel()
+	if stopErr := <-runErr; stopErr != nil && !errors.Is(stopErr, context.Canceled) && err == nil {
+		err = fmt.Errorf("node failed: %w", stopErr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// newNode creates the benchmarked aggregator.
+func newNode(ctx context.Context, conf Config, rootDir string, rec *recorder, logger log.Logger) (node.Node, error) {
+	nodeConfig := rollkitconfig.DefaultConfig
+	nodeConfig.RootDir = rootDir
+	nodeConfig.ChainID = chainID
+	nodeConfig.Node.Aggregator = true
+	nodeConfig.Node.BlockTime = rollkitconfig.DurationWrapper{Duration: conf.BlockTime}
+	nodeConfig.DA.BlockTime = rollkitconfig.DurationWrapper{Duration: conf.DABlockTime}
+	nodeConfig.P2P.ListenAddress = "/ip4/127.0.0.1/tcp/0"
+	nodeConfig.RPC.Address = "127.0.0.1:0"
+	nodeConfig.Mempool.Size = max(nodeConfig.Mempool.Size, 10*uint64(conf.TxsPerSecond)) //nolint:gosec // positive
+
+	gen, genesisKey, _ := types.GetGenesisWithPrivkey(chainID)
+	signer, err := noopsigner.NewNoopSigner(genesisKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer: %w", err)
+	}
+	nodeKey, err := node.InitFiles(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	p2pClient, err := p2p.NewClient(nodeConfig, &key.NodeKey{PrivKey: genesisKey, PubKey: genesisKey.GetPublic()},
+		dssync.MutexWrap(datastore.NewMapDatastore()), logger, p2p.NopMetrics())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create p2p client: %w", err)
+	}
+
+	return node.NewNode(
+		ctx,
+		nodeConfig,
+		&executor{DummyExecutor: coreexecutor.NewDummyExecutor(), recorder: rec},
+		&sequencer{LocalSequencer: grpcsequencer.NewLocalSequencer()},
+		&dataAvailability{DummyDA: coreda.NewDummyDA(conf.MaxBlobSize, 0, 0), recorder: rec},
+		signer,
+		*nodeKey,
+		p2pClient,
+		gen,
+		dssync.MutexWrap(datastore.NewMapDatastore()),
+		node.DefaultMetricsProvider(rollkitconfig.DefaultInstrumentationConfig()),
+		logger,
+	)
+}
+
+// measure waits for the first block of the node, submits transactions for the configured duration, and waits
+// for the submitted transactions and produced blocks to be included.
+func measure(ctx context.Context, conf Config, submitter txSubmitter, events <-chan block.Event, rec *recorder, runErr <-chan error) (*Result, error) {
+	handle := func(e block.Event) {
+		switch e.Type {
+		case block.EventBlockProduced:
+			rec.blockProduced(e.Height, time.Now())
+		case block.EventDAIncluded:
+			rec.daIncluded(e.Height, time.Now())
+		}
+	}
+	// nextEvent handles the next node event, or returns false on timeout
+	nextEvent := func(timeout <-chan time.Time) (bool, error) {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return false, errors.New("benchmark fell behind the node events")
+			}
+			handle(e)
+			return true, nil
+		case err := <-runErr:
+			return false, fmt.Errorf("node stopped: %w", err)
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-timeout:
+			return false, nil
+		}
+	}
+
+	// warm up until the first block, so that the node startup is not measured
+	for rec.firstBlock().IsZero() {
+		if _, err := nextEvent(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	res := &Result{}
+	start := time.Now()
+	end := start.Add(conf.Duration)
+	ticker := time.NewTicker(loadInterval)
+	defer ticker.Stop()
+	var seq uint64
+	for now := start; now.Before(end); now = time.Now() {
+		// submit the transactions due since the start, catching up after slow rounds
+		due := uint64(now.Sub(start).Seconds() * float64(conf.TxsPerSecond))
+		for ; seq < due; seq++ {
+			tx := make([]byte, conf.TxSize)
+			binary.BigEndian.PutUint64(tx, seq)
+			_, _ = rand.Read(tx[txSeqSize:])
+			rec.txSubmitted(tx, time.Now())
+			if _, err := submitter.SubmitTx(ctx, tx); err != nil {
+				rec.txRejected(tx)
+				res.RejectedTxs++
+				continue
+			}
+			res.SubmittedTxs++
+		}
+		if _, err := nextEvent(ticker.C); err != nil {
+			return nil, err
+		}
+	}
+
+	drain := time.After(conf.DrainTimeout)
+	for {
+		if txs, blocks := rec.pending(end); txs == 0 && blocks == 0 {
+			break
+		}
+		handled, err := nextEvent(drain)
+		if err != nil {
+			return nil, err
+		}
+		if !handled {
+			break
+		}
+	}
+
+	rec.summarize(res, start, end)
+	return res, nil
+}
@@ -0,0 +1,66 @@
+package benchmark
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	conf := DefaultConfig()
+	conf.Duration = 2 * time.Second
+	conf.TxsPerSecond = 200
+
+	res, err := Run(context.Background(), conf, log.NewNopLogger())
+	require.NoError(t, err)
+	t.Log("\n" + res.String())
+
+	assert.Equal(t, conf.Duration, res.Duration)
+	assert.Greater(t, res.SubmittedTxs, uint64(0))
+	assert.Zero(t, res.RejectedTxs)
+	assert.Greater(t, res.Blocks, uint64(0))
+	assert.Greater(t, res.Txs, uint64(0))
+	assert.Greater(t, res.DABytes, uint64(0))
+	assert.Greater(t, res.TxsPerSecond, 0.0)
+	assert.Greater(t, res.DABytesPerSecond, 0.0)
+
+	// every submitted transaction is included once, and its latency measured
+	assert.Zero(t, res.PendingTxs)
+	assert.Equal(t, int(res.SubmittedTxs), res.TxLatency.Count)
+	assert.Positive(t, res.TxLatency.P99)
+	assert.LessOrEqual(t, res.TxLatency.P50, res.TxLatency.P99)
+	assert.LessOrEqual(t, res.TxLatency.P99, res.TxLatency.Max)
+	assert.Positive(t, res.DALatency.Count)
+	assert.Positive(t, res.DALatency.P99)
+}
+
+func TestConfigValidate(t *testing.T) {
+	require.NoError(t, DefaultConfig().Validate())
+
+	for name, update := range map[string]func(*Config){
+		"no duration":        func(c *Config) { c.Duration = 0 },
+		"no load":            func(c *Config) { c.TxsPerSecond = 0 },
+		"small transactions": func(c *Config) { c.TxSize = 4 },
+		"no block time":      func(c *Config) { c.BlockTime = 0 },
+		"no blob size":       func(c *Config) { c.MaxBlobSize = 0 },
+	} {
+		conf := DefaultConfig()
+		update(&conf)
+		assert.Error(t, conf.Validate(), name)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[99-i] = time.Duration(i+1) * time.Millisecond
+	}
+	l := newLatencies(latencies)
+	assert.Equal(t, Latencies{Count: 100, P50: 50 * time.Millisecond, P90: 90 * time.Millisecond, P99: 99 * time.Millisecond, Max: 100 * time.Millisecond}, l)
+	assert.Equal(t, Latencies{}, newLatencies(nil))
+	assert.Equal(t, time.Second, percentile([]time.Duration{time.Second}, 99))
+}
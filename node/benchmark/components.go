@@ -0,0 +1,226 @@
+package benchmark
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	grpcsequencer "github.com/rollkit/rollkit/pkg/sequencer/grpc"
+)
+
+// recorder collects the timings of a benchmark run.
+type recorder struct {
+	mu sync.Mutex
+	// submitted holds the submission time of the transactions not included yet
+	submitted map[string]time.Time
+	// included holds the inclusion time of every included transaction
+	included []time.Time
+	// txLatencies holds the time from submission to inclusion of every included transaction
+	txLatencies []time.Duration
+	// produced holds the production time of the blocks not DA included yet, by height
+	produced map[uint64]time.Time
+	// blocks holds the production time of every block
+	blocks []time.Time
+	// daLatencies holds the time from production to DA inclusion of every DA included block
+	daLatencies []time.Duration
+	// daSubmissions holds the time and size of every successful DA submission
+	daSubmissions []daSubmission
+}
+
+type daSubmission struct {
+	time  time.Time
+	bytes uint64
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		submitted: make(map[string]time.Time),
+		produced:  make(map[uint64]time.Time),
+	}
+}
+
+// txSubmitted records the submission of a transaction.
+func (r *recorder) txSubmitted(tx []byte, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.submitted[string(tx)] = at
+}
+
+// txsIncluded records the inclusion of transactions in a block. Transactions not submitted by the load
+// generator are ignored.
+func (r *recorder) txsIncluded(txs [][]byte, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, tx := range txs {
+		submittedAt, ok := r.submitted[string(tx)]
+		if !ok {
+			continue
+		}
+		delete(r.submitted, string(tx))
+		r.included = append(r.included, at)
+		r.txLatencies = append(r.txLatencies, at.Sub(submittedAt))
+	}
+}
+
+// blockProduced records the production of the block at the given height.
+func (r *recorder) blockProduced(height uint64, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.produced[height] = at
+	r.blocks = append(r.blocks, at)
+}
+
+// daIncluded records the DA inclusion of the blocks up to the given height.
+func (r *recorder) daIncluded(height uint64, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for h, producedAt := range r.produced {
+		if h <= height {
+			delete(r.produced, h)
+			r.daLatencies = append(r.daLatencies, at.Sub(producedAt))
+		}
+	}
+}
+
+// blobsSubmitted records the successful submission of blobs to the DA layer.
+func (r *recorder) blobsSubmitted(blobs []coreda.Blob, at time.Time) {
+	var size uint64
+	for _, blob := range blobs {
+		size += uint64(len(blob))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.daSubmissions = append(r.daSubmissions, daSubmission{time: at, bytes: size})
+}
+
+// txRejected records that a submitted transaction was rejected by the node.
+func (r *recorder) txRejected(tx []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.submitted, string(tx))
+}
+
+// firstBlock returns the production time of the first block, or the zero time if no block was produced yet.
+func (r *recorder) firstBlock() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.blocks) == 0 {
+		return time.Time{}
+	}
+	return r.blocks[0]
+}
+
+// pending returns the number of submitted transactions not included yet, and of the blocks produced until the
+// given time not DA included yet.
+func (r *recorder) pending(until time.Time) (txs, blocks int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, producedAt := range r.produced {
+		if !producedAt.After(until) {
+			blocks++
+		}
+	}
+	return len(r.submitted), blocks
+}
+
+// summarize sets the counts and rates measured between start and end, and the latencies, of the result.
+func (r *recorder) summarize(res *Result, start, end time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	inWindow := func(t time.Time) bool {
+		return !t.Before(start) && !t.After(end)
+	}
+	for _, t := range r.blocks {
+		if inWindow(t) {
+			res.Blocks++
+		}
+	}
+	for _, t := range r.included {
+		if inWindow(t) {
+			res.Txs++
+		}
+	}
+	for _, s := range r.daSubmissions {
+		if inWindow(s.time) {
+			res.DABytes += s.bytes
+		}
+	}
+
+	res.Duration = end.Sub(start)
+	seconds := res.Duration.Seconds()
+	res.BlocksPerSecond = float64(res.Blocks) / seconds
+	res.TxsPerSecond = float64(res.Txs) / seconds
+	res.DABytesPerSecond = float64(res.DABytes) / seconds
+	res.TxLatency = newLatencies(r.txLatencies)
+	res.DALatency = newLatencies(r.daLatencies)
+	res.PendingTxs = len(r.submitted)
+	for _, producedAt := range r.produced {
+		if !producedAt.After(end) {
+			res.PendingBlocks++
+		}
+	}
+}
+
+// executor is a dummy executor recording the inclusion of transactions when they are executed.
+type executor struct {
+	*coreexecutor.DummyExecutor
+	recorder *recorder
+}
+
+// ExecuteTxs implements the Executor interface.
+func (e *executor) ExecuteTxs(ctx context.Context, txs [][]byte, blockHeight uint64, timestamp time.Time, prevStateRoot []byte) ([]byte, uint64, error) {
+	stateRoot, maxBytes, err := e.DummyExecutor.ExecuteTxs(ctx, txs, blockHeight, timestamp, prevStateRoot)
+	if err == nil {
+		e.recorder.txsIncluded(txs, time.Now())
+	}
+	return stateRoot, maxBytes, err
+}
+
+// dataAvailability is a dummy DA layer recording the size of the submitted blobs.
+type dataAvailability struct {
+	*coreda.DummyDA
+	recorder *recorder
+}
+
+// Submit implements the DA interface.
+func (d *dataAvailability) Submit(ctx context.Context, blobs []coreda.Blob, gasPrice float64, namespace []byte) ([]coreda.ID, error) {
+	return d.SubmitWithOptions(ctx, blobs, gasPrice, namespace, nil)
+}
+
+// SubmitWithOptions implements the DA interface.
+func (d *dataAvailability) SubmitWithOptions(ctx context.Context, blobs []coreda.Blob, gasPrice float64, namespace []byte, options []byte) ([]coreda.ID, error) {
+	ids, err := d.DummyDA.SubmitWithOptions(ctx, blobs, gasPrice, namespace, options)
+	if err == nil {
+		d.recorder.blobsSubmitted(blobs, time.Now())
+	}
+	return ids, err
+}
+
+// sequencer is an in-memory sequencer batching all the transactions queued since the previous block. Like the
+// single sequencer, it hands the batches to the block manager for submission to the DA layer.
+type sequencer struct {
+	*grpcsequencer.LocalSequencer
+	batchSubmissionChan chan coresequencer.Batch
+}
+
+// GetNextBatch implements the Sequencer interface.
+func (s *sequencer) GetNextBatch(ctx context.Context, req coresequencer.GetNextBatchRequest) (*coresequencer.GetNextBatchResponse, error) {
+	resp, err := s.LocalSequencer.GetNextBatch(ctx, req)
+	if err != nil || len(resp.Batch.Transactions) == 0 || s.batchSubmissionChan == nil {
+		return resp, err
+	}
+	select {
+	case s.batchSubmissionChan <- *resp.Batch:
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// SetBatchSubmissionChan sets the channel receiving the batches to submit to the DA layer.
+func (s *sequencer) SetBatchSubmissionChan(batchSubmissionChan chan coresequencer.Batch) {
+	s.batchSubmissionChan = batchSubmissionChan
+}
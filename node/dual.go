@@ -0,0 +1,219 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	gosync "sync"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/block"
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/p2p"
+	"github.com/rollkit/rollkit/pkg/p2p/key"
+	"github.com/rollkit/rollkit/pkg/service"
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/types"
+)
+
+var _ Node = &DualModeNode{}
+
+// DualModeNode runs a rollup node in light or full mode, and switches between both modes without restart.
+// Light and full nodes share the store layout, so the directories of the node are not re-initialized: upgrading
+// to full mode fetches and executes the blocks from the last executed block, and downgrading to light mode stops
+// syncing blocks to save resources, keeping the blocks synced so far. The headers received over p2p are kept in
+// both modes.
+type DualModeNode struct {
+	service.BaseService
+
+	// newNode creates the node running in the mode of the configuration
+	newNode  func(ctx context.Context, conf config.Config) (Node, error)
+	database ds.Batching
+
+	mu   gosync.RWMutex
+	node Node
+	// switchTo is the mode requested by SwitchMode, empty if no switch is in progress
+	switchTo string
+	switchCh chan struct{}
+}
+
+// newDualModeNode creates a DualModeNode starting in light mode if conf.Node.Light is set, and in full mode
+// otherwise.
+func newDualModeNode(
+	ctx context.Context,
+	conf config.Config,
+	exec coreexecutor.Executor,
+	sequencer coresequencer.Sequencer,
+	da coreda.DA,
+	signer signer.Signer,
+	nodeKey key.NodeKey,
+	p2pClient *p2p.Client,
+	genesis genesis.Genesis,
+	database ds.Batching,
+	metricsProvider MetricsProvider,
+	logger log.Logger,
+	opts options,
+) (*DualModeNode, error) {
+	if conf.Node.Aggregator {
+		return nil, errors.New("dual mode cannot be enabled in aggregator mode, light nodes do not produce blocks")
+	}
+	n := &DualModeNode{
+		database: database,
+		switchCh: make(chan struct{}, 1),
+	}
+	n.newNode = func(ctx context.Context, conf config.Config) (Node, error) {
+		if conf.Node.Light {
+			ln, err := newLightNode(ctx, conf, genesis, p2pClient, nodeKey, database, da, logger, opts)
+			if err != nil {
+				return nil, err
+			}
+			ln.modes, ln.sharedStore = n, true
+			return ln, nil
+		}
+		fn, err := newFullNode(ctx, conf, p2pClient, signer, nodeKey, genesis, database, exec, sequencer, da, metricsProvider, logger, opts)
+		if err != nil {
+			return nil, err
+		}
+		fn.modes, fn.sharedStore = n, true
+		return fn, nil
+	}
+
+	node, err := n.newNode(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+	n.node = node
+	n.BaseService = *service.NewBaseService(logger, "DualModeNode", n)
+	return n, nil
+}
+
+// Run runs the node in its current mode, and in the mode requested by SwitchMode once the node running in the
+// previous mode stopped, until the context is canceled. The store is closed when Run returns.
+func (n *DualModeNode) Run(ctx context.Context) error {
+	defer func() {
+		if err := n.database.Close(); err != nil {
+			n.Logger.Error("error closing store", "error", err)
+		}
+	}()
+	for {
+		node := n.current()
+		nodeCtx, cancel := context.WithCancel(ctx)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- node.Run(nodeCtx)
+		}()
+
+		select {
+		case err := <-errCh:
+			cancel()
+			return err
+		case <-ctx.Done():
+			cancel()
+			return <-errCh
+		case <-n.switchCh:
+		}
+		cancel()
+		if err := <-errCh; err != nil && !errors.Is(err, context.Canceled) {
+			n.Logger.Error("error stopping node before switching mode", "error", err)
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := n.switchMode(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// switchMode replaces the stopped node by a node running in the requested mode, with the runtime parameters of
+// the stopped node.
+func (n *DualModeNode) switchMode(ctx context.Context) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	mode := n.switchTo
+	n.switchTo = ""
+	conf := nodeConfig(n.node)
+	conf.Node.Light = mode == types.NodeModeLight
+	node, err := n.newNode(ctx, conf)
+	if err != nil {
+		return fmt.Errorf("failed to switch to %s mode: %w", mode, err)
+	}
+	n.node = node
+	n.Logger.Info("switched node mode", "mode", mode)
+	return nil
+}
+
+// SwitchMode requests the node to switch to the given mode, light or full. The node running in the current mode
+// is stopped and the node running in the requested mode is started asynchronously.
+func (n *DualModeNode) SwitchMode(mode string) error {
+	if mode != types.NodeModeLight && mode != types.NodeModeFull {
+		return fmt.Errorf("unknown mode %q, expected %s or %s", mode, types.NodeModeLight, types.NodeModeFull)
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.switchTo != "" {
+		return fmt.Errorf("switch to %s mode in progress", n.switchTo)
+	}
+	if n.mode() == mode {
+		return fmt.Errorf("node already runs in %s mode", mode)
+	}
+	n.switchTo = mode
+	n.switchCh <- struct{}{}
+	return nil
+}
+
+// Mode returns the mode the node currently runs in, light or full.
+func (n *DualModeNode) Mode() string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.mode()
+}
+
+func (n *DualModeNode) mode() string {
+	if nodeConfig(n.node).Node.Light {
+		return types.NodeModeLight
+	}
+	return types.NodeModeFull
+}
+
+// current returns the node running in the current mode.
+func (n *DualModeNode) current() Node {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.node
+}
+
+// IsRunning returns true if the node running in the current mode is running.
+func (n *DualModeNode) IsRunning() bool {
+	return n.current().IsRunning()
+}
+
+// Status returns the sync progress of the node running in the current mode.
+func (n *DualModeNode) Status(ctx context.Context) (types.NodeStatus, error) {
+	return n.current().Status(ctx)
+}
+
+// Subscribe implements Node. Subscriptions receive the events of the node running in the mode at the time of
+// the subscription, so no events are received after switching mode.
+func (n *DualModeNode) Subscribe(buffer int) (<-chan block.Event, func()) {
+	return n.current().Subscribe(buffer)
+}
+
+// nodeConfig returns the configuration of a light or full node, with the runtime parameters changed while the
+// node was running.
+func nodeConfig(node Node) config.Config {
+	switch node := node.(type) {
+	case *LightNode:
+		return node.runtimeConf.Config()
+	case *FullNode:
+		return node.runtimeConf.Config()
+	default:
+		panic(fmt.Sprintf("unexpected node type %T", node))
+	}
+}
@@ -0,0 +1,130 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rollkitconfig "github.com/rollkit/rollkit/pkg/config"
+	remote_signer "github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/types"
+)
+
+func newTestDualModeNode(t *testing.T, aggregator bool) (Node, error) {
+	t.Helper()
+	config := rollkitconfig.Config{
+		RootDir: t.TempDir(),
+		Node: rollkitconfig.NodeConfig{
+			Aggregator: aggregator,
+			Light:      true,
+			DualMode:   true,
+		},
+		DA: rollkitconfig.DAConfig{
+			Address:   MockDAAddress,
+			Namespace: MockDANamespace,
+		},
+		RPC: rollkitconfig.RPCConfig{
+			Address: "127.0.0.1:0",
+		},
+	}
+
+	genesis, genesisValidatorKey, _ := types.GetGenesisWithPrivkey("TestDualModeNode")
+	remoteSigner, err := remote_signer.NewNoopSigner(genesisValidatorKey)
+	require.NoError(t, err)
+	executor, sequencer, dac, p2pClient, ds := createTestComponents(t)
+	nodeKey, err := InitFiles(config.RootDir)
+	require.NoError(t, err)
+
+	return NewNode(
+		context.Background(),
+		config,
+		executor,
+		sequencer,
+		dac,
+		remoteSigner,
+		*nodeKey,
+		p2pClient,
+		genesis,
+		ds,
+		DefaultMetricsProvider(rollkitconfig.DefaultInstrumentationConfig()),
+		log.NewTestLogger(t),
+	)
+}
+
+func TestDualModeNode(t *testing.T) {
+	_, err := newTestDualModeNode(t, true)
+	require.ErrorContains(t, err, "dual mode cannot be enabled in aggregator mode")
+
+	node, err := newTestDualModeNode(t, false)
+	require.NoError(t, err)
+	require.IsType(t, new(DualModeNode), node)
+	dual := node.(*DualModeNode)
+	runner := startNodeWithCleanup(t, dual)
+
+	requireMode := func(mode string) {
+		t.Helper()
+		require.Eventually(t, func() bool {
+			status, err := dual.Status(runner.Ctx)
+			return err == nil && status.Mode == mode && dual.Mode() == mode
+		}, 5*time.Second, 50*time.Millisecond)
+	}
+	requireMode(types.NodeModeLight)
+	require.IsType(t, new(LightNode), dual.current())
+
+	// upgrade to a full node in place
+	require.NoError(t, dual.SwitchMode(types.NodeModeFull))
+	requireMode(types.NodeModeFull)
+	require.IsType(t, new(FullNode), dual.current())
+	assert.ErrorContains(t, dual.SwitchMode(types.NodeModeFull), "already runs in full mode")
+
+	// downgrade to a light node
+	require.NoError(t, dual.SwitchMode(types.NodeModeLight))
+	requireMode(types.NodeModeLight)
+	require.IsType(t, new(LightNode), dual.current())
+
+	assert.ErrorContains(t, dual.SwitchMode("archive"), "unknown mode")
+	select {
+	case err := <-runner.ErrCh:
+		require.Fail(t, "node stopped", "error: %v", err)
+	default:
+	}
+}
+
+func TestMigrateLightNodeStore(t *testing.T) {
+	ctx := context.Background()
+	database := dssync.MutexWrap(datastore.NewMapDatastore())
+	legacy := map[string][]byte{
+		"/headerSync/head": []byte("head"),
+		"/headerSync/1":    []byte("header"),
+		"/m/da-verifier":   []byte("state"),
+	}
+	for key, value := range legacy {
+		require.NoError(t, database.Put(ctx, datastore.NewKey(key), value))
+	}
+	// keys of the p2p connection gater are not moved
+	gaterKey := datastore.NewKey("/libp2p/net/conngater/peer/1")
+	require.NoError(t, database.Put(ctx, gaterKey, []byte("blocked")))
+
+	require.NoError(t, migrateLightNodeStore(ctx, database, log.NewNopLogger()))
+	for key, value := range legacy {
+		_, err := database.Get(ctx, datastore.NewKey(key))
+		require.ErrorIs(t, err, datastore.ErrNotFound)
+		moved, err := database.Get(ctx, datastore.NewKey("/"+RollkitPrefix+key))
+		require.NoError(t, err)
+		assert.Equal(t, value, moved)
+	}
+	_, err := database.Get(ctx, gaterKey)
+	require.NoError(t, err)
+
+	// data stored under the shared layout is left untouched
+	require.NoError(t, database.Put(ctx, datastore.NewKey("/m/other"), []byte("other")))
+	require.NoError(t, migrateLightNodeStore(ctx, database, log.NewNopLogger()))
+	_, err = database.Get(ctx, datastore.NewKey("/m/other"))
+	require.NoError(t, err)
+}
@@ -1,18 +1,22 @@
 package node
 
 import (
+	"cmp"
 	"context"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
-	"net/http/pprof"
 	"time"
 
 	"cosmossdk.io/log"
 	ds "github.com/ipfs/go-datastore"
 	ktds "github.com/ipfs/go-datastore/keytransform"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
@@ -20,15 +24,26 @@ import (
 	coreda "github.com/rollkit/rollkit/core/da"
 	coreexecutor "github.com/rollkit/rollkit/core/execution"
 	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/attest"
+	"github.com/rollkit/rollkit/pkg/blockdata"
 	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/debug"
+	"github.com/rollkit/rollkit/pkg/fraud"
 	genesispkg "github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/leader"
+	"github.com/rollkit/rollkit/pkg/logging"
+	"github.com/rollkit/rollkit/pkg/mempool"
 	"github.com/rollkit/rollkit/pkg/p2p"
 	"github.com/rollkit/rollkit/pkg/p2p/key"
 	rpcserver "github.com/rollkit/rollkit/pkg/rpc/server"
 	"github.com/rollkit/rollkit/pkg/service"
 	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/pkg/snapshot"
 	"github.com/rollkit/rollkit/pkg/store"
 	"github.com/rollkit/rollkit/pkg/sync"
+	"github.com/rollkit/rollkit/pkg/telemetry"
+	"github.com/rollkit/rollkit/pkg/txindex"
+	"github.com/rollkit/rollkit/types"
 )
 
 // prefixes used in KV store to separate rollkit data from execution environment data (if the same data base is reused)
@@ -38,6 +53,27 @@ const (
 	// genesisChunkSize is the maximum size, in bytes, of each
 	// chunk in the genesis structure for the chunked API
 	genesisChunkSize = 16 * 1024 * 1024 // 16 MiB
+
+	// snapshotPrefix is the prefix, within the rollkit KV store, under which state snapshots are stored
+	snapshotPrefix = "snapshots"
+
+	// txIndexPrefix is the prefix, within the rollkit KV store, under which the transaction index is stored
+	txIndexPrefix = "txindex"
+
+	// cacheSpillPrefix is the prefix, within the rollkit KV store, under which the block caches spill the entries
+	// beyond their bounds
+	cacheSpillPrefix = "cache"
+
+	// txGossipQueueSize is the number of submitted transactions waiting to be gossiped to peers
+	txGossipQueueSize = 1000
+
+	// stateSyncTimeout is the maximum time spent fetching and restoring a state snapshot
+	// before falling back to syncing all blocks
+	stateSyncTimeout = 5 * time.Minute
+
+	// leaderSyncPollInterval is the interval at which a newly elected leader checks whether
+	// it synced all blocks of the previous leader
+	leaderSyncPollInterval = 100 * time.Millisecond
 )
 
 var _ Node = &FullNode{}
@@ -52,6 +88,8 @@ type FullNode struct {
 	genChunks []string
 
 	nodeConfig config.Config
+	// runtimeConf serves the configuration with the runtime parameters changed while the node is running
+	runtimeConf *runtimeConfig
 
 	da coreda.DA
 
@@ -61,10 +99,37 @@ type FullNode struct {
 	Store        store.Store
 	blockManager *block.Manager
 	reaper       *block.Reaper
+	pruner       *store.Pruner
+	maintainer   *store.Maintainer
+	telemetry    *telemetry.Reporter
+	snapshots    *snapshot.Store
+	txIndexer    *txindex.Indexer
+	snapshotSvc  *snapshot.Service
+	blockDataSvc *blockdata.Service
+	fraudSvc     *fraud.Service
+	attestSvc    *attest.Service
+	elector      *leader.Elector
+	mempool      *mempool.Mempool
+	txGossip     *mempool.Gossip
+	// txGossipCh queues the transactions submitted to the node for gossip, nil if gossip is disabled
+	txGossipCh chan []byte
+	// attesters is the attester set of the chain, nil if attestations are disabled
+	attesters *attest.Set
+	// attestKey signs the attestations of the node if it is an attester of the set
+	attestKey crypto.PrivKey
+	// querier serves queries of the execution state, nil if the executor does not support queries
+	querier coreexecutor.Querier
+	// modes switches the node to light mode, nil unless the node is run by a DualModeNode
+	modes rpcserver.ModeSwitcher
+	// sharedStore is set if the store is closed by a DualModeNode instead of the node
+	sharedStore bool
+	// loopShutdownTimeouts overrides the shutdown timeout of individual block manager loops
+	loopShutdownTimeouts map[string]time.Duration
 
 	prometheusSrv *http.Server
 	pprofSrv      *http.Server
 	rpcServer     *http.Server
+	grpcServer    *http.Server
 }
 
 // newFullNode creates a new Rollkit full node.
@@ -81,10 +146,30 @@ func newFullNode(
 	da coreda.DA,
 	metricsProvider MetricsProvider,
 	logger log.Logger,
+	opts options,
 ) (fn *FullNode, err error) {
-	seqMetrics, _ := metricsProvider(genesis.ChainID)
+	if nodeConfig.Node.SequencingMode == config.SequencingModeBased && nodeConfig.Node.Aggregator {
+		return nil, fmt.Errorf("aggregator mode cannot be enabled in based sequencing mode, blocks are derived from the DA layer")
+	}
+	if nodeConfig.Node.Archive && nodeConfig.Pruning.KeepRecent > 0 {
+		return nil, fmt.Errorf("pruning cannot be enabled in archive mode, the data of all blocks is retained")
+	}
+	if nodeConfig.Node.Archive && nodeConfig.Snapshot.StateSync {
+		return nil, fmt.Errorf("state sync cannot be enabled in archive mode, the blocks below the snapshot height would be missing")
+	}
+	loopShutdownTimeouts, err := config.ParseLoopShutdownTimeouts(nodeConfig.Node.LoopShutdownTimeouts)
+	if err != nil {
+		return nil, err
+	}
+
+	seqMetrics, p2pMetrics := metricsProvider(genesis.ChainID)
+	p2pClient.SetMetrics(p2pMetrics)
+	p2pClient.SetGenesisFingerprint(genesis.Fingerprint())
 
 	mainKV := newPrefixKV(database, RollkitPrefix)
+	if err := store.Migrate(ctx, mainKV, logger); err != nil {
+		return nil, err
+	}
 	headerSyncService, err := initHeaderSyncService(mainKV, nodeConfig, genesis, p2pClient, logger)
 	if err != nil {
 		return nil, err
@@ -94,8 +179,23 @@ func newFullNode(
 	if err != nil {
 		return nil, err
 	}
+	dataSyncService.SetHeaderSyncService(headerSyncService)
 
-	store := store.New(mainKV)
+	rollkitStore := store.New(mainKV)
+	if nodeConfig.Node.Archive {
+		pruned, err := store.GetPrunedHeight(ctx, rollkitStore)
+		if err != nil {
+			return nil, fmt.Errorf("error while checking pruned blocks: %w", err)
+		}
+		if pruned > 0 {
+			return nil, fmt.Errorf("blocks up to height %d were pruned, archive mode requires the data of all blocks", pruned)
+		}
+	}
+
+	headerDA, err := namespacedDA(da, nodeConfig.DA.HeaderNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("error while initializing header namespace: %w", err)
+	}
 
 	blockManager, err := initBlockManager(
 		ctx,
@@ -103,9 +203,9 @@ func newFullNode(
 		exec,
 		nodeConfig,
 		genesis,
-		store,
+		rollkitStore,
 		sequencer,
-		da,
+		headerDA,
 		logger,
 		headerSyncService,
 		dataSyncService,
@@ -117,36 +217,172 @@ func newFullNode(
 		return nil, err
 	}
 
+	// block data is retrieved from both namespaces, unless headers and block data share the same namespace
+	if cmp.Or(nodeConfig.DA.DataNamespace, nodeConfig.DA.Namespace) != cmp.Or(nodeConfig.DA.HeaderNamespace, nodeConfig.DA.Namespace) {
+		dataDA, err := namespacedDA(da, nodeConfig.DA.DataNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("error while initializing data namespace: %w", err)
+		}
+		blockManager.SetDataDA(dataDA)
+	}
+
+	if nodeConfig.DA.ForcedInclusionNamespace != "" {
+		if err := enableForcedInclusion(ctx, blockManager, da, nodeConfig.DA.ForcedInclusionNamespace); err != nil {
+			return nil, err
+		}
+	}
+
 	reaper := block.NewReaper(
 		ctx,
 		exec,
-		sequencer,
+		blockManager.SeqClient(),
 		genesis.ChainID,
 		nodeConfig.Node.BlockTime.Duration,
-		logger.With("module", "Reaper"),
+		logger.With("module", logging.ModuleReaper),
 		mainKV,
 	)
 
 	// Connect the reaper to the manager for transaction notifications
 	reaper.SetManager(blockManager)
 
+	mp, err := mempool.New(nodeConfig.Mempool, exec, logger.With("module", logging.ModuleMempool))
+	if err != nil {
+		return nil, err
+	}
+	reaper.SetMempool(mp)
+	blockManager.SetMempool(mp)
+	var txGossipCh chan []byte
+	if nodeConfig.Mempool.Broadcast {
+		txGossipCh = make(chan []byte, txGossipQueueSize)
+	}
+
+	var pruner *store.Pruner
+	if nodeConfig.Pruning.KeepRecent > 0 {
+		pruner, err = store.NewPruner(
+			rollkitStore,
+			nodeConfig.Pruning.KeepRecent,
+			nodeConfig.Pruning.Interval.Duration,
+			blockManager.PrunableHeight,
+			logger.With("module", logging.ModulePruner),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error while initializing Pruner: %w", err)
+		}
+	}
+
+	maintainer := store.NewMaintainer(database, nodeConfig.Pruning.CompactionInterval.Duration, logger.With("module", logging.ModulePruner))
+
+	var snapshots *snapshot.Store
+	if nodeConfig.Snapshot.Interval > 0 || nodeConfig.Snapshot.StateSync {
+		snapshots, err = snapshot.NewStore(newPrefixKV(mainKV, snapshotPrefix), int(nodeConfig.Snapshot.KeepRecent)) //nolint:gosec // retention is a small number
+		if err != nil {
+			return nil, fmt.Errorf("error while initializing snapshot store: %w", err)
+		}
+		if nodeConfig.Snapshot.Interval > 0 {
+			blockManager.SetSnapshotStore(snapshots)
+		}
+	}
+
+	var txIndexer *txindex.Indexer
+	if nodeConfig.TxIndex.Enabled {
+		txIndexer, err = txindex.NewIndexer(ctx, newPrefixKV(mainKV, txIndexPrefix), rollkitStore, exec, logger.With("module", logging.ModuleTxIndex))
+		if err != nil {
+			return nil, fmt.Errorf("error while initializing TxIndexer: %w", err)
+		}
+		blockManager.SetTxIndexer(txIndexer)
+	}
+	if opts.shadowExec != nil {
+		blockManager.SetShadowExecutor(opts.shadowExec)
+	}
+	if err := blockManager.SetCacheSpillStore(newPrefixKV(mainKV, cacheSpillPrefix)); err != nil {
+		return nil, err
+	}
+	committee, err := newDACommittee(nodeConfig.DA)
+	if err != nil {
+		return nil, err
+	}
+	if committee != nil {
+		blockManager.SetDAC(committee)
+	}
+
+	if nodeConfig.Node.Aggregator && nodeConfig.SignerWatermarkPath() != "" {
+		watermark, err := initSignerWatermark(nodeConfig, logger)
+		if err != nil {
+			return nil, err
+		}
+		blockManager.SetSignerWatermark(watermark)
+	}
+
+	var attesters *attest.Set
+	if nodeConfig.Attestation.Attesters != "" {
+		attesters, err = attest.NewSet(nodeConfig.Attestation.Attesters, nodeConfig.Attestation.Quorum)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var elector *leader.Elector
+	if nodeConfig.Node.Aggregator && nodeConfig.Leader.Enabled {
+		lastState := blockManager.GetLastState()
+		proposer := blockManager.ProposerAt(lastState.LastBlockHeight + 1)
+		elector, err = initElector(nodeConfig, proposer, signer, nodeKey, da, lastState.DAHeight, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	node := &FullNode{
-		genesis:      genesis,
-		nodeConfig:   nodeConfig,
-		p2pClient:    p2pClient,
-		blockManager: blockManager,
-		reaper:       reaper,
-		da:           da,
-		Store:        store,
-		hSyncService: headerSyncService,
-		dSyncService: dataSyncService,
+		genesis:              genesis,
+		nodeConfig:           nodeConfig,
+		p2pClient:            p2pClient,
+		blockManager:         blockManager,
+		reaper:               reaper,
+		loopShutdownTimeouts: loopShutdownTimeouts,
+		pruner:               pruner,
+		maintainer:           maintainer,
+		snapshots:            snapshots,
+		txIndexer:            txIndexer,
+		elector:              elector,
+		attesters:            attesters,
+		attestKey:            nodeKey.PrivKey,
+		mempool:              mp,
+		txGossipCh:           txGossipCh,
+		da:                   da,
+		Store:                rollkitStore,
+		hSyncService:         headerSyncService,
+		dSyncService:         dataSyncService,
+	}
+
+	if querier, ok := exec.(coreexecutor.Querier); ok {
+		node.querier = querier
+	}
+
+	node.telemetry, err = telemetry.NewReporter(nodeConfig.Telemetry, genesis.ChainID, opts.version, node, rollkitStore, logger.With("module", logging.ModuleTelemetry))
+	if err != nil {
+		return nil, err
 	}
 
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
+	node.runtimeConf = newRuntimeConfig(nodeConfig, node.applyRuntimeConfig, logger)
 
 	return node, nil
 }
 
+// applyRuntimeConfig applies changed runtime parameters to the block manager, the pruner and the P2P client.
+func (n *FullNode) applyRuntimeConfig(old, updated config.Config) error {
+	if old.Pruning.KeepRecent != updated.Pruning.KeepRecent || old.Pruning.Interval != updated.Pruning.Interval {
+		if n.pruner == nil || updated.Pruning.KeepRecent == 0 {
+			return fmt.Errorf("%w: pruning can only be enabled or disabled with a restart", config.ErrInvalidRuntimeConfig)
+		}
+		if err := n.pruner.SetParams(updated.Pruning.KeepRecent, updated.Pruning.Interval.Duration); err != nil {
+			return fmt.Errorf("%w: %w", config.ErrInvalidRuntimeConfig, err)
+		}
+	}
+	n.blockManager.UpdateRuntimeConfig(updated)
+	n.p2pClient.SetMaxPeers(updated.P2P.MaxPeers)
+	return nil
+}
+
 func initHeaderSyncService(
 	mainKV ds.Batching,
 	nodeConfig config.Config,
@@ -154,13 +390,24 @@ func initHeaderSyncService(
 	p2pClient *p2p.Client,
 	logger log.Logger,
 ) (*sync.HeaderSyncService, error) {
-	headerSyncService, err := sync.NewHeaderSyncService(mainKV, nodeConfig, genesis, p2pClient, logger.With("module", "HeaderSyncService"))
+	headerSyncService, err := sync.NewHeaderSyncService(mainKV, nodeConfig, genesis, p2pClient, logger.With("module", logging.ModuleSync))
 	if err != nil {
 		return nil, fmt.Errorf("error while initializing HeaderSyncService: %w", err)
 	}
 	return headerSyncService, nil
 }
 
+func initSignerWatermark(nodeConfig config.Config, logger log.Logger) (*signer.WatermarkFile, error) {
+	watermark, err := signer.LoadWatermarkFile(nodeConfig.SignerWatermarkPath())
+	if err != nil {
+		return nil, fmt.Errorf("error while loading signer watermark: %w", err)
+	}
+	if w := watermark.Watermark(); w.Height > 0 {
+		logger.Info("loaded signer watermark", "height", w.Height, "round", w.Round, "timestamp", w.Timestamp)
+	}
+	return watermark, nil
+}
+
 func initDataSyncService(
 	mainKV ds.Batching,
 	nodeConfig config.Config,
@@ -168,13 +415,63 @@ func initDataSyncService(
 	p2pClient *p2p.Client,
 	logger log.Logger,
 ) (*sync.DataSyncService, error) {
-	dataSyncService, err := sync.NewDataSyncService(mainKV, nodeConfig, genesis, p2pClient, logger.With("module", "DataSyncService"))
+	dataSyncService, err := sync.NewDataSyncService(mainKV, nodeConfig, genesis, p2pClient, logger.With("module", logging.ModuleSync))
 	if err != nil {
 		return nil, fmt.Errorf("error while initializing DataSyncService: %w", err)
 	}
 	return dataSyncService, nil
 }
 
+// initElector initializes the leader elector of an aggregator running in high availability mode.
+// Lease claims are posted to their own DA namespace, and processed from a few leases before the last
+// synced DA height, as the lease only depends on recent claims. Claims must be signed by the key of the
+// given proposer.
+func initElector(
+	nodeConfig config.Config,
+	proposer []byte,
+	signer signer.Signer,
+	nodeKey key.NodeKey,
+	da coreda.DA,
+	daHeight uint64,
+	logger log.Logger,
+) (*leader.Elector, error) {
+	namespace := nodeConfig.Leader.Namespace
+	if namespace == "" {
+		return nil, errors.New("leader election requires a DA namespace for lease claims")
+	}
+	if namespace == cmp.Or(nodeConfig.DA.HeaderNamespace, nodeConfig.DA.Namespace) ||
+		namespace == cmp.Or(nodeConfig.DA.DataNamespace, nodeConfig.DA.Namespace) {
+		return nil, errors.New("the DA namespace of lease claims must differ from the namespaces of blocks")
+	}
+	leaseDA, err := namespacedDA(da, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error while initializing lease namespace: %w", err)
+	}
+	leaseBlocks := nodeConfig.Leader.LeaseBlocks
+	startHeight := nodeConfig.DA.StartHeight
+	if daHeight > startHeight+2*leaseBlocks {
+		startHeight = daHeight - 2*leaseBlocks
+	}
+	interval := nodeConfig.DA.BlockTime.Duration
+	if interval == 0 {
+		interval = config.DefaultConfig.DA.BlockTime.Duration
+	}
+	elector, err := leader.NewElector(
+		leaseDA,
+		signer,
+		proposer,
+		nodeKey.ID(),
+		leaseBlocks,
+		startHeight,
+		interval,
+		logger.With("module", logging.ModuleLeader),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error while initializing leader elector: %w", err)
+	}
+	return elector, nil
+}
+
 // initBlockManager initializes the block manager.
 // It requires:
 // - signingKey: the private key of the validator
@@ -183,6 +480,35 @@ func initDataSyncService(
 // - store: the store
 // - seqClient: the sequencing client
 // - da: the DA
+// namespacedDA returns a DA client bound to the hex encoded namespace, sharing the connection of da. If the
+// namespace is empty, da is returned.
+func namespacedDA(da coreda.DA, namespace string) (coreda.DA, error) {
+	if namespace == "" {
+		return da, nil
+	}
+	namespacer, ok := coreda.Implements[coreda.Namespacer](da)
+	if !ok {
+		return nil, errors.New("DA client does not support multiple namespaces")
+	}
+	nsBytes, err := hex.DecodeString(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode namespace: %w", err)
+	}
+	return namespacer.WithNamespace(nsBytes), nil
+}
+
+// enableForcedInclusion enables the forced inclusion lane of the block manager, using a DA client bound to the forced inclusion namespace.
+func enableForcedInclusion(ctx context.Context, blockManager *block.Manager, da coreda.DA, namespace string) error {
+	forcedDA, err := namespacedDA(da, namespace)
+	if err != nil {
+		return fmt.Errorf("error while initializing forced inclusion namespace: %w", err)
+	}
+	if err := blockManager.EnableForcedInclusion(ctx, forcedDA); err != nil {
+		return fmt.Errorf("error while enabling forced inclusion: %w", err)
+	}
+	return nil
+}
+
 func initBlockManager(
 	ctx context.Context,
 	signer signer.Signer,
@@ -210,9 +536,9 @@ func initBlockManager(
 		exec,
 		sequencer,
 		da,
-		logger.With("module", "BlockManager"),
-		headerSyncService.Store(),
-		dataSyncService.Store(),
+		logger.With("module", logging.ModuleBlock),
+		headerSyncService.LazyStore(),
+		dataSyncService.LazyStore(),
 		seqMetrics,
 		gasPrice,
 		gasMultiplier,
@@ -260,6 +586,72 @@ func (n *FullNode) headerPublishLoop(ctx context.Context) {
 	}
 }
 
+// fraudProofPublishLoop gossips the fraud proofs generated by the block manager.
+func (n *FullNode) fraudProofPublishLoop(ctx context.Context) {
+	for {
+		select {
+		case proof := <-n.blockManager.FraudProofCh:
+			if err := n.fraudSvc.Broadcast(ctx, proof); err != nil {
+				n.Logger.Error("failed to gossip fraud proof", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// SubmitTx admits a transaction submitted over RPC to the mempool and returns its hash. Admitted transactions
+// are gossiped to peers, so that transactions submitted to any full node reach the aggregator. Aggregators
+// reject transactions as if the mempool was full while the DA submission backlog exceeds its threshold.
+func (n *FullNode) SubmitTx(ctx context.Context, tx []byte) ([]byte, error) {
+	if err := n.blockManager.CheckDABacklog(); err != nil {
+		return nil, fmt.Errorf("%w: %w", mempool.ErrMempoolFull, err)
+	}
+	hash, err := n.mempool.Add(ctx, tx)
+	if errors.Is(err, mempool.ErrTxInMempool) {
+		return hash, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if n.txGossipCh != nil {
+		select {
+		case n.txGossipCh <- tx:
+		default:
+			n.Logger.Error("transaction gossip queue is full, transaction is not relayed", "hash", fmt.Sprintf("%X", hash))
+		}
+	}
+	return hash, nil
+}
+
+// Preconfirm issues a preconfirmation for a transaction accepted by the node. Preconfirmations are only issued
+// by the aggregator producing blocks, i.e. the leader when leader election is enabled.
+func (n *FullNode) Preconfirm(ctx context.Context, tx []byte) (*types.Preconfirmation, error) {
+	if n.elector != nil && !n.elector.IsLeader() {
+		return nil, block.ErrPreconfirmationsDisabled
+	}
+	return n.blockManager.Preconfirm(ctx, tx)
+}
+
+// VerifyPreconfirmation verifies a preconfirmation against the blocks of the node.
+func (n *FullNode) VerifyPreconfirmation(ctx context.Context, preconf *types.Preconfirmation) (block.PreconfirmationResult, error) {
+	return n.blockManager.VerifyPreconfirmation(ctx, preconf)
+}
+
+// txGossipLoop gossips the transactions submitted to the node.
+func (n *FullNode) txGossipLoop(ctx context.Context) {
+	for {
+		select {
+		case tx := <-n.txGossipCh:
+			if err := n.txGossip.Broadcast(ctx, tx); err != nil {
+				n.Logger.Error("failed to gossip transaction", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func (n *FullNode) dataPublishLoop(ctx context.Context) {
 	for {
 		select {
@@ -276,7 +668,8 @@ func (n *FullNode) dataPublishLoop(ctx context.Context) {
 	}
 }
 
-// startInstrumentationServer starts HTTP servers for instrumentation (Prometheus metrics and pprof).
+// startInstrumentationServer starts HTTP servers for instrumentation (Prometheus metrics, and pprof along with the
+// other debug endpoints, see debug.NewHandler).
 // Returns the primary server (Prometheus if enabled, otherwise pprof) and optionally a secondary server.
 func (n *FullNode) startInstrumentationServer() (*http.Server, *http.Server) {
 	var prometheusServer, pprofServer *http.Server
@@ -286,7 +679,7 @@ func (n *FullNode) startInstrumentationServer() (*http.Server, *http.Server) {
 		prometheusMux := http.NewServeMux()
 
 		// Register Prometheus metrics handler
-		prometheusMux.Handle("/metrics", promhttp.InstrumentMetricHandler(
+		prometheusMux.Handle(n.nodeConfig.Instrumentation.GetPrometheusPath(), promhttp.InstrumentMetricHandler(
 			prometheus.DefaultRegisterer, promhttp.HandlerFor(
 				prometheus.DefaultGatherer,
 				promhttp.HandlerOpts{MaxRequestsInFlight: n.nodeConfig.Instrumentation.MaxOpenConnections},
@@ -306,30 +699,14 @@ func (n *FullNode) startInstrumentationServer() (*http.Server, *http.Server) {
 			}
 		}()
 
-		n.Logger.Info("Started Prometheus HTTP server", "addr", n.nodeConfig.Instrumentation.PrometheusListenAddr)
+		n.Logger.Info("Started Prometheus HTTP server", "addr", n.nodeConfig.Instrumentation.PrometheusListenAddr, "path", n.nodeConfig.Instrumentation.GetPrometheusPath())
 	}
 
 	// Check if pprof is enabled
 	if n.nodeConfig.Instrumentation.IsPprofEnabled() {
-		pprofMux := http.NewServeMux()
-
-		// Register pprof handlers
-		pprofMux.HandleFunc("/debug/pprof/", pprof.Index)
-		pprofMux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
-		pprofMux.HandleFunc("/debug/pprof/profile", pprof.Profile)
-		pprofMux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
-		pprofMux.HandleFunc("/debug/pprof/trace", pprof.Trace)
-		// Register other pprof handlers
-		pprofMux.Handle("/debug/pprof/goroutine", pprof.Handler("goroutine"))
-		pprofMux.Handle("/debug/pprof/heap", pprof.Handler("heap"))
-		pprofMux.Handle("/debug/pprof/threadcreate", pprof.Handler("threadcreate"))
-		pprofMux.Handle("/debug/pprof/block", pprof.Handler("block"))
-		pprofMux.Handle("/debug/pprof/mutex", pprof.Handler("mutex"))
-		pprofMux.Handle("/debug/pprof/allocs", pprof.Handler("allocs"))
-
 		pprofServer = &http.Server{
 			Addr:              n.nodeConfig.Instrumentation.GetPprofListenAddr(),
-			Handler:           pprofMux,
+			Handler:           debug.NewHandler(logging.RecentOf(n.Logger)),
 			ReadHeaderTimeout: readHeaderTimeout,
 		}
 
@@ -360,7 +737,53 @@ func (n *FullNode) Run(ctx context.Context) error {
 	}
 
 	// Start RPC server
-	handler, err := rpcserver.NewServiceHandler(n.Store, n.p2pClient)
+	var txIndex rpcserver.TxIndex
+	if n.txIndexer != nil {
+		txIndex = n.txIndexer
+	}
+	txs := rpcserver.TxSources{
+		Submitter:        n,
+		Mempool:          n.mempool,
+		Preconfirmations: n,
+		Index:            txIndex,
+		Events:           n.blockManager,
+		Confirmations:    n.blockManager,
+		CommitTimeout:    n.nodeConfig.RPC.Timeout.Duration,
+	}
+	status := rpcserver.StatusSources{
+		Node:          n,
+		Elector:       n.elector,
+		Confirmations: n.blockManager,
+		GasPrices:     n.blockManager,
+		DAFees:        n.blockManager,
+		DAInclusion:   n.blockManager,
+		LightClient:   n.blockManager,
+		DAChains:      n.blockManager,
+		DADryRun:      n.blockManager,
+	}
+	admin := rpcserver.AdminSources{
+		Levels:     logging.LevelsOf(n.Logger),
+		Logs:       logging.RecentOf(n.Logger),
+		Maintainer: n.maintainer,
+		Config:     n.runtimeConf,
+		Modes:      n.modes,
+		Token:      n.nodeConfig.RPC.AdminToken,
+	}
+	if n.nodeConfig.Node.Aggregator {
+		admin.Rotator = n.blockManager
+		admin.Upgrader = n.blockManager
+		admin.Producer = n.blockManager
+	}
+	security, err := newRPCSecurity(n.nodeConfig.RPC)
+	if err != nil {
+		return fmt.Errorf("error loading RPC credentials: %w", err)
+	}
+	security.watch(ctx, n.Logger)
+	opts := rpcHandlerOptions(n.nodeConfig, n.genesis.ChainID, security.auth)
+	if n.querier != nil {
+		opts = append(opts, rpcserver.WithStateQuerier(n.querier))
+	}
+	handler, err := rpcserver.NewServiceHandler(n.Store, txIndex, n.p2pClient, status, n.blockManager, txs, admin, opts...)
 	if err != nil {
 		return fmt.Errorf("error creating RPC handler: %w", err)
 	}
@@ -368,19 +791,44 @@ func (n *FullNode) Run(ctx context.Context) error {
 	n.rpcServer = &http.Server{
 		Addr:         n.nodeConfig.RPC.Address,
 		Handler:      handler,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
+		ReadTimeout:  n.nodeConfig.RPC.Timeout.Duration,
+		WriteTimeout: n.nodeConfig.RPC.Timeout.Duration,
 		IdleTimeout:  120 * time.Second,
 	}
 
 	go func() {
-		if err := n.rpcServer.ListenAndServe(); err != http.ErrServerClosed {
+		if err := security.listenAndServe(n.rpcServer); err != http.ErrServerClosed {
 			n.Logger.Error("RPC server error", "err", err)
 		}
 	}()
 
 	n.Logger.Info("Started RPC server", "addr", n.nodeConfig.RPC.Address)
 
+	if n.nodeConfig.RPC.GRPCAddress != "" {
+		grpcHandler, err := rpcserver.NewGRPCHandler(n.Store, txIndex, n.p2pClient, status, n.blockManager, txs, admin, opts...)
+		if err != nil {
+			return fmt.Errorf("error creating gRPC handler: %w", err)
+		}
+		// event streams are canceled when the server shuts down instead of delaying the shutdown
+		streamCtx, cancelStreams := context.WithCancel(context.Background())
+		n.grpcServer = &http.Server{
+			Addr:              n.nodeConfig.RPC.GRPCAddress,
+			Handler:           grpcHandler,
+			ReadHeaderTimeout: n.nodeConfig.RPC.Timeout.Duration,
+			IdleTimeout:       120 * time.Second,
+			BaseContext:       func(net.Listener) context.Context { return streamCtx },
+		}
+		n.grpcServer.RegisterOnShutdown(cancelStreams)
+
+		go func() {
+			if err := security.listenAndServe(n.grpcServer); err != http.ErrServerClosed {
+				n.Logger.Error("gRPC server error", "err", err)
+			}
+		}()
+
+		n.Logger.Info("Started gRPC server", "addr", n.nodeConfig.RPC.GRPCAddress)
+	}
+
 	n.Logger.Info("starting P2P client")
 	err = n.p2pClient.Start(ctx)
 	if err != nil {
@@ -395,21 +843,157 @@ func (n *FullNode) Run(ctx context.Context) error {
 		return fmt.Errorf("error while starting data sync service: %w", err)
 	}
 
+	if n.nodeConfig.Node.FraudProofs {
+		n.fraudSvc = fraud.NewService(n.p2pClient.PubSub(), n.p2pClient.Host().ID(), n.genesis.ChainID, n.blockManager, n.Logger.With("module", logging.ModuleFraud))
+		n.p2pClient.SetTopicMisbehavior(fraud.TopicID(n.genesis.ChainID), p2p.InvalidFraudProof)
+		if err := n.fraudSvc.Start(ctx); err != nil {
+			return fmt.Errorf("error while starting fraud proof service: %w", err)
+		}
+		defer func() {
+			if err := n.fraudSvc.Stop(); err != nil {
+				n.Logger.Error("error stopping fraud proof service", "error", err)
+			}
+		}()
+	}
+
+	if n.attesters != nil {
+		n.attestSvc = attest.NewService(n.p2pClient.PubSub(), n.p2pClient.Host().ID(), n.genesis.ChainID, n.attesters, n.attestKey, n.blockManager, n.Store, n.Logger.With("module", logging.ModuleAttest))
+		n.p2pClient.SetTopicMisbehavior(attest.TopicID(n.genesis.ChainID), p2p.InvalidAttestation)
+		if err := n.attestSvc.Start(ctx); err != nil {
+			return fmt.Errorf("error while starting attestation service: %w", err)
+		}
+		defer func() {
+			if err := n.attestSvc.Stop(); err != nil {
+				n.Logger.Error("error stopping attestation service", "error", err)
+			}
+		}()
+	}
+
+	if n.txGossipCh != nil {
+		n.txGossip = mempool.NewGossip(n.p2pClient.PubSub(), n.p2pClient.Host().ID(), n.genesis.ChainID, n.mempool, n.Logger.With("module", logging.ModuleMempool))
+		n.p2pClient.SetTopicMisbehavior(mempool.TopicID(n.genesis.ChainID), p2p.InvalidTx)
+		if err := n.txGossip.Start(ctx); err != nil {
+			return fmt.Errorf("error while starting transaction gossip: %w", err)
+		}
+		defer func() {
+			if err := n.txGossip.Stop(); err != nil {
+				n.Logger.Error("error stopping transaction gossip", "error", err)
+			}
+		}()
+	}
+
+	n.blockDataSvc = blockdata.NewService(n.p2pClient.Host(), n.genesis.ChainID, n.Store, blockdata.Limits{
+		Requests:        n.nodeConfig.P2P.BlockDataRequests,
+		RequestsPerPeer: n.nodeConfig.P2P.BlockDataPeerRequests,
+	}, n.Logger.With("module", logging.ModuleBlockData))
+	n.blockDataSvc.SetInvalidDataReporter(func(id peer.ID) {
+		n.p2pClient.ReportPeer(id, p2p.InvalidData)
+	})
+	n.blockDataSvc.Start()
+	defer n.blockDataSvc.Stop()
+	// in commitments-only mode, missing block data is fetched from the data availability committee
+	if n.nodeConfig.DA.DACMembers == "" {
+		n.blockManager.SetDataFetcher(n.blockDataSvc)
+	}
+
+	if n.snapshots != nil {
+		n.snapshotSvc = snapshot.NewService(n.p2pClient.Host(), n.genesis.ChainID, n.snapshots, n.blockManager.GetDAIncludedHeight, n.Logger.With("module", logging.ModuleSnapshot))
+		n.snapshotSvc.Start()
+		defer n.snapshotSvc.Stop()
+
+		if n.nodeConfig.Snapshot.StateSync && !n.nodeConfig.Node.Aggregator {
+			if err := n.stateSync(ctx); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				n.Logger.Error("state sync failed, syncing all blocks instead", "error", err)
+			}
+		}
+	}
+
 	if n.nodeConfig.Node.Aggregator {
+		if err := n.blockManager.Recover(ctx); err != nil {
+			n.Logger.Error("failed to recover DA submissions", "error", err)
+		}
+	}
+
+	loops := n.newSupervisor()
+	switch {
+	case n.blockManager.Sequencing().Mode() == config.SequencingModeBased:
+		n.Logger.Info("working in based sequencing mode", "DA block time", n.nodeConfig.DA.BlockTime)
+		loops.Go(ctx, "produce_blocks", n.blockManager.Sequencing().ProduceBlocks)
+	case n.elector != nil:
+		n.Logger.Info("working in aggregator mode with leader election", "block time", n.nodeConfig.Node.BlockTime, "node", n.elector.NodeID())
+		go func() {
+			if err := n.elector.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				n.Logger.Error("leader elector stopped", "error", err)
+			}
+		}()
+		loops.Go(ctx, "elected_aggregator", n.runElectedAggregator)
+	case n.nodeConfig.Node.Aggregator:
 		n.Logger.Info("working in aggregator mode", "block time", n.nodeConfig.Node.BlockTime)
-		go n.blockManager.AggregationLoop(ctx)
-		go n.reaper.Start(ctx)
-		go n.blockManager.HeaderSubmissionLoop(ctx)
-		go n.blockManager.BatchSubmissionLoop(ctx)
-		go n.headerPublishLoop(ctx)
-		go n.dataPublishLoop(ctx)
-		go n.blockManager.DAIncluderLoop(ctx)
-	} else {
-		go n.blockManager.RetrieveLoop(ctx)
-		go n.blockManager.HeaderStoreRetrieveLoop(ctx)
-		go n.blockManager.DataStoreRetrieveLoop(ctx)
-		go n.blockManager.SyncLoop(ctx)
-		go n.blockManager.DAIncluderLoop(ctx)
+		n.startAggregatorLoops(ctx, loops)
+	default:
+		n.startSyncLoops(ctx, loops)
+	}
+	loops.Go(ctx, "da_includer", n.blockManager.DAIncluderLoop)
+	loops.Go(ctx, "da_audit", n.blockManager.DAAuditLoop)
+	loops.Go(ctx, "snapshot", n.blockManager.SnapshotLoop)
+	if n.fraudSvc != nil {
+		loops.Go(ctx, "fraud_proof_publish", n.fraudProofPublishLoop)
+	}
+	if n.txGossip != nil {
+		loops.Go(ctx, "tx_gossip", n.txGossipLoop)
+	}
+	loops.Go(ctx, "forced_inclusion_retrieve", n.blockManager.ForcedInclusionRetrieveLoop)
+	loops.Go(ctx, "da_block", n.blockManager.DABlockLoop)
+	loops.Go(ctx, "shadow_execution", n.blockManager.ShadowExecutionLoop)
+
+	if n.txIndexer != nil {
+		n.Logger.Info("transaction indexing enabled", "indexedHeight", n.txIndexer.IndexedHeight())
+		go func() {
+			if err := n.txIndexer.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				n.Logger.Error("tx indexer stopped", "error", err)
+			}
+		}()
+	}
+
+	if n.pruner != nil {
+		n.Logger.Info("block pruning enabled", "keepRecent", n.nodeConfig.Pruning.KeepRecent, "interval", n.nodeConfig.Pruning.Interval)
+		go func() {
+			if err := n.pruner.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				n.Logger.Error("pruner stopped", "error", err)
+			}
+		}()
+	}
+
+	if interval := n.nodeConfig.Pruning.CompactionInterval; interval.Duration > 0 {
+		n.Logger.Info("scheduled datastore compaction enabled", "interval", interval)
+		go func() {
+			if err := n.maintainer.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				n.Logger.Error("datastore maintainer stopped", "error", err)
+			}
+		}()
+	}
+
+	if instr := n.nodeConfig.Instrumentation; instr != nil && instr.ProfileCaptureInterval.Duration > 0 {
+		n.Logger.Info("debug bundle capture enabled", "dir", n.nodeConfig.DebugPath(), "interval", instr.ProfileCaptureInterval)
+		capturer := debug.NewCapturer(n.nodeConfig.DebugPath(), instr.ProfileCaptureInterval.Duration, instr.ProfileCaptureKeep,
+			logging.RecentOf(n.Logger), n.Logger)
+		go func() {
+			if err := capturer.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				n.Logger.Error("debug bundle capture stopped", "error", err)
+			}
+		}()
+	}
+
+	if n.nodeConfig.Telemetry.Enabled {
+		n.Logger.Info("telemetry reporting enabled", "endpoint", n.nodeConfig.Telemetry.Endpoint, "interval", n.nodeConfig.Telemetry.Interval)
+		go func() {
+			if err := n.telemetry.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				n.Logger.Error("telemetry reporter stopped", "error", err)
+			}
+		}()
 	}
 
 	// Block until context is canceled
@@ -417,6 +1001,7 @@ func (n *FullNode) Run(ctx context.Context) error {
 
 	// Perform cleanup
 	n.Logger.Info("halting full node...")
+	n.drainBlockManager(loops)
 	n.Logger.Info("shutting down full node sub services...")
 
 	// Use a timeout context to ensure shutdown doesn't hang
@@ -486,12 +1071,23 @@ func (n *FullNode) Run(ctx context.Context) error {
 		}
 	}
 
+	// Shutdown gRPC Server
+	if n.grpcServer != nil {
+		err = n.grpcServer.Shutdown(shutdownCtx)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			n.Logger.Error("error shutting down gRPC server", "error", err)
+			multiErr = errors.Join(multiErr, fmt.Errorf("shutting down gRPC server: %w", err))
+		}
+	}
+
 	// Ensure Store.Close is called last to maximize chance of data flushing
-	err = n.Store.Close()
-	if err != nil {
-		// Store.Close() might log internally, but log here too for context
-		n.Logger.Error("error closing store", "error", err)
-		multiErr = errors.Join(multiErr, fmt.Errorf("closing store: %w", err))
+	if !n.sharedStore {
+		err = n.Store.Close()
+		if err != nil {
+			// Store.Close() might log internally, but log here too for context
+			n.Logger.Error("error closing store", "error", err)
+			multiErr = errors.Join(multiErr, fmt.Errorf("closing store: %w", err))
+		}
 	}
 
 	// Log final status
@@ -509,6 +1105,154 @@ func (n *FullNode) Run(ctx context.Context) error {
 	return multiErr // Return shutdown errors if context was okay
 }
 
+// drainBlockManager waits for the block manager loops to return, letting in-flight DA submissions complete,
+// then submits the pending headers and batches and records a clean shutdown. Loops which do not stop before their
+// shutdown deadline are abandoned and the drain is skipped. If the drain does not complete within the shutdown
+// timeout, no clean shutdown is recorded and DA submissions are recovered on restart.
+func (n *FullNode) drainBlockManager(loops *block.Supervisor) {
+	if err := loops.Wait(); err != nil {
+		n.Logger.Error("block manager loops did not stop, skipping drain", "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.nodeConfig.Node.ShutdownTimeout.Duration)
+	defer cancel()
+
+	if n.nodeConfig.Node.Aggregator && (n.elector == nil || n.elector.IsLeader()) {
+		n.Logger.Info("draining pending DA submissions")
+		if err := n.blockManager.DrainDASubmissions(ctx); err != nil {
+			n.Logger.Error("failed to drain pending DA submissions", "error", err)
+			return
+		}
+	}
+	if err := n.blockManager.RecordCleanShutdown(ctx); err != nil {
+		n.Logger.Error("failed to record clean shutdown", "error", err)
+		return
+	}
+	n.Logger.Info("block manager drained")
+}
+
+// startAggregatorLoops starts the loops producing, publishing and submitting blocks.
+func (n *FullNode) startAggregatorLoops(ctx context.Context, loops *block.Supervisor) {
+	loops.Go(ctx, "produce_blocks", n.blockManager.Sequencing().ProduceBlocks)
+	if n.nodeConfig.Node.AsyncExecution {
+		loops.Go(ctx, "execution", n.blockManager.ExecutionLoop)
+	}
+	loops.Go(ctx, "reaper", n.reaper.Start)
+	loops.Go(ctx, "header_submission", n.blockManager.HeaderSubmissionLoop)
+	loops.Go(ctx, "batch_submission", n.blockManager.BatchSubmissionLoop)
+	loops.Go(ctx, "clock_check", n.blockManager.ClockCheckLoop)
+	loops.Go(ctx, "header_publish", n.headerPublishLoop)
+	loops.Go(ctx, "data_publish", n.dataPublishLoop)
+}
+
+// startSyncLoops starts the loops retrieving blocks from the DA layer and the p2p network and syncing them.
+func (n *FullNode) startSyncLoops(ctx context.Context, loops *block.Supervisor) {
+	loops.Go(ctx, "retrieve", n.blockManager.RetrieveLoop)
+	loops.Go(ctx, "backfill", n.blockManager.BackfillLoop)
+	// headers and block data received over p2p are ignored if retrieval from the DA layer is preferred
+	if !n.nodeConfig.DA.PreferRetrieval {
+		loops.Go(ctx, "header_store_retrieve", n.blockManager.HeaderStoreRetrieveLoop)
+		loops.Go(ctx, "data_store_retrieve", n.blockManager.DataStoreRetrieveLoop)
+		loops.Go(ctx, "data_fetch", n.blockManager.DataFetchLoop)
+	}
+	loops.Go(ctx, "sync", n.blockManager.SyncLoop)
+}
+
+// newSupervisor returns a supervisor enforcing the configured shutdown timeouts of the block manager loops.
+func (n *FullNode) newSupervisor() *block.Supervisor {
+	return block.NewSupervisor(n.nodeConfig.Node.LoopShutdownTimeout.Duration, n.loopShutdownTimeouts, n.Logger)
+}
+
+// runElectedAggregator produces blocks while this node is the elected leader, and syncs the blocks
+// of the active leader otherwise. Loops of the previous role are stopped before switching roles.
+func (n *FullNode) runElectedAggregator(ctx context.Context) {
+	var stop func()
+	start := func(startLoops func(context.Context, *block.Supervisor)) {
+		loopCtx, cancel := context.WithCancel(ctx)
+		loops := n.newSupervisor()
+		startLoops(loopCtx, loops)
+		stop = func() {
+			cancel()
+			if err := loops.Wait(); err != nil {
+				n.Logger.Error("loops of the previous role did not stop", "error", err)
+			}
+		}
+	}
+	start(n.startSyncLoops)
+
+	for {
+		select {
+		case <-ctx.Done():
+			stop()
+			return
+		case isLeader := <-n.elector.LeadershipCh():
+			if !isLeader {
+				n.Logger.Info("lost leadership, syncing blocks of the active leader")
+				stop()
+				start(n.startSyncLoops)
+				continue
+			}
+			n.Logger.Info("elected leader, syncing blocks of the previous leader")
+			if !n.waitForLeaderSync(ctx) {
+				continue
+			}
+			stop()
+			// blocks of the previous leader included in the DA layer must not be submitted again
+			n.blockManager.SyncLastSubmittedHeight(ctx)
+			n.Logger.Info("producing blocks as leader", "term", n.elector.Lease().Term)
+			start(n.startAggregatorLoops)
+		}
+	}
+}
+
+// waitForLeaderSync waits until all blocks received through header sync are synced, so that a newly
+// elected leader continues from the last block of the previous leader and never signs a height twice.
+// It returns false if the leadership is lost or the context is canceled in the meantime.
+func (n *FullNode) waitForLeaderSync(ctx context.Context) bool {
+	ticker := time.NewTicker(leaderSyncPollInterval)
+	defer ticker.Stop()
+	for n.elector.IsLeader() {
+		height, err := n.Store.Height(ctx)
+		if err == nil && height >= n.hSyncService.Store().Height() {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+	return false
+}
+
+// stateSync bootstraps a fresh node from a state snapshot fetched from peers. The snapshot is
+// verified against the header following the snapshot height, obtained through header sync.
+func (n *FullNode) stateSync(ctx context.Context) error {
+	height, err := n.Store.Height(ctx)
+	if err != nil {
+		return err
+	}
+	if height >= n.genesis.InitialHeight {
+		n.Logger.Info("store is not empty, skipping state sync", "height", height)
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, stateSyncTimeout)
+	defer cancel()
+
+	n.Logger.Info("starting state sync")
+	snap, err := n.snapshotSvc.Fetch(ctx, n.p2pClient.PeerIDs())
+	if err != nil {
+		return fmt.Errorf("failed to fetch snapshot: %w", err)
+	}
+	nextHeader, err := n.hSyncService.GetByHeight(ctx, snap.Height+1)
+	if err != nil {
+		return fmt.Errorf("failed to get header at height %d: %w", snap.Height+1, err)
+	}
+	return n.blockManager.RestoreSnapshot(ctx, snap, nextHeader)
+}
+
 // GetGenesis returns entire genesis doc.
 func (n *FullNode) GetGenesis() genesispkg.Genesis {
 	return n.genesis
@@ -528,6 +1272,24 @@ func (n *FullNode) IsRunning() bool {
 	return n.blockManager != nil
 }
 
+// Status returns the sync progress of the node.
+func (n *FullNode) Status(ctx context.Context) (types.NodeStatus, error) {
+	status, err := n.blockManager.Status(ctx)
+	if err != nil {
+		return types.NodeStatus{}, err
+	}
+	status.Peers = len(n.p2pClient.PeerIDs())
+	status.P2PListening = p2pListening(n.p2pClient)
+	status.CatchingUp = status.CatchingUp || n.hSyncService.IsSyncing()
+	return status, nil
+}
+
+// Subscribe returns a channel receiving the events of the block manager and a function cancelling the
+// subscription.
+func (n *FullNode) Subscribe(buffer int) (<-chan block.Event, func()) {
+	return n.blockManager.Subscribe(buffer)
+}
+
 // SetLogger sets the logger used by node.
 func (n *FullNode) SetLogger(logger log.Logger) {
 	n.Logger = logger
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -149,6 +150,30 @@ func (s *FullNodeTestSuite) TestBlockProduction() {
 	s.NotEmpty(data.Txs, "Expected block to contain transactions")
 }
 
+// TestSubmitTx tests that transactions submitted to the mempool are included in a block and removed from it
+func (s *FullNodeTestSuite) TestSubmitTx() {
+	require := require.New(s.T())
+	tx := []byte("submitted transaction")
+	hash, err := s.node.SubmitTx(s.ctx, tx)
+	require.NoError(err)
+
+	require.Eventually(func() bool {
+		return !s.node.mempool.Has(hash)
+	}, 5*time.Second, 50*time.Millisecond, "transaction was not removed from the mempool")
+
+	height, err := s.node.Store.Height(s.ctx)
+	require.NoError(err)
+	included := false
+	for h := uint64(1); h <= height && !included; h++ {
+		_, data, err := s.node.Store.GetBlockData(s.ctx, h)
+		require.NoError(err)
+		for _, blockTx := range data.Txs {
+			included = included || bytes.Equal(blockTx, tx)
+		}
+	}
+	s.True(included, "transaction was not included in a block")
+}
+
 // TestSubmitBlocksToDA tests the submission of blocks to the DA
 func (s *FullNodeTestSuite) TestSubmitBlocksToDA() {
 	s.executor.InjectTx([]byte("test transaction"))
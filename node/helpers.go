@@ -8,7 +8,9 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/rollkit/rollkit/pkg/keystore"
 	"github.com/rollkit/rollkit/pkg/p2p/key"
+	"github.com/rollkit/rollkit/pkg/retry"
 )
 
 // Source is an enum representing different sources of height
@@ -124,7 +126,7 @@ func waitForAtLeastNDAIncludedHeight(node Node, n uint64) error {
 
 // Retry attempts to execute the provided function up to the specified number of tries,
 // with a delay between attempts. It returns nil if the function succeeds, or the last
-// error encountered if all attempts fail.
+// error encountered if all attempts fail. Use retry.Policy for exponential backoff.
 //
 // Parameters:
 //   - tries: The maximum number of attempts to make
@@ -133,19 +135,19 @@ func waitForAtLeastNDAIncludedHeight(node Node, n uint64) error {
 //
 // Returns:
 //   - error: nil if the function succeeds, or the last error encountered
-func Retry(tries int, durationBetweenAttempts time.Duration, fn func() error) (err error) {
-	for i := 1; i <= tries-1; i++ {
-		err = fn()
-		if err == nil {
-			return nil
-		}
-		time.Sleep(durationBetweenAttempts)
+func Retry(tries int, durationBetweenAttempts time.Duration, fn func() error) error {
+	policy := retry.Policy{
+		InitialBackoff: durationBetweenAttempts,
+		Multiplier:     1,
+		MaxAttempts:    max(tries, 1),
 	}
-	return fn()
+	return policy.Do(context.Background(), func(context.Context) error { return fn() })
 }
 
 // InitFiles initializes the files for the node.
-// It creates a configuration directory and generates a node key.
+// It creates a configuration directory and generates a node key, loading the preshared key of the private
+// network from the swarm.key file of the configuration directory if any. The node key is encrypted with the
+// passphrase set in the ROLLKIT_NODE_KEY_PASSPHRASE environment variable, if any.
 // It returns the generated node key and an error if any occurs during the process.
 func InitFiles(dir string) (*key.NodeKey, error) {
 	// Create config directory
@@ -156,7 +158,7 @@ func InitFiles(dir string) (*key.NodeKey, error) {
 	}
 
 	// create the nodekey file
-	nodeKey, err := key.LoadOrGenNodeKey(configDir)
+	nodeKey, err := key.LoadOrGenNodeKeyWithPassphrase(configDir, keystore.Passphrase("", keystore.EnvNodeKeyPassphrase))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create node key: %w", err)
 	}
@@ -5,19 +5,28 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
+	gosync "sync"
 	"time"
 
 	"cosmossdk.io/log"
 	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
 
+	"github.com/rollkit/rollkit/block"
+	coreda "github.com/rollkit/rollkit/core/da"
 	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/debug"
 	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/logging"
 	"github.com/rollkit/rollkit/pkg/p2p"
 	"github.com/rollkit/rollkit/pkg/p2p/key"
 	rpcserver "github.com/rollkit/rollkit/pkg/rpc/server"
 	"github.com/rollkit/rollkit/pkg/service"
 	"github.com/rollkit/rollkit/pkg/store"
 	"github.com/rollkit/rollkit/pkg/sync"
+	"github.com/rollkit/rollkit/pkg/telemetry"
+	"github.com/rollkit/rollkit/types"
 )
 
 var _ Node = &LightNode{}
@@ -29,42 +38,233 @@ type LightNode struct {
 	P2P *p2p.Client
 
 	hSyncService *sync.HeaderSyncService
-	Store        store.Store
-	rpcServer    *http.Server
-	nodeConfig   config.Config
+	// daVerifier verifies the headers received over p2p against the DA layer, nil if disabled
+	daVerifier *sync.DAVerifier
+	// daSampler samples the DA blocks holding the headers verified by daVerifier, nil if disabled
+	daSampler  *sync.DASampler
+	Store      store.Store
+	maintainer *store.Maintainer
+	// telemetry reports anonymized metrics of the node if telemetry is enabled
+	telemetry  *telemetry.Reporter
+	rpcServer  *http.Server
+	grpcServer *http.Server
+	nodeConfig config.Config
+	// runtimeConf serves the configuration with the runtime parameters changed while the node is running
+	runtimeConf *runtimeConfig
+	// chainID labels the metrics of the node
+	chainID string
+	// headerRanges serves verified ranges of the headers received over p2p
+	headerRanges *sync.HeaderRangeVerifier
+	// modes switches the node to full mode, nil unless the node is run by a DualModeNode
+	modes rpcserver.ModeSwitcher
+	// sharedStore is set if the store is closed by a DualModeNode instead of the node
+	sharedStore bool
 }
 
 func newLightNode(
+	ctx context.Context,
 	conf config.Config,
 	genesis genesis.Genesis,
 	p2pClient *p2p.Client,
 	nodeKey key.NodeKey,
 	database ds.Batching,
+	da coreda.DA,
 	logger log.Logger,
+	opts options,
 ) (ln *LightNode, err error) {
-	headerSyncService, err := sync.NewHeaderSyncService(database, conf, genesis, p2pClient, logger.With("module", "HeaderSyncService"))
+	// light and full nodes share the store layout, so that a light node can be upgraded to a full node in place
+	if err := migrateLightNodeStore(ctx, database, logger); err != nil {
+		return nil, err
+	}
+	p2pClient.SetGenesisFingerprint(genesis.Fingerprint())
+	mainKV := newPrefixKV(database, RollkitPrefix)
+	if err := store.Migrate(ctx, mainKV, logger); err != nil {
+		return nil, err
+	}
+	headerSyncService, err := sync.NewHeaderSyncService(mainKV, conf, genesis, p2pClient, logger.With("module", logging.ModuleSync))
 	if err != nil {
 		return nil, fmt.Errorf("error while initializing HeaderSyncService: %w", err)
 	}
+	// light nodes only serve their verified headers to peers as header relays, within limits
+	headerSyncService.SetServing(conf.Node.LightServeHeaders, sync.ServeLimits{
+		Requests:        conf.Node.LightServeRequests,
+		RequestsPerPeer: conf.Node.LightServePeerRequests,
+	})
+
+	if _, err := newDACommittee(conf.DA); err != nil {
+		return nil, err
+	}
+
+	maintainer := store.NewMaintainer(database, conf.Pruning.CompactionInterval.Duration, logger.With("module", logging.ModulePruner))
+	store := store.New(mainKV)
+
+	var daVerifier *sync.DAVerifier
+	if conf.Node.LightDAVerification {
+		if da == nil {
+			return nil, errors.New("DA client is required for light node DA verification")
+		}
+		// only the namespace of headers is retrieved
+		headerDA, err := namespacedDA(da, conf.DA.HeaderNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("error while initializing header namespace: %w", err)
+		}
+		daVerifier, err = sync.NewDAVerifier(
+			ctx,
+			headerDA,
+			headerSyncService,
+			store,
+			genesis.ProposerAddress,
+			conf.DA.StartHeight,
+			conf.DA.BlockTime.Duration,
+			logger.With("module", logging.ModuleDAVerifier),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error while initializing DAVerifier: %w", err)
+		}
+	}
 
-	store := store.New(database)
+	var daSampler *sync.DASampler
+	if conf.Node.LightDASamples > 0 {
+		if daVerifier == nil {
+			return nil, errors.New("data availability sampling requires light node DA verification")
+		}
+		sampler, ok := coreda.Implements[coreda.Sampler](da)
+		if !ok {
+			return nil, errors.New("DA client does not support data availability sampling")
+		}
+		daSampler, err = sync.NewDASampler(
+			sampler,
+			store,
+			conf.Node.LightDASamples,
+			conf.DA.BlockTime.Duration,
+			logger.With("module", logging.ModuleDASampler),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error while initializing DASampler: %w", err)
+		}
+		daVerifier.SetSampler(daSampler)
+	}
 
 	node := &LightNode{
 		P2P:          p2pClient,
 		hSyncService: headerSyncService,
+		daVerifier:   daVerifier,
+		daSampler:    daSampler,
+		headerRanges: sync.NewHeaderRangeVerifier(headerSyncService, genesis),
 		Store:        store,
+		maintainer:   maintainer,
 		nodeConfig:   conf,
+		chainID:      genesis.ChainID,
+	}
+
+	node.telemetry, err = telemetry.NewReporter(conf.Telemetry, genesis.ChainID, opts.version, node, store, logger.With("module", logging.ModuleTelemetry))
+	if err != nil {
+		return nil, err
 	}
 
 	node.BaseService = *service.NewBaseService(logger, "LightNode", node)
+	node.runtimeConf = newRuntimeConfig(conf, node.applyRuntimeConfig, logger)
 
 	return node, nil
 }
 
+// legacyLightNodePrefixes are the key prefixes of the header store and the store metadata of light nodes created
+// before light nodes stored their data under RollkitPrefix like full nodes.
+var legacyLightNodePrefixes = []string{"/headerSync", "/m"}
+
+// migrateLightNodeStore moves the data of a light node created before light and full nodes shared the store
+// layout under RollkitPrefix. Data is only moved if the header store under RollkitPrefix is empty, so the data of
+// full nodes and of migrated light nodes is left untouched.
+func migrateLightNodeStore(ctx context.Context, database ds.Batching, logger log.Logger) error {
+	prefix := ds.NewKey(RollkitPrefix)
+	migrated, err := hasKeys(ctx, database, prefix.Child(ds.NewKey(legacyLightNodePrefixes[0])).String())
+	if err != nil || migrated {
+		return err
+	}
+	legacy, err := hasKeys(ctx, database, legacyLightNodePrefixes[0])
+	if err != nil || !legacy {
+		return err
+	}
+
+	batch, err := database.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to migrate light node store: %w", err)
+	}
+	moved := 0
+	for _, legacyPrefix := range legacyLightNodePrefixes {
+		results, err := database.Query(ctx, dsq.Query{Prefix: legacyPrefix})
+		if err != nil {
+			return fmt.Errorf("failed to migrate light node store: %w", err)
+		}
+		entries, err := results.Rest()
+		if err != nil {
+			return fmt.Errorf("failed to migrate light node store: %w", err)
+		}
+		for _, entry := range entries {
+			key := ds.NewKey(entry.Key)
+			if err := batch.Put(ctx, prefix.Child(key), entry.Value); err != nil {
+				return fmt.Errorf("failed to migrate light node store: %w", err)
+			}
+			if err := batch.Delete(ctx, key); err != nil {
+				return fmt.Errorf("failed to migrate light node store: %w", err)
+			}
+			moved++
+		}
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to migrate light node store: %w", err)
+	}
+	logger.Info("migrated light node store to the layout shared with full nodes", "keys", moved)
+	return nil
+}
+
+// hasKeys reports whether the datastore has keys under the given prefix.
+func hasKeys(ctx context.Context, database ds.Datastore, prefix string) (bool, error) {
+	results, err := database.Query(ctx, dsq.Query{Prefix: prefix, KeysOnly: true, Limit: 1})
+	if err != nil {
+		return false, fmt.Errorf("failed to query datastore: %w", err)
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return false, fmt.Errorf("failed to query datastore: %w", err)
+	}
+	return len(entries) > 0, nil
+}
+
+// applyRuntimeConfig applies the changed peer limit to the P2P client. Light nodes neither produce nor prune
+// blocks, so the other runtime parameters cannot be changed.
+func (ln *LightNode) applyRuntimeConfig(old, updated config.Config) error {
+	unchanged := updated
+	unchanged.P2P.MaxPeers = old.P2P.MaxPeers
+	if !reflect.DeepEqual(old, unchanged) {
+		return fmt.Errorf("%w: only the peer limit can be changed on light nodes", config.ErrInvalidRuntimeConfig)
+	}
+	ln.P2P.SetMaxPeers(updated.P2P.MaxPeers)
+	return nil
+}
+
 // OnStart starts the P2P and HeaderSync services
 func (ln *LightNode) OnStart(ctx context.Context) error {
 	// Start RPC server
-	handler, err := rpcserver.NewServiceHandler(ln.Store, ln.P2P)
+	status := rpcserver.StatusSources{Node: ln, HeaderRanges: ln.headerRanges}
+	if ln.daVerifier != nil {
+		status.DAVerification = ln.daVerifier
+	}
+	admin := rpcserver.AdminSources{
+		Levels:     logging.LevelsOf(ln.Logger),
+		Logs:       logging.RecentOf(ln.Logger),
+		Maintainer: ln.maintainer,
+		Config:     ln.runtimeConf,
+		Modes:      ln.modes,
+		Token:      ln.nodeConfig.RPC.AdminToken,
+	}
+	security, err := newRPCSecurity(ln.nodeConfig.RPC)
+	if err != nil {
+		return fmt.Errorf("error loading RPC credentials: %w", err)
+	}
+	security.watch(ctx, ln.Logger)
+	opts := rpcHandlerOptions(ln.nodeConfig, ln.chainID, security.auth)
+	handler, err := rpcserver.NewServiceHandler(ln.Store, nil, ln.P2P, status, nil, rpcserver.TxSources{}, admin, opts...)
 	if err != nil {
 		return fmt.Errorf("error creating RPC handler: %w", err)
 	}
@@ -72,19 +272,40 @@ func (ln *LightNode) OnStart(ctx context.Context) error {
 	ln.rpcServer = &http.Server{
 		Addr:         ln.nodeConfig.RPC.Address,
 		Handler:      handler,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
+		ReadTimeout:  ln.nodeConfig.RPC.Timeout.Duration,
+		WriteTimeout: ln.nodeConfig.RPC.Timeout.Duration,
 		IdleTimeout:  120 * time.Second,
 	}
 
 	go func() {
-		if err := ln.rpcServer.ListenAndServe(); err != http.ErrServerClosed {
+		if err := security.listenAndServe(ln.rpcServer); err != http.ErrServerClosed {
 			ln.Logger.Error("RPC server error", "err", err)
 		}
 	}()
 
 	ln.Logger.Info("Started RPC server", "addr", ln.nodeConfig.RPC.Address)
 
+	if ln.nodeConfig.RPC.GRPCAddress != "" {
+		grpcHandler, err := rpcserver.NewGRPCHandler(ln.Store, nil, ln.P2P, status, nil, rpcserver.TxSources{}, admin, opts...)
+		if err != nil {
+			return fmt.Errorf("error creating gRPC handler: %w", err)
+		}
+		ln.grpcServer = &http.Server{
+			Addr:              ln.nodeConfig.RPC.GRPCAddress,
+			Handler:           grpcHandler,
+			ReadHeaderTimeout: ln.nodeConfig.RPC.Timeout.Duration,
+			IdleTimeout:       120 * time.Second,
+		}
+
+		go func() {
+			if err := security.listenAndServe(ln.grpcServer); err != http.ErrServerClosed {
+				ln.Logger.Error("gRPC server error", "err", err)
+			}
+		}()
+
+		ln.Logger.Info("Started gRPC server", "addr", ln.nodeConfig.RPC.GRPCAddress)
+	}
+
 	if err := ln.P2P.Start(ctx); err != nil {
 		return err
 	}
@@ -93,9 +314,66 @@ func (ln *LightNode) OnStart(ctx context.Context) error {
 		return fmt.Errorf("error while starting header sync service: %w", err)
 	}
 
+	if ln.daVerifier != nil {
+		ln.Logger.Info("verifying headers against DA", "daHeight", ln.daVerifier.Status().DAHeight)
+		go func() {
+			if err := ln.daVerifier.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				ln.Logger.Error("DA verifier stopped", "error", err)
+			}
+		}()
+	}
+
+	if ln.daSampler != nil {
+		ln.Logger.Info("sampling DA blocks holding headers", "samples", ln.nodeConfig.Node.LightDASamples)
+		go func() {
+			if err := ln.daSampler.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				ln.Logger.Error("DA sampler stopped", "error", err)
+			}
+		}()
+	}
+
+	if interval := ln.nodeConfig.Pruning.CompactionInterval; interval.Duration > 0 {
+		ln.Logger.Info("scheduled datastore compaction enabled", "interval", interval)
+		go func() {
+			if err := ln.maintainer.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				ln.Logger.Error("datastore maintainer stopped", "error", err)
+			}
+		}()
+	}
+
+	if instr := ln.nodeConfig.Instrumentation; instr != nil && instr.ProfileCaptureInterval.Duration > 0 {
+		ln.Logger.Info("debug bundle capture enabled", "dir", ln.nodeConfig.DebugPath(), "interval", instr.ProfileCaptureInterval)
+		capturer := debug.NewCapturer(ln.nodeConfig.DebugPath(), instr.ProfileCaptureInterval.Duration, instr.ProfileCaptureKeep,
+			logging.RecentOf(ln.Logger), ln.Logger)
+		go func() {
+			if err := capturer.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				ln.Logger.Error("debug bundle capture stopped", "error", err)
+			}
+		}()
+	}
+
+	if ln.nodeConfig.Telemetry.Enabled {
+		ln.Logger.Info("telemetry reporting enabled", "endpoint", ln.nodeConfig.Telemetry.Endpoint, "interval", ln.nodeConfig.Telemetry.Interval)
+		go func() {
+			if err := ln.telemetry.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+				ln.Logger.Error("telemetry reporter stopped", "error", err)
+			}
+		}()
+	}
+
 	return nil
 }
 
+// Run starts the light node and blocks until the context is canceled, then stops it.
+func (ln *LightNode) Run(ctx context.Context) error {
+	if err := ln.OnStart(ctx); err != nil {
+		return err
+	}
+	<-ctx.Done()
+	ln.OnStop(ctx)
+	return ctx.Err()
+}
+
 // OnStop stops the light node
 func (ln *LightNode) OnStop(ctx context.Context) {
 	ln.Logger.Info("halting light node...")
@@ -110,12 +388,45 @@ func (ln *LightNode) OnStop(ctx context.Context) {
 	if ln.rpcServer != nil {
 		err = errors.Join(err, ln.rpcServer.Shutdown(shutdownCtx))
 	}
+	if ln.grpcServer != nil {
+		err = errors.Join(err, ln.grpcServer.Shutdown(shutdownCtx))
+	}
 
-	err = errors.Join(err, ln.Store.Close())
-	ln.Logger.Error("errors while stopping node:", "errors", err)
+	if !ln.sharedStore {
+		err = errors.Join(err, ln.Store.Close())
+	}
+	if err != nil {
+		ln.Logger.Error("errors while stopping node:", "errors", err)
+	}
 }
 
 // IsRunning returns true if the node is running.
 func (ln *LightNode) IsRunning() bool {
 	return ln.P2P != nil && ln.hSyncService != nil
 }
+
+// Status returns the sync progress of the node. The DA heights are reported if headers are verified against the
+// DA layer.
+func (ln *LightNode) Status(ctx context.Context) (types.NodeStatus, error) {
+	status := types.NodeStatus{
+		Mode:         types.NodeModeLight,
+		Height:       ln.hSyncService.Store().Height(),
+		Peers:        len(ln.P2P.PeerIDs()),
+		P2PListening: p2pListening(ln.P2P),
+		CatchingUp:   ln.hSyncService.IsSyncing(),
+	}
+	if ln.daVerifier != nil {
+		verification := ln.daVerifier.Status()
+		status.DAIncludedHeight = verification.VerifiedHeight
+		status.DAHeight = verification.DAHeight
+	}
+	return status, nil
+}
+
+// Subscribe implements Node. Light nodes neither produce nor apply blocks, so no events are published; the
+// returned channel is closed when the subscription is cancelled.
+func (ln *LightNode) Subscribe(int) (<-chan block.Event, func()) {
+	ch := make(chan block.Event)
+	var once gosync.Once
+	return ch, func() { once.Do(func() { close(ch) }) }
+}
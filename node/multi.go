@@ -0,0 +1,252 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	gosync "sync"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/p2p"
+	"github.com/rollkit/rollkit/pkg/p2p/key"
+	"github.com/rollkit/rollkit/pkg/signer"
+)
+
+var (
+	// ErrChainHostNotStarted is returned when starting a chain on a ChainHost which is not started.
+	ErrChainHostNotStarted = errors.New("chain host not started")
+	// ErrChainExists is returned when starting a chain already hosted by a ChainHost.
+	ErrChainExists = errors.New("chain already hosted")
+	// ErrChainNotFound is returned when stopping a chain not hosted by a ChainHost.
+	ErrChainNotFound = errors.New("chain not hosted")
+)
+
+// ChainSpec holds the components of a chain hosted by a ChainHost.
+type ChainSpec struct {
+	// Config is the configuration of the node of the chain. The chain ID is the chain ID of the genesis. The root
+	// directory, the RPC address and the DA namespaces must differ from the other hosted chains.
+	Config    config.Config
+	Genesis   genesis.Genesis
+	Executor  coreexecutor.Executor
+	Sequencer coresequencer.Sequencer
+	// Signer signs the blocks of the chain, nil unless the node is an aggregator.
+	Signer  signer.Signer
+	Options []Option
+}
+
+// ChainHost hosts multiple chains in a single process, for providers running many small chains. Each chain runs
+// its own node, with its own store, block manager and DA namespaces, while all chains share the p2p host and the
+// connection to the DA layer. Chains are started and stopped independently while the host runs.
+type ChainHost struct {
+	p2p             *p2p.Client
+	da              coreda.DA
+	database        ds.Batching
+	nodeKey         key.NodeKey
+	metricsProvider MetricsProvider
+	logger          log.Logger
+
+	mu     gosync.Mutex
+	ctx    context.Context
+	chains map[string]*hostedChain
+	// starting holds the IDs of the chains whose node is being created
+	starting map[string]struct{}
+}
+
+// hostedChain is a chain running on a ChainHost.
+type hostedChain struct {
+	node   Node
+	cancel context.CancelFunc
+	// done is closed once the node stopped, err holds the error the node stopped with
+	done chan struct{}
+	err  error
+}
+
+// NewChainHost creates a ChainHost sharing the p2p client, the DA client and the database among the hosted
+// chains. The p2p client owns the host the chains join, and da must support multiple namespaces if the chains
+// set their DA namespaces. The data of each chain is stored under its own prefix of the database.
+//
+// The metrics of all chains are provided by metricsProvider: the Prometheus and pprof servers of the nodes are not
+// supported on hosted chains, as every node would serve them on its own address.
+func NewChainHost(
+	p2pClient *p2p.Client,
+	da coreda.DA,
+	nodeKey key.NodeKey,
+	database ds.Batching,
+	metricsProvider MetricsProvider,
+	logger log.Logger,
+) *ChainHost {
+	return &ChainHost{
+		p2p:             p2pClient,
+		da:              da,
+		database:        database,
+		nodeKey:         nodeKey,
+		metricsProvider: metricsProvider,
+		logger:          logger,
+		chains:          make(map[string]*hostedChain),
+		starting:        make(map[string]struct{}),
+	}
+}
+
+// Start starts the shared p2p client. The hosted chains run until they are stopped, or until the context is
+// canceled.
+func (h *ChainHost) Start(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.ctx != nil {
+		return errors.New("chain host already started")
+	}
+	if err := h.p2p.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start p2p client: %w", err)
+	}
+	h.ctx = ctx
+	return nil
+}
+
+// StartChain creates the node of the chain and runs it in the background.
+func (h *ChainHost) StartChain(spec ChainSpec) error {
+	chainID := spec.Genesis.ChainID
+	if chainID == "" {
+		return errors.New("chain ID is required")
+	}
+	if spec.Config.Instrumentation != nil && (spec.Config.Instrumentation.Prometheus || spec.Config.Instrumentation.Pprof) {
+		return fmt.Errorf("chain %s: instrumentation servers are not supported on hosted chains", chainID)
+	}
+
+	// the chain ID is reserved while the node is created, without holding the lock, so that creating a node
+	// does not block the other chains
+	h.mu.Lock()
+	hostCtx := h.ctx
+	if hostCtx == nil {
+		h.mu.Unlock()
+		return ErrChainHostNotStarted
+	}
+	_, exists := h.chains[chainID]
+	_, starting := h.starting[chainID]
+	if exists || starting {
+		h.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrChainExists, chainID)
+	}
+	h.starting[chainID] = struct{}{}
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.starting, chainID)
+		h.mu.Unlock()
+	}()
+
+	da, err := namespacedDA(h.da, spec.Config.DA.Namespace)
+	if err != nil {
+		return fmt.Errorf("chain %s: %w", chainID, err)
+	}
+	conf := spec.Config
+	conf.ChainID = chainID
+	logger := h.logger.With("chain_id", chainID)
+	ctx, cancel := context.WithCancel(hostCtx)
+	node, err := NewNode(
+		ctx,
+		conf,
+		spec.Executor,
+		spec.Sequencer,
+		da,
+		spec.Signer,
+		h.nodeKey,
+		h.p2p.ForChain(chainID, logger),
+		spec.Genesis,
+		newPrefixKV(h.database, "/chains/"+chainID),
+		h.metricsProvider,
+		logger,
+		spec.Options...,
+	)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to create node of chain %s: %w", chainID, err)
+	}
+
+	chain := &hostedChain{node: node, cancel: cancel, done: make(chan struct{})}
+	h.mu.Lock()
+	if h.ctx != hostCtx {
+		// the host was stopped while the node was created
+		h.mu.Unlock()
+		cancel()
+		return ErrChainHostNotStarted
+	}
+	h.chains[chainID] = chain
+	h.mu.Unlock()
+	go func() {
+		defer close(chain.done)
+		chain.err = node.Run(ctx)
+		if chain.err != nil && !errors.Is(chain.err, context.Canceled) {
+			logger.Error("hosted chain stopped", "error", chain.err)
+		}
+	}()
+	logger.Info("started hosted chain")
+	return nil
+}
+
+// StopChain stops the node of the chain and waits until it is stopped. The data of the chain is kept, so that the
+// chain resumes where it stopped when started again.
+func (h *ChainHost) StopChain(chainID string) error {
+	h.mu.Lock()
+	chain, ok := h.chains[chainID]
+	delete(h.chains, chainID)
+	h.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrChainNotFound, chainID)
+	}
+	chain.cancel()
+	<-chain.done
+	h.logger.Info("stopped hosted chain", "chain_id", chainID)
+	if chain.err != nil && !errors.Is(chain.err, context.Canceled) {
+		return chain.err
+	}
+	return nil
+}
+
+// Chain returns the node of the hosted chain, false if the chain is not hosted.
+func (h *ChainHost) Chain(chainID string) (Node, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	chain, ok := h.chains[chainID]
+	if !ok {
+		return nil, false
+	}
+	return chain.node, true
+}
+
+// ChainIDs returns the IDs of the hosted chains, sorted.
+func (h *ChainHost) ChainIDs() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ids := make([]string, 0, len(h.chains))
+	for id := range h.chains {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// Stop stops all hosted chains and closes the shared p2p client.
+func (h *ChainHost) Stop() error {
+	var err error
+	for _, chainID := range h.ChainIDs() {
+		if stopErr := h.StopChain(chainID); stopErr != nil {
+			err = errors.Join(err, fmt.Errorf("chain %s: %w", chainID, stopErr))
+		}
+	}
+	h.mu.Lock()
+	started := h.ctx != nil
+	h.ctx = nil
+	h.mu.Unlock()
+	if started {
+		err = errors.Join(err, h.p2p.Close())
+	}
+	return err
+}
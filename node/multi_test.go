@@ -0,0 +1,94 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	rollkitconfig "github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/p2p"
+	remote_signer "github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/types"
+)
+
+// TestChainHost verifies that chains hosted in one process produce blocks independently, and are started and
+// stopped without affecting the other chains.
+func TestChainHost(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	hostConf := getTestConfig(t, 7)
+	nodeKey, err := InitFiles(hostConf.RootDir)
+	require.NoError(t, err)
+	p2pClient, err := p2p.NewClient(hostConf, nodeKey, dssync.MutexWrap(datastore.NewMapDatastore()), log.NewNopLogger(), p2p.NopMetrics())
+	require.NoError(t, err)
+	host := NewChainHost(p2pClient, coreda.NewDummyDA(100_000, 0, 0), *nodeKey, dssync.MutexWrap(datastore.NewMapDatastore()),
+		DefaultMetricsProvider(rollkitconfig.DefaultInstrumentationConfig()), log.NewTestLogger(t))
+
+	spec := func(n int) ChainSpec {
+		conf := getTestConfig(t, n)
+		conf.DA.Namespace = ""
+		conf.RPC.Address = fmt.Sprintf("127.0.0.1:%d", 41000+n)
+		genesis, validatorKey, _ := types.GetGenesisWithPrivkey(fmt.Sprintf("hosted-%d", n))
+		signer, err := remote_signer.NewNoopSigner(validatorKey)
+		require.NoError(t, err)
+		return ChainSpec{
+			Config:    conf,
+			Genesis:   genesis,
+			Executor:  coreexecutor.NewDummyExecutor(),
+			Sequencer: coresequencer.NewDummySequencer(),
+			Signer:    signer,
+		}
+	}
+
+	// the executor of a chain holds its state, so it is kept when the chain is started again
+	first := spec(1)
+	assert.ErrorIs(t, host.StartChain(first), ErrChainHostNotStarted)
+	require.NoError(t, host.Start(ctx))
+	defer func() { require.NoError(t, host.Stop()) }()
+
+	require.NoError(t, host.StartChain(first))
+	require.NoError(t, host.StartChain(spec(2)))
+	assert.ErrorIs(t, host.StartChain(first), ErrChainExists)
+	withPrometheus := spec(3)
+	withPrometheus.Config.Instrumentation = &rollkitconfig.InstrumentationConfig{Prometheus: true}
+	assert.Error(t, host.StartChain(withPrometheus))
+	assert.Equal(t, []string{"hosted-1", "hosted-2"}, host.ChainIDs())
+
+	height := func(chainID string) uint64 {
+		node, ok := host.Chain(chainID)
+		require.True(t, ok)
+		status, err := node.Status(ctx)
+		require.NoError(t, err)
+		return status.Height
+	}
+	require.Eventually(t, func() bool {
+		return height("hosted-1") >= 2 && height("hosted-2") >= 2
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// stopping a chain leaves the other chains running
+	stoppedAt := height("hosted-1")
+	require.NoError(t, host.StopChain("hosted-1"))
+	assert.ErrorIs(t, host.StopChain("hosted-1"), ErrChainNotFound)
+	assert.Equal(t, []string{"hosted-2"}, host.ChainIDs())
+	runningAt := height("hosted-2")
+	require.Eventually(t, func() bool {
+		return height("hosted-2") > runningAt
+	}, 10*time.Second, 50*time.Millisecond)
+
+	// a stopped chain resumes from its store when started again
+	require.NoError(t, host.StartChain(first))
+	require.Eventually(t, func() bool {
+		return height("hosted-1") > stoppedAt
+	}, 10*time.Second, 50*time.Millisecond)
+}
@@ -6,6 +6,7 @@ import (
 	"cosmossdk.io/log"
 	ds "github.com/ipfs/go-datastore"
 
+	"github.com/rollkit/rollkit/block"
 	coreda "github.com/rollkit/rollkit/core/da"
 	coreexecutor "github.com/rollkit/rollkit/core/execution"
 	coresequencer "github.com/rollkit/rollkit/core/sequencer"
@@ -15,6 +16,7 @@ import (
 	"github.com/rollkit/rollkit/pkg/p2p/key"
 	"github.com/rollkit/rollkit/pkg/service"
 	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/types"
 )
 
 // Node is the interface for a rollup node
@@ -22,11 +24,21 @@ type Node interface {
 	service.Service
 
 	IsRunning() bool
+
+	// Status returns the sync progress of the node.
+	Status(ctx context.Context) (types.NodeStatus, error)
+
+	// Subscribe returns a channel receiving the events of the node, such as produced blocks, blob submissions
+	// and DA inclusion, and a function cancelling the subscription. Up to buffer events are buffered; a
+	// subscriber falling further behind is dropped and its channel closed.
+	Subscribe(buffer int) (<-chan block.Event, func())
 }
 
-// NewNode returns a new Full or Light Node based on the config
+// NewNode returns a new Full or Light Node based on the config, or a DualModeNode switching between both modes
+// if dual mode is enabled
 // This is the entry point for composing a node, when compiling a node, you need to provide an executor
 // Example executors can be found in rollups/
+// Optional components, such as a shadow executor, are set with opts.
 func NewNode(
 	ctx context.Context,
 	conf config.Config,
@@ -40,9 +52,13 @@ func NewNode(
 	database ds.Batching,
 	metricsProvider MetricsProvider,
 	logger log.Logger,
+	opts ...Option,
 ) (Node, error) {
+	if conf.Node.DualMode {
+		return newDualModeNode(ctx, conf, exec, sequencer, da, signer, nodeKey, p2pClient, genesis, database, metricsProvider, logger, newOptions(opts))
+	}
 	if conf.Node.Light {
-		return newLightNode(conf, genesis, p2pClient, nodeKey, database, logger)
+		return newLightNode(ctx, conf, genesis, p2pClient, nodeKey, database, da, logger, newOptions(opts))
 	}
 
 	return newFullNode(
@@ -58,5 +74,6 @@ func NewNode(
 		da,
 		metricsProvider,
 		logger,
+		newOptions(opts),
 	)
 }
@@ -0,0 +1,38 @@
+package node
+
+import (
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+)
+
+// Option configures optional components of a node.
+type Option func(*options)
+
+type options struct {
+	shadowExec coreexecutor.Executor
+	version    string
+}
+
+// WithShadowExecutor sets a shadow executor on full nodes, e.g. a new version of the execution client. The shadow
+// executor re-executes the blocks executed by the node, trailing it by Node.ShadowExecutionDelay blocks, and state
+// roots differing from the executor of the node are reported without affecting the node. The shadow executor
+// must hold its own state, separate from the executor of the node.
+func WithShadowExecutor(exec coreexecutor.Executor) Option {
+	return func(o *options) {
+		o.shadowExec = exec
+	}
+}
+
+// WithVersion sets the version of the binary running the node, reported in telemetry reports.
+func WithVersion(version string) Option {
+	return func(o *options) {
+		o.version = version
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -0,0 +1,37 @@
+package node
+
+import (
+	"cmp"
+	"fmt"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/block"
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+)
+
+// NewStoreReplaySource returns a source replaying the blocks persisted in the database of a stopped node. See
+// block.Replay.
+func NewStoreReplaySource(database ds.Batching) block.ReplaySource {
+	return block.NewStoreReplaySource(store.New(newPrefixKV(database, RollkitPrefix)))
+}
+
+// NewDAReplaySource returns a source replaying the blocks retrieved from the DA layer, from the namespaces of
+// headers and block data of the node configuration, starting at daStartHeight. See block.Replay.
+func NewDAReplaySource(da coreda.DA, nodeConfig config.Config, genesis genesis.Genesis, daStartHeight uint64, logger log.Logger) (block.ReplaySource, error) {
+	headerDA, err := namespacedDA(da, nodeConfig.DA.HeaderNamespace)
+	if err != nil {
+		return nil, fmt.Errorf("error while initializing header namespace: %w", err)
+	}
+	var dataDA coreda.DA
+	if cmp.Or(nodeConfig.DA.DataNamespace, nodeConfig.DA.Namespace) != cmp.Or(nodeConfig.DA.HeaderNamespace, nodeConfig.DA.Namespace) {
+		if dataDA, err = namespacedDA(da, nodeConfig.DA.DataNamespace); err != nil {
+			return nil, fmt.Errorf("error while initializing data namespace: %w", err)
+		}
+	}
+	return block.NewDAReplaySource(headerDA, dataDA, genesis, daStartHeight, logger)
+}
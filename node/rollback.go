@@ -0,0 +1,35 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/block"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/pkg/sync"
+	"github.com/rollkit/rollkit/pkg/txindex"
+)
+
+// Rollback reverts the chain persisted in the database of a stopped node to the given height: the executor
+// state, the store, the header and data sync stores and the transaction index are rewound. Blocks at or below
+// the DA included height are only rolled back if force is set. See block.Rollback.
+func Rollback(ctx context.Context, exec coreexecutor.Executor, database ds.Batching, height uint64, force bool, logger log.Logger) error {
+	mainKV := newPrefixKV(database, RollkitPrefix)
+	if err := store.Migrate(ctx, mainKV, logger); err != nil {
+		return err
+	}
+	if err := block.Rollback(ctx, store.New(mainKV), exec, height, force, logger); err != nil {
+		return err
+	}
+	if err := sync.Rollback(ctx, mainKV, height); err != nil {
+		return fmt.Errorf("failed to roll back sync stores: %w", err)
+	}
+	if err := txindex.Rollback(ctx, newPrefixKV(mainKV, txIndexPrefix), height); err != nil {
+		return fmt.Errorf("failed to roll back transaction index: %w", err)
+	}
+	return nil
+}
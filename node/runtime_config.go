@@ -0,0 +1,68 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"cosmossdk.io/log"
+
+	"github.com/rollkit/rollkit/pkg/config"
+)
+
+// runtimeConfig serves the configuration of a node and applies changes of its runtime parameters to the running
+// services, persisting them to the config file.
+type runtimeConfig struct {
+	mu   sync.Mutex
+	conf config.Config
+	// apply applies the updated runtime parameters to the running services of the node
+	apply  func(old, updated config.Config) error
+	logger log.Logger
+}
+
+func newRuntimeConfig(conf config.Config, apply func(old, updated config.Config) error, logger log.Logger) *runtimeConfig {
+	return &runtimeConfig{
+		conf:   conf,
+		apply:  apply,
+		logger: logger,
+	}
+}
+
+// Config returns the configuration of the node with its current runtime parameters.
+func (r *runtimeConfig) Config() config.Config {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.conf
+}
+
+// UpdateConfig validates the update, applies it to the running services of the node and persists it to the
+// config file. Updates rejected by the services are not applied.
+func (r *runtimeConfig) UpdateConfig(_ context.Context, update config.RuntimeUpdate) (config.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	updated, err := update.Apply(r.conf)
+	if err != nil {
+		return r.conf, err
+	}
+	if err := r.apply(r.conf, updated); err != nil {
+		return r.conf, err
+	}
+	r.conf = updated
+	r.logger.Info("updated runtime config",
+		"blockTime", updated.Node.BlockTime,
+		"lazyMode", updated.Node.LazyMode,
+		"lazyBlockInterval", updated.Node.LazyBlockInterval,
+		"daGasPrice", updated.DA.GasPrice,
+		"daMaxGasPrice", updated.DA.MaxGasPrice,
+		"daDailyBudget", updated.DA.DailyBudget,
+		"pruningKeepRecent", updated.Pruning.KeepRecent,
+		"pruningInterval", updated.Pruning.Interval,
+		"maxPeers", updated.P2P.MaxPeers,
+	)
+
+	if err := config.SaveRuntimeConfig(updated); err != nil {
+		return updated, fmt.Errorf("runtime config applied but not persisted: %w", err)
+	}
+	return updated, nil
+}
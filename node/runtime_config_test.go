@@ -0,0 +1,55 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/config"
+)
+
+func TestRuntimeConfig(t *testing.T) {
+	conf := config.DefaultConfig
+	conf.RootDir = t.TempDir()
+	var applied []config.Config
+	var rejected bool
+	r := newRuntimeConfig(conf, func(_, updated config.Config) error {
+		if rejected {
+			return errors.New("rejected")
+		}
+		applied = append(applied, updated)
+		return nil
+	}, log.NewNopLogger())
+
+	blockTime := 3 * time.Second
+	updated, err := r.UpdateConfig(context.Background(), config.RuntimeUpdate{BlockTime: &blockTime})
+	require.NoError(t, err)
+	assert.Equal(t, blockTime, updated.Node.BlockTime.Duration)
+	assert.Equal(t, updated, r.Config())
+	require.Len(t, applied, 1)
+
+	// the update is persisted to the config file
+	v := viper.New()
+	v.Set(config.FlagRootDir, conf.RootDir)
+	persisted, err := config.LoadFromViper(v)
+	require.NoError(t, err)
+	assert.Equal(t, blockTime, persisted.Node.BlockTime.Duration)
+
+	// invalid updates and updates rejected by the services are not applied
+	zero := time.Duration(0)
+	_, err = r.UpdateConfig(context.Background(), config.RuntimeUpdate{BlockTime: &zero})
+	require.ErrorIs(t, err, config.ErrInvalidRuntimeConfig)
+	rejected = true
+	lazyMode := true
+	_, err = r.UpdateConfig(context.Background(), config.RuntimeUpdate{LazyMode: &lazyMode})
+	require.Error(t, err)
+	assert.Len(t, applied, 1)
+	assert.Equal(t, blockTime, r.Config().Node.BlockTime.Duration)
+	assert.False(t, r.Config().Node.LazyMode)
+}
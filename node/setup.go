@@ -1,11 +1,21 @@
 package node
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
 	"time"
 
+	"cosmossdk.io/log"
+
 	"github.com/rollkit/rollkit/block"
 	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/dac"
 	"github.com/rollkit/rollkit/pkg/p2p"
+	rpcserver "github.com/rollkit/rollkit/pkg/rpc/server"
+	"github.com/rollkit/rollkit/types"
 )
 
 const readHeaderTimeout = 10 * time.Second
@@ -18,9 +28,151 @@ type MetricsProvider func(chainID string) (*block.Metrics, *p2p.Metrics)
 func DefaultMetricsProvider(config *config.InstrumentationConfig) MetricsProvider {
 	return func(chainID string) (*block.Metrics, *p2p.Metrics) {
 		if config.Prometheus {
-			return block.PrometheusMetrics(config.Namespace, "chain_id", chainID),
-				p2p.PrometheusMetrics(config.Namespace, "chain_id", chainID)
+			type metrics struct {
+				block *block.Metrics
+				p2p   *p2p.Metrics
+			}
+			m := registerMetricsOnce("node/"+config.Namespace+"/"+chainID, func() metrics {
+				return metrics{
+					block: block.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+					p2p:   p2p.PrometheusMetrics(config.Namespace, "chain_id", chainID),
+				}
+			})
+			return m.block, m.p2p
 		}
 		return block.NopMetrics(), p2p.NopMetrics()
 	}
 }
+
+// registeredMetrics are the metrics registered with the default Prometheus registerer, by key. Collectors can
+// only be registered once, so the nodes created again in the same process, e.g. when a DualModeNode switches
+// mode, reuse the registered metrics.
+var registeredMetrics = struct {
+	sync.Mutex
+	metrics map[string]any
+}{metrics: make(map[string]any)}
+
+// registerMetricsOnce returns the metrics registered under the given key, registering them if needed.
+func registerMetricsOnce[T any](key string, register func() T) T {
+	registeredMetrics.Lock()
+	defer registeredMetrics.Unlock()
+	if m, ok := registeredMetrics.metrics[key]; ok {
+		return m.(T)
+	}
+	m := register()
+	registeredMetrics.metrics[key] = m
+	return m
+}
+
+// rpcHandlerOptions returns the handler options of the RPC servers: the limits of the configuration, whose
+// rejected requests are counted by Prometheus metrics if enabled, the readiness threshold, and the authenticator
+// of write requests if not nil.
+func rpcHandlerOptions(conf config.Config, chainID string, auth *rpcserver.Authenticator) []rpcserver.HandlerOption {
+	metrics := rpcserver.NopMetrics()
+	if conf.Instrumentation != nil && conf.Instrumentation.IsPrometheusEnabled() {
+		metrics = registerMetricsOnce("rpc/"+conf.Instrumentation.Namespace+"/"+chainID, func() *rpcserver.Metrics {
+			return rpcserver.PrometheusMetrics(conf.Instrumentation.Namespace, "chain_id", chainID)
+		})
+	}
+	opts := []rpcserver.HandlerOption{
+		rpcserver.WithLimits(rpcserver.Limits{
+			RequestsPerIP:   conf.RPC.RateLimitPerIP,
+			Requests:        conf.RPC.RateLimitGlobal,
+			Burst:           conf.RPC.RateLimitBurst,
+			MaxRequestBytes: conf.RPC.MaxRequestBytes,
+		}, metrics),
+		rpcserver.WithReadinessMaxSyncLag(conf.RPC.ReadinessMaxSyncLag),
+	}
+	if auth != nil {
+		opts = append(opts, rpcserver.WithAuthenticator(auth))
+	}
+	return opts
+}
+
+// rpcSecurity holds the TLS certificate of the RPC servers and the authenticator of write requests, loaded from
+// the files of the configuration.
+type rpcSecurity struct {
+	// certs serves the TLS certificate, nil to serve without TLS
+	certs *rpcserver.CertReloader
+	// auth authenticates write requests, nil to leave them unauthenticated
+	auth *rpcserver.Authenticator
+}
+
+// newRPCSecurity loads the TLS certificate and the credentials of write requests of the configuration.
+func newRPCSecurity(conf config.RPCConfig) (*rpcSecurity, error) {
+	s := &rpcSecurity{}
+	if conf.TLSCertFile != "" || conf.TLSKeyFile != "" {
+		if conf.TLSCertFile == "" || conf.TLSKeyFile == "" {
+			return nil, errors.New("serving the RPC over TLS requires both a certificate and a key file")
+		}
+		certs, err := rpcserver.NewCertReloader(conf.TLSCertFile, conf.TLSKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		s.certs = certs
+	}
+	if conf.AuthTokenFile != "" || conf.JWTSecretFile != "" {
+		auth, err := rpcserver.NewAuthenticator(conf.AuthTokenFile, conf.JWTSecretFile)
+		if err != nil {
+			return nil, err
+		}
+		s.auth = auth
+	}
+	return s, nil
+}
+
+// listenAndServe serves the requests of the server, over TLS if a certificate is configured.
+func (s *rpcSecurity) listenAndServe(server *http.Server) error {
+	if s.certs == nil {
+		return server.ListenAndServe()
+	}
+	server.TLSConfig = s.certs.TLSConfig()
+	return server.ListenAndServeTLS("", "")
+}
+
+// watch reloads the certificate and the credentials on SIGHUP or when their files change, until the context
+// is done.
+func (s *rpcSecurity) watch(ctx context.Context, logger log.Logger) {
+	var reloadables []rpcserver.Reloadable
+	if s.certs != nil {
+		reloadables = append(reloadables, s.certs)
+	}
+	if s.auth != nil {
+		reloadables = append(reloadables, s.auth)
+	}
+	if len(reloadables) == 0 {
+		return
+	}
+	go func() {
+		if err := rpcserver.WatchReload(ctx, logger, reloadables...); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Error("RPC credentials reloading stopped", "error", err)
+		}
+	}()
+}
+
+// p2pListening reports whether the p2p client listens for connections.
+func p2pListening(client *p2p.Client) bool {
+	return client.Host() != nil && len(client.Addrs()) > 0
+}
+
+// dacValidatorName is the name of the header validator checking the DA certificates of headers.
+const dacValidatorName = "dac"
+
+// newDACommittee returns the data availability committee of a chain in commitments-only mode, nil if no committee
+// is configured. The header validator checking the signatures of the committee on headers is registered, so that
+// full and light nodes reject headers whose data is not attested by the committee.
+func newDACommittee(conf config.DAConfig) (*dac.Committee, error) {
+	if conf.DACMembers == "" {
+		return nil, nil
+	}
+	committee, err := dac.NewCommittee(conf.DACMembers, conf.DACThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("error while initializing data availability committee: %w", err)
+	}
+	// the validator of a node previously created in the process is replaced
+	types.HeaderValidators.Unregister(dacValidatorName)
+	if err := types.HeaderValidators.Register(dacValidatorName, committee.VerifyHeader); err != nil {
+		return nil, err
+	}
+	return committee, nil
+}
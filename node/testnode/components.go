@@ -0,0 +1,54 @@
+package testnode
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rollkit/rollkit/block"
+	coreda "github.com/rollkit/rollkit/core/da"
+)
+
+// dataAvailability is a dummy DA layer whose height is the height of the last submission. Unlike the dummy DA
+// layer, it reports the heights above as from the future, so that the nodes retrieving blocks from it wait for
+// the next submission instead of skipping its height.
+type dataAvailability struct {
+	*coreda.DummyDA
+
+	mu sync.RWMutex
+	// submitted is false until the first submission, which is at height 0
+	submitted bool
+	height    uint64
+}
+
+// GetIDs implements the DA interface.
+func (d *dataAvailability) GetIDs(ctx context.Context, height uint64, namespace []byte) (*coreda.GetIDsResult, error) {
+	d.mu.RLock()
+	future := !d.submitted || height > d.height
+	d.mu.RUnlock()
+	if future {
+		return nil, fmt.Errorf("%w: DA height %d", block.ErrHeightFromFutureStr, height)
+	}
+	return d.DummyDA.GetIDs(ctx, height, namespace)
+}
+
+// Submit implements the DA interface.
+func (d *dataAvailability) Submit(ctx context.Context, blobs []coreda.Blob, gasPrice float64, namespace []byte) ([]coreda.ID, error) {
+	return d.SubmitWithOptions(ctx, blobs, gasPrice, namespace, nil)
+}
+
+// SubmitWithOptions implements the DA interface.
+func (d *dataAvailability) SubmitWithOptions(ctx context.Context, blobs []coreda.Blob, gasPrice float64, namespace []byte, options []byte) ([]coreda.ID, error) {
+	ids, err := d.DummyDA.SubmitWithOptions(ctx, blobs, gasPrice, namespace, options)
+	if err != nil || len(ids) == 0 {
+		return ids, err
+	}
+	height, _, err := coreda.SplitID(ids[0])
+	if err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.submitted, d.height = true, max(d.height, height)
+	return ids, nil
+}
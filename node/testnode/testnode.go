@@ -0,0 +1,388 @@
+// Package testnode runs a local rollup network in-process, for integration tests and local development: an
+// aggregator, full nodes and light nodes sharing an in-memory DA layer, connected to the aggregator over p2p on
+// the loopback interface. The keys of the network are derived from its chain ID, so that a network started twice
+// with the same configuration has the same genesis, proposer and peer IDs.
+package testnode
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	gosync "sync"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	"github.com/rollkit/rollkit/node"
+	rollkitconfig "github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/p2p"
+	"github.com/rollkit/rollkit/pkg/p2p/key"
+	"github.com/rollkit/rollkit/pkg/signer"
+	noopsigner "github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/types"
+)
+
+// pollInterval is the interval between two checks of the state of the nodes
+const pollInterval = 50 * time.Millisecond
+
+// Config configures a local network.
+type Config struct {
+	// ChainID is the chain ID of the network, from which its keys are derived.
+	ChainID string
+	// FullNodes is the number of full nodes besides the aggregator.
+	FullNodes int
+	// LightNodes is the number of light nodes.
+	LightNodes int
+	// BlockTime is the block time of the aggregator.
+	BlockTime time.Duration
+	// DABlockTime is the block time of the DA layer.
+	DABlockTime time.Duration
+	// MaxBlobSize is the maximum blob size of the DA layer.
+	MaxBlobSize uint64
+}
+
+// DefaultConfig returns the configuration of a network of an aggregator and a full node.
+func DefaultConfig() Config {
+	return Config{
+		ChainID:     "testnode",
+		FullNodes:   1,
+		BlockTime:   100 * time.Millisecond,
+		DABlockTime: 200 * time.Millisecond,
+		MaxBlobSize: 2 << 20,
+	}
+}
+
+// Validate checks the network configuration.
+func (c Config) Validate() error {
+	if c.ChainID == "" {
+		return errors.New("chain ID is required")
+	}
+	if c.FullNodes < 0 || c.LightNodes < 0 {
+		return errors.New("node counts must not be negative")
+	}
+	if c.BlockTime <= 0 || c.DABlockTime <= 0 {
+		return errors.New("block times must be positive")
+	}
+	if c.MaxBlobSize == 0 {
+		return errors.New("maximum blob size must be positive")
+	}
+	return nil
+}
+
+// Node is a node of a local network.
+type Node struct {
+	node.Node
+	// Name identifies the node in the network, e.g. aggregator, full-0 or light-0.
+	Name string
+	// Config is the configuration the node was created with.
+	Config rollkitconfig.Config
+	// NodeKey is the p2p key of the node.
+	NodeKey key.NodeKey
+	// P2PAddress is the p2p address of the node, including its peer ID.
+	P2PAddress string
+
+	port   int
+	runErr chan error
+}
+
+// Network is a local network of nodes.
+type Network struct {
+	Aggregator *Node
+	FullNodes  []*Node
+	LightNodes []*Node
+	// DA is the DA layer shared by the nodes.
+	DA coreda.DA
+	// Genesis is the genesis of the network, whose proposer is the aggregator.
+	Genesis genesis.Genesis
+
+	logger log.Logger
+	cancel context.CancelFunc
+	wg     gosync.WaitGroup
+}
+
+// New creates a local network, with the directories of its nodes under rootDir. The nodes are started by Start.
+func New(ctx context.Context, conf Config, rootDir string, logger log.Logger) (*Network, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid network configuration: %w", err)
+	}
+
+	proposerKey := deriveKey(conf.ChainID, "proposer")
+	proposer, err := types.NewSigner(proposerKey.GetPublic())
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive proposer: %w", err)
+	}
+	signer, err := noopsigner.NewNoopSigner(proposerKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signer: %w", err)
+	}
+	n := &Network{
+		DA:      &dataAvailability{DummyDA: coreda.NewDummyDA(conf.MaxBlobSize, 0, 0)},
+		Genesis: genesis.NewGenesis(conf.ChainID, 1, time.Now().UTC(), proposer.Address),
+		logger:  logger,
+	}
+
+	n.Aggregator, err = n.newNode(ctx, conf, rootDir, "aggregator", signer, func(c *rollkitconfig.Config) {
+		c.Node.Aggregator = true
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the other nodes use the aggregator as their peer
+	peer := n.Aggregator.P2PAddress
+
+	for i := range conf.FullNodes {
+		fullNode, err := n.newNode(ctx, conf, rootDir, fmt.Sprintf("full-%d", i), nil, func(c *rollkitconfig.Config) {
+			c.P2P.Peers = peer
+		})
+		if err != nil {
+			return nil, err
+		}
+		n.FullNodes = append(n.FullNodes, fullNode)
+	}
+	for i := range conf.LightNodes {
+		lightNode, err := n.newNode(ctx, conf, rootDir, fmt.Sprintf("light-%d", i), nil, func(c *rollkitconfig.Config) {
+			c.Node.Light = true
+			c.P2P.Peers = peer
+		})
+		if err != nil {
+			return nil, err
+		}
+		n.LightNodes = append(n.LightNodes, lightNode)
+	}
+	return n, nil
+}
+
+// newNode creates a node of the network, with the default configuration updated by configure.
+func (n *Network) newNode(
+	ctx context.Context,
+	conf Config,
+	rootDir string,
+	name string,
+	signer signer.Signer,
+	configure func(*rollkitconfig.Config),
+) (*Node, error) {
+	nodeConfig := rollkitconfig.DefaultConfig
+	nodeConfig.RootDir = filepath.Join(rootDir, name)
+	nodeConfig.ChainID = conf.ChainID
+	nodeConfig.Node.BlockTime = rollkitconfig.DurationWrapper{Duration: conf.BlockTime}
+	nodeConfig.DA.BlockTime = rollkitconfig.DurationWrapper{Duration: conf.DABlockTime}
+	nodeConfig.RPC.Address = "127.0.0.1:0"
+	// the node listens on a known port, so that it can be used as a peer and waited for
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	nodeConfig.P2P.ListenAddress = fmt.Sprintf("/ip4/127.0.0.1/tcp/%d", port)
+	configure(&nodeConfig)
+
+	privKey := deriveKey(conf.ChainID, name)
+	nodeKey := key.NodeKey{PrivKey: privKey, PubKey: privKey.GetPublic()}
+	configDir := filepath.Join(nodeConfig.RootDir, "config")
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create config directory of %s: %w", name, err)
+	}
+	if err := nodeKey.SaveAs(configDir); err != nil {
+		return nil, fmt.Errorf("failed to save node key of %s: %w", name, err)
+	}
+
+	peerID, err := peer.IDFromPrivateKey(privKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive peer ID of %s: %w", name, err)
+	}
+
+	logger := n.logger.With("node", name)
+	p2pClient, err := p2p.NewClient(nodeConfig, &nodeKey, dssync.MutexWrap(datastore.NewMapDatastore()), logger,
+		p2p.NopMetrics())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create p2p client of %s: %w", name, err)
+	}
+	rollupNode, err := node.NewNode(
+		ctx,
+		nodeConfig,
+		coreexecutor.NewDummyExecutor(),
+		coresequencer.NewDummySequencer(),
+		n.DA,
+		signer,
+		nodeKey,
+		p2pClient,
+		n.Genesis,
+		dssync.MutexWrap(datastore.NewMapDatastore()),
+		node.DefaultMetricsProvider(rollkitconfig.DefaultInstrumentationConfig()),
+		logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", name, err)
+	}
+	return &Node{
+		Node:       rollupNode,
+		Name:       name,
+		Config:     nodeConfig,
+		NodeKey:    nodeKey,
+		P2PAddress: fmt.Sprintf("%s/p2p/%s", nodeConfig.P2P.ListenAddress, peerID),
+		port:       port,
+	}, nil
+}
+
+// Nodes returns the nodes of the network, the aggregator first.
+func (n *Network) Nodes() []*Node {
+	nodes := append([]*Node{n.Aggregator}, n.FullNodes...)
+	return append(nodes, n.LightNodes...)
+}
+
+// Start runs the nodes of the network until Stop is called or the context is canceled. The aggregator is
+// started first, and the other nodes once it produced the first block, which they fetch from it over p2p to
+// initialize their header sync. Start returns once all the nodes listen for p2p connections.
+func (n *Network) Start(ctx context.Context) error {
+	ctx, n.cancel = context.WithCancel(ctx)
+	n.run(ctx, n.Aggregator)
+	if err := n.waitForListening(ctx, n.Aggregator); err != nil {
+		return errors.Join(err, n.Stop())
+	}
+	if err := n.WaitForHeight(ctx, n.Aggregator, n.Genesis.InitialHeight); err != nil {
+		return errors.Join(err, n.Stop())
+	}
+	for _, node := range n.Nodes()[1:] {
+		n.run(ctx, node)
+	}
+	for _, node := range n.Nodes()[1:] {
+		if err := n.waitForListening(ctx, node); err != nil {
+			return errors.Join(err, n.Stop())
+		}
+	}
+	return nil
+}
+
+// waitForListening waits until the node accepts p2p connections.
+func (n *Network) waitForListening(ctx context.Context, node *Node) error {
+	addr := fmt.Sprintf("127.0.0.1:%d", node.port)
+	return n.poll(ctx, func() error {
+		conn, err := net.DialTimeout("tcp", addr, pollInterval)
+		if err != nil {
+			return fmt.Errorf("%s does not listen for p2p connections: %w", node.Name, err)
+		}
+		return conn.Close()
+	})
+}
+
+// run runs the node in the background.
+func (n *Network) run(ctx context.Context, node *Node) {
+	node.runErr = make(chan error, 1)
+	n.wg.Add(1)
+	go func() {
+		defer n.wg.Done()
+		node.runErr <- node.Run(ctx)
+	}()
+}
+
+// Stop stops the nodes of the network, and returns the errors of the nodes which failed.
+func (n *Network) Stop() error {
+	if n.cancel == nil {
+		return nil
+	}
+	n.cancel()
+	n.wg.Wait()
+	var errs []error
+	for _, node := range n.Nodes() {
+		if node.runErr == nil {
+			continue
+		}
+		if err := <-node.runErr; err != nil && !errors.Is(err, context.Canceled) {
+			errs = append(errs, fmt.Errorf("%s: %w", node.Name, err))
+		}
+		node.runErr = nil
+	}
+	return errors.Join(errs...)
+}
+
+// WaitForHeight waits until the node reaches the height: the height of its last block, or of its last header for
+// light nodes.
+func (n *Network) WaitForHeight(ctx context.Context, node *Node, height uint64) error {
+	return n.poll(ctx, func() error {
+		return checkHeight(ctx, node, height)
+	})
+}
+
+// WaitForAllNodesAtHeight waits until all the nodes of the network reach the height.
+func (n *Network) WaitForAllNodesAtHeight(ctx context.Context, height uint64) error {
+	return n.poll(ctx, func() error {
+		var errs []error
+		for _, node := range n.Nodes() {
+			errs = append(errs, checkHeight(ctx, node, height))
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// poll calls check until it succeeds, a node stops or the context is canceled, in which case the last error of
+// check is returned.
+func (n *Network) poll(ctx context.Context, check func() error) error {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		err := check()
+		if err == nil {
+			return nil
+		}
+		for _, node := range n.Nodes() {
+			if node.runErr == nil {
+				continue
+			}
+			select {
+			case runErr := <-node.runErr:
+				node.runErr <- runErr
+				return fmt.Errorf("%s stopped: %w", node.Name, runErr)
+			default:
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %w", ctx.Err(), err)
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkHeight returns an error if the node did not reach the height.
+func checkHeight(ctx context.Context, node *Node, height uint64) error {
+	status, err := node.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", node.Name, err)
+	}
+	if status.Height < height {
+		return fmt.Errorf("%s at height %d, expected %d", node.Name, status.Height, height)
+	}
+	return nil
+}
+
+// deriveKey derives the ed25519 key of a node of the network from the chain ID.
+func deriveKey(chainID, name string) crypto.PrivKey {
+	seed := sha256.Sum256([]byte(chainID + "/" + name))
+	privKey, _, err := crypto.GenerateEd25519Key(bytes.NewReader(seed[:]))
+	if err != nil {
+		panic(err) // ed25519 key generation only fails if the seed is too short
+	}
+	return privKey
+}
+
+// freePort returns a free TCP port of the loopback interface.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, fmt.Errorf("failed to find a free port: %w", err)
+	}
+	defer l.Close() //nolint:errcheck // the listener only reserves the port
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,75 @@
+package testnode
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestNetwork(t *testing.T) {
+	conf := DefaultConfig()
+	conf.FullNodes = 2
+	conf.LightNodes = 1
+	network, err := New(context.Background(), conf, t.TempDir(), log.NewNopLogger())
+	require.NoError(t, err)
+	require.Len(t, network.Nodes(), 4)
+
+	require.NoError(t, network.Start(context.Background()))
+	t.Cleanup(func() {
+		assert.NoError(t, network.Stop())
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	require.NoError(t, network.WaitForAllNodesAtHeight(ctx, 3))
+
+	for _, node := range network.Nodes() {
+		status, err := node.Status(ctx)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, status.Height, uint64(3), node.Name)
+		assert.Positive(t, status.Peers, node.Name)
+	}
+	status, err := network.LightNodes[0].Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, types.NodeModeLight, status.Mode)
+}
+
+func TestNetworkDeterministicKeys(t *testing.T) {
+	conf := DefaultConfig()
+	first, err := New(context.Background(), conf, t.TempDir(), log.NewNopLogger())
+	require.NoError(t, err)
+	second, err := New(context.Background(), conf, t.TempDir(), log.NewNopLogger())
+	require.NoError(t, err)
+
+	assert.Equal(t, first.Genesis.ProposerAddress, second.Genesis.ProposerAddress)
+	for i, node := range first.Nodes() {
+		assert.Equal(t, node.NodeKey.ID(), second.Nodes()[i].NodeKey.ID(), node.Name)
+	}
+	assert.NotEqual(t, first.Aggregator.NodeKey.ID(), first.FullNodes[0].NodeKey.ID())
+
+	conf.ChainID = "other"
+	other, err := New(context.Background(), conf, t.TempDir(), log.NewNopLogger())
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Genesis.ProposerAddress, other.Genesis.ProposerAddress)
+}
+
+func TestConfigValidate(t *testing.T) {
+	require.NoError(t, DefaultConfig().Validate())
+
+	for name, update := range map[string]func(*Config){
+		"no chain ID":         func(c *Config) { c.ChainID = "" },
+		"negative full nodes": func(c *Config) { c.FullNodes = -1 },
+		"no block time":       func(c *Config) { c.BlockTime = 0 },
+		"no blob size":        func(c *Config) { c.MaxBlobSize = 0 },
+	} {
+		conf := DefaultConfig()
+		update(&conf)
+		assert.Error(t, conf.Validate(), name)
+	}
+}
@@ -0,0 +1,349 @@
+package attest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"cosmossdk.io/log"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/rollkit/rollkit/block"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+const (
+	// eventBuffer is the number of block events buffered for the service.
+	eventBuffer = 100
+
+	// maxPendingHeights is the number of heights above the last applied block for which attestations are kept
+	// until the block is applied. Attestations further ahead are ignored, later blocks attest their ancestors.
+	maxPendingHeights = 256
+)
+
+// Blocks provides the blocks applied by the node and tracks the attested height. It is implemented by
+// block.Manager.
+type Blocks interface {
+	Subscribe(buffer int) (<-chan block.Event, func())
+	SetAttestedHeight(height uint64)
+}
+
+// Service gossips block attestations over a dedicated pubsub topic. Attesters sign the hash of every block they
+// apply; once a quorum of the attester set attested the block applied by the node, the block and its ancestors
+// are attested, a finality level between soft confirmation and DA inclusion. Only attestations signed by
+// attesters are relayed, and peers gossiping invalid attestations are penalized by the p2p client.
+type Service struct {
+	ps      *pubsub.PubSub
+	self    peer.ID
+	topicID string
+	chainID string
+	set     *Set
+	// privKey signs the attestations of the node, nil if the node is not an attester
+	privKey crypto.PrivKey
+	blocks  Blocks
+	store   store.Store
+	logger  log.Logger
+
+	topic *pubsub.Topic
+	sub   *pubsub.Subscription
+
+	mu sync.Mutex
+	// votes holds the attestations of the heights above the attested height, by attester index
+	votes map[uint64]map[int]*types.BlockAttestation
+	// local holds the hashes of the blocks applied by the node above the attested height
+	local       map[uint64]types.Hash
+	localHeight uint64
+	attested    uint64
+}
+
+// NewService creates an attestation Service for the given chain. self is the ID of the local peer, whose
+// attestations are not handled again when delivered back by pubsub. privKey signs the attestations of the node
+// if it is an attester of the set, and is ignored otherwise.
+func NewService(
+	ps *pubsub.PubSub,
+	self peer.ID,
+	chainID string,
+	set *Set,
+	privKey crypto.PrivKey,
+	blocks Blocks,
+	store store.Store,
+	logger log.Logger,
+) *Service {
+	s := &Service{
+		ps:      ps,
+		self:    self,
+		topicID: TopicID(chainID),
+		chainID: chainID,
+		set:     set,
+		blocks:  blocks,
+		store:   store,
+		logger:  logger,
+		votes:   make(map[uint64]map[int]*types.BlockAttestation),
+		local:   make(map[uint64]types.Hash),
+	}
+	if privKey != nil && set.Contains(privKey.GetPublic()) {
+		s.privKey = privKey
+	}
+	return s
+}
+
+// TopicID returns the pubsub topic on which block attestations are gossiped for the given chain.
+func TopicID(chainID string) string {
+	return fmt.Sprintf("/%s/attestation/v0.0.1", chainID)
+}
+
+// IsAttester reports whether the node attests the blocks it applies.
+func (s *Service) IsAttester() bool {
+	return s.privKey != nil
+}
+
+// Start joins the attestation topic, attests the blocks applied by the node if it is an attester, and handles
+// the attestations received from peers until ctx is canceled.
+func (s *Service) Start(ctx context.Context) error {
+	height, err := s.store.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get store height: %w", err)
+	}
+	s.localHeight = height
+
+	if err := s.ps.RegisterTopicValidator(s.topicID, s.validate); err != nil {
+		return fmt.Errorf("failed to register attestation validator: %w", err)
+	}
+	topic, err := s.ps.Join(s.topicID)
+	if err != nil {
+		return fmt.Errorf("failed to join attestation topic: %w", err)
+	}
+	sub, err := topic.Subscribe()
+	if err != nil {
+		_ = topic.Close()
+		return fmt.Errorf("failed to subscribe to attestation topic: %w", err)
+	}
+	s.topic, s.sub = topic, sub
+
+	go s.run(ctx)
+	go s.watchBlocks(ctx)
+	return nil
+}
+
+// Stop leaves the attestation topic.
+func (s *Service) Stop() error {
+	if s.sub == nil {
+		return nil
+	}
+	s.sub.Cancel()
+	return errors.Join(s.ps.UnregisterTopicValidator(s.topicID), s.topic.Close())
+}
+
+// AttestedHeight returns the height up to which blocks are attested by a quorum of attesters.
+func (s *Service) AttestedHeight() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attested
+}
+
+func (s *Service) run(ctx context.Context) {
+	for {
+		msg, err := s.sub.Next(ctx)
+		if err != nil {
+			return
+		}
+		if msg.ReceivedFrom == s.self {
+			continue
+		}
+		s.add(ctx, msg.ValidatorData.(*types.BlockAttestation))
+	}
+}
+
+// watchBlocks attests the blocks applied by the node, and checks the quorums of the attestations received before
+// the blocks were applied, until ctx is canceled. Subscriptions dropped for falling behind are renewed.
+func (s *Service) watchBlocks(ctx context.Context) {
+	for ctx.Err() == nil {
+		events, cancel := s.blocks.Subscribe(eventBuffer)
+		s.handleEvents(ctx, events)
+		cancel()
+	}
+}
+
+func (s *Service) handleEvents(ctx context.Context, events <-chan block.Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				s.logger.Debug("block event subscription dropped, subscribing again")
+				return
+			}
+			if e.Type == block.EventNewBlock {
+				s.onBlock(ctx, e.Height, e.Hash)
+			}
+		}
+	}
+}
+
+// onBlock records the hash of a block applied by the node, marks it attested if a quorum attested it already,
+// and attests it if the node is an attester.
+func (s *Service) onBlock(ctx context.Context, height uint64, hash types.Hash) {
+	s.mu.Lock()
+	s.localHeight = max(s.localHeight, height)
+	if height > s.attested {
+		s.local[height] = hash
+	}
+	quorumHash, ok := s.quorum(height)
+	s.mu.Unlock()
+	if ok {
+		s.finalize(ctx, height, quorumHash)
+	}
+
+	if s.privKey == nil {
+		return
+	}
+	attestation, err := s.sign(height, hash)
+	if err != nil {
+		s.logger.Error("failed to sign block attestation", "height", height, "error", err)
+		return
+	}
+	s.add(ctx, attestation)
+	bz, err := attestation.MarshalBinary()
+	if err != nil {
+		s.logger.Error("failed to encode block attestation", "height", height, "error", err)
+		return
+	}
+	if err := s.topic.Publish(ctx, bz); err != nil && ctx.Err() == nil {
+		s.logger.Error("failed to publish block attestation", "height", height, "error", err)
+	}
+}
+
+func (s *Service) sign(height uint64, hash types.Hash) (*types.BlockAttestation, error) {
+	attestation := &types.BlockAttestation{
+		ChainID:   s.chainID,
+		Height:    height,
+		BlockHash: hash,
+		Signer:    types.Signer{PubKey: s.privKey.GetPublic(), Address: types.KeyAddress(s.privKey.GetPublic())},
+	}
+	bz, err := attestation.SignBytes()
+	if err != nil {
+		return nil, err
+	}
+	if attestation.Signature, err = s.privKey.Sign(bz); err != nil {
+		return nil, err
+	}
+	return attestation, nil
+}
+
+// add records a verified attestation, and marks its block attested once a quorum attested it.
+func (s *Service) add(ctx context.Context, attestation *types.BlockAttestation) {
+	attester := s.set.index(attestation.Signer.PubKey)
+	s.mu.Lock()
+	height := attestation.Height
+	if attester < 0 || height <= s.attested || height > s.localHeight+maxPendingHeights {
+		s.mu.Unlock()
+		return
+	}
+	votes, ok := s.votes[height]
+	if !ok {
+		votes = make(map[int]*types.BlockAttestation)
+		s.votes[height] = votes
+	}
+	if previous, ok := votes[attester]; ok {
+		s.mu.Unlock()
+		if !bytes.Equal(previous.BlockHash, attestation.BlockHash) {
+			s.logger.Error("attester attested conflicting blocks", "attester", attestation.Signer.Address, "height", height,
+				"hash", attestation.BlockHash, "previous", previous.BlockHash)
+		}
+		return
+	}
+	votes[attester] = attestation
+	quorumHash, ok := s.quorum(height)
+	s.mu.Unlock()
+	if ok {
+		s.finalize(ctx, height, quorumHash)
+	}
+}
+
+// quorum returns the hash attested by a quorum of attesters at the height. It must be called with mu held.
+func (s *Service) quorum(height uint64) (types.Hash, bool) {
+	counts := make(map[string]int)
+	for _, attestation := range s.votes[height] {
+		key := string(attestation.BlockHash)
+		counts[key]++
+		if counts[key] >= s.set.Quorum {
+			return attestation.BlockHash, true
+		}
+	}
+	return nil, false
+}
+
+// finalize marks the block at the height attested by a quorum attested, if the node applied the same block. If
+// the node did not apply the block yet, it is checked again when the block is applied.
+func (s *Service) finalize(ctx context.Context, height uint64, hash types.Hash) {
+	local, ok := s.localHash(ctx, height)
+	if !ok {
+		return
+	}
+	if !bytes.Equal(local, hash) {
+		s.logger.Error("a quorum of attesters attested a different block than the block applied by the node",
+			"height", height, "attested", hash, "applied", local)
+		return
+	}
+
+	s.mu.Lock()
+	if height <= s.attested {
+		s.mu.Unlock()
+		return
+	}
+	s.attested = height
+	for h := range s.votes {
+		if h <= height {
+			delete(s.votes, h)
+		}
+	}
+	for h := range s.local {
+		if h <= height {
+			delete(s.local, h)
+		}
+	}
+	s.mu.Unlock()
+
+	s.logger.Debug("block attested", "height", height, "hash", hash)
+	s.blocks.SetAttestedHeight(height)
+}
+
+// localHash returns the hash of the block applied by the node at the height, false if it was not applied.
+func (s *Service) localHash(ctx context.Context, height uint64) (types.Hash, bool) {
+	s.mu.Lock()
+	hash, ok := s.local[height]
+	s.mu.Unlock()
+	if ok {
+		return hash, true
+	}
+	header, _, err := s.store.GetBlockData(ctx, height)
+	if err != nil {
+		return nil, false
+	}
+	return header.Hash(), true
+}
+
+// validate accepts the well formed attestations of attesters of the chain.
+func (s *Service) validate(_ context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	var attestation types.BlockAttestation
+	if err := attestation.UnmarshalBinary(msg.Data); err != nil {
+		s.logger.Debug("failed to decode block attestation", "peer", from, "error", err)
+		return pubsub.ValidationReject
+	}
+	if err := attestation.ValidateBasic(); err != nil {
+		s.logger.Debug("rejecting invalid block attestation", "peer", from, "error", err)
+		return pubsub.ValidationReject
+	}
+	if attestation.ChainID != s.chainID || !s.set.Contains(attestation.Signer.PubKey) {
+		s.logger.Debug("rejecting block attestation of a non-attester", "peer", from, "chainID", attestation.ChainID)
+		return pubsub.ValidationReject
+	}
+	msg.ValidatorData = &attestation
+	return pubsub.ValidationAccept
+}
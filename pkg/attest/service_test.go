@@ -0,0 +1,191 @@
+package attest
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/multiformats/go-multiaddr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/block"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+const testChainID = "attest-test"
+
+// testBlocks delivers the events sent on its channel and records the attested height.
+type testBlocks struct {
+	events   chan block.Event
+	attested atomic.Uint64
+}
+
+func (b *testBlocks) Subscribe(int) (<-chan block.Event, func()) {
+	return b.events, func() {}
+}
+
+func (b *testBlocks) SetAttestedHeight(height uint64) {
+	b.attested.Store(height)
+}
+
+func TestNewSet(t *testing.T) {
+	var ids []string
+	for range 4 {
+		key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		require.NoError(t, err)
+		id, err := peerID(key)
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+
+	set, err := NewSet(strings.Join(ids, ", "), 0)
+	require.NoError(t, err)
+	assert.Len(t, set.Attesters, 4)
+	assert.Equal(t, 3, set.Quorum)
+
+	set, err = NewSet(strings.Join(ids, ","), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, set.Quorum)
+
+	for name, attesters := range map[string]string{
+		"empty":      " , ",
+		"invalid ID": "not-a-peer-id",
+	} {
+		_, err := NewSet(attesters, 0)
+		assert.Error(t, err, name)
+	}
+	_, err = NewSet(strings.Join(ids, ","), 5)
+	assert.Error(t, err)
+	_, err = NewSet(strings.Join(ids, ","), -1)
+	assert.Error(t, err)
+}
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	// nodes 0 and 1 are the attesters, node 2 only follows their attestations
+	mnet := mocknet.New()
+	defer mnet.Close() //nolint:errcheck
+	keys := make([]crypto.PrivKey, 3)
+	ids := make([]string, 2)
+	for i := range keys {
+		var err error
+		keys[i], _, err = crypto.GenerateEd25519Key(rand.Reader)
+		require.NoError(t, err)
+		addr, err := multiaddr.NewMultiaddr(fmt.Sprintf("/ip4/10.0.0.%d/tcp/4242", i+1))
+		require.NoError(t, err)
+		_, err = mnet.AddPeer(keys[i], addr)
+		require.NoError(t, err)
+		if i < len(ids) {
+			ids[i], err = peerID(keys[i])
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, mnet.LinkAll())
+	require.NoError(t, mnet.ConnectAllButSelf())
+	set, err := NewSet(strings.Join(ids, ","), 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, set.Quorum)
+
+	services := make([]*Service, 3)
+	blocks := make([]*testBlocks, 3)
+	for i, h := range mnet.Hosts() {
+		// flood publishing delivers attestations before the gossipsub mesh is formed
+		ps, err := pubsub.NewGossipSub(ctx, h, pubsub.WithFloodPublish(true))
+		require.NoError(t, err)
+		blocks[i] = &testBlocks{events: make(chan block.Event, 10)}
+		services[i] = NewService(ps, h.ID(), testChainID, set, keys[i], blocks[i], store.New(store.NewMemoryKVStore()), log.NewNopLogger())
+		require.NoError(t, services[i].Start(ctx))
+		defer services[i].Stop() //nolint:errcheck
+	}
+	assert.True(t, services[0].IsAttester())
+	assert.False(t, services[2].IsAttester())
+	require.Eventually(t, func() bool {
+		for _, s := range services {
+			if len(s.topic.ListPeers()) != 2 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// a block applied by all nodes is attested once both attesters attested it
+	hash := types.Hash(types.GetRandomBytes(32))
+	for _, b := range blocks {
+		b.events <- block.Event{Type: block.EventNewBlock, Height: 1, Hash: hash}
+	}
+	require.Eventually(t, func() bool {
+		for i, s := range services {
+			if s.AttestedHeight() != 1 || blocks[i].attested.Load() != 1 {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// a block is not attested by nodes which applied a different block
+	hash = types.GetRandomBytes(32)
+	for _, b := range blocks[:2] {
+		b.events <- block.Event{Type: block.EventNewBlock, Height: 2, Hash: hash}
+	}
+	blocks[2].events <- block.Event{Type: block.EventNewBlock, Height: 2, Hash: types.GetRandomBytes(32)}
+	require.Eventually(t, func() bool {
+		return services[0].AttestedHeight() == 2 && services[1].AttestedHeight() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, uint64(1), services[2].AttestedHeight())
+}
+
+func TestServiceRejectsNonAttesters(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	other, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	id, err := peerID(key)
+	require.NoError(t, err)
+	set, err := NewSet(id, 0)
+	require.NoError(t, err)
+
+	// a key outside the set does not attest
+	s := NewService(nil, "", testChainID, set, other, &testBlocks{}, store.New(store.NewMemoryKVStore()), log.NewNopLogger())
+	assert.False(t, s.IsAttester())
+
+	hash := types.Hash(types.GetRandomBytes(32))
+	for name, tc := range map[string]struct {
+		key     crypto.PrivKey
+		chainID string
+		result  pubsub.ValidationResult
+	}{
+		"attester":     {key, testChainID, pubsub.ValidationAccept},
+		"non-attester": {other, testChainID, pubsub.ValidationReject},
+		"other chain":  {key, "other-chain", pubsub.ValidationReject},
+	} {
+		t.Run(name, func(t *testing.T) {
+			signer := &Service{chainID: tc.chainID, privKey: tc.key}
+			attestation, err := signer.sign(1, hash)
+			require.NoError(t, err)
+			bz, err := attestation.MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, tc.result, s.validate(t.Context(), "", &pubsub.Message{Message: &pb.Message{Data: bz}}))
+		})
+	}
+	msg := &pubsub.Message{Message: &pb.Message{Data: []byte("garbage")}}
+	assert.Equal(t, pubsub.ValidationReject, s.validate(t.Context(), "", msg))
+}
+
+func peerID(key crypto.PrivKey) (string, error) {
+	id, err := peer.IDFromPrivateKey(key)
+	return id.String(), err
+}
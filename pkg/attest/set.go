@@ -0,0 +1,66 @@
+package attest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Set is the attester set of a chain: the full nodes whose attestations are counted, identified by their p2p
+// identity, and the number of attesters attesting a block required to mark it attested.
+type Set struct {
+	Attesters []crypto.PubKey
+	Quorum    int
+}
+
+// NewSet returns the attester set of the attesters given as comma separated peer IDs. A quorum of 0 requires
+// more than two thirds of the attesters.
+func NewSet(attesters string, quorum int) (*Set, error) {
+	var keys []crypto.PubKey
+	for _, entry := range strings.Split(attesters, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, err := peer.Decode(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attester %q: %w", entry, err)
+		}
+		key, err := id.ExtractPublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid attester %q: %w", entry, err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("attester set has no attesters")
+	}
+	if quorum == 0 {
+		quorum = len(keys)*2/3 + 1
+	}
+	if quorum < 1 || quorum > len(keys) {
+		return nil, fmt.Errorf("attestation quorum %d must be between 1 and the number of attesters %d", quorum, len(keys))
+	}
+	return &Set{Attesters: keys, Quorum: quorum}, nil
+}
+
+// index returns the index of the attester with the given public key, -1 if it is not an attester.
+func (s *Set) index(pubKey crypto.PubKey) int {
+	if pubKey == nil {
+		return -1
+	}
+	for i, attester := range s.Attesters {
+		if attester.Equals(pubKey) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Contains reports whether the public key is the key of an attester.
+func (s *Set) Contains(pubKey crypto.PubKey) bool {
+	return s.index(pubKey) >= 0
+}
@@ -0,0 +1,271 @@
+// Package blockdata serves the data of stored blocks to peers on request, so that nodes which received the
+// headers of blocks but not their data, e.g. nodes joining after the data was gossiped, can sync from peers.
+package blockdata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-msgio/pbio"
+	"golang.org/x/time/rate"
+
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+const (
+	// maxMessageSize is the maximum size of a block data protocol message.
+	maxMessageSize = 64 * 1024 * 1024
+
+	// streamTimeout is the deadline of a single block data request.
+	streamTimeout = 10 * time.Second
+
+	// peerQuotaTTL is the time after which the quota of an idle peer is forgotten.
+	peerQuotaTTL = 3 * time.Minute
+)
+
+var (
+	// ErrNotFound is returned by peers which do not store the requested block.
+	ErrNotFound = errors.New("block data not found")
+	// ErrRateLimited is returned by peers when a request exceeds their rate limit or the quota of the requesting peer.
+	ErrRateLimited = errors.New("block data request rate limited")
+)
+
+// Limits protects the node serving block data against peers flooding it with requests. Zero values disable the
+// corresponding limit.
+type Limits struct {
+	// Requests is the number of requests per second served over all peers.
+	Requests float64
+	// RequestsPerPeer is the quota of requests per second served to each peer.
+	RequestsPerPeer float64
+	// Burst is the number of requests served in a burst above the rates, at least 1.
+	Burst int
+}
+
+// Service serves the data of the blocks of the store to peers and fetches block data from peers using a libp2p
+// stream protocol.
+type Service struct {
+	host     host.Host
+	protocol protocol.ID
+	store    store.Store
+	limits   Limits
+	global   *rate.Limiter
+	logger   log.Logger
+
+	// reportInvalid is called with the peers serving data not matching the requested header
+	reportInvalid func(peer.ID)
+
+	mu        sync.Mutex
+	peers     map[peer.ID]*peerQuota
+	lastSweep time.Time
+}
+
+// peerQuota is the rate limiter of the requests of a peer.
+type peerQuota struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewService creates a block data Service for the given chain, serving the blocks of the store within the limits.
+func NewService(host host.Host, chainID string, store store.Store, limits Limits, logger log.Logger) *Service {
+	limits.Burst = max(limits.Burst, 1)
+	s := &Service{
+		host:     host,
+		protocol: ProtocolID(chainID),
+		store:    store,
+		limits:   limits,
+		logger:   logger,
+		peers:    make(map[peer.ID]*peerQuota),
+	}
+	if limits.Requests > 0 {
+		s.global = rate.NewLimiter(rate.Limit(limits.Requests), limits.Burst)
+	}
+	return s
+}
+
+// ProtocolID returns the libp2p protocol ID of the block data protocol for the given chain.
+func ProtocolID(chainID string) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/%s/blockdata/v0.0.1", chainID))
+}
+
+// SetInvalidDataReporter sets the function called with the peers serving block data which does not match the
+// header it was requested for, e.g. to lower their score.
+func (s *Service) SetInvalidDataReporter(report func(peer.ID)) {
+	s.reportInvalid = report
+}
+
+// Start registers the block data protocol handler on the host.
+func (s *Service) Start() {
+	s.host.SetStreamHandler(s.protocol, s.handleStream)
+}
+
+// Stop removes the block data protocol handler from the host.
+func (s *Service) Stop() {
+	s.host.RemoveStreamHandler(s.protocol)
+}
+
+func (s *Service) handleStream(stream network.Stream) {
+	defer stream.Close() //nolint:errcheck
+	_ = stream.SetDeadline(time.Now().Add(streamTimeout))
+
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	remote := stream.Conn().RemotePeer()
+	req := new(pb.BlockDataRequest)
+	if err := pbio.NewDelimitedReader(stream, maxMessageSize).ReadMsg(req); err != nil {
+		s.logger.Debug("failed to read block data request", "peer", remote, "error", err)
+		_ = stream.Reset()
+		return
+	}
+
+	var resp *pb.BlockDataResponse
+	if s.allow(remote, time.Now()) {
+		resp = s.handleRequest(ctx, req)
+	} else {
+		s.logger.Debug("rate limited block data request", "peer", remote)
+		resp = &pb.BlockDataResponse{Error: ErrRateLimited.Error()}
+	}
+	if err := pbio.NewDelimitedWriter(stream).WriteMsg(resp); err != nil {
+		s.logger.Debug("failed to write block data response", "peer", remote, "error", err)
+		_ = stream.Reset()
+	}
+}
+
+// allow reports whether a request of the peer is allowed by its quota and the rate limit of the service. The
+// quota of the peer is checked first, so that requests of a flooding peer do not consume the global rate.
+func (s *Service) allow(id peer.ID, now time.Time) bool {
+	if s.limits.RequestsPerPeer > 0 && !s.allowPeer(id, now) {
+		return false
+	}
+	return s.global == nil || s.global.AllowN(now, 1)
+}
+
+func (s *Service) allowPeer(id peer.ID, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastSweep) > peerQuotaTTL {
+		for p, q := range s.peers {
+			if now.Sub(q.lastSeen) > peerQuotaTTL {
+				delete(s.peers, p)
+			}
+		}
+		s.lastSweep = now
+	}
+	q, ok := s.peers[id]
+	if !ok {
+		q = &peerQuota{limiter: rate.NewLimiter(rate.Limit(s.limits.RequestsPerPeer), s.limits.Burst)}
+		s.peers[id] = q
+	}
+	q.lastSeen = now
+	return q.limiter.AllowN(now, 1)
+}
+
+func (s *Service) handleRequest(ctx context.Context, req *pb.BlockDataRequest) *pb.BlockDataResponse {
+	var (
+		data *types.Data
+		err  error
+	)
+	switch id := req.Identifier.(type) {
+	case *pb.BlockDataRequest_Height:
+		_, data, err = s.store.GetBlockData(ctx, id.Height)
+	case *pb.BlockDataRequest_Hash:
+		_, data, err = s.store.GetBlockByHash(ctx, id.Hash)
+	default:
+		return &pb.BlockDataResponse{Error: "block height or hash required"}
+	}
+	if err != nil {
+		// blocks may be missing because they were pruned or not synced yet
+		return &pb.BlockDataResponse{Error: ErrNotFound.Error()}
+	}
+	return &pb.BlockDataResponse{Data: data.ToProto()}
+}
+
+// Fetch requests the data of the block of the header from the connected peers, until a peer serves data matching
+// the header. Peers serving data which does not match are reported as serving invalid data.
+func (s *Service) Fetch(ctx context.Context, header *types.SignedHeader) (*types.Data, error) {
+	var errs error
+	for _, p := range s.host.Network().Peers() {
+		data, err := s.fetchFromPeer(ctx, p, header)
+		if err == nil {
+			return data, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		s.logger.Debug("failed to fetch block data from peer", "peer", p, "height", header.Height(), "error", err)
+		errs = errors.Join(errs, fmt.Errorf("peer %s: %w", p, err))
+	}
+	if errs == nil {
+		return nil, errors.New("no peers to fetch block data from")
+	}
+	return nil, errs
+}
+
+func (s *Service) fetchFromPeer(ctx context.Context, p peer.ID, header *types.SignedHeader) (*types.Data, error) {
+	resp, err := s.request(ctx, p, &pb.BlockDataRequest{Identifier: &pb.BlockDataRequest_Hash{Hash: header.Hash()}})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, errors.New("empty block data")
+	}
+	data := new(types.Data)
+	if err := data.FromProto(resp.Data); err != nil {
+		return nil, err
+	}
+	if err := types.Validate(header, data); err != nil {
+		if s.reportInvalid != nil {
+			s.reportInvalid(p)
+		}
+		return nil, err
+	}
+	return data, nil
+}
+
+func (s *Service) request(ctx context.Context, p peer.ID, req *pb.BlockDataRequest) (*pb.BlockDataResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, streamTimeout)
+	defer cancel()
+
+	stream, err := s.host.NewStream(ctx, p, s.protocol)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close() //nolint:errcheck
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = stream.SetDeadline(deadline)
+	}
+
+	if err := pbio.NewDelimitedWriter(stream).WriteMsg(req); err != nil {
+		_ = stream.Reset()
+		return nil, err
+	}
+	if err := stream.CloseWrite(); err != nil {
+		_ = stream.Reset()
+		return nil, err
+	}
+	resp := new(pb.BlockDataResponse)
+	if err := pbio.NewDelimitedReader(stream, maxMessageSize).ReadMsg(resp); err != nil {
+		_ = stream.Reset()
+		return nil, err
+	}
+	switch resp.Error {
+	case "":
+		return resp, nil
+	case ErrNotFound.Error():
+		return nil, ErrNotFound
+	case ErrRateLimited.Error():
+		return nil, ErrRateLimited
+	default:
+		return nil, errors.New(resp.Error)
+	}
+}
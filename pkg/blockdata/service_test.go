@@ -0,0 +1,89 @@
+package blockdata
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+const testChainID = "blockdata-test"
+
+func newTestStore(t *testing.T) store.Store {
+	t.Helper()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	return store.New(kv)
+}
+
+func TestServiceFetch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	defer mnet.Close() //nolint:errcheck
+	hosts := mnet.Hosts()
+
+	servingStore := newTestStore(t)
+	header, data := types.GetRandomBlock(1, 3, testChainID)
+	require.NoError(t, servingStore.SaveBlockData(ctx, header, data, &header.Signature))
+	// the data stored for the second block does not match its header
+	badHeader, _ := types.GetRandomBlock(2, 3, testChainID)
+	_, otherData := types.GetRandomBlock(2, 3, testChainID)
+	require.NoError(t, servingStore.SaveBlockData(ctx, badHeader, otherData, &badHeader.Signature))
+
+	server := NewService(hosts[0], testChainID, servingStore, Limits{RequestsPerPeer: 0.001, Burst: 3}, log.NewNopLogger())
+	server.Start()
+	defer server.Stop()
+
+	client := NewService(hosts[1], testChainID, newTestStore(t), Limits{}, log.NewNopLogger())
+	var reported []peer.ID
+	client.SetInvalidDataReporter(func(id peer.ID) {
+		reported = append(reported, id)
+	})
+
+	fetched, err := client.Fetch(ctx, header)
+	require.NoError(t, err)
+	assert.Equal(t, data.Txs, fetched.Txs)
+	assert.Equal(t, data.Height(), fetched.Height())
+
+	_, err = client.Fetch(ctx, badHeader)
+	require.Error(t, err)
+	assert.Equal(t, []peer.ID{hosts[0].ID()}, reported)
+
+	// blocks not stored by the peer are not found
+	missing, _ := types.GetRandomBlock(3, 1, testChainID)
+	_, err = client.fetchFromPeer(ctx, hosts[0].ID(), missing)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// the quota of the client is used up
+	_, err = client.fetchFromPeer(ctx, hosts[0].ID(), header)
+	assert.ErrorIs(t, err, ErrRateLimited)
+}
+
+func TestServiceLimits(t *testing.T) {
+	s := NewService(nil, testChainID, nil, Limits{Requests: 1, RequestsPerPeer: 1, Burst: 2}, log.NewNopLogger())
+	now := time.Now()
+
+	// the quota of a peer does not consume the quota of other peers
+	assert.True(t, s.allow("a", now))
+	assert.True(t, s.allow("a", now))
+	assert.False(t, s.allow("a", now))
+
+	// requests of all peers are limited by the global rate
+	assert.False(t, s.allow("b", now))
+	assert.True(t, s.allow("b", now.Add(time.Second)))
+
+	// quotas of idle peers are forgotten
+	s.allow("c", now.Add(2*peerQuotaTTL))
+	assert.NotContains(t, s.peers, peer.ID("a"))
+}
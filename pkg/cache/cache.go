@@ -1,88 +1,281 @@
 package cache
 
 import (
+	"container/list"
+	"context"
+	"encoding"
+	"fmt"
+	"strconv"
 	"sync"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
 )
 
+// Bounds bounds the entries of a Cache held in memory. The oldest entries beyond the bounds are spilled to the
+// spill store of the cache, see Cache.SetSpillStore; until a spill store is set, the cache is not bounded. Zero
+// values mean no bound.
+type Bounds struct {
+	// MaxItems bounds the items, by height and by hash
+	MaxItems int
+	// MaxHashes bounds the hashes marked as seen, and the hashes marked as DA-included
+	MaxHashes int
+}
+
 // Cache is a generic cache that maintains items that are seen and hard confirmed
 type Cache[T any] struct {
-	items      *sync.Map
-	hashes     *sync.Map
-	daIncluded *sync.Map
+	items      *tier[*T]
+	hashes     *tier[bool]
+	daIncluded *tier[bool]
 }
 
 // NewCache returns a new Cache struct
 func NewCache[T any]() *Cache[T] {
 	return &Cache[T]{
-		items:      new(sync.Map),
-		hashes:     new(sync.Map),
-		daIncluded: new(sync.Map),
+		items:      newTier[*T]("items", 0, nil, nil),
+		hashes:     newTier("hashes", 0, encodeBool, decodeBool),
+		daIncluded: newTier("da-included", 0, encodeBool, decodeBool),
 	}
 }
 
+// NewBoundedCache returns a new Cache holding at most the bounds in memory once a spill store is set. The items
+// are spilled in their binary encoding.
+func NewBoundedCache[T any, PT interface {
+	*T
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}](bounds Bounds) *Cache[T] {
+	encode := func(item *T) ([]byte, error) {
+		return PT(item).MarshalBinary()
+	}
+	decode := func(bz []byte) (*T, error) {
+		item := PT(new(T))
+		return (*T)(item), item.UnmarshalBinary(bz)
+	}
+	return &Cache[T]{
+		items:      newTier("items", bounds.MaxItems, encode, decode),
+		hashes:     newTier("hashes", bounds.MaxHashes, encodeBool, decodeBool),
+		daIncluded: newTier("da-included", bounds.MaxHashes, encodeBool, decodeBool),
+	}
+}
+
+// SetSpillStore sets the store to which the entries beyond the bounds of the cache are spilled, oldest first. The
+// entries left in the store by a previous cache are deleted. The items of caches created with NewCache are not
+// spilled.
+func (c *Cache[T]) SetSpillStore(spill ds.Batching) error {
+	for _, t := range []interface{ setSpill(ds.Batching) error }{c.items, c.hashes, c.daIncluded} {
+		if err := t.setSpill(spill); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetItemByHash returns an item from the cache by hash
 func (c *Cache[T]) GetItemByHash(hash string) *T {
-	item, ok := c.items.Load(hash)
-	if !ok {
-		return nil
-	}
-	return item.(*T)
+	item, _ := c.items.load(hash)
+	return item
 }
 
 // SetItemByHash sets an item in the cache by hash
 func (c *Cache[T]) SetItemByHash(hash string, item *T) {
-	c.items.Store(hash, item)
+	c.items.store(hash, item)
 }
 
 // DeleteItemByHash deletes an item from the cache by hash
 func (c *Cache[T]) DeleteItemByHash(hash string) {
-	c.items.Delete(hash)
+	c.items.delete(hash)
 }
 
 // GetItem returns an item from the cache by height
 func (c *Cache[T]) GetItem(height uint64) *T {
-	item, ok := c.items.Load(height)
-	if !ok {
-		return nil
-	}
-	val := item.(*T)
-	return val
+	item, _ := c.items.load(height)
+	return item
 }
 
 // SetItem sets an item in the cache by height
 func (c *Cache[T]) SetItem(height uint64, item *T) {
-	c.items.Store(height, item)
+	c.items.store(height, item)
 }
 
 // DeleteItem deletes an item from the cache by height
 func (c *Cache[T]) DeleteItem(height uint64) {
-	c.items.Delete(height)
+	c.items.delete(height)
 }
 
 // IsSeen returns true if the hash has been seen
 func (c *Cache[T]) IsSeen(hash string) bool {
-	seen, ok := c.hashes.Load(hash)
-	if !ok {
-		return false
-	}
-	return seen.(bool)
+	seen, _ := c.hashes.load(hash)
+	return seen
 }
 
 // SetSeen sets the hash as seen
 func (c *Cache[T]) SetSeen(hash string) {
-	c.hashes.Store(hash, true)
+	c.hashes.store(hash, true)
 }
 
 // IsDAIncluded returns true if the hash has been DA-included
 func (c *Cache[T]) IsDAIncluded(hash string) bool {
-	daIncluded, ok := c.daIncluded.Load(hash)
-	if !ok {
-		return false
-	}
-	return daIncluded.(bool)
+	daIncluded, _ := c.daIncluded.load(hash)
+	return daIncluded
 }
 
 // SetDAIncluded sets the hash as DA-included
 func (c *Cache[T]) SetDAIncluded(hash string) {
-	c.daIncluded.Store(hash, true)
+	c.daIncluded.store(hash, true)
+}
+
+// DeleteDAIncluded marks the hash as no longer DA-included
+func (c *Cache[T]) DeleteDAIncluded(hash string) {
+	c.daIncluded.delete(hash)
+}
+
+func encodeBool(bool) ([]byte, error) { return []byte{1}, nil }
+
+func decodeBool([]byte) (bool, error) { return true, nil }
+
+// tier holds the entries of a map in memory up to a bound, spilling the oldest entries beyond it to the spill
+// store. Entries are keyed by height or by hash.
+type tier[V any] struct {
+	mu sync.Mutex
+	// name namespaces the entries of the tier in the spill store
+	name    string
+	max     int
+	encode  func(V) ([]byte, error)
+	decode  func([]byte) (V, error)
+	entries map[any]*list.Element
+	// order holds the entries in memory, oldest first
+	order *list.List
+	spill ds.Batching
+	// spilled counts the entries written to the spill store and not deleted from it, an upper bound of the entries
+	// in the store, so that the store is not queried while it is empty
+	spilled int
+}
+
+// entry is an entry of a tier held in memory.
+type entry[V any] struct {
+	key   any
+	value V
+}
+
+func newTier[V any](name string, max int, encode func(V) ([]byte, error), decode func([]byte) (V, error)) *tier[V] {
+	return &tier[V]{
+		name:    name,
+		max:     max,
+		encode:  encode,
+		decode:  decode,
+		entries: make(map[any]*list.Element),
+		order:   list.New(),
+	}
+}
+
+// setSpill deletes the entries of the tier left in the spill store, and spills the entries beyond the bound.
+func (t *tier[V]) setSpill(spill ds.Batching) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.encode == nil {
+		return nil
+	}
+	ctx := context.Background()
+	results, err := spill.Query(ctx, dsq.Query{Prefix: "/" + t.name, KeysOnly: true})
+	if err != nil {
+		return fmt.Errorf("failed to query the %s spilled by a previous cache: %w", t.name, err)
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return fmt.Errorf("failed to query the %s spilled by a previous cache: %w", t.name, err)
+	}
+	for _, e := range entries {
+		if err := spill.Delete(ctx, ds.NewKey(e.Key)); err != nil {
+			return fmt.Errorf("failed to delete the %s spilled by a previous cache: %w", t.name, err)
+		}
+	}
+	t.spill, t.spilled = spill, 0
+	t.evict()
+	return nil
+}
+
+// load returns the value of the key, from memory or from the spill store.
+func (t *tier[V]) load(key any) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if e, ok := t.entries[key]; ok {
+		return e.Value.(*entry[V]).value, true
+	}
+	var zero V
+	if t.spilled == 0 {
+		return zero, false
+	}
+	bz, err := t.spill.Get(context.Background(), t.spillKey(key))
+	if err != nil {
+		return zero, false
+	}
+	value, err := t.decode(bz)
+	if err != nil {
+		return zero, false
+	}
+	return value, true
+}
+
+// store sets the value of the key in memory, and spills the oldest entries beyond the bound.
+func (t *tier[V]) store(key any, value V) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if e, ok := t.entries[key]; ok {
+		e.Value.(*entry[V]).value = value
+		return
+	}
+	t.entries[key] = t.order.PushBack(&entry[V]{key: key, value: value})
+	t.evict()
+}
+
+// delete deletes the key from memory and from the spill store.
+func (t *tier[V]) delete(key any) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if e, ok := t.entries[key]; ok {
+		t.order.Remove(e)
+		delete(t.entries, key)
+	}
+	if t.spilled == 0 {
+		return
+	}
+	ctx, spillKey := context.Background(), t.spillKey(key)
+	if found, err := t.spill.Has(ctx, spillKey); err != nil || !found {
+		return
+	}
+	if err := t.spill.Delete(ctx, spillKey); err == nil {
+		t.spilled--
+	}
+}
+
+// evict spills the oldest entries beyond the bound to the spill store. Entries which cannot be spilled are kept in
+// memory.
+func (t *tier[V]) evict() {
+	if t.spill == nil || t.max <= 0 {
+		return
+	}
+	for len(t.entries) > t.max {
+		oldest := t.order.Front().Value.(*entry[V])
+		bz, err := t.encode(oldest.value)
+		if err != nil {
+			return
+		}
+		if err := t.spill.Put(context.Background(), t.spillKey(oldest.key), bz); err != nil {
+			return
+		}
+		t.order.Remove(t.order.Front())
+		delete(t.entries, oldest.key)
+		t.spilled++
+	}
+}
+
+// spillKey returns the key of an entry in the spill store.
+func (t *tier[V]) spillKey(key any) ds.Key {
+	switch key := key.(type) {
+	case uint64:
+		return ds.NewKey("/" + t.name + "/height/" + strconv.FormatUint(key, 10))
+	default:
+		return ds.NewKey(fmt.Sprintf("/%s/hash/%v", t.name, key))
+	}
 }
@@ -1,9 +1,13 @@
 package cache
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
 )
 
 // TestNewCache verifies that NewCache initializes correctly
@@ -123,3 +127,73 @@ func TestCacheConcurrency(t *testing.T) {
 
 	wg.Wait()
 }
+
+// testItem is an item spilled in its binary encoding.
+type testItem string
+
+func (i *testItem) MarshalBinary() ([]byte, error) { return []byte(*i), nil }
+
+func (i *testItem) UnmarshalBinary(bz []byte) error {
+	*i = testItem(bz)
+	return nil
+}
+
+// TestBoundedCacheSpill tests that the entries beyond the bounds are spilled to the spill store, oldest first
+func TestBoundedCacheSpill(t *testing.T) {
+	spill := dssync.MutexWrap(ds.NewMapDatastore())
+	// entries left by a previous cache are deleted
+	if err := spill.Put(context.Background(), ds.NewKey("/hashes/hash/stale"), []byte{1}); err != nil {
+		t.Fatal(err)
+	}
+
+	cache := NewBoundedCache[testItem](Bounds{MaxItems: 2, MaxHashes: 2})
+	items := []testItem{"a", "b", "c", "d"}
+	for i := range items {
+		cache.SetItem(uint64(i), &items[i])
+		cache.SetSeen(string(items[i]))
+		cache.SetDAIncluded(string(items[i]))
+	}
+	// the cache is not bounded until a spill store is set
+	if len(cache.items.entries) != 4 {
+		t.Fatalf("expected 4 items in memory, got %d", len(cache.items.entries))
+	}
+
+	if err := cache.SetSpillStore(spill); err != nil {
+		t.Fatal(err)
+	}
+	if cache.IsSeen("stale") {
+		t.Error("hash spilled by a previous cache should be deleted")
+	}
+	cache.SetItemByHash("e", &items[0])
+	for _, tier := range []int{len(cache.items.entries), len(cache.hashes.entries), len(cache.daIncluded.entries)} {
+		if tier != 2 {
+			t.Errorf("expected 2 entries in memory, got %d", tier)
+		}
+	}
+	if _, ok := cache.items.entries[uint64(0)]; ok {
+		t.Error("the oldest item should be spilled")
+	}
+
+	// spilled entries are still found
+	for i, item := range items {
+		if got := cache.GetItem(uint64(i)); got == nil || *got != item {
+			t.Errorf("GetItem(%d) = %v, want %v", i, got, item)
+		}
+		if !cache.IsSeen(string(item)) || !cache.IsDAIncluded(string(item)) {
+			t.Errorf("hash %s should be seen and DA-included", item)
+		}
+	}
+	if got := cache.GetItemByHash("e"); got == nil || *got != items[0] {
+		t.Errorf("GetItemByHash(e) = %v, want %v", got, items[0])
+	}
+
+	// spilled entries are deleted from the spill store
+	cache.DeleteItem(0)
+	cache.DeleteDAIncluded("a")
+	if cache.GetItem(0) != nil || cache.IsDAIncluded("a") {
+		t.Error("deleted entries should not be found")
+	}
+	if found, _ := spill.Has(context.Background(), ds.NewKey("/items/height/0")); found {
+		t.Error("deleted item should be deleted from the spill store")
+	}
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rollkit/rollkit/pkg/consistency"
+	rpcclient "github.com/rollkit/rollkit/pkg/rpc/client"
+)
+
+// flagSamples is the number of heights sampled by the consistency check
+const flagSamples = "samples"
+
+// NewConsistencyCheckCmd returns a command comparing the chains served by several full nodes over RPC.
+func NewConsistencyCheckCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "consistency-check [rpc-url] [rpc-url]...",
+		Short: "Check that several nodes agree on the chain",
+		Long: `Compares the header hashes, app hashes and data hashes served over RPC by the given nodes at sampled heights
+up to the latest height served by all of them, including the DA included heights of the nodes. Divergences are
+reported with the values of every node, and forks with the first height at which the headers differ.
+
+The command fails if the nodes diverge, so that it can run periodically to alert operators of redundant RPC fleets.`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			samples, err := cmd.Flags().GetInt(flagSamples)
+			if err != nil {
+				return err
+			}
+
+			nodes := make([]consistency.Node, 0, len(args))
+			for _, url := range args {
+				nodes = append(nodes, consistency.Node{Name: url, Source: rpcclient.NewClient(url)})
+			}
+			checker, err := consistency.NewChecker(nodes, samples)
+			if err != nil {
+				return err
+			}
+
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			report, err := checker.Check(ctx)
+			if report != nil {
+				cmd.Print(report.String())
+			}
+			if err != nil {
+				return fmt.Errorf("consistency check failed: %w", err)
+			}
+			if !report.Consistent() {
+				return fmt.Errorf("nodes diverge: found %d divergences", len(report.Divergences))
+			}
+			return nil
+		},
+	}
+	cmd.Flags().Int(flagSamples, consistency.DefaultSamples, "number of heights sampled between the first block and the latest common height")
+	return cmd
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsistencyCheckCmd_Unreachable(t *testing.T) {
+	// closed servers refuse connections
+	var urls []string
+	for range 2 {
+		server := httptest.NewServer(nil)
+		server.Close()
+		urls = append(urls, server.URL)
+	}
+
+	rootCmd := &cobra.Command{Use: "root"}
+	rootCmd.AddCommand(NewConsistencyCheckCmd())
+
+	output, err := executeCommandC(rootCmd, append([]string{"consistency-check", "--samples", "3"}, urls...)...)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fewer than two nodes are reachable")
+	assert.Contains(t, output, urls[0]+": unreachable")
+	assert.Contains(t, output, urls[1]+": unreachable")
+
+	_, err = executeCommandC(rootCmd, "consistency-check", urls[0])
+	require.Error(t, err)
+}
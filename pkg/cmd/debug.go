@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	rpcclient "github.com/rollkit/rollkit/pkg/rpc/client"
+	rpc "github.com/rollkit/rollkit/types/pb/rollkit/v1/v1connect"
+)
+
+const flagDebugBundleOutput = "output"
+
+// NewDebugBundleCmd returns a command capturing a debug bundle of a running node via RPC
+func NewDebugBundleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "debug-bundle",
+		Short: "Capture a debug bundle of a running node via RPC",
+		Long: `This command captures a debug bundle of a running node in the specified directory (or current directory if not specified), to attach to support tickets.
+The bundle is a gzipped tarball holding the goroutine dump, the heap profile, the runtime metrics and the recent logs of the node.
+It is written to the file given by --output, or to a file named after the capture time in the current directory.
+The node must be configured with an admin token.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nodeConfig, err := ParseConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("error parsing config: %w", err)
+			}
+
+			// Get RPC address from config
+			rpcAddress := nodeConfig.RPC.Address
+			if rpcAddress == "" {
+				return fmt.Errorf("RPC address not found in node configuration")
+			}
+
+			var opts []connect.ClientOption
+			if nodeConfig.RPC.AdminToken != "" {
+				opts = append(opts, connect.WithInterceptors(rpcclient.AdminTokenInterceptor(nodeConfig.RPC.AdminToken)))
+			}
+			adminClient := rpc.NewAdminServiceClient(
+				&http.Client{Transport: http.DefaultTransport},
+				fmt.Sprintf("http://%s", rpcAddress),
+				opts...,
+			)
+
+			resp, err := adminClient.CaptureDebugBundle(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+			if err != nil {
+				return fmt.Errorf("error calling CaptureDebugBundle RPC: %w", err)
+			}
+
+			output, err := cmd.Flags().GetString(flagDebugBundleOutput)
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				output = resp.Msg.Name
+			}
+			if err := os.WriteFile(output, resp.Msg.Bundle, 0o600); err != nil {
+				return fmt.Errorf("error writing debug bundle: %w", err)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), output)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagDebugBundleOutput, "", "file the debug bundle is written to (defaults to a file named after the capture time)")
+	return cmd
+}
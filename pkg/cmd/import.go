@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	rollconf "github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/signer"
+)
+
+const (
+	// flagProposerAddress is the hex encoded address of the sequencer of the imported chain
+	flagProposerAddress = "proposer-address"
+	// flagSignatureScheme is the signature scheme of the sequencer key of the imported chain
+	flagSignatureScheme = "signature-scheme"
+)
+
+// NewImportGenesisCmd returns a command converting the genesis exported by a Cosmos SDK chain into the rollkit
+// genesis, so that the chain continues as a rollup from the height following its last block.
+func NewImportGenesisCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import-genesis [exported-genesis-file]",
+		Short: "Import the genesis exported by a Cosmos SDK chain",
+		Long: fmt.Sprintf(`Converts the genesis exported by a Cosmos SDK chain (e.g. with "<appd> export") into the rollkit
+genesis, which continues the chain from the initial height of the export. The exported genesis is copied to
+config/%s, from which the executor loads the application state.
+
+The sequencer defaults to the single validator of the exported chain; set --%s to the address of the
+sequencer key otherwise. Run this command before init, which keeps the imported genesis.`, genesis.AppGenesisFileName, flagProposerAddress),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			homePath, err := cmd.Flags().GetString(rollconf.FlagRootDir)
+			if err != nil {
+				return fmt.Errorf("error reading home flag: %w", err)
+			}
+			proposer, err := cmd.Flags().GetString(flagProposerAddress)
+			if err != nil {
+				return err
+			}
+			scheme, err := cmd.Flags().GetString(flagSignatureScheme)
+			if err != nil {
+				return err
+			}
+
+			var proposerAddress []byte
+			if proposer != "" {
+				if proposerAddress, err = hex.DecodeString(proposer); err != nil {
+					return fmt.Errorf("invalid proposer address: %w", err)
+				}
+			}
+
+			doc, err := genesis.LoadCosmosGenesis(args[0])
+			if err != nil {
+				return err
+			}
+			gen, err := doc.ToGenesis(proposerAddress, scheme, time.Now())
+			if err != nil {
+				return fmt.Errorf("failed to convert exported genesis: %w", err)
+			}
+			if err := genesis.ImportCosmosGenesis(homePath, doc, gen); err != nil {
+				return err
+			}
+
+			cmd.Printf("Imported chain %s continuing from height %d into %s\n", gen.ChainID, gen.InitialHeight, filepath.Join(homePath, "config"))
+			return nil
+		},
+	}
+	cmd.Flags().String(flagProposerAddress, "", "hex encoded address of the sequencer (defaults to the single validator of the exported chain)")
+	cmd.Flags().String(flagSignatureScheme, signer.SchemeEd25519, "signature scheme of the sequencer key (ed25519, secp256k1, bls)")
+	return cmd
+}
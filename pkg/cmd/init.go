@@ -1,19 +1,31 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 
+	"cosmossdk.io/log"
+
 	rollconf "github.com/rollkit/rollkit/pkg/config"
 	"github.com/rollkit/rollkit/pkg/hash"
 	"github.com/rollkit/rollkit/pkg/p2p/key"
+	"github.com/rollkit/rollkit/pkg/signer"
 	"github.com/rollkit/rollkit/pkg/signer/file"
+	grpcsigner "github.com/rollkit/rollkit/pkg/signer/grpc"
 )
 
-// CreateSigner sets up the signer configuration and creates necessary files
+// CreateSigner sets up the signer configuration and creates necessary files, returning the proposer address of
+// aggregator nodes. The signature scheme of remote signers is set from their key.
 func CreateSigner(config *rollconf.Config, homePath string, passphrase string) ([]byte, error) {
-	if config.Signer.SignerType == "file" && config.Node.Aggregator {
+	if !config.Node.Aggregator {
+		return nil, nil
+	}
+
+	var s signer.Signer
+	switch config.Signer.SignerType {
+	case "file":
 		if passphrase == "" {
 			return nil, fmt.Errorf("passphrase is required when using local file signer")
 		}
@@ -25,35 +37,47 @@ func CreateSigner(config *rollconf.Config, homePath string, passphrase string) (
 
 		config.Signer.SignerPath = signerDir
 
-		signer, err := file.CreateFileSystemSigner(config.Signer.SignerPath, []byte(passphrase))
+		fileSigner, err := file.CreateFileSystemSignerWithScheme(config.Signer.SignerPath, []byte(passphrase), config.Signer.SignatureScheme)
 		if err != nil {
 			return nil, fmt.Errorf("failed to initialize signer: %w", err)
 		}
-
-		pubKey, err := signer.GetPublic()
+		s = fileSigner
+	case "grpc":
+		remoteSigner, err := grpcsigner.NewSigner(context.Background(), config.Signer, log.NewNopLogger())
 		if err != nil {
-			return nil, fmt.Errorf("failed to get public key: %w", err)
+			return nil, fmt.Errorf("failed to connect to remote signer: %w", err)
 		}
+		s = remoteSigner
+	default:
+		return nil, fmt.Errorf("unknown signer type: %s", config.Signer.SignerType)
+	}
 
-		bz, err := pubKey.Raw()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get public key raw bytes: %w", err)
-		}
+	pubKey, err := s.GetPublic()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key: %w", err)
+	}
+
+	scheme, err := signer.SchemeOf(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	config.Signer.SignatureScheme = scheme
 
-		proposerAddress := hash.SumTruncated(bz)
-		return proposerAddress, nil
-	} else if config.Signer.SignerType != "file" && config.Node.Aggregator {
-		return nil, fmt.Errorf("remote signer not implemented for aggregator nodes, use local signer instead")
+	bz, err := pubKey.Raw()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key raw bytes: %w", err)
 	}
 
-	return nil, nil
+	proposerAddress := hash.SumTruncated(bz)
+	return proposerAddress, nil
 }
 
-// LoadOrGenNodeKey creates the node key file if it doesn't exist.
-func LoadOrGenNodeKey(homePath string) error {
+// LoadOrGenNodeKey creates the node key file if it doesn't exist, encrypted with the passphrase unless it is
+// empty.
+func LoadOrGenNodeKey(homePath string, passphrase []byte) error {
 	nodeKeyFile := filepath.Join(homePath, "config")
 
-	_, err := key.LoadOrGenNodeKey(nodeKeyFile)
+	_, err := key.LoadOrGenNodeKeyWithPassphrase(nodeKeyFile, passphrase)
 	if err != nil {
 		return fmt.Errorf("failed to create node key: %w", err)
 	}
@@ -46,8 +46,8 @@ func TestCreateSigner(t *testing.T) {
 		assert.NoError(err, "signer file should exist")
 	})
 
-	// Case 3: Non-File signer, Aggregator -> Error (Remote signer not implemented)
-	t.Run("RemoteSigner_Aggregator", func(t *testing.T) {
+	// Case 3: Unknown signer, Aggregator -> Error
+	t.Run("UnknownSigner_Aggregator", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		cfg := &rollconf.Config{
 			Signer: rollconf.SignerConfig{SignerType: "remote"},
@@ -55,7 +55,7 @@ func TestCreateSigner(t *testing.T) {
 		}
 		_, err := cmd.CreateSigner(cfg, tmpDir, "")
 		require.Error(err)
-		assert.Contains(err.Error(), "remote signer not implemented")
+		assert.Contains(err.Error(), "unknown signer type")
 	})
 
 	// Case 4: Not Aggregator -> No-op (returns nil, nil)
@@ -97,7 +97,7 @@ func TestLoadOrGenNodeKey(t *testing.T) {
 	// Case 1: Key doesn't exist -> Create key
 	t.Run("GenerateKey", func(t *testing.T) {
 		tmpDir := t.TempDir()
-		err := cmd.LoadOrGenNodeKey(tmpDir)
+		err := cmd.LoadOrGenNodeKey(tmpDir, nil)
 		require.NoError(err)
 		// Check if node key file was created
 		nodeKeyPath := filepath.Join(tmpDir, "config", "node_key.json")
@@ -109,14 +109,14 @@ func TestLoadOrGenNodeKey(t *testing.T) {
 	t.Run("LoadExistingKey", func(t *testing.T) {
 		tmpDir := t.TempDir()
 		// First call creates the key
-		err := cmd.LoadOrGenNodeKey(tmpDir)
+		err := cmd.LoadOrGenNodeKey(tmpDir, nil)
 		require.NoError(err)
 		nodeKeyPath := filepath.Join(tmpDir, "config", "node_key.json")
 		stat1, err := os.Stat(nodeKeyPath)
 		require.NoError(err)
 
 		// Second call should load the existing key without error
-		err = cmd.LoadOrGenNodeKey(tmpDir)
+		err = cmd.LoadOrGenNodeKey(tmpDir, nil)
 		require.NoError(err)
 		stat2, err := os.Stat(nodeKeyPath)
 		require.NoError(err)
@@ -135,7 +135,7 @@ func TestLoadOrGenNodeKey(t *testing.T) {
 		require.NoError(err)
 		f.Close()
 
-		err = cmd.LoadOrGenNodeKey(tmpDir)
+		err = cmd.LoadOrGenNodeKey(tmpDir, nil)
 		require.Error(err)
 		// The underlying error comes from os.MkdirAll in key.LoadOrGenNodeKey
 		assert.Contains(err.Error(), "failed to create node key")
@@ -0,0 +1,478 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/spf13/cobra"
+
+	rollconf "github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/hash"
+	"github.com/rollkit/rollkit/pkg/keystore"
+	"github.com/rollkit/rollkit/pkg/p2p/key"
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/pkg/signer/file"
+)
+
+const (
+	// flagNewPassphrase is the passphrase the change-passphrase command encrypts the key with
+	//nolint:gosec
+	flagNewPassphrase = "new-passphrase"
+	// flagExportPassphrase is the passphrase encrypting exported key files
+	//nolint:gosec
+	flagExportPassphrase = "export-passphrase"
+	// flagForce allows the import command to replace an existing key
+	flagForce = "force"
+	// flagKeyScheme is the signature scheme of the signer key generated by the rotate command
+	flagKeyScheme = "scheme"
+	// flagWatermarkHeight is the height the reset-watermark command resets the signer watermark to
+	flagWatermarkHeight = "height"
+	// flagYes confirms the reset-watermark command without prompting
+	flagYes = "yes"
+
+	// keyNode and keySigner are the keys managed by the keys command
+	keyNode   = "node"
+	keySigner = "signer"
+)
+
+// NodeKeyPassphrase returns the passphrase of the node key, set with the --rollkit.p2p.key_passphrase flag or the
+// ROLLKIT_NODE_KEY_PASSPHRASE environment variable. An empty passphrase means that the node key is not encrypted.
+func NodeKeyPassphrase(cmd *cobra.Command) ([]byte, error) {
+	passphrase, err := cmd.Flags().GetString(rollconf.FlagP2PKeyPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error reading node key passphrase flag: %w", err)
+	}
+	return keystore.Passphrase(passphrase, keystore.EnvNodeKeyPassphrase), nil
+}
+
+// SignerPassphrase returns the passphrase of the file signer, set with the --rollkit.signer.passphrase flag or the
+// ROLLKIT_SIGNER_PASSPHRASE environment variable.
+func SignerPassphrase(cmd *cobra.Command) ([]byte, error) {
+	passphrase, err := cmd.Flags().GetString(rollconf.FlagSignerPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error reading passphrase flag: %w", err)
+	}
+	return keystore.Passphrase(passphrase, keystore.EnvSignerPassphrase), nil
+}
+
+// LoadNodeKey loads the node key of the node, decrypting it with the passphrase returned by NodeKeyPassphrase.
+func LoadNodeKey(cmd *cobra.Command, nodeConfig rollconf.Config) (*key.NodeKey, error) {
+	passphrase, err := NodeKeyPassphrase(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return key.LoadNodeKeyWithPassphrase(filepath.Dir(nodeConfig.ConfigPath()), passphrase)
+}
+
+// NewKeysCmd returns a command managing the node key and the key of the file signer: migrating legacy plain text
+// keys to the encrypted keystore format, changing their passphrase, rotating, exporting and importing them.
+func NewKeysCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keys",
+		Short: "Manage the node key and the signer key",
+		Long: fmt.Sprintf(`Manages the node key (config/%s) and the key of the file signer (signer.json in the signer path).
+Keys are stored encrypted with their passphrase, set with --%s and --%s or the %s and
+%s environment variables. The node must be stopped while its keys are changed.`,
+			key.NodeKeyFileName, rollconf.FlagP2PKeyPassphrase, rollconf.FlagSignerPassphrase,
+			keystore.EnvNodeKeyPassphrase, keystore.EnvSignerPassphrase),
+	}
+	cmd.PersistentFlags().String(rollconf.FlagP2PKeyPassphrase, "", "passphrase encrypting the node key (defaults to $"+keystore.EnvNodeKeyPassphrase+")")
+	cmd.PersistentFlags().String(rollconf.FlagSignerPassphrase, "", "passphrase encrypting the signer key (defaults to $"+keystore.EnvSignerPassphrase+")")
+
+	cmd.AddCommand(
+		newKeysMigrateCmd(),
+		newKeysChangePassphraseCmd(),
+		newKeysRotateCmd(),
+		newKeysExportCmd(),
+		newKeysImportCmd(),
+		newKeysResetWatermarkCmd(),
+	)
+	return cmd
+}
+
+func newKeysMigrateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "migrate",
+		Short: "Encrypt legacy plain text keys",
+		Long: `Rewrites the plain text node key and the signer key written by previous versions in the encrypted
+keystore format. Keys already in the keystore format are left unchanged.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nodeConfig, err := ParseConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("error parsing config: %w", err)
+			}
+
+			passphrase, err := NodeKeyPassphrase(cmd)
+			if err != nil {
+				return err
+			}
+			if len(passphrase) == 0 {
+				cmd.Println("No node key passphrase set, skipping the node key.")
+			} else {
+				migrated, err := key.MigrateNodeKey(filepath.Dir(nodeConfig.ConfigPath()), passphrase)
+				if err != nil {
+					return fmt.Errorf("failed to migrate node key: %w", err)
+				}
+				printMigrated(cmd, keyNode, migrated)
+			}
+
+			if nodeConfig.Signer.SignerType != "file" || !signerKeyExists(nodeConfig) {
+				return nil
+			}
+			passphrase, err = SignerPassphrase(cmd)
+			if err != nil {
+				return err
+			}
+			migrated, err := file.MigrateKey(nodeConfig.Signer.SignerPath, passphrase)
+			if err != nil {
+				return fmt.Errorf("failed to migrate signer key: %w", err)
+			}
+			printMigrated(cmd, keySigner, migrated)
+			return nil
+		},
+	}
+}
+
+func printMigrated(cmd *cobra.Command, name string, migrated bool) {
+	if migrated {
+		cmd.Printf("Encrypted the %s key.\n", name)
+	} else {
+		cmd.Printf("The %s key is already encrypted.\n", name)
+	}
+}
+
+func newKeysChangePassphraseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       "change-passphrase [node|signer]",
+		Short:     "Change the passphrase of a key",
+		Long:      "Decrypts the key with its current passphrase and encrypts it with the passphrase set with --" + flagNewPassphrase + ".",
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{keyNode, keySigner},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			newPassphrase, err := cmd.Flags().GetString(flagNewPassphrase)
+			if err != nil {
+				return err
+			}
+			if newPassphrase == "" {
+				return fmt.Errorf("--%s must be set", flagNewPassphrase)
+			}
+			k, err := newManagedKey(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			privKey, err := k.load()
+			if err != nil {
+				return err
+			}
+			if err := k.save(privKey, []byte(newPassphrase)); err != nil {
+				return err
+			}
+			cmd.Printf("Changed the passphrase of the %s key.\n", k.name)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagNewPassphrase, "", "new passphrase of the key")
+	return cmd
+}
+
+func newKeysRotateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate [node|signer]",
+		Short: "Replace a key with a newly generated key",
+		Long: `Generates a new key encrypted with the current passphrase, and moves the previous key to a backup file
+next to it. Rotating the node key changes the peer ID of the node.
+
+The sequencer key must be rotated on chain as well: submit the printed public key with
+AdminService.RotateProposerKey, and restart the aggregator once it stops at the rotation height.`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: []string{keyNode, keySigner},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, err := newManagedKey(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			scheme, err := cmd.Flags().GetString(flagKeyScheme)
+			if err != nil {
+				return err
+			}
+			if k.name == keyNode {
+				scheme = signer.SchemeEd25519
+			} else if scheme == "" {
+				scheme = k.nodeConfig.Signer.SignatureScheme
+			}
+			// the current key must be readable before it is replaced
+			if _, err := k.load(); err != nil {
+				return err
+			}
+			privKey, _, err := signer.GenerateKeyPair(scheme, rand.Reader)
+			if err != nil {
+				return err
+			}
+
+			backup := fmt.Sprintf("%s.%d.bak", k.path, time.Now().Unix())
+			if err := os.Rename(k.path, backup); err != nil {
+				return fmt.Errorf("failed to back up the %s key: %w", k.name, err)
+			}
+			if err := k.save(privKey, k.passphrase); err != nil {
+				return errors.Join(err, os.Rename(backup, k.path))
+			}
+
+			cmd.Printf("Rotated the %s key, the previous key is saved in %s.\n", k.name, backup)
+			return printPublicKey(cmd, k.name, privKey.GetPublic())
+		},
+	}
+	cmd.Flags().String(flagKeyScheme, "", "signature scheme of the new signer key (ed25519, secp256k1, bls; defaults to the configured scheme)")
+	return cmd
+}
+
+func printPublicKey(cmd *cobra.Command, name string, pubKey crypto.PubKey) error {
+	if name == keyNode {
+		cmd.Printf("Node ID: %s\n", key.PubKeyToID(pubKey))
+		return nil
+	}
+	bz, err := signer.MarshalPublicKey(pubKey)
+	if err != nil {
+		return err
+	}
+	raw, err := pubKey.Raw()
+	if err != nil {
+		return err
+	}
+	cmd.Printf("Public key: %s\n", hex.EncodeToString(bz))
+	cmd.Printf("Proposer address: %s\n", hex.EncodeToString(hash.SumTruncated(raw)))
+	return nil
+}
+
+func newKeysExportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export [node|signer] [file]",
+		Short: "Export a key to an encrypted key file",
+		Long: `Writes the key to the given file in the encrypted keystore format, encrypted with the passphrase set with
+--` + flagExportPassphrase + `, or the current passphrase of the key if empty.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, err := newManagedKey(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			passphrase, err := exportPassphrase(cmd, k)
+			if err != nil {
+				return err
+			}
+			privKey, err := k.load()
+			if err != nil {
+				return err
+			}
+			encrypted, err := keystore.Encrypt(privKey, passphrase, keystore.KDFArgon2id)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt %s key: %w", k.name, err)
+			}
+			if err := keystore.WriteFile(args[1], encrypted); err != nil {
+				return err
+			}
+			cmd.Printf("Exported the %s key to %s.\n", k.name, args[1])
+			return nil
+		},
+	}
+	cmd.Flags().String(flagExportPassphrase, "", "passphrase encrypting the key file (defaults to the passphrase of the key)")
+	return cmd
+}
+
+func newKeysImportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [node|signer] [file]",
+		Short: "Import a key from an encrypted key file",
+		Long: `Decrypts the key file written by the export command with the passphrase set with --` + flagExportPassphrase + `,
+or the current passphrase of the key if empty, and stores it encrypted with the current passphrase of the key.
+Existing keys are only replaced with --` + flagForce + `.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			k, err := newManagedKey(cmd, args[0])
+			if err != nil {
+				return err
+			}
+			force, err := cmd.Flags().GetBool(flagForce)
+			if err != nil {
+				return err
+			}
+			if _, err := os.Stat(k.path); err == nil && !force {
+				return fmt.Errorf("%s key %s already exists, set --%s to replace it", k.name, k.path, flagForce)
+			}
+			passphrase, err := exportPassphrase(cmd, k)
+			if err != nil {
+				return err
+			}
+			encrypted, err := keystore.ReadFile(args[1])
+			if err != nil {
+				return err
+			}
+			privKey, err := encrypted.Decrypt(passphrase)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt key file %s: %w", args[1], err)
+			}
+			if k.name == keyNode && encrypted.KeyType != signer.SchemeEd25519 {
+				return fmt.Errorf("node keys must be %s keys, got %s", signer.SchemeEd25519, encrypted.KeyType)
+			}
+			if err := k.save(privKey, k.passphrase); err != nil {
+				return err
+			}
+			cmd.Printf("Imported the %s key from %s.\n", k.name, args[1])
+			return printPublicKey(cmd, k.name, privKey.GetPublic())
+		},
+	}
+	cmd.Flags().String(flagExportPassphrase, "", "passphrase encrypting the key file (defaults to the passphrase of the key)")
+	cmd.Flags().Bool(flagForce, false, "replace the existing key")
+	return cmd
+}
+
+func newKeysResetWatermarkCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reset-watermark",
+		Short: "Reset the signer high-watermark (DANGEROUS)",
+		Long: `Overrides the high-watermark recording the last header signed by the aggregator, allowing headers above
+--` + flagWatermarkHeight + ` to be signed. The aggregator refuses to sign headers at or below the watermark, which
+protects against double-signing after restoring a backup or during failovers.
+
+Only reset the watermark once no other aggregator signs with the same key, and no header was signed above the
+new height, e.g. after rolling back the chain. Signing two different headers at the same height forks the chain.
+The operator must confirm the reset by typing the new height, or with --` + flagYes + `.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := cmd.Flags().GetUint64(flagWatermarkHeight)
+			if err != nil {
+				return err
+			}
+			yes, err := cmd.Flags().GetBool(flagYes)
+			if err != nil {
+				return err
+			}
+			nodeConfig, err := ParseConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("error parsing config: %w", err)
+			}
+			path := nodeConfig.SignerWatermarkPath()
+			if path == "" {
+				return errors.New("the signer watermark is disabled")
+			}
+			watermarkFile, err := signer.LoadWatermarkFile(path)
+			if err != nil {
+				return err
+			}
+			current := watermarkFile.Watermark()
+			cmd.Printf("Current signer watermark: height %d, round %d, signed at %s.\n", current.Height, current.Round, current.Timestamp)
+
+			if !yes {
+				cmd.Printf("Resetting the watermark to height %d allows signing the headers above it again.\n", height)
+				cmd.Print("Type the new height to confirm: ")
+				answer, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if err != nil && answer == "" {
+					return fmt.Errorf("failed to read confirmation: %w", err)
+				}
+				if strings.TrimSpace(answer) != strconv.FormatUint(height, 10) {
+					return errors.New("confirmation does not match the new height, the watermark was not reset")
+				}
+			}
+			if err := signer.ResetWatermark(path, height); err != nil {
+				return err
+			}
+			cmd.Printf("Reset the signer watermark to height %d.\n", height)
+			return nil
+		},
+	}
+	cmd.Flags().Uint64(flagWatermarkHeight, 0, "height of the last header considered signed")
+	cmd.Flags().Bool(flagYes, false, "confirm the reset without prompting")
+	return cmd
+}
+
+// exportPassphrase returns the passphrase of exported key files, the passphrase of the key if not set.
+func exportPassphrase(cmd *cobra.Command, k *managedKey) ([]byte, error) {
+	passphrase, err := cmd.Flags().GetString(flagExportPassphrase)
+	if err != nil {
+		return nil, err
+	}
+	if passphrase == "" {
+		if len(k.passphrase) == 0 {
+			return nil, fmt.Errorf("--%s must be set when the %s key has no passphrase", flagExportPassphrase, k.name)
+		}
+		return bytes.Clone(k.passphrase), nil
+	}
+	return []byte(passphrase), nil
+}
+
+// managedKey is a key managed by the keys command, the node key or the key of the file signer.
+type managedKey struct {
+	name       string
+	path       string
+	passphrase []byte
+	nodeConfig rollconf.Config
+}
+
+func newManagedKey(cmd *cobra.Command, name string) (*managedKey, error) {
+	nodeConfig, err := ParseConfig(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing config: %w", err)
+	}
+	k := &managedKey{name: name, nodeConfig: nodeConfig}
+	switch name {
+	case keyNode:
+		k.path = filepath.Join(filepath.Dir(nodeConfig.ConfigPath()), key.NodeKeyFileName)
+		k.passphrase, err = NodeKeyPassphrase(cmd)
+	case keySigner:
+		if nodeConfig.Signer.SignerType != "file" {
+			return nil, fmt.Errorf("the %s signer has no local key", nodeConfig.Signer.SignerType)
+		}
+		k.path = filepath.Join(nodeConfig.Signer.SignerPath, "signer.json")
+		k.passphrase, err = SignerPassphrase(cmd)
+		if err == nil && len(k.passphrase) == 0 {
+			err = errors.New("passphrase is required when using local file signer")
+		}
+	default:
+		return nil, fmt.Errorf("unknown key %q, expected %s or %s", name, keyNode, keySigner)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// load decrypts the key with its current passphrase.
+func (k *managedKey) load() (crypto.PrivKey, error) {
+	if k.name == keyNode {
+		nodeKey, err := key.LoadNodeKeyWithPassphrase(filepath.Dir(k.path), k.passphrase)
+		if err != nil {
+			return nil, err
+		}
+		return nodeKey.PrivKey, nil
+	}
+	return file.LoadPrivateKey(filepath.Dir(k.path), k.passphrase)
+}
+
+// save stores the key encrypted with the passphrase. Node keys are stored in plain text if the passphrase is
+// empty.
+func (k *managedKey) save(privKey crypto.PrivKey, passphrase []byte) error {
+	if k.name == keyNode {
+		nodeKey := &key.NodeKey{PrivKey: privKey, PubKey: privKey.GetPublic()}
+		if len(passphrase) == 0 {
+			return nodeKey.SaveAs(filepath.Dir(k.path))
+		}
+		return nodeKey.SaveEncrypted(filepath.Dir(k.path), passphrase)
+	}
+	// SavePrivateKey wipes the passphrase
+	return file.SavePrivateKey(filepath.Dir(k.path), privKey, bytes.Clone(passphrase))
+}
+
+func signerKeyExists(nodeConfig rollconf.Config) bool {
+	_, err := os.Stat(filepath.Join(nodeConfig.Signer.SignerPath, "signer.json"))
+	return err == nil
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rollconf "github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/p2p/key"
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/pkg/signer/file"
+)
+
+func TestKeysCmd(t *testing.T) {
+	home := t.TempDir()
+	configDir := filepath.Join(home, "config")
+
+	cfg := rollconf.DefaultConfig
+	cfg.RootDir = home
+	cfg.Node.Aggregator = true
+	_, err := CreateSigner(&cfg, home, "signer-pass")
+	require.NoError(t, err)
+	require.NoError(t, cfg.SaveAsYaml())
+	// legacy plain text node key
+	nodeKey, err := key.LoadOrGenNodeKey(configDir)
+	require.NoError(t, err)
+
+	keys := func(args ...string) (string, error) {
+		rootCmd := &cobra.Command{Use: "root"}
+		rollconf.AddGlobalFlags(rootCmd, "keys-test")
+		rootCmd.AddCommand(NewKeysCmd())
+		args = append([]string{"keys", "--home", home, "--" + rollconf.FlagSignerPassphrase, "signer-pass"}, args...)
+		return executeCommandC(rootCmd, args...)
+	}
+
+	t.Run("migrate", func(t *testing.T) {
+		output, err := keys("migrate", "--"+rollconf.FlagP2PKeyPassphrase, "node-pass")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Encrypted the node key.")
+		assert.Contains(t, output, "The signer key is already encrypted.")
+
+		_, err = key.LoadNodeKey(configDir)
+		assert.ErrorIs(t, err, key.ErrNodeKeyEncrypted)
+		loaded, err := key.LoadNodeKeyWithPassphrase(configDir, []byte("node-pass"))
+		require.NoError(t, err)
+		assert.Equal(t, nodeKey.ID(), loaded.ID())
+	})
+
+	t.Run("change passphrase", func(t *testing.T) {
+		_, err := keys("change-passphrase", "node", "--"+rollconf.FlagP2PKeyPassphrase, "wrong", "--new-passphrase", "new-pass")
+		require.Error(t, err)
+
+		_, err = keys("change-passphrase", "node", "--"+rollconf.FlagP2PKeyPassphrase, "node-pass", "--new-passphrase", "new-pass")
+		require.NoError(t, err)
+		loaded, err := key.LoadNodeKeyWithPassphrase(configDir, []byte("new-pass"))
+		require.NoError(t, err)
+		assert.Equal(t, nodeKey.ID(), loaded.ID())
+	})
+
+	t.Run("rotate, export and import", func(t *testing.T) {
+		privKey, err := file.LoadPrivateKey(configDir, []byte("signer-pass"))
+		require.NoError(t, err)
+		exported := filepath.Join(t.TempDir(), "signer-export.json")
+
+		_, err = keys("export", "signer", exported, "--export-passphrase", "export-pass")
+		require.NoError(t, err)
+
+		output, err := keys("rotate", "signer")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Public key:")
+		rotated, err := file.LoadPrivateKey(configDir, []byte("signer-pass"))
+		require.NoError(t, err)
+		assert.False(t, rotated.Equals(privKey))
+		backups, err := filepath.Glob(filepath.Join(configDir, "signer.json.*.bak"))
+		require.NoError(t, err)
+		assert.Len(t, backups, 1)
+
+		_, err = keys("import", "signer", exported, "--export-passphrase", "export-pass")
+		assert.ErrorContains(t, err, "already exists")
+		_, err = keys("import", "signer", exported, "--export-passphrase", "wrong", "--force")
+		require.Error(t, err)
+		_, err = keys("import", "signer", exported, "--export-passphrase", "export-pass", "--force")
+		require.NoError(t, err)
+		imported, err := file.LoadPrivateKey(configDir, []byte("signer-pass"))
+		require.NoError(t, err)
+		assert.True(t, imported.Equals(privKey))
+	})
+
+	t.Run("rotate node key", func(t *testing.T) {
+		output, err := keys("rotate", "node", "--"+rollconf.FlagP2PKeyPassphrase, "new-pass")
+		require.NoError(t, err)
+		loaded, err := key.LoadNodeKeyWithPassphrase(configDir, []byte("new-pass"))
+		require.NoError(t, err)
+		assert.NotEqual(t, nodeKey.ID(), loaded.ID())
+		assert.Contains(t, output, loaded.ID())
+		_, err = os.Stat(filepath.Join(configDir, key.NodeKeyFileName))
+		require.NoError(t, err)
+	})
+
+	t.Run("reset watermark", func(t *testing.T) {
+		path := cfg.SignerWatermarkPath()
+		require.NoError(t, signer.ResetWatermark(path, 10))
+
+		resetWatermark := func(input string, args ...string) (string, error) {
+			rootCmd := &cobra.Command{Use: "root"}
+			rollconf.AddGlobalFlags(rootCmd, "keys-test")
+			rootCmd.AddCommand(NewKeysCmd())
+			rootCmd.SetIn(strings.NewReader(input))
+			return executeCommandC(rootCmd, append([]string{"keys", "reset-watermark", "--home", home}, args...)...)
+		}
+
+		// the reset must be confirmed by typing the new height
+		_, err := resetWatermark("yes\n", "--height", "5")
+		assert.ErrorContains(t, err, "confirmation does not match")
+		watermark, err := signer.LoadWatermarkFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), watermark.Watermark().Height)
+
+		output, err := resetWatermark("5\n", "--height", "5")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Current signer watermark: height 10")
+		assert.Contains(t, output, "Reset the signer watermark to height 5.")
+		watermark, err = signer.LoadWatermarkFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), watermark.Watermark().Height)
+
+		_, err = resetWatermark("", "--height", "3", "--yes")
+		require.NoError(t, err)
+		watermark, err = signer.LoadWatermarkFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), watermark.Watermark().Height)
+	})
+
+	_, err = keys("rotate", "unknown")
+	assert.ErrorContains(t, err, "unknown key")
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	rpcclient "github.com/rollkit/rollkit/pkg/rpc/client"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+	rpc "github.com/rollkit/rollkit/types/pb/rollkit/v1/v1connect"
+)
+
+// LogLevelCmd shows or changes the log levels of a running node via RPC
+var LogLevelCmd = &cobra.Command{
+	Use:   "log-level [module=level...]",
+	Short: "Show or change the log levels of a running node via RPC",
+	Long: `This command changes the log levels of a running node in the specified directory (or current directory if not specified) without restarting it.
+Levels are given as module=level pairs, e.g. "block=debug p2p=info"; a level without a module changes the default level.
+Without arguments, the current log levels are shown.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		nodeConfig, err := ParseConfig(cmd)
+		if err != nil {
+			return fmt.Errorf("error parsing config: %w", err)
+		}
+
+		// Get RPC address from config
+		rpcAddress := nodeConfig.RPC.Address
+		if rpcAddress == "" {
+			return fmt.Errorf("RPC address not found in node configuration")
+		}
+
+		var opts []connect.ClientOption
+		if nodeConfig.RPC.AdminToken != "" {
+			opts = append(opts, connect.WithInterceptors(rpcclient.AdminTokenInterceptor(nodeConfig.RPC.AdminToken)))
+		}
+		adminClient := rpc.NewAdminServiceClient(
+			&http.Client{Transport: http.DefaultTransport},
+			fmt.Sprintf("http://%s", rpcAddress),
+			opts...,
+		)
+
+		var levels string
+		if len(args) == 0 {
+			resp, err := adminClient.GetLogLevels(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+			if err != nil {
+				return fmt.Errorf("error calling GetLogLevels RPC: %w", err)
+			}
+			levels = resp.Msg.Levels
+		} else {
+			resp, err := adminClient.SetLogLevel(context.Background(), connect.NewRequest(&pb.SetLogLevelRequest{
+				Levels: strings.Join(args, " "),
+			}))
+			if err != nil {
+				return fmt.Errorf("error calling SetLogLevel RPC: %w", err)
+			}
+			levels = resp.Msg.Levels
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), levels)
+		return nil
+	},
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"cosmossdk.io/log"
+	"github.com/spf13/cobra"
+
+	"github.com/rollkit/rollkit/block"
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/node"
+	rollconf "github.com/rollkit/rollkit/pkg/config"
+	genesispkg "github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+)
+
+const (
+	// flagReplayToHeight is the height of the last block re-executed by the replay command
+	flagReplayToHeight = "to-height"
+	// flagReplayFromDA makes the replay command retrieve the blocks from the DA layer instead of the local store
+	flagReplayFromDA = "from-da"
+	// flagReplayDAStartHeight is the DA height from which the replay command retrieves the blocks
+	flagReplayDAStartHeight = "da-start-height"
+	// flagReplayExecHome is the directory of the executor state built by the replay command
+	flagReplayExecHome = "exec-home"
+)
+
+// NewReplayCmd returns a command re-executing the blocks of the chain from the genesis against a fresh executor
+// and verifying every state root, to audit the chain or track down non-deterministic execution. newExecutor
+// creates an executor from the node configuration and dbName is the name of the datastore of the node. newDA
+// creates the DA client used to retrieve the blocks from the DA layer; if nil, blocks are only replayed from
+// the local store.
+func NewReplayCmd(
+	newExecutor func(rollconf.Config) (coreexecutor.Executor, error),
+	newDA func(context.Context, rollconf.Config, log.Logger) (coreda.DA, error),
+	dbName string,
+) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Re-execute the blocks of the chain and verify the state roots (the node must be stopped)",
+		Long: `Re-executes all blocks from the genesis against a fresh executor, whose state is kept apart from the
+state of the node, and verifies the state root after every block against the state root committed to by the
+chain. Blocks are read from the local store, or retrieved from the DA layer with --from-da. The first divergent
+height is reported and the command fails.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			toHeight, err := cmd.Flags().GetUint64(flagReplayToHeight)
+			if err != nil {
+				return err
+			}
+			fromDA, err := cmd.Flags().GetBool(flagReplayFromDA)
+			if err != nil {
+				return err
+			}
+			execHome, err := cmd.Flags().GetString(flagReplayExecHome)
+			if err != nil {
+				return err
+			}
+			if fromDA && newDA == nil {
+				return errors.New("replaying blocks from the DA layer is not supported")
+			}
+			nodeConfig, err := ParseConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("error parsing config: %w", err)
+			}
+			daStartHeight := nodeConfig.DA.StartHeight
+			if cmd.Flags().Changed(flagReplayDAStartHeight) {
+				if daStartHeight, err = cmd.Flags().GetUint64(flagReplayDAStartHeight); err != nil {
+					return err
+				}
+			}
+			logger := SetupLogger(nodeConfig.Log)
+			genesis, err := genesispkg.LoadGenesis(filepath.Join(filepath.Dir(nodeConfig.ConfigPath()), "genesis.json"))
+			if err != nil {
+				return fmt.Errorf("failed to load genesis: %w", err)
+			}
+
+			// the executor state is rebuilt from scratch, apart from the state of the node
+			if execHome == "" {
+				if execHome, err = os.MkdirTemp("", "rollkit-replay-"); err != nil {
+					return err
+				}
+				defer os.RemoveAll(execHome) //nolint:errcheck // best effort cleanup
+			} else if entries, err := os.ReadDir(execHome); err == nil && len(entries) > 0 {
+				return fmt.Errorf("executor home %s is not empty", execHome)
+			}
+			execConfig := nodeConfig
+			execConfig.RootDir = execHome
+			execConfig.DBPath = "data"
+			executor, err := newExecutor(execConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create executor: %w", err)
+			}
+
+			var source block.ReplaySource
+			if fromDA {
+				da, err := newDA(cmd.Context(), nodeConfig, logger)
+				if err != nil {
+					return fmt.Errorf("failed to create DA client: %w", err)
+				}
+				if source, err = node.NewDAReplaySource(da, nodeConfig, genesis, daStartHeight, logger); err != nil {
+					return err
+				}
+			} else {
+				datastore, err := store.NewDefaultKVStore(nodeConfig.RootDir, nodeConfig.DBPath, dbName)
+				if err != nil {
+					return err
+				}
+				defer datastore.Close() //nolint:errcheck // the datastore is only read
+				source = node.NewStoreReplaySource(datastore)
+			}
+
+			result, err := block.Replay(cmd.Context(), source, executor, genesis, toHeight, logger)
+			if err != nil {
+				return err
+			}
+			if d := result.Divergence; d != nil {
+				cmd.Printf("State root diverged at height %d: committed %X, replayed %X.\n", d.Height, d.CommittedAppHash, d.AppHash)
+				return fmt.Errorf("state root diverged at height %d", d.Height)
+			}
+			cmd.Printf("Replayed blocks up to height %d, all state roots match (app hash %X).\n", result.LastHeight, result.AppHash)
+			return nil
+		},
+	}
+	cmd.Flags().Uint64(flagReplayToHeight, 0, "height of the last block to replay (default: the head of the chain)")
+	cmd.Flags().Bool(flagReplayFromDA, false, "retrieve the blocks from the DA layer instead of the local store")
+	cmd.Flags().Uint64(flagReplayDAStartHeight, 0, "DA height from which blocks are retrieved (default: the DA start height of the node)")
+	cmd.Flags().String(flagReplayExecHome, "", "empty directory in which the executor state is rebuilt (default: a temporary directory)")
+	return cmd
+}
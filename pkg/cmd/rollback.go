@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/node"
+	rollconf "github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/store"
+)
+
+const (
+	// flagRollbackHeight is the height to which the rollback command reverts the chain
+	flagRollbackHeight = "height"
+	// flagForceUnsafe allows the rollback command to revert blocks included in the DA layer
+	flagForceUnsafe = "force-unsafe"
+)
+
+// NewRollbackCmd returns a command reverting the chain state of a stopped node to a prior height, e.g. to
+// recover from a bad upgrade or non-deterministic execution. newExecutor creates the executor of the node,
+// which must implement coreexecutor.Rollbacker, and dbName is the name of the datastore of the node.
+func NewRollbackCmd(newExecutor func(rollconf.Config) (coreexecutor.Executor, error), dbName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Roll back the chain state to a prior height (the node must be stopped)",
+		Long: `Reverts the executor state, the block store, the header and data sync stores and the transaction
+index to the given height. Blocks at or below the DA included height are part of the canonical chain: rolling
+them back is refused unless --force-unsafe is set.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := cmd.Flags().GetUint64(flagRollbackHeight)
+			if err != nil {
+				return err
+			}
+			if height == 0 {
+				return errors.New("--height must be set")
+			}
+			force, err := cmd.Flags().GetBool(flagForceUnsafe)
+			if err != nil {
+				return err
+			}
+			nodeConfig, err := ParseConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("error parsing config: %w", err)
+			}
+			logger := SetupLogger(nodeConfig.Log)
+
+			executor, err := newExecutor(nodeConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create executor: %w", err)
+			}
+			datastore, err := store.NewDefaultKVStore(nodeConfig.RootDir, nodeConfig.DBPath, dbName)
+			if err != nil {
+				return err
+			}
+			defer datastore.Close() //nolint:errcheck // the rollback is committed before closing
+
+			if err := node.Rollback(cmd.Context(), executor, datastore, height, force, logger); err != nil {
+				return err
+			}
+			cmd.Printf("Rolled back chain state to height %d.\n", height)
+			return nil
+		},
+	}
+	cmd.Flags().Uint64(flagRollbackHeight, 0, "height of the last block to keep")
+	cmd.Flags().Bool(flagForceUnsafe, false, "roll back blocks included in the DA layer (DANGEROUS: forks the node off the chain)")
+	return cmd
+}
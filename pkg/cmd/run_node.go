@@ -21,10 +21,12 @@ import (
 	"github.com/rollkit/rollkit/node"
 	rollconf "github.com/rollkit/rollkit/pkg/config"
 	genesispkg "github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/logging"
 	"github.com/rollkit/rollkit/pkg/p2p"
 	"github.com/rollkit/rollkit/pkg/p2p/key"
 	"github.com/rollkit/rollkit/pkg/signer"
 	"github.com/rollkit/rollkit/pkg/signer/file"
+	grpcsigner "github.com/rollkit/rollkit/pkg/signer/grpc"
 )
 
 // ParseConfig is an helpers that loads the node configuration and validates it.
@@ -45,44 +47,44 @@ func ParseConfig(cmd *cobra.Command) (rollconf.Config, error) {
 // It applies the following settings from the config:
 //   - Log format (text or JSON)
 //   - Log level (debug, info, warn, error)
+//   - Log levels of individual modules
 //   - Stack traces for error logs
 //
+// The levels of the returned logger can be changed while the node is running, see logging.LevelsOf.
 // The returned logger is already configured with the "module" field set to "main".
 func SetupLogger(config rollconf.LogConfig) log.Logger {
-	var logOptions []log.Option
-
-	// Configure logger format
-	if config.Format == "json" {
-		logOptions = append(logOptions, log.OutputJSONOption())
-	}
-
-	// Configure logger level
-	switch strings.ToLower(config.Level) {
-	case "debug":
-		logOptions = append(logOptions, log.LevelOption(zerolog.DebugLevel))
-	case "info":
-		logOptions = append(logOptions, log.LevelOption(zerolog.InfoLevel))
-	case "warn":
-		logOptions = append(logOptions, log.LevelOption(zerolog.WarnLevel))
-	case "error":
-		logOptions = append(logOptions, log.LevelOption(zerolog.ErrorLevel))
-	default:
-		logOptions = append(logOptions, log.LevelOption(zerolog.InfoLevel))
+	levels := logging.NewLevels(zerolog.InfoLevel)
+	// unknown log levels default to info
+	_ = levels.Set(config.Level)
+	moduleErr := levels.Set(config.ModuleLevels)
+
+	logger := logging.NewLogger(os.Stdout, levels, config.Format == "json", config.Trace).With("module", logging.ModuleMain)
+	if moduleErr != nil {
+		logger.Error("ignoring invalid module log levels", "module_levels", config.ModuleLevels, "error", moduleErr)
 	}
+	return logger
+}
 
-	// Configure stack traces
-	if config.Trace {
-		logOptions = append(logOptions, log.TraceOption(true))
+// MultiplexDA returns the DA client submitting to the DA layer at the configured address and the configured
+// additional DA backends, see coreda.Multiplexer. The DA layer at the address is primary, and the additional
+// backends are connected to with dial. primary is returned as is if no additional backends are configured.
+func MultiplexDA(config rollconf.DAConfig, primary coreda.DA, dial func(address string) (coreda.DA, error)) (coreda.DA, error) {
+	if config.Backends == "" {
+		return primary, nil
 	}
-
-	// Initialize logger with configured options
-	configuredLogger := log.NewLogger(os.Stdout)
-	if len(logOptions) > 0 {
-		configuredLogger = log.NewLogger(os.Stdout, logOptions...)
+	backends := []coreda.DA{primary}
+	for _, address := range strings.Split(config.Backends, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		backend, err := dial(address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create DA client for backend %s: %w", address, err)
+		}
+		backends = append(backends, backend)
 	}
-
-	// Add module to logger
-	return configuredLogger.With("module", "main")
+	return coreda.NewMultiplexer(config.Quorum, config.MaxFailures, backends...)
 }
 
 // StartNode handles the node startup logic
@@ -103,17 +105,22 @@ func StartNode(
 	// create a new remote signer
 	var signer signer.Signer
 	if nodeConfig.Signer.SignerType == "file" && nodeConfig.Node.Aggregator {
-		passphrase, err := cmd.Flags().GetString(rollconf.FlagSignerPassphrase)
+		passphrase, err := SignerPassphrase(cmd)
 		if err != nil {
 			return err
 		}
 
-		signer, err = file.LoadFileSystemSigner(nodeConfig.Signer.SignerPath, []byte(passphrase))
+		signer, err = file.LoadFileSystemSigner(nodeConfig.Signer.SignerPath, passphrase)
+		if err != nil {
+			return err
+		}
+	} else if nodeConfig.Signer.SignerType == "grpc" && nodeConfig.Node.Aggregator {
+		remoteSigner, err := grpcsigner.NewSigner(ctx, nodeConfig.Signer, logger)
 		if err != nil {
 			return err
 		}
-	} else if nodeConfig.Signer.SignerType == "grpc" {
-		panic("grpc remote signer not implemented")
+		go remoteSigner.Monitor(ctx)
+		signer = remoteSigner
 	} else if nodeConfig.Node.Aggregator {
 		return fmt.Errorf("unknown remote signer type: %s", nodeConfig.Signer.SignerType)
 	}
@@ -140,6 +147,7 @@ func StartNode(
 		datastore,
 		metrics,
 		logger,
+		node.WithVersion(Version),
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create node: %w", err)
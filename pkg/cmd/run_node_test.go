@@ -306,6 +306,37 @@ func TestCentralizedAddresses(t *testing.T) {
 	}
 }
 
+func TestMultiplexDA(t *testing.T) {
+	primary := coreda.NewDummyDA(100_000, 0, 0)
+	var dialed []string
+	dial := func(address string) (coreda.DA, error) {
+		dialed = append(dialed, address)
+		return coreda.NewDummyDA(100_000, 0, 0), nil
+	}
+
+	// without additional backends the primary DA client is used as is
+	da, err := MultiplexDA(rollconf.DefaultConfig.DA, primary, dial)
+	assert.NoError(t, err)
+	assert.Same(t, primary, da)
+	assert.Empty(t, dialed)
+
+	conf := rollconf.DefaultConfig.DA
+	conf.Backends = "http://da-1:7980, http://da-2:7980"
+	conf.Quorum = 2
+	da, err = MultiplexDA(conf, primary, dial)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"http://da-1:7980", "http://da-2:7980"}, dialed)
+	mux, ok := da.(*coreda.Multiplexer)
+	assert.True(t, ok)
+	assert.Len(t, mux.Unwrap(), 3)
+	assert.Same(t, primary, mux.Unwrap()[0])
+	assert.Equal(t, 2, mux.Quorum())
+
+	conf.Quorum = 4
+	_, err = MultiplexDA(conf, primary, dial)
+	assert.Error(t, err, "quorum exceeding the number of backends")
+}
+
 func TestStartNodeErrors(t *testing.T) {
 	baseCtx := context.Background()
 	logger := log.NewNopLogger() // Use NopLogger for tests unless specific logging output is needed
@@ -338,13 +369,14 @@ func TestStartNodeErrors(t *testing.T) {
 		expectPanic    bool
 	}{
 		{
-			name: "GRPCSignerPanic",
+			name: "GRPCSignerUnreachableError",
 			configModifier: func(cfg *rollconf.Config) {
 				cfg.RootDir = tmpDir // Need RootDir for ConfigPath default
 				cfg.Signer.SignerType = "grpc"
+				cfg.Signer.SignerPath = "127.0.0.1:1"
 				cfg.Node.Aggregator = true // Required for signer logic to be hit
 			},
-			expectPanic: true, // Expects panic("grpc remote signer not implemented")
+			expectedError: "failed to get public key of remote signer",
 		},
 		{
 			name: "UnknownSignerError",
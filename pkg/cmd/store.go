@@ -1,11 +1,22 @@
 package cmd
 
 import (
+	"bufio"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/spf13/cobra"
+
+	"github.com/rollkit/rollkit/node"
+	"github.com/rollkit/rollkit/pkg/store"
+)
+
+const (
+	// flagExportFromHeight is the height of the first block exported by the store-export command
+	flagExportFromHeight = "from-height"
+	// flagExportToHeight is the height of the last block exported by the store-export command
+	flagExportToHeight = "to-height"
 )
 
 // UnsafeCleanDataDir removes all contents of the specified data directory.
@@ -53,3 +64,96 @@ This operation is unsafe and cannot be undone. Use with caution!`,
 		return nil
 	},
 }
+
+// NewStoreExportCmd returns a command exporting the blocks of a stopped node to a chain archive, to migrate the
+// node to new hardware or seed other nodes out-of-band. dbName is the name of the datastore of the node.
+func NewStoreExportCmd(dbName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "store-export [archive-file]",
+		Short: "Export the blocks of the node to a chain archive (the node must be stopped)",
+		Long: `Writes the headers, data and signatures of a range of blocks of the store to a compressed and
+checksummed chain archive, which store-import loads into the store of another node.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			from, err := cmd.Flags().GetUint64(flagExportFromHeight)
+			if err != nil {
+				return err
+			}
+			to, err := cmd.Flags().GetUint64(flagExportToHeight)
+			if err != nil {
+				return err
+			}
+			nodeConfig, err := ParseConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("error parsing config: %w", err)
+			}
+			datastore, err := store.NewDefaultKVStore(nodeConfig.RootDir, nodeConfig.DBPath, dbName)
+			if err != nil {
+				return err
+			}
+			defer datastore.Close() //nolint:errcheck // the store is only read
+
+			f, err := os.Create(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to create archive: %w", err)
+			}
+			w := bufio.NewWriter(f)
+			to, err = node.ExportBlocks(cmd.Context(), datastore, from, to, w)
+			if err == nil {
+				err = w.Flush()
+			}
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				_ = os.Remove(args[0])
+				return fmt.Errorf("failed to export blocks: %w", err)
+			}
+			cmd.Printf("Exported blocks %d to %d to %s\n", from, to, args[0])
+			return nil
+		},
+	}
+	cmd.Flags().Uint64(flagExportFromHeight, 1, "height of the first block to export")
+	cmd.Flags().Uint64(flagExportToHeight, 0, "height of the last block to export (0 for the last block of the store)")
+	return cmd
+}
+
+// NewStoreImportCmd returns a command importing the blocks of a chain archive into the store of a stopped node.
+// dbName is the name of the datastore of the node.
+func NewStoreImportCmd(dbName string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "store-import [archive-file]",
+		Short: "Import the blocks of a chain archive into the store (the node must be stopped)",
+		Long: `Loads the blocks of a chain archive written by store-export into the store. Every block is validated
+and must extend the chain of the store; the checksums of the archive and the imported blocks are verified once
+all blocks are saved. An interrupted import is resumed by running the command again with the same archive,
+which skips the blocks already imported. The node executes the imported blocks when it starts, then syncs the
+following blocks over p2p or from the DA layer.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			nodeConfig, err := ParseConfig(cmd)
+			if err != nil {
+				return fmt.Errorf("error parsing config: %w", err)
+			}
+			datastore, err := store.NewDefaultKVStore(nodeConfig.RootDir, nodeConfig.DBPath, dbName)
+			if err != nil {
+				return err
+			}
+			defer datastore.Close() //nolint:errcheck // imported blocks are committed before closing
+
+			f, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open archive: %w", err)
+			}
+			defer f.Close() //nolint:errcheck // the archive is only read
+
+			res, err := node.ImportBlocks(cmd.Context(), datastore, f)
+			if err != nil {
+				return fmt.Errorf("failed to import blocks (run the command again to resume): %w", err)
+			}
+			cmd.Printf("Imported blocks %d to %d of chain %s: %d imported, %d already in store\n",
+				res.From, res.To, res.ChainID, res.Imported, res.Skipped)
+			return nil
+		},
+	}
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+
+	"github.com/rollkit/rollkit/pkg/retry"
 )
 
 const (
@@ -23,6 +25,8 @@ const (
 	FlagRootDir = "home"
 	// FlagDBPath is a flag for specifying the database path
 	FlagDBPath = "rollkit.db_path"
+	// FlagDBBackend is a flag for specifying the database backend
+	FlagDBBackend = "rollkit.db_backend"
 	// FlagChainID is a flag for specifying the chain ID
 	FlagChainID = "chain_id"
 
@@ -32,16 +36,72 @@ const (
 	FlagAggregator = "rollkit.node.aggregator"
 	// FlagLight is a flag for running the node in light mode
 	FlagLight = "rollkit.node.light"
+	// FlagLightDAVerification is a flag for verifying headers received by light nodes against the DA layer
+	FlagLightDAVerification = "rollkit.node.light_da_verification"
+	// FlagLightDASamples is a flag for specifying the number of shares sampled by light nodes in every DA block holding headers
+	FlagLightDASamples = "rollkit.node.light_da_samples"
+	// FlagLightServeHeaders is a flag for serving the headers verified by light nodes to peers
+	FlagLightServeHeaders = "rollkit.node.light_serve_headers"
+	// FlagLightServeRequests is a flag for specifying the number of header requests per second served by light nodes to peers
+	FlagLightServeRequests = "rollkit.node.light_serve_requests"
+	// FlagLightServePeerRequests is a flag for specifying the quota of header requests per second served by light nodes to each peer
+	FlagLightServePeerRequests = "rollkit.node.light_serve_peer_requests"
+	// FlagDualMode is a flag for allowing the node to switch between light and full mode while running
+	FlagDualMode = "rollkit.node.dual_mode"
+	// FlagArchive is a flag for running the node in archive mode, retaining the data of all blocks
+	FlagArchive = "rollkit.node.archive"
 	// FlagBlockTime is a flag for specifying the block time
 	FlagBlockTime = "rollkit.node.block_time"
 	// FlagTrustedHash is a flag for specifying the trusted hash
 	FlagTrustedHash = "rollkit.node.trusted_hash"
+	// FlagTrustedHeight is a flag for specifying the height of the trusted hash
+	FlagTrustedHeight = "rollkit.node.trusted_height"
 	// FlagLazyAggregator is a flag for enabling lazy aggregation mode that only produces blocks when transactions are available
 	FlagLazyAggregator = "rollkit.node.lazy_mode"
 	// FlagMaxPendingHeaders is a flag to limit and pause block production when too many headers are waiting for DA confirmation
 	FlagMaxPendingHeaders = "rollkit.node.max_pending_headers"
+	// FlagDABacklogThreshold is a flag to slow block production and reject transactions when too many headers are waiting for DA submission
+	FlagDABacklogThreshold = "rollkit.node.da_backlog_threshold"
+	// FlagAsyncExecution is a flag for enabling the production of blocks before the previous blocks are executed
+	FlagAsyncExecution = "rollkit.node.async_execution"
+	// FlagMaxExecutionLag is a flag to limit and pause block production when too many produced blocks are waiting for execution
+	FlagMaxExecutionLag = "rollkit.node.max_execution_lag"
 	// FlagLazyBlockTime is a flag for specifying the maximum interval between blocks in lazy aggregation mode
 	FlagLazyBlockTime = "rollkit.node.lazy_block_interval"
+	// FlagSlotAlignment is a flag for aligning block production to wall-clock slots of the block time
+	FlagSlotAlignment = "rollkit.node.slot_alignment"
+	// FlagBlockJitter is a flag for specifying the maximum random delay of block production
+	FlagBlockJitter = "rollkit.node.block_jitter"
+	// FlagShutdownTimeout is a flag for specifying how long in-flight DA submissions are drained on shutdown
+	FlagShutdownTimeout = "rollkit.node.shutdown_timeout"
+	// FlagLoopShutdownTimeout is a flag for specifying how long each block manager loop is given to stop on shutdown
+	FlagLoopShutdownTimeout = "rollkit.node.loop_shutdown_timeout"
+	// FlagLoopShutdownTimeouts is a flag for overriding the shutdown timeout of individual block manager loops
+	FlagLoopShutdownTimeouts = "rollkit.node.loop_shutdown_timeouts"
+	// FlagShadowExecutionDelay is a flag for specifying how many blocks the shadow executor trails the primary executor
+	FlagShadowExecutionDelay = "rollkit.node.shadow_execution_delay"
+	// FlagPreconfirmationHorizon is a flag for specifying within how many blocks the aggregator promises to include accepted transactions
+	FlagPreconfirmationHorizon = "rollkit.node.preconfirmation_horizon"
+	// FlagSequencingMode is a flag for choosing how blocks are ordered, by an aggregator or by the DA layer
+	FlagSequencingMode = "rollkit.node.sequencing_mode"
+	// FlagSequencerAddress is a flag for specifying the address of a remote sequencer network serving the gRPC sequencing API
+	FlagSequencerAddress = "rollkit.node.sequencer_address"
+	// FlagMaxBlockBytes is a flag for specifying the maximum size of the transactions of a block
+	FlagMaxBlockBytes = "rollkit.node.max_block_bytes"
+	// FlagMaxBlockGas is a flag for specifying the maximum gas of the transactions of a block
+	FlagMaxBlockGas = "rollkit.node.max_block_gas"
+	// FlagFraudProofs is a flag for enabling the detection and gossiping of fraud proofs
+	FlagFraudProofs = "rollkit.node.fraud_proofs"
+	// FlagProofDeadline is a flag for specifying how long headers wait for validity proofs before DA submission
+	FlagProofDeadline = "rollkit.node.proof_deadline"
+	// FlagMinBlockTimeDelta is a flag for specifying the minimum difference between the timestamps of consecutive blocks
+	FlagMinBlockTimeDelta = "rollkit.node.min_block_time_delta"
+	// FlagMaxClockDrift is a flag for specifying the clock drift above which the aggregator warns
+	FlagMaxClockDrift = "rollkit.node.max_clock_drift"
+	// FlagNTPServer is a flag for specifying the NTP server checking the clock of the aggregator
+	FlagNTPServer = "rollkit.node.ntp_server"
+	// FlagHaltHeight is a flag for specifying the height before which the node halts for a binary swap
+	FlagHaltHeight = "rollkit.node.halt_height"
 
 	// Data Availability configuration flags
 
@@ -53,16 +113,70 @@ const (
 	FlagDABlockTime = "rollkit.da.block_time"
 	// FlagDAGasPrice is a flag for specifying the data availability layer gas price
 	FlagDAGasPrice = "rollkit.da.gas_price"
+	// FlagDAMaxGasPrice is a flag for specifying the maximum data availability layer gas price
+	FlagDAMaxGasPrice = "rollkit.da.max_gas_price"
 	// FlagDAGasMultiplier is a flag for specifying the data availability layer gas price retry multiplier
 	FlagDAGasMultiplier = "rollkit.da.gas_multiplier"
 	// FlagDAStartHeight is a flag for specifying the data availability layer start height
 	FlagDAStartHeight = "rollkit.da.start_height"
 	// FlagDANamespace is a flag for specifying the DA namespace ID
 	FlagDANamespace = "rollkit.da.namespace"
+	// FlagDAHeaderNamespace is a flag for specifying the DA namespace ID of block headers
+	FlagDAHeaderNamespace = "rollkit.da.header_namespace"
+	// FlagDADataNamespace is a flag for specifying the DA namespace ID of block data
+	FlagDADataNamespace = "rollkit.da.data_namespace"
 	// FlagDASubmitOptions is a flag for data availability submit options
 	FlagDASubmitOptions = "rollkit.da.submit_options"
 	// FlagDAMempoolTTL is a flag for specifying the DA mempool TTL
 	FlagDAMempoolTTL = "rollkit.da.mempool_ttl"
+	// FlagDAForcedInclusionNamespace is a flag for specifying the DA namespace scanned for forced inclusion transactions
+	FlagDAForcedInclusionNamespace = "rollkit.da.forced_inclusion_namespace"
+	// FlagDAForcedInclusionDeadline is a flag for specifying the number of blocks within which forced inclusion transactions must be included
+	FlagDAForcedInclusionDeadline = "rollkit.da.forced_inclusion_deadline"
+	// FlagDACompression is a flag for specifying the codec used to compress batches submitted to the DA layer
+	FlagDACompression = "rollkit.da.compression"
+	// FlagDABlobEnvelope is a flag for wrapping the blobs submitted to the DA layer in versioned envelopes
+	FlagDABlobEnvelope = "rollkit.da.blob_envelope"
+	// FlagDASharedNamespace is a flag for sharing the DA namespaces with other chains
+	FlagDASharedNamespace = "rollkit.da.shared_namespace"
+	// FlagDAPreferRetrieval is a flag for syncing blocks from the DA layer instead of p2p
+	FlagDAPreferRetrieval = "rollkit.da.prefer_retrieval"
+	// FlagDABackfillRateLimit is a flag for specifying the maximum number of DA heights requested per second when backfilling
+	FlagDABackfillRateLimit = "rollkit.da.backfill_rate_limit"
+	// FlagDARetrieveWorkers is a flag for specifying the number of DA heights retrieved concurrently when syncing
+	FlagDARetrieveWorkers = "rollkit.da.retrieve_workers"
+	// FlagDAMaxSubmissionsInFlight is a flag for specifying the maximum number of concurrent header submissions to the DA layer
+	FlagDAMaxSubmissionsInFlight = "rollkit.da.max_submissions_in_flight"
+	// FlagDAMaxBlocksPerBlob is a flag for specifying the maximum number of blocks whose headers are bundled into a single DA blob
+	FlagDAMaxBlocksPerBlob = "rollkit.da.max_blocks_per_blob"
+	// FlagDAMaxBlobSize is a flag for specifying the maximum size of the blobs submitted to the DA layer
+	FlagDAMaxBlobSize = "rollkit.da.max_blob_size"
+	// FlagDAEpochBlocks is a flag for specifying the number of blocks of the epochs posted to the DA layer
+	FlagDAEpochBlocks = "rollkit.da.epoch_blocks"
+	// FlagDAEpochTime is a flag for specifying the maximum duration of the epochs posted to the DA layer
+	FlagDAEpochTime = "rollkit.da.epoch_time"
+	// FlagDAReorgCheckDepth is a flag for specifying the number of DA included blocks checked for DA reorgs
+	FlagDAReorgCheckDepth = "rollkit.da.reorg_check_depth"
+	// FlagDAAuditInterval is a flag for specifying the interval at which DA included blocks are re-verified
+	FlagDAAuditInterval = "rollkit.da.audit_interval"
+	// FlagDAAuditSampleSize is a flag for specifying the number of DA included blocks re-verified per audit
+	FlagDAAuditSampleSize = "rollkit.da.audit_sample_size"
+	// FlagDAGasPerByte is a flag for specifying the DA gas consumed per byte of blob, used to account for DA fees
+	FlagDAGasPerByte = "rollkit.da.gas_per_byte"
+	// FlagDADailyBudget is a flag for specifying the DA fees above which DA submissions pause for the rest of the day
+	FlagDADailyBudget = "rollkit.da.daily_budget"
+	// FlagDADryRun is a flag for simulating DA submissions without broadcasting them
+	FlagDADryRun = "rollkit.da.dry_run"
+	// FlagDACMembers is a flag for specifying the members of the data availability committee storing block data in commitments-only mode
+	FlagDACMembers = "rollkit.da.dac_members"
+	// FlagDACThreshold is a flag for specifying the number of data availability committee signatures required on every header
+	FlagDACThreshold = "rollkit.da.dac_threshold"
+	// FlagDABackends is a flag for specifying the addresses of additional DA backends blobs are submitted to
+	FlagDABackends = "rollkit.da.backends"
+	// FlagDAQuorum is a flag for specifying the number of DA backends that must include a blob
+	FlagDAQuorum = "rollkit.da.quorum"
+	// FlagDAMaxFailures is a flag for specifying the number of consecutive failures after which a DA backend is switched
+	FlagDAMaxFailures = "rollkit.da.max_failures"
 
 	// P2P configuration flags
 
@@ -74,6 +188,25 @@ const (
 	FlagP2PBlockedPeers = "rollkit.p2p.blocked_peers"
 	// FlagP2PAllowedPeers is a flag for specifying the P2P allowed peers
 	FlagP2PAllowedPeers = "rollkit.p2p.allowed_peers"
+	// FlagP2PAllowlistOnly is a flag for restricting P2P connections to the allowed peers
+	FlagP2PAllowlistOnly = "rollkit.p2p.allowlist_only"
+	// FlagP2PBanThreshold is a flag for specifying the peer score below which peers are banned
+	FlagP2PBanThreshold = "rollkit.p2p.ban_threshold"
+	// FlagP2PBanDuration is a flag for specifying how long peers banned for a low score are banned
+	FlagP2PBanDuration = "rollkit.p2p.ban_duration"
+	// FlagP2PMaxPeers is a flag for specifying the maximum number of connected peers
+	FlagP2PMaxPeers = "rollkit.p2p.max_peers"
+	// FlagP2PPeerExchange is a flag for enabling peer exchange on gossip mesh pruning
+	FlagP2PPeerExchange = "rollkit.p2p.peer_exchange"
+	// FlagP2PBlockDataRequests is a flag for specifying the number of block data requests per second served to peers
+	FlagP2PBlockDataRequests = "rollkit.p2p.block_data_requests"
+	// FlagP2PBlockDataPeerRequests is a flag for specifying the quota of block data requests per second served to each peer
+	FlagP2PBlockDataPeerRequests = "rollkit.p2p.block_data_peer_requests"
+	// FlagP2PBlockDataFetchDelay is a flag for specifying how long block data is awaited before fetching it from peers
+	FlagP2PBlockDataFetchDelay = "rollkit.p2p.block_data_fetch_delay"
+	// FlagP2PKeyPassphrase is a flag for specifying the passphrase encrypting the node key
+	//nolint:gosec
+	FlagP2PKeyPassphrase = "rollkit.p2p.key_passphrase"
 
 	// Instrumentation configuration flags
 
@@ -81,12 +214,18 @@ const (
 	FlagPrometheus = "rollkit.instrumentation.prometheus"
 	// FlagPrometheusListenAddr is a flag for specifying the Prometheus listen address
 	FlagPrometheusListenAddr = "rollkit.instrumentation.prometheus_listen_addr"
+	// FlagPrometheusPath is a flag for specifying the HTTP path of the Prometheus metrics endpoint
+	FlagPrometheusPath = "rollkit.instrumentation.prometheus_path"
 	// FlagMaxOpenConnections is a flag for specifying the maximum number of open connections
 	FlagMaxOpenConnections = "rollkit.instrumentation.max_open_connections"
 	// FlagPprof is a flag for enabling pprof profiling endpoints for runtime debugging
 	FlagPprof = "rollkit.instrumentation.pprof"
 	// FlagPprofListenAddr is a flag for specifying the pprof listen address
 	FlagPprofListenAddr = "rollkit.instrumentation.pprof_listen_addr"
+	// FlagProfileCaptureInterval is a flag for specifying the interval at which debug bundles are captured
+	FlagProfileCaptureInterval = "rollkit.instrumentation.profile_capture_interval"
+	// FlagProfileCaptureKeep is a flag for specifying the number of debug bundles kept
+	FlagProfileCaptureKeep = "rollkit.instrumentation.profile_capture_keep"
 
 	// Logging configuration flags
 
@@ -96,6 +235,8 @@ const (
 	FlagLogFormat = "rollkit.log.format"
 	// FlagLogTrace is a flag for enabling stack traces in error logs
 	FlagLogTrace = "rollkit.log.trace"
+	// FlagLogModuleLevels is a flag for specifying the log levels of individual modules
+	FlagLogModuleLevels = "rollkit.log.module_levels"
 
 	// Signer configuration flags
 
@@ -103,23 +244,181 @@ const (
 	FlagSignerType = "rollkit.signer.type"
 	// FlagSignerPath is a flag for specifying the signer path
 	FlagSignerPath = "rollkit.signer.path"
+	// FlagSignerSignatureScheme is a flag for specifying the signature scheme of the sequencer key created by init
+	FlagSignerSignatureScheme = "rollkit.signer.signature_scheme"
+	// FlagSignerTLSCAFile is a flag for specifying the CA certificate file used to verify the gRPC remote signer
+	FlagSignerTLSCAFile = "rollkit.signer.tls_ca_file"
+	// FlagSignerTLSCertFile is a flag for specifying the client certificate file used to authenticate to the gRPC remote signer
+	FlagSignerTLSCertFile = "rollkit.signer.tls_cert_file"
+	// FlagSignerTLSKeyFile is a flag for specifying the client key file used to authenticate to the gRPC remote signer
+	FlagSignerTLSKeyFile = "rollkit.signer.tls_key_file"
+	// FlagSignerTimeout is a flag for specifying the timeout of requests to the gRPC remote signer
+	FlagSignerTimeout = "rollkit.signer.timeout"
+	// FlagSignerHealthCheckInterval is a flag for specifying the interval of health checks of the gRPC remote signer
+	FlagSignerHealthCheckInterval = "rollkit.signer.health_check_interval"
+	// FlagSignerWatermarkFile is a flag for specifying the high-watermark file protecting the aggregator from double-signing
+	FlagSignerWatermarkFile = "rollkit.signer.watermark_file"
 
 	// FlagSignerPassphrase is a flag for specifying the signer passphrase
 	//nolint:gosec
 	FlagSignerPassphrase = "rollkit.signer.passphrase"
 
+	// Pruning configuration flags
+
+	// FlagPruningKeepRecent is a flag for specifying the number of recent blocks to keep when pruning
+	FlagPruningKeepRecent = "rollkit.pruning.keep_recent"
+	// FlagPruningInterval is a flag for specifying how often the pruning service runs
+	FlagPruningInterval = "rollkit.pruning.interval"
+	// FlagPruningCompactionInterval is a flag for specifying how often the datastore is compacted
+	FlagPruningCompactionInterval = "rollkit.pruning.compaction_interval"
+
+	// Snapshot configuration flags
+
+	// FlagSnapshotInterval is a flag for specifying the number of blocks between state snapshots
+	FlagSnapshotInterval = "rollkit.snapshot.interval"
+	// FlagSnapshotKeepRecent is a flag for specifying the number of snapshots to keep
+	FlagSnapshotKeepRecent = "rollkit.snapshot.keep_recent"
+	// FlagSnapshotStateSync is a flag for enabling state sync from peer snapshots on a fresh node
+	FlagSnapshotStateSync = "rollkit.snapshot.state_sync"
+
+	// Transaction indexing configuration flags
+
+	// FlagTxIndexEnabled is a flag for enabling the indexing of transactions by hash and by event
+	FlagTxIndexEnabled = "rollkit.tx_index.enabled"
+
+	// Mempool configuration flags
+
+	// FlagMempoolSize is a flag for specifying the maximum number of transactions in the mempool
+	FlagMempoolSize = "rollkit.mempool.size"
+	// FlagMempoolTTL is a flag for specifying the duration after which transactions are evicted from the mempool
+	FlagMempoolTTL = "rollkit.mempool.ttl"
+	// FlagMempoolBroadcast is a flag for enabling the gossip of mempool transactions to peers
+	FlagMempoolBroadcast = "rollkit.mempool.broadcast"
+	// FlagMempoolMaxTxBytes is a flag for specifying the maximum size of a transaction accepted by the node
+	FlagMempoolMaxTxBytes = "rollkit.mempool.max_tx_bytes"
+	// FlagMempoolTxPrefixes is a flag for specifying the payload prefixes of the transactions accepted by the node
+	FlagMempoolTxPrefixes = "rollkit.mempool.tx_prefixes"
+
+	// Cache configuration flags
+
+	// FlagCacheMaxItems is a flag for specifying the maximum number of headers and of block data held in memory
+	// while waiting to be synced
+	FlagCacheMaxItems = "rollkit.cache.max_items"
+	// FlagCacheMaxHashes is a flag for specifying the maximum number of hashes held in memory by the block caches
+	FlagCacheMaxHashes = "rollkit.cache.max_hashes"
+
+	// Telemetry configuration flags
+
+	// FlagTelemetryEnabled is a flag for enabling the periodic reporting of anonymized node metrics
+	FlagTelemetryEnabled = "rollkit.telemetry.enabled"
+	// FlagTelemetryEndpoint is a flag for specifying the URL the telemetry reports are posted to
+	FlagTelemetryEndpoint = "rollkit.telemetry.endpoint"
+	// FlagTelemetryInterval is a flag for specifying the interval between telemetry reports
+	FlagTelemetryInterval = "rollkit.telemetry.interval"
+
+	// Leader election configuration flags
+
+	// FlagLeaderElection is a flag for enabling leader election between aggregators sharing the sequencer key
+	FlagLeaderElection = "rollkit.leader.enabled"
+	// FlagLeaderLeaseBlocks is a flag for specifying the number of DA blocks a leadership lease is valid for
+	FlagLeaderLeaseBlocks = "rollkit.leader.lease_blocks"
+	// FlagLeaderNamespace is a flag for specifying the DA namespace of the lease claims
+	FlagLeaderNamespace = "rollkit.leader.namespace"
+
+	// Attestation configuration flags
+
+	// FlagAttestationAttesters is a flag for specifying the peer IDs of the attesters of the chain
+	FlagAttestationAttesters = "rollkit.attestation.attesters"
+	// FlagAttestationQuorum is a flag for specifying the number of attesters required to attest a block
+	FlagAttestationQuorum = "rollkit.attestation.quorum"
+
+	// Retry configuration flags
+
+	// FlagRetryDAInitialBackoff is a flag for specifying the delay before the first retry of DA submissions
+	FlagRetryDAInitialBackoff = "rollkit.retry.da.initial_backoff"
+	// FlagRetryDAMaxBackoff is a flag for specifying the maximum delay between retries of DA submissions
+	FlagRetryDAMaxBackoff = "rollkit.retry.da.max_backoff"
+	// FlagRetryDAMaxAttempts is a flag for specifying the maximum number of attempts of DA submissions
+	FlagRetryDAMaxAttempts = "rollkit.retry.da.max_attempts"
+	// FlagRetryDAMaxElapsedTime is a flag for specifying the maximum time spent retrying DA submissions
+	FlagRetryDAMaxElapsedTime = "rollkit.retry.da.max_elapsed_time"
+
+	// FlagRetryExecutorInitialBackoff is a flag for specifying the delay before the first retry of executor calls
+	FlagRetryExecutorInitialBackoff = "rollkit.retry.executor.initial_backoff"
+	// FlagRetryExecutorMaxBackoff is a flag for specifying the maximum delay between retries of executor calls
+	FlagRetryExecutorMaxBackoff = "rollkit.retry.executor.max_backoff"
+	// FlagRetryExecutorMaxAttempts is a flag for specifying the maximum number of attempts of executor calls
+	FlagRetryExecutorMaxAttempts = "rollkit.retry.executor.max_attempts"
+	// FlagRetryExecutorMaxElapsedTime is a flag for specifying the maximum time spent retrying executor calls
+	FlagRetryExecutorMaxElapsedTime = "rollkit.retry.executor.max_elapsed_time"
+
+	// FlagRetrySequencerInitialBackoff is a flag for specifying the delay before the first retry of sequencer requests
+	FlagRetrySequencerInitialBackoff = "rollkit.retry.sequencer.initial_backoff"
+	// FlagRetrySequencerMaxBackoff is a flag for specifying the maximum delay between retries of sequencer requests
+	FlagRetrySequencerMaxBackoff = "rollkit.retry.sequencer.max_backoff"
+	// FlagRetrySequencerMaxAttempts is a flag for specifying the maximum number of attempts of sequencer requests
+	FlagRetrySequencerMaxAttempts = "rollkit.retry.sequencer.max_attempts"
+	// FlagRetrySequencerMaxElapsedTime is a flag for specifying the maximum time spent retrying sequencer requests
+	FlagRetrySequencerMaxElapsedTime = "rollkit.retry.sequencer.max_elapsed_time"
+
+	// FlagRetrySyncInitialBackoff is a flag for specifying the delay before the first retry of sync waits
+	FlagRetrySyncInitialBackoff = "rollkit.retry.sync.initial_backoff"
+	// FlagRetrySyncMaxBackoff is a flag for specifying the maximum delay between retries of sync waits
+	FlagRetrySyncMaxBackoff = "rollkit.retry.sync.max_backoff"
+	// FlagRetrySyncMaxAttempts is a flag for specifying the maximum number of attempts of sync waits
+	FlagRetrySyncMaxAttempts = "rollkit.retry.sync.max_attempts"
+	// FlagRetrySyncMaxElapsedTime is a flag for specifying the maximum time spent retrying sync waits
+	FlagRetrySyncMaxElapsedTime = "rollkit.retry.sync.max_elapsed_time"
+
 	// RPC configuration flags
 
 	// FlagRPCAddress is a flag for specifying the RPC server address
 	FlagRPCAddress = "rollkit.rpc.address"
+	// FlagRPCGRPCAddress is a flag for specifying the gRPC server address
+	FlagRPCGRPCAddress = "rollkit.rpc.grpc_address"
+	// FlagRPCAdminToken is a flag for specifying the bearer token authenticating requests to the admin RPC service
+	FlagRPCAdminToken = "rollkit.rpc.admin_token" // #nosec G101
+	// FlagRPCTLSCertFile is a flag for specifying the certificate file used to serve the RPC over TLS
+	FlagRPCTLSCertFile = "rollkit.rpc.tls_cert_file"
+	// FlagRPCTLSKeyFile is a flag for specifying the key file of the certificate used to serve the RPC over TLS
+	FlagRPCTLSKeyFile = "rollkit.rpc.tls_key_file"
+	// FlagRPCAuthTokenFile is a flag for specifying the file listing the bearer tokens authenticating RPC write requests
+	FlagRPCAuthTokenFile = "rollkit.rpc.auth_token_file" // #nosec G101
+	// FlagRPCJWTSecretFile is a flag for specifying the file holding the secret verifying the JWTs authenticating RPC write requests
+	FlagRPCJWTSecretFile = "rollkit.rpc.jwt_secret_file" // #nosec G101
+	// FlagRPCRateLimitPerIP is a flag for specifying the number of RPC requests per second allowed per client IP
+	FlagRPCRateLimitPerIP = "rollkit.rpc.rate_limit_per_ip"
+	// FlagRPCRateLimitGlobal is a flag for specifying the number of RPC requests per second allowed over all clients
+	FlagRPCRateLimitGlobal = "rollkit.rpc.rate_limit_global"
+	// FlagRPCRateLimitBurst is a flag for specifying the number of RPC requests allowed in a burst above the rate limits
+	FlagRPCRateLimitBurst = "rollkit.rpc.rate_limit_burst"
+	// FlagRPCMaxRequestBytes is a flag for specifying the maximum size of RPC request bodies
+	FlagRPCMaxRequestBytes = "rollkit.rpc.max_request_bytes"
+	// FlagRPCTimeout is a flag for specifying the timeout for reading RPC requests and writing responses
+	FlagRPCTimeout = "rollkit.rpc.timeout"
+	// FlagRPCReadinessMaxSyncLag is a flag for specifying the maximum sync lag of a node reported as ready
+	FlagRPCReadinessMaxSyncLag = "rollkit.rpc.readiness_max_sync_lag"
+)
+
+const (
+	// SequencingModeAggregator is the sequencing mode where an aggregator orders blocks and posts them to the DA layer.
+	SequencingModeAggregator = "aggregator"
+	// SequencingModeBased is the sequencing mode where blocks are derived from the batches posted to the DA layer.
+	SequencingModeBased = "based"
+
+	// DBBackendBadger is the database backend persisting data on disk with badger.
+	DBBackendBadger = "badger"
+	// DBBackendMemory is the database backend keeping all data in memory. The data is lost when the node stops.
+	DBBackendMemory = "memory"
 )
 
 // Config stores Rollkit configuration.
 type Config struct {
 	// Base configuration
-	RootDir string `mapstructure:"-" yaml:"-" comment:"Root directory where rollkit files are located"`
-	DBPath  string `mapstructure:"db_path" yaml:"db_path" comment:"Path inside the root directory where the database is located"`
-	ChainID string `mapstructure:"chain_id" yaml:"chain_id" comment:"Chain ID for the rollup"`
+	RootDir   string `mapstructure:"-" yaml:"-" comment:"Root directory where rollkit files are located"`
+	DBPath    string `mapstructure:"db_path" yaml:"db_path" comment:"Path inside the root directory where the database is located"`
+	DBBackend string `mapstructure:"db_backend" yaml:"db_backend" comment:"Database backend: badger or memory. The memory backend keeps all data in memory and loses it when the node stops, for ephemeral devnets and tests."`
+	ChainID   string `mapstructure:"chain_id" yaml:"chain_id" comment:"Chain ID for the rollup"`
 	// P2P configuration
 	P2P P2PConfig `mapstructure:"p2p" yaml:"p2p"`
 
@@ -140,6 +439,33 @@ type Config struct {
 
 	// Remote signer configuration
 	Signer SignerConfig `mapstructure:"signer" yaml:"signer"`
+
+	// Pruning configuration
+	Pruning PruningConfig `mapstructure:"pruning" yaml:"pruning"`
+
+	// Snapshot configuration
+	Snapshot SnapshotConfig `mapstructure:"snapshot" yaml:"snapshot"`
+
+	// Transaction indexing configuration
+	TxIndex TxIndexConfig `mapstructure:"tx_index" yaml:"tx_index"`
+
+	// Leader election configuration
+	Leader LeaderConfig `mapstructure:"leader" yaml:"leader"`
+
+	// Attestation configuration
+	Attestation AttestationConfig `mapstructure:"attestation" yaml:"attestation"`
+
+	// Mempool configuration
+	Mempool MempoolConfig `mapstructure:"mempool" yaml:"mempool"`
+
+	// Retry configuration
+	Retry RetryConfig `mapstructure:"retry" yaml:"retry"`
+
+	// Cache configuration
+	Cache CacheConfig `mapstructure:"cache" yaml:"cache"`
+
+	// Telemetry configuration
+	Telemetry TelemetryConfig `mapstructure:"telemetry" yaml:"telemetry"`
 }
 
 // DAConfig contains all Data Availability configuration parameters
@@ -147,28 +473,106 @@ type DAConfig struct {
 	Address       string          `mapstructure:"address" yaml:"address" comment:"Address of the data availability layer service (host:port). This is the endpoint where Rollkit will connect to submit and retrieve data."`
 	AuthToken     string          `mapstructure:"auth_token" yaml:"auth_token" comment:"Authentication token for the data availability layer service. Required if the DA service needs authentication."`
 	GasPrice      float64         `mapstructure:"gas_price" yaml:"gas_price" comment:"Gas price for data availability transactions. Use -1 for automatic gas price determination. Higher values may result in faster inclusion."`
+	MaxGasPrice   float64         `mapstructure:"max_gas_price" yaml:"max_gas_price" comment:"Maximum gas price for data availability transactions. The gas price starts at gas_price, is multiplied by gas_multiplier when submissions are rejected as underpriced or the DA mempool is full, and decays back after successful submissions. Use 0 for no maximum."`
 	GasMultiplier float64         `mapstructure:"gas_multiplier" yaml:"gas_multiplier" comment:"Multiplier applied to gas price when retrying failed DA submissions. Values > 1 increase gas price on retries to improve chances of inclusion."`
 	SubmitOptions string          `mapstructure:"submit_options" yaml:"submit_options" comment:"Additional options passed to the DA layer when submitting data. Format depends on the specific DA implementation being used."`
 	Namespace     string          `mapstructure:"namespace" yaml:"namespace" comment:"Namespace ID used when submitting blobs to the DA layer."`
 	BlockTime     DurationWrapper `mapstructure:"block_time" yaml:"block_time" comment:"Average block time of the DA chain (duration). Determines frequency of DA layer syncing, maximum backoff time for retries, and is multiplied by MempoolTTL to calculate transaction expiration. Examples: \"15s\", \"30s\", \"1m\", \"2m30s\", \"10m\"."`
 	StartHeight   uint64          `mapstructure:"start_height" yaml:"start_height" comment:"Starting block height on the DA layer from which to begin syncing. Useful when deploying a new rollup on an existing DA chain."`
 	MempoolTTL    uint64          `mapstructure:"mempool_ttl" yaml:"mempool_ttl" comment:"Number of DA blocks after which a transaction is considered expired and dropped from the mempool. Controls retry backoff timing."`
+
+	HeaderNamespace string `mapstructure:"header_namespace" yaml:"header_namespace" comment:"Namespace ID of the block headers submitted to the DA layer. Light nodes verifying headers against the DA layer only retrieve this namespace. Leave empty to use the namespace."`
+	DataNamespace   string `mapstructure:"data_namespace" yaml:"data_namespace" comment:"Namespace ID of the block data submitted to the DA layer. Leave empty to use the namespace."`
+
+	ForcedInclusionNamespace string `mapstructure:"forced_inclusion_namespace" yaml:"forced_inclusion_namespace" comment:"Namespace ID scanned for transactions posted directly to the DA layer by users. The aggregator must include them in a block, which makes the rollup censorship resistant. Leave empty to disable forced inclusion."`
+	ForcedInclusionDeadline  uint64 `mapstructure:"forced_inclusion_deadline" yaml:"forced_inclusion_deadline" comment:"Number of blocks within which a forced inclusion transaction must be included after it was found on the DA layer. Missed deadlines are logged and reported in metrics."`
+
+	Compression     string `mapstructure:"compression" yaml:"compression" comment:"Codec used to compress batches before submitting them to the DA layer: none, gzip or zstd. The codec is recorded in every blob, so syncing nodes decompress blobs regardless of their own setting."`
+	BlobEnvelope    bool   `mapstructure:"blob_envelope" yaml:"blob_envelope" comment:"Wrap the headers and batches submitted to the DA layer in versioned envelopes recording the blob format and the chain ID. Nodes skip envelopes of versions they do not support instead of failing to sync. Enable once all nodes of the network run a release reading envelopes."`
+	SharedNamespace bool   `mapstructure:"shared_namespace" yaml:"shared_namespace" comment:"The DA namespaces are shared with other chains. Blobs are always submitted in envelopes, and retrieved blobs without an envelope are skipped since the chain they belong to is unknown. Blobs of other chains are skipped by their chain ID, and counted in the per-chain retrieval statistics."`
+
+	PreferRetrieval   bool    `mapstructure:"prefer_retrieval" yaml:"prefer_retrieval" comment:"Sync blocks from the DA layer only, ignoring headers and block data received over p2p. The node keeps backfilling ranges of DA heights instead of only doing so when it falls far behind or stops receiving blocks over p2p."`
+	BackfillRateLimit float64 `mapstructure:"backfill_rate_limit" yaml:"backfill_rate_limit" comment:"Maximum number of DA heights requested per second when backfilling headers and block data from the DA layer."`
+	RetrieveWorkers   int     `mapstructure:"retrieve_workers" yaml:"retrieve_workers" comment:"Number of DA heights fetched and decoded concurrently when syncing from the DA layer. Retrieved DA heights are still applied in order."`
+
+	MaxSubmissionsInFlight int `mapstructure:"max_submissions_in_flight" yaml:"max_submissions_in_flight" comment:"Maximum number of header submissions to the DA layer running concurrently. A new submission of the headers produced since the last one starts every DA block time while fewer submissions are in flight, so that blocks keep being submitted when the DA round trip exceeds the DA block time."`
+	MaxBlocksPerBlob       int `mapstructure:"max_blocks_per_blob" yaml:"max_blocks_per_blob" comment:"Maximum number of blocks whose headers are bundled into a single DA blob, amortizing the per-blob DA fees of small blocks. 1 submits every header in its own blob. Bundles are compressed with the DA compression codec."`
+
+	MaxBlobSize uint64 `mapstructure:"max_blob_size" yaml:"max_blob_size" comment:"Maximum size in bytes of the blobs submitted to the DA layer. Block data exceeding it is split across several blobs, reassembled by syncing nodes. The maximum blob size reported by the DA layer applies if it is lower. Use 0 to only use the limit of the DA layer."`
+
+	EpochBlocks uint64          `mapstructure:"epoch_blocks" yaml:"epoch_blocks" comment:"Post headers and block data to the DA layer in epochs rather than every DA block time: an epoch is posted once this many blocks were produced since the previous one, with the headers of the epoch bundled into a single blob and its block data in a single submission. The DA included height advances over whole epochs. Use 0 to only close epochs after epoch_time."`
+	EpochTime   DurationWrapper `mapstructure:"epoch_time" yaml:"epoch_time" comment:"Maximum duration of an epoch posted to the DA layer: the blocks produced since the previous epoch are posted once this duration elapsed, even if fewer than epoch_blocks blocks were produced. Use 0 to only close epochs after epoch_blocks blocks. Epochs are disabled if both are 0."`
+
+	ReorgCheckDepth uint64          `mapstructure:"reorg_check_depth" yaml:"reorg_check_depth" comment:"Number of the latest DA included blocks whose blobs are checked every DA block time to still be in the DA layer. If a blob is missing for two consecutive checks, the DA included height is rolled back below its block, and aggregators submit the blocks again. Use 0 to disable DA reorg detection."`
+	AuditInterval   DurationWrapper `mapstructure:"audit_interval" yaml:"audit_interval" comment:"Interval at which a random sample of the DA included blocks, down to the pruned height, is re-verified against the DA layer (duration): the blobs including every sampled block must still be retrievable, at the DA height stored for them, and match their stored commitment. Discrepancies, caused by silent DA data loss or corruption of the local store, are logged as errors and counted in metrics. Use 0 to disable the audit."`
+	AuditSampleSize uint64          `mapstructure:"audit_sample_size" yaml:"audit_sample_size" comment:"Number of DA included blocks re-verified against the DA layer every audit_interval."`
+
+	GasPerByte  uint64  `mapstructure:"gas_per_byte" yaml:"gas_per_byte" comment:"DA gas consumed per byte of blob. The DA fees paid for every submitted blob are accounted as its size times gas_per_byte times the gas price it was submitted with, and reported in metrics and by the StatusService. Use 0 to disable DA fee accounting."`
+	DailyBudget float64 `mapstructure:"daily_budget" yaml:"daily_budget" comment:"DA fees, in units of the gas price, that may be paid per UTC day. Once the fees paid during the day reach the budget, DA submissions pause until the next day or until the budget is raised, and an alert is logged and published. Blocks keep being produced and are submitted once submissions resume. Use 0 for no budget."`
+	DryRun      bool    `mapstructure:"dry_run" yaml:"dry_run" comment:"Simulate DA submissions instead of broadcasting them: the headers and batches due for submission are encoded into blobs as they would be submitted, the blobs are checked against the maximum blob size of the DA layer and decoded back as syncing nodes would, and their sizes and estimated DA fees are logged. Nothing is submitted, so the DA included height does not advance. The StatusService simulates the submission of any block in the store regardless of this setting. Useful before launching a chain and for DA fee estimation in CI."`
+
+	DACMembers   string `mapstructure:"dac_members" yaml:"dac_members" comment:"Members of the data availability committee, as comma separated pubkey@address entries where pubkey is the hex encoded public key of the member and address the address of its DACService. When set, the chain runs in commitments-only mode: only headers are posted to the DA layer, the data of blocks is stored by the committee members, and headers are only valid with the signatures of dac_threshold members attesting that they store the data. Full and light nodes verify the signatures, and full nodes fetch missing block data from the members. Leave empty to post block data to the DA layer."`
+	DACThreshold int    `mapstructure:"dac_threshold" yaml:"dac_threshold" comment:"Number of distinct data availability committee members whose signatures are required on every header of a block with transactions. Must be between 1 and the number of dac_members when the committee is set."`
+
+	Backends    string `mapstructure:"backends" yaml:"backends" comment:"Comma separated addresses of additional DA backends, using the auth token and namespaces of the DA layer at address. Blobs are submitted to the DA layer at address first and then to the following backends until quorum backends included them, and blocks are only DA included once quorum backends included them. Reads are served by the DA layer at address. Leave empty to only use the DA layer at address."`
+	Quorum      int    `mapstructure:"quorum" yaml:"quorum" comment:"Number of DA backends, among address and backends, that must include a blob for it to be considered DA included."`
+	MaxFailures int    `mapstructure:"max_failures" yaml:"max_failures" comment:"Number of consecutive failed submissions after which the DA backend submitted to first is switched to the next backend."`
 }
 
 // NodeConfig contains all Rollkit specific configuration parameters
 type NodeConfig struct {
 	// Node mode configuration
-	Aggregator bool `yaml:"aggregator" comment:"Run node in aggregator mode"`
-	Light      bool `yaml:"light" comment:"Run node in light mode"`
+	Aggregator          bool `yaml:"aggregator" comment:"Run node in aggregator mode"`
+	Light               bool `yaml:"light" comment:"Run node in light mode"`
+	LightDAVerification bool `mapstructure:"light_da_verification" yaml:"light_da_verification" comment:"Verify that headers received by a light node over p2p were published to the DA layer by the proposer, instead of trusting header gossip alone. Requires access to the DA layer."`
+	DualMode            bool `mapstructure:"dual_mode" yaml:"dual_mode" comment:"Allow the node to switch between light and full mode while running, through the AdminService, without re-initializing its directories. The node starts in light mode if light is set. Upgrading to full mode fetches and executes the blocks from the last executed block, and downgrading stops block sync to save resources. Cannot be enabled in aggregator mode."`
+	Archive             bool `mapstructure:"archive" yaml:"archive" comment:"Run node in archive mode, retaining the headers and data of all blocks to serve them and historical state queries for any height. Pruning cannot be enabled in archive mode, and a node whose blocks were already pruned cannot switch to archive mode."`
+
+	LightDASamples int `mapstructure:"light_da_samples" yaml:"light_da_samples" comment:"Number of random shares sampled by a light node in every DA block holding headers. Headers are only verified once the DA block holding them passed data availability sampling. Requires light_da_verification and a DA client supporting sampling. Use 0 to disable sampling."`
+
+	LightServeHeaders      bool    `mapstructure:"light_serve_headers" yaml:"light_serve_headers" comment:"Serve the headers verified by a light node to other light nodes and wallets over p2p, acting as a header relay, within the light_serve_requests limits. Light nodes only fetch headers from peers otherwise."`
+	LightServeRequests     float64 `mapstructure:"light_serve_requests" yaml:"light_serve_requests" comment:"Number of header requests per second served by a light node to peers over all peers, when light_serve_headers is set. Requests above the rate are refused. Use 0 for no limit."`
+	LightServePeerRequests float64 `mapstructure:"light_serve_peer_requests" yaml:"light_serve_peer_requests" comment:"Quota of header requests per second served by a light node to each peer, when light_serve_headers is set. Use 0 for no limit."`
 
 	// Block management configuration
-	BlockTime         DurationWrapper `mapstructure:"block_time" yaml:"block_time" comment:"Block time (duration). Examples: \"500ms\", \"1s\", \"5s\", \"1m\", \"2m30s\", \"10m\"."`
-	MaxPendingHeaders uint64          `mapstructure:"max_pending_headers" yaml:"max_pending_headers" comment:"Maximum number of headers pending DA submission. When this limit is reached, the aggregator pauses block production until some headers are confirmed. Use 0 for no limit."`
-	LazyMode          bool            `mapstructure:"lazy_mode" yaml:"lazy_mode" comment:"Enables lazy aggregation mode, where blocks are only produced when transactions are available or after LazyBlockTime. Optimizes resources by avoiding empty block creation during periods of inactivity."`
-	LazyBlockInterval DurationWrapper `mapstructure:"lazy_block_interval" yaml:"lazy_block_interval" comment:"Maximum interval between blocks in lazy aggregation mode (LazyAggregator). Ensures blocks are produced periodically even without transactions to keep the chain active. Generally larger than BlockTime."`
+	BlockTime            DurationWrapper `mapstructure:"block_time" yaml:"block_time" comment:"Block time (duration). Examples: \"500ms\", \"1s\", \"5s\", \"1m\", \"2m30s\", \"10m\"."`
+	MaxPendingHeaders    uint64          `mapstructure:"max_pending_headers" yaml:"max_pending_headers" comment:"Maximum number of headers pending DA submission. When this limit is reached, the aggregator pauses block production until some headers are confirmed. Use 0 for no limit."`
+	DABacklogThreshold   uint64          `mapstructure:"da_backlog_threshold" yaml:"da_backlog_threshold" comment:"Number of headers pending DA submission above which the aggregator applies backpressure: the block time grows in proportion to the backlog, and transactions submitted to the node are rejected until the backlog drains. Keeps a stalled DA layer from letting the chain run far ahead of the DA layer before max_pending_headers pauses block production. Use 0 to disable."`
+	AsyncExecution       bool            `mapstructure:"async_execution" yaml:"async_execution" comment:"Enables asynchronous execution on the aggregator. Blocks are ordered, persisted, gossiped and submitted to the DA layer before they are executed, and executed in the background in order. Headers commit to the state root of the last executed block, and record their execution lag, so that the state roots of blocks are attached to later headers. Useful for chains whose execution time exceeds the block time at peak load."`
+	MaxExecutionLag      uint64          `mapstructure:"max_execution_lag" yaml:"max_execution_lag" comment:"Maximum number of blocks produced with asynchronous execution that wait for execution. When this limit is reached, the aggregator pauses block production until execution catches up. Use 0 for no limit."`
+	LazyMode             bool            `mapstructure:"lazy_mode" yaml:"lazy_mode" comment:"Enables lazy aggregation mode, where blocks are only produced when transactions are available or after LazyBlockTime. Optimizes resources by avoiding empty block creation during periods of inactivity."`
+	LazyBlockInterval    DurationWrapper `mapstructure:"lazy_block_interval" yaml:"lazy_block_interval" comment:"Maximum interval between blocks in lazy aggregation mode (LazyAggregator). Ensures blocks are produced periodically even without transactions to keep the chain active. Generally larger than BlockTime."`
+	SlotAlignment        bool            `mapstructure:"slot_alignment" yaml:"slot_alignment" comment:"Aligns block production to wall-clock slots of block_time, e.g. the top of every second with a 1s block time, so that chains coordinating with external systems such as oracles or auctions have predictable block boundaries. Blocks are produced at the start of the next slot, and slots missed during a pause are skipped instead of being caught up. Blocks are produced block_time after the previous block otherwise."`
+	BlockJitter          DurationWrapper `mapstructure:"block_jitter" yaml:"block_jitter" comment:"Maximum random delay added to the production time of every block (duration), e.g. to spread the load of external systems reacting to blocks. With slot_alignment, blocks are produced at a random offset within the first block_jitter of their slot, which must be shorter than block_time. Use 0 to disable jitter."`
+	SequencingMode       string          `mapstructure:"sequencing_mode" yaml:"sequencing_mode" comment:"Strategy ordering the blocks of the chain: aggregator or based. In aggregator mode, the aggregator orders blocks and posts them to the DA layer. In based mode, every node derives blocks from the batches posted to the DA namespace, in DA order, and no aggregator runs."`
+	MaxBlockBytes        uint64          `mapstructure:"max_block_bytes" yaml:"max_block_bytes" comment:"Maximum total size in bytes of the transactions of a block produced by the aggregator. Transactions exceeding the limit are deferred to the next block, and batches are requested from the sequencer for the remaining capacity. Use 0 for no limit."`
+	MaxBlockGas          uint64          `mapstructure:"max_block_gas" yaml:"max_block_gas" comment:"Maximum total gas of the transactions of a block produced by the aggregator. Transactions exceeding the limit are deferred to the next block. Only enforced if the execution client reports the gas of transactions. Use 0 for no limit."`
+	FraudProofs          bool            `mapstructure:"fraud_proofs" yaml:"fraud_proofs" comment:"Enables fraud proofs. Full nodes check the state roots committed to by the sequencer against the state roots computed by re-executing blocks, gossip a fraud proof on mismatch, and halt upon detecting or receiving a valid fraud proof."`
+	ProofDeadline        DurationWrapper `mapstructure:"proof_deadline" yaml:"proof_deadline" comment:"Maximum time after block production that the header of a block waits for the validity proof of the block before being submitted to the DA layer (duration). Only used if the execution client generates validity proofs. Headers whose proof is not ready by the deadline are submitted without proof commitment. Use 0 to always wait for proofs."`
+	ShutdownTimeout      DurationWrapper `mapstructure:"shutdown_timeout" yaml:"shutdown_timeout" comment:"Maximum time spent on shutdown completing in-flight DA submissions, submitting pending headers and batches, and persisting DA inclusion state (duration). If exceeded, the node stops without recording a clean shutdown and recovers from the DA layer on restart."`
+	LoopShutdownTimeout  DurationWrapper `mapstructure:"loop_shutdown_timeout" yaml:"loop_shutdown_timeout" comment:"Maximum time each block manager loop is given to return once shutdown begins, e.g. while it is blocked in a DA call (duration). Loops still running after their timeout are logged by name and abandoned, and the node stops without draining DA submissions. Use 0 to wait for loops indefinitely."`
+	LoopShutdownTimeouts string          `mapstructure:"loop_shutdown_timeouts" yaml:"loop_shutdown_timeouts" comment:"Shutdown timeouts of individual block manager loops overriding loop_shutdown_timeout, e.g. \"header_submission=60s retrieve=5s\". Loop names are logged when loops do not stop in time."`
+
+	// Shadow execution configuration
+	ShadowExecutionDelay uint64 `mapstructure:"shadow_execution_delay" yaml:"shadow_execution_delay" comment:"Number of blocks the shadow executor trails the primary executor, when the node embedding Rollkit sets a shadow executor, e.g. a new version of the execution client validated before an upgrade. The shadow executor re-executes every block executed by the node, and state roots differing from the primary executor are logged and reported in metrics without affecting the node. Shadow execution stops at the first divergence until the node is restarted."`
+
+	// Preconfirmation configuration
+	PreconfirmationHorizon uint64 `mapstructure:"preconfirmation_horizon" yaml:"preconfirmation_horizon" comment:"Number of blocks within which the aggregator promises to include the transactions submitted to it. When set, transactions accepted by the aggregator are answered with a preconfirmation signed by the aggregator, committing to include the transaction at or below the current height plus this horizon. Broken promises are logged and reported in metrics. Use 0 to disable preconfirmations."`
+
+	// Block timestamp configuration
+	MinBlockTimeDelta DurationWrapper `mapstructure:"min_block_time_delta" yaml:"min_block_time_delta" comment:"Minimum difference between the timestamps of consecutive blocks produced by the aggregator (duration). Block timestamps proposed by the sequencer earlier than the previous block timestamp plus this delta, e.g. after a restart on a host whose clock went backwards, are raised to it, so that block timestamps strictly increase."`
+	MaxClockDrift     DurationWrapper `mapstructure:"max_clock_drift" yaml:"max_clock_drift" comment:"Drift of the host clock, measured against the NTP server if configured, and of the block timestamps proposed by the sequencer, above which the aggregator logs warnings (duration). Use 0 to disable the warnings."`
+	NTPServer         string          `mapstructure:"ntp_server" yaml:"ntp_server" comment:"Address of an NTP server (host or host:port, e.g. pool.ntp.org) against which the aggregator periodically checks the host clock. Block timestamps are corrected by the measured clock offset. Leave empty to trust the host clock."`
+
+	// Upgrade configuration
+	HaltHeight uint64 `mapstructure:"halt_height" yaml:"halt_height" comment:"Height of an upgrade before which the node halts, neither producing nor syncing the block at this height, so that its binary can be swapped. Upgrades changing protocol parameters are scheduled by the sequencer on the DA layer instead, and halt nodes whose binary does not support them. Use 0 to disable, and reset to 0 after the swap."`
 
 	// Header configuration
-	TrustedHash string `mapstructure:"trusted_hash" yaml:"trusted_hash" comment:"Initial trusted hash used to bootstrap the header exchange service. Allows nodes to start synchronizing from a specific trusted point in the chain instead of genesis. When provided, the node will fetch the corresponding header/block from peers using this hash and use it as a starting point for synchronization. If not provided, the node will attempt to fetch the genesis block instead."`
+	TrustedHash   string `mapstructure:"trusted_hash" yaml:"trusted_hash" comment:"Initial trusted hash used to bootstrap the header exchange service. Allows nodes to start synchronizing from a specific trusted point in the chain instead of genesis. When provided, the node will fetch the corresponding header/block from peers using this hash and use it as a starting point for synchronization. If not provided, the node will attempt to fetch the genesis block instead."`
+	TrustedHeight uint64 `mapstructure:"trusted_height" yaml:"trusted_height" comment:"Height of the header with the trusted hash. When set, the header is fetched by height and checked against the trusted hash, and the block data at this height is verified against the header. Headers and data below the trusted height are fetched from peers and verified backwards from it only when queried. Use 0 to look up the trusted header by hash."`
+
+	// Sequencer configuration
+	SequencerAddress string `mapstructure:"sequencer_address" yaml:"sequencer_address" comment:"Address of an external shared sequencer network serving the gRPC sequencing API (e.g. https://sequencer:7980), from which the aggregator sources ordered batches instead of its local sequencer. Requests failing because the sequencer cannot be reached are retried with exponential backoff. Leave empty to use the local sequencer."`
 }
 
 // LogConfig contains all logging configuration parameters
@@ -176,6 +580,8 @@ type LogConfig struct {
 	Level  string `mapstructure:"level" yaml:"level" comment:"Log level (debug, info, warn, error)"`
 	Format string `mapstructure:"format" yaml:"format" comment:"Log format (text, json)"`
 	Trace  bool   `mapstructure:"trace" yaml:"trace" comment:"Enable stack traces in error logs"`
+
+	ModuleLevels string `mapstructure:"module_levels" yaml:"module_levels" comment:"Log levels of individual modules overriding the log level, e.g. \"block=debug p2p=warn\". Module levels can be changed while the node is running with the SetLogLevel RPC."`
 }
 
 // P2PConfig contains all peer-to-peer networking configuration parameters
@@ -184,17 +590,139 @@ type P2PConfig struct {
 	Peers         string `mapstructure:"peers" yaml:"peers" comment:"Comma separated list of peers to connect to"`
 	BlockedPeers  string `mapstructure:"blocked_peers" yaml:"blocked_peers" comment:"Comma separated list of peer IDs to block from connecting"`
 	AllowedPeers  string `mapstructure:"allowed_peers" yaml:"allowed_peers" comment:"Comma separated list of peer IDs to allow connections from"`
+	AllowlistOnly bool   `mapstructure:"allowlist_only" yaml:"allowlist_only" comment:"Only connect to the allowed peers and the peers to connect to, restricting gossip to known operators. Allowed peers are given as peer IDs or multiaddrs. Combine with a preshared key in config/swarm.key to run a private network."`
+
+	BanThreshold float64         `mapstructure:"ban_threshold" yaml:"ban_threshold" comment:"Peer score at or below which a peer is disconnected and banned. Peers lose score for gossiping invalid headers or data, slow responses and excessive requests, and recover it over time."`
+	BanDuration  DurationWrapper `mapstructure:"ban_duration" yaml:"ban_duration" comment:"Duration for which peers are banned when their score falls to the ban threshold (duration). Peers banned through the RPC stay banned until unbanned."`
+
+	PeerExchange bool `mapstructure:"peer_exchange" yaml:"peer_exchange" comment:"Send the signed peer records of other peers to the peers pruned from a full gossip mesh, so that new nodes joining through this node find the rest of the network. Enable on seed nodes and well connected nodes."`
+
+	MaxPeers uint64 `mapstructure:"max_peers" yaml:"max_peers" comment:"Maximum number of connected peers. Connections with new peers beyond the limit are rejected, and the peers with the lowest score are disconnected when the limit is lowered. Can be changed while the node is running with the UpdateConfig RPC. Use 0 for no limit."`
+
+	BlockDataRequests     float64         `mapstructure:"block_data_requests" yaml:"block_data_requests" comment:"Number of requests per second for the data of stored blocks served to peers over all peers. Requests above the rate are refused. Use 0 for no limit."`
+	BlockDataPeerRequests float64         `mapstructure:"block_data_peer_requests" yaml:"block_data_peer_requests" comment:"Quota of requests per second for the data of stored blocks served to each peer. Use 0 for no limit."`
+	BlockDataFetchDelay   DurationWrapper `mapstructure:"block_data_fetch_delay" yaml:"block_data_fetch_delay" comment:"Time after which the data of the next block is requested from peers when its header was received but its data was neither gossiped nor retrieved from the DA layer, e.g. for nodes joining after the data was gossiped (duration). Use 0 to disable fetching block data from peers."`
 }
 
 // SignerConfig contains all signer configuration parameters
 type SignerConfig struct {
-	SignerType string `mapstructure:"signer_type" yaml:"signer_type" comment:"Type of remote signer to use (file, grpc)"`
-	SignerPath string `mapstructure:"signer_path" yaml:"signer_path" comment:"Path to the signer file or address"`
+	SignerType string `mapstructure:"signer_type" yaml:"signer_type" comment:"Type of signer to use (file, grpc)"`
+	SignerPath string `mapstructure:"signer_path" yaml:"signer_path" comment:"Path to the signer file, or address of the gRPC remote signer (e.g. https://signer:7000)"`
+
+	SignatureScheme string `mapstructure:"signature_scheme" yaml:"signature_scheme" comment:"Signature scheme of the sequencer key created by init: ed25519, secp256k1 or bls. The scheme is recorded in genesis, and full and light nodes reject headers signed with keys of another scheme."`
+
+	TLSCAFile   string `mapstructure:"tls_ca_file" yaml:"tls_ca_file" comment:"Path to the PEM encoded CA certificate used to verify the gRPC remote signer. Setting it enables TLS. Empty to use the system roots for https addresses."`
+	TLSCertFile string `mapstructure:"tls_cert_file" yaml:"tls_cert_file" comment:"Path to the PEM encoded client certificate presented to the gRPC remote signer for mutual authentication. Requires tls_key_file."`
+	TLSKeyFile  string `mapstructure:"tls_key_file" yaml:"tls_key_file" comment:"Path to the PEM encoded key of the client certificate presented to the gRPC remote signer."`
+
+	Timeout             DurationWrapper `mapstructure:"timeout" yaml:"timeout" comment:"Timeout of requests to the gRPC remote signer (duration). Examples: \"1s\", \"500ms\"."`
+	HealthCheckInterval DurationWrapper `mapstructure:"health_check_interval" yaml:"health_check_interval" comment:"Interval at which the reachability of the gRPC remote signer is checked (duration). Block production pauses while the signer is unreachable."`
+
+	WatermarkFile string `mapstructure:"watermark_file" yaml:"watermark_file" comment:"Path of the file recording the last header signed by the aggregator, relative to the root directory. Headers at or below it are never signed, protecting against double-signing after restoring a backup or during failovers. Reset it with the keys reset-watermark command. Empty to disable."`
+}
+
+// PruningConfig contains all block pruning configuration parameters
+type PruningConfig struct {
+	KeepRecent uint64          `mapstructure:"keep_recent" yaml:"keep_recent" comment:"Number of most recent blocks for which headers and data are kept. Older blocks are pruned once they are DA included, keeping only their commitments. Use 0 to disable pruning."`
+	Interval   DurationWrapper `mapstructure:"interval" yaml:"interval" comment:"Interval at which the pruning service deletes old blocks (duration). Examples: \"1m\", \"10m\", \"1h\"."`
+
+	CompactionInterval DurationWrapper `mapstructure:"compaction_interval" yaml:"compaction_interval" comment:"Interval at which the datastore is compacted, reclaiming the disk space of pruned and overwritten entries (duration). Compaction can also be triggered with the CompactStore RPC. Use 0 to disable scheduled compaction."`
+}
+
+// SnapshotConfig contains all state snapshot and state sync configuration parameters
+type SnapshotConfig struct {
+	Interval   uint64 `mapstructure:"interval" yaml:"interval" comment:"Number of blocks between state snapshots served to peers for state sync. Requires an execution client supporting snapshots. Use 0 to disable snapshot creation."`
+	KeepRecent uint64 `mapstructure:"keep_recent" yaml:"keep_recent" comment:"Number of most recent state snapshots to keep."`
+	StateSync  bool   `mapstructure:"state_sync" yaml:"state_sync" comment:"Bootstrap a fresh full node from a state snapshot fetched from peers instead of replaying all blocks. The snapshot state root is verified against the signed header committing to it before switching to normal sync."`
+}
+
+// TxIndexConfig contains all transaction indexing configuration parameters
+type TxIndexConfig struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled" comment:"Index the transactions of applied blocks by hash and by the events emitted by the execution layer, enabling the TxByHash and TxSearch RPC endpoints. Indexing runs in the background and catches up from the store after a restart."`
+}
+
+// LeaderConfig contains all aggregator leader election configuration parameters
+type LeaderConfig struct {
+	Enabled     bool   `mapstructure:"enabled" yaml:"enabled" comment:"Run the aggregator in high-availability mode. Aggregators sharing the sequencer key elect a single leader through leases posted to the DA layer; standby aggregators sync blocks and take over block production when the lease of the leader expires."`
+	LeaseBlocks uint64 `mapstructure:"lease_blocks" yaml:"lease_blocks" comment:"Number of DA blocks a leadership lease is valid for. The leader renews its lease halfway through and stops producing blocks before the lease expires."`
+	Namespace   string `mapstructure:"namespace" yaml:"namespace" comment:"Namespace ID of the lease claims posted to the DA layer, required with leader election. It must differ from the namespaces of the block headers and data, so that nodes retrieving blocks do not fetch the claims."`
+}
+
+// AttestationConfig contains all block attestation configuration parameters
+type AttestationConfig struct {
+	Attesters string `mapstructure:"attesters" yaml:"attesters" comment:"Comma separated peer IDs of the full nodes attesting blocks. Attesters sign the hash of every block they apply and gossip their attestations; blocks attested by a quorum of them are attested, a confirmation tier between soft confirmation and DA inclusion. A node listed here attests blocks with its node key. Leave empty to disable attestations."`
+	Quorum    int    `mapstructure:"quorum" yaml:"quorum" comment:"Number of attesters required to attest a block. Use 0 for more than two thirds of the attesters."`
+}
+
+// MempoolConfig contains all mempool configuration parameters
+type MempoolConfig struct {
+	Size       uint64          `mapstructure:"size" yaml:"size" comment:"Maximum number of transactions waiting in the mempool. When the mempool is full, a transaction is only admitted if its priority is higher than the lowest priority transaction, which is evicted."`
+	TTL        DurationWrapper `mapstructure:"ttl" yaml:"ttl" comment:"Duration after which transactions not included in a block are evicted from the mempool (duration). Use 0 to keep transactions until they are included."`
+	Broadcast  bool            `mapstructure:"broadcast" yaml:"broadcast" comment:"Gossip the transactions admitted to the mempool to peers, so that transactions submitted to full nodes are relayed to the aggregator."`
+	MaxTxBytes uint64          `mapstructure:"max_tx_bytes" yaml:"max_tx_bytes" comment:"Maximum size in bytes of a transaction accepted by the node. Larger transactions are rejected before reaching the executor, whether submitted over RPC, gossiped by peers or returned by the executor. Use 0 for no limit."`
+	TxPrefixes string          `mapstructure:"tx_prefixes" yaml:"tx_prefixes" comment:"Comma separated hex encoded prefixes, one of which the payload of a transaction must start with to be accepted by the node, e.g. the version byte of the transaction encoding of the chain. Leave empty to accept any payload."`
+}
+
+// CacheConfig contains the memory bounds of the caches of headers and block data of the block manager
+type CacheConfig struct {
+	MaxItems  int `mapstructure:"max_items" yaml:"max_items" comment:"Maximum number of headers, and of block data, held in memory while waiting to be synced. The oldest beyond it are spilled to the database. Use 0 for no limit."`
+	MaxHashes int `mapstructure:"max_hashes" yaml:"max_hashes" comment:"Maximum number of hashes of headers, and of block data, held in memory to track the blocks seen and included in the DA layer. The oldest beyond it are spilled to the database. Use 0 for no limit."`
+}
+
+// TelemetryConfig contains the opt-in reporting of anonymized node metrics
+type TelemetryConfig struct {
+	Enabled  bool            `mapstructure:"enabled" yaml:"enabled" comment:"Periodically report anonymized node metrics to the telemetry endpoint, e.g. for public dashboards of the health of the nodes of a network. Reports hold a random node identifier, not linked to the p2p identity of the node, the chain ID, the Rollkit version, the node mode, the height, the DA lag and the number of peers. Disabled by default."`
+	Endpoint string          `mapstructure:"endpoint" yaml:"endpoint" comment:"URL the telemetry reports are posted to as JSON. Required if telemetry is enabled."`
+	Interval DurationWrapper `mapstructure:"interval" yaml:"interval" comment:"Interval between telemetry reports (duration). Examples: \"1m\", \"10m\", \"1h\"."`
+}
+
+// RetryConfig contains the retry policies of the operations of the node which may fail transiently
+type RetryConfig struct {
+	DA        RetryPolicyConfig `mapstructure:"da" yaml:"da" comment:"Retry policy of DA submissions. Submissions rejected because the DA mempool is full or the gas price is too low wait for the DA mempool TTL instead."`
+	Executor  RetryPolicyConfig `mapstructure:"executor" yaml:"executor" comment:"Retry policy of the calls to the executor failing because the execution client cannot be reached."`
+	Sequencer RetryPolicyConfig `mapstructure:"sequencer" yaml:"sequencer" comment:"Retry policy of the requests to a sequencer network failing because it cannot be reached."`
+	Sync      RetryPolicyConfig `mapstructure:"sync" yaml:"sync" comment:"Retry policy of the fetch of the genesis or trusted header and block from peers when a full or light node starts."`
+}
+
+// RetryPolicyConfig contains the parameters of the retry policy of an operation
+type RetryPolicyConfig struct {
+	InitialBackoff DurationWrapper `mapstructure:"initial_backoff" yaml:"initial_backoff" comment:"Delay before the first retry (duration). The delay doubles after every failed attempt, randomized by 20% so that nodes do not retry in lockstep."`
+	MaxBackoff     DurationWrapper `mapstructure:"max_backoff" yaml:"max_backoff" comment:"Maximum delay between attempts (duration). Use 0 for no maximum."`
+	MaxAttempts    int             `mapstructure:"max_attempts" yaml:"max_attempts" comment:"Maximum number of attempts, including the first one. Use 0 for no limit."`
+	MaxElapsedTime DurationWrapper `mapstructure:"max_elapsed_time" yaml:"max_elapsed_time" comment:"Time since the first attempt after which the operation is not retried anymore (duration). Use 0 for no limit."`
+}
+
+// Policy returns the retry policy, retrying all errors.
+func (c RetryPolicyConfig) Policy() retry.Policy {
+	return retry.Policy{
+		InitialBackoff: c.InitialBackoff.Duration,
+		MaxBackoff:     c.MaxBackoff.Duration,
+		Jitter:         retry.DefaultJitter,
+		MaxAttempts:    c.MaxAttempts,
+		MaxElapsedTime: c.MaxElapsedTime.Duration,
+	}
 }
 
 // RPCConfig contains all RPC server configuration parameters
 type RPCConfig struct {
-	Address string `mapstructure:"address" yaml:"address" comment:"Address to bind the RPC server to (host:port). Default: 127.0.0.1:7331"`
+	Address     string `mapstructure:"address" yaml:"address" comment:"Address to bind the RPC server to (host:port). Default: 127.0.0.1:7331"`
+	GRPCAddress string `mapstructure:"grpc_address" yaml:"grpc_address" comment:"Address to bind a gRPC-only server exposing the RPC services to (host:port). The RPC server also accepts gRPC requests. Empty to disable."`
+
+	AdminToken string `mapstructure:"admin_token" yaml:"admin_token" comment:"Bearer token required in the Authorization header of requests to the admin RPC service. Runtime configuration changes with the UpdateConfig RPC are only enabled if a token, auth_token_file or jwt_secret_file is set. Empty to leave the other admin endpoints unauthenticated unless write authentication is enabled."`
+
+	TLSCertFile string `mapstructure:"tls_cert_file" yaml:"tls_cert_file" comment:"Path to the PEM encoded certificate used to serve the RPC and gRPC servers over TLS. Requires tls_key_file. The certificate is reloaded on SIGHUP or when the files change. Empty to serve without TLS."`
+	TLSKeyFile  string `mapstructure:"tls_key_file" yaml:"tls_key_file" comment:"Path to the PEM encoded key of the certificate used to serve the RPC and gRPC servers over TLS."`
+
+	AuthTokenFile string `mapstructure:"auth_token_file" yaml:"auth_token_file" comment:"Path to a file listing the bearer tokens accepted for RPC write requests (transaction submission and admin requests), one per line. Reloaded on SIGHUP or when the file changes. Empty to disable token authentication."`
+	JWTSecretFile string `mapstructure:"jwt_secret_file" yaml:"jwt_secret_file" comment:"Path to a file holding the hex encoded secret of at least 32 bytes verifying the HS256 JWTs accepted as bearer tokens for RPC write requests. Reloaded on SIGHUP or when the file changes. Empty to disable JWT authentication."`
+
+	RateLimitPerIP  float64         `mapstructure:"rate_limit_per_ip" yaml:"rate_limit_per_ip" comment:"Number of RPC requests per second allowed per client IP, identified by the remote address of the connection. Requests above the limit are rejected with HTTP status 429. Use 0 for no limit."`
+	RateLimitGlobal float64         `mapstructure:"rate_limit_global" yaml:"rate_limit_global" comment:"Number of RPC requests per second allowed over all clients. Requests above the limit are rejected with HTTP status 429. Use 0 for no limit."`
+	RateLimitBurst  int             `mapstructure:"rate_limit_burst" yaml:"rate_limit_burst" comment:"Number of RPC requests allowed in a burst above the per-IP and global rate limits."`
+	MaxRequestBytes int64           `mapstructure:"max_request_bytes" yaml:"max_request_bytes" comment:"Maximum size in bytes of RPC request bodies. Larger requests are rejected with HTTP status 413. Use 0 for no limit."`
+	Timeout         DurationWrapper `mapstructure:"timeout" yaml:"timeout" comment:"Timeout for reading RPC requests and writing responses (duration). Examples: \"10s\", \"1m\"."`
+
+	ReadinessMaxSyncLag uint64 `mapstructure:"readiness_max_sync_lag" yaml:"readiness_max_sync_lag" comment:"Maximum number of blocks by which a node may trail the latest block known from its peers while the /health/ready endpoint reports it as ready. Use 0 to ignore the sync lag."`
 }
 
 // Validate ensures that the root directory exists.
@@ -212,11 +740,42 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// ParseLoopShutdownTimeouts parses the shutdown timeouts of individual loops, given as space separated
+// loop=duration pairs, e.g. "header_submission=60s retrieve=5s".
+func ParseLoopShutdownTimeouts(spec string) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, field := range strings.Fields(spec) {
+		loop, value, ok := strings.Cut(field, "=")
+		if !ok || loop == "" {
+			return nil, fmt.Errorf("invalid loop shutdown timeout %q, expected loop=duration", field)
+		}
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout < 0 {
+			return nil, fmt.Errorf("invalid shutdown timeout of loop %s: %q", loop, value)
+		}
+		timeouts[loop] = timeout
+	}
+	return timeouts, nil
+}
+
 // ConfigPath returns the path to the configuration file.
 func (c *Config) ConfigPath() string {
 	return filepath.Join(c.RootDir, AppConfigDir, ConfigName)
 }
 
+// DebugPath returns the path to the directory of the debug bundles captured by the node.
+func (c *Config) DebugPath() string {
+	return filepath.Join(c.RootDir, DebugDir)
+}
+
+// SignerWatermarkPath returns the path to the signer high-watermark file, empty if disabled.
+func (c *Config) SignerWatermarkPath() string {
+	if c.Signer.WatermarkFile == "" || filepath.IsAbs(c.Signer.WatermarkFile) {
+		return c.Signer.WatermarkFile
+	}
+	return filepath.Join(c.RootDir, c.Signer.WatermarkFile)
+}
+
 // AddGlobalFlags registers the basic configuration flags that are common across applications.
 // This includes logging configuration and root directory settings.
 // It should be used in apps that do not already define their logger and home flag.
@@ -224,6 +783,7 @@ func AddGlobalFlags(cmd *cobra.Command, defaultHome string) {
 	cmd.PersistentFlags().String(FlagLogLevel, DefaultConfig.Log.Level, "Set the log level (debug, info, warn, error)")
 	cmd.PersistentFlags().String(FlagLogFormat, DefaultConfig.Log.Format, "Set the log format (text, json)")
 	cmd.PersistentFlags().Bool(FlagLogTrace, DefaultConfig.Log.Trace, "Enable stack traces in error logs")
+	cmd.PersistentFlags().String(FlagLogModuleLevels, DefaultConfig.Log.ModuleLevels, "Set the log levels of individual modules (e.g. \"block=debug p2p=warn\")")
 	cmd.PersistentFlags().String(FlagRootDir, DefaultRootDirWithName(defaultHome), "Root directory for application data")
 }
 
@@ -233,49 +793,192 @@ func AddFlags(cmd *cobra.Command) {
 
 	// Add base flags
 	cmd.Flags().String(FlagDBPath, def.DBPath, "path for the node database")
+	cmd.Flags().String(FlagDBBackend, def.DBBackend, "database backend (badger, memory)")
 	cmd.Flags().String(FlagChainID, def.ChainID, "chain ID")
 
 	// Node configuration flags
 	cmd.Flags().Bool(FlagAggregator, def.Node.Aggregator, "run node in aggregator mode")
 	cmd.Flags().Bool(FlagLight, def.Node.Light, "run light client")
+	cmd.Flags().Bool(FlagLightDAVerification, def.Node.LightDAVerification, "verify headers received by the light client against the DA layer")
+	cmd.Flags().Int(FlagLightDASamples, def.Node.LightDASamples, "number of shares sampled by the light client in every DA block holding headers (0 to disable sampling)")
+	cmd.Flags().Bool(FlagLightServeHeaders, def.Node.LightServeHeaders, "serve the headers verified by the light client to peers")
+	cmd.Flags().Float64(FlagLightServeRequests, def.Node.LightServeRequests, "header requests per second served by the light client to peers (0 for no limit)")
+	cmd.Flags().Float64(FlagLightServePeerRequests, def.Node.LightServePeerRequests, "header requests per second served by the light client to each peer (0 for no limit)")
+	cmd.Flags().Bool(FlagDualMode, def.Node.DualMode, "allow switching between light and full mode while running")
+	cmd.Flags().Bool(FlagArchive, def.Node.Archive, "run node in archive mode, retaining all blocks for historical queries")
 	cmd.Flags().Duration(FlagBlockTime, def.Node.BlockTime.Duration, "block time (for aggregator mode)")
 	cmd.Flags().String(FlagTrustedHash, def.Node.TrustedHash, "initial trusted hash to start the header exchange service")
+	cmd.Flags().Uint64(FlagTrustedHeight, def.Node.TrustedHeight, "height of the trusted hash, 0 to look up the trusted header by hash")
 	cmd.Flags().Bool(FlagLazyAggregator, def.Node.LazyMode, "produce blocks only when transactions are available or after lazy block time")
 	cmd.Flags().Uint64(FlagMaxPendingHeaders, def.Node.MaxPendingHeaders, "maximum headers pending DA confirmation before pausing block production (0 for no limit)")
+	cmd.Flags().Uint64(FlagDABacklogThreshold, def.Node.DABacklogThreshold, "headers pending DA submission above which block production slows down and transactions are rejected (0 to disable)")
+	cmd.Flags().Bool(FlagAsyncExecution, def.Node.AsyncExecution, "produce blocks before the previous blocks are executed, executing them in the background (for aggregator mode)")
+	cmd.Flags().Uint64(FlagMaxExecutionLag, def.Node.MaxExecutionLag, "maximum produced blocks waiting for execution before pausing block production (0 for no limit)")
 	cmd.Flags().Duration(FlagLazyBlockTime, def.Node.LazyBlockInterval.Duration, "maximum interval between blocks in lazy aggregation mode")
+	cmd.Flags().Bool(FlagSlotAlignment, def.Node.SlotAlignment, "align block production to wall-clock slots of the block time")
+	cmd.Flags().Duration(FlagBlockJitter, def.Node.BlockJitter.Duration, "maximum random delay added to the production time of every block (0 to disable)")
+	cmd.Flags().String(FlagSequencingMode, def.Node.SequencingMode, "strategy ordering blocks (aggregator, based)")
+	cmd.Flags().String(FlagSequencerAddress, def.Node.SequencerAddress, "address of an external sequencer network serving the gRPC sequencing API (empty for the local sequencer)")
+	cmd.Flags().Duration(FlagShutdownTimeout, def.Node.ShutdownTimeout.Duration, "maximum time spent draining in-flight DA submissions on shutdown")
+	cmd.Flags().Duration(FlagLoopShutdownTimeout, def.Node.LoopShutdownTimeout.Duration, "maximum time each block manager loop is given to stop on shutdown (0 to wait indefinitely)")
+	cmd.Flags().String(FlagLoopShutdownTimeouts, def.Node.LoopShutdownTimeouts, "shutdown timeouts of individual block manager loops (e.g. \"header_submission=60s retrieve=5s\")")
+	cmd.Flags().Uint64(FlagMaxBlockBytes, def.Node.MaxBlockBytes, "maximum size of the transactions of a block in bytes (0 for no limit)")
+	cmd.Flags().Uint64(FlagShadowExecutionDelay, def.Node.ShadowExecutionDelay, "number of blocks the shadow executor trails the primary executor")
+	cmd.Flags().Uint64(FlagPreconfirmationHorizon, def.Node.PreconfirmationHorizon, "number of blocks within which the aggregator promises to include accepted transactions (0 disables preconfirmations)")
+	cmd.Flags().Uint64(FlagMaxBlockGas, def.Node.MaxBlockGas, "maximum gas of the transactions of a block (0 for no limit)")
+	cmd.Flags().Bool(FlagFraudProofs, def.Node.FraudProofs, "detect invalid state transitions, gossip fraud proofs and halt on valid fraud proofs")
+	cmd.Flags().Duration(FlagProofDeadline, def.Node.ProofDeadline.Duration, "maximum time headers wait for validity proofs before DA submission (0 to always wait)")
+	cmd.Flags().Duration(FlagMinBlockTimeDelta, def.Node.MinBlockTimeDelta.Duration, "minimum difference between the timestamps of consecutive blocks")
+	cmd.Flags().Duration(FlagMaxClockDrift, def.Node.MaxClockDrift.Duration, "clock drift above which the aggregator warns (0 to disable)")
+	cmd.Flags().String(FlagNTPServer, def.Node.NTPServer, "NTP server checking the clock of the aggregator (empty to trust the host clock)")
+	cmd.Flags().Uint64(FlagHaltHeight, def.Node.HaltHeight, "height before which the node halts for a binary swap (0 to disable)")
 
 	// Data Availability configuration flags
 	cmd.Flags().String(FlagDAAddress, def.DA.Address, "DA address (host:port)")
 	cmd.Flags().String(FlagDAAuthToken, def.DA.AuthToken, "DA auth token")
 	cmd.Flags().Duration(FlagDABlockTime, def.DA.BlockTime.Duration, "DA chain block time (for syncing)")
 	cmd.Flags().Float64(FlagDAGasPrice, def.DA.GasPrice, "DA gas price for blob transactions")
+	cmd.Flags().Float64(FlagDAMaxGasPrice, def.DA.MaxGasPrice, "maximum DA gas price when escalating the gas price of blob transactions (0 for no maximum)")
 	cmd.Flags().Float64(FlagDAGasMultiplier, def.DA.GasMultiplier, "DA gas price multiplier for retrying blob transactions")
 	cmd.Flags().Uint64(FlagDAStartHeight, def.DA.StartHeight, "starting DA block height (for syncing)")
 	cmd.Flags().String(FlagDANamespace, def.DA.Namespace, "DA namespace to submit blob transactions")
+	cmd.Flags().String(FlagDAHeaderNamespace, def.DA.HeaderNamespace, "DA namespace of block headers (empty uses the DA namespace)")
+	cmd.Flags().String(FlagDADataNamespace, def.DA.DataNamespace, "DA namespace of block data (empty uses the DA namespace)")
 	cmd.Flags().String(FlagDASubmitOptions, def.DA.SubmitOptions, "DA submit options")
 	cmd.Flags().Uint64(FlagDAMempoolTTL, def.DA.MempoolTTL, "number of DA blocks until transaction is dropped from the mempool")
+	cmd.Flags().String(FlagDAForcedInclusionNamespace, def.DA.ForcedInclusionNamespace, "DA namespace scanned for forced inclusion transactions (empty disables forced inclusion)")
+	cmd.Flags().Uint64(FlagDAForcedInclusionDeadline, def.DA.ForcedInclusionDeadline, "number of blocks within which forced inclusion transactions must be included")
+	cmd.Flags().String(FlagDACompression, def.DA.Compression, "codec used to compress batches submitted to the DA layer (none, gzip, zstd)")
+	cmd.Flags().Bool(FlagDABlobEnvelope, def.DA.BlobEnvelope, "wrap headers and batches submitted to the DA layer in versioned envelopes")
+	cmd.Flags().Bool(FlagDASharedNamespace, def.DA.SharedNamespace, "share the DA namespaces with other chains, filtering blobs by the chain ID of their envelope")
+	cmd.Flags().Bool(FlagDAPreferRetrieval, def.DA.PreferRetrieval, "sync blocks from the DA layer only, ignoring p2p")
+	cmd.Flags().Float64(FlagDABackfillRateLimit, def.DA.BackfillRateLimit, "maximum number of DA heights requested per second when backfilling from the DA layer")
+	cmd.Flags().Int(FlagDARetrieveWorkers, def.DA.RetrieveWorkers, "number of DA heights retrieved concurrently when syncing from the DA layer")
+	cmd.Flags().Int(FlagDAMaxSubmissionsInFlight, def.DA.MaxSubmissionsInFlight, "maximum number of concurrent header submissions to the DA layer")
+	cmd.Flags().Int(FlagDAMaxBlocksPerBlob, def.DA.MaxBlocksPerBlob, "maximum number of blocks whose headers are bundled into a single DA blob")
+	cmd.Flags().Uint64(FlagDAMaxBlobSize, def.DA.MaxBlobSize, "maximum size in bytes of the blobs submitted to the DA layer, larger block data is split (0 uses the limit of the DA layer)")
+	cmd.Flags().Uint64(FlagDAEpochBlocks, def.DA.EpochBlocks, "number of blocks after which an epoch is posted to the DA layer (0 to only use the epoch time)")
+	cmd.Flags().Duration(FlagDAEpochTime, def.DA.EpochTime.Duration, "maximum duration of an epoch posted to the DA layer (0 to only use the epoch blocks)")
+	cmd.Flags().Uint64(FlagDAReorgCheckDepth, def.DA.ReorgCheckDepth, "number of the latest DA included blocks checked for DA reorgs (0 to disable)")
+	cmd.Flags().Duration(FlagDAAuditInterval, def.DA.AuditInterval.Duration, "interval at which a random sample of DA included blocks is re-verified against the DA layer (0 to disable)")
+	cmd.Flags().Uint64(FlagDAAuditSampleSize, def.DA.AuditSampleSize, "number of DA included blocks re-verified against the DA layer per audit")
+	cmd.Flags().Uint64(FlagDAGasPerByte, def.DA.GasPerByte, "DA gas consumed per byte of blob, used to account for DA fees (0 to disable DA fee accounting)")
+	cmd.Flags().Float64(FlagDADailyBudget, def.DA.DailyBudget, "DA fees per UTC day above which DA submissions pause (0 for no budget)")
+	cmd.Flags().Bool(FlagDADryRun, def.DA.DryRun, "simulate DA submissions, logging blob sizes and estimated DA fees, without broadcasting them")
+	cmd.Flags().String(FlagDACMembers, def.DA.DACMembers, "data availability committee members as comma separated pubkey@address entries, enabling commitments-only mode (empty to post block data to the DA layer)")
+	cmd.Flags().Int(FlagDACThreshold, def.DA.DACThreshold, "number of data availability committee signatures required on every header")
+	cmd.Flags().String(FlagDABackends, def.DA.Backends, "comma separated addresses of additional DA backends blobs are submitted to (empty to only use the DA address)")
+	cmd.Flags().Int(FlagDAQuorum, def.DA.Quorum, "number of DA backends that must include a blob for it to be DA included")
+	cmd.Flags().Int(FlagDAMaxFailures, def.DA.MaxFailures, "number of consecutive failures after which the DA backend submitted to first is switched")
 
 	// P2P configuration flags
 	cmd.Flags().String(FlagP2PListenAddress, def.P2P.ListenAddress, "P2P listen address (host:port)")
 	cmd.Flags().String(FlagP2PPeers, def.P2P.Peers, "Comma separated list of seed nodes to connect to")
 	cmd.Flags().String(FlagP2PBlockedPeers, def.P2P.BlockedPeers, "Comma separated list of nodes to ignore")
 	cmd.Flags().String(FlagP2PAllowedPeers, def.P2P.AllowedPeers, "Comma separated list of nodes to whitelist")
+	cmd.Flags().Bool(FlagP2PAllowlistOnly, def.P2P.AllowlistOnly, "only connect to the allowed peers and the peers to connect to")
+	cmd.Flags().Float64(FlagP2PBanThreshold, def.P2P.BanThreshold, "peer score at or below which peers are banned")
+	cmd.Flags().Duration(FlagP2PBanDuration, def.P2P.BanDuration.Duration, "duration for which peers with a low score are banned")
+	cmd.Flags().Uint64(FlagP2PMaxPeers, def.P2P.MaxPeers, "maximum number of connected peers (0 for no limit)")
+	cmd.Flags().Bool(FlagP2PPeerExchange, def.P2P.PeerExchange, "send signed peer records of other peers to peers pruned from a full gossip mesh")
+	cmd.Flags().Float64(FlagP2PBlockDataRequests, def.P2P.BlockDataRequests, "block data requests per second served to peers (0 for no limit)")
+	cmd.Flags().Float64(FlagP2PBlockDataPeerRequests, def.P2P.BlockDataPeerRequests, "block data requests per second served to each peer (0 for no limit)")
+	cmd.Flags().Duration(FlagP2PBlockDataFetchDelay, def.P2P.BlockDataFetchDelay.Duration, "time after which missing block data is fetched from peers (0 to disable)")
+	cmd.Flags().String(FlagP2PKeyPassphrase, "", "passphrase encrypting the node key (defaults to $ROLLKIT_NODE_KEY_PASSPHRASE, the node key is stored unencrypted if empty)")
+
+	// Pruning configuration flags
+	cmd.Flags().Uint64(FlagPruningKeepRecent, def.Pruning.KeepRecent, "number of recent blocks to keep when pruning (0 disables pruning)")
+	cmd.Flags().Duration(FlagPruningInterval, def.Pruning.Interval.Duration, "interval at which old blocks are pruned")
+	cmd.Flags().Duration(FlagPruningCompactionInterval, def.Pruning.CompactionInterval.Duration, "interval at which the datastore is compacted (0 disables scheduled compaction)")
+
+	// Snapshot configuration flags
+	cmd.Flags().Uint64(FlagSnapshotInterval, def.Snapshot.Interval, "number of blocks between state snapshots (0 disables snapshots)")
+	cmd.Flags().Uint64(FlagSnapshotKeepRecent, def.Snapshot.KeepRecent, "number of recent state snapshots to keep")
+	cmd.Flags().Bool(FlagSnapshotStateSync, def.Snapshot.StateSync, "bootstrap a fresh node from a state snapshot fetched from peers")
+
+	// Transaction indexing configuration flags
+	cmd.Flags().Bool(FlagTxIndexEnabled, def.TxIndex.Enabled, "index transactions by hash and by event")
+
+	// Mempool configuration flags
+	cmd.Flags().Uint64(FlagMempoolSize, def.Mempool.Size, "maximum number of transactions in the mempool")
+	cmd.Flags().Duration(FlagMempoolTTL, def.Mempool.TTL.Duration, "duration after which transactions are evicted from the mempool (0 disables eviction)")
+	cmd.Flags().Bool(FlagMempoolBroadcast, def.Mempool.Broadcast, "gossip mempool transactions to peers")
+	cmd.Flags().Uint64(FlagMempoolMaxTxBytes, def.Mempool.MaxTxBytes, "maximum size in bytes of a transaction accepted by the node (0 for no limit)")
+	cmd.Flags().String(FlagMempoolTxPrefixes, def.Mempool.TxPrefixes, "comma separated hex encoded prefixes one of which transaction payloads must start with (empty accepts any payload)")
+
+	// Cache configuration flags
+	cmd.Flags().Int(FlagCacheMaxItems, def.Cache.MaxItems, "maximum number of headers, and of block data, held in memory while waiting to be synced (0 for no limit)")
+	cmd.Flags().Int(FlagCacheMaxHashes, def.Cache.MaxHashes, "maximum number of hashes of headers, and of block data, held in memory (0 for no limit)")
+
+	// Telemetry configuration flags
+	cmd.Flags().Bool(FlagTelemetryEnabled, def.Telemetry.Enabled, "periodically report anonymized node metrics to the telemetry endpoint")
+	cmd.Flags().String(FlagTelemetryEndpoint, def.Telemetry.Endpoint, "URL the telemetry reports are posted to")
+	cmd.Flags().Duration(FlagTelemetryInterval, def.Telemetry.Interval.Duration, "interval between telemetry reports (duration)")
+
+	// Leader election configuration flags
+	cmd.Flags().Bool(FlagLeaderElection, def.Leader.Enabled, "enable leader election between aggregators sharing the sequencer key")
+	cmd.Flags().Uint64(FlagLeaderLeaseBlocks, def.Leader.LeaseBlocks, "number of DA blocks a leadership lease is valid for")
+	cmd.Flags().String(FlagLeaderNamespace, def.Leader.Namespace, "DA namespace of the lease claims (required with leader election)")
+
+	// Attestation configuration flags
+	cmd.Flags().String(FlagAttestationAttesters, def.Attestation.Attesters, "comma separated peer IDs of the attesters of the chain (empty disables attestations)")
+	cmd.Flags().Int(FlagAttestationQuorum, def.Attestation.Quorum, "number of attesters required to attest a block (0 for more than two thirds)")
+
+	// Retry configuration flags
+	cmd.Flags().Duration(FlagRetryDAInitialBackoff, def.Retry.DA.InitialBackoff.Duration, "delay before the first retry of DA submissions")
+	cmd.Flags().Duration(FlagRetryDAMaxBackoff, def.Retry.DA.MaxBackoff.Duration, "maximum delay between retries of DA submissions (0 for no maximum)")
+	cmd.Flags().Int(FlagRetryDAMaxAttempts, def.Retry.DA.MaxAttempts, "maximum number of attempts of DA submissions (0 for no limit)")
+	cmd.Flags().Duration(FlagRetryDAMaxElapsedTime, def.Retry.DA.MaxElapsedTime.Duration, "maximum time spent retrying DA submissions (0 for no limit)")
+	cmd.Flags().Duration(FlagRetryExecutorInitialBackoff, def.Retry.Executor.InitialBackoff.Duration, "delay before the first retry of executor calls")
+	cmd.Flags().Duration(FlagRetryExecutorMaxBackoff, def.Retry.Executor.MaxBackoff.Duration, "maximum delay between retries of executor calls (0 for no maximum)")
+	cmd.Flags().Int(FlagRetryExecutorMaxAttempts, def.Retry.Executor.MaxAttempts, "maximum number of attempts of executor calls (0 for no limit)")
+	cmd.Flags().Duration(FlagRetryExecutorMaxElapsedTime, def.Retry.Executor.MaxElapsedTime.Duration, "maximum time spent retrying executor calls (0 for no limit)")
+	cmd.Flags().Duration(FlagRetrySequencerInitialBackoff, def.Retry.Sequencer.InitialBackoff.Duration, "delay before the first retry of sequencer requests")
+	cmd.Flags().Duration(FlagRetrySequencerMaxBackoff, def.Retry.Sequencer.MaxBackoff.Duration, "maximum delay between retries of sequencer requests (0 for no maximum)")
+	cmd.Flags().Int(FlagRetrySequencerMaxAttempts, def.Retry.Sequencer.MaxAttempts, "maximum number of attempts of sequencer requests (0 for no limit)")
+	cmd.Flags().Duration(FlagRetrySequencerMaxElapsedTime, def.Retry.Sequencer.MaxElapsedTime.Duration, "maximum time spent retrying sequencer requests (0 for no limit)")
+	cmd.Flags().Duration(FlagRetrySyncInitialBackoff, def.Retry.Sync.InitialBackoff.Duration, "delay before the first retry of sync waits")
+	cmd.Flags().Duration(FlagRetrySyncMaxBackoff, def.Retry.Sync.MaxBackoff.Duration, "maximum delay between retries of sync waits (0 for no maximum)")
+	cmd.Flags().Int(FlagRetrySyncMaxAttempts, def.Retry.Sync.MaxAttempts, "maximum number of attempts of sync waits (0 for no limit)")
+	cmd.Flags().Duration(FlagRetrySyncMaxElapsedTime, def.Retry.Sync.MaxElapsedTime.Duration, "maximum time spent retrying sync waits (0 for no limit)")
 
 	// RPC configuration flags
 	cmd.Flags().String(FlagRPCAddress, def.RPC.Address, "RPC server address (host:port)")
+	cmd.Flags().String(FlagRPCGRPCAddress, def.RPC.GRPCAddress, "gRPC server address (host:port), empty to disable")
+	cmd.Flags().String(FlagRPCAdminToken, def.RPC.AdminToken, "bearer token authenticating admin RPC requests, required for runtime config changes unless write authentication is enabled")
+	cmd.Flags().String(FlagRPCTLSCertFile, def.RPC.TLSCertFile, "certificate file serving the RPC over TLS, reloaded on SIGHUP or change")
+	cmd.Flags().String(FlagRPCTLSKeyFile, def.RPC.TLSKeyFile, "key file of the certificate serving the RPC over TLS")
+	cmd.Flags().String(FlagRPCAuthTokenFile, def.RPC.AuthTokenFile, "file listing the bearer tokens authenticating RPC write requests")
+	cmd.Flags().String(FlagRPCJWTSecretFile, def.RPC.JWTSecretFile, "file holding the hex encoded secret verifying JWTs authenticating RPC write requests")
+	cmd.Flags().Float64(FlagRPCRateLimitPerIP, def.RPC.RateLimitPerIP, "RPC requests per second allowed per client IP (0 for no limit)")
+	cmd.Flags().Float64(FlagRPCRateLimitGlobal, def.RPC.RateLimitGlobal, "RPC requests per second allowed over all clients (0 for no limit)")
+	cmd.Flags().Int(FlagRPCRateLimitBurst, def.RPC.RateLimitBurst, "RPC requests allowed in a burst above the rate limits")
+	cmd.Flags().Int64(FlagRPCMaxRequestBytes, def.RPC.MaxRequestBytes, "maximum size of RPC request bodies in bytes (0 for no limit)")
+	cmd.Flags().Duration(FlagRPCTimeout, def.RPC.Timeout.Duration, "timeout for reading RPC requests and writing responses")
+	cmd.Flags().Uint64(FlagRPCReadinessMaxSyncLag, def.RPC.ReadinessMaxSyncLag, "maximum number of blocks a node may trail its peers while reported as ready (0 to ignore the sync lag)")
 
 	// Instrumentation configuration flags
 	instrDef := DefaultInstrumentationConfig()
 	cmd.Flags().Bool(FlagPrometheus, instrDef.Prometheus, "enable Prometheus metrics")
 	cmd.Flags().String(FlagPrometheusListenAddr, instrDef.PrometheusListenAddr, "Prometheus metrics listen address")
+	cmd.Flags().String(FlagPrometheusPath, instrDef.PrometheusPath, "HTTP path of the Prometheus metrics endpoint")
 	cmd.Flags().Int(FlagMaxOpenConnections, instrDef.MaxOpenConnections, "maximum number of simultaneous connections for metrics")
 	cmd.Flags().Bool(FlagPprof, instrDef.Pprof, "enable pprof HTTP endpoint")
 	cmd.Flags().String(FlagPprofListenAddr, instrDef.PprofListenAddr, "pprof HTTP server listening address")
+	cmd.Flags().Duration(FlagProfileCaptureInterval, instrDef.ProfileCaptureInterval.Duration, "interval at which debug bundles are captured to the debug directory (0 to disable)")
+	cmd.Flags().Int(FlagProfileCaptureKeep, instrDef.ProfileCaptureKeep, "number of debug bundles kept in the debug directory")
 
 	// Signer configuration flags
 	cmd.Flags().String(FlagSignerType, def.Signer.SignerType, "type of signer to use (file, grpc)")
 	cmd.Flags().String(FlagSignerPath, def.Signer.SignerPath, "path to the signer file or address")
-	cmd.Flags().String(FlagSignerPassphrase, "", "passphrase for the signer (required for file signer and if aggregator is enabled)")
+	cmd.Flags().String(FlagSignerSignatureScheme, def.Signer.SignatureScheme, "signature scheme of the sequencer key created by init (ed25519, secp256k1, bls)")
+	cmd.Flags().String(FlagSignerTLSCAFile, def.Signer.TLSCAFile, "CA certificate file used to verify the gRPC remote signer")
+	cmd.Flags().String(FlagSignerTLSCertFile, def.Signer.TLSCertFile, "client certificate file used to authenticate to the gRPC remote signer")
+	cmd.Flags().String(FlagSignerTLSKeyFile, def.Signer.TLSKeyFile, "client key file used to authenticate to the gRPC remote signer")
+	cmd.Flags().Duration(FlagSignerTimeout, def.Signer.Timeout.Duration, "timeout of requests to the gRPC remote signer")
+	cmd.Flags().Duration(FlagSignerHealthCheckInterval, def.Signer.HealthCheckInterval.Duration, "interval of health checks of the gRPC remote signer")
+	cmd.Flags().String(FlagSignerWatermarkFile, def.Signer.WatermarkFile, "high-watermark file of the last header signed by the aggregator, empty to disable")
+	cmd.Flags().String(FlagSignerPassphrase, "", "passphrase for the signer (required for file signer and if aggregator is enabled, defaults to $ROLLKIT_SIGNER_PASSPHRASE)")
 }
 
 // Load loads the node configuration in the following order of precedence:
@@ -51,56 +51,200 @@ func TestAddFlags(t *testing.T) {
 
 	// Test specific flags
 	assertFlagValue(t, flags, FlagDBPath, DefaultConfig.DBPath)
+	assertFlagValue(t, flags, FlagDBBackend, DefaultConfig.DBBackend)
 
 	// Node flags
 	assertFlagValue(t, flags, FlagAggregator, DefaultConfig.Node.Aggregator)
 	assertFlagValue(t, flags, FlagLight, DefaultConfig.Node.Light)
+	assertFlagValue(t, flags, FlagLightDAVerification, DefaultConfig.Node.LightDAVerification)
+	assertFlagValue(t, flags, FlagLightDASamples, DefaultConfig.Node.LightDASamples)
+	assertFlagValue(t, flags, FlagLightServeHeaders, DefaultConfig.Node.LightServeHeaders)
+	assertFlagValue(t, flags, FlagLightServeRequests, DefaultConfig.Node.LightServeRequests)
+	assertFlagValue(t, flags, FlagLightServePeerRequests, DefaultConfig.Node.LightServePeerRequests)
+	assertFlagValue(t, flags, FlagDualMode, DefaultConfig.Node.DualMode)
+	assertFlagValue(t, flags, FlagArchive, DefaultConfig.Node.Archive)
 	assertFlagValue(t, flags, FlagBlockTime, DefaultConfig.Node.BlockTime.Duration)
 	assertFlagValue(t, flags, FlagTrustedHash, DefaultConfig.Node.TrustedHash)
+	assertFlagValue(t, flags, FlagTrustedHeight, DefaultConfig.Node.TrustedHeight)
 	assertFlagValue(t, flags, FlagLazyAggregator, DefaultConfig.Node.LazyMode)
 	assertFlagValue(t, flags, FlagMaxPendingHeaders, DefaultConfig.Node.MaxPendingHeaders)
+	assertFlagValue(t, flags, FlagAsyncExecution, DefaultConfig.Node.AsyncExecution)
+	assertFlagValue(t, flags, FlagDABacklogThreshold, DefaultConfig.Node.DABacklogThreshold)
+	assertFlagValue(t, flags, FlagMaxExecutionLag, DefaultConfig.Node.MaxExecutionLag)
 	assertFlagValue(t, flags, FlagLazyBlockTime, DefaultConfig.Node.LazyBlockInterval.Duration)
+	assertFlagValue(t, flags, FlagSlotAlignment, DefaultConfig.Node.SlotAlignment)
+	assertFlagValue(t, flags, FlagBlockJitter, DefaultConfig.Node.BlockJitter.Duration)
+	assertFlagValue(t, flags, FlagSequencingMode, DefaultConfig.Node.SequencingMode)
+	assertFlagValue(t, flags, FlagSequencerAddress, DefaultConfig.Node.SequencerAddress)
+	assertFlagValue(t, flags, FlagShutdownTimeout, DefaultConfig.Node.ShutdownTimeout.Duration)
+	assertFlagValue(t, flags, FlagLoopShutdownTimeout, DefaultConfig.Node.LoopShutdownTimeout.Duration)
+	assertFlagValue(t, flags, FlagLoopShutdownTimeouts, DefaultConfig.Node.LoopShutdownTimeouts)
+	assertFlagValue(t, flags, FlagMaxBlockBytes, DefaultConfig.Node.MaxBlockBytes)
+	assertFlagValue(t, flags, FlagShadowExecutionDelay, DefaultConfig.Node.ShadowExecutionDelay)
+	assertFlagValue(t, flags, FlagPreconfirmationHorizon, DefaultConfig.Node.PreconfirmationHorizon)
+	assertFlagValue(t, flags, FlagMaxBlockGas, DefaultConfig.Node.MaxBlockGas)
+	assertFlagValue(t, flags, FlagFraudProofs, DefaultConfig.Node.FraudProofs)
+	assertFlagValue(t, flags, FlagProofDeadline, DefaultConfig.Node.ProofDeadline.Duration)
+	assertFlagValue(t, flags, FlagMinBlockTimeDelta, DefaultConfig.Node.MinBlockTimeDelta.Duration)
+	assertFlagValue(t, flags, FlagMaxClockDrift, DefaultConfig.Node.MaxClockDrift.Duration)
+	assertFlagValue(t, flags, FlagNTPServer, DefaultConfig.Node.NTPServer)
+	assertFlagValue(t, flags, FlagHaltHeight, DefaultConfig.Node.HaltHeight)
 
 	// DA flags
 	assertFlagValue(t, flags, FlagDAAddress, DefaultConfig.DA.Address)
 	assertFlagValue(t, flags, FlagDAAuthToken, DefaultConfig.DA.AuthToken)
 	assertFlagValue(t, flags, FlagDABlockTime, DefaultConfig.DA.BlockTime.Duration)
 	assertFlagValue(t, flags, FlagDAGasPrice, DefaultConfig.DA.GasPrice)
+	assertFlagValue(t, flags, FlagDAMaxGasPrice, DefaultConfig.DA.MaxGasPrice)
 	assertFlagValue(t, flags, FlagDAGasMultiplier, DefaultConfig.DA.GasMultiplier)
 	assertFlagValue(t, flags, FlagDAStartHeight, DefaultConfig.DA.StartHeight)
 	assertFlagValue(t, flags, FlagDANamespace, DefaultConfig.DA.Namespace)
+	assertFlagValue(t, flags, FlagDAHeaderNamespace, DefaultConfig.DA.HeaderNamespace)
+	assertFlagValue(t, flags, FlagDADataNamespace, DefaultConfig.DA.DataNamespace)
 	assertFlagValue(t, flags, FlagDASubmitOptions, DefaultConfig.DA.SubmitOptions)
 	assertFlagValue(t, flags, FlagDAMempoolTTL, DefaultConfig.DA.MempoolTTL)
+	assertFlagValue(t, flags, FlagDAForcedInclusionNamespace, DefaultConfig.DA.ForcedInclusionNamespace)
+	assertFlagValue(t, flags, FlagDAForcedInclusionDeadline, DefaultConfig.DA.ForcedInclusionDeadline)
+	assertFlagValue(t, flags, FlagDACompression, DefaultConfig.DA.Compression)
+	assertFlagValue(t, flags, FlagDABlobEnvelope, DefaultConfig.DA.BlobEnvelope)
+	assertFlagValue(t, flags, FlagDASharedNamespace, DefaultConfig.DA.SharedNamespace)
+	assertFlagValue(t, flags, FlagDAPreferRetrieval, DefaultConfig.DA.PreferRetrieval)
+	assertFlagValue(t, flags, FlagDABackfillRateLimit, DefaultConfig.DA.BackfillRateLimit)
+	assertFlagValue(t, flags, FlagDARetrieveWorkers, DefaultConfig.DA.RetrieveWorkers)
+	assertFlagValue(t, flags, FlagDAMaxSubmissionsInFlight, DefaultConfig.DA.MaxSubmissionsInFlight)
+	assertFlagValue(t, flags, FlagDAMaxBlocksPerBlob, DefaultConfig.DA.MaxBlocksPerBlob)
+	assertFlagValue(t, flags, FlagDAMaxBlobSize, DefaultConfig.DA.MaxBlobSize)
+	assertFlagValue(t, flags, FlagDAEpochBlocks, DefaultConfig.DA.EpochBlocks)
+	assertFlagValue(t, flags, FlagDAEpochTime, DefaultConfig.DA.EpochTime.Duration)
+	assertFlagValue(t, flags, FlagDAReorgCheckDepth, DefaultConfig.DA.ReorgCheckDepth)
+	assertFlagValue(t, flags, FlagDAAuditInterval, DefaultConfig.DA.AuditInterval.Duration)
+	assertFlagValue(t, flags, FlagDAAuditSampleSize, DefaultConfig.DA.AuditSampleSize)
+	assertFlagValue(t, flags, FlagDAGasPerByte, DefaultConfig.DA.GasPerByte)
+	assertFlagValue(t, flags, FlagDADailyBudget, DefaultConfig.DA.DailyBudget)
+	assertFlagValue(t, flags, FlagDADryRun, DefaultConfig.DA.DryRun)
+	assertFlagValue(t, flags, FlagDACMembers, DefaultConfig.DA.DACMembers)
+	assertFlagValue(t, flags, FlagDACThreshold, DefaultConfig.DA.DACThreshold)
+	assertFlagValue(t, flags, FlagDABackends, DefaultConfig.DA.Backends)
+	assertFlagValue(t, flags, FlagDAQuorum, DefaultConfig.DA.Quorum)
+	assertFlagValue(t, flags, FlagDAMaxFailures, DefaultConfig.DA.MaxFailures)
 
 	// P2P flags
 	assertFlagValue(t, flags, FlagP2PListenAddress, DefaultConfig.P2P.ListenAddress)
 	assertFlagValue(t, flags, FlagP2PPeers, DefaultConfig.P2P.Peers)
 	assertFlagValue(t, flags, FlagP2PBlockedPeers, DefaultConfig.P2P.BlockedPeers)
 	assertFlagValue(t, flags, FlagP2PAllowedPeers, DefaultConfig.P2P.AllowedPeers)
+	assertFlagValue(t, flags, FlagP2PAllowlistOnly, DefaultConfig.P2P.AllowlistOnly)
+	assertFlagValue(t, flags, FlagP2PBanThreshold, DefaultConfig.P2P.BanThreshold)
+	assertFlagValue(t, flags, FlagP2PBanDuration, DefaultConfig.P2P.BanDuration.Duration)
+	assertFlagValue(t, flags, FlagP2PMaxPeers, DefaultConfig.P2P.MaxPeers)
+	assertFlagValue(t, flags, FlagP2PPeerExchange, DefaultConfig.P2P.PeerExchange)
+	assertFlagValue(t, flags, FlagP2PBlockDataRequests, DefaultConfig.P2P.BlockDataRequests)
+	assertFlagValue(t, flags, FlagP2PBlockDataPeerRequests, DefaultConfig.P2P.BlockDataPeerRequests)
+	assertFlagValue(t, flags, FlagP2PBlockDataFetchDelay, DefaultConfig.P2P.BlockDataFetchDelay.Duration)
 
 	// Instrumentation flags
 	instrDef := DefaultInstrumentationConfig()
 	assertFlagValue(t, flags, FlagPrometheus, instrDef.Prometheus)
 	assertFlagValue(t, flags, FlagPrometheusListenAddr, instrDef.PrometheusListenAddr)
+	assertFlagValue(t, flags, FlagPrometheusPath, instrDef.PrometheusPath)
 	assertFlagValue(t, flags, FlagMaxOpenConnections, instrDef.MaxOpenConnections)
 	assertFlagValue(t, flags, FlagPprof, instrDef.Pprof)
 	assertFlagValue(t, flags, FlagPprofListenAddr, instrDef.PprofListenAddr)
+	assertFlagValue(t, flags, FlagProfileCaptureInterval, instrDef.ProfileCaptureInterval.Duration)
+	assertFlagValue(t, flags, FlagProfileCaptureKeep, instrDef.ProfileCaptureKeep)
 
 	// Logging flags (in persistent flags)
 	assertFlagValue(t, persistentFlags, FlagLogLevel, DefaultConfig.Log.Level)
 	assertFlagValue(t, persistentFlags, FlagLogFormat, "text")
 	assertFlagValue(t, persistentFlags, FlagLogTrace, false)
+	assertFlagValue(t, persistentFlags, FlagLogModuleLevels, DefaultConfig.Log.ModuleLevels)
 
 	// Signer flags
 	assertFlagValue(t, flags, FlagSignerPassphrase, "")
+	assertFlagValue(t, flags, FlagP2PKeyPassphrase, "")
 	assertFlagValue(t, flags, FlagSignerType, "file")
 	assertFlagValue(t, flags, FlagSignerPath, DefaultConfig.Signer.SignerPath)
+	assertFlagValue(t, flags, FlagSignerSignatureScheme, DefaultConfig.Signer.SignatureScheme)
+	assertFlagValue(t, flags, FlagSignerTLSCAFile, "")
+	assertFlagValue(t, flags, FlagSignerTLSCertFile, "")
+	assertFlagValue(t, flags, FlagSignerTLSKeyFile, "")
+	assertFlagValue(t, flags, FlagSignerTimeout, DefaultConfig.Signer.Timeout.Duration)
+	assertFlagValue(t, flags, FlagSignerHealthCheckInterval, DefaultConfig.Signer.HealthCheckInterval.Duration)
+	assertFlagValue(t, flags, FlagSignerWatermarkFile, DefaultConfig.Signer.WatermarkFile)
 
 	// RPC flags
 	assertFlagValue(t, flags, FlagRPCAddress, DefaultConfig.RPC.Address)
+	assertFlagValue(t, flags, FlagRPCGRPCAddress, DefaultConfig.RPC.GRPCAddress)
+	assertFlagValue(t, flags, FlagRPCAdminToken, DefaultConfig.RPC.AdminToken)
+	assertFlagValue(t, flags, FlagRPCTLSCertFile, DefaultConfig.RPC.TLSCertFile)
+	assertFlagValue(t, flags, FlagRPCTLSKeyFile, DefaultConfig.RPC.TLSKeyFile)
+	assertFlagValue(t, flags, FlagRPCAuthTokenFile, DefaultConfig.RPC.AuthTokenFile)
+	assertFlagValue(t, flags, FlagRPCJWTSecretFile, DefaultConfig.RPC.JWTSecretFile)
+	assertFlagValue(t, flags, FlagRPCRateLimitPerIP, DefaultConfig.RPC.RateLimitPerIP)
+	assertFlagValue(t, flags, FlagRPCRateLimitGlobal, DefaultConfig.RPC.RateLimitGlobal)
+	assertFlagValue(t, flags, FlagRPCRateLimitBurst, DefaultConfig.RPC.RateLimitBurst)
+	assertFlagValue(t, flags, FlagRPCMaxRequestBytes, DefaultConfig.RPC.MaxRequestBytes)
+	assertFlagValue(t, flags, FlagRPCTimeout, DefaultConfig.RPC.Timeout.Duration)
+	assertFlagValue(t, flags, FlagRPCReadinessMaxSyncLag, DefaultConfig.RPC.ReadinessMaxSyncLag)
+
+	// Pruning flags
+	assertFlagValue(t, flags, FlagPruningKeepRecent, DefaultConfig.Pruning.KeepRecent)
+	assertFlagValue(t, flags, FlagPruningInterval, DefaultConfig.Pruning.Interval.Duration)
+	assertFlagValue(t, flags, FlagPruningCompactionInterval, DefaultConfig.Pruning.CompactionInterval.Duration)
+
+	// Snapshot flags
+	assertFlagValue(t, flags, FlagSnapshotInterval, DefaultConfig.Snapshot.Interval)
+	assertFlagValue(t, flags, FlagSnapshotKeepRecent, DefaultConfig.Snapshot.KeepRecent)
+	assertFlagValue(t, flags, FlagSnapshotStateSync, DefaultConfig.Snapshot.StateSync)
+
+	// Tx index flags
+	assertFlagValue(t, flags, FlagTxIndexEnabled, DefaultConfig.TxIndex.Enabled)
+
+	// Leader election flags
+	assertFlagValue(t, flags, FlagLeaderElection, DefaultConfig.Leader.Enabled)
+	assertFlagValue(t, flags, FlagLeaderLeaseBlocks, DefaultConfig.Leader.LeaseBlocks)
+	assertFlagValue(t, flags, FlagLeaderNamespace, DefaultConfig.Leader.Namespace)
+
+	// Attestation flags
+	assertFlagValue(t, flags, FlagAttestationAttesters, DefaultConfig.Attestation.Attesters)
+	assertFlagValue(t, flags, FlagAttestationQuorum, DefaultConfig.Attestation.Quorum)
+
+	// Mempool flags
+	assertFlagValue(t, flags, FlagMempoolSize, DefaultConfig.Mempool.Size)
+	assertFlagValue(t, flags, FlagMempoolTTL, DefaultConfig.Mempool.TTL.Duration)
+	assertFlagValue(t, flags, FlagMempoolBroadcast, DefaultConfig.Mempool.Broadcast)
+	assertFlagValue(t, flags, FlagMempoolMaxTxBytes, DefaultConfig.Mempool.MaxTxBytes)
+	assertFlagValue(t, flags, FlagMempoolTxPrefixes, DefaultConfig.Mempool.TxPrefixes)
+
+	// Cache flags
+	assertFlagValue(t, flags, FlagCacheMaxItems, DefaultConfig.Cache.MaxItems)
+	assertFlagValue(t, flags, FlagCacheMaxHashes, DefaultConfig.Cache.MaxHashes)
+
+	// Telemetry flags
+	assertFlagValue(t, flags, FlagTelemetryEnabled, DefaultConfig.Telemetry.Enabled)
+	assertFlagValue(t, flags, FlagTelemetryEndpoint, DefaultConfig.Telemetry.Endpoint)
+	assertFlagValue(t, flags, FlagTelemetryInterval, DefaultConfig.Telemetry.Interval.Duration)
+
+	// Retry flags
+	assertFlagValue(t, flags, FlagRetryDAInitialBackoff, DefaultConfig.Retry.DA.InitialBackoff.Duration)
+	assertFlagValue(t, flags, FlagRetryDAMaxBackoff, DefaultConfig.Retry.DA.MaxBackoff.Duration)
+	assertFlagValue(t, flags, FlagRetryDAMaxAttempts, DefaultConfig.Retry.DA.MaxAttempts)
+	assertFlagValue(t, flags, FlagRetryDAMaxElapsedTime, DefaultConfig.Retry.DA.MaxElapsedTime.Duration)
+	assertFlagValue(t, flags, FlagRetryExecutorInitialBackoff, DefaultConfig.Retry.Executor.InitialBackoff.Duration)
+	assertFlagValue(t, flags, FlagRetryExecutorMaxBackoff, DefaultConfig.Retry.Executor.MaxBackoff.Duration)
+	assertFlagValue(t, flags, FlagRetryExecutorMaxAttempts, DefaultConfig.Retry.Executor.MaxAttempts)
+	assertFlagValue(t, flags, FlagRetryExecutorMaxElapsedTime, DefaultConfig.Retry.Executor.MaxElapsedTime.Duration)
+	assertFlagValue(t, flags, FlagRetrySequencerInitialBackoff, DefaultConfig.Retry.Sequencer.InitialBackoff.Duration)
+	assertFlagValue(t, flags, FlagRetrySequencerMaxBackoff, DefaultConfig.Retry.Sequencer.MaxBackoff.Duration)
+	assertFlagValue(t, flags, FlagRetrySequencerMaxAttempts, DefaultConfig.Retry.Sequencer.MaxAttempts)
+	assertFlagValue(t, flags, FlagRetrySequencerMaxElapsedTime, DefaultConfig.Retry.Sequencer.MaxElapsedTime.Duration)
+	assertFlagValue(t, flags, FlagRetrySyncInitialBackoff, DefaultConfig.Retry.Sync.InitialBackoff.Duration)
+	assertFlagValue(t, flags, FlagRetrySyncMaxBackoff, DefaultConfig.Retry.Sync.MaxBackoff.Duration)
+	assertFlagValue(t, flags, FlagRetrySyncMaxAttempts, DefaultConfig.Retry.Sync.MaxAttempts)
+	assertFlagValue(t, flags, FlagRetrySyncMaxElapsedTime, DefaultConfig.Retry.Sync.MaxElapsedTime.Duration)
 
 	// Count the number of flags we're explicitly checking
-	expectedFlagCount := 35 // Update this number if you add more flag checks above
+	expectedFlagCount := 161 // Update this number if you add more flag checks above
 
 	// Get the actual number of flags (both regular and persistent)
 	actualFlagCount := 0
@@ -296,3 +440,18 @@ func assertFlagValue(t *testing.T, flags *pflag.FlagSet, name string, expectedVa
 		}
 	}
 }
+
+func TestParseLoopShutdownTimeouts(t *testing.T) {
+	timeouts, err := ParseLoopShutdownTimeouts("header_submission=60s  retrieve=500ms")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"header_submission": time.Minute, "retrieve": 500 * time.Millisecond}, timeouts)
+
+	timeouts, err = ParseLoopShutdownTimeouts("")
+	require.NoError(t, err)
+	assert.Empty(t, timeouts)
+
+	for _, spec := range []string{"retrieve", "=5s", "retrieve=fast", "retrieve=-1s"} {
+		_, err = ParseLoopShutdownTimeouts(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -15,6 +15,8 @@ const (
 	ConfigName = ConfigFileName + "." + ConfigExtension
 	// AppConfigDir is the directory name for the app configuration.
 	AppConfigDir = "config"
+	// DebugDir is the directory name for the debug bundles captured by the node.
+	DebugDir = "debug"
 )
 
 // DefaultRootDir returns the default root directory for rollkit
@@ -37,26 +39,55 @@ func DefaultRootDirWithName(appName string) string {
 
 // DefaultConfig keeps default values of NodeConfig
 var DefaultConfig = Config{
-	RootDir: DefaultRootDir,
-	DBPath:  "data",
-	ChainID: "rollkit-test",
+	RootDir:   DefaultRootDir,
+	DBPath:    "data",
+	DBBackend: DBBackendBadger,
+	ChainID:   "rollkit-test",
 	P2P: P2PConfig{
 		ListenAddress: "/ip4/0.0.0.0/tcp/7676",
 		Peers:         "",
+		BanThreshold:  -100,
+		BanDuration:   DurationWrapper{1 * time.Hour},
+
+		BlockDataRequests:     100,
+		BlockDataPeerRequests: 10,
+		BlockDataFetchDelay:   DurationWrapper{10 * time.Second},
 	},
 	Node: NodeConfig{
-		Aggregator:        false,
-		BlockTime:         DurationWrapper{1 * time.Second},
-		LazyMode:          false,
-		LazyBlockInterval: DurationWrapper{60 * time.Second},
-		Light:             false,
-		TrustedHash:       "",
+		Aggregator:             false,
+		BlockTime:              DurationWrapper{1 * time.Second},
+		LazyMode:               false,
+		LazyBlockInterval:      DurationWrapper{60 * time.Second},
+		MaxExecutionLag:        100,
+		SequencingMode:         SequencingModeAggregator,
+		ProofDeadline:          DurationWrapper{10 * time.Minute},
+		ShutdownTimeout:        DurationWrapper{30 * time.Second},
+		LoopShutdownTimeout:    DurationWrapper{10 * time.Second},
+		MinBlockTimeDelta:      DurationWrapper{1 * time.Millisecond},
+		MaxClockDrift:          DurationWrapper{1 * time.Second},
+		Light:                  false,
+		LightServeRequests:     100,
+		LightServePeerRequests: 10,
+		TrustedHash:            "",
+		TrustedHeight:          0,
 	},
 	DA: DAConfig{
-		Address:       "http://localhost:7980",
-		BlockTime:     DurationWrapper{6 * time.Second},
-		GasPrice:      -1,
-		GasMultiplier: 0,
+		Address:                 "http://localhost:7980",
+		BlockTime:               DurationWrapper{6 * time.Second},
+		GasPrice:                -1,
+		GasMultiplier:           0,
+		ForcedInclusionDeadline: 10,
+		Compression:             "none",
+		BackfillRateLimit:       20,
+		RetrieveWorkers:         8,
+		MaxSubmissionsInFlight:  3,
+		MaxBlocksPerBlob:        1,
+		ReorgCheckDepth:         20,
+		AuditInterval:           DurationWrapper{time.Hour},
+		AuditSampleSize:         10,
+		GasPerByte:              8,
+		Quorum:                  1,
+		MaxFailures:             3,
 	},
 	Instrumentation: DefaultInstrumentationConfig(),
 	Log: LogConfig{
@@ -65,10 +96,77 @@ var DefaultConfig = Config{
 		Trace:  false,
 	},
 	Signer: SignerConfig{
-		SignerType: "file",
-		SignerPath: "config",
+		SignerType:          "file",
+		SignerPath:          "config",
+		SignatureScheme:     "ed25519",
+		Timeout:             DurationWrapper{5 * time.Second},
+		HealthCheckInterval: DurationWrapper{5 * time.Second},
+		WatermarkFile:       "data/signer_watermark.json",
 	},
 	RPC: RPCConfig{
-		Address: "127.0.0.1:7331",
+		Address:         "127.0.0.1:7331",
+		RateLimitBurst:  20,
+		MaxRequestBytes: 4 << 20,
+		Timeout:         DurationWrapper{10 * time.Second},
+
+		ReadinessMaxSyncLag: 10,
+	},
+	Pruning: PruningConfig{
+		KeepRecent: 0,
+		Interval:   DurationWrapper{10 * time.Minute},
+	},
+	Snapshot: SnapshotConfig{
+		Interval:   0,
+		KeepRecent: 2,
+		StateSync:  false,
+	},
+	TxIndex: TxIndexConfig{
+		Enabled: false,
+	},
+	Leader: LeaderConfig{
+		Enabled:     false,
+		LeaseBlocks: 10,
+		Namespace:   "",
+	},
+	Attestation: AttestationConfig{
+		Attesters: "",
+		Quorum:    0,
+	},
+	Mempool: MempoolConfig{
+		Size:       5000,
+		TTL:        DurationWrapper{10 * time.Minute},
+		Broadcast:  true,
+		MaxTxBytes: 1 << 20,
+		TxPrefixes: "",
+	},
+	Cache: CacheConfig{
+		MaxItems:  1000,
+		MaxHashes: 100_000,
+	},
+	Telemetry: TelemetryConfig{
+		Interval: DurationWrapper{10 * time.Minute},
+	},
+	Retry: RetryConfig{
+		DA: RetryPolicyConfig{
+			InitialBackoff: DurationWrapper{100 * time.Millisecond},
+			MaxBackoff:     DurationWrapper{6 * time.Second},
+			MaxAttempts:    30,
+		},
+		Executor: RetryPolicyConfig{
+			InitialBackoff: DurationWrapper{100 * time.Millisecond},
+			MaxBackoff:     DurationWrapper{5 * time.Second},
+			MaxAttempts:    5,
+			MaxElapsedTime: DurationWrapper{30 * time.Second},
+		},
+		Sequencer: RetryPolicyConfig{
+			InitialBackoff: DurationWrapper{100 * time.Millisecond},
+			MaxBackoff:     DurationWrapper{1 * time.Second},
+			MaxAttempts:    5,
+		},
+		Sync: RetryPolicyConfig{
+			InitialBackoff: DurationWrapper{1 * time.Second},
+			MaxBackoff:     DurationWrapper{10 * time.Second},
+			MaxElapsedTime: DurationWrapper{time.Minute},
+		},
 	},
 }
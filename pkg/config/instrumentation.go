@@ -1,10 +1,13 @@
 package config
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 // InstrumentationConfig defines the configuration for metrics reporting.
 type InstrumentationConfig struct {
-	// When true, Prometheus metrics are served under /metrics on
+	// When true, Prometheus metrics are served under PrometheusPath on
 	// PrometheusListenAddr.
 	// Check out the documentation for the list of available metrics.
 	Prometheus bool `yaml:"prometheus" comment:"Enable Prometheus metrics"` // When true, Prometheus metrics are served
@@ -12,6 +15,10 @@ type InstrumentationConfig struct {
 	// Address to listen for Prometheus collector(s) connections.
 	PrometheusListenAddr string `mapstructure:"prometheus_listen_addr" yaml:"prometheus_listen_addr" comment:"Address to listen for Prometheus metrics"`
 
+	// HTTP path under which Prometheus metrics are served.
+	// Default is "/metrics".
+	PrometheusPath string `mapstructure:"prometheus_path" yaml:"prometheus_path" comment:"HTTP path under which Prometheus metrics are served"`
+
 	// Maximum number of simultaneous connections.
 	// If you want to accept a larger number than the default, make sure
 	// you increase your OS limits.
@@ -39,6 +46,13 @@ type InstrumentationConfig struct {
 	// Address to listen for pprof connections.
 	// Default is ":6060" which is the standard port for pprof.
 	PprofListenAddr string `mapstructure:"pprof_listen_addr" yaml:"pprof_listen_addr" comment:"Address to listen for pprof connections"`
+
+	// Interval at which debug bundles are captured to the debug directory of
+	// the node. 0 disables the capture.
+	ProfileCaptureInterval DurationWrapper `mapstructure:"profile_capture_interval" yaml:"profile_capture_interval" comment:"Interval at which debug bundles holding the goroutine dump, the heap profile, the runtime metrics and the recent logs of the node are captured to the debug directory under the root directory (duration), to inspect a node which got stuck after the fact. Use 0 to disable the capture."`
+
+	// Number of debug bundles kept in the debug directory.
+	ProfileCaptureKeep int `mapstructure:"profile_capture_keep" yaml:"profile_capture_keep" comment:"Number of most recent debug bundles kept in the debug directory. Older bundles are deleted."`
 }
 
 // DefaultInstrumentationConfig returns a default configuration for metrics
@@ -47,10 +61,12 @@ func DefaultInstrumentationConfig() *InstrumentationConfig {
 	return &InstrumentationConfig{
 		Prometheus:           false,
 		PrometheusListenAddr: ":26660",
+		PrometheusPath:       "/metrics",
 		MaxOpenConnections:   3,
 		Namespace:            "rollkit",
 		Pprof:                false,
 		PprofListenAddr:      ":6060",
+		ProfileCaptureKeep:   24,
 	}
 }
 
@@ -60,6 +76,9 @@ func (cfg *InstrumentationConfig) ValidateBasic() error {
 	if cfg.MaxOpenConnections < 0 {
 		return errors.New("max_open_connections can't be negative")
 	}
+	if cfg.PrometheusPath != "" && !strings.HasPrefix(cfg.PrometheusPath, "/") {
+		return errors.New("prometheus_path must start with /")
+	}
 	return nil
 }
 
@@ -68,6 +87,16 @@ func (cfg *InstrumentationConfig) IsPrometheusEnabled() bool {
 	return cfg.Prometheus && cfg.PrometheusListenAddr != ""
 }
 
+// GetPrometheusPath returns the HTTP path under which Prometheus metrics are served.
+// If PrometheusPath is empty, it returns the default path "/metrics".
+func (cfg *InstrumentationConfig) GetPrometheusPath() string {
+	if cfg.PrometheusPath == "" {
+		return DefaultInstrumentationConfig().PrometheusPath
+	}
+
+	return cfg.PrometheusPath
+}
+
 // IsPprofEnabled returns true if pprof endpoints are enabled.
 func (cfg *InstrumentationConfig) IsPprofEnabled() bool {
 	return cfg.Pprof
@@ -13,6 +13,7 @@ func TestDefaultInstrumentationConfig(t *testing.T) {
 
 	assert.False(t, cfg.Prometheus)
 	assert.Equal(t, ":26660", cfg.PrometheusListenAddr)
+	assert.Equal(t, "/metrics", cfg.PrometheusPath)
 	assert.Equal(t, 3, cfg.MaxOpenConnections)
 	assert.Equal(t, "rollkit", cfg.Namespace)
 	assert.False(t, cfg.Pprof)
@@ -41,6 +42,13 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"relative prometheus path",
+			&InstrumentationConfig{
+				PrometheusPath: "metrics",
+			},
+			true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -167,3 +175,32 @@ func TestGetPprofListenAddr(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPrometheusPath(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		cfg      *InstrumentationConfig
+		expected string
+	}{
+		{
+			"prometheus path set",
+			&InstrumentationConfig{
+				PrometheusPath: "/custom/metrics",
+			},
+			"/custom/metrics",
+		},
+		{
+			"prometheus path not set",
+			&InstrumentationConfig{},
+			"/metrics",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.cfg.GetPrometheusPath())
+		})
+	}
+}
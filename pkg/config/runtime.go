@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ErrInvalidRuntimeConfig is returned when a runtime configuration change results in invalid parameters.
+var ErrInvalidRuntimeConfig = errors.New("invalid runtime config")
+
+// RuntimeUpdate contains the configuration parameters that can be changed while the node is running.
+// Nil fields are left unchanged.
+type RuntimeUpdate struct {
+	BlockTime         *time.Duration
+	LazyMode          *bool
+	LazyBlockInterval *time.Duration
+	DAGasPrice        *float64
+	DAMaxGasPrice     *float64
+	DADailyBudget     *float64
+	PruningKeepRecent *uint64
+	PruningInterval   *time.Duration
+	MaxPeers          *uint64
+}
+
+// Apply returns a copy of the configuration with the update applied. An error wrapping ErrInvalidRuntimeConfig
+// is returned if the resulting runtime parameters are invalid.
+func (u RuntimeUpdate) Apply(c Config) (Config, error) {
+	if u.BlockTime != nil {
+		c.Node.BlockTime.Duration = *u.BlockTime
+	}
+	if u.LazyMode != nil {
+		c.Node.LazyMode = *u.LazyMode
+	}
+	if u.LazyBlockInterval != nil {
+		c.Node.LazyBlockInterval.Duration = *u.LazyBlockInterval
+	}
+	if u.DAGasPrice != nil {
+		c.DA.GasPrice = *u.DAGasPrice
+	}
+	if u.DAMaxGasPrice != nil {
+		c.DA.MaxGasPrice = *u.DAMaxGasPrice
+	}
+	if u.DADailyBudget != nil {
+		c.DA.DailyBudget = *u.DADailyBudget
+	}
+	if u.PruningKeepRecent != nil {
+		c.Pruning.KeepRecent = *u.PruningKeepRecent
+	}
+	if u.PruningInterval != nil {
+		c.Pruning.Interval.Duration = *u.PruningInterval
+	}
+	if u.MaxPeers != nil {
+		c.P2P.MaxPeers = *u.MaxPeers
+	}
+
+	switch {
+	case c.Node.BlockTime.Duration <= 0:
+		return c, fmt.Errorf("%w: block time must be positive", ErrInvalidRuntimeConfig)
+	case c.Node.LazyBlockInterval.Duration <= 0:
+		return c, fmt.Errorf("%w: lazy block interval must be positive", ErrInvalidRuntimeConfig)
+	case c.DA.GasPrice < 0 && c.DA.GasPrice != -1:
+		return c, fmt.Errorf("%w: DA gas price must be -1 or not negative", ErrInvalidRuntimeConfig)
+	case c.DA.MaxGasPrice < 0:
+		return c, fmt.Errorf("%w: maximum DA gas price must not be negative", ErrInvalidRuntimeConfig)
+	case c.DA.MaxGasPrice > 0 && c.DA.GasPrice > c.DA.MaxGasPrice:
+		return c, fmt.Errorf("%w: DA gas price %v exceeds the maximum DA gas price %v", ErrInvalidRuntimeConfig, c.DA.GasPrice, c.DA.MaxGasPrice)
+	case c.DA.DailyBudget < 0:
+		return c, fmt.Errorf("%w: DA daily budget must not be negative", ErrInvalidRuntimeConfig)
+	case c.Pruning.KeepRecent > 0 && c.Pruning.Interval.Duration <= 0:
+		return c, fmt.Errorf("%w: pruning interval must be positive", ErrInvalidRuntimeConfig)
+	}
+	return c, nil
+}
+
+// SaveRuntimeConfig persists the runtime parameters of the configuration to the config file in its root directory.
+// The other parameters of the config file are left unchanged, so that values given by flags are not persisted.
+func SaveRuntimeConfig(c Config) error {
+	v := viper.New()
+	v.Set(FlagRootDir, c.RootDir)
+	file, err := LoadFromViper(v)
+	if err != nil {
+		return err
+	}
+	file.Node.BlockTime = c.Node.BlockTime
+	file.Node.LazyMode = c.Node.LazyMode
+	file.Node.LazyBlockInterval = c.Node.LazyBlockInterval
+	file.DA.GasPrice = c.DA.GasPrice
+	file.DA.MaxGasPrice = c.DA.MaxGasPrice
+	file.DA.DailyBudget = c.DA.DailyBudget
+	file.Pruning.KeepRecent = c.Pruning.KeepRecent
+	file.Pruning.Interval = c.Pruning.Interval
+	file.P2P.MaxPeers = c.P2P.MaxPeers
+	return file.SaveAsYaml()
+}
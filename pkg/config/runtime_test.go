@@ -0,0 +1,74 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuntimeUpdateApply(t *testing.T) {
+	blockTime := 2 * time.Second
+	lazyMode := true
+	gasPrice := 0.5
+	maxPeers := uint64(20)
+	updated, err := RuntimeUpdate{
+		BlockTime:  &blockTime,
+		LazyMode:   &lazyMode,
+		DAGasPrice: &gasPrice,
+		MaxPeers:   &maxPeers,
+	}.Apply(DefaultConfig)
+	require.NoError(t, err)
+	assert.Equal(t, blockTime, updated.Node.BlockTime.Duration)
+	assert.True(t, updated.Node.LazyMode)
+	assert.Equal(t, gasPrice, updated.DA.GasPrice)
+	assert.Equal(t, maxPeers, updated.P2P.MaxPeers)
+	// fields left nil are unchanged, and the original config is not modified
+	assert.Equal(t, DefaultConfig.Node.LazyBlockInterval, updated.Node.LazyBlockInterval)
+	assert.Equal(t, time.Second, DefaultConfig.Node.BlockTime.Duration)
+
+	zero := time.Duration(0)
+	negative := -2.0
+	lowMax := 0.1
+	keepRecent := uint64(100)
+	for name, update := range map[string]RuntimeUpdate{
+		"zero block time":        {BlockTime: &zero},
+		"zero lazy interval":     {LazyBlockInterval: &zero},
+		"negative gas price":     {DAGasPrice: &negative},
+		"negative max gas price": {DAMaxGasPrice: &negative},
+		"negative daily budget":  {DADailyBudget: &negative},
+		"gas price above max":    {DAGasPrice: &gasPrice, DAMaxGasPrice: &lowMax},
+		"zero pruning interval":  {PruningKeepRecent: &keepRecent, PruningInterval: &zero},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := update.Apply(DefaultConfig)
+			assert.ErrorIs(t, err, ErrInvalidRuntimeConfig)
+		})
+	}
+}
+
+func TestSaveRuntimeConfig(t *testing.T) {
+	fileConf := DefaultConfig
+	fileConf.RootDir = t.TempDir()
+	fileConf.P2P.Peers = "seed1.example.com:26656"
+	require.NoError(t, fileConf.SaveAsYaml())
+
+	// the running node was started with flags overriding the config file
+	running := fileConf
+	running.P2P.Peers = "seed2.example.com:26656"
+	running.Node.BlockTime.Duration = 3 * time.Second
+	running.P2P.MaxPeers = 10
+	require.NoError(t, SaveRuntimeConfig(running))
+
+	cmd := &cobra.Command{Use: "test"}
+	AddFlags(cmd)
+	AddGlobalFlags(cmd, "")
+	require.NoError(t, cmd.ParseFlags([]string{"--home=" + fileConf.RootDir}))
+	loaded, err := Load(cmd)
+	require.NoError(t, err)
+	assert.Equal(t, 3*time.Second, loaded.Node.BlockTime.Duration)
+	assert.Equal(t, uint64(10), loaded.P2P.MaxPeers)
+	assert.Equal(t, "seed1.example.com:26656", loaded.P2P.Peers)
+}
@@ -0,0 +1,332 @@
+// Package consistency checks that several full nodes of a rollup agree on the chain. It compares the headers, app
+// hashes and data hashes the nodes serve over RPC at sampled heights, and reports forks and divergence, so that
+// operators of redundant RPC fleets detect non-deterministic execution early.
+package consistency
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"connectrpc.com/connect"
+
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+const (
+	// FieldHeaderHash is the field of a divergence between header hashes
+	FieldHeaderHash = "header hash"
+	// FieldAppHash is the field of a divergence between app hashes
+	FieldAppHash = "app hash"
+	// FieldDataHash is the field of a divergence between data hashes
+	FieldDataHash = "data hash"
+
+	// DefaultSamples is the default number of sampled heights
+	DefaultSamples = 10
+)
+
+// Source serves the status and blocks of a node, it is implemented by the RPC client.
+type Source interface {
+	GetStatus(ctx context.Context) (*pb.GetStatusResponse, error)
+	GetBlockByHeight(ctx context.Context, height uint64) (*pb.Block, error)
+}
+
+// Node is a node checked for consistency.
+type Node struct {
+	// Name identifies the node in reports, e.g. its RPC address.
+	Name   string
+	Source Source
+}
+
+// NodeReport describes a checked node.
+type NodeReport struct {
+	Name             string
+	Height           uint64
+	DAIncludedHeight uint64
+	// Error is the reason the node could not be checked, empty if it was.
+	Error string
+}
+
+// Divergence describes a height at which the nodes disagree.
+type Divergence struct {
+	Height uint64
+	// Field is the diverging field: FieldHeaderHash, FieldAppHash or FieldDataHash.
+	Field string
+	// Values maps the name of every node serving the block to its hex encoded value of the field.
+	Values map[string]string
+	// Finalized is set if the block is DA included on all the nodes serving it, i.e. the nodes diverge on
+	// finalized blocks.
+	Finalized bool
+}
+
+// Report is the outcome of a consistency check.
+type Report struct {
+	Nodes []NodeReport
+	// Heights are the checked heights, in increasing order.
+	Heights []uint64
+	// Divergences are the divergences found at the checked heights, by height.
+	Divergences []Divergence
+	// ForkHeight is the first height at which the header hashes of the nodes differ, 0 if no fork was found.
+	ForkHeight uint64
+}
+
+// Consistent reports whether the nodes agree at all checked heights.
+func (r *Report) Consistent() bool {
+	return len(r.Divergences) == 0
+}
+
+// String implements fmt.Stringer.
+func (r *Report) String() string {
+	var b strings.Builder
+	for _, node := range r.Nodes {
+		if node.Error != "" {
+			fmt.Fprintf(&b, "%s: unreachable: %s\n", node.Name, node.Error)
+			continue
+		}
+		fmt.Fprintf(&b, "%s: height %d, DA included height %d\n", node.Name, node.Height, node.DAIncludedHeight)
+	}
+	fmt.Fprintf(&b, "checked %d heights", len(r.Heights))
+	if len(r.Heights) > 0 {
+		fmt.Fprintf(&b, " from %d to %d", r.Heights[0], r.Heights[len(r.Heights)-1])
+	}
+	b.WriteString("\n")
+	if r.Consistent() {
+		b.WriteString("nodes are consistent\n")
+		return b.String()
+	}
+	if r.ForkHeight > 0 {
+		fmt.Fprintf(&b, "FORK at height %d\n", r.ForkHeight)
+	}
+	for _, d := range r.Divergences {
+		finalized := ""
+		if d.Finalized {
+			finalized = " (DA included)"
+		}
+		fmt.Fprintf(&b, "%s diverges at height %d%s:\n", d.Field, d.Height, finalized)
+		names := make([]string, 0, len(d.Values))
+		for name := range d.Values {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			fmt.Fprintf(&b, "  %s: %s\n", name, d.Values[name])
+		}
+	}
+	return b.String()
+}
+
+// Checker checks that nodes agree on the chain.
+type Checker struct {
+	nodes   []Node
+	samples int
+}
+
+// NewChecker creates a new Checker comparing the given nodes at up to samples heights, besides the latest common
+// height and the DA included heights of the nodes.
+func NewChecker(nodes []Node, samples int) (*Checker, error) {
+	if len(nodes) < 2 {
+		return nil, errors.New("at least two nodes are required")
+	}
+	if samples <= 0 {
+		samples = DefaultSamples
+	}
+	return &Checker{nodes: nodes, samples: samples}, nil
+}
+
+// Check compares the nodes at sampled heights up to the latest height served by all of them. Unreachable nodes
+// are reported and left out, it fails if fewer than two nodes are reachable.
+func (c *Checker) Check(ctx context.Context) (*Report, error) {
+	report := &Report{Nodes: make([]NodeReport, len(c.nodes))}
+	var wg sync.WaitGroup
+	for i, node := range c.nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Nodes[i] = NodeReport{Name: node.Name}
+			status, err := node.Source.GetStatus(ctx)
+			if err != nil {
+				report.Nodes[i].Error = err.Error()
+				return
+			}
+			report.Nodes[i].Height = status.Height
+			report.Nodes[i].DAIncludedHeight = status.DaIncludedHeight
+		}()
+	}
+	wg.Wait()
+
+	var reachable []int
+	var commonHeight uint64
+	for i, node := range report.Nodes {
+		if node.Error != "" {
+			continue
+		}
+		if len(reachable) == 0 || node.Height < commonHeight {
+			commonHeight = node.Height
+		}
+		reachable = append(reachable, i)
+	}
+	if len(reachable) < 2 {
+		return report, errors.New("fewer than two nodes are reachable")
+	}
+
+	daIncludedHeights := make([]uint64, 0, len(reachable))
+	for _, i := range reachable {
+		daIncludedHeights = append(daIncludedHeights, report.Nodes[i].DAIncludedHeight)
+	}
+	report.Heights = sampleHeights(commonHeight, c.samples, daIncludedHeights...)
+
+	// lastAgreed is the highest checked height below the first fork on which the header hashes agree
+	var lastAgreed uint64
+	for _, height := range report.Heights {
+		divergences, err := c.compare(ctx, report, reachable, height)
+		if err != nil {
+			return report, err
+		}
+		report.Divergences = append(report.Divergences, divergences...)
+		forked := slices.ContainsFunc(divergences, func(d Divergence) bool { return d.Field == FieldHeaderHash })
+		if forked && report.ForkHeight == 0 {
+			report.ForkHeight, err = c.findFork(ctx, reachable, lastAgreed, height)
+			if err != nil {
+				return report, err
+			}
+		}
+		if !forked && report.ForkHeight == 0 {
+			lastAgreed = height
+		}
+	}
+	return report, nil
+}
+
+// sampleHeights returns the heights to check up to the common height: samples heights evenly spread from 1 to
+// the common height, the common height itself, and the given heights not above it.
+func sampleHeights(commonHeight uint64, samples int, heights ...uint64) []uint64 {
+	if commonHeight == 0 {
+		return nil
+	}
+	sampled := []uint64{commonHeight}
+	for i := range uint64(samples) { //nolint:gosec // samples is positive
+		sampled = append(sampled, 1+i*(commonHeight-1)/uint64(max(samples-1, 1))) //nolint:gosec // samples is positive
+	}
+	for _, height := range heights {
+		if height > 0 && height <= commonHeight {
+			sampled = append(sampled, height)
+		}
+	}
+	slices.Sort(sampled)
+	return slices.Compact(sampled)
+}
+
+// blockInfo holds the compared fields of the block of a node at a height.
+type blockInfo struct {
+	node   int
+	fields map[string]string
+}
+
+// fetch returns the compared fields of the blocks of the nodes at the given height. Nodes not serving the block,
+// e.g. because they pruned it, are left out.
+func (c *Checker) fetch(ctx context.Context, nodes []int, height uint64) ([]blockInfo, error) {
+	infos := make([]*blockInfo, len(nodes))
+	errs := make([]error, len(nodes))
+	var wg sync.WaitGroup
+	for i, node := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			block, err := c.nodes[node].Source.GetBlockByHeight(ctx, height)
+			if connect.CodeOf(err) == connect.CodeNotFound {
+				return
+			}
+			if err != nil {
+				errs[i] = fmt.Errorf("failed to get block %d from %s: %w", height, c.nodes[node].Name, err)
+				return
+			}
+			var header types.SignedHeader
+			if err := header.FromProto(block.GetHeader()); err != nil {
+				errs[i] = fmt.Errorf("invalid block %d from %s: %w", height, c.nodes[node].Name, err)
+				return
+			}
+			infos[i] = &blockInfo{node: node, fields: map[string]string{
+				FieldHeaderHash: hex.EncodeToString(header.Hash()),
+				FieldAppHash:    hex.EncodeToString(header.AppHash),
+				FieldDataHash:   hex.EncodeToString(header.DataHash),
+			}}
+		}()
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	var result []blockInfo
+	for _, info := range infos {
+		if info != nil {
+			result = append(result, *info)
+		}
+	}
+	return result, nil
+}
+
+// compare returns the divergences between the blocks of the nodes at the given height.
+func (c *Checker) compare(ctx context.Context, report *Report, nodes []int, height uint64) ([]Divergence, error) {
+	infos, err := c.fetch(ctx, nodes, height)
+	if err != nil {
+		return nil, err
+	}
+	var divergences []Divergence
+	for _, field := range []string{FieldHeaderHash, FieldAppHash, FieldDataHash} {
+		values := make(map[string]string, len(infos))
+		finalized := true
+		for _, info := range infos {
+			values[c.nodes[info.node].Name] = info.fields[field]
+			finalized = finalized && height <= report.Nodes[info.node].DAIncludedHeight
+		}
+		if !diverge(values) {
+			continue
+		}
+		divergences = append(divergences, Divergence{Height: height, Field: field, Values: values, Finalized: finalized})
+	}
+	return divergences, nil
+}
+
+// findFork returns the first height above agreed and up to forked at which the header hashes of the nodes
+// differ. Headers commit to the hash of the previous header, so nodes whose headers differ at a height differ at
+// all the following heights too.
+func (c *Checker) findFork(ctx context.Context, nodes []int, agreed, forked uint64) (uint64, error) {
+	for forked-agreed > 1 {
+		mid := agreed + (forked-agreed)/2
+		infos, err := c.fetch(ctx, nodes, mid)
+		if err != nil {
+			return 0, err
+		}
+		values := make(map[string]string, len(infos))
+		for _, info := range infos {
+			values[c.nodes[info.node].Name] = info.fields[FieldHeaderHash]
+		}
+		if diverge(values) {
+			forked = mid
+		} else {
+			agreed = mid
+		}
+	}
+	return forked, nil
+}
+
+// diverge reports whether the values are not all equal.
+func diverge(values map[string]string) bool {
+	var first string
+	var seen bool
+	for _, value := range values {
+		if !seen {
+			first, seen = value, true
+			continue
+		}
+		if value != first {
+			return true
+		}
+	}
+	return false
+}
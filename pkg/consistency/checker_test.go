@@ -0,0 +1,144 @@
+package consistency
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// chain is a Source serving in-memory blocks.
+type chain struct {
+	blocks           []*pb.Block
+	daIncludedHeight uint64
+	// pruned is the height up to which blocks are not served
+	pruned uint64
+	err    error
+	// requested records the requested heights
+	requested []uint64
+}
+
+func (c *chain) GetStatus(context.Context) (*pb.GetStatusResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &pb.GetStatusResponse{Height: uint64(len(c.blocks)), DaIncludedHeight: c.daIncludedHeight}, nil
+}
+
+func (c *chain) GetBlockByHeight(_ context.Context, height uint64) (*pb.Block, error) {
+	c.requested = append(c.requested, height)
+	if height <= c.pruned || height > uint64(len(c.blocks)) {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("block not found"))
+	}
+	return c.blocks[height-1], nil
+}
+
+// newBlocks returns random blocks from the given height on.
+func newBlocks(t *testing.T, from, n uint64) []*pb.Block {
+	t.Helper()
+	blocks := make([]*pb.Block, n)
+	for i := range blocks {
+		header, _ := types.GetRandomBlock(from+uint64(i), 1, "testchain")
+		headerPb, err := header.ToProto()
+		require.NoError(t, err)
+		blocks[i] = &pb.Block{Header: headerPb}
+	}
+	return blocks
+}
+
+func TestNewChecker(t *testing.T) {
+	_, err := NewChecker([]Node{{Name: "a", Source: &chain{}}}, 0)
+	require.Error(t, err)
+	c, err := NewChecker([]Node{{Name: "a", Source: &chain{}}, {Name: "b", Source: &chain{}}}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultSamples, c.samples)
+}
+
+func TestSampleHeights(t *testing.T) {
+	assert.Nil(t, sampleHeights(0, 5))
+	assert.Equal(t, []uint64{1}, sampleHeights(1, 5))
+	assert.Equal(t, []uint64{1, 25, 50, 75, 100}, sampleHeights(100, 5))
+	assert.Equal(t, []uint64{1, 25, 42, 50, 75}, sampleHeights(75, 4, 50, 42, 120, 0))
+}
+
+func TestCheck_Consistent(t *testing.T) {
+	blocks := newBlocks(t, 1, 100)
+	a := &chain{blocks: blocks, daIncludedHeight: 90}
+	b := &chain{blocks: blocks[:95], daIncludedHeight: 80, pruned: 10}
+	c, err := NewChecker([]Node{{Name: "a", Source: a}, {Name: "b", Source: b}}, 5)
+	require.NoError(t, err)
+
+	report, err := c.Check(context.Background())
+	require.NoError(t, err)
+	assert.True(t, report.Consistent())
+	assert.Zero(t, report.ForkHeight)
+	assert.Equal(t, []uint64{1, 24, 48, 71, 80, 90, 95}, report.Heights)
+	assert.Equal(t, []NodeReport{{Name: "a", Height: 100, DAIncludedHeight: 90}, {Name: "b", Height: 95, DAIncludedHeight: 80}}, report.Nodes)
+	assert.Contains(t, report.String(), "nodes are consistent")
+}
+
+func TestCheck_Fork(t *testing.T) {
+	blocks := newBlocks(t, 1, 100)
+	forked := append(append([]*pb.Block{}, blocks[:62]...), newBlocks(t, 63, 38)...)
+	a := &chain{blocks: blocks, daIncludedHeight: 70}
+	b := &chain{blocks: forked, daIncludedHeight: 50}
+	unreachable := &chain{err: errors.New("connection refused")}
+	c, err := NewChecker([]Node{{Name: "a", Source: a}, {Name: "b", Source: b}, {Name: "c", Source: unreachable}}, 5)
+	require.NoError(t, err)
+
+	report, err := c.Check(context.Background())
+	require.NoError(t, err)
+	assert.False(t, report.Consistent())
+	assert.Equal(t, uint64(63), report.ForkHeight)
+	assert.Equal(t, "connection refused", report.Nodes[2].Error)
+
+	// all the checked heights from the fork on diverge
+	var heights []uint64
+	for _, d := range report.Divergences {
+		if d.Field == FieldHeaderHash {
+			heights = append(heights, d.Height)
+			assert.False(t, d.Finalized)
+			assert.Len(t, d.Values, 2)
+		}
+	}
+	assert.Equal(t, []uint64{70, 75, 100}, heights)
+	assert.Contains(t, report.String(), "FORK at height 63")
+}
+
+func TestCheck_FinalizedDivergence(t *testing.T) {
+	blocks := newBlocks(t, 1, 10)
+	diverged := append([]*pb.Block{}, blocks...)
+	// the app hash differs at height 10, which both nodes DA included
+	var header types.SignedHeader
+	require.NoError(t, header.FromProto(blocks[9].Header))
+	header.AppHash = []byte{1, 2, 3}
+	headerPb, err := header.ToProto()
+	require.NoError(t, err)
+	diverged[9] = &pb.Block{Header: headerPb}
+
+	c, err := NewChecker([]Node{{Name: "a", Source: &chain{blocks: blocks, daIncludedHeight: 10}}, {Name: "b", Source: &chain{blocks: diverged, daIncludedHeight: 10}}}, 2)
+	require.NoError(t, err)
+	report, err := c.Check(context.Background())
+	require.NoError(t, err)
+	require.Len(t, report.Divergences, 2)
+	assert.Equal(t, uint64(10), report.ForkHeight)
+	for _, d := range report.Divergences {
+		assert.Equal(t, uint64(10), d.Height)
+		assert.True(t, d.Finalized)
+	}
+	assert.Equal(t, FieldAppHash, report.Divergences[1].Field)
+	assert.Equal(t, "010203", report.Divergences[1].Values["b"])
+}
+
+func TestCheck_Unreachable(t *testing.T) {
+	c, err := NewChecker([]Node{{Name: "a", Source: &chain{blocks: newBlocks(t, 1, 3)}}, {Name: "b", Source: &chain{err: errors.New("timeout")}}}, 2)
+	require.NoError(t, err)
+	_, err = c.Check(context.Background())
+	require.Error(t, err)
+}
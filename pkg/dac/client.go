@@ -0,0 +1,121 @@
+package dac
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+	rpc "github.com/rollkit/rollkit/types/pb/rollkit/v1/v1connect"
+)
+
+// Client is a Member reached through its remote DACService.
+type Client struct {
+	client rpc.DACServiceClient
+}
+
+var _ Member = (*Client)(nil)
+
+// NewClient returns a Client connecting to the committee member at address. Addresses without a scheme are
+// connected to without TLS, https addresses are verified with the system roots.
+func NewClient(address string) (*Client, error) {
+	if address == "" {
+		return nil, errors.New("committee member address is empty")
+	}
+	baseURL := address
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "http://" + baseURL
+	}
+
+	// gRPC requires HTTP/2, which is negotiated with TLS or used without it
+	transport := &http.Transport{Protocols: new(http.Protocols)}
+	switch {
+	case strings.HasPrefix(baseURL, "https://"):
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		transport.Protocols.SetHTTP2(true)
+	case strings.HasPrefix(baseURL, "http://"):
+		transport.Protocols.SetUnencryptedHTTP2(true)
+	default:
+		return nil, fmt.Errorf("unsupported committee member address %s, expected an http or https address", address)
+	}
+
+	return &Client{
+		client: rpc.NewDACServiceClient(&http.Client{Transport: transport}, baseURL, connect.WithGRPC()),
+	}, nil
+}
+
+// StoreData implements the Member interface.
+func (c *Client) StoreData(ctx context.Context, header *types.SignedHeader, data *types.Data) (types.Signature, error) {
+	ph, err := header.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.StoreData(ctx, connect.NewRequest(&pb.StoreDataRequest{
+		Header: ph,
+		Data:   data.ToProto(),
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg.Signature, nil
+}
+
+// GetData implements the Member interface.
+func (c *Client) GetData(ctx context.Context, headerHash types.Hash) (*types.Data, error) {
+	resp, err := c.client.GetData(ctx, connect.NewRequest(&pb.GetDataRequest{HeaderHash: headerHash}))
+	if connect.CodeOf(err) == connect.CodeNotFound {
+		return nil, fmt.Errorf("%w: %w", ErrNotFound, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	data := new(types.Data)
+	if err := data.FromProto(resp.Msg.Data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// server exposes a Member as a DACService.
+type server struct {
+	member Member
+}
+
+// NewHandler returns the path and handler serving the DACService backed by the member.
+func NewHandler(m Member, opts ...connect.HandlerOption) (string, http.Handler) {
+	return rpc.NewDACServiceHandler(&server{member: m}, opts...)
+}
+
+// StoreData implements the DACServiceHandler interface.
+func (s *server) StoreData(ctx context.Context, req *connect.Request[pb.StoreDataRequest]) (*connect.Response[pb.StoreDataResponse], error) {
+	header, data := new(types.SignedHeader), new(types.Data)
+	if err := header.FromProto(req.Msg.Header); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err := data.FromProto(req.Msg.Data); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	signature, err := s.member.StoreData(ctx, header, data)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&pb.StoreDataResponse{Signature: signature}), nil
+}
+
+// GetData implements the DACServiceHandler interface.
+func (s *server) GetData(ctx context.Context, req *connect.Request[pb.GetDataRequest]) (*connect.Response[pb.GetDataResponse], error) {
+	data, err := s.member.GetData(ctx, req.Msg.HeaderHash)
+	if errors.Is(err, ErrNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&pb.GetDataResponse{Data: data.ToProto()}), nil
+}
@@ -0,0 +1,141 @@
+package dac
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/types"
+)
+
+// storeTimeout is the deadline of storing the data of a block on a member.
+const storeTimeout = 10 * time.Second
+
+// Committee is the data availability committee of a chain in commitments-only mode. It certifies the data of the
+// blocks produced by the aggregator, verifies the certificates of headers and fetches block data from its members.
+type Committee struct {
+	types.DACommittee
+	members []Member
+}
+
+// NewCommittee returns the committee of the members given as comma separated pubkey@address entries, where pubkey
+// is the hex encoded public key of the member and address the address of its DACService.
+func NewCommittee(spec string, threshold int) (*Committee, error) {
+	var keys []crypto.PubKey
+	var members []Member
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		keyHex, address, ok := strings.Cut(entry, "@")
+		if !ok {
+			return nil, fmt.Errorf("invalid committee member %q, expected pubkey@address", entry)
+		}
+		bz, err := hex.DecodeString(keyHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key of committee member %q: %w", entry, err)
+		}
+		key, err := signer.UnmarshalPublicKey(bz)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key of committee member %q: %w", entry, err)
+		}
+		client, err := NewClient(address)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		members = append(members, client)
+	}
+	return NewCommitteeWithMembers(keys, members, threshold)
+}
+
+// NewCommitteeWithMembers returns the committee of the given members, where keys[i] is the public key of
+// members[i].
+func NewCommitteeWithMembers(keys []crypto.PubKey, members []Member, threshold int) (*Committee, error) {
+	if len(members) == 0 {
+		return nil, errors.New("data availability committee has no members")
+	}
+	if len(keys) != len(members) {
+		return nil, fmt.Errorf("got %d public keys for %d committee members", len(keys), len(members))
+	}
+	if threshold < 1 || threshold > len(members) {
+		return nil, fmt.Errorf("committee threshold %d must be between 1 and the number of members %d", threshold, len(members))
+	}
+	return &Committee{
+		DACommittee: types.DACommittee{Members: keys, Threshold: threshold},
+		members:     members,
+	}, nil
+}
+
+// Certify stores the data of the block on all members and returns the attestations of the members which stored
+// it, the DA certificate of the header. It fails if fewer than Threshold members attested the data.
+func (c *Committee) Certify(ctx context.Context, header *types.SignedHeader, data *types.Data) ([]types.DACAttestation, error) {
+	bz, err := types.DACSignBytes(&header.Header)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, storeTimeout)
+	defer cancel()
+
+	var (
+		mu           sync.Mutex
+		wg           sync.WaitGroup
+		errs         error
+		attestations []types.DACAttestation
+	)
+	for i, member := range c.members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			signature, err := member.StoreData(ctx, header, data)
+			if err == nil {
+				var verified bool
+				if verified, err = c.Members[i].Verify(bz, signature); err == nil && !verified {
+					err = types.ErrSignatureVerificationFailed
+				}
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = errors.Join(errs, fmt.Errorf("member %d: %w", i, err))
+				return
+			}
+			attestations = append(attestations, types.DACAttestation{
+				Signer:    types.Signer{PubKey: c.Members[i], Address: types.KeyAddress(c.Members[i])},
+				Signature: signature,
+			})
+		}()
+	}
+	wg.Wait()
+	if len(attestations) < c.Threshold {
+		return nil, fmt.Errorf("%w: %d of %d required committee signatures: %w", types.ErrInvalidDACertificate, len(attestations), c.Threshold, errs)
+	}
+	return attestations, nil
+}
+
+// Fetch returns the data of the block of the header from the first member serving it, verified against the header.
+func (c *Committee) Fetch(ctx context.Context, header *types.SignedHeader) (*types.Data, error) {
+	var errs error
+	for i, member := range c.members {
+		data, err := member.GetData(ctx, header.Hash())
+		if err == nil {
+			err = types.Validate(header, data)
+		}
+		if err == nil {
+			return data, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		errs = errors.Join(errs, fmt.Errorf("member %d: %w", i, err))
+	}
+	return nil, errs
+}
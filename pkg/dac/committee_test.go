@@ -0,0 +1,114 @@
+package dac
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/types"
+)
+
+// newTestMember serves a LocalMember over gRPC without TLS and returns its committee entry.
+func newTestMember(t *testing.T) (string, crypto.PubKey) {
+	t.Helper()
+	privKey, pubKey, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	s, err := noop.NewNoopSigner(privKey)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.Handle(NewHandler(NewLocalMember(s, dssync.MutexWrap(ds.NewMapDatastore()))))
+	ts := httptest.NewUnstartedServer(mux)
+	ts.Config.Protocols = new(http.Protocols)
+	ts.Config.Protocols.SetUnencryptedHTTP2(true)
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	bz, err := signer.MarshalPublicKey(pubKey)
+	require.NoError(t, err)
+	return hex.EncodeToString(bz) + "@" + ts.URL, pubKey
+}
+
+// unavailableMember is a Member which cannot be reached.
+type unavailableMember struct{}
+
+func (unavailableMember) StoreData(context.Context, *types.SignedHeader, *types.Data) (types.Signature, error) {
+	return nil, errors.New("unavailable")
+}
+
+func (unavailableMember) GetData(context.Context, types.Hash) (*types.Data, error) {
+	return nil, errors.New("unavailable")
+}
+
+func TestCommittee(t *testing.T) {
+	ctx := context.Background()
+	entry1, key1 := newTestMember(t)
+	entry2, key2 := newTestMember(t)
+	committee, err := NewCommittee(entry1+", "+entry2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []crypto.PubKey{key1, key2}, committee.Members)
+
+	header, data := types.GetRandomBlock(5, 3, "test-chain")
+	certificate, err := committee.Certify(ctx, header, data)
+	require.NoError(t, err)
+	assert.Len(t, certificate, 2)
+	header.DACertificate = certificate
+	require.NoError(t, committee.VerifyHeader(header))
+
+	fetched, err := committee.Fetch(ctx, header)
+	require.NoError(t, err)
+	assert.Equal(t, data, fetched)
+
+	t.Run("unknown blocks are not served", func(t *testing.T) {
+		other, _ := types.GetRandomBlock(6, 1, "test-chain")
+		_, err := committee.Fetch(ctx, other)
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("members only store data matching the header", func(t *testing.T) {
+		_, otherData := types.GetRandomBlock(5, 3, "test-chain")
+		_, err := committee.Certify(ctx, header, otherData)
+		assert.ErrorIs(t, err, types.ErrInvalidDACertificate)
+	})
+
+	t.Run("certification fails below the threshold", func(t *testing.T) {
+		_, key3, err := crypto.GenerateEd25519Key(rand.Reader)
+		require.NoError(t, err)
+		members := append(committee.members, unavailableMember{})
+		keys := []crypto.PubKey{key1, key2, key3}
+
+		degraded, err := NewCommitteeWithMembers(keys, members, 2)
+		require.NoError(t, err)
+		certificate, err := degraded.Certify(ctx, header, data)
+		require.NoError(t, err)
+		assert.Len(t, certificate, 2)
+
+		degraded, err = NewCommitteeWithMembers(keys, members, 3)
+		require.NoError(t, err)
+		_, err = degraded.Certify(ctx, header, data)
+		assert.ErrorIs(t, err, types.ErrInvalidDACertificate)
+	})
+
+	t.Run("invalid committees", func(t *testing.T) {
+		_, err := NewCommittee(entry1, 2)
+		assert.Error(t, err)
+		_, err = NewCommittee(entry1, 0)
+		assert.Error(t, err)
+		_, err = NewCommittee("", 1)
+		assert.Error(t, err)
+		_, err = NewCommittee("nokey", 1)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,21 @@
+/*
+Package dac implements the data availability committee of chains running in commitments-only mode, where only the
+headers of blocks are posted to the DA layer and the data of blocks is stored by the members of the committee.
+
+The aggregator stores the data of every block with transactions on the members and attaches their signatures to
+the header, as its DA certificate. Full and light nodes only accept headers attested by enough members, and full
+nodes fetch the data of blocks from the members when it was not gossiped.
+
+	committee, err := NewCommittee(conf.DA.DACMembers, conf.DA.DACThreshold)
+	if err != nil {
+		panic(err)
+	}
+	types.HeaderValidators.Register("dac", committee.VerifyHeader)
+
+Members serve the DACService over gRPC. LocalMember is a reference implementation of a member, storing block data
+in a datastore, and NewHandler exposes it as a DACService:
+
+	mux := http.NewServeMux()
+	mux.Handle(NewHandler(NewLocalMember(signer, datastore)))
+*/
+package dac
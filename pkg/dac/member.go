@@ -0,0 +1,85 @@
+package dac
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ds "github.com/ipfs/go-datastore"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// ErrNotFound is returned by members which do not store the requested block data.
+var ErrNotFound = errors.New("block data not found")
+
+// Member is a member of the data availability committee.
+type Member interface {
+	// StoreData stores the data of the block of the header and returns the signature of the member attesting it,
+	// over the DACSignBytes of the header.
+	StoreData(ctx context.Context, header *types.SignedHeader, data *types.Data) (types.Signature, error)
+	// GetData returns the data of the block with the given header hash.
+	GetData(ctx context.Context, headerHash types.Hash) (*types.Data, error)
+}
+
+// LocalMember is a reference committee member storing block data in a datastore.
+type LocalMember struct {
+	signer signer.Signer
+	store  ds.Datastore
+}
+
+var _ Member = (*LocalMember)(nil)
+
+// NewLocalMember creates a new LocalMember signing attestations with the signer.
+func NewLocalMember(signer signer.Signer, store ds.Datastore) *LocalMember {
+	return &LocalMember{signer: signer, store: store}
+}
+
+// StoreData implements the Member interface. The header must be signed by its signer and commit to the data.
+func (m *LocalMember) StoreData(ctx context.Context, header *types.SignedHeader, data *types.Data) (types.Signature, error) {
+	if err := header.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid header: %w", err)
+	}
+	if err := types.Validate(header, data); err != nil {
+		return nil, err
+	}
+	bz, err := proto.Marshal(data.ToProto())
+	if err != nil {
+		return nil, err
+	}
+	if err := m.store.Put(ctx, dataKey(header.Hash()), bz); err != nil {
+		return nil, fmt.Errorf("failed to store block data: %w", err)
+	}
+	attestation, err := types.GetDACAttestation(m.signer, &header.Header)
+	if err != nil {
+		return nil, err
+	}
+	return attestation.Signature, nil
+}
+
+// GetData implements the Member interface.
+func (m *LocalMember) GetData(ctx context.Context, headerHash types.Hash) (*types.Data, error) {
+	bz, err := m.store.Get(ctx, dataKey(headerHash))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var pd pb.Data
+	if err := proto.Unmarshal(bz, &pd); err != nil {
+		return nil, err
+	}
+	data := new(types.Data)
+	if err := data.FromProto(&pd); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func dataKey(headerHash types.Hash) ds.Key {
+	return ds.NewKey("/dac/" + headerHash.String())
+}
@@ -0,0 +1,100 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"cosmossdk.io/log"
+
+	"github.com/rollkit/rollkit/pkg/logging"
+)
+
+// Capturer captures a debug bundle to a directory every interval, keeping the most recent bundles, so that the
+// state of a node which got stuck can be inspected after the fact.
+type Capturer struct {
+	dir      string
+	interval time.Duration
+	keep     int
+	logs     *logging.Recent
+	logger   log.Logger
+}
+
+// NewCapturer creates a Capturer writing bundles holding the given recent logs to dir every interval, and keeping
+// the last keep bundles, at least one.
+func NewCapturer(dir string, interval time.Duration, keep int, logs *logging.Recent, logger log.Logger) *Capturer {
+	return &Capturer{
+		dir:      dir,
+		interval: interval,
+		keep:     max(keep, 1),
+		logs:     logs,
+		logger:   logger,
+	}
+}
+
+// Run captures a bundle every interval until the context is canceled. Bundles which cannot be captured are logged
+// and skipped. It returns immediately if the interval is not positive.
+func (c *Capturer) Run(ctx context.Context) error {
+	if c.interval <= 0 {
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create debug bundle directory: %w", err)
+	}
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if err := c.Capture(time.Now()); err != nil {
+			c.logger.Error("failed to capture debug bundle", "dir", c.dir, "error", err)
+		}
+	}
+}
+
+// Capture writes a debug bundle captured at the given time to the directory, and deletes the oldest bundles
+// beyond the number of bundles kept.
+func (c *Capturer) Capture(now time.Time) error {
+	path := filepath.Join(c.dir, BundleName(now))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := WriteBundle(f, c.logs); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return c.prune()
+}
+
+// prune deletes the oldest bundles beyond the number of bundles kept. Bundle names sort by capture time.
+func (c *Capturer) prune() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	var bundles []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), "debug-") && strings.HasSuffix(e.Name(), ".tar.gz") {
+			bundles = append(bundles, e.Name())
+		}
+	}
+	sort.Strings(bundles)
+	for len(bundles) > c.keep {
+		if err := os.Remove(filepath.Join(c.dir, bundles[0])); err != nil {
+			return err
+		}
+		bundles = bundles[1:]
+	}
+	return nil
+}
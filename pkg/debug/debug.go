@@ -0,0 +1,132 @@
+// Package debug serves the debug endpoints of a node, pprof profiles and runtime metrics, and captures debug
+// bundles: tarballs of the goroutine dump, the heap profile, the runtime metrics and the recent logs of the node,
+// to attach to support tickets.
+package debug
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/pprof"
+	"runtime/metrics"
+	runtimepprof "runtime/pprof"
+	"time"
+
+	"github.com/rollkit/rollkit/pkg/logging"
+)
+
+// NewHandler returns the handler of the debug endpoints:
+//   - /debug/pprof/   - pprof profiles, see net/http/pprof
+//   - /debug/runtime  - runtime metrics, see runtime/metrics
+//   - /debug/bundle   - a debug bundle, see WriteBundle
+//
+// logs are the recent logs included in bundles, nil if not retained.
+func NewHandler(logs *logging.Recent) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	for _, profile := range []string{"goroutine", "heap", "threadcreate", "block", "mutex", "allocs"} {
+		mux.Handle("/debug/pprof/"+profile, pprof.Handler(profile))
+	}
+	mux.HandleFunc("/debug/runtime", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_ = WriteRuntimeMetrics(w)
+	})
+	mux.HandleFunc("/debug/bundle", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", BundleName(time.Now())))
+		_ = WriteBundle(w, logs)
+	})
+	return mux
+}
+
+// BundleName returns the file name of a debug bundle captured at the given time.
+func BundleName(t time.Time) string {
+	return "debug-" + t.UTC().Format("20060102T150405Z") + ".tar.gz"
+}
+
+// WriteBundle writes a debug bundle to w: a gzipped tarball holding
+//   - goroutines.txt  - the stack traces of all goroutines
+//   - heap.pb.gz      - the heap profile, for go tool pprof
+//   - runtime.txt     - the runtime metrics
+//   - logs.txt        - the recent logs, if logs is not nil
+func WriteBundle(w io.Writer, logs *logging.Recent) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	files := []bundleFile{
+		{"goroutines.txt", func(w io.Writer) error { return runtimepprof.Lookup("goroutine").WriteTo(w, 2) }},
+		{"heap.pb.gz", func(w io.Writer) error { return runtimepprof.Lookup("heap").WriteTo(w, 0) }},
+		{"runtime.txt", WriteRuntimeMetrics},
+	}
+	if logs != nil {
+		files = append(files, bundleFile{"logs.txt", func(w io.Writer) error {
+			_, err := w.Write(logs.Bytes())
+			return err
+		}})
+	}
+	now := time.Now()
+	for _, f := range files {
+		if err := f.writeTo(tw, now); err != nil {
+			return fmt.Errorf("failed to write %s to debug bundle: %w", f.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// bundleFile is a file of a debug bundle.
+type bundleFile struct {
+	name  string
+	write func(io.Writer) error
+}
+
+// writeTo adds the file to the tarball. The content is buffered, as the size of tar entries is written first.
+func (f bundleFile) writeTo(tw *tar.Writer, modTime time.Time) error {
+	var buf bytes.Buffer
+	if err := f.write(&buf); err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0o644, Size: int64(buf.Len()), ModTime: modTime}); err != nil {
+		return err
+	}
+	_, err := tw.Write(buf.Bytes())
+	return err
+}
+
+// WriteRuntimeMetrics writes the scalar runtime metrics to w, one "name value" pair per line, and the count of
+// the samples of histograms.
+func WriteRuntimeMetrics(w io.Writer) error {
+	descs := metrics.All()
+	samples := make([]metrics.Sample, len(descs))
+	for i, desc := range descs {
+		samples[i].Name = desc.Name
+	}
+	metrics.Read(samples)
+	for _, sample := range samples {
+		var err error
+		switch sample.Value.Kind() {
+		case metrics.KindUint64:
+			_, err = fmt.Fprintf(w, "%s %d\n", sample.Name, sample.Value.Uint64())
+		case metrics.KindFloat64:
+			_, err = fmt.Fprintf(w, "%s %g\n", sample.Name, sample.Value.Float64())
+		case metrics.KindFloat64Histogram:
+			var count uint64
+			for _, c := range sample.Value.Float64Histogram().Counts {
+				count += c
+			}
+			_, err = fmt.Fprintf(w, "%s count=%d\n", sample.Name, count)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package debug
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/logging"
+)
+
+// readBundle returns the files of a debug bundle by name.
+func readBundle(t *testing.T, r io.Reader) map[string][]byte {
+	t.Helper()
+	gz, err := gzip.NewReader(r)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	files := make(map[string][]byte)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+		files[hdr.Name], err = io.ReadAll(tr)
+		require.NoError(t, err)
+	}
+}
+
+func TestWriteBundle(t *testing.T) {
+	logs := logging.NewRecent(10)
+	_, err := logs.Write([]byte("stuck in DAIncluderLoop\n"))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteBundle(&buf, logs))
+	files := readBundle(t, &buf)
+	assert.Contains(t, string(files["goroutines.txt"]), "TestWriteBundle")
+	assert.NotEmpty(t, files["heap.pb.gz"])
+	assert.Contains(t, string(files["runtime.txt"]), "/sched/goroutines:goroutines")
+	assert.Equal(t, "stuck in DAIncluderLoop\n", string(files["logs.txt"]))
+
+	// logs are left out if they are not retained
+	buf.Reset()
+	require.NoError(t, WriteBundle(&buf, nil))
+	assert.NotContains(t, readBundle(t, &buf), "logs.txt")
+}
+
+func TestHandler(t *testing.T) {
+	srv := httptest.NewServer(NewHandler(nil))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/debug/runtime")
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Contains(t, string(body), "/gc/cycles/total:gc-cycles")
+
+	resp, err = http.Get(srv.URL + "/debug/bundle")
+	require.NoError(t, err)
+	defer resp.Body.Close() //nolint:errcheck
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, readBundle(t, resp.Body), "goroutines.txt")
+}
+
+// TestCapturer verifies that bundles are captured to the directory, and that only the most recent bundles are kept.
+func TestCapturer(t *testing.T) {
+	dir := t.TempDir()
+	c := NewCapturer(dir, time.Hour, 2, nil, log.NewNopLogger())
+	start := time.Now()
+	for i := range 3 {
+		require.NoError(t, c.Capture(start.Add(time.Duration(i)*time.Second)))
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, BundleName(start.Add(time.Second)), entries[0].Name())
+	assert.Equal(t, BundleName(start.Add(2*time.Second)), entries[1].Name())
+}
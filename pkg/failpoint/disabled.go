@@ -0,0 +1,10 @@
+//go:build !failpoints
+
+package failpoint
+
+import "context"
+
+// Inject returns nil, failpoints are only compiled in with the failpoints build tag.
+func Inject(context.Context, string) error {
+	return nil
+}
@@ -0,0 +1,78 @@
+//go:build failpoints
+
+package failpoint
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// failpoint is an enabled failpoint.
+type failpoint struct {
+	action Action
+	// triggered is the number of times the failpoint triggered since it was enabled
+	triggered int
+}
+
+var (
+	mu         sync.Mutex
+	failpoints = make(map[string]*failpoint)
+)
+
+// Enable enables the failpoint with the given action, replacing its previous action.
+func Enable(name string, action Action) {
+	mu.Lock()
+	defer mu.Unlock()
+	failpoints[name] = &failpoint{action: action}
+}
+
+// Disable disables the failpoint.
+func Disable(name string) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(failpoints, name)
+}
+
+// Reset disables all failpoints.
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	clear(failpoints)
+}
+
+// Triggered returns the number of times the failpoint triggered since it was enabled, 0 if it is disabled.
+func Triggered(name string) int {
+	mu.Lock()
+	defer mu.Unlock()
+	if fp, ok := failpoints[name]; ok {
+		return fp.triggered
+	}
+	return 0
+}
+
+// Inject triggers the failpoint if it is enabled: it waits for the delay of its action, then returns the error
+// of its action. It returns nil if the failpoint is disabled, and the context error if the context is done
+// before the delay elapsed.
+func Inject(ctx context.Context, name string) error {
+	mu.Lock()
+	fp, ok := failpoints[name]
+	if !ok || (fp.action.Times > 0 && fp.triggered >= fp.action.Times) {
+		mu.Unlock()
+		return nil
+	}
+	fp.triggered++
+	action := fp.action
+	mu.Unlock()
+
+	if action.Delay > 0 {
+		timer := time.NewTimer(action.Delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return action.Err
+}
@@ -0,0 +1,47 @@
+// Package failpoint injects failures at named points of the block manager and sync services, so that tests can
+// exercise the crash and retry paths of the node: DA timeouts, executor errors, p2p partitions and slow store
+// writes.
+//
+// Failpoints are only compiled in with the failpoints build tag. In other builds Inject always returns nil and
+// is inlined away, so production binaries are unaffected:
+//
+//	go test -tags=failpoints ./...
+package failpoint
+
+import (
+	"errors"
+	"time"
+)
+
+// Names of the failpoints.
+const (
+	// DASubmit fails the submission of blobs to the DA layer with the injected error, which is mapped to a DA
+	// status like errors of the DA client, e.g. coreda.ErrContextDeadline for a DA timeout.
+	DASubmit = "da/submit"
+	// DARetrieve fails the retrieval of blobs from the DA layer with the injected error.
+	DARetrieve = "da/retrieve"
+	// ExecuteTxs fails the execution of the transactions of a block with the injected error.
+	ExecuteTxs = "block/execute-txs"
+	// SaveBlock fails or delays the writes of blocks to the store.
+	SaveBlock = "block/save-block"
+	// DAIncludedHeight fails the increment of the DA included height, which crashes the DA includer.
+	DAIncludedHeight = "block/da-included-height"
+	// P2PRetrieve partitions the node from the headers and data received over p2p, which are left in the sync
+	// stores until the failpoint is disabled.
+	P2PRetrieve = "block/p2p-retrieve"
+	// SyncBroadcast partitions the node from its peers by dropping the headers and data it broadcasts.
+	SyncBroadcast = "sync/broadcast"
+)
+
+// ErrInjected is the error returned by tests which do not need a specific error.
+var ErrInjected = errors.New("injected failure")
+
+// Action is the failure injected when an enabled failpoint is reached.
+type Action struct {
+	// Err is returned by Inject, nil to only delay the caller
+	Err error
+	// Delay is waited before Inject returns, or until its context is done
+	Delay time.Duration
+	// Times is the number of times the failpoint triggers before disabling itself, 0 to trigger until disabled
+	Times int
+}
@@ -0,0 +1,51 @@
+//go:build failpoints
+
+package failpoint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInject(t *testing.T) {
+	t.Cleanup(Reset)
+	ctx := context.Background()
+	assert.NoError(t, Inject(ctx, DASubmit))
+
+	Enable(DASubmit, Action{Err: ErrInjected})
+	assert.ErrorIs(t, Inject(ctx, DASubmit), ErrInjected)
+	assert.ErrorIs(t, Inject(ctx, DASubmit), ErrInjected)
+	assert.Equal(t, 2, Triggered(DASubmit))
+	assert.NoError(t, Inject(ctx, DARetrieve))
+
+	Disable(DASubmit)
+	assert.NoError(t, Inject(ctx, DASubmit))
+	assert.Equal(t, 0, Triggered(DASubmit))
+
+	// failpoints stop triggering after the given number of times
+	Enable(ExecuteTxs, Action{Err: ErrInjected, Times: 2})
+	assert.Error(t, Inject(ctx, ExecuteTxs))
+	assert.Error(t, Inject(ctx, ExecuteTxs))
+	assert.NoError(t, Inject(ctx, ExecuteTxs))
+	assert.Equal(t, 2, Triggered(ExecuteTxs))
+
+	Reset()
+	assert.NoError(t, Inject(ctx, ExecuteTxs))
+}
+
+func TestInject_Delay(t *testing.T) {
+	t.Cleanup(Reset)
+	Enable(SaveBlock, Action{Delay: 50 * time.Millisecond})
+	start := time.Now()
+	assert.NoError(t, Inject(context.Background(), SaveBlock))
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	// the delay is interrupted when the context is done
+	Enable(SaveBlock, Action{Delay: time.Hour, Err: ErrInjected})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, Inject(ctx, SaveBlock), context.DeadlineExceeded)
+}
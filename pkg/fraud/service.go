@@ -0,0 +1,130 @@
+package fraud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/log"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// Verifier verifies the fraud proofs received from peers and halts the node on valid fraud proofs.
+// It is implemented by block.Manager.
+type Verifier interface {
+	// VerifyFraudProof returns nil if the proof is valid, and an error wrapping
+	// types.ErrUnverifiableFraudProof if the node cannot verify it.
+	VerifyFraudProof(ctx context.Context, proof *types.FraudProof) error
+	// HandleFraudProof verifies the proof and halts the node if it is valid.
+	HandleFraudProof(ctx context.Context, proof *types.FraudProof) error
+}
+
+// Service gossips fraud proofs over a dedicated pubsub topic. Only fraud proofs verified by the node are
+// relayed, and peers gossiping invalid fraud proofs are penalized by the p2p client.
+type Service struct {
+	ps       *pubsub.PubSub
+	self     peer.ID
+	topicID  string
+	verifier Verifier
+	logger   log.Logger
+
+	topic *pubsub.Topic
+	sub   *pubsub.Subscription
+}
+
+// NewService creates a fraud proof Service for the given chain. self is the ID of the local peer, whose
+// fraud proofs are not handled again when delivered back by pubsub.
+func NewService(ps *pubsub.PubSub, self peer.ID, chainID string, verifier Verifier, logger log.Logger) *Service {
+	return &Service{
+		ps:       ps,
+		self:     self,
+		topicID:  TopicID(chainID),
+		verifier: verifier,
+		logger:   logger,
+	}
+}
+
+// TopicID returns the pubsub topic on which fraud proofs are gossiped for the given chain.
+func TopicID(chainID string) string {
+	return fmt.Sprintf("/%s/fraud/v0.0.1", chainID)
+}
+
+// Start joins the fraud proof topic and handles the fraud proofs received from peers until ctx is canceled.
+func (s *Service) Start(ctx context.Context) error {
+	if err := s.ps.RegisterTopicValidator(s.topicID, s.validate); err != nil {
+		return fmt.Errorf("failed to register fraud proof validator: %w", err)
+	}
+	topic, err := s.ps.Join(s.topicID)
+	if err != nil {
+		return fmt.Errorf("failed to join fraud proof topic: %w", err)
+	}
+	sub, err := topic.Subscribe()
+	if err != nil {
+		_ = topic.Close()
+		return fmt.Errorf("failed to subscribe to fraud proof topic: %w", err)
+	}
+	s.topic, s.sub = topic, sub
+
+	go s.run(ctx)
+	return nil
+}
+
+// Stop leaves the fraud proof topic.
+func (s *Service) Stop() error {
+	if s.sub == nil {
+		return nil
+	}
+	s.sub.Cancel()
+	return errors.Join(s.ps.UnregisterTopicValidator(s.topicID), s.topic.Close())
+}
+
+// Broadcast gossips a fraud proof to peers.
+func (s *Service) Broadcast(ctx context.Context, proof *types.FraudProof) error {
+	bz, err := proof.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode fraud proof: %w", err)
+	}
+	return s.topic.Publish(ctx, bz)
+}
+
+func (s *Service) run(ctx context.Context) {
+	for {
+		msg, err := s.sub.Next(ctx)
+		if err != nil {
+			return
+		}
+		if msg.ReceivedFrom == s.self {
+			continue
+		}
+		proof := msg.ValidatorData.(*types.FraudProof)
+		s.logger.Info("received fraud proof", "peer", msg.ReceivedFrom, "height", proof.DisputedHeight())
+		if err := s.verifier.HandleFraudProof(ctx, proof); err != nil {
+			s.logger.Error("failed to handle fraud proof", "error", err)
+		}
+	}
+}
+
+// validate accepts the fraud proofs verified by the node. Fraud proofs which cannot be verified yet are
+// ignored, i.e. neither relayed nor penalized.
+func (s *Service) validate(ctx context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	var proof types.FraudProof
+	if err := proof.UnmarshalBinary(msg.Data); err != nil {
+		s.logger.Debug("failed to decode fraud proof", "peer", from, "error", err)
+		return pubsub.ValidationReject
+	}
+	err := s.verifier.VerifyFraudProof(ctx, &proof)
+	switch {
+	case err == nil:
+		msg.ValidatorData = &proof
+		return pubsub.ValidationAccept
+	case errors.Is(err, types.ErrUnverifiableFraudProof):
+		s.logger.Debug("ignoring fraud proof", "peer", from, "error", err)
+		return pubsub.ValidationIgnore
+	default:
+		s.logger.Info("rejecting invalid fraud proof", "peer", from, "error", err)
+		return pubsub.ValidationReject
+	}
+}
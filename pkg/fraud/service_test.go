@@ -0,0 +1,80 @@
+package fraud
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+const testChainID = "fraud-test"
+
+// testVerifier accepts fraud proofs, except those with an unverifiable expected app hash.
+type testVerifier struct {
+	unverifiable []byte
+	handled      chan *types.FraudProof
+}
+
+func (v *testVerifier) VerifyFraudProof(_ context.Context, proof *types.FraudProof) error {
+	if bytes.Equal(proof.ExpectedAppHash, v.unverifiable) {
+		return types.ErrUnverifiableFraudProof
+	}
+	return nil
+}
+
+func (v *testVerifier) HandleFraudProof(_ context.Context, proof *types.FraudProof) error {
+	v.handled <- proof
+	return nil
+}
+
+func TestService(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	defer mnet.Close() //nolint:errcheck
+	hosts := mnet.Hosts()
+
+	services := make([]*Service, 2)
+	verifiers := []*testVerifier{
+		{handled: make(chan *types.FraudProof, 2)},
+		{unverifiable: []byte("unverifiable"), handled: make(chan *types.FraudProof, 2)},
+	}
+	for i, h := range hosts {
+		// flood publishing delivers fraud proofs before the gossipsub mesh is formed
+		ps, err := pubsub.NewGossipSub(ctx, h, pubsub.WithFloodPublish(true))
+		require.NoError(t, err)
+		services[i] = NewService(ps, h.ID(), testChainID, verifiers[i], log.NewNopLogger())
+		require.NoError(t, services[i].Start(ctx))
+		defer services[i].Stop() //nolint:errcheck
+	}
+	require.Eventually(t, func() bool {
+		return len(services[0].topic.ListPeers()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	header, _, _ := types.GenerateRandomBlockCustomWithAppHash(&types.BlockConfig{Height: 11, NTxs: 1}, testChainID, []byte("invalid_app_hash"))
+	unverifiable := &types.FraudProof{Header: header, ExpectedAppHash: []byte("unverifiable")}
+	valid := &types.FraudProof{Header: header, ExpectedAppHash: []byte("app_hash")}
+	require.NoError(t, services[0].Broadcast(ctx, unverifiable))
+	require.NoError(t, services[0].Broadcast(ctx, valid))
+
+	// fraud proofs which cannot be verified are not handled, and the sender does not handle its own proofs
+	select {
+	case proof := <-verifiers[1].handled:
+		assert.Equal(t, valid.ExpectedAppHash, proof.ExpectedAppHash)
+		assert.Equal(t, header.Hash(), proof.Header.Hash())
+	case <-ctx.Done():
+		require.Fail(t, "fraud proof was not received")
+	}
+	assert.Empty(t, verifiers[0].handled)
+	assert.Empty(t, verifiers[1].handled)
+}
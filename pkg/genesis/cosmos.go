@@ -0,0 +1,176 @@
+package genesis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rollkit/rollkit/pkg/hash"
+	"github.com/rollkit/rollkit/pkg/signer"
+)
+
+// AppGenesisFileName is the name of the file in the config directory holding the genesis of an imported chain,
+// from which the executor loads the application state in InitChain.
+const AppGenesisFileName = "app_genesis.json"
+
+// CosmosGenesis is the genesis exported by a Cosmos SDK chain, e.g. with `<appd> export`. Both the CometBFT
+// genesis layout and the Cosmos SDK v0.50 layout, with the validators in the consensus section, are supported.
+type CosmosGenesis struct {
+	ChainID       string            `json:"chain_id"`
+	GenesisTime   time.Time         `json:"genesis_time"`
+	InitialHeight cosmosHeight      `json:"initial_height"`
+	AppHash       hexBytes          `json:"app_hash"`
+	AppState      json.RawMessage   `json:"app_state"`
+	Validators    []CosmosValidator `json:"validators"`
+	Consensus     *struct {
+		Validators []CosmosValidator `json:"validators"`
+	} `json:"consensus"`
+
+	// raw holds the exported genesis as read
+	raw []byte
+}
+
+// CosmosValidator is a validator of an exported Cosmos SDK genesis.
+type CosmosValidator struct {
+	Name   string `json:"name"`
+	PubKey struct {
+		Type  string `json:"type"`
+		Value []byte `json:"value"`
+	} `json:"pub_key"`
+}
+
+// cosmosPubKeySchemes maps the amino names of the CometBFT consensus key types to signature schemes.
+var cosmosPubKeySchemes = map[string]string{
+	"tendermint/PubKeyEd25519":   signer.SchemeEd25519,
+	"tendermint/PubKeySecp256k1": signer.SchemeSecp256k1,
+	"cometbft/PubKeyBls12_381":   signer.SchemeBLS,
+}
+
+// LoadCosmosGenesis loads the genesis exported by a Cosmos SDK chain from the specified file path.
+func LoadCosmosGenesis(path string) (CosmosGenesis, error) {
+	bz, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return CosmosGenesis{}, fmt.Errorf("failed to read exported genesis: %w", err)
+	}
+	var doc CosmosGenesis
+	if err := json.Unmarshal(bz, &doc); err != nil {
+		return CosmosGenesis{}, fmt.Errorf("invalid exported genesis: %w", err)
+	}
+	if doc.ChainID == "" {
+		return CosmosGenesis{}, errors.New("missing chain_id in exported genesis")
+	}
+	doc.raw = bz
+	return doc, nil
+}
+
+// ConsensusValidators returns the validators of the exported chain.
+func (c CosmosGenesis) ConsensusValidators() []CosmosValidator {
+	if c.Consensus != nil && len(c.Consensus.Validators) > 0 {
+		return c.Consensus.Validators
+	}
+	return c.Validators
+}
+
+// ToGenesis converts the exported genesis into a rollkit genesis continuing the chain from its initial height,
+// i.e. the height following the last block of the exported chain. The executor must return the app hash of the
+// exported chain, if set, from InitChain, and the app state hash of the genesis commits to the exported genesis. The sequencer is given by its proposer address and signature scheme;
+// if no address is given, the single validator of the exported chain becomes the sequencer.
+func (c CosmosGenesis) ToGenesis(proposerAddress []byte, signatureScheme string, daStartTime time.Time) (Genesis, error) {
+	if proposerAddress == nil {
+		validators := c.ConsensusValidators()
+		if len(validators) != 1 {
+			return Genesis{}, fmt.Errorf("exported genesis has %d validators, a proposer address is required", len(validators))
+		}
+		scheme, ok := cosmosPubKeySchemes[validators[0].PubKey.Type]
+		if !ok {
+			return Genesis{}, fmt.Errorf("%w: validator key type %q", signer.ErrUnknownScheme, validators[0].PubKey.Type)
+		}
+		proposerAddress = hash.SumTruncated(validators[0].PubKey.Value)
+		signatureScheme = scheme
+	}
+
+	initialHeight := uint64(c.InitialHeight)
+	if initialHeight == 0 {
+		initialHeight = 1
+	}
+	genesis := NewGenesis(c.ChainID, initialHeight, daStartTime, proposerAddress)
+	genesis.SignatureScheme = signatureScheme
+	genesis.AppHash = c.AppHash
+	if c.raw != nil {
+		sum := sha256.Sum256(c.raw)
+		genesis.AppStateHash = sum[:]
+	}
+	if err := genesis.Validate(); err != nil {
+		return Genesis{}, err
+	}
+	return genesis, nil
+}
+
+// ImportCosmosGenesis saves the rollkit genesis of the exported chain and the exported genesis, from which the
+// executor loads the application state, in the config directory of the specified home path. If the genesis file
+// already exists, it returns ErrGenesisExists.
+func ImportCosmosGenesis(homePath string, doc CosmosGenesis, genesis Genesis) error {
+	configDir := filepath.Join(homePath, "config")
+	genesisPath := filepath.Join(configDir, "genesis.json")
+	if _, err := os.Stat(genesisPath); err == nil {
+		return ErrGenesisExists
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to check for existing genesis file at %s: %w", genesisPath, err)
+	}
+	if err := os.MkdirAll(configDir, 0o750); err != nil {
+		return fmt.Errorf("error creating config directory: %w", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(configDir, AppGenesisFileName), doc.raw, 0o600); err != nil {
+		return fmt.Errorf("failed to write app genesis file: %w", err)
+	}
+	if err := genesis.Save(genesisPath); err != nil {
+		return fmt.Errorf("error writing genesis file: %w", err)
+	}
+	return nil
+}
+
+// cosmosHeight is a height encoded as a JSON string, as CometBFT does, or as a JSON number.
+type cosmosHeight uint64
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (h *cosmosHeight) UnmarshalJSON(bz []byte) error {
+	s := strings.Trim(string(bz), `"`)
+	if s == "" || s == "null" {
+		*h = 0
+		return nil
+	}
+	height, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid initial_height: %w", err)
+	}
+	*h = cosmosHeight(height)
+	return nil
+}
+
+// hexBytes is a byte slice encoded as a hex string, as CometBFT does.
+type hexBytes []byte
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *hexBytes) UnmarshalJSON(bz []byte) error {
+	var s string
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return err
+	}
+	decoded, err := hex.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("invalid hex string: %w", err)
+	}
+	if len(decoded) == 0 {
+		decoded = nil
+	}
+	*b = decoded
+	return nil
+}
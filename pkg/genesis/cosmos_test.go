@@ -0,0 +1,106 @@
+package genesis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/hash"
+	"github.com/rollkit/rollkit/pkg/signer"
+)
+
+const exportedGenesis = `{
+  "app_name": "simd",
+  "app_version": "v0.50.0",
+  "genesis_time": "2024-01-01T00:00:00Z",
+  "chain_id": "sovereign-1",
+  "initial_height": "1234",
+  "app_hash": "ABCDEF",
+  "app_state": {"bank": {"balances": []}},
+  "consensus": {
+    "validators": [
+      {
+        "address": "0000000000000000000000000000000000000000",
+        "name": "validator",
+        "power": "100",
+        "pub_key": {"type": "tendermint/PubKeyEd25519", "value": "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="}
+      }
+    ]
+  }
+}`
+
+func writeExportedGenesis(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "exported.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestCosmosGenesisToGenesis(t *testing.T) {
+	doc, err := LoadCosmosGenesis(writeExportedGenesis(t, exportedGenesis))
+	require.NoError(t, err)
+	daStartTime := time.Now()
+
+	genesis, err := doc.ToGenesis(nil, "", daStartTime)
+	require.NoError(t, err)
+	assert.Equal(t, "sovereign-1", genesis.ChainID)
+	assert.Equal(t, uint64(1234), genesis.InitialHeight)
+	assert.Equal(t, daStartTime, genesis.GenesisDAStartTime)
+	assert.Equal(t, []byte{0xab, 0xcd, 0xef}, genesis.AppHash)
+	assert.Equal(t, hash.SumTruncated(make([]byte, 32)), genesis.ProposerAddress)
+	assert.Equal(t, signer.SchemeEd25519, genesis.Scheme())
+
+	genesis, err = doc.ToGenesis([]byte("sequencer"), signer.SchemeBLS, daStartTime)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("sequencer"), genesis.ProposerAddress)
+	assert.Equal(t, signer.SchemeBLS, genesis.Scheme())
+}
+
+func TestCosmosGenesisLegacyLayout(t *testing.T) {
+	doc, err := LoadCosmosGenesis(writeExportedGenesis(t, `{
+  "genesis_time": "2024-01-01T00:00:00Z",
+  "chain_id": "sovereign-1",
+  "initial_height": 1,
+  "app_hash": "",
+  "validators": [],
+  "app_state": {}
+}`))
+	require.NoError(t, err)
+
+	_, err = doc.ToGenesis(nil, "", time.Now())
+	assert.ErrorContains(t, err, "a proposer address is required")
+
+	genesis, err := doc.ToGenesis([]byte("sequencer"), "", time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), genesis.InitialHeight)
+	assert.Nil(t, genesis.AppHash)
+}
+
+func TestImportCosmosGenesis(t *testing.T) {
+	doc, err := LoadCosmosGenesis(writeExportedGenesis(t, exportedGenesis))
+	require.NoError(t, err)
+	genesis, err := doc.ToGenesis(nil, "", time.Now())
+	require.NoError(t, err)
+
+	home := t.TempDir()
+	require.NoError(t, ImportCosmosGenesis(home, doc, genesis))
+	loaded, err := LoadGenesis(filepath.Join(home, "config", "genesis.json"))
+	require.NoError(t, err)
+	assert.Equal(t, genesis.InitialHeight, loaded.InitialHeight)
+	assert.Equal(t, genesis.AppHash, loaded.AppHash)
+	appGenesis, err := os.ReadFile(filepath.Join(home, "config", AppGenesisFileName))
+	require.NoError(t, err)
+	assert.Equal(t, exportedGenesis, string(appGenesis))
+	assert.Equal(t, genesis.Fingerprint(), loaded.Fingerprint())
+
+	assert.ErrorIs(t, ImportCosmosGenesis(home, doc, genesis), ErrGenesisExists)
+
+	// a node started with a different application state than the one of the genesis refuses to load it
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config", AppGenesisFileName), []byte(`{"chain_id":"other"}`), 0o600))
+	_, err = LoadGenesis(filepath.Join(home, "config", "genesis.json"))
+	assert.ErrorContains(t, err, "app_state_hash")
+}
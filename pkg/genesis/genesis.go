@@ -1,8 +1,13 @@
 package genesis
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
 	"fmt"
 	"time"
+
+	"github.com/rollkit/rollkit/pkg/signer"
 )
 
 // Genesis represents the genesis state of the blockchain.
@@ -13,8 +18,22 @@ type Genesis struct {
 	GenesisDAStartTime time.Time `json:"genesis_da_start_height"` // TODO: change to uint64 and remove time.Time, basically we need a mechanism to convert DAHeight to time.Time
 	InitialHeight      uint64    `json:"initial_height"`
 	ProposerAddress    []byte    `json:"proposer_address"`
+	// SignatureScheme is the signature scheme of the sequencer keys, ed25519 if empty
+	SignatureScheme string `json:"signature_scheme,omitempty"`
+	// AppHash is the state root the executor must return from InitChain, set when importing the state of an
+	// existing chain
+	AppHash []byte `json:"app_hash,omitempty"`
+	// ProposerPubKey is the public key of the sequencer, encoded with signer.MarshalPublicKey. It is optional;
+	// when set, it must match ProposerAddress and SignatureScheme.
+	ProposerPubKey []byte `json:"proposer_pub_key,omitempty"`
+	// AppStateHash is the SHA-256 hash of the app genesis file holding the application state of an imported
+	// chain, checked when the genesis is loaded
+	AppStateHash []byte `json:"app_state_hash,omitempty"`
 }
 
+// maxChainIDLen is the maximum length of a chain ID, as in CometBFT.
+const maxChainIDLen = 50
+
 // NewGenesis creates a new Genesis instance.
 func NewGenesis(
 	chainID string,
@@ -37,6 +56,9 @@ func (g Genesis) Validate() error {
 	if g.ChainID == "" {
 		return fmt.Errorf("invalid or missing chain_id in genesis file")
 	}
+	if len(g.ChainID) > maxChainIDLen {
+		return fmt.Errorf("chain_id must be at most %d characters, got %d", maxChainIDLen, len(g.ChainID))
+	}
 
 	// Check initial height
 	if g.InitialHeight < 1 {
@@ -52,5 +74,71 @@ func (g Genesis) Validate() error {
 		return fmt.Errorf("proposer_address cannot be nil")
 	}
 
+	if err := signer.ValidateScheme(g.Scheme()); err != nil {
+		return fmt.Errorf("invalid signature_scheme in genesis file: %w", err)
+	}
+
+	if g.ProposerPubKey != nil {
+		if err := g.validateProposerPubKey(); err != nil {
+			return err
+		}
+	}
+
+	if g.AppStateHash != nil && len(g.AppStateHash) != sha256.Size {
+		return fmt.Errorf("app_state_hash must be %d bytes, got %d", sha256.Size, len(g.AppStateHash))
+	}
+
 	return nil
 }
+
+// validateProposerPubKey checks that the public key of the sequencer is of the signature scheme of the genesis,
+// and that the proposer address is its address.
+func (g Genesis) validateProposerPubKey() error {
+	pubKey, err := signer.UnmarshalPublicKey(g.ProposerPubKey)
+	if err != nil {
+		return fmt.Errorf("invalid proposer_pub_key in genesis file: %w", err)
+	}
+	scheme, err := signer.SchemeOf(pubKey)
+	if err != nil {
+		return fmt.Errorf("invalid proposer_pub_key in genesis file: %w", err)
+	}
+	if scheme != g.Scheme() {
+		return fmt.Errorf("proposer_pub_key is a %s key, but the signature scheme is %s", scheme, g.Scheme())
+	}
+	raw, err := pubKey.Raw()
+	if err != nil {
+		return fmt.Errorf("invalid proposer_pub_key in genesis file: %w", err)
+	}
+	if address := sha256.Sum256(raw); !bytes.Equal(address[:], g.ProposerAddress) {
+		return fmt.Errorf("proposer_address %X is not the address of proposer_pub_key %X", g.ProposerAddress, address)
+	}
+	return nil
+}
+
+// Fingerprint returns a hash identifying the genesis, exchanged with peers to only connect nodes of the same
+// chain: chains sharing a chain ID but started from a different genesis have different fingerprints.
+func (g Genesis) Fingerprint() []byte {
+	var bz []byte
+	appendBytes := func(b []byte) {
+		bz = binary.AppendUvarint(bz, uint64(len(b)))
+		bz = append(bz, b...)
+	}
+	appendBytes([]byte(g.ChainID))
+	bz = binary.BigEndian.AppendUint64(bz, g.InitialHeight)
+	bz = binary.BigEndian.AppendUint64(bz, uint64(g.GenesisDAStartTime.UnixNano()))
+	appendBytes(g.ProposerAddress)
+	appendBytes([]byte(g.Scheme()))
+	appendBytes(g.ProposerPubKey)
+	appendBytes(g.AppHash)
+	appendBytes(g.AppStateHash)
+	sum := sha256.Sum256(bz)
+	return sum[:]
+}
+
+// Scheme returns the signature scheme of the sequencer keys.
+func (g Genesis) Scheme() string {
+	if g.SignatureScheme == "" {
+		return signer.SchemeEd25519
+	}
+	return g.SignatureScheme
+}
@@ -1,10 +1,16 @@
 package genesis
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/signer"
 )
 
 func TestNewGenesis(t *testing.T) {
@@ -62,8 +68,22 @@ func TestNewGenesis(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// testProposerKey returns the encoded public key of a new sequencer key of the scheme, and its address.
+func testProposerKey(t *testing.T, scheme string) ([]byte, []byte) {
+	t.Helper()
+	_, pubKey, err := signer.GenerateKeyPair(scheme, rand.Reader)
+	require.NoError(t, err)
+	bz, err := signer.MarshalPublicKey(pubKey)
+	require.NoError(t, err)
+	raw, err := pubKey.Raw()
+	require.NoError(t, err)
+	address := sha256.Sum256(raw)
+	return bz, address[:]
+}
+
 func TestGenesis_Validate(t *testing.T) {
 	validTime := time.Now()
+	pubKey, address := testProposerKey(t, signer.SchemeEd25519)
 	tests := []struct {
 		name    string
 		genesis Genesis
@@ -119,6 +139,94 @@ func TestGenesis_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid - bls signature scheme",
+			genesis: Genesis{
+				ChainID:            "test-chain",
+				GenesisDAStartTime: validTime,
+				InitialHeight:      1,
+				ProposerAddress:    []byte("proposer"),
+				SignatureScheme:    "bls",
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid - unknown signature scheme",
+			genesis: Genesis{
+				ChainID:            "test-chain",
+				GenesisDAStartTime: validTime,
+				InitialHeight:      1,
+				ProposerAddress:    []byte("proposer"),
+				SignatureScheme:    "rsa",
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid - chain_id too long",
+			genesis: Genesis{
+				ChainID:            strings.Repeat("c", maxChainIDLen+1),
+				GenesisDAStartTime: validTime,
+				InitialHeight:      1,
+				ProposerAddress:    []byte("proposer"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid - proposer public key matching the proposer address",
+			genesis: Genesis{
+				ChainID:            "test-chain",
+				GenesisDAStartTime: validTime,
+				InitialHeight:      1,
+				ProposerAddress:    address,
+				ProposerPubKey:     pubKey,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid - proposer public key not matching the proposer address",
+			genesis: Genesis{
+				ChainID:            "test-chain",
+				GenesisDAStartTime: validTime,
+				InitialHeight:      1,
+				ProposerAddress:    []byte("proposer"),
+				ProposerPubKey:     pubKey,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid - proposer public key of another signature scheme",
+			genesis: Genesis{
+				ChainID:            "test-chain",
+				GenesisDAStartTime: validTime,
+				InitialHeight:      1,
+				ProposerAddress:    address,
+				ProposerPubKey:     pubKey,
+				SignatureScheme:    signer.SchemeSecp256k1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid - malformed proposer public key",
+			genesis: Genesis{
+				ChainID:            "test-chain",
+				GenesisDAStartTime: validTime,
+				InitialHeight:      1,
+				ProposerAddress:    address,
+				ProposerPubKey:     []byte("pubkey"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid - app state hash of the wrong size",
+			genesis: Genesis{
+				ChainID:            "test-chain",
+				GenesisDAStartTime: validTime,
+				InitialHeight:      1,
+				ProposerAddress:    []byte("proposer"),
+				AppStateHash:       []byte("hash"),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -130,3 +238,33 @@ func TestGenesis_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestGenesis_Fingerprint(t *testing.T) {
+	genesis := NewGenesis("test-chain", 1, time.Now(), []byte("proposer"))
+	assert.Len(t, genesis.Fingerprint(), sha256.Size)
+	assert.Equal(t, genesis.Fingerprint(), genesis.Fingerprint())
+
+	// genesis documents sharing the chain ID but differing in any other field have different fingerprints
+	variants := map[string]func(g *Genesis){
+		"chain ID":        func(g *Genesis) { g.ChainID = "test-chain-2" },
+		"initial height":  func(g *Genesis) { g.InitialHeight = 2 },
+		"DA start time":   func(g *Genesis) { g.GenesisDAStartTime = g.GenesisDAStartTime.Add(time.Second) },
+		"proposer":        func(g *Genesis) { g.ProposerAddress = []byte("other") },
+		"scheme":          func(g *Genesis) { g.SignatureScheme = signer.SchemeBLS },
+		"app hash":        func(g *Genesis) { g.AppHash = []byte{1} },
+		"app state hash":  func(g *Genesis) { g.AppStateHash = make([]byte, sha256.Size) },
+		"proposer pubkey": func(g *Genesis) { g.ProposerPubKey = []byte{1} },
+	}
+	for name, modify := range variants {
+		t.Run(name, func(t *testing.T) {
+			other := genesis
+			modify(&other)
+			assert.NotEqual(t, genesis.Fingerprint(), other.Fingerprint())
+		})
+	}
+
+	// the default signature scheme is the same as an explicit ed25519 scheme
+	explicit := genesis
+	explicit.SignatureScheme = signer.SchemeEd25519
+	assert.Equal(t, genesis.Fingerprint(), explicit.Fingerprint())
+}
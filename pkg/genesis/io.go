@@ -1,6 +1,8 @@
 package genesis
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -14,7 +16,7 @@ var ErrGenesisExists = fmt.Errorf("genesis file already exists")
 // If the genesis file already exists, it skips the creation and returns ErrGenesisExists.
 // The genesis file is saved in the config directory of the specified home path.
 // It should only be used when the application is NOT handling the genesis creation.
-func CreateGenesis(homePath string, chainID string, initialHeight uint64, proposerAddress []byte) error {
+func CreateGenesis(homePath string, chainID string, initialHeight uint64, proposerAddress []byte, signatureScheme string) error {
 	configDir := filepath.Join(homePath, "config")
 	genesisPath := filepath.Join(configDir, "genesis.json")
 
@@ -36,6 +38,7 @@ func CreateGenesis(homePath string, chainID string, initialHeight uint64, propos
 		time.Now(),      // Current time as genesis DA start height
 		proposerAddress, // Proposer address
 	)
+	genesisData.SignatureScheme = signatureScheme
 
 	if err := genesisData.Save(genesisPath); err != nil {
 		return fmt.Errorf("error writing genesis file: %w", err)
@@ -66,9 +69,31 @@ func LoadGenesis(genesisPath string) (Genesis, error) {
 		return Genesis{}, err
 	}
 
+	if err := genesis.verifyAppState(filepath.Join(filepath.Dir(cleanPath), AppGenesisFileName)); err != nil {
+		return Genesis{}, err
+	}
+
 	return genesis, nil
 }
 
+// verifyAppState checks that the app genesis file at the given path, if present, matches the app state hash of
+// the genesis. Nodes without the app genesis file, e.g. light nodes, do not load the application state.
+func (g Genesis) verifyAppState(appGenesisPath string) error {
+	if g.AppStateHash == nil {
+		return nil
+	}
+	bz, err := os.ReadFile(appGenesisPath) //nolint:gosec // the path is derived from the genesis path
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read app genesis file: %w", err)
+	}
+	if sum := sha256.Sum256(bz); !bytes.Equal(sum[:], g.AppStateHash) {
+		return fmt.Errorf("app genesis file %s does not match the app_state_hash of the genesis: got %X, expected %X", appGenesisPath, sum, g.AppStateHash)
+	}
+	return nil
+}
+
 // Save saves the genesis state to the specified file path.
 func (g Genesis) Save(genesisPath string) error {
 	genesisJSON, err := json.MarshalIndent(g, "", "  ")
@@ -0,0 +1,244 @@
+// Package keystore encrypts the private keys of a node at rest. Keys are encrypted with AES-GCM under a key derived
+// from a passphrase with argon2id or scrypt, and stored as versioned JSON documents, so that the key derivation
+// parameters can be raised without breaking existing key files.
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/rollkit/rollkit/pkg/signer"
+)
+
+const (
+	// Version is the version of the encrypted key format written by Encrypt.
+	Version = 1
+
+	// KDFArgon2id derives the encryption key with argon2id, the default.
+	KDFArgon2id = "argon2id"
+	// KDFScrypt derives the encryption key with scrypt.
+	KDFScrypt = "scrypt"
+
+	// EnvNodeKeyPassphrase is the environment variable providing the passphrase of the node key if none is set
+	// with a flag.
+	//nolint:gosec
+	EnvNodeKeyPassphrase = "ROLLKIT_NODE_KEY_PASSPHRASE"
+	// EnvSignerPassphrase is the environment variable providing the passphrase of the file signer if none is set
+	// with a flag.
+	//nolint:gosec
+	EnvSignerPassphrase = "ROLLKIT_SIGNER_PASSPHRASE"
+
+	keyLen  = 32
+	saltLen = 16
+)
+
+// ErrWrongPassphrase is returned when a key cannot be decrypted with the given passphrase.
+var ErrWrongPassphrase = errors.New("failed to decrypt key (wrong passphrase?)")
+
+// KDFParams are the parameters of the key derivation function of an encrypted key.
+type KDFParams struct {
+	// Time, Memory (in KiB) and Threads are the parameters of argon2id
+	Time    uint32 `json:"time,omitempty"`
+	Memory  uint32 `json:"memory,omitempty"`
+	Threads uint8  `json:"threads,omitempty"`
+	// N, R and P are the parameters of scrypt
+	N int `json:"n,omitempty"`
+	R int `json:"r,omitempty"`
+	P int `json:"p,omitempty"`
+}
+
+// defaultKDFParams returns the parameters used to encrypt new keys with the key derivation function.
+func defaultKDFParams(kdf string) (KDFParams, error) {
+	switch kdf {
+	case KDFArgon2id:
+		return KDFParams{Time: 3, Memory: 32 * 1024, Threads: 4}, nil
+	case KDFScrypt:
+		return KDFParams{N: 1 << 15, R: 8, P: 1}, nil
+	default:
+		return KDFParams{}, fmt.Errorf("unknown key derivation function %q", kdf)
+	}
+}
+
+// EncryptedKey is a private key encrypted with a passphrase.
+type EncryptedKey struct {
+	Version int `json:"version"`
+	// KeyType is the signature scheme of the key
+	KeyType string `json:"key_type"`
+	// PubKey is the raw public key, authenticated as additional data of the ciphertext
+	PubKey     []byte    `json:"pub_key"`
+	KDF        string    `json:"kdf"`
+	KDFParams  KDFParams `json:"kdf_params"`
+	Salt       []byte    `json:"salt"`
+	Nonce      []byte    `json:"nonce"`
+	Ciphertext []byte    `json:"ciphertext"`
+}
+
+// Encrypt encrypts the private key with a key derived from the passphrase with the key derivation function,
+// KDFArgon2id if empty.
+func Encrypt(privKey crypto.PrivKey, passphrase []byte, kdf string) (*EncryptedKey, error) {
+	if kdf == "" {
+		kdf = KDFArgon2id
+	}
+	params, err := defaultKDFParams(kdf)
+	if err != nil {
+		return nil, err
+	}
+	scheme, err := signer.SchemeOf(privKey)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := privKey.GetPublic().Raw()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw public key: %w", err)
+	}
+	privBytes, err := privKey.Raw()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raw private key: %w", err)
+	}
+	defer zeroBytes(privBytes)
+
+	key := &EncryptedKey{
+		Version:   Version,
+		KeyType:   scheme,
+		PubKey:    pubKey,
+		KDF:       kdf,
+		KDFParams: params,
+		Salt:      make([]byte, saltLen),
+	}
+	if _, err := rand.Read(key.Salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	gcm, err := key.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	key.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, key.Nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	key.Ciphertext = gcm.Seal(nil, key.Nonce, privBytes, key.PubKey)
+	return key, nil
+}
+
+// Decrypt decrypts the private key with the passphrase.
+func (k *EncryptedKey) Decrypt(passphrase []byte) (crypto.PrivKey, error) {
+	if k.Version != Version {
+		return nil, fmt.Errorf("unsupported key version %d", k.Version)
+	}
+	gcm, err := k.cipher(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if len(k.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid nonce length: %d", len(k.Nonce))
+	}
+	privBytes, err := gcm.Open(nil, k.Nonce, k.Ciphertext, k.PubKey)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	privKey, err := signer.UnmarshalRawPrivateKey(k.KeyType, privBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal private key: %w", err)
+	}
+	return privKey, nil
+}
+
+// cipher returns the AES-GCM cipher keyed with the key derived from the passphrase.
+func (k *EncryptedKey) cipher(passphrase []byte) (cipher.AEAD, error) {
+	var derived []byte
+	switch k.KDF {
+	case KDFArgon2id:
+		p := k.KDFParams
+		if p.Time == 0 || p.Memory == 0 || p.Threads == 0 {
+			return nil, errors.New("invalid argon2id parameters")
+		}
+		derived = argon2.IDKey(passphrase, k.Salt, p.Time, p.Memory, p.Threads, keyLen)
+	case KDFScrypt:
+		var err error
+		derived, err = scrypt.Key(passphrase, k.Salt, k.KDFParams.N, k.KDFParams.R, k.KDFParams.P, keyLen)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scrypt parameters: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown key derivation function %q", k.KDF)
+	}
+	defer zeroBytes(derived)
+	block, err := aes.NewCipher(derived)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// IsEncrypted reports whether the key file content is an encrypted key, as opposed to the legacy key formats.
+func IsEncrypted(bz []byte) bool {
+	var key struct {
+		Version    int    `json:"version"`
+		Ciphertext []byte `json:"ciphertext"`
+	}
+	return json.Unmarshal(bz, &key) == nil && key.Version > 0 && len(key.Ciphertext) > 0
+}
+
+// ReadFile reads the encrypted key stored at path.
+func ReadFile(path string) (*EncryptedKey, error) {
+	bz, err := os.ReadFile(path) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file %s: %w", path, err)
+	}
+	if !IsEncrypted(bz) {
+		return nil, fmt.Errorf("key file %s is not an encrypted key", path)
+	}
+	key := new(EncryptedKey)
+	if err := json.Unmarshal(bz, key); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal key file %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// WriteFile stores the encrypted key at path, readable by the owner only. The file is replaced atomically, so
+// that a failed write never loses the previous key.
+func WriteFile(path string, key *EncryptedKey) error {
+	bz, err := json.MarshalIndent(key, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create key directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, bz, 0o600); err != nil {
+		return fmt.Errorf("failed to write key file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write key file: %w", err)
+	}
+	return nil
+}
+
+// Passphrase returns the passphrase set with a flag, or the value of the environment variable if the flag is
+// empty.
+func Passphrase(flag, env string) []byte {
+	if flag != "" {
+		return []byte(flag)
+	}
+	return []byte(os.Getenv(env))
+}
+
+// zeroBytes overwrites a byte slice with zeros
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,93 @@
+package keystore
+
+import (
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/signer"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	for _, scheme := range []string{signer.SchemeEd25519, signer.SchemeSecp256k1, signer.SchemeBLS} {
+		for _, kdf := range []string{KDFArgon2id, KDFScrypt} {
+			t.Run(scheme+"/"+kdf, func(t *testing.T) {
+				privKey, _, err := signer.GenerateKeyPair(scheme, rand.Reader)
+				require.NoError(t, err)
+
+				key, err := Encrypt(privKey, []byte("passphrase"), kdf)
+				require.NoError(t, err)
+				assert.Equal(t, Version, key.Version)
+				assert.Equal(t, scheme, key.KeyType)
+				assert.Equal(t, kdf, key.KDF)
+
+				decrypted, err := key.Decrypt([]byte("passphrase"))
+				require.NoError(t, err)
+				assert.True(t, decrypted.Equals(privKey))
+
+				_, err = key.Decrypt([]byte("wrong"))
+				assert.ErrorIs(t, err, ErrWrongPassphrase)
+			})
+		}
+	}
+}
+
+func TestDecryptTampered(t *testing.T) {
+	privKey, _, err := signer.GenerateKeyPair(signer.SchemeEd25519, rand.Reader)
+	require.NoError(t, err)
+	key, err := Encrypt(privKey, []byte("passphrase"), "")
+	require.NoError(t, err)
+	assert.Equal(t, KDFArgon2id, key.KDF)
+
+	// the public key is authenticated with the ciphertext
+	otherKey, _, err := signer.GenerateKeyPair(signer.SchemeEd25519, rand.Reader)
+	require.NoError(t, err)
+	key.PubKey, err = otherKey.GetPublic().Raw()
+	require.NoError(t, err)
+	_, err = key.Decrypt([]byte("passphrase"))
+	assert.ErrorIs(t, err, ErrWrongPassphrase)
+
+	key.Version = Version + 1
+	_, err = key.Decrypt([]byte("passphrase"))
+	assert.ErrorContains(t, err, "unsupported key version")
+
+	_, err = Encrypt(privKey, []byte("passphrase"), "pbkdf2")
+	assert.ErrorContains(t, err, "unknown key derivation function")
+}
+
+func TestReadWriteFile(t *testing.T) {
+	privKey, _, err := signer.GenerateKeyPair(signer.SchemeEd25519, rand.Reader)
+	require.NoError(t, err)
+	key, err := Encrypt(privKey, []byte("passphrase"), KDFScrypt)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "keys", "key.json")
+	require.NoError(t, WriteFile(path, key))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(bz))
+	read, err := ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, key, read)
+
+	// legacy plain text keys are not encrypted keys
+	legacy := filepath.Join(t.TempDir(), "node_key.json")
+	require.NoError(t, os.WriteFile(legacy, []byte(`{"priv_key":"AAAA","pub_key":"AAAA"}`), 0o600))
+	_, err = ReadFile(legacy)
+	assert.Error(t, err)
+}
+
+func TestPassphrase(t *testing.T) {
+	t.Setenv(EnvNodeKeyPassphrase, "from-env")
+	assert.Equal(t, []byte("from-flag"), Passphrase("from-flag", EnvNodeKeyPassphrase))
+	assert.Equal(t, []byte("from-env"), Passphrase("", EnvNodeKeyPassphrase))
+	assert.Empty(t, Passphrase("", EnvSignerPassphrase))
+}
@@ -0,0 +1,358 @@
+package leader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rollkit/rollkit/block"
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// maxHeightsPerSync bounds the number of DA heights processed in a single sync round.
+const maxHeightsPerSync = 100
+
+// Lease is the leadership lease held by an aggregator.
+type Lease struct {
+	// Holder is the node ID of the aggregator holding the lease, empty if the lease was never acquired.
+	Holder string
+	// Term is incremented every time the lease changes hands.
+	Term uint64
+	// Expiry is the DA height after which the lease expires.
+	Expiry uint64
+}
+
+// Elector elects a single active aggregator among aggregators sharing the sequencer key.
+//
+// Aggregators post signed lease claims to their own namespace of the DA layer. As the DA layer totally orders the claims,
+// every node replays them deterministically and agrees on the lease holder: a claim acquires the
+// lease if it is vacant or expired, and the holder renews it by posting a claim with the same term.
+// Leases are measured in DA blocks. The leader stops producing blocks before its lease expires and a
+// standby only acquires the lease after it expired, so two aggregators never produce blocks at the same time.
+type Elector struct {
+	da          coreda.DA
+	signer      signer.Signer
+	proposer    []byte
+	nodeID      string
+	leaseBlocks uint64
+	interval    time.Duration
+	logger      log.Logger
+
+	mu           sync.RWMutex
+	lease        Lease
+	daHeight     uint64
+	lastProgress time.Time
+	caughtUp     bool
+	lastClaim    uint64
+	isLeader     bool
+
+	leaderCh chan bool
+}
+
+// NewElector creates an Elector for the node with the given ID. da is bound to the namespace of the
+// lease claims. Claims are signed with the sequencer signer and only claims signed by the genesis
+// proposer are accepted.
+// DA heights are processed from startHeight, every interval.
+func NewElector(
+	da coreda.DA,
+	signer signer.Signer,
+	proposer []byte,
+	nodeID string,
+	leaseBlocks uint64,
+	startHeight uint64,
+	interval time.Duration,
+	logger log.Logger,
+) (*Elector, error) {
+	if signer == nil {
+		return nil, errors.New("signer is required for leader election")
+	}
+	if nodeID == "" {
+		return nil, errors.New("node ID is required for leader election")
+	}
+	if leaseBlocks < 2 {
+		return nil, fmt.Errorf("lease of %d DA blocks is too short, must be at least 2", leaseBlocks)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid leader election interval: %s", interval)
+	}
+	return &Elector{
+		da:          da,
+		signer:      signer,
+		proposer:    proposer,
+		nodeID:      nodeID,
+		leaseBlocks: leaseBlocks,
+		interval:    interval,
+		logger:      logger,
+		daHeight:    startHeight,
+		leaderCh:    make(chan bool, 1),
+	}, nil
+}
+
+// NodeID returns the ID of this node.
+func (e *Elector) NodeID() string {
+	return e.nodeID
+}
+
+// Lease returns the current lease as seen by this node.
+func (e *Elector) Lease() Lease {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.lease
+}
+
+// IsLeader returns true if this node is the active leader and may produce blocks.
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.isLeader
+}
+
+// LeadershipCh returns a channel receiving the new leadership status of this node whenever it changes.
+func (e *Elector) LeadershipCh() <-chan bool {
+	return e.leaderCh
+}
+
+// Run processes lease claims from the DA layer and campaigns for leadership every interval
+// until the context is canceled.
+func (e *Elector) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		if err := e.sync(ctx); err != nil && ctx.Err() == nil {
+			e.logger.Error("failed to process lease claims", "daHeight", e.currentHeight(), "error", err)
+		}
+		if err := e.campaign(ctx); err != nil && ctx.Err() == nil {
+			e.logger.Error("failed to submit lease claim", "error", err)
+		}
+		e.updateLeadership(time.Now())
+
+		select {
+		case <-ctx.Done():
+			e.setLeader(false)
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Elector) currentHeight() uint64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.daHeight
+}
+
+// sync applies the lease claims of the DA heights available since the last round.
+func (e *Elector) sync(ctx context.Context) error {
+	e.mu.Lock()
+	e.caughtUp = false
+	e.mu.Unlock()
+	for range maxHeightsPerSync {
+		height := e.currentHeight()
+		claims, err := e.fetchClaims(ctx, height)
+		if err != nil {
+			if isHeightFromFuture(err) {
+				e.mu.Lock()
+				e.caughtUp = true
+				e.mu.Unlock()
+				break
+			}
+			return err
+		}
+		e.mu.Lock()
+		for _, claim := range claims {
+			e.apply(claim, height)
+		}
+		e.daHeight = height + 1
+		e.lastProgress = time.Now()
+		e.mu.Unlock()
+	}
+	return nil
+}
+
+func (e *Elector) fetchClaims(ctx context.Context, height uint64) ([]*pb.LeaseClaim, error) {
+	res, err := e.da.GetIDs(ctx, height, nil)
+	if errors.Is(err, coreda.ErrBlobNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if res == nil || len(res.IDs) == 0 {
+		return nil, nil
+	}
+	blobs, err := e.da.Get(ctx, res.IDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	var claims []*pb.LeaseClaim
+	for _, blob := range blobs {
+		claim := new(pb.LeaseClaim)
+		if err := proto.Unmarshal(blob, claim); err != nil || claim.Holder == "" {
+			continue
+		}
+		if err := e.verifyClaim(claim); err != nil {
+			e.logger.Debug("ignoring invalid lease claim", "daHeight", height, "holder", claim.Holder, "error", err)
+			continue
+		}
+		claims = append(claims, claim)
+	}
+	return claims, nil
+}
+
+// apply updates the lease with a claim included at the given DA height. It must be called with mu held.
+func (e *Elector) apply(claim *pb.LeaseClaim, height uint64) {
+	// reject replayed claims
+	if height < claim.DaHeight || height > claim.DaHeight+e.leaseBlocks {
+		return
+	}
+	switch {
+	case e.lease.Holder == "" || height > e.lease.Expiry:
+		// the lease is vacant or expired: any claim for a newer term acquires it
+		if claim.Term <= e.lease.Term {
+			return
+		}
+		e.lease = Lease{Holder: claim.Holder, Term: claim.Term, Expiry: height + e.leaseBlocks}
+		e.logger.Info("leadership lease acquired", "holder", claim.Holder, "term", claim.Term, "expiry", e.lease.Expiry)
+	case claim.Holder == e.lease.Holder && claim.Term == e.lease.Term:
+		e.lease.Expiry = height + e.leaseBlocks
+		e.logger.Debug("leadership lease renewed", "holder", claim.Holder, "term", claim.Term, "expiry", e.lease.Expiry)
+	}
+}
+
+// campaign submits a claim to acquire a vacant or expired lease, or to renew the lease held by this node.
+func (e *Elector) campaign(ctx context.Context) error {
+	e.mu.RLock()
+	lease, height, lastClaim, caughtUp := e.lease, e.daHeight, e.lastClaim, e.caughtUp
+	e.mu.RUnlock()
+
+	// the lease is only known once all claims up to the DA head were processed
+	if !caughtUp {
+		return nil
+	}
+	// wait for a pending claim to be included before submitting a new one
+	if lastClaim != 0 && height <= lastClaim+e.leaseBlocks/4 {
+		return nil
+	}
+
+	var term uint64
+	switch {
+	case lease.Holder == "" || height > lease.Expiry:
+		term = lease.Term + 1
+	case lease.Holder == e.nodeID && lease.Expiry-height <= e.leaseBlocks/2:
+		term = lease.Term
+	default:
+		return nil
+	}
+
+	claim, err := e.signClaim(term, height)
+	if err != nil {
+		return err
+	}
+	blob, err := proto.Marshal(claim)
+	if err != nil {
+		return err
+	}
+	if _, err := e.da.Submit(ctx, []coreda.Blob{blob}, -1, nil); err != nil {
+		return err
+	}
+	e.mu.Lock()
+	e.lastClaim = height
+	e.mu.Unlock()
+	e.logger.Debug("submitted lease claim", "term", term, "daHeight", height)
+	return nil
+}
+
+// updateLeadership recomputes whether this node may produce blocks. The leader steps down a quarter
+// of the lease before it expires, or if it could not follow the DA layer for half a lease.
+// A node lagging behind the DA head is never the leader.
+func (e *Elector) updateLeadership(now time.Time) {
+	e.mu.RLock()
+	margin := max(e.leaseBlocks/4, 1)
+	stale := now.Sub(e.lastProgress) > time.Duration(e.leaseBlocks/2)*e.interval
+	isLeader := e.caughtUp && e.lease.Holder == e.nodeID && e.daHeight+margin <= e.lease.Expiry && !stale
+	e.mu.RUnlock()
+	e.setLeader(isLeader)
+}
+
+func (e *Elector) setLeader(isLeader bool) {
+	e.mu.Lock()
+	changed := e.isLeader != isLeader
+	e.isLeader = isLeader
+	e.mu.Unlock()
+	if !changed {
+		return
+	}
+	// keep only the latest leadership status in the channel
+	select {
+	case <-e.leaderCh:
+	default:
+	}
+	e.leaderCh <- isLeader
+}
+
+func (e *Elector) signClaim(term uint64, daHeight uint64) (*pb.LeaseClaim, error) {
+	pubKey, err := e.signer.GetPublic()
+	if err != nil {
+		return nil, err
+	}
+	pubKeyBytes, err := signer.MarshalPublicKey(pubKey)
+	if err != nil {
+		return nil, err
+	}
+	claim := &pb.LeaseClaim{Holder: e.nodeID, Term: term, DaHeight: daHeight, PubKey: pubKeyBytes}
+	bz, err := claimSignBytes(claim)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Signature, err = e.signer.Sign(bz); err != nil {
+		return nil, err
+	}
+	return claim, nil
+}
+
+func (e *Elector) verifyClaim(claim *pb.LeaseClaim) error {
+	pubKey, err := signer.UnmarshalPublicKey(claim.PubKey)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(types.KeyAddress(pubKey), e.proposer) {
+		return errors.New("claim not signed by the sequencer")
+	}
+	bz, err := claimSignBytes(claim)
+	if err != nil {
+		return err
+	}
+	ok, err := pubKey.Verify(bz, claim.Signature)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("invalid claim signature")
+	}
+	return nil
+}
+
+// claimSignBytes returns the bytes of a claim covered by its signature.
+func claimSignBytes(claim *pb.LeaseClaim) ([]byte, error) {
+	return proto.MarshalOptions{Deterministic: true}.Marshal(&pb.LeaseClaim{
+		Holder:   claim.Holder,
+		Term:     claim.Term,
+		DaHeight: claim.DaHeight,
+		PubKey:   claim.PubKey,
+	})
+}
+
+// isHeightFromFuture checks if the DA layer has no block at the height yet.
+func isHeightFromFuture(err error) bool {
+	return errors.Is(err, coreda.ErrFutureHeight) || strings.Contains(err.Error(), block.ErrHeightFromFutureStr.Error())
+}
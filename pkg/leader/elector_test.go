@@ -0,0 +1,246 @@
+package leader
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+)
+
+// testDA is a DA layer producing blocks on demand: submitted blobs are included in the next mined block
+// and heights that were not mined yet are reported as future heights.
+type testDA struct {
+	*coreda.DummyDA
+
+	mu      sync.Mutex
+	pending []coreda.Blob
+	blocks  [][]coreda.Blob
+}
+
+func newTestDA() *testDA {
+	return &testDA{DummyDA: coreda.NewDummyDA(1024*1024, 1, 1)}
+}
+
+func (d *testDA) Submit(_ context.Context, blobs []coreda.Blob, _ float64, _ []byte) ([]coreda.ID, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.pending = append(d.pending, blobs...)
+	return make([]coreda.ID, len(blobs)), nil
+}
+
+func (d *testDA) GetIDs(_ context.Context, height uint64, _ []byte) (*coreda.GetIDsResult, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if height >= uint64(len(d.blocks)) {
+		return nil, coreda.ErrFutureHeight
+	}
+	ids := make([]coreda.ID, len(d.blocks[height]))
+	for i := range ids {
+		ids[i] = make([]byte, 16)
+		binary.LittleEndian.PutUint64(ids[i], height)
+		binary.LittleEndian.PutUint64(ids[i][8:], uint64(i))
+	}
+	return &coreda.GetIDsResult{IDs: ids, Timestamp: time.Now()}, nil
+}
+
+func (d *testDA) Get(_ context.Context, ids []coreda.ID, _ []byte) ([]coreda.Blob, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	blobs := make([]coreda.Blob, len(ids))
+	for i, id := range ids {
+		blobs[i] = d.blocks[binary.LittleEndian.Uint64(id)][binary.LittleEndian.Uint64(id[8:])]
+	}
+	return blobs, nil
+}
+
+// mine includes the pending blobs in a new DA block.
+func (d *testDA) mine(n int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for range n {
+		d.blocks = append(d.blocks, d.pending)
+		d.pending = nil
+	}
+}
+
+func newTestSigner(t *testing.T) signer.Signer {
+	t.Helper()
+	privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	s, err := noop.NewNoopSigner(privKey)
+	require.NoError(t, err)
+	return s
+}
+
+func newTestElector(t *testing.T, da coreda.DA, s signer.Signer, proposer []byte, nodeID string) *Elector {
+	t.Helper()
+	e, err := NewElector(da, s, proposer, nodeID, 4, 0, time.Second, log.NewNopLogger())
+	require.NoError(t, err)
+	return e
+}
+
+// round runs a single election round of the elector.
+func round(t *testing.T, e *Elector) {
+	t.Helper()
+	ctx := t.Context()
+	require.NoError(t, e.sync(ctx))
+	require.NoError(t, e.campaign(ctx))
+	e.updateLeadership(time.Now())
+}
+
+func TestNewElector(t *testing.T) {
+	da := newTestDA()
+	s := newTestSigner(t)
+	logger := log.NewNopLogger()
+
+	_, err := NewElector(da, nil, nil, "a", 4, 0, time.Second, logger)
+	assert.Error(t, err)
+	_, err = NewElector(da, s, nil, "", 4, 0, time.Second, logger)
+	assert.Error(t, err)
+	_, err = NewElector(da, s, nil, "a", 1, 0, time.Second, logger)
+	assert.Error(t, err)
+	_, err = NewElector(da, s, nil, "a", 4, 0, 0, logger)
+	assert.Error(t, err)
+}
+
+func TestElectorFailover(t *testing.T) {
+	da := newTestDA()
+	s := newTestSigner(t)
+	proposer, err := s.GetAddress()
+	require.NoError(t, err)
+
+	a := newTestElector(t, da, s, proposer, "a")
+	b := newTestElector(t, da, s, proposer, "b")
+
+	// both campaign for the vacant lease, the first claim included wins
+	round(t, a)
+	round(t, b)
+	da.mine(1)
+	round(t, a)
+	round(t, b)
+
+	assert.Equal(t, Lease{Holder: "a", Term: 1, Expiry: 4}, a.Lease())
+	assert.Equal(t, a.Lease(), b.Lease())
+	assert.True(t, a.IsLeader())
+	assert.False(t, b.IsLeader())
+	assert.True(t, <-a.LeadershipCh())
+
+	// the leader renews its lease while the standby waits
+	for range 6 {
+		da.mine(1)
+		round(t, a)
+		round(t, b)
+		assert.True(t, a.IsLeader())
+		assert.False(t, b.IsLeader())
+	}
+	lease := a.Lease()
+	assert.Equal(t, uint64(1), lease.Term)
+	assert.Greater(t, lease.Expiry, uint64(7))
+	assert.Equal(t, lease, b.Lease())
+
+	// the leader fails, the standby takes over only after the lease expired
+	expiry := lease.Expiry
+	for b.currentHeight() <= expiry+1 {
+		assert.False(t, b.IsLeader())
+		da.mine(1)
+		round(t, b)
+	}
+	da.mine(1)
+	round(t, b)
+	assert.Equal(t, "b", b.Lease().Holder)
+	assert.Equal(t, uint64(2), b.Lease().Term)
+	assert.True(t, b.IsLeader())
+	assert.True(t, <-b.LeadershipCh())
+
+	// the former leader catches up and steps down
+	round(t, a)
+	assert.Equal(t, b.Lease(), a.Lease())
+	assert.False(t, a.IsLeader())
+	assert.False(t, <-a.LeadershipCh())
+}
+
+func TestElectorRejectsInvalidClaims(t *testing.T) {
+	da := newTestDA()
+	s := newTestSigner(t)
+	proposer, err := s.GetAddress()
+	require.NoError(t, err)
+
+	// claims signed by another key are ignored
+	other := newTestElector(t, da, newTestSigner(t), proposer, "other")
+	round(t, other)
+	da.mine(1)
+
+	a := newTestElector(t, da, s, proposer, "a")
+	round(t, a)
+	assert.Equal(t, Lease{}, a.Lease())
+
+	// tampered claims are ignored
+	claim, err := a.signClaim(7, 1)
+	require.NoError(t, err)
+	claim.Holder = "mallory"
+	blob, err := proto.Marshal(claim)
+	require.NoError(t, err)
+	_, err = da.Submit(t.Context(), []coreda.Blob{blob}, -1, nil)
+	require.NoError(t, err)
+	da.mine(1)
+	require.NoError(t, a.sync(t.Context()))
+	assert.Equal(t, "a", a.Lease().Holder)
+	assert.Equal(t, uint64(1), a.Lease().Term)
+
+	// a replayed claim does not extend an expired lease
+	claim, err = a.signClaim(1, 1)
+	require.NoError(t, err)
+	blob, err = proto.Marshal(claim)
+	require.NoError(t, err)
+	da.mine(10)
+	_, err = da.Submit(t.Context(), []coreda.Blob{blob}, -1, nil)
+	require.NoError(t, err)
+	da.mine(1)
+	expiry := a.Lease().Expiry
+	require.NoError(t, a.sync(t.Context()))
+	assert.Equal(t, expiry, a.Lease().Expiry)
+	a.updateLeadership(time.Now())
+	assert.False(t, a.IsLeader())
+}
+
+func TestElectorWaitsForDAHead(t *testing.T) {
+	da := newTestDA()
+	s := newTestSigner(t)
+	proposer, err := s.GetAddress()
+	require.NoError(t, err)
+
+	a := newTestElector(t, da, s, proposer, "a")
+	da.mine(maxHeightsPerSync + 1)
+
+	// no claim is submitted before all DA heights were processed
+	round(t, a)
+	assert.Empty(t, da.pending)
+	assert.False(t, a.IsLeader())
+
+	round(t, a)
+	assert.Len(t, da.pending, 1)
+	da.mine(1)
+	round(t, a)
+	assert.True(t, a.IsLeader())
+}
+
+func TestIsHeightFromFuture(t *testing.T) {
+	assert.True(t, isHeightFromFuture(fmt.Errorf("height 5: %w", coreda.ErrFutureHeight)))
+	// errors of DA layers reached over RPC only keep the message
+	assert.True(t, isHeightFromFuture(errors.New("blob: given height is from the future: 5")))
+	assert.False(t, isHeightFromFuture(errors.New("connection refused")))
+}
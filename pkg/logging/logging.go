@@ -0,0 +1,187 @@
+// Package logging provides loggers with per-module log levels that can be changed while the node is running.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"cosmossdk.io/log"
+	"github.com/rs/zerolog"
+)
+
+// Modules of the node, set as the "module" key of their loggers.
+const (
+	ModuleMain       = "main"
+	ModuleBlock      = "block"
+	ModuleSync       = "sync"
+	ModuleP2P        = "p2p"
+	ModuleReaper     = "reaper"
+	ModulePruner     = "pruner"
+	ModuleTxIndex    = "txindex"
+	ModuleLeader     = "leader"
+	ModuleSnapshot   = "snapshot"
+	ModuleDAVerifier = "da_verifier"
+	ModuleDASampler  = "da_sampler"
+	ModuleFraud      = "fraud"
+	ModuleMempool    = "mempool"
+	ModuleBlockData  = "block_data"
+	ModuleTelemetry  = "telemetry"
+	ModuleAttest     = "attest"
+)
+
+// Levels holds the default log level and the log levels of individual modules. Levels are safe for
+// concurrent use, so that they can be changed while the node is running.
+type Levels struct {
+	mu       sync.RWMutex
+	fallback zerolog.Level
+	modules  map[string]zerolog.Level
+}
+
+// NewLevels creates Levels logging every module at the given default level.
+func NewLevels(fallback zerolog.Level) *Levels {
+	return &Levels{
+		fallback: fallback,
+		modules:  make(map[string]zerolog.Level),
+	}
+}
+
+// Set applies a log level specification, a list of module=level pairs separated by spaces or commas,
+// e.g. "block=debug p2p=info". A level without a module changes the default level. The specification is
+// applied only if it is valid as a whole.
+func (l *Levels) Set(spec string) error {
+	fallback, modules, err := parseSpec(spec)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if fallback != nil {
+		l.fallback = *fallback
+	}
+	for module, level := range modules {
+		l.modules[module] = level
+	}
+	return nil
+}
+
+// Level returns the log level of the module.
+func (l *Levels) Level(module string) zerolog.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if level, ok := l.modules[module]; ok {
+		return level
+	}
+	return l.fallback
+}
+
+// String returns the specification of the levels, the default level followed by the module levels.
+func (l *Levels) String() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	parts := make([]string, 0, len(l.modules))
+	for module, level := range l.modules {
+		parts = append(parts, module+"="+level.String())
+	}
+	sort.Strings(parts)
+	return strings.Join(append([]string{l.fallback.String()}, parts...), " ")
+}
+
+// filter implements log.FilterFunc, dropping the events below the level of their module.
+func (l *Levels) filter(module, level string) bool {
+	lvl, err := zerolog.ParseLevel(level)
+	if err != nil {
+		return false
+	}
+	return lvl < l.Level(module)
+}
+
+func parseSpec(spec string) (*zerolog.Level, map[string]zerolog.Level, error) {
+	var fallback *zerolog.Level
+	modules := make(map[string]zerolog.Level)
+	for _, part := range strings.FieldsFunc(spec, func(r rune) bool { return r == ' ' || r == ',' }) {
+		module, name, found := strings.Cut(part, "=")
+		if !found {
+			module, name = "", part
+		}
+		level, err := parseLevel(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !found {
+			fallback = &level
+			continue
+		}
+		if module == "" {
+			return nil, nil, fmt.Errorf("missing module in %q", part)
+		}
+		modules[module] = level
+	}
+	return fallback, modules, nil
+}
+
+func parseLevel(name string) (zerolog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return zerolog.DebugLevel, nil
+	case "info":
+		return zerolog.InfoLevel, nil
+	case "warn":
+		return zerolog.WarnLevel, nil
+	case "error":
+		return zerolog.ErrorLevel, nil
+	default:
+		return zerolog.NoLevel, fmt.Errorf("invalid log level %q, expected debug, info, warn or error", name)
+	}
+}
+
+// Logger is a log.Logger whose module levels can be changed while it is in use.
+type Logger struct {
+	log.Logger
+	levels *Levels
+	recent *Recent
+}
+
+// NewLogger creates a logger writing to dst, dropping the events below the level of their module.
+// Events are written as JSON if json is set, and errors include stack traces if trace is set. The last
+// RecentLines events written are retained, see RecentOf.
+func NewLogger(dst io.Writer, levels *Levels, json, trace bool) Logger {
+	recent := NewRecent(RecentLines)
+	options := []log.Option{
+		// events are filtered by module, so the logger itself must not drop any
+		log.LevelOption(zerolog.DebugLevel),
+		log.FilterOption(levels.filter),
+	}
+	if json {
+		options = append(options, log.OutputJSONOption())
+	}
+	if trace {
+		options = append(options, log.TraceOption(true))
+	}
+	return Logger{Logger: log.NewLogger(io.MultiWriter(dst, recent), options...), levels: levels, recent: recent}
+}
+
+// With returns a logger with the key/value pairs added, sharing the levels and recent events of l.
+func (l Logger) With(keyVals ...any) log.Logger {
+	return Logger{Logger: l.Logger.With(keyVals...), levels: l.levels, recent: l.recent}
+}
+
+// Recent returns the recent events written by the logger.
+func (l Logger) Recent() *Recent {
+	return l.recent
+}
+
+// Levels returns the module levels of the logger.
+func (l Logger) Levels() *Levels {
+	return l.levels
+}
+
+// LevelsOf returns the module levels of the logger, or nil if the levels of logger cannot be changed.
+func LevelsOf(logger log.Logger) *Levels {
+	if l, ok := logger.(interface{ Levels() *Levels }); ok {
+		return l.Levels()
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package logging
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevels_Set(t *testing.T) {
+	levels := NewLevels(zerolog.InfoLevel)
+	require.NoError(t, levels.Set("block=debug, p2p=WARN"))
+	assert.Equal(t, zerolog.DebugLevel, levels.Level(ModuleBlock))
+	assert.Equal(t, zerolog.WarnLevel, levels.Level(ModuleP2P))
+	assert.Equal(t, zerolog.InfoLevel, levels.Level(ModuleSync))
+
+	require.NoError(t, levels.Set("error sync=info"))
+	assert.Equal(t, zerolog.ErrorLevel, levels.Level(ModuleMain))
+	assert.Equal(t, "error block=debug p2p=warn sync=info", levels.String())
+
+	// invalid specifications are not applied
+	require.Error(t, levels.Set("block=info p2p=verbose"))
+	require.Error(t, levels.Set("=info"))
+	assert.Equal(t, zerolog.DebugLevel, levels.Level(ModuleBlock))
+
+	require.NoError(t, levels.Set(""))
+}
+
+func TestLogger(t *testing.T) {
+	var buf bytes.Buffer
+	levels := NewLevels(zerolog.InfoLevel)
+	logger := NewLogger(&buf, levels, true, false).With("module", ModuleMain)
+	block := logger.With("module", ModuleBlock)
+	require.Same(t, levels, LevelsOf(block))
+
+	block.Debug("hidden")
+	logger.Info("shown")
+	require.NoError(t, levels.Set("block=debug"))
+	block.Debug("now shown")
+	logger.Debug("still hidden")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"message":"shown"`)
+	assert.Contains(t, lines[1], `"message":"now shown"`)
+}
+
+func TestRecent(t *testing.T) {
+	recent := NewRecent(2)
+	for _, line := range []string{"a\n", "b\n", "c\n"} {
+		_, err := recent.Write([]byte(line))
+		require.NoError(t, err)
+	}
+	assert.Equal(t, "b\nc\n", string(recent.Bytes()))
+
+	var buf bytes.Buffer
+	logger := NewLogger(&buf, NewLevels(zerolog.InfoLevel), true, false).With("module", ModuleMain)
+	block := logger.With("module", ModuleBlock)
+	require.NotNil(t, RecentOf(block))
+	require.Same(t, RecentOf(logger), RecentOf(block))
+
+	block.Info("retained")
+	assert.Equal(t, buf.String(), string(RecentOf(logger).Bytes()))
+	assert.Contains(t, string(RecentOf(logger).Bytes()), "retained")
+}
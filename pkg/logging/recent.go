@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"bytes"
+	"sync"
+
+	"cosmossdk.io/log"
+)
+
+// RecentLines is the number of log lines retained by the loggers created with NewLogger.
+const RecentLines = 2000
+
+// Recent retains the most recent log lines written to it, e.g. to include them in debug bundles. Recent is safe
+// for concurrent use.
+type Recent struct {
+	mu    sync.Mutex
+	lines [][]byte
+	// next is the index of lines the next line is written to, once lines is full
+	next int
+	max  int
+}
+
+// NewRecent creates a Recent retaining the given number of lines.
+func NewRecent(lines int) *Recent {
+	return &Recent{max: max(lines, 1)}
+}
+
+// Write implements io.Writer. Every write is retained as a line, as loggers write an event per write.
+func (r *Recent) Write(p []byte) (int, error) {
+	line := bytes.Clone(p)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.lines) < r.max {
+		r.lines = append(r.lines, line)
+	} else {
+		r.lines[r.next] = line
+		r.next = (r.next + 1) % r.max
+	}
+	return len(p), nil
+}
+
+// Bytes returns the retained lines, oldest first.
+func (r *Recent) Bytes() []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var buf bytes.Buffer
+	for i := range r.lines {
+		buf.Write(r.lines[(r.next+i)%len(r.lines)])
+	}
+	return buf.Bytes()
+}
+
+// RecentOf returns the recent log lines retained by the logger, or nil if the logger does not retain them.
+func RecentOf(logger log.Logger) *Recent {
+	if l, ok := logger.(interface{ Recent() *Recent }); ok {
+		return l.Recent()
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/log"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Gossip relays transactions over a dedicated pubsub topic, so that transactions submitted to any node reach
+// the aggregator. Only transactions admitted to the mempool of the node are relayed, and peers gossiping
+// transactions rejected by the executor are penalized by the p2p client.
+type Gossip struct {
+	ps      *pubsub.PubSub
+	self    peer.ID
+	topicID string
+	mempool *Mempool
+	logger  log.Logger
+
+	topic *pubsub.Topic
+	sub   *pubsub.Subscription
+}
+
+// NewGossip creates a transaction Gossip for the given chain, admitting the transactions received from peers
+// to the mempool. self is the ID of the local peer, whose transactions are already in the mempool.
+func NewGossip(ps *pubsub.PubSub, self peer.ID, chainID string, mempool *Mempool, logger log.Logger) *Gossip {
+	return &Gossip{
+		ps:      ps,
+		self:    self,
+		topicID: TopicID(chainID),
+		mempool: mempool,
+		logger:  logger,
+	}
+}
+
+// TopicID returns the pubsub topic on which transactions are gossiped for the given chain.
+func TopicID(chainID string) string {
+	return fmt.Sprintf("/%s/mempool/v0.0.1", chainID)
+}
+
+// Start joins the transaction topic and relays the transactions received from peers until ctx is canceled.
+func (g *Gossip) Start(ctx context.Context) error {
+	if err := g.ps.RegisterTopicValidator(g.topicID, g.validate); err != nil {
+		return fmt.Errorf("failed to register transaction validator: %w", err)
+	}
+	topic, err := g.ps.Join(g.topicID)
+	if err != nil {
+		return fmt.Errorf("failed to join transaction topic: %w", err)
+	}
+	sub, err := topic.Subscribe()
+	if err != nil {
+		_ = topic.Close()
+		return fmt.Errorf("failed to subscribe to transaction topic: %w", err)
+	}
+	g.topic, g.sub = topic, sub
+
+	go g.run(ctx)
+	return nil
+}
+
+// Stop leaves the transaction topic.
+func (g *Gossip) Stop() error {
+	if g.sub == nil {
+		return nil
+	}
+	g.sub.Cancel()
+	return errors.Join(g.ps.UnregisterTopicValidator(g.topicID), g.topic.Close())
+}
+
+// Broadcast gossips a transaction admitted to the mempool to peers.
+func (g *Gossip) Broadcast(ctx context.Context, tx []byte) error {
+	return g.topic.Publish(ctx, tx)
+}
+
+// run drains the subscription: transactions are admitted to the mempool by the validator.
+func (g *Gossip) run(ctx context.Context) {
+	for {
+		if _, err := g.sub.Next(ctx); err != nil {
+			return
+		}
+	}
+}
+
+// validate admits the transactions received from peers to the mempool, and relays the admitted transactions.
+// Transactions already in the mempool or not admitted for lack of space are ignored, i.e. neither relayed nor
+// penalized.
+func (g *Gossip) validate(ctx context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	if from == g.self {
+		return pubsub.ValidationAccept
+	}
+	_, err := g.mempool.Add(ctx, msg.Data)
+	switch {
+	case err == nil:
+		return pubsub.ValidationAccept
+	case errors.Is(err, ErrTxInMempool), errors.Is(err, ErrMempoolFull):
+		return pubsub.ValidationIgnore
+	default:
+		g.logger.Debug("rejecting invalid transaction", "peer", from, "error", err)
+		return pubsub.ValidationReject
+	}
+}
@@ -0,0 +1,51 @@
+package mempool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainID = "mempool-test"
+
+func TestGossip(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	defer mnet.Close() //nolint:errcheck
+	hosts := mnet.Hosts()
+
+	mempools := []*Mempool{newTestMempool(t, 10, 0), newTestMempool(t, 10, 0)}
+	gossips := make([]*Gossip, 2)
+	for i, h := range hosts {
+		// flood publishing delivers transactions before the gossipsub mesh is formed
+		ps, err := pubsub.NewGossipSub(ctx, h, pubsub.WithFloodPublish(true))
+		require.NoError(t, err)
+		gossips[i] = NewGossip(ps, h.ID(), testChainID, mempools[i], log.NewNopLogger())
+		require.NoError(t, gossips[i].Start(ctx))
+		defer gossips[i].Stop() //nolint:errcheck
+	}
+	require.Eventually(t, func() bool {
+		return len(gossips[0].topic.ListPeers()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the sender admits its transactions before broadcasting them, and invalid transactions are not admitted
+	hash, err := mempools[0].Add(ctx, []byte("1:tx"))
+	require.NoError(t, err)
+	require.NoError(t, gossips[0].Broadcast(ctx, []byte("1:tx")))
+	require.NoError(t, gossips[0].Broadcast(ctx, []byte("invalid")))
+
+	require.Eventually(t, func() bool {
+		return mempools[1].Has(hash)
+	}, 5*time.Second, 10*time.Millisecond)
+	count, _ := mempools[1].Size()
+	assert.Equal(t, 1, count)
+}
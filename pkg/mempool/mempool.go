@@ -0,0 +1,289 @@
+package mempool
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/txindex"
+	"github.com/rollkit/rollkit/types"
+)
+
+var (
+	// ErrTxInMempool is returned when adding a transaction which is already in the mempool.
+	ErrTxInMempool = errors.New("tx already exists in mempool")
+	// ErrMempoolFull is returned when the mempool is full and the transaction does not have a higher priority
+	// than the transactions in the mempool.
+	ErrMempoolFull = errors.New("mempool is full")
+	// ErrTxRejected is returned when the executor rejects a transaction.
+	ErrTxRejected = errors.New("tx rejected by executor")
+	// ErrTxEmpty is returned when adding an empty transaction.
+	ErrTxEmpty = errors.New("empty transaction")
+	// ErrTxTooLarge is returned when adding a transaction larger than the maximum transaction size.
+	ErrTxTooLarge = errors.New("tx too large")
+	// ErrTxInvalidPrefix is returned when adding a transaction whose payload does not start with an accepted
+	// prefix.
+	ErrTxInvalidPrefix = errors.New("tx payload does not start with an accepted prefix")
+)
+
+// Tx is a transaction waiting in the mempool.
+type Tx struct {
+	Tx       []byte
+	Hash     []byte
+	Priority int64
+	// AddedAt is the time the transaction was admitted to the mempool.
+	AddedAt time.Time
+
+	// seq orders transactions of the same priority by arrival
+	seq uint64
+}
+
+// Mempool holds the transactions submitted to the node until they are included in a block. Transactions are
+// checked against the size and prefix limits of the node, then by the executor if it implements
+// coreexecutor.TxChecker, before they are admitted, and are ordered by the priority assigned by the executor,
+// then by arrival. Mempool is safe for concurrent use.
+type Mempool struct {
+	checker    coreexecutor.TxChecker
+	size       int
+	ttl        time.Duration
+	maxTxBytes uint64
+	// prefixes holds the accepted payload prefixes, empty to accept any payload
+	prefixes [][]byte
+	logger   log.Logger
+
+	mtx   sync.Mutex
+	txs   map[string]*Tx
+	bytes uint64
+	seq   uint64
+
+	txsAvailable chan struct{}
+	// now returns the current time, overridden in tests
+	now func() time.Time
+}
+
+// New creates a Mempool. Transactions are checked by exec if it implements coreexecutor.TxChecker, and all
+// transactions have the same priority otherwise. It returns an error if the accepted prefixes are not valid hex.
+func New(conf config.MempoolConfig, exec coreexecutor.Executor, logger log.Logger) (*Mempool, error) {
+	prefixes, err := parsePrefixes(conf.TxPrefixes)
+	if err != nil {
+		return nil, err
+	}
+	checker, _ := exec.(coreexecutor.TxChecker)
+	return &Mempool{
+		checker:      checker,
+		size:         int(conf.Size),
+		ttl:          conf.TTL.Duration,
+		maxTxBytes:   conf.MaxTxBytes,
+		prefixes:     prefixes,
+		logger:       logger,
+		txs:          make(map[string]*Tx),
+		txsAvailable: make(chan struct{}, 1),
+		now:          time.Now,
+	}, nil
+}
+
+// parsePrefixes parses comma separated hex encoded prefixes.
+func parsePrefixes(spec string) ([][]byte, error) {
+	var prefixes [][]byte
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimPrefix(strings.TrimSpace(field), "0x")
+		if field == "" {
+			continue
+		}
+		prefix, err := hex.DecodeString(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tx prefix %q: %w", field, err)
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	return prefixes, nil
+}
+
+// CheckTxFormat checks the transaction against the size and prefix limits of the node, without calling the
+// executor.
+func (m *Mempool) CheckTxFormat(tx []byte) error {
+	if len(tx) == 0 {
+		return ErrTxEmpty
+	}
+	if m.maxTxBytes > 0 && uint64(len(tx)) > m.maxTxBytes {
+		return fmt.Errorf("%w: %d bytes, maximum %d", ErrTxTooLarge, len(tx), m.maxTxBytes)
+	}
+	if len(m.prefixes) == 0 {
+		return nil
+	}
+	for _, prefix := range m.prefixes {
+		if bytes.HasPrefix(tx, prefix) {
+			return nil
+		}
+	}
+	return ErrTxInvalidPrefix
+}
+
+// Add checks the transaction and admits it to the mempool, and returns its hash. When the mempool is full, the
+// transaction with the lowest priority is evicted if the new transaction has a higher priority.
+func (m *Mempool) Add(ctx context.Context, tx []byte) ([]byte, error) {
+	if err := m.CheckTxFormat(tx); err != nil {
+		return nil, err
+	}
+	hash := txindex.TxHash(tx)
+	key := string(hash)
+	m.mtx.Lock()
+	_, ok := m.txs[key]
+	m.mtx.Unlock()
+	if ok {
+		return hash, ErrTxInMempool
+	}
+
+	// the executor is called without holding the lock, as checks may be slow
+	var priority int64
+	if m.checker != nil {
+		var err error
+		if priority, err = m.checker.CheckTx(ctx, tx); err != nil {
+			return hash, fmt.Errorf("%w: %w", ErrTxRejected, err)
+		}
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.txs[key]; ok {
+		return hash, ErrTxInMempool
+	}
+	m.purgeExpired()
+	if m.size > 0 && len(m.txs) >= m.size {
+		lowest := m.lowestPriority()
+		if lowest == nil || lowest.Priority >= priority {
+			return hash, ErrMempoolFull
+		}
+		m.remove(string(lowest.Hash))
+		m.logger.Debug("evicted tx with lower priority", "hash", fmt.Sprintf("%X", lowest.Hash), "priority", lowest.Priority)
+	}
+	m.seq++
+	m.txs[key] = &Tx{Tx: tx, Hash: hash, Priority: priority, AddedAt: m.now(), seq: m.seq}
+	m.bytes += uint64(len(tx))
+
+	select {
+	case m.txsAvailable <- struct{}{}:
+	default:
+	}
+	return hash, nil
+}
+
+// Has reports whether the transaction with the given hash is in the mempool.
+func (m *Mempool) Has(hash []byte) bool {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.purgeExpired()
+	_, ok := m.txs[string(hash)]
+	return ok
+}
+
+// Txs returns the transactions in the mempool, ordered by priority and then by arrival.
+func (m *Mempool) Txs() [][]byte {
+	sorted := m.sorted(0)
+	txs := make([][]byte, len(sorted))
+	for i, tx := range sorted {
+		txs[i] = tx.Tx
+	}
+	return txs
+}
+
+// UnconfirmedTxs returns up to limit transactions in the mempool, ordered by priority and then by arrival.
+// All transactions are returned if limit is 0.
+func (m *Mempool) UnconfirmedTxs(limit int) []Tx {
+	sorted := m.sorted(limit)
+	txs := make([]Tx, len(sorted))
+	for i, tx := range sorted {
+		txs[i] = *tx
+	}
+	return txs
+}
+
+// Size returns the number of transactions in the mempool and their total size in bytes.
+func (m *Mempool) Size() (int, uint64) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.purgeExpired()
+	return len(m.txs), m.bytes
+}
+
+// Update removes the transactions included in a block from the mempool.
+func (m *Mempool) Update(txs types.Txs) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, tx := range txs {
+		m.remove(string(txindex.TxHash(tx)))
+	}
+}
+
+// TxsAvailable returns a channel receiving a value when transactions are admitted to the mempool.
+// Notifications are coalesced: a single value is sent for transactions admitted before it is received.
+func (m *Mempool) TxsAvailable() <-chan struct{} {
+	return m.txsAvailable
+}
+
+func (m *Mempool) sorted(limit int) []*Tx {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.purgeExpired()
+	txs := make([]*Tx, 0, len(m.txs))
+	for _, tx := range m.txs {
+		txs = append(txs, tx)
+	}
+	sort.Slice(txs, func(i, j int) bool { return higherPriority(txs[i], txs[j]) })
+	if limit > 0 && len(txs) > limit {
+		txs = txs[:limit]
+	}
+	return txs
+}
+
+// lowestPriority returns the transaction evicted first when the mempool is full. It must be called with mtx
+// held.
+func (m *Mempool) lowestPriority() *Tx {
+	var lowest *Tx
+	for _, tx := range m.txs {
+		if lowest == nil || higherPriority(lowest, tx) {
+			lowest = tx
+		}
+	}
+	return lowest
+}
+
+// purgeExpired evicts the transactions which waited in the mempool for longer than the TTL. It must be called
+// with mtx held.
+func (m *Mempool) purgeExpired() {
+	if m.ttl <= 0 {
+		return
+	}
+	cutoff := m.now().Add(-m.ttl)
+	for key, tx := range m.txs {
+		if tx.AddedAt.Before(cutoff) {
+			m.remove(key)
+		}
+	}
+}
+
+// remove must be called with mtx held.
+func (m *Mempool) remove(key string) {
+	if tx, ok := m.txs[key]; ok {
+		delete(m.txs, key)
+		m.bytes -= uint64(len(tx.Tx))
+	}
+}
+
+// higherPriority reports whether a is ordered before b.
+func higherPriority(a, b *Tx) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	return a.seq < b.seq
+}
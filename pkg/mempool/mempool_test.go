@@ -0,0 +1,170 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/txindex"
+	"github.com/rollkit/rollkit/types"
+)
+
+// priorityExecutor assigns transactions of the form "<priority>:<payload>" their priority, and rejects other
+// transactions.
+type priorityExecutor struct {
+	coreexecutor.Executor
+}
+
+func (priorityExecutor) CheckTx(_ context.Context, tx []byte) (int64, error) {
+	prefix, _, ok := strings.Cut(string(tx), ":")
+	if !ok {
+		return 0, errors.New("missing priority")
+	}
+	return strconv.ParseInt(prefix, 10, 64)
+}
+
+func newTestMempool(t *testing.T, size uint64, ttl time.Duration) *Mempool {
+	t.Helper()
+	conf := config.MempoolConfig{Size: size, TTL: config.DurationWrapper{Duration: ttl}}
+	mp, err := New(conf, priorityExecutor{}, log.NewNopLogger())
+	require.NoError(t, err)
+	return mp
+}
+
+func TestAdd(t *testing.T) {
+	ctx := t.Context()
+	mp := newTestMempool(t, 10, 0)
+
+	hash, err := mp.Add(ctx, []byte("1:a"))
+	require.NoError(t, err)
+	assert.Equal(t, txindex.TxHash([]byte("1:a")), hash)
+	assert.True(t, mp.Has(hash))
+	select {
+	case <-mp.TxsAvailable():
+	default:
+		assert.Fail(t, "expected notification of available transactions")
+	}
+
+	_, err = mp.Add(ctx, []byte("1:a"))
+	assert.ErrorIs(t, err, ErrTxInMempool)
+	_, err = mp.Add(ctx, []byte("invalid"))
+	assert.ErrorIs(t, err, ErrTxRejected)
+	_, err = mp.Add(ctx, nil)
+	assert.ErrorIs(t, err, ErrTxEmpty)
+
+	count, bytes := mp.Size()
+	assert.Equal(t, 1, count)
+	assert.Equal(t, uint64(3), bytes)
+}
+
+func TestAddWithoutChecker(t *testing.T) {
+	mp, err := New(config.MempoolConfig{Size: 10}, coreexecutor.NewDummyExecutor(), log.NewNopLogger())
+	require.NoError(t, err)
+	_, err = mp.Add(t.Context(), []byte("any tx"))
+	require.NoError(t, err)
+	txs := mp.UnconfirmedTxs(0)
+	require.Len(t, txs, 1)
+	assert.Equal(t, int64(0), txs[0].Priority)
+}
+
+func TestTxFormat(t *testing.T) {
+	ctx := t.Context()
+	conf := config.MempoolConfig{Size: 10, MaxTxBytes: 8, TxPrefixes: "01, 0x0a0b"}
+	mp, err := New(conf, coreexecutor.NewDummyExecutor(), log.NewNopLogger())
+	require.NoError(t, err)
+
+	_, err = mp.Add(ctx, []byte{0x01, 'a'})
+	require.NoError(t, err)
+	_, err = mp.Add(ctx, []byte{0x0a, 0x0b, 'a'})
+	require.NoError(t, err)
+	_, err = mp.Add(ctx, []byte{0x0a, 'a'})
+	assert.ErrorIs(t, err, ErrTxInvalidPrefix)
+	_, err = mp.Add(ctx, []byte{0x01, 'a', 'b', 'c', 'd', 'e', 'f', 'g', 'h'})
+	assert.ErrorIs(t, err, ErrTxTooLarge)
+	count, _ := mp.Size()
+	assert.Equal(t, 2, count)
+
+	_, err = New(config.MempoolConfig{TxPrefixes: "zz"}, coreexecutor.NewDummyExecutor(), log.NewNopLogger())
+	assert.Error(t, err)
+}
+
+func TestPriorityOrdering(t *testing.T) {
+	ctx := t.Context()
+	mp := newTestMempool(t, 10, 0)
+	for _, tx := range []string{"1:a", "5:b", "1:c", "3:d", "5:e"} {
+		_, err := mp.Add(ctx, []byte(tx))
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, [][]byte{[]byte("5:b"), []byte("5:e"), []byte("3:d"), []byte("1:a"), []byte("1:c")}, mp.Txs())
+
+	txs := mp.UnconfirmedTxs(2)
+	require.Len(t, txs, 2)
+	assert.Equal(t, []byte("5:b"), txs[0].Tx)
+	assert.Equal(t, int64(5), txs[0].Priority)
+	assert.Equal(t, txindex.TxHash([]byte("5:e")), txs[1].Hash)
+}
+
+func TestEvictionWhenFull(t *testing.T) {
+	ctx := t.Context()
+	mp := newTestMempool(t, 2, 0)
+	for _, tx := range []string{"2:a", "1:b"} {
+		_, err := mp.Add(ctx, []byte(tx))
+		require.NoError(t, err)
+	}
+
+	_, err := mp.Add(ctx, []byte("1:c"))
+	assert.ErrorIs(t, err, ErrMempoolFull)
+
+	// the transaction with the lowest priority is evicted for a transaction with a higher priority
+	_, err = mp.Add(ctx, []byte("3:d"))
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("3:d"), []byte("2:a")}, mp.Txs())
+}
+
+func TestTTLEviction(t *testing.T) {
+	ctx := t.Context()
+	mp := newTestMempool(t, 10, time.Minute)
+	now := time.Now()
+	mp.now = func() time.Time { return now }
+
+	_, err := mp.Add(ctx, []byte("1:a"))
+	require.NoError(t, err)
+	now = now.Add(30 * time.Second)
+	_, err = mp.Add(ctx, []byte("1:b"))
+	require.NoError(t, err)
+
+	now = now.Add(45 * time.Second)
+	assert.Equal(t, [][]byte{[]byte("1:b")}, mp.Txs())
+	count, bytes := mp.Size()
+	assert.Equal(t, 1, count)
+	assert.Equal(t, uint64(3), bytes)
+
+	// expired transactions can be submitted again
+	_, err = mp.Add(ctx, []byte("1:a"))
+	assert.NoError(t, err)
+}
+
+func TestUpdate(t *testing.T) {
+	ctx := t.Context()
+	mp := newTestMempool(t, 10, 0)
+	for _, tx := range []string{"1:a", "1:b", "1:c"} {
+		_, err := mp.Add(ctx, []byte(tx))
+		require.NoError(t, err)
+	}
+
+	mp.Update(types.Txs{types.Tx("1:a"), types.Tx("1:c"), types.Tx("1:unknown")})
+	assert.Equal(t, [][]byte{[]byte("1:b")}, mp.Txs())
+	count, bytes := mp.Size()
+	assert.Equal(t, 1, count)
+	assert.Equal(t, uint64(3), bytes)
+}
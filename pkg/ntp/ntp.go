@@ -0,0 +1,104 @@
+// Package ntp measures the offset of the host clock against an NTP server, using a single SNTP (RFC 4330)
+// request.
+package ntp
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// DefaultPort is the port of NTP servers
+	DefaultPort = "123"
+
+	// packetSize is the size of an NTP packet without extensions
+	packetSize = 48
+	// epochOffset is the number of seconds from the NTP epoch (1900) to the unix epoch (1970)
+	epochOffset = 2208988800
+	// clientMode is the first byte of requests: no leap indicator, version 4, client mode
+	clientMode = 0x23
+	// serverMode is the mode of server replies
+	serverMode = 4
+	// defaultTimeout is the timeout of requests if the context has no deadline
+	defaultTimeout = 5 * time.Second
+)
+
+// Offset returns the offset of the host clock against the NTP server at the given address (host or host:port):
+// the duration to add to the host clock to obtain the time of the server.
+func Offset(ctx context.Context, server string) (time.Duration, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, DefaultPort)
+	}
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", server)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to NTP server %s: %w", server, err)
+	}
+	defer conn.Close() //nolint:errcheck // read only connection
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return 0, err
+	}
+
+	req := make([]byte, packetSize)
+	req[0] = clientMode
+	sent := time.Now()
+	// the transmit timestamp is echoed by the server as originate timestamp, identifying the reply
+	binary.BigEndian.PutUint64(req[40:], toNTPTime(sent))
+	if _, err := conn.Write(req); err != nil {
+		return 0, fmt.Errorf("failed to send NTP request to %s: %w", server, err)
+	}
+
+	resp := make([]byte, packetSize)
+	n, err := conn.Read(resp)
+	received := time.Now()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read NTP reply from %s: %w", server, err)
+	}
+	return offset(req, resp[:n], sent, received)
+}
+
+// offset computes the clock offset from a reply to the request, sent and received at the given times of the host
+// clock: ((t2 - t1) + (t3 - t4)) / 2, where t2 and t3 are the receive and transmit times of the server.
+func offset(req, resp []byte, sent, received time.Time) (time.Duration, error) {
+	if len(resp) < packetSize {
+		return 0, errors.New("short NTP reply")
+	}
+	if mode := resp[0] & 0x07; mode != serverMode {
+		return 0, fmt.Errorf("unexpected NTP reply mode %d", mode)
+	}
+	// stratum 0 is a kiss-o'-death reply, e.g. rate limiting
+	if resp[1] == 0 {
+		return 0, fmt.Errorf("NTP server refused the request: %s", resp[12:16])
+	}
+	if binary.BigEndian.Uint64(resp[24:]) != binary.BigEndian.Uint64(req[40:]) {
+		return 0, errors.New("NTP reply does not match the request")
+	}
+	serverReceived := fromNTPTime(binary.BigEndian.Uint64(resp[32:]))
+	serverSent := fromNTPTime(binary.BigEndian.Uint64(resp[40:]))
+	return (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2, nil
+}
+
+// toNTPTime converts a time to an NTP timestamp: seconds since the NTP epoch in the upper 32 bits, and the
+// fraction of the second in the lower 32 bits.
+func toNTPTime(t time.Time) uint64 {
+	nanos := uint64(t.UnixNano()) + epochOffset*uint64(time.Second) //nolint:gosec // times after 1970
+	seconds := nanos / uint64(time.Second)
+	fraction := (nanos % uint64(time.Second)) << 32 / uint64(time.Second)
+	return seconds<<32 | fraction
+}
+
+// fromNTPTime converts an NTP timestamp to a time.
+func fromNTPTime(ts uint64) time.Time {
+	seconds := int64(ts>>32) - epochOffset
+	nanos := int64((ts & 0xffffffff) * uint64(time.Second) >> 32) //nolint:gosec // below one second
+	return time.Unix(seconds, nanos)
+}
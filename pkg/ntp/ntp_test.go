@@ -0,0 +1,87 @@
+package ntp
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serve replies to NTP requests with the host time shifted by skew, or with the given stratum.
+func serve(t *testing.T, skew time.Duration, stratum byte) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		req := make([]byte, packetSize)
+		for {
+			n, addr, err := conn.ReadFrom(req)
+			if err != nil {
+				return
+			}
+			if n < packetSize {
+				continue
+			}
+			resp := make([]byte, packetSize)
+			resp[0] = 0x24 // version 4, server mode
+			resp[1] = stratum
+			copy(resp[12:16], "RATE")
+			copy(resp[24:32], req[40:48])
+			binary.BigEndian.PutUint64(resp[32:], toNTPTime(time.Now().Add(skew)))
+			binary.BigEndian.PutUint64(resp[40:], toNTPTime(time.Now().Add(skew)))
+			_, _ = conn.WriteTo(resp, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestOffset(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, skew := range []time.Duration{0, 3 * time.Second, -90 * time.Minute} {
+		offset, err := Offset(ctx, serve(t, skew, 2))
+		require.NoError(t, err)
+		assert.InDelta(t, skew, offset, float64(50*time.Millisecond))
+	}
+
+	_, err := Offset(ctx, serve(t, 0, 0))
+	require.ErrorContains(t, err, "RATE")
+}
+
+func TestOffset_InvalidReply(t *testing.T) {
+	req := make([]byte, packetSize)
+	sent := time.Now()
+	binary.BigEndian.PutUint64(req[40:], toNTPTime(sent))
+
+	resp := make([]byte, packetSize)
+	resp[0] = 0x24
+	resp[1] = 1
+	copy(resp[24:32], req[40:48])
+	_, err := offset(req, resp, sent, sent)
+	require.NoError(t, err)
+
+	_, err = offset(req, resp[:packetSize-1], sent, sent)
+	assert.Error(t, err)
+
+	resp[0] = 0x23
+	_, err = offset(req, resp, sent, sent)
+	assert.ErrorContains(t, err, "mode")
+
+	resp[0] = 0x24
+	resp[24]++
+	_, err = offset(req, resp, sent, sent)
+	assert.ErrorContains(t, err, "does not match")
+}
+
+func TestNTPTime(t *testing.T) {
+	now := time.Now()
+	assert.WithinDuration(t, now, fromNTPTime(toNTPTime(now)), time.Microsecond)
+	assert.Equal(t, uint64(epochOffset)<<32, toNTPTime(time.Unix(0, 0)))
+}
@@ -0,0 +1,93 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/log"
+
+	"github.com/rollkit/rollkit/pkg/logging"
+)
+
+// ErrHostNotStarted is returned when starting the client of a hosted chain before the client owning the host.
+var ErrHostNotStarted = errors.New("the client owning the shared host is not started")
+
+// ForChain returns the client of another chain sharing the host of the client, for a process hosting multiple
+// chains. The client of the chain shares the libp2p host, the DHT, the gossip router, the connection gater and the
+// peer scores, and has its own chain ID, topics, genesis handshake and peer discovery namespace.
+//
+// It must be started after the client owning the host, and closing it leaves the host open.
+func (c *Client) ForChain(chainID string, logger log.Logger) *Client {
+	return &Client{
+		logger:    logger.With("module", logging.ModuleP2P),
+		conf:      c.conf,
+		chainID:   chainID,
+		ds:        c.ds,
+		privKey:   c.privKey,
+		psk:       c.psk,
+		allowlist: c.allowlist,
+		gater:     c.gater,
+		scorer:    c.scorer,
+		metrics:   c.metrics,
+		bandwidth: c.bandwidth,
+		gossip:    c.gossip,
+		topics:    make(map[string]*Topic),
+		parent:    c,
+	}
+}
+
+// startChain joins the chain on the started host of the parent: it sets up the genesis handshake of the chain,
+// joins its topics, and advertises and looks up the peers of the chain.
+func (c *Client) startChain(ctx context.Context) error {
+	c.parent.topicsMu.Lock()
+	ps := c.parent.ps
+	c.parent.topicsMu.Unlock()
+	if ps == nil || c.parent.disc == nil {
+		return ErrHostNotStarted
+	}
+	c.host, c.dht, c.disc = c.parent.host, c.parent.dht, c.parent.disc
+
+	if err := c.setupGenesisHandshake(ctx); err != nil {
+		return err
+	}
+
+	c.topicsMu.Lock()
+	c.ps = ps
+	for _, t := range c.topics {
+		if err := t.join(ps); err != nil {
+			c.topicsMu.Unlock()
+			return fmt.Errorf("failed to join topic %s: %w", t.name, err)
+		}
+	}
+	c.topicsMu.Unlock()
+
+	if err := c.advertise(ctx); err != nil {
+		return err
+	}
+	if err := c.findPeers(ctx); err != nil {
+		return err
+	}
+	go c.discoveryLoop(ctx)
+	return nil
+}
+
+// closeChain stops serving the genesis fingerprint of the chain and leaves the topics of the chain. Topics with
+// active subscriptions stay joined until the host is closed. The advertisement of the chain ends with the context
+// the client was started with.
+func (c *Client) closeChain() error {
+	if c.host == nil {
+		return nil
+	}
+	if c.genesisFingerprint != nil {
+		c.host.RemoveStreamHandler(genesisProtocolID(c.chainID))
+	}
+	c.topicsMu.Lock()
+	defer c.topicsMu.Unlock()
+	for _, t := range c.topics {
+		if err := t.leave(c.ps); err != nil {
+			c.logger.Debug("failed to leave topic", "topic", t.name, "error", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForChain(t *testing.T) {
+	ctx := t.Context()
+	logger := log.NewTestLogger(t)
+
+	clients := startTestNetwork(ctx, t, 2, map[int]hostDescr{
+		1: {conns: []int{0}},
+	}, logger)
+	clients.WaitForDHT()
+
+	// the client of a chain cannot be started before the client owning the host
+	unstarted := newTestClient(t, clients[0].conf, nil)
+	assert.ErrorIs(t, unstarted.ForChain("chain-a", logger).Start(ctx), ErrHostNotStarted)
+
+	chains := make([]*Client, len(clients))
+	topics := make([]*Topic, len(clients))
+	for i, c := range clients {
+		chains[i] = c.ForChain("chain-a", logger)
+		chains[i].SetGenesisFingerprint([]byte("genesis-a"))
+		var err error
+		topics[i], err = chains[i].RegisterTopic("prices", TopicOptions{})
+		require.NoError(t, err)
+		require.NoError(t, chains[i].Start(ctx))
+	}
+	assert.Equal(t, "/chain-a-app/prices", topics[0].Name())
+	_, _, network, err := chains[0].Info()
+	require.NoError(t, err)
+	assert.Equal(t, "chain-a", network)
+	// the chains share the host of their client
+	assert.Equal(t, clients[0].Host().ID(), chains[0].Host().ID())
+
+	sub, err := topics[1].Subscribe()
+	require.NoError(t, err)
+	defer sub.Cancel()
+	senderSub, err := topics[0].Subscribe()
+	require.NoError(t, err)
+	defer senderSub.Cancel()
+	require.Eventually(t, func() bool {
+		for _, topic := range chains[0].NetworkStats().Topics {
+			if topic.Topic == topics[0].Name() && topic.MeshPeers == 1 {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, topics[0].Publish(ctx, []byte("price")))
+	nextCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	msg, err := sub.Next(nextCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "price", string(msg.Data))
+
+	// closing the client of a chain leaves the host open
+	senderSub.Cancel()
+	require.NoError(t, chains[0].Close())
+	assert.ErrorIs(t, topics[0].Publish(ctx, []byte("price")), ErrTopicNotJoined)
+	assert.Contains(t, clients[0].PeerIDs(), clients[1].Host().ID())
+}
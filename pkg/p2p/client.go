@@ -5,7 +5,10 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"cosmossdk.io/log"
@@ -16,8 +19,10 @@ import (
 	"github.com/libp2p/go-libp2p/core/crypto"
 	cdiscovery "github.com/libp2p/go-libp2p/core/discovery"
 	"github.com/libp2p/go-libp2p/core/host"
+	libp2pmetrics "github.com/libp2p/go-libp2p/core/metrics"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/pnet"
 	discovery "github.com/libp2p/go-libp2p/p2p/discovery/routing"
 	discutil "github.com/libp2p/go-libp2p/p2p/discovery/util"
 	routedhost "github.com/libp2p/go-libp2p/p2p/host/routed"
@@ -26,6 +31,7 @@ import (
 
 	"github.com/rollkit/rollkit/pkg/config"
 	rollhash "github.com/rollkit/rollkit/pkg/hash"
+	"github.com/rollkit/rollkit/pkg/logging"
 	"github.com/rollkit/rollkit/pkg/p2p/key"
 )
 
@@ -36,6 +42,13 @@ const (
 
 	// peerLimit defines limit of number of peers returned during active peer discovery.
 	peerLimit = 60
+
+	// discoveryInterval is the interval at which good peers are saved, and peers are looked up again if there
+	// are fewer connected peers than targetPeers.
+	discoveryInterval = time.Minute
+
+	// targetPeers is the number of connected peers below which active peer discovery is repeated.
+	targetPeers = 10
 )
 
 // Client is a P2P client, implemented with libp2p.
@@ -48,7 +61,13 @@ type Client struct {
 
 	conf    config.P2PConfig
 	chainID string
+	// ds persists the connection gater and the saved peers
+	ds      datastore.Datastore
 	privKey crypto.PrivKey
+	// psk is the preshared key of the private network, nil for the public network
+	psk pnet.PSK
+	// allowlist holds the only peers connections are allowed with, nil if any peer is allowed
+	allowlist map[peer.ID]struct{}
 
 	host  host.Host
 	dht   *dht.IpfsDHT
@@ -56,7 +75,26 @@ type Client struct {
 	gater *conngater.BasicConnectionGater
 	ps    *pubsub.PubSub
 
+	scorer *peerScorer
+	// maxPeers is the maximum number of connected peers, 0 for no limit
+	maxPeers atomic.Uint64
+
 	metrics *Metrics
+	// bandwidth counts the bytes exchanged with peers, by peer and protocol
+	bandwidth *libp2pmetrics.BandwidthCounter
+	// gossip counts the gossip messages of every topic and tracks the gossip mesh
+	gossip *gossipStats
+
+	// topicsMu guards ps, once gossiping is set up, and topics
+	topicsMu sync.Mutex
+	// topics holds the topics registered by the application, by name
+	topics map[string]*Topic
+
+	// genesisFingerprint is the fingerprint of the genesis exchanged with peers, nil to accept peers of any genesis
+	genesisFingerprint []byte
+
+	// parent is the client whose host is shared by the client of a hosted chain, nil for a client owning its host
+	parent *Client
 }
 
 // NewClient creates new Client object.
@@ -82,14 +120,39 @@ func NewClient(
 		return nil, fmt.Errorf("node key is required")
 	}
 
-	return &Client{
+	if metrics == nil {
+		metrics = NopMetrics()
+	}
+
+	c := &Client{
 		conf:    conf.P2P,
+		ds:      ds,
 		gater:   gater,
 		privKey: nodeKey.PrivKey,
+		psk:     nodeKey.PSK,
 		chainID: conf.ChainID,
-		logger:  logger,
+		logger:  logger.With("module", logging.ModuleP2P),
 		metrics: metrics,
-	}, nil
+		scorer:  newPeerScorer(conf.P2P.BanThreshold, conf.P2P.BanDuration.Duration),
+
+		bandwidth: libp2pmetrics.NewBandwidthCounter(),
+		gossip:    newGossipStats(),
+		topics:    make(map[string]*Topic),
+	}
+	c.maxPeers.Store(conf.P2P.MaxPeers)
+	if conf.P2P.AllowlistOnly {
+		c.allowlist = make(map[peer.ID]struct{})
+		for _, id := range c.parsePeerIDs(conf.P2P.AllowedPeers) {
+			c.allowlist[id] = struct{}{}
+		}
+		for _, p := range c.parseAddrInfoList(conf.P2P.Peers) {
+			c.allowlist[p.ID] = struct{}{}
+		}
+		if len(c.allowlist) == 0 {
+			return nil, fmt.Errorf("allowlist_only requires allowed peers")
+		}
+	}
+	return c, nil
 }
 
 // Start establish Client's P2P connectivity.
@@ -100,6 +163,9 @@ func NewClient(
 // 3. Setup DHT, establish connection to seed nodes and initialize peer discovery.
 // 4. Use active peer discovery to look for peers from same ORU network.
 func (c *Client) Start(ctx context.Context) error {
+	if c.parent != nil {
+		return c.startChain(ctx)
+	}
 	c.logger.Debug("starting P2P client")
 	host, err := c.listen()
 	if err != nil {
@@ -114,6 +180,14 @@ func (c *Client) startWithHost(ctx context.Context, h host.Host) error {
 		c.logger.Info("listening on", "address", fmt.Sprintf("%s/p2p/%s", a, c.host.ID()))
 	}
 
+	if err := c.watchPeerRecords(ctx); err != nil {
+		return err
+	}
+
+	if err := c.setupGenesisHandshake(ctx); err != nil {
+		return err
+	}
+
 	c.logger.Debug("blocking blacklisted peers", "blacklist", c.conf.BlockedPeers)
 	if err := c.setupBlockedPeers(c.parseAddrInfoList(c.conf.BlockedPeers)); err != nil {
 		return err
@@ -139,11 +213,21 @@ func (c *Client) startWithHost(ctx context.Context, h host.Host) error {
 		return err
 	}
 
+	go c.scoringLoop(ctx)
+	go c.statsLoop(ctx)
+	go c.discoveryLoop(ctx)
+
 	return nil
 }
 
 // Close gently stops Client.
 func (c *Client) Close() error {
+	if c.parent != nil {
+		return c.closeChain()
+	}
+	if err := c.savePeers(context.Background()); err != nil {
+		c.logger.Error("failed to save peers", "error", err)
+	}
 	return errors.Join(
 		c.dht.Close(),
 		c.host.Close(),
@@ -165,6 +249,11 @@ func (c *Client) PubSub() *pubsub.PubSub {
 	return c.ps
 }
 
+// SetMetrics sets the metrics updated by the client. It must be called before Start.
+func (c *Client) SetMetrics(metrics *Metrics) {
+	c.metrics = metrics
+}
+
 // ConnectionGater returns the client's connection gater
 func (c *Client) ConnectionGater() *conngater.BasicConnectionGater {
 	return c.gater
@@ -213,11 +302,38 @@ func (c *Client) listen() (host.Host, error) {
 		return nil, err
 	}
 
-	return libp2p.New(libp2p.ListenAddrs(maddr), libp2p.Identity(c.privKey), libp2p.ConnectionGater(c.gater))
+	opts := []libp2p.Option{
+		libp2p.ListenAddrs(maddr),
+		libp2p.Identity(c.privKey),
+		libp2p.ConnectionGater(scoreGater{c.gater, c.scorer, c.peerLimitReached, c.peerAllowed}),
+		libp2p.BandwidthReporter(c.bandwidth),
+	}
+	if c.psk != nil {
+		c.logger.Info("joining private network with preshared key")
+		opts = append(opts, libp2p.PrivateNetwork(c.psk))
+	}
+	return libp2p.New(opts...)
+}
+
+// peerAllowed reports whether connections with the peer are allowed by the allowlist.
+func (c *Client) peerAllowed(id peer.ID) bool {
+	if c.allowlist == nil {
+		return true
+	}
+	_, ok := c.allowlist[id]
+	return ok
 }
 
 func (c *Client) setupDHT(ctx context.Context) error {
 	peers := c.parseAddrInfoList(c.conf.Peers)
+	saved, err := c.loadPeers(ctx)
+	if err != nil {
+		return err
+	}
+	if len(saved) > 0 {
+		c.logger.Info("connecting to saved peers", "count", len(saved))
+		peers = append(peers, saved...)
+	}
 	if len(peers) == 0 {
 		c.logger.Info("no peers - only listening for connections")
 	}
@@ -226,7 +342,6 @@ func (c *Client) setupDHT(ctx context.Context) error {
 		c.logger.Debug("peer", "addr", sa)
 	}
 
-	var err error
 	c.dht, err = dht.New(ctx, c.host, dht.Mode(dht.ModeServer), dht.BootstrapPeers(peers...))
 	if err != nil {
 		return fmt.Errorf("failed to create DHT: %w", err)
@@ -302,12 +417,42 @@ func (c *Client) findPeers(ctx context.Context) error {
 	}
 
 	for peer := range peerCh {
+		if peer.ID == c.host.ID() || c.host.Network().Connectedness(peer.ID) == network.Connected {
+			continue
+		}
 		go c.tryConnect(ctx, peer)
 	}
 
 	return nil
 }
 
+// discoveryLoop saves the good peers, and looks up peers of the network again while there are few connected
+// peers, until the context is canceled. Nodes joining through a single seed node find the other peers as the
+// seed node and the peers they connect to fill their DHT.
+func (c *Client) discoveryLoop(ctx context.Context) {
+	ticker := time.NewTicker(discoveryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		// the peers of a shared host are saved by the client owning the host
+		if c.parent == nil {
+			if err := c.savePeers(ctx); err != nil && ctx.Err() == nil {
+				c.logger.Error("failed to save peers", "error", err)
+			}
+		}
+		if len(c.host.Network().Peers()) >= targetPeers {
+			continue
+		}
+		if err := c.findPeers(ctx); err != nil && ctx.Err() == nil {
+			c.logger.Error("failed to find peers", "error", err)
+		}
+	}
+}
+
 // tryConnect attempts to connect to a peer and logs error if necessary
 func (c *Client) tryConnect(ctx context.Context, peer peer.AddrInfo) {
 	err := c.host.Connect(ctx, peer)
@@ -317,11 +462,23 @@ func (c *Client) tryConnect(ctx context.Context, peer peer.AddrInfo) {
 }
 
 func (c *Client) setupGossiping(ctx context.Context) error {
-	var err error
-	c.ps, err = pubsub.NewGossipSub(ctx, c.host)
+	ps, err := pubsub.NewGossipSub(ctx, c.host,
+		pubsub.WithRawTracer(c.scorer.tracer(c.ReportPeer)),
+		pubsub.WithRawTracer(c.gossip),
+		// peers pruned from a full mesh are sent signed peer records of other peers to connect to
+		pubsub.WithPeerExchange(c.conf.PeerExchange),
+	)
 	if err != nil {
 		return err
 	}
+	c.topicsMu.Lock()
+	defer c.topicsMu.Unlock()
+	c.ps = ps
+	for _, t := range c.topics {
+		if err := t.join(ps); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -348,6 +505,30 @@ func (c *Client) parseAddrInfoList(addrInfoStr string) []peer.AddrInfo {
 	return addrs
 }
 
+// parsePeerIDs parses a comma separated string of peer IDs or multiaddrs into a list of peer IDs.
+func (c *Client) parsePeerIDs(peersStr string) []peer.ID {
+	if len(peersStr) == 0 {
+		return nil
+	}
+	var ids []peer.ID
+	for _, p := range strings.Split(peersStr, ",") {
+		p = strings.TrimSpace(p)
+		if strings.HasPrefix(p, "/") {
+			for _, addrInfo := range c.parseAddrInfoList(p) {
+				ids = append(ids, addrInfo.ID)
+			}
+			continue
+		}
+		id, err := peer.Decode(p)
+		if err != nil {
+			c.logger.Error("failed to parse peer ID", "id", p, "error", err)
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 // getNamespace returns unique string identifying ORU network.
 //
 // It is used to advertise/find peers in libp2p DHT.
@@ -382,3 +563,137 @@ func (c *Client) GetNetworkInfo() (NetworkInfo, error) {
 		ConnectedPeers: c.PeerIDs(),
 	}, nil
 }
+
+// ReportPeer lowers the score of a misbehaving peer. Peers whose score falls to the ban threshold are
+// disconnected and banned for the configured ban duration.
+func (c *Client) ReportPeer(id peer.ID, m Misbehavior) {
+	c.logger.Debug("peer misbehaved", "peer", id, "misbehavior", m)
+	if !c.scorer.report(id, m) {
+		return
+	}
+	c.logger.Info("banning peer with low score", "peer", id, "misbehavior", m, "duration", c.conf.BanDuration.Duration)
+	c.disconnect(id)
+}
+
+// SetTopicMisbehavior sets the misbehavior reported for peers gossiping messages rejected by the validator
+// of the topic, e.g. InvalidHeader for the topic on which headers are gossiped.
+func (c *Client) SetTopicMisbehavior(topic string, m Misbehavior) {
+	c.scorer.setTopicMisbehavior(topic, m)
+}
+
+// PeerScores returns the scores of the connected peers, and of the peers recently penalized or banned.
+func (c *Client) PeerScores() []PeerScore {
+	scores := c.scorer.scores()
+	known := make(map[peer.ID]int, len(scores))
+	for i, score := range scores {
+		known[score.ID] = i
+	}
+	for _, id := range c.host.Network().Peers() {
+		i, ok := known[id]
+		if !ok {
+			i = len(scores)
+			known[id] = i
+			scores = append(scores, PeerScore{ID: id})
+		}
+		scores[i].Connected = true
+	}
+	for _, id := range c.gater.ListBlockedPeers() {
+		i, ok := known[id]
+		if !ok {
+			i = len(scores)
+			known[id] = i
+			scores = append(scores, PeerScore{ID: id})
+		}
+		scores[i].Banned = true
+		scores[i].BannedUntil = time.Time{}
+	}
+	return scores
+}
+
+// BanPeer disconnects the peer and bans it until it is unbanned. The ban is persisted.
+func (c *Client) BanPeer(id peer.ID) error {
+	if id == c.host.ID() {
+		return fmt.Errorf("cannot ban self")
+	}
+	if err := c.gater.BlockPeer(id); err != nil {
+		return fmt.Errorf("failed to ban peer: %w", err)
+	}
+	c.logger.Info("banned peer", "peer", id)
+	c.disconnect(id)
+	return nil
+}
+
+// UnbanPeer lifts the ban of the peer, whether it was banned for a low score or through BanPeer, and resets its score.
+func (c *Client) UnbanPeer(id peer.ID) error {
+	if err := c.gater.UnblockPeer(id); err != nil {
+		return fmt.Errorf("failed to unban peer: %w", err)
+	}
+	c.scorer.reset(id)
+	c.logger.Info("unbanned peer", "peer", id)
+	return nil
+}
+
+// SetMaxPeers changes the maximum number of connected peers, 0 for no limit. If more peers are connected,
+// the peers with the lowest score are disconnected. The limit of a shared host is only set by the client owning
+// the host, the clients of hosted chains ignore it.
+func (c *Client) SetMaxPeers(maxPeers uint64) {
+	if c.parent != nil {
+		return
+	}
+	c.maxPeers.Store(maxPeers)
+	if maxPeers == 0 || c.host == nil {
+		return
+	}
+
+	var connected []PeerScore
+	for _, score := range c.PeerScores() {
+		if score.Connected {
+			connected = append(connected, score)
+		}
+	}
+	if uint64(len(connected)) <= maxPeers {
+		return
+	}
+	sort.SliceStable(connected, func(i, j int) bool { return connected[i].Score < connected[j].Score })
+	for _, score := range connected[:uint64(len(connected))-maxPeers] {
+		c.logger.Info("disconnecting peer above the peer limit", "peer", score.ID, "score", score.Score, "maxPeers", maxPeers)
+		if err := c.host.Network().ClosePeer(score.ID); err != nil {
+			c.logger.Error("failed to disconnect peer", "peer", score.ID, "error", err)
+		}
+	}
+}
+
+// peerLimitReached reports whether a connection with a new peer would exceed the maximum number of peers.
+func (c *Client) peerLimitReached(id peer.ID) bool {
+	maxPeers := c.maxPeers.Load()
+	if maxPeers == 0 || c.host == nil || c.host.Network().Connectedness(id) == network.Connected {
+		return false
+	}
+	return uint64(len(c.host.Network().Peers())) >= maxPeers
+}
+
+// disconnect closes the connections with the peer in the background, as it may be called from the pubsub event loop.
+func (c *Client) disconnect(id peer.ID) {
+	go func() {
+		if err := c.host.Network().ClosePeer(id); err != nil {
+			c.logger.Error("failed to disconnect peer", "peer", id, "error", err)
+		}
+	}()
+}
+
+// scoringLoop penalizes slow peers and starts new scoring intervals until the context is canceled.
+func (c *Client) scoringLoop(ctx context.Context) {
+	ticker := time.NewTicker(scoringInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, id := range c.scorer.tick(c.host.Network().Peers(), c.host.Peerstore().LatencyEWMA) {
+			c.logger.Info("banning peer with low score", "peer", id, "misbehavior", SlowResponse, "duration", c.conf.BanDuration.Duration)
+			c.disconnect(id)
+		}
+	}
+}
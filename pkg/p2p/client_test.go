@@ -11,6 +11,7 @@ import (
 	"github.com/ipfs/go-datastore"
 	dssync "github.com/ipfs/go-datastore/sync"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/pnet"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -187,3 +188,94 @@ func ClientInitFiles(t *testing.T, tempDir string) {
 	}
 
 }
+
+func TestMaxPeers(t *testing.T) {
+	assert := assert.New(t)
+	logger := log.NewTestLogger(t)
+
+	clients := startTestNetwork(t.Context(), t, 3, map[int]hostDescr{
+		0: {conns: []int{1, 2}},
+	}, logger)
+	require.Eventually(t, func() bool {
+		return len(clients[0].host.Network().Peers()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.False(clients[0].peerLimitReached(clients[1].host.ID()))
+
+	// the peer with the lowest score is disconnected when the limit is lowered
+	clients[0].scorer.mu.Lock()
+	clients[0].scorer.threshold = config.DefaultConfig.P2P.BanThreshold
+	clients[0].scorer.mu.Unlock()
+	clients[0].ReportPeer(clients[2].host.ID(), InvalidHeader)
+	assert.Len(clients[0].host.Network().Peers(), 2)
+	clients[0].SetMaxPeers(1)
+	assert.Equal([]peer.ID{clients[1].host.ID()}, clients[0].host.Network().Peers())
+
+	// connections with new peers are rejected, but not additional connections with connected peers
+	assert.True(clients[0].peerLimitReached(clients[2].host.ID()))
+	assert.False(clients[0].peerLimitReached(clients[1].host.ID()))
+
+	clients[0].SetMaxPeers(0)
+	assert.False(clients[0].peerLimitReached(clients[2].host.ID()))
+}
+
+// newTestClient creates a client listening on a random local port.
+func newTestClient(t *testing.T, p2pConf config.P2PConfig, psk pnet.PSK) *Client {
+	t.Helper()
+	nodeKey, err := key.GenerateNodeKey()
+	require.NoError(t, err)
+	nodeKey.PSK = psk
+	p2pConf.ListenAddress = "/ip4/127.0.0.1/tcp/0"
+	client, err := NewClient(config.Config{RootDir: t.TempDir(), ChainID: "TestChain", P2P: p2pConf}, nodeKey,
+		dssync.MutexWrap(datastore.NewMapDatastore()), log.NewTestLogger(t), NopMetrics())
+	require.NoError(t, err)
+	client.host, err = client.listen()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.host.Close() })
+	return client
+}
+
+func connectTestClients(ctx context.Context, from, to *Client) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	return from.host.Connect(ctx, peer.AddrInfo{ID: to.host.ID(), Addrs: to.host.Addrs()})
+}
+
+func TestPrivateNetwork(t *testing.T) {
+	ctx := t.Context()
+	psk := pnet.PSK(make([]byte, 32))
+	otherPSK := pnet.PSK(append(make([]byte, 31), 1))
+
+	member1 := newTestClient(t, config.P2PConfig{}, psk)
+	member2 := newTestClient(t, config.P2PConfig{}, psk)
+	outsider := newTestClient(t, config.P2PConfig{}, otherPSK)
+	public := newTestClient(t, config.P2PConfig{}, nil)
+
+	require.NoError(t, connectTestClients(ctx, member1, member2))
+	assert.Error(t, connectTestClients(ctx, outsider, member1))
+	assert.Error(t, connectTestClients(ctx, public, member1))
+}
+
+func TestAllowlistOnly(t *testing.T) {
+	ctx := t.Context()
+	allowed := newTestClient(t, config.P2PConfig{}, nil)
+	other := newTestClient(t, config.P2PConfig{}, nil)
+	gated := newTestClient(t, config.P2PConfig{AllowlistOnly: true, AllowedPeers: allowed.host.ID().String()}, nil)
+
+	assert.True(t, gated.peerAllowed(allowed.host.ID()))
+	assert.False(t, gated.peerAllowed(other.host.ID()))
+	require.NoError(t, connectTestClients(ctx, allowed, gated))
+	assert.Error(t, connectTestClients(ctx, gated, other))
+	// inbound connections are closed by the gated peer once secured
+	_ = connectTestClients(ctx, other, gated)
+	assert.Eventually(t, func() bool {
+		return len(other.host.Network().ConnsToPeer(gated.host.ID())) == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Empty(t, gated.host.Network().ConnsToPeer(other.host.ID()))
+
+	// the allowlist must not be empty
+	nodeKey, err := key.GenerateNodeKey()
+	require.NoError(t, err)
+	_, err = NewClient(config.Config{RootDir: t.TempDir(), P2P: config.P2PConfig{AllowlistOnly: true}}, nodeKey,
+		dssync.MutexWrap(datastore.NewMapDatastore()), log.NewTestLogger(t), NopMetrics())
+	assert.Error(t, err)
+}
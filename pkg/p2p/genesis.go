@@ -0,0 +1,110 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+const (
+	// genesisHandshakeTimeout is the deadline of the genesis handshake with a peer.
+	genesisHandshakeTimeout = 10 * time.Second
+
+	// maxFingerprintSize is the maximum size of the genesis fingerprint read from a peer.
+	maxFingerprintSize = 64
+)
+
+// genesisProtocolID returns the ID of the protocol on which nodes of the chain send their genesis fingerprint.
+func genesisProtocolID(chainID string) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/%s/genesis/v0.0.1", chainID))
+}
+
+// SetGenesisFingerprint sets the fingerprint of the genesis of the chain, exchanged with every connected peer:
+// peers with a different genesis, e.g. nodes of another testnet sharing the chain ID, are disconnected and banned.
+// It must be called before Start.
+func (c *Client) SetGenesisFingerprint(fingerprint []byte) {
+	c.genesisFingerprint = fingerprint
+}
+
+// setupGenesisHandshake serves the genesis fingerprint of the node, and checks the genesis fingerprint of every
+// peer once identified, until the context is canceled. Peers not supporting the protocol are not checked.
+func (c *Client) setupGenesisHandshake(ctx context.Context) error {
+	if c.genesisFingerprint == nil {
+		return nil
+	}
+	protocolID := genesisProtocolID(c.chainID)
+	c.host.SetStreamHandler(protocolID, c.handleGenesisStream)
+	sub, err := c.host.EventBus().Subscribe(new(event.EvtPeerIdentificationCompleted))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to peer identification: %w", err)
+	}
+	go func() {
+		defer sub.Close() //nolint:errcheck // closing a subscription does not fail
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-sub.Out():
+				if !ok {
+					return
+				}
+				evt := e.(event.EvtPeerIdentificationCompleted)
+				if !slices.Contains(evt.Protocols, protocolID) {
+					continue
+				}
+				go c.checkGenesis(ctx, evt.Peer)
+			}
+		}
+	}()
+	return nil
+}
+
+// handleGenesisStream sends the genesis fingerprint of the node to the peer.
+func (c *Client) handleGenesisStream(s network.Stream) {
+	defer s.Close() //nolint:errcheck // the fingerprint was sent or the peer is gone
+	_ = s.SetWriteDeadline(time.Now().Add(genesisHandshakeTimeout))
+	if _, err := s.Write(c.genesisFingerprint); err != nil {
+		c.logger.Debug("failed to send genesis fingerprint", "peer", s.Conn().RemotePeer(), "error", err)
+	}
+}
+
+// checkGenesis requests the genesis fingerprint of the peer, and disconnects and bans the peer if it differs from
+// the fingerprint of the node.
+func (c *Client) checkGenesis(ctx context.Context, id peer.ID) {
+	fingerprint, err := c.requestGenesisFingerprint(ctx, id)
+	if err != nil {
+		c.logger.Debug("genesis handshake failed", "peer", id, "error", err)
+		return
+	}
+	if bytes.Equal(fingerprint, c.genesisFingerprint) {
+		return
+	}
+	c.logger.Info("disconnecting peer with a different genesis", "peer", id,
+		"fingerprint", fmt.Sprintf("%X", fingerprint), "expected", fmt.Sprintf("%X", c.genesisFingerprint))
+	c.metrics.GenesisMismatches.Add(1)
+	c.ReportPeer(id, GenesisMismatch)
+	c.disconnect(id)
+}
+
+// requestGenesisFingerprint reads the genesis fingerprint of the peer.
+func (c *Client) requestGenesisFingerprint(ctx context.Context, id peer.ID) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, genesisHandshakeTimeout)
+	defer cancel()
+	s, err := c.host.NewStream(ctx, id, genesisProtocolID(c.chainID))
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close() //nolint:errcheck // the stream is only read
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.SetReadDeadline(deadline)
+	}
+	return io.ReadAll(io.LimitReader(s, maxFingerprintSize))
+}
@@ -0,0 +1,46 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/config"
+)
+
+func TestGenesisHandshake(t *testing.T) {
+	ctx := t.Context()
+	newClient := func(fingerprint []byte) *Client {
+		c := newTestClient(t, config.DefaultConfig.P2P, nil)
+		c.SetGenesisFingerprint(fingerprint)
+		require.NoError(t, c.setupGenesisHandshake(ctx))
+		return c
+	}
+	node := newClient([]byte("genesis"))
+	same := newClient([]byte("genesis"))
+	other := newClient([]byte("other genesis"))
+	legacy := newClient(nil)
+
+	require.NoError(t, connectTestClients(ctx, same, node))
+	require.NoError(t, connectTestClients(ctx, other, node))
+	require.NoError(t, connectTestClients(ctx, legacy, node))
+
+	// the peer with a different genesis is disconnected and banned, by the end of the connection detecting the
+	// mismatch first
+	assert.Eventually(t, func() bool {
+		return node.host.Network().Connectedness(other.host.ID()) != network.Connected
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return node.scorer.isBanned(other.host.ID()) || other.scorer.isBanned(node.host.ID())
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Error(t, connectTestClients(ctx, other, node))
+
+	// peers with the same genesis, and peers not supporting the handshake, stay connected
+	assert.Equal(t, network.Connected, node.host.Network().Connectedness(same.host.ID()))
+	assert.Equal(t, network.Connected, node.host.Network().Connectedness(legacy.host.ID()))
+	assert.False(t, node.scorer.isBanned(same.host.ID()))
+	assert.False(t, node.scorer.isBanned(legacy.host.ID()))
+}
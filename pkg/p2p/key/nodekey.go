@@ -3,13 +3,16 @@ package key
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/pnet"
 
 	rollhash "github.com/rollkit/rollkit/pkg/hash"
+	"github.com/rollkit/rollkit/pkg/keystore"
 	rollos "github.com/rollkit/rollkit/pkg/os"
 )
 
@@ -17,11 +20,16 @@ const (
 	NodeKeyFileName = "node_key.json"
 )
 
+// ErrNodeKeyEncrypted is returned when loading an encrypted node key without passphrase.
+var ErrNodeKeyEncrypted = errors.New("node key is encrypted, a passphrase is required")
+
 // NodeKey is the persistent peer key.
-// It contains the nodes private key for authentication.
+// It contains the nodes private key for authentication, and the preshared key of the private network the node
+// belongs to, if any.
 type NodeKey struct {
 	PrivKey crypto.PrivKey // our priv key
 	PubKey  crypto.PubKey  // our pub key
+	PSK     pnet.PSK       // preshared key of the private network, nil for the public network
 }
 
 type nodeKeyJSON struct {
@@ -99,6 +107,18 @@ func (nodeKey *NodeKey) SaveAs(dirPath string) error {
 	return nil
 }
 
+// SaveEncrypted persists the NodeKey to dirPath, encrypted with the passphrase.
+func (nodeKey *NodeKey) SaveEncrypted(dirPath string, passphrase []byte) error {
+	if nodeKey.PrivKey == nil {
+		return fmt.Errorf("nodeKey has nil key(s)")
+	}
+	encrypted, err := keystore.Encrypt(nodeKey.PrivKey, passphrase, keystore.KDFArgon2id)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt node key: %w", err)
+	}
+	return keystore.WriteFile(filepath.Join(dirPath, NodeKeyFileName), encrypted)
+}
+
 // PubKeyToID returns the ID corresponding to the given PubKey.
 // It's the hex-encoding of the pubKey.Address().
 func PubKeyToID(pubKey crypto.PubKey) string {
@@ -128,12 +148,18 @@ func GenerateNodeKey() (*NodeKey, error) {
 
 // LoadOrGenNodeKey attempts to load the NodeKey from the given directory path.
 // If the file node_key.json does not exist in that directory, it generates
-// and saves a new NodeKey there.
+// and saves a new NodeKey there. The preshared key is loaded from swarm.key, see LoadPSK.
 func LoadOrGenNodeKey(dirPath string) (*NodeKey, error) {
+	return LoadOrGenNodeKeyWithPassphrase(dirPath, nil)
+}
+
+// LoadOrGenNodeKeyWithPassphrase is LoadOrGenNodeKey for node keys encrypted with the passphrase. Generated node
+// keys are encrypted if the passphrase is not empty, and stored in plain text otherwise.
+func LoadOrGenNodeKeyWithPassphrase(dirPath string, passphrase []byte) (*NodeKey, error) {
 	fullPath := filepath.Join(dirPath, NodeKeyFileName)
 	if rollos.FileExists(fullPath) {
 		// Pass the directory path, LoadNodeKey will append the filename
-		nodeKey, err := LoadNodeKey(dirPath)
+		nodeKey, err := LoadNodeKeyWithPassphrase(dirPath, passphrase)
 		if err != nil {
 			return nil, fmt.Errorf("failed to load node key from %s: %w", fullPath, err)
 		}
@@ -145,24 +171,81 @@ func LoadOrGenNodeKey(dirPath string) (*NodeKey, error) {
 	}
 
 	// Save uses the constructed full path
-	if err := nodeKey.SaveAs(dirPath); err != nil {
+	if len(passphrase) > 0 {
+		err = nodeKey.SaveEncrypted(dirPath, passphrase)
+	} else {
+		err = nodeKey.SaveAs(dirPath)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to save node key to %s: %w", fullPath, err)
 	}
 
+	if nodeKey.PSK, err = LoadPSK(dirPath); err != nil {
+		return nil, err
+	}
 	return nodeKey, nil
 }
 
-// LoadNodeKey loads NodeKey located in dirPath/node_key.json.
+// LoadNodeKey loads NodeKey located in dirPath/node_key.json, and the preshared key located in dirPath/swarm.key
+// if any. Encrypted node keys are loaded with LoadNodeKeyWithPassphrase.
 func LoadNodeKey(dirPath string) (*NodeKey, error) {
+	return LoadNodeKeyWithPassphrase(dirPath, nil)
+}
+
+// LoadNodeKeyWithPassphrase is LoadNodeKey for node keys encrypted with the passphrase. Legacy plain text node
+// keys are loaded regardless of the passphrase, and can be encrypted with MigrateNodeKey.
+func LoadNodeKeyWithPassphrase(dirPath string, passphrase []byte) (*NodeKey, error) {
+	nodeKey, _, err := loadNodeKey(dirPath, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if nodeKey.PSK, err = LoadPSK(dirPath); err != nil {
+		return nil, err
+	}
+	return nodeKey, nil
+}
+
+// MigrateNodeKey encrypts the legacy plain text node key located in dirPath with the passphrase. It reports
+// whether the node key was migrated, node keys already encrypted are left unchanged.
+func MigrateNodeKey(dirPath string, passphrase []byte) (bool, error) {
+	if len(passphrase) == 0 {
+		return false, errors.New("a passphrase is required to encrypt the node key")
+	}
+	nodeKey, encrypted, err := loadNodeKey(dirPath, passphrase)
+	if err != nil || encrypted {
+		return false, err
+	}
+	if err := nodeKey.SaveEncrypted(dirPath, passphrase); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// loadNodeKey loads the node key located in dirPath/node_key.json, decrypting it with the passphrase if it is
+// encrypted, and reports whether it was.
+func loadNodeKey(dirPath string, passphrase []byte) (*NodeKey, bool, error) {
 	fullPath := filepath.Join(dirPath, NodeKeyFileName)
 	jsonBytes, err := os.ReadFile(fullPath) //nolint:gosec
 	if err != nil {
-		return nil, fmt.Errorf("failed to read node key file %s: %w", fullPath, err)
+		return nil, false, fmt.Errorf("failed to read node key file %s: %w", fullPath, err)
+	}
+	if !keystore.IsEncrypted(jsonBytes) {
+		nodeKey := new(NodeKey)
+		if err := json.Unmarshal(jsonBytes, nodeKey); err != nil {
+			return nil, false, fmt.Errorf("failed to unmarshal node key from %s: %w", fullPath, err)
+		}
+		return nodeKey, false, nil
+	}
+	if len(passphrase) == 0 {
+		return nil, true, fmt.Errorf("%w: %s", ErrNodeKeyEncrypted, fullPath)
+	}
+	encrypted, err := keystore.ReadFile(fullPath)
+	if err != nil {
+		return nil, true, err
 	}
-	nodeKey := new(NodeKey)
-	err = json.Unmarshal(jsonBytes, nodeKey)
+	privKey, err := encrypted.Decrypt(passphrase)
 	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshal node key from %s: %w", fullPath, err)
+		return nil, true, fmt.Errorf("failed to decrypt node key from %s: %w", fullPath, err)
 	}
-	return nodeKey, nil
+	return &NodeKey{PrivKey: privKey, PubKey: privKey.GetPublic()}, true, nil
 }
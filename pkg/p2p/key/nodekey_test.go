@@ -9,6 +9,8 @@ import (
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/keystore"
 )
 
 func TestNodeKey_ID(t *testing.T) {
@@ -198,3 +200,55 @@ func TestLoadOrGenNodeKey(t *testing.T) {
 		})
 	}
 }
+
+func TestEncryptedNodeKey(t *testing.T) {
+	tempDir := t.TempDir()
+	passphrase := []byte("passphrase")
+
+	nodeKey, err := LoadOrGenNodeKeyWithPassphrase(tempDir, passphrase)
+	require.NoError(t, err)
+
+	bz, err := os.ReadFile(filepath.Join(tempDir, NodeKeyFileName))
+	require.NoError(t, err)
+	assert.True(t, keystore.IsEncrypted(bz))
+
+	loaded, err := LoadNodeKeyWithPassphrase(tempDir, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, nodeKey.ID(), loaded.ID())
+	assert.True(t, nodeKey.PrivKey.Equals(loaded.PrivKey))
+
+	_, err = LoadNodeKey(tempDir)
+	assert.ErrorIs(t, err, ErrNodeKeyEncrypted)
+	_, err = LoadNodeKeyWithPassphrase(tempDir, []byte("wrong"))
+	assert.ErrorIs(t, err, keystore.ErrWrongPassphrase)
+
+	migrated, err := MigrateNodeKey(tempDir, passphrase)
+	require.NoError(t, err)
+	assert.False(t, migrated)
+}
+
+func TestMigrateNodeKey(t *testing.T) {
+	tempDir := t.TempDir()
+	passphrase := []byte("passphrase")
+
+	nodeKey, err := LoadOrGenNodeKey(tempDir)
+	require.NoError(t, err)
+
+	// legacy plain text keys are loaded regardless of the passphrase
+	loaded, err := LoadNodeKeyWithPassphrase(tempDir, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, nodeKey.ID(), loaded.ID())
+
+	_, err = MigrateNodeKey(tempDir, nil)
+	assert.Error(t, err)
+
+	migrated, err := MigrateNodeKey(tempDir, passphrase)
+	require.NoError(t, err)
+	assert.True(t, migrated)
+
+	_, err = LoadNodeKey(tempDir)
+	assert.ErrorIs(t, err, ErrNodeKeyEncrypted)
+	loaded, err = LoadNodeKeyWithPassphrase(tempDir, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, nodeKey.ID(), loaded.ID())
+}
@@ -0,0 +1,38 @@
+package key
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/libp2p/go-libp2p/core/pnet"
+
+	rollos "github.com/rollkit/rollkit/pkg/os"
+)
+
+// PSKFileName is the name of the file holding the preshared key of a private network, in the swarm key format
+// of libp2p:
+//
+//	/key/swarm/psk/1.0.0/
+//	/base16/
+//	<64 hex characters>
+const PSKFileName = "swarm.key"
+
+// LoadPSK loads the preshared key of the private network from dirPath/swarm.key. It returns a nil key if the file
+// does not exist, in which case the node joins the public network.
+func LoadPSK(dirPath string) (pnet.PSK, error) {
+	fullPath := filepath.Join(dirPath, PSKFileName)
+	if !rollos.FileExists(fullPath) {
+		return nil, nil
+	}
+	bz, err := os.ReadFile(fullPath) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read preshared key file %s: %w", fullPath, err)
+	}
+	psk, err := pnet.DecodeV1PSK(bytes.NewReader(bz))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode preshared key from %s: %w", fullPath, err)
+	}
+	return psk, nil
+}
@@ -0,0 +1,35 @@
+package key
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadPSK(t *testing.T) {
+	dir := t.TempDir()
+	_, err := LoadOrGenNodeKey(dir)
+	require.NoError(t, err)
+
+	// without swarm key, the node joins the public network
+	nodeKey, err := LoadNodeKey(dir)
+	require.NoError(t, err)
+	assert.Nil(t, nodeKey.PSK)
+
+	swarmKey := "/key/swarm/psk/1.0.0/\n/base16/\n" + strings.Repeat("ab", 32) + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, PSKFileName), []byte(swarmKey), 0o600))
+	nodeKey, err = LoadNodeKey(dir)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(strings.Repeat("\xab", 32)), []byte(nodeKey.PSK))
+	nodeKey, err = LoadOrGenNodeKey(dir)
+	require.NoError(t, err)
+	assert.Len(t, nodeKey.PSK, 32)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, PSKFileName), []byte("not a swarm key"), 0o600))
+	_, err = LoadNodeKey(dir)
+	assert.Error(t, err)
+}
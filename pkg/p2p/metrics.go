@@ -18,17 +18,31 @@ type Metrics struct {
 	// Number of peers.
 	Peers metrics.Gauge
 	// Number of bytes received from a given peer.
-	PeerReceiveBytesTotal metrics.Counter `metrics_labels:"peer_id,chID"`
+	PeerReceiveBytesTotal metrics.Counter `metrics_labels:"peer_id"`
 	// Number of bytes sent to a given peer.
-	PeerSendBytesTotal metrics.Counter `metrics_labels:"peer_id,chID"`
+	PeerSendBytesTotal metrics.Counter `metrics_labels:"peer_id"`
 	// Pending bytes to be sent to a given peer.
 	PeerPendingSendBytes metrics.Gauge `metrics_labels:"peer_id"`
 	// Number of transactions submitted by each peer.
 	NumTxs metrics.Gauge `metrics_labels:"peer_id"`
-	// Number of bytes of each message type received.
+	// Number of bytes of each message type received, the message type being the gossip topic.
 	MessageReceiveBytesTotal metrics.Counter `metrics_labels:"message_type"`
-	// Number of bytes of each message type sent.
+	// Number of bytes of each message type sent, the message type being the gossip topic.
 	MessageSendBytesTotal metrics.Counter `metrics_labels:"message_type"`
+	// Number of gossip messages received on each topic, including duplicates.
+	TopicMessagesReceivedTotal metrics.Counter `metrics_labels:"topic"`
+	// Number of gossip messages sent on each topic, once for every peer they are sent to.
+	TopicMessagesSentTotal metrics.Counter `metrics_labels:"topic"`
+	// Number of duplicate gossip messages received on each topic.
+	TopicDuplicateMessagesTotal metrics.Counter `metrics_labels:"topic"`
+	// Number of gossip messages received on each topic rejected by the topic validator.
+	TopicRejectedMessagesTotal metrics.Counter `metrics_labels:"topic"`
+	// Number of gossip messages received on each topic ignored for exceeding the rate limit of the topic.
+	TopicRateLimitedMessagesTotal metrics.Counter `metrics_labels:"topic"`
+	// Number of peers in the gossip mesh of each topic.
+	TopicMeshPeers metrics.Gauge `metrics_labels:"topic"`
+	// Number of peers disconnected for having a different genesis.
+	GenesisMismatches metrics.Counter
 }
 
 // PrometheusMetrics returns Metrics build using Prometheus client library.
@@ -51,13 +65,13 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Subsystem: MetricsSubsystem,
 			Name:      "peer_receive_bytes_total",
 			Help:      "Number of bytes received from a given peer.",
-		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		}, append(labels, "peer_id")).With(labelsAndValues...),
 		PeerSendBytesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
 			Name:      "peer_send_bytes_total",
 			Help:      "Number of bytes sent to a given peer.",
-		}, append(labels, "peer_id", "chID")).With(labelsAndValues...),
+		}, append(labels, "peer_id")).With(labelsAndValues...),
 		PeerPendingSendBytes: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -82,6 +96,48 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "message_send_bytes_total",
 			Help:      "Number of bytes of each message type sent.",
 		}, append(labels, "message_type")).With(labelsAndValues...),
+		TopicMessagesReceivedTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "topic_messages_received_total",
+			Help:      "Number of gossip messages received on each topic, including duplicates.",
+		}, append(labels, "topic")).With(labelsAndValues...),
+		TopicMessagesSentTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "topic_messages_sent_total",
+			Help:      "Number of gossip messages sent on each topic, once for every peer they are sent to.",
+		}, append(labels, "topic")).With(labelsAndValues...),
+		TopicDuplicateMessagesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "topic_duplicate_messages_total",
+			Help:      "Number of duplicate gossip messages received on each topic.",
+		}, append(labels, "topic")).With(labelsAndValues...),
+		TopicRejectedMessagesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "topic_rejected_messages_total",
+			Help:      "Number of gossip messages received on each topic rejected by the topic validator.",
+		}, append(labels, "topic")).With(labelsAndValues...),
+		TopicRateLimitedMessagesTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "topic_rate_limited_messages_total",
+			Help:      "Number of gossip messages received on each topic ignored for exceeding the rate limit of the topic.",
+		}, append(labels, "topic")).With(labelsAndValues...),
+		TopicMeshPeers: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "topic_mesh_peers",
+			Help:      "Number of peers in the gossip mesh of each topic.",
+		}, append(labels, "topic")).With(labelsAndValues...),
+		GenesisMismatches: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "genesis_mismatches",
+			Help:      "Number of peers disconnected for having a different genesis.",
+		}, labels).With(labelsAndValues...),
 	}
 }
 
@@ -95,5 +151,13 @@ func NopMetrics() *Metrics {
 		NumTxs:                   discard.NewGauge(),
 		MessageReceiveBytesTotal: discard.NewCounter(),
 		MessageSendBytesTotal:    discard.NewCounter(),
+
+		TopicMessagesReceivedTotal:    discard.NewCounter(),
+		TopicMessagesSentTotal:        discard.NewCounter(),
+		TopicDuplicateMessagesTotal:   discard.NewCounter(),
+		TopicRejectedMessagesTotal:    discard.NewCounter(),
+		TopicRateLimitedMessagesTotal: discard.NewCounter(),
+		TopicMeshPeers:                discard.NewGauge(),
+		GenesisMismatches:             discard.NewCounter(),
 	}
 }
@@ -0,0 +1,214 @@
+package p2p
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/query"
+	"github.com/libp2p/go-libp2p/core/event"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/libp2p/go-libp2p/core/record"
+)
+
+const (
+	// peerBookPrefix is the datastore prefix of the signed peer records of good peers
+	peerBookPrefix = "/p2p/peerbook"
+
+	// savedPeerTTL is the time after which a saved peer which was not connected since is forgotten
+	savedPeerTTL = 7 * 24 * time.Hour
+
+	// maxSavedPeers is the maximum number of saved peers, the peers connected least recently are forgotten first
+	maxSavedPeers = peerLimit
+
+	// minSavedPeerScore is the score below which connected peers are not saved, and saved peers are forgotten
+	minSavedPeerScore = -10
+)
+
+// savedPeer is a peer saved in the peer book.
+type savedPeer struct {
+	// Record is the signed peer record of the peer, holding its addresses
+	Record []byte `json:"record"`
+	// LastSeen is the last time the peer was connected
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// watchPeerRecords adds the signed peer records received from peers through the identify protocol to the
+// peerstore, until the context is canceled. They are saved with the good peers, and sent to other peers by
+// GossipSub peer exchange.
+func (c *Client) watchPeerRecords(ctx context.Context) error {
+	cab, ok := peerstore.GetCertifiedAddrBook(c.host.Peerstore())
+	if !ok {
+		return nil
+	}
+	sub, err := c.host.EventBus().Subscribe(new(event.EvtPeerIdentificationCompleted))
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to peer identification: %w", err)
+	}
+	go func() {
+		defer sub.Close() //nolint:errcheck // closing a subscription does not fail
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case e, ok := <-sub.Out():
+				if !ok {
+					return
+				}
+				evt := e.(event.EvtPeerIdentificationCompleted)
+				if evt.SignedPeerRecord == nil {
+					continue
+				}
+				if _, err := cab.ConsumePeerRecord(evt.SignedPeerRecord, peerstore.ConnectedAddrTTL); err != nil {
+					c.logger.Debug("invalid signed peer record", "peer", evt.Peer, "error", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// savePeers saves the signed peer records of the connected peers with a good score, so that they are connected
+// on restart without going through the seed nodes. Only peer records signed by the peers themselves are
+// saved, so that addresses of peers cannot be forged by other peers.
+func (c *Client) savePeers(ctx context.Context) error {
+	cab, ok := peerstore.GetCertifiedAddrBook(c.host.Peerstore())
+	if !ok {
+		return nil
+	}
+	scores := make(map[peer.ID]PeerScore)
+	for _, score := range c.scorer.scores() {
+		scores[score.ID] = score
+	}
+	good := func(id peer.ID) bool {
+		score, ok := scores[id]
+		return !ok || (!score.Banned && score.Score >= minSavedPeerScore)
+	}
+
+	now := time.Now()
+	for _, id := range c.host.Network().Peers() {
+		env := cab.GetPeerRecord(id)
+		if env == nil || !good(id) {
+			continue
+		}
+		rec, err := env.Marshal()
+		if err != nil {
+			return fmt.Errorf("failed to marshal peer record of %s: %w", id, err)
+		}
+		value, err := json.Marshal(savedPeer{Record: rec, LastSeen: now})
+		if err != nil {
+			return err
+		}
+		if err := c.ds.Put(ctx, peerBookKey(id), value); err != nil {
+			return fmt.Errorf("failed to save peer %s: %w", id, err)
+		}
+	}
+
+	// forget the peers which were not seen for long or misbehaved, and the oldest peers above the limit
+	saved, err := c.savedPeers(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(saved, func(i, j int) bool { return saved[i].lastSeen.After(saved[j].lastSeen) })
+	for i, p := range saved {
+		if i < maxSavedPeers && now.Sub(p.lastSeen) < savedPeerTTL && good(p.ID) {
+			continue
+		}
+		if err := c.ds.Delete(ctx, peerBookKey(p.ID)); err != nil {
+			return fmt.Errorf("failed to forget peer %s: %w", p.ID, err)
+		}
+	}
+	return nil
+}
+
+// loadPeers returns the saved peers, whose signed addresses are added to the peerstore. Peers with an invalid
+// signed record are forgotten.
+func (c *Client) loadPeers(ctx context.Context) ([]peer.AddrInfo, error) {
+	saved, err := c.savedPeers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cab, _ := peerstore.GetCertifiedAddrBook(c.host.Peerstore())
+	peers := make([]peer.AddrInfo, 0, len(saved))
+	for _, p := range saved {
+		if time.Since(p.lastSeen) >= savedPeerTTL || p.ID == c.host.ID() {
+			continue
+		}
+		if cab != nil {
+			if _, err := cab.ConsumePeerRecord(p.envelope, peerstore.AddressTTL); err != nil {
+				c.logger.Info("failed to add addresses of saved peer", "peer", p.ID, "error", err)
+				continue
+			}
+		}
+		peers = append(peers, p.AddrInfo)
+	}
+	return peers, nil
+}
+
+// savedAddrInfo is a saved peer with a verified peer record.
+type savedAddrInfo struct {
+	peer.AddrInfo
+	envelope *record.Envelope
+	lastSeen time.Time
+}
+
+// savedPeers returns the peers of the peer book with a valid signed record, forgetting the others.
+func (c *Client) savedPeers(ctx context.Context) ([]savedAddrInfo, error) {
+	results, err := c.ds.Query(ctx, query.Query{Prefix: peerBookPrefix})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved peers: %w", err)
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read saved peers: %w", err)
+	}
+
+	var peers []savedAddrInfo
+	for _, entry := range entries {
+		p, err := c.parseSavedPeer(entry)
+		if err != nil {
+			c.logger.Info("forgetting invalid saved peer", "key", entry.Key, "error", err)
+			if err := c.ds.Delete(ctx, datastore.NewKey(entry.Key)); err != nil {
+				return nil, fmt.Errorf("failed to forget peer: %w", err)
+			}
+			continue
+		}
+		peers = append(peers, p)
+	}
+	return peers, nil
+}
+
+// parseSavedPeer verifies the signed peer record of a peer book entry.
+func (c *Client) parseSavedPeer(entry query.Entry) (savedAddrInfo, error) {
+	var saved savedPeer
+	if err := json.Unmarshal(entry.Value, &saved); err != nil {
+		return savedAddrInfo{}, err
+	}
+	env, rec, err := record.ConsumeEnvelope(saved.Record, peer.PeerRecordEnvelopeDomain)
+	if err != nil {
+		return savedAddrInfo{}, err
+	}
+	peerRec, ok := rec.(*peer.PeerRecord)
+	if !ok {
+		return savedAddrInfo{}, fmt.Errorf("unexpected record type %T", rec)
+	}
+	if datastore.NewKey(entry.Key) != peerBookKey(peerRec.PeerID) {
+		return savedAddrInfo{}, fmt.Errorf("record of peer %s saved as another peer", peerRec.PeerID)
+	}
+	if !peerRec.PeerID.MatchesPublicKey(env.PublicKey) {
+		return savedAddrInfo{}, fmt.Errorf("record of peer %s not signed by the peer", peerRec.PeerID)
+	}
+	return savedAddrInfo{
+		AddrInfo: peer.AddrInfo{ID: peerRec.PeerID, Addrs: peerRec.Addrs},
+		envelope: env,
+		lastSeen: saved.LastSeen,
+	}, nil
+}
+
+func peerBookKey(id peer.ID) datastore.Key {
+	return datastore.NewKey(peerBookPrefix).ChildString(id.String())
+}
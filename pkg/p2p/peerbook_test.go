@@ -0,0 +1,72 @@
+package p2p
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peerstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/config"
+)
+
+func TestPeerBook(t *testing.T) {
+	ctx := t.Context()
+	client := newTestClient(t, config.P2PConfig{BanThreshold: -100}, nil)
+	require.NoError(t, client.watchPeerRecords(ctx))
+	good := newTestClient(t, config.P2PConfig{}, nil)
+	bad := newTestClient(t, config.P2PConfig{}, nil)
+	require.NoError(t, connectTestClients(ctx, client, good))
+	require.NoError(t, connectTestClients(ctx, client, bad))
+	client.ReportPeer(bad.host.ID(), InvalidHeader)
+
+	// signed peer records are received through the identify protocol
+	cab, ok := peerstore.GetCertifiedAddrBook(client.host.Peerstore())
+	require.True(t, ok)
+	require.Eventually(t, func() bool {
+		return cab.GetPeerRecord(good.host.ID()) != nil && cab.GetPeerRecord(bad.host.ID()) != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, client.savePeers(ctx))
+
+	// a restarted node loads the good peers with their signed addresses
+	restarted := newTestClient(t, config.P2PConfig{}, nil)
+	require.NoError(t, restarted.watchPeerRecords(ctx))
+	restarted.ds = client.ds
+	peers, err := restarted.loadPeers(ctx)
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	assert.Equal(t, good.host.ID(), peers[0].ID)
+	assert.ElementsMatch(t, good.host.Addrs(), peers[0].Addrs)
+	assert.ElementsMatch(t, good.host.Addrs(), restarted.host.Peerstore().Addrs(good.host.ID()))
+	require.NoError(t, connectTestClients(ctx, restarted, good))
+
+	// records saved as another peer are forgotten
+	value, err := client.ds.Get(ctx, peerBookKey(good.host.ID()))
+	require.NoError(t, err)
+	require.NoError(t, client.ds.Put(ctx, peerBookKey(bad.host.ID()), value))
+	peers, err = restarted.loadPeers(ctx)
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	_, err = client.ds.Get(ctx, peerBookKey(bad.host.ID()))
+	assert.Error(t, err)
+
+	// peers not seen for long are forgotten
+	var saved savedPeer
+	require.NoError(t, json.Unmarshal(value, &saved))
+	saved.LastSeen = time.Now().Add(-savedPeerTTL)
+	value, err = json.Marshal(saved)
+	require.NoError(t, err)
+	require.NoError(t, client.ds.Put(ctx, peerBookKey(good.host.ID()), value))
+	peers, err = restarted.loadPeers(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, peers)
+	require.NoError(t, restarted.savePeers(ctx))
+	saved = savedPeer{}
+	value, err = client.ds.Get(ctx, peerBookKey(good.host.ID()))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(value, &saved))
+	// the restarted node is connected to the peer, which is saved again
+	assert.WithinDuration(t, time.Now(), saved.LastSeen, time.Minute)
+}
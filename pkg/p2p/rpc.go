@@ -8,6 +8,14 @@ type P2PRPC interface {
 	GetPeers() ([]peer.AddrInfo, error)
 	// GetNetworkInfo returns network information
 	GetNetworkInfo() (NetworkInfo, error)
+	// PeerScores returns the scores of known peers
+	PeerScores() []PeerScore
+	// BanPeer bans a peer until it is unbanned
+	BanPeer(id peer.ID) error
+	// UnbanPeer lifts the ban of a peer
+	UnbanPeer(id peer.ID) error
+	// NetworkStats returns the traffic with peers and the gossip traffic and mesh of every topic
+	NetworkStats() NetworkStats
 }
 
 // NetworkInfo represents network information
@@ -0,0 +1,327 @@
+package p2p
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-libp2p/p2p/net/conngater"
+)
+
+const (
+	// scoreHalfLife is the time after which half of the penalties of a peer are forgiven.
+	scoreHalfLife = 10 * time.Minute
+
+	// scoringInterval is the interval at which message rates and latencies of peers are checked.
+	scoringInterval = 30 * time.Second
+
+	// maxMessagesPerInterval is the number of gossip messages a peer may send per scoring interval
+	// before it is penalized for excessive requests.
+	maxMessagesPerInterval = 1000
+
+	// slowResponseLatency is the average round trip time above which a peer is penalized for slow responses.
+	slowResponseLatency = 2 * time.Second
+
+	// genesisMismatchPenalty is the penalty of peers with a different genesis, below any sensible ban threshold.
+	genesisMismatchPenalty = 1e6
+)
+
+// Misbehavior is a kind of peer misbehavior lowering the score of the peer.
+type Misbehavior int
+
+const (
+	// InvalidHeader is reported when a peer gossips a header failing validation.
+	InvalidHeader Misbehavior = iota
+	// InvalidData is reported when a peer gossips block data failing validation.
+	InvalidData
+	// SlowResponse is reported when a peer responds slowly.
+	SlowResponse
+	// ExcessiveRequests is reported when a peer sends more messages than it is allowed to.
+	ExcessiveRequests
+	// InvalidFraudProof is reported when a peer gossips a fraud proof failing verification.
+	InvalidFraudProof
+	// InvalidTx is reported when a peer gossips a transaction rejected by the executor.
+	InvalidTx
+	// InvalidAppMessage is reported when a peer gossips a message rejected by the validator of an application topic.
+	InvalidAppMessage
+	// GenesisMismatch is reported when the genesis fingerprint of a peer differs from the one of the node.
+	GenesisMismatch
+	// InvalidAttestation is reported when a peer gossips a block attestation which is malformed or not signed by
+	// an attester.
+	InvalidAttestation
+)
+
+// penalty returns the score a peer loses for the misbehavior.
+func (m Misbehavior) penalty() float64 {
+	switch m {
+	case GenesisMismatch:
+		// peers of another chain are banned right away
+		return genesisMismatchPenalty
+	case InvalidHeader, InvalidData, InvalidFraudProof, InvalidAttestation:
+		return 50
+	case ExcessiveRequests:
+		return 20
+	case InvalidTx, InvalidAppMessage:
+		// transactions may be valid against the state of the sender and become invalid by the time they arrive
+		return 10
+	default:
+		return 5
+	}
+}
+
+func (m Misbehavior) String() string {
+	switch m {
+	case InvalidHeader:
+		return "invalid header"
+	case InvalidData:
+		return "invalid data"
+	case SlowResponse:
+		return "slow response"
+	case ExcessiveRequests:
+		return "excessive requests"
+	case InvalidFraudProof:
+		return "invalid fraud proof"
+	case InvalidTx:
+		return "invalid transaction"
+	case InvalidAppMessage:
+		return "invalid application message"
+	case GenesisMismatch:
+		return "genesis mismatch"
+	case InvalidAttestation:
+		return "invalid attestation"
+	default:
+		return "unknown"
+	}
+}
+
+// PeerScore describes the reputation of a peer.
+type PeerScore struct {
+	ID        peer.ID `json:"id"`
+	Score     float64 `json:"score"`
+	Connected bool    `json:"connected"`
+	Banned    bool    `json:"banned"`
+	// BannedUntil is the end of the ban of a peer banned for a low score. It is zero for peers banned until unbanned.
+	BannedUntil time.Time `json:"banned_until"`
+}
+
+type peerState struct {
+	score   float64
+	updated time.Time
+	// messages is the number of gossip messages received in the current scoring interval
+	messages    int
+	bannedUntil time.Time
+}
+
+// peerScorer keeps track of the scores of peers. Peers start with a score of 0 and lose score when they
+// misbehave; penalties decay exponentially, so that peers recover unless they keep misbehaving. Peers whose
+// score falls to the ban threshold are banned for the ban duration.
+type peerScorer struct {
+	threshold   float64
+	banDuration time.Duration
+	now         func() time.Time
+
+	mu     sync.Mutex
+	peers  map[peer.ID]*peerState
+	topics map[string]Misbehavior
+}
+
+func newPeerScorer(threshold float64, banDuration time.Duration) *peerScorer {
+	return &peerScorer{
+		threshold:   threshold,
+		banDuration: banDuration,
+		now:         time.Now,
+		peers:       make(map[peer.ID]*peerState),
+		topics:      make(map[string]Misbehavior),
+	}
+}
+
+// state returns the state of the peer with penalties decayed until now. It must be called with mu held.
+func (s *peerScorer) state(id peer.ID, now time.Time) *peerState {
+	ps, ok := s.peers[id]
+	if !ok {
+		ps = &peerState{updated: now}
+		s.peers[id] = ps
+	}
+	if elapsed := now.Sub(ps.updated); elapsed > 0 {
+		ps.score *= math.Pow(0.5, float64(elapsed)/float64(scoreHalfLife))
+		ps.updated = now
+	}
+	if !ps.bannedUntil.IsZero() && !now.Before(ps.bannedUntil) {
+		// the ban expired, the peer starts over
+		ps.bannedUntil = time.Time{}
+		ps.score = 0
+	}
+	return ps
+}
+
+// report lowers the score of the peer for the misbehavior. It returns true if the peer got banned.
+func (s *peerScorer) report(id peer.ID, m Misbehavior) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	ps := s.state(id, now)
+	ps.score -= m.penalty()
+	if !ps.bannedUntil.IsZero() || ps.score > s.threshold {
+		return false
+	}
+	ps.bannedUntil = now.Add(s.banDuration)
+	return true
+}
+
+// isBanned reports whether the peer is banned for a low score.
+func (s *peerScorer) isBanned(id peer.ID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.peers[id]; !ok {
+		return false
+	}
+	return !s.state(id, s.now()).bannedUntil.IsZero()
+}
+
+// reset forgets the score and ban of the peer.
+func (s *peerScorer) reset(id peer.ID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.peers, id)
+}
+
+// scores returns the scores of the known peers, lowest first.
+func (s *peerScorer) scores() []PeerScore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	scores := make([]PeerScore, 0, len(s.peers))
+	for id := range s.peers {
+		ps := s.state(id, now)
+		scores = append(scores, PeerScore{
+			ID:          id,
+			Score:       ps.score,
+			Banned:      !ps.bannedUntil.IsZero(),
+			BannedUntil: ps.bannedUntil,
+		})
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].Score < scores[j].Score })
+	return scores
+}
+
+// tick starts a new scoring interval. Connected peers with a high latency are penalized, and peers that
+// recovered their score and are not connected anymore are forgotten. It returns the peers that got banned.
+func (s *peerScorer) tick(connected []peer.ID, latency func(peer.ID) time.Duration) []peer.ID {
+	var banned []peer.ID
+	for _, id := range connected {
+		if latency(id) > slowResponseLatency && s.report(id, SlowResponse) {
+			banned = append(banned, id)
+		}
+	}
+
+	isConnected := make(map[peer.ID]bool, len(connected))
+	for _, id := range connected {
+		isConnected[id] = true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	for id := range s.peers {
+		ps := s.state(id, now)
+		ps.messages = 0
+		if !isConnected[id] && ps.bannedUntil.IsZero() && ps.score > -1 {
+			delete(s.peers, id)
+		}
+	}
+	return banned
+}
+
+// setTopicMisbehavior sets the misbehavior reported for peers gossiping messages rejected on the topic.
+func (s *peerScorer) setTopicMisbehavior(topic string, m Misbehavior) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.topics[topic] = m
+}
+
+// countMessage counts a gossip message received from the peer. It returns true if the peer exceeded the
+// number of messages allowed per scoring interval, which is reported once per interval.
+func (s *peerScorer) countMessage(id peer.ID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ps := s.state(id, s.now())
+	ps.messages++
+	return ps.messages == maxMessagesPerInterval+1
+}
+
+// tracer returns a pubsub.RawTracer reporting misbehaving peers through report.
+func (s *peerScorer) tracer(report func(peer.ID, Misbehavior)) pubsub.RawTracer {
+	return &scoreTracer{scorer: s, report: report}
+}
+
+var _ pubsub.RawTracer = (*scoreTracer)(nil)
+
+// scoreTracer reports peers gossiping messages rejected by topic validators, or too many messages.
+type scoreTracer struct {
+	scorer *peerScorer
+	report func(peer.ID, Misbehavior)
+}
+
+func (t *scoreTracer) RejectMessage(msg *pubsub.Message, reason string) {
+	switch reason {
+	case pubsub.RejectValidationFailed, pubsub.RejectInvalidSignature, pubsub.RejectMissingSignature, pubsub.RejectUnexpectedSignature:
+	default:
+		return
+	}
+	t.scorer.mu.Lock()
+	m, ok := t.scorer.topics[msg.GetTopic()]
+	t.scorer.mu.Unlock()
+	if ok {
+		t.report(msg.ReceivedFrom, m)
+	}
+}
+
+func (t *scoreTracer) ValidateMessage(msg *pubsub.Message) {
+	if t.scorer.countMessage(msg.ReceivedFrom) {
+		t.report(msg.ReceivedFrom, ExcessiveRequests)
+	}
+}
+
+func (t *scoreTracer) DuplicateMessage(msg *pubsub.Message) {
+	if t.scorer.countMessage(msg.ReceivedFrom) {
+		t.report(msg.ReceivedFrom, ExcessiveRequests)
+	}
+}
+
+func (t *scoreTracer) ThrottlePeer(p peer.ID) {
+	t.report(p, ExcessiveRequests)
+}
+
+func (t *scoreTracer) AddPeer(peer.ID, protocol.ID)         {}
+func (t *scoreTracer) RemovePeer(peer.ID)                   {}
+func (t *scoreTracer) Join(string)                          {}
+func (t *scoreTracer) Leave(string)                         {}
+func (t *scoreTracer) Graft(peer.ID, string)                {}
+func (t *scoreTracer) Prune(peer.ID, string)                {}
+func (t *scoreTracer) DeliverMessage(*pubsub.Message)       {}
+func (t *scoreTracer) RecvRPC(*pubsub.RPC)                  {}
+func (t *scoreTracer) SendRPC(*pubsub.RPC, peer.ID)         {}
+func (t *scoreTracer) DropRPC(*pubsub.RPC, peer.ID)         {}
+func (t *scoreTracer) UndeliverableMessage(*pubsub.Message) {}
+
+// scoreGater rejects connections with peers banned for a low score, in addition to the peers blocked
+// by the underlying BasicConnectionGater, connections with new peers beyond the peer limit and connections
+// with peers not allowed by the allowlist.
+type scoreGater struct {
+	*conngater.BasicConnectionGater
+	scorer       *peerScorer
+	limitReached func(peer.ID) bool
+	allowed      func(peer.ID) bool
+}
+
+func (g scoreGater) InterceptPeerDial(p peer.ID) bool {
+	return g.allowed(p) && !g.scorer.isBanned(p) && !g.limitReached(p) && g.BasicConnectionGater.InterceptPeerDial(p)
+}
+
+func (g scoreGater) InterceptSecured(dir network.Direction, p peer.ID, addrs network.ConnMultiaddrs) bool {
+	return g.allowed(p) && !g.scorer.isBanned(p) && !g.limitReached(p) && g.BasicConnectionGater.InterceptSecured(dir, p, addrs)
+}
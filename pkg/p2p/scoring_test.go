@@ -0,0 +1,90 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerScorer(t *testing.T) {
+	now := time.Unix(1000, 0)
+	scorer := newPeerScorer(-100, time.Hour)
+	scorer.now = func() time.Time { return now }
+	id := peer.ID("peer")
+
+	// penalties decay with time
+	assert.False(t, scorer.report(id, InvalidHeader))
+	now = now.Add(scoreHalfLife)
+	require.Len(t, scorer.scores(), 1)
+	assert.InDelta(t, -25, scorer.scores()[0].Score, 0.001)
+	assert.False(t, scorer.isBanned(id))
+
+	// the peer is banned once its score falls to the threshold
+	assert.False(t, scorer.report(id, InvalidData))
+	assert.True(t, scorer.report(id, InvalidHeader))
+	assert.True(t, scorer.isBanned(id))
+	assert.False(t, scorer.report(id, InvalidHeader), "a banned peer is only banned once")
+	score := scorer.scores()[0]
+	assert.True(t, score.Banned)
+	assert.Equal(t, now.Add(time.Hour), score.BannedUntil)
+
+	// the ban expires
+	now = now.Add(time.Hour)
+	assert.False(t, scorer.isBanned(id))
+	assert.Zero(t, scorer.scores()[0].Score)
+
+	// unknown peers are not banned
+	assert.False(t, scorer.isBanned(peer.ID("other")))
+}
+
+func TestPeerScorer_Tick(t *testing.T) {
+	scorer := newPeerScorer(-10, time.Hour)
+	now := time.Unix(1000, 0)
+	scorer.now = func() time.Time { return now }
+	slow, fast, gone := peer.ID("slow"), peer.ID("fast"), peer.ID("gone")
+	latency := func(id peer.ID) time.Duration {
+		if id == slow {
+			return 2 * slowResponseLatency
+		}
+		return time.Millisecond
+	}
+	scorer.report(gone, SlowResponse)
+	scorer.reset(gone)
+	scorer.countMessage(gone)
+
+	assert.Empty(t, scorer.tick([]peer.ID{slow, fast}, latency))
+	assert.Equal(t, []peer.ID{slow}, scorer.tick([]peer.ID{slow, fast}, latency))
+	scores := scorer.scores()
+	require.Len(t, scores, 1, "peers with a neutral score that are not connected are forgotten")
+	assert.Equal(t, slow, scores[0].ID)
+}
+
+func TestScoreTracer(t *testing.T) {
+	scorer := newPeerScorer(-100, time.Hour)
+	reported := make(map[peer.ID][]Misbehavior)
+	tracer := scorer.tracer(func(id peer.ID, m Misbehavior) {
+		reported[id] = append(reported[id], m)
+	})
+	scorer.setTopicMisbehavior("headers", InvalidHeader)
+
+	topic := "headers"
+	msg := &pubsub.Message{Message: &pubsubpb.Message{Topic: &topic}, ReceivedFrom: peer.ID("invalid")}
+	tracer.RejectMessage(msg, pubsub.RejectValidationFailed)
+	// messages ignored by the validator or dropped on local overload are not the fault of the peer
+	tracer.RejectMessage(msg, pubsub.RejectValidationIgnored)
+	tracer.RejectMessage(msg, pubsub.RejectValidationQueueFull)
+	otherTopic := "other"
+	tracer.RejectMessage(&pubsub.Message{Message: &pubsubpb.Message{Topic: &otherTopic}, ReceivedFrom: peer.ID("invalid")}, pubsub.RejectValidationFailed)
+	assert.Equal(t, []Misbehavior{InvalidHeader}, reported[peer.ID("invalid")])
+
+	flood := &pubsub.Message{Message: &pubsubpb.Message{Topic: &topic}, ReceivedFrom: peer.ID("flood")}
+	for range 2 * maxMessagesPerInterval {
+		tracer.ValidateMessage(flood)
+	}
+	assert.Equal(t, []Misbehavior{ExcessiveRequests}, reported[peer.ID("flood")])
+}
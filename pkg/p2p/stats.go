@@ -0,0 +1,352 @@
+package p2p
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	libp2pmetrics "github.com/libp2p/go-libp2p/core/metrics"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+)
+
+const (
+	// statsInterval is the interval at which message rates are computed and metrics are updated.
+	statsInterval = 10 * time.Second
+
+	// bandwidthIdleTimeout is the time after which the bandwidth counters of idle peers and protocols are dropped.
+	bandwidthIdleTimeout = time.Hour
+)
+
+// NetworkStats describes the traffic of the p2p client. Rates are in bytes per second, averaged over the last
+// seconds.
+type NetworkStats struct {
+	TotalIn  uint64  `json:"total_in"`
+	TotalOut uint64  `json:"total_out"`
+	RateIn   float64 `json:"rate_in"`
+	RateOut  float64 `json:"rate_out"`
+	// Peers holds the traffic with every connected peer, by peer ID.
+	Peers []PeerBandwidth `json:"peers"`
+	// Topics holds the gossip traffic and mesh of every topic, by topic.
+	Topics []TopicStats `json:"topics"`
+}
+
+// PeerBandwidth describes the traffic with a peer. Rates are in bytes per second.
+type PeerBandwidth struct {
+	ID       peer.ID `json:"id"`
+	TotalIn  uint64  `json:"total_in"`
+	TotalOut uint64  `json:"total_out"`
+	RateIn   float64 `json:"rate_in"`
+	RateOut  float64 `json:"rate_out"`
+}
+
+// TopicStats describes the gossip traffic and the mesh of a GossipSub topic.
+type TopicStats struct {
+	Topic string `json:"topic"`
+	// MessagesReceived is the number of messages received, including duplicates.
+	MessagesReceived uint64 `json:"messages_received"`
+	// MessagesSent is the number of messages sent, counting a message once for every peer it is sent to.
+	MessagesSent  uint64 `json:"messages_sent"`
+	BytesReceived uint64 `json:"bytes_received"`
+	BytesSent     uint64 `json:"bytes_sent"`
+	// Duplicates is the number of received messages that were received before.
+	Duplicates uint64 `json:"duplicates"`
+	// Rejected is the number of received messages rejected by the topic validator.
+	Rejected uint64 `json:"rejected"`
+	// RateLimited is the number of received messages ignored for exceeding the rate limit of the topic.
+	RateLimited uint64 `json:"rate_limited"`
+	// ReceiveRate and SendRate are the messages received and sent per second over the last stats interval.
+	ReceiveRate float64 `json:"receive_rate"`
+	SendRate    float64 `json:"send_rate"`
+	// MeshPeers is the number of peers in the gossip mesh of the topic, to which full messages are forwarded.
+	MeshPeers int `json:"mesh_peers"`
+	// TopicPeers is the number of connected peers subscribed to the topic.
+	TopicPeers int `json:"topic_peers"`
+	// MeshHealthy is set if the mesh holds the minimum number of mesh peers of GossipSub, or all the peers
+	// subscribed to the topic if there are fewer.
+	MeshHealthy bool `json:"mesh_healthy"`
+}
+
+// meshHealthy reports whether a mesh of the given size is healthy for the given number of subscribed peers.
+func meshHealthy(meshPeers, topicPeers int) bool {
+	return meshPeers > 0 && (meshPeers >= pubsub.GossipSubDlo || meshPeers >= topicPeers)
+}
+
+// topicCounters holds the message counters of a topic.
+type topicCounters struct {
+	received, sent           uint64
+	bytesReceived, bytesSent uint64
+	duplicates, rejected     uint64
+	rateLimited              uint64
+}
+
+// gossipStats counts the gossip messages of every topic and tracks the gossip mesh, as a pubsub.RawTracer.
+type gossipStats struct {
+	mu       sync.Mutex
+	counters map[string]*topicCounters
+	mesh     map[string]map[peer.ID]struct{}
+
+	// last holds the counters at the previous tick, from which rates are computed
+	last     map[string]topicCounters
+	lastTick time.Time
+	// receiveRates and sendRates hold the messages per second of the topics over the previous stats interval
+	receiveRates map[string]float64
+	sendRates    map[string]float64
+}
+
+func newGossipStats() *gossipStats {
+	return &gossipStats{
+		counters:     make(map[string]*topicCounters),
+		mesh:         make(map[string]map[peer.ID]struct{}),
+		last:         make(map[string]topicCounters),
+		lastTick:     time.Now(),
+		receiveRates: make(map[string]float64),
+		sendRates:    make(map[string]float64),
+	}
+}
+
+// topic returns the counters of the topic. It must be called with mu held.
+func (s *gossipStats) topic(topic string) *topicCounters {
+	c, ok := s.counters[topic]
+	if !ok {
+		c = &topicCounters{}
+		s.counters[topic] = c
+	}
+	return c
+}
+
+// tick computes the message rates since the previous tick, and returns the counters accumulated since then.
+func (s *gossipStats) tick(now time.Time) map[string]topicCounters {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	elapsed := now.Sub(s.lastTick).Seconds()
+	deltas := make(map[string]topicCounters, len(s.counters))
+	for topic, c := range s.counters {
+		last := s.last[topic]
+		deltas[topic] = topicCounters{
+			received:      c.received - last.received,
+			sent:          c.sent - last.sent,
+			bytesReceived: c.bytesReceived - last.bytesReceived,
+			bytesSent:     c.bytesSent - last.bytesSent,
+			duplicates:    c.duplicates - last.duplicates,
+			rejected:      c.rejected - last.rejected,
+			rateLimited:   c.rateLimited - last.rateLimited,
+		}
+		if elapsed > 0 {
+			s.receiveRates[topic] = float64(deltas[topic].received) / elapsed
+			s.sendRates[topic] = float64(deltas[topic].sent) / elapsed
+		}
+		s.last[topic] = *c
+	}
+	s.lastTick = now
+	return deltas
+}
+
+// topics returns the stats of the topics with traffic or a mesh, and of the given topics, by topic. Peers
+// subscribed to the topics and mesh health are left to the caller.
+func (s *gossipStats) topics(subscribed []string) []TopicStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make(map[string]struct{}, len(s.counters))
+	for topic := range s.counters {
+		names[topic] = struct{}{}
+	}
+	for topic := range s.mesh {
+		names[topic] = struct{}{}
+	}
+	for _, topic := range subscribed {
+		names[topic] = struct{}{}
+	}
+
+	stats := make([]TopicStats, 0, len(names))
+	for topic := range names {
+		ts := TopicStats{
+			Topic:       topic,
+			ReceiveRate: s.receiveRates[topic],
+			SendRate:    s.sendRates[topic],
+			MeshPeers:   len(s.mesh[topic]),
+		}
+		if c, ok := s.counters[topic]; ok {
+			ts.MessagesReceived = c.received
+			ts.MessagesSent = c.sent
+			ts.BytesReceived = c.bytesReceived
+			ts.BytesSent = c.bytesSent
+			ts.Duplicates = c.duplicates
+			ts.Rejected = c.rejected
+			ts.RateLimited = c.rateLimited
+		}
+		stats = append(stats, ts)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Topic < stats[j].Topic })
+	return stats
+}
+
+// rateLimit counts a message of the topic ignored for exceeding the rate limit of the topic.
+func (s *gossipStats) rateLimit(topic string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.topic(topic).rateLimited++
+}
+
+var _ pubsub.RawTracer = (*gossipStats)(nil)
+
+func (s *gossipStats) RecvRPC(rpc *pubsub.RPC) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, msg := range rpc.GetPublish() {
+		c := s.topic(msg.GetTopic())
+		c.received++
+		c.bytesReceived += uint64(len(msg.GetData()))
+	}
+}
+
+func (s *gossipStats) SendRPC(rpc *pubsub.RPC, _ peer.ID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, msg := range rpc.GetPublish() {
+		c := s.topic(msg.GetTopic())
+		c.sent++
+		c.bytesSent += uint64(len(msg.GetData()))
+	}
+}
+
+func (s *gossipStats) DuplicateMessage(msg *pubsub.Message) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.topic(msg.GetTopic()).duplicates++
+}
+
+func (s *gossipStats) RejectMessage(msg *pubsub.Message, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.topic(msg.GetTopic()).rejected++
+}
+
+func (s *gossipStats) Graft(p peer.ID, topic string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mesh[topic] == nil {
+		s.mesh[topic] = make(map[peer.ID]struct{})
+	}
+	s.mesh[topic][p] = struct{}{}
+}
+
+func (s *gossipStats) Prune(p peer.ID, topic string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.mesh[topic], p)
+}
+
+func (s *gossipStats) RemovePeer(p peer.ID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, mesh := range s.mesh {
+		delete(mesh, p)
+	}
+}
+
+func (s *gossipStats) Leave(topic string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.mesh, topic)
+}
+
+func (s *gossipStats) AddPeer(peer.ID, protocol.ID)         {}
+func (s *gossipStats) Join(string)                          {}
+func (s *gossipStats) ValidateMessage(*pubsub.Message)      {}
+func (s *gossipStats) DeliverMessage(*pubsub.Message)       {}
+func (s *gossipStats) DropRPC(*pubsub.RPC, peer.ID)         {}
+func (s *gossipStats) ThrottlePeer(peer.ID)                 {}
+func (s *gossipStats) UndeliverableMessage(*pubsub.Message) {}
+
+// NetworkStats returns the traffic of the client with its connected peers, and the gossip traffic and mesh of
+// every topic.
+func (c *Client) NetworkStats() NetworkStats {
+	totals := c.bandwidth.GetBandwidthTotals()
+	stats := NetworkStats{
+		TotalIn:  uint64(totals.TotalIn),  //nolint:gosec // byte counts are positive
+		TotalOut: uint64(totals.TotalOut), //nolint:gosec // byte counts are positive
+		RateIn:   totals.RateIn,
+		RateOut:  totals.RateOut,
+	}
+	if c.host != nil {
+		for _, id := range c.host.Network().Peers() {
+			stats.Peers = append(stats.Peers, peerBandwidth(id, c.bandwidth.GetBandwidthForPeer(id)))
+		}
+		sort.Slice(stats.Peers, func(i, j int) bool { return stats.Peers[i].ID < stats.Peers[j].ID })
+	}
+
+	var subscribed []string
+	if c.ps != nil {
+		subscribed = c.ps.GetTopics()
+	}
+	stats.Topics = c.gossip.topics(subscribed)
+	for i := range stats.Topics {
+		if c.ps != nil {
+			stats.Topics[i].TopicPeers = len(c.ps.ListPeers(stats.Topics[i].Topic))
+		}
+		stats.Topics[i].MeshHealthy = meshHealthy(stats.Topics[i].MeshPeers, stats.Topics[i].TopicPeers)
+	}
+	return stats
+}
+
+func peerBandwidth(id peer.ID, s libp2pmetrics.Stats) PeerBandwidth {
+	return PeerBandwidth{
+		ID:       id,
+		TotalIn:  uint64(s.TotalIn),  //nolint:gosec // byte counts are positive
+		TotalOut: uint64(s.TotalOut), //nolint:gosec // byte counts are positive
+		RateIn:   s.RateIn,
+		RateOut:  s.RateOut,
+	}
+}
+
+// statsLoop computes gossip message rates and updates the metrics of the client until the context is canceled.
+func (c *Client) statsLoop(ctx context.Context) {
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+	// lastPeers holds the bytes exchanged with the peers at the previous tick
+	lastPeers := make(map[peer.ID]libp2pmetrics.Stats)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			lastPeers = c.updateMetrics(now, lastPeers)
+		}
+	}
+}
+
+// updateMetrics updates the metrics with the traffic since the previous update, given the bytes exchanged with
+// the peers at the previous update, and returns the bytes exchanged with the peers now.
+func (c *Client) updateMetrics(now time.Time, lastPeers map[peer.ID]libp2pmetrics.Stats) map[peer.ID]libp2pmetrics.Stats {
+	c.bandwidth.TrimIdle(now.Add(-bandwidthIdleTimeout))
+	peers := c.bandwidth.GetBandwidthByPeer()
+	for id, s := range peers {
+		last := lastPeers[id]
+		if s.TotalIn < last.TotalIn || s.TotalOut < last.TotalOut {
+			// the counters of the peer were dropped while idle
+			last = libp2pmetrics.Stats{}
+		}
+		c.metrics.PeerReceiveBytesTotal.With("peer_id", id.String()).Add(float64(s.TotalIn - last.TotalIn))
+		c.metrics.PeerSendBytesTotal.With("peer_id", id.String()).Add(float64(s.TotalOut - last.TotalOut))
+	}
+	if c.host != nil {
+		c.metrics.Peers.Set(float64(len(c.host.Network().Peers())))
+	}
+
+	for topic, delta := range c.gossip.tick(now) {
+		c.metrics.MessageReceiveBytesTotal.With("message_type", topic).Add(float64(delta.bytesReceived))
+		c.metrics.MessageSendBytesTotal.With("message_type", topic).Add(float64(delta.bytesSent))
+		c.metrics.TopicMessagesReceivedTotal.With("topic", topic).Add(float64(delta.received))
+		c.metrics.TopicMessagesSentTotal.With("topic", topic).Add(float64(delta.sent))
+		c.metrics.TopicDuplicateMessagesTotal.With("topic", topic).Add(float64(delta.duplicates))
+		c.metrics.TopicRejectedMessagesTotal.With("topic", topic).Add(float64(delta.rejected))
+		c.metrics.TopicRateLimitedMessagesTotal.With("topic", topic).Add(float64(delta.rateLimited))
+	}
+	for _, ts := range c.NetworkStats().Topics {
+		c.metrics.TopicMeshPeers.With("topic", ts.Topic).Set(float64(ts.MeshPeers))
+	}
+	return peers
+}
@@ -0,0 +1,122 @@
+package p2p
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsubpb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/config"
+)
+
+func TestGossipStats(t *testing.T) {
+	stats := newGossipStats()
+	start := stats.lastTick
+	headers, data := "headers", "data"
+	publish := func(topic string, size int) *pubsub.RPC {
+		return &pubsub.RPC{RPC: pubsubpb.RPC{Publish: []*pubsubpb.Message{{Topic: &topic, Data: make([]byte, size)}}}}
+	}
+
+	stats.RecvRPC(publish(headers, 100))
+	stats.RecvRPC(publish(headers, 100))
+	stats.SendRPC(publish(headers, 100), peer.ID("a"))
+	stats.SendRPC(publish(data, 1000), peer.ID("a"))
+	stats.SendRPC(publish(data, 1000), peer.ID("b"))
+	stats.DuplicateMessage(&pubsub.Message{Message: &pubsubpb.Message{Topic: &headers}})
+	stats.RejectMessage(&pubsub.Message{Message: &pubsubpb.Message{Topic: &headers}}, pubsub.RejectValidationFailed)
+
+	stats.Graft(peer.ID("a"), headers)
+	stats.Graft(peer.ID("b"), headers)
+	stats.Graft(peer.ID("a"), data)
+	stats.Prune(peer.ID("b"), headers)
+	stats.RemovePeer(peer.ID("a"))
+	stats.Graft(peer.ID("c"), data)
+
+	deltas := stats.tick(start.Add(2 * time.Second))
+	assert.Equal(t, topicCounters{received: 2, sent: 1, bytesReceived: 200, bytesSent: 100, duplicates: 1, rejected: 1}, deltas[headers])
+	assert.Equal(t, topicCounters{sent: 2, bytesSent: 2000}, deltas[data])
+
+	topics := stats.topics([]string{"tx"})
+	require.Len(t, topics, 3)
+	assert.Equal(t, TopicStats{Topic: data, MessagesSent: 2, BytesSent: 2000, SendRate: 1, MeshPeers: 1}, topics[0])
+	assert.Equal(t, TopicStats{
+		Topic: headers, MessagesReceived: 2, MessagesSent: 1, BytesReceived: 200, BytesSent: 100,
+		Duplicates: 1, Rejected: 1, ReceiveRate: 1, SendRate: 0.5,
+	}, topics[1])
+	assert.Equal(t, TopicStats{Topic: "tx"}, topics[2])
+
+	// only the messages since the previous tick are counted
+	stats.RecvRPC(publish(headers, 50))
+	deltas = stats.tick(start.Add(3 * time.Second))
+	assert.Equal(t, topicCounters{received: 1, bytesReceived: 50}, deltas[headers])
+	assert.Equal(t, float64(1), stats.topics(nil)[1].ReceiveRate)
+	assert.Equal(t, uint64(3), stats.topics(nil)[1].MessagesReceived)
+
+	stats.Leave(data)
+	assert.Zero(t, stats.topics(nil)[0].MeshPeers)
+}
+
+func TestMeshHealthy(t *testing.T) {
+	assert.False(t, meshHealthy(0, 0))
+	assert.False(t, meshHealthy(0, 3))
+	assert.True(t, meshHealthy(2, 2))
+	assert.False(t, meshHealthy(2, 10))
+	assert.True(t, meshHealthy(pubsub.GossipSubDlo, 10))
+}
+
+func TestClientNetworkStats(t *testing.T) {
+	ctx := t.Context()
+	sender := newTestClient(t, config.P2PConfig{}, nil)
+	receiver := newTestClient(t, config.P2PConfig{}, nil)
+	require.NoError(t, sender.setupGossiping(ctx))
+	require.NoError(t, receiver.setupGossiping(ctx))
+	require.NoError(t, connectTestClients(ctx, sender, receiver))
+
+	const topicID = "TestChain-stats"
+	senderTopic, err := sender.ps.Join(topicID)
+	require.NoError(t, err)
+	receiverTopic, err := receiver.ps.Join(topicID)
+	require.NoError(t, err)
+	sub, err := receiverTopic.Subscribe()
+	require.NoError(t, err)
+	defer sub.Cancel()
+	// peers subscribed to a topic graft each other into their mesh
+	senderSub, err := senderTopic.Subscribe()
+	require.NoError(t, err)
+	defer senderSub.Cancel()
+
+	require.Eventually(t, func() bool {
+		topics := sender.NetworkStats().Topics
+		return len(topics) == 1 && topics[0].MeshPeers == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, senderTopic.Publish(ctx, []byte("message")))
+	nextCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	msg, err := sub.Next(nextCtx)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("message"), msg.Data)
+
+	stats := sender.NetworkStats()
+	require.Len(t, stats.Topics, 1)
+	assert.Equal(t, topicID, stats.Topics[0].Topic)
+	assert.Equal(t, uint64(1), stats.Topics[0].MessagesSent)
+	assert.Equal(t, uint64(len("message")), stats.Topics[0].BytesSent)
+	assert.Equal(t, 1, stats.Topics[0].TopicPeers)
+
+	// the bandwidth counters are updated asynchronously
+	require.Eventually(t, func() bool {
+		stats := sender.NetworkStats()
+		return stats.TotalOut > 0 && len(stats.Peers) == 1 && stats.Peers[0].TotalOut > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, receiver.host.ID(), sender.NetworkStats().Peers[0].ID)
+
+	require.Eventually(t, func() bool {
+		topics := receiver.NetworkStats().Topics
+		return len(topics) == 1 && topics[0].MessagesReceived == 1 && topics[0].MeshPeers == 1 && topics[0].MeshHealthy
+	}, 5*time.Second, 10*time.Millisecond)
+}
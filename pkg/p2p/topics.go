@@ -0,0 +1,191 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/time/rate"
+)
+
+// peerLimiterTTL is the time after which the rate limiter of a peer idle on a topic is forgotten
+const peerLimiterTTL = 3 * time.Minute
+
+// ErrTopicNotJoined is returned when publishing or subscribing to an application topic before the client started.
+var ErrTopicNotJoined = errors.New("topic not joined, the p2p client is not started")
+
+// TopicValidator validates a message gossiped on an application topic by the peer which created it. Messages
+// failing validation are neither delivered nor forwarded, and the peer which forwarded them is penalized.
+type TopicValidator func(ctx context.Context, from peer.ID, data []byte) error
+
+// TopicOptions configures an application topic.
+type TopicOptions struct {
+	// Validator validates the messages received on the topic. All messages are accepted if nil.
+	Validator TopicValidator
+	// MessagesPerPeer is the number of messages per second accepted from each peer creating messages, 0 for no
+	// limit. Messages above the limit are ignored: they are neither delivered nor forwarded, without penalty
+	// for the peer which forwarded them.
+	MessagesPerPeer float64
+	// Burst is the number of messages accepted from a peer in a burst above the rate, at least 1.
+	Burst int
+}
+
+// Topic is a gossip topic registered by the application, through which application-specific messages are
+// gossiped over the p2p network of the rollup.
+type Topic struct {
+	client *Client
+	name   string
+	opts   TopicOptions
+
+	mu        sync.Mutex
+	topic     *pubsub.Topic
+	peers     map[peer.ID]*peerLimiter
+	lastSweep time.Time
+}
+
+// peerLimiter is the rate limiter of a peer on a topic.
+type peerLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RegisterTopic registers an application topic. The topic is namespaced by the chain ID, so that the messages
+// of different rollups do not mix, and joined when the client starts, or immediately if it is started.
+func (c *Client) RegisterTopic(name string, opts TopicOptions) (*Topic, error) {
+	if name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("invalid topic name %q", name)
+	}
+	if opts.MessagesPerPeer < 0 {
+		return nil, fmt.Errorf("negative rate limit of topic %s", name)
+	}
+	opts.Burst = max(opts.Burst, 1)
+	t := &Topic{
+		client: c,
+		name:   c.appTopic(name),
+		opts:   opts,
+		peers:  make(map[peer.ID]*peerLimiter),
+	}
+
+	c.topicsMu.Lock()
+	defer c.topicsMu.Unlock()
+	if _, ok := c.topics[name]; ok {
+		return nil, fmt.Errorf("topic %s already registered", name)
+	}
+	if c.ps != nil {
+		if err := t.join(c.ps); err != nil {
+			return nil, err
+		}
+	}
+	c.topics[name] = t
+	c.scorer.setTopicMisbehavior(t.name, InvalidAppMessage)
+	return t, nil
+}
+
+// appTopic returns the gossip topic of the application topic with the given name.
+func (c *Client) appTopic(name string) string {
+	return "/" + c.chainID + "-app/" + name
+}
+
+// Name returns the name of the gossip topic, including the namespace of the chain.
+func (t *Topic) Name() string {
+	return t.name
+}
+
+// Publish gossips the message on the topic. The message is validated by the validator of the topic first.
+func (t *Topic) Publish(ctx context.Context, data []byte) error {
+	topic, err := t.joined()
+	if err != nil {
+		return err
+	}
+	return topic.Publish(ctx, data)
+}
+
+// Subscribe returns a subscription to the messages of the topic, including the messages published by the node.
+// The subscription is cancelled when the client is closed.
+func (t *Topic) Subscribe() (*pubsub.Subscription, error) {
+	topic, err := t.joined()
+	if err != nil {
+		return nil, err
+	}
+	return topic.Subscribe()
+}
+
+func (t *Topic) joined() (*pubsub.Topic, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.topic == nil {
+		return nil, ErrTopicNotJoined
+	}
+	return t.topic, nil
+}
+
+// join registers the validator of the topic with the pubsub and joins the topic.
+func (t *Topic) join(ps *pubsub.PubSub) error {
+	if err := ps.RegisterTopicValidator(t.name, t.validate); err != nil {
+		return fmt.Errorf("failed to register validator of topic %s: %w", t.name, err)
+	}
+	topic, err := ps.Join(t.name)
+	if err != nil {
+		return fmt.Errorf("failed to join topic %s: %w", t.name, err)
+	}
+	t.mu.Lock()
+	t.topic = topic
+	t.mu.Unlock()
+	return nil
+}
+
+// leave unregisters the validator of the topic and leaves the topic.
+func (t *Topic) leave(ps *pubsub.PubSub) error {
+	t.mu.Lock()
+	topic := t.topic
+	t.topic = nil
+	t.mu.Unlock()
+	if topic == nil {
+		return nil
+	}
+	_ = ps.UnregisterTopicValidator(t.name)
+	return topic.Close()
+}
+
+// validate applies the rate limit and the validator of the topic to a received message.
+func (t *Topic) validate(ctx context.Context, from peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	local := from == t.client.host.ID()
+	if !local && t.opts.MessagesPerPeer > 0 && !t.allow(msg.GetFrom(), time.Now()) {
+		t.client.gossip.rateLimit(t.name)
+		return pubsub.ValidationIgnore
+	}
+	if t.opts.Validator == nil {
+		return pubsub.ValidationAccept
+	}
+	if err := t.opts.Validator(ctx, msg.GetFrom(), msg.GetData()); err != nil {
+		t.client.logger.Debug("invalid application message", "topic", t.name, "peer", from, "error", err)
+		return pubsub.ValidationReject
+	}
+	return pubsub.ValidationAccept
+}
+
+// allow reports whether a message created by the peer is allowed by the rate limit of the topic.
+func (t *Topic) allow(id peer.ID, now time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if now.Sub(t.lastSweep) > peerLimiterTTL {
+		for p, l := range t.peers {
+			if now.Sub(l.lastSeen) > peerLimiterTTL {
+				delete(t.peers, p)
+			}
+		}
+		t.lastSweep = now
+	}
+	l, ok := t.peers[id]
+	if !ok {
+		l = &peerLimiter{limiter: rate.NewLimiter(rate.Limit(t.opts.MessagesPerPeer), t.opts.Burst)}
+		t.peers[id] = l
+	}
+	l.lastSeen = now
+	return l.limiter.AllowN(now, 1)
+}
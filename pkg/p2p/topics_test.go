@@ -0,0 +1,90 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/config"
+)
+
+func TestRegisterTopic(t *testing.T) {
+	ctx := t.Context()
+	sender := newTestClient(t, config.P2PConfig{}, nil)
+	receiver := newTestClient(t, config.P2PConfig{BanThreshold: -100}, nil)
+
+	senderTopic, err := sender.RegisterTopic("prices", TopicOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "/TestChain-app/prices", senderTopic.Name())
+	assert.ErrorIs(t, senderTopic.Publish(ctx, []byte("price")), ErrTopicNotJoined)
+	_, err = sender.RegisterTopic("prices", TopicOptions{})
+	assert.Error(t, err)
+	_, err = sender.RegisterTopic("a/b", TopicOptions{})
+	assert.Error(t, err)
+
+	// topics registered before the client starts are joined when gossiping is set up
+	require.NoError(t, sender.setupGossiping(ctx))
+	require.NoError(t, receiver.setupGossiping(ctx))
+	// topics registered after are joined immediately
+	receiverTopic, err := receiver.RegisterTopic("prices", TopicOptions{
+		Validator: func(_ context.Context, from peer.ID, data []byte) error {
+			if string(data) == "invalid" {
+				return errors.New("invalid price")
+			}
+			return nil
+		},
+		MessagesPerPeer: 0.001,
+		Burst:           3,
+	})
+	require.NoError(t, err)
+	require.NoError(t, connectTestClients(ctx, sender, receiver))
+
+	sub, err := receiverTopic.Subscribe()
+	require.NoError(t, err)
+	defer sub.Cancel()
+	senderSub, err := senderTopic.Subscribe()
+	require.NoError(t, err)
+	defer senderSub.Cancel()
+	require.Eventually(t, func() bool {
+		topics := sender.NetworkStats().Topics
+		return len(topics) == 1 && topics[0].MeshPeers == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	next := func() string {
+		nextCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		msg, err := sub.Next(nextCtx)
+		require.NoError(t, err)
+		return string(msg.Data)
+	}
+	// the sender of an invalid message is penalized
+	require.NoError(t, senderTopic.Publish(ctx, []byte("invalid")))
+	require.Eventually(t, func() bool {
+		scores := receiver.PeerScores()
+		return len(scores) == 1 && scores[0].Score < 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the invalid message consumed a message of the burst, so one of the next messages exceeds the rate limit
+	for _, data := range []string{"first", "second", "third"} {
+		require.NoError(t, senderTopic.Publish(ctx, []byte(data)))
+	}
+	received := []string{next(), next()}
+	assert.Subset(t, []string{"first", "second", "third"}, received)
+	require.Eventually(t, func() bool {
+		stats := receiver.NetworkStats().Topics
+		return len(stats) == 1 && stats[0].RateLimited == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	stats := receiver.NetworkStats().Topics[0]
+	assert.Equal(t, "/TestChain-app/prices", stats.Topic)
+	// ignored messages are rejected by pubsub as well
+	assert.Equal(t, uint64(2), stats.Rejected)
+
+	// messages published by the node itself are not rate limited
+	require.NoError(t, receiverTopic.Publish(ctx, []byte("own")))
+	assert.Equal(t, "own", next())
+}
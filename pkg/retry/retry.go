@@ -0,0 +1,168 @@
+// Package retry retries failing operations following a policy: exponential backoff with jitter between attempts,
+// bounded by a maximum number of attempts and a maximum elapsed time, retrying only the errors classified as
+// retryable, and stopping when the context is done.
+package retry
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// DefaultJitter is the jitter of the retry policies of the node configuration.
+const DefaultJitter = 0.2
+
+// Policy describes how a failing operation is retried.
+type Policy struct {
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff bounds the delay between attempts, 0 for no bound.
+	MaxBackoff time.Duration
+	// Multiplier is the factor by which the delay grows after every failed attempt, 2 if 0.
+	Multiplier float64
+	// Jitter is the fraction of the delay randomized, between 0 and 1: a delay d is drawn uniformly from
+	// [d*(1-Jitter), d*(1+Jitter)], so that clients failing together do not retry in lockstep.
+	Jitter float64
+	// MaxAttempts is the maximum number of attempts, including the first one, 0 for no limit.
+	MaxAttempts int
+	// MaxElapsedTime bounds the time since the first attempt after which no attempt is started, 0 for no limit.
+	MaxElapsedTime time.Duration
+	// Retryable reports whether a failed attempt is retried, all errors but the ones wrapped with Permanent are
+	// retried if nil.
+	Retryable func(error) bool
+	// OnRetry is called before waiting for the delay of every retry, e.g. to log the failed attempt.
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// permanentError is an error which is never retried.
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps an error so that it is not retried, whatever the classification of the policy.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err: err}
+}
+
+// retryable reports whether the failed attempt is retried.
+func (p Policy) retryable(err error) bool {
+	var permanent permanentError
+	if errors.As(err, &permanent) {
+		return false
+	}
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// Do calls fn until it succeeds, fails with an error which is not retryable, the attempts allowed by the policy
+// are exhausted or the context is done, waiting for the backoff of the policy between attempts. It returns the
+// error of the last attempt.
+func (p Policy) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	_, err := DoValue(ctx, p, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// DoValue is Policy.Do for operations returning a value. It returns the value of the last attempt.
+func DoValue[T any](ctx context.Context, p Policy, fn func(ctx context.Context) (T, error)) (T, error) {
+	b := p.NewBackoff()
+	for {
+		res, err := fn(ctx)
+		b.Count()
+		if err == nil || !p.retryable(err) || b.Done() {
+			if permanent, ok := err.(permanentError); ok { //nolint:errorlint // only the wrapper added by Permanent is removed
+				err = permanent.err
+			}
+			return res, err
+		}
+		delay := b.Next()
+		if p.MaxElapsedTime > 0 && time.Since(b.start)+delay > p.MaxElapsedTime {
+			return res, err
+		}
+		if p.OnRetry != nil {
+			p.OnRetry(b.Attempts(), err, delay)
+		}
+		if Sleep(ctx, delay) != nil {
+			return res, err
+		}
+	}
+}
+
+// Sleep waits for the delay, and returns the error of the context if it is done first.
+func Sleep(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Backoff tracks the attempts of an operation retried by a custom loop, e.g. one adjusting the delay to the
+// failure, and computes the delays between them following its policy.
+type Backoff struct {
+	policy   Policy
+	start    time.Time
+	attempts int
+	delay    time.Duration
+}
+
+// NewBackoff returns the backoff of an operation following the policy, starting now.
+func (p Policy) NewBackoff() *Backoff {
+	return &Backoff{policy: p, start: time.Now()}
+}
+
+// Count counts an attempt.
+func (b *Backoff) Count() {
+	b.attempts++
+}
+
+// Attempts returns the number of attempts counted.
+func (b *Backoff) Attempts() int {
+	return b.attempts
+}
+
+// Done reports whether the policy allows no more attempts: MaxAttempts attempts were counted, or MaxElapsedTime
+// elapsed since the backoff started.
+func (b *Backoff) Done() bool {
+	if b.policy.MaxAttempts > 0 && b.attempts >= b.policy.MaxAttempts {
+		return true
+	}
+	return b.policy.MaxElapsedTime > 0 && time.Since(b.start) >= b.policy.MaxElapsedTime
+}
+
+// Next returns the delay before retrying a failed attempt: the initial backoff after the first failure, growing
+// by the multiplier with every failure up to the maximum backoff, and randomized by the jitter.
+func (b *Backoff) Next() time.Duration {
+	if b.delay == 0 {
+		b.delay = b.policy.InitialBackoff
+	} else {
+		multiplier := b.policy.Multiplier
+		if multiplier == 0 {
+			multiplier = 2
+		}
+		b.delay = time.Duration(float64(b.delay) * multiplier)
+	}
+	if b.policy.MaxBackoff > 0 && b.delay > b.policy.MaxBackoff {
+		b.delay = b.policy.MaxBackoff
+	}
+	if b.policy.Jitter <= 0 {
+		return b.delay
+	}
+	return time.Duration(float64(b.delay) * (1 + b.policy.Jitter*(2*rand.Float64()-1))) //nolint:gosec // jitter needs no secure randomness
+}
+
+// Reset resets the delay to the initial backoff, e.g. after an attempt made progress.
+func (b *Backoff) Reset() {
+	b.delay = 0
+}
@@ -0,0 +1,130 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failing returns an operation failing with err its first failures calls, and the number of calls made.
+func failing(failures int, err error) (func(context.Context) error, *int) {
+	calls := new(int)
+	return func(context.Context) error {
+		*calls++
+		if *calls <= failures {
+			return err
+		}
+		return nil
+	}, calls
+}
+
+func TestPolicyDo(t *testing.T) {
+	transient, fatal := errors.New("transient"), errors.New("fatal")
+	policy := Policy{
+		InitialBackoff: time.Millisecond,
+		MaxAttempts:    4,
+		Retryable:      func(err error) bool { return errors.Is(err, transient) },
+	}
+	tests := []struct {
+		name      string
+		failures  int
+		err       error
+		wantCalls int
+		wantErr   error
+	}{
+		{name: "succeeds first", wantCalls: 1},
+		{name: "retryable errors retried", failures: 3, err: transient, wantCalls: 4},
+		{name: "attempts exhausted", failures: 10, err: transient, wantCalls: 4, wantErr: transient},
+		{name: "other errors not retried", failures: 3, err: fatal, wantCalls: 1, wantErr: fatal},
+		{name: "permanent errors not retried", failures: 3, err: Permanent(transient), wantCalls: 1, wantErr: transient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, calls := failing(tt.failures, tt.err)
+			var retries []int
+			p := policy
+			p.OnRetry = func(attempt int, err error, delay time.Duration) { retries = append(retries, attempt) }
+
+			err := p.Do(t.Context(), fn)
+			assert.Equal(t, tt.wantCalls, *calls)
+			assert.Len(t, retries, tt.wantCalls-1)
+			if tt.wantErr == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+	_, isPermanent := Permanent(transient).(permanentError)
+	assert.True(t, isPermanent)
+	assert.NoError(t, Permanent(nil))
+}
+
+func TestPolicyDo_Stops(t *testing.T) {
+	transient := errors.New("transient")
+
+	// retries stop when the context is done
+	fn, calls := failing(10, transient)
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	require.ErrorIs(t, Policy{InitialBackoff: time.Hour}.Do(ctx, fn), transient)
+	assert.Equal(t, 1, *calls)
+
+	// no attempt starts after the maximum elapsed time
+	fn, calls = failing(10, transient)
+	start := time.Now()
+	err := Policy{InitialBackoff: 100 * time.Millisecond, Multiplier: 1, MaxElapsedTime: 250 * time.Millisecond}.Do(t.Context(), fn)
+	require.ErrorIs(t, err, transient)
+	assert.Equal(t, 3, *calls)
+	assert.Less(t, time.Since(start), 250*time.Millisecond)
+}
+
+func TestDoValue(t *testing.T) {
+	calls := 0
+	v, err := DoValue(t.Context(), Policy{MaxAttempts: 3}, func(context.Context) (int, error) {
+		calls++
+		if calls < 2 {
+			return 0, errors.New("transient")
+		}
+		return 42, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 42, v)
+	assert.Equal(t, 2, calls)
+}
+
+func TestBackoff(t *testing.T) {
+	b := Policy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, MaxAttempts: 3}.NewBackoff()
+	assert.Equal(t, 100*time.Millisecond, b.Next())
+	assert.Equal(t, 200*time.Millisecond, b.Next())
+	assert.Equal(t, 400*time.Millisecond, b.Next())
+	assert.Equal(t, 800*time.Millisecond, b.Next())
+	assert.Equal(t, time.Second, b.Next(), "the delay is bounded by the maximum backoff")
+	b.Reset()
+	assert.Equal(t, 100*time.Millisecond, b.Next())
+
+	for range 3 {
+		assert.False(t, b.Done())
+		b.Count()
+	}
+	assert.True(t, b.Done())
+	assert.Equal(t, 3, b.Attempts())
+
+	// delays are randomized by the jitter
+	jittered := Policy{InitialBackoff: time.Second, Jitter: 0.5}.NewBackoff()
+	for range 100 {
+		jittered.Reset()
+		delay := jittered.Next()
+		assert.GreaterOrEqual(t, delay, 500*time.Millisecond)
+		assert.LessOrEqual(t, delay, 1500*time.Millisecond)
+	}
+
+	// the maximum elapsed time bounds the attempts of a backoff
+	expired := Policy{MaxElapsedTime: time.Millisecond}.NewBackoff()
+	time.Sleep(2 * time.Millisecond)
+	assert.True(t, expired.Done())
+}
@@ -1,34 +1,96 @@
 package client
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"net/http"
 
 	"connectrpc.com/connect"
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/rollkit/rollkit/pkg/signer"
 	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
 	rpc "github.com/rollkit/rollkit/types/pb/rollkit/v1/v1connect"
 )
 
-// Client is the client for StoreService, P2PService, and HealthService
+// Client is the client for StoreService, P2PService, HealthService, StatusService, AdminService, TxService
+// and EventService
 type Client struct {
 	storeClient  rpc.StoreServiceClient
 	p2pClient    rpc.P2PServiceClient
 	healthClient rpc.HealthServiceClient
+	statusClient rpc.StatusServiceClient
+	adminClient  rpc.AdminServiceClient
+	txClient     rpc.TxServiceClient
+	eventClient  rpc.EventServiceClient
+}
+
+// Option configures the RPC client
+type Option func(*options)
+
+type options struct {
+	adminToken string
+	authToken  string
+	httpClient *http.Client
+}
+
+// WithAdminToken sets the bearer token sent with requests to the AdminService
+func WithAdminToken(token string) Option {
+	return func(o *options) {
+		o.adminToken = token
+	}
+}
+
+// WithAuthToken sets the bearer token, or JWT, sent with write requests: transaction submission, and admin
+// requests if no admin token is set
+func WithAuthToken(token string) Option {
+	return func(o *options) {
+		o.authToken = token
+	}
+}
+
+// WithHTTPClient sets the HTTP client sending the requests, e.g. to trust the CA of a node serving the RPC over
+// TLS. It must support HTTP/2.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
 }
 
 // NewClient creates a new RPC client
-func NewClient(baseURL string) *Client {
-	httpClient := http.DefaultClient
+func NewClient(baseURL string, opts ...Option) *Client {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	adminOpts := []connect.ClientOption{connect.WithGRPC()}
+	txOpts := []connect.ClientOption{connect.WithGRPC()}
+	if token := cmp.Or(o.adminToken, o.authToken); token != "" {
+		adminOpts = append(adminOpts, connect.WithInterceptors(AdminTokenInterceptor(token)))
+	}
+	if o.authToken != "" {
+		txOpts = append(txOpts, connect.WithInterceptors(AdminTokenInterceptor(o.authToken)))
+	}
+
+	httpClient := cmp.Or(o.httpClient, http.DefaultClient)
 	storeClient := rpc.NewStoreServiceClient(httpClient, baseURL, connect.WithGRPC())
 	p2pClient := rpc.NewP2PServiceClient(httpClient, baseURL, connect.WithGRPC())
 	healthClient := rpc.NewHealthServiceClient(httpClient, baseURL, connect.WithGRPC())
+	statusClient := rpc.NewStatusServiceClient(httpClient, baseURL, connect.WithGRPC())
+	adminClient := rpc.NewAdminServiceClient(httpClient, baseURL, adminOpts...)
+	txClient := rpc.NewTxServiceClient(httpClient, baseURL, txOpts...)
+	eventClient := rpc.NewEventServiceClient(httpClient, baseURL, connect.WithGRPC())
 
 	return &Client{
 		storeClient:  storeClient,
 		p2pClient:    p2pClient,
 		healthClient: healthClient,
+		statusClient: statusClient,
+		adminClient:  adminClient,
+		txClient:     txClient,
+		eventClient:  eventClient,
 	}
 }
 
@@ -64,6 +126,82 @@ func (c *Client) GetBlockByHash(ctx context.Context, hash []byte) (*pb.Block, er
 	return resp.Msg.Block, nil
 }
 
+// TxByHash returns an indexed transaction by hash
+func (c *Client) TxByHash(ctx context.Context, hash []byte) (*pb.TxResult, error) {
+	req := connect.NewRequest(&pb.TxByHashRequest{
+		Hash: hash,
+	})
+
+	resp, err := c.storeClient.TxByHash(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg.Tx, nil
+}
+
+// TxSearch returns a page of the indexed transactions matching the query, and the total number of matches
+func (c *Client) TxSearch(ctx context.Context, query string, page, perPage uint32) ([]*pb.TxResult, uint64, error) {
+	req := connect.NewRequest(&pb.TxSearchRequest{
+		Query:   query,
+		Page:    page,
+		PerPage: perPage,
+	})
+
+	resp, err := c.storeClient.TxSearch(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return resp.Msg.Txs, resp.Msg.TotalCount, nil
+}
+
+// GetTxReceipt returns the transaction with the given hash along with its execution result
+func (c *Client) GetTxReceipt(ctx context.Context, hash []byte) (*pb.TxResult, error) {
+	req := connect.NewRequest(&pb.GetTxReceiptRequest{
+		Identifier: &pb.GetTxReceiptRequest_Hash{Hash: hash},
+	})
+
+	resp, err := c.storeClient.GetTxReceipt(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg.Receipt, nil
+}
+
+// GetTxReceiptAt returns the transaction at the given index of the block at the given height along with its
+// execution result
+func (c *Client) GetTxReceiptAt(ctx context.Context, height uint64, index uint32) (*pb.TxResult, error) {
+	req := connect.NewRequest(&pb.GetTxReceiptRequest{
+		Identifier: &pb.GetTxReceiptRequest_Position{Position: &pb.TxPosition{Height: height, Index: index}},
+	})
+
+	resp, err := c.storeClient.GetTxReceipt(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg.Receipt, nil
+}
+
+// Query queries the execution state after the block at the given height, or after the latest block if height is
+// 0, and returns the result and the height queried
+func (c *Client) Query(ctx context.Context, path string, data []byte, height uint64) ([]byte, uint64, error) {
+	req := connect.NewRequest(&pb.QueryRequest{
+		Path:   path,
+		Data:   data,
+		Height: height,
+	})
+
+	resp, err := c.storeClient.Query(ctx, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return resp.Msg.Value, resp.Msg.Height, nil
+}
+
 // GetState returns the current state
 func (c *Client) GetState(ctx context.Context) (*pb.State, error) {
 	req := connect.NewRequest(&emptypb.Empty{})
@@ -89,6 +227,17 @@ func (c *Client) GetMetadata(ctx context.Context, key string) ([]byte, error) {
 	return resp.Msg.Value, nil
 }
 
+// GetDAMetadata returns the DA blobs including the DA included block at the given height
+func (c *Client) GetDAMetadata(ctx context.Context, height uint64) (*pb.DAMetadata, error) {
+	req := connect.NewRequest(&pb.GetDAMetadataRequest{Height: height})
+	resp, err := c.storeClient.GetDAMetadata(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg.Metadata, nil
+}
+
 // GetPeerInfo returns information about the connected peers
 func (c *Client) GetPeerInfo(ctx context.Context) ([]*pb.PeerInfo, error) {
 	req := connect.NewRequest(&emptypb.Empty{})
@@ -111,6 +260,42 @@ func (c *Client) GetNetInfo(ctx context.Context) (*pb.NetInfo, error) {
 	return resp.Msg.NetInfo, nil
 }
 
+// NetPeers returns the connected peers, and the peers recently penalized or banned, with their scores
+func (c *Client) NetPeers(ctx context.Context) ([]*pb.PeerScore, error) {
+	req := connect.NewRequest(&emptypb.Empty{})
+	resp, err := c.p2pClient.NetPeers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg.Peers, nil
+}
+
+// NetStats returns the bandwidth used with every connected peer, and the gossip traffic and mesh health of every topic
+func (c *Client) NetStats(ctx context.Context) (*pb.NetStatsResponse, error) {
+	req := connect.NewRequest(&emptypb.Empty{})
+	resp, err := c.p2pClient.NetStats(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Msg, nil
+}
+
+// BanPeer bans the peer with the given ID until it is unbanned
+func (c *Client) BanPeer(ctx context.Context, peerID string) error {
+	req := connect.NewRequest(&pb.BanPeerRequest{PeerId: peerID})
+	_, err := c.p2pClient.BanPeer(ctx, req)
+	return err
+}
+
+// UnbanPeer lifts the ban of the peer with the given ID
+func (c *Client) UnbanPeer(ctx context.Context, peerID string) error {
+	req := connect.NewRequest(&pb.UnbanPeerRequest{PeerId: peerID})
+	_, err := c.p2pClient.UnbanPeer(ctx, req)
+	return err
+}
+
 // GetHealth calls the HealthService.Livez endpoint and returns the HealthStatus
 func (c *Client) GetHealth(ctx context.Context) (pb.HealthStatus, error) {
 	req := connect.NewRequest(&emptypb.Empty{})
@@ -120,3 +305,337 @@ func (c *Client) GetHealth(ctx context.Context) (pb.HealthStatus, error) {
 	}
 	return resp.Msg.Status, nil
 }
+
+// GetLeader returns the active aggregator as seen by the node
+func (c *Client) GetLeader(ctx context.Context) (*pb.GetLeaderResponse, error) {
+	req := connect.NewRequest(&emptypb.Empty{})
+	resp, err := c.statusClient.GetLeader(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// GetBlockConfirmationStatus returns the confirmation tier of the block at the given height
+func (c *Client) GetBlockConfirmationStatus(ctx context.Context, height uint64) (*pb.GetBlockConfirmationStatusResponse, error) {
+	req := connect.NewRequest(&pb.GetBlockConfirmationStatusRequest{Height: height})
+	resp, err := c.statusClient.GetBlockConfirmationStatus(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// GetDAGasPrice returns the effective gas price used by the node for DA submissions
+func (c *Client) GetDAGasPrice(ctx context.Context) (float64, error) {
+	req := connect.NewRequest(&emptypb.Empty{})
+	resp, err := c.statusClient.GetDAGasPrice(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Msg.GasPrice, nil
+}
+
+// GetDAFees returns the DA fees paid by the node, per day and in total, and its DA daily budget
+func (c *Client) GetDAFees(ctx context.Context) (*pb.GetDAFeesResponse, error) {
+	req := connect.NewRequest(&emptypb.Empty{})
+	resp, err := c.statusClient.GetDAFees(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// GetDAVerification returns the progress of the verification of headers against the DA layer
+func (c *Client) GetDAVerification(ctx context.Context) (*pb.GetDAVerificationResponse, error) {
+	req := connect.NewRequest(&emptypb.Empty{})
+	resp, err := c.statusClient.GetDAVerification(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// GetStatus returns the sync progress of the node
+func (c *Client) GetStatus(ctx context.Context) (*pb.GetStatusResponse, error) {
+	req := connect.NewRequest(&emptypb.Empty{})
+	resp, err := c.statusClient.GetStatus(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// GetDAInclusionProof returns the DA blobs including the header and data of the block at the given height,
+// with their inclusion proofs, so that the DA inclusion of the block can be verified independently
+func (c *Client) GetDAInclusionProof(ctx context.Context, height uint64) (*pb.GetDAInclusionProofResponse, error) {
+	req := connect.NewRequest(&pb.GetDAInclusionProofRequest{Height: height})
+	resp, err := c.statusClient.GetDAInclusionProof(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// GetHeadersRange returns the headers from height from to height to, both included, after the node verified their
+// signatures and that each header links to the previous one
+func (c *Client) GetHeadersRange(ctx context.Context, from, to uint64) ([]*pb.SignedHeader, error) {
+	req := connect.NewRequest(&pb.GetHeadersRangeRequest{From: from, To: to})
+	resp, err := c.statusClient.GetHeadersRange(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg.Headers, nil
+}
+
+// GetDAChainStats returns the statistics of the blobs retrieved from the DA layer per chain, ordered by chain ID
+func (c *Client) GetDAChainStats(ctx context.Context) ([]*pb.DAChainStats, error) {
+	req := connect.NewRequest(&emptypb.Empty{})
+	resp, err := c.statusClient.GetDAChainStats(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg.Chains, nil
+}
+
+// DryRunDASubmission simulates the DA submission of the block at the given height without broadcasting it, and
+// returns the sizes and estimated DA fees of its blobs and the reasons they would be rejected
+func (c *Client) DryRunDASubmission(ctx context.Context, height uint64) (*pb.DryRunDASubmissionResponse, error) {
+	req := connect.NewRequest(&pb.DryRunDASubmissionRequest{Height: height})
+	resp, err := c.statusClient.DryRunDASubmission(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// GetLogLevels returns the log levels of the node, the default level followed by the module levels
+func (c *Client) GetLogLevels(ctx context.Context) (string, error) {
+	req := connect.NewRequest(&emptypb.Empty{})
+	resp, err := c.adminClient.GetLogLevels(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.Msg.Levels, nil
+}
+
+// SetLogLevel changes the log levels of the node, e.g. "block=debug p2p=info", and returns the resulting levels
+func (c *Client) SetLogLevel(ctx context.Context, levels string) (string, error) {
+	req := connect.NewRequest(&pb.SetLogLevelRequest{Levels: levels})
+	resp, err := c.adminClient.SetLogLevel(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.Msg.Levels, nil
+}
+
+// CaptureDebugBundle captures a debug bundle of the node, and returns the gzipped tarball and its suggested file name
+func (c *Client) CaptureDebugBundle(ctx context.Context) ([]byte, string, error) {
+	req := connect.NewRequest(&emptypb.Empty{})
+	resp, err := c.adminClient.CaptureDebugBundle(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+	return resp.Msg.Bundle, resp.Msg.Name, nil
+}
+
+// CompactStore compacts the datastore of the node and returns its disk usage after compaction
+func (c *Client) CompactStore(ctx context.Context) (*pb.StoreUsageResponse, error) {
+	req := connect.NewRequest(&emptypb.Empty{})
+	resp, err := c.adminClient.CompactStore(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// GetStoreUsage returns the disk usage of the datastore of the node, per key prefix
+func (c *Client) GetStoreUsage(ctx context.Context) (*pb.StoreUsageResponse, error) {
+	req := connect.NewRequest(&emptypb.Empty{})
+	resp, err := c.adminClient.GetStoreUsage(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// GetConfig returns the runtime parameters of the node
+func (c *Client) GetConfig(ctx context.Context) (*pb.RuntimeConfig, error) {
+	req := connect.NewRequest(&emptypb.Empty{})
+	resp, err := c.adminClient.GetConfig(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// UpdateConfig changes the runtime parameters set in the request without restarting the node, and returns the
+// resulting runtime parameters. It requires an admin token.
+func (c *Client) UpdateConfig(ctx context.Context, update *pb.UpdateConfigRequest) (*pb.RuntimeConfig, error) {
+	resp, err := c.adminClient.UpdateConfig(ctx, connect.NewRequest(update))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// RotateProposerKey rotates the signing key of the sequencer to newKey from the given height on
+func (c *Client) RotateProposerKey(ctx context.Context, newKey crypto.PubKey, height uint64) (*pb.RotateProposerKeyResponse, error) {
+	pubKey, err := signer.MarshalPublicKey(newKey)
+	if err != nil {
+		return nil, err
+	}
+	req := connect.NewRequest(&pb.RotateProposerKeyRequest{NewPubKey: pubKey, Height: height})
+	resp, err := c.adminClient.RotateProposerKey(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// ScheduleUpgrade schedules a chain upgrade at the height of the request
+func (c *Client) ScheduleUpgrade(ctx context.Context, upgrade *pb.ScheduleUpgradeRequest) (*pb.ScheduleUpgradeResponse, error) {
+	resp, err := c.adminClient.ScheduleUpgrade(ctx, connect.NewRequest(upgrade))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// SwitchMode switches a dual mode node to the given mode, light or full, and returns the mode it switches to
+func (c *Client) SwitchMode(ctx context.Context, mode string) (string, error) {
+	resp, err := c.adminClient.SwitchMode(ctx, connect.NewRequest(&pb.SwitchModeRequest{Mode: mode}))
+	if err != nil {
+		return "", err
+	}
+	return resp.Msg.Mode, nil
+}
+
+// PauseSequencer pauses block production of the aggregator. It returns once the blocks produced before the pause
+// are submitted to the DA layer.
+func (c *Client) PauseSequencer(ctx context.Context) (*pb.SequencerStateResponse, error) {
+	resp, err := c.adminClient.PauseSequencer(ctx, connect.NewRequest(&emptypb.Empty{}))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// ResumeSequencer resumes block production of the aggregator paused by PauseSequencer
+func (c *Client) ResumeSequencer(ctx context.Context) (*pb.SequencerStateResponse, error) {
+	resp, err := c.adminClient.ResumeSequencer(ctx, connect.NewRequest(&emptypb.Empty{}))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// AdminTokenInterceptor returns a client interceptor sending the bearer token in the Authorization header. It
+// also sends the tokens authenticating write requests.
+func AdminTokenInterceptor(token string) connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			req.Header().Set("Authorization", "Bearer "+token)
+			return next(ctx, req)
+		}
+	})
+}
+
+// SubmitTx submits a transaction to the mempool of the node and returns its hash
+func (c *Client) SubmitTx(ctx context.Context, tx []byte) ([]byte, error) {
+	req := connect.NewRequest(&pb.SubmitTxRequest{Tx: tx})
+	resp, err := c.txClient.SubmitTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg.Hash, nil
+}
+
+// TxRejection returns the reason a transaction submitted with SubmitTx or BroadcastTxCommit was rejected at
+// intake, false if err does not report a rejected transaction
+func TxRejection(err error) (*pb.TxRejection, bool) {
+	var connectErr *connect.Error
+	if !errors.As(err, &connectErr) {
+		return nil, false
+	}
+	for _, detail := range connectErr.Details() {
+		value, err := detail.Value()
+		if err != nil {
+			continue
+		}
+		if rejection, ok := value.(*pb.TxRejection); ok {
+			return rejection, true
+		}
+	}
+	return nil, false
+}
+
+// SubmitTxWithPreconfirmation submits a transaction to the mempool of the node, and returns the response holding
+// its hash and, if the node is a sequencer issuing preconfirmations, the preconfirmation of the transaction
+func (c *Client) SubmitTxWithPreconfirmation(ctx context.Context, tx []byte) (*pb.SubmitTxResponse, error) {
+	req := connect.NewRequest(&pb.SubmitTxRequest{Tx: tx})
+	resp, err := c.txClient.SubmitTx(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// VerifyPreconfirmation verifies the signature of a preconfirmation and whether the sequencer kept it
+func (c *Client) VerifyPreconfirmation(ctx context.Context, preconf *pb.Preconfirmation) (*pb.VerifyPreconfirmationResponse, error) {
+	req := connect.NewRequest(&pb.VerifyPreconfirmationRequest{Preconfirmation: preconf})
+	resp, err := c.txClient.VerifyPreconfirmation(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// BroadcastTxAsync submits a transaction to the mempool of the node without waiting for the mempool to accept
+// it, and returns its hash
+func (c *Client) BroadcastTxAsync(ctx context.Context, tx []byte) ([]byte, error) {
+	req := connect.NewRequest(&pb.SubmitTxRequest{Tx: tx})
+	resp, err := c.txClient.BroadcastTxAsync(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg.Hash, nil
+}
+
+// BroadcastTxCommit submits a transaction to the mempool of the node, and waits until the block including it
+// reaches the requested confirmation tier. It returns the height, index and execution result of the transaction.
+func (c *Client) BroadcastTxCommit(ctx context.Context, req *pb.BroadcastTxCommitRequest) (*pb.BroadcastTxCommitResponse, error) {
+	resp, err := c.txClient.BroadcastTxCommit(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// UnconfirmedTxs returns up to limit transactions waiting in the mempool of the node, ordered by priority,
+// along with the number and total size of the transactions in the mempool
+func (c *Client) UnconfirmedTxs(ctx context.Context, limit uint32) (*pb.UnconfirmedTxsResponse, error) {
+	req := connect.NewRequest(&pb.UnconfirmedTxsRequest{Limit: limit})
+	resp, err := c.txClient.UnconfirmedTxs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Msg, nil
+}
+
+// NumUnconfirmedTxs returns the number and total size in bytes of the transactions in the mempool of the node
+func (c *Client) NumUnconfirmedTxs(ctx context.Context) (uint64, uint64, error) {
+	req := connect.NewRequest(&emptypb.Empty{})
+	resp, err := c.txClient.NumUnconfirmedTxs(ctx, req)
+	if err != nil {
+		return 0, 0, err
+	}
+	return resp.Msg.Count, resp.Msg.TotalBytes, nil
+}
+
+// Subscribe streams the node events of the given types, or all events if no type is given,
+// until ctx is canceled or the stream is closed
+func (c *Client) Subscribe(ctx context.Context, types ...pb.EventType) (*connect.ServerStreamForClient[pb.NodeEvent], error) {
+	req := connect.NewRequest(&pb.SubscribeRequest{Types: types})
+	return c.eventClient.Subscribe(ctx, req)
+}
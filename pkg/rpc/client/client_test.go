@@ -2,11 +2,15 @@ package client
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"connectrpc.com/connect"
+	ds "github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/mock"
@@ -16,8 +20,10 @@ import (
 
 	"github.com/rollkit/rollkit/pkg/p2p"
 	"github.com/rollkit/rollkit/pkg/rpc/server"
+	"github.com/rollkit/rollkit/pkg/store"
 	"github.com/rollkit/rollkit/test/mocks"
 	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
 	rpc "github.com/rollkit/rollkit/types/pb/rollkit/v1/v1connect"
 )
 
@@ -27,7 +33,7 @@ func setupTestServer(t *testing.T, mockStore *mocks.Store, mockP2P *mocks.P2PRPC
 	mux := http.NewServeMux()
 
 	// Create the servers
-	storeServer := server.NewStoreServer(mockStore)
+	storeServer := server.NewStoreServer(mockStore, nil)
 	p2pServer := server.NewP2PServer(mockP2P)
 
 	// Register the store service
@@ -115,6 +121,7 @@ func TestClientGetBlockByHeight(t *testing.T) {
 	data := &types.Data{}
 
 	// Setup mock expectations
+	mockStore.On("GetMetadata", mock.Anything, store.PrunedHeightKey).Return(nil, ds.ErrNotFound)
 	mockStore.On("GetBlockData", mock.Anything, height).Return(header, data, nil)
 
 	// Setup test server and client
@@ -223,3 +230,97 @@ func TestClientGetNetInfo(t *testing.T) {
 	require.Equal(t, "0.0.0.0:26656", resultNetInfo.ListenAddresses[0])
 	mockP2P.AssertExpectations(t)
 }
+
+func TestClientNetPeers(t *testing.T) {
+	mockStore := mocks.NewStore(t)
+	mockP2P := mocks.NewP2PRPC(t)
+
+	peerID, err := peer.Decode("12D3KooWJbD9TQoMSSSUyfhHMmgVY3LqCjxYFz8wQ92Qa6DAqtmh")
+	require.NoError(t, err)
+	bannedUntil := time.Now().Add(time.Hour)
+	mockP2P.On("PeerScores").Return([]p2p.PeerScore{
+		{ID: peerID, Score: -120, Banned: true, BannedUntil: bannedUntil},
+	})
+
+	testServer, client := setupTestServer(t, mockStore, mockP2P)
+	defer testServer.Close()
+
+	peers, err := client.NetPeers(context.Background())
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	require.Equal(t, peerID.String(), peers[0].Id)
+	require.Equal(t, float64(-120), peers[0].Score)
+	require.True(t, peers[0].Banned)
+	require.True(t, bannedUntil.Equal(peers[0].BannedUntil.AsTime()))
+}
+
+func TestClientNetStats(t *testing.T) {
+	mockStore := mocks.NewStore(t)
+	mockP2P := mocks.NewP2PRPC(t)
+
+	peerID, err := peer.Decode("12D3KooWJbD9TQoMSSSUyfhHMmgVY3LqCjxYFz8wQ92Qa6DAqtmh")
+	require.NoError(t, err)
+	mockP2P.On("NetworkStats").Return(p2p.NetworkStats{
+		TotalIn:  3000,
+		TotalOut: 5000,
+		RateIn:   30,
+		Peers:    []p2p.PeerBandwidth{{ID: peerID, TotalIn: 3000, TotalOut: 5000, RateOut: 50}},
+		Topics: []p2p.TopicStats{{
+			Topic: "chain-header", MessagesReceived: 10, BytesReceived: 2000, Duplicates: 4,
+			ReceiveRate: 0.5, MeshPeers: 1, TopicPeers: 1, MeshHealthy: true,
+		}},
+	})
+
+	testServer, client := setupTestServer(t, mockStore, mockP2P)
+	defer testServer.Close()
+
+	stats, err := client.NetStats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(3000), stats.TotalIn)
+	require.Equal(t, uint64(5000), stats.TotalOut)
+	require.Equal(t, float64(30), stats.RateIn)
+	require.Len(t, stats.Peers, 1)
+	require.Equal(t, peerID.String(), stats.Peers[0].Id)
+	require.Equal(t, float64(50), stats.Peers[0].RateOut)
+	require.Len(t, stats.Topics, 1)
+	require.Equal(t, "chain-header", stats.Topics[0].Topic)
+	require.Equal(t, uint64(10), stats.Topics[0].MessagesReceived)
+	require.Equal(t, uint64(4), stats.Topics[0].Duplicates)
+	require.Equal(t, uint32(1), stats.Topics[0].MeshPeers)
+	require.True(t, stats.Topics[0].MeshHealthy)
+}
+
+func TestClientBanPeer(t *testing.T) {
+	mockStore := mocks.NewStore(t)
+	mockP2P := mocks.NewP2PRPC(t)
+
+	peerID, err := peer.Decode("12D3KooWJbD9TQoMSSSUyfhHMmgVY3LqCjxYFz8wQ92Qa6DAqtmh")
+	require.NoError(t, err)
+	mockP2P.On("BanPeer", peerID).Return(nil).Once()
+	mockP2P.On("UnbanPeer", peerID).Return(nil).Once()
+
+	testServer, client := setupTestServer(t, mockStore, mockP2P)
+	defer testServer.Close()
+
+	require.NoError(t, client.BanPeer(context.Background(), peerID.String()))
+	require.NoError(t, client.UnbanPeer(context.Background(), peerID.String()))
+
+	// invalid peer IDs are rejected
+	require.Error(t, client.BanPeer(context.Background(), "invalid"))
+}
+
+func TestTxRejection(t *testing.T) {
+	connectErr := connect.NewError(connect.CodeInvalidArgument, errors.New("tx too large"))
+	detail, err := connect.NewErrorDetail(&pb.TxRejection{Reason: pb.TxRejectionReason_TX_REJECTION_REASON_TOO_LARGE, Message: "tx too large"})
+	require.NoError(t, err)
+	connectErr.AddDetail(detail)
+
+	rejection, ok := TxRejection(fmt.Errorf("submit: %w", connectErr))
+	require.True(t, ok)
+	require.Equal(t, pb.TxRejectionReason_TX_REJECTION_REASON_TOO_LARGE, rejection.Reason)
+
+	_, ok = TxRejection(connect.NewError(connect.CodeUnavailable, errors.New("unavailable")))
+	require.False(t, ok)
+	_, ok = TxRejection(errors.New("other"))
+	require.False(t, ok)
+}
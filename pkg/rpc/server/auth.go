@@ -0,0 +1,152 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+
+	"connectrpc.com/connect"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrUnauthenticated is returned by Authenticator.Authenticate for requests without valid credentials.
+var ErrUnauthenticated = errors.New("invalid or missing credentials")
+
+// Authenticator authenticates the requests to write endpoints, i.e. transaction submission and the admin
+// service, with the bearer credential of their Authorization header: a static token listed in the token file, or
+// a JWT signed with HS256 by the secret of the JWT secret file.
+type Authenticator struct {
+	tokenFile     string
+	jwtSecretFile string
+
+	mu     sync.RWMutex
+	tokens [][]byte
+	secret []byte
+}
+
+var _ Reloadable = (*Authenticator)(nil)
+
+// NewAuthenticator loads the token file, listing one token per line, and the JWT secret file, holding the hex
+// encoded secret. Either file may be empty to disable the corresponding credentials.
+func NewAuthenticator(tokenFile, jwtSecretFile string) (*Authenticator, error) {
+	if tokenFile == "" && jwtSecretFile == "" {
+		return nil, errors.New("authenticator requires a token file or a JWT secret file")
+	}
+	a := &Authenticator{tokenFile: tokenFile, jwtSecretFile: jwtSecretFile}
+	if err := a.Reload(); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Reload implements the Reloadable interface.
+func (a *Authenticator) Reload() error {
+	var tokens [][]byte
+	if a.tokenFile != "" {
+		bz, err := os.ReadFile(a.tokenFile)
+		if err != nil {
+			return fmt.Errorf("failed to read RPC token file: %w", err)
+		}
+		// lines starting with # are comments
+		scanner := bufio.NewScanner(bytes.NewReader(bz))
+		for scanner.Scan() {
+			token := strings.TrimSpace(scanner.Text())
+			if token != "" && !strings.HasPrefix(token, "#") {
+				tokens = append(tokens, []byte(token))
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read RPC token file: %w", err)
+		}
+	}
+	var secret []byte
+	if a.jwtSecretFile != "" {
+		bz, err := os.ReadFile(a.jwtSecretFile)
+		if err != nil {
+			return fmt.Errorf("failed to read JWT secret file: %w", err)
+		}
+		secret, err = hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(bz)), "0x"))
+		if err != nil {
+			return fmt.Errorf("failed to decode JWT secret: %w", err)
+		}
+		if len(secret) < 32 {
+			return fmt.Errorf("JWT secret too short: %d bytes, expected at least 32", len(secret))
+		}
+	}
+
+	a.mu.Lock()
+	a.tokens = tokens
+	a.secret = secret
+	a.mu.Unlock()
+	return nil
+}
+
+// Files implements the Reloadable interface.
+func (a *Authenticator) Files() []string {
+	var files []string
+	for _, file := range []string{a.tokenFile, a.jwtSecretFile} {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// Authenticate checks the value of the Authorization header of a request. It returns an error wrapping
+// ErrUnauthenticated if the header does not hold a valid bearer credential.
+func (a *Authenticator) Authenticate(authorization string) error {
+	credential, ok := strings.CutPrefix(authorization, "Bearer ")
+	if !ok || credential == "" {
+		return fmt.Errorf("%w: bearer credential required", ErrUnauthenticated)
+	}
+
+	a.mu.RLock()
+	tokens, secret := a.tokens, a.secret
+	a.mu.RUnlock()
+	for _, token := range tokens {
+		if subtle.ConstantTimeCompare([]byte(credential), token) == 1 {
+			return nil
+		}
+	}
+	if secret == nil {
+		return ErrUnauthenticated
+	}
+	_, err := jwt.Parse(credential, func(*jwt.Token) (any, error) {
+		return secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUnauthenticated, err)
+	}
+	return nil
+}
+
+// WithAuthenticator requires the credentials checked by the authenticator for requests to write endpoints:
+// transaction submission and the admin service. Admin requests are also accepted with the admin token.
+func WithAuthenticator(auth *Authenticator) HandlerOption {
+	return func(o *handlerOptions) {
+		o.auth = auth
+	}
+}
+
+// newWriteAuthInterceptor rejects the requests to the given procedures without credentials accepted by the
+// authenticator. The requests to all procedures are authenticated if none is given.
+func newWriteAuthInterceptor(auth *Authenticator, procedures ...string) connect.Interceptor {
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if len(procedures) == 0 || slices.Contains(procedures, req.Spec().Procedure) {
+				if err := auth.Authenticate(req.Header().Get("Authorization")); err != nil {
+					return nil, connect.NewError(connect.CodeUnauthenticated, err)
+				}
+			}
+			return next(ctx, req)
+		}
+	})
+}
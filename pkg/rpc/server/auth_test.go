@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rollkit/rollkit/pkg/config"
+	rpcclient "github.com/rollkit/rollkit/pkg/rpc/client"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+	rpc "github.com/rollkit/rollkit/types/pb/rollkit/v1/v1connect"
+)
+
+// newTestAuthenticator writes a token file and a JWT secret file and returns the authenticator loading them,
+// with the JWT secret.
+func newTestAuthenticator(t *testing.T, tokens string) (*Authenticator, []byte) {
+	t.Helper()
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "tokens")
+	require.NoError(t, os.WriteFile(tokenFile, []byte(tokens), 0o600))
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	secretFile := filepath.Join(dir, "jwt.hex")
+	require.NoError(t, os.WriteFile(secretFile, []byte("0x"+hex.EncodeToString(secret)+"\n"), 0o600))
+
+	auth, err := NewAuthenticator(tokenFile, secretFile)
+	require.NoError(t, err)
+	return auth, secret
+}
+
+func signJWT(t *testing.T, method jwt.SigningMethod, secret []byte, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(secret)
+	require.NoError(t, err)
+	return token
+}
+
+func TestAuthenticator(t *testing.T) {
+	auth, secret := newTestAuthenticator(t, "# operators\ntoken-a\n\n  token-b  \n")
+
+	valid := signJWT(t, jwt.SigningMethodHS256, secret, jwt.MapClaims{"iat": time.Now().Unix()})
+	tests := []struct {
+		name          string
+		authorization string
+		wantErr       bool
+	}{
+		{name: "token", authorization: "Bearer token-a"},
+		{name: "trimmed token", authorization: "Bearer token-b"},
+		{name: "JWT", authorization: "Bearer " + valid},
+		{name: "missing", authorization: "", wantErr: true},
+		{name: "not a bearer credential", authorization: "token-a", wantErr: true},
+		{name: "comment", authorization: "Bearer # operators", wantErr: true},
+		{name: "unknown token", authorization: "Bearer token-c", wantErr: true},
+		{name: "JWT signed with another secret", authorization: "Bearer " + signJWT(t, jwt.SigningMethodHS256, []byte("another secret of thirty-two byte"), nil), wantErr: true},
+		{name: "JWT signed with another method", authorization: "Bearer " + signJWT(t, jwt.SigningMethodHS512, secret, nil), wantErr: true},
+		{name: "expired JWT", authorization: "Bearer " + signJWT(t, jwt.SigningMethodHS256, secret, jwt.MapClaims{"exp": time.Now().Add(-time.Minute).Unix()}), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := auth.Authenticate(tt.authorization)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrUnauthenticated)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	// tokens are replaced on reload, and kept if the files cannot be loaded
+	require.NoError(t, os.WriteFile(auth.Files()[0], []byte("token-c\n"), 0o600))
+	require.NoError(t, auth.Reload())
+	assert.Error(t, auth.Authenticate("Bearer token-a"))
+	assert.NoError(t, auth.Authenticate("Bearer token-c"))
+	require.NoError(t, os.WriteFile(auth.Files()[1], []byte("not hex"), 0o600))
+	require.Error(t, auth.Reload())
+	assert.NoError(t, auth.Authenticate("Bearer token-c"))
+	assert.NoError(t, auth.Authenticate("Bearer "+valid))
+
+	_, err := NewAuthenticator("", "")
+	assert.Error(t, err)
+}
+
+func TestWriteAuthentication(t *testing.T) {
+	auth, _ := newTestAuthenticator(t, "writer\n")
+	submitter := &testTxSubmitter{}
+	configs := &testConfigManager{conf: config.DefaultConfig}
+	handler, err := NewServiceHandler(nil, nil, nil, StatusSources{}, nil, TxSources{Submitter: submitter},
+		AdminSources{Config: configs, Token: "secret"}, WithAuthenticator(auth))
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// transactions are only submitted with a write credential
+	unauthenticated := rpcclient.NewClient(server.URL)
+	_, err = unauthenticated.SubmitTx(context.Background(), []byte("tx1"))
+	require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	client := rpcclient.NewClient(server.URL, rpcclient.WithAuthToken("writer"))
+	_, err = client.SubmitTx(context.Background(), []byte("tx1"))
+	require.NoError(t, err)
+	require.Len(t, submitter.submitted(), 1)
+
+	// read requests are not authenticated
+	_, _, err = unauthenticated.NumUnconfirmedTxs(context.Background())
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+
+	// admin requests are accepted with the admin token or a write credential
+	for token, authorized := range map[string]bool{"secret": true, "writer": true, "unknown": false} {
+		admin := rpc.NewAdminServiceClient(http.DefaultClient, server.URL, connect.WithInterceptors(rpcclient.AdminTokenInterceptor(token)))
+		_, err = admin.GetConfig(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+		if authorized {
+			assert.NoError(t, err, token)
+		} else {
+			assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err), token)
+		}
+	}
+}
+
+func TestAdminAuthenticationWithoutToken(t *testing.T) {
+	auth, _ := newTestAuthenticator(t, "writer\n")
+	configs := &testConfigManager{conf: config.DefaultConfig}
+	handler, err := NewServiceHandler(nil, nil, nil, StatusSources{}, nil, TxSources{},
+		AdminSources{Config: configs}, WithAuthenticator(auth))
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// runtime config changes are enabled by the authenticator without an admin token
+	maxPeers := uint64(5)
+	unauthenticated := rpc.NewAdminServiceClient(http.DefaultClient, server.URL)
+	_, err = unauthenticated.UpdateConfig(context.Background(), connect.NewRequest(&pb.UpdateConfigRequest{MaxPeers: &maxPeers}))
+	require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+	admin := rpc.NewAdminServiceClient(http.DefaultClient, server.URL, connect.WithInterceptors(rpcclient.AdminTokenInterceptor("writer")))
+	resp, err := admin.UpdateConfig(context.Background(), connect.NewRequest(&pb.UpdateConfigRequest{MaxPeers: &maxPeers}))
+	require.NoError(t, err)
+	require.Equal(t, maxPeers, resp.Msg.MaxPeers)
+}
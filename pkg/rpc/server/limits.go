@@ -0,0 +1,134 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// RejectReasonRateLimitPerIP is the reason of requests rejected by the per-IP rate limit.
+	RejectReasonRateLimitPerIP = "rate_limit_per_ip"
+	// RejectReasonRateLimitGlobal is the reason of requests rejected by the global rate limit.
+	RejectReasonRateLimitGlobal = "rate_limit_global"
+	// RejectReasonRequestTooLarge is the reason of requests rejected for exceeding the maximum request size.
+	RejectReasonRequestTooLarge = "request_too_large"
+
+	// clientLimiterTTL is the time after which the rate limiter of an idle client is forgotten
+	clientLimiterTTL = 3 * time.Minute
+)
+
+// Limits protects the RPC endpoints against clients flooding the node with requests. Zero values disable the
+// corresponding limit.
+type Limits struct {
+	// RequestsPerIP is the number of requests per second allowed per client IP. Clients are identified by the
+	// remote address of the connection, so clients behind a proxy share the limit.
+	RequestsPerIP float64
+	// Requests is the number of requests per second allowed over all clients.
+	Requests float64
+	// Burst is the number of requests allowed in a burst above the rates, at least 1.
+	Burst int
+	// MaxRequestBytes is the maximum size of request bodies.
+	MaxRequestBytes int64
+}
+
+// LimitHandler rejects the requests exceeding the rate limits with HTTP status 429, and the requests whose body
+// exceeds the maximum request size with HTTP status 413, before they reach the wrapped handler.
+type LimitHandler struct {
+	handler http.Handler
+	limits  Limits
+	metrics *Metrics
+	global  *rate.Limiter
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+// clientLimiter is the rate limiter of a client IP.
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// NewLimitHandler wraps the handler with the limits. If metrics is nil, rejected requests are not counted.
+func NewLimitHandler(handler http.Handler, limits Limits, metrics *Metrics) *LimitHandler {
+	if metrics == nil {
+		metrics = NopMetrics()
+	}
+	limits.Burst = max(limits.Burst, 1)
+	h := &LimitHandler{
+		handler: handler,
+		limits:  limits,
+		metrics: metrics,
+		clients: make(map[string]*clientLimiter),
+	}
+	if limits.Requests > 0 {
+		h.global = rate.NewLimiter(rate.Limit(limits.Requests), limits.Burst)
+	}
+	return h
+}
+
+// ServeHTTP implements http.Handler
+func (h *LimitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the per-IP limit is checked first, so that requests of a flooding client do not consume the global limit
+	if h.limits.RequestsPerIP > 0 && !h.allowClient(clientIP(r)) {
+		h.reject(w, http.StatusTooManyRequests, RejectReasonRateLimitPerIP)
+		return
+	}
+	if h.global != nil && !h.global.Allow() {
+		h.reject(w, http.StatusTooManyRequests, RejectReasonRateLimitGlobal)
+		return
+	}
+	if h.limits.MaxRequestBytes > 0 {
+		if r.ContentLength > h.limits.MaxRequestBytes {
+			h.reject(w, http.StatusRequestEntityTooLarge, RejectReasonRequestTooLarge)
+			return
+		}
+		// bodies of unknown length fail to read beyond the limit
+		r.Body = http.MaxBytesReader(w, r.Body, h.limits.MaxRequestBytes)
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
+// allowClient reports whether a request of the client IP is allowed by the per-IP rate limit.
+func (h *LimitHandler) allowClient(ip string) bool {
+	now := time.Now()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if now.Sub(h.lastSweep) > clientLimiterTTL {
+		for client, l := range h.clients {
+			if now.Sub(l.lastSeen) > clientLimiterTTL {
+				delete(h.clients, client)
+			}
+		}
+		h.lastSweep = now
+	}
+	l, ok := h.clients[ip]
+	if !ok {
+		l = &clientLimiter{limiter: rate.NewLimiter(rate.Limit(h.limits.RequestsPerIP), h.limits.Burst)}
+		h.clients[ip] = l
+	}
+	l.lastSeen = now
+	return l.limiter.AllowN(now, 1)
+}
+
+func (h *LimitHandler) reject(w http.ResponseWriter, status int, reason string) {
+	h.metrics.RejectedRequests.With("reason", reason).Add(1)
+	if status == http.StatusTooManyRequests {
+		w.Header().Set("Retry-After", "1")
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// clientIP returns the IP of the remote address of the request.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
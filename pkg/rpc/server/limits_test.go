@@ -0,0 +1,91 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitHandlerRateLimits(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	serve := func(h http.Handler, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("per IP", func(t *testing.T) {
+		h := NewLimitHandler(ok, Limits{RequestsPerIP: 0.001, Burst: 2}, nil)
+		assert.Equal(t, http.StatusOK, serve(h, "10.0.0.1:1000").Code)
+		assert.Equal(t, http.StatusOK, serve(h, "10.0.0.1:1001").Code)
+		rec := serve(h, "10.0.0.1:1002")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+		// other clients are not affected
+		assert.Equal(t, http.StatusOK, serve(h, "10.0.0.2:1000").Code)
+	})
+
+	t.Run("global", func(t *testing.T) {
+		h := NewLimitHandler(ok, Limits{Requests: 0.001, Burst: 2}, nil)
+		assert.Equal(t, http.StatusOK, serve(h, "10.0.0.1:1000").Code)
+		assert.Equal(t, http.StatusOK, serve(h, "10.0.0.2:1000").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serve(h, "10.0.0.3:1000").Code)
+	})
+
+	t.Run("no limits", func(t *testing.T) {
+		h := NewLimitHandler(ok, Limits{}, nil)
+		for range 100 {
+			require.Equal(t, http.StatusOK, serve(h, "10.0.0.1:1000").Code)
+		}
+	})
+}
+
+func TestLimitHandlerMaxRequestBytes(t *testing.T) {
+	h := NewLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		}
+	}), Limits{MaxRequestBytes: 8}, nil)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("12345678")))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// the declared length exceeds the limit
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("123456789")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	// bodies of unknown length are cut at the limit
+	req := httptest.NewRequest(http.MethodPost, "/", io.NopCloser(strings.NewReader("123456789")))
+	req.ContentLength = -1
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func TestServiceHandlerLimits(t *testing.T) {
+	handler, err := NewServiceHandler(nil, nil, nil, StatusSources{}, nil, TxSources{}, AdminSources{},
+		WithLimits(Limits{RequestsPerIP: 0.001, Burst: 1}, NopMetrics()))
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/rollkit.v1.HealthService/Livez")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.NotEqual(t, http.StatusTooManyRequests, resp.StatusCode)
+
+	resp, err = http.Get(server.URL + "/rollkit.v1.HealthService/Livez")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+}
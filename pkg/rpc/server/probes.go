@@ -0,0 +1,103 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// LivenessPath is the path of the HTTP endpoint reporting that the node is running, for Kubernetes liveness
+	// probes.
+	LivenessPath = "/health/live"
+	// ReadinessPath is the path of the HTTP endpoint reporting whether the node is ready to serve requests, for
+	// Kubernetes readiness probes.
+	ReadinessPath = "/health/ready"
+
+	// readinessCheckOK is the result of a passed readiness check
+	readinessCheckOK = "ok"
+)
+
+// ReadinessResponse is the JSON body returned by the readiness endpoint. Checks maps the name of every check
+// to "ok" or to the reason it failed.
+type ReadinessResponse struct {
+	Ready   bool              `json:"ready"`
+	Height  uint64            `json:"height"`
+	SyncLag uint64            `json:"sync_lag"`
+	Checks  map[string]string `json:"checks"`
+}
+
+// LivenessHandler reports that the node is running: it replies with HTTP status 200 as long as the RPC server
+// serves requests.
+type LivenessHandler struct{}
+
+// ServeHTTP implements http.Handler
+func (LivenessHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = w.Write([]byte(readinessCheckOK))
+}
+
+// ReadinessHandler reports whether the node is ready to serve requests: its executor and the DA layer are
+// reachable, it listens for p2p connections, and it trails the latest block known from its peers by at most
+// maxSyncLag blocks. It replies with HTTP status 200 if all checks pass, and 503 otherwise.
+type ReadinessHandler struct {
+	node NodeStatusSource
+	// maxSyncLag is the maximum sync lag of a ready node, 0 to ignore the sync lag
+	maxSyncLag uint64
+}
+
+// NewReadinessHandler creates a new ReadinessHandler instance. node may be nil if the node status is not
+// available, in which case the node is never ready.
+func NewReadinessHandler(node NodeStatusSource, maxSyncLag uint64) *ReadinessHandler {
+	return &ReadinessHandler{
+		node:       node,
+		maxSyncLag: maxSyncLag,
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (h *ReadinessHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.node == nil {
+		http.Error(w, "node status is not available", http.StatusServiceUnavailable)
+		return
+	}
+	status, err := h.node.Status(r.Context())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to get node status: %s", err), http.StatusServiceUnavailable)
+		return
+	}
+
+	resp := ReadinessResponse{
+		Ready:   true,
+		Height:  status.Height,
+		SyncLag: status.SyncLag,
+		Checks: map[string]string{
+			"executor": readinessCheckOK,
+			"da":       readinessCheckOK,
+			"p2p":      readinessCheckOK,
+			"sync":     readinessCheckOK,
+		},
+	}
+	fail := func(check, reason string) {
+		resp.Ready = false
+		resp.Checks[check] = reason
+	}
+	if status.ExecutorError != "" {
+		fail("executor", status.ExecutorError)
+	}
+	if status.DAError != "" {
+		fail("da", status.DAError)
+	}
+	if !status.P2PListening {
+		fail("p2p", "not listening for p2p connections")
+	}
+	if h.maxSyncLag > 0 && status.SyncLag > h.maxSyncLag {
+		fail("sync", fmt.Sprintf("%d blocks behind peers, above the maximum of %d", status.SyncLag, h.maxSyncLag))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !resp.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
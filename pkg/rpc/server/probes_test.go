@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+type failingNodeStatus struct{}
+
+func (failingNodeStatus) Status(context.Context) (types.NodeStatus, error) {
+	return types.NodeStatus{}, errors.New("status unavailable")
+}
+
+func TestLivenessHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	LivenessHandler{}.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, LivenessPath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestReadinessHandler(t *testing.T) {
+	ready := types.NodeStatus{Height: 10, P2PListening: true, SyncLag: 3}
+
+	tests := []struct {
+		name       string
+		node       NodeStatusSource
+		maxSyncLag uint64
+		wantCode   int
+		// failed is the name of the failed check, empty if the node is ready
+		failed string
+	}{
+		{name: "ready", node: testNodeStatus(ready), maxSyncLag: 3, wantCode: http.StatusOK},
+		{name: "sync lag ignored", node: testNodeStatus(types.NodeStatus{P2PListening: true, SyncLag: 1000}), wantCode: http.StatusOK},
+		{name: "executor unreachable", node: testNodeStatus(types.NodeStatus{P2PListening: true, ExecutorError: "engine down"}), wantCode: http.StatusServiceUnavailable, failed: "executor"},
+		{name: "DA unreachable", node: testNodeStatus(types.NodeStatus{P2PListening: true, DAError: "connection refused"}), wantCode: http.StatusServiceUnavailable, failed: "da"},
+		{name: "p2p not listening", node: testNodeStatus(types.NodeStatus{}), wantCode: http.StatusServiceUnavailable, failed: "p2p"},
+		{name: "sync lag too high", node: testNodeStatus(ready), maxSyncLag: 2, wantCode: http.StatusServiceUnavailable, failed: "sync"},
+		{name: "status error", node: failingNodeStatus{}, wantCode: http.StatusServiceUnavailable},
+		{name: "no status", wantCode: http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			NewReadinessHandler(tt.node, tt.maxSyncLag).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReadinessPath, nil))
+			assert.Equal(t, tt.wantCode, rec.Code)
+			if rec.Header().Get("Content-Type") != "application/json" {
+				return
+			}
+
+			var resp ReadinessResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tt.wantCode == http.StatusOK, resp.Ready)
+			for check, result := range resp.Checks {
+				if check == tt.failed {
+					assert.NotEqual(t, readinessCheckOK, result)
+				} else {
+					assert.Equal(t, readinessCheckOK, result, check)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceHandlerProbes(t *testing.T) {
+	handler, err := NewServiceHandler(nil, nil, nil, StatusSources{Node: testNodeStatus(types.NodeStatus{P2PListening: true, SyncLag: 20})}, nil, TxSources{}, AdminSources{}, WithReadinessMaxSyncLag(10))
+	require.NoError(t, err)
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + LivenessPath)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp, err = http.Get(srv.URL + ReadinessPath)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
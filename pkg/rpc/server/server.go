@@ -1,34 +1,67 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"connectrpc.com/connect"
 	"connectrpc.com/grpcreflect"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/rollkit/rollkit/block"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/debug"
+	"github.com/rollkit/rollkit/pkg/leader"
+	"github.com/rollkit/rollkit/pkg/logging"
+	"github.com/rollkit/rollkit/pkg/mempool"
 	"github.com/rollkit/rollkit/pkg/p2p"
+	"github.com/rollkit/rollkit/pkg/signer"
 	"github.com/rollkit/rollkit/pkg/store"
+	rollkitsync "github.com/rollkit/rollkit/pkg/sync"
+	"github.com/rollkit/rollkit/pkg/txindex"
 	"github.com/rollkit/rollkit/types"
 	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
 	rpc "github.com/rollkit/rollkit/types/pb/rollkit/v1/v1connect"
 )
 
+// TxIndex provides the transactions indexed by the node.
+type TxIndex interface {
+	TxByHash(ctx context.Context, hash []byte) (*pb.TxResult, error)
+	TxSearch(ctx context.Context, query string, page, perPage int) ([]*pb.TxResult, int, error)
+}
+
+// StateQuerier queries the execution state at a height. It is implemented by the execution clients
+// implementing coreexecutor.Querier.
+type StateQuerier interface {
+	Query(ctx context.Context, path string, data []byte, height uint64) ([]byte, error)
+}
+
 // StoreServer implements the StoreService defined in the proto file
 type StoreServer struct {
-	store store.Store
+	store   store.Store
+	txIndex TxIndex
+	// querier serves the Query RPC, nil if the execution client does not support queries
+	querier StateQuerier
 }
 
-// NewStoreServer creates a new StoreServer instance
-func NewStoreServer(store store.Store) *StoreServer {
+// NewStoreServer creates a new StoreServer instance. txIndex may be nil if transactions are not indexed.
+func NewStoreServer(store store.Store, txIndex TxIndex) *StoreServer {
 	return &StoreServer{
-		store: store,
+		store:   store,
+		txIndex: txIndex,
 	}
 }
 
@@ -54,6 +87,10 @@ func (s *StoreServer) GetBlock(
 				return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("store is empty, no latest block available"))
 			}
 		}
+		// Blocks pruned from the store are not available, unlike on archive nodes
+		if err := store.CheckNotPruned(ctx, s.store, fetchHeight); err != nil {
+			return nil, prunedError(err)
+		}
 		// Fetch by the determined height (either specific or latest)
 		header, data, err = s.store.GetBlockData(ctx, fetchHeight)
 
@@ -132,6 +169,134 @@ func (s *StoreServer) GetMetadata(
 	}), nil
 }
 
+// GetDAMetadata implements the GetDAMetadata RPC method
+func (s *StoreServer) GetDAMetadata(
+	ctx context.Context,
+	req *connect.Request[pb.GetDAMetadataRequest],
+) (*connect.Response[pb.GetDAMetadataResponse], error) {
+	metadata, err := s.store.GetDAMetadata(ctx, req.Msg.Height)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+
+	return connect.NewResponse(&pb.GetDAMetadataResponse{
+		Metadata: metadata.ToProto(),
+	}), nil
+}
+
+// TxByHash implements the TxByHash RPC method
+func (s *StoreServer) TxByHash(
+	ctx context.Context,
+	req *connect.Request[pb.TxByHashRequest],
+) (*connect.Response[pb.TxByHashResponse], error) {
+	if s.txIndex == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("transaction indexing is disabled"))
+	}
+	tx, err := s.txIndex.TxByHash(ctx, req.Msg.Hash)
+	if errors.Is(err, txindex.ErrNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to retrieve transaction: %w", err))
+	}
+	return connect.NewResponse(&pb.TxByHashResponse{Tx: tx}), nil
+}
+
+// TxSearch implements the TxSearch RPC method
+func (s *StoreServer) TxSearch(
+	ctx context.Context,
+	req *connect.Request[pb.TxSearchRequest],
+) (*connect.Response[pb.TxSearchResponse], error) {
+	if s.txIndex == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("transaction indexing is disabled"))
+	}
+	txs, total, err := s.txIndex.TxSearch(ctx, req.Msg.Query, int(req.Msg.Page), int(req.Msg.PerPage))
+	if errors.Is(err, txindex.ErrInvalidQuery) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to search transactions: %w", err))
+	}
+	return connect.NewResponse(&pb.TxSearchResponse{
+		Txs:        txs,
+		TotalCount: uint64(total), //nolint:gosec // total is never negative
+	}), nil
+}
+
+// GetTxReceipt implements the GetTxReceipt RPC method, serving the execution results of transactions persisted
+// by the node, whether or not transactions are indexed.
+func (s *StoreServer) GetTxReceipt(
+	ctx context.Context,
+	req *connect.Request[pb.GetTxReceiptRequest],
+) (*connect.Response[pb.GetTxReceiptResponse], error) {
+	var (
+		receipt *pb.TxResult
+		err     error
+	)
+	switch identifier := req.Msg.Identifier.(type) {
+	case *pb.GetTxReceiptRequest_Hash:
+		receipt, err = txindex.TxReceiptByHash(ctx, s.store, identifier.Hash)
+	case *pb.GetTxReceiptRequest_Position:
+		if identifier.Position == nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("position is required"))
+		}
+		receipt, err = txindex.TxReceipt(ctx, s.store, identifier.Position.Height, identifier.Position.Index)
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid or unsupported identifier type provided"))
+	}
+	if errors.Is(err, txindex.ErrNotFound) {
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to retrieve transaction receipt: %w", err))
+	}
+	return connect.NewResponse(&pb.GetTxReceiptResponse{Receipt: receipt}), nil
+}
+
+// Query implements the Query RPC method, querying the execution state after the block at the requested height,
+// or after the latest block if the height is 0.
+func (s *StoreServer) Query(
+	ctx context.Context,
+	req *connect.Request[pb.QueryRequest],
+) (*connect.Response[pb.QueryResponse], error) {
+	if s.querier == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("state queries are not supported by the execution client"))
+	}
+	latest, err := s.store.Height(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to get latest height: %w", err))
+	}
+	height := req.Msg.Height
+	if height == 0 {
+		height = latest
+	}
+	if height == 0 || height > latest {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("height %d is above the latest height %d", height, latest))
+	}
+	if err := store.CheckNotPruned(ctx, s.store, height); err != nil {
+		return nil, prunedError(err)
+	}
+	value, err := s.querier.Query(ctx, req.Msg.Path, req.Msg.Data, height)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to query state at height %d: %w", height, err))
+	}
+	return connect.NewResponse(&pb.QueryResponse{
+		Value:  value,
+		Height: height,
+	}), nil
+}
+
+// prunedError maps the error checking whether a block was pruned to a Connect error.
+func prunedError(err error) error {
+	if errors.Is(err, store.ErrPruned) {
+		return connect.NewError(connect.CodeNotFound, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
+
 // P2PServer implements the P2PService defined in the proto file
 type P2PServer struct {
 	// Add dependencies needed for P2P functionality
@@ -189,6 +354,99 @@ func (p *P2PServer) GetNetInfo(
 	}), nil
 }
 
+// NetPeers implements the NetPeers RPC method
+func (p *P2PServer) NetPeers(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.NetPeersResponse], error) {
+	scores := p.peerManager.PeerScores()
+	pbPeers := make([]*pb.PeerScore, len(scores))
+	for i, score := range scores {
+		pbPeers[i] = &pb.PeerScore{
+			Id:        score.ID.String(),
+			Score:     score.Score,
+			Connected: score.Connected,
+			Banned:    score.Banned,
+		}
+		if !score.BannedUntil.IsZero() {
+			pbPeers[i].BannedUntil = timestamppb.New(score.BannedUntil)
+		}
+	}
+	return connect.NewResponse(&pb.NetPeersResponse{Peers: pbPeers}), nil
+}
+
+// NetStats implements the NetStats RPC method
+func (p *P2PServer) NetStats(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.NetStatsResponse], error) {
+	stats := p.peerManager.NetworkStats()
+	resp := &pb.NetStatsResponse{
+		TotalIn:  stats.TotalIn,
+		TotalOut: stats.TotalOut,
+		RateIn:   stats.RateIn,
+		RateOut:  stats.RateOut,
+		Peers:    make([]*pb.PeerBandwidth, len(stats.Peers)),
+		Topics:   make([]*pb.TopicStats, len(stats.Topics)),
+	}
+	for i, bw := range stats.Peers {
+		resp.Peers[i] = &pb.PeerBandwidth{
+			Id:       bw.ID.String(),
+			TotalIn:  bw.TotalIn,
+			TotalOut: bw.TotalOut,
+			RateIn:   bw.RateIn,
+			RateOut:  bw.RateOut,
+		}
+	}
+	for i, ts := range stats.Topics {
+		resp.Topics[i] = &pb.TopicStats{
+			Topic:            ts.Topic,
+			MessagesReceived: ts.MessagesReceived,
+			MessagesSent:     ts.MessagesSent,
+			BytesReceived:    ts.BytesReceived,
+			BytesSent:        ts.BytesSent,
+			Duplicates:       ts.Duplicates,
+			Rejected:         ts.Rejected,
+			ReceiveRate:      ts.ReceiveRate,
+			SendRate:         ts.SendRate,
+			MeshPeers:        uint32(ts.MeshPeers),  //nolint:gosec // bounded by the number of peers
+			TopicPeers:       uint32(ts.TopicPeers), //nolint:gosec // bounded by the number of peers
+			MeshHealthy:      ts.MeshHealthy,
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// BanPeer implements the BanPeer RPC method
+func (p *P2PServer) BanPeer(
+	ctx context.Context,
+	req *connect.Request[pb.BanPeerRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	id, err := peer.Decode(req.Msg.PeerId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid peer ID: %w", err))
+	}
+	if err := p.peerManager.BanPeer(id); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
+// UnbanPeer implements the UnbanPeer RPC method
+func (p *P2PServer) UnbanPeer(
+	ctx context.Context,
+	req *connect.Request[pb.UnbanPeerRequest],
+) (*connect.Response[emptypb.Empty], error) {
+	id, err := peer.Decode(req.Msg.PeerId)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid peer ID: %w", err))
+	}
+	if err := p.peerManager.UnbanPeer(id); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&emptypb.Empty{}), nil
+}
+
 // HealthServer implements the HealthService defined in the proto file
 type HealthServer struct{}
 
@@ -208,11 +466,1197 @@ func (h *HealthServer) Livez(
 	}), nil
 }
 
-// NewServiceHandler creates a new HTTP handler for Store, P2P and Health services
-func NewServiceHandler(store store.Store, peerManager p2p.P2PRPC) (http.Handler, error) {
-	storeServer := NewStoreServer(store)
+// ConfirmationSource provides the confirmation status of blocks. It is implemented by block.Manager.
+type ConfirmationSource interface {
+	GetBlockConfirmationStatus(height uint64) block.ConfirmationStatus
+	GetSoftConfirmedHeight() uint64
+	GetAttestedHeight() uint64
+	GetDAIncludedHeight() uint64
+}
+
+// GasPriceSource provides the effective gas price of DA submissions. It is implemented by block.Manager.
+type GasPriceSource interface {
+	GetDAGasPrice() float64
+}
+
+// DAFeeSource provides the DA fees paid by the node. It is implemented by block.Manager.
+type DAFeeSource interface {
+	GetDAFees() block.DAFeeReport
+}
+
+// DAVerificationSource provides the progress of the verification of headers against the DA layer.
+// It is implemented by sync.DAVerifier.
+type DAVerificationSource interface {
+	Status() rollkitsync.DAVerificationStatus
+}
+
+// DAInclusionSource provides the DA inclusion proofs of blocks. It is implemented by block.Manager.
+type DAInclusionSource interface {
+	GetDAInclusionProof(ctx context.Context, height uint64) (*block.DAInclusionProof, error)
+}
+
+// LightClientSource provides the header updates consumed by light clients of the rollup. It is implemented by
+// block.Manager.
+type LightClientSource interface {
+	GetLightClientUpdate(ctx context.Context, height uint64) (*block.LightClientUpdate, error)
+}
+
+// HeaderRangeSource provides verified ranges of headers. It is implemented by sync.HeaderRangeVerifier.
+type HeaderRangeSource interface {
+	GetHeadersRange(ctx context.Context, from, to uint64) ([]*types.SignedHeader, error)
+}
+
+// DAChainSource provides the statistics of the blobs retrieved per chain. It is implemented by block.Manager.
+type DAChainSource interface {
+	GetDAChainStats() []block.DAChainStats
+}
+
+// DADryRunSource simulates the DA submission of blocks. It is implemented by block.Manager.
+type DADryRunSource interface {
+	DryRunDASubmission(ctx context.Context, height uint64) (*block.DADryRun, error)
+}
+
+// NodeStatusSource provides the sync progress of the node. It is implemented by the full and light nodes.
+type NodeStatusSource interface {
+	Status(ctx context.Context) (types.NodeStatus, error)
+}
+
+// StatusSources provides the node status served by the StatusService.
+// Nil sources are not available on the node, e.g. the elector is nil if leader election is disabled.
+type StatusSources struct {
+	Node           NodeStatusSource
+	Elector        *leader.Elector
+	Confirmations  ConfirmationSource
+	GasPrices      GasPriceSource
+	DAFees         DAFeeSource
+	DAVerification DAVerificationSource
+	DAInclusion    DAInclusionSource
+	LightClient    LightClientSource
+	HeaderRanges   HeaderRangeSource
+	DAChains       DAChainSource
+	DADryRun       DADryRunSource
+}
+
+// StatusServer implements the StatusService defined in the proto file
+type StatusServer struct {
+	sources StatusSources
+}
+
+// NewStatusServer creates a new StatusServer instance
+func NewStatusServer(sources StatusSources) *StatusServer {
+	return &StatusServer{
+		sources: sources,
+	}
+}
+
+// GetStatus implements the StatusService.GetStatus RPC
+func (s *StatusServer) GetStatus(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.GetStatusResponse], error) {
+	if s.sources.Node == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("node status is not available"))
+	}
+	status, err := s.sources.Node.Status(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&pb.GetStatusResponse{
+		Mode:             status.Mode,
+		Height:           status.Height,
+		DaIncludedHeight: status.DAIncludedHeight,
+		DaHeight:         status.DAHeight,
+		DaHeadHeight:     status.DAHeadHeight,
+		PendingHeaders:   status.PendingHeaders,
+		PendingBatches:   status.PendingBatches,
+		Peers:            uint32(status.Peers), //nolint:gosec // peer counts fit in uint32
+		CatchingUp:       status.CatchingUp,
+		ExecutorError:    status.ExecutorError,
+		DaError:          status.DAError,
+		P2PListening:     status.P2PListening,
+		SyncLag:          status.SyncLag,
+		Paused:           status.Paused,
+	}), nil
+}
+
+// GetLeader implements the StatusService.GetLeader RPC
+func (s *StatusServer) GetLeader(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.GetLeaderResponse], error) {
+	elector := s.sources.Elector
+	if elector == nil {
+		return connect.NewResponse(&pb.GetLeaderResponse{}), nil
+	}
+
+	lease := elector.Lease()
+	return connect.NewResponse(&pb.GetLeaderResponse{
+		Enabled:     true,
+		Leader:      lease.Holder,
+		Term:        lease.Term,
+		LeaseExpiry: lease.Expiry,
+		IsLeader:    elector.IsLeader(),
+	}), nil
+}
+
+// GetBlockConfirmationStatus implements the StatusService.GetBlockConfirmationStatus RPC
+func (s *StatusServer) GetBlockConfirmationStatus(
+	ctx context.Context,
+	req *connect.Request[pb.GetBlockConfirmationStatusRequest],
+) (*connect.Response[pb.GetBlockConfirmationStatusResponse], error) {
+	confirmations := s.sources.Confirmations
+	if confirmations == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("block confirmations are not tracked by this node"))
+	}
+	if req.Msg.Height == 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("height must be greater than 0"))
+	}
+
+	return connect.NewResponse(&pb.GetBlockConfirmationStatusResponse{
+		Height:              req.Msg.Height,
+		Status:              confirmationStatusToProto(confirmations.GetBlockConfirmationStatus(req.Msg.Height)),
+		SoftConfirmedHeight: confirmations.GetSoftConfirmedHeight(),
+		DaIncludedHeight:    confirmations.GetDAIncludedHeight(),
+		AttestedHeight:      confirmations.GetAttestedHeight(),
+	}), nil
+}
+
+// confirmationStatusToProto converts a block confirmation status to its protobuf representation.
+func confirmationStatusToProto(status block.ConfirmationStatus) pb.ConfirmationStatus {
+	switch status {
+	case block.ConfirmationSoftConfirmed:
+		return pb.ConfirmationStatus_CONFIRMATION_STATUS_SOFT_CONFIRMED
+	case block.ConfirmationAttested:
+		return pb.ConfirmationStatus_CONFIRMATION_STATUS_ATTESTED
+	case block.ConfirmationDAFinalized:
+		return pb.ConfirmationStatus_CONFIRMATION_STATUS_DA_FINALIZED
+	default:
+		return pb.ConfirmationStatus_CONFIRMATION_STATUS_PENDING
+	}
+}
+
+// GetDAGasPrice implements the StatusService.GetDAGasPrice RPC
+func (s *StatusServer) GetDAGasPrice(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.GetDAGasPriceResponse], error) {
+	if s.sources.GasPrices == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("DA submissions are not tracked by this node"))
+	}
+	return connect.NewResponse(&pb.GetDAGasPriceResponse{
+		GasPrice: s.sources.GasPrices.GetDAGasPrice(),
+	}), nil
+}
+
+// GetDAFees implements the StatusService.GetDAFees RPC
+func (s *StatusServer) GetDAFees(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.GetDAFeesResponse], error) {
+	if s.sources.DAFees == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("DA fees are not tracked by this node"))
+	}
+	report := s.sources.DAFees.GetDAFees()
+	resp := &pb.GetDAFeesResponse{
+		Total:       daFeesToProto(report.Total),
+		Today:       daFeesToProto(report.Today),
+		DailyBudget: report.DailyBudget,
+		Paused:      report.Paused,
+	}
+	for _, day := range report.Days {
+		resp.Days = append(resp.Days, &pb.DailyDAFees{Day: day.Day, Fees: daFeesToProto(day.DAFees)})
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func daFeesToProto(fees block.DAFees) *pb.DAFees {
+	return &pb.DAFees{Fees: fees.Fees, Blobs: fees.Blobs, Bytes: fees.Bytes}
+}
+
+// GetDAVerification implements the StatusService.GetDAVerification RPC
+func (s *StatusServer) GetDAVerification(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.GetDAVerificationResponse], error) {
+	if s.sources.DAVerification == nil {
+		return connect.NewResponse(&pb.GetDAVerificationResponse{}), nil
+	}
+	status := s.sources.DAVerification.Status()
+	return connect.NewResponse(&pb.GetDAVerificationResponse{
+		Enabled:        true,
+		VerifiedHeight: status.VerifiedHeight,
+		DaHeight:       status.DAHeight,
+	}), nil
+}
+
+// GetDAInclusionProof implements the StatusService.GetDAInclusionProof RPC
+func (s *StatusServer) GetDAInclusionProof(
+	ctx context.Context,
+	req *connect.Request[pb.GetDAInclusionProofRequest],
+) (*connect.Response[pb.GetDAInclusionProofResponse], error) {
+	if s.sources.DAInclusion == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("DA inclusion proofs are not available on this node"))
+	}
+	proof, err := s.sources.DAInclusion.GetDAInclusionProof(ctx, req.Msg.Height)
+	switch {
+	case errors.Is(err, block.ErrNotDAIncluded):
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, block.ErrDAInclusionUnknown):
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	case err != nil:
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	}
+	resp := &pb.GetDAInclusionProofResponse{
+		Height: proof.Height,
+		Header: daBlobProofToProto(proof.Header),
+	}
+	if proof.Data != nil {
+		resp.Data = daBlobProofToProto(*proof.Data)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func daBlobProofToProto(proof block.DABlobProof) *pb.DABlobProof {
+	return &pb.DABlobProof{
+		DaHeight:   proof.DAHeight,
+		Namespace:  proof.Namespace,
+		Id:         proof.ID,
+		Commitment: proof.Commitment,
+		Proof:      proof.Proof,
+	}
+}
+
+// GetLightClientUpdate implements the StatusService.GetLightClientUpdate RPC
+func (s *StatusServer) GetLightClientUpdate(
+	ctx context.Context,
+	req *connect.Request[pb.GetLightClientUpdateRequest],
+) (*connect.Response[pb.GetLightClientUpdateResponse], error) {
+	if s.sources.LightClient == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("light client updates are not available on this node"))
+	}
+	update, err := s.sources.LightClient.GetLightClientUpdate(ctx, req.Msg.Height)
+	switch {
+	case errors.Is(err, block.ErrNotDAIncluded):
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, block.ErrDAInclusionUnknown):
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	case err != nil:
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	header, err := update.Header.ToProto()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert block header to proto format: %w", err))
+	}
+	resp := &pb.GetLightClientUpdateResponse{
+		ConsensusState: &pb.LightClientConsensusState{
+			Height:          update.ConsensusState.Height,
+			Timestamp:       timestamppb.New(update.ConsensusState.Timestamp),
+			Root:            update.ConsensusState.Root,
+			ProposerAddress: update.ConsensusState.ProposerAddress,
+		},
+		SignedHeader: header,
+		DaHeader:     daBlobPointerToProto(update.DAHeader),
+	}
+	if update.DAData != nil {
+		resp.DaData = daBlobPointerToProto(*update.DAData)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func daBlobPointerToProto(pointer block.DABlobPointer) *pb.DABlobPointer {
+	return &pb.DABlobPointer{
+		DaHeight:   pointer.DAHeight,
+		Namespace:  pointer.Namespace,
+		Id:         pointer.ID,
+		Commitment: pointer.Commitment,
+	}
+}
+
+// GetHeadersRange implements the StatusService.GetHeadersRange RPC
+func (s *StatusServer) GetHeadersRange(
+	ctx context.Context,
+	req *connect.Request[pb.GetHeadersRangeRequest],
+) (*connect.Response[pb.GetHeadersRangeResponse], error) {
+	if s.sources.HeaderRanges == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("header ranges are not available on this node"))
+	}
+	headers, err := s.sources.HeaderRanges.GetHeadersRange(ctx, req.Msg.From, req.Msg.To)
+	switch {
+	case errors.Is(err, rollkitsync.ErrInvalidHeaderRange):
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	case errors.Is(err, rollkitsync.ErrHeaderRangeUnavailable):
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	case err != nil:
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	resp := &pb.GetHeadersRangeResponse{Headers: make([]*pb.SignedHeader, 0, len(headers))}
+	for _, header := range headers {
+		pbHeader, err := header.ToProto()
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to convert block header to proto format: %w", err))
+		}
+		resp.Headers = append(resp.Headers, pbHeader)
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// GetDAChainStats implements the StatusService.GetDAChainStats RPC
+func (s *StatusServer) GetDAChainStats(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.GetDAChainStatsResponse], error) {
+	if s.sources.DAChains == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("DA retrievals are not tracked by this node"))
+	}
+	stats := s.sources.DAChains.GetDAChainStats()
+	resp := &pb.GetDAChainStatsResponse{Chains: make([]*pb.DAChainStats, 0, len(stats))}
+	for _, chain := range stats {
+		resp.Chains = append(resp.Chains, &pb.DAChainStats{
+			ChainId:      chain.ChainID,
+			Blobs:        chain.Blobs,
+			Bytes:        chain.Bytes,
+			LastDaHeight: chain.LastDAHeight,
+		})
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// DryRunDASubmission implements the StatusService.DryRunDASubmission RPC
+func (s *StatusServer) DryRunDASubmission(
+	ctx context.Context,
+	req *connect.Request[pb.DryRunDASubmissionRequest],
+) (*connect.Response[pb.DryRunDASubmissionResponse], error) {
+	if s.sources.DADryRun == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("DA submissions cannot be simulated by this node"))
+	}
+	dryRun, err := s.sources.DADryRun.DryRunDASubmission(ctx, req.Msg.Height)
+	switch {
+	case errors.Is(err, ds.ErrNotFound):
+		return nil, connect.NewError(connect.CodeNotFound, err)
+	case err != nil:
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	resp := &pb.DryRunDASubmissionResponse{
+		Height:      dryRun.Height,
+		Header:      daBlobEstimateToProto(dryRun.Header),
+		GasPrice:    dryRun.GasPrice,
+		MaxBlobSize: dryRun.MaxBlobSize,
+		Fees:        dryRun.Fees,
+	}
+	if dryRun.Data != nil {
+		resp.Data = daBlobEstimateToProto(*dryRun.Data)
+	}
+	for _, err := range dryRun.Errors {
+		resp.Errors = append(resp.Errors, err.Error())
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func daBlobEstimateToProto(estimate block.DABlobEstimate) *pb.DABlobEstimate {
+	return &pb.DABlobEstimate{BlobSizes: estimate.BlobSizes, Bytes: estimate.Bytes, Fees: estimate.Fees}
+}
+
+// StoreMaintainer compacts the datastore of the node and reports its disk usage. It is implemented by
+// store.Maintainer.
+type StoreMaintainer interface {
+	Compact(ctx context.Context) error
+	DiskUsage(ctx context.Context) (store.DiskUsage, error)
+}
+
+// ConfigManager provides the configuration of the node and changes its runtime parameters without restart.
+// It is implemented by the nodes.
+type ConfigManager interface {
+	Config() config.Config
+	UpdateConfig(ctx context.Context, update config.RuntimeUpdate) (config.Config, error)
+}
+
+// KeyRotator rotates the signing key of the sequencer. It is implemented by block.Manager on aggregators.
+type KeyRotator interface {
+	RotateProposerKey(ctx context.Context, newKey crypto.PubKey, height uint64) (*types.KeyRotation, error)
+}
+
+// Upgrader schedules chain upgrades. It is implemented by block.Manager on aggregators.
+type Upgrader interface {
+	ScheduleUpgrade(ctx context.Context, upgrade types.Upgrade) (*types.Upgrade, error)
+}
+
+// BlockProducer pauses and resumes block production of the sequencer. It is implemented by block.Manager on
+// aggregators.
+type BlockProducer interface {
+	PauseBlockProduction(ctx context.Context) error
+	ResumeBlockProduction()
+	BlockProductionPaused() bool
+	GetStoreHeight(ctx context.Context) (uint64, error)
+}
+
+// ModeSwitcher switches a node between light and full mode without restarting it. It is implemented by
+// node.DualModeNode.
+type ModeSwitcher interface {
+	SwitchMode(mode string) error
+}
+
+// AdminSources provides the node administration served by the AdminService.
+// Nil sources are not available on the node, e.g. levels is nil if the logger does not support module levels.
+type AdminSources struct {
+	Levels *logging.Levels
+	// Logs are the recent logs of the node included in debug bundles, nil if they are not retained
+	Logs       *logging.Recent
+	Maintainer StoreMaintainer
+	Config     ConfigManager
+	Rotator    KeyRotator
+	Upgrader   Upgrader
+	Modes      ModeSwitcher
+	Producer   BlockProducer
+	// Token is the bearer token required in the Authorization header of admin requests.
+	// If empty and no write authenticator is configured, admin requests are not authenticated and runtime config
+	// changes, key rotations, upgrades, mode switches, debug bundles and pausing block production are disabled.
+	Token string
+}
+
+// AdminServer implements the AdminService defined in the proto file
+type AdminServer struct {
+	sources AdminSources
+	// auth also authenticates admin requests if not nil
+	auth *Authenticator
+}
+
+// NewAdminServer creates a new AdminServer instance
+func NewAdminServer(sources AdminSources) *AdminServer {
+	return &AdminServer{
+		sources: sources,
+	}
+}
+
+// GetLogLevels implements the AdminService.GetLogLevels RPC
+func (a *AdminServer) GetLogLevels(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.LogLevelsResponse], error) {
+	if a.sources.Levels == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("log levels cannot be changed on this node"))
+	}
+	return connect.NewResponse(&pb.LogLevelsResponse{Levels: a.sources.Levels.String()}), nil
+}
+
+// SetLogLevel implements the AdminService.SetLogLevel RPC
+func (a *AdminServer) SetLogLevel(
+	ctx context.Context,
+	req *connect.Request[pb.SetLogLevelRequest],
+) (*connect.Response[pb.LogLevelsResponse], error) {
+	if a.sources.Levels == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("log levels cannot be changed on this node"))
+	}
+	if err := a.sources.Levels.Set(req.Msg.Levels); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(&pb.LogLevelsResponse{Levels: a.sources.Levels.String()}), nil
+}
+
+// CompactStore implements the AdminService.CompactStore RPC
+func (a *AdminServer) CompactStore(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.StoreUsageResponse], error) {
+	if a.sources.Maintainer == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("datastore cannot be compacted on this node"))
+	}
+	if err := a.sources.Maintainer.Compact(ctx); err != nil {
+		switch {
+		case errors.Is(err, store.ErrCompactionUnsupported):
+			return nil, connect.NewError(connect.CodeUnimplemented, err)
+		case errors.Is(err, store.ErrCompactionRunning):
+			return nil, connect.NewError(connect.CodeAborted, err)
+		default:
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+	}
+	return a.GetStoreUsage(ctx, req)
+}
+
+// GetStoreUsage implements the AdminService.GetStoreUsage RPC
+func (a *AdminServer) GetStoreUsage(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.StoreUsageResponse], error) {
+	if a.sources.Maintainer == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("datastore usage is not reported on this node"))
+	}
+	usage, err := a.sources.Maintainer.DiskUsage(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	prefixes := make([]*pb.PrefixUsage, len(usage.Prefixes))
+	for i, p := range usage.Prefixes {
+		prefixes[i] = &pb.PrefixUsage{Prefix: p.Prefix, Keys: p.Keys, Bytes: p.Bytes}
+	}
+	return connect.NewResponse(&pb.StoreUsageResponse{DiskUsage: usage.Total, Prefixes: prefixes}), nil
+}
+
+// GetConfig implements the AdminService.GetConfig RPC
+func (a *AdminServer) GetConfig(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.RuntimeConfig], error) {
+	if a.sources.Config == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("config is not served by this node"))
+	}
+	return connect.NewResponse(runtimeConfigToProto(a.sources.Config.Config())), nil
+}
+
+// UpdateConfig implements the AdminService.UpdateConfig RPC
+func (a *AdminServer) UpdateConfig(
+	ctx context.Context,
+	req *connect.Request[pb.UpdateConfigRequest],
+) (*connect.Response[pb.RuntimeConfig], error) {
+	if a.sources.Config == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("config cannot be changed on this node"))
+	}
+	if !a.authenticated() {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("runtime config changes require authenticated admin requests"))
+	}
+	update, err := runtimeUpdateFromProto(req.Msg)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	conf, err := a.sources.Config.UpdateConfig(ctx, update)
+	if errors.Is(err, config.ErrInvalidRuntimeConfig) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(runtimeConfigToProto(conf)), nil
+}
+
+// RotateProposerKey implements the AdminService.RotateProposerKey RPC
+func (a *AdminServer) RotateProposerKey(
+	ctx context.Context,
+	req *connect.Request[pb.RotateProposerKeyRequest],
+) (*connect.Response[pb.RotateProposerKeyResponse], error) {
+	if a.sources.Rotator == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("sequencer key cannot be rotated on this node"))
+	}
+	if !a.authenticated() {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("key rotations require authenticated admin requests"))
+	}
+	newKey, err := signer.UnmarshalPublicKey(req.Msg.NewPubKey)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid public key: %w", err))
+	}
+	rotation, err := a.sources.Rotator.RotateProposerKey(ctx, newKey, req.Msg.Height)
+	if errors.Is(err, types.ErrInvalidKeyRotation) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&pb.RotateProposerKeyResponse{
+		Height:          rotation.Height,
+		ProposerAddress: rotation.NewProposer(),
+	}), nil
+}
+
+// ScheduleUpgrade implements the AdminService.ScheduleUpgrade RPC
+func (a *AdminServer) ScheduleUpgrade(
+	ctx context.Context,
+	req *connect.Request[pb.ScheduleUpgradeRequest],
+) (*connect.Response[pb.ScheduleUpgradeResponse], error) {
+	if a.sources.Upgrader == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("upgrades cannot be scheduled on this node"))
+	}
+	if !a.authenticated() {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("upgrades require authenticated admin requests"))
+	}
+	upgrade, err := a.sources.Upgrader.ScheduleUpgrade(ctx, types.Upgrade{
+		Name:            req.Msg.Name,
+		Height:          req.Msg.Height,
+		BlockVersion:    req.Msg.BlockVersion,
+		Namespace:       req.Msg.Namespace,
+		SignatureScheme: req.Msg.SignatureScheme,
+	})
+	if errors.Is(err, types.ErrInvalidUpgrade) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&pb.ScheduleUpgradeResponse{
+		Name:   upgrade.Name,
+		Height: upgrade.Height,
+	}), nil
+}
+
+// SwitchMode implements the AdminService.SwitchMode RPC
+func (a *AdminServer) SwitchMode(
+	ctx context.Context,
+	req *connect.Request[pb.SwitchModeRequest],
+) (*connect.Response[pb.SwitchModeResponse], error) {
+	if a.sources.Modes == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("mode cannot be switched on this node"))
+	}
+	if !a.authenticated() {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("mode switches require authenticated admin requests"))
+	}
+	if err := a.sources.Modes.SwitchMode(req.Msg.Mode); err != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+	return connect.NewResponse(&pb.SwitchModeResponse{Mode: req.Msg.Mode}), nil
+}
+
+// CaptureDebugBundle implements the AdminService.CaptureDebugBundle RPC
+func (a *AdminServer) CaptureDebugBundle(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.DebugBundleResponse], error) {
+	if !a.authenticated() {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("debug bundles require authenticated admin requests"))
+	}
+	var buf bytes.Buffer
+	if err := debug.WriteBundle(&buf, a.sources.Logs); err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&pb.DebugBundleResponse{Bundle: buf.Bytes(), Name: debug.BundleName(time.Now())}), nil
+}
+
+// PauseSequencer implements the AdminService.PauseSequencer RPC
+func (a *AdminServer) PauseSequencer(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.SequencerStateResponse], error) {
+	if a.sources.Producer == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("block production cannot be paused on this node"))
+	}
+	if !a.authenticated() {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("pausing block production requires authenticated admin requests"))
+	}
+	if err := a.sources.Producer.PauseBlockProduction(ctx); err != nil {
+		// block production stays paused, the request can be retried to wait for the DA submissions again
+		return nil, connect.NewError(connect.CodeAborted, err)
+	}
+	return a.sequencerState(ctx)
+}
+
+// ResumeSequencer implements the AdminService.ResumeSequencer RPC
+func (a *AdminServer) ResumeSequencer(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.SequencerStateResponse], error) {
+	if a.sources.Producer == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("block production cannot be resumed on this node"))
+	}
+	if !a.authenticated() {
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("resuming block production requires authenticated admin requests"))
+	}
+	a.sources.Producer.ResumeBlockProduction()
+	return a.sequencerState(ctx)
+}
+
+// sequencerState returns whether block production is paused and the height of the last block produced.
+func (a *AdminServer) sequencerState(ctx context.Context) (*connect.Response[pb.SequencerStateResponse], error) {
+	height, err := a.sources.Producer.GetStoreHeight(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&pb.SequencerStateResponse{
+		Paused: a.sources.Producer.BlockProductionPaused(),
+		Height: height,
+	}), nil
+}
+
+// runtimeConfigToProto converts the runtime parameters of the configuration to protobuf format.
+func runtimeConfigToProto(conf config.Config) *pb.RuntimeConfig {
+	return &pb.RuntimeConfig{
+		BlockTime:         durationpb.New(conf.Node.BlockTime.Duration),
+		LazyMode:          conf.Node.LazyMode,
+		LazyBlockInterval: durationpb.New(conf.Node.LazyBlockInterval.Duration),
+		DaGasPrice:        conf.DA.GasPrice,
+		DaMaxGasPrice:     conf.DA.MaxGasPrice,
+		PruningKeepRecent: conf.Pruning.KeepRecent,
+		PruningInterval:   durationpb.New(conf.Pruning.Interval.Duration),
+		MaxPeers:          conf.P2P.MaxPeers,
+		DaDailyBudget:     conf.DA.DailyBudget,
+	}
+}
+
+// runtimeUpdateFromProto converts the fields set in the request to a runtime config update.
+func runtimeUpdateFromProto(req *pb.UpdateConfigRequest) (config.RuntimeUpdate, error) {
+	var update config.RuntimeUpdate
+	for _, d := range []struct {
+		name  string
+		value *durationpb.Duration
+		field **time.Duration
+	}{
+		{"block_time", req.BlockTime, &update.BlockTime},
+		{"lazy_block_interval", req.LazyBlockInterval, &update.LazyBlockInterval},
+		{"pruning_interval", req.PruningInterval, &update.PruningInterval},
+	} {
+		if d.value == nil {
+			continue
+		}
+		if err := d.value.CheckValid(); err != nil {
+			return update, fmt.Errorf("invalid %s: %w", d.name, err)
+		}
+		duration := d.value.AsDuration()
+		*d.field = &duration
+	}
+	update.LazyMode = req.LazyMode
+	update.DAGasPrice = req.DaGasPrice
+	update.DAMaxGasPrice = req.DaMaxGasPrice
+	update.DADailyBudget = req.DaDailyBudget
+	update.PruningKeepRecent = req.PruningKeepRecent
+	update.MaxPeers = req.MaxPeers
+	return update, nil
+}
+
+// authenticated returns whether admin requests are authenticated, with the admin token or the write authenticator.
+func (a *AdminServer) authenticated() bool {
+	return a.sources.Token != "" || a.auth != nil
+}
+
+// newAdminAuthInterceptor rejects admin requests without the bearer token in their Authorization header, or
+// credentials accepted by the authenticator if not nil. An empty token is never accepted.
+func newAdminAuthInterceptor(token string, auth *Authenticator) connect.Interceptor {
+	expected := []byte("Bearer " + token)
+	return connect.UnaryInterceptorFunc(func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			authorization := req.Header().Get("Authorization")
+			authenticated := token != "" && subtle.ConstantTimeCompare([]byte(authorization), expected) == 1
+			if !authenticated && (auth == nil || auth.Authenticate(authorization) != nil) {
+				return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid or missing admin token"))
+			}
+			return next(ctx, req)
+		}
+	})
+}
+
+// TxSubmitter submits transactions to the mempool of the node, and gossips them to peers. It is implemented
+// by node.FullNode.
+type TxSubmitter interface {
+	SubmitTx(ctx context.Context, tx []byte) ([]byte, error)
+}
+
+// Preconfirmations issues the preconfirmations of the transactions accepted by the node and verifies them. It is
+// implemented by node.FullNode.
+type Preconfirmations interface {
+	Preconfirm(ctx context.Context, tx []byte) (*types.Preconfirmation, error)
+	VerifyPreconfirmation(ctx context.Context, preconf *types.Preconfirmation) (block.PreconfirmationResult, error)
+}
+
+// Mempool lists the transactions waiting for inclusion in a block. It is implemented by mempool.Mempool.
+type Mempool interface {
+	UnconfirmedTxs(limit int) []mempool.Tx
+	Size() (int, uint64)
+}
+
+// TxSources provides the transaction submission and mempool served by the TxService.
+// Nil sources are not available on the node, e.g. light nodes have no mempool.
+type TxSources struct {
+	Submitter TxSubmitter
+	Mempool   Mempool
+	// Preconfirmations attaches preconfirmations to the transactions accepted by SubmitTx, if the node issues them.
+	Preconfirmations Preconfirmations
+	// Index, Events and Confirmations track the transactions submitted with BroadcastTxCommit until their block
+	// is confirmed. BroadcastTxCommit is unimplemented if any of them is nil.
+	Index         TxIndex
+	Events        EventSource
+	Confirmations ConfirmationSource
+	// CommitTimeout is the maximum time BroadcastTxCommit waits for the confirmation of a transaction.
+	CommitTimeout time.Duration
+}
+
+const (
+	// defaultUnconfirmedTxsLimit is the number of transactions returned by UnconfirmedTxs if no limit is set
+	defaultUnconfirmedTxsLimit = 30
+	// maxUnconfirmedTxsLimit is the maximum number of transactions returned by UnconfirmedTxs
+	maxUnconfirmedTxsLimit = 100
+
+	// txCommitPollInterval is the interval at which BroadcastTxCommit looks the transaction up in the index between
+	// block events, as transactions are indexed in the background after their block is published.
+	txCommitPollInterval = 100 * time.Millisecond
+)
+
+// TxServer implements the TxService defined in the proto file
+type TxServer struct {
+	sources TxSources
+}
+
+// NewTxServer creates a new TxServer instance
+func NewTxServer(sources TxSources) *TxServer {
+	return &TxServer{
+		sources: sources,
+	}
+}
+
+// SubmitTx implements the TxService.SubmitTx RPC
+func (t *TxServer) SubmitTx(
+	ctx context.Context,
+	req *connect.Request[pb.SubmitTxRequest],
+) (*connect.Response[pb.SubmitTxResponse], error) {
+	if t.sources.Submitter == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("transactions are not accepted by light nodes"))
+	}
+	if len(req.Msg.Tx) == 0 {
+		return nil, submitTxError(mempool.ErrTxEmpty)
+	}
+	hash, err := t.sources.Submitter.SubmitTx(ctx, req.Msg.Tx)
+	if err != nil {
+		return nil, submitTxError(err)
+	}
+	resp := &pb.SubmitTxResponse{Hash: hash}
+	if t.sources.Preconfirmations != nil {
+		preconf, err := t.sources.Preconfirmations.Preconfirm(ctx, req.Msg.Tx)
+		switch {
+		case errors.Is(err, block.ErrPreconfirmationsDisabled):
+		case err != nil:
+			return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("transaction accepted but not preconfirmed: %w", err))
+		default:
+			if resp.Preconfirmation, err = preconf.ToProto(); err != nil {
+				return nil, connect.NewError(connect.CodeInternal, err)
+			}
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// submitTxError converts an error returned by the TxSubmitter to a connect error. Transactions rejected at intake
+// are reported with a TxRejection detail, so that clients can tell the reasons apart.
+func submitTxError(err error) error {
+	var (
+		code   connect.Code
+		reason pb.TxRejectionReason
+	)
+	switch {
+	case errors.Is(err, mempool.ErrTxEmpty):
+		code, reason = connect.CodeInvalidArgument, pb.TxRejectionReason_TX_REJECTION_REASON_EMPTY
+	case errors.Is(err, mempool.ErrTxTooLarge):
+		code, reason = connect.CodeInvalidArgument, pb.TxRejectionReason_TX_REJECTION_REASON_TOO_LARGE
+	case errors.Is(err, mempool.ErrTxInvalidPrefix):
+		code, reason = connect.CodeInvalidArgument, pb.TxRejectionReason_TX_REJECTION_REASON_INVALID_PREFIX
+	case errors.Is(err, mempool.ErrTxRejected):
+		code, reason = connect.CodeInvalidArgument, pb.TxRejectionReason_TX_REJECTION_REASON_REJECTED_BY_APP
+	case errors.Is(err, mempool.ErrMempoolFull):
+		code, reason = connect.CodeResourceExhausted, pb.TxRejectionReason_TX_REJECTION_REASON_MEMPOOL_FULL
+	default:
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+	connectErr := connect.NewError(code, err)
+	if detail, detailErr := connect.NewErrorDetail(&pb.TxRejection{Reason: reason, Message: err.Error()}); detailErr == nil {
+		connectErr.AddDetail(detail)
+	}
+	return connectErr
+}
+
+// BroadcastTxAsync implements the TxService.BroadcastTxAsync RPC. The transaction is submitted in the background,
+// and errors are not reported: clients look the transaction up by hash to know whether it was included.
+func (t *TxServer) BroadcastTxAsync(
+	ctx context.Context,
+	req *connect.Request[pb.SubmitTxRequest],
+) (*connect.Response[pb.SubmitTxResponse], error) {
+	if t.sources.Submitter == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("transactions are not accepted by light nodes"))
+	}
+	if len(req.Msg.Tx) == 0 {
+		return nil, submitTxError(mempool.ErrTxEmpty)
+	}
+	tx := req.Msg.Tx
+	go func() {
+		_, _ = t.sources.Submitter.SubmitTx(context.Background(), tx)
+	}()
+	return connect.NewResponse(&pb.SubmitTxResponse{Hash: txindex.TxHash(tx)}), nil
+}
+
+// BroadcastTxCommit implements the TxService.BroadcastTxCommit RPC. It submits the transaction, and waits until
+// the block including it reaches the requested confirmation tier, up to the timeout of the request and the
+// CommitTimeout of the node.
+func (t *TxServer) BroadcastTxCommit(
+	ctx context.Context,
+	req *connect.Request[pb.BroadcastTxCommitRequest],
+) (*connect.Response[pb.BroadcastTxCommitResponse], error) {
+	if t.sources.Submitter == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("transactions are not accepted by light nodes"))
+	}
+	if t.sources.Index == nil || t.sources.Events == nil || t.sources.Confirmations == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("transaction indexing is disabled on this node"))
+	}
+	if len(req.Msg.Tx) == 0 {
+		return nil, submitTxError(mempool.ErrTxEmpty)
+	}
+	want := block.ConfirmationSoftConfirmed
+	switch req.Msg.Confirmation {
+	case pb.ConfirmationStatus_CONFIRMATION_STATUS_PENDING, pb.ConfirmationStatus_CONFIRMATION_STATUS_SOFT_CONFIRMED:
+	case pb.ConfirmationStatus_CONFIRMATION_STATUS_ATTESTED:
+		want = block.ConfirmationAttested
+	case pb.ConfirmationStatus_CONFIRMATION_STATUS_DA_FINALIZED:
+		want = block.ConfirmationDAFinalized
+	default:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown confirmation status %d", req.Msg.Confirmation))
+	}
+	timeout := t.sources.CommitTimeout
+	if req.Msg.Timeout != nil {
+		if d := req.Msg.Timeout.AsDuration(); d > 0 && (timeout == 0 || d < timeout) {
+			timeout = d
+		}
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// subscribe before submitting, so that the events of the block including the transaction are not missed
+	events, unsubscribe := t.sources.Events.Subscribe(subscriptionBuffer)
+	defer unsubscribe()
+	hash, err := t.sources.Submitter.SubmitTx(ctx, req.Msg.Tx)
+	if err != nil {
+		return nil, submitTxError(err)
+	}
+
+	ticker := time.NewTicker(txCommitPollInterval)
+	defer ticker.Stop()
+	for {
+		res, err := t.sources.Index.TxByHash(ctx, hash)
+		if err != nil && !errors.Is(err, txindex.ErrNotFound) {
+			return nil, connect.NewError(connect.CodeInternal, err)
+		}
+		if err == nil {
+			if status := t.sources.Confirmations.GetBlockConfirmationStatus(res.Height); status >= want {
+				return connect.NewResponse(&pb.BroadcastTxCommitResponse{
+					Result:       res,
+					Confirmation: confirmationStatusToProto(status),
+				}), nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil, connect.NewError(connect.CodeDeadlineExceeded,
+				fmt.Errorf("transaction %X not confirmed as %s before the timeout", hash, want))
+		case _, ok := <-events:
+			if !ok {
+				// the subscription is closed when falling behind, keep polling the index
+				events = nil
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+// UnconfirmedTxs implements the TxService.UnconfirmedTxs RPC
+func (t *TxServer) UnconfirmedTxs(
+	ctx context.Context,
+	req *connect.Request[pb.UnconfirmedTxsRequest],
+) (*connect.Response[pb.UnconfirmedTxsResponse], error) {
+	if t.sources.Mempool == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("mempool is not available on this node"))
+	}
+	limit := int(req.Msg.Limit)
+	if limit == 0 {
+		limit = defaultUnconfirmedTxsLimit
+	}
+	limit = min(limit, maxUnconfirmedTxsLimit)
+
+	count, totalBytes := t.sources.Mempool.Size()
+	txs := t.sources.Mempool.UnconfirmedTxs(limit)
+	resp := &pb.UnconfirmedTxsResponse{
+		Txs:        make([]*pb.UnconfirmedTx, len(txs)),
+		Count:      uint64(count),
+		TotalBytes: totalBytes,
+	}
+	for i, tx := range txs {
+		resp.Txs[i] = &pb.UnconfirmedTx{
+			Tx:         tx.Tx,
+			Hash:       tx.Hash,
+			Priority:   tx.Priority,
+			ReceivedAt: timestamppb.New(tx.AddedAt),
+		}
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// NumUnconfirmedTxs implements the TxService.NumUnconfirmedTxs RPC
+func (t *TxServer) NumUnconfirmedTxs(
+	ctx context.Context,
+	req *connect.Request[emptypb.Empty],
+) (*connect.Response[pb.NumUnconfirmedTxsResponse], error) {
+	if t.sources.Mempool == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("mempool is not available on this node"))
+	}
+	count, totalBytes := t.sources.Mempool.Size()
+	return connect.NewResponse(&pb.NumUnconfirmedTxsResponse{
+		Count:      uint64(count),
+		TotalBytes: totalBytes,
+	}), nil
+}
+
+// VerifyPreconfirmation implements the TxService.VerifyPreconfirmation RPC
+func (t *TxServer) VerifyPreconfirmation(
+	ctx context.Context,
+	req *connect.Request[pb.VerifyPreconfirmationRequest],
+) (*connect.Response[pb.VerifyPreconfirmationResponse], error) {
+	if t.sources.Preconfirmations == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("preconfirmations are not verified by light nodes"))
+	}
+	preconf := new(types.Preconfirmation)
+	if err := preconf.FromProto(req.Msg.Preconfirmation); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	res, err := t.sources.Preconfirmations.VerifyPreconfirmation(ctx, preconf)
+	if errors.Is(err, types.ErrInvalidPreconfirmation) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&pb.VerifyPreconfirmationResponse{
+		Status: preconfirmationStatusToProto(res.Status),
+		Height: res.Height,
+	}), nil
+}
+
+func preconfirmationStatusToProto(status block.PreconfirmationStatus) pb.PreconfirmationStatus {
+	switch status {
+	case block.PreconfirmationPending:
+		return pb.PreconfirmationStatus_PRECONFIRMATION_STATUS_PENDING
+	case block.PreconfirmationIncluded:
+		return pb.PreconfirmationStatus_PRECONFIRMATION_STATUS_INCLUDED
+	case block.PreconfirmationViolated:
+		return pb.PreconfirmationStatus_PRECONFIRMATION_STATUS_VIOLATED
+	default:
+		return pb.PreconfirmationStatus_PRECONFIRMATION_STATUS_UNSPECIFIED
+	}
+}
+
+// NewServiceHandler creates a new HTTP handler for Store, P2P, Health, Status, Admin, Tx and Event services.
+// The services are served over the gRPC, gRPC-Web and Connect protocols.
+// If events is not nil, node events are also streamed to WebSocket clients on SubscribePath.
+// Liveness and readiness probes are served on LivenessPath and ReadinessPath.
+// If txIndex is nil, the transaction query endpoints are unimplemented.
+// Tx endpoints whose source is nil are unimplemented.
+// Admin endpoints whose source is nil are unimplemented, and admin requests are authenticated if admin.Token is set
+// or the options hold an authenticator.
+func NewServiceHandler(
+	store store.Store,
+	txIndex TxIndex,
+	peerManager p2p.P2PRPC,
+	status StatusSources,
+	events EventSource,
+	txs TxSources,
+	admin AdminSources,
+	opts ...HandlerOption,
+) (http.Handler, error) {
+	o := newHandlerOptions(opts)
+	mux := newServiceMux(store, txIndex, peerManager, status, events, txs, admin, o)
+
+	// Register WebSocket event subscriptions
+	if events != nil {
+		mux.Handle(SubscribePath, NewSubscribeHandler(events))
+	}
+
+	// Register the Kubernetes liveness and readiness probes
+	mux.Handle(LivenessPath, LivenessHandler{})
+	mux.Handle(ReadinessPath, NewReadinessHandler(status.Node, o.maxSyncLag))
+
+	return newH2CHandler(applyHandlerOptions(mux, o)), nil
+}
+
+// HandlerOption configures the handlers created by NewServiceHandler and NewGRPCHandler.
+type HandlerOption func(*handlerOptions)
+
+type handlerOptions struct {
+	limits  *Limits
+	metrics *Metrics
+	querier StateQuerier
+	// auth authenticates the requests to write endpoints, nil to leave them unauthenticated
+	auth *Authenticator
+	// maxSyncLag is the maximum sync lag of a node reported as ready, 0 to ignore the sync lag
+	maxSyncLag uint64
+}
+
+// WithLimits protects the handler with the limits, counting rejected requests in metrics.
+func WithLimits(limits Limits, metrics *Metrics) HandlerOption {
+	return func(o *handlerOptions) {
+		o.limits = &limits
+		o.metrics = metrics
+	}
+}
+
+// WithStateQuerier serves the Query RPC of the StoreService with the querier. Without it, Query is unimplemented.
+func WithStateQuerier(querier StateQuerier) HandlerOption {
+	return func(o *handlerOptions) {
+		o.querier = querier
+	}
+}
+
+// WithReadinessMaxSyncLag reports nodes trailing the latest block known from their peers by more than maxSyncLag
+// blocks as not ready on ReadinessPath. By default, the sync lag is ignored.
+func WithReadinessMaxSyncLag(maxSyncLag uint64) HandlerOption {
+	return func(o *handlerOptions) {
+		o.maxSyncLag = maxSyncLag
+	}
+}
+
+func newHandlerOptions(opts []HandlerOption) handlerOptions {
+	var o handlerOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// applyHandlerOptions wraps the handler according to the options. It is applied within the h2c handler, which
+// serves every stream of HTTP/2 connections with the wrapped handler.
+func applyHandlerOptions(handler http.Handler, o handlerOptions) http.Handler {
+	if o.limits != nil {
+		handler = NewLimitHandler(handler, *o.limits, o.metrics)
+	}
+	return handler
+}
+
+// NewGRPCHandler creates a new HTTP handler serving the same services as NewServiceHandler over the gRPC
+// protocol only, for tooling expecting a plain gRPC server. Requests using other protocols are rejected.
+func NewGRPCHandler(
+	store store.Store,
+	txIndex TxIndex,
+	peerManager p2p.P2PRPC,
+	status StatusSources,
+	events EventSource,
+	txs TxSources,
+	admin AdminSources,
+	opts ...HandlerOption,
+) (http.Handler, error) {
+	o := newHandlerOptions(opts)
+	mux := newServiceMux(store, txIndex, peerManager, status, events, txs, admin, o)
+	return newH2CHandler(applyHandlerOptions(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// gRPC requests have an application/grpc or application/grpc+<codec> content type
+		contentType := r.Header.Get("Content-Type")
+		if contentType != "application/grpc" && !strings.HasPrefix(contentType, "application/grpc+") {
+			http.Error(w, "only the gRPC protocol is supported", http.StatusUnsupportedMediaType)
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}), o)), nil
+}
+
+// newServiceMux registers the handlers of all services. Requests to write endpoints are authenticated if the
+// options hold an authenticator.
+func newServiceMux(
+	store store.Store,
+	txIndex TxIndex,
+	peerManager p2p.P2PRPC,
+	status StatusSources,
+	events EventSource,
+	txs TxSources,
+	admin AdminSources,
+	o handlerOptions,
+) *http.ServeMux {
+	storeServer := NewStoreServer(store, txIndex)
+	storeServer.querier = o.querier
 	p2pServer := NewP2PServer(peerManager)
 	healthServer := NewHealthServer()
+	statusServer := NewStatusServer(status)
+	adminServer := NewAdminServer(admin)
+	adminServer.auth = o.auth
+	txServer := NewTxServer(txs)
+	eventServer := NewEventServer(events)
 
 	mux := http.NewServeMux()
 
@@ -221,6 +1665,10 @@ func NewServiceHandler(store store.Store, peerManager p2p.P2PRPC) (http.Handler,
 		rpc.StoreServiceName,
 		rpc.P2PServiceName,
 		rpc.HealthServiceName,
+		rpc.StatusServiceName,
+		rpc.AdminServiceName,
+		rpc.TxServiceName,
+		rpc.EventServiceName,
 	)
 	mux.Handle(grpcreflect.NewHandlerV1(reflector, compress1KB))
 	mux.Handle(grpcreflect.NewHandlerV1Alpha(reflector, compress1KB))
@@ -237,12 +1685,41 @@ func NewServiceHandler(store store.Store, peerManager p2p.P2PRPC) (http.Handler,
 	healthPath, healthHandler := rpc.NewHealthServiceHandler(healthServer)
 	mux.Handle(healthPath, healthHandler)
 
-	// Use h2c to support HTTP/2 without TLS
-	return h2c.NewHandler(mux, &http2.Server{
+	// Register StatusService
+	statusPath, statusHandler := rpc.NewStatusServiceHandler(statusServer)
+	mux.Handle(statusPath, statusHandler)
+
+	// Register AdminService
+	var adminOpts []connect.HandlerOption
+	if admin.Token != "" || o.auth != nil {
+		adminOpts = append(adminOpts, connect.WithInterceptors(newAdminAuthInterceptor(admin.Token, o.auth)))
+	}
+	adminPath, adminHandler := rpc.NewAdminServiceHandler(adminServer, adminOpts...)
+	mux.Handle(adminPath, adminHandler)
+
+	// Register TxService
+	var txOpts []connect.HandlerOption
+	if o.auth != nil {
+		txOpts = append(txOpts, connect.WithInterceptors(newWriteAuthInterceptor(o.auth, rpc.TxServiceSubmitTxProcedure,
+			rpc.TxServiceBroadcastTxAsyncProcedure, rpc.TxServiceBroadcastTxCommitProcedure)))
+	}
+	txPath, txHandler := rpc.NewTxServiceHandler(txServer, txOpts...)
+	mux.Handle(txPath, txHandler)
+
+	// Register EventService
+	eventPath, eventHandler := rpc.NewEventServiceHandler(eventServer)
+	mux.Handle(eventPath, eventHandler)
+
+	return mux
+}
+
+// newH2CHandler wraps the handler with h2c to support HTTP/2 without TLS
+func newH2CHandler(handler http.Handler) http.Handler {
+	return h2c.NewHandler(handler, &http2.Server{
 		IdleTimeout:          120 * time.Second,
 		MaxReadFrameSize:     1 << 24,
 		MaxConcurrentStreams: 100,
 		ReadIdleTimeout:      30 * time.Second,
 		PingTimeout:          15 * time.Second,
-	}), nil
+	})
 }
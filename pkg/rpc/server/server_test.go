@@ -2,17 +2,42 @@ package server
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
 	"connectrpc.com/connect"
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/rollkit/rollkit/block"
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/leader"
+	"github.com/rollkit/rollkit/pkg/logging"
+	"github.com/rollkit/rollkit/pkg/mempool"
+	rpcclient "github.com/rollkit/rollkit/pkg/rpc/client"
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/pkg/store"
+	rollkitsync "github.com/rollkit/rollkit/pkg/sync"
+	"github.com/rollkit/rollkit/pkg/txindex"
 	"github.com/rollkit/rollkit/test/mocks"
 	"github.com/rollkit/rollkit/types"
 	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+	rpc "github.com/rollkit/rollkit/types/pb/rollkit/v1/v1connect"
 )
 
 func TestGetBlock(t *testing.T) {
@@ -25,10 +50,11 @@ func TestGetBlock(t *testing.T) {
 	data := &types.Data{}
 
 	// Setup mock expectations
+	mockStore.On("GetMetadata", mock.Anything, store.PrunedHeightKey).Return(binary.LittleEndian.AppendUint64(nil, 5), nil)
 	mockStore.On("GetBlockData", mock.Anything, height).Return(header, data, nil)
 
 	// Create server with mock store
-	server := NewStoreServer(mockStore)
+	server := NewStoreServer(mockStore, nil)
 
 	// Test GetBlock with height
 	t.Run("by height", func(t *testing.T) {
@@ -45,6 +71,19 @@ func TestGetBlock(t *testing.T) {
 		mockStore.AssertExpectations(t)
 	})
 
+	// Test GetBlock with a pruned height
+	t.Run("pruned height", func(t *testing.T) {
+		req := connect.NewRequest(&pb.GetBlockRequest{
+			Identifier: &pb.GetBlockRequest_Height{
+				Height: 5,
+			},
+		})
+		_, err := server.GetBlock(context.Background(), req)
+		require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+		require.ErrorIs(t, err, store.ErrPruned)
+		require.ErrorContains(t, err, "earliest available height is 6")
+	})
+
 	// Test GetBlock with hash
 	t.Run("by hash", func(t *testing.T) {
 		hash := []byte("test_hash")
@@ -64,6 +103,52 @@ func TestGetBlock(t *testing.T) {
 	})
 }
 
+// testQuerier returns the queried path and height.
+type testQuerier struct{}
+
+func (testQuerier) Query(ctx context.Context, path string, data []byte, height uint64) ([]byte, error) {
+	return fmt.Appendf(nil, "%s@%d", path, height), nil
+}
+
+func TestQuery(t *testing.T) {
+	mockStore := mocks.NewStore(t)
+	mockStore.On("Height", mock.Anything).Return(uint64(10), nil)
+	mockStore.On("GetMetadata", mock.Anything, store.PrunedHeightKey).Return(binary.LittleEndian.AppendUint64(nil, 5), nil)
+	server := NewStoreServer(mockStore, nil)
+	query := func(height uint64) (*pb.QueryResponse, error) {
+		resp, err := server.Query(context.Background(), connect.NewRequest(&pb.QueryRequest{Path: "key", Height: height}))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Msg, nil
+	}
+
+	// queries are unimplemented without querier
+	_, err := query(7)
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+
+	server.querier = testQuerier{}
+	resp, err := query(7)
+	require.NoError(t, err)
+	require.Equal(t, []byte("key@7"), resp.Value)
+	require.Equal(t, uint64(7), resp.Height)
+
+	// height 0 queries the latest block
+	resp, err = query(0)
+	require.NoError(t, err)
+	require.Equal(t, []byte("key@10"), resp.Value)
+	require.Equal(t, uint64(10), resp.Height)
+
+	_, err = query(11)
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// pruned heights are reported with the earliest available height
+	_, err = query(5)
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	require.ErrorIs(t, err, store.ErrPruned)
+	require.ErrorContains(t, err, "earliest available height is 6")
+}
+
 func TestGetState(t *testing.T) {
 	// Create a mock store
 	mockStore := mocks.NewStore(t)
@@ -85,7 +170,7 @@ func TestGetState(t *testing.T) {
 	mockStore.On("GetState", mock.Anything).Return(state, nil)
 
 	// Create server with mock store
-	server := NewStoreServer(mockStore)
+	server := NewStoreServer(mockStore, nil)
 
 	// Call GetState
 	req := connect.NewRequest(&emptypb.Empty{})
@@ -116,7 +201,7 @@ func TestGetMetadata(t *testing.T) {
 	mockStore.On("GetMetadata", mock.Anything, key).Return(value, nil)
 
 	// Create server with mock store
-	server := NewStoreServer(mockStore)
+	server := NewStoreServer(mockStore, nil)
 
 	// Call GetMetadata
 	req := connect.NewRequest(&pb.GetMetadataRequest{
@@ -129,3 +214,1007 @@ func TestGetMetadata(t *testing.T) {
 	require.Equal(t, value, resp.Msg.Value)
 	mockStore.AssertExpectations(t)
 }
+
+func TestGetDAMetadata(t *testing.T) {
+	mockStore := mocks.NewStore(t)
+	metadata := &types.DAMetadata{
+		Height: 5,
+		Header: types.DABlobMetadata{DAHeight: 10, Namespace: "0a0b", ID: []byte{1}, Commitment: []byte{2}},
+	}
+	mockStore.On("GetDAMetadata", mock.Anything, uint64(5)).Return(metadata, nil)
+	mockStore.On("GetDAMetadata", mock.Anything, uint64(6)).Return(nil, ds.ErrNotFound)
+	server := NewStoreServer(mockStore, nil)
+
+	resp, err := server.GetDAMetadata(context.Background(), connect.NewRequest(&pb.GetDAMetadataRequest{Height: 5}))
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), resp.Msg.Metadata.Height)
+	require.Equal(t, uint64(10), resp.Msg.Metadata.Header.DaHeight)
+	require.Equal(t, "0a0b", resp.Msg.Metadata.Header.Namespace)
+	require.Equal(t, []byte{1}, resp.Msg.Metadata.Header.Id)
+	require.Equal(t, []byte{2}, resp.Msg.Metadata.Header.Commitment)
+	require.Nil(t, resp.Msg.Metadata.Data)
+
+	_, err = server.GetDAMetadata(context.Background(), connect.NewRequest(&pb.GetDAMetadataRequest{Height: 6}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+type testTxIndex map[string]*pb.TxResult
+
+func (idx testTxIndex) TxByHash(_ context.Context, hash []byte) (*pb.TxResult, error) {
+	tx, ok := idx[string(hash)]
+	if !ok {
+		return nil, txindex.ErrNotFound
+	}
+	return tx, nil
+}
+
+func (idx testTxIndex) TxSearch(_ context.Context, query string, _, _ int) ([]*pb.TxResult, int, error) {
+	if query != "tx.height=1" {
+		return nil, 0, txindex.ErrInvalidQuery
+	}
+	return []*pb.TxResult{idx["hash"]}, 1, nil
+}
+
+func TestTxByHash(t *testing.T) {
+	tx := &pb.TxResult{Hash: []byte("hash"), Height: 1, Tx: []byte("tx")}
+	server := NewStoreServer(mocks.NewStore(t), testTxIndex{"hash": tx})
+
+	resp, err := server.TxByHash(context.Background(), connect.NewRequest(&pb.TxByHashRequest{Hash: []byte("hash")}))
+	require.NoError(t, err)
+	require.Equal(t, tx.Tx, resp.Msg.Tx.Tx)
+
+	_, err = server.TxByHash(context.Background(), connect.NewRequest(&pb.TxByHashRequest{Hash: []byte("unknown")}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// transactions not indexed
+	server = NewStoreServer(mocks.NewStore(t), nil)
+	_, err = server.TxByHash(context.Background(), connect.NewRequest(&pb.TxByHashRequest{Hash: []byte("hash")}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+func TestTxSearch(t *testing.T) {
+	tx := &pb.TxResult{Hash: []byte("hash"), Height: 1, Tx: []byte("tx")}
+	server := NewStoreServer(mocks.NewStore(t), testTxIndex{"hash": tx})
+
+	resp, err := server.TxSearch(context.Background(), connect.NewRequest(&pb.TxSearchRequest{Query: "tx.height=1"}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Txs, 1)
+	require.Equal(t, uint64(1), resp.Msg.TotalCount)
+
+	_, err = server.TxSearch(context.Background(), connect.NewRequest(&pb.TxSearchRequest{Query: "height"}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestGetTxReceipt(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	header, data := types.GetRandomBlock(1, 2, "test-chain")
+	require.NoError(t, s.SaveBlockData(ctx, header, data, &header.Signature))
+	txs := [][]byte{data.Txs[0], data.Txs[1]}
+	require.NoError(t, txindex.SaveTxResults(ctx, s, 1, txs, []coreexecutor.TxResult{{Index: 0}, {Index: 1, Code: 3, Log: "failed"}}))
+	server := NewStoreServer(s, nil)
+
+	resp, err := server.GetTxReceipt(ctx, connect.NewRequest(&pb.GetTxReceiptRequest{
+		Identifier: &pb.GetTxReceiptRequest_Hash{Hash: txindex.TxHash(txs[1])},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), resp.Msg.Receipt.Index)
+	require.Equal(t, uint32(3), resp.Msg.Receipt.Code)
+	require.Equal(t, "failed", resp.Msg.Receipt.Log)
+
+	resp, err = server.GetTxReceipt(ctx, connect.NewRequest(&pb.GetTxReceiptRequest{
+		Identifier: &pb.GetTxReceiptRequest_Position{Position: &pb.TxPosition{Height: 1, Index: 0}},
+	}))
+	require.NoError(t, err)
+	require.Equal(t, txs[0], resp.Msg.Receipt.Tx)
+
+	_, err = server.GetTxReceipt(ctx, connect.NewRequest(&pb.GetTxReceiptRequest{
+		Identifier: &pb.GetTxReceiptRequest_Position{Position: &pb.TxPosition{Height: 2}},
+	}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+	_, err = server.GetTxReceipt(ctx, connect.NewRequest(&pb.GetTxReceiptRequest{}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestGetLeader(t *testing.T) {
+	// leader election disabled
+	server := NewStatusServer(StatusSources{})
+	resp, err := server.GetLeader(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.False(t, resp.Msg.Enabled)
+	require.Empty(t, resp.Msg.Leader)
+
+	// leader election enabled, no lease acquired yet
+	privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	signer, err := noop.NewNoopSigner(privKey)
+	require.NoError(t, err)
+	elector, err := leader.NewElector(coreda.NewDummyDA(1024, 1, 1), signer, nil, "node", 10, 0, time.Second, log.NewNopLogger())
+	require.NoError(t, err)
+
+	server = NewStatusServer(StatusSources{Elector: elector})
+	resp, err = server.GetLeader(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.True(t, resp.Msg.Enabled)
+	require.Empty(t, resp.Msg.Leader)
+	require.False(t, resp.Msg.IsLeader)
+}
+
+// testConfirmations reports the blocks up to daIncluded as DA finalized, up to attested as attested and up to
+// softConfirmed as soft-confirmed.
+type testConfirmations struct {
+	softConfirmed, attested, daIncluded uint64
+}
+
+func (c testConfirmations) GetBlockConfirmationStatus(height uint64) block.ConfirmationStatus {
+	switch {
+	case height <= c.daIncluded:
+		return block.ConfirmationDAFinalized
+	case height <= c.attested:
+		return block.ConfirmationAttested
+	case height <= c.softConfirmed:
+		return block.ConfirmationSoftConfirmed
+	default:
+		return block.ConfirmationPending
+	}
+}
+
+func (c testConfirmations) GetSoftConfirmedHeight() uint64 { return c.softConfirmed }
+
+func (c testConfirmations) GetAttestedHeight() uint64 { return c.attested }
+
+func (c testConfirmations) GetDAIncludedHeight() uint64 { return c.daIncluded }
+
+func TestGetBlockConfirmationStatus(t *testing.T) {
+	server := NewStatusServer(StatusSources{Confirmations: testConfirmations{softConfirmed: 10, attested: 7, daIncluded: 5}})
+
+	for height, expected := range map[uint64]pb.ConfirmationStatus{
+		5:  pb.ConfirmationStatus_CONFIRMATION_STATUS_DA_FINALIZED,
+		7:  pb.ConfirmationStatus_CONFIRMATION_STATUS_ATTESTED,
+		8:  pb.ConfirmationStatus_CONFIRMATION_STATUS_SOFT_CONFIRMED,
+		11: pb.ConfirmationStatus_CONFIRMATION_STATUS_PENDING,
+	} {
+		resp, err := server.GetBlockConfirmationStatus(context.Background(), connect.NewRequest(&pb.GetBlockConfirmationStatusRequest{Height: height}))
+		require.NoError(t, err)
+		require.Equal(t, height, resp.Msg.Height)
+		require.Equal(t, expected, resp.Msg.Status)
+		require.Equal(t, uint64(10), resp.Msg.SoftConfirmedHeight)
+		require.Equal(t, uint64(5), resp.Msg.DaIncludedHeight)
+		require.Equal(t, uint64(7), resp.Msg.AttestedHeight)
+	}
+
+	_, err := server.GetBlockConfirmationStatus(context.Background(), connect.NewRequest(&pb.GetBlockConfirmationStatusRequest{}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// confirmations not tracked
+	server = NewStatusServer(StatusSources{})
+	_, err = server.GetBlockConfirmationStatus(context.Background(), connect.NewRequest(&pb.GetBlockConfirmationStatusRequest{Height: 1}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+type testGasPrice float64
+
+func (p testGasPrice) GetDAGasPrice() float64 { return float64(p) }
+
+func TestGetDAGasPrice(t *testing.T) {
+	server := NewStatusServer(StatusSources{GasPrices: testGasPrice(2.5)})
+	resp, err := server.GetDAGasPrice(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.Equal(t, 2.5, resp.Msg.GasPrice)
+
+	// DA submissions not tracked
+	server = NewStatusServer(StatusSources{})
+	_, err = server.GetDAGasPrice(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+type testDAFees block.DAFeeReport
+
+func (f testDAFees) GetDAFees() block.DAFeeReport { return block.DAFeeReport(f) }
+
+func TestGetDAFees(t *testing.T) {
+	today := block.DAFees{Fees: 120, Blobs: 3, Bytes: 15}
+	server := NewStatusServer(StatusSources{DAFees: testDAFees{
+		Total:       block.DAFees{Fees: 200, Blobs: 5, Bytes: 25},
+		Today:       today,
+		Days:        []block.DailyDAFees{{Day: "2026-10-14", DAFees: block.DAFees{Fees: 80, Blobs: 2, Bytes: 10}}, {Day: "2026-10-15", DAFees: today}},
+		DailyBudget: 100,
+		Paused:      true,
+	}})
+	resp, err := server.GetDAFees(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.Equal(t, 200.0, resp.Msg.Total.Fees)
+	require.Equal(t, uint64(3), resp.Msg.Today.Blobs)
+	require.Len(t, resp.Msg.Days, 2)
+	require.Equal(t, "2026-10-14", resp.Msg.Days[0].Day)
+	require.Equal(t, uint64(10), resp.Msg.Days[0].Fees.Bytes)
+	require.Equal(t, 100.0, resp.Msg.DailyBudget)
+	require.True(t, resp.Msg.Paused)
+
+	// DA fees not tracked
+	server = NewStatusServer(StatusSources{})
+	_, err = server.GetDAFees(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+type testDAChains []block.DAChainStats
+
+func (c testDAChains) GetDAChainStats() []block.DAChainStats {
+	return c
+}
+
+func TestGetDAChainStats(t *testing.T) {
+	server := NewStatusServer(StatusSources{DAChains: testDAChains{
+		{ChainID: "chain-a", Blobs: 3, Bytes: 300, LastDAHeight: 12},
+		{ChainID: "chain-b", Blobs: 1, Bytes: 50, LastDAHeight: 10},
+	}})
+	resp, err := server.GetDAChainStats(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Chains, 2)
+	require.Equal(t, "chain-a", resp.Msg.Chains[0].ChainId)
+	require.Equal(t, uint64(300), resp.Msg.Chains[0].Bytes)
+	require.Equal(t, uint64(10), resp.Msg.Chains[1].LastDaHeight)
+
+	// DA retrievals not tracked
+	server = NewStatusServer(StatusSources{})
+	_, err = server.GetDAChainStats(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+type testDADryRun map[uint64]*block.DADryRun
+
+func (d testDADryRun) DryRunDASubmission(_ context.Context, height uint64) (*block.DADryRun, error) {
+	dryRun, ok := d[height]
+	if !ok {
+		return nil, fmt.Errorf("failed to load block %d: %w", height, ds.ErrNotFound)
+	}
+	return dryRun, nil
+}
+
+func TestDryRunDASubmission(t *testing.T) {
+	server := NewStatusServer(StatusSources{DADryRun: testDADryRun{
+		1: {Height: 1, Header: block.DABlobEstimate{BlobSizes: []uint64{200}, Bytes: 200, Fees: 400}, GasPrice: 2, Fees: 400},
+		2: {
+			Height:      2,
+			Header:      block.DABlobEstimate{BlobSizes: []uint64{200}, Bytes: 200, Fees: 400},
+			Data:        &block.DABlobEstimate{BlobSizes: []uint64{100, 50}, Bytes: 150, Fees: 300},
+			MaxBlobSize: 100,
+			Fees:        700,
+			Errors:      []error{coreda.ErrBlobSizeOverLimit},
+		},
+	}})
+	resp, err := server.DryRunDASubmission(context.Background(), connect.NewRequest(&pb.DryRunDASubmissionRequest{Height: 1}))
+	require.NoError(t, err)
+	require.Equal(t, []uint64{200}, resp.Msg.Header.BlobSizes)
+	require.Equal(t, 400.0, resp.Msg.Fees)
+	require.Equal(t, 2.0, resp.Msg.GasPrice)
+	require.Nil(t, resp.Msg.Data)
+	require.Empty(t, resp.Msg.Errors)
+
+	resp, err = server.DryRunDASubmission(context.Background(), connect.NewRequest(&pb.DryRunDASubmissionRequest{Height: 2}))
+	require.NoError(t, err)
+	require.Equal(t, uint64(150), resp.Msg.Data.Bytes)
+	require.Equal(t, uint64(100), resp.Msg.MaxBlobSize)
+	require.Equal(t, []string{coreda.ErrBlobSizeOverLimit.Error()}, resp.Msg.Errors)
+
+	_, err = server.DryRunDASubmission(context.Background(), connect.NewRequest(&pb.DryRunDASubmissionRequest{Height: 3}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// DA submissions cannot be simulated
+	server = NewStatusServer(StatusSources{})
+	_, err = server.DryRunDASubmission(context.Background(), connect.NewRequest(&pb.DryRunDASubmissionRequest{Height: 1}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+type testNodeStatus types.NodeStatus
+
+func (s testNodeStatus) Status(context.Context) (types.NodeStatus, error) {
+	return types.NodeStatus(s), nil
+}
+
+func TestGetStatus(t *testing.T) {
+	server := NewStatusServer(StatusSources{Node: testNodeStatus{
+		Mode:             types.NodeModeFull,
+		Height:           12,
+		DAIncludedHeight: 10,
+		DAHeight:         40,
+		DAHeadHeight:     42,
+		Peers:            3,
+		CatchingUp:       true,
+		ExecutorError:    "engine unreachable",
+		DAError:          "da unreachable",
+		P2PListening:     true,
+		SyncLag:          5,
+		Paused:           true,
+	}})
+	resp, err := server.GetStatus(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.Equal(t, types.NodeModeFull, resp.Msg.Mode)
+	require.Equal(t, uint64(12), resp.Msg.Height)
+	require.Equal(t, uint64(10), resp.Msg.DaIncludedHeight)
+	require.Equal(t, uint64(40), resp.Msg.DaHeight)
+	require.Equal(t, uint64(42), resp.Msg.DaHeadHeight)
+	require.Equal(t, uint32(3), resp.Msg.Peers)
+	require.True(t, resp.Msg.CatchingUp)
+	require.Equal(t, "engine unreachable", resp.Msg.ExecutorError)
+	require.Equal(t, "da unreachable", resp.Msg.DaError)
+	require.True(t, resp.Msg.P2PListening)
+	require.Equal(t, uint64(5), resp.Msg.SyncLag)
+	require.True(t, resp.Msg.Paused)
+
+	server = NewStatusServer(StatusSources{})
+	_, err = server.GetStatus(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+type testDAVerification rollkitsync.DAVerificationStatus
+
+func (v testDAVerification) Status() rollkitsync.DAVerificationStatus {
+	return rollkitsync.DAVerificationStatus(v)
+}
+
+func TestGetDAVerification(t *testing.T) {
+	server := NewStatusServer(StatusSources{DAVerification: testDAVerification{VerifiedHeight: 10, DAHeight: 42}})
+	resp, err := server.GetDAVerification(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.True(t, resp.Msg.Enabled)
+	require.Equal(t, uint64(10), resp.Msg.VerifiedHeight)
+	require.Equal(t, uint64(42), resp.Msg.DaHeight)
+
+	// DA verification disabled
+	server = NewStatusServer(StatusSources{})
+	resp, err = server.GetDAVerification(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.False(t, resp.Msg.Enabled)
+}
+
+type testDAInclusion map[uint64]*block.DAInclusionProof
+
+func (d testDAInclusion) GetDAInclusionProof(_ context.Context, height uint64) (*block.DAInclusionProof, error) {
+	if height > 2 {
+		return nil, block.ErrNotDAIncluded
+	}
+	proof, ok := d[height]
+	if !ok {
+		return nil, block.ErrDAInclusionUnknown
+	}
+	return proof, nil
+}
+
+func TestGetDAInclusionProof(t *testing.T) {
+	header := block.DABlobProof{DAHeight: 7, Namespace: "ns", ID: []byte("header_id"), Commitment: []byte("c"), Proof: []byte("proof")}
+	server := NewStatusServer(StatusSources{DAInclusion: testDAInclusion{
+		1: {Height: 1, Header: header},
+		2: {Height: 2, Header: header, Data: &block.DABlobProof{DAHeight: 8, ID: []byte("data_id"), Proof: []byte("data_proof")}},
+	}})
+
+	resp, err := server.GetDAInclusionProof(context.Background(), connect.NewRequest(&pb.GetDAInclusionProofRequest{Height: 1}))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), resp.Msg.Height)
+	require.Equal(t, uint64(7), resp.Msg.Header.DaHeight)
+	require.Equal(t, "ns", resp.Msg.Header.Namespace)
+	require.Equal(t, []byte("header_id"), resp.Msg.Header.Id)
+	require.Equal(t, []byte("c"), resp.Msg.Header.Commitment)
+	require.Equal(t, []byte("proof"), resp.Msg.Header.Proof)
+	require.Nil(t, resp.Msg.Data)
+
+	resp, err = server.GetDAInclusionProof(context.Background(), connect.NewRequest(&pb.GetDAInclusionProofRequest{Height: 2}))
+	require.NoError(t, err)
+	require.Equal(t, uint64(8), resp.Msg.Data.DaHeight)
+	require.Equal(t, []byte("data_proof"), resp.Msg.Data.Proof)
+
+	_, err = server.GetDAInclusionProof(context.Background(), connect.NewRequest(&pb.GetDAInclusionProofRequest{Height: 3}))
+	require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	server = NewStatusServer(StatusSources{DAInclusion: testDAInclusion{}})
+	_, err = server.GetDAInclusionProof(context.Background(), connect.NewRequest(&pb.GetDAInclusionProofRequest{Height: 1}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// DA inclusion proofs not available
+	server = NewStatusServer(StatusSources{})
+	_, err = server.GetDAInclusionProof(context.Background(), connect.NewRequest(&pb.GetDAInclusionProofRequest{Height: 1}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+type testLightClient map[uint64]*block.LightClientUpdate
+
+func (l testLightClient) GetLightClientUpdate(_ context.Context, height uint64) (*block.LightClientUpdate, error) {
+	if height == 0 {
+		height = 1
+	}
+	if height > 1 {
+		return nil, block.ErrNotDAIncluded
+	}
+	update, ok := l[height]
+	if !ok {
+		return nil, block.ErrDAInclusionUnknown
+	}
+	return update, nil
+}
+
+func TestGetLightClientUpdate(t *testing.T) {
+	header, _ := types.GetRandomBlock(1, 1, "test-chain")
+	server := NewStatusServer(StatusSources{LightClient: testLightClient{
+		1: {
+			ConsensusState: block.LightClientConsensusState{Height: 1, Timestamp: header.Time(), Root: header.AppHash, ProposerAddress: header.ProposerAddress},
+			Header:         header,
+			DAHeader:       block.DABlobPointer{DAHeight: 7, Namespace: "ns", ID: []byte("header_id"), Commitment: []byte("c")},
+		},
+	}})
+
+	resp, err := server.GetLightClientUpdate(context.Background(), connect.NewRequest(&pb.GetLightClientUpdateRequest{}))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), resp.Msg.ConsensusState.Height)
+	require.True(t, header.Time().Equal(resp.Msg.ConsensusState.Timestamp.AsTime()))
+	require.Equal(t, []byte(header.AppHash), resp.Msg.ConsensusState.Root)
+	require.Equal(t, header.ProposerAddress, resp.Msg.ConsensusState.ProposerAddress)
+	var signedHeader types.SignedHeader
+	require.NoError(t, signedHeader.FromProto(resp.Msg.SignedHeader))
+	require.Equal(t, header.Hash(), signedHeader.Hash())
+	require.NoError(t, signedHeader.ValidateBasic())
+	require.Equal(t, uint64(7), resp.Msg.DaHeader.DaHeight)
+	require.Equal(t, []byte("header_id"), resp.Msg.DaHeader.Id)
+	require.Nil(t, resp.Msg.DaData)
+
+	_, err = server.GetLightClientUpdate(context.Background(), connect.NewRequest(&pb.GetLightClientUpdateRequest{Height: 2}))
+	require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+	server = NewStatusServer(StatusSources{LightClient: testLightClient{}})
+	_, err = server.GetLightClientUpdate(context.Background(), connect.NewRequest(&pb.GetLightClientUpdateRequest{Height: 1}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// light client updates not available
+	server = NewStatusServer(StatusSources{})
+	_, err = server.GetLightClientUpdate(context.Background(), connect.NewRequest(&pb.GetLightClientUpdateRequest{Height: 1}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+type testHeaderRanges []*types.SignedHeader
+
+func (h testHeaderRanges) GetHeadersRange(_ context.Context, from, to uint64) ([]*types.SignedHeader, error) {
+	if from == 0 || to < from {
+		return nil, rollkitsync.ErrInvalidHeaderRange
+	}
+	if to > uint64(len(h)) {
+		return nil, rollkitsync.ErrHeaderRangeUnavailable
+	}
+	return h[from-1 : to], nil
+}
+
+func TestGetHeadersRange(t *testing.T) {
+	header1, _ := types.GetRandomBlock(1, 1, "test-chain")
+	header2, _ := types.GetRandomBlock(2, 1, "test-chain")
+	server := NewStatusServer(StatusSources{HeaderRanges: testHeaderRanges{header1, header2}})
+
+	resp, err := server.GetHeadersRange(context.Background(), connect.NewRequest(&pb.GetHeadersRangeRequest{From: 1, To: 2}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Headers, 2)
+	for i, header := range []*types.SignedHeader{header1, header2} {
+		var signedHeader types.SignedHeader
+		require.NoError(t, signedHeader.FromProto(resp.Msg.Headers[i]))
+		require.Equal(t, header.Hash(), signedHeader.Hash())
+	}
+
+	_, err = server.GetHeadersRange(context.Background(), connect.NewRequest(&pb.GetHeadersRangeRequest{From: 2, To: 1}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = server.GetHeadersRange(context.Background(), connect.NewRequest(&pb.GetHeadersRangeRequest{From: 1, To: 3}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	// header ranges not available
+	server = NewStatusServer(StatusSources{})
+	_, err = server.GetHeadersRange(context.Background(), connect.NewRequest(&pb.GetHeadersRangeRequest{From: 1, To: 1}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+func TestSetLogLevel(t *testing.T) {
+	levels := logging.NewLevels(zerolog.InfoLevel)
+	server := NewAdminServer(AdminSources{Levels: levels})
+	resp, err := server.SetLogLevel(context.Background(), connect.NewRequest(&pb.SetLogLevelRequest{Levels: "block=debug p2p=warn"}))
+	require.NoError(t, err)
+	require.Equal(t, "info block=debug p2p=warn", resp.Msg.Levels)
+	require.Equal(t, zerolog.DebugLevel, levels.Level(logging.ModuleBlock))
+
+	_, err = server.SetLogLevel(context.Background(), connect.NewRequest(&pb.SetLogLevelRequest{Levels: "block=verbose"}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	resp, err = server.GetLogLevels(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.Equal(t, "info block=debug p2p=warn", resp.Msg.Levels)
+
+	// log levels cannot be changed
+	server = NewAdminServer(AdminSources{})
+	_, err = server.GetLogLevels(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+func TestCaptureDebugBundle(t *testing.T) {
+	logs := logging.NewRecent(10)
+	_, err := logs.Write([]byte("recent log line\n"))
+	require.NoError(t, err)
+
+	server := NewAdminServer(AdminSources{Logs: logs, Token: "secret"})
+	resp, err := server.CaptureDebugBundle(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Msg.Bundle)
+	require.Regexp(t, `^debug-.*\.tar\.gz$`, resp.Msg.Name)
+
+	// debug bundles expose the logs of the node, and require an admin token
+	server = NewAdminServer(AdminSources{Logs: logs})
+	_, err = server.CaptureDebugBundle(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+func TestStoreMaintenance(t *testing.T) {
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	require.NoError(t, kv.Put(context.Background(), ds.NewKey("/0/h/1"), []byte("header")))
+	server := NewAdminServer(AdminSources{Maintainer: store.NewMaintainer(kv, 0, log.NewNopLogger())})
+
+	resp, err := server.CompactStore(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Prefixes, 1)
+	require.Equal(t, "/0/h", resp.Msg.Prefixes[0].Prefix)
+	require.Equal(t, uint64(1), resp.Msg.Prefixes[0].Keys)
+	require.Equal(t, uint64(len("/0/h/1")+len("header")), resp.Msg.Prefixes[0].Bytes)
+
+	// the datastore cannot be compacted
+	server = NewAdminServer(AdminSources{})
+	_, err = server.GetStoreUsage(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+// testConfigManager applies runtime config updates without running services.
+type testConfigManager struct {
+	conf config.Config
+}
+
+func (m *testConfigManager) Config() config.Config {
+	return m.conf
+}
+
+func (m *testConfigManager) UpdateConfig(_ context.Context, update config.RuntimeUpdate) (config.Config, error) {
+	updated, err := update.Apply(m.conf)
+	if err != nil {
+		return m.conf, err
+	}
+	m.conf = updated
+	return updated, nil
+}
+
+func TestUpdateConfig(t *testing.T) {
+	configs := &testConfigManager{conf: config.DefaultConfig}
+	handler, err := NewServiceHandler(nil, nil, nil, StatusSources{}, nil, TxSources{}, AdminSources{Config: configs, Token: "secret"})
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// admin requests without the admin token are rejected
+	unauthenticated := rpc.NewAdminServiceClient(http.DefaultClient, server.URL)
+	_, err = unauthenticated.GetConfig(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	client := rpc.NewAdminServiceClient(http.DefaultClient, server.URL, connect.WithInterceptors(rpcclient.AdminTokenInterceptor("secret")))
+	resp, err := client.GetConfig(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.Equal(t, config.DefaultConfig.Node.BlockTime.Duration, resp.Msg.BlockTime.AsDuration())
+	require.Equal(t, config.DefaultConfig.DA.GasPrice, resp.Msg.DaGasPrice)
+
+	lazyMode := true
+	maxPeers := uint64(5)
+	resp, err = client.UpdateConfig(context.Background(), connect.NewRequest(&pb.UpdateConfigRequest{
+		BlockTime: durationpb.New(2 * time.Second),
+		LazyMode:  &lazyMode,
+		MaxPeers:  &maxPeers,
+	}))
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Second, resp.Msg.BlockTime.AsDuration())
+	require.True(t, resp.Msg.LazyMode)
+	require.Equal(t, maxPeers, resp.Msg.MaxPeers)
+	// unset fields are left unchanged
+	require.Equal(t, config.DefaultConfig.Node.LazyBlockInterval.Duration, resp.Msg.LazyBlockInterval.AsDuration())
+	require.Equal(t, 2*time.Second, configs.conf.Node.BlockTime.Duration)
+
+	_, err = client.UpdateConfig(context.Background(), connect.NewRequest(&pb.UpdateConfigRequest{BlockTime: durationpb.New(0)}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	require.Equal(t, 2*time.Second, configs.conf.Node.BlockTime.Duration)
+
+	// runtime config changes are disabled without an admin token
+	admin := NewAdminServer(AdminSources{Config: configs})
+	_, err = admin.GetConfig(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	_, err = admin.UpdateConfig(context.Background(), connect.NewRequest(&pb.UpdateConfigRequest{MaxPeers: &maxPeers}))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+}
+
+// testKeyRotator accepts rotations at heights after 10.
+type testKeyRotator struct{}
+
+func (testKeyRotator) RotateProposerKey(_ context.Context, newKey crypto.PubKey, height uint64) (*types.KeyRotation, error) {
+	if height <= 10 {
+		return nil, types.ErrInvalidKeyRotation
+	}
+	return &types.KeyRotation{Height: height, NewKey: newKey}, nil
+}
+
+func TestRotateProposerKey(t *testing.T) {
+	_, pubKey, err := crypto.GenerateEd25519Key(nil)
+	require.NoError(t, err)
+	pubKeyBytes, err := crypto.MarshalPublicKey(pubKey)
+	require.NoError(t, err)
+
+	admin := NewAdminServer(AdminSources{Rotator: testKeyRotator{}, Token: "secret"})
+	resp, err := admin.RotateProposerKey(context.Background(), connect.NewRequest(&pb.RotateProposerKeyRequest{NewPubKey: pubKeyBytes, Height: 11}))
+	require.NoError(t, err)
+	require.Equal(t, uint64(11), resp.Msg.Height)
+	require.Equal(t, types.KeyAddress(pubKey), resp.Msg.ProposerAddress)
+
+	_, err = admin.RotateProposerKey(context.Background(), connect.NewRequest(&pb.RotateProposerKeyRequest{NewPubKey: pubKeyBytes, Height: 10}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = admin.RotateProposerKey(context.Background(), connect.NewRequest(&pb.RotateProposerKeyRequest{NewPubKey: []byte("invalid"), Height: 11}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// key rotations are disabled without an admin token, and on nodes which are not aggregators
+	admin = NewAdminServer(AdminSources{Rotator: testKeyRotator{}})
+	_, err = admin.RotateProposerKey(context.Background(), connect.NewRequest(&pb.RotateProposerKeyRequest{NewPubKey: pubKeyBytes, Height: 11}))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	admin = NewAdminServer(AdminSources{Token: "secret"})
+	_, err = admin.RotateProposerKey(context.Background(), connect.NewRequest(&pb.RotateProposerKeyRequest{NewPubKey: pubKeyBytes, Height: 11}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+// testUpgrader accepts upgrades at heights after 10.
+type testUpgrader struct{}
+
+func (testUpgrader) ScheduleUpgrade(_ context.Context, upgrade types.Upgrade) (*types.Upgrade, error) {
+	if upgrade.Height <= 10 {
+		return nil, types.ErrInvalidUpgrade
+	}
+	return &upgrade, nil
+}
+
+func TestScheduleUpgrade(t *testing.T) {
+	req := &pb.ScheduleUpgradeRequest{Name: "v2", Height: 11, BlockVersion: 2}
+	admin := NewAdminServer(AdminSources{Upgrader: testUpgrader{}, Token: "secret"})
+	resp, err := admin.ScheduleUpgrade(context.Background(), connect.NewRequest(req))
+	require.NoError(t, err)
+	require.Equal(t, "v2", resp.Msg.Name)
+	require.Equal(t, uint64(11), resp.Msg.Height)
+
+	_, err = admin.ScheduleUpgrade(context.Background(), connect.NewRequest(&pb.ScheduleUpgradeRequest{Name: "v2", Height: 10, BlockVersion: 2}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// upgrades are disabled without an admin token, and on nodes which are not aggregators
+	admin = NewAdminServer(AdminSources{Upgrader: testUpgrader{}})
+	_, err = admin.ScheduleUpgrade(context.Background(), connect.NewRequest(req))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	admin = NewAdminServer(AdminSources{Token: "secret"})
+	_, err = admin.ScheduleUpgrade(context.Background(), connect.NewRequest(req))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+// testModeSwitcher runs in light mode.
+type testModeSwitcher struct{}
+
+func (testModeSwitcher) SwitchMode(mode string) error {
+	if mode != types.NodeModeFull {
+		return fmt.Errorf("node already runs in %s mode", mode)
+	}
+	return nil
+}
+
+func TestSwitchMode(t *testing.T) {
+	admin := NewAdminServer(AdminSources{Modes: testModeSwitcher{}, Token: "secret"})
+	resp, err := admin.SwitchMode(context.Background(), connect.NewRequest(&pb.SwitchModeRequest{Mode: types.NodeModeFull}))
+	require.NoError(t, err)
+	require.Equal(t, types.NodeModeFull, resp.Msg.Mode)
+
+	_, err = admin.SwitchMode(context.Background(), connect.NewRequest(&pb.SwitchModeRequest{Mode: types.NodeModeLight}))
+	require.Equal(t, connect.CodeFailedPrecondition, connect.CodeOf(err))
+
+	// mode switches are disabled without an admin token, and on nodes which are not in dual mode
+	admin = NewAdminServer(AdminSources{Modes: testModeSwitcher{}})
+	_, err = admin.SwitchMode(context.Background(), connect.NewRequest(&pb.SwitchModeRequest{Mode: types.NodeModeFull}))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	admin = NewAdminServer(AdminSources{Token: "secret"})
+	_, err = admin.SwitchMode(context.Background(), connect.NewRequest(&pb.SwitchModeRequest{Mode: types.NodeModeFull}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+// testBlockProducer fails to pause while DA submissions fail.
+type testBlockProducer struct {
+	paused   bool
+	daFailed bool
+}
+
+func (p *testBlockProducer) PauseBlockProduction(context.Context) error {
+	p.paused = true
+	if p.daFailed {
+		return errors.New("blocks are still waiting for DA submission")
+	}
+	return nil
+}
+
+func (p *testBlockProducer) ResumeBlockProduction() { p.paused = false }
+
+func (p *testBlockProducer) BlockProductionPaused() bool { return p.paused }
+
+func (p *testBlockProducer) GetStoreHeight(context.Context) (uint64, error) { return 7, nil }
+
+func TestPauseSequencer(t *testing.T) {
+	producer := &testBlockProducer{}
+	admin := NewAdminServer(AdminSources{Producer: producer, Token: "secret"})
+	resp, err := admin.PauseSequencer(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.True(t, resp.Msg.Paused)
+	require.Equal(t, uint64(7), resp.Msg.Height)
+
+	resp, err = admin.ResumeSequencer(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.False(t, resp.Msg.Paused)
+	require.Equal(t, uint64(7), resp.Msg.Height)
+
+	// block production stays paused if the DA submissions do not complete
+	producer.daFailed = true
+	_, err = admin.PauseSequencer(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(t, connect.CodeAborted, connect.CodeOf(err))
+	require.True(t, producer.paused)
+
+	// pausing block production is disabled without an admin token, and on nodes which are not aggregators
+	admin = NewAdminServer(AdminSources{Producer: producer})
+	_, err = admin.PauseSequencer(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	_, err = admin.ResumeSequencer(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(t, connect.CodePermissionDenied, connect.CodeOf(err))
+	admin = NewAdminServer(AdminSources{Token: "secret"})
+	_, err = admin.PauseSequencer(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+type testTxSubmitter struct {
+	mu  sync.Mutex
+	txs [][]byte
+}
+
+func (s *testTxSubmitter) SubmitTx(_ context.Context, tx []byte) ([]byte, error) {
+	switch string(tx) {
+	case "invalid":
+		return nil, fmt.Errorf("%w: malformed", mempool.ErrTxRejected)
+	case "too large":
+		return nil, fmt.Errorf("%w: 9 bytes, maximum 8", mempool.ErrTxTooLarge)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.txs = append(s.txs, tx)
+	return txindex.TxHash(tx), nil
+}
+
+func (s *testTxSubmitter) submitted() [][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.txs
+}
+
+func TestSubmitTx(t *testing.T) {
+	submitter := &testTxSubmitter{}
+	server := NewTxServer(TxSources{Submitter: submitter})
+	resp, err := server.SubmitTx(context.Background(), connect.NewRequest(&pb.SubmitTxRequest{Tx: []byte("tx1")}))
+	require.NoError(t, err)
+	require.Equal(t, txindex.TxHash([]byte("tx1")), resp.Msg.Hash)
+	require.Equal(t, [][]byte{[]byte("tx1")}, submitter.submitted())
+
+	_, err = server.SubmitTx(context.Background(), connect.NewRequest(&pb.SubmitTxRequest{}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	_, err = server.SubmitTx(context.Background(), connect.NewRequest(&pb.SubmitTxRequest{Tx: []byte("invalid")}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// rejected transactions are reported with the reason of the rejection
+	for tx, reason := range map[string]pb.TxRejectionReason{
+		"":          pb.TxRejectionReason_TX_REJECTION_REASON_EMPTY,
+		"invalid":   pb.TxRejectionReason_TX_REJECTION_REASON_REJECTED_BY_APP,
+		"too large": pb.TxRejectionReason_TX_REJECTION_REASON_TOO_LARGE,
+	} {
+		_, err = server.SubmitTx(context.Background(), connect.NewRequest(&pb.SubmitTxRequest{Tx: []byte(tx)}))
+		var connectErr *connect.Error
+		require.ErrorAs(t, err, &connectErr)
+		require.Len(t, connectErr.Details(), 1)
+		value, err := connectErr.Details()[0].Value()
+		require.NoError(t, err)
+		rejection, ok := value.(*pb.TxRejection)
+		require.True(t, ok)
+		require.Equal(t, reason, rejection.Reason)
+		require.NotEmpty(t, rejection.Message)
+	}
+
+	// transactions are not accepted
+	server = NewTxServer(TxSources{})
+	_, err = server.SubmitTx(context.Background(), connect.NewRequest(&pb.SubmitTxRequest{Tx: []byte("tx1")}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+// testPreconfirmations issues preconfirmations with a signer, and reports them as included at height 2.
+type testPreconfirmations struct {
+	signer *noop.NoopSigner
+}
+
+func (p testPreconfirmations) Preconfirm(_ context.Context, tx []byte) (*types.Preconfirmation, error) {
+	if string(tx) == "disabled" {
+		return nil, block.ErrPreconfirmationsDisabled
+	}
+	return types.GetPreconfirmation(p.signer, types.Preconfirmation{
+		ChainID:   "test",
+		TxHash:    txindex.TxHash(tx),
+		Height:    1,
+		MaxHeight: 3,
+		Timestamp: time.Now(),
+	})
+}
+
+func (p testPreconfirmations) VerifyPreconfirmation(_ context.Context, preconf *types.Preconfirmation) (block.PreconfirmationResult, error) {
+	if err := preconf.ValidateBasic(); err != nil {
+		return block.PreconfirmationResult{}, err
+	}
+	return block.PreconfirmationResult{Status: block.PreconfirmationIncluded, Height: 2}, nil
+}
+
+func TestPreconfirmations(t *testing.T) {
+	ctx := context.Background()
+	privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	signer, err := noop.NewNoopSigner(privKey)
+	require.NoError(t, err)
+	server := NewTxServer(TxSources{
+		Submitter:        &testTxSubmitter{},
+		Preconfirmations: testPreconfirmations{signer: signer.(*noop.NoopSigner)},
+	})
+
+	resp, err := server.SubmitTx(ctx, connect.NewRequest(&pb.SubmitTxRequest{Tx: []byte("tx1")}))
+	require.NoError(t, err)
+	require.NotNil(t, resp.Msg.Preconfirmation)
+	require.Equal(t, resp.Msg.Hash, resp.Msg.Preconfirmation.TxHash)
+	require.Equal(t, uint64(3), resp.Msg.Preconfirmation.MaxHeight)
+
+	verified, err := server.VerifyPreconfirmation(ctx, connect.NewRequest(&pb.VerifyPreconfirmationRequest{
+		Preconfirmation: resp.Msg.Preconfirmation,
+	}))
+	require.NoError(t, err)
+	require.Equal(t, pb.PreconfirmationStatus_PRECONFIRMATION_STATUS_INCLUDED, verified.Msg.Status)
+	require.Equal(t, uint64(2), verified.Msg.Height)
+
+	// tampered preconfirmations are invalid
+	resp.Msg.Preconfirmation.MaxHeight = 10
+	_, err = server.VerifyPreconfirmation(ctx, connect.NewRequest(&pb.VerifyPreconfirmationRequest{
+		Preconfirmation: resp.Msg.Preconfirmation,
+	}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// transactions are accepted without preconfirmation if the node does not issue them
+	resp, err = server.SubmitTx(ctx, connect.NewRequest(&pb.SubmitTxRequest{Tx: []byte("disabled")}))
+	require.NoError(t, err)
+	require.Nil(t, resp.Msg.Preconfirmation)
+
+	server = NewTxServer(TxSources{})
+	_, err = server.VerifyPreconfirmation(ctx, connect.NewRequest(&pb.VerifyPreconfirmationRequest{}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+func TestBroadcastTxAsync(t *testing.T) {
+	submitter := &testTxSubmitter{}
+	server := NewTxServer(TxSources{Submitter: submitter})
+	resp, err := server.BroadcastTxAsync(context.Background(), connect.NewRequest(&pb.SubmitTxRequest{Tx: []byte("tx1")}))
+	require.NoError(t, err)
+	require.Equal(t, txindex.TxHash([]byte("tx1")), resp.Msg.Hash)
+	require.Eventually(t, func() bool { return len(submitter.submitted()) == 1 }, time.Second, 10*time.Millisecond)
+
+	// rejected transactions are not reported
+	_, err = server.BroadcastTxAsync(context.Background(), connect.NewRequest(&pb.SubmitTxRequest{Tx: []byte("invalid")}))
+	require.NoError(t, err)
+	_, err = server.BroadcastTxAsync(context.Background(), connect.NewRequest(&pb.SubmitTxRequest{}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	server = NewTxServer(TxSources{})
+	_, err = server.BroadcastTxAsync(context.Background(), connect.NewRequest(&pb.SubmitTxRequest{Tx: []byte("tx1")}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+func TestBroadcastTxCommit(t *testing.T) {
+	ctx := context.Background()
+	included := &pb.TxResult{Hash: txindex.TxHash([]byte("tx1")), Height: 2, Index: 1, Tx: []byte("tx1"), Code: 3, Log: "failed"}
+	sources := TxSources{
+		Submitter:     &testTxSubmitter{},
+		Index:         testTxIndex{string(included.Hash): included},
+		Events:        newTestEventSource(),
+		Confirmations: testConfirmations{softConfirmed: 2, daIncluded: 1},
+		CommitTimeout: time.Second,
+	}
+	server := NewTxServer(sources)
+
+	resp, err := server.BroadcastTxCommit(ctx, connect.NewRequest(&pb.BroadcastTxCommitRequest{Tx: []byte("tx1")}))
+	require.NoError(t, err)
+	require.Equal(t, included, resp.Msg.Result)
+	require.Equal(t, pb.ConfirmationStatus_CONFIRMATION_STATUS_SOFT_CONFIRMED, resp.Msg.Confirmation)
+
+	// the block of the transaction is not DA included before the timeout
+	sources.Events = newTestEventSource()
+	server = NewTxServer(sources)
+	_, err = server.BroadcastTxCommit(ctx, connect.NewRequest(&pb.BroadcastTxCommitRequest{
+		Tx:           []byte("tx1"),
+		Confirmation: pb.ConfirmationStatus_CONFIRMATION_STATUS_DA_FINALIZED,
+		Timeout:      durationpb.New(50 * time.Millisecond),
+	}))
+	require.Equal(t, connect.CodeDeadlineExceeded, connect.CodeOf(err))
+
+	sources.Events = newTestEventSource()
+	sources.Confirmations = testConfirmations{softConfirmed: 2, attested: 2, daIncluded: 1}
+	server = NewTxServer(sources)
+	resp, err = server.BroadcastTxCommit(ctx, connect.NewRequest(&pb.BroadcastTxCommitRequest{
+		Tx:           []byte("tx1"),
+		Confirmation: pb.ConfirmationStatus_CONFIRMATION_STATUS_ATTESTED,
+	}))
+	require.NoError(t, err)
+	require.Equal(t, pb.ConfirmationStatus_CONFIRMATION_STATUS_ATTESTED, resp.Msg.Confirmation)
+
+	sources.Events = newTestEventSource()
+	sources.Confirmations = testConfirmations{softConfirmed: 2, daIncluded: 2}
+	server = NewTxServer(sources)
+	resp, err = server.BroadcastTxCommit(ctx, connect.NewRequest(&pb.BroadcastTxCommitRequest{
+		Tx:           []byte("tx1"),
+		Confirmation: pb.ConfirmationStatus_CONFIRMATION_STATUS_DA_FINALIZED,
+	}))
+	require.NoError(t, err)
+	require.Equal(t, pb.ConfirmationStatus_CONFIRMATION_STATUS_DA_FINALIZED, resp.Msg.Confirmation)
+
+	// transactions are waited for until the timeout of the node
+	sources.Events = newTestEventSource()
+	sources.CommitTimeout = 50 * time.Millisecond
+	server = NewTxServer(sources)
+	_, err = server.BroadcastTxCommit(ctx, connect.NewRequest(&pb.BroadcastTxCommitRequest{
+		Tx:      []byte("tx2"),
+		Timeout: durationpb.New(time.Minute),
+	}))
+	require.Equal(t, connect.CodeDeadlineExceeded, connect.CodeOf(err))
+
+	sources.Events = newTestEventSource()
+	server = NewTxServer(sources)
+	_, err = server.BroadcastTxCommit(ctx, connect.NewRequest(&pb.BroadcastTxCommitRequest{Tx: []byte("invalid")}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	// transactions cannot be tracked without index
+	server = NewTxServer(TxSources{Submitter: &testTxSubmitter{}})
+	_, err = server.BroadcastTxCommit(ctx, connect.NewRequest(&pb.BroadcastTxCommitRequest{Tx: []byte("tx1")}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
+
+func TestUnconfirmedTxs(t *testing.T) {
+	mp, err := mempool.New(config.MempoolConfig{Size: 200}, coreexecutor.NewDummyExecutor(), log.NewNopLogger())
+	require.NoError(t, err)
+	for i := range 150 {
+		_, err := mp.Add(context.Background(), []byte(fmt.Sprintf("tx%03d", i)))
+		require.NoError(t, err)
+	}
+	server := NewTxServer(TxSources{Mempool: mp})
+
+	resp, err := server.UnconfirmedTxs(context.Background(), connect.NewRequest(&pb.UnconfirmedTxsRequest{}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Txs, defaultUnconfirmedTxsLimit)
+	require.Equal(t, uint64(150), resp.Msg.Count)
+	require.Equal(t, uint64(150*5), resp.Msg.TotalBytes)
+	require.Equal(t, []byte("tx000"), resp.Msg.Txs[0].Tx)
+	require.Equal(t, txindex.TxHash([]byte("tx000")), resp.Msg.Txs[0].Hash)
+	require.NotNil(t, resp.Msg.Txs[0].ReceivedAt)
+
+	resp, err = server.UnconfirmedTxs(context.Background(), connect.NewRequest(&pb.UnconfirmedTxsRequest{Limit: 1000}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Txs, maxUnconfirmedTxsLimit)
+
+	num, err := server.NumUnconfirmedTxs(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+	require.Equal(t, uint64(150), num.Msg.Count)
+	require.Equal(t, uint64(150*5), num.Msg.TotalBytes)
+
+	// the mempool is not available
+	server = NewTxServer(TxSources{})
+	_, err = server.UnconfirmedTxs(context.Background(), connect.NewRequest(&pb.UnconfirmedTxsRequest{}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+	_, err = server.NumUnconfirmedTxs(context.Background(), connect.NewRequest(&emptypb.Empty{}))
+	require.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+}
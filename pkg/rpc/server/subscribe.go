@@ -0,0 +1,239 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rollkit/rollkit/block"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+const (
+	// SubscribePath is the path of the WebSocket endpoint streaming node events.
+	SubscribePath = "/subscribe"
+
+	// subscriptionBuffer is the number of events buffered for a WebSocket subscriber before it is disconnected
+	subscriptionBuffer = 100
+
+	wsWriteTimeout = 10 * time.Second
+	wsPongTimeout  = 60 * time.Second
+	wsPingInterval = wsPongTimeout * 9 / 10
+)
+
+// EventSource provides the events streamed by the subscribe endpoint. It is implemented by block.Manager.
+type EventSource interface {
+	Subscribe(buffer int) (<-chan block.Event, func())
+}
+
+// EventMessage is the JSON message sent to WebSocket subscribers for every event.
+type EventMessage struct {
+	Type   block.EventType `json:"type"`
+	Height uint64          `json:"height"`
+	// Block is only set for new_block and block_produced events.
+	Block *BlockEventInfo `json:"block,omitempty"`
+	// DAHeight is only set for blob_submitted events.
+	DAHeight uint64 `json:"da_height,omitempty"`
+}
+
+// BlockEventInfo describes the block of a new_block, block_produced or block_executed event.
+type BlockEventInfo struct {
+	Hash   string    `json:"hash"`
+	Time   time.Time `json:"time"`
+	NumTxs int       `json:"num_txs"`
+}
+
+// SubscribeHandler streams node events to WebSocket clients. Clients may restrict the streamed
+// events with the events query parameter, e.g. /subscribe?events=new_block,da_included.
+type SubscribeHandler struct {
+	events   EventSource
+	upgrader websocket.Upgrader
+}
+
+// NewSubscribeHandler creates a new SubscribeHandler instance
+func NewSubscribeHandler(events EventSource) *SubscribeHandler {
+	return &SubscribeHandler{
+		events: events,
+		upgrader: websocket.Upgrader{
+			// events are public chain data, allow explorers served from other origins
+			CheckOrigin: func(*http.Request) bool { return true },
+		},
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (h *SubscribeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseEventFilter(r.URL.Query().Get("events"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// the upgrader already replied with an error
+		return
+	}
+	defer conn.Close() //nolint:errcheck
+
+	events, cancel := h.events.Subscribe(subscriptionBuffer)
+	defer cancel()
+
+	// the reader detects closed connections and handles pongs, clients are not expected to send messages
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		conn.SetReadLimit(512)
+		_ = conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				msg := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "subscriber too slow")
+				_ = conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(wsWriteTimeout))
+				return
+			}
+			if len(filter) > 0 && !filter[event.Type] {
+				continue
+			}
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := conn.WriteJSON(newEventMessage(event)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func newEventMessage(event block.Event) EventMessage {
+	msg := EventMessage{Type: event.Type, Height: event.Height, DAHeight: event.DAHeight}
+	if event.Type == block.EventNewBlock || event.Type == block.EventBlockProduced || event.Type == block.EventBlockExecuted {
+		msg.Block = &BlockEventInfo{
+			Hash:   event.Hash.String(),
+			Time:   event.Time,
+			NumTxs: event.NumTxs,
+		}
+	}
+	return msg
+}
+
+// EventServer implements the EventService defined in the proto file
+type EventServer struct {
+	events EventSource
+}
+
+// NewEventServer creates a new EventServer instance. If events is nil, events cannot be streamed.
+func NewEventServer(events EventSource) *EventServer {
+	return &EventServer{
+		events: events,
+	}
+}
+
+// Subscribe implements the EventService.Subscribe RPC
+func (e *EventServer) Subscribe(
+	ctx context.Context,
+	req *connect.Request[pb.SubscribeRequest],
+	stream *connect.ServerStream[pb.NodeEvent],
+) error {
+	if e.events == nil {
+		return connect.NewError(connect.CodeUnimplemented, fmt.Errorf("events are not available on this node"))
+	}
+	filter := make(map[block.EventType]bool)
+	for _, eventType := range req.Msg.Types {
+		t, ok := blockEventTypes[eventType]
+		if !ok {
+			return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("unknown event type %v", eventType))
+		}
+		filter[t] = true
+	}
+
+	events, cancel := e.events.Subscribe(subscriptionBuffer)
+	defer cancel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("subscriber too slow"))
+			}
+			if len(filter) > 0 && !filter[event.Type] {
+				continue
+			}
+			if err := stream.Send(newNodeEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// blockEventTypes maps the event types of the EventService to the event types of the Manager
+var blockEventTypes = map[pb.EventType]block.EventType{
+	pb.EventType_EVENT_TYPE_NEW_BLOCK:           block.EventNewBlock,
+	pb.EventType_EVENT_TYPE_SOFT_CONFIRMED:      block.EventSoftConfirmed,
+	pb.EventType_EVENT_TYPE_DA_INCLUDED:         block.EventDAIncluded,
+	pb.EventType_EVENT_TYPE_BLOCK_PRODUCED:      block.EventBlockProduced,
+	pb.EventType_EVENT_TYPE_BLOB_SUBMITTED:      block.EventBlobSubmitted,
+	pb.EventType_EVENT_TYPE_SYNC_CAUGHT_UP:      block.EventSyncCaughtUp,
+	pb.EventType_EVENT_TYPE_DA_REORG:            block.EventDAReorg,
+	pb.EventType_EVENT_TYPE_BLOCK_EXECUTED:      block.EventBlockExecuted,
+	pb.EventType_EVENT_TYPE_DA_BUDGET_EXHAUSTED: block.EventDABudgetExhausted,
+	pb.EventType_EVENT_TYPE_ATTESTED:            block.EventAttested,
+}
+
+func newNodeEvent(event block.Event) *pb.NodeEvent {
+	msg := &pb.NodeEvent{Height: event.Height, DaHeight: event.DAHeight}
+	for eventType, t := range blockEventTypes {
+		if t == event.Type {
+			msg.Type = eventType
+		}
+	}
+	if event.Type == block.EventNewBlock || event.Type == block.EventBlockProduced || event.Type == block.EventBlockExecuted {
+		msg.Hash = event.Hash
+		msg.Time = timestamppb.New(event.Time)
+		msg.NumTxs = uint64(event.NumTxs)
+	}
+	return msg
+}
+
+// parseEventFilter parses a comma separated list of event types, an empty list matches all events.
+func parseEventFilter(query string) (map[block.EventType]bool, error) {
+	filter := make(map[block.EventType]bool)
+	if query == "" {
+		return filter, nil
+	}
+	for _, name := range strings.Split(query, ",") {
+		switch eventType := block.EventType(strings.TrimSpace(name)); eventType {
+		case block.EventNewBlock, block.EventSoftConfirmed, block.EventDAIncluded,
+			block.EventBlockProduced, block.EventBlobSubmitted, block.EventSyncCaughtUp, block.EventDAReorg,
+			block.EventBlockExecuted, block.EventDABudgetExhausted, block.EventAttested:
+			filter[eventType] = true
+		default:
+			return nil, fmt.Errorf("unknown event type %q", name)
+		}
+	}
+	return filter, nil
+}
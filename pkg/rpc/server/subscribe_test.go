@@ -0,0 +1,146 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/rollkit/rollkit/block"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+	rpc "github.com/rollkit/rollkit/types/pb/rollkit/v1/v1connect"
+)
+
+// testEventSource hands out a single subscription fed by the test.
+type testEventSource struct {
+	ch       chan block.Event
+	canceled chan struct{}
+}
+
+func newTestEventSource() *testEventSource {
+	return &testEventSource{ch: make(chan block.Event, 10), canceled: make(chan struct{})}
+}
+
+func (s *testEventSource) Subscribe(int) (<-chan block.Event, func()) {
+	return s.ch, func() { close(s.canceled) }
+}
+
+func dialSubscribe(t *testing.T, server *httptest.Server, query string) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + SubscribePath + query
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func TestSubscribe(t *testing.T) {
+	source := newTestEventSource()
+	handler, err := NewServiceHandler(nil, nil, nil, StatusSources{}, source, TxSources{}, AdminSources{})
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	conn := dialSubscribe(t, server, "?events=new_block,da_included")
+
+	hash := types.Hash(types.GetRandomBytes(32))
+	blockTime := time.Now().UTC().Truncate(time.Second)
+	source.ch <- block.Event{Type: block.EventNewBlock, Height: 7, Hash: hash, Time: blockTime, NumTxs: 2}
+	source.ch <- block.Event{Type: block.EventSoftConfirmed, Height: 7}
+	source.ch <- block.Event{Type: block.EventDAIncluded, Height: 5}
+
+	var msg EventMessage
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, EventMessage{
+		Type:   block.EventNewBlock,
+		Height: 7,
+		Block:  &BlockEventInfo{Hash: hash.String(), Time: blockTime, NumTxs: 2},
+	}, msg)
+
+	// soft_confirmed events are filtered out
+	msg = EventMessage{}
+	require.NoError(t, conn.ReadJSON(&msg))
+	assert.Equal(t, EventMessage{Type: block.EventDAIncluded, Height: 5}, msg)
+
+	// closing the connection cancels the subscription
+	require.NoError(t, conn.Close())
+	select {
+	case <-source.canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription not canceled")
+	}
+}
+
+func TestSubscribeSlowSubscriber(t *testing.T) {
+	source := newTestEventSource()
+	server := httptest.NewServer(NewSubscribeHandler(source))
+	defer server.Close()
+
+	conn := dialSubscribe(t, server, "")
+	close(source.ch)
+
+	_, _, err := conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	require.ErrorAs(t, err, &closeErr)
+	assert.Equal(t, websocket.ClosePolicyViolation, closeErr.Code)
+}
+
+func TestSubscribeInvalidFilter(t *testing.T) {
+	server := httptest.NewServer(NewSubscribeHandler(newTestEventSource()))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + SubscribePath + "?events=unknown")
+	require.NoError(t, err)
+	defer resp.Body.Close() //nolint:errcheck
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// TestGRPCSubscribe verifies that events are streamed by the gRPC server, which rejects other protocols.
+func TestGRPCSubscribe(t *testing.T) {
+	source := newTestEventSource()
+	handler, err := NewGRPCHandler(nil, nil, nil, StatusSources{}, source, TxSources{}, AdminSources{})
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// the response headers are only sent with the first streamed event
+	hash := types.Hash(types.GetRandomBytes(32))
+	blockTime := time.Now().UTC().Truncate(time.Second)
+	source.ch <- block.Event{Type: block.EventDAIncluded, Height: 5}
+	source.ch <- block.Event{Type: block.EventNewBlock, Height: 7, Hash: hash, Time: blockTime, NumTxs: 2}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	client := rpc.NewEventServiceClient(http.DefaultClient, server.URL, connect.WithGRPC())
+	stream, err := client.Subscribe(ctx, connect.NewRequest(&pb.SubscribeRequest{
+		Types: []pb.EventType{pb.EventType_EVENT_TYPE_NEW_BLOCK},
+	}))
+	require.NoError(t, err)
+
+	require.True(t, stream.Receive(), stream.Err())
+	event := stream.Msg()
+	assert.Equal(t, pb.EventType_EVENT_TYPE_NEW_BLOCK, event.Type)
+	assert.Equal(t, uint64(7), event.Height)
+	assert.Equal(t, []byte(hash), event.Hash)
+	assert.Equal(t, blockTime, event.Time.AsTime())
+	assert.Equal(t, uint64(2), event.NumTxs)
+	cancel()
+	_ = stream.Close()
+
+	// the Connect protocol is not served
+	connectClient := rpc.NewHealthServiceClient(http.DefaultClient, server.URL)
+	_, err = connectClient.Livez(t.Context(), connect.NewRequest(&emptypb.Empty{}))
+	require.Error(t, err)
+	grpcClient := rpc.NewHealthServiceClient(http.DefaultClient, server.URL, connect.WithGRPC())
+	_, err = grpcClient.Livez(t.Context(), connect.NewRequest(&emptypb.Empty{}))
+	require.NoError(t, err)
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	"cosmossdk.io/log"
+	"github.com/fsnotify/fsnotify"
+)
+
+// Reloadable is implemented by the credentials of the RPC servers loaded from files, reloaded by WatchReload.
+type Reloadable interface {
+	// Reload loads the files again. The previous credentials are kept if it fails.
+	Reload() error
+	// Files returns the paths of the loaded files.
+	Files() []string
+}
+
+// CertReloader serves the TLS certificate loaded from a certificate and key file, so that a renewed certificate
+// is served without restarting the node once reloaded.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+}
+
+var _ Reloadable = (*CertReloader)(nil)
+
+// NewCertReloader loads the PEM encoded certificate and key files.
+func NewCertReloader(certFile, keyFile string) (*CertReloader, error) {
+	r := &CertReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload implements the Reloadable interface.
+func (r *CertReloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	r.mu.Lock()
+	r.cert = &cert
+	r.mu.Unlock()
+	return nil
+}
+
+// Files implements the Reloadable interface.
+func (r *CertReloader) Files() []string {
+	return []string{r.certFile, r.keyFile}
+}
+
+// GetCertificate returns the last loaded certificate, for use as tls.Config.GetCertificate.
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// TLSConfig returns the TLS configuration of a server serving the last loaded certificate.
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: r.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+}
+
+// WatchReload reloads the credentials when the node receives SIGHUP or when their files change, until the
+// context is done. The directories of the files are watched, so that files replaced by renaming, e.g. Kubernetes
+// secrets, are reloaded too. Credentials failing to reload are kept until the next reload.
+func WatchReload(ctx context.Context, logger log.Logger, reloadables ...Reloadable) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer watcher.Close() //nolint:errcheck // nothing to do on close errors
+
+	dirs := make(map[string]bool)
+	for _, r := range reloadables {
+		for _, file := range r.Files() {
+			dir := filepath.Dir(file)
+			if dirs[dir] {
+				continue
+			}
+			if err := watcher.Add(dir); err != nil {
+				return fmt.Errorf("failed to watch %s: %w", dir, err)
+			}
+			dirs[dir] = true
+		}
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	reload := func(reason string) {
+		for _, r := range reloadables {
+			if err := r.Reload(); err != nil {
+				logger.Error("failed to reload RPC credentials, keeping the previous ones", "reason", reason, "files", r.Files(), "error", err)
+				continue
+			}
+			logger.Debug("reloaded RPC credentials", "reason", reason, "files", r.Files())
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-hup:
+			reload("SIGHUP")
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			reload("file change")
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Error("failed to watch RPC credential files", "error", err)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCertificate writes a new self-signed certificate with the given common name and its key to the files.
+func writeCertificate(t *testing.T, certFile, keyFile, commonName string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	// the key is written first, so that the watcher never loads a new certificate with the old key
+	require.NoError(t, os.WriteFile(keyFile+".tmp", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+	require.NoError(t, os.WriteFile(certFile+".tmp", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.Rename(keyFile+".tmp", keyFile))
+	require.NoError(t, os.Rename(certFile+".tmp", certFile))
+}
+
+// servedCommonName returns the common name of the certificate served by the reloader.
+func servedCommonName(t *testing.T, r *CertReloader) string {
+	t.Helper()
+	cert, err := r.GetCertificate(&tls.ClientHelloInfo{})
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return leaf.Subject.CommonName
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+
+	_, err := NewCertReloader(certFile, keyFile)
+	require.Error(t, err)
+
+	writeCertificate(t, certFile, keyFile, "first")
+	r, err := NewCertReloader(certFile, keyFile)
+	require.NoError(t, err)
+	assert.Equal(t, "first", servedCommonName(t, r))
+	assert.Equal(t, []string{certFile, keyFile}, r.Files())
+
+	// an invalid certificate is not loaded, the previous one is kept
+	require.NoError(t, os.WriteFile(certFile, []byte("invalid"), 0o600))
+	require.Error(t, r.Reload())
+	assert.Equal(t, "first", servedCommonName(t, r))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		done <- WatchReload(ctx, log.NewNopLogger(), r)
+	}()
+
+	// a renewed certificate is served without restarting the server
+	require.Eventually(t, func() bool {
+		writeCertificate(t, certFile, keyFile, "renewed")
+		return servedCommonName(t, r) == "renewed"
+	}, 5*time.Second, 100*time.Millisecond)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("WatchReload did not return after the context was canceled")
+	}
+}
@@ -0,0 +1,24 @@
+/*
+gRPC Sequencer implements the Sequencer interface by delegating the ordering of transactions to a remote
+SequencerService, so that the node sources its batches from an external shared sequencer network instead of the
+local aggregator.
+
+	sequencer, err := NewSequencer("https://sequencer:7980")
+	if err != nil {
+		panic(err)
+	}
+
+Requests failing because the sequencer cannot be reached return errors wrapping coresequencer.ErrUnavailable,
+which the block manager retries with exponential backoff.
+
+NewHandler exposes any Sequencer as a SequencerService. LocalSequencer is a reference implementation of a shared
+sequencer, ordering the transactions of several rollups in memory, and Server runs it in-process, e.g. for tests
+and local development:
+
+	server := NewServer(NewLocalSequencer(), "127.0.0.1:7980")
+	if err := server.Start(ctx); err != nil {
+		panic(err)
+	}
+	defer server.Stop()
+*/
+package grpc
@@ -0,0 +1,149 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+)
+
+// LocalSequencer is a reference shared sequencer ordering the transactions of several rollups in memory. The
+// transactions submitted for a rollup are returned in submission order by GetNextBatch, and VerifyBatch only
+// accepts the batches returned for the rollup.
+type LocalSequencer struct {
+	mu sync.Mutex
+	// queues holds the transactions not returned yet of every rollup
+	queues map[string][][]byte
+	// batches holds the hashes of the batches returned for every rollup
+	batches map[string]map[string]struct{}
+}
+
+var _ coresequencer.Sequencer = (*LocalSequencer)(nil)
+
+// NewLocalSequencer creates a new LocalSequencer instance.
+func NewLocalSequencer() *LocalSequencer {
+	return &LocalSequencer{
+		queues:  make(map[string][][]byte),
+		batches: make(map[string]map[string]struct{}),
+	}
+}
+
+// SubmitRollupBatchTxs implements the Sequencer interface by queuing the transactions of the batch.
+func (s *LocalSequencer) SubmitRollupBatchTxs(_ context.Context, req coresequencer.SubmitRollupBatchTxsRequest) (*coresequencer.SubmitRollupBatchTxsResponse, error) {
+	if req.Batch == nil || len(req.Batch.Transactions) == 0 {
+		return &coresequencer.SubmitRollupBatchTxsResponse{}, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rollupID := string(req.RollupId)
+	s.queues[rollupID] = append(s.queues[rollupID], req.Batch.Transactions...)
+	return &coresequencer.SubmitRollupBatchTxsResponse{}, nil
+}
+
+// GetNextBatch implements the Sequencer interface by returning the queued transactions of the rollup, up to
+// req.MaxBytes. The first queued transaction is returned even if it exceeds req.MaxBytes, so that it does not
+// hold back the queue.
+func (s *LocalSequencer) GetNextBatch(_ context.Context, req coresequencer.GetNextBatchRequest) (*coresequencer.GetNextBatchResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rollupID := string(req.RollupId)
+	queue := s.queues[rollupID]
+	var n int
+	var size uint64
+	for n < len(queue) {
+		size += uint64(len(queue[n]))
+		if n > 0 && req.MaxBytes > 0 && size > req.MaxBytes {
+			break
+		}
+		n++
+	}
+	batch := &coresequencer.Batch{Transactions: queue[:n:n]}
+	s.queues[rollupID] = queue[n:]
+
+	hash, err := batch.Hash()
+	if err != nil {
+		return nil, err
+	}
+	if s.batches[rollupID] == nil {
+		s.batches[rollupID] = make(map[string]struct{})
+	}
+	s.batches[rollupID][string(hash)] = struct{}{}
+	return &coresequencer.GetNextBatchResponse{
+		Batch:     batch,
+		Timestamp: time.Now(),
+		BatchData: [][]byte{hash},
+	}, nil
+}
+
+// VerifyBatch implements the Sequencer interface by checking that the batch was returned for the rollup.
+func (s *LocalSequencer) VerifyBatch(_ context.Context, req coresequencer.VerifyBatchRequest) (*coresequencer.VerifyBatchResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(req.BatchData) == 0 {
+		return &coresequencer.VerifyBatchResponse{Status: false}, nil
+	}
+	for _, hash := range req.BatchData {
+		if _, ok := s.batches[string(req.RollupId)][string(hash)]; !ok {
+			return &coresequencer.VerifyBatchResponse{Status: false}, nil
+		}
+	}
+	return &coresequencer.VerifyBatchResponse{Status: true}, nil
+}
+
+// Server serves a Sequencer as a SequencerService over gRPC without TLS, to run a sequencer in-process.
+type Server struct {
+	listenAddr string
+	server     *http.Server
+	listener   net.Listener
+}
+
+// NewServer creates a new Server instance serving the sequencer on listenAddr.
+func NewServer(sequencer coresequencer.Sequencer, listenAddr string) *Server {
+	mux := http.NewServeMux()
+	mux.Handle(NewHandler(sequencer))
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		Protocols:         new(http.Protocols),
+	}
+	server.Protocols.SetHTTP1(true)
+	server.Protocols.SetUnencryptedHTTP2(true)
+	return &Server{listenAddr: listenAddr, server: server}
+}
+
+// Start starts serving requests until the context is done or Stop is called.
+func (s *Server) Start(ctx context.Context) error {
+	if s.listener != nil {
+		return errors.New("server already started")
+	}
+	listener, err := net.Listen("tcp", s.listenAddr)
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+	go func() {
+		_ = s.server.Serve(listener)
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = s.server.Close()
+	}()
+	return nil
+}
+
+// Addr returns the address the server listens on once started, e.g. to connect to a server listening on port 0.
+func (s *Server) Addr() string {
+	if s.listener == nil {
+		return s.listenAddr
+	}
+	return s.listener.Addr().String()
+}
+
+// Stop stops the server.
+func (s *Server) Stop() error {
+	return s.server.Close()
+}
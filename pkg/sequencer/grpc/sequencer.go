@@ -0,0 +1,182 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+	rpc "github.com/rollkit/rollkit/types/pb/rollkit/v1/v1connect"
+)
+
+// Sequencer orders transactions with a remote SequencerService.
+type Sequencer struct {
+	client rpc.SequencerServiceClient
+}
+
+var _ coresequencer.Sequencer = (*Sequencer)(nil)
+
+// NewSequencer returns a Sequencer connecting to the remote sequencer at address. Addresses without a scheme
+// are connected to without TLS, https addresses are verified with the system roots.
+func NewSequencer(address string) (*Sequencer, error) {
+	if address == "" {
+		return nil, errors.New("sequencer address is empty")
+	}
+	baseURL := address
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "http://" + baseURL
+	}
+
+	// gRPC requires HTTP/2, which is negotiated with TLS or used without it
+	transport := &http.Transport{Protocols: new(http.Protocols)}
+	switch {
+	case strings.HasPrefix(baseURL, "https://"):
+		transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		transport.Protocols.SetHTTP2(true)
+	case strings.HasPrefix(baseURL, "http://"):
+		transport.Protocols.SetUnencryptedHTTP2(true)
+	default:
+		return nil, fmt.Errorf("unsupported sequencer address %s, expected an http or https address", address)
+	}
+
+	return &Sequencer{
+		client: rpc.NewSequencerServiceClient(&http.Client{Transport: transport}, baseURL, connect.WithGRPC()),
+	}, nil
+}
+
+// SubmitRollupBatchTxs implements the Sequencer interface.
+func (s *Sequencer) SubmitRollupBatchTxs(ctx context.Context, req coresequencer.SubmitRollupBatchTxsRequest) (*coresequencer.SubmitRollupBatchTxsResponse, error) {
+	_, err := s.client.SubmitBatchTxs(ctx, connect.NewRequest(&pb.SubmitBatchTxsRequest{
+		RollupId: req.RollupId,
+		Batch:    batchToProto(req.Batch),
+	}))
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &coresequencer.SubmitRollupBatchTxsResponse{}, nil
+}
+
+// GetNextBatch implements the Sequencer interface.
+func (s *Sequencer) GetNextBatch(ctx context.Context, req coresequencer.GetNextBatchRequest) (*coresequencer.GetNextBatchResponse, error) {
+	resp, err := s.client.GetNextBatch(ctx, connect.NewRequest(&pb.GetNextBatchRequest{
+		RollupId:      req.RollupId,
+		LastBatchData: req.LastBatchData,
+		MaxBytes:      req.MaxBytes,
+	}))
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	res := &coresequencer.GetNextBatchResponse{
+		Batch:     batchFromProto(resp.Msg.Batch),
+		BatchData: resp.Msg.BatchData,
+	}
+	if resp.Msg.Timestamp != nil {
+		res.Timestamp = resp.Msg.Timestamp.AsTime()
+	}
+	return res, nil
+}
+
+// VerifyBatch implements the Sequencer interface.
+func (s *Sequencer) VerifyBatch(ctx context.Context, req coresequencer.VerifyBatchRequest) (*coresequencer.VerifyBatchResponse, error) {
+	resp, err := s.client.VerifyBatch(ctx, connect.NewRequest(&pb.VerifyBatchRequest{
+		RollupId:  req.RollupId,
+		BatchData: req.BatchData,
+	}))
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &coresequencer.VerifyBatchResponse{Status: resp.Msg.Status}, nil
+}
+
+// wrapError wraps the errors of requests that did not reach the remote sequencer with
+// coresequencer.ErrUnavailable, so that they are retried.
+func wrapError(err error) error {
+	switch connect.CodeOf(err) {
+	case connect.CodeUnavailable, connect.CodeDeadlineExceeded:
+		return fmt.Errorf("%w: %w", coresequencer.ErrUnavailable, err)
+	default:
+		return err
+	}
+}
+
+func batchToProto(batch *coresequencer.Batch) *pb.Batch {
+	if batch == nil {
+		return nil
+	}
+	return &pb.Batch{Txs: batch.Transactions}
+}
+
+func batchFromProto(batch *pb.Batch) *coresequencer.Batch {
+	if batch == nil {
+		return nil
+	}
+	return &coresequencer.Batch{Transactions: batch.Txs}
+}
+
+// server exposes a Sequencer as a SequencerService.
+type server struct {
+	sequencer coresequencer.Sequencer
+}
+
+// NewHandler returns the path and handler serving the SequencerService backed by the sequencer.
+func NewHandler(s coresequencer.Sequencer, opts ...connect.HandlerOption) (string, http.Handler) {
+	return rpc.NewSequencerServiceHandler(&server{sequencer: s}, opts...)
+}
+
+// SubmitBatchTxs implements the SequencerServiceHandler interface.
+func (s *server) SubmitBatchTxs(ctx context.Context, req *connect.Request[pb.SubmitBatchTxsRequest]) (*connect.Response[pb.SubmitBatchTxsResponse], error) {
+	_, err := s.sequencer.SubmitRollupBatchTxs(ctx, coresequencer.SubmitRollupBatchTxsRequest{
+		RollupId: req.Msg.RollupId,
+		Batch:    batchFromProto(req.Msg.Batch),
+	})
+	if err != nil {
+		return nil, handlerError(err)
+	}
+	return connect.NewResponse(&pb.SubmitBatchTxsResponse{}), nil
+}
+
+// GetNextBatch implements the SequencerServiceHandler interface.
+func (s *server) GetNextBatch(ctx context.Context, req *connect.Request[pb.GetNextBatchRequest]) (*connect.Response[pb.GetNextBatchResponse], error) {
+	res, err := s.sequencer.GetNextBatch(ctx, coresequencer.GetNextBatchRequest{
+		RollupId:      req.Msg.RollupId,
+		LastBatchData: req.Msg.LastBatchData,
+		MaxBytes:      req.Msg.MaxBytes,
+	})
+	if err != nil {
+		return nil, handlerError(err)
+	}
+	resp := &pb.GetNextBatchResponse{}
+	if res != nil {
+		resp.Batch = batchToProto(res.Batch)
+		resp.Timestamp = timestamppb.New(res.Timestamp)
+		resp.BatchData = res.BatchData
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// VerifyBatch implements the SequencerServiceHandler interface.
+func (s *server) VerifyBatch(ctx context.Context, req *connect.Request[pb.VerifyBatchRequest]) (*connect.Response[pb.VerifyBatchResponse], error) {
+	res, err := s.sequencer.VerifyBatch(ctx, coresequencer.VerifyBatchRequest{
+		RollupId:  req.Msg.RollupId,
+		BatchData: req.Msg.BatchData,
+	})
+	if err != nil {
+		return nil, handlerError(err)
+	}
+	return connect.NewResponse(&pb.VerifyBatchResponse{Status: res != nil && res.Status}), nil
+}
+
+// handlerError returns the connect error of a failed request to the sequencer.
+func handlerError(err error) error {
+	if errors.Is(err, coresequencer.ErrUnavailable) {
+		return connect.NewError(connect.CodeUnavailable, err)
+	}
+	return connect.NewError(connect.CodeInternal, err)
+}
@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
+)
+
+// newTestServer serves the sequencer over gRPC without TLS and returns a client connected to it.
+func newTestServer(t *testing.T, s coresequencer.Sequencer) (*Sequencer, *httptest.Server) {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.Handle(NewHandler(s))
+	ts := httptest.NewUnstartedServer(mux)
+	ts.Config.Protocols = new(http.Protocols)
+	ts.Config.Protocols.SetUnencryptedHTTP2(true)
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	client, err := NewSequencer(ts.URL)
+	require.NoError(t, err)
+	return client, ts
+}
+
+func TestSequencer(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newTestServer(t, NewLocalSequencer())
+	rollupID := []byte("test-rollup")
+
+	_, err := client.SubmitRollupBatchTxs(ctx, coresequencer.SubmitRollupBatchTxsRequest{
+		RollupId: rollupID,
+		Batch:    &coresequencer.Batch{Transactions: [][]byte{[]byte("tx1"), []byte("tx2"), []byte("tx3")}},
+	})
+	require.NoError(t, err)
+	_, err = client.SubmitRollupBatchTxs(ctx, coresequencer.SubmitRollupBatchTxsRequest{
+		RollupId: []byte("other-rollup"),
+		Batch:    &coresequencer.Batch{Transactions: [][]byte{[]byte("other")}},
+	})
+	require.NoError(t, err)
+
+	// transactions are returned in submission order, up to the maximum size
+	res, err := client.GetNextBatch(ctx, coresequencer.GetNextBatchRequest{RollupId: rollupID, MaxBytes: 6})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("tx1"), []byte("tx2")}, res.Batch.Transactions)
+	assert.False(t, res.Timestamp.IsZero())
+	require.Len(t, res.BatchData, 1)
+	hash, err := res.Batch.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, hash, res.BatchData[0])
+
+	verified, err := client.VerifyBatch(ctx, coresequencer.VerifyBatchRequest{RollupId: rollupID, BatchData: res.BatchData})
+	require.NoError(t, err)
+	assert.True(t, verified.Status)
+	verified, err = client.VerifyBatch(ctx, coresequencer.VerifyBatchRequest{RollupId: []byte("other-rollup"), BatchData: res.BatchData})
+	require.NoError(t, err)
+	assert.False(t, verified.Status)
+
+	res, err = client.GetNextBatch(ctx, coresequencer.GetNextBatchRequest{RollupId: rollupID, LastBatchData: res.BatchData})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("tx3")}, res.Batch.Transactions)
+
+	res, err = client.GetNextBatch(ctx, coresequencer.GetNextBatchRequest{RollupId: rollupID})
+	require.NoError(t, err)
+	assert.Empty(t, res.Batch.Transactions)
+}
+
+func TestSequencerUnavailable(t *testing.T) {
+	client, ts := newTestServer(t, NewLocalSequencer())
+	ts.Close()
+
+	_, err := client.GetNextBatch(context.Background(), coresequencer.GetNextBatchRequest{RollupId: []byte("test-rollup")})
+	require.ErrorIs(t, err, coresequencer.ErrUnavailable)
+}
+
+func TestNewSequencerInvalidAddress(t *testing.T) {
+	_, err := NewSequencer("")
+	assert.Error(t, err)
+	_, err = NewSequencer("ftp://sequencer:7980")
+	assert.Error(t, err)
+}
+
+func TestServer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	server := NewServer(NewLocalSequencer(), "127.0.0.1:0")
+	require.NoError(t, server.Start(ctx))
+	defer func() { _ = server.Stop() }()
+
+	client, err := NewSequencer(server.Addr())
+	require.NoError(t, err)
+	_, err = client.SubmitRollupBatchTxs(ctx, coresequencer.SubmitRollupBatchTxsRequest{
+		RollupId: []byte("test-rollup"),
+		Batch:    &coresequencer.Batch{Transactions: [][]byte{[]byte("tx")}},
+	})
+	require.NoError(t, err)
+	res, err := client.GetNextBatch(ctx, coresequencer.GetNextBatchRequest{RollupId: []byte("test-rollup")})
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("tx")}, res.Batch.Transactions)
+}
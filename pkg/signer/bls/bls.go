@@ -0,0 +1,173 @@
+package bls
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	pb "github.com/libp2p/go-libp2p/core/crypto/pb"
+)
+
+// KeyType is the key type of BLS keys. It is not known to libp2p, so BLS keys are marshalled by the signer
+// package.
+const KeyType pb.KeyType = 4
+
+const (
+	// PrivKeySize is the size of raw private keys.
+	PrivKeySize = fr.Bytes
+	// PubKeySize is the size of raw public keys.
+	PubKeySize = bls12381.SizeOfG1AffineCompressed
+	// SignatureSize is the size of signatures.
+	SignatureSize = bls12381.SizeOfG2AffineCompressed
+)
+
+// dst is the domain separation tag of the basic scheme ciphersuite with public keys in G1.
+var dst = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_NUL_")
+
+var (
+	// ErrInvalidPrivKey is returned when a raw private key is not a valid scalar.
+	ErrInvalidPrivKey = errors.New("invalid BLS private key")
+	// ErrInvalidPubKey is returned when a raw public key is not a valid G1 point.
+	ErrInvalidPubKey = errors.New("invalid BLS public key")
+)
+
+// PrivKey is a BLS private key.
+type PrivKey struct {
+	sk  fr.Element
+	pub *PubKey
+}
+
+// PubKey is a BLS public key.
+type PubKey struct {
+	pk bls12381.G1Affine
+}
+
+var (
+	_ crypto.PrivKey = (*PrivKey)(nil)
+	_ crypto.PubKey  = (*PubKey)(nil)
+)
+
+// GenerateKey generates a BLS key pair using the given source of randomness.
+func GenerateKey(src io.Reader) (*PrivKey, *PubKey, error) {
+	// reduce 64 random bytes modulo the group order to avoid a biased scalar
+	buf := make([]byte, 64)
+	for {
+		if _, err := io.ReadFull(src, buf); err != nil {
+			return nil, nil, fmt.Errorf("failed to read randomness: %w", err)
+		}
+		n := new(big.Int).SetBytes(buf)
+		var sk fr.Element
+		sk.SetBigInt(n)
+		if !sk.IsZero() {
+			priv := newPrivKey(sk)
+			return priv, priv.pub, nil
+		}
+	}
+}
+
+// UnmarshalPrivateKey decodes a raw BLS private key.
+func UnmarshalPrivateKey(data []byte) (crypto.PrivKey, error) {
+	var sk fr.Element
+	if len(data) != PrivKeySize || sk.SetBytesCanonical(data) != nil || sk.IsZero() {
+		return nil, ErrInvalidPrivKey
+	}
+	return newPrivKey(sk), nil
+}
+
+// UnmarshalPublicKey decodes a raw BLS public key. The point must be in the G1 subgroup.
+func UnmarshalPublicKey(data []byte) (crypto.PubKey, error) {
+	pub := new(PubKey)
+	if len(data) != PubKeySize {
+		return nil, ErrInvalidPubKey
+	}
+	if _, err := pub.pk.SetBytes(data); err != nil || pub.pk.IsInfinity() {
+		return nil, ErrInvalidPubKey
+	}
+	return pub, nil
+}
+
+func newPrivKey(sk fr.Element) *PrivKey {
+	var pub PubKey
+	pub.pk.ScalarMultiplicationBase(sk.BigInt(new(big.Int)))
+	return &PrivKey{sk: sk, pub: &pub}
+}
+
+// Sign signs the message.
+func (k *PrivKey) Sign(msg []byte) ([]byte, error) {
+	h, err := bls12381.HashToG2(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+	var sig bls12381.G2Affine
+	sig.ScalarMultiplication(&h, k.sk.BigInt(new(big.Int)))
+	bz := sig.Bytes()
+	return bz[:], nil
+}
+
+// GetPublic returns the public key paired with the private key.
+func (k *PrivKey) GetPublic() crypto.PubKey {
+	return k.pub
+}
+
+// Raw returns the scalar of the private key, in big endian.
+func (k *PrivKey) Raw() ([]byte, error) {
+	bz := k.sk.Bytes()
+	return bz[:], nil
+}
+
+// Type returns the BLS key type.
+func (k *PrivKey) Type() pb.KeyType {
+	return KeyType
+}
+
+// Equals reports whether both keys are the same BLS private key.
+func (k *PrivKey) Equals(other crypto.Key) bool {
+	o, ok := other.(*PrivKey)
+	if !ok {
+		return false
+	}
+	a, b := k.sk.Bytes(), o.sk.Bytes()
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
+
+// Verify verifies the signature of the message.
+func (k *PubKey) Verify(data []byte, sigBytes []byte) (bool, error) {
+	var sig bls12381.G2Affine
+	if len(sigBytes) != SignatureSize {
+		return false, nil
+	}
+	if _, err := sig.SetBytes(sigBytes); err != nil || sig.IsInfinity() {
+		return false, nil
+	}
+	h, err := bls12381.HashToG2(data, dst)
+	if err != nil {
+		return false, err
+	}
+	// e(pk, H(m)) == e(g1, sig)
+	_, _, g1, _ := bls12381.Generators()
+	var negG1 bls12381.G1Affine
+	negG1.Neg(&g1)
+	return bls12381.PairingCheck([]bls12381.G1Affine{k.pk, negG1}, []bls12381.G2Affine{h, sig})
+}
+
+// Raw returns the compressed G1 point of the public key.
+func (k *PubKey) Raw() ([]byte, error) {
+	bz := k.pk.Bytes()
+	return bz[:], nil
+}
+
+// Type returns the BLS key type.
+func (k *PubKey) Type() pb.KeyType {
+	return KeyType
+}
+
+// Equals reports whether both keys are the same BLS public key.
+func (k *PubKey) Equals(other crypto.Key) bool {
+	o, ok := other.(*PubKey)
+	return ok && k.pk.Equal(&o.pk)
+}
@@ -0,0 +1,66 @@
+package bls
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	priv, pub, err := GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	assert.True(t, priv.GetPublic().Equals(pub))
+
+	msg := []byte("header")
+	sig, err := priv.Sign(msg)
+	require.NoError(t, err)
+	assert.Len(t, sig, SignatureSize)
+
+	ok, err := pub.Verify(msg, sig)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = pub.Verify([]byte("other header"), sig)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, other, err := GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ok, err = other.Verify(msg, sig)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = pub.Verify(msg, sig[:SignatureSize-1])
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestUnmarshal(t *testing.T) {
+	priv, pub, err := GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	raw, err := priv.Raw()
+	require.NoError(t, err)
+	decodedPriv, err := UnmarshalPrivateKey(raw)
+	require.NoError(t, err)
+	assert.True(t, priv.Equals(decodedPriv))
+
+	raw, err = pub.Raw()
+	require.NoError(t, err)
+	assert.Len(t, raw, PubKeySize)
+	decodedPub, err := UnmarshalPublicKey(raw)
+	require.NoError(t, err)
+	assert.True(t, pub.Equals(decodedPub))
+
+	_, err = UnmarshalPrivateKey(make([]byte, PrivKeySize))
+	assert.ErrorIs(t, err, ErrInvalidPrivKey)
+	_, err = UnmarshalPublicKey(raw[1:])
+	assert.ErrorIs(t, err, ErrInvalidPubKey)
+	// the compressed point at infinity
+	infinity := make([]byte, PubKeySize)
+	infinity[0] = 0xc0
+	_, err = UnmarshalPublicKey(infinity)
+	assert.ErrorIs(t, err, ErrInvalidPubKey)
+}
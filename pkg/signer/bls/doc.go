@@ -0,0 +1,8 @@
+/*
+Package bls implements BLS signatures over the BLS12-381 curve as libp2p keys, so that the sequencer can sign
+headers with BLS keys wherever a crypto.PrivKey or crypto.PubKey is expected.
+
+Public keys are compressed G1 points (48 bytes) and signatures are compressed G2 points (96 bytes), following the
+minimal-pubkey-size variant of the IETF BLS signature draft with the basic scheme ciphersuite.
+*/
+package bls
@@ -2,6 +2,9 @@ package file
 
 import (
 	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,8 +12,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/keystore"
+	"github.com/rollkit/rollkit/pkg/signer"
 )
 
 func TestCreateFileSystemSigner(t *testing.T) {
@@ -37,14 +44,17 @@ func TestCreateFileSystemSigner(t *testing.T) {
 		fileBytes, err := os.ReadFile(keyFile)
 		require.NoError(t, err)
 
-		var data keyData
+		var data keystore.EncryptedKey
 		err = json.Unmarshal(fileBytes, &data)
 		require.NoError(t, err)
 
 		// Check key data
-		assert.NotEmpty(t, data.PrivKeyEncrypted)
+		assert.Equal(t, keystore.Version, data.Version)
+		assert.Equal(t, keystore.KDFArgon2id, data.KDF)
+		assert.Equal(t, "ed25519", data.KeyType)
+		assert.NotEmpty(t, data.Ciphertext)
 		assert.NotEmpty(t, data.Nonce)
-		assert.NotEmpty(t, data.PubKeyBytes)
+		assert.NotEmpty(t, data.PubKey)
 		assert.NotEmpty(t, data.Salt)
 	})
 
@@ -281,47 +291,115 @@ func TestKeyPersistence(t *testing.T) {
 	assert.True(t, valid, "Original signature should be valid with loaded key")
 }
 
-func TestBackwardCompatibility(t *testing.T) {
+func TestKeyPersistence_Schemes(t *testing.T) {
 	t.Parallel()
 
-	t.Run("fallback to naive key derivation", func(t *testing.T) {
-		tempDir := t.TempDir()
-		keyPath := tempDir
-		passphrase := []byte("secure-test-passphrase")
+	for _, scheme := range []string{signer.SchemeSecp256k1, signer.SchemeBLS} {
+		t.Run(scheme, func(t *testing.T) {
+			keyPath := t.TempDir()
+			created, err := CreateFileSystemSignerWithScheme(keyPath, []byte("secure-test-passphrase"), scheme)
+			require.NoError(t, err)
+			message := []byte("Test message")
+			signature, err := created.Sign(message)
+			require.NoError(t, err)
 
-		// Create a signer
-		_, err := CreateFileSystemSigner(keyPath, passphrase)
-		require.NoError(t, err)
+			loaded, err := LoadFileSystemSigner(keyPath, []byte("secure-test-passphrase"))
+			require.NoError(t, err)
+			pubKey, err := loaded.GetPublic()
+			require.NoError(t, err)
+			got, err := signer.SchemeOf(pubKey)
+			require.NoError(t, err)
+			assert.Equal(t, scheme, got)
+			valid, err := pubKey.Verify(message, signature)
+			require.NoError(t, err)
+			assert.True(t, valid)
+		})
+	}
+}
 
-		keyFile := filepath.Join(keyPath, "signer.json")
+// writeLegacyKeyFile stores the private key in the format of older versions of the file signer. The key is derived
+// with the naive key derivation if salt is nil.
+func writeLegacyKeyFile(t *testing.T, keyPath string, privKey crypto.PrivKey, passphrase, salt []byte) {
+	t.Helper()
+	derivedKey := fallbackDeriveKey(bytes.Clone(passphrase), 32)
+	if salt != nil {
+		derivedKey = deriveKeyArgon2(passphrase, salt, 32)
+	}
+	block, err := aes.NewCipher(derivedKey)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	require.NoError(t, err)
+	privBytes, err := privKey.Raw()
+	require.NoError(t, err)
+	pubBytes, err := privKey.GetPublic().Raw()
+	require.NoError(t, err)
+	bz, err := json.Marshal(keyData{
+		PrivKeyEncrypted: gcm.Seal(nil, nonce, privBytes, nil),
+		Nonce:            nonce,
+		PubKeyBytes:      pubBytes,
+		Salt:             salt,
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(keyPath, "signer.json"), bz, 0600))
+}
 
-		// Read the generated key file
-		fileBytes, err := os.ReadFile(keyFile)
-		require.NoError(t, err)
+func TestBackwardCompatibility(t *testing.T) {
+	t.Parallel()
 
-		var data keyData
-		err = json.Unmarshal(fileBytes, &data)
-		require.NoError(t, err)
+	for name, salt := range map[string][]byte{
+		"argon2 key derivation":         []byte("legacy-salt-1234"),
+		"fallback naive key derivation": nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			keyPath := t.TempDir()
+			privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+			require.NoError(t, err)
+			writeLegacyKeyFile(t, keyPath, privKey, []byte("secure-test-passphrase-long-enough"), salt)
 
-		// Remove salt field to simulate old format
-		data.Salt = nil
+			s, err := LoadFileSystemSigner(keyPath, []byte("secure-test-passphrase-long-enough"))
+			require.NoError(t, err)
+			pubKey, err := s.GetPublic()
+			require.NoError(t, err)
+			assert.True(t, pubKey.Equals(privKey.GetPublic()))
 
-		// Write modified key data back to file
-		modifiedBytes, err := json.Marshal(data)
-		require.NoError(t, err)
+			// legacy keys are migrated to the keystore format
+			migrated, err := MigrateKey(keyPath, []byte("secure-test-passphrase-long-enough"))
+			require.NoError(t, err)
+			assert.True(t, migrated)
+			bz, err := os.ReadFile(filepath.Join(keyPath, "signer.json"))
+			require.NoError(t, err)
+			assert.True(t, keystore.IsEncrypted(bz))
+			loaded, err := LoadPrivateKey(keyPath, []byte("secure-test-passphrase-long-enough"))
+			require.NoError(t, err)
+			assert.True(t, loaded.Equals(privKey))
 
-		err = os.WriteFile(keyFile, modifiedBytes, 0600)
-		require.NoError(t, err)
+			migrated, err = MigrateKey(keyPath, []byte("secure-test-passphrase-long-enough"))
+			require.NoError(t, err)
+			assert.False(t, migrated)
+		})
+	}
+}
 
-		// Try to load with the same passphrase
-		passphrase = []byte("secure-test-passphrase")
-		_, err = LoadFileSystemSigner(keyPath, passphrase)
-		// This might fail, but we're testing if it properly attempts to use fallback
-		// The important thing is that it doesn't panic
-		if err != nil {
-			assert.NotContains(t, err.Error(), "salt")
-		}
-	})
+func TestSavePrivateKey(t *testing.T) {
+	keyPath := t.TempDir()
+	_, err := CreateFileSystemSignerWithScheme(keyPath, []byte("old-passphrase"), signer.SchemeSecp256k1)
+	require.NoError(t, err)
+
+	// changing the passphrase of the key
+	privKey, err := LoadPrivateKey(keyPath, []byte("old-passphrase"))
+	require.NoError(t, err)
+	require.NoError(t, SavePrivateKey(keyPath, privKey, []byte("new-passphrase")))
+
+	_, err = LoadFileSystemSigner(keyPath, []byte("old-passphrase"))
+	assert.ErrorIs(t, err, keystore.ErrWrongPassphrase)
+	s, err := LoadFileSystemSigner(keyPath, []byte("new-passphrase"))
+	require.NoError(t, err)
+	pubKey, err := s.GetPublic()
+	require.NoError(t, err)
+	assert.True(t, pubKey.Equals(privKey.GetPublic()))
 }
 
 func TestEdgeCases(t *testing.T) {
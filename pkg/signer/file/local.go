@@ -1,13 +1,13 @@
 package file
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"sync"
@@ -15,6 +15,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"golang.org/x/crypto/argon2"
 
+	"github.com/rollkit/rollkit/pkg/keystore"
 	"github.com/rollkit/rollkit/pkg/signer"
 )
 
@@ -27,17 +28,29 @@ type FileSystemSigner struct {
 	mu         sync.RWMutex
 }
 
-// keyData represents the encrypted key data stored on disk
+// keyData represents the encrypted key data stored on disk by older versions of the file signer, before keys were
+// stored in the keystore format. Keys in this format are still loaded, and can be rewritten with MigrateKey.
 type keyData struct {
 	PrivKeyEncrypted []byte `json:"priv_key_encrypted"`
 	Nonce            []byte `json:"nonce"`
 	PubKeyBytes      []byte `json:"pub_key"`
 	Salt             []byte `json:"salt,omitempty"`
+	// KeyType is the signature scheme of the key, ed25519 if empty
+	KeyType string `json:"key_type,omitempty"`
 }
 
-// CreateFileSystemSigner creates a new key pair and saves it encrypted to disk.
+// CreateFileSystemSigner creates a new ed25519 key pair and saves it encrypted to disk.
 func CreateFileSystemSigner(keyPath string, passphrase []byte) (signer.Signer, error) {
+	return CreateFileSystemSignerWithScheme(keyPath, passphrase, signer.SchemeEd25519)
+}
+
+// CreateFileSystemSignerWithScheme creates a new key pair of the signature scheme, ed25519 if empty, and saves it
+// encrypted to disk.
+func CreateFileSystemSignerWithScheme(keyPath string, passphrase []byte, scheme string) (signer.Signer, error) {
 	defer zeroBytes(passphrase) // Wipe passphrase from memory after use
+	if scheme == "" {
+		scheme = signer.SchemeEd25519
+	}
 
 	filePath := filepath.Join(keyPath, "signer.json")
 
@@ -55,26 +68,25 @@ func CreateFileSystemSigner(keyPath string, passphrase []byte) (signer.Signer, e
 		return nil, fmt.Errorf("failed to check key file status: %w", err)
 	}
 
-	// Generate new Ed25519 key pair
-	privKey, pubKey, err := crypto.GenerateKeyPair(crypto.Ed25519, 256)
+	privKey, pubKey, err := signer.GenerateKeyPair(scheme, rand.Reader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate key pair: %w", err)
 	}
 
-	signer := &FileSystemSigner{
+	fileSigner := &FileSystemSigner{
 		privateKey: privKey,
 		publicKey:  pubKey,
 		keyFile:    filePath,
 	}
 
 	// Save keys to disk
-	if err := signer.saveKeys(passphrase); err != nil {
+	if err := fileSigner.saveKeys(passphrase); err != nil {
 		// Attempt to clean up the file if saving failed partially
 		_ = os.Remove(filePath)
 		return nil, fmt.Errorf("failed to save keys: %w", err)
 	}
 
-	return signer, nil
+	return fileSigner, nil
 }
 
 // LoadFileSystemSigner loads existing keys from an encrypted file on disk.
@@ -104,84 +116,25 @@ func LoadFileSystemSigner(keyPath string, passphrase []byte) (signer.Signer, err
 	return signer, nil
 }
 
-// saveKeys encrypts and saves the private key to disk
+// saveKeys encrypts and saves the private key to disk in the keystore format
 func (s *FileSystemSigner) saveKeys(passphrase []byte) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	defer zeroBytes(passphrase) // Wipe passphrase from memory after use
 
 	if s.privateKey == nil || s.publicKey == nil {
 		return fmt.Errorf("keys not initialized")
 	}
+	return savePrivateKey(s.keyFile, s.privateKey, passphrase)
+}
 
-	// Create or reuse a random salt for Argon2
-	salt := make([]byte, 16)
-	if _, err := rand.Read(salt); err != nil {
-		return fmt.Errorf("failed to generate salt: %w", err)
-	}
-
-	// Get raw private key bytes
-	privKeyBytes, err := s.privateKey.Raw()
-	if err != nil {
-		return fmt.Errorf("failed to get raw private key: %w", err)
-	}
-
-	// Get raw public key bytes
-	pubKeyBytes, err := s.publicKey.Raw()
-	if err != nil {
-		return fmt.Errorf("failed to get raw public key: %w", err)
-	}
-
-	// Derive a key with Argon2
-	derivedKey := deriveKeyArgon2(passphrase, salt, 32)
-
-	// Zero out passphrase from memory once we have our derived key
-	zeroBytes(passphrase)
-
-	// Create AES cipher
-	block, err := aes.NewCipher(derivedKey)
-	if err != nil {
-		return fmt.Errorf("failed to create cipher: %w", err)
-	}
-
-	// Create GCM mode
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return fmt.Errorf("failed to create GCM: %w", err)
-	}
-
-	// Create a nonce
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return fmt.Errorf("failed to generate nonce: %w", err)
-	}
-
-	// Encrypt the private key
-	encryptedPrivKey := gcm.Seal(nil, nonce, privKeyBytes, nil)
-
-	// Create key data structure
-	data := keyData{
-		PrivKeyEncrypted: encryptedPrivKey,
-		Nonce:            nonce,
-		PubKeyBytes:      pubKeyBytes,
-		Salt:             salt,
-	}
-
-	// Marshal to JSON
-	jsonData, err := json.Marshal(data)
+// savePrivateKey encrypts the private key with the passphrase and writes it to the key file.
+func savePrivateKey(keyFile string, privKey crypto.PrivKey, passphrase []byte) error {
+	encrypted, err := keystore.Encrypt(privKey, passphrase, keystore.KDFArgon2id)
 	if err != nil {
-		return fmt.Errorf("failed to marshal key data: %w", err)
-	}
-
-	// Write to file with secure permissions
-	if err := os.WriteFile(s.keyFile, jsonData, 0600); err != nil {
-		return fmt.Errorf("failed to write key file: %w", err)
+		return fmt.Errorf("failed to encrypt private key: %w", err)
 	}
-
-	// Zero out derivedKey bytes for security
-	zeroBytes(derivedKey)
-	zeroBytes(privKeyBytes)
-
-	return nil
+	return keystore.WriteFile(keyFile, encrypted)
 }
 
 // loadKeys decrypts and loads the keys from disk into memory
@@ -190,63 +143,109 @@ func (s *FileSystemSigner) loadKeys(passphrase []byte) error {
 	defer s.mu.Unlock()
 	defer zeroBytes(passphrase) // Wipe passphrase from memory after use
 
+	privKey, _, err := loadPrivateKey(s.keyFile, passphrase)
+	if err != nil {
+		return err
+	}
+	s.privateKey = privKey
+	s.publicKey = privKey.GetPublic()
+	return nil
+}
+
+// loadPrivateKey decrypts the private key stored in the key file, and reports whether it is stored in the legacy
+// format of the file signer rather than in the keystore format.
+func loadPrivateKey(keyFile string, passphrase []byte) (crypto.PrivKey, bool, error) {
 	// Read the key file
-	jsonData, err := os.ReadFile(s.keyFile)
+	jsonData, err := os.ReadFile(keyFile) //nolint:gosec // key file path is provided by the operator
 	if err != nil {
-		return fmt.Errorf("failed to read key file: %w", err)
+		return nil, false, fmt.Errorf("failed to read key file: %w", err)
+	}
+	if keystore.IsEncrypted(jsonData) {
+		encrypted, err := keystore.ReadFile(keyFile)
+		if err != nil {
+			return nil, false, err
+		}
+		privKey, err := encrypted.Decrypt(passphrase)
+		if err != nil {
+			return nil, false, err
+		}
+		return privKey, false, nil
 	}
 
 	// Unmarshal JSON
 	var data keyData
 	if err := json.Unmarshal(jsonData, &data); err != nil {
-		return fmt.Errorf("failed to unmarshal key data: %w", err)
+		return nil, true, fmt.Errorf("failed to unmarshal key data: %w", err)
 	}
 
 	// If there's no salt in the file, fallback to older naive deriveKey (for backward-compatibility)
 	var derivedKey []byte
 	if len(data.Salt) == 0 {
 		// fallback to naive approach
-		derivedKey = fallbackDeriveKey(passphrase, 32)
+		derivedKey = fallbackDeriveKey(bytes.Clone(passphrase), 32)
 	} else {
 		derivedKey = deriveKeyArgon2(passphrase, data.Salt, 32)
 	}
+	// Zero out sensitive data
+	defer zeroBytes(derivedKey)
 
 	block, err := aes.NewCipher(derivedKey)
 	if err != nil {
-		return fmt.Errorf("failed to create cipher: %w", err)
+		return nil, true, fmt.Errorf("failed to create cipher: %w", err)
 	}
 
 	gcm, err := cipher.NewGCM(block)
 	if err != nil {
-		return fmt.Errorf("failed to create GCM: %w", err)
+		return nil, true, fmt.Errorf("failed to create GCM: %w", err)
 	}
 
 	// Decrypt the private key
 	privKeyBytes, err := gcm.Open(nil, data.Nonce, data.PrivKeyEncrypted, nil)
 	if err != nil {
-		return fmt.Errorf("failed to decrypt private key (wrong passphrase?): %w", err)
+		return nil, true, fmt.Errorf("failed to decrypt private key (wrong passphrase?): %w", err)
 	}
 
-	// Unmarshal the private key
-	privKey, err := crypto.UnmarshalEd25519PrivateKey(privKeyBytes)
-	if err != nil {
-		return fmt.Errorf("failed to unmarshal private key: %w", err)
+	scheme := data.KeyType
+	if scheme == "" {
+		scheme = signer.SchemeEd25519
 	}
 
-	// Unmarshal the public key
-	pubKey, err := crypto.UnmarshalEd25519PublicKey(data.PubKeyBytes)
+	// Unmarshal the private key
+	privKey, err := signer.UnmarshalRawPrivateKey(scheme, privKeyBytes)
 	if err != nil {
-		return fmt.Errorf("failed to unmarshal public key: %w", err)
+		return nil, true, fmt.Errorf("failed to unmarshal private key: %w", err)
 	}
+	return privKey, true, nil
+}
 
-	// Set the keys
-	s.privateKey = privKey
-	s.publicKey = pubKey
+// LoadPrivateKey decrypts the private key of the file signer stored in keyPath, in the keystore format or in the
+// legacy format of the file signer. It is used to export, import and migrate keys; nodes sign with the signer
+// returned by LoadFileSystemSigner.
+func LoadPrivateKey(keyPath string, passphrase []byte) (crypto.PrivKey, error) {
+	privKey, _, err := loadPrivateKey(filepath.Join(keyPath, "signer.json"), passphrase)
+	return privKey, err
+}
 
-	// Zero out sensitive data
-	zeroBytes(derivedKey)
+// SavePrivateKey encrypts the private key with the passphrase, and stores it as the key of the file signer in
+// keyPath, replacing the existing key if any.
+func SavePrivateKey(keyPath string, privKey crypto.PrivKey, passphrase []byte) error {
+	defer zeroBytes(passphrase) // Wipe passphrase from memory after use
+	return savePrivateKey(filepath.Join(keyPath, "signer.json"), privKey, passphrase)
+}
 
-	return nil
+// MigrateKey rewrites the key of the file signer stored in keyPath in the legacy format of the file signer in the
+// keystore format. It reports whether the key was migrated, keys in the keystore format are left unchanged.
+func MigrateKey(keyPath string, passphrase []byte) (bool, error) {
+	defer zeroBytes(passphrase) // Wipe passphrase from memory after use
+	keyFile := filepath.Join(keyPath, "signer.json")
+	privKey, legacy, err := loadPrivateKey(keyFile, passphrase)
+	if err != nil || !legacy {
+		return false, err
+	}
+	if err := savePrivateKey(keyFile, privKey, passphrase); err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 // Sign signs a message using the private key
@@ -313,7 +312,7 @@ func zeroBytes(b []byte) {
 	}
 }
 
-// getAddress returns the Ed25519 address of the signer.
+// getAddress returns the address of the signer.
 func getAddress(pubKey crypto.PubKey) ([]byte, error) {
 	bz, err := pubKey.Raw()
 	if err != nil {
@@ -0,0 +1,26 @@
+/*
+gRPC Remote Signer implements the Signer interface by delegating signing to a remote SignerService, so that the
+sequencer key can live in an HSM or a separate signer process instead of a file on disk.
+
+The connection uses HTTP/2, over TLS when a CA certificate is configured or the address uses https, and the client
+can authenticate itself with a certificate for mutual TLS.
+
+	signer, err := NewSigner(ctx, config.SignerConfig{
+		SignerType:  "grpc",
+		SignerPath:  "https://signer:7000",
+		TLSCAFile:   "/path/to/ca.pem",
+		TLSCertFile: "/path/to/client.pem",
+		TLSKeyFile:  "/path/to/client-key.pem",
+		Timeout:     config.DurationWrapper{Duration: 5 * time.Second},
+	}, logger)
+	if err != nil {
+		panic(err)
+	}
+
+	// Check the reachability of the signer until the context is done
+	go signer.Monitor(ctx)
+
+The signer is unhealthy while it is unreachable or serves another key, which pauses block production. NewHandler
+exposes any Signer as a SignerService, e.g. to run a signer process backed by a file or HSM signer.
+*/
+package grpc
@@ -0,0 +1,245 @@
+package grpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"cosmossdk.io/log"
+	"github.com/libp2p/go-libp2p/core/crypto"
+
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/signer"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+	rpc "github.com/rollkit/rollkit/types/pb/rollkit/v1/v1connect"
+)
+
+// ErrUnavailable is returned by Healthy when the remote signer is unreachable or serves another key.
+var ErrUnavailable = errors.New("remote signer unavailable")
+
+// Signer signs messages with the key of a remote SignerService.
+type Signer struct {
+	client   rpc.SignerServiceClient
+	timeout  time.Duration
+	interval time.Duration
+	logger   log.Logger
+
+	pubKey  crypto.PubKey
+	address []byte
+
+	mu        sync.RWMutex
+	healthErr error
+}
+
+var _ signer.Signer = (*Signer)(nil)
+var _ signer.HealthChecker = (*Signer)(nil)
+
+// NewSigner connects to the remote signer at conf.SignerPath and fetches its public key.
+func NewSigner(ctx context.Context, conf config.SignerConfig, logger log.Logger) (*Signer, error) {
+	tlsConfig, err := LoadTLSConfig(conf.TLSCAFile, conf.TLSCertFile, conf.TLSKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	baseURL := conf.SignerPath
+	if !strings.Contains(baseURL, "://") {
+		if tlsConfig != nil {
+			baseURL = "https://" + baseURL
+		} else {
+			baseURL = "http://" + baseURL
+		}
+	}
+	if strings.HasPrefix(baseURL, "https://") && tlsConfig == nil {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	// gRPC requires HTTP/2, which is negotiated with TLS or used without it
+	transport := &http.Transport{TLSClientConfig: tlsConfig, Protocols: new(http.Protocols)}
+	if tlsConfig != nil {
+		transport.Protocols.SetHTTP2(true)
+	} else {
+		transport.Protocols.SetUnencryptedHTTP2(true)
+	}
+
+	s := &Signer{
+		client:   rpc.NewSignerServiceClient(&http.Client{Transport: transport}, baseURL, connect.WithGRPC()),
+		timeout:  conf.Timeout.Duration,
+		interval: conf.HealthCheckInterval.Duration,
+		logger:   logger.With("module", "remote_signer"),
+	}
+	s.pubKey, err = s.fetchPublic(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key of remote signer %s: %w", baseURL, err)
+	}
+	bz, err := s.pubKey.Raw()
+	if err != nil {
+		return nil, err
+	}
+	address := sha256.Sum256(bz)
+	s.address = address[:]
+	return s, nil
+}
+
+// LoadTLSConfig returns the TLS configuration verifying the server with the CA certificate and authenticating
+// the client with the certificate and key files, or nil if no CA certificate is given.
+func LoadTLSConfig(caFile, certFile, keyFile string) (*tls.Config, error) {
+	if caFile == "" {
+		if certFile != "" || keyFile != "" {
+			return nil, errors.New("client certificate requires a CA certificate")
+		}
+		return nil, nil
+	}
+	ca, err := os.ReadFile(caFile) //nolint:gosec // path is provided by the node operator
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("no certificate found in %s", caFile)
+	}
+	tlsConfig := &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// Sign implements the Signer interface by requesting the signature from the remote signer. Failures mark the
+// signer unhealthy until the next successful request.
+func (s *Signer) Sign(message []byte) ([]byte, error) {
+	ctx, cancel := s.requestContext(context.Background())
+	defer cancel()
+	resp, err := s.client.Sign(ctx, connect.NewRequest(&pb.SignRequest{Message: message}))
+	if err != nil {
+		s.setHealth(err)
+		return nil, fmt.Errorf("%w: %w", ErrUnavailable, err)
+	}
+	s.setHealth(nil)
+	return resp.Msg.Signature, nil
+}
+
+// GetPublic implements the Signer interface by returning the public key fetched when connecting.
+func (s *Signer) GetPublic() (crypto.PubKey, error) {
+	return s.pubKey, nil
+}
+
+// GetAddress implements the Signer interface by returning the address of the public key.
+func (s *Signer) GetAddress() ([]byte, error) {
+	return s.address, nil
+}
+
+// Healthy implements the HealthChecker interface. It returns an error wrapping ErrUnavailable while the remote
+// signer is unreachable or serves another key.
+func (s *Signer) Healthy() error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.healthErr != nil {
+		return fmt.Errorf("%w: %w", ErrUnavailable, s.healthErr)
+	}
+	return nil
+}
+
+// Monitor checks the remote signer at the health check interval until the context is done, so that block
+// production pauses while it is unavailable and resumes once it is back.
+func (s *Signer) Monitor(ctx context.Context) {
+	if s.interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.check(ctx)
+		}
+	}
+}
+
+// check fetches the public key of the remote signer and updates its health.
+func (s *Signer) check(ctx context.Context) {
+	pubKey, err := s.fetchPublic(ctx)
+	if ctx.Err() != nil {
+		return
+	}
+	if err == nil && !pubKey.Equals(s.pubKey) {
+		err = errors.New("remote signer serves another key")
+	}
+	s.setHealth(err)
+}
+
+// fetchPublic requests the public key of the remote signer.
+func (s *Signer) fetchPublic(ctx context.Context) (crypto.PubKey, error) {
+	ctx, cancel := s.requestContext(ctx)
+	defer cancel()
+	resp, err := s.client.GetPublic(ctx, connect.NewRequest(&pb.GetPublicRequest{}))
+	if err != nil {
+		return nil, err
+	}
+	return signer.UnmarshalPublicKey(resp.Msg.PublicKey)
+}
+
+func (s *Signer) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.timeout)
+}
+
+// setHealth records the result of the last request, logging transitions.
+func (s *Signer) setHealth(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case err != nil && s.healthErr == nil:
+		s.logger.Error("remote signer unavailable, pausing block production", "error", err)
+	case err == nil && s.healthErr != nil:
+		s.logger.Info("remote signer available again")
+	}
+	s.healthErr = err
+}
+
+// server exposes a Signer as a SignerService.
+type server struct {
+	signer signer.Signer
+}
+
+// NewHandler returns the path and handler serving the SignerService backed by the signer.
+func NewHandler(s signer.Signer, opts ...connect.HandlerOption) (string, http.Handler) {
+	return rpc.NewSignerServiceHandler(&server{signer: s}, opts...)
+}
+
+// Sign implements the SignerServiceHandler interface.
+func (s *server) Sign(_ context.Context, req *connect.Request[pb.SignRequest]) (*connect.Response[pb.SignResponse], error) {
+	signature, err := s.signer.Sign(req.Msg.Message)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&pb.SignResponse{Signature: signature}), nil
+}
+
+// GetPublic implements the SignerServiceHandler interface.
+func (s *server) GetPublic(context.Context, *connect.Request[pb.GetPublicRequest]) (*connect.Response[pb.GetPublicResponse], error) {
+	pubKey, err := s.signer.GetPublic()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	bz, err := signer.MarshalPublicKey(pubKey)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return connect.NewResponse(&pb.GetPublicResponse{PublicKey: bz}), nil
+}
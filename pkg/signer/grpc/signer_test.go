@@ -0,0 +1,164 @@
+package grpc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+)
+
+func newTestSigner(t *testing.T, scheme string) signer.Signer {
+	t.Helper()
+	privKey, _, err := signer.GenerateKeyPair(scheme, rand.Reader)
+	require.NoError(t, err)
+	s, err := noop.NewNoopSigner(privKey)
+	require.NoError(t, err)
+	return s
+}
+
+// writePEM writes the PEM block to a file in dir and returns its path.
+func writePEM(t *testing.T, dir, name, typ string, bz []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: bz}), 0o600))
+	return path
+}
+
+// newClientCert writes a self-signed client certificate and its key to dir, returning the certificate, its key
+// file and the pool of the certificate.
+func newClientCert(t *testing.T, dir string) (string, string, *x509.CertPool) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sequencer"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return writePEM(t, dir, "client.pem", "CERTIFICATE", der), writePEM(t, dir, "client-key.pem", "PRIVATE KEY", keyDER), pool
+}
+
+func TestSignerMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, clientCAs := newClientCert(t, dir)
+
+	local := newTestSigner(t, signer.SchemeEd25519)
+	mux := http.NewServeMux()
+	mux.Handle(NewHandler(local))
+	ts := httptest.NewUnstartedServer(mux)
+	ts.EnableHTTP2 = true
+	ts.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs, MinVersion: tls.VersionTLS12}
+	ts.StartTLS()
+	defer ts.Close()
+	caFile := writePEM(t, dir, "ca.pem", "CERTIFICATE", ts.Certificate().Raw)
+
+	conf := config.SignerConfig{
+		SignerType:  "grpc",
+		SignerPath:  ts.URL,
+		TLSCAFile:   caFile,
+		TLSCertFile: certFile,
+		TLSKeyFile:  keyFile,
+		Timeout:     config.DurationWrapper{Duration: time.Second},
+	}
+	remote, err := NewSigner(t.Context(), conf, log.NewNopLogger())
+	require.NoError(t, err)
+	require.NoError(t, remote.Healthy())
+
+	localKey, err := local.GetPublic()
+	require.NoError(t, err)
+	remoteKey, err := remote.GetPublic()
+	require.NoError(t, err)
+	assert.True(t, localKey.Equals(remoteKey))
+	localAddress, err := local.GetAddress()
+	require.NoError(t, err)
+	remoteAddress, err := remote.GetAddress()
+	require.NoError(t, err)
+	assert.Equal(t, localAddress, remoteAddress)
+
+	message := []byte("header")
+	signature, err := remote.Sign(message)
+	require.NoError(t, err)
+	valid, err := remoteKey.Verify(message, signature)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	// the server rejects clients without certificate
+	conf.TLSCertFile, conf.TLSKeyFile = "", ""
+	_, err = NewSigner(t.Context(), conf, log.NewNopLogger())
+	assert.Error(t, err)
+}
+
+func TestSignerHealth(t *testing.T) {
+	local := newTestSigner(t, signer.SchemeBLS)
+	mux := http.NewServeMux()
+	mux.Handle(NewHandler(local))
+	ts := httptest.NewUnstartedServer(mux)
+	ts.Config.Protocols = new(http.Protocols)
+	ts.Config.Protocols.SetUnencryptedHTTP2(true)
+	ts.Start()
+	defer ts.Close()
+
+	conf := config.SignerConfig{
+		SignerType: "grpc",
+		SignerPath: ts.Listener.Addr().String(),
+		Timeout:    config.DurationWrapper{Duration: time.Second},
+	}
+	remote, err := NewSigner(t.Context(), conf, log.NewNopLogger())
+	require.NoError(t, err)
+	scheme, err := signer.SchemeOf(remote.pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, signer.SchemeBLS, scheme)
+
+	remote.check(t.Context())
+	require.NoError(t, remote.Healthy())
+
+	// the signer is unhealthy while unreachable
+	ts.Close()
+	remote.check(t.Context())
+	assert.ErrorIs(t, remote.Healthy(), ErrUnavailable)
+	_, err = remote.Sign([]byte("header"))
+	assert.ErrorIs(t, err, ErrUnavailable)
+}
+
+func TestLoadTLSConfig(t *testing.T) {
+	tlsConfig, err := LoadTLSConfig("", "", "")
+	require.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+
+	_, err = LoadTLSConfig("", "client.pem", "client-key.pem")
+	assert.Error(t, err)
+	_, err = LoadTLSConfig(filepath.Join(t.TempDir(), "missing.pem"), "", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,136 @@
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/rollkit/rollkit/pkg/signer/bls"
+)
+
+// Signature schemes of the sequencer keys.
+const (
+	// SchemeEd25519 signs with ed25519 keys. It is the default scheme.
+	SchemeEd25519 = "ed25519"
+	// SchemeSecp256k1 signs with secp256k1 keys: signatures are DER encoded ECDSA signatures of the SHA-256
+	// digest of the message.
+	SchemeSecp256k1 = "secp256k1"
+	// SchemeBLS signs with BLS12-381 keys, see package bls.
+	SchemeBLS = "bls"
+)
+
+// ErrUnknownScheme is returned for signature schemes or keys of signature schemes not supported.
+var ErrUnknownScheme = errors.New("unknown signature scheme")
+
+// ValidateScheme checks that the signature scheme is supported.
+func ValidateScheme(scheme string) error {
+	switch scheme {
+	case SchemeEd25519, SchemeSecp256k1, SchemeBLS:
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownScheme, scheme)
+	}
+}
+
+// SchemeOf returns the signature scheme of the key.
+func SchemeOf(key crypto.Key) (string, error) {
+	switch key.Type() {
+	case crypto.Ed25519:
+		return SchemeEd25519, nil
+	case crypto.Secp256k1:
+		return SchemeSecp256k1, nil
+	case bls.KeyType:
+		return SchemeBLS, nil
+	default:
+		return "", fmt.Errorf("%w: key type %s", ErrUnknownScheme, key.Type())
+	}
+}
+
+// GenerateKeyPair generates a key pair of the signature scheme using the given source of randomness.
+func GenerateKeyPair(scheme string, src io.Reader) (crypto.PrivKey, crypto.PubKey, error) {
+	switch scheme {
+	case SchemeEd25519:
+		return crypto.GenerateEd25519Key(src)
+	case SchemeSecp256k1:
+		return crypto.GenerateSecp256k1Key(src)
+	case SchemeBLS:
+		return bls.GenerateKey(src)
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", ErrUnknownScheme, scheme)
+	}
+}
+
+// UnmarshalRawPrivateKey decodes a raw private key of the signature scheme.
+func UnmarshalRawPrivateKey(scheme string, data []byte) (crypto.PrivKey, error) {
+	switch scheme {
+	case SchemeEd25519:
+		return crypto.UnmarshalEd25519PrivateKey(data)
+	case SchemeSecp256k1:
+		return crypto.UnmarshalSecp256k1PrivateKey(data)
+	case SchemeBLS:
+		return bls.UnmarshalPrivateKey(data)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownScheme, scheme)
+	}
+}
+
+// UnmarshalRawPublicKey decodes a raw public key of the signature scheme.
+func UnmarshalRawPublicKey(scheme string, data []byte) (crypto.PubKey, error) {
+	switch scheme {
+	case SchemeEd25519:
+		return crypto.UnmarshalEd25519PublicKey(data)
+	case SchemeSecp256k1:
+		return crypto.UnmarshalSecp256k1PublicKey(data)
+	case SchemeBLS:
+		return bls.UnmarshalPublicKey(data)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownScheme, scheme)
+	}
+}
+
+// MarshalPublicKey encodes a public key with the libp2p key encoding. libp2p does not know BLS keys, so they are
+// encoded by hand with the BLS key type.
+func MarshalPublicKey(key crypto.PubKey) ([]byte, error) {
+	if key.Type() != bls.KeyType {
+		return crypto.MarshalPublicKey(key)
+	}
+	data, err := key.Raw()
+	if err != nil {
+		return nil, err
+	}
+	bz := protowire.AppendTag(nil, 1, protowire.VarintType)
+	bz = protowire.AppendVarint(bz, uint64(bls.KeyType))
+	bz = protowire.AppendTag(bz, 2, protowire.BytesType)
+	return protowire.AppendBytes(bz, data), nil
+}
+
+// UnmarshalPublicKey decodes a public key encoded by MarshalPublicKey.
+func UnmarshalPublicKey(bz []byte) (crypto.PubKey, error) {
+	if data, ok := blsKeyData(bz); ok {
+		return bls.UnmarshalPublicKey(data)
+	}
+	return crypto.UnmarshalPublicKey(bz)
+}
+
+// blsKeyData returns the raw key of a BLS public key encoded by MarshalPublicKey.
+func blsKeyData(bz []byte) ([]byte, bool) {
+	num, typ, n := protowire.ConsumeTag(bz)
+	if n < 0 || num != 1 || typ != protowire.VarintType {
+		return nil, false
+	}
+	bz = bz[n:]
+	keyType, n := protowire.ConsumeVarint(bz)
+	if n < 0 || keyType != uint64(bls.KeyType) {
+		return nil, false
+	}
+	bz = bz[n:]
+	num, typ, n = protowire.ConsumeTag(bz)
+	if n < 0 || num != 2 || typ != protowire.BytesType {
+		return nil, false
+	}
+	data, n := protowire.ConsumeBytes(bz[n:])
+	return data, n >= 0
+}
@@ -0,0 +1,50 @@
+package signer
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemes(t *testing.T) {
+	for _, scheme := range []string{SchemeEd25519, SchemeSecp256k1, SchemeBLS} {
+		t.Run(scheme, func(t *testing.T) {
+			require.NoError(t, ValidateScheme(scheme))
+			priv, pub, err := GenerateKeyPair(scheme, rand.Reader)
+			require.NoError(t, err)
+
+			got, err := SchemeOf(pub)
+			require.NoError(t, err)
+			assert.Equal(t, scheme, got)
+
+			sig, err := priv.Sign([]byte("header"))
+			require.NoError(t, err)
+			ok, err := pub.Verify([]byte("header"), sig)
+			require.NoError(t, err)
+			assert.True(t, ok)
+
+			bz, err := MarshalPublicKey(pub)
+			require.NoError(t, err)
+			decoded, err := UnmarshalPublicKey(bz)
+			require.NoError(t, err)
+			assert.True(t, pub.Equals(decoded))
+
+			raw, err := priv.Raw()
+			require.NoError(t, err)
+			decodedPriv, err := UnmarshalRawPrivateKey(scheme, raw)
+			require.NoError(t, err)
+			assert.True(t, priv.Equals(decodedPriv))
+			raw, err = pub.Raw()
+			require.NoError(t, err)
+			decoded, err = UnmarshalRawPublicKey(scheme, raw)
+			require.NoError(t, err)
+			assert.True(t, pub.Equals(decoded))
+		})
+	}
+
+	assert.ErrorIs(t, ValidateScheme("rsa"), ErrUnknownScheme)
+	_, _, err := GenerateKeyPair("rsa", rand.Reader)
+	assert.ErrorIs(t, err, ErrUnknownScheme)
+}
@@ -10,14 +10,14 @@ import (
 )
 
 // NoopSigner implements the remote_signer.Signer interface.
-// It generates a new Ed25519 key pair for each instance.
+// It signs with an in-memory private key of any signature scheme.
 type NoopSigner struct {
 	privKey crypto.PrivKey
 	pubKey  crypto.PubKey
 	address []byte
 }
 
-// NewNoopSigner creates a new signer with a fresh Ed25519 key pair.
+// NewNoopSigner creates a new signer with the given private key.
 func NewNoopSigner(privKey crypto.PrivKey) (signer.Signer, error) {
 	sig := &NoopSigner{
 		privKey: privKey,
@@ -33,7 +33,7 @@ func NewNoopSigner(privKey crypto.PrivKey) (signer.Signer, error) {
 	return sig, nil
 }
 
-// Sign implements the Signer interface by signing the message with the Ed25519 private key.
+// Sign implements the Signer interface by signing the message with the private key.
 func (n *NoopSigner) Sign(message []byte) ([]byte, error) {
 	if n.privKey == nil {
 		return nil, fmt.Errorf("private key not loaded")
@@ -41,17 +41,17 @@ func (n *NoopSigner) Sign(message []byte) ([]byte, error) {
 	return n.privKey.Sign(message)
 }
 
-// GetPublic implements the Signer interface by returning the Ed25519 public key.
+// GetPublic implements the Signer interface by returning the public key.
 func (n *NoopSigner) GetPublic() (crypto.PubKey, error) {
 	return n.pubKey, nil
 }
 
-// GetAddress implements the Signer interface by returning the Ed25519 address.
+// GetAddress implements the Signer interface by returning the address of the public key.
 func (n *NoopSigner) GetAddress() ([]byte, error) {
 	return n.address, nil
 }
 
-// getAddress returns the Ed25519 address of the signer.
+// getAddress returns the address of the signer.
 func getAddress(pubKey crypto.PubKey) ([]byte, error) {
 	bz, err := pubKey.Raw()
 	if err != nil {
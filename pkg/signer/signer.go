@@ -15,3 +15,9 @@ type Signer interface {
 	// GetAddress returns the address of the signer.
 	GetAddress() ([]byte, error)
 }
+
+// HealthChecker is implemented by signers whose key can become unavailable, e.g. remote signers.
+type HealthChecker interface {
+	// Healthy returns an error while the signer cannot sign.
+	Healthy() error
+}
@@ -0,0 +1,128 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrDoubleSign is returned when signing a header at or below the high-watermark of the signer.
+var ErrDoubleSign = errors.New("refusing to sign at or below the signer high-watermark")
+
+// Watermark is the position of the last header signed by the aggregator.
+type Watermark struct {
+	Height    uint64    `json:"height"`
+	Round     uint32    `json:"round"`
+	Timestamp time.Time `json:"timestamp"`
+	// SignBytesHash and Signature are those of the last signed header, which is signed again when the node
+	// restarts before the header was saved
+	SignBytesHash []byte `json:"sign_bytes_hash,omitempty"`
+	Signature     []byte `json:"signature,omitempty"`
+}
+
+// WatermarkFile is the high-watermark of a signer persisted in a local file. Signing through it refuses to sign a
+// header at or below the last signed header, preventing double-signing after restoring the node from a backup or
+// when two aggregators run with the same key during a failover.
+type WatermarkFile struct {
+	path string
+
+	mu        sync.Mutex
+	watermark Watermark
+}
+
+// LoadWatermarkFile loads the high-watermark from the file at path. A missing file is an empty watermark.
+func LoadWatermarkFile(path string) (*WatermarkFile, error) {
+	f := &WatermarkFile{path: path}
+	bz, err := os.ReadFile(path) //nolint:gosec // path is provided by the operator
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signer watermark file %s: %w", path, err)
+	}
+	if err := json.Unmarshal(bz, &f.watermark); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal signer watermark file %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Watermark returns the position of the last signed header.
+func (f *WatermarkFile) Watermark() Watermark {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.watermark
+}
+
+// Sign signs the sign bytes of the header at the given height and round with the signer, if it is above the
+// high-watermark, and raises the watermark before returning the signature. Signing the last signed header again
+// returns its signature. It returns ErrDoubleSign for any other header at or below the watermark.
+func (f *WatermarkFile) Sign(signer Signer, height uint64, round uint32, timestamp time.Time, signBytes []byte) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	hash := sha256.Sum256(signBytes)
+	if height < f.watermark.Height || (height == f.watermark.Height && round <= f.watermark.Round) {
+		if height == f.watermark.Height && round == f.watermark.Round &&
+			len(f.watermark.Signature) > 0 && bytes.Equal(f.watermark.SignBytesHash, hash[:]) {
+			return f.watermark.Signature, nil
+		}
+		return nil, fmt.Errorf("%w: height %d round %d, watermark at height %d round %d",
+			ErrDoubleSign, height, round, f.watermark.Height, f.watermark.Round)
+	}
+
+	signature, err := signer.Sign(signBytes)
+	if err != nil {
+		return nil, err
+	}
+	watermark := Watermark{
+		Height:        height,
+		Round:         round,
+		Timestamp:     timestamp,
+		SignBytesHash: hash[:],
+		Signature:     signature,
+	}
+	// the signature is only released once the watermark is persisted
+	if err := writeWatermark(f.path, watermark); err != nil {
+		return nil, err
+	}
+	f.watermark = watermark
+	return signature, nil
+}
+
+// ResetWatermark overrides the high-watermark stored at path, allowing headers above the given height to be
+// signed. It must only be used once the operator confirmed that no other signer signed above the height, e.g.
+// when intentionally rolling back the chain.
+func ResetWatermark(path string, height uint64) error {
+	return writeWatermark(path, Watermark{Height: height, Timestamp: time.Now()})
+}
+
+// writeWatermark atomically replaces the watermark file, syncing it to disk.
+func writeWatermark(path string, watermark Watermark) error {
+	bz, err := json.MarshalIndent(watermark, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal signer watermark: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create signer watermark directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600) //nolint:gosec // path is provided by the operator
+	if err != nil {
+		return fmt.Errorf("failed to write signer watermark file: %w", err)
+	}
+	_, err = file.Write(bz)
+	err = errors.Join(err, file.Sync(), file.Close())
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to write signer watermark file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package signer
+
+import (
+	"crypto/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSigner signs with a private key, counting signatures.
+type testSigner struct {
+	crypto.PrivKey
+	signed int
+}
+
+func (s *testSigner) Sign(message []byte) ([]byte, error) {
+	s.signed++
+	return s.PrivKey.Sign(message)
+}
+
+func (s *testSigner) GetPublic() (crypto.PubKey, error) {
+	return s.PrivKey.GetPublic(), nil
+}
+
+func (s *testSigner) GetAddress() ([]byte, error) {
+	return nil, nil
+}
+
+func TestWatermarkFile(t *testing.T) {
+	priv, _, err := GenerateKeyPair(SchemeEd25519, rand.Reader)
+	require.NoError(t, err)
+	signer := &testSigner{PrivKey: priv}
+	path := filepath.Join(t.TempDir(), "data", "signer_watermark.json")
+
+	watermark, err := LoadWatermarkFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), watermark.Watermark().Height)
+
+	now := time.Now().UTC()
+	sig, err := watermark.Sign(signer, 5, 0, now, []byte("header 5"))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), watermark.Watermark().Height)
+
+	// the last signed header is signed again, without signing
+	again, err := watermark.Sign(signer, 5, 0, now, []byte("header 5"))
+	require.NoError(t, err)
+	assert.Equal(t, sig, again)
+	assert.Equal(t, 1, signer.signed)
+
+	// any other header at or below the watermark is refused
+	_, err = watermark.Sign(signer, 5, 0, now, []byte("other header 5"))
+	assert.ErrorIs(t, err, ErrDoubleSign)
+	_, err = watermark.Sign(signer, 4, 0, now, []byte("header 4"))
+	assert.ErrorIs(t, err, ErrDoubleSign)
+	assert.Equal(t, 1, signer.signed)
+
+	// the watermark is persisted, e.g. when the node restarts
+	_, err = watermark.Sign(signer, 6, 0, now, []byte("header 6"))
+	require.NoError(t, err)
+	watermark, err = LoadWatermarkFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), watermark.Watermark().Height)
+	assert.True(t, now.Equal(watermark.Watermark().Timestamp))
+	_, err = watermark.Sign(signer, 6, 0, now, []byte("other header 6"))
+	assert.ErrorIs(t, err, ErrDoubleSign)
+
+	// the operator can override the watermark
+	require.NoError(t, ResetWatermark(path, 4))
+	watermark, err = LoadWatermarkFile(path)
+	require.NoError(t, err)
+	_, err = watermark.Sign(signer, 4, 0, now, []byte("header 4"))
+	assert.ErrorIs(t, err, ErrDoubleSign)
+	_, err = watermark.Sign(signer, 5, 0, now, []byte("other header 5"))
+	require.NoError(t, err)
+}
@@ -0,0 +1,192 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/libp2p/go-msgio/pbio"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+const (
+	// maxMessageSize is the maximum size of a snapshot protocol message, allowing a full chunk and some overhead.
+	maxMessageSize = ChunkSize + 1024*1024
+
+	// streamTimeout is the deadline of a single snapshot request.
+	streamTimeout = 30 * time.Second
+)
+
+// SnapshotHeightFunc returns the highest height at which snapshots may be served, i.e. the height
+// up to which blocks (and thus the committed state roots) are included in the DA layer.
+type SnapshotHeightFunc func() uint64
+
+// Service serves snapshots to peers and fetches snapshots from peers using a libp2p stream protocol.
+type Service struct {
+	host      host.Host
+	protocol  protocol.ID
+	store     *Store
+	maxHeight SnapshotHeightFunc
+	logger    log.Logger
+}
+
+// NewService creates a snapshot Service for the given chain. Only snapshots of heights strictly
+// below maxHeight are served, so that the state root committed in the next header is DA included.
+func NewService(host host.Host, chainID string, store *Store, maxHeight SnapshotHeightFunc, logger log.Logger) *Service {
+	return &Service{
+		host:      host,
+		protocol:  ProtocolID(chainID),
+		store:     store,
+		maxHeight: maxHeight,
+		logger:    logger,
+	}
+}
+
+// ProtocolID returns the libp2p protocol ID of the snapshot protocol for the given chain.
+func ProtocolID(chainID string) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/%s/snapshot/v0.0.1", chainID))
+}
+
+// Start registers the snapshot protocol handler on the host.
+func (s *Service) Start() {
+	s.host.SetStreamHandler(s.protocol, s.handleStream)
+}
+
+// Stop removes the snapshot protocol handler from the host.
+func (s *Service) Stop() {
+	s.host.RemoveStreamHandler(s.protocol)
+}
+
+func (s *Service) handleStream(stream network.Stream) {
+	defer stream.Close() //nolint:errcheck
+	_ = stream.SetDeadline(time.Now().Add(streamTimeout))
+
+	ctx, cancel := context.WithTimeout(context.Background(), streamTimeout)
+	defer cancel()
+
+	req := new(pb.SnapshotRequest)
+	if err := pbio.NewDelimitedReader(stream, maxMessageSize).ReadMsg(req); err != nil {
+		s.logger.Debug("failed to read snapshot request", "peer", stream.Conn().RemotePeer(), "error", err)
+		_ = stream.Reset()
+		return
+	}
+
+	resp := s.handleRequest(ctx, req)
+	if err := pbio.NewDelimitedWriter(stream).WriteMsg(resp); err != nil {
+		s.logger.Debug("failed to write snapshot response", "peer", stream.Conn().RemotePeer(), "error", err)
+		_ = stream.Reset()
+	}
+}
+
+func (s *Service) handleRequest(ctx context.Context, req *pb.SnapshotRequest) *pb.SnapshotResponse {
+	maxHeight := s.maxHeight()
+	if maxHeight == 0 {
+		return &pb.SnapshotResponse{Error: ErrNotFound.Error()}
+	}
+	if req.Manifest {
+		manifest, err := s.store.Latest(ctx, maxHeight-1)
+		if err != nil {
+			return &pb.SnapshotResponse{Error: err.Error()}
+		}
+		return &pb.SnapshotResponse{Manifest: manifest}
+	}
+	if req.Height >= maxHeight {
+		return &pb.SnapshotResponse{Error: ErrNotFound.Error()}
+	}
+	chunk, err := s.store.Chunk(ctx, req.Height, req.Chunk)
+	if err != nil {
+		return &pb.SnapshotResponse{Error: err.Error()}
+	}
+	return &pb.SnapshotResponse{Chunk: chunk}
+}
+
+// Fetch downloads the latest snapshot from the first of the given peers able to serve one.
+// The chunks are verified against the manifest; verifying the manifest itself against the
+// chain is left to the caller.
+func (s *Service) Fetch(ctx context.Context, peers []peer.ID) (*Snapshot, error) {
+	var errs error
+	for _, p := range peers {
+		snapshot, err := s.fetchFromPeer(ctx, p)
+		if err == nil {
+			return snapshot, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		s.logger.Debug("failed to fetch snapshot from peer", "peer", p, "error", err)
+		errs = errors.Join(errs, fmt.Errorf("peer %s: %w", p, err))
+	}
+	if errs == nil {
+		return nil, errors.New("no peers to fetch snapshot from")
+	}
+	return nil, errs
+}
+
+func (s *Service) fetchFromPeer(ctx context.Context, p peer.ID) (*Snapshot, error) {
+	resp, err := s.request(ctx, p, &pb.SnapshotRequest{Manifest: true})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Manifest == nil {
+		return nil, errors.New("empty snapshot manifest")
+	}
+	manifest := resp.Manifest
+	snapshot, err := FromManifest(manifest)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("fetching snapshot", "peer", p, "height", manifest.Height, "chunks", len(manifest.ChunkHashes))
+
+	snapshot.Chunks = make([][]byte, len(manifest.ChunkHashes))
+	for i := range manifest.ChunkHashes {
+		index := uint32(i) //nolint:gosec // chunk count is bounded by the message size
+		resp, err := s.request(ctx, p, &pb.SnapshotRequest{Height: manifest.Height, Chunk: index})
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch chunk %d: %w", index, err)
+		}
+		if err := VerifyChunk(manifest, index, resp.Chunk); err != nil {
+			return nil, err
+		}
+		snapshot.Chunks[i] = resp.Chunk
+	}
+	return snapshot, nil
+}
+
+func (s *Service) request(ctx context.Context, p peer.ID, req *pb.SnapshotRequest) (*pb.SnapshotResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, streamTimeout)
+	defer cancel()
+
+	stream, err := s.host.NewStream(ctx, p, s.protocol)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close() //nolint:errcheck
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = stream.SetDeadline(deadline)
+	}
+
+	if err := pbio.NewDelimitedWriter(stream).WriteMsg(req); err != nil {
+		_ = stream.Reset()
+		return nil, err
+	}
+	if err := stream.CloseWrite(); err != nil {
+		_ = stream.Reset()
+		return nil, err
+	}
+	resp := new(pb.SnapshotResponse)
+	if err := pbio.NewDelimitedReader(stream, maxMessageSize).ReadMsg(resp); err != nil {
+		_ = stream.Reset()
+		return nil, err
+	}
+	if resp.Error != "" {
+		return nil, errors.New(resp.Error)
+	}
+	return resp, nil
+}
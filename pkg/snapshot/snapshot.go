@@ -0,0 +1,122 @@
+package snapshot
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// ChunkSize is the maximum size, in bytes, of a snapshot chunk transferred over p2p.
+const ChunkSize = 4 * 1024 * 1024 // 4 MiB
+
+// ErrInvalidChunk is returned when a chunk does not match the hash committed in the manifest.
+var ErrInvalidChunk = errors.New("invalid snapshot chunk")
+
+// Snapshot is a snapshot of the rollup state taken after executing the block at Height.
+// The execution state is split in chunks whose hashes are committed in the manifest.
+type Snapshot struct {
+	Height uint64
+	State  types.State
+	Header *types.SignedHeader
+	Data   *types.Data
+	Chunks [][]byte
+}
+
+// New creates a Snapshot of the given execution state, splitting it in chunks of ChunkSize.
+func New(state types.State, header *types.SignedHeader, data *types.Data, appState []byte) (*Snapshot, error) {
+	if header.Height() != state.LastBlockHeight {
+		return nil, fmt.Errorf("header height %d does not match state height %d", header.Height(), state.LastBlockHeight)
+	}
+	if len(appState) == 0 {
+		return nil, errors.New("empty execution state")
+	}
+	var chunks [][]byte
+	for i := 0; i < len(appState); i += ChunkSize {
+		end := min(i+ChunkSize, len(appState))
+		chunks = append(chunks, appState[i:end])
+	}
+	return &Snapshot{
+		Height: state.LastBlockHeight,
+		State:  state,
+		Header: header,
+		Data:   data,
+		Chunks: chunks,
+	}, nil
+}
+
+// AppState returns the execution state by concatenating all chunks.
+func (s *Snapshot) AppState() []byte {
+	return bytes.Join(s.Chunks, nil)
+}
+
+// Manifest returns the protobuf manifest describing the snapshot.
+func (s *Snapshot) Manifest() (*pb.SnapshotManifest, error) {
+	state, err := s.State.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	header, err := s.Header.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([][]byte, len(s.Chunks))
+	for i, chunk := range s.Chunks {
+		hashes[i] = chunkHash(chunk)
+	}
+	return &pb.SnapshotManifest{
+		Height:      s.Height,
+		State:       state,
+		Header:      header,
+		Data:        s.Data.ToProto(),
+		ChunkHashes: hashes,
+	}, nil
+}
+
+// FromManifest creates a Snapshot without chunks from a manifest. Chunks are
+// expected to be filled in by the caller once fetched and verified with VerifyChunk.
+func FromManifest(manifest *pb.SnapshotManifest) (*Snapshot, error) {
+	s := &Snapshot{
+		Height: manifest.Height,
+		Header: new(types.SignedHeader),
+		Data:   new(types.Data),
+	}
+	if manifest.State == nil || manifest.State.Version == nil || manifest.Header == nil || manifest.Data == nil {
+		return nil, errors.New("incomplete snapshot manifest")
+	}
+	if err := s.State.FromProto(manifest.State); err != nil {
+		return nil, fmt.Errorf("invalid snapshot state: %w", err)
+	}
+	if err := s.Header.FromProto(manifest.Header); err != nil {
+		return nil, fmt.Errorf("invalid snapshot header: %w", err)
+	}
+	if err := s.Data.FromProto(manifest.Data); err != nil {
+		return nil, fmt.Errorf("invalid snapshot data: %w", err)
+	}
+	if s.State.LastBlockHeight != s.Height || s.Header.Height() != s.Height {
+		return nil, fmt.Errorf("inconsistent snapshot manifest at height %d", s.Height)
+	}
+	if len(manifest.ChunkHashes) == 0 {
+		return nil, errors.New("snapshot manifest has no chunks")
+	}
+	return s, nil
+}
+
+// VerifyChunk checks that the chunk at the given index matches the hash committed in the manifest.
+func VerifyChunk(manifest *pb.SnapshotManifest, index uint32, chunk []byte) error {
+	if int(index) >= len(manifest.ChunkHashes) {
+		return fmt.Errorf("%w: index %d out of range", ErrInvalidChunk, index)
+	}
+	if !bytes.Equal(chunkHash(chunk), manifest.ChunkHashes[index]) {
+		return fmt.Errorf("%w: hash mismatch at index %d", ErrInvalidChunk, index)
+	}
+	return nil
+}
+
+func chunkHash(chunk []byte) []byte {
+	hash := sha256.Sum256(chunk)
+	return hash[:]
+}
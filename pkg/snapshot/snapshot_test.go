@@ -0,0 +1,140 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+const testChainID = "snapshot-test"
+
+func newTestSnapshot(t *testing.T, height uint64, appState []byte) *Snapshot {
+	t.Helper()
+	header, data := types.GetRandomBlock(height, 2, testChainID)
+	state := types.State{
+		Version:         types.InitStateVersion,
+		ChainID:         testChainID,
+		InitialHeight:   1,
+		LastBlockHeight: height,
+		LastBlockTime:   header.Time(),
+		AppHash:         types.GetRandomBytes(32),
+	}
+	snap, err := New(state, header, data, appState)
+	require.NoError(t, err)
+	return snap
+}
+
+func newTestStore(t *testing.T, keepRecent int) *Store {
+	t.Helper()
+	store, err := NewStore(dssync.MutexWrap(ds.NewMapDatastore()), keepRecent)
+	require.NoError(t, err)
+	return store
+}
+
+func TestSnapshotManifest(t *testing.T) {
+	appState := bytes.Repeat([]byte{1, 2, 3}, ChunkSize) // 3 chunks
+	snap := newTestSnapshot(t, 10, appState)
+	require.Len(t, snap.Chunks, 3)
+	assert.Equal(t, appState, snap.AppState())
+
+	manifest, err := snap.Manifest()
+	require.NoError(t, err)
+	assert.Len(t, manifest.ChunkHashes, 3)
+
+	restored, err := FromManifest(manifest)
+	require.NoError(t, err)
+	assert.Equal(t, snap.Height, restored.Height)
+	assert.Equal(t, snap.State.AppHash, restored.State.AppHash)
+	assert.Equal(t, snap.Header.Hash(), restored.Header.Hash())
+
+	for i, chunk := range snap.Chunks {
+		assert.NoError(t, VerifyChunk(manifest, uint32(i), chunk)) //nolint:gosec
+	}
+	assert.ErrorIs(t, VerifyChunk(manifest, 0, snap.Chunks[1]), ErrInvalidChunk)
+	assert.ErrorIs(t, VerifyChunk(manifest, 3, snap.Chunks[0]), ErrInvalidChunk)
+
+	_, err = New(snap.State, snap.Header, snap.Data, nil)
+	assert.Error(t, err)
+	manifest.Height = 11
+	_, err = FromManifest(manifest)
+	assert.Error(t, err)
+}
+
+func TestStore(t *testing.T) {
+	ctx := t.Context()
+	store := newTestStore(t, 2)
+
+	_, err := store.Latest(ctx, 100)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	for _, height := range []uint64{10, 20, 30} {
+		require.NoError(t, store.Save(ctx, newTestSnapshot(t, height, []byte{byte(height)})))
+	}
+
+	heights, err := store.Heights(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{20, 30}, heights)
+
+	manifest, err := store.Latest(ctx, 29)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), manifest.Height)
+
+	chunk, err := store.Chunk(ctx, 30, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{30}, chunk)
+
+	_, err = store.Chunk(ctx, 10, 0)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = store.Latest(ctx, 19)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestServiceFetch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	mnet, err := mocknet.FullMeshLinked(2)
+	require.NoError(t, err)
+	defer mnet.Close() //nolint:errcheck
+	hosts := mnet.Hosts()
+
+	servingStore := newTestStore(t, 2)
+	for _, height := range []uint64{10, 20} {
+		require.NoError(t, servingStore.Save(ctx, newTestSnapshot(t, height, bytes.Repeat([]byte{byte(height)}, ChunkSize+1))))
+	}
+
+	var daIncludedHeight atomic.Uint64
+	daIncludedHeight.Store(20)
+	server := NewService(hosts[0], testChainID, servingStore, daIncludedHeight.Load, log.NewNopLogger())
+	server.Start()
+	defer server.Stop()
+
+	client := NewService(hosts[1], testChainID, newTestStore(t, 1), func() uint64 { return 0 }, log.NewNopLogger())
+
+	// the snapshot at height 20 is not served as the next header is not DA included yet
+	snap, err := client.Fetch(ctx, []peer.ID{hosts[0].ID()})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), snap.Height)
+	assert.Equal(t, bytes.Repeat([]byte{10}, ChunkSize+1), snap.AppState())
+
+	daIncludedHeight.Store(21)
+	snap, err = client.Fetch(ctx, []peer.ID{hosts[0].ID()})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(20), snap.Height)
+
+	// the client does not serve any snapshot
+	_, err = server.Fetch(ctx, []peer.ID{hosts[1].ID()})
+	assert.Error(t, err)
+}
@@ -0,0 +1,180 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+const (
+	manifestKey = "manifest"
+	chunkPrefix = "chunk"
+)
+
+// ErrNotFound is returned when no snapshot is available.
+var ErrNotFound = errors.New("snapshot not found")
+
+// Store persists snapshots in a datastore, keeping only the keepRecent most recent ones.
+type Store struct {
+	db         ds.Batching
+	keepRecent int
+}
+
+// NewStore creates a snapshot Store on top of the given datastore.
+func NewStore(db ds.Batching, keepRecent int) (*Store, error) {
+	if keepRecent < 1 {
+		return nil, fmt.Errorf("invalid number of snapshots to keep: %d", keepRecent)
+	}
+	return &Store{db: db, keepRecent: keepRecent}, nil
+}
+
+// Save persists a snapshot and deletes the snapshots exceeding the retention.
+func (s *Store) Save(ctx context.Context, snapshot *Snapshot) error {
+	manifest, err := snapshot.Manifest()
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot manifest: %w", err)
+	}
+	manifestBytes, err := proto.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot manifest: %w", err)
+	}
+
+	batch, err := s.db.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create a new batch: %w", err)
+	}
+	for i, chunk := range snapshot.Chunks {
+		if err := batch.Put(ctx, chunkKey(snapshot.Height, uint32(i)), chunk); err != nil { //nolint:gosec // chunk count is bounded by the state size
+			return fmt.Errorf("failed to put snapshot chunk: %w", err)
+		}
+	}
+	// the manifest is written last so that a snapshot is only listed once all its chunks are stored
+	if err := batch.Put(ctx, heightKey(snapshot.Height).ChildString(manifestKey), manifestBytes); err != nil {
+		return fmt.Errorf("failed to put snapshot manifest: %w", err)
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit snapshot: %w", err)
+	}
+	return s.prune(ctx)
+}
+
+// Heights returns the heights of all stored snapshots in ascending order.
+func (s *Store) Heights(ctx context.Context) ([]uint64, error) {
+	results, err := s.db.Query(ctx, dsq.Query{KeysOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer results.Close()
+
+	var heights []uint64
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		key := ds.NewKey(result.Key)
+		if key.Name() != manifestKey {
+			continue
+		}
+		height, err := strconv.ParseUint(key.Parent().Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+		heights = append(heights, height)
+	}
+	slices.Sort(heights)
+	return heights, nil
+}
+
+// Latest returns the manifest of the most recent snapshot taken at or below maxHeight.
+func (s *Store) Latest(ctx context.Context, maxHeight uint64) (*pb.SnapshotManifest, error) {
+	heights, err := s.Heights(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(heights) - 1; i >= 0; i-- {
+		if heights[i] <= maxHeight {
+			return s.Manifest(ctx, heights[i])
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// Manifest returns the manifest of the snapshot at the given height.
+func (s *Store) Manifest(ctx context.Context, height uint64) (*pb.SnapshotManifest, error) {
+	bz, err := s.db.Get(ctx, heightKey(height).ChildString(manifestKey))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load snapshot manifest at height %d: %w", height, err)
+	}
+	manifest := new(pb.SnapshotManifest)
+	if err := proto.Unmarshal(bz, manifest); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snapshot manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// Chunk returns the chunk with the given index of the snapshot at the given height.
+func (s *Store) Chunk(ctx context.Context, height uint64, index uint32) ([]byte, error) {
+	chunk, err := s.db.Get(ctx, chunkKey(height, index))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	return chunk, err
+}
+
+// prune deletes the oldest snapshots exceeding the retention.
+func (s *Store) prune(ctx context.Context) error {
+	heights, err := s.Heights(ctx)
+	if err != nil {
+		return err
+	}
+	if len(heights) <= s.keepRecent {
+		return nil
+	}
+	for _, height := range heights[:len(heights)-s.keepRecent] {
+		if err := s.delete(ctx, height); err != nil {
+			return fmt.Errorf("failed to delete snapshot at height %d: %w", height, err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) delete(ctx context.Context, height uint64) error {
+	results, err := s.db.Query(ctx, dsq.Query{Prefix: heightKey(height).String(), KeysOnly: true})
+	if err != nil {
+		return err
+	}
+	defer results.Close()
+
+	batch, err := s.db.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	for result := range results.Next() {
+		if result.Error != nil {
+			return result.Error
+		}
+		if err := batch.Delete(ctx, ds.NewKey(result.Key)); err != nil {
+			return err
+		}
+	}
+	return batch.Commit(ctx)
+}
+
+func heightKey(height uint64) ds.Key {
+	return ds.NewKey(strconv.FormatUint(height, 10))
+}
+
+func chunkKey(height uint64, index uint32) ds.Key {
+	return heightKey(height).ChildString(chunkPrefix).ChildString(strconv.FormatUint(uint64(index), 10))
+}
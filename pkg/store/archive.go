@@ -0,0 +1,368 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// Chain archives hold a range of blocks exported from a store, to migrate a node to new hardware or seed nodes
+// out-of-band instead of syncing them over p2p or from the DA layer. An archive starts with an uncompressed
+// preamble:
+//
+//	magic "RKCA" | version (1 byte) | from (8 bytes) | to (8 bytes) | chain ID length (uvarint) | chain ID
+//
+// followed by a zstd stream of one record per block, in ascending height order:
+//
+//	header length (uvarint) | header | data length (uvarint) | data | signature length (uvarint) | signature | CRC-32C (4 bytes)
+//
+// where the CRC-32C covers the record, and terminated by a zero header length and the SHA-256 of all records.
+// Heights and checksums are big-endian.
+const (
+	// archiveMagic identifies chain archives
+	archiveMagic = "RKCA"
+	// ArchiveVersion is the version of the chain archive format written by ExportBlocks
+	ArchiveVersion = 1
+
+	// maxArchiveFieldSize bounds the size of a header, data or signature read from an archive
+	maxArchiveFieldSize = 64 * 1024 * 1024
+)
+
+var (
+	// ErrInvalidArchive is returned when a chain archive is malformed or fails its checksums.
+	ErrInvalidArchive = errors.New("invalid chain archive")
+
+	crc32c = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// ArchiveInfo describes the blocks held in a chain archive.
+type ArchiveInfo struct {
+	ChainID string
+	From    uint64
+	To      uint64
+}
+
+// ImportResult reports the outcome of ImportBlocks.
+type ImportResult struct {
+	ArchiveInfo
+	// Imported is the number of blocks saved to the store
+	Imported uint64
+	// Skipped is the number of blocks already in the store, e.g. imported before an interrupted import was resumed
+	Skipped uint64
+}
+
+// ExportBlocks writes the blocks of the store from height from to height to, inclusive, to w as a chain archive.
+func ExportBlocks(ctx context.Context, s Store, from, to uint64, w io.Writer) error {
+	height, err := s.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get store height: %w", err)
+	}
+	if from == 0 || from > to || to > height {
+		return fmt.Errorf("invalid export range [%d, %d], store height is %d", from, to, height)
+	}
+	if err := CheckNotPruned(ctx, s, from); err != nil {
+		return err
+	}
+	first, _, err := s.GetBlockData(ctx, from)
+	if err != nil {
+		return fmt.Errorf("failed to load block %d: %w", from, err)
+	}
+
+	preamble := make([]byte, 0, len(archiveMagic)+1+16+binary.MaxVarintLen64+len(first.ChainID()))
+	preamble = append(preamble, archiveMagic...)
+	preamble = append(preamble, ArchiveVersion)
+	preamble = binary.BigEndian.AppendUint64(preamble, from)
+	preamble = binary.BigEndian.AppendUint64(preamble, to)
+	preamble = binary.AppendUvarint(preamble, uint64(len(first.ChainID())))
+	preamble = append(preamble, first.ChainID()...)
+	if _, err := w.Write(preamble); err != nil {
+		return fmt.Errorf("failed to write archive preamble: %w", err)
+	}
+
+	zw, err := zstd.NewWriter(w)
+	if err != nil {
+		return err
+	}
+	if err := writeArchiveRecords(ctx, s, from, to, zw); err != nil {
+		_ = zw.Close()
+		return err
+	}
+	return zw.Close()
+}
+
+// writeArchiveRecords writes the records of the blocks from height from to height to, followed by the
+// terminator and the checksum of the records.
+func writeArchiveRecords(ctx context.Context, s Store, from, to uint64, w io.Writer) error {
+	checksum := sha256.New()
+	records := io.MultiWriter(w, checksum)
+	for h := from; h <= to; h++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		header, data, err := s.GetBlockData(ctx, h)
+		if err != nil {
+			return fmt.Errorf("failed to load block %d: %w", h, err)
+		}
+		signature, err := s.GetSignature(ctx, h)
+		if err != nil {
+			return fmt.Errorf("failed to load signature of block %d: %w", h, err)
+		}
+		record, err := encodeArchiveRecord(header, data, *signature)
+		if err != nil {
+			return fmt.Errorf("failed to encode block %d: %w", h, err)
+		}
+		if _, err := records.Write(record); err != nil {
+			return fmt.Errorf("failed to write block %d: %w", h, err)
+		}
+	}
+	if _, err := w.Write(binary.AppendUvarint(nil, 0)); err != nil {
+		return err
+	}
+	_, err := w.Write(checksum.Sum(nil))
+	return err
+}
+
+// ImportBlocks reads a chain archive written by ExportBlocks from r and saves its blocks to the store. Every block
+// is validated, and must extend the previous block of the archive or the last block of the store. Blocks already
+// in the store are skipped after checking they match the archive, so that an interrupted import is resumed by
+// importing the same archive again. The first block of the archive must follow the last block of the store,
+// unless the store is empty. Once all blocks are saved, the checksum of the archive is verified and the
+// imported blocks are checked with VerifyBlocks.
+func ImportBlocks(ctx context.Context, s Store, r io.Reader) (ImportResult, error) {
+	br := bufio.NewReader(r)
+	info, err := readArchivePreamble(br)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	result := ImportResult{ArchiveInfo: info}
+
+	height, err := s.Height(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to get store height: %w", err)
+	}
+	if height != 0 && info.From > height+1 {
+		return result, fmt.Errorf("archive starts at height %d, but the store ends at height %d", info.From, height)
+	}
+
+	zr, err := zstd.NewReader(br)
+	if err != nil {
+		return result, err
+	}
+	defer zr.Close()
+	records := bufio.NewReader(zr)
+	checksum := sha256.New()
+
+	var prev *types.SignedHeader
+	if info.From > 1 && height >= info.From-1 {
+		if prev, _, err = s.GetBlockData(ctx, info.From-1); err != nil {
+			return result, fmt.Errorf("failed to load block %d: %w", info.From-1, err)
+		}
+	}
+	for h := info.From; h <= info.To; h++ {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		header, data, signature, err := readArchiveRecord(records, checksum)
+		if err != nil {
+			return result, fmt.Errorf("failed to read block %d: %w", h, err)
+		}
+		if header.Height() != h || header.ChainID() != info.ChainID {
+			return result, fmt.Errorf("%w: unexpected block %d of chain %q at height %d", ErrInvalidArchive, header.Height(), header.ChainID(), h)
+		}
+		if err := validateArchivedBlock(prev, header, data); err != nil {
+			return result, fmt.Errorf("invalid block %d: %w", h, err)
+		}
+		prev = header
+
+		if h <= height {
+			stored, _, err := s.GetBlockData(ctx, h)
+			if err != nil {
+				return result, fmt.Errorf("failed to load block %d: %w", h, err)
+			}
+			if !bytes.Equal(stored.Hash(), header.Hash()) {
+				return result, fmt.Errorf("block %d of the archive does not match the block of the store", h)
+			}
+			result.Skipped++
+			continue
+		}
+		if err := s.SaveBlockData(ctx, header, data, &signature); err != nil {
+			return result, fmt.Errorf("failed to save block %d: %w", h, err)
+		}
+		if err := s.SetHeight(ctx, h); err != nil {
+			return result, fmt.Errorf("failed to set store height: %w", err)
+		}
+		result.Imported++
+	}
+
+	if size, err := binary.ReadUvarint(records); err != nil || size != 0 {
+		return result, fmt.Errorf("%w: missing terminator after block %d", ErrInvalidArchive, info.To)
+	}
+	var expected [sha256.Size]byte
+	if _, err := io.ReadFull(records, expected[:]); err != nil {
+		return result, fmt.Errorf("%w: missing checksum: %w", ErrInvalidArchive, err)
+	}
+	if !bytes.Equal(expected[:], checksum.Sum(nil)) {
+		return result, fmt.Errorf("%w: archive checksum mismatch", ErrInvalidArchive)
+	}
+
+	if err := VerifyBlocks(ctx, s, info.From, info.To); err != nil {
+		return result, fmt.Errorf("imported blocks failed verification: %w", err)
+	}
+	return result, nil
+}
+
+// VerifyBlocks checks the integrity of the blocks of the store from height from to height to, inclusive: every
+// header is validated, matches its data and signature, and links to the previous header.
+func VerifyBlocks(ctx context.Context, s Store, from, to uint64) error {
+	var prev *types.SignedHeader
+	if from > 1 {
+		// the link to the previous block is only checked if the store holds it
+		prev, _, _ = s.GetBlockData(ctx, from-1)
+	}
+	for h := from; h <= to; h++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		header, data, err := s.GetBlockData(ctx, h)
+		if err != nil {
+			return fmt.Errorf("failed to load block %d: %w", h, err)
+		}
+		signature, err := s.GetSignature(ctx, h)
+		if err != nil {
+			return fmt.Errorf("failed to load signature of block %d: %w", h, err)
+		}
+		if header.Height() != h {
+			return fmt.Errorf("block at height %d has height %d", h, header.Height())
+		}
+		if !bytes.Equal(*signature, header.Signature) {
+			return fmt.Errorf("signature of block %d does not match its header", h)
+		}
+		if err := validateArchivedBlock(prev, header, data); err != nil {
+			return fmt.Errorf("invalid block %d: %w", h, err)
+		}
+		prev = header
+	}
+	return nil
+}
+
+// validateArchivedBlock validates a block and checks that it extends the previous block, if known.
+func validateArchivedBlock(prev, header *types.SignedHeader, data *types.Data) error {
+	if err := header.ValidateBasic(); err != nil {
+		return err
+	}
+	if err := types.Validate(header, data); err != nil {
+		return err
+	}
+	if prev != nil && !bytes.Equal(prev.Hash(), header.LastHeader()) {
+		return types.ErrLastHeaderHashMismatch
+	}
+	return nil
+}
+
+func readArchivePreamble(r *bufio.Reader) (ArchiveInfo, error) {
+	var fixed [len(archiveMagic) + 1 + 16]byte
+	if _, err := io.ReadFull(r, fixed[:]); err != nil {
+		return ArchiveInfo{}, fmt.Errorf("%w: failed to read preamble: %w", ErrInvalidArchive, err)
+	}
+	if string(fixed[:len(archiveMagic)]) != archiveMagic {
+		return ArchiveInfo{}, fmt.Errorf("%w: bad magic", ErrInvalidArchive)
+	}
+	if version := fixed[len(archiveMagic)]; version != ArchiveVersion {
+		return ArchiveInfo{}, fmt.Errorf("%w: unsupported version %d", ErrInvalidArchive, version)
+	}
+	info := ArchiveInfo{
+		From: binary.BigEndian.Uint64(fixed[len(archiveMagic)+1:]),
+		To:   binary.BigEndian.Uint64(fixed[len(archiveMagic)+9:]),
+	}
+	if info.From == 0 || info.From > info.To {
+		return ArchiveInfo{}, fmt.Errorf("%w: invalid range [%d, %d]", ErrInvalidArchive, info.From, info.To)
+	}
+	chainID, err := readArchiveField(r)
+	if err != nil {
+		return ArchiveInfo{}, fmt.Errorf("%w: failed to read chain ID: %w", ErrInvalidArchive, err)
+	}
+	info.ChainID = string(chainID)
+	return info, nil
+}
+
+func encodeArchiveRecord(header *types.SignedHeader, data *types.Data, signature types.Signature) ([]byte, error) {
+	headerBz, err := header.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	dataBz, err := data.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	var record []byte
+	for _, field := range [][]byte{headerBz, dataBz, signature} {
+		record = binary.AppendUvarint(record, uint64(len(field)))
+		record = append(record, field...)
+	}
+	return binary.BigEndian.AppendUint32(record, crc32.Checksum(record, crc32c)), nil
+}
+
+// readArchiveRecord reads the record of a block, and adds it to the checksum of the archive.
+func readArchiveRecord(r *bufio.Reader, checksum hash.Hash) (*types.SignedHeader, *types.Data, types.Signature, error) {
+	var (
+		record []byte
+		fields [3][]byte
+	)
+	for i := range fields {
+		field, err := readArchiveField(r)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+		}
+		if i == 0 && len(field) == 0 {
+			return nil, nil, nil, fmt.Errorf("%w: unexpected end of blocks", ErrInvalidArchive)
+		}
+		record = binary.AppendUvarint(record, uint64(len(field)))
+		record = append(record, field...)
+		fields[i] = field
+	}
+	var crc [4]byte
+	if _, err := io.ReadFull(r, crc[:]); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	if binary.BigEndian.Uint32(crc[:]) != crc32.Checksum(record, crc32c) {
+		return nil, nil, nil, fmt.Errorf("%w: record checksum mismatch", ErrInvalidArchive)
+	}
+	checksum.Write(record)
+	checksum.Write(crc[:])
+
+	header := new(types.SignedHeader)
+	if err := header.UnmarshalBinary(fields[0]); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: failed to unmarshal header: %w", ErrInvalidArchive, err)
+	}
+	data := new(types.Data)
+	if err := data.UnmarshalBinary(fields[1]); err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: failed to unmarshal data: %w", ErrInvalidArchive, err)
+	}
+	return header, data, types.Signature(fields[2]), nil
+}
+
+func readArchiveField(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	if size > maxArchiveFieldSize {
+		return nil, fmt.Errorf("field of %d bytes exceeds the limit of %d bytes", size, maxArchiveFieldSize)
+	}
+	field := make([]byte, size)
+	if _, err := io.ReadFull(r, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
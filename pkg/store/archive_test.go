@@ -0,0 +1,122 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/types"
+)
+
+// saveChain saves n linked and signed blocks to the store.
+func saveChain(t *testing.T, s Store, n uint64) []*types.SignedHeader {
+	t.Helper()
+	ctx := context.Background()
+	privKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	signer, err := noop.NewNoopSigner(privKey)
+	require.NoError(t, err)
+
+	var headers []*types.SignedHeader
+	for height := uint64(1); height <= n; height++ {
+		header, data, _ := types.GenerateRandomBlockCustom(&types.BlockConfig{Height: height, NTxs: 2, PrivKey: privKey}, "archive-chain")
+		if height > 1 {
+			header.LastHeaderHash = headers[height-2].Hash()
+			header.Signature, err = types.GetSignature(header.Header, signer)
+			require.NoError(t, err)
+		}
+		require.NoError(t, s.SaveBlockData(ctx, header, data, &header.Signature))
+		require.NoError(t, s.SetHeight(ctx, height))
+		headers = append(headers, header)
+	}
+	return headers
+}
+
+func TestExportImportBlocks(t *testing.T) {
+	ctx := context.Background()
+	source := New(NewMemoryKVStore())
+	headers := saveChain(t, source, 5)
+
+	var archive bytes.Buffer
+	require.NoError(t, ExportBlocks(ctx, source, 1, 5, &archive))
+
+	target := New(NewMemoryKVStore())
+	res, err := ImportBlocks(ctx, target, bytes.NewReader(archive.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, ImportResult{ArchiveInfo: ArchiveInfo{ChainID: "archive-chain", From: 1, To: 5}, Imported: 5}, res)
+	height, err := target.Height(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), height)
+	for _, header := range headers {
+		stored, _, err := target.GetBlockData(ctx, header.Height())
+		require.NoError(t, err)
+		assert.Equal(t, header.Hash(), stored.Hash())
+	}
+	require.NoError(t, VerifyBlocks(ctx, target, 1, 5))
+
+	// importing the archive again resumes the import, skipping the blocks already imported
+	res, err = ImportBlocks(ctx, target, bytes.NewReader(archive.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), res.Imported)
+	assert.Equal(t, uint64(5), res.Skipped)
+
+	// an archive extending the store is imported after it
+	extended := New(NewMemoryKVStore())
+	var first, rest bytes.Buffer
+	require.NoError(t, ExportBlocks(ctx, source, 1, 2, &first))
+	_, err = ImportBlocks(ctx, extended, bytes.NewReader(first.Bytes()))
+	require.NoError(t, err)
+	require.NoError(t, ExportBlocks(ctx, source, 3, 5, &rest))
+	res, err = ImportBlocks(ctx, extended, &rest)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), res.Imported)
+
+	// an archive leaving a gap after the store is refused
+	gapped := New(NewMemoryKVStore())
+	_, err = ImportBlocks(ctx, gapped, bytes.NewReader(first.Bytes()))
+	require.NoError(t, err)
+	var gap bytes.Buffer
+	require.NoError(t, ExportBlocks(ctx, source, 4, 5, &gap))
+	_, err = ImportBlocks(ctx, gapped, &gap)
+	require.Error(t, err)
+}
+
+func TestImportBlocks_Corrupted(t *testing.T) {
+	ctx := context.Background()
+	source := New(NewMemoryKVStore())
+	saveChain(t, source, 3)
+
+	var archive bytes.Buffer
+	require.NoError(t, ExportBlocks(ctx, source, 1, 3, &archive))
+
+	// truncated archives are detected, and the blocks read before the truncation are kept for resuming
+	truncated := archive.Bytes()[:archive.Len()-10]
+	target := New(NewMemoryKVStore())
+	_, err := ImportBlocks(ctx, target, bytes.NewReader(truncated))
+	require.ErrorIs(t, err, ErrInvalidArchive)
+	res, err := ImportBlocks(ctx, target, bytes.NewReader(archive.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), res.Imported+res.Skipped)
+
+	// archives of an unsupported version are refused
+	bad := bytes.Clone(archive.Bytes())
+	bad[len(archiveMagic)] = ArchiveVersion + 1
+	_, err = ImportBlocks(ctx, New(NewMemoryKVStore()), bytes.NewReader(bad))
+	require.ErrorIs(t, err, ErrInvalidArchive)
+
+	// blocks not linked to the previous block fail verification
+	unlinked := New(NewMemoryKVStore())
+	saveChain(t, unlinked, 1)
+	var other bytes.Buffer
+	otherSource := New(NewMemoryKVStore())
+	saveChain(t, otherSource, 2)
+	require.NoError(t, ExportBlocks(ctx, otherSource, 2, 2, &other))
+	_, err = ImportBlocks(ctx, unlinked, &other)
+	require.ErrorIs(t, err, types.ErrLastHeaderHashMismatch)
+}
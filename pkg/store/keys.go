@@ -18,6 +18,10 @@ func getSignatureKey(height uint64) string {
 	return GenerateKey([]string{signaturePrefix, strconv.FormatUint(height, 10)})
 }
 
+func getDAMetadataKey(height uint64) string {
+	return GenerateKey([]string{daMetadataPrefix, strconv.FormatUint(height, 10)})
+}
+
 func getStateKey() string {
 	return statePrefix
 }
@@ -33,3 +37,7 @@ func getIndexKey(hash types.Hash) string {
 func getHeightKey() string {
 	return GenerateKey([]string{heightPrefix})
 }
+
+func getSchemaVersionKey() string {
+	return GenerateKey([]string{schemaVersionPrefix})
+}
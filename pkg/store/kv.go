@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"path"
 	"path/filepath"
 	"strings"
@@ -9,7 +10,10 @@ import (
 
 	ds "github.com/ipfs/go-datastore"
 	dsq "github.com/ipfs/go-datastore/query"
+	dssync "github.com/ipfs/go-datastore/sync"
 	badger4 "github.com/ipfs/go-ds-badger4"
+
+	"github.com/rollkit/rollkit/pkg/config"
 )
 
 // NewDefaultInMemoryKVStore builds KVStore that works in-memory (without accessing disk).
@@ -29,6 +33,24 @@ func NewDefaultKVStore(rootDir, dbPath, dbName string) (ds.Batching, error) {
 	return badger4.NewDatastore(path, nil)
 }
 
+// NewMemoryKVStore builds KVStore keeping all data in a map. Unlike NewDefaultInMemoryKVStore, it does not
+// allocate badger memtables, which makes it cheap to create in tests and ephemeral devnets.
+func NewMemoryKVStore() ds.Batching {
+	return dssync.MutexWrap(ds.NewMapDatastore())
+}
+
+// NewKVStore creates the key-value store of the database backend set in the configuration.
+func NewKVStore(conf config.Config, dbName string) (ds.Batching, error) {
+	switch conf.DBBackend {
+	case config.DBBackendBadger, "":
+		return NewDefaultKVStore(conf.RootDir, conf.DBPath, dbName)
+	case config.DBBackendMemory:
+		return NewMemoryKVStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown database backend %q", conf.DBBackend)
+	}
+}
+
 // PrefixEntries retrieves all entries in the datastore whose keys have the supplied prefix
 func PrefixEntries(ctx context.Context, store ds.Datastore, prefix string) (dsq.Results, error) {
 	results, err := store.Query(ctx, dsq.Query{Prefix: prefix})
@@ -0,0 +1,157 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/dgraph-io/badger/v4"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	badger4 "github.com/ipfs/go-ds-badger4"
+)
+
+var (
+	// ErrCompactionUnsupported is returned when the datastore backend does not support compaction.
+	ErrCompactionUnsupported = errors.New("datastore does not support compaction")
+	// ErrCompactionRunning is returned when a compaction is requested while another one is running.
+	ErrCompactionRunning = errors.New("compaction already running")
+)
+
+// usagePrefixDepth is the number of key namespaces grouped together when reporting disk usage,
+// e.g. "/0/h" for the headers saved by the rollkit store.
+const usagePrefixDepth = 2
+
+// PrefixUsage is the number of keys and bytes, including keys and values, stored under a key prefix.
+type PrefixUsage struct {
+	Prefix string
+	Keys   uint64
+	Bytes  uint64
+}
+
+// DiskUsage reports the size of the datastore.
+type DiskUsage struct {
+	// Total is the on-disk size reported by the backend, or 0 if the backend does not report it.
+	Total uint64
+	// Prefixes is the logical size of the data stored under each key prefix, sorted by prefix.
+	Prefixes []PrefixUsage
+}
+
+// Maintainer compacts the datastore backing the node, reclaiming the space of deleted and overwritten
+// entries, and reports its disk usage.
+type Maintainer struct {
+	db       ds.Datastore
+	interval time.Duration
+	logger   log.Logger
+
+	compacting sync.Mutex
+}
+
+// NewMaintainer creates a new Maintainer for the datastore. If interval is positive, Run compacts the
+// datastore every interval.
+func NewMaintainer(db ds.Datastore, interval time.Duration, logger log.Logger) *Maintainer {
+	return &Maintainer{
+		db:       db,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+// Run compacts the datastore every interval until the context is canceled.
+func (m *Maintainer) Run(ctx context.Context) error {
+	if m.interval <= 0 {
+		return nil
+	}
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if err := m.Compact(ctx); err != nil && ctx.Err() == nil {
+			m.logger.Error("failed to compact datastore", "error", err)
+		}
+	}
+}
+
+// Compact runs the compaction of the datastore backend. For badger, the LSM tree is flattened and the value
+// log is garbage collected. Other backends are compacted if they implement datastore.GCDatastore.
+func (m *Maintainer) Compact(ctx context.Context) error {
+	if !m.compacting.TryLock() {
+		return ErrCompactionRunning
+	}
+	defer m.compacting.Unlock()
+
+	before, _ := ds.DiskUsage(ctx, m.db)
+	start := time.Now()
+	switch db := m.db.(type) {
+	case *badger4.Datastore:
+		if err := db.DB.Flatten(runtime.NumCPU()); err != nil {
+			return fmt.Errorf("failed to flatten badger LSM tree: %w", err)
+		}
+		if err := db.CollectGarbage(ctx); err != nil && !errors.Is(err, badger.ErrGCInMemoryMode) {
+			return fmt.Errorf("failed to collect badger value log garbage: %w", err)
+		}
+	case ds.GCDatastore:
+		if err := db.CollectGarbage(ctx); err != nil {
+			return fmt.Errorf("failed to collect garbage: %w", err)
+		}
+	default:
+		return ErrCompactionUnsupported
+	}
+	after, _ := ds.DiskUsage(ctx, m.db)
+	m.logger.Info("compacted datastore", "diskUsageBefore", before, "diskUsageAfter", after, "duration", time.Since(start))
+	return nil
+}
+
+// DiskUsage returns the on-disk size of the datastore and the size of the data stored under each key prefix.
+// It iterates over all keys, so it should not be called frequently on large datastores.
+func (m *Maintainer) DiskUsage(ctx context.Context) (DiskUsage, error) {
+	total, err := ds.DiskUsage(ctx, m.db)
+	if err != nil {
+		return DiskUsage{}, fmt.Errorf("failed to get disk usage: %w", err)
+	}
+	results, err := m.db.Query(ctx, dsq.Query{KeysOnly: true, ReturnsSizes: true})
+	if err != nil {
+		return DiskUsage{}, fmt.Errorf("failed to query datastore: %w", err)
+	}
+	defer results.Close() //nolint:errcheck // read-only query
+
+	usage := make(map[string]*PrefixUsage)
+	for res := range results.Next() {
+		if res.Error != nil {
+			return DiskUsage{}, fmt.Errorf("failed to iterate datastore: %w", res.Error)
+		}
+		prefix := usagePrefix(res.Key)
+		u, ok := usage[prefix]
+		if !ok {
+			u = &PrefixUsage{Prefix: prefix}
+			usage[prefix] = u
+		}
+		u.Keys++
+		u.Bytes += uint64(len(res.Key) + res.Size) //nolint:gosec // sizes are not negative
+	}
+
+	prefixes := make([]PrefixUsage, 0, len(usage))
+	for _, u := range usage {
+		prefixes = append(prefixes, *u)
+	}
+	sort.Slice(prefixes, func(i, j int) bool { return prefixes[i].Prefix < prefixes[j].Prefix })
+	return DiskUsage{Total: total, Prefixes: prefixes}, nil
+}
+
+// usagePrefix returns the prefix under which the size of the key is reported.
+func usagePrefix(key string) string {
+	namespaces := ds.RawKey(key).Namespaces()
+	if len(namespaces) > usagePrefixDepth {
+		namespaces = namespaces[:usagePrefixDepth]
+	}
+	return ds.KeyWithNamespaces(namespaces).String()
+}
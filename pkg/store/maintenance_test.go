@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintainer(t *testing.T) {
+	ctx := context.Background()
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	for key, value := range map[string]string{
+		"/0/h/1":       "header1",
+		"/0/h/2":       "header2",
+		"/0/m/pruned":  "1",
+		"/0":           "root",
+		"/exec/state1": "state",
+	} {
+		require.NoError(t, kv.Put(ctx, ds.NewKey(key), []byte(value)))
+	}
+	require.NoError(t, kv.Delete(ctx, ds.NewKey("/0/h/2")))
+
+	m := NewMaintainer(kv, 0, log.NewNopLogger())
+	require.NoError(t, m.Compact(ctx))
+
+	usage, err := m.DiskUsage(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []PrefixUsage{
+		{Prefix: "/0", Keys: 1, Bytes: uint64(len("/0") + len("root"))},
+		{Prefix: "/0/h", Keys: 1, Bytes: uint64(len("/0/h/1") + len("header1"))},
+		{Prefix: "/0/m", Keys: 1, Bytes: uint64(len("/0/m/pruned") + len("1"))},
+		{Prefix: "/exec/state1", Keys: 1, Bytes: uint64(len("/exec/state1") + len("state"))},
+	}, usage.Prefixes)
+
+	// compactions do not run concurrently
+	m.compacting.Lock()
+	require.ErrorIs(t, m.Compact(ctx), ErrCompactionRunning)
+	m.compacting.Unlock()
+
+	// the datastore backend does not support compaction
+	m = NewMaintainer(ds.NewMapDatastore(), 0, log.NewNopLogger())
+	require.ErrorIs(t, m.Compact(ctx), ErrCompactionUnsupported)
+}
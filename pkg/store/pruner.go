@@ -0,0 +1,182 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+)
+
+// PrunedHeightKey is the metadata key used for persisting the height up to which blocks have been pruned.
+const PrunedHeightKey = "pruned-height"
+
+// ErrPruned is returned for blocks which were pruned from the store.
+var ErrPruned = errors.New("block pruned")
+
+// PrunableHeightFunc returns the highest height whose block data may be pruned, i.e. blocks that are DA finalized.
+type PrunableHeightFunc func() uint64
+
+// Pruner periodically deletes headers and data of blocks that are older than the retention window
+// and that are reported as prunable by the block manager.
+type Pruner struct {
+	store    Store
+	prunable PrunableHeightFunc
+	logger   log.Logger
+
+	// mu guards keepRecent and interval, which can be changed while the pruner is running
+	mu         sync.Mutex
+	keepRecent uint64
+	interval   time.Duration
+	// intervalCh is used to signal Run when the interval is changed
+	intervalCh chan struct{}
+}
+
+// NewPruner creates a new Pruner keeping the keepRecent most recent blocks.
+func NewPruner(store Store, keepRecent uint64, interval time.Duration, prunable PrunableHeightFunc, logger log.Logger) (*Pruner, error) {
+	if err := validatePruningParams(keepRecent, interval); err != nil {
+		return nil, err
+	}
+	return &Pruner{
+		store:      store,
+		keepRecent: keepRecent,
+		interval:   interval,
+		intervalCh: make(chan struct{}, 1),
+		prunable:   prunable,
+		logger:     logger,
+	}, nil
+}
+
+func validatePruningParams(keepRecent uint64, interval time.Duration) error {
+	if keepRecent == 0 {
+		return errors.New("keep recent must be greater than 0")
+	}
+	if interval <= 0 {
+		return fmt.Errorf("invalid pruning interval: %s", interval)
+	}
+	return nil
+}
+
+// SetParams changes the number of most recent blocks kept and the pruning interval of a running pruner.
+func (p *Pruner) SetParams(keepRecent uint64, interval time.Duration) error {
+	if err := validatePruningParams(keepRecent, interval); err != nil {
+		return err
+	}
+	p.mu.Lock()
+	changed := p.interval != interval
+	p.keepRecent, p.interval = keepRecent, interval
+	p.mu.Unlock()
+
+	if changed {
+		select {
+		case p.intervalCh <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// params returns the number of most recent blocks kept and the pruning interval.
+func (p *Pruner) params() (uint64, time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.keepRecent, p.interval
+}
+
+// Run prunes the store every interval until the context is canceled.
+func (p *Pruner) Run(ctx context.Context) error {
+	_, interval := p.params()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.intervalCh:
+			_, interval := p.params()
+			ticker.Reset(interval)
+			continue
+		case <-ticker.C:
+		}
+		if _, err := p.Prune(ctx); err != nil && ctx.Err() == nil {
+			p.logger.Error("failed to prune blocks", "error", err)
+		}
+	}
+}
+
+// Prune deletes all blocks that can be pruned and returns the height up to which blocks are pruned.
+func (p *Pruner) Prune(ctx context.Context) (uint64, error) {
+	pruned, err := p.PrunedHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+	height, err := p.store.Height(ctx)
+	if err != nil {
+		return pruned, fmt.Errorf("failed to get store height: %w", err)
+	}
+	keepRecent, _ := p.params()
+	if height <= keepRecent {
+		return pruned, nil
+	}
+	target := min(height-keepRecent, p.prunable())
+	if target <= pruned {
+		return pruned, nil
+	}
+
+	for h := pruned + 1; h <= target; h++ {
+		if err := p.store.DeleteBlockData(ctx, h); err != nil {
+			return pruned, fmt.Errorf("failed to prune block at height %d: %w", h, err)
+		}
+		pruned = h
+		// persist progress periodically so a restart does not need to start over
+		if h%1000 == 0 || h == target {
+			if err := p.setPrunedHeight(ctx, pruned); err != nil {
+				return pruned, err
+			}
+		}
+	}
+	p.logger.Debug("pruned blocks", "prunedHeight", pruned, "height", height)
+	return pruned, nil
+}
+
+// PrunedHeight returns the height up to which blocks have been pruned.
+func (p *Pruner) PrunedHeight(ctx context.Context) (uint64, error) {
+	return GetPrunedHeight(ctx, p.store)
+}
+
+// CheckNotPruned returns an error wrapping ErrPruned, with the earliest height whose block is available, if the
+// block at the given height was pruned from the store.
+func CheckNotPruned(ctx context.Context, store Store, height uint64) error {
+	pruned, err := GetPrunedHeight(ctx, store)
+	if err != nil {
+		return fmt.Errorf("failed to get pruned height: %w", err)
+	}
+	if height <= pruned {
+		return fmt.Errorf("%w: block at height %d is not available, earliest available height is %d", ErrPruned, height, pruned+1)
+	}
+	return nil
+}
+
+// GetPrunedHeight returns the height up to which blocks have been pruned from the store, 0 if no block was
+// pruned.
+func GetPrunedHeight(ctx context.Context, store Store) (uint64, error) {
+	bz, err := store.GetMetadata(ctx, PrunedHeightKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(bz) != heightLength {
+		return 0, fmt.Errorf("invalid length of pruned height: %d, expected %d", len(bz), heightLength)
+	}
+	return binary.LittleEndian.Uint64(bz), nil
+}
+
+func (p *Pruner) setPrunedHeight(ctx context.Context, height uint64) error {
+	return p.store.SetMetadata(ctx, PrunedHeightKey, encodeHeight(height))
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestPruner(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+	ctx := t.Context()
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := New(kv)
+
+	chainID := "TestPruner"
+	hashes := make(map[uint64]types.Hash)
+	for h := uint64(1); h <= 10; h++ {
+		header, data := types.GetRandomBlock(h, 1, chainID)
+		require.NoError(s.SaveBlockData(ctx, header, data, &types.Signature{}))
+		require.NoError(s.SetHeight(ctx, h))
+		hashes[h] = header.Hash()
+	}
+
+	finalized := uint64(4)
+	_, err = NewPruner(s, 0, time.Minute, func() uint64 { return finalized }, log.NewNopLogger())
+	require.Error(err)
+	p, err := NewPruner(s, 3, time.Minute, func() uint64 { return finalized }, log.NewNopLogger())
+	require.NoError(err)
+
+	// pruning is bounded by the finalized height
+	pruned, err := p.Prune(ctx)
+	require.NoError(err)
+	assert.Equal(uint64(4), pruned)
+
+	// pruning is bounded by the retention window
+	finalized = 10
+	pruned, err = p.Prune(ctx)
+	require.NoError(err)
+	assert.Equal(uint64(7), pruned)
+
+	persisted, err := p.PrunedHeight(ctx)
+	require.NoError(err)
+	assert.Equal(uint64(7), persisted)
+
+	for h := uint64(1); h <= 10; h++ {
+		_, _, err := s.GetBlockData(ctx, h)
+		if h <= 7 {
+			assert.Error(err, "block %d should be pruned", h)
+			err = CheckNotPruned(ctx, s, h)
+			assert.ErrorIs(err, ErrPruned)
+			assert.ErrorContains(err, "earliest available height is 8")
+		} else {
+			assert.NoError(err, "block %d should be kept", h)
+			assert.NoError(CheckNotPruned(ctx, s, h))
+		}
+		// commitments to pruned blocks are kept
+		_, err = s.GetSignatureByHash(ctx, hashes[h])
+		assert.NoError(err)
+	}
+
+	// the retention window can be changed while the pruner is running
+	require.Error(p.SetParams(0, time.Minute))
+	require.NoError(p.SetParams(1, time.Second))
+	pruned, err = p.Prune(ctx)
+	require.NoError(err)
+	assert.Equal(uint64(9), pruned)
+}
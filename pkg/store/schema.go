@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+)
+
+// SchemaVersion is the version of the layout and encoding of the data saved in the store. It is increased with a
+// migration whenever a release changes how data is saved, e.g. the encoding of a metadata value, so that the data
+// saved by previous releases is migrated instead of being misread.
+const SchemaVersion uint64 = 1
+
+// ErrSchemaTooNew is returned when the store was written by a newer release, whose schema is unknown.
+var ErrSchemaTooNew = errors.New("store schema version is newer than supported, the store was written by a newer release")
+
+// migration upgrades the data of a store from the previous schema version to version. Migrations are run in
+// order and the version is saved after each of them, so a migration interrupted by a crash is run again: it must
+// be idempotent.
+type migration struct {
+	version     uint64
+	description string
+	migrate     func(ctx context.Context, db ds.Batching) error
+}
+
+// migrations are the migrations of the store, by increasing version. Stores written before versioning are at
+// version 0.
+var migrations = []migration{
+	{
+		version:     1,
+		description: "record the schema version",
+		migrate:     func(context.Context, ds.Batching) error { return nil },
+	},
+}
+
+// GetSchemaVersion returns the schema version of the store, 0 if it was written before versioning, and whether it
+// holds any data.
+func GetSchemaVersion(ctx context.Context, db ds.Datastore) (uint64, bool, error) {
+	versionBytes, err := db.Get(ctx, ds.NewKey(getSchemaVersionKey()))
+	if err == nil {
+		version, err := decodeHeight(versionBytes)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid store schema version: %w", err)
+		}
+		return version, true, nil
+	}
+	if !errors.Is(err, ds.ErrNotFound) {
+		return 0, false, err
+	}
+	results, err := db.Query(ctx, dsq.Query{KeysOnly: true, Limit: 1})
+	if err != nil {
+		return 0, false, err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return 0, false, err
+	}
+	return 0, len(entries) > 0, nil
+}
+
+// Migrate migrates the data of the store to SchemaVersion, and must be called at startup before the store is
+// used. New stores are stamped with SchemaVersion. It returns ErrSchemaTooNew if the store was written by a newer
+// release, rather than corrupting it.
+func Migrate(ctx context.Context, db ds.Batching, logger log.Logger) error {
+	return migrate(ctx, db, migrations, logger)
+}
+
+func migrate(ctx context.Context, db ds.Batching, migrations []migration, logger log.Logger) error {
+	latest := uint64(0)
+	if len(migrations) > 0 {
+		latest = migrations[len(migrations)-1].version
+	}
+	version, hasData, err := GetSchemaVersion(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to read store schema version: %w", err)
+	}
+	if !hasData {
+		return setSchemaVersion(ctx, db, latest)
+	}
+	if version > latest {
+		return fmt.Errorf("%w: %d, supported %d", ErrSchemaTooNew, version, latest)
+	}
+	for _, m := range migrations {
+		if m.version <= version {
+			continue
+		}
+		logger.Info("migrating store", "from", version, "to", m.version, "migration", m.description)
+		if err := m.migrate(ctx, db); err != nil {
+			return fmt.Errorf("failed to migrate store to schema version %d: %w", m.version, err)
+		}
+		if err := setSchemaVersion(ctx, db, m.version); err != nil {
+			return err
+		}
+		version = m.version
+	}
+	return nil
+}
+
+func setSchemaVersion(ctx context.Context, db ds.Batching, version uint64) error {
+	if err := db.Put(ctx, ds.NewKey(getSchemaVersionKey()), encodeHeight(version)); err != nil {
+		return fmt.Errorf("failed to save store schema version: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrate(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	logger := log.NewNopLogger()
+	key := ds.NewKey(getMetaKey("d"))
+	require.Equal(t, SchemaVersion, migrations[len(migrations)-1].version, "SchemaVersion must be the version of the last migration")
+
+	// the test migrations change the encoding of a metadata value from little-endian to big-endian
+	var ran []uint64
+	testMigrations := []migration{
+		{version: 1, description: "record the schema version", migrate: func(context.Context, ds.Batching) error {
+			ran = append(ran, 1)
+			return nil
+		}},
+		{version: 2, description: "encode heights in big-endian", migrate: func(ctx context.Context, db ds.Batching) error {
+			ran = append(ran, 2)
+			value, err := db.Get(ctx, key)
+			if err != nil {
+				return err
+			}
+			return db.Put(ctx, key, binary.BigEndian.AppendUint64(nil, binary.LittleEndian.Uint64(value)))
+		}},
+	}
+
+	// new stores are stamped with the latest version without migrating
+	db := NewMemoryKVStore()
+	require.NoError(t, migrate(ctx, db, testMigrations, logger))
+	assert.Empty(t, ran)
+	version, hasData, err := GetSchemaVersion(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), version)
+	assert.True(t, hasData)
+
+	// stores written before versioning are migrated from version 0
+	db = NewMemoryKVStore()
+	require.NoError(t, db.Put(ctx, key, binary.LittleEndian.AppendUint64(nil, 42)))
+	require.NoError(t, migrate(ctx, db, testMigrations, logger))
+	assert.Equal(t, []uint64{1, 2}, ran)
+	value, err := db.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(42), binary.BigEndian.Uint64(value))
+
+	// migrated stores are not migrated again
+	require.NoError(t, migrate(ctx, db, testMigrations, logger))
+	assert.Equal(t, []uint64{1, 2}, ran)
+
+	// stores written by a newer release are refused
+	assert.ErrorIs(t, migrate(ctx, db, testMigrations[:1], logger), ErrSchemaTooNew)
+}
+
+func TestMigrateFailure(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	db := NewMemoryKVStore()
+	require.NoError(t, db.Put(ctx, ds.NewKey(getHeightKey()), encodeHeight(1)))
+
+	failing := []migration{
+		{version: 1, migrate: func(context.Context, ds.Batching) error { return nil }},
+		{version: 2, migrate: func(context.Context, ds.Batching) error { return errors.New("disk full") }},
+	}
+	require.Error(t, migrate(ctx, db, failing, log.NewNopLogger()))
+
+	// the version of the last successful migration is saved, so that only the failed migration is run again
+	version, _, err := GetSchemaVersion(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), version)
+
+	// the store is stamped with the current version by Migrate
+	require.NoError(t, Migrate(ctx, db, log.NewNopLogger()))
+	version, _, err = GetSchemaVersion(ctx, db)
+	require.NoError(t, err)
+	assert.Equal(t, SchemaVersion, version)
+}
@@ -21,6 +21,10 @@ var (
 	statePrefix     = "s"
 	metaPrefix      = "m"
 	heightPrefix    = "t"
+	// daMetadataPrefix is the prefix of the DA blobs including DA included blocks
+	daMetadataPrefix = "a"
+	// schemaVersionPrefix is the key of the schema version of the store
+	schemaVersionPrefix = "v"
 )
 
 // DefaultStore is a default store implmementation.
@@ -138,6 +142,92 @@ func (s *DefaultStore) GetBlockData(ctx context.Context, height uint64) (*types.
 	return header, data, nil
 }
 
+// DeleteBlockData deletes block header and data at given height.
+// The signature and the hash index of the block are kept, so commitments to pruned blocks remain available.
+func (s *DefaultStore) DeleteBlockData(ctx context.Context, height uint64) error {
+	batch, err := s.db.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create a new batch: %w", err)
+	}
+	if err := batch.Delete(ctx, ds.NewKey(getHeaderKey(height))); err != nil {
+		return fmt.Errorf("failed to delete header blob in batch: %w", err)
+	}
+	if err := batch.Delete(ctx, ds.NewKey(getDataKey(height))); err != nil {
+		return fmt.Errorf("failed to delete data blob in batch: %w", err)
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+	return nil
+}
+
+// Rollback deletes the headers, data, signatures, hash indexes and DA metadata of the blocks above the given
+// height, lowers the height of the Store to it, and saves the state and metadata, in a single batch. Metadata
+// keys with nil values are deleted.
+func (s *DefaultStore) Rollback(ctx context.Context, height uint64, state types.State, metadata map[string][]byte) error {
+	currentHeight, err := s.Height(ctx)
+	if err != nil {
+		return err
+	}
+	if height >= currentHeight {
+		return nil
+	}
+
+	batch, err := s.db.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create a new batch: %w", err)
+	}
+	for h := height + 1; h <= currentHeight; h++ {
+		headerBlob, err := s.db.Get(ctx, ds.NewKey(getHeaderKey(h)))
+		switch {
+		case errors.Is(err, ds.ErrNotFound):
+		case err != nil:
+			return fmt.Errorf("failed to load block header: %w", err)
+		default:
+			header := new(types.SignedHeader)
+			if err := header.UnmarshalBinary(headerBlob); err != nil {
+				return fmt.Errorf("failed to unmarshal block header: %w", err)
+			}
+			if err := batch.Delete(ctx, ds.NewKey(getIndexKey(header.Hash()))); err != nil {
+				return fmt.Errorf("failed to delete index key in batch: %w", err)
+			}
+		}
+		for _, key := range []string{getHeaderKey(h), getDataKey(h), getSignatureKey(h), getDAMetadataKey(h)} {
+			if err := batch.Delete(ctx, ds.NewKey(key)); err != nil {
+				return fmt.Errorf("failed to delete block at height %d in batch: %w", h, err)
+			}
+		}
+	}
+	if err := batch.Put(ctx, ds.NewKey(getHeightKey()), encodeHeight(height)); err != nil {
+		return fmt.Errorf("failed to put height in batch: %w", err)
+	}
+	pbState, err := state.ToProto()
+	if err != nil {
+		return fmt.Errorf("failed to marshal state to JSON: %w", err)
+	}
+	stateBlob, err := proto.Marshal(pbState)
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(ctx, ds.NewKey(getStateKey()), stateBlob); err != nil {
+		return fmt.Errorf("failed to put state in batch: %w", err)
+	}
+	for key, value := range metadata {
+		if value == nil {
+			err = batch.Delete(ctx, ds.NewKey(getMetaKey(key)))
+		} else {
+			err = batch.Put(ctx, ds.NewKey(getMetaKey(key)), value)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update metadata %s in batch: %w", key, err)
+		}
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+	return nil
+}
+
 // GetBlockByHash returns block with given block header hash, or error if it's not found in Store.
 func (s *DefaultStore) GetBlockByHash(ctx context.Context, hash []byte) (*types.SignedHeader, *types.Data, error) {
 	height, err := s.getHeightByHash(ctx, hash)
@@ -210,6 +300,32 @@ func (s *DefaultStore) GetState(ctx context.Context) (types.State, error) {
 	return state, err
 }
 
+// SetDAMetadata saves the DA blobs including the block at the height of the metadata. DA metadata is kept when
+// the block is pruned, so that explorers and bridges can still locate it in the DA layer.
+func (s *DefaultStore) SetDAMetadata(ctx context.Context, metadata *types.DAMetadata) error {
+	blob, err := metadata.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal DA metadata to binary: %w", err)
+	}
+	if err := s.db.Put(ctx, ds.NewKey(getDAMetadataKey(metadata.Height)), blob); err != nil {
+		return fmt.Errorf("failed to set DA metadata for height %d: %w", metadata.Height, err)
+	}
+	return nil
+}
+
+// GetDAMetadata returns the DA blobs including the block at given height, or error if they are not found in Store.
+func (s *DefaultStore) GetDAMetadata(ctx context.Context, height uint64) (*types.DAMetadata, error) {
+	blob, err := s.db.Get(ctx, ds.NewKey(getDAMetadataKey(height)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DA metadata for height %d: %w", height, err)
+	}
+	metadata := new(types.DAMetadata)
+	if err := metadata.UnmarshalBinary(blob); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal DA metadata: %w", err)
+	}
+	return metadata, nil
+}
+
 // SetMetadata saves arbitrary value in the store.
 //
 // Metadata is separated from other data by using prefix in KV.
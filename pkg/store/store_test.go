@@ -2,12 +2,15 @@ package store
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	ds "github.com/ipfs/go-datastore"
+	badger4 "github.com/ipfs/go-ds-badger4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rollkit/rollkit/pkg/config"
 	"github.com/rollkit/rollkit/types"
 )
 
@@ -86,7 +89,7 @@ func TestStoreLoad(t *testing.T) {
 
 	mKV, _ := NewDefaultInMemoryKVStore()
 	dKV, _ := NewDefaultKVStore(tmpDir, "db", "test")
-	for _, kv := range []ds.Batching{mKV, dKV} {
+	for _, kv := range []ds.Batching{mKV, dKV, NewMemoryKVStore()} {
 		for _, c := range cases {
 			t.Run(c.name, func(t *testing.T) {
 				assert := assert.New(t)
@@ -155,6 +158,40 @@ func TestRestart(t *testing.T) {
 	assert.Equal(expectedHeight, state2.LastBlockHeight)
 }
 
+func TestNewKVStore(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	conf := config.DefaultConfig
+	conf.RootDir = t.TempDir()
+	kv, err := NewKVStore(conf, "test")
+	require.NoError(err)
+	require.IsType(&badger4.Datastore{}, kv)
+	require.NoError(kv.Close())
+
+	// the memory backend does not touch the disk and forgets its data when closed
+	conf.RootDir = filepath.Join(t.TempDir(), "missing")
+	conf.DBBackend = config.DBBackendMemory
+	kv, err = NewKVStore(conf, "test")
+	require.NoError(err)
+	s := New(kv)
+	require.NoError(s.SetMetadata(t.Context(), "key", []byte("value")))
+	value, err := s.GetMetadata(t.Context(), "key")
+	require.NoError(err)
+	require.Equal([]byte("value"), value)
+	require.NoError(s.Close())
+	require.NoDirExists(conf.RootDir)
+
+	kv, err = NewKVStore(conf, "test")
+	require.NoError(err)
+	_, err = New(kv).GetMetadata(t.Context(), "key")
+	require.ErrorIs(err, ds.ErrNotFound)
+
+	conf.DBBackend = "leveldb"
+	_, err = NewKVStore(conf, "test")
+	require.ErrorContains(err, "unknown database backend")
+}
+
 func TestMetadata(t *testing.T) {
 	t.Parallel()
 	require := require.New(t)
@@ -185,3 +222,90 @@ func TestMetadata(t *testing.T) {
 	require.Error(err)
 	require.Nil(v)
 }
+
+func TestDAMetadata(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	s := New(kv)
+
+	withData := &types.DAMetadata{
+		Height: 1,
+		Header: types.DABlobMetadata{DAHeight: 10, Namespace: "0a0b", ID: []byte{1}, Commitment: []byte{2}},
+		Data:   &types.DABlobMetadata{DAHeight: 11, Namespace: "0c0d", ID: []byte{3}, Commitment: []byte{4}},
+	}
+	empty := &types.DAMetadata{
+		Height: 2,
+		Header: types.DABlobMetadata{DAHeight: 12, Namespace: "0a0b", ID: []byte{5}},
+	}
+	require.NoError(s.SetDAMetadata(t.Context(), withData))
+	require.NoError(s.SetDAMetadata(t.Context(), empty))
+
+	metadata, err := s.GetDAMetadata(t.Context(), 1)
+	require.NoError(err)
+	require.Equal(withData, metadata)
+	metadata, err = s.GetDAMetadata(t.Context(), 2)
+	require.NoError(err)
+	require.Equal(empty, metadata)
+
+	_, err = s.GetDAMetadata(t.Context(), 3)
+	require.ErrorIs(err, ds.ErrNotFound)
+}
+
+func TestRollback(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	kv, err := NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	bstore := New(kv)
+	headers := make([]*types.SignedHeader, 5)
+	for i := range headers {
+		var data *types.Data
+		headers[i], data = types.GetRandomBlock(uint64(i+1), 2, "TestRollback")
+		require.NoError(bstore.SaveBlockData(t.Context(), headers[i], data, &headers[i].Signature))
+		require.NoError(bstore.SetHeight(t.Context(), headers[i].Height()))
+		require.NoError(bstore.SetDAMetadata(t.Context(), &types.DAMetadata{Height: headers[i].Height()}))
+	}
+	require.NoError(bstore.SetMetadata(t.Context(), "removed", []byte("value")))
+
+	state := types.State{ChainID: "TestRollback", InitialHeight: 1, LastBlockHeight: 3}
+	require.NoError(bstore.Rollback(t.Context(), 3, state, map[string][]byte{"added": []byte("value"), "removed": nil}))
+	height, err := bstore.Height(t.Context())
+	require.NoError(err)
+	assert.Equal(uint64(3), height)
+	storedState, err := bstore.GetState(t.Context())
+	require.NoError(err)
+	assert.Equal(state.LastBlockHeight, storedState.LastBlockHeight)
+	value, err := bstore.GetMetadata(t.Context(), "added")
+	require.NoError(err)
+	assert.Equal([]byte("value"), value)
+	_, err = bstore.GetMetadata(t.Context(), "removed")
+	assert.ErrorIs(err, ds.ErrNotFound)
+
+	_, _, err = bstore.GetBlockData(t.Context(), 3)
+	assert.NoError(err)
+	_, _, err = bstore.GetBlockByHash(t.Context(), headers[2].Hash())
+	assert.NoError(err)
+	_, err = bstore.GetDAMetadata(t.Context(), 3)
+	assert.NoError(err)
+	for _, header := range headers[3:] {
+		_, _, err = bstore.GetBlockData(t.Context(), header.Height())
+		assert.ErrorIs(err, ds.ErrNotFound)
+		_, err = bstore.GetSignature(t.Context(), header.Height())
+		assert.ErrorIs(err, ds.ErrNotFound)
+		_, _, err = bstore.GetBlockByHash(t.Context(), header.Hash())
+		assert.ErrorIs(err, ds.ErrNotFound)
+		_, err = bstore.GetDAMetadata(t.Context(), header.Height())
+		assert.ErrorIs(err, ds.ErrNotFound)
+	}
+
+	// rolling back to a height at or above the store height is a no-op
+	require.NoError(bstore.Rollback(t.Context(), 4, state, nil))
+	height, err = bstore.Height(t.Context())
+	require.NoError(err)
+	assert.Equal(uint64(3), height)
+}
@@ -22,6 +22,13 @@ type Store interface {
 	// GetBlockByHash returns block with given block header hash, or error if it's not found in Store.
 	GetBlockByHash(ctx context.Context, hash []byte) (*types.SignedHeader, *types.Data, error)
 
+	// DeleteBlockData deletes block header and data at given height, keeping the signature and hash index.
+	DeleteBlockData(ctx context.Context, height uint64) error
+
+	// Rollback deletes the blocks above the given height, lowers the height of the Store to it, and saves the
+	// state and metadata, deleting the metadata keys with nil values, all at once.
+	Rollback(ctx context.Context, height uint64, state types.State, metadata map[string][]byte) error
+
 	// GetSignature returns signature for a block at given height, or error if it's not found in Store.
 	GetSignature(ctx context.Context, height uint64) (*types.Signature, error)
 	// GetSignatureByHash returns signature for a block with given block header hash, or error if it's not found in Store.
@@ -33,6 +40,11 @@ type Store interface {
 	// GetState returns last state saved with UpdateState.
 	GetState(ctx context.Context) (types.State, error)
 
+	// SetDAMetadata saves the DA blobs including the block at the height of the metadata.
+	SetDAMetadata(ctx context.Context, metadata *types.DAMetadata) error
+	// GetDAMetadata returns the DA blobs including the block at given height, or error if they are not found in Store.
+	GetDAMetadata(ctx context.Context, height uint64) (*types.DAMetadata, error)
+
 	// SetMetadata saves arbitrary value in the store.
 	//
 	// This method enables rollkit to safely persist any information.
@@ -0,0 +1,250 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/celestiaorg/go-header"
+	goheaderstore "github.com/celestiaorg/go-header/store"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/pkg/retry"
+	"github.com/rollkit/rollkit/types"
+)
+
+// ErrBelowInitialHeight is returned when requesting a header or block below the initial height of the chain.
+var ErrBelowInitialHeight = errors.New("height is below the initial height")
+
+// checkpointPrefix returns the datastore prefix under which the checkpoint of the store is persisted, along with
+// the headers or blocks below it fetched from peers.
+func (syncService *SyncService[H]) checkpointPrefix() ds.Key {
+	return ds.NewKey(string(syncService.syncType) + "-checkpoint")
+}
+
+// loadCheckpoint loads the height at which the store was initialized, and the lowest height fetched below it.
+// Stores initialized before checkpoints were recorded start at the initial height.
+func (syncService *SyncService[H]) loadCheckpoint(ctx context.Context) error {
+	syncService.backfillMu.Lock()
+	defer syncService.backfillMu.Unlock()
+	prefix := syncService.checkpointPrefix()
+	tail, err := syncService.getHeight(ctx, prefix.ChildString("tail"))
+	if err != nil {
+		return err
+	}
+	syncService.tail = max(tail, syncService.genesis.InitialHeight)
+	lowest, err := syncService.getHeight(ctx, prefix.ChildString("lowest"))
+	if err != nil {
+		return err
+	}
+	syncService.lowest = syncService.tail
+	if lowest > 0 {
+		syncService.lowest = min(lowest, syncService.tail)
+	}
+	return nil
+}
+
+// setCheckpoint records the height of the header or block the store is initialized with.
+func (syncService *SyncService[H]) setCheckpoint(ctx context.Context, height uint64) error {
+	syncService.backfillMu.Lock()
+	defer syncService.backfillMu.Unlock()
+	if err := syncService.ds.Put(ctx, syncService.checkpointPrefix().ChildString("tail"), binary.LittleEndian.AppendUint64(nil, height)); err != nil {
+		return fmt.Errorf("failed to save the %s checkpoint: %w", syncService.syncType, err)
+	}
+	syncService.tail, syncService.lowest = height, height
+	return nil
+}
+
+// fetchTrusted fetches the trusted header or block from peers, from which the store is initialized: the header
+// with the trusted hash, at the trusted height if set, or the block at the height of the trusted header, which
+// is verified against the header. Errors which fetching again cannot fix are not retried by setFirstAndStart.
+func (syncService *SyncService[H]) fetchTrusted(ctx context.Context) (H, error) {
+	var zero H
+	trustedHash, err := hex.DecodeString(syncService.conf.Node.TrustedHash)
+	if err != nil {
+		return zero, retry.Permanent(fmt.Errorf("failed to parse the trusted hash for initializing the store: %w", err))
+	}
+
+	if syncService.syncType == dataSync && syncService.headerService != nil {
+		trustedHeader, err := syncService.headerService.trustedHeader(ctx)
+		if err != nil {
+			return zero, err
+		}
+		trusted, err := syncService.getter.GetByHeight(ctx, trustedHeader.Height())
+		if err != nil {
+			return zero, fmt.Errorf("failed to fetch the trusted block for initializing the store: %w", err)
+		}
+		if data, ok := any(trusted).(*types.Data); ok {
+			if err := types.Validate(trustedHeader, data); err != nil {
+				return zero, retry.Permanent(fmt.Errorf("trusted block does not match the trusted header: %w", err))
+			}
+		}
+		return trusted, nil
+	}
+
+	var trusted H
+	if height := syncService.conf.Node.TrustedHeight; height > 0 {
+		trusted, err = syncService.getter.GetByHeight(ctx, height)
+	} else {
+		trusted, err = syncService.getter.Get(ctx, trustedHash)
+	}
+	if err != nil {
+		return zero, fmt.Errorf("failed to fetch the trusted header/block for initializing the store: %w", err)
+	}
+	if !bytes.Equal(trusted.Hash(), trustedHash) {
+		return zero, retry.Permanent(fmt.Errorf("hash %s of the header/block at height %d does not match the trusted hash %X",
+			trusted.Hash(), trusted.Height(), trustedHash))
+	}
+	return trusted, nil
+}
+
+// trustedHeader returns the header the store of the header sync service was initialized with.
+func (syncService *SyncService[H]) trustedHeader(ctx context.Context) (*types.SignedHeader, error) {
+	syncService.backfillMu.Lock()
+	tail := syncService.tail
+	syncService.backfillMu.Unlock()
+	if !syncService.isInitialized() {
+		return nil, errors.New("the header store is not initialized with the trusted header")
+	}
+	h, err := syncService.store.GetByHeight(ctx, tail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the trusted header: %w", err)
+	}
+	trusted, ok := any(h).(*types.SignedHeader)
+	if !ok {
+		return nil, fmt.Errorf("unexpected header type %T", h)
+	}
+	return trusted, nil
+}
+
+// GetByHeight returns the header or block at the given height. Headers and blocks below the checkpoint the
+// store was initialized with are fetched from peers on the first query, and verified backwards from the
+// checkpoint: each header or block must have the hash its successor links to.
+func (syncService *SyncService[H]) GetByHeight(ctx context.Context, height uint64) (H, error) {
+	var zero H
+	if height < syncService.genesis.InitialHeight {
+		return zero, fmt.Errorf("%w: %d < %d", ErrBelowInitialHeight, height, syncService.genesis.InitialHeight)
+	}
+	syncService.backfillMu.Lock()
+	if height >= syncService.tail {
+		syncService.backfillMu.Unlock()
+		return syncService.store.GetByHeight(ctx, height)
+	}
+	defer syncService.backfillMu.Unlock()
+	if height >= syncService.lowest {
+		return syncService.getBackfilled(ctx, height)
+	}
+
+	next, err := syncService.lowestVerified(ctx)
+	if err != nil {
+		return zero, err
+	}
+	for h := syncService.lowest - 1; h >= height; h-- {
+		hash := next.LastHeader()
+		prev, err := syncService.getter.Get(ctx, hash)
+		if err != nil {
+			return zero, fmt.Errorf("failed to fetch the %s at height %d: %w", syncService.syncType, h, err)
+		}
+		if !bytes.Equal(prev.Hash(), hash) || prev.Height() != h {
+			return zero, fmt.Errorf("%s at height %d does not match the hash %s linked to by height %d",
+				syncService.syncType, h, hash, h+1)
+		}
+		if err := syncService.putBackfilled(ctx, prev); err != nil {
+			return zero, err
+		}
+		next = prev
+	}
+	return next, nil
+}
+
+// Height returns the height of the latest header or block of the store.
+func (syncService *SyncService[H]) Height() uint64 {
+	return syncService.store.Height()
+}
+
+// lowestVerified returns the header or block at the lowest verified height, from which the lower ones are
+// verified.
+func (syncService *SyncService[H]) lowestVerified(ctx context.Context) (H, error) {
+	if syncService.lowest == syncService.tail {
+		return syncService.store.GetByHeight(ctx, syncService.tail)
+	}
+	return syncService.getBackfilled(ctx, syncService.lowest)
+}
+
+// getBackfilled returns a header or block below the checkpoint, fetched from peers.
+func (syncService *SyncService[H]) getBackfilled(ctx context.Context, height uint64) (H, error) {
+	var zero H
+	bz, err := syncService.ds.Get(ctx, syncService.backfillKey(height))
+	if err != nil {
+		return zero, fmt.Errorf("failed to load the %s at height %d: %w", syncService.syncType, height, err)
+	}
+	h := header.New[H]()
+	if err := h.UnmarshalBinary(bz); err != nil {
+		return zero, fmt.Errorf("failed to decode the %s at height %d: %w", syncService.syncType, height, err)
+	}
+	return h, nil
+}
+
+// putBackfilled saves a verified header or block below the checkpoint, and lowers the lowest verified height.
+func (syncService *SyncService[H]) putBackfilled(ctx context.Context, h H) error {
+	bz, err := h.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	batch, err := syncService.ds.Batch(ctx)
+	if err != nil {
+		return err
+	}
+	if err := batch.Put(ctx, syncService.backfillKey(h.Height()), bz); err != nil {
+		return err
+	}
+	lowest := binary.LittleEndian.AppendUint64(nil, h.Height())
+	if err := batch.Put(ctx, syncService.checkpointPrefix().ChildString("lowest"), lowest); err != nil {
+		return err
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to save the %s at height %d: %w", syncService.syncType, h.Height(), err)
+	}
+	syncService.lowest = h.Height()
+	return nil
+}
+
+func (syncService *SyncService[H]) backfillKey(height uint64) ds.Key {
+	return syncService.checkpointPrefix().ChildString(strconv.FormatUint(height, 10))
+}
+
+// getHeight returns the height stored under the key, 0 if not found.
+func (syncService *SyncService[H]) getHeight(ctx context.Context, key ds.Key) (uint64, error) {
+	bz, err := syncService.ds.Get(ctx, key)
+	if errors.Is(err, ds.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if len(bz) != 8 {
+		return 0, fmt.Errorf("invalid height under %s", key)
+	}
+	return binary.LittleEndian.Uint64(bz), nil
+}
+
+// lazyStore is the store of a SyncService, serving the headers or blocks below its checkpoint through
+// SyncService.GetByHeight.
+type lazyStore[H header.Header[H]] struct {
+	*goheaderstore.Store[H]
+	syncService *SyncService[H]
+}
+
+func (s lazyStore[H]) GetByHeight(ctx context.Context, height uint64) (H, error) {
+	return s.syncService.GetByHeight(ctx, height)
+}
+
+// LazyStore returns the store of the SyncService, fetching the headers or blocks below the checkpoint it was
+// initialized with from peers when they are queried.
+func (syncService *SyncService[H]) LazyStore() header.Store[H] {
+	return lazyStore[H]{Store: syncService.store, syncService: syncService}
+}
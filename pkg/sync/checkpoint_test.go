@@ -0,0 +1,129 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/celestiaorg/go-header"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// testGetter is a header.Getter serving the headers of peers, counting the headers fetched by hash.
+type testGetter struct {
+	testHeaders
+	fetched int
+}
+
+func (g *testGetter) Head(context.Context, ...header.HeadOption[*types.SignedHeader]) (*types.SignedHeader, error) {
+	return g.testHeaders[g.Height()], nil
+}
+
+func (g *testGetter) Get(_ context.Context, hash header.Hash) (*types.SignedHeader, error) {
+	for _, h := range g.testHeaders {
+		if bytes.Equal(h.Hash(), hash) {
+			g.fetched++
+			return h, nil
+		}
+	}
+	return nil, header.ErrNotFound
+}
+
+func (g *testGetter) GetRangeByHeight(context.Context, *types.SignedHeader, uint64) ([]*types.SignedHeader, error) {
+	return nil, header.ErrNotFound
+}
+
+func newTestCheckpointService(t *testing.T, db ds.Batching, gen genesis.Genesis, getter header.Getter[*types.SignedHeader], trusted *types.SignedHeader) *HeaderSyncService {
+	t.Helper()
+	conf := config.DefaultConfig
+	conf.Node.TrustedHash = hex.EncodeToString(trusted.Hash())
+	conf.Node.TrustedHeight = trusted.Height()
+	syncService := &HeaderSyncService{
+		conf:     conf,
+		syncType: headerSync,
+		genesis:  gen,
+		store:    newTestHeaderStore(t, db),
+		ds:       db,
+		getter:   getter,
+	}
+	require.NoError(t, syncService.loadCheckpoint(context.Background()))
+	return syncService
+}
+
+func TestCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	headers, gen := makeHeaderChain(t, 6)
+	getter := &testGetter{testHeaders: headers}
+
+	syncService := newTestCheckpointService(t, db, gen, getter, headers[4])
+	trusted, err := syncService.fetchTrusted(ctx)
+	require.NoError(t, err)
+	require.Equal(t, headers[4].Hash(), trusted.Hash())
+	require.NoError(t, syncService.store.Init(ctx, trusted))
+	require.NoError(t, syncService.setCheckpoint(ctx, trusted.Height()))
+	require.NoError(t, syncService.store.Append(ctx, headers[5]))
+
+	// headers above the checkpoint are served by the store
+	h, err := syncService.GetByHeight(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, headers[5].Hash(), h.Hash())
+	assert.Equal(t, 0, getter.fetched)
+
+	// headers below the checkpoint are fetched and verified backwards on the first query only
+	h, err = syncService.GetByHeight(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, headers[2].Hash(), h.Hash())
+	assert.Equal(t, 2, getter.fetched)
+	h, err = syncService.GetByHeight(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, headers[3].Hash(), h.Hash())
+	assert.Equal(t, 2, getter.fetched)
+
+	_, err = syncService.GetByHeight(ctx, 0)
+	assert.ErrorIs(t, err, ErrBelowInitialHeight)
+	require.NoError(t, syncService.store.Stop(ctx))
+
+	// the checkpoint and the verified headers are persisted across restarts
+	syncService = newTestCheckpointService(t, db, gen, getter, headers[4])
+	assert.Equal(t, uint64(4), syncService.tail)
+	assert.Equal(t, uint64(2), syncService.lowest)
+	h, err = syncService.GetByHeight(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, headers[1].Hash(), h.Hash())
+	assert.Equal(t, 3, getter.fetched)
+	require.NoError(t, syncService.store.Stop(ctx))
+}
+
+func TestCheckpoint_Rejects(t *testing.T) {
+	ctx := context.Background()
+	headers, gen := makeHeaderChain(t, 4)
+	forked, _ := makeHeaderChain(t, 4)
+
+	// the header at the trusted height must have the trusted hash
+	db, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	syncService := newTestCheckpointService(t, db, gen, &testGetter{testHeaders: forked}, headers[3])
+	_, err = syncService.fetchTrusted(ctx)
+	assert.ErrorContains(t, err, "does not match the trusted hash")
+	require.NoError(t, syncService.store.Stop(ctx))
+
+	// headers below the checkpoint must have the hash their successor links to
+	peerHeaders := testHeaders{1: headers[1], 2: forked[2], 3: headers[3]}
+	syncService = newTestCheckpointService(t, db, gen, &testGetter{testHeaders: peerHeaders}, headers[3])
+	require.NoError(t, syncService.store.Init(ctx, headers[3]))
+	require.NoError(t, syncService.setCheckpoint(ctx, 3))
+	_, err = syncService.GetByHeight(ctx, 1)
+	assert.Error(t, err)
+	assert.Equal(t, uint64(3), syncService.lowest)
+	require.NoError(t, syncService.store.Stop(ctx))
+}
@@ -0,0 +1,306 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rollkit/rollkit/block"
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+const (
+	// DAVerifierStateKey is the key used for persisting the DA verifier state in store.
+	DAVerifierStateKey = "da-verifier"
+
+	// maxDAHeightsPerRound bounds the number of DA heights scanned before verified heights are advanced.
+	maxDAHeightsPerRound = 100
+)
+
+// HeaderGetter provides the headers received over p2p.
+type HeaderGetter interface {
+	Height() uint64
+	GetByHeight(ctx context.Context, height uint64) (*types.SignedHeader, error)
+}
+
+// DAVerificationStatus is the progress of a DAVerifier.
+type DAVerificationStatus struct {
+	// VerifiedHeight is the height up to which headers received over p2p were found on the DA layer.
+	VerifiedHeight uint64 `json:"verified_height"`
+	// DAHeight is the next DA height to scan.
+	DAHeight uint64 `json:"da_height"`
+	// Proposer is the proposer whose headers are verified, set once the key of the initial proposer is rotated.
+	Proposer []byte `json:"proposer,omitempty"`
+}
+
+// DAVerifier verifies that the headers received over p2p were published to the DA layer by the
+// proposer, instead of trusting header gossip alone. It scans the DA namespace for headers signed by
+// the proposer and advances the verified height while they match the headers received over p2p.
+// Headers carrying a key rotation signed by the proposer are accepted, and the verifier follows the new key.
+// With a DASampler, headers are only verified once the DA blocks holding them passed data availability sampling.
+type DAVerifier struct {
+	da       coreda.DA
+	headers  HeaderGetter
+	store    store.Store
+	proposer []byte
+	interval time.Duration
+	logger   log.Logger
+	// sampler samples the DA blocks holding the headers, nil if disabled
+	sampler *DASampler
+
+	mu     sync.RWMutex
+	status DAVerificationStatus
+	// daHeaders are the headers found on the DA layer above the verified height
+	daHeaders map[uint64]daHeader
+}
+
+// daHeader is a header found on the DA layer and not verified yet.
+type daHeader struct {
+	hash     types.Hash
+	daHeight uint64
+}
+
+// NewDAVerifier creates a DAVerifier checking the headers of the proposer. Its state is persisted in
+// the store; the first time it runs, the DA layer is scanned from startHeight.
+func NewDAVerifier(
+	ctx context.Context,
+	da coreda.DA,
+	headers HeaderGetter,
+	store store.Store,
+	proposer []byte,
+	startHeight uint64,
+	interval time.Duration,
+	logger log.Logger,
+) (*DAVerifier, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid DA verification interval: %s", interval)
+	}
+	status := DAVerificationStatus{DAHeight: startHeight}
+	raw, err := store.GetMetadata(ctx, DAVerifierStateKey)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, fmt.Errorf("failed to load DA verifier state: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(raw, &status); err != nil {
+			return nil, fmt.Errorf("failed to decode DA verifier state: %w", err)
+		}
+	}
+	if len(status.Proposer) > 0 {
+		proposer = status.Proposer
+	}
+	return &DAVerifier{
+		da:        da,
+		headers:   headers,
+		store:     store,
+		proposer:  proposer,
+		interval:  interval,
+		logger:    logger,
+		status:    status,
+		daHeaders: make(map[uint64]daHeader),
+	}, nil
+}
+
+// SetSampler makes the verifier only verify the headers whose DA blocks passed data availability sampling.
+func (v *DAVerifier) SetSampler(sampler *DASampler) {
+	v.sampler = sampler
+}
+
+// Status returns the verification progress.
+func (v *DAVerifier) Status() DAVerificationStatus {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.status
+}
+
+// Run verifies headers every interval until the context is canceled.
+func (v *DAVerifier) Run(ctx context.Context) error {
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+	for {
+		caughtUp, err := v.verify(ctx)
+		if err != nil && ctx.Err() == nil {
+			v.logger.Error("failed to verify headers against DA", "daHeight", v.Status().DAHeight, "error", err)
+		}
+		if caughtUp || err != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+		} else if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+}
+
+// verify scans the next DA heights and advances the verified height. It reports whether the DA head was reached.
+func (v *DAVerifier) verify(ctx context.Context) (bool, error) {
+	caughtUp := false
+	for range maxDAHeightsPerRound {
+		daHeight := v.Status().DAHeight
+		headers, err := v.fetchHeaders(ctx, daHeight)
+		if err != nil {
+			if errors.Is(err, coreda.ErrFutureHeight) || strings.Contains(err.Error(), block.ErrHeightFromFutureStr.Error()) {
+				caughtUp = true
+				break
+			}
+			return false, err
+		}
+		v.mu.Lock()
+		for _, header := range headers {
+			if header.Height() <= v.status.VerifiedHeight {
+				continue
+			}
+			if _, ok := v.daHeaders[header.Height()]; !ok {
+				v.daHeaders[header.Height()] = daHeader{hash: header.Hash(), daHeight: daHeight}
+				if v.sampler != nil {
+					v.sampler.Schedule(header.Height(), daHeight)
+				}
+			}
+		}
+		v.status.DAHeight = daHeight + 1
+		v.mu.Unlock()
+	}
+
+	if err := v.advance(ctx); err != nil {
+		return caughtUp, err
+	}
+	return caughtUp, v.save(ctx)
+}
+
+// advance advances the verified height while the headers received over p2p match the headers found on the DA layer.
+func (v *DAVerifier) advance(ctx context.Context) error {
+	for {
+		v.mu.RLock()
+		height := v.status.VerifiedHeight + 1
+		pending, ok := v.daHeaders[height]
+		v.mu.RUnlock()
+		// GetByHeight waits for headers not received over p2p yet
+		if !ok || height > v.headers.Height() {
+			return nil
+		}
+		header, err := v.headers.GetByHeight(ctx, height)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(header.Hash(), pending.hash) {
+			return fmt.Errorf("header %d received over p2p (%s) does not match the header published on DA (%s)", height, header.Hash(), pending.hash)
+		}
+		if v.sampler != nil {
+			state, ok, err := v.sampler.State(ctx, height)
+			if err != nil {
+				return err
+			}
+			// headers are verified once the sampling of their DA block completes
+			if !ok {
+				return nil
+			}
+			if !state.Available {
+				return fmt.Errorf("header %d failed data availability sampling of DA height %d", height, state.DAHeight)
+			}
+		}
+		v.mu.Lock()
+		v.status.VerifiedHeight = height
+		delete(v.daHeaders, height)
+		v.mu.Unlock()
+	}
+}
+
+// fetchHeaders returns the headers signed by the proposer at the given DA height.
+func (v *DAVerifier) fetchHeaders(ctx context.Context, daHeight uint64) ([]*types.SignedHeader, error) {
+	res, err := v.da.GetIDs(ctx, daHeight, nil)
+	if errors.Is(err, coreda.ErrBlobNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if res == nil || len(res.IDs) == 0 {
+		return nil, nil
+	}
+	blobs, err := v.da.Get(ctx, res.IDs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var headers []*types.SignedHeader
+	for _, blob := range blobs {
+		env, ok, err := types.OpenBlob(blob)
+		bz := env.Payload
+		if !ok {
+			bz, err = types.DecompressBlob(blob)
+		}
+		// blobs which cannot be decoded, including envelopes of unsupported versions, are skipped
+		if err != nil {
+			continue
+		}
+		bundled, err := types.UnbundleBlob(bz)
+		if err != nil {
+			continue
+		}
+		for _, bz := range bundled {
+			var headerPb pb.SignedHeader
+			if err := proto.Unmarshal(bz, &headerPb); err != nil {
+				continue
+			}
+			header := new(types.SignedHeader)
+			if err := header.FromProto(&headerPb); err != nil {
+				continue
+			}
+			if header.Validate() != nil || !v.followProposer(header) {
+				continue
+			}
+			headers = append(headers, header)
+		}
+	}
+	return headers, nil
+}
+
+// followProposer reports whether the header is signed by the proposer. Headers signed by a new key are accepted
+// if they carry a key rotation signed by the proposer, which is then replaced by the new key.
+func (v *DAVerifier) followProposer(header *types.SignedHeader) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if bytes.Equal(header.ProposerAddress, v.proposer) {
+		return true
+	}
+	if header.Rotation == nil || !bytes.Equal(header.Rotation.Signer.Address, v.proposer) {
+		return false
+	}
+	v.logger.Info("following rotated sequencer key", "height", header.Rotation.Height,
+		"proposer", fmt.Sprintf("%X", header.ProposerAddress))
+	v.proposer = header.ProposerAddress
+	v.status.Proposer = header.ProposerAddress
+	return true
+}
+
+// save persists the verification progress. Scanning resumes from the DA height of the oldest header not
+// verified yet, so that headers found on the DA layer are not lost on restart.
+func (v *DAVerifier) save(ctx context.Context) error {
+	v.mu.RLock()
+	state := v.status
+	for _, pending := range v.daHeaders {
+		state.DAHeight = min(state.DAHeight, pending.daHeight)
+	}
+	v.mu.RUnlock()
+	bz, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := v.store.SetMetadata(ctx, DAVerifierStateKey, bz); err != nil {
+		return fmt.Errorf("failed to save DA verifier state: %w", err)
+	}
+	return nil
+}
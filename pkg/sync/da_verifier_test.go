@@ -0,0 +1,169 @@
+package sync
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/test/mocks"
+	"github.com/rollkit/rollkit/types"
+)
+
+// testHeaders is a HeaderGetter serving the headers received over p2p.
+type testHeaders map[uint64]*types.SignedHeader
+
+func (h testHeaders) Height() uint64 {
+	return uint64(len(h))
+}
+
+func (h testHeaders) GetByHeight(_ context.Context, height uint64) (*types.SignedHeader, error) {
+	return h[height], nil
+}
+
+func makeSignedHeaders(t *testing.T, n uint64) ([]*types.SignedHeader, []byte) {
+	t.Helper()
+	pk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	signer, err := noop.NewNoopSigner(pk)
+	require.NoError(t, err)
+
+	var headers []*types.SignedHeader
+	for height := uint64(1); height <= n; height++ {
+		header, err := types.GetRandomSignedHeaderCustom(&types.HeaderConfig{
+			Height:   height,
+			DataHash: types.GetRandomBytes(32),
+			AppHash:  types.GetRandomBytes(32),
+			Signer:   signer,
+		}, "test-chain")
+		require.NoError(t, err)
+		headers = append(headers, header)
+	}
+	return headers, headers[0].ProposerAddress
+}
+
+// mockDAHeaders serves one header per DA height, starting at DA height 1.
+func mockDAHeaders(t *testing.T, daClient *mocks.DA, headers []*types.SignedHeader) {
+	t.Helper()
+	for i, header := range headers {
+		blob, err := header.MarshalBinary()
+		require.NoError(t, err)
+		id := []byte{byte(i)}
+		daClient.On("GetIDs", mock.Anything, uint64(i+1), mock.Anything).
+			Return(&coreda.GetIDsResult{IDs: []coreda.ID{id}}, nil).Maybe()
+		daClient.On("Get", mock.Anything, []coreda.ID{id}, mock.Anything).
+			Return([]coreda.Blob{blob}, nil).Maybe()
+	}
+	daClient.On("GetIDs", mock.Anything, uint64(len(headers)+1), mock.Anything).
+		Return(nil, coreda.ErrFutureHeight).Maybe()
+}
+
+func TestDAVerifier(t *testing.T) {
+	ctx := context.Background()
+	headers, proposer := makeSignedHeaders(t, 3)
+	daClient := mocks.NewDA(t)
+	mockDAHeaders(t, daClient, headers)
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+
+	// only the first two headers were received over p2p
+	p2pHeaders := testHeaders{1: headers[0], 2: headers[1]}
+	v, err := NewDAVerifier(ctx, daClient, p2pHeaders, s, proposer, 1, time.Second, log.NewNopLogger())
+	require.NoError(t, err)
+
+	caughtUp, err := v.verify(ctx)
+	require.NoError(t, err)
+	require.True(t, caughtUp)
+	require.Equal(t, DAVerificationStatus{VerifiedHeight: 2, DAHeight: 4}, v.Status())
+
+	// the state resumes from the DA height of the header not verified yet
+	restored, err := NewDAVerifier(ctx, daClient, p2pHeaders, s, proposer, 1, time.Second, log.NewNopLogger())
+	require.NoError(t, err)
+	require.Equal(t, DAVerificationStatus{VerifiedHeight: 2, DAHeight: 3}, restored.Status())
+
+	p2pHeaders[3] = headers[2]
+	_, err = restored.verify(ctx)
+	require.NoError(t, err)
+	require.Equal(t, DAVerificationStatus{VerifiedHeight: 3, DAHeight: 4}, restored.Status())
+}
+
+func TestDAVerifier_Mismatch(t *testing.T) {
+	ctx := context.Background()
+	headers, proposer := makeSignedHeaders(t, 2)
+	daClient := mocks.NewDA(t)
+	mockDAHeaders(t, daClient, headers)
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+
+	// the second header received over p2p was never published on DA
+	forged, _ := makeSignedHeaders(t, 2)
+	p2pHeaders := testHeaders{1: headers[0], 2: forged[1]}
+	v, err := NewDAVerifier(ctx, daClient, p2pHeaders, store.New(kv), proposer, 1, time.Second, log.NewNopLogger())
+	require.NoError(t, err)
+
+	_, err = v.verify(ctx)
+	require.ErrorContains(t, err, "does not match")
+	require.Equal(t, uint64(1), v.Status().VerifiedHeight)
+}
+
+func TestDAVerifier_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+	newSigner := func() signer.Signer {
+		pk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+		require.NoError(t, err)
+		s, err := noop.NewNoopSigner(pk)
+		require.NoError(t, err)
+		return s
+	}
+	signerA, signerB := newSigner(), newSigner()
+	keyB, err := signerB.GetPublic()
+	require.NoError(t, err)
+	rotation, err := types.GetKeyRotation(signerA, keyB, "test-chain", 3)
+	require.NoError(t, err)
+
+	// headers 3 and 4 are signed by the rotated key and carry the rotation
+	var headers []*types.SignedHeader
+	for height := uint64(1); height <= 4; height++ {
+		s := signerA
+		if height >= rotation.Height {
+			s = signerB
+		}
+		header, err := types.GetRandomSignedHeaderCustom(&types.HeaderConfig{Height: height, Signer: s}, "test-chain")
+		require.NoError(t, err)
+		if height >= rotation.Height {
+			header.Rotation = rotation
+		}
+		headers = append(headers, header)
+	}
+	daClient := mocks.NewDA(t)
+	mockDAHeaders(t, daClient, headers)
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+	p2pHeaders := testHeaders{1: headers[0], 2: headers[1], 3: headers[2], 4: headers[3]}
+	v, err := NewDAVerifier(ctx, daClient, p2pHeaders, s, headers[0].ProposerAddress, 1, time.Second, log.NewNopLogger())
+	require.NoError(t, err)
+
+	_, err = v.verify(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), v.Status().VerifiedHeight)
+	require.Equal(t, headers[3].ProposerAddress, v.Status().Proposer)
+
+	// the rotated key is followed after a restart
+	restored, err := NewDAVerifier(ctx, daClient, p2pHeaders, s, headers[0].ProposerAddress, 1, time.Second, log.NewNopLogger())
+	require.NoError(t, err)
+	require.Equal(t, headers[3].ProposerAddress, restored.proposer)
+}
@@ -0,0 +1,211 @@
+package sync
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/store"
+)
+
+const (
+	// DASamplingStateKeyPrefix is the prefix of the keys used for persisting the sampling state of headers in store.
+	DASamplingStateKeyPrefix = "das"
+
+	// maxConcurrentSamples bounds the number of DA blocks sampled concurrently.
+	maxConcurrentSamples = 4
+)
+
+// SamplingState is the data availability sampling state of a header.
+type SamplingState struct {
+	// DAHeight is the height of the DA block holding the header.
+	DAHeight uint64 `json:"da_height"`
+	// Samples is the number of shares of the DA block sampled.
+	Samples int `json:"samples"`
+	// Available reports whether all sampled shares were available.
+	Available bool `json:"available"`
+}
+
+// DASampler checks that the DA blocks holding headers were published by sampling random shares of them, so that
+// light nodes only accept headers whose data is available. Headers are scheduled for sampling once found on the
+// DA layer, and their sampling state is persisted in the store once sampling completes. Sampling is retried
+// until the shares are found to be available or unavailable.
+type DASampler struct {
+	sampler  coreda.Sampler
+	store    store.Store
+	samples  int
+	interval time.Duration
+	logger   log.Logger
+
+	mu sync.Mutex
+	// scheduled maps the heights of the headers waiting for sampling to the height of their DA block
+	scheduled map[uint64]uint64
+	notify    chan struct{}
+}
+
+// NewDASampler creates a DASampler sampling the given number of shares of every DA block, and retrying failed
+// sampling every interval.
+func NewDASampler(sampler coreda.Sampler, store store.Store, samples int, interval time.Duration, logger log.Logger) (*DASampler, error) {
+	if samples <= 0 {
+		return nil, fmt.Errorf("invalid number of data availability samples: %d", samples)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid data availability sampling interval: %s", interval)
+	}
+	return &DASampler{
+		sampler:   sampler,
+		store:     store,
+		samples:   samples,
+		interval:  interval,
+		logger:    logger,
+		scheduled: make(map[uint64]uint64),
+		notify:    make(chan struct{}, 1),
+	}, nil
+}
+
+// DASamplingStateKey returns the key of the sampling state of the header at the given height.
+func DASamplingStateKey(height uint64) string {
+	return DASamplingStateKeyPrefix + "/" + strconv.FormatUint(height, 10)
+}
+
+// Schedule schedules the sampling of the DA block at daHeight holding the header at the given height.
+func (s *DASampler) Schedule(height, daHeight uint64) {
+	s.mu.Lock()
+	s.scheduled[height] = daHeight
+	s.mu.Unlock()
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// State returns the sampling state of the header at the given height, and false if it was not sampled yet.
+func (s *DASampler) State(ctx context.Context, height uint64) (SamplingState, bool, error) {
+	var state SamplingState
+	raw, err := s.store.GetMetadata(ctx, DASamplingStateKey(height))
+	if errors.Is(err, ds.ErrNotFound) {
+		return state, false, nil
+	}
+	if err != nil {
+		return state, false, fmt.Errorf("failed to load sampling state of header %d: %w", height, err)
+	}
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return state, false, fmt.Errorf("failed to decode sampling state of header %d: %w", height, err)
+	}
+	return state, true, nil
+}
+
+// Run samples the scheduled headers until the context is canceled.
+func (s *DASampler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		s.sampleScheduled(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.notify:
+		case <-ticker.C:
+		}
+	}
+}
+
+// sampleScheduled samples the DA blocks of the scheduled headers, lowest DA heights first. Every DA block is
+// sampled once for all the headers it holds.
+func (s *DASampler) sampleScheduled(ctx context.Context) {
+	s.mu.Lock()
+	byDAHeight := make(map[uint64][]uint64)
+	for height, daHeight := range s.scheduled {
+		byDAHeight[daHeight] = append(byDAHeight[daHeight], height)
+	}
+	s.mu.Unlock()
+	daHeights := make([]uint64, 0, len(byDAHeight))
+	for daHeight := range byDAHeight {
+		daHeights = append(daHeights, daHeight)
+	}
+	slices.Sort(daHeights)
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentSamples)
+	for _, daHeight := range daHeights {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(daHeight uint64, heights []uint64) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := s.sample(ctx, daHeight, heights); err != nil && ctx.Err() == nil {
+				s.logger.Error("failed to sample DA block, retrying", "daHeight", daHeight, "error", err)
+			}
+		}(daHeight, byDAHeight[daHeight])
+	}
+	wg.Wait()
+}
+
+// sample samples random shares of the DA block at daHeight and records the sampling state of the headers it
+// holds. Sampling is retried if it fails with another error than coreda.ErrShareUnavailable.
+func (s *DASampler) sample(ctx context.Context, daHeight uint64, heights []uint64) error {
+	count, err := s.sampler.ShareCount(ctx, daHeight)
+	if err != nil {
+		return err
+	}
+	samples := uint64(s.samples) //nolint:gosec // positive
+	indices := make([]uint64, 0, min(count, samples))
+	if count <= samples {
+		// DA blocks with fewer shares than samples are sampled entirely
+		for index := range count {
+			indices = append(indices, index)
+		}
+	} else {
+		picked := make(map[uint64]struct{}, samples)
+		for uint64(len(indices)) < samples {
+			index := rand.Uint64N(count) //nolint:gosec // randomly seeded, the sampled shares cannot be predicted by the DA block producer
+			if _, ok := picked[index]; !ok {
+				picked[index] = struct{}{}
+				indices = append(indices, index)
+			}
+		}
+	}
+
+	state := SamplingState{DAHeight: daHeight, Samples: len(indices), Available: true}
+	err = s.sampler.SampleShares(ctx, daHeight, indices)
+	if errors.Is(err, coreda.ErrShareUnavailable) {
+		s.logger.Error("data availability sampling failed, headers are not accepted", "daHeight", daHeight, "heights", heights, "error", err)
+		state.Available = false
+	} else if err != nil {
+		return err
+	}
+
+	bz, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	for _, height := range heights {
+		if err := s.store.SetMetadata(ctx, DASamplingStateKey(height), bz); err != nil {
+			return fmt.Errorf("failed to save sampling state of header %d: %w", height, err)
+		}
+		s.mu.Lock()
+		if s.scheduled[height] == daHeight {
+			delete(s.scheduled, height)
+		}
+		s.mu.Unlock()
+	}
+	s.logger.Debug("sampled DA block", "daHeight", daHeight, "samples", len(indices), "available", state.Available)
+	return nil
+}
@@ -0,0 +1,132 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	gosync "sync"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/test/mocks"
+)
+
+// testSampler is a DA layer whose DA blocks have 100 shares, recording the sampled shares.
+type testSampler struct {
+	mu gosync.Mutex
+	// withheld are the DA heights whose shares are unavailable
+	withheld map[uint64]bool
+	// unreachable are the DA heights whose sampling fails with another error
+	unreachable map[uint64]bool
+	sampled     map[uint64][]uint64
+}
+
+func (s *testSampler) ShareCount(_ context.Context, height uint64) (uint64, error) {
+	if s.unreachable[height] {
+		return 0, errors.New("connection refused")
+	}
+	return 100, nil
+}
+
+func (s *testSampler) SampleShares(_ context.Context, height uint64, indices []uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sampled[height] = indices
+	if s.withheld[height] {
+		return fmt.Errorf("%w: share %d", coreda.ErrShareUnavailable, indices[0])
+	}
+	return nil
+}
+
+func newTestSampler() *testSampler {
+	return &testSampler{withheld: make(map[uint64]bool), unreachable: make(map[uint64]bool), sampled: make(map[uint64][]uint64)}
+}
+
+func TestDASampler(t *testing.T) {
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	sampler := newTestSampler()
+	sampler.withheld[2] = true
+	sampler.unreachable[3] = true
+	s, err := NewDASampler(sampler, store.New(kv), 8, time.Second, log.NewNopLogger())
+	require.NoError(t, err)
+
+	// headers 1 and 2 share a DA block
+	s.Schedule(1, 1)
+	s.Schedule(2, 1)
+	s.Schedule(3, 2)
+	s.Schedule(4, 3)
+	s.sampleScheduled(ctx)
+
+	// distinct random shares are sampled once per DA block
+	require.Len(t, sampler.sampled[1], 8)
+	seen := make(map[uint64]bool)
+	for _, index := range sampler.sampled[1] {
+		assert.Less(t, index, uint64(100))
+		assert.False(t, seen[index])
+		seen[index] = true
+	}
+	for height, want := range map[uint64]SamplingState{
+		1: {DAHeight: 1, Samples: 8, Available: true},
+		2: {DAHeight: 1, Samples: 8, Available: true},
+		3: {DAHeight: 2, Samples: 8, Available: false},
+	} {
+		state, ok, err := s.State(ctx, height)
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, want, state)
+	}
+
+	// sampling failing with another error is retried
+	_, ok, err := s.State(ctx, 4)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, map[uint64]uint64{4: 3}, s.scheduled)
+	sampler.unreachable[3] = false
+	s.sampleScheduled(ctx)
+	state, ok, err := s.State(ctx, 4)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, state.Available)
+	assert.Empty(t, s.scheduled)
+
+	_, err = NewDASampler(sampler, store.New(kv), 0, time.Second, log.NewNopLogger())
+	assert.Error(t, err)
+}
+
+func TestDAVerifier_Sampling(t *testing.T) {
+	ctx := context.Background()
+	headers, proposer := makeSignedHeaders(t, 3)
+	daClient := mocks.NewDA(t)
+	mockDAHeaders(t, daClient, headers)
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+
+	// the data of the DA block holding the third header was withheld
+	sampler := newTestSampler()
+	sampler.withheld[3] = true
+	das, err := NewDASampler(sampler, s, 4, time.Second, log.NewNopLogger())
+	require.NoError(t, err)
+	p2pHeaders := testHeaders{1: headers[0], 2: headers[1], 3: headers[2]}
+	v, err := NewDAVerifier(ctx, daClient, p2pHeaders, s, proposer, 1, time.Second, log.NewNopLogger())
+	require.NoError(t, err)
+	v.SetSampler(das)
+
+	// headers are not verified before their DA block was sampled
+	_, err = v.verify(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), v.Status().VerifiedHeight)
+
+	das.sampleScheduled(ctx)
+	_, err = v.verify(ctx)
+	require.ErrorContains(t, err, "failed data availability sampling")
+	require.Equal(t, uint64(2), v.Status().VerifiedHeight)
+}
@@ -0,0 +1,181 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/types"
+)
+
+const (
+	// MaxHeaderRange is the maximum number of headers returned by a header range query.
+	MaxHeaderRange = 1000
+
+	// headerRangeCacheSize bounds the number of verified headers whose hash is cached.
+	headerRangeCacheSize = 10 * MaxHeaderRange
+)
+
+var (
+	// ErrInvalidHeaderRange is returned when a header range is empty, starts before the initial height or
+	// exceeds MaxHeaderRange.
+	ErrInvalidHeaderRange = errors.New("invalid header range")
+
+	// ErrHeaderRangeUnavailable is returned when a header range ends above the headers received over p2p.
+	ErrHeaderRangeUnavailable = errors.New("header range not available")
+
+	// ErrHeaderRangeVerification is returned when a header of a range fails verification.
+	ErrHeaderRangeVerification = errors.New("header range verification failed")
+)
+
+// HeaderRangeVerifier serves ranges of the headers received over p2p, so that a light node can be used as a
+// header oracle by downstream verifiers such as bridges and wallets. The signatures of a range are verified in
+// parallel, and the range must be continuous: consecutive heights of the chain, each header linking to the hash
+// of the previous one and signed by the same proposer, unless it carries a key rotation signed by the previous
+// proposer. A range starting at the initial height must be signed by the genesis proposer; other ranges are
+// anchored to the header preceding them, which is verified as well. The hashes of the headers whose signature
+// was verified are cached, so that repeated range queries only check continuity.
+type HeaderRangeVerifier struct {
+	headers HeaderGetter
+	genesis genesis.Genesis
+
+	mu sync.Mutex
+	// verified are the hashes of the headers whose signature was verified, by height
+	verified map[uint64]types.Hash
+	// order are the heights of verified in insertion order, the oldest are evicted first
+	order []uint64
+}
+
+// NewHeaderRangeVerifier creates a HeaderRangeVerifier serving the given headers of the chain.
+func NewHeaderRangeVerifier(headers HeaderGetter, genesis genesis.Genesis) *HeaderRangeVerifier {
+	return &HeaderRangeVerifier{
+		headers:  headers,
+		genesis:  genesis,
+		verified: make(map[uint64]types.Hash),
+	}
+}
+
+// GetHeadersRange returns the verified headers from height from to height to, both included.
+func (v *HeaderRangeVerifier) GetHeadersRange(ctx context.Context, from, to uint64) ([]*types.SignedHeader, error) {
+	if from < v.genesis.InitialHeight || to < from || to-from >= MaxHeaderRange {
+		return nil, fmt.Errorf("%w: [%d, %d], at most %d headers from height %d", ErrInvalidHeaderRange, from, to,
+			MaxHeaderRange, v.genesis.InitialHeight)
+	}
+	if height := v.headers.Height(); to > height {
+		return nil, fmt.Errorf("%w: [%d, %d] ends above height %d", ErrHeaderRangeUnavailable, from, to, height)
+	}
+
+	// the header preceding the range anchors its first header
+	start := from
+	if from > v.genesis.InitialHeight {
+		start = from - 1
+	}
+	headers := make([]*types.SignedHeader, 0, to-start+1)
+	for height := start; height <= to; height++ {
+		header, err := v.headers.GetByHeight(ctx, height)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to get header %d: %v", ErrHeaderRangeUnavailable, height, err)
+		}
+		if header == nil {
+			return nil, fmt.Errorf("%w: header %d not found", ErrHeaderRangeUnavailable, height)
+		}
+		headers = append(headers, header)
+	}
+
+	hashes := make([]types.Hash, len(headers))
+	for i, header := range headers {
+		hashes[i] = header.Hash()
+	}
+	if err := v.verifyContinuity(start, headers, hashes); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrHeaderRangeVerification, err)
+	}
+	if err := v.verifySignatures(ctx, headers, hashes); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrHeaderRangeVerification, err)
+	}
+	return headers[from-start:], nil
+}
+
+// verifyContinuity checks that the headers, starting at the given height, form a chain of the proposer.
+func (v *HeaderRangeVerifier) verifyContinuity(start uint64, headers []*types.SignedHeader, hashes []types.Hash) error {
+	for i, header := range headers {
+		height := start + uint64(i) //nolint:gosec // ranges are bounded by MaxHeaderRange
+		if header.Height() != height {
+			return fmt.Errorf("expected header %d, got header %d", height, header.Height())
+		}
+		if header.ChainID() != v.genesis.ChainID {
+			return fmt.Errorf("header %d: expected chain ID %s, got %s", height, v.genesis.ChainID, header.ChainID())
+		}
+		if i == 0 {
+			if height == v.genesis.InitialHeight && !bytes.Equal(header.ProposerAddress, v.genesis.ProposerAddress) {
+				return fmt.Errorf("header %d: %w", height, types.ErrProposerAddressMismatch)
+			}
+			continue
+		}
+		prev := headers[i-1]
+		if !bytes.Equal(header.LastHeader(), hashes[i-1]) {
+			return fmt.Errorf("header %d: %w: expected %X, got %X", height, types.ErrLastHeaderHashMismatch,
+				hashes[i-1], header.LastHeader())
+		}
+		rotated := header.Rotation != nil && bytes.Equal(header.Rotation.Signer.Address, prev.ProposerAddress)
+		if !bytes.Equal(header.ProposerAddress, prev.ProposerAddress) && !rotated {
+			return fmt.Errorf("header %d: expected proposer %X, got %X", height, prev.ProposerAddress,
+				header.ProposerAddress)
+		}
+	}
+	return nil
+}
+
+// verifySignatures verifies the signatures of the headers missing from the cache in a batch, see
+// types.VerifySignatures, and caches the verified headers.
+func (v *HeaderRangeVerifier) verifySignatures(ctx context.Context, headers []*types.SignedHeader, hashes []types.Hash) error {
+	var pending []int
+	v.mu.Lock()
+	for i, header := range headers {
+		if cached, ok := v.verified[header.Height()]; !ok || !bytes.Equal(cached, hashes[i]) {
+			pending = append(pending, i)
+		}
+	}
+	v.mu.Unlock()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	batch := make([]*types.SignedHeader, len(pending))
+	for j, i := range pending {
+		batch[j] = headers[i]
+	}
+	// the signatures verified in a batch are not verified again by ValidateBasic
+	sigErrs := types.VerifySignatures(ctx, batch)
+	// the error of the lowest header is reported
+	for j, header := range batch {
+		err := sigErrs[j]
+		if err == nil {
+			err = header.ValidateBasic()
+		}
+		if err != nil {
+			return fmt.Errorf("header %d: %w", header.Height(), err)
+		}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for _, i := range pending {
+		v.cache(headers[i].Height(), hashes[i])
+	}
+	return nil
+}
+
+// cache records the hash of a verified header, evicting the oldest cached headers beyond headerRangeCacheSize.
+func (v *HeaderRangeVerifier) cache(height uint64, hash types.Hash) {
+	if _, ok := v.verified[height]; !ok {
+		v.order = append(v.order, height)
+	}
+	v.verified[height] = hash
+	for len(v.order) > headerRangeCacheSize {
+		delete(v.verified, v.order[0])
+		v.order = v.order[1:]
+	}
+}
@@ -0,0 +1,148 @@
+package sync
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/genesis"
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+	"github.com/rollkit/rollkit/types"
+)
+
+// makeHeaderChain returns n headers of a chain signed by the same proposer, each linking to the previous one.
+func makeHeaderChain(t *testing.T, n uint64) (testHeaders, genesis.Genesis) {
+	t.Helper()
+	pk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	signer, err := noop.NewNoopSigner(pk)
+	require.NoError(t, err)
+
+	headers := make(testHeaders)
+	var prev *types.SignedHeader
+	for height := uint64(1); height <= n; height++ {
+		header, err := types.GetRandomSignedHeaderCustom(&types.HeaderConfig{
+			Height:   height,
+			DataHash: types.GetRandomBytes(32),
+			AppHash:  types.GetRandomBytes(32),
+			Signer:   signer,
+		}, "test-chain")
+		require.NoError(t, err)
+		if prev != nil {
+			header.LastHeaderHash = prev.Hash()
+			header.Signature, err = types.GetSignature(header.Header, signer)
+			require.NoError(t, err)
+		}
+		headers[height] = header
+		prev = header
+	}
+	return headers, genesis.NewGenesis("test-chain", 1, time.Now(), headers[1].ProposerAddress)
+}
+
+func TestHeaderRangeVerifier(t *testing.T) {
+	ctx := context.Background()
+	headers, gen := makeHeaderChain(t, 5)
+	v := NewHeaderRangeVerifier(headers, gen)
+
+	got, err := v.GetHeadersRange(ctx, 1, 5)
+	require.NoError(t, err)
+	require.Len(t, got, 5)
+	for i, header := range got {
+		assert.Equal(t, uint64(i+1), header.Height())
+	}
+	assert.Len(t, v.verified, 5)
+
+	// ranges after the initial height are anchored to the preceding header
+	got, err = v.GetHeadersRange(ctx, 3, 4)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, uint64(3), got[0].Height())
+
+	for _, tc := range []struct {
+		name     string
+		from, to uint64
+		err      error
+	}{
+		{"before initial height", 0, 2, ErrInvalidHeaderRange},
+		{"empty", 3, 2, ErrInvalidHeaderRange},
+		{"too large", 1, MaxHeaderRange + 1, ErrInvalidHeaderRange},
+		{"above height", 4, 6, ErrHeaderRangeUnavailable},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := v.GetHeadersRange(ctx, tc.from, tc.to)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestHeaderRangeVerifierRejects(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid signature", func(t *testing.T) {
+		headers, gen := makeHeaderChain(t, 3)
+		v := NewHeaderRangeVerifier(headers, gen)
+		headers[3].Signature = types.GetRandomBytes(64)
+		_, err := v.GetHeadersRange(ctx, 1, 3)
+		assert.ErrorIs(t, err, ErrHeaderRangeVerification)
+		assert.ErrorIs(t, err, types.ErrSignatureVerificationFailed)
+		// the headers before the invalid one are not cached either
+		assert.Empty(t, v.verified)
+	})
+
+	t.Run("broken link", func(t *testing.T) {
+		headers, gen := makeHeaderChain(t, 4)
+		v := NewHeaderRangeVerifier(headers, gen)
+		// the anchor does not match the first header of the range
+		other, _ := makeHeaderChain(t, 4)
+		headers[2] = other[2]
+		_, err := v.GetHeadersRange(ctx, 3, 4)
+		assert.ErrorIs(t, err, types.ErrLastHeaderHashMismatch)
+	})
+
+	t.Run("other proposer", func(t *testing.T) {
+		headers, gen := makeHeaderChain(t, 2)
+		gen.ProposerAddress = types.GetRandomBytes(32)
+		v := NewHeaderRangeVerifier(headers, gen)
+		_, err := v.GetHeadersRange(ctx, 1, 2)
+		assert.ErrorIs(t, err, types.ErrProposerAddressMismatch)
+	})
+
+	t.Run("other chain", func(t *testing.T) {
+		headers, gen := makeHeaderChain(t, 2)
+		gen.ChainID = "other-chain"
+		v := NewHeaderRangeVerifier(headers, gen)
+		_, err := v.GetHeadersRange(ctx, 1, 2)
+		assert.ErrorIs(t, err, ErrHeaderRangeVerification)
+	})
+}
+
+func TestHeaderRangeVerifierCache(t *testing.T) {
+	ctx := context.Background()
+	headers, gen := makeHeaderChain(t, 3)
+	v := NewHeaderRangeVerifier(headers, gen)
+	_, err := v.GetHeadersRange(ctx, 1, 3)
+	require.NoError(t, err)
+
+	// cached headers are not verified again
+	v.mu.Lock()
+	cached := v.verified[2]
+	v.mu.Unlock()
+	headers[2] = &types.SignedHeader{Header: headers[2].Header, Signer: headers[2].Signer, Signature: types.GetRandomBytes(64)}
+	_, err = v.GetHeadersRange(ctx, 1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, cached, v.verified[2])
+
+	// the oldest headers are evicted
+	for height := uint64(1); height <= headerRangeCacheSize+1; height++ {
+		v.cache(height+10, types.Hash{byte(height)})
+	}
+	assert.Len(t, v.verified, headerRangeCacheSize)
+	assert.Len(t, v.order, headerRangeCacheSize)
+	_, ok := v.verified[1]
+	assert.False(t, ok)
+}
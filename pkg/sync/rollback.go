@@ -0,0 +1,101 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/celestiaorg/go-header"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// headKey is the key of the head hash in the go-header store.
+var headKey = ds.NewKey("head")
+
+// Rollback removes the headers and data above the given height from the stores of the header and data sync
+// services backed by the datastore, so that they resume syncing from the height. It must be called while the
+// sync services are stopped.
+func Rollback(ctx context.Context, store ds.Batching, height uint64) error {
+	if err := rollbackStore[*types.SignedHeader](ctx, store, headerSync, height); err != nil {
+		return err
+	}
+	return rollbackStore[*types.Data](ctx, store, dataSync, height)
+}
+
+// rollbackStore rewrites the go-header store of the sync type, which does not support deleting headers: the
+// headers above height and their height indexes are deleted, and the head is moved to the header at height. If
+// the store holds no header at height, the head is deleted and the store is initialized again on start.
+func rollbackStore[H header.Header[H]](ctx context.Context, store ds.Batching, syncType syncType, height uint64) error {
+	s := namespace.Wrap(store, ds.NewKey(string(syncType)))
+	headHash, err := s.Get(ctx, headKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load %s store head: %w", syncType, err)
+	}
+	var hash header.Hash
+	if err := hash.UnmarshalJSON(headHash); err != nil {
+		return fmt.Errorf("failed to decode %s store head: %w", syncType, err)
+	}
+	bz, err := s.Get(ctx, ds.NewKey(hash.String()))
+	if err != nil {
+		return fmt.Errorf("failed to load %s store head: %w", syncType, err)
+	}
+	var zero H
+	head := zero.New()
+	if err := head.UnmarshalBinary(bz); err != nil {
+		return fmt.Errorf("failed to decode %s store head: %w", syncType, err)
+	}
+	if head.Height() <= height {
+		return nil
+	}
+
+	batch, err := s.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create a new batch: %w", err)
+	}
+	for h := height + 1; h <= head.Height(); h++ {
+		hash, err := s.Get(ctx, heightKey(h))
+		if errors.Is(err, ds.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load %s store index at height %d: %w", syncType, h, err)
+		}
+		if err := batch.Delete(ctx, ds.NewKey(header.Hash(hash).String())); err != nil {
+			return err
+		}
+		if err := batch.Delete(ctx, heightKey(h)); err != nil {
+			return err
+		}
+	}
+	hash, err = s.Get(ctx, heightKey(height))
+	switch {
+	case errors.Is(err, ds.ErrNotFound):
+		err = batch.Delete(ctx, headKey)
+	case err != nil:
+		return fmt.Errorf("failed to load %s store index at height %d: %w", syncType, height, err)
+	default:
+		if headHash, err = hash.MarshalJSON(); err != nil {
+			return err
+		}
+		err = batch.Put(ctx, headKey, headHash)
+	}
+	if err != nil {
+		return err
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to roll back %s store: %w", syncType, err)
+	}
+	return nil
+}
+
+// heightKey is the key of the hash of the header at the given height in the go-header store.
+func heightKey(height uint64) ds.Key {
+	return ds.NewKey(strconv.FormatUint(height, 10))
+}
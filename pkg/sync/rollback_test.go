@@ -0,0 +1,54 @@
+package sync
+
+import (
+	"context"
+	"testing"
+
+	goheaderstore "github.com/celestiaorg/go-header/store"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+func newTestHeaderStore(t *testing.T, db ds.Batching) *goheaderstore.Store[*types.SignedHeader] {
+	t.Helper()
+	s, err := goheaderstore.NewStore[*types.SignedHeader](db, goheaderstore.WithStorePrefix(string(headerSync)))
+	require.NoError(t, err)
+	require.NoError(t, s.Start(context.Background()))
+	return s
+}
+
+func TestRollback(t *testing.T) {
+	ctx := context.Background()
+	db, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	headers, _ := makeSignedHeaders(t, 5)
+
+	headerStore := newTestHeaderStore(t, db)
+	require.NoError(t, headerStore.Init(ctx, headers[0]))
+	require.NoError(t, headerStore.Append(ctx, headers[1:]...))
+	require.NoError(t, headerStore.Stop(ctx))
+
+	require.NoError(t, Rollback(ctx, db, 3))
+	headerStore = newTestHeaderStore(t, db)
+	head, err := headerStore.Head(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), head.Height())
+	has, err := headerStore.Has(ctx, headers[4].Hash())
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// the store accepts headers again above the rollback height
+	require.NoError(t, headerStore.Append(ctx, headers[3]))
+	require.NoError(t, headerStore.Stop(ctx))
+
+	// rolling back below the first header of the store empties it
+	require.NoError(t, Rollback(ctx, db, 0))
+	headerStore = newTestHeaderStore(t, db)
+	_, err = headerStore.Head(ctx)
+	assert.Error(t, err)
+	require.NoError(t, headerStore.Stop(ctx))
+}
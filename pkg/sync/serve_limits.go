@@ -0,0 +1,106 @@
+package sync
+
+import (
+	"sync"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	"golang.org/x/time/rate"
+)
+
+// servePeerQuotaTTL is the time after which the quota of an idle peer is forgotten.
+const servePeerQuotaTTL = 3 * time.Minute
+
+// ServeLimits protects a node serving headers or blocks to peers over p2p against peers flooding it with requests.
+// Zero values disable the corresponding limit.
+type ServeLimits struct {
+	// Requests is the number of requests per second served over all peers.
+	Requests float64
+	// RequestsPerPeer is the quota of requests per second served to each peer.
+	RequestsPerPeer float64
+	// Burst is the number of requests served in a burst above the rates, at least 1.
+	Burst int
+}
+
+// serveLimiter rate limits the requests served to peers, globally and per peer.
+type serveLimiter struct {
+	limits ServeLimits
+	global *rate.Limiter
+
+	mu        sync.Mutex
+	peers     map[peer.ID]*servePeerQuota
+	lastSweep time.Time
+}
+
+// servePeerQuota is the rate limiter of the requests of a peer.
+type servePeerQuota struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newServeLimiter(limits ServeLimits) *serveLimiter {
+	limits.Burst = max(limits.Burst, 1)
+	l := &serveLimiter{
+		limits: limits,
+		peers:  make(map[peer.ID]*servePeerQuota),
+	}
+	if limits.Requests > 0 {
+		l.global = rate.NewLimiter(rate.Limit(limits.Requests), limits.Burst)
+	}
+	return l
+}
+
+// allow reports whether a request of the peer is allowed by its quota and the global rate limit. The quota of the
+// peer is checked first, so that requests of a flooding peer do not consume the global rate.
+func (l *serveLimiter) allow(id peer.ID, now time.Time) bool {
+	if l.limits.RequestsPerPeer > 0 && !l.allowPeer(id, now) {
+		return false
+	}
+	return l.global == nil || l.global.AllowN(now, 1)
+}
+
+func (l *serveLimiter) allowPeer(id peer.ID, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Sub(l.lastSweep) > servePeerQuotaTTL {
+		for p, q := range l.peers {
+			if now.Sub(q.lastSeen) > servePeerQuotaTTL {
+				delete(l.peers, p)
+			}
+		}
+		l.lastSweep = now
+	}
+	q, ok := l.peers[id]
+	if !ok {
+		q = &servePeerQuota{limiter: rate.NewLimiter(rate.Limit(l.limits.RequestsPerPeer), l.limits.Burst)}
+		l.peers[id] = q
+	}
+	q.lastSeen = now
+	return q.limiter.AllowN(now, 1)
+}
+
+// limitedHost is a host whose stream handlers are only called for the requests allowed by the limiter, so that
+// the rate of the requests served by the go-header exchange server is limited. The streams of the requests above
+// the limits are reset.
+type limitedHost struct {
+	host.Host
+	limiter *serveLimiter
+	logger  log.Logger
+}
+
+// SetStreamHandler sets the handler of the protocol, wrapped with the limiter.
+func (h limitedHost) SetStreamHandler(pid protocol.ID, handler network.StreamHandler) {
+	h.Host.SetStreamHandler(pid, func(stream network.Stream) {
+		remote := stream.Conn().RemotePeer()
+		if !h.limiter.allow(remote, time.Now()) {
+			h.logger.Debug("rate limited header request", "peer", remote, "protocol", pid)
+			_ = stream.Reset()
+			return
+		}
+		handler(stream)
+	})
+}
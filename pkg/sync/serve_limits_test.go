@@ -0,0 +1,68 @@
+package sync
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/libp2p/go-libp2p/core/network"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeLimiter(t *testing.T) {
+	l := newServeLimiter(ServeLimits{Requests: 1, RequestsPerPeer: 1, Burst: 2})
+	now := time.Now()
+
+	// the quota of a peer does not consume the quota of other peers
+	assert.True(t, l.allow("a", now))
+	assert.True(t, l.allow("a", now))
+	assert.False(t, l.allow("a", now))
+	// the global rate is used up by the requests of peer a
+	assert.False(t, l.allow("b", now))
+	assert.True(t, l.allow("b", now.Add(time.Second)))
+
+	// zero limits allow all requests
+	l = newServeLimiter(ServeLimits{})
+	for range 10 {
+		assert.True(t, l.allow("a", now))
+	}
+}
+
+// TestLimitedHost verifies that the streams of the requests above the quota of a peer are reset before reaching
+// the handler.
+func TestLimitedHost(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err)
+	defer mnet.Close() //nolint:errcheck
+	hosts := mnet.Hosts()
+
+	const pid = "/limited-host-test"
+	server := limitedHost{Host: hosts[0], limiter: newServeLimiter(ServeLimits{RequestsPerPeer: 0.001, Burst: 1}), logger: log.NewNopLogger()}
+	server.SetStreamHandler(pid, func(stream network.Stream) {
+		defer stream.Close() //nolint:errcheck
+		_, _ = stream.Write([]byte("ok"))
+	})
+
+	request := func() ([]byte, error) {
+		stream, err := hosts[1].NewStream(ctx, hosts[0].ID(), pid)
+		if err != nil {
+			return nil, err
+		}
+		defer stream.Close() //nolint:errcheck
+		return io.ReadAll(stream)
+	}
+	resp, err := request()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ok"), resp)
+
+	// the quota of the peer is used up
+	_, err = request()
+	assert.Error(t, err)
+}
@@ -2,10 +2,11 @@ package sync
 
 import (
 	"context"
-	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"cosmossdk.io/log"
 	"github.com/celestiaorg/go-header"
@@ -20,8 +21,10 @@ import (
 	"github.com/multiformats/go-multiaddr"
 
 	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/failpoint"
 	"github.com/rollkit/rollkit/pkg/genesis"
 	"github.com/rollkit/rollkit/pkg/p2p"
+	"github.com/rollkit/rollkit/pkg/retry"
 	"github.com/rollkit/rollkit/types"
 )
 
@@ -44,12 +47,31 @@ type SyncService[H header.Header[H]] struct {
 
 	p2p *p2p.Client
 
-	ex           *goheaderp2p.Exchange[H]
-	sub          *goheaderp2p.Subscriber[H]
+	ex  *goheaderp2p.Exchange[H]
+	sub *goheaderp2p.Subscriber[H]
+	// subscription keeps the node subscribed to the gossip topic, so that it relays the gossiped headers or data
+	subscription header.Subscription[H]
 	p2pServer    *goheaderp2p.ExchangeServer[H]
 	store        *goheaderstore.Store[H]
 	syncer       *goheadersync.Syncer[H]
 	syncerStatus *SyncerStatus
+
+	ds ds.Batching
+	// getter fetches the trusted header/block and the ones below it from peers
+	getter header.Getter[H]
+	// headerService is the header sync service the trusted block of the data sync service is verified against
+	headerService *HeaderSyncService
+
+	// noServe disables serving the store to peers, see SetServing
+	noServe bool
+	// serveLimits limits the requests of peers served from the store, nil for no limits
+	serveLimits *ServeLimits
+
+	backfillMu sync.Mutex
+	// tail is the height of the header/block the store was initialized with
+	tail uint64
+	// lowest is the lowest height fetched and verified below the tail
+	lowest uint64
 }
 
 // DataSyncService is the P2P Sync Service for blocks.
@@ -91,6 +113,9 @@ func newSyncService[H header.Header[H]](
 	if p2p == nil {
 		return nil, errors.New("p2p client cannot be nil")
 	}
+	if conf.Node.TrustedHeight > 0 && conf.Node.TrustedHash == "" {
+		return nil, errors.New("trusted height requires a trusted hash")
+	}
 	ss, err := goheaderstore.NewStore[H](
 		store,
 		goheaderstore.WithStorePrefix(string(syncType)),
@@ -108,6 +133,7 @@ func newSyncService[H header.Header[H]](
 		syncType:     syncType,
 		logger:       logger,
 		syncerStatus: new(SyncerStatus),
+		ds:           store,
 	}, nil
 }
 
@@ -116,6 +142,19 @@ func (syncService *SyncService[H]) Store() *goheaderstore.Store[H] {
 	return syncService.store
 }
 
+// SetHeaderSyncService sets the header sync service the trusted block of the data sync service is verified
+// against, when starting from a trusted hash.
+func (syncService *SyncService[H]) SetHeaderSyncService(headerService *HeaderSyncService) {
+	syncService.headerService = headerService
+}
+
+// SetServing sets whether the headers/blocks of the store are served to peers over p2p, and the limits of the
+// requests served. The store is served without limits by default. It must be called before Start.
+func (syncService *SyncService[H]) SetServing(serve bool, limits ServeLimits) {
+	syncService.noServe = !serve
+	syncService.serveLimits = &limits
+}
+
 func (syncService *SyncService[H]) initStoreAndStartSyncer(ctx context.Context, initial H) error {
 	if initial.IsZero() {
 		return errors.New("failed to initialize the store and start syncer")
@@ -123,6 +162,9 @@ func (syncService *SyncService[H]) initStoreAndStartSyncer(ctx context.Context,
 	if err := syncService.store.Init(ctx, initial); err != nil {
 		return err
 	}
+	if err := syncService.setCheckpoint(ctx, initial.Height()); err != nil {
+		return err
+	}
 	if err := syncService.StartSyncer(ctx); err != nil {
 		return err
 	}
@@ -141,6 +183,12 @@ func (syncService *SyncService[H]) WriteToStoreAndBroadcast(ctx context.Context,
 		if err := syncService.store.Init(ctx, headerOrData); err != nil {
 			return errors.New("failed to initialize the store")
 		}
+	} else if head, err := syncService.store.Head(ctx); err == nil && head.Height()+1 == headerOrData.Height() {
+		// local broadcasts skip the syncer, so the store is extended here, letting peers which join later fetch
+		// the past headers or blocks from this node
+		if err := syncService.store.Append(ctx, headerOrData); err != nil {
+			return fmt.Errorf("failed to append to the store: %w", err)
+		}
 	}
 
 	firstStart := false
@@ -151,6 +199,11 @@ func (syncService *SyncService[H]) WriteToStoreAndBroadcast(ctx context.Context,
 		}
 	}
 
+	if err := failpoint.Inject(ctx, failpoint.SyncBroadcast); err != nil {
+		syncService.logger.Debug("dropping broadcast", "height", headerOrData.Height(), "error", err)
+		return nil
+	}
+
 	// Broadcast for subscribers
 	if err := syncService.sub.Broadcast(ctx, headerOrData); err != nil {
 		// for the first block when starting the app, broadcast error is expected
@@ -179,6 +232,10 @@ func (syncService *SyncService[H]) Start(ctx context.Context) error {
 		return err
 	}
 
+	if err := syncService.loadCheckpoint(ctx); err != nil {
+		return fmt.Errorf("failed to load the %s checkpoint: %w", syncService.syncType, err)
+	}
+
 	if err := syncService.prepareSyncer(ctx); err != nil {
 		return err
 	}
@@ -201,10 +258,17 @@ func (syncService *SyncService[H]) setupP2P(ctx context.Context) ([]peer.ID, err
 		return nil, err
 	}
 
+	// peers gossiping headers or data rejected by the subscriber validator are penalized
+	misbehavior := p2p.InvalidData
+	if syncService.syncType == headerSync {
+		misbehavior = p2p.InvalidHeader
+	}
+	syncService.p2p.SetTopicMisbehavior(goheaderp2p.PubsubTopicID(syncService.getChainID()), misbehavior)
+
 	if err := syncService.sub.Start(ctx); err != nil {
 		return nil, fmt.Errorf("error while starting subscriber: %w", err)
 	}
-	if _, err := syncService.sub.Subscribe(); err != nil {
+	if syncService.subscription, err = syncService.sub.Subscribe(); err != nil {
 		return nil, fmt.Errorf("error while subscribing: %w", err)
 	}
 	if err := syncService.store.Start(ctx); err != nil {
@@ -217,11 +281,17 @@ func (syncService *SyncService[H]) setupP2P(ctx context.Context) ([]peer.ID, err
 	}
 	networkID := syncService.getNetworkID(network)
 
-	if syncService.p2pServer, err = newP2PServer(syncService.p2p.Host(), syncService.store, networkID); err != nil {
-		return nil, fmt.Errorf("error while creating p2p server: %w", err)
-	}
-	if err := syncService.p2pServer.Start(ctx); err != nil {
-		return nil, fmt.Errorf("error while starting p2p server: %w", err)
+	if !syncService.noServe {
+		var serverHost host.Host = syncService.p2p.Host()
+		if syncService.serveLimits != nil {
+			serverHost = limitedHost{Host: serverHost, limiter: newServeLimiter(*syncService.serveLimits), logger: syncService.logger}
+		}
+		if syncService.p2pServer, err = newP2PServer(serverHost, syncService.store, networkID); err != nil {
+			return nil, fmt.Errorf("error while creating p2p server: %w", err)
+		}
+		if err := syncService.p2pServer.Start(ctx); err != nil {
+			return nil, fmt.Errorf("error while starting p2p server: %w", err)
+		}
 	}
 
 	peerIDs := syncService.getPeerIDs()
@@ -231,6 +301,7 @@ func (syncService *SyncService[H]) setupP2P(ctx context.Context) ([]peer.ID, err
 	if err := syncService.ex.Start(ctx); err != nil {
 		return nil, fmt.Errorf("error while starting exchange: %w", err)
 	}
+	syncService.getter = syncService.ex
 	return peerIDs, nil
 }
 
@@ -258,49 +329,59 @@ func (syncService *SyncService[H]) prepareSyncer(ctx context.Context) error {
 }
 
 // setFirstAndStart looks up for the trusted hash or the genesis header/block.
-// If trusted hash is available, it fetches the trusted header/block (by hash) from peers.
+// If trusted hash is available, it fetches the trusted header/block from peers: the header with the trusted
+// hash, at the trusted height if set, and the block at the height of the trusted header. The headers/blocks
+// below it are only fetched and verified backwards when queried, see GetByHeight.
 // Otherwise, it tries to fetch the genesis header/block by height.
+// Fetching is retried following the sync retry policy of the configuration, e.g. while peers are connecting or
+// the aggregator has not published the genesis yet.
 // If trusted header/block is available, syncer is started.
 func (syncService *SyncService[H]) setFirstAndStart(ctx context.Context, peerIDs []peer.ID) error {
-	// Look to see if trusted hash is passed, if not get the genesis header/block
-	var trusted H
 	// Try fetching the trusted header/block from peers if exists
-	if len(peerIDs) > 0 {
+	if len(peerIDs) == 0 || syncService.isInitialized() {
+		return nil
+	}
+	policy := syncService.conf.Retry.Sync.Policy()
+	policy.OnRetry = func(attempt int, err error, backoff time.Duration) {
+		syncService.logger.Info("failed to fetch the first header/block from peers, retrying",
+			"type", syncService.syncType, "attempt", attempt, "backoff", backoff, "error", err)
+	}
+	trusted, err := retry.DoValue(ctx, policy, func(ctx context.Context) (H, error) {
 		if syncService.conf.Node.TrustedHash != "" {
-			trustedHashBytes, err := hex.DecodeString(syncService.conf.Node.TrustedHash)
-			if err != nil {
-				return fmt.Errorf("failed to parse the trusted hash for initializing the store: %w", err)
-			}
-
-			if trusted, err = syncService.ex.Get(ctx, trustedHashBytes); err != nil {
-				return fmt.Errorf("failed to fetch the trusted header/block for initializing the store: %w", err)
-			}
-		} else {
-			// Try fetching the genesis header/block if available, otherwise fallback to block
-			var err error
-			if trusted, err = syncService.ex.GetByHeight(ctx, syncService.genesis.InitialHeight); err != nil {
-				// Full/light nodes have to wait for aggregator to publish the genesis block
-				// proposing aggregator can init the store and start the syncer when the first block is published
-				return fmt.Errorf("failed to fetch the genesis: %w", err)
-			}
+			return syncService.fetchTrusted(ctx)
 		}
-
-		return syncService.initStoreAndStartSyncer(ctx, trusted)
+		// Full/light nodes have to wait for aggregator to publish the genesis block
+		// proposing aggregator can init the store and start the syncer when the first block is published
+		trusted, err := syncService.getter.GetByHeight(ctx, syncService.genesis.InitialHeight)
+		if err != nil {
+			return trusted, fmt.Errorf("failed to fetch the genesis: %w", err)
+		}
+		return trusted, nil
+	})
+	if err != nil {
+		return err
 	}
-	return nil
+	return syncService.initStoreAndStartSyncer(ctx, trusted)
 }
 
 // Stop is a part of Service interface.
 //
 // `store` is closed last because it's used by other services.
 func (syncService *SyncService[H]) Stop(ctx context.Context) error {
-	err := errors.Join(
-		syncService.p2pServer.Stop(ctx),
+	var err error
+	// the subscriptions are cancelled first, as the topic cannot be left while subscribed to it
+	if syncService.syncerStatus.isStarted() {
+		err = syncService.syncer.Stop(ctx)
+	}
+	if syncService.subscription != nil {
+		syncService.subscription.Cancel()
+	}
+	err = errors.Join(err,
 		syncService.ex.Stop(ctx),
 		syncService.sub.Stop(ctx),
 	)
-	if syncService.syncerStatus.isStarted() {
-		err = errors.Join(err, syncService.syncer.Stop(ctx))
+	if syncService.p2pServer != nil {
+		err = errors.Join(err, syncService.p2pServer.Stop(ctx))
 	}
 	err = errors.Join(err, syncService.store.Stop(ctx))
 	return err
@@ -361,6 +442,14 @@ func (syncService *SyncService[H]) StartSyncer(ctx context.Context) error {
 	return nil
 }
 
+// IsSyncing returns true while the syncer is catching up with the head of the network.
+func (syncService *SyncService[H]) IsSyncing() bool {
+	if !syncService.syncerStatus.isStarted() {
+		return false
+	}
+	return !syncService.syncer.State().Finished()
+}
+
 func (syncService *SyncService[H]) getNetworkID(network string) string {
 	return network + "-" + string(syncService.syncType)
 }
@@ -0,0 +1,178 @@
+// Package telemetry periodically reports anonymized metrics of a node to a telemetry endpoint, for networks running
+// public dashboards of the health of their nodes. Reporting is opt-in, see config.TelemetryConfig.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/types"
+)
+
+// NodeIDKey is the key used for persisting the telemetry identifier of the node in store.
+const NodeIDKey = "telemetry-node-id"
+
+// reportTimeout bounds the time spent posting a report.
+const reportTimeout = 10 * time.Second
+
+// ErrNoEndpoint is returned when telemetry is enabled without an endpoint.
+var ErrNoEndpoint = errors.New("telemetry is enabled but no endpoint is set")
+
+// Report is the telemetry report of a node, posted as JSON to the telemetry endpoint.
+type Report struct {
+	// NodeID is a random identifier of the node, not linked to its p2p identity, persisted across restarts so that
+	// the reports of a node can be told apart.
+	NodeID  string `json:"node_id"`
+	ChainID string `json:"chain_id"`
+	// Version is the Rollkit version of the node, empty if unknown.
+	Version string `json:"version"`
+	// Mode is the mode of the node, one of the types.NodeMode constants.
+	Mode   string `json:"mode"`
+	Height uint64 `json:"height"`
+	// DALag is the number of blocks not included in the DA layer yet.
+	DALag uint64    `json:"da_lag"`
+	Peers int       `json:"peers"`
+	Time  time.Time `json:"time"`
+}
+
+// StatusSource provides the sync progress of the node. It is implemented by the full and light nodes.
+type StatusSource interface {
+	Status(ctx context.Context) (types.NodeStatus, error)
+}
+
+// MetadataStore persists the telemetry identifier of the node. It is implemented by store.Store.
+type MetadataStore interface {
+	GetMetadata(ctx context.Context, key string) ([]byte, error)
+	SetMetadata(ctx context.Context, key string, value []byte) error
+}
+
+// Reporter posts the telemetry report of the node to the telemetry endpoint every interval.
+type Reporter struct {
+	conf    config.TelemetryConfig
+	chainID string
+	version string
+	status  StatusSource
+	store   MetadataStore
+	client  *http.Client
+	logger  log.Logger
+}
+
+// NewReporter creates a new Reporter of the node status. It returns ErrNoEndpoint if telemetry is enabled without
+// an endpoint.
+func NewReporter(conf config.TelemetryConfig, chainID, version string, status StatusSource, store MetadataStore, logger log.Logger) (*Reporter, error) {
+	if conf.Enabled && conf.Endpoint == "" {
+		return nil, ErrNoEndpoint
+	}
+	return &Reporter{
+		conf:    conf,
+		chainID: chainID,
+		version: version,
+		status:  status,
+		store:   store,
+		client:  &http.Client{Timeout: reportTimeout},
+		logger:  logger,
+	}, nil
+}
+
+// Run posts a report every interval until the context is canceled. Reports which cannot be posted are logged and
+// skipped. It returns immediately if telemetry is disabled.
+func (r *Reporter) Run(ctx context.Context) error {
+	if !r.conf.Enabled || r.conf.Interval.Duration <= 0 {
+		return nil
+	}
+	nodeID, err := NodeID(ctx, r.store)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(r.conf.Interval.Duration)
+	defer ticker.Stop()
+	for {
+		if err := r.report(ctx, nodeID); err != nil && ctx.Err() == nil {
+			r.logger.Error("failed to post telemetry report", "endpoint", r.conf.Endpoint, "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Report returns the telemetry report of the node.
+func (r *Reporter) Report(ctx context.Context, nodeID string) (Report, error) {
+	status, err := r.status.Status(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to get node status: %w", err)
+	}
+	report := Report{
+		NodeID:  nodeID,
+		ChainID: r.chainID,
+		Version: r.version,
+		Mode:    status.Mode,
+		Height:  status.Height,
+		Peers:   status.Peers,
+		Time:    time.Now().UTC(),
+	}
+	if status.Height > status.DAIncludedHeight {
+		report.DALag = status.Height - status.DAIncludedHeight
+	}
+	return report, nil
+}
+
+// report posts the telemetry report of the node to the endpoint.
+func (r *Reporter) report(ctx context.Context, nodeID string) error {
+	report, err := r.Report(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode telemetry report: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.conf.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck // the body is not read
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry endpoint replied %s", resp.Status)
+	}
+	return nil
+}
+
+// NodeID returns the telemetry identifier of the node, generated at random the first time and persisted in the
+// store.
+func NodeID(ctx context.Context, store MetadataStore) (string, error) {
+	id, err := store.GetMetadata(ctx, NodeIDKey)
+	switch {
+	case err == nil:
+		return string(id), nil
+	case !errors.Is(err, ds.ErrNotFound):
+		return "", fmt.Errorf("failed to load telemetry node ID: %w", err)
+	}
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate telemetry node ID: %w", err)
+	}
+	nodeID := hex.EncodeToString(raw)
+	if err := store.SetMetadata(ctx, NodeIDKey, []byte(nodeID)); err != nil {
+		return "", fmt.Errorf("failed to save telemetry node ID: %w", err)
+	}
+	return nodeID, nil
+}
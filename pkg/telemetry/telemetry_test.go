@@ -0,0 +1,84 @@
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/config"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+type testStatus types.NodeStatus
+
+func (s testStatus) Status(context.Context) (types.NodeStatus, error) {
+	return types.NodeStatus(s), nil
+}
+
+func newTestStore(t *testing.T) store.Store {
+	t.Helper()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	return store.New(kv)
+}
+
+func TestReporter(t *testing.T) {
+	reports := make(chan Report, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var report Report
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&report))
+		reports <- report
+	}))
+	defer srv.Close()
+
+	s := newTestStore(t)
+	status := testStatus{Mode: types.NodeModeFull, Height: 10, DAIncludedHeight: 7, Peers: 3}
+	conf := config.TelemetryConfig{Enabled: true, Endpoint: srv.URL, Interval: config.DurationWrapper{Duration: 10 * time.Millisecond}}
+	r, err := NewReporter(conf, "test-chain", "v1.2.3", status, s, log.NewNopLogger())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() { done <- r.Run(ctx) }()
+	first, second := <-reports, <-reports
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+
+	assert.Len(t, first.NodeID, 32)
+	assert.Equal(t, "test-chain", first.ChainID)
+	assert.Equal(t, "v1.2.3", first.Version)
+	assert.Equal(t, types.NodeModeFull, first.Mode)
+	assert.Equal(t, uint64(10), first.Height)
+	assert.Equal(t, uint64(3), first.DALag)
+	assert.Equal(t, 3, first.Peers)
+	assert.False(t, first.Time.IsZero())
+	assert.Equal(t, first.NodeID, second.NodeID)
+
+	// the node ID is persisted across restarts
+	nodeID, err := NodeID(t.Context(), s)
+	require.NoError(t, err)
+	assert.Equal(t, first.NodeID, nodeID)
+	otherID, err := NodeID(t.Context(), newTestStore(t))
+	require.NoError(t, err)
+	assert.NotEqual(t, nodeID, otherID)
+}
+
+func TestReporter_Disabled(t *testing.T) {
+	_, err := NewReporter(config.TelemetryConfig{Enabled: true}, "test-chain", "", testStatus{}, newTestStore(t), log.NewNopLogger())
+	require.ErrorIs(t, err, ErrNoEndpoint)
+
+	// telemetry is off by default
+	r, err := NewReporter(config.DefaultConfig.Telemetry, "test-chain", "", testStatus{}, newTestStore(t), log.NewNopLogger())
+	require.NoError(t, err)
+	require.NoError(t, r.Run(t.Context()))
+}
@@ -0,0 +1,374 @@
+package txindex
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+
+	"cosmossdk.io/log"
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+	"google.golang.org/protobuf/proto"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/store"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+const (
+	// DefaultPerPage is the number of transactions per page returned by TxSearch when none is requested.
+	DefaultPerPage = 30
+	// MaxPerPage is the maximum number of transactions per page returned by TxSearch.
+	MaxPerPage = 100
+
+	txPrefix     = "tx"
+	eventPrefix  = "ev"
+	heightKeyStr = "height"
+)
+
+// ErrNotFound is returned when no indexed transaction matches the requested hash.
+var ErrNotFound = errors.New("transaction not found")
+
+// Indexer indexes the transactions of the blocks in the store by hash and by the events they emitted.
+// Indexing runs in the background: the block Manager notifies the Indexer of new blocks, and the Indexer
+// catches up from the store, so that no block is missed if notifications are dropped or the node restarts.
+type Indexer struct {
+	db     ds.Batching
+	store  store.Store
+	events coreexecutor.EventEmitter
+	logger log.Logger
+
+	notifyCh      chan struct{}
+	indexedHeight atomic.Uint64
+}
+
+// NewIndexer creates an Indexer persisting its index in db. Transactions are indexed by event if exec
+// implements coreexecutor.EventEmitter.
+func NewIndexer(ctx context.Context, db ds.Batching, store store.Store, exec coreexecutor.Executor, logger log.Logger) (*Indexer, error) {
+	idx := &Indexer{
+		db:       db,
+		store:    store,
+		logger:   logger,
+		notifyCh: make(chan struct{}, 1),
+	}
+	if emitter, ok := exec.(coreexecutor.EventEmitter); ok {
+		idx.events = emitter
+	}
+	raw, err := db.Get(ctx, ds.NewKey(heightKeyStr))
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return nil, fmt.Errorf("failed to load indexed height: %w", err)
+	}
+	if err == nil {
+		if len(raw) != 8 {
+			return nil, fmt.Errorf("invalid indexed height length: %d", len(raw))
+		}
+		idx.indexedHeight.Store(binary.LittleEndian.Uint64(raw))
+	}
+	return idx, nil
+}
+
+// IndexedHeight returns the height up to which blocks are indexed.
+func (idx *Indexer) IndexedHeight() uint64 {
+	return idx.indexedHeight.Load()
+}
+
+// Notify signals the Indexer that new blocks are available in the store. It never blocks.
+func (idx *Indexer) Notify() {
+	select {
+	case idx.notifyCh <- struct{}{}:
+	default:
+	}
+}
+
+// Run indexes the blocks of the store as they are notified, until the context is canceled.
+func (idx *Indexer) Run(ctx context.Context) error {
+	for {
+		if err := idx.indexBlocks(ctx); err != nil && ctx.Err() == nil {
+			idx.logger.Error("failed to index blocks", "height", idx.IndexedHeight()+1, "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-idx.notifyCh:
+		}
+	}
+}
+
+// indexBlocks indexes the blocks of the store above the indexed height.
+func (idx *Indexer) indexBlocks(ctx context.Context) error {
+	height, err := idx.store.Height(ctx)
+	if err != nil {
+		return err
+	}
+	// blocks are indexed once executed, so that the results of their transactions are known
+	if state, err := idx.store.GetState(ctx); err == nil && state.LastBlockHeight < height {
+		height = state.LastBlockHeight
+	}
+	for h := idx.IndexedHeight() + 1; h <= height; h++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := idx.indexBlock(ctx, h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexBlock indexes the transactions of the block at the given height and advances the indexed height.
+func (idx *Indexer) indexBlock(ctx context.Context, height uint64) error {
+	batch, err := idx.db.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create a new batch: %w", err)
+	}
+
+	_, data, err := idx.store.GetBlockData(ctx, height)
+	switch {
+	case errors.Is(err, ds.ErrNotFound):
+		// blocks pruned before indexing was enabled cannot be indexed
+		idx.logger.Debug("skipping block missing from store", "height", height)
+	case err != nil:
+		return fmt.Errorf("failed to load block %d: %w", height, err)
+	default:
+		var events [][]coreexecutor.Event
+		if idx.events != nil {
+			events, err = idx.events.TxEvents(ctx, height)
+			if err != nil {
+				idx.logger.Error("failed to get transaction events, indexing by hash only", "height", height, "error", err)
+				events = nil
+			} else if len(events) != len(data.Txs) {
+				idx.logger.Error("transaction events do not match transactions, indexing by hash only", "height", height, "events", len(events), "txs", len(data.Txs))
+				events = nil
+			}
+		}
+		results, err := loadTxResults(ctx, idx.store, height)
+		if err != nil {
+			idx.logger.Error("failed to load transaction results, indexing without results", "height", height, "error", err)
+			results = nil
+		} else if results != nil && len(results) != len(data.Txs) {
+			idx.logger.Error("transaction results do not match transactions, indexing without results", "height", height, "results", len(results), "txs", len(data.Txs))
+			results = nil
+		}
+		for i, tx := range data.Txs {
+			var result *coreexecutor.TxResult
+			if results != nil {
+				result = &results[i]
+			}
+			res := newTxResult(tx, height, i, result)
+			if events != nil {
+				res.Events = eventsToProto(events[i])
+			}
+			if err := idx.putTx(ctx, batch, res); err != nil {
+				return err
+			}
+		}
+	}
+
+	heightBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(heightBytes, height)
+	if err := batch.Put(ctx, ds.NewKey(heightKeyStr), heightBytes); err != nil {
+		return err
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit index of block %d: %w", height, err)
+	}
+	idx.indexedHeight.Store(height)
+	return nil
+}
+
+// putTx adds the transaction and its index entries to the batch.
+func (idx *Indexer) putTx(ctx context.Context, batch ds.Batch, res *pb.TxResult) error {
+	bz, err := proto.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("failed to marshal transaction: %w", err)
+	}
+	if err := batch.Put(ctx, txKey(res.Hash), bz); err != nil {
+		return err
+	}
+	for _, key := range eventKeys(res) {
+		if err := batch.Put(ctx, key, res.Hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// eventKeys returns the event index entries of the transaction.
+func eventKeys(res *pb.TxResult) []ds.Key {
+	keys := []ds.Key{eventKey(TxHeightKey, strconv.FormatUint(res.Height, 10), res.Height, res.Index)}
+	for _, event := range res.Events {
+		for _, attr := range event.Attributes {
+			if attr.Key == "" || attr.Value == "" {
+				continue
+			}
+			keys = append(keys, eventKey(event.Type+"."+attr.Key, attr.Value, res.Height, res.Index))
+		}
+	}
+	return keys
+}
+
+// Rollback removes the transactions of the blocks above the given height from the index persisted in db, and
+// lowers the indexed height to it, so that the blocks produced again above the height are indexed. It must be
+// called while the Indexer is stopped.
+func Rollback(ctx context.Context, db ds.Batching, height uint64) error {
+	results, err := db.Query(ctx, dsq.Query{Prefix: ds.NewKey(txPrefix).String()})
+	if err != nil {
+		return fmt.Errorf("failed to query indexed transactions: %w", err)
+	}
+	defer results.Close()
+
+	batch, err := db.Batch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create a new batch: %w", err)
+	}
+	for result := range results.Next() {
+		if result.Error != nil {
+			return fmt.Errorf("failed to query indexed transactions: %w", result.Error)
+		}
+		var res pb.TxResult
+		if err := proto.Unmarshal(result.Value, &res); err != nil {
+			return fmt.Errorf("failed to unmarshal transaction: %w", err)
+		}
+		if res.Height <= height {
+			continue
+		}
+		for _, key := range append(eventKeys(&res), txKey(res.Hash)) {
+			if err := batch.Delete(ctx, key); err != nil {
+				return err
+			}
+		}
+	}
+
+	raw, err := db.Get(ctx, ds.NewKey(heightKeyStr))
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return fmt.Errorf("failed to load indexed height: %w", err)
+	}
+	if err == nil && len(raw) == 8 && binary.LittleEndian.Uint64(raw) > height {
+		if err := batch.Put(ctx, ds.NewKey(heightKeyStr), binary.LittleEndian.AppendUint64(nil, height)); err != nil {
+			return err
+		}
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit index rollback: %w", err)
+	}
+	return nil
+}
+
+// TxByHash returns the indexed transaction with the given hash.
+func (idx *Indexer) TxByHash(ctx context.Context, hash []byte) (*pb.TxResult, error) {
+	bz, err := idx.db.Get(ctx, txKey(hash))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := new(pb.TxResult)
+	if err := proto.Unmarshal(bz, res); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction: %w", err)
+	}
+	return res, nil
+}
+
+// TxSearch returns the page of indexed transactions matching the query, ordered by height and index,
+// along with the total number of matching transactions. Pages are numbered from 1.
+func (idx *Indexer) TxSearch(ctx context.Context, query string, page, perPage int) ([]*pb.TxResult, int, error) {
+	conditions, err := parseQuery(query)
+	if err != nil {
+		return nil, 0, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = DefaultPerPage
+	}
+	perPage = min(perPage, MaxPerPage)
+
+	// the first condition selects the candidates from the event index, the others filter them
+	results, err := idx.db.Query(ctx, dsq.Query{
+		Prefix: eventKeyPrefix(conditions[0].key, conditions[0].value).String(),
+		Orders: []dsq.Order{dsq.OrderByKey{}},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	defer results.Close()
+
+	var (
+		txs   []*pb.TxResult
+		total int
+		seen  = make(map[string]struct{})
+	)
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, 0, result.Error
+		}
+		// a transaction with several matching attributes is indexed more than once
+		if _, ok := seen[string(result.Value)]; ok {
+			continue
+		}
+		seen[string(result.Value)] = struct{}{}
+
+		res, err := idx.TxByHash(ctx, result.Value)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !matchesAll(res, conditions[1:]) {
+			continue
+		}
+		total++
+		if total > (page-1)*perPage && len(txs) < perPage {
+			txs = append(txs, res)
+		}
+	}
+	return txs, total, nil
+}
+
+func matchesAll(res *pb.TxResult, conditions []condition) bool {
+	for _, c := range conditions {
+		if !c.matches(res) {
+			return false
+		}
+	}
+	return true
+}
+
+// TxHash returns the hash under which a transaction is indexed.
+func TxHash(tx []byte) []byte {
+	hash := sha256.Sum256(tx)
+	return hash[:]
+}
+
+func eventsToProto(events []coreexecutor.Event) []*pb.Event {
+	pbEvents := make([]*pb.Event, len(events))
+	for i, event := range events {
+		attrs := make([]*pb.EventAttribute, len(event.Attributes))
+		for j, attr := range event.Attributes {
+			attrs[j] = &pb.EventAttribute{Key: attr.Key, Value: attr.Value}
+		}
+		pbEvents[i] = &pb.Event{Type: event.Type, Attributes: attrs}
+	}
+	return pbEvents
+}
+
+func txKey(hash []byte) ds.Key {
+	return ds.NewKey(txPrefix).ChildString(hex.EncodeToString(hash))
+}
+
+// eventKeyPrefix returns the prefix of the index entries of transactions having an event attribute equal
+// to value. Keys and values are escaped since they may contain the key separator.
+func eventKeyPrefix(key, value string) ds.Key {
+	return ds.NewKey(eventPrefix).ChildString(url.PathEscape(key)).ChildString(url.PathEscape(value))
+}
+
+// eventKey returns the index entry of a transaction, ordered by height and index within the same key and value.
+func eventKey(key, value string, height uint64, index uint32) ds.Key {
+	return eventKeyPrefix(key, value).ChildString(fmt.Sprintf("%020d", height)).ChildString(fmt.Sprintf("%010d", index))
+}
@@ -0,0 +1,193 @@
+package txindex
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"cosmossdk.io/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// eventExecutor emits a transfer event for every transaction, with the block height as sender.
+type eventExecutor struct {
+	*coreexecutor.DummyExecutor
+	txsPerBlock int
+}
+
+func (e eventExecutor) TxEvents(_ context.Context, blockHeight uint64) ([][]coreexecutor.Event, error) {
+	events := make([][]coreexecutor.Event, e.txsPerBlock)
+	for i := range events {
+		events[i] = []coreexecutor.Event{{
+			Type: "transfer",
+			Attributes: []coreexecutor.EventAttribute{
+				{Key: "sender", Value: fmt.Sprintf("addr/%d", blockHeight)},
+				{Key: "index", Value: fmt.Sprint(i)},
+			},
+		}}
+	}
+	return events, nil
+}
+
+func setupStore(t *testing.T, blocks, txsPerBlock int) (store.Store, []*types.Data) {
+	t.Helper()
+	ctx := context.Background()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	s := store.New(kv)
+
+	var data []*types.Data
+	for height := uint64(1); height <= uint64(blocks); height++ {
+		header, d := types.GetRandomBlock(height, txsPerBlock, "test-chain")
+		require.NoError(t, s.SaveBlockData(ctx, header, d, &header.Signature))
+		require.NoError(t, s.SetHeight(ctx, height))
+		data = append(data, d)
+	}
+	return s, data
+}
+
+func TestIndexer(t *testing.T) {
+	ctx := context.Background()
+	s, data := setupStore(t, 3, 2)
+	db, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+
+	exec := eventExecutor{DummyExecutor: coreexecutor.NewDummyExecutor(), txsPerBlock: 2}
+	idx, err := NewIndexer(ctx, db, s, exec, log.NewNopLogger())
+	require.NoError(t, err)
+	require.NoError(t, idx.indexBlocks(ctx))
+	assert.Equal(t, uint64(3), idx.IndexedHeight())
+
+	tx := data[1].Txs[1]
+	res, err := idx.TxByHash(ctx, TxHash(tx))
+	require.NoError(t, err)
+	assert.Equal(t, []byte(tx), res.Tx)
+	assert.Equal(t, uint64(2), res.Height)
+	assert.Equal(t, uint32(1), res.Index)
+	require.Len(t, res.Events, 1)
+	assert.Equal(t, "transfer", res.Events[0].Type)
+
+	_, err = idx.TxByHash(ctx, TxHash([]byte("unknown")))
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// the indexed height is restored
+	restored, err := NewIndexer(ctx, db, s, exec, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), restored.IndexedHeight())
+}
+
+func TestIndexer_TxSearch(t *testing.T) {
+	ctx := context.Background()
+	s, data := setupStore(t, 12, 3)
+	db, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+
+	idx, err := NewIndexer(ctx, db, s, eventExecutor{DummyExecutor: coreexecutor.NewDummyExecutor(), txsPerBlock: 3}, log.NewNopLogger())
+	require.NoError(t, err)
+	require.NoError(t, idx.indexBlocks(ctx))
+
+	// height 1 must not match heights 10 to 12
+	txs, total, err := idx.TxSearch(ctx, "tx.height=1", 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	require.Len(t, txs, 3)
+	for i, tx := range txs {
+		assert.Equal(t, []byte(data[0].Txs[i]), tx.Tx)
+	}
+
+	txs, total, err = idx.TxSearch(ctx, "transfer.sender='addr/11' AND transfer.index=2", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, txs, 1)
+	assert.Equal(t, []byte(data[10].Txs[2]), txs[0].Tx)
+
+	// pagination, ordered by height and index
+	txs, total, err = idx.TxSearch(ctx, "transfer.index=0", 2, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 12, total)
+	require.Len(t, txs, 5)
+	assert.Equal(t, uint64(6), txs[0].Height)
+	assert.Equal(t, uint64(10), txs[4].Height)
+
+	_, _, err = idx.TxSearch(ctx, "sender", 1, 10)
+	assert.ErrorIs(t, err, ErrInvalidQuery)
+}
+
+func TestIndexer_WithoutEvents(t *testing.T) {
+	ctx := context.Background()
+	s, data := setupStore(t, 1, 1)
+	db, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+
+	idx, err := NewIndexer(ctx, db, s, coreexecutor.NewDummyExecutor(), log.NewNopLogger())
+	require.NoError(t, err)
+	require.NoError(t, idx.indexBlocks(ctx))
+
+	res, err := idx.TxByHash(ctx, TxHash(data[0].Txs[0]))
+	require.NoError(t, err)
+	assert.Empty(t, res.Events)
+}
+
+func TestIndexer_TxResults(t *testing.T) {
+	ctx := context.Background()
+	s, data := setupStore(t, 3, 2)
+	db, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+
+	require.NoError(t, SaveTxResults(ctx, s, 1, nil, []coreexecutor.TxResult{
+		{Index: 0, GasUsed: 21000},
+		{Index: 1, Code: 5, Log: "out of gas", GasUsed: 50000},
+	}))
+	// results not matching the transactions are ignored
+	require.NoError(t, SaveTxResults(ctx, s, 2, nil, []coreexecutor.TxResult{{Index: 0, Code: 1}}))
+
+	idx, err := NewIndexer(ctx, db, s, coreexecutor.NewDummyExecutor(), log.NewNopLogger())
+	require.NoError(t, err)
+	require.NoError(t, idx.indexBlocks(ctx))
+
+	res, err := idx.TxByHash(ctx, TxHash(data[0].Txs[1]))
+	require.NoError(t, err)
+	assert.Equal(t, uint32(5), res.Code)
+	assert.Equal(t, "out of gas", res.Log)
+	assert.Equal(t, uint64(50000), res.GasUsed)
+
+	for _, tx := range []types.Tx{data[1].Txs[0], data[2].Txs[0]} {
+		res, err = idx.TxByHash(ctx, TxHash(tx))
+		require.NoError(t, err)
+		assert.Zero(t, res.Code)
+		assert.Zero(t, res.GasUsed)
+	}
+}
+
+func TestRollback(t *testing.T) {
+	ctx := context.Background()
+	s, data := setupStore(t, 4, 2)
+	db, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+
+	exec := eventExecutor{DummyExecutor: coreexecutor.NewDummyExecutor(), txsPerBlock: 2}
+	idx, err := NewIndexer(ctx, db, s, exec, log.NewNopLogger())
+	require.NoError(t, err)
+	require.NoError(t, idx.indexBlocks(ctx))
+
+	require.NoError(t, Rollback(ctx, db, 2))
+	restored, err := NewIndexer(ctx, db, s, exec, log.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), restored.IndexedHeight())
+
+	_, err = restored.TxByHash(ctx, TxHash(data[1].Txs[0]))
+	assert.NoError(t, err)
+	_, err = restored.TxByHash(ctx, TxHash(data[2].Txs[0]))
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, total, err := restored.TxSearch(ctx, "transfer.sender='addr/3'", 1, 10)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	_, total, err = restored.TxSearch(ctx, "transfer.index=0", 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+}
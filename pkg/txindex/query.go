@@ -0,0 +1,65 @@
+package txindex
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// TxHeightKey is the query key matching the height of the block including a transaction.
+const TxHeightKey = "tx.height"
+
+// ErrInvalidQuery is returned for malformed search queries.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// condition matches transactions having an event attribute, or a height, equal to value.
+// Event attributes are referred to as "<event type>.<attribute key>".
+type condition struct {
+	key   string
+	value string
+}
+
+// parseQuery parses a query made of conditions joined by AND, e.g. "tx.height=5 AND transfer.sender='alice'".
+// Values may be enclosed in single quotes.
+func parseQuery(query string) ([]condition, error) {
+	var conditions []condition
+	for _, part := range strings.Split(query, " AND ") {
+		key, value, ok := strings.Cut(part, "=")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			value = value[1 : len(value)-1]
+		}
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("%w: malformed condition %q", ErrInvalidQuery, strings.TrimSpace(part))
+		}
+		if !strings.Contains(key, ".") {
+			return nil, fmt.Errorf("%w: key %q is not of the form <type>.<attribute>", ErrInvalidQuery, key)
+		}
+		if key == TxHeightKey {
+			if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+				return nil, fmt.Errorf("%w: invalid height %q", ErrInvalidQuery, value)
+			}
+		}
+		conditions = append(conditions, condition{key: key, value: value})
+	}
+	return conditions, nil
+}
+
+// matches reports whether the transaction satisfies the condition.
+func (c condition) matches(tx *pb.TxResult) bool {
+	if c.key == TxHeightKey {
+		return strconv.FormatUint(tx.Height, 10) == c.value
+	}
+	for _, event := range tx.Events {
+		for _, attr := range event.Attributes {
+			if event.Type+"."+attr.Key == c.key && attr.Value == c.value {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,143 @@
+package txindex
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	ds "github.com/ipfs/go-datastore"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/pkg/store"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+const (
+	// TxResultsKeyPrefix is the prefix of the store metadata keys of the execution results of the transactions of
+	// a block.
+	TxResultsKeyPrefix = "tx-results"
+	// TxPositionKeyPrefix is the prefix of the store metadata keys of the height and index of the transactions
+	// whose execution results are stored, by transaction hash.
+	TxPositionKeyPrefix = "tx-position"
+)
+
+// SaveTxResults persists the execution results of the transactions of the block at the given height in the
+// store metadata, so that they are served as receipts and indexed with the transactions. The transactions are
+// also recorded by hash, so that their receipts are found by hash without the transaction index.
+func SaveTxResults(ctx context.Context, s store.Store, height uint64, txs [][]byte, results []coreexecutor.TxResult) error {
+	bz, err := json.Marshal(results)
+	if err != nil {
+		return fmt.Errorf("failed to encode transaction results of block %d: %w", height, err)
+	}
+	if err := s.SetMetadata(ctx, txResultsKey(height), bz); err != nil {
+		return fmt.Errorf("failed to save transaction results of block %d: %w", height, err)
+	}
+	for i, tx := range txs {
+		position := binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint64(nil, height), uint32(i)) //nolint:gosec // number of transactions in a block is bounded by the block size
+		if err := s.SetMetadata(ctx, txPositionKey(TxHash(tx)), position); err != nil {
+			return fmt.Errorf("failed to save position of transaction %d of block %d: %w", i, height, err)
+		}
+	}
+	return nil
+}
+
+// TxReceipt returns the transaction at the given index of the block at the given height, along with its
+// execution result if it is known. It does not require the transaction index.
+func TxReceipt(ctx context.Context, s store.Store, height uint64, index uint32) (*pb.TxResult, error) {
+	_, data, err := s.GetBlockData(ctx, height)
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load block %d: %w", height, err)
+	}
+	if int(index) >= len(data.Txs) {
+		return nil, ErrNotFound
+	}
+	results, err := loadTxResults(ctx, s, height)
+	if err != nil {
+		return nil, err
+	}
+	var result *coreexecutor.TxResult
+	if len(results) == len(data.Txs) {
+		result = &results[index]
+	}
+	return newTxResult(data.Txs[index], height, int(index), result), nil
+}
+
+// TxReceiptByHash returns the transaction with the given hash along with its execution result, for transactions
+// whose results were saved by SaveTxResults. It does not require the transaction index.
+func TxReceiptByHash(ctx context.Context, s store.Store, hash []byte) (*pb.TxResult, error) {
+	position, err := s.GetMetadata(ctx, txPositionKey(hash))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(position) != 12 {
+		return nil, fmt.Errorf("invalid transaction position length: %d", len(position))
+	}
+	res, err := TxReceipt(ctx, s, binary.BigEndian.Uint64(position), binary.BigEndian.Uint32(position[8:]))
+	if err != nil {
+		return nil, err
+	}
+	// the block may have been rolled back and produced again without the transaction
+	if !bytes.Equal(res.Hash, hash) {
+		return nil, ErrNotFound
+	}
+	return res, nil
+}
+
+// loadTxResults returns the execution results of the transactions of the block at the given height, or nil if
+// they are unknown.
+func loadTxResults(ctx context.Context, s store.Store, height uint64) ([]coreexecutor.TxResult, error) {
+	bz, err := s.GetMetadata(ctx, txResultsKey(height))
+	if errors.Is(err, ds.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var results []coreexecutor.TxResult
+	if err := json.Unmarshal(bz, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction results: %w", err)
+	}
+	return results, nil
+}
+
+// newTxResult returns the transaction at the given index of the block at the given height, along with its
+// execution result if it is not nil.
+func newTxResult(tx []byte, height uint64, index int, result *coreexecutor.TxResult) *pb.TxResult {
+	res := &pb.TxResult{
+		Hash:   TxHash(tx),
+		Height: height,
+		Index:  uint32(index), //nolint:gosec // number of transactions in a block is bounded by the block size
+		Tx:     tx,
+	}
+	if result != nil {
+		res.Code = result.Code
+		res.Log = result.Log
+		res.GasUsed = result.GasUsed
+		if len(result.Events) > 0 {
+			res.Events = eventsToProto(result.Events)
+		}
+	}
+	return res
+}
+
+// txResultsKey returns the store metadata key of the execution results of the transactions of the block at the
+// given height.
+func txResultsKey(height uint64) string {
+	return TxResultsKeyPrefix + "/" + strconv.FormatUint(height, 10)
+}
+
+// txPositionKey returns the store metadata key of the height and index of the transaction with the given hash.
+func txPositionKey(hash []byte) string {
+	return TxPositionKeyPrefix + "/" + hex.EncodeToString(hash)
+}
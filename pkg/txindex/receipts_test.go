@@ -0,0 +1,65 @@
+package txindex
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/types"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+func TestTxReceipts(t *testing.T) {
+	ctx := context.Background()
+	s, data := setupStore(t, 2, 2)
+	transfer := coreexecutor.Event{Type: "transfer", Attributes: []coreexecutor.EventAttribute{{Key: "sender", Value: "alice"}}}
+	require.NoError(t, SaveTxResults(ctx, s, 1, txsOf(data[0]), []coreexecutor.TxResult{
+		{Index: 0, GasUsed: 21000, Events: []coreexecutor.Event{transfer}},
+		{Index: 1, Code: 5, Log: "out of gas", GasUsed: 50000},
+	}))
+
+	receipt, err := TxReceiptByHash(ctx, s, TxHash(data[0].Txs[1]))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), receipt.Height)
+	assert.Equal(t, uint32(1), receipt.Index)
+	assert.Equal(t, []byte(data[0].Txs[1]), receipt.Tx)
+	assert.Equal(t, uint32(5), receipt.Code)
+	assert.Equal(t, "out of gas", receipt.Log)
+	assert.Equal(t, uint64(50000), receipt.GasUsed)
+
+	receipt, err = TxReceipt(ctx, s, 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(21000), receipt.GasUsed)
+	assert.Equal(t, []*pb.Event{{Type: "transfer", Attributes: []*pb.EventAttribute{{Key: "sender", Value: "alice"}}}}, receipt.Events)
+
+	// transactions of blocks without results are served without result
+	receipt, err = TxReceipt(ctx, s, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(data[1].Txs[1]), receipt.Tx)
+	assert.Zero(t, receipt.GasUsed)
+	_, err = TxReceiptByHash(ctx, s, TxHash(data[1].Txs[1]))
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = TxReceipt(ctx, s, 1, 2)
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = TxReceipt(ctx, s, 3, 0)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	t.Run("transactions of blocks produced again are not found", func(t *testing.T) {
+		header, d := types.GetRandomBlock(1, 2, "test-chain")
+		require.NoError(t, s.SaveBlockData(ctx, header, d, &header.Signature))
+		_, err := TxReceiptByHash(ctx, s, TxHash(data[0].Txs[1]))
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func txsOf(data *types.Data) [][]byte {
+	txs := make([][]byte, len(data.Txs))
+	for i, tx := range data.Txs {
+		txs[i] = tx
+	}
+	return txs
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rollkit/go-execution-evm"
@@ -14,7 +13,6 @@ import (
 	rollcmd "github.com/rollkit/rollkit/pkg/cmd"
 	rollconf "github.com/rollkit/rollkit/pkg/config"
 	"github.com/rollkit/rollkit/pkg/p2p"
-	"github.com/rollkit/rollkit/pkg/p2p/key"
 	"github.com/rollkit/rollkit/pkg/store"
 	"github.com/rollkit/rollkit/sequencers/based"
 	"github.com/spf13/cobra"
@@ -115,7 +113,16 @@ func NewExtendedRunNodeCmd(ctx context.Context) *cobra.Command {
 				if err != nil {
 					return fmt.Errorf("failed to create DA client: %w", err)
 				}
-				rollDA = &client.DA
+				rollDA, err = rollcmd.MultiplexDA(nodeConfig.DA, &client.DA, func(address string) (coreda.DA, error) {
+					client, err := jsonrpc.NewClient(ctx, logger, address, nodeConfig.DA.AuthToken, nodeConfig.DA.Namespace)
+					if err != nil {
+						return nil, err
+					}
+					return &client.DA, nil
+				})
+				if err != nil {
+					return fmt.Errorf("failed to create DA client: %w", err)
+				}
 			} else {
 				rollDA = coreda.NewDummyDA(100_000, 0, 0)
 			}
@@ -131,7 +138,7 @@ func NewExtendedRunNodeCmd(ctx context.Context) *cobra.Command {
 				basedDA = coreda.NewDummyDA(100_000, 0, 0)
 			}
 
-			datastore, err := store.NewDefaultKVStore(nodeConfig.RootDir, nodeConfig.DBPath, "based")
+			datastore, err := store.NewKVStore(nodeConfig, "based")
 			if err != nil {
 				return fmt.Errorf("failed to create datastore: %w", err)
 			}
@@ -157,7 +164,7 @@ func NewExtendedRunNodeCmd(ctx context.Context) *cobra.Command {
 				}
 			}
 
-			nodeKey, err := key.LoadNodeKey(filepath.Dir(nodeConfig.ConfigPath()))
+			nodeKey, err := rollcmd.LoadNodeKey(cmd, nodeConfig)
 			if err != nil {
 				return fmt.Errorf("failed to load node key: %w", err)
 			}
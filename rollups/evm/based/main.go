@@ -31,6 +31,9 @@ func main() {
 		rollcmd.VersionCmd,
 		cmd.InitCmd(),
 		rollcmd.NetInfoCmd,
+		rollcmd.LogLevelCmd,
+		rollcmd.NewDebugBundleCmd(),
+		rollcmd.NewKeysCmd(),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -36,12 +36,12 @@ func InitCmd() *cobra.Command {
 				return fmt.Errorf("error validating config: %w", err)
 			}
 
-			passphrase, err := cmd.Flags().GetString(rollconf.FlagSignerPassphrase)
+			passphrase, err := rollcmd.SignerPassphrase(cmd)
 			if err != nil {
-				return fmt.Errorf("error reading passphrase flag: %w", err)
+				return err
 			}
 
-			proposerAddress, err := rollcmd.CreateSigner(&cfg, homePath, passphrase)
+			proposerAddress, err := rollcmd.CreateSigner(&cfg, homePath, string(passphrase))
 			if err != nil {
 				return err
 			}
@@ -50,7 +50,11 @@ func InitCmd() *cobra.Command {
 				return fmt.Errorf("error writing rollkit.yaml file: %w", err)
 			}
 
-			if err := rollcmd.LoadOrGenNodeKey(homePath); err != nil {
+			nodeKeyPassphrase, err := rollcmd.NodeKeyPassphrase(cmd)
+			if err != nil {
+				return err
+			}
+			if err := rollcmd.LoadOrGenNodeKey(homePath, nodeKeyPassphrase); err != nil {
 				return err
 			}
 
@@ -61,7 +65,7 @@ func InitCmd() *cobra.Command {
 			}
 
 			// Initialize genesis without app state
-			err = rollgenesis.CreateGenesis(homePath, chainID, 1, proposerAddress)
+			err = rollgenesis.CreateGenesis(homePath, chainID, 1, proposerAddress, cfg.Signer.SignatureScheme)
 			if errors.Is(err, rollgenesis.ErrGenesisExists) {
 				// check if existing genesis file is valid
 				if genesis, err := rollgenesis.LoadGenesis(homePath); err == nil {
@@ -3,7 +3,6 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 
 	"github.com/rollkit/rollkit/da/jsonrpc"
 	"github.com/rollkit/rollkit/sequencers/single"
@@ -15,11 +14,11 @@ import (
 
 	evm "github.com/rollkit/go-execution-evm"
 
+	coreda "github.com/rollkit/rollkit/core/da"
 	"github.com/rollkit/rollkit/core/execution"
 	rollcmd "github.com/rollkit/rollkit/pkg/cmd"
 	"github.com/rollkit/rollkit/pkg/config"
 	"github.com/rollkit/rollkit/pkg/p2p"
-	"github.com/rollkit/rollkit/pkg/p2p/key"
 	"github.com/rollkit/rollkit/pkg/store"
 )
 
@@ -54,8 +53,18 @@ var RunCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		da, err := rollcmd.MultiplexDA(nodeConfig.DA, &daJrpc.DA, func(address string) (coreda.DA, error) {
+			client, err := jsonrpc.NewClient(context.Background(), logger, address, nodeConfig.DA.AuthToken, nodeConfig.DA.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			return &client.DA, nil
+		})
+		if err != nil {
+			return err
+		}
 
-		datastore, err := store.NewDefaultKVStore(nodeConfig.RootDir, nodeConfig.DBPath, "evm-single")
+		datastore, err := store.NewKVStore(nodeConfig, "evm-single")
 		if err != nil {
 			return err
 		}
@@ -69,7 +78,7 @@ var RunCmd = &cobra.Command{
 			context.Background(),
 			logger,
 			datastore,
-			&daJrpc.DA,
+			da,
 			[]byte(nodeConfig.ChainID),
 			nodeConfig.Node.BlockTime.Duration,
 			singleMetrics,
@@ -79,7 +88,7 @@ var RunCmd = &cobra.Command{
 			return err
 		}
 
-		nodeKey, err := key.LoadNodeKey(filepath.Dir(nodeConfig.ConfigPath()))
+		nodeKey, err := rollcmd.LoadNodeKey(cmd, nodeConfig)
 		if err != nil {
 			return err
 		}
@@ -89,7 +98,7 @@ var RunCmd = &cobra.Command{
 			return err
 		}
 
-		return rollcmd.StartNode(logger, cmd, executor, sequencer, &daJrpc.DA, nodeKey, p2pClient, datastore, nodeConfig)
+		return rollcmd.StartNode(logger, cmd, executor, sequencer, da, nodeKey, p2pClient, datastore, nodeConfig)
 	},
 }
 
@@ -26,7 +26,10 @@ func main() {
 		cmd.RunCmd,
 		rollcmd.VersionCmd,
 		rollcmd.NetInfoCmd,
+		rollcmd.LogLevelCmd,
+		rollcmd.NewDebugBundleCmd(),
 		rollcmd.StoreUnsafeCleanCmd,
+		rollcmd.NewKeysCmd(),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"context"
+
+	"cosmossdk.io/log"
+	"github.com/spf13/cobra"
+
+	coreda "github.com/rollkit/rollkit/core/da"
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	"github.com/rollkit/rollkit/da/jsonrpc"
+	rollcmd "github.com/rollkit/rollkit/pkg/cmd"
+	"github.com/rollkit/rollkit/pkg/config"
+	kvexecutor "github.com/rollkit/rollkit/rollups/testapp/kv"
+)
+
+// ReplayCmd returns a command re-executing the testapp blocks and verifying the state roots.
+func ReplayCmd() *cobra.Command {
+	return rollcmd.NewReplayCmd(func(nodeConfig config.Config) (coreexecutor.Executor, error) {
+		return kvexecutor.NewKVExecutor(nodeConfig.RootDir, nodeConfig.DBPath)
+	}, func(ctx context.Context, nodeConfig config.Config, logger log.Logger) (coreda.DA, error) {
+		daJrpc, err := jsonrpc.NewClient(ctx, logger, nodeConfig.DA.Address, nodeConfig.DA.AuthToken, nodeConfig.DA.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		return rollcmd.MultiplexDA(nodeConfig.DA, &daJrpc.DA, func(address string) (coreda.DA, error) {
+			client, err := jsonrpc.NewClient(ctx, logger, address, nodeConfig.DA.AuthToken, nodeConfig.DA.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			return &client.DA, nil
+		})
+	}, "testapp")
+}
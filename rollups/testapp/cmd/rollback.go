@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	coreexecutor "github.com/rollkit/rollkit/core/execution"
+	rollcmd "github.com/rollkit/rollkit/pkg/cmd"
+	"github.com/rollkit/rollkit/pkg/config"
+	kvexecutor "github.com/rollkit/rollkit/rollups/testapp/kv"
+)
+
+// RollbackCmd returns a command rolling back the testapp chain state to a prior height.
+func RollbackCmd() *cobra.Command {
+	return rollcmd.NewRollbackCmd(func(nodeConfig config.Config) (coreexecutor.Executor, error) {
+		return kvexecutor.NewKVExecutor(nodeConfig.RootDir, nodeConfig.DBPath)
+	}, "testapp")
+}
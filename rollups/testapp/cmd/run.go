@@ -3,17 +3,18 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"path/filepath"
 
 	"cosmossdk.io/log"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 
+	coreda "github.com/rollkit/rollkit/core/da"
+	coresequencer "github.com/rollkit/rollkit/core/sequencer"
 	"github.com/rollkit/rollkit/da/jsonrpc"
 	rollcmd "github.com/rollkit/rollkit/pkg/cmd"
 	"github.com/rollkit/rollkit/pkg/config"
 	"github.com/rollkit/rollkit/pkg/p2p"
-	"github.com/rollkit/rollkit/pkg/p2p/key"
+	grpcsequencer "github.com/rollkit/rollkit/pkg/sequencer/grpc"
 	"github.com/rollkit/rollkit/pkg/store"
 	kvexecutor "github.com/rollkit/rollkit/rollups/testapp/kv"
 	"github.com/rollkit/rollkit/sequencers/single"
@@ -60,13 +61,23 @@ var RunCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
+		da, err := rollcmd.MultiplexDA(nodeConfig.DA, &daJrpc.DA, func(address string) (coreda.DA, error) {
+			client, err := jsonrpc.NewClient(ctx, logger, address, nodeConfig.DA.AuthToken, nodeConfig.DA.Namespace)
+			if err != nil {
+				return nil, err
+			}
+			return &client.DA, nil
+		})
+		if err != nil {
+			return err
+		}
 
-		nodeKey, err := key.LoadNodeKey(filepath.Dir(nodeConfig.ConfigPath()))
+		nodeKey, err := rollcmd.LoadNodeKey(cmd, nodeConfig)
 		if err != nil {
 			return err
 		}
 
-		datastore, err := store.NewDefaultKVStore(nodeConfig.RootDir, nodeConfig.DBPath, "testapp")
+		datastore, err := store.NewKVStore(nodeConfig, "testapp")
 		if err != nil {
 			return err
 		}
@@ -87,16 +98,22 @@ var RunCmd = &cobra.Command{
 			}
 		}
 
-		sequencer, err := single.NewSequencer(
-			ctx,
-			logger,
-			datastore,
-			&daJrpc.DA,
-			[]byte(nodeConfig.ChainID),
-			nodeConfig.Node.BlockTime.Duration,
-			singleMetrics,
-			nodeConfig.Node.Aggregator,
-		)
+		// batches are sourced from an external sequencer network if configured, and from the local sequencer otherwise
+		var sequencer coresequencer.Sequencer
+		if nodeConfig.Node.SequencerAddress != "" {
+			sequencer, err = grpcsequencer.NewSequencer(nodeConfig.Node.SequencerAddress)
+		} else {
+			sequencer, err = single.NewSequencer(
+				ctx,
+				logger,
+				datastore,
+				da,
+				[]byte(nodeConfig.ChainID),
+				nodeConfig.Node.BlockTime.Duration,
+				singleMetrics,
+				nodeConfig.Node.Aggregator,
+			)
+		}
 		if err != nil {
 			return err
 		}
@@ -106,6 +123,6 @@ var RunCmd = &cobra.Command{
 			return err
 		}
 
-		return rollcmd.StartNode(logger, cmd, executor, sequencer, &daJrpc.DA, nodeKey, p2pClient, datastore, nodeConfig)
+		return rollcmd.StartNode(logger, cmd, executor, sequencer, da, nodeKey, p2pClient, datastore, nodeConfig)
 	},
 }
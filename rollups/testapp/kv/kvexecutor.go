@@ -5,17 +5,23 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/query"
+
+	"github.com/rollkit/rollkit/core/execution"
 	"github.com/rollkit/rollkit/pkg/store"
 )
 
 var (
 	genesisInitializedKey = ds.NewKey("/genesis/initialized")
 	genesisStateRootKey   = ds.NewKey("/genesis/stateroot")
+	finalizedHeightKey    = ds.NewKey("/finalizedHeight")
+	// historyPrefix is the prefix of the values overwritten by each block, used to roll back blocks
+	historyPrefix = ds.NewKey("/history")
 	// Define a buffer size for the transaction channel
 	txChannelBufferSize = 10000
 )
@@ -70,9 +76,8 @@ func (k *KVExecutor) computeStateRoot(ctx context.Context) ([]byte, error) {
 		if result.Error != nil {
 			return nil, fmt.Errorf("error iterating query results: %w", result.Error)
 		}
-		// Exclude reserved genesis keys from the state root calculation
-		dsKey := ds.NewKey(result.Key)
-		if dsKey.Equal(genesisInitializedKey) || dsKey.Equal(genesisStateRootKey) {
+		// Exclude reserved keys from the state root calculation
+		if isReservedKey(ds.NewKey(result.Key)) {
 			continue
 		}
 		keys = append(keys, result.Key)
@@ -91,6 +96,18 @@ func (k *KVExecutor) computeStateRoot(ctx context.Context) ([]byte, error) {
 	return []byte(sb.String()), nil
 }
 
+// isReservedKey reports whether the key holds executor metadata rather than application state. Reserved keys
+// cannot be written by transactions and are not part of the state root.
+func isReservedKey(key ds.Key) bool {
+	return key.Equal(genesisInitializedKey) || key.Equal(genesisStateRootKey) || key.Equal(finalizedHeightKey) ||
+		historyPrefix.IsAncestorOf(key)
+}
+
+// historyKey returns the key of the value of key before it was overwritten by the block at given height.
+func historyKey(blockHeight uint64, key ds.Key) ds.Key {
+	return historyPrefix.ChildString(fmt.Sprintf("%020d", blockHeight)).Child(key)
+}
+
 // InitChain initializes the chain state with genesis parameters.
 // It checks the database to see if genesis was already performed.
 // If not, it computes the state root from the current DB state and persists genesis info.
@@ -187,26 +204,15 @@ func (k *KVExecutor) ExecuteTxs(ctx context.Context, txs [][]byte, blockHeight u
 		return nil, 0, fmt.Errorf("failed to create database batch: %w", err)
 	}
 
-	// Process transactions and stage them in the batch
+	// Process transactions and stage them in the batch, recording the values they overwrite
+	recorded := make(map[ds.Key]bool)
 	for _, tx := range txs {
-		parts := strings.SplitN(string(tx), "=", 2)
-		if len(parts) != 2 {
-			return nil, 0, errors.New("malformed transaction; expected format key=value")
-		}
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
-		if key == "" {
-			return nil, 0, errors.New("empty key in transaction")
-		}
-		dsKey := ds.NewKey(key)
-		// Prevent writing reserved keys via transactions
-		if dsKey.Equal(genesisInitializedKey) || dsKey.Equal(genesisStateRootKey) {
-			return nil, 0, fmt.Errorf("transaction attempts to modify reserved key: %s", key)
-		}
-		err = batch.Put(ctx, dsKey, []byte(value))
+		key, value, err := parseTx(tx)
 		if err != nil {
-			// This error is unlikely for Put unless the context is cancelled.
-			return nil, 0, fmt.Errorf("failed to stage put operation in batch for key '%s': %w", key, err)
+			return nil, 0, err
+		}
+		if err := k.stageTx(ctx, batch, blockHeight, recorded, key, value); err != nil {
+			return nil, 0, err
 		}
 	}
 
@@ -240,7 +246,148 @@ func (k *KVExecutor) SetFinal(ctx context.Context, blockHeight uint64) error {
 		return errors.New("invalid blockHeight: cannot be zero")
 	}
 
-	return k.db.Put(ctx, ds.NewKey("/finalizedHeight"), []byte(fmt.Sprintf("%d", blockHeight)))
+	return k.db.Put(ctx, finalizedHeightKey, []byte(fmt.Sprintf("%d", blockHeight)))
+}
+
+// recordHistory stages the current value of key in the batch, so that the block at given height can be rolled
+// back. The first byte of the recorded value tells whether the key existed. A block executed again keeps the
+// values recorded by its first execution.
+func (k *KVExecutor) recordHistory(ctx context.Context, batch ds.Batch, blockHeight uint64, key ds.Key) error {
+	hKey := historyKey(blockHeight, key)
+	exists, err := k.db.Has(ctx, hKey)
+	if err != nil {
+		return fmt.Errorf("failed to check history of key '%s': %w", key, err)
+	}
+	if exists {
+		return nil
+	}
+	previous := []byte{0}
+	value, err := k.db.Get(ctx, key)
+	switch {
+	case errors.Is(err, ds.ErrNotFound):
+	case err != nil:
+		return fmt.Errorf("failed to get value for key '%s': %w", key, err)
+	default:
+		previous = append([]byte{1}, value...)
+	}
+	if err := batch.Put(ctx, hKey, previous); err != nil {
+		return fmt.Errorf("failed to stage history of key '%s' in batch: %w", key, err)
+	}
+	return nil
+}
+
+// Rollback restores the values overwritten by the blocks above given height, and returns the state root after
+// the block at given height.
+func (k *KVExecutor) Rollback(ctx context.Context, blockHeight uint64) ([]byte, error) {
+	results, err := k.db.Query(ctx, query.Query{Prefix: historyPrefix.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+	type change struct {
+		height   uint64
+		key      ds.Key
+		previous []byte
+	}
+	var changes []change
+	for result := range results.Next() {
+		if result.Error != nil {
+			results.Close()
+			return nil, fmt.Errorf("error iterating history: %w", result.Error)
+		}
+		// history keys are /history/<height>/<key>
+		namespaces := ds.NewKey(result.Key).List()
+		if len(namespaces) < 3 || len(result.Value) == 0 {
+			results.Close()
+			return nil, fmt.Errorf("malformed history entry '%s'", result.Key)
+		}
+		height, err := strconv.ParseUint(namespaces[1], 10, 64)
+		if err != nil {
+			results.Close()
+			return nil, fmt.Errorf("malformed history entry '%s': %w", result.Key, err)
+		}
+		if height > blockHeight {
+			changes = append(changes, change{height: height, key: ds.KeyWithNamespaces(namespaces[2:]), previous: result.Value})
+		}
+	}
+	results.Close()
+
+	// restore the values from the highest block down, so that each key ends up with its value before the
+	// first rolled back block that overwrote it
+	sort.SliceStable(changes, func(i, j int) bool { return changes[i].height > changes[j].height })
+	batch, err := k.db.Batch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database batch: %w", err)
+	}
+	for _, c := range changes {
+		if c.previous[0] == 0 {
+			err = batch.Delete(ctx, c.key)
+		} else {
+			err = batch.Put(ctx, c.key, c.previous[1:])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to stage restore of key '%s' in batch: %w", c.key, err)
+		}
+		if err := batch.Delete(ctx, historyKey(c.height, c.key)); err != nil {
+			return nil, fmt.Errorf("failed to stage history deletion in batch: %w", err)
+		}
+	}
+	if err := batch.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit rollback batch: %w", err)
+	}
+	return k.computeStateRoot(ctx)
+}
+
+// Query returns the value of the key given as path after the block at given height. The value is the value
+// recorded in the history by the first block above the height which overwrote the key, or the current value if
+// no such block was executed. data is ignored.
+func (k *KVExecutor) Query(ctx context.Context, path string, data []byte, blockHeight uint64) ([]byte, error) {
+	key := ds.NewKey(path)
+	if isReservedKey(key) {
+		return nil, fmt.Errorf("key '%s' is reserved", key)
+	}
+	results, err := k.db.Query(ctx, query.Query{Prefix: historyPrefix.String()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query history: %w", err)
+	}
+	defer results.Close()
+
+	var (
+		found    bool
+		height   uint64
+		previous []byte
+	)
+	for result := range results.Next() {
+		if result.Error != nil {
+			return nil, fmt.Errorf("error iterating history: %w", result.Error)
+		}
+		// history keys are /history/<height>/<key>
+		namespaces := ds.NewKey(result.Key).List()
+		if len(namespaces) < 3 || len(result.Value) == 0 {
+			return nil, fmt.Errorf("malformed history entry '%s'", result.Key)
+		}
+		if !ds.KeyWithNamespaces(namespaces[2:]).Equal(key) {
+			continue
+		}
+		h, err := strconv.ParseUint(namespaces[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("malformed history entry '%s': %w", result.Key, err)
+		}
+		if h > blockHeight && (!found || h < height) {
+			found, height, previous = true, h, result.Value
+		}
+	}
+
+	if !found {
+		value, err := k.db.Get(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get value for key '%s' at height %d: %w", key, blockHeight, err)
+		}
+		return value, nil
+	}
+	if previous[0] == 0 {
+		return nil, fmt.Errorf("failed to get value for key '%s' at height %d: %w", key, blockHeight, ds.ErrNotFound)
+	}
+	return previous[1:], nil
 }
 
 // InjectTx adds a transaction to the mempool channel.
@@ -255,3 +402,109 @@ func (k *KVExecutor) InjectTx(tx []byte) {
 		// Consider adding metrics here
 	}
 }
+
+// CheckTx validates that the transaction is in the format "key=value" and does not modify a reserved key.
+// All transactions have the same priority.
+func (k *KVExecutor) CheckTx(ctx context.Context, tx []byte) (int64, error) {
+	if _, _, err := parseTx(tx); err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
+
+// parseTx parses a transaction in the format "key=value" which does not modify a reserved key.
+func parseTx(tx []byte) (ds.Key, []byte, error) {
+	parts := strings.SplitN(string(tx), "=", 2)
+	if len(parts) != 2 {
+		return ds.Key{}, nil, errors.New("malformed transaction; expected format key=value")
+	}
+	key := strings.TrimSpace(parts[0])
+	if key == "" {
+		return ds.Key{}, nil, errors.New("empty key in transaction")
+	}
+	dsKey := ds.NewKey(key)
+	// Prevent writing reserved keys via transactions
+	if isReservedKey(dsKey) {
+		return ds.Key{}, nil, fmt.Errorf("transaction attempts to modify reserved key: %s", key)
+	}
+	return dsKey, []byte(strings.TrimSpace(parts[1])), nil
+}
+
+// stageTx stages the write of a transaction in the batch of the block at given height, recording the value it
+// overwrites unless the key is already in recorded.
+func (k *KVExecutor) stageTx(ctx context.Context, batch ds.Batch, blockHeight uint64, recorded map[ds.Key]bool, key ds.Key, value []byte) error {
+	if !recorded[key] {
+		if err := k.recordHistory(ctx, batch, blockHeight, key); err != nil {
+			return err
+		}
+		recorded[key] = true
+	}
+	if err := batch.Put(ctx, key, value); err != nil {
+		// This error is unlikely for Put unless the context is cancelled.
+		return fmt.Errorf("failed to stage put operation in batch for key '%s': %w", key, err)
+	}
+	return nil
+}
+
+// BeginBlock starts the execution of a block whose transactions are streamed in chunks. Unlike ExecuteTxs,
+// malformed transactions and transactions writing a reserved key fail individually and the other transactions
+// of the block are applied.
+func (k *KVExecutor) BeginBlock(ctx context.Context, blockHeight uint64, timestamp time.Time, prevStateRoot []byte) (execution.BlockExecution, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	batch, err := k.db.Batch(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create database batch: %w", err)
+	}
+	return &blockExecution{k: k, batch: batch, blockHeight: blockHeight, recorded: make(map[ds.Key]bool)}, nil
+}
+
+// blockExecution stages the transactions of a block streamed to the KVExecutor in a batch, committed once all
+// transactions are executed.
+type blockExecution struct {
+	k           *KVExecutor
+	batch       ds.Batch
+	blockHeight uint64
+	recorded    map[ds.Key]bool
+	// executed is the number of transactions executed so far
+	executed int
+}
+
+// ExecuteTxs stages the next transactions of the block, and reports the invalid ones as failed.
+func (b *blockExecution) ExecuteTxs(ctx context.Context, txs [][]byte) ([]execution.TxResult, error) {
+	results := make([]execution.TxResult, len(txs))
+	for i, tx := range txs {
+		results[i].Index = b.executed + i
+		key, value, err := parseTx(tx)
+		if err != nil {
+			results[i].Code, results[i].Log = 1, err.Error()
+			continue
+		}
+		if err := b.k.stageTx(ctx, b.batch, b.blockHeight, b.recorded, key, value); err != nil {
+			return nil, err
+		}
+	}
+	b.executed += len(txs)
+	return results, nil
+}
+
+// Commit applies the staged transactions and returns the new state root.
+func (b *blockExecution) Commit(ctx context.Context) ([]byte, uint64, error) {
+	if err := b.batch.Commit(ctx); err != nil {
+		return nil, 0, fmt.Errorf("failed to commit transaction batch: %w", err)
+	}
+	stateRoot, err := b.k.computeStateRoot(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to compute state root after executing transactions: %w", err)
+	}
+	return stateRoot, 1024, nil
+}
+
+// Abort discards the staged transactions; the batch is simply never committed.
+func (b *blockExecution) Abort(ctx context.Context) error {
+	return nil
+}
@@ -153,6 +153,120 @@ func TestExecuteTxs_Invalid(t *testing.T) {
 	}
 }
 
+func TestCheckTx(t *testing.T) {
+	exec, err := NewKVExecutor(t.TempDir(), "testdb")
+	if err != nil {
+		t.Fatalf("Failed to create KVExecutor: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := exec.CheckTx(ctx, []byte("key1=value1")); err != nil {
+		t.Errorf("Expected valid transaction to pass CheckTx, got: %v", err)
+	}
+	for _, tx := range []string{"invalidformat", "=value", "/genesis/initialized=true", "/history/1=value"} {
+		if _, err := exec.CheckTx(ctx, []byte(tx)); err == nil {
+			t.Errorf("Expected CheckTx to reject transaction %q", tx)
+		}
+	}
+}
+
+func TestRollback(t *testing.T) {
+	exec, err := NewKVExecutor(t.TempDir(), "testdb")
+	if err != nil {
+		t.Fatalf("Failed to create KVExecutor: %v", err)
+	}
+	ctx := context.Background()
+
+	root1, _, err := exec.ExecuteTxs(ctx, [][]byte{[]byte("key1=value1")}, 1, time.Now(), []byte(""))
+	if err != nil {
+		t.Fatalf("ExecuteTxs failed: %v", err)
+	}
+	if err := exec.SetFinal(ctx, 1); err != nil {
+		t.Fatalf("SetFinal failed: %v", err)
+	}
+	blocks := [][][]byte{
+		{[]byte("key1=value2"), []byte("key2=value2")},
+		{[]byte("key1=value3"), []byte("key1=value4"), []byte("key3=value3")},
+	}
+	for i, txs := range blocks {
+		if _, _, err := exec.ExecuteTxs(ctx, txs, uint64(i+2), time.Now(), nil); err != nil {
+			t.Fatalf("ExecuteTxs failed: %v", err)
+		}
+	}
+
+	stateRoot, err := exec.Rollback(ctx, 1)
+	if err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	if !bytes.Equal(stateRoot, root1) {
+		t.Errorf("Expected state root %s after rollback, got %s", root1, stateRoot)
+	}
+	if value, _ := exec.GetStoreValue(ctx, "key1"); value != "value1" {
+		t.Errorf("Expected key1 to be restored to value1, got %q", value)
+	}
+	if _, exists := exec.GetStoreValue(ctx, "key2"); exists {
+		t.Error("Expected key2 written after the rollback height to be deleted")
+	}
+
+	// transactions cannot overwrite the history used for rollbacks
+	if _, _, err := exec.ExecuteTxs(ctx, [][]byte{[]byte("/history/1/key1=value")}, 2, time.Now(), nil); err == nil {
+		t.Error("Expected error for transaction writing a reserved key, got nil")
+	}
+}
+
+func TestQuery(t *testing.T) {
+	exec, err := NewKVExecutor(t.TempDir(), "testdb")
+	if err != nil {
+		t.Fatalf("Failed to create KVExecutor: %v", err)
+	}
+	ctx := context.Background()
+
+	blocks := [][][]byte{
+		{[]byte("key1=value1")},
+		{[]byte("key1=value2"), []byte("key2=value2")},
+		{[]byte("key1=value3"), []byte("key1=value4"), []byte("key3=value3")},
+	}
+	for i, txs := range blocks {
+		if _, _, err := exec.ExecuteTxs(ctx, txs, uint64(i+1), time.Now(), nil); err != nil {
+			t.Fatalf("ExecuteTxs failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		key    string
+		height uint64
+		value  string
+	}{
+		{"key1", 1, "value1"},
+		{"key1", 2, "value2"},
+		{"key1", 3, "value4"},
+		{"key2", 2, "value2"},
+		{"key2", 3, "value2"},
+		{"key3", 3, "value3"},
+	}
+	for _, tt := range tests {
+		value, err := exec.Query(ctx, tt.key, nil, tt.height)
+		if err != nil {
+			t.Errorf("Query of %s at height %d failed: %v", tt.key, tt.height, err)
+			continue
+		}
+		if string(value) != tt.value {
+			t.Errorf("Expected %s at height %d to be %q, got %q", tt.key, tt.height, tt.value, value)
+		}
+	}
+
+	// keys written after the height did not exist
+	if _, err := exec.Query(ctx, "key2", nil, 1); err == nil {
+		t.Error("Expected error for key written after the height, got nil")
+	}
+	if _, err := exec.Query(ctx, "key3", nil, 2); err == nil {
+		t.Error("Expected error for key written after the height, got nil")
+	}
+	if _, err := exec.Query(ctx, "/history/1/key1", nil, 3); err == nil {
+		t.Error("Expected error for reserved key, got nil")
+	}
+}
+
 func TestSetFinal(t *testing.T) {
 	exec, err := NewKVExecutor(t.TempDir(), "testdb")
 	if err != nil {
@@ -172,3 +286,60 @@ func TestSetFinal(t *testing.T) {
 		t.Error("Expected error for blockHeight 0, got nil")
 	}
 }
+
+func TestBeginBlock_PartialFailure(t *testing.T) {
+	exec, err := NewKVExecutor(t.TempDir(), "testdb")
+	if err != nil {
+		t.Fatalf("Failed to create KVExecutor: %v", err)
+	}
+	ctx := context.Background()
+
+	block, err := exec.BeginBlock(ctx, 1, time.Now(), []byte(""))
+	if err != nil {
+		t.Fatalf("BeginBlock failed: %v", err)
+	}
+	results, err := block.ExecuteTxs(ctx, [][]byte{[]byte("key1=value1"), []byte("invalidformat")})
+	if err != nil {
+		t.Fatalf("ExecuteTxs failed: %v", err)
+	}
+	more, err := block.ExecuteTxs(ctx, [][]byte{[]byte("/finalizedHeight=1"), []byte("key2=value2")})
+	if err != nil {
+		t.Fatalf("ExecuteTxs failed: %v", err)
+	}
+	results = append(results, more...)
+	for i, failed := range []bool{false, true, true, false} {
+		if results[i].Index != i {
+			t.Errorf("Expected result %d to have index %d, got %d", i, i, results[i].Index)
+		}
+		if (results[i].Code != 0) != failed || (results[i].Log != "") != failed {
+			t.Errorf("Expected transaction %d failed=%v, got code %d log %q", i, failed, results[i].Code, results[i].Log)
+		}
+	}
+
+	// nothing is applied before the block is committed
+	if _, exists := exec.GetStoreValue(ctx, "key1"); exists {
+		t.Error("Expected key1 not to be written before commit")
+	}
+	stateRoot, _, err := block.Commit(ctx)
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if string(stateRoot) != "/key1:value1;/key2:value2;" {
+		t.Errorf("Unexpected state root: %s", stateRoot)
+	}
+
+	// an aborted block leaves the state unchanged
+	block, err = exec.BeginBlock(ctx, 2, time.Now(), stateRoot)
+	if err != nil {
+		t.Fatalf("BeginBlock failed: %v", err)
+	}
+	if _, err := block.ExecuteTxs(ctx, [][]byte{[]byte("key1=changed")}); err != nil {
+		t.Fatalf("ExecuteTxs failed: %v", err)
+	}
+	if err := block.Abort(ctx); err != nil {
+		t.Fatalf("Abort failed: %v", err)
+	}
+	if value, _ := exec.GetStoreValue(ctx, "key1"); value != "value1" {
+		t.Errorf("Expected key1 to be unchanged after abort, got %q", value)
+	}
+}
@@ -18,7 +18,16 @@ func main() {
 		cmds.RunCmd,
 		rollcmd.VersionCmd,
 		rollcmd.NetInfoCmd,
+		rollcmd.LogLevelCmd,
+		rollcmd.NewDebugBundleCmd(),
 		rollcmd.StoreUnsafeCleanCmd,
+		rollcmd.NewStoreExportCmd("testapp"),
+		rollcmd.NewStoreImportCmd("testapp"),
+		cmds.RollbackCmd(),
+		cmds.ReplayCmd(),
+		rollcmd.NewImportGenesisCmd(),
+		rollcmd.NewConsistencyCheckCmd(),
+		rollcmd.NewKeysCmd(),
 		initCmd,
 	)
 
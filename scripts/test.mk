@@ -16,6 +16,12 @@ test-e2e: build build-da
 	@go test -mod=readonly -failfast -timeout=15m -tags='e2e' ./test/e2e/... --binary=$(CURDIR)/build/testapp
 .PHONY: test-e2e
 
+## test-failpoints: Running unit tests with failpoints compiled in
+test-failpoints:
+	@echo "--> Running unit tests with failpoints"
+	@go test -mod=readonly -tags='failpoints' ./block/... ./pkg/...
+.PHONY: test-failpoints
+
 ## cover: generate to code coverage report.
 cover:
 	@echo "--> Generating Code Coverage"
@@ -33,6 +33,24 @@ func (_m *Store) Close() error {
 	return r0
 }
 
+// DeleteBlockData provides a mock function with given fields: ctx, height
+func (_m *Store) DeleteBlockData(ctx context.Context, height uint64) error {
+	ret := _m.Called(ctx, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteBlockData")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = rf(ctx, height)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetBlockByHash provides a mock function with given fields: ctx, hash
 func (_m *Store) GetBlockByHash(ctx context.Context, hash []byte) (*types.SignedHeader, *types.Data, error) {
 	ret := _m.Called(ctx, hash)
@@ -111,6 +129,36 @@ func (_m *Store) GetBlockData(ctx context.Context, height uint64) (*types.Signed
 	return r0, r1, r2
 }
 
+// GetDAMetadata provides a mock function with given fields: ctx, height
+func (_m *Store) GetDAMetadata(ctx context.Context, height uint64) (*types.DAMetadata, error) {
+	ret := _m.Called(ctx, height)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDAMetadata")
+	}
+
+	var r0 *types.DAMetadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*types.DAMetadata, error)); ok {
+		return rf(ctx, height)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *types.DAMetadata); ok {
+		r0 = rf(ctx, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.DAMetadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMetadata provides a mock function with given fields: ctx, key
 func (_m *Store) GetMetadata(ctx context.Context, key string) ([]byte, error) {
 	ret := _m.Called(ctx, key)
@@ -257,6 +305,24 @@ func (_m *Store) Height(ctx context.Context) (uint64, error) {
 	return r0, r1
 }
 
+// Rollback provides a mock function with given fields: ctx, height, state, metadata
+func (_m *Store) Rollback(ctx context.Context, height uint64, state types.State, metadata map[string][]byte) error {
+	ret := _m.Called(ctx, height, state, metadata)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rollback")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, types.State, map[string][]byte) error); ok {
+		r0 = rf(ctx, height, state, metadata)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveBlockData provides a mock function with given fields: ctx, header, data, signature
 func (_m *Store) SaveBlockData(ctx context.Context, header *types.SignedHeader, data *types.Data, signature *types.Signature) error {
 	ret := _m.Called(ctx, header, data, signature)
@@ -275,6 +341,24 @@ func (_m *Store) SaveBlockData(ctx context.Context, header *types.SignedHeader,
 	return r0
 }
 
+// SetDAMetadata provides a mock function with given fields: ctx, metadata
+func (_m *Store) SetDAMetadata(ctx context.Context, metadata *types.DAMetadata) error {
+	ret := _m.Called(ctx, metadata)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDAMetadata")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.DAMetadata) error); ok {
+		r0 = rf(ctx, metadata)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetHeight provides a mock function with given fields: ctx, height
 func (_m *Store) SetHeight(ctx context.Context, height uint64) error {
 	ret := _m.Called(ctx, height)
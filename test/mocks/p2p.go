@@ -14,6 +14,24 @@ type P2PRPC struct {
 	mock.Mock
 }
 
+// BanPeer provides a mock function with given fields: id
+func (_m *P2PRPC) BanPeer(id peer.ID) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BanPeer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(peer.ID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetNetworkInfo provides a mock function with no fields
 func (_m *P2PRPC) GetNetworkInfo() (p2p.NetworkInfo, error) {
 	ret := _m.Called()
@@ -72,6 +90,62 @@ func (_m *P2PRPC) GetPeers() ([]peer.AddrInfo, error) {
 	return r0, r1
 }
 
+// NetworkStats provides a mock function with no fields
+func (_m *P2PRPC) NetworkStats() p2p.NetworkStats {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NetworkStats")
+	}
+
+	var r0 p2p.NetworkStats
+	if rf, ok := ret.Get(0).(func() p2p.NetworkStats); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(p2p.NetworkStats)
+	}
+
+	return r0
+}
+
+// PeerScores provides a mock function with no fields
+func (_m *P2PRPC) PeerScores() []p2p.PeerScore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PeerScores")
+	}
+
+	var r0 []p2p.PeerScore
+	if rf, ok := ret.Get(0).(func() []p2p.PeerScore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]p2p.PeerScore)
+		}
+	}
+
+	return r0
+}
+
+// UnbanPeer provides a mock function with given fields: id
+func (_m *P2PRPC) UnbanPeer(id peer.ID) error {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnbanPeer")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(peer.ID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewP2PRPC creates a new instance of P2PRPC. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewP2PRPC(t interface {
@@ -0,0 +1,119 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rollkit/rollkit/pkg/signer"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// ErrInvalidAttestation is returned for block attestations which are malformed or not signed by their attester.
+var ErrInvalidAttestation = errors.New("invalid block attestation")
+
+// BlockAttestation is the signature of an attester, a full node of the configured attester set, attesting that it
+// applied the block with the given hash at the given height. As the hash of a block commits to the previous
+// block, attesting a block attests the chain up to it.
+type BlockAttestation struct {
+	ChainID   string
+	Height    uint64
+	BlockHash Hash
+	Signer    Signer
+	Signature Signature
+}
+
+// SignBytes returns the bytes of the attestation covered by its signature.
+func (a *BlockAttestation) SignBytes() ([]byte, error) {
+	ap, err := a.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	ap.Signature = nil
+	return proto.MarshalOptions{Deterministic: true}.Marshal(ap)
+}
+
+// ValidateBasic checks that the attestation is well formed and signed. It does not check that the signer is an
+// attester.
+func (a *BlockAttestation) ValidateBasic() error {
+	if a.ChainID == "" {
+		return fmt.Errorf("%w: chain ID is empty", ErrInvalidAttestation)
+	}
+	if a.Height == 0 {
+		return fmt.Errorf("%w: height is 0", ErrInvalidAttestation)
+	}
+	if len(a.BlockHash) != 32 {
+		return fmt.Errorf("%w: invalid block hash length %d", ErrInvalidAttestation, len(a.BlockHash))
+	}
+	if a.Signer.PubKey == nil {
+		return fmt.Errorf("%w: missing public key", ErrInvalidAttestation)
+	}
+	if len(a.Signature) == 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidAttestation, ErrSignatureEmpty)
+	}
+	bz, err := a.SignBytes()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidAttestation, err)
+	}
+	verified, err := a.Signer.PubKey.Verify(bz, a.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidAttestation, err)
+	}
+	if !verified {
+		return fmt.Errorf("%w: %w", ErrInvalidAttestation, ErrSignatureVerificationFailed)
+	}
+	return nil
+}
+
+// ToProto converts BlockAttestation into protobuf representation and returns it.
+func (a *BlockAttestation) ToProto() (*pb.BlockAttestation, error) {
+	ap := &pb.BlockAttestation{
+		ChainId:   a.ChainID,
+		Height:    a.Height,
+		BlockHash: a.BlockHash,
+		Signature: a.Signature[:],
+	}
+	if a.Signer.PubKey != nil {
+		var err error
+		if ap.PubKey, err = signer.MarshalPublicKey(a.Signer.PubKey); err != nil {
+			return nil, err
+		}
+	}
+	return ap, nil
+}
+
+// FromProto fills BlockAttestation with data from its protobuf representation.
+func (a *BlockAttestation) FromProto(other *pb.BlockAttestation) error {
+	if other == nil {
+		return errors.New("block attestation is nil")
+	}
+	pubKey, err := signer.UnmarshalPublicKey(other.PubKey)
+	if err != nil {
+		return err
+	}
+	a.ChainID = other.ChainId
+	a.Height = other.Height
+	a.BlockHash = other.BlockHash
+	a.Signer = Signer{PubKey: pubKey, Address: KeyAddress(pubKey)}
+	a.Signature = other.Signature
+	return nil
+}
+
+// MarshalBinary encodes BlockAttestation into binary form and returns it.
+func (a *BlockAttestation) MarshalBinary() ([]byte, error) {
+	ap, err := a.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(ap)
+}
+
+// UnmarshalBinary decodes binary form of BlockAttestation into object.
+func (a *BlockAttestation) UnmarshalBinary(bz []byte) error {
+	var ap pb.BlockAttestation
+	if err := proto.Unmarshal(bz, &ap); err != nil {
+		return err
+	}
+	return a.FromProto(&ap)
+}
@@ -0,0 +1,51 @@
+package types
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signAttestation(t *testing.T, key crypto.PrivKey, a BlockAttestation) BlockAttestation {
+	t.Helper()
+	a.Signer = Signer{PubKey: key.GetPublic(), Address: KeyAddress(key.GetPublic())}
+	bz, err := a.SignBytes()
+	require.NoError(t, err)
+	a.Signature, err = key.Sign(bz)
+	require.NoError(t, err)
+	return a
+}
+
+func TestBlockAttestation(t *testing.T) {
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	hash := GetRandomBytes(32)
+	attestation := signAttestation(t, key, BlockAttestation{ChainID: "test", Height: 10, BlockHash: hash})
+	require.NoError(t, attestation.ValidateBasic())
+
+	bz, err := attestation.MarshalBinary()
+	require.NoError(t, err)
+	var decoded BlockAttestation
+	require.NoError(t, decoded.UnmarshalBinary(bz))
+	require.NoError(t, decoded.ValidateBasic())
+	assert.Equal(t, attestation, decoded)
+
+	tampered := decoded
+	tampered.Height = 11
+	unsigned := decoded
+	unsigned.Signature = nil
+	for name, invalid := range map[string]BlockAttestation{
+		"tampered":           tampered,
+		"unsigned":           unsigned,
+		"no chain ID":        signAttestation(t, key, BlockAttestation{Height: 10, BlockHash: hash}),
+		"no height":          signAttestation(t, key, BlockAttestation{ChainID: "test", BlockHash: hash}),
+		"invalid block hash": signAttestation(t, key, BlockAttestation{ChainID: "test", Height: 10, BlockHash: hash[:8]}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, invalid.ValidateBasic(), ErrInvalidAttestation)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// blobBundlePrefix marks blobs bundling the blobs of several blocks, it is followed by the bundled blobs, each
+// prefixed with its length as an unsigned varint. Like blobEnvelopePrefix, the prefix starts with a zero byte, so
+// a bundle is never mistaken for a single header or batch.
+var blobBundlePrefix = []byte{0x00, 'r', 'k', 'b'}
+
+// ErrInvalidBundle is returned for bundles whose blobs overrun the bundle.
+var ErrInvalidBundle = errors.New("invalid blob bundle")
+
+// BundleBlobs encodes the blobs of several blocks into a single blob, preserving the boundaries of the blobs,
+// so that they are submitted to the DA layer at the cost of a single blob.
+func BundleBlobs(blobs [][]byte) []byte {
+	size := len(blobBundlePrefix)
+	for _, blob := range blobs {
+		size += binary.MaxVarintLen64 + len(blob)
+	}
+	bundle := make([]byte, 0, size)
+	bundle = append(bundle, blobBundlePrefix...)
+	for _, blob := range blobs {
+		bundle = binary.AppendUvarint(bundle, uint64(len(blob)))
+		bundle = append(bundle, blob...)
+	}
+	return bundle
+}
+
+// UnbundleBlob returns the blobs bundled by BundleBlobs, or the blob itself if it is not a bundle.
+func UnbundleBlob(blob []byte) ([][]byte, error) {
+	if !bytes.HasPrefix(blob, blobBundlePrefix) {
+		return [][]byte{blob}, nil
+	}
+	var blobs [][]byte
+	rest := blob[len(blobBundlePrefix):]
+	for len(rest) > 0 {
+		size, n := binary.Uvarint(rest)
+		if n <= 0 || size > uint64(len(rest)-n) {
+			return nil, ErrInvalidBundle
+		}
+		rest = rest[n:]
+		blobs = append(blobs, rest[:size])
+		rest = rest[size:]
+	}
+	return blobs, nil
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleBlobs(t *testing.T) {
+	blobs := [][]byte{GetRandomBytes(10), {}, GetRandomBytes(300)}
+	bundle := BundleBlobs(blobs)
+
+	unbundled, err := UnbundleBlob(bundle)
+	require.NoError(t, err)
+	assert.Equal(t, blobs, unbundled)
+
+	// bundles survive compression
+	compressed, err := CompressBlob(BlobCodecZstd, BundleBlobs([][]byte{make([]byte, 1000), make([]byte, 1000)}))
+	require.NoError(t, err)
+	decompressed, err := DecompressBlob(compressed)
+	require.NoError(t, err)
+	unbundled, err = UnbundleBlob(decompressed)
+	require.NoError(t, err)
+	assert.Len(t, unbundled, 2)
+}
+
+func TestUnbundleBlob(t *testing.T) {
+	// other blobs are returned as is
+	blob := []byte{0x0a, 0x01, 0x02}
+	unbundled, err := UnbundleBlob(blob)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{blob}, unbundled)
+
+	// bundled blobs must not overrun the bundle
+	bundle := BundleBlobs([][]byte{GetRandomBytes(10)})
+	_, err = UnbundleBlob(bundle[:len(bundle)-1])
+	assert.ErrorIs(t, err, ErrInvalidBundle)
+}
@@ -0,0 +1,172 @@
+package types
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// BlobCodec identifies the compression codec of a blob submitted to the DA layer.
+type BlobCodec byte
+
+const (
+	// BlobCodecNone submits blobs uncompressed.
+	BlobCodecNone BlobCodec = iota
+	// BlobCodecGzip compresses blobs with gzip.
+	BlobCodecGzip
+	// BlobCodecZstd compresses blobs with zstd.
+	BlobCodecZstd
+)
+
+// MaxDecompressedBlobSize is the maximum size of a decompressed blob, protecting nodes against decompression bombs.
+const MaxDecompressedBlobSize = 64 * 1024 * 1024
+
+// blobEnvelopePrefix marks compressed blobs, it is followed by the codec and the compressed payload.
+// The prefix starts with a zero byte, which never starts a protobuf message, so a compressed blob is
+// never mistaken for an uncompressed header or batch.
+var blobEnvelopePrefix = []byte{0x00, 'r', 'k', 'c'}
+
+var (
+	// ErrUnknownBlobCodec is returned for blobs compressed with an unsupported codec.
+	ErrUnknownBlobCodec = errors.New("unknown blob codec")
+	// ErrBlobTooLarge is returned when a decompressed blob exceeds MaxDecompressedBlobSize.
+	ErrBlobTooLarge = errors.New("decompressed blob too large")
+)
+
+var (
+	zstdEncoder = sync.OnceValues(func() (*zstd.Encoder, error) {
+		return zstd.NewWriter(nil)
+	})
+	zstdDecoder = sync.OnceValues(func() (*zstd.Decoder, error) {
+		return zstd.NewReader(nil, zstd.WithDecoderMaxMemory(MaxDecompressedBlobSize), zstd.WithDecoderConcurrency(0))
+	})
+)
+
+// ParseBlobCodec parses the name of a blob codec: "none" (or empty), "gzip" or "zstd".
+func ParseBlobCodec(name string) (BlobCodec, error) {
+	switch name {
+	case "", "none":
+		return BlobCodecNone, nil
+	case "gzip":
+		return BlobCodecGzip, nil
+	case "zstd":
+		return BlobCodecZstd, nil
+	default:
+		return BlobCodecNone, fmt.Errorf("%w: %q", ErrUnknownBlobCodec, name)
+	}
+}
+
+// String returns the name of the codec.
+func (c BlobCodec) String() string {
+	switch c {
+	case BlobCodecNone:
+		return "none"
+	case BlobCodecGzip:
+		return "gzip"
+	case BlobCodecZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(c))
+	}
+}
+
+// CompressBlob compresses the blob with the given codec and wraps it in an envelope recording the codec.
+// The blob is returned unchanged if the codec is BlobCodecNone or if compression does not reduce its size.
+func CompressBlob(codec BlobCodec, blob []byte) ([]byte, error) {
+	if codec == BlobCodecNone {
+		return blob, nil
+	}
+	payload, err := compress(codec, blob)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(blobEnvelopePrefix)+1+len(payload) >= len(blob) {
+		return blob, nil
+	}
+	envelope := make([]byte, 0, len(blobEnvelopePrefix)+1+len(payload))
+	envelope = append(envelope, blobEnvelopePrefix...)
+	envelope = append(envelope, byte(codec))
+	return append(envelope, payload...), nil
+}
+
+// compress compresses the blob with the given codec.
+func compress(codec BlobCodec, blob []byte) ([]byte, error) {
+	switch codec {
+	case BlobCodecNone:
+		return blob, nil
+	case BlobCodecGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(blob); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case BlobCodecZstd:
+		enc, err := zstdEncoder()
+		if err != nil {
+			return nil, err
+		}
+		return enc.EncodeAll(blob, nil), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownBlobCodec, codec)
+	}
+}
+
+// DecompressBlob returns the decompressed payload of a blob created by CompressBlob.
+// Blobs without a compression envelope are returned unchanged.
+func DecompressBlob(blob []byte) ([]byte, error) {
+	if !bytes.HasPrefix(blob, blobEnvelopePrefix) || len(blob) <= len(blobEnvelopePrefix) {
+		return blob, nil
+	}
+	codec := BlobCodec(blob[len(blobEnvelopePrefix)])
+	if codec == BlobCodecNone {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownBlobCodec, codec)
+	}
+	return decompress(codec, blob[len(blobEnvelopePrefix)+1:])
+}
+
+// decompress decompresses the payload compressed with the given codec.
+func decompress(codec BlobCodec, payload []byte) ([]byte, error) {
+	switch codec {
+	case BlobCodecNone:
+		return payload, nil
+	case BlobCodecGzip:
+		r, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress gzip blob: %w", err)
+		}
+		defer r.Close() //nolint:errcheck
+		out, err := io.ReadAll(io.LimitReader(r, MaxDecompressedBlobSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress gzip blob: %w", err)
+		}
+		if len(out) > MaxDecompressedBlobSize {
+			return nil, ErrBlobTooLarge
+		}
+		return out, nil
+	case BlobCodecZstd:
+		dec, err := zstdDecoder()
+		if err != nil {
+			return nil, err
+		}
+		out, err := dec.DecodeAll(payload, nil)
+		if errors.Is(err, zstd.ErrDecoderSizeExceeded) || errors.Is(err, zstd.ErrWindowSizeExceeded) {
+			return nil, ErrBlobTooLarge
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress zstd blob: %w", err)
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownBlobCodec, codec)
+	}
+}
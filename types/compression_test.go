@@ -0,0 +1,66 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressBlob(t *testing.T) {
+	compressible := bytes.Repeat([]byte("rollkit transaction "), 100)
+
+	for _, codec := range []BlobCodec{BlobCodecGzip, BlobCodecZstd} {
+		t.Run(codec.String(), func(t *testing.T) {
+			compressed, err := CompressBlob(codec, compressible)
+			require.NoError(t, err)
+			assert.Less(t, len(compressed), len(compressible))
+			assert.Equal(t, byte(codec), compressed[len(blobEnvelopePrefix)])
+
+			decompressed, err := DecompressBlob(compressed)
+			require.NoError(t, err)
+			assert.Equal(t, compressible, decompressed)
+		})
+	}
+
+	// blobs are not wrapped if compression does not help
+	incompressible := GetRandomBytes(64)
+	blob, err := CompressBlob(BlobCodecZstd, incompressible)
+	require.NoError(t, err)
+	assert.Equal(t, incompressible, blob)
+
+	blob, err = CompressBlob(BlobCodecNone, compressible)
+	require.NoError(t, err)
+	assert.Equal(t, compressible, blob)
+}
+
+func TestDecompressBlob(t *testing.T) {
+	// uncompressed blobs are returned unchanged
+	blob := []byte{0x0a, 0x01, 0x02}
+	out, err := DecompressBlob(blob)
+	require.NoError(t, err)
+	assert.Equal(t, blob, out)
+
+	_, err = DecompressBlob(append(append([]byte{}, blobEnvelopePrefix...), 0xff, 0x01))
+	assert.ErrorIs(t, err, ErrUnknownBlobCodec)
+
+	_, err = DecompressBlob(append(append([]byte{}, blobEnvelopePrefix...), byte(BlobCodecZstd), 0x01, 0x02))
+	assert.Error(t, err)
+
+	// decompression bombs are rejected
+	bomb, err := CompressBlob(BlobCodecGzip, make([]byte, MaxDecompressedBlobSize+1))
+	require.NoError(t, err)
+	_, err = DecompressBlob(bomb)
+	assert.ErrorIs(t, err, ErrBlobTooLarge)
+}
+
+func TestParseBlobCodec(t *testing.T) {
+	for name, expected := range map[string]BlobCodec{"": BlobCodecNone, "none": BlobCodecNone, "gzip": BlobCodecGzip, "zstd": BlobCodecZstd} {
+		codec, err := ParseBlobCodec(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, codec)
+	}
+	_, err := ParseBlobCodec("lz4")
+	assert.ErrorIs(t, err, ErrUnknownBlobCodec)
+}
@@ -8,6 +8,7 @@ import (
 	"cosmossdk.io/log"
 
 	coreda "github.com/rollkit/rollkit/core/da"
+	"github.com/rollkit/rollkit/pkg/failpoint"
 )
 
 // TODO: remove this after we modify the da interfaces
@@ -25,7 +26,11 @@ func SubmitWithHelpers(
 	gasPrice float64,
 	options []byte,
 ) coreda.ResultSubmit { // Return core ResultSubmit type
-	ids, err := da.SubmitWithOptions(ctx, data, gasPrice, nameSpacePlaceholder, options)
+	var ids []coreda.ID
+	err := failpoint.Inject(ctx, failpoint.DASubmit)
+	if err == nil {
+		ids, err = da.SubmitWithOptions(ctx, data, gasPrice, nameSpacePlaceholder, options)
+	}
 
 	// Handle errors returned by SubmitWithOptions
 	if err != nil {
@@ -37,6 +42,8 @@ func SubmitWithHelpers(
 			status = coreda.StatusAlreadyInMempool
 		case errors.Is(err, coreda.ErrTxIncorrectAccountSequence):
 			status = coreda.StatusIncorrectAccountSequence
+		case errors.Is(err, coreda.ErrTxUnderpriced):
+			status = coreda.StatusUnderpriced
 		case errors.Is(err, coreda.ErrBlobSizeOverLimit):
 			status = coreda.StatusTooBig
 		case errors.Is(err, coreda.ErrContextDeadline):
@@ -63,13 +70,21 @@ func SubmitWithHelpers(
 		}
 	}
 
+	// IDs encode the DA height of the blobs, it is left unset for DA layers using other IDs
+	var height uint64
+	if len(ids) > 0 {
+		if h, _, err := coreda.SplitID(ids[0]); err == nil {
+			height = h
+		}
+	}
+
 	logger.Debug("DA submission successful via helper", "num_ids", len(ids))
 	return coreda.ResultSubmit{
 		BaseResult: coreda.BaseResult{
 			Code:           coreda.StatusSuccess,
 			IDs:            ids,
 			SubmittedCount: uint64(len(ids)),
-			Height:         0,
+			Height:         height,
 			BlobSize:       0,
 		},
 	}
@@ -86,7 +101,11 @@ func RetrieveWithHelpers(
 ) coreda.ResultRetrieve {
 
 	// 1. Get IDs
-	idsResult, err := da.GetIDs(ctx, dataLayerHeight, nameSpacePlaceholder)
+	var idsResult *coreda.GetIDsResult
+	err := failpoint.Inject(ctx, failpoint.DARetrieve)
+	if err == nil {
+		idsResult, err = da.GetIDs(ctx, dataLayerHeight, nameSpacePlaceholder)
+	}
 	if err != nil {
 		// Handle specific "not found" error
 		if errors.Is(err, coreda.ErrBlobNotFound) {
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"errors"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// DABlobMetadata locates a blob in the DA layer.
+type DABlobMetadata struct {
+	DAHeight  uint64
+	Namespace string
+	// ID identifies the blob in the DA layer
+	ID []byte
+	// Commitment to the blob, empty if the DA layer IDs do not embed it
+	Commitment []byte
+}
+
+// DAMetadata holds the DA blobs including the header and data of a DA included block, mapping the rollup height
+// to its location in the DA layer.
+type DAMetadata struct {
+	Height uint64
+	Header DABlobMetadata
+	// Data is nil for blocks without transactions, whose data is not submitted to the DA layer
+	Data *DABlobMetadata
+}
+
+// ToProto converts DAMetadata into protobuf representation and returns it.
+func (m *DAMetadata) ToProto() *pb.DAMetadata {
+	other := &pb.DAMetadata{
+		Height: m.Height,
+		Header: m.Header.ToProto(),
+	}
+	if m.Data != nil {
+		other.Data = m.Data.ToProto()
+	}
+	return other
+}
+
+// FromProto fills DAMetadata with data from its protobuf representation.
+func (m *DAMetadata) FromProto(other *pb.DAMetadata) error {
+	if other == nil || other.Header == nil {
+		return errors.New("DA metadata is nil")
+	}
+	m.Height = other.Height
+	m.Header.FromProto(other.Header)
+	m.Data = nil
+	if other.Data != nil {
+		m.Data = new(DABlobMetadata)
+		m.Data.FromProto(other.Data)
+	}
+	return nil
+}
+
+// MarshalBinary encodes DAMetadata into binary form and returns it.
+func (m *DAMetadata) MarshalBinary() ([]byte, error) {
+	return proto.Marshal(m.ToProto())
+}
+
+// UnmarshalBinary decodes binary form of DAMetadata into object.
+func (m *DAMetadata) UnmarshalBinary(bz []byte) error {
+	var other pb.DAMetadata
+	if err := proto.Unmarshal(bz, &other); err != nil {
+		return err
+	}
+	return m.FromProto(&other)
+}
+
+// ToProto converts DABlobMetadata into protobuf representation and returns it.
+func (b *DABlobMetadata) ToProto() *pb.DABlobMetadata {
+	return &pb.DABlobMetadata{
+		DaHeight:   b.DAHeight,
+		Namespace:  b.Namespace,
+		Id:         b.ID,
+		Commitment: b.Commitment,
+	}
+}
+
+// FromProto fills DABlobMetadata with data from its protobuf representation.
+func (b *DABlobMetadata) FromProto(other *pb.DABlobMetadata) {
+	b.DAHeight = other.DaHeight
+	b.Namespace = other.Namespace
+	b.ID = other.Id
+	b.Commitment = other.Commitment
+}
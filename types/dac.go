@@ -0,0 +1,117 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rollkit/rollkit/pkg/signer"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// ErrInvalidDACertificate is returned for headers whose data is not attested by enough members of the data
+// availability committee.
+var ErrInvalidDACertificate = errors.New("invalid data availability certificate")
+
+// emptyDataHash is the data hash of blocks without transactions, whose data is not stored by the committee.
+var emptyDataHash = new(Data).DACommitment()
+
+// DACAttestation is the signature of a data availability committee member attesting that it stores the data of
+// a block and serves it on request. The attestations of a block form its DA certificate.
+type DACAttestation struct {
+	Signer    Signer
+	Signature Signature
+}
+
+// DACSignBytes returns the bytes signed by the data availability committee members storing the data of the block
+// of the header.
+func DACSignBytes(header *Header) ([]byte, error) {
+	return proto.MarshalOptions{Deterministic: true}.Marshal(&pb.DACStatement{
+		ChainId:  header.ChainID(),
+		Height:   header.Height(),
+		DataHash: header.DataHash,
+	})
+}
+
+// DACommittee is the data availability committee of a chain in commitments-only mode: only the headers are
+// posted to the DA layer, and the data of blocks is stored by the members of the committee. A header is valid
+// once Threshold members attested that they store the data of the block.
+type DACommittee struct {
+	Members   []crypto.PubKey
+	Threshold int
+}
+
+// VerifyHeader checks that the DA certificate of the header holds valid signatures of at least Threshold
+// distinct members of the committee. Blocks without transactions need no certificate.
+func (c DACommittee) VerifyHeader(header *SignedHeader) error {
+	if bytes.Equal(header.DataHash, emptyDataHash) {
+		return nil
+	}
+	bz, err := DACSignBytes(&header.Header)
+	if err != nil {
+		return err
+	}
+	attested := make([]bool, len(c.Members))
+	var count int
+	for _, attestation := range header.DACertificate {
+		member := c.memberIndex(attestation.Signer.PubKey)
+		if member < 0 || attested[member] {
+			continue
+		}
+		verified, err := attestation.Signer.PubKey.Verify(bz, attestation.Signature)
+		if err != nil || !verified {
+			continue
+		}
+		attested[member] = true
+		count++
+	}
+	if count < c.Threshold {
+		return fmt.Errorf("%w: %d of %d required committee signatures", ErrInvalidDACertificate, count, c.Threshold)
+	}
+	return nil
+}
+
+// memberIndex returns the index of the member with the given public key, -1 if it is not a member.
+func (c DACommittee) memberIndex(pubKey crypto.PubKey) int {
+	if pubKey == nil {
+		return -1
+	}
+	for i, member := range c.Members {
+		if member.Equals(pubKey) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ToProto converts DACAttestation into protobuf representation and returns it.
+func (a *DACAttestation) ToProto() (*pb.DACAttestation, error) {
+	ap := &pb.DACAttestation{
+		Signer:    &pb.Signer{Address: a.Signer.Address},
+		Signature: a.Signature[:],
+	}
+	if a.Signer.PubKey != nil {
+		var err error
+		if ap.Signer.PubKey, err = signer.MarshalPublicKey(a.Signer.PubKey); err != nil {
+			return nil, err
+		}
+	}
+	return ap, nil
+}
+
+// FromProto fills DACAttestation with data from its protobuf representation.
+func (a *DACAttestation) FromProto(other *pb.DACAttestation) error {
+	if other == nil || other.Signer == nil {
+		return errors.New("DA attestation signer is nil")
+	}
+	pubKey, err := signer.UnmarshalPublicKey(other.Signer.PubKey)
+	if err != nil {
+		return err
+	}
+	a.Signer = Signer{PubKey: pubKey, Address: KeyAddress(pubKey)}
+	a.Signature = other.Signature
+	return nil
+}
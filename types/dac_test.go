@@ -0,0 +1,69 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDACommittee(t *testing.T) {
+	members := []crypto.PubKey{}
+	attestations := []DACAttestation{}
+	header, _ := GetRandomBlock(3, 2, "test")
+	for range 3 {
+		s := newTestSigner(t)
+		pk, err := s.GetPublic()
+		require.NoError(t, err)
+		members = append(members, pk)
+		attestation, err := GetDACAttestation(s, &header.Header)
+		require.NoError(t, err)
+		attestations = append(attestations, attestation)
+	}
+	committee := DACommittee{Members: members, Threshold: 2}
+	outsider, err := GetDACAttestation(newTestSigner(t), &header.Header)
+	require.NoError(t, err)
+	forged := attestations[1]
+	forged.Signature = attestations[0].Signature
+
+	for name, tc := range map[string]struct {
+		certificate []DACAttestation
+		valid       bool
+	}{
+		"threshold reached":   {certificate: attestations[:2], valid: true},
+		"all members":         {certificate: attestations, valid: true},
+		"below threshold":     {certificate: attestations[:1]},
+		"duplicate member":    {certificate: []DACAttestation{attestations[0], attestations[0]}},
+		"not a member":        {certificate: []DACAttestation{attestations[0], outsider}},
+		"invalid signature":   {certificate: []DACAttestation{attestations[0], forged}},
+		"missing certificate": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			h := *header
+			h.DACertificate = tc.certificate
+			err := committee.VerifyHeader(&h)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidDACertificate)
+			}
+		})
+	}
+
+	t.Run("the certificate is serialized with the header", func(t *testing.T) {
+		h := *header
+		h.DACertificate = attestations
+		bz, err := h.MarshalBinary()
+		require.NoError(t, err)
+		var decoded SignedHeader
+		require.NoError(t, decoded.UnmarshalBinary(bz))
+		assert.Equal(t, attestations, decoded.DACertificate)
+		assert.NoError(t, committee.VerifyHeader(&decoded))
+	})
+
+	t.Run("blocks without transactions need no certificate", func(t *testing.T) {
+		empty, _ := GetRandomBlock(4, 0, "test")
+		assert.NoError(t, committee.VerifyHeader(empty))
+	})
+}
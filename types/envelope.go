@@ -0,0 +1,93 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// BlobEnvelopeVersion is the latest version of the blob envelope supported by this binary.
+const BlobEnvelopeVersion byte = 1
+
+// blobEnvelopeMagic marks versioned blob envelopes, it is followed by the version of the envelope. Version 1
+// continues with the codec of the payload, the length of the chain ID as an unsigned varint, the chain ID and
+// the payload. Like blobEnvelopePrefix, the magic starts with a zero byte, so an envelope is never mistaken for
+// a single header or batch.
+//
+// Later versions may change everything after the version, nodes skip the envelopes of versions they do not
+// support instead of failing to sync, so that new blob formats can be rolled out before all nodes upgrade.
+var blobEnvelopeMagic = []byte{0x00, 'r', 'k', 'v'}
+
+var (
+	// ErrInvalidBlobEnvelope is returned for blob envelopes which cannot be decoded.
+	ErrInvalidBlobEnvelope = errors.New("invalid blob envelope")
+	// ErrUnsupportedBlobVersion is returned for blob envelopes of a version later than BlobEnvelopeVersion.
+	ErrUnsupportedBlobVersion = errors.New("unsupported blob envelope version")
+)
+
+// BlobEnvelope is a versioned envelope of the blobs submitted to the DA layer, recording the format of the
+// payload and the chain it belongs to.
+type BlobEnvelope struct {
+	Version byte
+	Codec   BlobCodec
+	ChainID string
+	// Payload is the decompressed payload, a single header or batch, or a bundle of blobs
+	Payload []byte
+}
+
+// SealBlob wraps the blob in an envelope of the latest version, compressing it with the given codec. The blob
+// is stored uncompressed if compression does not reduce its size.
+func SealBlob(chainID string, codec BlobCodec, blob []byte) ([]byte, error) {
+	payload, err := compress(codec, blob)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) >= len(blob) {
+		codec, payload = BlobCodecNone, blob
+	}
+	envelope := make([]byte, 0, len(blobEnvelopeMagic)+2+binary.MaxVarintLen64+len(chainID)+len(payload))
+	envelope = append(envelope, blobEnvelopeMagic...)
+	envelope = append(envelope, BlobEnvelopeVersion, byte(codec))
+	envelope = binary.AppendUvarint(envelope, uint64(len(chainID)))
+	envelope = append(envelope, chainID...)
+	return append(envelope, payload...), nil
+}
+
+// OpenBlob decodes a blob wrapped by SealBlob, decompressing its payload. It returns false if the blob has no
+// envelope, and ErrUnsupportedBlobVersion, with the version set, for envelopes of later versions.
+func OpenBlob(blob []byte) (BlobEnvelope, bool, error) {
+	if !bytes.HasPrefix(blob, blobEnvelopeMagic) {
+		return BlobEnvelope{}, false, nil
+	}
+	rest := blob[len(blobEnvelopeMagic):]
+	if len(rest) == 0 {
+		return BlobEnvelope{}, true, fmt.Errorf("%w: missing version", ErrInvalidBlobEnvelope)
+	}
+	env := BlobEnvelope{Version: rest[0]}
+	if env.Version == 0 {
+		return env, true, fmt.Errorf("%w: version 0", ErrInvalidBlobEnvelope)
+	}
+	if env.Version > BlobEnvelopeVersion {
+		return env, true, fmt.Errorf("%w: %d", ErrUnsupportedBlobVersion, env.Version)
+	}
+
+	rest = rest[1:]
+	if len(rest) == 0 {
+		return env, true, fmt.Errorf("%w: missing codec", ErrInvalidBlobEnvelope)
+	}
+	env.Codec = BlobCodec(rest[0])
+	rest = rest[1:]
+	size, n := binary.Uvarint(rest)
+	if n <= 0 || size > uint64(len(rest)-n) {
+		return env, true, fmt.Errorf("%w: invalid chain ID", ErrInvalidBlobEnvelope)
+	}
+	rest = rest[n:]
+	env.ChainID = string(rest[:size])
+	payload, err := decompress(env.Codec, rest[size:])
+	if err != nil {
+		return env, true, err
+	}
+	env.Payload = payload
+	return env, true, nil
+}
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSealBlob(t *testing.T) {
+	compressible := bytes.Repeat([]byte("rollkit transaction "), 100)
+
+	for _, codec := range []BlobCodec{BlobCodecNone, BlobCodecGzip, BlobCodecZstd} {
+		t.Run(codec.String(), func(t *testing.T) {
+			sealed, err := SealBlob("test-chain", codec, compressible)
+			require.NoError(t, err)
+			assert.True(t, bytes.HasPrefix(sealed, blobEnvelopeMagic))
+
+			env, ok, err := OpenBlob(sealed)
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.Equal(t, BlobEnvelopeVersion, env.Version)
+			assert.Equal(t, codec, env.Codec)
+			assert.Equal(t, "test-chain", env.ChainID)
+			assert.Equal(t, compressible, env.Payload)
+		})
+	}
+
+	// blobs are stored uncompressed if compression does not help
+	incompressible := GetRandomBytes(64)
+	sealed, err := SealBlob("test-chain", BlobCodecZstd, incompressible)
+	require.NoError(t, err)
+	env, ok, err := OpenBlob(sealed)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, BlobCodecNone, env.Codec)
+	assert.Equal(t, incompressible, env.Payload)
+
+	// bundles and compressed blobs are not envelopes
+	_, ok, err = OpenBlob(BundleBlobs([][]byte{{1}, {2}}))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	_, ok, err = OpenBlob([]byte{0x0a, 0x01, 0x02})
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestOpenBlob_Errors(t *testing.T) {
+	envelope := func(rest ...byte) []byte {
+		return append(append([]byte{}, blobEnvelopeMagic...), rest...)
+	}
+
+	// envelopes of later versions are detected, whatever follows the version
+	env, ok, err := OpenBlob(envelope(BlobEnvelopeVersion+1, 0xff, 0xff))
+	assert.True(t, ok)
+	assert.ErrorIs(t, err, ErrUnsupportedBlobVersion)
+	assert.Equal(t, BlobEnvelopeVersion+1, env.Version)
+
+	for name, blob := range map[string][]byte{
+		"no version":        envelope(),
+		"version 0":         envelope(0),
+		"no codec":          envelope(BlobEnvelopeVersion),
+		"truncated chainID": envelope(BlobEnvelopeVersion, byte(BlobCodecNone), 10, 'a'),
+	} {
+		_, ok, err := OpenBlob(blob)
+		assert.True(t, ok, name)
+		assert.ErrorIs(t, err, ErrInvalidBlobEnvelope, name)
+	}
+
+	_, _, err = OpenBlob(envelope(BlobEnvelopeVersion, 0xff, 0))
+	assert.ErrorIs(t, err, ErrUnknownBlobCodec)
+	_, _, err = OpenBlob(envelope(BlobEnvelopeVersion, byte(BlobCodecZstd), 0, 0x01, 0x02))
+	assert.Error(t, err)
+}
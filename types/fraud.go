@@ -0,0 +1,99 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+var (
+	// ErrInvalidFraudProof is returned for fraud proofs which do not prove an invalid state root.
+	ErrInvalidFraudProof = errors.New("invalid fraud proof")
+	// ErrUnverifiableFraudProof is returned for fraud proofs which cannot be verified by a node, e.g. because
+	// it did not execute the disputed block yet.
+	ErrUnverifiableFraudProof = errors.New("fraud proof cannot be verified")
+)
+
+// FraudProof proves that the sequencer committed to an invalid state transition.
+//
+// With deferred execution, the header of a block commits to the state root after the previous block. The
+// proof holds the signed header committing to an invalid state root, and the state root computed by
+// re-executing the previous block.
+type FraudProof struct {
+	Header          *SignedHeader
+	ExpectedAppHash Hash
+}
+
+// DisputedHeight returns the height of the block whose state root is disputed.
+func (fp *FraudProof) DisputedHeight() uint64 {
+	return fp.Header.AppHashHeight()
+}
+
+// ValidateBasic checks that the header is signed by its proposer and commits to another state root than the
+// expected one. It does not check the expected state root, which requires re-executing the disputed block.
+func (fp *FraudProof) ValidateBasic() error {
+	if fp.Header == nil {
+		return fmt.Errorf("%w: header is nil", ErrInvalidFraudProof)
+	}
+	if fp.Header.Height() < 2 {
+		return fmt.Errorf("%w: no state transition before height %d", ErrInvalidFraudProof, fp.Header.Height())
+	}
+	if err := fp.Header.ValidateBasic(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidFraudProof, err)
+	}
+	if len(fp.ExpectedAppHash) == 0 {
+		return fmt.Errorf("%w: expected app hash is empty", ErrInvalidFraudProof)
+	}
+	if bytes.Equal(fp.Header.AppHash, fp.ExpectedAppHash) {
+		return fmt.Errorf("%w: header commits to the expected app hash", ErrInvalidFraudProof)
+	}
+	return nil
+}
+
+// ToProto converts FraudProof into protobuf representation and returns it.
+func (fp *FraudProof) ToProto() (*pb.FraudProof, error) {
+	header, err := fp.Header.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return &pb.FraudProof{
+		Header:          header,
+		ExpectedAppHash: fp.ExpectedAppHash[:],
+	}, nil
+}
+
+// FromProto fills FraudProof with data from its protobuf representation.
+func (fp *FraudProof) FromProto(other *pb.FraudProof) error {
+	if other == nil {
+		return errors.New("fraud proof is nil")
+	}
+	var header SignedHeader
+	if err := header.FromProto(other.Header); err != nil {
+		return err
+	}
+	fp.Header = &header
+	fp.ExpectedAppHash = other.ExpectedAppHash
+	return nil
+}
+
+// MarshalBinary encodes FraudProof into binary form and returns it.
+func (fp *FraudProof) MarshalBinary() ([]byte, error) {
+	pp, err := fp.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(pp)
+}
+
+// UnmarshalBinary decodes binary form of FraudProof into object.
+func (fp *FraudProof) UnmarshalBinary(data []byte) error {
+	var pProof pb.FraudProof
+	if err := proto.Unmarshal(data, &pProof); err != nil {
+		return err
+	}
+	return fp.FromProto(&pProof)
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFraudProof(t *testing.T) {
+	header, _, _ := GenerateRandomBlockCustomWithAppHash(&BlockConfig{Height: 11, NTxs: 1}, "test", []byte("invalid_app_hash"))
+	proof := &FraudProof{Header: header, ExpectedAppHash: []byte("app_hash")}
+	require.NoError(t, proof.ValidateBasic())
+	assert.Equal(t, uint64(10), proof.DisputedHeight())
+
+	bz, err := proof.MarshalBinary()
+	require.NoError(t, err)
+	var decoded FraudProof
+	require.NoError(t, decoded.UnmarshalBinary(bz))
+	require.NoError(t, decoded.ValidateBasic())
+	assert.Equal(t, header.Hash(), decoded.Header.Hash())
+	assert.Equal(t, proof.ExpectedAppHash, decoded.ExpectedAppHash)
+
+	for name, invalid := range map[string]*FraudProof{
+		"nil header":          {ExpectedAppHash: []byte("app_hash")},
+		"empty expected hash": {Header: header},
+		"same app hash":       {Header: header, ExpectedAppHash: header.AppHash},
+		"initial height":      {Header: &SignedHeader{Header: Header{BaseHeader: BaseHeader{Height: 1}}}, ExpectedAppHash: []byte("app_hash")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, invalid.ValidateBasic(), ErrInvalidFraudProof)
+		})
+	}
+}
+
+// TestFraudProofExecutionLag verifies that the state root disputed by a fraud proof on a header with an
+// execution lag is the state root after the last block executed when the header was produced.
+func TestFraudProofExecutionLag(t *testing.T) {
+	header, _, _ := GenerateRandomBlockCustomWithAppHash(&BlockConfig{Height: 11, NTxs: 1}, "test", []byte("invalid_app_hash"))
+	header.ExecutionLag = 3
+	require.NoError(t, header.Header.ValidateBasic())
+	assert.Equal(t, uint64(7), header.AppHashHeight())
+	proof := &FraudProof{Header: header, ExpectedAppHash: []byte("app_hash")}
+	assert.Equal(t, uint64(7), proof.DisputedHeight())
+
+	// the execution lag is part of the signed header
+	bz, err := header.MarshalBinary()
+	require.NoError(t, err)
+	var decoded SignedHeader
+	require.NoError(t, decoded.UnmarshalBinary(bz))
+	assert.Equal(t, header.ExecutionLag, decoded.ExecutionLag)
+	assert.Equal(t, header.Hash(), decoded.Hash())
+
+	header.ExecutionLag = 11
+	assert.ErrorContains(t, header.Header.ValidateBasic(), "execution lag 11 exceeds height 11")
+}
@@ -2,7 +2,10 @@ package types
 
 import (
 	"crypto/sha256"
+	"encoding/binary"
 	"hash"
+
+	"github.com/rollkit/rollkit/core/execution"
 )
 
 var (
@@ -37,6 +40,20 @@ func (d *Data) DACommitment() Hash {
 	return leafHashOpt(sha256.New(), dBytes)
 }
 
+// LanesHash returns the commitment to the lanes of a block set in its header, nil if the block has no lanes.
+func LanesHash(lanes []execution.Lane) Hash {
+	if len(lanes) == 0 {
+		return nil
+	}
+	s := sha256.New()
+	for _, lane := range lanes {
+		s.Write(binary.BigEndian.AppendUint32(nil, uint32(len(lane.Name)))) //nolint:gosec // lane names are short
+		s.Write([]byte(lane.Name))
+		s.Write(binary.BigEndian.AppendUint64(nil, lane.NumTxs))
+	}
+	return s.Sum(nil)
+}
+
 func leafHashOpt(s hash.Hash, leaf []byte) []byte {
 	s.Reset()
 	s.Write(leafPrefix)
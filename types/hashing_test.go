@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/core/execution"
 )
 
 // TestHeaderHash tests the Hash method of the Header.
@@ -96,3 +98,16 @@ func TestLeafHashOpt(t *testing.T) {
 	assert.Equal(t, expectedHash2, hash2)
 	assert.NotEqual(t, hash1, hash2)
 }
+
+// TestLanesHash tests the commitment to the lanes of a block.
+func TestLanesHash(t *testing.T) {
+	lanes := []execution.Lane{{Name: "oracle", NumTxs: 1}, {Name: "user", NumTxs: 3}}
+	hash := LanesHash(lanes)
+	assert.Len(t, hash, sha256.Size)
+	assert.Equal(t, hash, LanesHash([]execution.Lane{{Name: "oracle", NumTxs: 1}, {Name: "user", NumTxs: 3}}))
+
+	assert.NotEqual(t, hash, LanesHash([]execution.Lane{{Name: "oracle", NumTxs: 2}, {Name: "user", NumTxs: 2}}), "lane sizes are committed to")
+	assert.NotEqual(t, hash, LanesHash([]execution.Lane{{Name: "user", NumTxs: 3}, {Name: "oracle", NumTxs: 1}}), "lane order is committed to")
+	assert.NotEqual(t, hash, LanesHash([]execution.Lane{{Name: "oracleu", NumTxs: 1}, {Name: "ser", NumTxs: 3}}), "lane names are length prefixed")
+	assert.Nil(t, LanesHash(nil))
+}
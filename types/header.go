@@ -19,8 +19,14 @@ var (
 
 	// ErrProposerVerificationFailed is returned when the proposer verification fails.
 	ErrProposerVerificationFailed = errors.New("proposer verification failed")
+
+	// ErrInvalidDABlock is returned when the DA block committed to in a header is invalid.
+	ErrInvalidDABlock = errors.New("invalid DA block")
 )
 
+// MaxDABlockHashSize is the maximum size of the DA block hash committed to in a header.
+const MaxDABlockHashSize = 64
+
 // BaseHeader contains the most basic data of a header
 type BaseHeader struct {
 	// Height represents the block height (aka block number) of a given header
@@ -59,6 +65,22 @@ type Header struct {
 	// We keep this in case users choose another signature format where the
 	// pubkey can't be recovered by the signature (e.g. ed25519).
 	ProposerAddress []byte // original proposer of the block
+
+	// ExecutionLag is the number of blocks ordered after the block whose resulting state AppHash is, zero if
+	// AppHash is the state after the previous block. It is set by proposers executing blocks asynchronously,
+	// which attach the state roots of the blocks to later headers once executed.
+	ExecutionLag uint64
+
+	// LanesHash is the commitment to the lanes the transactions of the block are partitioned into by the executor,
+	// see LanesHash, empty if the executor does not order transactions.
+	LanesHash Hash
+
+	// DABlockHeight is the height of the latest DA block observed by the proposer when creating the block, zero if
+	// the proposer did not observe the DA layer. It is never lower than in the previous header, and lets the
+	// executor expose the DA layer to the transactions of the block, e.g. for randomness or bridging.
+	DABlockHeight uint64
+	// DABlockHash is the hash of the DA block at DABlockHeight, empty if DABlockHeight is zero.
+	DABlockHash Hash
 }
 
 // New creates a new Header.
@@ -110,11 +132,28 @@ func (h *Header) Validate() error {
 	return h.ValidateBasic()
 }
 
+// AppHashHeight returns the height of the block after which AppHash is the state root.
+func (h *Header) AppHashHeight() uint64 {
+	return h.Height() - 1 - h.ExecutionLag
+}
+
 // ValidateBasic performs basic validation of a header.
 func (h *Header) ValidateBasic() error {
 	if len(h.ProposerAddress) == 0 {
 		return ErrNoProposerAddress
 	}
+	if h.ExecutionLag > 0 && h.ExecutionLag >= h.Height() {
+		return fmt.Errorf("execution lag %d exceeds height %d", h.ExecutionLag, h.Height())
+	}
+	if h.DABlockHeight == 0 && len(h.DABlockHash) > 0 {
+		return fmt.Errorf("%w: hash set without height", ErrInvalidDABlock)
+	}
+	if h.DABlockHeight > 0 && len(h.DABlockHash) == 0 {
+		return fmt.Errorf("%w: no hash for DA height %d", ErrInvalidDABlock, h.DABlockHeight)
+	}
+	if len(h.DABlockHash) > MaxDABlockHashSize {
+		return fmt.Errorf("%w: hash of %d bytes exceeds %d bytes", ErrInvalidDABlock, len(h.DABlockHash), MaxDABlockHashSize)
+	}
 
 	return nil
 }
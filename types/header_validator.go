@@ -0,0 +1,83 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ErrHeaderRejected is returned when a signed header is rejected by a validator of a HeaderValidatorRegistry.
+var ErrHeaderRejected = errors.New("header rejected by custom validator")
+
+// HeaderValidatorFunc is a custom verification predicate for signed headers, e.g. checking an extra commitment
+// of the application. It is called on headers that passed the basic validation, and returns an error if the
+// header is invalid.
+type HeaderValidatorFunc func(header *SignedHeader) error
+
+// HeaderValidatorRegistry holds the custom header validators of an application. The validators run in
+// registration order.
+type HeaderValidatorRegistry struct {
+	mu         sync.RWMutex
+	names      []string
+	validators map[string]HeaderValidatorFunc
+}
+
+// NewHeaderValidatorRegistry returns an empty HeaderValidatorRegistry.
+func NewHeaderValidatorRegistry() *HeaderValidatorRegistry {
+	return &HeaderValidatorRegistry{validators: make(map[string]HeaderValidatorFunc)}
+}
+
+// HeaderValidators is the registry of the custom header validators applied by SignedHeader.Validate, which
+// validates the headers received over p2p and retrieved from the DA layer. Applications embedding the node
+// register their validators before starting it.
+var HeaderValidators = NewHeaderValidatorRegistry()
+
+// Register adds a validator under the given name. Names must be unique.
+func (r *HeaderValidatorRegistry) Register(name string, fn HeaderValidatorFunc) error {
+	if name == "" {
+		return errors.New("header validator name cannot be empty")
+	}
+	if fn == nil {
+		return fmt.Errorf("header validator %q cannot be nil", name)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.validators[name]; ok {
+		return fmt.Errorf("header validator %q is already registered", name)
+	}
+	r.names = append(r.names, name)
+	r.validators[name] = fn
+	return nil
+}
+
+// Unregister removes the validator registered under the given name, if any.
+func (r *HeaderValidatorRegistry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.validators[name]; !ok {
+		return
+	}
+	delete(r.validators, name)
+	r.names = slices.DeleteFunc(r.names, func(n string) bool { return n == name })
+}
+
+// Names returns the names of the registered validators, in registration order.
+func (r *HeaderValidatorRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Clone(r.names)
+}
+
+// Validate runs the registered validators on the header, and returns an error wrapping ErrHeaderRejected and
+// the error of the first validator rejecting it.
+func (r *HeaderValidatorRegistry) Validate(header *SignedHeader) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, name := range r.names {
+		if err := r.validators[name](header); err != nil {
+			return fmt.Errorf("%w %q: %w", ErrHeaderRejected, name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderValidatorRegistry(t *testing.T) {
+	header, _, err := GetRandomSignedHeader("TestHeaderValidatorRegistry")
+	require.NoError(t, err)
+
+	r := NewHeaderValidatorRegistry()
+	require.NoError(t, r.Validate(header))
+
+	var calls []string
+	errCommitment := errors.New("missing commitment")
+	require.NoError(t, r.Register("height", func(h *SignedHeader) error {
+		calls = append(calls, "height")
+		return nil
+	}))
+	require.NoError(t, r.Register("commitment", func(h *SignedHeader) error {
+		calls = append(calls, "commitment")
+		if len(h.ProofCommitment) == 0 {
+			return errCommitment
+		}
+		return nil
+	}))
+	assert.Error(t, r.Register("height", func(*SignedHeader) error { return nil }))
+	assert.Error(t, r.Register("", func(*SignedHeader) error { return nil }))
+	assert.Error(t, r.Register("nil", nil))
+	assert.Equal(t, []string{"height", "commitment"}, r.Names())
+
+	err = r.Validate(header)
+	assert.ErrorIs(t, err, ErrHeaderRejected)
+	assert.ErrorIs(t, err, errCommitment)
+	assert.Equal(t, []string{"height", "commitment"}, calls)
+
+	header.ProofCommitment = bytes.Repeat([]byte{1}, 32)
+	require.NoError(t, r.Validate(header))
+
+	r.Unregister("commitment")
+	r.Unregister("unknown")
+	header.ProofCommitment = nil
+	require.NoError(t, r.Validate(header))
+	assert.Equal(t, []string{"height"}, r.Names())
+}
+
+func TestSignedHeaderValidateCustomValidators(t *testing.T) {
+	header, _, err := GetRandomSignedHeader("TestSignedHeaderValidateCustomValidators")
+	require.NoError(t, err)
+	require.NoError(t, header.Validate())
+
+	require.NoError(t, HeaderValidators.Register("reject", func(*SignedHeader) error { return errors.New("rejected") }))
+	t.Cleanup(func() { HeaderValidators.Unregister("reject") })
+	assert.ErrorIs(t, header.Validate(), ErrHeaderRejected)
+	// the basic validation does not run the custom validators
+	assert.NoError(t, header.ValidateBasic())
+}
@@ -0,0 +1,1097 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/admin.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SetLogLevelRequest defines the request for changing log levels
+type SetLogLevelRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Space or comma separated module=level pairs, e.g. "block=debug p2p=info".
+	// A level without a module changes the default level.
+	Levels        string `protobuf:"bytes,1,opt,name=levels,proto3" json:"levels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_rollkit_v1_admin_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetLogLevelRequest) ProtoMessage() {}
+
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_admin_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_admin_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SetLogLevelRequest) GetLevels() string {
+	if x != nil {
+		return x.Levels
+	}
+	return ""
+}
+
+// LogLevelsResponse defines the response for retrieving log levels
+type LogLevelsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Default level followed by the module levels, e.g. "info block=debug"
+	Levels        string `protobuf:"bytes,1,opt,name=levels,proto3" json:"levels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogLevelsResponse) Reset() {
+	*x = LogLevelsResponse{}
+	mi := &file_rollkit_v1_admin_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogLevelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogLevelsResponse) ProtoMessage() {}
+
+func (x *LogLevelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_admin_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogLevelsResponse.ProtoReflect.Descriptor instead.
+func (*LogLevelsResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_admin_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *LogLevelsResponse) GetLevels() string {
+	if x != nil {
+		return x.Levels
+	}
+	return ""
+}
+
+// PrefixUsage is the size of the data stored under a key prefix
+type PrefixUsage struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Prefix string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// Number of keys stored under the prefix
+	Keys uint64 `protobuf:"varint,2,opt,name=keys,proto3" json:"keys,omitempty"`
+	// Size of the keys and values stored under the prefix, in bytes
+	Bytes         uint64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PrefixUsage) Reset() {
+	*x = PrefixUsage{}
+	mi := &file_rollkit_v1_admin_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PrefixUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefixUsage) ProtoMessage() {}
+
+func (x *PrefixUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_admin_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefixUsage.ProtoReflect.Descriptor instead.
+func (*PrefixUsage) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_admin_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PrefixUsage) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *PrefixUsage) GetKeys() uint64 {
+	if x != nil {
+		return x.Keys
+	}
+	return 0
+}
+
+func (x *PrefixUsage) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+// StoreUsageResponse defines the response for retrieving the disk usage of the datastore
+type StoreUsageResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// On-disk size of the datastore in bytes, 0 if the datastore does not report it
+	DiskUsage uint64 `protobuf:"varint,1,opt,name=disk_usage,json=diskUsage,proto3" json:"disk_usage,omitempty"`
+	// Size of the data stored under each key prefix
+	Prefixes      []*PrefixUsage `protobuf:"bytes,2,rep,name=prefixes,proto3" json:"prefixes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreUsageResponse) Reset() {
+	*x = StoreUsageResponse{}
+	mi := &file_rollkit_v1_admin_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreUsageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreUsageResponse) ProtoMessage() {}
+
+func (x *StoreUsageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_admin_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreUsageResponse.ProtoReflect.Descriptor instead.
+func (*StoreUsageResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_admin_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StoreUsageResponse) GetDiskUsage() uint64 {
+	if x != nil {
+		return x.DiskUsage
+	}
+	return 0
+}
+
+func (x *StoreUsageResponse) GetPrefixes() []*PrefixUsage {
+	if x != nil {
+		return x.Prefixes
+	}
+	return nil
+}
+
+// RuntimeConfig contains the configuration parameters that can be changed while the node is running
+type RuntimeConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Time between blocks produced by the aggregator
+	BlockTime *durationpb.Duration `protobuf:"bytes,1,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`
+	// Whether the aggregator only produces blocks when transactions are available
+	LazyMode bool `protobuf:"varint,2,opt,name=lazy_mode,json=lazyMode,proto3" json:"lazy_mode,omitempty"`
+	// Maximum interval between blocks in lazy aggregation mode
+	LazyBlockInterval *durationpb.Duration `protobuf:"bytes,3,opt,name=lazy_block_interval,json=lazyBlockInterval,proto3" json:"lazy_block_interval,omitempty"`
+	// Gas price of DA submissions, -1 if it is determined by the DA layer
+	DaGasPrice float64 `protobuf:"fixed64,4,opt,name=da_gas_price,json=daGasPrice,proto3" json:"da_gas_price,omitempty"`
+	// Maximum gas price of DA submissions, 0 for no maximum
+	DaMaxGasPrice float64 `protobuf:"fixed64,5,opt,name=da_max_gas_price,json=daMaxGasPrice,proto3" json:"da_max_gas_price,omitempty"`
+	// Number of most recent blocks kept when pruning, 0 if pruning is disabled
+	PruningKeepRecent uint64 `protobuf:"varint,6,opt,name=pruning_keep_recent,json=pruningKeepRecent,proto3" json:"pruning_keep_recent,omitempty"`
+	// Interval at which old blocks are pruned
+	PruningInterval *durationpb.Duration `protobuf:"bytes,7,opt,name=pruning_interval,json=pruningInterval,proto3" json:"pruning_interval,omitempty"`
+	// Maximum number of connected peers, 0 for no limit
+	MaxPeers uint64 `protobuf:"varint,8,opt,name=max_peers,json=maxPeers,proto3" json:"max_peers,omitempty"`
+	// DA fees that may be paid per UTC day before DA submissions pause, 0 for no budget
+	DaDailyBudget float64 `protobuf:"fixed64,9,opt,name=da_daily_budget,json=daDailyBudget,proto3" json:"da_daily_budget,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RuntimeConfig) Reset() {
+	*x = RuntimeConfig{}
+	mi := &file_rollkit_v1_admin_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuntimeConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuntimeConfig) ProtoMessage() {}
+
+func (x *RuntimeConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_admin_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuntimeConfig.ProtoReflect.Descriptor instead.
+func (*RuntimeConfig) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_admin_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RuntimeConfig) GetBlockTime() *durationpb.Duration {
+	if x != nil {
+		return x.BlockTime
+	}
+	return nil
+}
+
+func (x *RuntimeConfig) GetLazyMode() bool {
+	if x != nil {
+		return x.LazyMode
+	}
+	return false
+}
+
+func (x *RuntimeConfig) GetLazyBlockInterval() *durationpb.Duration {
+	if x != nil {
+		return x.LazyBlockInterval
+	}
+	return nil
+}
+
+func (x *RuntimeConfig) GetDaGasPrice() float64 {
+	if x != nil {
+		return x.DaGasPrice
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetDaMaxGasPrice() float64 {
+	if x != nil {
+		return x.DaMaxGasPrice
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetPruningKeepRecent() uint64 {
+	if x != nil {
+		return x.PruningKeepRecent
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetPruningInterval() *durationpb.Duration {
+	if x != nil {
+		return x.PruningInterval
+	}
+	return nil
+}
+
+func (x *RuntimeConfig) GetMaxPeers() uint64 {
+	if x != nil {
+		return x.MaxPeers
+	}
+	return 0
+}
+
+func (x *RuntimeConfig) GetDaDailyBudget() float64 {
+	if x != nil {
+		return x.DaDailyBudget
+	}
+	return 0
+}
+
+// UpdateConfigRequest defines the request for changing runtime parameters. Unset fields are left unchanged.
+type UpdateConfigRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	BlockTime         *durationpb.Duration   `protobuf:"bytes,1,opt,name=block_time,json=blockTime,proto3" json:"block_time,omitempty"`
+	LazyMode          *bool                  `protobuf:"varint,2,opt,name=lazy_mode,json=lazyMode,proto3,oneof" json:"lazy_mode,omitempty"`
+	LazyBlockInterval *durationpb.Duration   `protobuf:"bytes,3,opt,name=lazy_block_interval,json=lazyBlockInterval,proto3" json:"lazy_block_interval,omitempty"`
+	DaGasPrice        *float64               `protobuf:"fixed64,4,opt,name=da_gas_price,json=daGasPrice,proto3,oneof" json:"da_gas_price,omitempty"`
+	DaMaxGasPrice     *float64               `protobuf:"fixed64,5,opt,name=da_max_gas_price,json=daMaxGasPrice,proto3,oneof" json:"da_max_gas_price,omitempty"`
+	PruningKeepRecent *uint64                `protobuf:"varint,6,opt,name=pruning_keep_recent,json=pruningKeepRecent,proto3,oneof" json:"pruning_keep_recent,omitempty"`
+	PruningInterval   *durationpb.Duration   `protobuf:"bytes,7,opt,name=pruning_interval,json=pruningInterval,proto3" json:"pruning_interval,omitempty"`
+	MaxPeers          *uint64                `protobuf:"varint,8,opt,name=max_peers,json=maxPeers,proto3,oneof" json:"max_peers,omitempty"`
+	DaDailyBudget     *float64               `protobuf:"fixed64,9,opt,name=da_daily_budget,json=daDailyBudget,proto3,oneof" json:"da_daily_budget,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateConfigRequest) Reset() {
+	*x = UpdateConfigRequest{}
+	mi := &file_rollkit_v1_admin_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateConfigRequest) ProtoMessage() {}
+
+func (x *UpdateConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_admin_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateConfigRequest.ProtoReflect.Descriptor instead.
+func (*UpdateConfigRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_admin_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UpdateConfigRequest) GetBlockTime() *durationpb.Duration {
+	if x != nil {
+		return x.BlockTime
+	}
+	return nil
+}
+
+func (x *UpdateConfigRequest) GetLazyMode() bool {
+	if x != nil && x.LazyMode != nil {
+		return *x.LazyMode
+	}
+	return false
+}
+
+func (x *UpdateConfigRequest) GetLazyBlockInterval() *durationpb.Duration {
+	if x != nil {
+		return x.LazyBlockInterval
+	}
+	return nil
+}
+
+func (x *UpdateConfigRequest) GetDaGasPrice() float64 {
+	if x != nil && x.DaGasPrice != nil {
+		return *x.DaGasPrice
+	}
+	return 0
+}
+
+func (x *UpdateConfigRequest) GetDaMaxGasPrice() float64 {
+	if x != nil && x.DaMaxGasPrice != nil {
+		return *x.DaMaxGasPrice
+	}
+	return 0
+}
+
+func (x *UpdateConfigRequest) GetPruningKeepRecent() uint64 {
+	if x != nil && x.PruningKeepRecent != nil {
+		return *x.PruningKeepRecent
+	}
+	return 0
+}
+
+func (x *UpdateConfigRequest) GetPruningInterval() *durationpb.Duration {
+	if x != nil {
+		return x.PruningInterval
+	}
+	return nil
+}
+
+func (x *UpdateConfigRequest) GetMaxPeers() uint64 {
+	if x != nil && x.MaxPeers != nil {
+		return *x.MaxPeers
+	}
+	return 0
+}
+
+func (x *UpdateConfigRequest) GetDaDailyBudget() float64 {
+	if x != nil && x.DaDailyBudget != nil {
+		return *x.DaDailyBudget
+	}
+	return 0
+}
+
+// RotateProposerKeyRequest defines the request for rotating the signing key of the sequencer
+type RotateProposerKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Public key the sequencer rotates to, in libp2p protobuf encoding
+	NewPubKey []byte `protobuf:"bytes,1,opt,name=new_pub_key,json=newPubKey,proto3" json:"new_pub_key,omitempty"`
+	// First block height whose header must be signed by the new key
+	Height        uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateProposerKeyRequest) Reset() {
+	*x = RotateProposerKeyRequest{}
+	mi := &file_rollkit_v1_admin_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateProposerKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateProposerKeyRequest) ProtoMessage() {}
+
+func (x *RotateProposerKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_admin_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateProposerKeyRequest.ProtoReflect.Descriptor instead.
+func (*RotateProposerKeyRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_admin_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RotateProposerKeyRequest) GetNewPubKey() []byte {
+	if x != nil {
+		return x.NewPubKey
+	}
+	return nil
+}
+
+func (x *RotateProposerKeyRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// RotateProposerKeyResponse defines the response for rotating the signing key of the sequencer
+type RotateProposerKeyResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// First block height whose header must be signed by the new key
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// Proposer address of the new key
+	ProposerAddress []byte `protobuf:"bytes,2,opt,name=proposer_address,json=proposerAddress,proto3" json:"proposer_address,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RotateProposerKeyResponse) Reset() {
+	*x = RotateProposerKeyResponse{}
+	mi := &file_rollkit_v1_admin_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateProposerKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateProposerKeyResponse) ProtoMessage() {}
+
+func (x *RotateProposerKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_admin_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateProposerKeyResponse.ProtoReflect.Descriptor instead.
+func (*RotateProposerKeyResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_admin_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RotateProposerKeyResponse) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *RotateProposerKeyResponse) GetProposerAddress() []byte {
+	if x != nil {
+		return x.ProposerAddress
+	}
+	return nil
+}
+
+// ScheduleUpgradeRequest defines the request for scheduling a chain upgrade
+type ScheduleUpgradeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the upgrade
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// First block height whose header must follow the upgrade
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// Block protocol version from the upgrade height on, 0 to keep the current version
+	BlockVersion uint64 `protobuf:"varint,3,opt,name=block_version,json=blockVersion,proto3" json:"block_version,omitempty"`
+	// Hex encoded DA namespace of the headers from the upgrade height on, empty to keep the current namespace
+	Namespace string `protobuf:"bytes,4,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Signature scheme of the sequencer key from the upgrade height on, empty to keep the current scheme
+	SignatureScheme string `protobuf:"bytes,5,opt,name=signature_scheme,json=signatureScheme,proto3" json:"signature_scheme,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ScheduleUpgradeRequest) Reset() {
+	*x = ScheduleUpgradeRequest{}
+	mi := &file_rollkit_v1_admin_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleUpgradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleUpgradeRequest) ProtoMessage() {}
+
+func (x *ScheduleUpgradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_admin_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleUpgradeRequest.ProtoReflect.Descriptor instead.
+func (*ScheduleUpgradeRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_admin_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ScheduleUpgradeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ScheduleUpgradeRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ScheduleUpgradeRequest) GetBlockVersion() uint64 {
+	if x != nil {
+		return x.BlockVersion
+	}
+	return 0
+}
+
+func (x *ScheduleUpgradeRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ScheduleUpgradeRequest) GetSignatureScheme() string {
+	if x != nil {
+		return x.SignatureScheme
+	}
+	return ""
+}
+
+// ScheduleUpgradeResponse defines the response for scheduling a chain upgrade
+type ScheduleUpgradeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the upgrade
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// First block height whose header must follow the upgrade
+	Height        uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduleUpgradeResponse) Reset() {
+	*x = ScheduleUpgradeResponse{}
+	mi := &file_rollkit_v1_admin_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduleUpgradeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduleUpgradeResponse) ProtoMessage() {}
+
+func (x *ScheduleUpgradeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_admin_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduleUpgradeResponse.ProtoReflect.Descriptor instead.
+func (*ScheduleUpgradeResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_admin_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ScheduleUpgradeResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ScheduleUpgradeResponse) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// SwitchModeRequest defines the request for switching the mode of a dual mode node
+type SwitchModeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Mode to switch to: light or full
+	Mode          string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SwitchModeRequest) Reset() {
+	*x = SwitchModeRequest{}
+	mi := &file_rollkit_v1_admin_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwitchModeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwitchModeRequest) ProtoMessage() {}
+
+func (x *SwitchModeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_admin_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwitchModeRequest.ProtoReflect.Descriptor instead.
+func (*SwitchModeRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_admin_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SwitchModeRequest) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+// SwitchModeResponse defines the response for switching the mode of a dual mode node
+type SwitchModeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Mode the node switches to. The switch completes asynchronously, once the node running in the previous mode
+	// stopped; the mode reported by StatusService.GetStatus changes when the node runs in the new mode.
+	Mode          string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SwitchModeResponse) Reset() {
+	*x = SwitchModeResponse{}
+	mi := &file_rollkit_v1_admin_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SwitchModeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwitchModeResponse) ProtoMessage() {}
+
+func (x *SwitchModeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_admin_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwitchModeResponse.ProtoReflect.Descriptor instead.
+func (*SwitchModeResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_admin_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SwitchModeResponse) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+// DebugBundleResponse defines the response for capturing a debug bundle
+type DebugBundleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Gzipped tarball of the debug bundle
+	Bundle []byte `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	// Suggested file name of the bundle
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DebugBundleResponse) Reset() {
+	*x = DebugBundleResponse{}
+	mi := &file_rollkit_v1_admin_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugBundleResponse) ProtoMessage() {}
+
+func (x *DebugBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_admin_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugBundleResponse.ProtoReflect.Descriptor instead.
+func (*DebugBundleResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_admin_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DebugBundleResponse) GetBundle() []byte {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+func (x *DebugBundleResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// SequencerStateResponse defines the response for pausing and resuming block production
+type SequencerStateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether block production is paused
+	Paused bool `protobuf:"varint,1,opt,name=paused,proto3" json:"paused,omitempty"`
+	// Height of the last block produced
+	Height        uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SequencerStateResponse) Reset() {
+	*x = SequencerStateResponse{}
+	mi := &file_rollkit_v1_admin_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SequencerStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SequencerStateResponse) ProtoMessage() {}
+
+func (x *SequencerStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_admin_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SequencerStateResponse.ProtoReflect.Descriptor instead.
+func (*SequencerStateResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_admin_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SequencerStateResponse) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *SequencerStateResponse) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+var File_rollkit_v1_admin_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_admin_proto_rawDesc = "" +
+	"\n" +
+	"\x16rollkit/v1/admin.proto\x12\n" +
+	"rollkit.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\",\n" +
+	"\x12SetLogLevelRequest\x12\x16\n" +
+	"\x06levels\x18\x01 \x01(\tR\x06levels\"+\n" +
+	"\x11LogLevelsResponse\x12\x16\n" +
+	"\x06levels\x18\x01 \x01(\tR\x06levels\"O\n" +
+	"\vPrefixUsage\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x12\n" +
+	"\x04keys\x18\x02 \x01(\x04R\x04keys\x12\x14\n" +
+	"\x05bytes\x18\x03 \x01(\x04R\x05bytes\"h\n" +
+	"\x12StoreUsageResponse\x12\x1d\n" +
+	"\n" +
+	"disk_usage\x18\x01 \x01(\x04R\tdiskUsage\x123\n" +
+	"\bprefixes\x18\x02 \x03(\v2\x17.rollkit.v1.PrefixUsageR\bprefixes\"\xb7\x03\n" +
+	"\rRuntimeConfig\x128\n" +
+	"\n" +
+	"block_time\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\tblockTime\x12\x1b\n" +
+	"\tlazy_mode\x18\x02 \x01(\bR\blazyMode\x12I\n" +
+	"\x13lazy_block_interval\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x11lazyBlockInterval\x12 \n" +
+	"\fda_gas_price\x18\x04 \x01(\x01R\n" +
+	"daGasPrice\x12'\n" +
+	"\x10da_max_gas_price\x18\x05 \x01(\x01R\rdaMaxGasPrice\x12.\n" +
+	"\x13pruning_keep_recent\x18\x06 \x01(\x04R\x11pruningKeepRecent\x12D\n" +
+	"\x10pruning_interval\x18\a \x01(\v2\x19.google.protobuf.DurationR\x0fpruningInterval\x12\x1b\n" +
+	"\tmax_peers\x18\b \x01(\x04R\bmaxPeers\x12&\n" +
+	"\x0fda_daily_budget\x18\t \x01(\x01R\rdaDailyBudget\"\xc9\x04\n" +
+	"\x13UpdateConfigRequest\x128\n" +
+	"\n" +
+	"block_time\x18\x01 \x01(\v2\x19.google.protobuf.DurationR\tblockTime\x12 \n" +
+	"\tlazy_mode\x18\x02 \x01(\bH\x00R\blazyMode\x88\x01\x01\x12I\n" +
+	"\x13lazy_block_interval\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x11lazyBlockInterval\x12%\n" +
+	"\fda_gas_price\x18\x04 \x01(\x01H\x01R\n" +
+	"daGasPrice\x88\x01\x01\x12,\n" +
+	"\x10da_max_gas_price\x18\x05 \x01(\x01H\x02R\rdaMaxGasPrice\x88\x01\x01\x123\n" +
+	"\x13pruning_keep_recent\x18\x06 \x01(\x04H\x03R\x11pruningKeepRecent\x88\x01\x01\x12D\n" +
+	"\x10pruning_interval\x18\a \x01(\v2\x19.google.protobuf.DurationR\x0fpruningInterval\x12 \n" +
+	"\tmax_peers\x18\b \x01(\x04H\x04R\bmaxPeers\x88\x01\x01\x12+\n" +
+	"\x0fda_daily_budget\x18\t \x01(\x01H\x05R\rdaDailyBudget\x88\x01\x01B\f\n" +
+	"\n" +
+	"_lazy_modeB\x0f\n" +
+	"\r_da_gas_priceB\x13\n" +
+	"\x11_da_max_gas_priceB\x16\n" +
+	"\x14_pruning_keep_recentB\f\n" +
+	"\n" +
+	"_max_peersB\x12\n" +
+	"\x10_da_daily_budget\"R\n" +
+	"\x18RotateProposerKeyRequest\x12\x1e\n" +
+	"\vnew_pub_key\x18\x01 \x01(\fR\tnewPubKey\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height\"^\n" +
+	"\x19RotateProposerKeyResponse\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\x12)\n" +
+	"\x10proposer_address\x18\x02 \x01(\fR\x0fproposerAddress\"\xb2\x01\n" +
+	"\x16ScheduleUpgradeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height\x12#\n" +
+	"\rblock_version\x18\x03 \x01(\x04R\fblockVersion\x12\x1c\n" +
+	"\tnamespace\x18\x04 \x01(\tR\tnamespace\x12)\n" +
+	"\x10signature_scheme\x18\x05 \x01(\tR\x0fsignatureScheme\"E\n" +
+	"\x17ScheduleUpgradeResponse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height\"'\n" +
+	"\x11SwitchModeRequest\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\"(\n" +
+	"\x12SwitchModeResponse\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\"A\n" +
+	"\x13DebugBundleResponse\x12\x16\n" +
+	"\x06bundle\x18\x01 \x01(\fR\x06bundle\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"H\n" +
+	"\x16SequencerStateResponse\x12\x16\n" +
+	"\x06paused\x18\x01 \x01(\bR\x06paused\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height2\xcf\a\n" +
+	"\fAdminService\x12G\n" +
+	"\fGetLogLevels\x12\x16.google.protobuf.Empty\x1a\x1d.rollkit.v1.LogLevelsResponse\"\x00\x12N\n" +
+	"\vSetLogLevel\x12\x1e.rollkit.v1.SetLogLevelRequest\x1a\x1d.rollkit.v1.LogLevelsResponse\"\x00\x12H\n" +
+	"\fCompactStore\x12\x16.google.protobuf.Empty\x1a\x1e.rollkit.v1.StoreUsageResponse\"\x00\x12I\n" +
+	"\rGetStoreUsage\x12\x16.google.protobuf.Empty\x1a\x1e.rollkit.v1.StoreUsageResponse\"\x00\x12@\n" +
+	"\tGetConfig\x12\x16.google.protobuf.Empty\x1a\x19.rollkit.v1.RuntimeConfig\"\x00\x12L\n" +
+	"\fUpdateConfig\x12\x1f.rollkit.v1.UpdateConfigRequest\x1a\x19.rollkit.v1.RuntimeConfig\"\x00\x12b\n" +
+	"\x11RotateProposerKey\x12$.rollkit.v1.RotateProposerKeyRequest\x1a%.rollkit.v1.RotateProposerKeyResponse\"\x00\x12\\\n" +
+	"\x0fScheduleUpgrade\x12\".rollkit.v1.ScheduleUpgradeRequest\x1a#.rollkit.v1.ScheduleUpgradeResponse\"\x00\x12M\n" +
+	"\n" +
+	"SwitchMode\x12\x1d.rollkit.v1.SwitchModeRequest\x1a\x1e.rollkit.v1.SwitchModeResponse\"\x00\x12O\n" +
+	"\x12CaptureDebugBundle\x12\x16.google.protobuf.Empty\x1a\x1f.rollkit.v1.DebugBundleResponse\"\x00\x12N\n" +
+	"\x0ePauseSequencer\x12\x16.google.protobuf.Empty\x1a\".rollkit.v1.SequencerStateResponse\"\x00\x12O\n" +
+	"\x0fResumeSequencer\x12\x16.google.protobuf.Empty\x1a\".rollkit.v1.SequencerStateResponse\"\x00B0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_admin_proto_rawDescOnce sync.Once
+	file_rollkit_v1_admin_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_admin_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_admin_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_admin_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_admin_proto_rawDesc), len(file_rollkit_v1_admin_proto_rawDesc)))
+	})
+	return file_rollkit_v1_admin_proto_rawDescData
+}
+
+var file_rollkit_v1_admin_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_rollkit_v1_admin_proto_goTypes = []any{
+	(*SetLogLevelRequest)(nil),        // 0: rollkit.v1.SetLogLevelRequest
+	(*LogLevelsResponse)(nil),         // 1: rollkit.v1.LogLevelsResponse
+	(*PrefixUsage)(nil),               // 2: rollkit.v1.PrefixUsage
+	(*StoreUsageResponse)(nil),        // 3: rollkit.v1.StoreUsageResponse
+	(*RuntimeConfig)(nil),             // 4: rollkit.v1.RuntimeConfig
+	(*UpdateConfigRequest)(nil),       // 5: rollkit.v1.UpdateConfigRequest
+	(*RotateProposerKeyRequest)(nil),  // 6: rollkit.v1.RotateProposerKeyRequest
+	(*RotateProposerKeyResponse)(nil), // 7: rollkit.v1.RotateProposerKeyResponse
+	(*ScheduleUpgradeRequest)(nil),    // 8: rollkit.v1.ScheduleUpgradeRequest
+	(*ScheduleUpgradeResponse)(nil),   // 9: rollkit.v1.ScheduleUpgradeResponse
+	(*SwitchModeRequest)(nil),         // 10: rollkit.v1.SwitchModeRequest
+	(*SwitchModeResponse)(nil),        // 11: rollkit.v1.SwitchModeResponse
+	(*DebugBundleResponse)(nil),       // 12: rollkit.v1.DebugBundleResponse
+	(*SequencerStateResponse)(nil),    // 13: rollkit.v1.SequencerStateResponse
+	(*durationpb.Duration)(nil),       // 14: google.protobuf.Duration
+	(*emptypb.Empty)(nil),             // 15: google.protobuf.Empty
+}
+var file_rollkit_v1_admin_proto_depIdxs = []int32{
+	2,  // 0: rollkit.v1.StoreUsageResponse.prefixes:type_name -> rollkit.v1.PrefixUsage
+	14, // 1: rollkit.v1.RuntimeConfig.block_time:type_name -> google.protobuf.Duration
+	14, // 2: rollkit.v1.RuntimeConfig.lazy_block_interval:type_name -> google.protobuf.Duration
+	14, // 3: rollkit.v1.RuntimeConfig.pruning_interval:type_name -> google.protobuf.Duration
+	14, // 4: rollkit.v1.UpdateConfigRequest.block_time:type_name -> google.protobuf.Duration
+	14, // 5: rollkit.v1.UpdateConfigRequest.lazy_block_interval:type_name -> google.protobuf.Duration
+	14, // 6: rollkit.v1.UpdateConfigRequest.pruning_interval:type_name -> google.protobuf.Duration
+	15, // 7: rollkit.v1.AdminService.GetLogLevels:input_type -> google.protobuf.Empty
+	0,  // 8: rollkit.v1.AdminService.SetLogLevel:input_type -> rollkit.v1.SetLogLevelRequest
+	15, // 9: rollkit.v1.AdminService.CompactStore:input_type -> google.protobuf.Empty
+	15, // 10: rollkit.v1.AdminService.GetStoreUsage:input_type -> google.protobuf.Empty
+	15, // 11: rollkit.v1.AdminService.GetConfig:input_type -> google.protobuf.Empty
+	5,  // 12: rollkit.v1.AdminService.UpdateConfig:input_type -> rollkit.v1.UpdateConfigRequest
+	6,  // 13: rollkit.v1.AdminService.RotateProposerKey:input_type -> rollkit.v1.RotateProposerKeyRequest
+	8,  // 14: rollkit.v1.AdminService.ScheduleUpgrade:input_type -> rollkit.v1.ScheduleUpgradeRequest
+	10, // 15: rollkit.v1.AdminService.SwitchMode:input_type -> rollkit.v1.SwitchModeRequest
+	15, // 16: rollkit.v1.AdminService.CaptureDebugBundle:input_type -> google.protobuf.Empty
+	15, // 17: rollkit.v1.AdminService.PauseSequencer:input_type -> google.protobuf.Empty
+	15, // 18: rollkit.v1.AdminService.ResumeSequencer:input_type -> google.protobuf.Empty
+	1,  // 19: rollkit.v1.AdminService.GetLogLevels:output_type -> rollkit.v1.LogLevelsResponse
+	1,  // 20: rollkit.v1.AdminService.SetLogLevel:output_type -> rollkit.v1.LogLevelsResponse
+	3,  // 21: rollkit.v1.AdminService.CompactStore:output_type -> rollkit.v1.StoreUsageResponse
+	3,  // 22: rollkit.v1.AdminService.GetStoreUsage:output_type -> rollkit.v1.StoreUsageResponse
+	4,  // 23: rollkit.v1.AdminService.GetConfig:output_type -> rollkit.v1.RuntimeConfig
+	4,  // 24: rollkit.v1.AdminService.UpdateConfig:output_type -> rollkit.v1.RuntimeConfig
+	7,  // 25: rollkit.v1.AdminService.RotateProposerKey:output_type -> rollkit.v1.RotateProposerKeyResponse
+	9,  // 26: rollkit.v1.AdminService.ScheduleUpgrade:output_type -> rollkit.v1.ScheduleUpgradeResponse
+	11, // 27: rollkit.v1.AdminService.SwitchMode:output_type -> rollkit.v1.SwitchModeResponse
+	12, // 28: rollkit.v1.AdminService.CaptureDebugBundle:output_type -> rollkit.v1.DebugBundleResponse
+	13, // 29: rollkit.v1.AdminService.PauseSequencer:output_type -> rollkit.v1.SequencerStateResponse
+	13, // 30: rollkit.v1.AdminService.ResumeSequencer:output_type -> rollkit.v1.SequencerStateResponse
+	19, // [19:31] is the sub-list for method output_type
+	7,  // [7:19] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_admin_proto_init() }
+func file_rollkit_v1_admin_proto_init() {
+	if File_rollkit_v1_admin_proto != nil {
+		return
+	}
+	file_rollkit_v1_admin_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_admin_proto_rawDesc), len(file_rollkit_v1_admin_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   14,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rollkit_v1_admin_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_admin_proto_depIdxs,
+		MessageInfos:      file_rollkit_v1_admin_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_admin_proto = out.File
+	file_rollkit_v1_admin_proto_goTypes = nil
+	file_rollkit_v1_admin_proto_depIdxs = nil
+}
@@ -0,0 +1,165 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/attestation.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// BlockAttestation is the signature of an attester, a full node of the configured attester set, attesting that it
+// applied the block with the given hash at the given height. Blocks attested by a quorum of attesters are final
+// before they are included in the DA layer.
+type BlockAttestation struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChainId string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Height  uint64                 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// Hash of the header of the block
+	BlockHash []byte `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	// Public key of the attester, the public key of its p2p identity
+	PubKey        []byte `protobuf:"bytes,4,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Signature     []byte `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockAttestation) Reset() {
+	*x = BlockAttestation{}
+	mi := &file_rollkit_v1_attestation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockAttestation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockAttestation) ProtoMessage() {}
+
+func (x *BlockAttestation) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_attestation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockAttestation.ProtoReflect.Descriptor instead.
+func (*BlockAttestation) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_attestation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BlockAttestation) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *BlockAttestation) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *BlockAttestation) GetBlockHash() []byte {
+	if x != nil {
+		return x.BlockHash
+	}
+	return nil
+}
+
+func (x *BlockAttestation) GetPubKey() []byte {
+	if x != nil {
+		return x.PubKey
+	}
+	return nil
+}
+
+func (x *BlockAttestation) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+var File_rollkit_v1_attestation_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_attestation_proto_rawDesc = "" +
+	"\n" +
+	"\x1crollkit/v1/attestation.proto\x12\n" +
+	"rollkit.v1\"\x9b\x01\n" +
+	"\x10BlockAttestation\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x03 \x01(\fR\tblockHash\x12\x17\n" +
+	"\apub_key\x18\x04 \x01(\fR\x06pubKey\x12\x1c\n" +
+	"\tsignature\x18\x05 \x01(\fR\tsignatureB0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_attestation_proto_rawDescOnce sync.Once
+	file_rollkit_v1_attestation_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_attestation_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_attestation_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_attestation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_attestation_proto_rawDesc), len(file_rollkit_v1_attestation_proto_rawDesc)))
+	})
+	return file_rollkit_v1_attestation_proto_rawDescData
+}
+
+var file_rollkit_v1_attestation_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_rollkit_v1_attestation_proto_goTypes = []any{
+	(*BlockAttestation)(nil), // 0: rollkit.v1.BlockAttestation
+}
+var file_rollkit_v1_attestation_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_attestation_proto_init() }
+func file_rollkit_v1_attestation_proto_init() {
+	if File_rollkit_v1_attestation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_attestation_proto_rawDesc), len(file_rollkit_v1_attestation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rollkit_v1_attestation_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_attestation_proto_depIdxs,
+		MessageInfos:      file_rollkit_v1_attestation_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_attestation_proto = out.File
+	file_rollkit_v1_attestation_proto_goTypes = nil
+	file_rollkit_v1_attestation_proto_depIdxs = nil
+}
@@ -0,0 +1,231 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/blockdata.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// BlockDataRequest requests the data of a block served by a peer.
+type BlockDataRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The height or the header hash of the block
+	//
+	// Types that are valid to be assigned to Identifier:
+	//
+	//	*BlockDataRequest_Height
+	//	*BlockDataRequest_Hash
+	Identifier    isBlockDataRequest_Identifier `protobuf_oneof:"identifier"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockDataRequest) Reset() {
+	*x = BlockDataRequest{}
+	mi := &file_rollkit_v1_blockdata_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockDataRequest) ProtoMessage() {}
+
+func (x *BlockDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_blockdata_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockDataRequest.ProtoReflect.Descriptor instead.
+func (*BlockDataRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_blockdata_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BlockDataRequest) GetIdentifier() isBlockDataRequest_Identifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *BlockDataRequest) GetHeight() uint64 {
+	if x != nil {
+		if x, ok := x.Identifier.(*BlockDataRequest_Height); ok {
+			return x.Height
+		}
+	}
+	return 0
+}
+
+func (x *BlockDataRequest) GetHash() []byte {
+	if x != nil {
+		if x, ok := x.Identifier.(*BlockDataRequest_Hash); ok {
+			return x.Hash
+		}
+	}
+	return nil
+}
+
+type isBlockDataRequest_Identifier interface {
+	isBlockDataRequest_Identifier()
+}
+
+type BlockDataRequest_Height struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3,oneof"`
+}
+
+type BlockDataRequest_Hash struct {
+	Hash []byte `protobuf:"bytes,2,opt,name=hash,proto3,oneof"`
+}
+
+func (*BlockDataRequest_Height) isBlockDataRequest_Identifier() {}
+
+func (*BlockDataRequest_Hash) isBlockDataRequest_Identifier() {}
+
+// BlockDataResponse is the response to a BlockDataRequest.
+type BlockDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *Data                  `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockDataResponse) Reset() {
+	*x = BlockDataResponse{}
+	mi := &file_rollkit_v1_blockdata_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockDataResponse) ProtoMessage() {}
+
+func (x *BlockDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_blockdata_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockDataResponse.ProtoReflect.Descriptor instead.
+func (*BlockDataResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_blockdata_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BlockDataResponse) GetData() *Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *BlockDataResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_rollkit_v1_blockdata_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_blockdata_proto_rawDesc = "" +
+	"\n" +
+	"\x1arollkit/v1/blockdata.proto\x12\n" +
+	"rollkit.v1\x1a\x18rollkit/v1/rollkit.proto\"P\n" +
+	"\x10BlockDataRequest\x12\x18\n" +
+	"\x06height\x18\x01 \x01(\x04H\x00R\x06height\x12\x14\n" +
+	"\x04hash\x18\x02 \x01(\fH\x00R\x04hashB\f\n" +
+	"\n" +
+	"identifier\"O\n" +
+	"\x11BlockDataResponse\x12$\n" +
+	"\x04data\x18\x01 \x01(\v2\x10.rollkit.v1.DataR\x04data\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05errorB0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_blockdata_proto_rawDescOnce sync.Once
+	file_rollkit_v1_blockdata_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_blockdata_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_blockdata_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_blockdata_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_blockdata_proto_rawDesc), len(file_rollkit_v1_blockdata_proto_rawDesc)))
+	})
+	return file_rollkit_v1_blockdata_proto_rawDescData
+}
+
+var file_rollkit_v1_blockdata_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rollkit_v1_blockdata_proto_goTypes = []any{
+	(*BlockDataRequest)(nil),  // 0: rollkit.v1.BlockDataRequest
+	(*BlockDataResponse)(nil), // 1: rollkit.v1.BlockDataResponse
+	(*Data)(nil),              // 2: rollkit.v1.Data
+}
+var file_rollkit_v1_blockdata_proto_depIdxs = []int32{
+	2, // 0: rollkit.v1.BlockDataResponse.data:type_name -> rollkit.v1.Data
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_blockdata_proto_init() }
+func file_rollkit_v1_blockdata_proto_init() {
+	if File_rollkit_v1_blockdata_proto != nil {
+		return
+	}
+	file_rollkit_v1_rollkit_proto_init()
+	file_rollkit_v1_blockdata_proto_msgTypes[0].OneofWrappers = []any{
+		(*BlockDataRequest_Height)(nil),
+		(*BlockDataRequest_Hash)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_blockdata_proto_rawDesc), len(file_rollkit_v1_blockdata_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rollkit_v1_blockdata_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_blockdata_proto_depIdxs,
+		MessageInfos:      file_rollkit_v1_blockdata_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_blockdata_proto = out.File
+	file_rollkit_v1_blockdata_proto_goTypes = nil
+	file_rollkit_v1_blockdata_proto_depIdxs = nil
+}
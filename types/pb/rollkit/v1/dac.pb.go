@@ -0,0 +1,294 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/dac.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// StoreDataRequest defines the request for storing the data of a block
+type StoreDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Header        *SignedHeader          `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Data          *Data                  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreDataRequest) Reset() {
+	*x = StoreDataRequest{}
+	mi := &file_rollkit_v1_dac_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreDataRequest) ProtoMessage() {}
+
+func (x *StoreDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_dac_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreDataRequest.ProtoReflect.Descriptor instead.
+func (*StoreDataRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_dac_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StoreDataRequest) GetHeader() *SignedHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *StoreDataRequest) GetData() *Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// StoreDataResponse defines the response for storing the data of a block
+type StoreDataResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Signature of the DACStatement of the block
+	Signature     []byte `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StoreDataResponse) Reset() {
+	*x = StoreDataResponse{}
+	mi := &file_rollkit_v1_dac_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StoreDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreDataResponse) ProtoMessage() {}
+
+func (x *StoreDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_dac_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreDataResponse.ProtoReflect.Descriptor instead.
+func (*StoreDataResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_dac_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *StoreDataResponse) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// GetDataRequest defines the request for getting the data of a block
+type GetDataRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Hash of the header of the block
+	HeaderHash    []byte `protobuf:"bytes,1,opt,name=header_hash,json=headerHash,proto3" json:"header_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDataRequest) Reset() {
+	*x = GetDataRequest{}
+	mi := &file_rollkit_v1_dac_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDataRequest) ProtoMessage() {}
+
+func (x *GetDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_dac_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDataRequest.ProtoReflect.Descriptor instead.
+func (*GetDataRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_dac_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetDataRequest) GetHeaderHash() []byte {
+	if x != nil {
+		return x.HeaderHash
+	}
+	return nil
+}
+
+// GetDataResponse defines the response for getting the data of a block
+type GetDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *Data                  `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDataResponse) Reset() {
+	*x = GetDataResponse{}
+	mi := &file_rollkit_v1_dac_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDataResponse) ProtoMessage() {}
+
+func (x *GetDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_dac_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDataResponse.ProtoReflect.Descriptor instead.
+func (*GetDataResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_dac_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetDataResponse) GetData() *Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_rollkit_v1_dac_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_dac_proto_rawDesc = "" +
+	"\n" +
+	"\x14rollkit/v1/dac.proto\x12\n" +
+	"rollkit.v1\x1a\x18rollkit/v1/rollkit.proto\"j\n" +
+	"\x10StoreDataRequest\x120\n" +
+	"\x06header\x18\x01 \x01(\v2\x18.rollkit.v1.SignedHeaderR\x06header\x12$\n" +
+	"\x04data\x18\x02 \x01(\v2\x10.rollkit.v1.DataR\x04data\"1\n" +
+	"\x11StoreDataResponse\x12\x1c\n" +
+	"\tsignature\x18\x01 \x01(\fR\tsignature\"1\n" +
+	"\x0eGetDataRequest\x12\x1f\n" +
+	"\vheader_hash\x18\x01 \x01(\fR\n" +
+	"headerHash\"7\n" +
+	"\x0fGetDataResponse\x12$\n" +
+	"\x04data\x18\x01 \x01(\v2\x10.rollkit.v1.DataR\x04data2\x9e\x01\n" +
+	"\n" +
+	"DACService\x12J\n" +
+	"\tStoreData\x12\x1c.rollkit.v1.StoreDataRequest\x1a\x1d.rollkit.v1.StoreDataResponse\"\x00\x12D\n" +
+	"\aGetData\x12\x1a.rollkit.v1.GetDataRequest\x1a\x1b.rollkit.v1.GetDataResponse\"\x00B0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_dac_proto_rawDescOnce sync.Once
+	file_rollkit_v1_dac_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_dac_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_dac_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_dac_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_dac_proto_rawDesc), len(file_rollkit_v1_dac_proto_rawDesc)))
+	})
+	return file_rollkit_v1_dac_proto_rawDescData
+}
+
+var file_rollkit_v1_dac_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_rollkit_v1_dac_proto_goTypes = []any{
+	(*StoreDataRequest)(nil),  // 0: rollkit.v1.StoreDataRequest
+	(*StoreDataResponse)(nil), // 1: rollkit.v1.StoreDataResponse
+	(*GetDataRequest)(nil),    // 2: rollkit.v1.GetDataRequest
+	(*GetDataResponse)(nil),   // 3: rollkit.v1.GetDataResponse
+	(*SignedHeader)(nil),      // 4: rollkit.v1.SignedHeader
+	(*Data)(nil),              // 5: rollkit.v1.Data
+}
+var file_rollkit_v1_dac_proto_depIdxs = []int32{
+	4, // 0: rollkit.v1.StoreDataRequest.header:type_name -> rollkit.v1.SignedHeader
+	5, // 1: rollkit.v1.StoreDataRequest.data:type_name -> rollkit.v1.Data
+	5, // 2: rollkit.v1.GetDataResponse.data:type_name -> rollkit.v1.Data
+	0, // 3: rollkit.v1.DACService.StoreData:input_type -> rollkit.v1.StoreDataRequest
+	2, // 4: rollkit.v1.DACService.GetData:input_type -> rollkit.v1.GetDataRequest
+	1, // 5: rollkit.v1.DACService.StoreData:output_type -> rollkit.v1.StoreDataResponse
+	3, // 6: rollkit.v1.DACService.GetData:output_type -> rollkit.v1.GetDataResponse
+	5, // [5:7] is the sub-list for method output_type
+	3, // [3:5] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_dac_proto_init() }
+func file_rollkit_v1_dac_proto_init() {
+	if File_rollkit_v1_dac_proto != nil {
+		return
+	}
+	file_rollkit_v1_rollkit_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_dac_proto_rawDesc), len(file_rollkit_v1_dac_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rollkit_v1_dac_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_dac_proto_depIdxs,
+		MessageInfos:      file_rollkit_v1_dac_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_dac_proto = out.File
+	file_rollkit_v1_dac_proto_goTypes = nil
+	file_rollkit_v1_dac_proto_depIdxs = nil
+}
@@ -0,0 +1,329 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/event_rpc.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EventType defines the kind of a node event
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED EventType = 0
+	// A block was applied to the local state
+	EventType_EVENT_TYPE_NEW_BLOCK EventType = 1
+	// The soft-confirmed height advanced
+	EventType_EVENT_TYPE_SOFT_CONFIRMED EventType = 2
+	// The DA included height advanced
+	EventType_EVENT_TYPE_DA_INCLUDED EventType = 3
+	// The node produced a block
+	EventType_EVENT_TYPE_BLOCK_PRODUCED EventType = 4
+	// Headers were submitted to the DA layer
+	EventType_EVENT_TYPE_BLOB_SUBMITTED EventType = 5
+	// The node caught up with the chain after starting
+	EventType_EVENT_TYPE_SYNC_CAUGHT_UP EventType = 6
+	// The DA included height was rolled back after a DA reorg
+	EventType_EVENT_TYPE_DA_REORG EventType = 7
+	// A block produced with asynchronous execution was executed
+	EventType_EVENT_TYPE_BLOCK_EXECUTED EventType = 8
+	// DA submissions paused because the DA daily budget is exhausted
+	EventType_EVENT_TYPE_DA_BUDGET_EXHAUSTED EventType = 9
+	// The attested height advanced
+	EventType_EVENT_TYPE_ATTESTED EventType = 10
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0:  "EVENT_TYPE_UNSPECIFIED",
+		1:  "EVENT_TYPE_NEW_BLOCK",
+		2:  "EVENT_TYPE_SOFT_CONFIRMED",
+		3:  "EVENT_TYPE_DA_INCLUDED",
+		4:  "EVENT_TYPE_BLOCK_PRODUCED",
+		5:  "EVENT_TYPE_BLOB_SUBMITTED",
+		6:  "EVENT_TYPE_SYNC_CAUGHT_UP",
+		7:  "EVENT_TYPE_DA_REORG",
+		8:  "EVENT_TYPE_BLOCK_EXECUTED",
+		9:  "EVENT_TYPE_DA_BUDGET_EXHAUSTED",
+		10: "EVENT_TYPE_ATTESTED",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED":         0,
+		"EVENT_TYPE_NEW_BLOCK":           1,
+		"EVENT_TYPE_SOFT_CONFIRMED":      2,
+		"EVENT_TYPE_DA_INCLUDED":         3,
+		"EVENT_TYPE_BLOCK_PRODUCED":      4,
+		"EVENT_TYPE_BLOB_SUBMITTED":      5,
+		"EVENT_TYPE_SYNC_CAUGHT_UP":      6,
+		"EVENT_TYPE_DA_REORG":            7,
+		"EVENT_TYPE_BLOCK_EXECUTED":      8,
+		"EVENT_TYPE_DA_BUDGET_EXHAUSTED": 9,
+		"EVENT_TYPE_ATTESTED":            10,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_rollkit_v1_event_rpc_proto_enumTypes[0].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_rollkit_v1_event_rpc_proto_enumTypes[0]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_rollkit_v1_event_rpc_proto_rawDescGZIP(), []int{0}
+}
+
+// SubscribeRequest defines the request for subscribing to node events
+type SubscribeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types of the streamed events, all events are streamed if empty
+	Types         []EventType `protobuf:"varint,1,rep,packed,name=types,proto3,enum=rollkit.v1.EventType" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_rollkit_v1_event_rpc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_event_rpc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_event_rpc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubscribeRequest) GetTypes() []EventType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+// NodeEvent defines a node event
+type NodeEvent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Type   EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=rollkit.v1.EventType" json:"type,omitempty"`
+	Height uint64                 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// Hash, time and number of transactions of the block, only set for new block, block produced and block executed events
+	Hash   []byte                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	Time   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	NumTxs uint64                 `protobuf:"varint,5,opt,name=num_txs,json=numTxs,proto3" json:"num_txs,omitempty"`
+	// DA height the headers were submitted at, only set for blob submitted events
+	DaHeight      uint64 `protobuf:"varint,6,opt,name=da_height,json=daHeight,proto3" json:"da_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeEvent) Reset() {
+	*x = NodeEvent{}
+	mi := &file_rollkit_v1_event_rpc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeEvent) ProtoMessage() {}
+
+func (x *NodeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_event_rpc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeEvent.ProtoReflect.Descriptor instead.
+func (*NodeEvent) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_event_rpc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NodeEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *NodeEvent) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *NodeEvent) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *NodeEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *NodeEvent) GetNumTxs() uint64 {
+	if x != nil {
+		return x.NumTxs
+	}
+	return 0
+}
+
+func (x *NodeEvent) GetDaHeight() uint64 {
+	if x != nil {
+		return x.DaHeight
+	}
+	return 0
+}
+
+var File_rollkit_v1_event_rpc_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_event_rpc_proto_rawDesc = "" +
+	"\n" +
+	"\x1arollkit/v1/event_rpc.proto\x12\n" +
+	"rollkit.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"?\n" +
+	"\x10SubscribeRequest\x12+\n" +
+	"\x05types\x18\x01 \x03(\x0e2\x15.rollkit.v1.EventTypeR\x05types\"\xc8\x01\n" +
+	"\tNodeEvent\x12)\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x15.rollkit.v1.EventTypeR\x04type\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height\x12\x12\n" +
+	"\x04hash\x18\x03 \x01(\fR\x04hash\x12.\n" +
+	"\x04time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\x17\n" +
+	"\anum_txs\x18\x05 \x01(\x04R\x06numTxs\x12\x1b\n" +
+	"\tda_height\x18\x06 \x01(\x04R\bdaHeight*\xce\x02\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14EVENT_TYPE_NEW_BLOCK\x10\x01\x12\x1d\n" +
+	"\x19EVENT_TYPE_SOFT_CONFIRMED\x10\x02\x12\x1a\n" +
+	"\x16EVENT_TYPE_DA_INCLUDED\x10\x03\x12\x1d\n" +
+	"\x19EVENT_TYPE_BLOCK_PRODUCED\x10\x04\x12\x1d\n" +
+	"\x19EVENT_TYPE_BLOB_SUBMITTED\x10\x05\x12\x1d\n" +
+	"\x19EVENT_TYPE_SYNC_CAUGHT_UP\x10\x06\x12\x17\n" +
+	"\x13EVENT_TYPE_DA_REORG\x10\a\x12\x1d\n" +
+	"\x19EVENT_TYPE_BLOCK_EXECUTED\x10\b\x12\"\n" +
+	"\x1eEVENT_TYPE_DA_BUDGET_EXHAUSTED\x10\t\x12\x17\n" +
+	"\x13EVENT_TYPE_ATTESTED\x10\n" +
+	"2T\n" +
+	"\fEventService\x12D\n" +
+	"\tSubscribe\x12\x1c.rollkit.v1.SubscribeRequest\x1a\x15.rollkit.v1.NodeEvent\"\x000\x01B0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_event_rpc_proto_rawDescOnce sync.Once
+	file_rollkit_v1_event_rpc_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_event_rpc_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_event_rpc_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_event_rpc_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_event_rpc_proto_rawDesc), len(file_rollkit_v1_event_rpc_proto_rawDesc)))
+	})
+	return file_rollkit_v1_event_rpc_proto_rawDescData
+}
+
+var file_rollkit_v1_event_rpc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_rollkit_v1_event_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rollkit_v1_event_rpc_proto_goTypes = []any{
+	(EventType)(0),                // 0: rollkit.v1.EventType
+	(*SubscribeRequest)(nil),      // 1: rollkit.v1.SubscribeRequest
+	(*NodeEvent)(nil),             // 2: rollkit.v1.NodeEvent
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_rollkit_v1_event_rpc_proto_depIdxs = []int32{
+	0, // 0: rollkit.v1.SubscribeRequest.types:type_name -> rollkit.v1.EventType
+	0, // 1: rollkit.v1.NodeEvent.type:type_name -> rollkit.v1.EventType
+	3, // 2: rollkit.v1.NodeEvent.time:type_name -> google.protobuf.Timestamp
+	1, // 3: rollkit.v1.EventService.Subscribe:input_type -> rollkit.v1.SubscribeRequest
+	2, // 4: rollkit.v1.EventService.Subscribe:output_type -> rollkit.v1.NodeEvent
+	4, // [4:5] is the sub-list for method output_type
+	3, // [3:4] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_event_rpc_proto_init() }
+func file_rollkit_v1_event_rpc_proto_init() {
+	if File_rollkit_v1_event_rpc_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_event_rpc_proto_rawDesc), len(file_rollkit_v1_event_rpc_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rollkit_v1_event_rpc_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_event_rpc_proto_depIdxs,
+		EnumInfos:         file_rollkit_v1_event_rpc_proto_enumTypes,
+		MessageInfos:      file_rollkit_v1_event_rpc_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_event_rpc_proto = out.File
+	file_rollkit_v1_event_rpc_proto_goTypes = nil
+	file_rollkit_v1_event_rpc_proto_depIdxs = nil
+}
@@ -0,0 +1,139 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/fraud.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FraudProof proves that the sequencer signed a header committing to an invalid state root
+type FraudProof struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Signed header committing to the state root after the previous block
+	Header *SignedHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// State root after the previous block, computed by re-executing it
+	ExpectedAppHash []byte `protobuf:"bytes,2,opt,name=expected_app_hash,json=expectedAppHash,proto3" json:"expected_app_hash,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FraudProof) Reset() {
+	*x = FraudProof{}
+	mi := &file_rollkit_v1_fraud_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FraudProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FraudProof) ProtoMessage() {}
+
+func (x *FraudProof) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_fraud_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FraudProof.ProtoReflect.Descriptor instead.
+func (*FraudProof) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_fraud_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FraudProof) GetHeader() *SignedHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *FraudProof) GetExpectedAppHash() []byte {
+	if x != nil {
+		return x.ExpectedAppHash
+	}
+	return nil
+}
+
+var File_rollkit_v1_fraud_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_fraud_proto_rawDesc = "" +
+	"\n" +
+	"\x16rollkit/v1/fraud.proto\x12\n" +
+	"rollkit.v1\x1a\x18rollkit/v1/rollkit.proto\"j\n" +
+	"\n" +
+	"FraudProof\x120\n" +
+	"\x06header\x18\x01 \x01(\v2\x18.rollkit.v1.SignedHeaderR\x06header\x12*\n" +
+	"\x11expected_app_hash\x18\x02 \x01(\fR\x0fexpectedAppHashB0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_fraud_proto_rawDescOnce sync.Once
+	file_rollkit_v1_fraud_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_fraud_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_fraud_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_fraud_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_fraud_proto_rawDesc), len(file_rollkit_v1_fraud_proto_rawDesc)))
+	})
+	return file_rollkit_v1_fraud_proto_rawDescData
+}
+
+var file_rollkit_v1_fraud_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_rollkit_v1_fraud_proto_goTypes = []any{
+	(*FraudProof)(nil),   // 0: rollkit.v1.FraudProof
+	(*SignedHeader)(nil), // 1: rollkit.v1.SignedHeader
+}
+var file_rollkit_v1_fraud_proto_depIdxs = []int32{
+	1, // 0: rollkit.v1.FraudProof.header:type_name -> rollkit.v1.SignedHeader
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_fraud_proto_init() }
+func file_rollkit_v1_fraud_proto_init() {
+	if File_rollkit_v1_fraud_proto != nil {
+		return
+	}
+	file_rollkit_v1_rollkit_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_fraud_proto_rawDesc), len(file_rollkit_v1_fraud_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rollkit_v1_fraud_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_fraud_proto_depIdxs,
+		MessageInfos:      file_rollkit_v1_fraud_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_fraud_proto = out.File
+	file_rollkit_v1_fraud_proto_goTypes = nil
+	file_rollkit_v1_fraud_proto_depIdxs = nil
+}
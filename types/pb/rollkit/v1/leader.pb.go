@@ -0,0 +1,164 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/leader.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// LeaseClaim is posted to the DA layer by an aggregator to acquire or renew the leadership lease.
+// Field numbers start at 16 so that lease claims are never decoded as headers or batches
+// sharing the same namespace.
+type LeaseClaim struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Holder string                 `protobuf:"bytes,16,opt,name=holder,proto3" json:"holder,omitempty"`
+	Term   uint64                 `protobuf:"varint,17,opt,name=term,proto3" json:"term,omitempty"`
+	// DA height observed when the claim was created, claims included more than a lease later are ignored
+	DaHeight      uint64 `protobuf:"varint,18,opt,name=da_height,json=daHeight,proto3" json:"da_height,omitempty"`
+	PubKey        []byte `protobuf:"bytes,19,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Signature     []byte `protobuf:"bytes,20,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaseClaim) Reset() {
+	*x = LeaseClaim{}
+	mi := &file_rollkit_v1_leader_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaseClaim) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaseClaim) ProtoMessage() {}
+
+func (x *LeaseClaim) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_leader_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaseClaim.ProtoReflect.Descriptor instead.
+func (*LeaseClaim) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_leader_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LeaseClaim) GetHolder() string {
+	if x != nil {
+		return x.Holder
+	}
+	return ""
+}
+
+func (x *LeaseClaim) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *LeaseClaim) GetDaHeight() uint64 {
+	if x != nil {
+		return x.DaHeight
+	}
+	return 0
+}
+
+func (x *LeaseClaim) GetPubKey() []byte {
+	if x != nil {
+		return x.PubKey
+	}
+	return nil
+}
+
+func (x *LeaseClaim) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+var File_rollkit_v1_leader_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_leader_proto_rawDesc = "" +
+	"\n" +
+	"\x17rollkit/v1/leader.proto\x12\n" +
+	"rollkit.v1\"\x8c\x01\n" +
+	"\n" +
+	"LeaseClaim\x12\x16\n" +
+	"\x06holder\x18\x10 \x01(\tR\x06holder\x12\x12\n" +
+	"\x04term\x18\x11 \x01(\x04R\x04term\x12\x1b\n" +
+	"\tda_height\x18\x12 \x01(\x04R\bdaHeight\x12\x17\n" +
+	"\apub_key\x18\x13 \x01(\fR\x06pubKey\x12\x1c\n" +
+	"\tsignature\x18\x14 \x01(\fR\tsignatureB0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_leader_proto_rawDescOnce sync.Once
+	file_rollkit_v1_leader_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_leader_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_leader_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_leader_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_leader_proto_rawDesc), len(file_rollkit_v1_leader_proto_rawDesc)))
+	})
+	return file_rollkit_v1_leader_proto_rawDescData
+}
+
+var file_rollkit_v1_leader_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_rollkit_v1_leader_proto_goTypes = []any{
+	(*LeaseClaim)(nil), // 0: rollkit.v1.LeaseClaim
+}
+var file_rollkit_v1_leader_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_leader_proto_init() }
+func file_rollkit_v1_leader_proto_init() {
+	if File_rollkit_v1_leader_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_leader_proto_rawDesc), len(file_rollkit_v1_leader_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rollkit_v1_leader_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_leader_proto_depIdxs,
+		MessageInfos:      file_rollkit_v1_leader_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_leader_proto = out.File
+	file_rollkit_v1_leader_proto_goTypes = nil
+	file_rollkit_v1_leader_proto_depIdxs = nil
+}
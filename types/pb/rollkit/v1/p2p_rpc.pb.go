@@ -10,6 +10,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -233,12 +234,550 @@ func (x *NetInfo) GetConnectedPeers() []string {
 	return nil
 }
 
+// PeerScore contains the reputation of a peer
+type PeerScore struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Peer ID
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Score of the peer, lowered when the peer misbehaves and recovering over time
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	// Whether the peer is connected
+	Connected bool `protobuf:"varint,3,opt,name=connected,proto3" json:"connected,omitempty"`
+	// Whether the peer is banned
+	Banned bool `protobuf:"varint,4,opt,name=banned,proto3" json:"banned,omitempty"`
+	// End of the ban of a peer banned for a low score, unset for peers banned until unbanned
+	BannedUntil   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=banned_until,json=bannedUntil,proto3" json:"banned_until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerScore) Reset() {
+	*x = PeerScore{}
+	mi := &file_rollkit_v1_p2p_rpc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerScore) ProtoMessage() {}
+
+func (x *PeerScore) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_p2p_rpc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerScore.ProtoReflect.Descriptor instead.
+func (*PeerScore) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_p2p_rpc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PeerScore) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PeerScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PeerScore) GetConnected() bool {
+	if x != nil {
+		return x.Connected
+	}
+	return false
+}
+
+func (x *PeerScore) GetBanned() bool {
+	if x != nil {
+		return x.Banned
+	}
+	return false
+}
+
+func (x *PeerScore) GetBannedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BannedUntil
+	}
+	return nil
+}
+
+// NetPeersResponse defines the response for retrieving peer scores
+type NetPeersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Peers and their scores
+	Peers         []*PeerScore `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetPeersResponse) Reset() {
+	*x = NetPeersResponse{}
+	mi := &file_rollkit_v1_p2p_rpc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetPeersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetPeersResponse) ProtoMessage() {}
+
+func (x *NetPeersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_p2p_rpc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetPeersResponse.ProtoReflect.Descriptor instead.
+func (*NetPeersResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_p2p_rpc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NetPeersResponse) GetPeers() []*PeerScore {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+// BanPeerRequest defines the request for banning a peer
+type BanPeerRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Peer ID
+	PeerId        string `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BanPeerRequest) Reset() {
+	*x = BanPeerRequest{}
+	mi := &file_rollkit_v1_p2p_rpc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BanPeerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BanPeerRequest) ProtoMessage() {}
+
+func (x *BanPeerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_p2p_rpc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BanPeerRequest.ProtoReflect.Descriptor instead.
+func (*BanPeerRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_p2p_rpc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *BanPeerRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+// UnbanPeerRequest defines the request for unbanning a peer
+type UnbanPeerRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Peer ID
+	PeerId        string `protobuf:"bytes,1,opt,name=peer_id,json=peerId,proto3" json:"peer_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnbanPeerRequest) Reset() {
+	*x = UnbanPeerRequest{}
+	mi := &file_rollkit_v1_p2p_rpc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnbanPeerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnbanPeerRequest) ProtoMessage() {}
+
+func (x *UnbanPeerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_p2p_rpc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnbanPeerRequest.ProtoReflect.Descriptor instead.
+func (*UnbanPeerRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_p2p_rpc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UnbanPeerRequest) GetPeerId() string {
+	if x != nil {
+		return x.PeerId
+	}
+	return ""
+}
+
+// PeerBandwidth contains the traffic with a peer
+type PeerBandwidth struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Peer ID
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Bytes received from the peer
+	TotalIn uint64 `protobuf:"varint,2,opt,name=total_in,json=totalIn,proto3" json:"total_in,omitempty"`
+	// Bytes sent to the peer
+	TotalOut uint64 `protobuf:"varint,3,opt,name=total_out,json=totalOut,proto3" json:"total_out,omitempty"`
+	// Bytes per second received from the peer
+	RateIn float64 `protobuf:"fixed64,4,opt,name=rate_in,json=rateIn,proto3" json:"rate_in,omitempty"`
+	// Bytes per second sent to the peer
+	RateOut       float64 `protobuf:"fixed64,5,opt,name=rate_out,json=rateOut,proto3" json:"rate_out,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerBandwidth) Reset() {
+	*x = PeerBandwidth{}
+	mi := &file_rollkit_v1_p2p_rpc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerBandwidth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerBandwidth) ProtoMessage() {}
+
+func (x *PeerBandwidth) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_p2p_rpc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerBandwidth.ProtoReflect.Descriptor instead.
+func (*PeerBandwidth) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_p2p_rpc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PeerBandwidth) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PeerBandwidth) GetTotalIn() uint64 {
+	if x != nil {
+		return x.TotalIn
+	}
+	return 0
+}
+
+func (x *PeerBandwidth) GetTotalOut() uint64 {
+	if x != nil {
+		return x.TotalOut
+	}
+	return 0
+}
+
+func (x *PeerBandwidth) GetRateIn() float64 {
+	if x != nil {
+		return x.RateIn
+	}
+	return 0
+}
+
+func (x *PeerBandwidth) GetRateOut() float64 {
+	if x != nil {
+		return x.RateOut
+	}
+	return 0
+}
+
+// TopicStats contains the gossip traffic and mesh of a topic
+type TopicStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Topic
+	Topic string `protobuf:"bytes,1,opt,name=topic,proto3" json:"topic,omitempty"`
+	// Messages received, including duplicates
+	MessagesReceived uint64 `protobuf:"varint,2,opt,name=messages_received,json=messagesReceived,proto3" json:"messages_received,omitempty"`
+	// Messages sent, counted once for every peer they are sent to
+	MessagesSent uint64 `protobuf:"varint,3,opt,name=messages_sent,json=messagesSent,proto3" json:"messages_sent,omitempty"`
+	// Bytes of the messages received
+	BytesReceived uint64 `protobuf:"varint,4,opt,name=bytes_received,json=bytesReceived,proto3" json:"bytes_received,omitempty"`
+	// Bytes of the messages sent
+	BytesSent uint64 `protobuf:"varint,5,opt,name=bytes_sent,json=bytesSent,proto3" json:"bytes_sent,omitempty"`
+	// Duplicate messages received
+	Duplicates uint64 `protobuf:"varint,6,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	// Messages received rejected by the topic validator
+	Rejected uint64 `protobuf:"varint,7,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	// Messages per second received over the last stats interval
+	ReceiveRate float64 `protobuf:"fixed64,8,opt,name=receive_rate,json=receiveRate,proto3" json:"receive_rate,omitempty"`
+	// Messages per second sent over the last stats interval
+	SendRate float64 `protobuf:"fixed64,9,opt,name=send_rate,json=sendRate,proto3" json:"send_rate,omitempty"`
+	// Peers in the gossip mesh of the topic
+	MeshPeers uint32 `protobuf:"varint,10,opt,name=mesh_peers,json=meshPeers,proto3" json:"mesh_peers,omitempty"`
+	// Connected peers subscribed to the topic
+	TopicPeers uint32 `protobuf:"varint,11,opt,name=topic_peers,json=topicPeers,proto3" json:"topic_peers,omitempty"`
+	// Whether the mesh holds the minimum number of mesh peers, or all the subscribed peers if there are fewer
+	MeshHealthy   bool `protobuf:"varint,12,opt,name=mesh_healthy,json=meshHealthy,proto3" json:"mesh_healthy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopicStats) Reset() {
+	*x = TopicStats{}
+	mi := &file_rollkit_v1_p2p_rpc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopicStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopicStats) ProtoMessage() {}
+
+func (x *TopicStats) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_p2p_rpc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopicStats.ProtoReflect.Descriptor instead.
+func (*TopicStats) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_p2p_rpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TopicStats) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *TopicStats) GetMessagesReceived() uint64 {
+	if x != nil {
+		return x.MessagesReceived
+	}
+	return 0
+}
+
+func (x *TopicStats) GetMessagesSent() uint64 {
+	if x != nil {
+		return x.MessagesSent
+	}
+	return 0
+}
+
+func (x *TopicStats) GetBytesReceived() uint64 {
+	if x != nil {
+		return x.BytesReceived
+	}
+	return 0
+}
+
+func (x *TopicStats) GetBytesSent() uint64 {
+	if x != nil {
+		return x.BytesSent
+	}
+	return 0
+}
+
+func (x *TopicStats) GetDuplicates() uint64 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *TopicStats) GetRejected() uint64 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *TopicStats) GetReceiveRate() float64 {
+	if x != nil {
+		return x.ReceiveRate
+	}
+	return 0
+}
+
+func (x *TopicStats) GetSendRate() float64 {
+	if x != nil {
+		return x.SendRate
+	}
+	return 0
+}
+
+func (x *TopicStats) GetMeshPeers() uint32 {
+	if x != nil {
+		return x.MeshPeers
+	}
+	return 0
+}
+
+func (x *TopicStats) GetTopicPeers() uint32 {
+	if x != nil {
+		return x.TopicPeers
+	}
+	return 0
+}
+
+func (x *TopicStats) GetMeshHealthy() bool {
+	if x != nil {
+		return x.MeshHealthy
+	}
+	return false
+}
+
+// NetStatsResponse defines the response for retrieving network stats
+type NetStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Bytes received from all peers
+	TotalIn uint64 `protobuf:"varint,1,opt,name=total_in,json=totalIn,proto3" json:"total_in,omitempty"`
+	// Bytes sent to all peers
+	TotalOut uint64 `protobuf:"varint,2,opt,name=total_out,json=totalOut,proto3" json:"total_out,omitempty"`
+	// Bytes per second received from all peers
+	RateIn float64 `protobuf:"fixed64,3,opt,name=rate_in,json=rateIn,proto3" json:"rate_in,omitempty"`
+	// Bytes per second sent to all peers
+	RateOut float64 `protobuf:"fixed64,4,opt,name=rate_out,json=rateOut,proto3" json:"rate_out,omitempty"`
+	// Traffic with every connected peer
+	Peers []*PeerBandwidth `protobuf:"bytes,5,rep,name=peers,proto3" json:"peers,omitempty"`
+	// Gossip traffic and mesh of every topic
+	Topics        []*TopicStats `protobuf:"bytes,6,rep,name=topics,proto3" json:"topics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NetStatsResponse) Reset() {
+	*x = NetStatsResponse{}
+	mi := &file_rollkit_v1_p2p_rpc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetStatsResponse) ProtoMessage() {}
+
+func (x *NetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_p2p_rpc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetStatsResponse.ProtoReflect.Descriptor instead.
+func (*NetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_p2p_rpc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NetStatsResponse) GetTotalIn() uint64 {
+	if x != nil {
+		return x.TotalIn
+	}
+	return 0
+}
+
+func (x *NetStatsResponse) GetTotalOut() uint64 {
+	if x != nil {
+		return x.TotalOut
+	}
+	return 0
+}
+
+func (x *NetStatsResponse) GetRateIn() float64 {
+	if x != nil {
+		return x.RateIn
+	}
+	return 0
+}
+
+func (x *NetStatsResponse) GetRateOut() float64 {
+	if x != nil {
+		return x.RateOut
+	}
+	return 0
+}
+
+func (x *NetStatsResponse) GetPeers() []*PeerBandwidth {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+func (x *NetStatsResponse) GetTopics() []*TopicStats {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
 var File_rollkit_v1_p2p_rpc_proto protoreflect.FileDescriptor
 
 const file_rollkit_v1_p2p_rpc_proto_rawDesc = "" +
 	"\n" +
 	"\x18rollkit/v1/p2p_rpc.proto\x12\n" +
-	"rollkit.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x18rollkit/v1/rollkit.proto\x1a\x16rollkit/v1/state.proto\"A\n" +
+	"rollkit.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18rollkit/v1/rollkit.proto\x1a\x16rollkit/v1/state.proto\"A\n" +
 	"\x13GetPeerInfoResponse\x12*\n" +
 	"\x05peers\x18\x01 \x03(\v2\x14.rollkit.v1.PeerInfoR\x05peers\"D\n" +
 	"\x12GetNetInfoResponse\x12.\n" +
@@ -249,12 +788,61 @@ const file_rollkit_v1_p2p_rpc_proto_rawDesc = "" +
 	"\aNetInfo\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12)\n" +
 	"\x10listen_addresses\x18\x02 \x03(\tR\x0flistenAddresses\x12'\n" +
-	"\x0fconnected_peers\x18\x03 \x03(\tR\x0econnectedPeers2\x9e\x01\n" +
+	"\x0fconnected_peers\x18\x03 \x03(\tR\x0econnectedPeers\"\xa6\x01\n" +
+	"\tPeerScore\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\x12\x1c\n" +
+	"\tconnected\x18\x03 \x01(\bR\tconnected\x12\x16\n" +
+	"\x06banned\x18\x04 \x01(\bR\x06banned\x12=\n" +
+	"\fbanned_until\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vbannedUntil\"?\n" +
+	"\x10NetPeersResponse\x12+\n" +
+	"\x05peers\x18\x01 \x03(\v2\x15.rollkit.v1.PeerScoreR\x05peers\")\n" +
+	"\x0eBanPeerRequest\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\"+\n" +
+	"\x10UnbanPeerRequest\x12\x17\n" +
+	"\apeer_id\x18\x01 \x01(\tR\x06peerId\"\x8b\x01\n" +
+	"\rPeerBandwidth\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\btotal_in\x18\x02 \x01(\x04R\atotalIn\x12\x1b\n" +
+	"\ttotal_out\x18\x03 \x01(\x04R\btotalOut\x12\x17\n" +
+	"\arate_in\x18\x04 \x01(\x01R\x06rateIn\x12\x19\n" +
+	"\brate_out\x18\x05 \x01(\x01R\arateOut\"\x99\x03\n" +
+	"\n" +
+	"TopicStats\x12\x14\n" +
+	"\x05topic\x18\x01 \x01(\tR\x05topic\x12+\n" +
+	"\x11messages_received\x18\x02 \x01(\x04R\x10messagesReceived\x12#\n" +
+	"\rmessages_sent\x18\x03 \x01(\x04R\fmessagesSent\x12%\n" +
+	"\x0ebytes_received\x18\x04 \x01(\x04R\rbytesReceived\x12\x1d\n" +
+	"\n" +
+	"bytes_sent\x18\x05 \x01(\x04R\tbytesSent\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x06 \x01(\x04R\n" +
+	"duplicates\x12\x1a\n" +
+	"\brejected\x18\a \x01(\x04R\brejected\x12!\n" +
+	"\freceive_rate\x18\b \x01(\x01R\vreceiveRate\x12\x1b\n" +
+	"\tsend_rate\x18\t \x01(\x01R\bsendRate\x12\x1d\n" +
+	"\n" +
+	"mesh_peers\x18\n" +
+	" \x01(\rR\tmeshPeers\x12\x1f\n" +
+	"\vtopic_peers\x18\v \x01(\rR\n" +
+	"topicPeers\x12!\n" +
+	"\fmesh_healthy\x18\f \x01(\bR\vmeshHealthy\"\xdf\x01\n" +
+	"\x10NetStatsResponse\x12\x19\n" +
+	"\btotal_in\x18\x01 \x01(\x04R\atotalIn\x12\x1b\n" +
+	"\ttotal_out\x18\x02 \x01(\x04R\btotalOut\x12\x17\n" +
+	"\arate_in\x18\x03 \x01(\x01R\x06rateIn\x12\x19\n" +
+	"\brate_out\x18\x04 \x01(\x01R\arateOut\x12/\n" +
+	"\x05peers\x18\x05 \x03(\v2\x19.rollkit.v1.PeerBandwidthR\x05peers\x12.\n" +
+	"\x06topics\x18\x06 \x03(\v2\x16.rollkit.v1.TopicStatsR\x06topics2\xac\x03\n" +
 	"\n" +
 	"P2PService\x12H\n" +
 	"\vGetPeerInfo\x12\x16.google.protobuf.Empty\x1a\x1f.rollkit.v1.GetPeerInfoResponse\"\x00\x12F\n" +
 	"\n" +
-	"GetNetInfo\x12\x16.google.protobuf.Empty\x1a\x1e.rollkit.v1.GetNetInfoResponse\"\x00B0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+	"GetNetInfo\x12\x16.google.protobuf.Empty\x1a\x1e.rollkit.v1.GetNetInfoResponse\"\x00\x12B\n" +
+	"\bNetPeers\x12\x16.google.protobuf.Empty\x1a\x1c.rollkit.v1.NetPeersResponse\"\x00\x12?\n" +
+	"\aBanPeer\x12\x1a.rollkit.v1.BanPeerRequest\x1a\x16.google.protobuf.Empty\"\x00\x12C\n" +
+	"\tUnbanPeer\x12\x1c.rollkit.v1.UnbanPeerRequest\x1a\x16.google.protobuf.Empty\"\x00\x12B\n" +
+	"\bNetStats\x12\x16.google.protobuf.Empty\x1a\x1c.rollkit.v1.NetStatsResponse\"\x00B0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
 
 var (
 	file_rollkit_v1_p2p_rpc_proto_rawDescOnce sync.Once
@@ -268,26 +856,46 @@ func file_rollkit_v1_p2p_rpc_proto_rawDescGZIP() []byte {
 	return file_rollkit_v1_p2p_rpc_proto_rawDescData
 }
 
-var file_rollkit_v1_p2p_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_rollkit_v1_p2p_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_rollkit_v1_p2p_rpc_proto_goTypes = []any{
-	(*GetPeerInfoResponse)(nil), // 0: rollkit.v1.GetPeerInfoResponse
-	(*GetNetInfoResponse)(nil),  // 1: rollkit.v1.GetNetInfoResponse
-	(*PeerInfo)(nil),            // 2: rollkit.v1.PeerInfo
-	(*NetInfo)(nil),             // 3: rollkit.v1.NetInfo
-	(*emptypb.Empty)(nil),       // 4: google.protobuf.Empty
+	(*GetPeerInfoResponse)(nil),   // 0: rollkit.v1.GetPeerInfoResponse
+	(*GetNetInfoResponse)(nil),    // 1: rollkit.v1.GetNetInfoResponse
+	(*PeerInfo)(nil),              // 2: rollkit.v1.PeerInfo
+	(*NetInfo)(nil),               // 3: rollkit.v1.NetInfo
+	(*PeerScore)(nil),             // 4: rollkit.v1.PeerScore
+	(*NetPeersResponse)(nil),      // 5: rollkit.v1.NetPeersResponse
+	(*BanPeerRequest)(nil),        // 6: rollkit.v1.BanPeerRequest
+	(*UnbanPeerRequest)(nil),      // 7: rollkit.v1.UnbanPeerRequest
+	(*PeerBandwidth)(nil),         // 8: rollkit.v1.PeerBandwidth
+	(*TopicStats)(nil),            // 9: rollkit.v1.TopicStats
+	(*NetStatsResponse)(nil),      // 10: rollkit.v1.NetStatsResponse
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 12: google.protobuf.Empty
 }
 var file_rollkit_v1_p2p_rpc_proto_depIdxs = []int32{
-	2, // 0: rollkit.v1.GetPeerInfoResponse.peers:type_name -> rollkit.v1.PeerInfo
-	3, // 1: rollkit.v1.GetNetInfoResponse.net_info:type_name -> rollkit.v1.NetInfo
-	4, // 2: rollkit.v1.P2PService.GetPeerInfo:input_type -> google.protobuf.Empty
-	4, // 3: rollkit.v1.P2PService.GetNetInfo:input_type -> google.protobuf.Empty
-	0, // 4: rollkit.v1.P2PService.GetPeerInfo:output_type -> rollkit.v1.GetPeerInfoResponse
-	1, // 5: rollkit.v1.P2PService.GetNetInfo:output_type -> rollkit.v1.GetNetInfoResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	2,  // 0: rollkit.v1.GetPeerInfoResponse.peers:type_name -> rollkit.v1.PeerInfo
+	3,  // 1: rollkit.v1.GetNetInfoResponse.net_info:type_name -> rollkit.v1.NetInfo
+	11, // 2: rollkit.v1.PeerScore.banned_until:type_name -> google.protobuf.Timestamp
+	4,  // 3: rollkit.v1.NetPeersResponse.peers:type_name -> rollkit.v1.PeerScore
+	8,  // 4: rollkit.v1.NetStatsResponse.peers:type_name -> rollkit.v1.PeerBandwidth
+	9,  // 5: rollkit.v1.NetStatsResponse.topics:type_name -> rollkit.v1.TopicStats
+	12, // 6: rollkit.v1.P2PService.GetPeerInfo:input_type -> google.protobuf.Empty
+	12, // 7: rollkit.v1.P2PService.GetNetInfo:input_type -> google.protobuf.Empty
+	12, // 8: rollkit.v1.P2PService.NetPeers:input_type -> google.protobuf.Empty
+	6,  // 9: rollkit.v1.P2PService.BanPeer:input_type -> rollkit.v1.BanPeerRequest
+	7,  // 10: rollkit.v1.P2PService.UnbanPeer:input_type -> rollkit.v1.UnbanPeerRequest
+	12, // 11: rollkit.v1.P2PService.NetStats:input_type -> google.protobuf.Empty
+	0,  // 12: rollkit.v1.P2PService.GetPeerInfo:output_type -> rollkit.v1.GetPeerInfoResponse
+	1,  // 13: rollkit.v1.P2PService.GetNetInfo:output_type -> rollkit.v1.GetNetInfoResponse
+	5,  // 14: rollkit.v1.P2PService.NetPeers:output_type -> rollkit.v1.NetPeersResponse
+	12, // 15: rollkit.v1.P2PService.BanPeer:output_type -> google.protobuf.Empty
+	12, // 16: rollkit.v1.P2PService.UnbanPeer:output_type -> google.protobuf.Empty
+	10, // 17: rollkit.v1.P2PService.NetStats:output_type -> rollkit.v1.NetStatsResponse
+	12, // [12:18] is the sub-list for method output_type
+	6,  // [6:12] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_rollkit_v1_p2p_rpc_proto_init() }
@@ -303,7 +911,7 @@ func file_rollkit_v1_p2p_rpc_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_p2p_rpc_proto_rawDesc), len(file_rollkit_v1_p2p_rpc_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   4,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
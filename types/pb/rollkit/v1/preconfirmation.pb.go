@@ -0,0 +1,252 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/preconfirmation.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// PreconfirmationStatus is the status of a preconfirmation with respect to the blocks of the chain.
+type PreconfirmationStatus int32
+
+const (
+	// Unknown status
+	PreconfirmationStatus_PRECONFIRMATION_STATUS_UNSPECIFIED PreconfirmationStatus = 0
+	// The transaction is not included yet, and blocks up to max_height may still include it
+	PreconfirmationStatus_PRECONFIRMATION_STATUS_PENDING PreconfirmationStatus = 1
+	// The transaction is included in a block at or below max_height
+	PreconfirmationStatus_PRECONFIRMATION_STATUS_INCLUDED PreconfirmationStatus = 2
+	// The transaction is not included in any block at or below max_height
+	PreconfirmationStatus_PRECONFIRMATION_STATUS_VIOLATED PreconfirmationStatus = 3
+)
+
+// Enum value maps for PreconfirmationStatus.
+var (
+	PreconfirmationStatus_name = map[int32]string{
+		0: "PRECONFIRMATION_STATUS_UNSPECIFIED",
+		1: "PRECONFIRMATION_STATUS_PENDING",
+		2: "PRECONFIRMATION_STATUS_INCLUDED",
+		3: "PRECONFIRMATION_STATUS_VIOLATED",
+	}
+	PreconfirmationStatus_value = map[string]int32{
+		"PRECONFIRMATION_STATUS_UNSPECIFIED": 0,
+		"PRECONFIRMATION_STATUS_PENDING":     1,
+		"PRECONFIRMATION_STATUS_INCLUDED":    2,
+		"PRECONFIRMATION_STATUS_VIOLATED":    3,
+	}
+)
+
+func (x PreconfirmationStatus) Enum() *PreconfirmationStatus {
+	p := new(PreconfirmationStatus)
+	*p = x
+	return p
+}
+
+func (x PreconfirmationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PreconfirmationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_rollkit_v1_preconfirmation_proto_enumTypes[0].Descriptor()
+}
+
+func (PreconfirmationStatus) Type() protoreflect.EnumType {
+	return &file_rollkit_v1_preconfirmation_proto_enumTypes[0]
+}
+
+func (x PreconfirmationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PreconfirmationStatus.Descriptor instead.
+func (PreconfirmationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_rollkit_v1_preconfirmation_proto_rawDescGZIP(), []int{0}
+}
+
+// Preconfirmation is a commitment signed by the sequencer when it accepts a transaction, promising to include
+// the transaction in a block at or below max_height. Users and bridges hold the sequencer accountable to it.
+type Preconfirmation struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChainId string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// SHA-256 hash of the transaction
+	TxHash []byte `protobuf:"bytes,2,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	// Height of the last block produced when the transaction was accepted
+	Height uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	// Height of the last block which may include the transaction
+	MaxHeight uint64                 `protobuf:"varint,4,opt,name=max_height,json=maxHeight,proto3" json:"max_height,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// Public key of the sequencer signing the preconfirmation
+	PubKey        []byte `protobuf:"bytes,6,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Signature     []byte `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Preconfirmation) Reset() {
+	*x = Preconfirmation{}
+	mi := &file_rollkit_v1_preconfirmation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Preconfirmation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Preconfirmation) ProtoMessage() {}
+
+func (x *Preconfirmation) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_preconfirmation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Preconfirmation.ProtoReflect.Descriptor instead.
+func (*Preconfirmation) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_preconfirmation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Preconfirmation) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *Preconfirmation) GetTxHash() []byte {
+	if x != nil {
+		return x.TxHash
+	}
+	return nil
+}
+
+func (x *Preconfirmation) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Preconfirmation) GetMaxHeight() uint64 {
+	if x != nil {
+		return x.MaxHeight
+	}
+	return 0
+}
+
+func (x *Preconfirmation) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Preconfirmation) GetPubKey() []byte {
+	if x != nil {
+		return x.PubKey
+	}
+	return nil
+}
+
+func (x *Preconfirmation) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+var File_rollkit_v1_preconfirmation_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_preconfirmation_proto_rawDesc = "" +
+	"\n" +
+	" rollkit/v1/preconfirmation.proto\x12\n" +
+	"rollkit.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xed\x01\n" +
+	"\x0fPreconfirmation\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x17\n" +
+	"\atx_hash\x18\x02 \x01(\fR\x06txHash\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x04R\x06height\x12\x1d\n" +
+	"\n" +
+	"max_height\x18\x04 \x01(\x04R\tmaxHeight\x128\n" +
+	"\ttimestamp\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x17\n" +
+	"\apub_key\x18\x06 \x01(\fR\x06pubKey\x12\x1c\n" +
+	"\tsignature\x18\a \x01(\fR\tsignature*\xad\x01\n" +
+	"\x15PreconfirmationStatus\x12&\n" +
+	"\"PRECONFIRMATION_STATUS_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1ePRECONFIRMATION_STATUS_PENDING\x10\x01\x12#\n" +
+	"\x1fPRECONFIRMATION_STATUS_INCLUDED\x10\x02\x12#\n" +
+	"\x1fPRECONFIRMATION_STATUS_VIOLATED\x10\x03B0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_preconfirmation_proto_rawDescOnce sync.Once
+	file_rollkit_v1_preconfirmation_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_preconfirmation_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_preconfirmation_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_preconfirmation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_preconfirmation_proto_rawDesc), len(file_rollkit_v1_preconfirmation_proto_rawDesc)))
+	})
+	return file_rollkit_v1_preconfirmation_proto_rawDescData
+}
+
+var file_rollkit_v1_preconfirmation_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_rollkit_v1_preconfirmation_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_rollkit_v1_preconfirmation_proto_goTypes = []any{
+	(PreconfirmationStatus)(0),    // 0: rollkit.v1.PreconfirmationStatus
+	(*Preconfirmation)(nil),       // 1: rollkit.v1.Preconfirmation
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_rollkit_v1_preconfirmation_proto_depIdxs = []int32{
+	2, // 0: rollkit.v1.Preconfirmation.timestamp:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_preconfirmation_proto_init() }
+func file_rollkit_v1_preconfirmation_proto_init() {
+	if File_rollkit_v1_preconfirmation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_preconfirmation_proto_rawDesc), len(file_rollkit_v1_preconfirmation_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rollkit_v1_preconfirmation_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_preconfirmation_proto_depIdxs,
+		EnumInfos:         file_rollkit_v1_preconfirmation_proto_enumTypes,
+		MessageInfos:      file_rollkit_v1_preconfirmation_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_preconfirmation_proto = out.File
+	file_rollkit_v1_preconfirmation_proto_goTypes = nil
+	file_rollkit_v1_preconfirmation_proto_depIdxs = nil
+}
@@ -110,7 +110,18 @@ type Header struct {
 	// validatorhash for compatibility with tendermint light client.
 	ValidatorHash []byte `protobuf:"bytes,11,opt,name=validator_hash,json=validatorHash,proto3" json:"validator_hash,omitempty"`
 	// Chain ID the block belongs to
-	ChainId       string `protobuf:"bytes,12,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ChainId string `protobuf:"bytes,12,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// Number of blocks ordered after the block whose resulting state app_hash is, zero if app_hash is the state
+	// after the previous block. Set by proposers executing blocks asynchronously.
+	ExecutionLag uint64 `protobuf:"varint,13,opt,name=execution_lag,json=executionLag,proto3" json:"execution_lag,omitempty"`
+	// Commitment to the lanes the transactions of the block are partitioned into by the executor, empty if the
+	// executor does not order transactions.
+	LanesHash []byte `protobuf:"bytes,14,opt,name=lanes_hash,json=lanesHash,proto3" json:"lanes_hash,omitempty"`
+	// Height of the latest DA block observed by the proposer when creating the block, zero if the proposer did not
+	// observe the DA layer. Never lower than in the previous header.
+	DaBlockHeight uint64 `protobuf:"varint,15,opt,name=da_block_height,json=daBlockHeight,proto3" json:"da_block_height,omitempty"`
+	// Hash of the DA block at da_block_height, empty if da_block_height is zero
+	DaBlockHash   []byte `protobuf:"bytes,16,opt,name=da_block_hash,json=daBlockHash,proto3" json:"da_block_hash,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -229,14 +240,49 @@ func (x *Header) GetChainId() string {
 	return ""
 }
 
+func (x *Header) GetExecutionLag() uint64 {
+	if x != nil {
+		return x.ExecutionLag
+	}
+	return 0
+}
+
+func (x *Header) GetLanesHash() []byte {
+	if x != nil {
+		return x.LanesHash
+	}
+	return nil
+}
+
+func (x *Header) GetDaBlockHeight() uint64 {
+	if x != nil {
+		return x.DaBlockHeight
+	}
+	return 0
+}
+
+func (x *Header) GetDaBlockHash() []byte {
+	if x != nil {
+		return x.DaBlockHash
+	}
+	return nil
+}
+
 // SignedHeader is a header with a signature and a validator set.
 type SignedHeader struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Header        *Header                `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
-	Signature     []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	Signer        *Signer                `protobuf:"bytes,3,opt,name=signer,proto3" json:"signer,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Header    *Header                `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Signature []byte                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Signer    *Signer                `protobuf:"bytes,3,opt,name=signer,proto3" json:"signer,omitempty"`
+	// Commitment to the validity proof of the block, attached before DA submission
+	ProofCommitment []byte `protobuf:"bytes,4,opt,name=proof_commitment,json=proofCommitment,proto3" json:"proof_commitment,omitempty"`
+	// Rotation of the sequencer key authorizing the signer, set on headers signed by a rotated key
+	Rotation *KeyRotation `protobuf:"bytes,5,opt,name=rotation,proto3" json:"rotation,omitempty"`
+	// Signatures of the data availability committee members storing the data of the block, set in
+	// commitments-only mode where the data is not posted to the DA layer
+	DacCertificate []*DACAttestation `protobuf:"bytes,6,rep,name=dac_certificate,json=dacCertificate,proto3" json:"dac_certificate,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *SignedHeader) Reset() {
@@ -290,6 +336,143 @@ func (x *SignedHeader) GetSigner() *Signer {
 	return nil
 }
 
+func (x *SignedHeader) GetProofCommitment() []byte {
+	if x != nil {
+		return x.ProofCommitment
+	}
+	return nil
+}
+
+func (x *SignedHeader) GetRotation() *KeyRotation {
+	if x != nil {
+		return x.Rotation
+	}
+	return nil
+}
+
+func (x *SignedHeader) GetDacCertificate() []*DACAttestation {
+	if x != nil {
+		return x.DacCertificate
+	}
+	return nil
+}
+
+// DACAttestation is the signature of a data availability committee member attesting that it stores the data of
+// a block and serves it on request.
+type DACAttestation struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Signer *Signer                `protobuf:"bytes,1,opt,name=signer,proto3" json:"signer,omitempty"`
+	// Signature of the DACStatement of the block
+	Signature     []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DACAttestation) Reset() {
+	*x = DACAttestation{}
+	mi := &file_rollkit_v1_rollkit_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DACAttestation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DACAttestation) ProtoMessage() {}
+
+func (x *DACAttestation) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_rollkit_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DACAttestation.ProtoReflect.Descriptor instead.
+func (*DACAttestation) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_rollkit_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DACAttestation) GetSigner() *Signer {
+	if x != nil {
+		return x.Signer
+	}
+	return nil
+}
+
+func (x *DACAttestation) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// DACStatement is the statement signed by data availability committee members storing the data of a block.
+type DACStatement struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Height        uint64                 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	DataHash      []byte                 `protobuf:"bytes,3,opt,name=data_hash,json=dataHash,proto3" json:"data_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DACStatement) Reset() {
+	*x = DACStatement{}
+	mi := &file_rollkit_v1_rollkit_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DACStatement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DACStatement) ProtoMessage() {}
+
+func (x *DACStatement) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_rollkit_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DACStatement.ProtoReflect.Descriptor instead.
+func (*DACStatement) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_rollkit_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DACStatement) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *DACStatement) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *DACStatement) GetDataHash() []byte {
+	if x != nil {
+		return x.DataHash
+	}
+	return nil
+}
+
 // Signer is a signer of a block in the blockchain.
 type Signer struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -303,7 +486,7 @@ type Signer struct {
 
 func (x *Signer) Reset() {
 	*x = Signer{}
-	mi := &file_rollkit_v1_rollkit_proto_msgTypes[3]
+	mi := &file_rollkit_v1_rollkit_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -315,7 +498,7 @@ func (x *Signer) String() string {
 func (*Signer) ProtoMessage() {}
 
 func (x *Signer) ProtoReflect() protoreflect.Message {
-	mi := &file_rollkit_v1_rollkit_proto_msgTypes[3]
+	mi := &file_rollkit_v1_rollkit_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -328,7 +511,7 @@ func (x *Signer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Signer.ProtoReflect.Descriptor instead.
 func (*Signer) Descriptor() ([]byte, []int) {
-	return file_rollkit_v1_rollkit_proto_rawDescGZIP(), []int{3}
+	return file_rollkit_v1_rollkit_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Signer) GetAddress() []byte {
@@ -362,7 +545,7 @@ type Metadata struct {
 
 func (x *Metadata) Reset() {
 	*x = Metadata{}
-	mi := &file_rollkit_v1_rollkit_proto_msgTypes[4]
+	mi := &file_rollkit_v1_rollkit_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -374,7 +557,7 @@ func (x *Metadata) String() string {
 func (*Metadata) ProtoMessage() {}
 
 func (x *Metadata) ProtoReflect() protoreflect.Message {
-	mi := &file_rollkit_v1_rollkit_proto_msgTypes[4]
+	mi := &file_rollkit_v1_rollkit_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -387,7 +570,7 @@ func (x *Metadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
 func (*Metadata) Descriptor() ([]byte, []int) {
-	return file_rollkit_v1_rollkit_proto_rawDescGZIP(), []int{4}
+	return file_rollkit_v1_rollkit_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *Metadata) GetChainId() string {
@@ -429,7 +612,7 @@ type Data struct {
 
 func (x *Data) Reset() {
 	*x = Data{}
-	mi := &file_rollkit_v1_rollkit_proto_msgTypes[5]
+	mi := &file_rollkit_v1_rollkit_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -441,7 +624,7 @@ func (x *Data) String() string {
 func (*Data) ProtoMessage() {}
 
 func (x *Data) ProtoReflect() protoreflect.Message {
-	mi := &file_rollkit_v1_rollkit_proto_msgTypes[5]
+	mi := &file_rollkit_v1_rollkit_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -454,7 +637,7 @@ func (x *Data) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Data.ProtoReflect.Descriptor instead.
 func (*Data) Descriptor() ([]byte, []int) {
-	return file_rollkit_v1_rollkit_proto_rawDescGZIP(), []int{5}
+	return file_rollkit_v1_rollkit_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Data) GetMetadata() *Metadata {
@@ -490,7 +673,7 @@ type Vote struct {
 
 func (x *Vote) Reset() {
 	*x = Vote{}
-	mi := &file_rollkit_v1_rollkit_proto_msgTypes[6]
+	mi := &file_rollkit_v1_rollkit_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -502,7 +685,7 @@ func (x *Vote) String() string {
 func (*Vote) ProtoMessage() {}
 
 func (x *Vote) ProtoReflect() protoreflect.Message {
-	mi := &file_rollkit_v1_rollkit_proto_msgTypes[6]
+	mi := &file_rollkit_v1_rollkit_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -515,7 +698,7 @@ func (x *Vote) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Vote.ProtoReflect.Descriptor instead.
 func (*Vote) Descriptor() ([]byte, []int) {
-	return file_rollkit_v1_rollkit_proto_rawDescGZIP(), []int{6}
+	return file_rollkit_v1_rollkit_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Vote) GetChainId() string {
@@ -558,10 +741,10 @@ var File_rollkit_v1_rollkit_proto protoreflect.FileDescriptor
 const file_rollkit_v1_rollkit_proto_rawDesc = "" +
 	"\n" +
 	"\x18rollkit/v1/rollkit.proto\x12\n" +
-	"rollkit.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"1\n" +
+	"rollkit.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x19rollkit/v1/rotation.proto\"1\n" +
 	"\aVersion\x12\x14\n" +
 	"\x05block\x18\x01 \x01(\x04R\x05block\x12\x10\n" +
-	"\x03app\x18\x02 \x01(\x04R\x03app\"\xaf\x03\n" +
+	"\x03app\x18\x02 \x01(\x04R\x03app\"\xbf\x04\n" +
 	"\x06Header\x12-\n" +
 	"\aversion\x18\x01 \x01(\v2\x13.rollkit.v1.VersionR\aversion\x12\x16\n" +
 	"\x06height\x18\x02 \x01(\x04R\x06height\x12\x12\n" +
@@ -575,11 +758,26 @@ const file_rollkit_v1_rollkit_proto_rawDesc = "" +
 	"\x10proposer_address\x18\n" +
 	" \x01(\fR\x0fproposerAddress\x12%\n" +
 	"\x0evalidator_hash\x18\v \x01(\fR\rvalidatorHash\x12\x19\n" +
-	"\bchain_id\x18\f \x01(\tR\achainId\"\x84\x01\n" +
+	"\bchain_id\x18\f \x01(\tR\achainId\x12#\n" +
+	"\rexecution_lag\x18\r \x01(\x04R\fexecutionLag\x12\x1d\n" +
+	"\n" +
+	"lanes_hash\x18\x0e \x01(\fR\tlanesHash\x12&\n" +
+	"\x0fda_block_height\x18\x0f \x01(\x04R\rdaBlockHeight\x12\"\n" +
+	"\rda_block_hash\x18\x10 \x01(\fR\vdaBlockHash\"\xa9\x02\n" +
 	"\fSignedHeader\x12*\n" +
 	"\x06header\x18\x01 \x01(\v2\x12.rollkit.v1.HeaderR\x06header\x12\x1c\n" +
 	"\tsignature\x18\x02 \x01(\fR\tsignature\x12*\n" +
-	"\x06signer\x18\x03 \x01(\v2\x12.rollkit.v1.SignerR\x06signer\";\n" +
+	"\x06signer\x18\x03 \x01(\v2\x12.rollkit.v1.SignerR\x06signer\x12)\n" +
+	"\x10proof_commitment\x18\x04 \x01(\fR\x0fproofCommitment\x123\n" +
+	"\brotation\x18\x05 \x01(\v2\x17.rollkit.v1.KeyRotationR\brotation\x12C\n" +
+	"\x0fdac_certificate\x18\x06 \x03(\v2\x1a.rollkit.v1.DACAttestationR\x0edacCertificate\"Z\n" +
+	"\x0eDACAttestation\x12*\n" +
+	"\x06signer\x18\x01 \x01(\v2\x12.rollkit.v1.SignerR\x06signer\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\fR\tsignature\"^\n" +
+	"\fDACStatement\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height\x12\x1b\n" +
+	"\tdata_hash\x18\x03 \x01(\fR\bdataHash\";\n" +
 	"\x06Signer\x12\x18\n" +
 	"\aaddress\x18\x01 \x01(\fR\aaddress\x12\x17\n" +
 	"\apub_key\x18\x02 \x01(\fR\x06pubKey\"w\n" +
@@ -610,28 +808,34 @@ func file_rollkit_v1_rollkit_proto_rawDescGZIP() []byte {
 	return file_rollkit_v1_rollkit_proto_rawDescData
 }
 
-var file_rollkit_v1_rollkit_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_rollkit_v1_rollkit_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_rollkit_v1_rollkit_proto_goTypes = []any{
 	(*Version)(nil),               // 0: rollkit.v1.Version
 	(*Header)(nil),                // 1: rollkit.v1.Header
 	(*SignedHeader)(nil),          // 2: rollkit.v1.SignedHeader
-	(*Signer)(nil),                // 3: rollkit.v1.Signer
-	(*Metadata)(nil),              // 4: rollkit.v1.Metadata
-	(*Data)(nil),                  // 5: rollkit.v1.Data
-	(*Vote)(nil),                  // 6: rollkit.v1.Vote
-	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*DACAttestation)(nil),        // 3: rollkit.v1.DACAttestation
+	(*DACStatement)(nil),          // 4: rollkit.v1.DACStatement
+	(*Signer)(nil),                // 5: rollkit.v1.Signer
+	(*Metadata)(nil),              // 6: rollkit.v1.Metadata
+	(*Data)(nil),                  // 7: rollkit.v1.Data
+	(*Vote)(nil),                  // 8: rollkit.v1.Vote
+	(*KeyRotation)(nil),           // 9: rollkit.v1.KeyRotation
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
 }
 var file_rollkit_v1_rollkit_proto_depIdxs = []int32{
-	0, // 0: rollkit.v1.Header.version:type_name -> rollkit.v1.Version
-	1, // 1: rollkit.v1.SignedHeader.header:type_name -> rollkit.v1.Header
-	3, // 2: rollkit.v1.SignedHeader.signer:type_name -> rollkit.v1.Signer
-	4, // 3: rollkit.v1.Data.metadata:type_name -> rollkit.v1.Metadata
-	7, // 4: rollkit.v1.Vote.timestamp:type_name -> google.protobuf.Timestamp
-	5, // [5:5] is the sub-list for method output_type
-	5, // [5:5] is the sub-list for method input_type
-	5, // [5:5] is the sub-list for extension type_name
-	5, // [5:5] is the sub-list for extension extendee
-	0, // [0:5] is the sub-list for field type_name
+	0,  // 0: rollkit.v1.Header.version:type_name -> rollkit.v1.Version
+	1,  // 1: rollkit.v1.SignedHeader.header:type_name -> rollkit.v1.Header
+	5,  // 2: rollkit.v1.SignedHeader.signer:type_name -> rollkit.v1.Signer
+	9,  // 3: rollkit.v1.SignedHeader.rotation:type_name -> rollkit.v1.KeyRotation
+	3,  // 4: rollkit.v1.SignedHeader.dac_certificate:type_name -> rollkit.v1.DACAttestation
+	5,  // 5: rollkit.v1.DACAttestation.signer:type_name -> rollkit.v1.Signer
+	6,  // 6: rollkit.v1.Data.metadata:type_name -> rollkit.v1.Metadata
+	10, // 7: rollkit.v1.Vote.timestamp:type_name -> google.protobuf.Timestamp
+	8,  // [8:8] is the sub-list for method output_type
+	8,  // [8:8] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_rollkit_v1_rollkit_proto_init() }
@@ -639,13 +843,14 @@ func file_rollkit_v1_rollkit_proto_init() {
 	if File_rollkit_v1_rollkit_proto != nil {
 		return
 	}
+	file_rollkit_v1_rotation_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_rollkit_proto_rawDesc), len(file_rollkit_v1_rollkit_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
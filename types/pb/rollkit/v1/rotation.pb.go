@@ -0,0 +1,215 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/rotation.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// KeyRotation is posted to the DA layer by the sequencer to rotate its signing key: headers from the
+// given height on must be signed by the new key. It is signed by the key it replaces, and carried by
+// the headers signed by the new key so that header sync can follow the rotation.
+// Field numbers start at 32 so that rotations are never decoded as headers, batches or lease claims
+// sharing the same namespace.
+type KeyRotation struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChainId string                 `protobuf:"bytes,32,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// First block height whose header must be signed by the new key
+	Height    uint64 `protobuf:"varint,33,opt,name=height,proto3" json:"height,omitempty"`
+	NewPubKey []byte `protobuf:"bytes,34,opt,name=new_pub_key,json=newPubKey,proto3" json:"new_pub_key,omitempty"`
+	// Public key of the sequencer signing the rotation
+	PubKey        []byte `protobuf:"bytes,35,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Signature     []byte `protobuf:"bytes,36,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyRotation) Reset() {
+	*x = KeyRotation{}
+	mi := &file_rollkit_v1_rotation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyRotation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRotation) ProtoMessage() {}
+
+func (x *KeyRotation) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_rotation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRotation.ProtoReflect.Descriptor instead.
+func (*KeyRotation) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_rotation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *KeyRotation) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *KeyRotation) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *KeyRotation) GetNewPubKey() []byte {
+	if x != nil {
+		return x.NewPubKey
+	}
+	return nil
+}
+
+func (x *KeyRotation) GetPubKey() []byte {
+	if x != nil {
+		return x.PubKey
+	}
+	return nil
+}
+
+func (x *KeyRotation) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// KeyRotations lists the key rotations applied by a node, ordered by height.
+type KeyRotations struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rotations     []*KeyRotation         `protobuf:"bytes,1,rep,name=rotations,proto3" json:"rotations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *KeyRotations) Reset() {
+	*x = KeyRotations{}
+	mi := &file_rollkit_v1_rotation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *KeyRotations) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeyRotations) ProtoMessage() {}
+
+func (x *KeyRotations) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_rotation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeyRotations.ProtoReflect.Descriptor instead.
+func (*KeyRotations) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_rotation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KeyRotations) GetRotations() []*KeyRotation {
+	if x != nil {
+		return x.Rotations
+	}
+	return nil
+}
+
+var File_rollkit_v1_rotation_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_rotation_proto_rawDesc = "" +
+	"\n" +
+	"\x19rollkit/v1/rotation.proto\x12\n" +
+	"rollkit.v1\"\x97\x01\n" +
+	"\vKeyRotation\x12\x19\n" +
+	"\bchain_id\x18  \x01(\tR\achainId\x12\x16\n" +
+	"\x06height\x18! \x01(\x04R\x06height\x12\x1e\n" +
+	"\vnew_pub_key\x18\" \x01(\fR\tnewPubKey\x12\x17\n" +
+	"\apub_key\x18# \x01(\fR\x06pubKey\x12\x1c\n" +
+	"\tsignature\x18$ \x01(\fR\tsignature\"E\n" +
+	"\fKeyRotations\x125\n" +
+	"\trotations\x18\x01 \x03(\v2\x17.rollkit.v1.KeyRotationR\trotationsB0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_rotation_proto_rawDescOnce sync.Once
+	file_rollkit_v1_rotation_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_rotation_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_rotation_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_rotation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_rotation_proto_rawDesc), len(file_rollkit_v1_rotation_proto_rawDesc)))
+	})
+	return file_rollkit_v1_rotation_proto_rawDescData
+}
+
+var file_rollkit_v1_rotation_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rollkit_v1_rotation_proto_goTypes = []any{
+	(*KeyRotation)(nil),  // 0: rollkit.v1.KeyRotation
+	(*KeyRotations)(nil), // 1: rollkit.v1.KeyRotations
+}
+var file_rollkit_v1_rotation_proto_depIdxs = []int32{
+	0, // 0: rollkit.v1.KeyRotations.rotations:type_name -> rollkit.v1.KeyRotation
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_rotation_proto_init() }
+func file_rollkit_v1_rotation_proto_init() {
+	if File_rollkit_v1_rotation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_rotation_proto_rawDesc), len(file_rollkit_v1_rotation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rollkit_v1_rotation_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_rotation_proto_depIdxs,
+		MessageInfos:      file_rollkit_v1_rotation_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_rotation_proto = out.File
+	file_rollkit_v1_rotation_proto_goTypes = nil
+	file_rollkit_v1_rotation_proto_depIdxs = nil
+}
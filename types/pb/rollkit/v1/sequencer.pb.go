@@ -0,0 +1,435 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/sequencer.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SubmitBatchTxsRequest defines the request for submitting a batch of transactions
+type SubmitBatchTxsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unique identifier of the rollup chain
+	RollupId      []byte `protobuf:"bytes,1,opt,name=rollup_id,json=rollupId,proto3" json:"rollup_id,omitempty"`
+	Batch         *Batch `protobuf:"bytes,2,opt,name=batch,proto3" json:"batch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitBatchTxsRequest) Reset() {
+	*x = SubmitBatchTxsRequest{}
+	mi := &file_rollkit_v1_sequencer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitBatchTxsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitBatchTxsRequest) ProtoMessage() {}
+
+func (x *SubmitBatchTxsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_sequencer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitBatchTxsRequest.ProtoReflect.Descriptor instead.
+func (*SubmitBatchTxsRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_sequencer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubmitBatchTxsRequest) GetRollupId() []byte {
+	if x != nil {
+		return x.RollupId
+	}
+	return nil
+}
+
+func (x *SubmitBatchTxsRequest) GetBatch() *Batch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+// SubmitBatchTxsResponse defines the response for submitting a batch of transactions
+type SubmitBatchTxsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitBatchTxsResponse) Reset() {
+	*x = SubmitBatchTxsResponse{}
+	mi := &file_rollkit_v1_sequencer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitBatchTxsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitBatchTxsResponse) ProtoMessage() {}
+
+func (x *SubmitBatchTxsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_sequencer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitBatchTxsResponse.ProtoReflect.Descriptor instead.
+func (*SubmitBatchTxsResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_sequencer_proto_rawDescGZIP(), []int{1}
+}
+
+// GetNextBatchRequest defines the request for getting the next batch of transactions
+type GetNextBatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unique identifier of the rollup chain
+	RollupId []byte `protobuf:"bytes,1,opt,name=rollup_id,json=rollupId,proto3" json:"rollup_id,omitempty"`
+	// data identifying the last batch received by the rollup
+	LastBatchData [][]byte `protobuf:"bytes,2,rep,name=last_batch_data,json=lastBatchData,proto3" json:"last_batch_data,omitempty"`
+	// maximum size in bytes of the transactions of the batch, 0 for no limit
+	MaxBytes      uint64 `protobuf:"varint,3,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNextBatchRequest) Reset() {
+	*x = GetNextBatchRequest{}
+	mi := &file_rollkit_v1_sequencer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNextBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNextBatchRequest) ProtoMessage() {}
+
+func (x *GetNextBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_sequencer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNextBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetNextBatchRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_sequencer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetNextBatchRequest) GetRollupId() []byte {
+	if x != nil {
+		return x.RollupId
+	}
+	return nil
+}
+
+func (x *GetNextBatchRequest) GetLastBatchData() [][]byte {
+	if x != nil {
+		return x.LastBatchData
+	}
+	return nil
+}
+
+func (x *GetNextBatchRequest) GetMaxBytes() uint64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+// GetNextBatchResponse defines the response for getting the next batch of transactions
+type GetNextBatchResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Batch     *Batch                 `protobuf:"bytes,1,opt,name=batch,proto3" json:"batch,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// data identifying the batch, used to verify it and to request the next batch
+	BatchData     [][]byte `protobuf:"bytes,3,rep,name=batch_data,json=batchData,proto3" json:"batch_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNextBatchResponse) Reset() {
+	*x = GetNextBatchResponse{}
+	mi := &file_rollkit_v1_sequencer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNextBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNextBatchResponse) ProtoMessage() {}
+
+func (x *GetNextBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_sequencer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNextBatchResponse.ProtoReflect.Descriptor instead.
+func (*GetNextBatchResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_sequencer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetNextBatchResponse) GetBatch() *Batch {
+	if x != nil {
+		return x.Batch
+	}
+	return nil
+}
+
+func (x *GetNextBatchResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *GetNextBatchResponse) GetBatchData() [][]byte {
+	if x != nil {
+		return x.BatchData
+	}
+	return nil
+}
+
+// VerifyBatchRequest defines the request for verifying a batch of transactions
+type VerifyBatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unique identifier of the rollup chain
+	RollupId []byte `protobuf:"bytes,1,opt,name=rollup_id,json=rollupId,proto3" json:"rollup_id,omitempty"`
+	// data identifying the batch to verify
+	BatchData     [][]byte `protobuf:"bytes,2,rep,name=batch_data,json=batchData,proto3" json:"batch_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyBatchRequest) Reset() {
+	*x = VerifyBatchRequest{}
+	mi := &file_rollkit_v1_sequencer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBatchRequest) ProtoMessage() {}
+
+func (x *VerifyBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_sequencer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBatchRequest.ProtoReflect.Descriptor instead.
+func (*VerifyBatchRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_sequencer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VerifyBatchRequest) GetRollupId() []byte {
+	if x != nil {
+		return x.RollupId
+	}
+	return nil
+}
+
+func (x *VerifyBatchRequest) GetBatchData() [][]byte {
+	if x != nil {
+		return x.BatchData
+	}
+	return nil
+}
+
+// VerifyBatchResponse defines the response for verifying a batch of transactions
+type VerifyBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        bool                   `protobuf:"varint,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyBatchResponse) Reset() {
+	*x = VerifyBatchResponse{}
+	mi := &file_rollkit_v1_sequencer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyBatchResponse) ProtoMessage() {}
+
+func (x *VerifyBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_sequencer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyBatchResponse.ProtoReflect.Descriptor instead.
+func (*VerifyBatchResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_sequencer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VerifyBatchResponse) GetStatus() bool {
+	if x != nil {
+		return x.Status
+	}
+	return false
+}
+
+var File_rollkit_v1_sequencer_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_sequencer_proto_rawDesc = "" +
+	"\n" +
+	"\x1arollkit/v1/sequencer.proto\x12\n" +
+	"rollkit.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16rollkit/v1/batch.proto\"]\n" +
+	"\x15SubmitBatchTxsRequest\x12\x1b\n" +
+	"\trollup_id\x18\x01 \x01(\fR\brollupId\x12'\n" +
+	"\x05batch\x18\x02 \x01(\v2\x11.rollkit.v1.BatchR\x05batch\"\x18\n" +
+	"\x16SubmitBatchTxsResponse\"w\n" +
+	"\x13GetNextBatchRequest\x12\x1b\n" +
+	"\trollup_id\x18\x01 \x01(\fR\brollupId\x12&\n" +
+	"\x0flast_batch_data\x18\x02 \x03(\fR\rlastBatchData\x12\x1b\n" +
+	"\tmax_bytes\x18\x03 \x01(\x04R\bmaxBytes\"\x98\x01\n" +
+	"\x14GetNextBatchResponse\x12'\n" +
+	"\x05batch\x18\x01 \x01(\v2\x11.rollkit.v1.BatchR\x05batch\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x1d\n" +
+	"\n" +
+	"batch_data\x18\x03 \x03(\fR\tbatchData\"P\n" +
+	"\x12VerifyBatchRequest\x12\x1b\n" +
+	"\trollup_id\x18\x01 \x01(\fR\brollupId\x12\x1d\n" +
+	"\n" +
+	"batch_data\x18\x02 \x03(\fR\tbatchData\"-\n" +
+	"\x13VerifyBatchResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\bR\x06status2\x94\x02\n" +
+	"\x10SequencerService\x12Y\n" +
+	"\x0eSubmitBatchTxs\x12!.rollkit.v1.SubmitBatchTxsRequest\x1a\".rollkit.v1.SubmitBatchTxsResponse\"\x00\x12S\n" +
+	"\fGetNextBatch\x12\x1f.rollkit.v1.GetNextBatchRequest\x1a .rollkit.v1.GetNextBatchResponse\"\x00\x12P\n" +
+	"\vVerifyBatch\x12\x1e.rollkit.v1.VerifyBatchRequest\x1a\x1f.rollkit.v1.VerifyBatchResponse\"\x00B0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_sequencer_proto_rawDescOnce sync.Once
+	file_rollkit_v1_sequencer_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_sequencer_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_sequencer_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_sequencer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_sequencer_proto_rawDesc), len(file_rollkit_v1_sequencer_proto_rawDesc)))
+	})
+	return file_rollkit_v1_sequencer_proto_rawDescData
+}
+
+var file_rollkit_v1_sequencer_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_rollkit_v1_sequencer_proto_goTypes = []any{
+	(*SubmitBatchTxsRequest)(nil),  // 0: rollkit.v1.SubmitBatchTxsRequest
+	(*SubmitBatchTxsResponse)(nil), // 1: rollkit.v1.SubmitBatchTxsResponse
+	(*GetNextBatchRequest)(nil),    // 2: rollkit.v1.GetNextBatchRequest
+	(*GetNextBatchResponse)(nil),   // 3: rollkit.v1.GetNextBatchResponse
+	(*VerifyBatchRequest)(nil),     // 4: rollkit.v1.VerifyBatchRequest
+	(*VerifyBatchResponse)(nil),    // 5: rollkit.v1.VerifyBatchResponse
+	(*Batch)(nil),                  // 6: rollkit.v1.Batch
+	(*timestamppb.Timestamp)(nil),  // 7: google.protobuf.Timestamp
+}
+var file_rollkit_v1_sequencer_proto_depIdxs = []int32{
+	6, // 0: rollkit.v1.SubmitBatchTxsRequest.batch:type_name -> rollkit.v1.Batch
+	6, // 1: rollkit.v1.GetNextBatchResponse.batch:type_name -> rollkit.v1.Batch
+	7, // 2: rollkit.v1.GetNextBatchResponse.timestamp:type_name -> google.protobuf.Timestamp
+	0, // 3: rollkit.v1.SequencerService.SubmitBatchTxs:input_type -> rollkit.v1.SubmitBatchTxsRequest
+	2, // 4: rollkit.v1.SequencerService.GetNextBatch:input_type -> rollkit.v1.GetNextBatchRequest
+	4, // 5: rollkit.v1.SequencerService.VerifyBatch:input_type -> rollkit.v1.VerifyBatchRequest
+	1, // 6: rollkit.v1.SequencerService.SubmitBatchTxs:output_type -> rollkit.v1.SubmitBatchTxsResponse
+	3, // 7: rollkit.v1.SequencerService.GetNextBatch:output_type -> rollkit.v1.GetNextBatchResponse
+	5, // 8: rollkit.v1.SequencerService.VerifyBatch:output_type -> rollkit.v1.VerifyBatchResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_sequencer_proto_init() }
+func file_rollkit_v1_sequencer_proto_init() {
+	if File_rollkit_v1_sequencer_proto != nil {
+		return
+	}
+	file_rollkit_v1_batch_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_sequencer_proto_rawDesc), len(file_rollkit_v1_sequencer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rollkit_v1_sequencer_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_sequencer_proto_depIdxs,
+		MessageInfos:      file_rollkit_v1_sequencer_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_sequencer_proto = out.File
+	file_rollkit_v1_sequencer_proto_goTypes = nil
+	file_rollkit_v1_sequencer_proto_depIdxs = nil
+}
@@ -0,0 +1,302 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/snapshot.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SnapshotManifest describes a state snapshot taken after executing the block at height.
+type SnapshotManifest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	State         *State                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	Header        *SignedHeader          `protobuf:"bytes,3,opt,name=header,proto3" json:"header,omitempty"`
+	Data          *Data                  `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	ChunkHashes   [][]byte               `protobuf:"bytes,5,rep,name=chunk_hashes,json=chunkHashes,proto3" json:"chunk_hashes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotManifest) Reset() {
+	*x = SnapshotManifest{}
+	mi := &file_rollkit_v1_snapshot_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotManifest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotManifest) ProtoMessage() {}
+
+func (x *SnapshotManifest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_snapshot_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotManifest.ProtoReflect.Descriptor instead.
+func (*SnapshotManifest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_snapshot_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SnapshotManifest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *SnapshotManifest) GetState() *State {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *SnapshotManifest) GetHeader() *SignedHeader {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *SnapshotManifest) GetData() *Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SnapshotManifest) GetChunkHashes() [][]byte {
+	if x != nil {
+		return x.ChunkHashes
+	}
+	return nil
+}
+
+// SnapshotRequest requests either the latest snapshot manifest or a single chunk of a snapshot.
+type SnapshotRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// manifest requests the latest available snapshot manifest when set.
+	Manifest      bool   `protobuf:"varint,1,opt,name=manifest,proto3" json:"manifest,omitempty"`
+	Height        uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Chunk         uint32 `protobuf:"varint,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotRequest) Reset() {
+	*x = SnapshotRequest{}
+	mi := &file_rollkit_v1_snapshot_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRequest) ProtoMessage() {}
+
+func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_snapshot_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_snapshot_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SnapshotRequest) GetManifest() bool {
+	if x != nil {
+		return x.Manifest
+	}
+	return false
+}
+
+func (x *SnapshotRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *SnapshotRequest) GetChunk() uint32 {
+	if x != nil {
+		return x.Chunk
+	}
+	return 0
+}
+
+// SnapshotResponse is the response to a SnapshotRequest.
+type SnapshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Manifest      *SnapshotManifest      `protobuf:"bytes,1,opt,name=manifest,proto3" json:"manifest,omitempty"`
+	Chunk         []byte                 `protobuf:"bytes,2,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnapshotResponse) Reset() {
+	*x = SnapshotResponse{}
+	mi := &file_rollkit_v1_snapshot_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnapshotResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotResponse) ProtoMessage() {}
+
+func (x *SnapshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_snapshot_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotResponse.ProtoReflect.Descriptor instead.
+func (*SnapshotResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_snapshot_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SnapshotResponse) GetManifest() *SnapshotManifest {
+	if x != nil {
+		return x.Manifest
+	}
+	return nil
+}
+
+func (x *SnapshotResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *SnapshotResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_rollkit_v1_snapshot_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_snapshot_proto_rawDesc = "" +
+	"\n" +
+	"\x19rollkit/v1/snapshot.proto\x12\n" +
+	"rollkit.v1\x1a\x18rollkit/v1/rollkit.proto\x1a\x16rollkit/v1/state.proto\"\xce\x01\n" +
+	"\x10SnapshotManifest\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\x12'\n" +
+	"\x05state\x18\x02 \x01(\v2\x11.rollkit.v1.StateR\x05state\x120\n" +
+	"\x06header\x18\x03 \x01(\v2\x18.rollkit.v1.SignedHeaderR\x06header\x12$\n" +
+	"\x04data\x18\x04 \x01(\v2\x10.rollkit.v1.DataR\x04data\x12!\n" +
+	"\fchunk_hashes\x18\x05 \x03(\fR\vchunkHashes\"[\n" +
+	"\x0fSnapshotRequest\x12\x1a\n" +
+	"\bmanifest\x18\x01 \x01(\bR\bmanifest\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height\x12\x14\n" +
+	"\x05chunk\x18\x03 \x01(\rR\x05chunk\"x\n" +
+	"\x10SnapshotResponse\x128\n" +
+	"\bmanifest\x18\x01 \x01(\v2\x1c.rollkit.v1.SnapshotManifestR\bmanifest\x12\x14\n" +
+	"\x05chunk\x18\x02 \x01(\fR\x05chunk\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05errorB0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_snapshot_proto_rawDescOnce sync.Once
+	file_rollkit_v1_snapshot_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_snapshot_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_snapshot_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_snapshot_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_snapshot_proto_rawDesc), len(file_rollkit_v1_snapshot_proto_rawDesc)))
+	})
+	return file_rollkit_v1_snapshot_proto_rawDescData
+}
+
+var file_rollkit_v1_snapshot_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_rollkit_v1_snapshot_proto_goTypes = []any{
+	(*SnapshotManifest)(nil), // 0: rollkit.v1.SnapshotManifest
+	(*SnapshotRequest)(nil),  // 1: rollkit.v1.SnapshotRequest
+	(*SnapshotResponse)(nil), // 2: rollkit.v1.SnapshotResponse
+	(*State)(nil),            // 3: rollkit.v1.State
+	(*SignedHeader)(nil),     // 4: rollkit.v1.SignedHeader
+	(*Data)(nil),             // 5: rollkit.v1.Data
+}
+var file_rollkit_v1_snapshot_proto_depIdxs = []int32{
+	3, // 0: rollkit.v1.SnapshotManifest.state:type_name -> rollkit.v1.State
+	4, // 1: rollkit.v1.SnapshotManifest.header:type_name -> rollkit.v1.SignedHeader
+	5, // 2: rollkit.v1.SnapshotManifest.data:type_name -> rollkit.v1.Data
+	0, // 3: rollkit.v1.SnapshotResponse.manifest:type_name -> rollkit.v1.SnapshotManifest
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_snapshot_proto_init() }
+func file_rollkit_v1_snapshot_proto_init() {
+	if File_rollkit_v1_snapshot_proto != nil {
+		return
+	}
+	file_rollkit_v1_rollkit_proto_init()
+	file_rollkit_v1_state_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_snapshot_proto_rawDesc), len(file_rollkit_v1_snapshot_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rollkit_v1_snapshot_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_snapshot_proto_depIdxs,
+		MessageInfos:      file_rollkit_v1_snapshot_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_snapshot_proto = out.File
+	file_rollkit_v1_snapshot_proto_goTypes = nil
+	file_rollkit_v1_snapshot_proto_depIdxs = nil
+}
@@ -123,6 +123,141 @@ func (x *State) GetAppHash() []byte {
 	return nil
 }
 
+// DABlobMetadata locates a blob in the DA layer.
+type DABlobMetadata struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Height of the DA block including the blob
+	DaHeight uint64 `protobuf:"varint,1,opt,name=da_height,json=daHeight,proto3" json:"da_height,omitempty"`
+	// DA namespace of the blob
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// ID of the blob in the DA layer
+	Id []byte `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	// Commitment to the blob, empty if the DA layer IDs do not embed it
+	Commitment    []byte `protobuf:"bytes,4,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DABlobMetadata) Reset() {
+	*x = DABlobMetadata{}
+	mi := &file_rollkit_v1_state_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DABlobMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DABlobMetadata) ProtoMessage() {}
+
+func (x *DABlobMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_state_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DABlobMetadata.ProtoReflect.Descriptor instead.
+func (*DABlobMetadata) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_state_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DABlobMetadata) GetDaHeight() uint64 {
+	if x != nil {
+		return x.DaHeight
+	}
+	return 0
+}
+
+func (x *DABlobMetadata) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DABlobMetadata) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *DABlobMetadata) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+// DAMetadata holds the DA blobs including the header and data of a DA included block.
+type DAMetadata struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Height uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Header *DABlobMetadata        `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	// Unset for blocks without transactions, whose data is not submitted to the DA layer
+	Data          *DABlobMetadata `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DAMetadata) Reset() {
+	*x = DAMetadata{}
+	mi := &file_rollkit_v1_state_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DAMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DAMetadata) ProtoMessage() {}
+
+func (x *DAMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_state_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DAMetadata.ProtoReflect.Descriptor instead.
+func (*DAMetadata) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_state_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DAMetadata) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *DAMetadata) GetHeader() *DABlobMetadata {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *DAMetadata) GetData() *DABlobMetadata {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
 var File_rollkit_v1_state_proto protoreflect.FileDescriptor
 
 const file_rollkit_v1_state_proto_rawDesc = "" +
@@ -137,7 +272,19 @@ const file_rollkit_v1_state_proto_rawDesc = "" +
 	"\x0flast_block_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rlastBlockTime\x12\x1b\n" +
 	"\tda_height\x18\x06 \x01(\x04R\bdaHeight\x12*\n" +
 	"\x11last_results_hash\x18\a \x01(\fR\x0flastResultsHash\x12\x19\n" +
-	"\bapp_hash\x18\b \x01(\fR\aappHashB0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+	"\bapp_hash\x18\b \x01(\fR\aappHash\"{\n" +
+	"\x0eDABlobMetadata\x12\x1b\n" +
+	"\tda_height\x18\x01 \x01(\x04R\bdaHeight\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\fR\x02id\x12\x1e\n" +
+	"\n" +
+	"commitment\x18\x04 \x01(\fR\n" +
+	"commitment\"\x88\x01\n" +
+	"\n" +
+	"DAMetadata\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\x122\n" +
+	"\x06header\x18\x02 \x01(\v2\x1a.rollkit.v1.DABlobMetadataR\x06header\x12.\n" +
+	"\x04data\x18\x03 \x01(\v2\x1a.rollkit.v1.DABlobMetadataR\x04dataB0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
 
 var (
 	file_rollkit_v1_state_proto_rawDescOnce sync.Once
@@ -151,20 +298,24 @@ func file_rollkit_v1_state_proto_rawDescGZIP() []byte {
 	return file_rollkit_v1_state_proto_rawDescData
 }
 
-var file_rollkit_v1_state_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_rollkit_v1_state_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_rollkit_v1_state_proto_goTypes = []any{
 	(*State)(nil),                 // 0: rollkit.v1.State
-	(*Version)(nil),               // 1: rollkit.v1.Version
-	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*DABlobMetadata)(nil),        // 1: rollkit.v1.DABlobMetadata
+	(*DAMetadata)(nil),            // 2: rollkit.v1.DAMetadata
+	(*Version)(nil),               // 3: rollkit.v1.Version
+	(*timestamppb.Timestamp)(nil), // 4: google.protobuf.Timestamp
 }
 var file_rollkit_v1_state_proto_depIdxs = []int32{
-	1, // 0: rollkit.v1.State.version:type_name -> rollkit.v1.Version
-	2, // 1: rollkit.v1.State.last_block_time:type_name -> google.protobuf.Timestamp
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 0: rollkit.v1.State.version:type_name -> rollkit.v1.Version
+	4, // 1: rollkit.v1.State.last_block_time:type_name -> google.protobuf.Timestamp
+	1, // 2: rollkit.v1.DAMetadata.header:type_name -> rollkit.v1.DABlobMetadata
+	1, // 3: rollkit.v1.DAMetadata.data:type_name -> rollkit.v1.DABlobMetadata
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_rollkit_v1_state_proto_init() }
@@ -179,7 +330,7 @@ func file_rollkit_v1_state_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_state_proto_rawDesc), len(file_rollkit_v1_state_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
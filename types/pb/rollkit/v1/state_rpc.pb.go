@@ -340,12 +340,609 @@ func (x *GetMetadataResponse) GetValue() []byte {
 	return nil
 }
 
+// GetDAMetadataRequest defines the request for retrieving the DA metadata of a block
+type GetDAMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDAMetadataRequest) Reset() {
+	*x = GetDAMetadataRequest{}
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDAMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDAMetadataRequest) ProtoMessage() {}
+
+func (x *GetDAMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDAMetadataRequest.ProtoReflect.Descriptor instead.
+func (*GetDAMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_state_rpc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetDAMetadataRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// GetDAMetadataResponse defines the response for retrieving the DA metadata of a block
+type GetDAMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *DAMetadata            `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDAMetadataResponse) Reset() {
+	*x = GetDAMetadataResponse{}
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDAMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDAMetadataResponse) ProtoMessage() {}
+
+func (x *GetDAMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDAMetadataResponse.ProtoReflect.Descriptor instead.
+func (*GetDAMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_state_rpc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetDAMetadataResponse) GetMetadata() *DAMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// TxByHashRequest defines the request for retrieving an indexed transaction by hash
+type TxByHashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hash          []byte                 `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxByHashRequest) Reset() {
+	*x = TxByHashRequest{}
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxByHashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxByHashRequest) ProtoMessage() {}
+
+func (x *TxByHashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxByHashRequest.ProtoReflect.Descriptor instead.
+func (*TxByHashRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_state_rpc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TxByHashRequest) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+// TxByHashResponse defines the response for retrieving an indexed transaction by hash
+type TxByHashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tx            *TxResult              `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxByHashResponse) Reset() {
+	*x = TxByHashResponse{}
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxByHashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxByHashResponse) ProtoMessage() {}
+
+func (x *TxByHashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxByHashResponse.ProtoReflect.Descriptor instead.
+func (*TxByHashResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_state_rpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TxByHashResponse) GetTx() *TxResult {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+// TxSearchRequest defines the request for searching indexed transactions
+type TxSearchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// query is a list of conditions joined by AND, e.g. "tx.height=5 AND transfer.sender='alice'"
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// page is the 1-based page number, defaults to 1
+	Page uint32 `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	// per_page is the number of transactions per page, defaults to 30 and is capped at 100
+	PerPage       uint32 `protobuf:"varint,3,opt,name=per_page,json=perPage,proto3" json:"per_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxSearchRequest) Reset() {
+	*x = TxSearchRequest{}
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxSearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxSearchRequest) ProtoMessage() {}
+
+func (x *TxSearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxSearchRequest.ProtoReflect.Descriptor instead.
+func (*TxSearchRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_state_rpc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TxSearchRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *TxSearchRequest) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *TxSearchRequest) GetPerPage() uint32 {
+	if x != nil {
+		return x.PerPage
+	}
+	return 0
+}
+
+// TxSearchResponse defines the response for searching indexed transactions
+type TxSearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Txs           []*TxResult            `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	TotalCount    uint64                 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxSearchResponse) Reset() {
+	*x = TxSearchResponse{}
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxSearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxSearchResponse) ProtoMessage() {}
+
+func (x *TxSearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxSearchResponse.ProtoReflect.Descriptor instead.
+func (*TxSearchResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_state_rpc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TxSearchResponse) GetTxs() []*TxResult {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+func (x *TxSearchResponse) GetTotalCount() uint64 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// TxPosition is the position of a transaction in the chain
+type TxPosition struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Height uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// index is the position of the transaction in the block
+	Index         uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxPosition) Reset() {
+	*x = TxPosition{}
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxPosition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxPosition) ProtoMessage() {}
+
+func (x *TxPosition) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxPosition.ProtoReflect.Descriptor instead.
+func (*TxPosition) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_state_rpc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TxPosition) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *TxPosition) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+// GetTxReceiptRequest defines the request for retrieving the receipt of a transaction
+type GetTxReceiptRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Identifier:
+	//
+	//	*GetTxReceiptRequest_Hash
+	//	*GetTxReceiptRequest_Position
+	Identifier    isGetTxReceiptRequest_Identifier `protobuf_oneof:"identifier"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTxReceiptRequest) Reset() {
+	*x = GetTxReceiptRequest{}
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTxReceiptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTxReceiptRequest) ProtoMessage() {}
+
+func (x *GetTxReceiptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTxReceiptRequest.ProtoReflect.Descriptor instead.
+func (*GetTxReceiptRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_state_rpc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetTxReceiptRequest) GetIdentifier() isGetTxReceiptRequest_Identifier {
+	if x != nil {
+		return x.Identifier
+	}
+	return nil
+}
+
+func (x *GetTxReceiptRequest) GetHash() []byte {
+	if x != nil {
+		if x, ok := x.Identifier.(*GetTxReceiptRequest_Hash); ok {
+			return x.Hash
+		}
+	}
+	return nil
+}
+
+func (x *GetTxReceiptRequest) GetPosition() *TxPosition {
+	if x != nil {
+		if x, ok := x.Identifier.(*GetTxReceiptRequest_Position); ok {
+			return x.Position
+		}
+	}
+	return nil
+}
+
+type isGetTxReceiptRequest_Identifier interface {
+	isGetTxReceiptRequest_Identifier()
+}
+
+type GetTxReceiptRequest_Hash struct {
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3,oneof"`
+}
+
+type GetTxReceiptRequest_Position struct {
+	Position *TxPosition `protobuf:"bytes,2,opt,name=position,proto3,oneof"`
+}
+
+func (*GetTxReceiptRequest_Hash) isGetTxReceiptRequest_Identifier() {}
+
+func (*GetTxReceiptRequest_Position) isGetTxReceiptRequest_Identifier() {}
+
+// GetTxReceiptResponse defines the response for retrieving the receipt of a transaction
+type GetTxReceiptResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Receipt       *TxResult              `protobuf:"bytes,1,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTxReceiptResponse) Reset() {
+	*x = GetTxReceiptResponse{}
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTxReceiptResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTxReceiptResponse) ProtoMessage() {}
+
+func (x *GetTxReceiptResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTxReceiptResponse.ProtoReflect.Descriptor instead.
+func (*GetTxReceiptResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_state_rpc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetTxReceiptResponse) GetReceipt() *TxResult {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+// QueryRequest defines the request for querying the execution state
+type QueryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// path is the query path defined by the execution client, e.g. a key of the state
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// data holds the query parameters, interpreted by the execution client
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// height is the height of the block after which the state is queried, 0 for the latest block
+	Height        uint64 `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryRequest) Reset() {
+	*x = QueryRequest{}
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRequest) ProtoMessage() {}
+
+func (x *QueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRequest.ProtoReflect.Descriptor instead.
+func (*QueryRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_state_rpc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *QueryRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *QueryRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// QueryResponse defines the response for querying the execution state
+type QueryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Value []byte                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// height is the height of the block after which the state was queried
+	Height        uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryResponse) Reset() {
+	*x = QueryResponse{}
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryResponse) ProtoMessage() {}
+
+func (x *QueryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_state_rpc_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryResponse.ProtoReflect.Descriptor instead.
+func (*QueryResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_state_rpc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *QueryResponse) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *QueryResponse) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
 var File_rollkit_v1_state_rpc_proto protoreflect.FileDescriptor
 
 const file_rollkit_v1_state_rpc_proto_rawDesc = "" +
 	"\n" +
 	"\x1arollkit/v1/state_rpc.proto\x12\n" +
-	"rollkit.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x18rollkit/v1/rollkit.proto\x1a\x16rollkit/v1/state.proto\"_\n" +
+	"rollkit.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x18rollkit/v1/rollkit.proto\x1a\x16rollkit/v1/state.proto\x1a\x18rollkit/v1/txindex.proto\"_\n" +
 	"\x05Block\x120\n" +
 	"\x06header\x18\x01 \x01(\v2\x18.rollkit.v1.SignedHeaderR\x06header\x12$\n" +
 	"\x04data\x18\x02 \x01(\v2\x10.rollkit.v1.DataR\x04data\"O\n" +
@@ -361,11 +958,50 @@ const file_rollkit_v1_state_rpc_proto_rawDesc = "" +
 	"\x12GetMetadataRequest\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\"+\n" +
 	"\x13GetMetadataResponse\x12\x14\n" +
-	"\x05value\x18\x01 \x01(\fR\x05value2\xed\x01\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\".\n" +
+	"\x14GetDAMetadataRequest\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\"K\n" +
+	"\x15GetDAMetadataResponse\x122\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x16.rollkit.v1.DAMetadataR\bmetadata\"%\n" +
+	"\x0fTxByHashRequest\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\fR\x04hash\"8\n" +
+	"\x10TxByHashResponse\x12$\n" +
+	"\x02tx\x18\x01 \x01(\v2\x14.rollkit.v1.TxResultR\x02tx\"V\n" +
+	"\x0fTxSearchRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\rR\x04page\x12\x19\n" +
+	"\bper_page\x18\x03 \x01(\rR\aperPage\"[\n" +
+	"\x10TxSearchResponse\x12&\n" +
+	"\x03txs\x18\x01 \x03(\v2\x14.rollkit.v1.TxResultR\x03txs\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x04R\n" +
+	"totalCount\":\n" +
+	"\n" +
+	"TxPosition\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\rR\x05index\"o\n" +
+	"\x13GetTxReceiptRequest\x12\x14\n" +
+	"\x04hash\x18\x01 \x01(\fH\x00R\x04hash\x124\n" +
+	"\bposition\x18\x02 \x01(\v2\x16.rollkit.v1.TxPositionH\x00R\bpositionB\f\n" +
+	"\n" +
+	"identifier\"F\n" +
+	"\x14GetTxReceiptResponse\x12.\n" +
+	"\areceipt\x18\x01 \x01(\v2\x14.rollkit.v1.TxResultR\areceipt\"N\n" +
+	"\fQueryRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x16\n" +
+	"\x06height\x18\x03 \x01(\x04R\x06height\"=\n" +
+	"\rQueryResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height2\xec\x04\n" +
 	"\fStoreService\x12G\n" +
 	"\bGetBlock\x12\x1b.rollkit.v1.GetBlockRequest\x1a\x1c.rollkit.v1.GetBlockResponse\"\x00\x12B\n" +
 	"\bGetState\x12\x16.google.protobuf.Empty\x1a\x1c.rollkit.v1.GetStateResponse\"\x00\x12P\n" +
-	"\vGetMetadata\x12\x1e.rollkit.v1.GetMetadataRequest\x1a\x1f.rollkit.v1.GetMetadataResponse\"\x00B0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+	"\vGetMetadata\x12\x1e.rollkit.v1.GetMetadataRequest\x1a\x1f.rollkit.v1.GetMetadataResponse\"\x00\x12V\n" +
+	"\rGetDAMetadata\x12 .rollkit.v1.GetDAMetadataRequest\x1a!.rollkit.v1.GetDAMetadataResponse\"\x00\x12G\n" +
+	"\bTxByHash\x12\x1b.rollkit.v1.TxByHashRequest\x1a\x1c.rollkit.v1.TxByHashResponse\"\x00\x12G\n" +
+	"\bTxSearch\x12\x1b.rollkit.v1.TxSearchRequest\x1a\x1c.rollkit.v1.TxSearchResponse\"\x00\x12S\n" +
+	"\fGetTxReceipt\x12\x1f.rollkit.v1.GetTxReceiptRequest\x1a .rollkit.v1.GetTxReceiptResponse\"\x00\x12>\n" +
+	"\x05Query\x12\x18.rollkit.v1.QueryRequest\x1a\x19.rollkit.v1.QueryResponse\"\x00B0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
 
 var (
 	file_rollkit_v1_state_rpc_proto_rawDescOnce sync.Once
@@ -379,35 +1015,63 @@ func file_rollkit_v1_state_rpc_proto_rawDescGZIP() []byte {
 	return file_rollkit_v1_state_rpc_proto_rawDescData
 }
 
-var file_rollkit_v1_state_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_rollkit_v1_state_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_rollkit_v1_state_rpc_proto_goTypes = []any{
-	(*Block)(nil),               // 0: rollkit.v1.Block
-	(*GetBlockRequest)(nil),     // 1: rollkit.v1.GetBlockRequest
-	(*GetBlockResponse)(nil),    // 2: rollkit.v1.GetBlockResponse
-	(*GetStateResponse)(nil),    // 3: rollkit.v1.GetStateResponse
-	(*GetMetadataRequest)(nil),  // 4: rollkit.v1.GetMetadataRequest
-	(*GetMetadataResponse)(nil), // 5: rollkit.v1.GetMetadataResponse
-	(*SignedHeader)(nil),        // 6: rollkit.v1.SignedHeader
-	(*Data)(nil),                // 7: rollkit.v1.Data
-	(*State)(nil),               // 8: rollkit.v1.State
-	(*emptypb.Empty)(nil),       // 9: google.protobuf.Empty
+	(*Block)(nil),                 // 0: rollkit.v1.Block
+	(*GetBlockRequest)(nil),       // 1: rollkit.v1.GetBlockRequest
+	(*GetBlockResponse)(nil),      // 2: rollkit.v1.GetBlockResponse
+	(*GetStateResponse)(nil),      // 3: rollkit.v1.GetStateResponse
+	(*GetMetadataRequest)(nil),    // 4: rollkit.v1.GetMetadataRequest
+	(*GetMetadataResponse)(nil),   // 5: rollkit.v1.GetMetadataResponse
+	(*GetDAMetadataRequest)(nil),  // 6: rollkit.v1.GetDAMetadataRequest
+	(*GetDAMetadataResponse)(nil), // 7: rollkit.v1.GetDAMetadataResponse
+	(*TxByHashRequest)(nil),       // 8: rollkit.v1.TxByHashRequest
+	(*TxByHashResponse)(nil),      // 9: rollkit.v1.TxByHashResponse
+	(*TxSearchRequest)(nil),       // 10: rollkit.v1.TxSearchRequest
+	(*TxSearchResponse)(nil),      // 11: rollkit.v1.TxSearchResponse
+	(*TxPosition)(nil),            // 12: rollkit.v1.TxPosition
+	(*GetTxReceiptRequest)(nil),   // 13: rollkit.v1.GetTxReceiptRequest
+	(*GetTxReceiptResponse)(nil),  // 14: rollkit.v1.GetTxReceiptResponse
+	(*QueryRequest)(nil),          // 15: rollkit.v1.QueryRequest
+	(*QueryResponse)(nil),         // 16: rollkit.v1.QueryResponse
+	(*SignedHeader)(nil),          // 17: rollkit.v1.SignedHeader
+	(*Data)(nil),                  // 18: rollkit.v1.Data
+	(*State)(nil),                 // 19: rollkit.v1.State
+	(*DAMetadata)(nil),            // 20: rollkit.v1.DAMetadata
+	(*TxResult)(nil),              // 21: rollkit.v1.TxResult
+	(*emptypb.Empty)(nil),         // 22: google.protobuf.Empty
 }
 var file_rollkit_v1_state_rpc_proto_depIdxs = []int32{
-	6, // 0: rollkit.v1.Block.header:type_name -> rollkit.v1.SignedHeader
-	7, // 1: rollkit.v1.Block.data:type_name -> rollkit.v1.Data
-	0, // 2: rollkit.v1.GetBlockResponse.block:type_name -> rollkit.v1.Block
-	8, // 3: rollkit.v1.GetStateResponse.state:type_name -> rollkit.v1.State
-	1, // 4: rollkit.v1.StoreService.GetBlock:input_type -> rollkit.v1.GetBlockRequest
-	9, // 5: rollkit.v1.StoreService.GetState:input_type -> google.protobuf.Empty
-	4, // 6: rollkit.v1.StoreService.GetMetadata:input_type -> rollkit.v1.GetMetadataRequest
-	2, // 7: rollkit.v1.StoreService.GetBlock:output_type -> rollkit.v1.GetBlockResponse
-	3, // 8: rollkit.v1.StoreService.GetState:output_type -> rollkit.v1.GetStateResponse
-	5, // 9: rollkit.v1.StoreService.GetMetadata:output_type -> rollkit.v1.GetMetadataResponse
-	7, // [7:10] is the sub-list for method output_type
-	4, // [4:7] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	17, // 0: rollkit.v1.Block.header:type_name -> rollkit.v1.SignedHeader
+	18, // 1: rollkit.v1.Block.data:type_name -> rollkit.v1.Data
+	0,  // 2: rollkit.v1.GetBlockResponse.block:type_name -> rollkit.v1.Block
+	19, // 3: rollkit.v1.GetStateResponse.state:type_name -> rollkit.v1.State
+	20, // 4: rollkit.v1.GetDAMetadataResponse.metadata:type_name -> rollkit.v1.DAMetadata
+	21, // 5: rollkit.v1.TxByHashResponse.tx:type_name -> rollkit.v1.TxResult
+	21, // 6: rollkit.v1.TxSearchResponse.txs:type_name -> rollkit.v1.TxResult
+	12, // 7: rollkit.v1.GetTxReceiptRequest.position:type_name -> rollkit.v1.TxPosition
+	21, // 8: rollkit.v1.GetTxReceiptResponse.receipt:type_name -> rollkit.v1.TxResult
+	1,  // 9: rollkit.v1.StoreService.GetBlock:input_type -> rollkit.v1.GetBlockRequest
+	22, // 10: rollkit.v1.StoreService.GetState:input_type -> google.protobuf.Empty
+	4,  // 11: rollkit.v1.StoreService.GetMetadata:input_type -> rollkit.v1.GetMetadataRequest
+	6,  // 12: rollkit.v1.StoreService.GetDAMetadata:input_type -> rollkit.v1.GetDAMetadataRequest
+	8,  // 13: rollkit.v1.StoreService.TxByHash:input_type -> rollkit.v1.TxByHashRequest
+	10, // 14: rollkit.v1.StoreService.TxSearch:input_type -> rollkit.v1.TxSearchRequest
+	13, // 15: rollkit.v1.StoreService.GetTxReceipt:input_type -> rollkit.v1.GetTxReceiptRequest
+	15, // 16: rollkit.v1.StoreService.Query:input_type -> rollkit.v1.QueryRequest
+	2,  // 17: rollkit.v1.StoreService.GetBlock:output_type -> rollkit.v1.GetBlockResponse
+	3,  // 18: rollkit.v1.StoreService.GetState:output_type -> rollkit.v1.GetStateResponse
+	5,  // 19: rollkit.v1.StoreService.GetMetadata:output_type -> rollkit.v1.GetMetadataResponse
+	7,  // 20: rollkit.v1.StoreService.GetDAMetadata:output_type -> rollkit.v1.GetDAMetadataResponse
+	9,  // 21: rollkit.v1.StoreService.TxByHash:output_type -> rollkit.v1.TxByHashResponse
+	11, // 22: rollkit.v1.StoreService.TxSearch:output_type -> rollkit.v1.TxSearchResponse
+	14, // 23: rollkit.v1.StoreService.GetTxReceipt:output_type -> rollkit.v1.GetTxReceiptResponse
+	16, // 24: rollkit.v1.StoreService.Query:output_type -> rollkit.v1.QueryResponse
+	17, // [17:25] is the sub-list for method output_type
+	9,  // [9:17] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_rollkit_v1_state_rpc_proto_init() }
@@ -417,17 +1081,22 @@ func file_rollkit_v1_state_rpc_proto_init() {
 	}
 	file_rollkit_v1_rollkit_proto_init()
 	file_rollkit_v1_state_proto_init()
+	file_rollkit_v1_txindex_proto_init()
 	file_rollkit_v1_state_rpc_proto_msgTypes[1].OneofWrappers = []any{
 		(*GetBlockRequest_Height)(nil),
 		(*GetBlockRequest_Hash)(nil),
 	}
+	file_rollkit_v1_state_rpc_proto_msgTypes[13].OneofWrappers = []any{
+		(*GetTxReceiptRequest_Hash)(nil),
+		(*GetTxReceiptRequest_Position)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_state_rpc_proto_rawDesc), len(file_rollkit_v1_state_rpc_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,1890 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/status_rpc.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ConfirmationStatus defines the confirmation tier of a block
+type ConfirmationStatus int32
+
+const (
+	// Block not known to be signed by the sequencer yet
+	ConfirmationStatus_CONFIRMATION_STATUS_PENDING ConfirmationStatus = 0
+	// Block signed by the sequencer, not included in the DA layer yet
+	ConfirmationStatus_CONFIRMATION_STATUS_SOFT_CONFIRMED ConfirmationStatus = 1
+	// Block included in the DA layer
+	ConfirmationStatus_CONFIRMATION_STATUS_DA_FINALIZED ConfirmationStatus = 2
+	// Block attested by a quorum of attesters, not included in the DA layer yet
+	ConfirmationStatus_CONFIRMATION_STATUS_ATTESTED ConfirmationStatus = 3
+)
+
+// Enum value maps for ConfirmationStatus.
+var (
+	ConfirmationStatus_name = map[int32]string{
+		0: "CONFIRMATION_STATUS_PENDING",
+		1: "CONFIRMATION_STATUS_SOFT_CONFIRMED",
+		2: "CONFIRMATION_STATUS_DA_FINALIZED",
+		3: "CONFIRMATION_STATUS_ATTESTED",
+	}
+	ConfirmationStatus_value = map[string]int32{
+		"CONFIRMATION_STATUS_PENDING":        0,
+		"CONFIRMATION_STATUS_SOFT_CONFIRMED": 1,
+		"CONFIRMATION_STATUS_DA_FINALIZED":   2,
+		"CONFIRMATION_STATUS_ATTESTED":       3,
+	}
+)
+
+func (x ConfirmationStatus) Enum() *ConfirmationStatus {
+	p := new(ConfirmationStatus)
+	*p = x
+	return p
+}
+
+func (x ConfirmationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConfirmationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_rollkit_v1_status_rpc_proto_enumTypes[0].Descriptor()
+}
+
+func (ConfirmationStatus) Type() protoreflect.EnumType {
+	return &file_rollkit_v1_status_rpc_proto_enumTypes[0]
+}
+
+func (x ConfirmationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConfirmationStatus.Descriptor instead.
+func (ConfirmationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{0}
+}
+
+// GetStatusResponse defines the response for retrieving the sync progress of the node
+type GetStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Mode of the node: aggregator, full, based or light
+	Mode string `protobuf:"bytes,1,opt,name=mode,proto3" json:"mode,omitempty"`
+	// Height of the last block in the store, or of the last header for light nodes
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// Height up to which blocks are included in the DA layer
+	DaIncludedHeight uint64 `protobuf:"varint,3,opt,name=da_included_height,json=daIncludedHeight,proto3" json:"da_included_height,omitempty"`
+	// Next DA height to retrieve
+	DaHeight uint64 `protobuf:"varint,4,opt,name=da_height,json=daHeight,proto3" json:"da_height,omitempty"`
+	// Latest DA height seen by the node, 0 if none was seen yet
+	DaHeadHeight uint64 `protobuf:"varint,5,opt,name=da_head_height,json=daHeadHeight,proto3" json:"da_head_height,omitempty"`
+	// Number of headers produced but not submitted to the DA layer yet
+	PendingHeaders uint64 `protobuf:"varint,6,opt,name=pending_headers,json=pendingHeaders,proto3" json:"pending_headers,omitempty"`
+	// Number of batches queued for submission to the DA layer
+	PendingBatches uint64 `protobuf:"varint,7,opt,name=pending_batches,json=pendingBatches,proto3" json:"pending_batches,omitempty"`
+	// Number of connected p2p peers
+	Peers uint32 `protobuf:"varint,8,opt,name=peers,proto3" json:"peers,omitempty"`
+	// Whether the node is behind the DA layer or its p2p peers
+	CatchingUp bool `protobuf:"varint,9,opt,name=catching_up,json=catchingUp,proto3" json:"catching_up,omitempty"`
+	// Reason the executor is unhealthy, empty if it is healthy or does not report its health
+	ExecutorError string `protobuf:"bytes,10,opt,name=executor_error,json=executorError,proto3" json:"executor_error,omitempty"`
+	// Reason the DA layer is unreachable, empty if it is reachable or the node has no DA client
+	DaError string `protobuf:"bytes,11,opt,name=da_error,json=daError,proto3" json:"da_error,omitempty"`
+	// Whether the node listens for p2p connections
+	P2PListening bool `protobuf:"varint,12,opt,name=p2p_listening,json=p2pListening,proto3" json:"p2p_listening,omitempty"`
+	// Number of blocks by which the node trails the latest block known from its p2p peers
+	SyncLag uint64 `protobuf:"varint,13,opt,name=sync_lag,json=syncLag,proto3" json:"sync_lag,omitempty"`
+	// Whether block production of the aggregator is paused through AdminService.PauseSequencer
+	Paused        bool `protobuf:"varint,14,opt,name=paused,proto3" json:"paused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusResponse) ProtoMessage() {}
+
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetStatusResponse) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetDaIncludedHeight() uint64 {
+	if x != nil {
+		return x.DaIncludedHeight
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetDaHeight() uint64 {
+	if x != nil {
+		return x.DaHeight
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetDaHeadHeight() uint64 {
+	if x != nil {
+		return x.DaHeadHeight
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetPendingHeaders() uint64 {
+	if x != nil {
+		return x.PendingHeaders
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetPendingBatches() uint64 {
+	if x != nil {
+		return x.PendingBatches
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetPeers() uint32 {
+	if x != nil {
+		return x.Peers
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetCatchingUp() bool {
+	if x != nil {
+		return x.CatchingUp
+	}
+	return false
+}
+
+func (x *GetStatusResponse) GetExecutorError() string {
+	if x != nil {
+		return x.ExecutorError
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetDaError() string {
+	if x != nil {
+		return x.DaError
+	}
+	return ""
+}
+
+func (x *GetStatusResponse) GetP2PListening() bool {
+	if x != nil {
+		return x.P2PListening
+	}
+	return false
+}
+
+func (x *GetStatusResponse) GetSyncLag() uint64 {
+	if x != nil {
+		return x.SyncLag
+	}
+	return 0
+}
+
+func (x *GetStatusResponse) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+// GetLeaderResponse defines the response for retrieving the active leader
+type GetLeaderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether leader election is enabled on this node
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Node ID of the active leader, empty if no lease is held
+	Leader string `protobuf:"bytes,2,opt,name=leader,proto3" json:"leader,omitempty"`
+	// Term of the current lease
+	Term uint64 `protobuf:"varint,3,opt,name=term,proto3" json:"term,omitempty"`
+	// DA height after which the lease expires
+	LeaseExpiry uint64 `protobuf:"varint,4,opt,name=lease_expiry,json=leaseExpiry,proto3" json:"lease_expiry,omitempty"`
+	// Whether this node is the active leader
+	IsLeader      bool `protobuf:"varint,5,opt,name=is_leader,json=isLeader,proto3" json:"is_leader,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLeaderResponse) Reset() {
+	*x = GetLeaderResponse{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLeaderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLeaderResponse) ProtoMessage() {}
+
+func (x *GetLeaderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLeaderResponse.ProtoReflect.Descriptor instead.
+func (*GetLeaderResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetLeaderResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GetLeaderResponse) GetLeader() string {
+	if x != nil {
+		return x.Leader
+	}
+	return ""
+}
+
+func (x *GetLeaderResponse) GetTerm() uint64 {
+	if x != nil {
+		return x.Term
+	}
+	return 0
+}
+
+func (x *GetLeaderResponse) GetLeaseExpiry() uint64 {
+	if x != nil {
+		return x.LeaseExpiry
+	}
+	return 0
+}
+
+func (x *GetLeaderResponse) GetIsLeader() bool {
+	if x != nil {
+		return x.IsLeader
+	}
+	return false
+}
+
+// GetBlockConfirmationStatusRequest defines the request for retrieving the confirmation status of a block
+type GetBlockConfirmationStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockConfirmationStatusRequest) Reset() {
+	*x = GetBlockConfirmationStatusRequest{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockConfirmationStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockConfirmationStatusRequest) ProtoMessage() {}
+
+func (x *GetBlockConfirmationStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockConfirmationStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockConfirmationStatusRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetBlockConfirmationStatusRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// GetBlockConfirmationStatusResponse defines the response for retrieving the confirmation status of a block
+type GetBlockConfirmationStatusResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Height uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Status ConfirmationStatus     `protobuf:"varint,2,opt,name=status,proto3,enum=rollkit.v1.ConfirmationStatus" json:"status,omitempty"`
+	// Height up to which blocks are signed by the sequencer
+	SoftConfirmedHeight uint64 `protobuf:"varint,3,opt,name=soft_confirmed_height,json=softConfirmedHeight,proto3" json:"soft_confirmed_height,omitempty"`
+	// Height up to which blocks are included in the DA layer
+	DaIncludedHeight uint64 `protobuf:"varint,4,opt,name=da_included_height,json=daIncludedHeight,proto3" json:"da_included_height,omitempty"`
+	// Height up to which blocks are attested by a quorum of attesters, 0 if attestations are disabled
+	AttestedHeight uint64 `protobuf:"varint,5,opt,name=attested_height,json=attestedHeight,proto3" json:"attested_height,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetBlockConfirmationStatusResponse) Reset() {
+	*x = GetBlockConfirmationStatusResponse{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockConfirmationStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockConfirmationStatusResponse) ProtoMessage() {}
+
+func (x *GetBlockConfirmationStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockConfirmationStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetBlockConfirmationStatusResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetBlockConfirmationStatusResponse) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetBlockConfirmationStatusResponse) GetStatus() ConfirmationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ConfirmationStatus_CONFIRMATION_STATUS_PENDING
+}
+
+func (x *GetBlockConfirmationStatusResponse) GetSoftConfirmedHeight() uint64 {
+	if x != nil {
+		return x.SoftConfirmedHeight
+	}
+	return 0
+}
+
+func (x *GetBlockConfirmationStatusResponse) GetDaIncludedHeight() uint64 {
+	if x != nil {
+		return x.DaIncludedHeight
+	}
+	return 0
+}
+
+func (x *GetBlockConfirmationStatusResponse) GetAttestedHeight() uint64 {
+	if x != nil {
+		return x.AttestedHeight
+	}
+	return 0
+}
+
+// GetDAGasPriceResponse defines the response for retrieving the effective DA gas price
+type GetDAGasPriceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Gas price used for the next DA submission, -1 if determined by the DA layer
+	GasPrice      float64 `protobuf:"fixed64,1,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDAGasPriceResponse) Reset() {
+	*x = GetDAGasPriceResponse{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDAGasPriceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDAGasPriceResponse) ProtoMessage() {}
+
+func (x *GetDAGasPriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDAGasPriceResponse.ProtoReflect.Descriptor instead.
+func (*GetDAGasPriceResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetDAGasPriceResponse) GetGasPrice() float64 {
+	if x != nil {
+		return x.GasPrice
+	}
+	return 0
+}
+
+// DAFees defines the DA fees paid for the blobs submitted during a period
+type DAFees struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// DA fees paid, in units of the gas price
+	Fees float64 `protobuf:"fixed64,1,opt,name=fees,proto3" json:"fees,omitempty"`
+	// Number of blobs submitted
+	Blobs uint64 `protobuf:"varint,2,opt,name=blobs,proto3" json:"blobs,omitempty"`
+	// Total size in bytes of the blobs submitted
+	Bytes         uint64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DAFees) Reset() {
+	*x = DAFees{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DAFees) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DAFees) ProtoMessage() {}
+
+func (x *DAFees) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DAFees.ProtoReflect.Descriptor instead.
+func (*DAFees) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DAFees) GetFees() float64 {
+	if x != nil {
+		return x.Fees
+	}
+	return 0
+}
+
+func (x *DAFees) GetBlobs() uint64 {
+	if x != nil {
+		return x.Blobs
+	}
+	return 0
+}
+
+func (x *DAFees) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+// DailyDAFees defines the DA fees paid during a UTC day
+type DailyDAFees struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// UTC date, formatted as YYYY-MM-DD
+	Day           string  `protobuf:"bytes,1,opt,name=day,proto3" json:"day,omitempty"`
+	Fees          *DAFees `protobuf:"bytes,2,opt,name=fees,proto3" json:"fees,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DailyDAFees) Reset() {
+	*x = DailyDAFees{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DailyDAFees) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DailyDAFees) ProtoMessage() {}
+
+func (x *DailyDAFees) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DailyDAFees.ProtoReflect.Descriptor instead.
+func (*DailyDAFees) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DailyDAFees) GetDay() string {
+	if x != nil {
+		return x.Day
+	}
+	return ""
+}
+
+func (x *DailyDAFees) GetFees() *DAFees {
+	if x != nil {
+		return x.Fees
+	}
+	return nil
+}
+
+// GetDAFeesResponse defines the response for retrieving the DA fees paid by the node
+type GetDAFeesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// DA fees paid since the node started accounting for them
+	Total *DAFees `protobuf:"bytes,1,opt,name=total,proto3" json:"total,omitempty"`
+	// DA fees paid during the current UTC day
+	Today *DAFees `protobuf:"bytes,2,opt,name=today,proto3" json:"today,omitempty"`
+	// DA fees of the last days with DA submissions, oldest first
+	Days []*DailyDAFees `protobuf:"bytes,3,rep,name=days,proto3" json:"days,omitempty"`
+	// DA fees that may be paid per UTC day, 0 if there is no budget
+	DailyBudget float64 `protobuf:"fixed64,4,opt,name=daily_budget,json=dailyBudget,proto3" json:"daily_budget,omitempty"`
+	// Whether DA submissions are paused because the fees paid today reached the daily budget
+	Paused        bool `protobuf:"varint,5,opt,name=paused,proto3" json:"paused,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDAFeesResponse) Reset() {
+	*x = GetDAFeesResponse{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDAFeesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDAFeesResponse) ProtoMessage() {}
+
+func (x *GetDAFeesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDAFeesResponse.ProtoReflect.Descriptor instead.
+func (*GetDAFeesResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetDAFeesResponse) GetTotal() *DAFees {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *GetDAFeesResponse) GetToday() *DAFees {
+	if x != nil {
+		return x.Today
+	}
+	return nil
+}
+
+func (x *GetDAFeesResponse) GetDays() []*DailyDAFees {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *GetDAFeesResponse) GetDailyBudget() float64 {
+	if x != nil {
+		return x.DailyBudget
+	}
+	return 0
+}
+
+func (x *GetDAFeesResponse) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+// GetDAVerificationResponse defines the response for retrieving the DA verification progress of a light node
+type GetDAVerificationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Whether headers are verified against the DA layer by this node
+	Enabled bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// Height up to which headers received over p2p were found on the DA layer
+	VerifiedHeight uint64 `protobuf:"varint,2,opt,name=verified_height,json=verifiedHeight,proto3" json:"verified_height,omitempty"`
+	// Next DA height to scan
+	DaHeight      uint64 `protobuf:"varint,3,opt,name=da_height,json=daHeight,proto3" json:"da_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDAVerificationResponse) Reset() {
+	*x = GetDAVerificationResponse{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDAVerificationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDAVerificationResponse) ProtoMessage() {}
+
+func (x *GetDAVerificationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDAVerificationResponse.ProtoReflect.Descriptor instead.
+func (*GetDAVerificationResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetDAVerificationResponse) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *GetDAVerificationResponse) GetVerifiedHeight() uint64 {
+	if x != nil {
+		return x.VerifiedHeight
+	}
+	return 0
+}
+
+func (x *GetDAVerificationResponse) GetDaHeight() uint64 {
+	if x != nil {
+		return x.DaHeight
+	}
+	return 0
+}
+
+// GetDAInclusionProofRequest defines the request for retrieving the DA inclusion proof of a block
+type GetDAInclusionProofRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDAInclusionProofRequest) Reset() {
+	*x = GetDAInclusionProofRequest{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDAInclusionProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDAInclusionProofRequest) ProtoMessage() {}
+
+func (x *GetDAInclusionProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDAInclusionProofRequest.ProtoReflect.Descriptor instead.
+func (*GetDAInclusionProofRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetDAInclusionProofRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// DABlobProof defines the location of a blob in the DA layer and its inclusion proof
+type DABlobProof struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Height of the DA block including the blob
+	DaHeight uint64 `protobuf:"varint,1,opt,name=da_height,json=daHeight,proto3" json:"da_height,omitempty"`
+	// DA namespace of the blob
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// ID of the blob in the DA layer
+	Id []byte `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	// Commitment to the blob, empty if the DA layer IDs do not embed it
+	Commitment []byte `protobuf:"bytes,4,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	// Inclusion proof returned by the DA layer
+	Proof         []byte `protobuf:"bytes,5,opt,name=proof,proto3" json:"proof,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DABlobProof) Reset() {
+	*x = DABlobProof{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DABlobProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DABlobProof) ProtoMessage() {}
+
+func (x *DABlobProof) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DABlobProof.ProtoReflect.Descriptor instead.
+func (*DABlobProof) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DABlobProof) GetDaHeight() uint64 {
+	if x != nil {
+		return x.DaHeight
+	}
+	return 0
+}
+
+func (x *DABlobProof) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DABlobProof) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *DABlobProof) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+func (x *DABlobProof) GetProof() []byte {
+	if x != nil {
+		return x.Proof
+	}
+	return nil
+}
+
+// GetDAInclusionProofResponse defines the response for retrieving the DA inclusion proof of a block
+type GetDAInclusionProofResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Height uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Header *DABlobProof           `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	// Unset for blocks without transactions, whose data is not submitted to the DA layer
+	Data          *DABlobProof `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDAInclusionProofResponse) Reset() {
+	*x = GetDAInclusionProofResponse{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDAInclusionProofResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDAInclusionProofResponse) ProtoMessage() {}
+
+func (x *GetDAInclusionProofResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDAInclusionProofResponse.ProtoReflect.Descriptor instead.
+func (*GetDAInclusionProofResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetDAInclusionProofResponse) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *GetDAInclusionProofResponse) GetHeader() *DABlobProof {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *GetDAInclusionProofResponse) GetData() *DABlobProof {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// GetLightClientUpdateRequest defines the request for retrieving the light client update of a block
+type GetLightClientUpdateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Height of the block, the last DA included block is returned if 0
+	Height        uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLightClientUpdateRequest) Reset() {
+	*x = GetLightClientUpdateRequest{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLightClientUpdateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLightClientUpdateRequest) ProtoMessage() {}
+
+func (x *GetLightClientUpdateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLightClientUpdateRequest.ProtoReflect.Descriptor instead.
+func (*GetLightClientUpdateRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetLightClientUpdateRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// LightClientConsensusState defines the consensus state of the rollup at a height, as tracked by light clients
+type LightClientConsensusState struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Height    uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// State root committed to by the header, i.e. the state root after the previous block (deferred execution)
+	Root []byte `protobuf:"bytes,3,opt,name=root,proto3" json:"root,omitempty"`
+	// Address of the sequencer which signed the header
+	ProposerAddress []byte `protobuf:"bytes,4,opt,name=proposer_address,json=proposerAddress,proto3" json:"proposer_address,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LightClientConsensusState) Reset() {
+	*x = LightClientConsensusState{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LightClientConsensusState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LightClientConsensusState) ProtoMessage() {}
+
+func (x *LightClientConsensusState) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LightClientConsensusState.ProtoReflect.Descriptor instead.
+func (*LightClientConsensusState) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LightClientConsensusState) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *LightClientConsensusState) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *LightClientConsensusState) GetRoot() []byte {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *LightClientConsensusState) GetProposerAddress() []byte {
+	if x != nil {
+		return x.ProposerAddress
+	}
+	return nil
+}
+
+// DABlobPointer defines the location of a blob in the DA layer
+type DABlobPointer struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Height of the DA block including the blob
+	DaHeight uint64 `protobuf:"varint,1,opt,name=da_height,json=daHeight,proto3" json:"da_height,omitempty"`
+	// DA namespace of the blob
+	Namespace string `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// ID of the blob in the DA layer
+	Id []byte `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	// Commitment to the blob, empty if the DA layer IDs do not embed it
+	Commitment    []byte `protobuf:"bytes,4,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DABlobPointer) Reset() {
+	*x = DABlobPointer{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DABlobPointer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DABlobPointer) ProtoMessage() {}
+
+func (x *DABlobPointer) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DABlobPointer.ProtoReflect.Descriptor instead.
+func (*DABlobPointer) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DABlobPointer) GetDaHeight() uint64 {
+	if x != nil {
+		return x.DaHeight
+	}
+	return 0
+}
+
+func (x *DABlobPointer) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DABlobPointer) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *DABlobPointer) GetCommitment() []byte {
+	if x != nil {
+		return x.Commitment
+	}
+	return nil
+}
+
+// GetLightClientUpdateResponse defines the header update of a DA included block, verified by light clients
+// against the public key of the sequencer
+type GetLightClientUpdateResponse struct {
+	state          protoimpl.MessageState     `protogen:"open.v1"`
+	ConsensusState *LightClientConsensusState `protobuf:"bytes,1,opt,name=consensus_state,json=consensusState,proto3" json:"consensus_state,omitempty"`
+	// Header signed by the sequencer, carrying the signer and the key rotation, if any
+	SignedHeader *SignedHeader  `protobuf:"bytes,2,opt,name=signed_header,json=signedHeader,proto3" json:"signed_header,omitempty"`
+	DaHeader     *DABlobPointer `protobuf:"bytes,3,opt,name=da_header,json=daHeader,proto3" json:"da_header,omitempty"`
+	// Unset for blocks without transactions, whose data is not submitted to the DA layer
+	DaData        *DABlobPointer `protobuf:"bytes,4,opt,name=da_data,json=daData,proto3" json:"da_data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLightClientUpdateResponse) Reset() {
+	*x = GetLightClientUpdateResponse{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLightClientUpdateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLightClientUpdateResponse) ProtoMessage() {}
+
+func (x *GetLightClientUpdateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLightClientUpdateResponse.ProtoReflect.Descriptor instead.
+func (*GetLightClientUpdateResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetLightClientUpdateResponse) GetConsensusState() *LightClientConsensusState {
+	if x != nil {
+		return x.ConsensusState
+	}
+	return nil
+}
+
+func (x *GetLightClientUpdateResponse) GetSignedHeader() *SignedHeader {
+	if x != nil {
+		return x.SignedHeader
+	}
+	return nil
+}
+
+func (x *GetLightClientUpdateResponse) GetDaHeader() *DABlobPointer {
+	if x != nil {
+		return x.DaHeader
+	}
+	return nil
+}
+
+func (x *GetLightClientUpdateResponse) GetDaData() *DABlobPointer {
+	if x != nil {
+		return x.DaData
+	}
+	return nil
+}
+
+// GetHeadersRangeRequest defines the request for retrieving a range of headers
+type GetHeadersRangeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// First height of the range
+	From uint64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	// Last height of the range, included
+	To            uint64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHeadersRangeRequest) Reset() {
+	*x = GetHeadersRangeRequest{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHeadersRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHeadersRangeRequest) ProtoMessage() {}
+
+func (x *GetHeadersRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHeadersRangeRequest.ProtoReflect.Descriptor instead.
+func (*GetHeadersRangeRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetHeadersRangeRequest) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetHeadersRangeRequest) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+// GetHeadersRangeResponse defines the response for retrieving a range of headers. The headers are signed by the
+// sequencer and each header links to the hash of the previous one.
+type GetHeadersRangeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Headers of the range, in ascending height order
+	Headers       []*SignedHeader `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHeadersRangeResponse) Reset() {
+	*x = GetHeadersRangeResponse{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHeadersRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHeadersRangeResponse) ProtoMessage() {}
+
+func (x *GetHeadersRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHeadersRangeResponse.ProtoReflect.Descriptor instead.
+func (*GetHeadersRangeResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetHeadersRangeResponse) GetHeaders() []*SignedHeader {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+// DAChainStats defines the statistics of the blobs of a chain retrieved from the DA layer
+type DAChainStats struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChainId string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// Number of blobs of the chain retrieved
+	Blobs uint64 `protobuf:"varint,2,opt,name=blobs,proto3" json:"blobs,omitempty"`
+	// Total size in bytes of the blobs of the chain retrieved
+	Bytes uint64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// Last DA height at which a blob of the chain was retrieved
+	LastDaHeight  uint64 `protobuf:"varint,4,opt,name=last_da_height,json=lastDaHeight,proto3" json:"last_da_height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DAChainStats) Reset() {
+	*x = DAChainStats{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DAChainStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DAChainStats) ProtoMessage() {}
+
+func (x *DAChainStats) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DAChainStats.ProtoReflect.Descriptor instead.
+func (*DAChainStats) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DAChainStats) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *DAChainStats) GetBlobs() uint64 {
+	if x != nil {
+		return x.Blobs
+	}
+	return 0
+}
+
+func (x *DAChainStats) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *DAChainStats) GetLastDaHeight() uint64 {
+	if x != nil {
+		return x.LastDaHeight
+	}
+	return 0
+}
+
+// GetDAChainStatsResponse defines the response for retrieving the statistics of the blobs retrieved per chain.
+// Blobs of other chains are found in DA namespaces shared with them.
+type GetDAChainStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Statistics of the chains, ordered by chain ID
+	Chains        []*DAChainStats `protobuf:"bytes,1,rep,name=chains,proto3" json:"chains,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDAChainStatsResponse) Reset() {
+	*x = GetDAChainStatsResponse{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDAChainStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDAChainStatsResponse) ProtoMessage() {}
+
+func (x *GetDAChainStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDAChainStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetDAChainStatsResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetDAChainStatsResponse) GetChains() []*DAChainStats {
+	if x != nil {
+		return x.Chains
+	}
+	return nil
+}
+
+// DryRunDASubmissionRequest defines the request for simulating the DA submission of a block
+type DryRunDASubmissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Height        uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DryRunDASubmissionRequest) Reset() {
+	*x = DryRunDASubmissionRequest{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DryRunDASubmissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DryRunDASubmissionRequest) ProtoMessage() {}
+
+func (x *DryRunDASubmissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DryRunDASubmissionRequest.ProtoReflect.Descriptor instead.
+func (*DryRunDASubmissionRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DryRunDASubmissionRequest) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+// DABlobEstimate defines the simulated DA submission of the header or the data of a block
+type DABlobEstimate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sizes in bytes of the blobs submitted, more than one for data split into parts
+	BlobSizes []uint64 `protobuf:"varint,1,rep,packed,name=blob_sizes,json=blobSizes,proto3" json:"blob_sizes,omitempty"`
+	// Total size in bytes of the blobs
+	Bytes uint64 `protobuf:"varint,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// Estimated DA fees of the blobs, in units of the gas price
+	Fees          float64 `protobuf:"fixed64,3,opt,name=fees,proto3" json:"fees,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DABlobEstimate) Reset() {
+	*x = DABlobEstimate{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DABlobEstimate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DABlobEstimate) ProtoMessage() {}
+
+func (x *DABlobEstimate) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DABlobEstimate.ProtoReflect.Descriptor instead.
+func (*DABlobEstimate) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DABlobEstimate) GetBlobSizes() []uint64 {
+	if x != nil {
+		return x.BlobSizes
+	}
+	return nil
+}
+
+func (x *DABlobEstimate) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *DABlobEstimate) GetFees() float64 {
+	if x != nil {
+		return x.Fees
+	}
+	return 0
+}
+
+// DryRunDASubmissionResponse defines the response for simulating the DA submission of a block. The blobs are
+// encoded as the node submits them, and decoded back as syncing nodes decode them.
+type DryRunDASubmissionResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Height uint64                 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Header *DABlobEstimate        `protobuf:"bytes,2,opt,name=header,proto3" json:"header,omitempty"`
+	// Simulated submission of the block data, unset if the data of the block is not submitted
+	Data *DABlobEstimate `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Gas price the blobs would be submitted with
+	GasPrice float64 `protobuf:"fixed64,4,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	// Maximum size in bytes of the blobs accepted by the DA layer, 0 if unknown
+	MaxBlobSize uint64 `protobuf:"varint,5,opt,name=max_blob_size,json=maxBlobSize,proto3" json:"max_blob_size,omitempty"`
+	// Estimated DA fees of the header and data, in units of the gas price
+	Fees float64 `protobuf:"fixed64,6,opt,name=fees,proto3" json:"fees,omitempty"`
+	// Reasons the DA layer or syncing nodes would reject the blobs, empty if they are valid
+	Errors        []string `protobuf:"bytes,7,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DryRunDASubmissionResponse) Reset() {
+	*x = DryRunDASubmissionResponse{}
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DryRunDASubmissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DryRunDASubmissionResponse) ProtoMessage() {}
+
+func (x *DryRunDASubmissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_status_rpc_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DryRunDASubmissionResponse.ProtoReflect.Descriptor instead.
+func (*DryRunDASubmissionResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_status_rpc_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DryRunDASubmissionResponse) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *DryRunDASubmissionResponse) GetHeader() *DABlobEstimate {
+	if x != nil {
+		return x.Header
+	}
+	return nil
+}
+
+func (x *DryRunDASubmissionResponse) GetData() *DABlobEstimate {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DryRunDASubmissionResponse) GetGasPrice() float64 {
+	if x != nil {
+		return x.GasPrice
+	}
+	return 0
+}
+
+func (x *DryRunDASubmissionResponse) GetMaxBlobSize() uint64 {
+	if x != nil {
+		return x.MaxBlobSize
+	}
+	return 0
+}
+
+func (x *DryRunDASubmissionResponse) GetFees() float64 {
+	if x != nil {
+		return x.Fees
+	}
+	return 0
+}
+
+func (x *DryRunDASubmissionResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+var File_rollkit_v1_status_rpc_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_status_rpc_proto_rawDesc = "" +
+	"\n" +
+	"\x1brollkit/v1/status_rpc.proto\x12\n" +
+	"rollkit.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18rollkit/v1/rollkit.proto\"\xd3\x03\n" +
+	"\x11GetStatusResponse\x12\x12\n" +
+	"\x04mode\x18\x01 \x01(\tR\x04mode\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height\x12,\n" +
+	"\x12da_included_height\x18\x03 \x01(\x04R\x10daIncludedHeight\x12\x1b\n" +
+	"\tda_height\x18\x04 \x01(\x04R\bdaHeight\x12$\n" +
+	"\x0eda_head_height\x18\x05 \x01(\x04R\fdaHeadHeight\x12'\n" +
+	"\x0fpending_headers\x18\x06 \x01(\x04R\x0ependingHeaders\x12'\n" +
+	"\x0fpending_batches\x18\a \x01(\x04R\x0ependingBatches\x12\x14\n" +
+	"\x05peers\x18\b \x01(\rR\x05peers\x12\x1f\n" +
+	"\vcatching_up\x18\t \x01(\bR\n" +
+	"catchingUp\x12%\n" +
+	"\x0eexecutor_error\x18\n" +
+	" \x01(\tR\rexecutorError\x12\x19\n" +
+	"\bda_error\x18\v \x01(\tR\adaError\x12#\n" +
+	"\rp2p_listening\x18\f \x01(\bR\fp2pListening\x12\x19\n" +
+	"\bsync_lag\x18\r \x01(\x04R\asyncLag\x12\x16\n" +
+	"\x06paused\x18\x0e \x01(\bR\x06paused\"\x99\x01\n" +
+	"\x11GetLeaderResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x16\n" +
+	"\x06leader\x18\x02 \x01(\tR\x06leader\x12\x12\n" +
+	"\x04term\x18\x03 \x01(\x04R\x04term\x12!\n" +
+	"\flease_expiry\x18\x04 \x01(\x04R\vleaseExpiry\x12\x1b\n" +
+	"\tis_leader\x18\x05 \x01(\bR\bisLeader\";\n" +
+	"!GetBlockConfirmationStatusRequest\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\"\xff\x01\n" +
+	"\"GetBlockConfirmationStatusResponse\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\x126\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1e.rollkit.v1.ConfirmationStatusR\x06status\x122\n" +
+	"\x15soft_confirmed_height\x18\x03 \x01(\x04R\x13softConfirmedHeight\x12,\n" +
+	"\x12da_included_height\x18\x04 \x01(\x04R\x10daIncludedHeight\x12'\n" +
+	"\x0fattested_height\x18\x05 \x01(\x04R\x0eattestedHeight\"4\n" +
+	"\x15GetDAGasPriceResponse\x12\x1b\n" +
+	"\tgas_price\x18\x01 \x01(\x01R\bgasPrice\"H\n" +
+	"\x06DAFees\x12\x12\n" +
+	"\x04fees\x18\x01 \x01(\x01R\x04fees\x12\x14\n" +
+	"\x05blobs\x18\x02 \x01(\x04R\x05blobs\x12\x14\n" +
+	"\x05bytes\x18\x03 \x01(\x04R\x05bytes\"G\n" +
+	"\vDailyDAFees\x12\x10\n" +
+	"\x03day\x18\x01 \x01(\tR\x03day\x12&\n" +
+	"\x04fees\x18\x02 \x01(\v2\x12.rollkit.v1.DAFeesR\x04fees\"\xcf\x01\n" +
+	"\x11GetDAFeesResponse\x12(\n" +
+	"\x05total\x18\x01 \x01(\v2\x12.rollkit.v1.DAFeesR\x05total\x12(\n" +
+	"\x05today\x18\x02 \x01(\v2\x12.rollkit.v1.DAFeesR\x05today\x12+\n" +
+	"\x04days\x18\x03 \x03(\v2\x17.rollkit.v1.DailyDAFeesR\x04days\x12!\n" +
+	"\fdaily_budget\x18\x04 \x01(\x01R\vdailyBudget\x12\x16\n" +
+	"\x06paused\x18\x05 \x01(\bR\x06paused\"{\n" +
+	"\x19GetDAVerificationResponse\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\x12'\n" +
+	"\x0fverified_height\x18\x02 \x01(\x04R\x0everifiedHeight\x12\x1b\n" +
+	"\tda_height\x18\x03 \x01(\x04R\bdaHeight\"4\n" +
+	"\x1aGetDAInclusionProofRequest\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\"\x8e\x01\n" +
+	"\vDABlobProof\x12\x1b\n" +
+	"\tda_height\x18\x01 \x01(\x04R\bdaHeight\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\fR\x02id\x12\x1e\n" +
+	"\n" +
+	"commitment\x18\x04 \x01(\fR\n" +
+	"commitment\x12\x14\n" +
+	"\x05proof\x18\x05 \x01(\fR\x05proof\"\x93\x01\n" +
+	"\x1bGetDAInclusionProofResponse\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\x12/\n" +
+	"\x06header\x18\x02 \x01(\v2\x17.rollkit.v1.DABlobProofR\x06header\x12+\n" +
+	"\x04data\x18\x03 \x01(\v2\x17.rollkit.v1.DABlobProofR\x04data\"5\n" +
+	"\x1bGetLightClientUpdateRequest\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\"\xac\x01\n" +
+	"\x19LightClientConsensusState\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x12\n" +
+	"\x04root\x18\x03 \x01(\fR\x04root\x12)\n" +
+	"\x10proposer_address\x18\x04 \x01(\fR\x0fproposerAddress\"z\n" +
+	"\rDABlobPointer\x12\x1b\n" +
+	"\tda_height\x18\x01 \x01(\x04R\bdaHeight\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x0e\n" +
+	"\x02id\x18\x03 \x01(\fR\x02id\x12\x1e\n" +
+	"\n" +
+	"commitment\x18\x04 \x01(\fR\n" +
+	"commitment\"\x99\x02\n" +
+	"\x1cGetLightClientUpdateResponse\x12N\n" +
+	"\x0fconsensus_state\x18\x01 \x01(\v2%.rollkit.v1.LightClientConsensusStateR\x0econsensusState\x12=\n" +
+	"\rsigned_header\x18\x02 \x01(\v2\x18.rollkit.v1.SignedHeaderR\fsignedHeader\x126\n" +
+	"\tda_header\x18\x03 \x01(\v2\x19.rollkit.v1.DABlobPointerR\bdaHeader\x122\n" +
+	"\ada_data\x18\x04 \x01(\v2\x19.rollkit.v1.DABlobPointerR\x06daData\"<\n" +
+	"\x16GetHeadersRangeRequest\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x04R\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\x04R\x02to\"M\n" +
+	"\x17GetHeadersRangeResponse\x122\n" +
+	"\aheaders\x18\x01 \x03(\v2\x18.rollkit.v1.SignedHeaderR\aheaders\"{\n" +
+	"\fDAChainStats\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x14\n" +
+	"\x05blobs\x18\x02 \x01(\x04R\x05blobs\x12\x14\n" +
+	"\x05bytes\x18\x03 \x01(\x04R\x05bytes\x12$\n" +
+	"\x0elast_da_height\x18\x04 \x01(\x04R\flastDaHeight\"K\n" +
+	"\x17GetDAChainStatsResponse\x120\n" +
+	"\x06chains\x18\x01 \x03(\v2\x18.rollkit.v1.DAChainStatsR\x06chains\"3\n" +
+	"\x19DryRunDASubmissionRequest\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\"Y\n" +
+	"\x0eDABlobEstimate\x12\x1d\n" +
+	"\n" +
+	"blob_sizes\x18\x01 \x03(\x04R\tblobSizes\x12\x14\n" +
+	"\x05bytes\x18\x02 \x01(\x04R\x05bytes\x12\x12\n" +
+	"\x04fees\x18\x03 \x01(\x01R\x04fees\"\x85\x02\n" +
+	"\x1aDryRunDASubmissionResponse\x12\x16\n" +
+	"\x06height\x18\x01 \x01(\x04R\x06height\x122\n" +
+	"\x06header\x18\x02 \x01(\v2\x1a.rollkit.v1.DABlobEstimateR\x06header\x12.\n" +
+	"\x04data\x18\x03 \x01(\v2\x1a.rollkit.v1.DABlobEstimateR\x04data\x12\x1b\n" +
+	"\tgas_price\x18\x04 \x01(\x01R\bgasPrice\x12\"\n" +
+	"\rmax_blob_size\x18\x05 \x01(\x04R\vmaxBlobSize\x12\x12\n" +
+	"\x04fees\x18\x06 \x01(\x01R\x04fees\x12\x16\n" +
+	"\x06errors\x18\a \x03(\tR\x06errors*\xa5\x01\n" +
+	"\x12ConfirmationStatus\x12\x1f\n" +
+	"\x1bCONFIRMATION_STATUS_PENDING\x10\x00\x12&\n" +
+	"\"CONFIRMATION_STATUS_SOFT_CONFIRMED\x10\x01\x12$\n" +
+	" CONFIRMATION_STATUS_DA_FINALIZED\x10\x02\x12 \n" +
+	"\x1cCONFIRMATION_STATUS_ATTESTED\x10\x032\xf2\a\n" +
+	"\rStatusService\x12D\n" +
+	"\tGetStatus\x12\x16.google.protobuf.Empty\x1a\x1d.rollkit.v1.GetStatusResponse\"\x00\x12D\n" +
+	"\tGetLeader\x12\x16.google.protobuf.Empty\x1a\x1d.rollkit.v1.GetLeaderResponse\"\x00\x12}\n" +
+	"\x1aGetBlockConfirmationStatus\x12-.rollkit.v1.GetBlockConfirmationStatusRequest\x1a..rollkit.v1.GetBlockConfirmationStatusResponse\"\x00\x12L\n" +
+	"\rGetDAGasPrice\x12\x16.google.protobuf.Empty\x1a!.rollkit.v1.GetDAGasPriceResponse\"\x00\x12D\n" +
+	"\tGetDAFees\x12\x16.google.protobuf.Empty\x1a\x1d.rollkit.v1.GetDAFeesResponse\"\x00\x12T\n" +
+	"\x11GetDAVerification\x12\x16.google.protobuf.Empty\x1a%.rollkit.v1.GetDAVerificationResponse\"\x00\x12h\n" +
+	"\x13GetDAInclusionProof\x12&.rollkit.v1.GetDAInclusionProofRequest\x1a'.rollkit.v1.GetDAInclusionProofResponse\"\x00\x12k\n" +
+	"\x14GetLightClientUpdate\x12'.rollkit.v1.GetLightClientUpdateRequest\x1a(.rollkit.v1.GetLightClientUpdateResponse\"\x00\x12\\\n" +
+	"\x0fGetHeadersRange\x12\".rollkit.v1.GetHeadersRangeRequest\x1a#.rollkit.v1.GetHeadersRangeResponse\"\x00\x12P\n" +
+	"\x0fGetDAChainStats\x12\x16.google.protobuf.Empty\x1a#.rollkit.v1.GetDAChainStatsResponse\"\x00\x12e\n" +
+	"\x12DryRunDASubmission\x12%.rollkit.v1.DryRunDASubmissionRequest\x1a&.rollkit.v1.DryRunDASubmissionResponse\"\x00B0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_status_rpc_proto_rawDescOnce sync.Once
+	file_rollkit_v1_status_rpc_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_status_rpc_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_status_rpc_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_status_rpc_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_status_rpc_proto_rawDesc), len(file_rollkit_v1_status_rpc_proto_rawDesc)))
+	})
+	return file_rollkit_v1_status_rpc_proto_rawDescData
+}
+
+var file_rollkit_v1_status_rpc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_rollkit_v1_status_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_rollkit_v1_status_rpc_proto_goTypes = []any{
+	(ConfirmationStatus)(0),                    // 0: rollkit.v1.ConfirmationStatus
+	(*GetStatusResponse)(nil),                  // 1: rollkit.v1.GetStatusResponse
+	(*GetLeaderResponse)(nil),                  // 2: rollkit.v1.GetLeaderResponse
+	(*GetBlockConfirmationStatusRequest)(nil),  // 3: rollkit.v1.GetBlockConfirmationStatusRequest
+	(*GetBlockConfirmationStatusResponse)(nil), // 4: rollkit.v1.GetBlockConfirmationStatusResponse
+	(*GetDAGasPriceResponse)(nil),              // 5: rollkit.v1.GetDAGasPriceResponse
+	(*DAFees)(nil),                             // 6: rollkit.v1.DAFees
+	(*DailyDAFees)(nil),                        // 7: rollkit.v1.DailyDAFees
+	(*GetDAFeesResponse)(nil),                  // 8: rollkit.v1.GetDAFeesResponse
+	(*GetDAVerificationResponse)(nil),          // 9: rollkit.v1.GetDAVerificationResponse
+	(*GetDAInclusionProofRequest)(nil),         // 10: rollkit.v1.GetDAInclusionProofRequest
+	(*DABlobProof)(nil),                        // 11: rollkit.v1.DABlobProof
+	(*GetDAInclusionProofResponse)(nil),        // 12: rollkit.v1.GetDAInclusionProofResponse
+	(*GetLightClientUpdateRequest)(nil),        // 13: rollkit.v1.GetLightClientUpdateRequest
+	(*LightClientConsensusState)(nil),          // 14: rollkit.v1.LightClientConsensusState
+	(*DABlobPointer)(nil),                      // 15: rollkit.v1.DABlobPointer
+	(*GetLightClientUpdateResponse)(nil),       // 16: rollkit.v1.GetLightClientUpdateResponse
+	(*GetHeadersRangeRequest)(nil),             // 17: rollkit.v1.GetHeadersRangeRequest
+	(*GetHeadersRangeResponse)(nil),            // 18: rollkit.v1.GetHeadersRangeResponse
+	(*DAChainStats)(nil),                       // 19: rollkit.v1.DAChainStats
+	(*GetDAChainStatsResponse)(nil),            // 20: rollkit.v1.GetDAChainStatsResponse
+	(*DryRunDASubmissionRequest)(nil),          // 21: rollkit.v1.DryRunDASubmissionRequest
+	(*DABlobEstimate)(nil),                     // 22: rollkit.v1.DABlobEstimate
+	(*DryRunDASubmissionResponse)(nil),         // 23: rollkit.v1.DryRunDASubmissionResponse
+	(*timestamppb.Timestamp)(nil),              // 24: google.protobuf.Timestamp
+	(*SignedHeader)(nil),                       // 25: rollkit.v1.SignedHeader
+	(*emptypb.Empty)(nil),                      // 26: google.protobuf.Empty
+}
+var file_rollkit_v1_status_rpc_proto_depIdxs = []int32{
+	0,  // 0: rollkit.v1.GetBlockConfirmationStatusResponse.status:type_name -> rollkit.v1.ConfirmationStatus
+	6,  // 1: rollkit.v1.DailyDAFees.fees:type_name -> rollkit.v1.DAFees
+	6,  // 2: rollkit.v1.GetDAFeesResponse.total:type_name -> rollkit.v1.DAFees
+	6,  // 3: rollkit.v1.GetDAFeesResponse.today:type_name -> rollkit.v1.DAFees
+	7,  // 4: rollkit.v1.GetDAFeesResponse.days:type_name -> rollkit.v1.DailyDAFees
+	11, // 5: rollkit.v1.GetDAInclusionProofResponse.header:type_name -> rollkit.v1.DABlobProof
+	11, // 6: rollkit.v1.GetDAInclusionProofResponse.data:type_name -> rollkit.v1.DABlobProof
+	24, // 7: rollkit.v1.LightClientConsensusState.timestamp:type_name -> google.protobuf.Timestamp
+	14, // 8: rollkit.v1.GetLightClientUpdateResponse.consensus_state:type_name -> rollkit.v1.LightClientConsensusState
+	25, // 9: rollkit.v1.GetLightClientUpdateResponse.signed_header:type_name -> rollkit.v1.SignedHeader
+	15, // 10: rollkit.v1.GetLightClientUpdateResponse.da_header:type_name -> rollkit.v1.DABlobPointer
+	15, // 11: rollkit.v1.GetLightClientUpdateResponse.da_data:type_name -> rollkit.v1.DABlobPointer
+	25, // 12: rollkit.v1.GetHeadersRangeResponse.headers:type_name -> rollkit.v1.SignedHeader
+	19, // 13: rollkit.v1.GetDAChainStatsResponse.chains:type_name -> rollkit.v1.DAChainStats
+	22, // 14: rollkit.v1.DryRunDASubmissionResponse.header:type_name -> rollkit.v1.DABlobEstimate
+	22, // 15: rollkit.v1.DryRunDASubmissionResponse.data:type_name -> rollkit.v1.DABlobEstimate
+	26, // 16: rollkit.v1.StatusService.GetStatus:input_type -> google.protobuf.Empty
+	26, // 17: rollkit.v1.StatusService.GetLeader:input_type -> google.protobuf.Empty
+	3,  // 18: rollkit.v1.StatusService.GetBlockConfirmationStatus:input_type -> rollkit.v1.GetBlockConfirmationStatusRequest
+	26, // 19: rollkit.v1.StatusService.GetDAGasPrice:input_type -> google.protobuf.Empty
+	26, // 20: rollkit.v1.StatusService.GetDAFees:input_type -> google.protobuf.Empty
+	26, // 21: rollkit.v1.StatusService.GetDAVerification:input_type -> google.protobuf.Empty
+	10, // 22: rollkit.v1.StatusService.GetDAInclusionProof:input_type -> rollkit.v1.GetDAInclusionProofRequest
+	13, // 23: rollkit.v1.StatusService.GetLightClientUpdate:input_type -> rollkit.v1.GetLightClientUpdateRequest
+	17, // 24: rollkit.v1.StatusService.GetHeadersRange:input_type -> rollkit.v1.GetHeadersRangeRequest
+	26, // 25: rollkit.v1.StatusService.GetDAChainStats:input_type -> google.protobuf.Empty
+	21, // 26: rollkit.v1.StatusService.DryRunDASubmission:input_type -> rollkit.v1.DryRunDASubmissionRequest
+	1,  // 27: rollkit.v1.StatusService.GetStatus:output_type -> rollkit.v1.GetStatusResponse
+	2,  // 28: rollkit.v1.StatusService.GetLeader:output_type -> rollkit.v1.GetLeaderResponse
+	4,  // 29: rollkit.v1.StatusService.GetBlockConfirmationStatus:output_type -> rollkit.v1.GetBlockConfirmationStatusResponse
+	5,  // 30: rollkit.v1.StatusService.GetDAGasPrice:output_type -> rollkit.v1.GetDAGasPriceResponse
+	8,  // 31: rollkit.v1.StatusService.GetDAFees:output_type -> rollkit.v1.GetDAFeesResponse
+	9,  // 32: rollkit.v1.StatusService.GetDAVerification:output_type -> rollkit.v1.GetDAVerificationResponse
+	12, // 33: rollkit.v1.StatusService.GetDAInclusionProof:output_type -> rollkit.v1.GetDAInclusionProofResponse
+	16, // 34: rollkit.v1.StatusService.GetLightClientUpdate:output_type -> rollkit.v1.GetLightClientUpdateResponse
+	18, // 35: rollkit.v1.StatusService.GetHeadersRange:output_type -> rollkit.v1.GetHeadersRangeResponse
+	20, // 36: rollkit.v1.StatusService.GetDAChainStats:output_type -> rollkit.v1.GetDAChainStatsResponse
+	23, // 37: rollkit.v1.StatusService.DryRunDASubmission:output_type -> rollkit.v1.DryRunDASubmissionResponse
+	27, // [27:38] is the sub-list for method output_type
+	16, // [16:27] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_status_rpc_proto_init() }
+func file_rollkit_v1_status_rpc_proto_init() {
+	if File_rollkit_v1_status_rpc_proto != nil {
+		return
+	}
+	file_rollkit_v1_rollkit_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_status_rpc_proto_rawDesc), len(file_rollkit_v1_status_rpc_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   23,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rollkit_v1_status_rpc_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_status_rpc_proto_depIdxs,
+		EnumInfos:         file_rollkit_v1_status_rpc_proto_enumTypes,
+		MessageInfos:      file_rollkit_v1_status_rpc_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_status_rpc_proto = out.File
+	file_rollkit_v1_status_rpc_proto_goTypes = nil
+	file_rollkit_v1_status_rpc_proto_depIdxs = nil
+}
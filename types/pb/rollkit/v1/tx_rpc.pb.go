@@ -0,0 +1,847 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/tx_rpc.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TxRejectionReason is the reason a transaction was rejected at intake
+type TxRejectionReason int32
+
+const (
+	TxRejectionReason_TX_REJECTION_REASON_UNSPECIFIED TxRejectionReason = 0
+	// The transaction is empty
+	TxRejectionReason_TX_REJECTION_REASON_EMPTY TxRejectionReason = 1
+	// The transaction is larger than the maximum transaction size of the node
+	TxRejectionReason_TX_REJECTION_REASON_TOO_LARGE TxRejectionReason = 2
+	// The payload of the transaction does not start with an accepted prefix
+	TxRejectionReason_TX_REJECTION_REASON_INVALID_PREFIX TxRejectionReason = 3
+	// The transaction was rejected by the check of the application
+	TxRejectionReason_TX_REJECTION_REASON_REJECTED_BY_APP TxRejectionReason = 4
+	// The mempool is full, or the DA submission backlog of the aggregator exceeds its threshold
+	TxRejectionReason_TX_REJECTION_REASON_MEMPOOL_FULL TxRejectionReason = 5
+)
+
+// Enum value maps for TxRejectionReason.
+var (
+	TxRejectionReason_name = map[int32]string{
+		0: "TX_REJECTION_REASON_UNSPECIFIED",
+		1: "TX_REJECTION_REASON_EMPTY",
+		2: "TX_REJECTION_REASON_TOO_LARGE",
+		3: "TX_REJECTION_REASON_INVALID_PREFIX",
+		4: "TX_REJECTION_REASON_REJECTED_BY_APP",
+		5: "TX_REJECTION_REASON_MEMPOOL_FULL",
+	}
+	TxRejectionReason_value = map[string]int32{
+		"TX_REJECTION_REASON_UNSPECIFIED":     0,
+		"TX_REJECTION_REASON_EMPTY":           1,
+		"TX_REJECTION_REASON_TOO_LARGE":       2,
+		"TX_REJECTION_REASON_INVALID_PREFIX":  3,
+		"TX_REJECTION_REASON_REJECTED_BY_APP": 4,
+		"TX_REJECTION_REASON_MEMPOOL_FULL":    5,
+	}
+)
+
+func (x TxRejectionReason) Enum() *TxRejectionReason {
+	p := new(TxRejectionReason)
+	*p = x
+	return p
+}
+
+func (x TxRejectionReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TxRejectionReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_rollkit_v1_tx_rpc_proto_enumTypes[0].Descriptor()
+}
+
+func (TxRejectionReason) Type() protoreflect.EnumType {
+	return &file_rollkit_v1_tx_rpc_proto_enumTypes[0]
+}
+
+func (x TxRejectionReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TxRejectionReason.Descriptor instead.
+func (TxRejectionReason) EnumDescriptor() ([]byte, []int) {
+	return file_rollkit_v1_tx_rpc_proto_rawDescGZIP(), []int{0}
+}
+
+// SubmitTxRequest defines the request for submitting a transaction
+type SubmitTxRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tx            []byte                 `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitTxRequest) Reset() {
+	*x = SubmitTxRequest{}
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitTxRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitTxRequest) ProtoMessage() {}
+
+func (x *SubmitTxRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTxRequest.ProtoReflect.Descriptor instead.
+func (*SubmitTxRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_tx_rpc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubmitTxRequest) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+// SubmitTxResponse defines the response for submitting a transaction
+type SubmitTxResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// SHA-256 hash of the transaction, used to look it up once included
+	Hash []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Commitment of the sequencer to include the transaction, set if the node is a sequencer issuing
+	// preconfirmations
+	Preconfirmation *Preconfirmation `protobuf:"bytes,2,opt,name=preconfirmation,proto3" json:"preconfirmation,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SubmitTxResponse) Reset() {
+	*x = SubmitTxResponse{}
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitTxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitTxResponse) ProtoMessage() {}
+
+func (x *SubmitTxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTxResponse.ProtoReflect.Descriptor instead.
+func (*SubmitTxResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_tx_rpc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubmitTxResponse) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *SubmitTxResponse) GetPreconfirmation() *Preconfirmation {
+	if x != nil {
+		return x.Preconfirmation
+	}
+	return nil
+}
+
+// TxRejection is attached as error detail to the errors of SubmitTx and BroadcastTxCommit when the transaction
+// is rejected at intake
+type TxRejection struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Reason TxRejectionReason      `protobuf:"varint,1,opt,name=reason,proto3,enum=rollkit.v1.TxRejectionReason" json:"reason,omitempty"`
+	// Human readable reason, e.g. the size limit of the node or the error of the application check
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxRejection) Reset() {
+	*x = TxRejection{}
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxRejection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxRejection) ProtoMessage() {}
+
+func (x *TxRejection) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxRejection.ProtoReflect.Descriptor instead.
+func (*TxRejection) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_tx_rpc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TxRejection) GetReason() TxRejectionReason {
+	if x != nil {
+		return x.Reason
+	}
+	return TxRejectionReason_TX_REJECTION_REASON_UNSPECIFIED
+}
+
+func (x *TxRejection) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// BroadcastTxCommitRequest defines the request for submitting a transaction and waiting for its block
+type BroadcastTxCommitRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tx    []byte                 `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	// Confirmation tier of the block including the transaction to wait for. Pending waits for soft confirmation.
+	Confirmation ConfirmationStatus `protobuf:"varint,2,opt,name=confirmation,proto3,enum=rollkit.v1.ConfirmationStatus" json:"confirmation,omitempty"`
+	// Maximum time to wait for the confirmation. Unset or above the timeout of the node waits for the timeout of
+	// the node.
+	Timeout       *durationpb.Duration `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastTxCommitRequest) Reset() {
+	*x = BroadcastTxCommitRequest{}
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastTxCommitRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastTxCommitRequest) ProtoMessage() {}
+
+func (x *BroadcastTxCommitRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastTxCommitRequest.ProtoReflect.Descriptor instead.
+func (*BroadcastTxCommitRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_tx_rpc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BroadcastTxCommitRequest) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+func (x *BroadcastTxCommitRequest) GetConfirmation() ConfirmationStatus {
+	if x != nil {
+		return x.Confirmation
+	}
+	return ConfirmationStatus_CONFIRMATION_STATUS_PENDING
+}
+
+func (x *BroadcastTxCommitRequest) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+// BroadcastTxCommitResponse defines the response for submitting a transaction and waiting for its block
+type BroadcastTxCommitResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Transaction, with the height and index of the transaction in the block including it, and its execution result
+	Result *TxResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// Confirmation tier of the block including the transaction
+	Confirmation  ConfirmationStatus `protobuf:"varint,2,opt,name=confirmation,proto3,enum=rollkit.v1.ConfirmationStatus" json:"confirmation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BroadcastTxCommitResponse) Reset() {
+	*x = BroadcastTxCommitResponse{}
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BroadcastTxCommitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BroadcastTxCommitResponse) ProtoMessage() {}
+
+func (x *BroadcastTxCommitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BroadcastTxCommitResponse.ProtoReflect.Descriptor instead.
+func (*BroadcastTxCommitResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_tx_rpc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BroadcastTxCommitResponse) GetResult() *TxResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *BroadcastTxCommitResponse) GetConfirmation() ConfirmationStatus {
+	if x != nil {
+		return x.Confirmation
+	}
+	return ConfirmationStatus_CONFIRMATION_STATUS_PENDING
+}
+
+// UnconfirmedTxsRequest defines the request for listing the transactions in the mempool
+type UnconfirmedTxsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Maximum number of transactions returned (default 30, maximum 100)
+	Limit         uint32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnconfirmedTxsRequest) Reset() {
+	*x = UnconfirmedTxsRequest{}
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnconfirmedTxsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnconfirmedTxsRequest) ProtoMessage() {}
+
+func (x *UnconfirmedTxsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnconfirmedTxsRequest.ProtoReflect.Descriptor instead.
+func (*UnconfirmedTxsRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_tx_rpc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UnconfirmedTxsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// UnconfirmedTx is a transaction waiting in the mempool
+type UnconfirmedTx struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tx    []byte                 `protobuf:"bytes,1,opt,name=tx,proto3" json:"tx,omitempty"`
+	Hash  []byte                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Priority assigned by the executor
+	Priority int64 `protobuf:"varint,3,opt,name=priority,proto3" json:"priority,omitempty"`
+	// Time the transaction was admitted to the mempool
+	ReceivedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnconfirmedTx) Reset() {
+	*x = UnconfirmedTx{}
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnconfirmedTx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnconfirmedTx) ProtoMessage() {}
+
+func (x *UnconfirmedTx) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnconfirmedTx.ProtoReflect.Descriptor instead.
+func (*UnconfirmedTx) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_tx_rpc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UnconfirmedTx) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+func (x *UnconfirmedTx) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *UnconfirmedTx) GetPriority() int64 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *UnconfirmedTx) GetReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return nil
+}
+
+// UnconfirmedTxsResponse defines the response for listing the transactions in the mempool
+type UnconfirmedTxsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Txs   []*UnconfirmedTx       `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	// Number of transactions in the mempool
+	Count uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Total size of the transactions in the mempool in bytes
+	TotalBytes    uint64 `protobuf:"varint,3,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnconfirmedTxsResponse) Reset() {
+	*x = UnconfirmedTxsResponse{}
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnconfirmedTxsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnconfirmedTxsResponse) ProtoMessage() {}
+
+func (x *UnconfirmedTxsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnconfirmedTxsResponse.ProtoReflect.Descriptor instead.
+func (*UnconfirmedTxsResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_tx_rpc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UnconfirmedTxsResponse) GetTxs() []*UnconfirmedTx {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+func (x *UnconfirmedTxsResponse) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *UnconfirmedTxsResponse) GetTotalBytes() uint64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+// NumUnconfirmedTxsResponse defines the response for counting the transactions in the mempool
+type NumUnconfirmedTxsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         uint64                 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	TotalBytes    uint64                 `protobuf:"varint,2,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NumUnconfirmedTxsResponse) Reset() {
+	*x = NumUnconfirmedTxsResponse{}
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NumUnconfirmedTxsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NumUnconfirmedTxsResponse) ProtoMessage() {}
+
+func (x *NumUnconfirmedTxsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NumUnconfirmedTxsResponse.ProtoReflect.Descriptor instead.
+func (*NumUnconfirmedTxsResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_tx_rpc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *NumUnconfirmedTxsResponse) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *NumUnconfirmedTxsResponse) GetTotalBytes() uint64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+// VerifyPreconfirmationRequest defines the request for verifying a preconfirmation
+type VerifyPreconfirmationRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Preconfirmation *Preconfirmation       `protobuf:"bytes,1,opt,name=preconfirmation,proto3" json:"preconfirmation,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VerifyPreconfirmationRequest) Reset() {
+	*x = VerifyPreconfirmationRequest{}
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPreconfirmationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPreconfirmationRequest) ProtoMessage() {}
+
+func (x *VerifyPreconfirmationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPreconfirmationRequest.ProtoReflect.Descriptor instead.
+func (*VerifyPreconfirmationRequest) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_tx_rpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *VerifyPreconfirmationRequest) GetPreconfirmation() *Preconfirmation {
+	if x != nil {
+		return x.Preconfirmation
+	}
+	return nil
+}
+
+// VerifyPreconfirmationResponse defines the response for verifying a preconfirmation
+type VerifyPreconfirmationResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Status PreconfirmationStatus  `protobuf:"varint,1,opt,name=status,proto3,enum=rollkit.v1.PreconfirmationStatus" json:"status,omitempty"`
+	// Height of the block including the transaction, if included
+	Height        uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPreconfirmationResponse) Reset() {
+	*x = VerifyPreconfirmationResponse{}
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPreconfirmationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPreconfirmationResponse) ProtoMessage() {}
+
+func (x *VerifyPreconfirmationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_tx_rpc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPreconfirmationResponse.ProtoReflect.Descriptor instead.
+func (*VerifyPreconfirmationResponse) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_tx_rpc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VerifyPreconfirmationResponse) GetStatus() PreconfirmationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PreconfirmationStatus_PRECONFIRMATION_STATUS_UNSPECIFIED
+}
+
+func (x *VerifyPreconfirmationResponse) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+var File_rollkit_v1_tx_rpc_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_tx_rpc_proto_rawDesc = "" +
+	"\n" +
+	"\x17rollkit/v1/tx_rpc.proto\x12\n" +
+	"rollkit.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a rollkit/v1/preconfirmation.proto\x1a\x1brollkit/v1/status_rpc.proto\x1a\x18rollkit/v1/txindex.proto\"!\n" +
+	"\x0fSubmitTxRequest\x12\x0e\n" +
+	"\x02tx\x18\x01 \x01(\fR\x02tx\"m\n" +
+	"\x10SubmitTxResponse\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\fR\x04hash\x12E\n" +
+	"\x0fpreconfirmation\x18\x02 \x01(\v2\x1b.rollkit.v1.PreconfirmationR\x0fpreconfirmation\"^\n" +
+	"\vTxRejection\x125\n" +
+	"\x06reason\x18\x01 \x01(\x0e2\x1d.rollkit.v1.TxRejectionReasonR\x06reason\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\xa3\x01\n" +
+	"\x18BroadcastTxCommitRequest\x12\x0e\n" +
+	"\x02tx\x18\x01 \x01(\fR\x02tx\x12B\n" +
+	"\fconfirmation\x18\x02 \x01(\x0e2\x1e.rollkit.v1.ConfirmationStatusR\fconfirmation\x123\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\atimeout\"\x8d\x01\n" +
+	"\x19BroadcastTxCommitResponse\x12,\n" +
+	"\x06result\x18\x01 \x01(\v2\x14.rollkit.v1.TxResultR\x06result\x12B\n" +
+	"\fconfirmation\x18\x02 \x01(\x0e2\x1e.rollkit.v1.ConfirmationStatusR\fconfirmation\"-\n" +
+	"\x15UnconfirmedTxsRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\rR\x05limit\"\x8c\x01\n" +
+	"\rUnconfirmedTx\x12\x0e\n" +
+	"\x02tx\x18\x01 \x01(\fR\x02tx\x12\x12\n" +
+	"\x04hash\x18\x02 \x01(\fR\x04hash\x12\x1a\n" +
+	"\bpriority\x18\x03 \x01(\x03R\bpriority\x12;\n" +
+	"\vreceived_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"receivedAt\"|\n" +
+	"\x16UnconfirmedTxsResponse\x12+\n" +
+	"\x03txs\x18\x01 \x03(\v2\x19.rollkit.v1.UnconfirmedTxR\x03txs\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x04R\x05count\x12\x1f\n" +
+	"\vtotal_bytes\x18\x03 \x01(\x04R\n" +
+	"totalBytes\"R\n" +
+	"\x19NumUnconfirmedTxsResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x04R\x05count\x12\x1f\n" +
+	"\vtotal_bytes\x18\x02 \x01(\x04R\n" +
+	"totalBytes\"e\n" +
+	"\x1cVerifyPreconfirmationRequest\x12E\n" +
+	"\x0fpreconfirmation\x18\x01 \x01(\v2\x1b.rollkit.v1.PreconfirmationR\x0fpreconfirmation\"r\n" +
+	"\x1dVerifyPreconfirmationResponse\x129\n" +
+	"\x06status\x18\x01 \x01(\x0e2!.rollkit.v1.PreconfirmationStatusR\x06status\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height*\xf1\x01\n" +
+	"\x11TxRejectionReason\x12#\n" +
+	"\x1fTX_REJECTION_REASON_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19TX_REJECTION_REASON_EMPTY\x10\x01\x12!\n" +
+	"\x1dTX_REJECTION_REASON_TOO_LARGE\x10\x02\x12&\n" +
+	"\"TX_REJECTION_REASON_INVALID_PREFIX\x10\x03\x12'\n" +
+	"#TX_REJECTION_REASON_REJECTED_BY_APP\x10\x04\x12$\n" +
+	" TX_REJECTION_REASON_MEMPOOL_FULL\x10\x052\xaa\x04\n" +
+	"\tTxService\x12G\n" +
+	"\bSubmitTx\x12\x1b.rollkit.v1.SubmitTxRequest\x1a\x1c.rollkit.v1.SubmitTxResponse\"\x00\x12O\n" +
+	"\x10BroadcastTxAsync\x12\x1b.rollkit.v1.SubmitTxRequest\x1a\x1c.rollkit.v1.SubmitTxResponse\"\x00\x12b\n" +
+	"\x11BroadcastTxCommit\x12$.rollkit.v1.BroadcastTxCommitRequest\x1a%.rollkit.v1.BroadcastTxCommitResponse\"\x00\x12Y\n" +
+	"\x0eUnconfirmedTxs\x12!.rollkit.v1.UnconfirmedTxsRequest\x1a\".rollkit.v1.UnconfirmedTxsResponse\"\x00\x12T\n" +
+	"\x11NumUnconfirmedTxs\x12\x16.google.protobuf.Empty\x1a%.rollkit.v1.NumUnconfirmedTxsResponse\"\x00\x12n\n" +
+	"\x15VerifyPreconfirmation\x12(.rollkit.v1.VerifyPreconfirmationRequest\x1a).rollkit.v1.VerifyPreconfirmationResponse\"\x00B0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_tx_rpc_proto_rawDescOnce sync.Once
+	file_rollkit_v1_tx_rpc_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_tx_rpc_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_tx_rpc_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_tx_rpc_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_tx_rpc_proto_rawDesc), len(file_rollkit_v1_tx_rpc_proto_rawDesc)))
+	})
+	return file_rollkit_v1_tx_rpc_proto_rawDescData
+}
+
+var file_rollkit_v1_tx_rpc_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_rollkit_v1_tx_rpc_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_rollkit_v1_tx_rpc_proto_goTypes = []any{
+	(TxRejectionReason)(0),                // 0: rollkit.v1.TxRejectionReason
+	(*SubmitTxRequest)(nil),               // 1: rollkit.v1.SubmitTxRequest
+	(*SubmitTxResponse)(nil),              // 2: rollkit.v1.SubmitTxResponse
+	(*TxRejection)(nil),                   // 3: rollkit.v1.TxRejection
+	(*BroadcastTxCommitRequest)(nil),      // 4: rollkit.v1.BroadcastTxCommitRequest
+	(*BroadcastTxCommitResponse)(nil),     // 5: rollkit.v1.BroadcastTxCommitResponse
+	(*UnconfirmedTxsRequest)(nil),         // 6: rollkit.v1.UnconfirmedTxsRequest
+	(*UnconfirmedTx)(nil),                 // 7: rollkit.v1.UnconfirmedTx
+	(*UnconfirmedTxsResponse)(nil),        // 8: rollkit.v1.UnconfirmedTxsResponse
+	(*NumUnconfirmedTxsResponse)(nil),     // 9: rollkit.v1.NumUnconfirmedTxsResponse
+	(*VerifyPreconfirmationRequest)(nil),  // 10: rollkit.v1.VerifyPreconfirmationRequest
+	(*VerifyPreconfirmationResponse)(nil), // 11: rollkit.v1.VerifyPreconfirmationResponse
+	(*Preconfirmation)(nil),               // 12: rollkit.v1.Preconfirmation
+	(ConfirmationStatus)(0),               // 13: rollkit.v1.ConfirmationStatus
+	(*durationpb.Duration)(nil),           // 14: google.protobuf.Duration
+	(*TxResult)(nil),                      // 15: rollkit.v1.TxResult
+	(*timestamppb.Timestamp)(nil),         // 16: google.protobuf.Timestamp
+	(PreconfirmationStatus)(0),            // 17: rollkit.v1.PreconfirmationStatus
+	(*emptypb.Empty)(nil),                 // 18: google.protobuf.Empty
+}
+var file_rollkit_v1_tx_rpc_proto_depIdxs = []int32{
+	12, // 0: rollkit.v1.SubmitTxResponse.preconfirmation:type_name -> rollkit.v1.Preconfirmation
+	0,  // 1: rollkit.v1.TxRejection.reason:type_name -> rollkit.v1.TxRejectionReason
+	13, // 2: rollkit.v1.BroadcastTxCommitRequest.confirmation:type_name -> rollkit.v1.ConfirmationStatus
+	14, // 3: rollkit.v1.BroadcastTxCommitRequest.timeout:type_name -> google.protobuf.Duration
+	15, // 4: rollkit.v1.BroadcastTxCommitResponse.result:type_name -> rollkit.v1.TxResult
+	13, // 5: rollkit.v1.BroadcastTxCommitResponse.confirmation:type_name -> rollkit.v1.ConfirmationStatus
+	16, // 6: rollkit.v1.UnconfirmedTx.received_at:type_name -> google.protobuf.Timestamp
+	7,  // 7: rollkit.v1.UnconfirmedTxsResponse.txs:type_name -> rollkit.v1.UnconfirmedTx
+	12, // 8: rollkit.v1.VerifyPreconfirmationRequest.preconfirmation:type_name -> rollkit.v1.Preconfirmation
+	17, // 9: rollkit.v1.VerifyPreconfirmationResponse.status:type_name -> rollkit.v1.PreconfirmationStatus
+	1,  // 10: rollkit.v1.TxService.SubmitTx:input_type -> rollkit.v1.SubmitTxRequest
+	1,  // 11: rollkit.v1.TxService.BroadcastTxAsync:input_type -> rollkit.v1.SubmitTxRequest
+	4,  // 12: rollkit.v1.TxService.BroadcastTxCommit:input_type -> rollkit.v1.BroadcastTxCommitRequest
+	6,  // 13: rollkit.v1.TxService.UnconfirmedTxs:input_type -> rollkit.v1.UnconfirmedTxsRequest
+	18, // 14: rollkit.v1.TxService.NumUnconfirmedTxs:input_type -> google.protobuf.Empty
+	10, // 15: rollkit.v1.TxService.VerifyPreconfirmation:input_type -> rollkit.v1.VerifyPreconfirmationRequest
+	2,  // 16: rollkit.v1.TxService.SubmitTx:output_type -> rollkit.v1.SubmitTxResponse
+	2,  // 17: rollkit.v1.TxService.BroadcastTxAsync:output_type -> rollkit.v1.SubmitTxResponse
+	5,  // 18: rollkit.v1.TxService.BroadcastTxCommit:output_type -> rollkit.v1.BroadcastTxCommitResponse
+	8,  // 19: rollkit.v1.TxService.UnconfirmedTxs:output_type -> rollkit.v1.UnconfirmedTxsResponse
+	9,  // 20: rollkit.v1.TxService.NumUnconfirmedTxs:output_type -> rollkit.v1.NumUnconfirmedTxsResponse
+	11, // 21: rollkit.v1.TxService.VerifyPreconfirmation:output_type -> rollkit.v1.VerifyPreconfirmationResponse
+	16, // [16:22] is the sub-list for method output_type
+	10, // [10:16] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_tx_rpc_proto_init() }
+func file_rollkit_v1_tx_rpc_proto_init() {
+	if File_rollkit_v1_tx_rpc_proto != nil {
+		return
+	}
+	file_rollkit_v1_preconfirmation_proto_init()
+	file_rollkit_v1_status_rpc_proto_init()
+	file_rollkit_v1_txindex_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_tx_rpc_proto_rawDesc), len(file_rollkit_v1_tx_rpc_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rollkit_v1_tx_rpc_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_tx_rpc_proto_depIdxs,
+		EnumInfos:         file_rollkit_v1_tx_rpc_proto_enumTypes,
+		MessageInfos:      file_rollkit_v1_tx_rpc_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_tx_rpc_proto = out.File
+	file_rollkit_v1_tx_rpc_proto_goTypes = nil
+	file_rollkit_v1_tx_rpc_proto_depIdxs = nil
+}
@@ -0,0 +1,310 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/txindex.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EventAttribute is a key-value pair attached to an event.
+type EventAttribute struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventAttribute) Reset() {
+	*x = EventAttribute{}
+	mi := &file_rollkit_v1_txindex_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventAttribute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventAttribute) ProtoMessage() {}
+
+func (x *EventAttribute) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_txindex_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventAttribute.ProtoReflect.Descriptor instead.
+func (*EventAttribute) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_txindex_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *EventAttribute) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *EventAttribute) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// Event is an event emitted by the execution layer while executing a transaction.
+type Event struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Attributes    []*EventAttribute      `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_rollkit_v1_txindex_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Event) ProtoMessage() {}
+
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_txindex_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_txindex_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Event) GetAttributes() []*EventAttribute {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// TxResult is an indexed transaction.
+type TxResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// hash is the SHA-256 hash of the transaction
+	Hash   []byte `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Height uint64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// index is the position of the transaction in the block
+	Index  uint32   `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	Tx     []byte   `protobuf:"bytes,4,opt,name=tx,proto3" json:"tx,omitempty"`
+	Events []*Event `protobuf:"bytes,5,rep,name=events,proto3" json:"events,omitempty"`
+	// code is 0 if the transaction succeeded. The execution result is only known for executors reporting the
+	// result of each transaction.
+	Code uint32 `protobuf:"varint,6,opt,name=code,proto3" json:"code,omitempty"`
+	// log describes why the transaction failed, if it did
+	Log           string `protobuf:"bytes,7,opt,name=log,proto3" json:"log,omitempty"`
+	GasUsed       uint64 `protobuf:"varint,8,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TxResult) Reset() {
+	*x = TxResult{}
+	mi := &file_rollkit_v1_txindex_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TxResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxResult) ProtoMessage() {}
+
+func (x *TxResult) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_txindex_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxResult.ProtoReflect.Descriptor instead.
+func (*TxResult) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_txindex_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TxResult) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *TxResult) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *TxResult) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *TxResult) GetTx() []byte {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+func (x *TxResult) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *TxResult) GetCode() uint32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *TxResult) GetLog() string {
+	if x != nil {
+		return x.Log
+	}
+	return ""
+}
+
+func (x *TxResult) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
+}
+
+var File_rollkit_v1_txindex_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_txindex_proto_rawDesc = "" +
+	"\n" +
+	"\x18rollkit/v1/txindex.proto\x12\n" +
+	"rollkit.v1\"8\n" +
+	"\x0eEventAttribute\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"W\n" +
+	"\x05Event\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12:\n" +
+	"\n" +
+	"attributes\x18\x02 \x03(\v2\x1a.rollkit.v1.EventAttributeR\n" +
+	"attributes\"\xc8\x01\n" +
+	"\bTxResult\x12\x12\n" +
+	"\x04hash\x18\x01 \x01(\fR\x04hash\x12\x16\n" +
+	"\x06height\x18\x02 \x01(\x04R\x06height\x12\x14\n" +
+	"\x05index\x18\x03 \x01(\rR\x05index\x12\x0e\n" +
+	"\x02tx\x18\x04 \x01(\fR\x02tx\x12)\n" +
+	"\x06events\x18\x05 \x03(\v2\x11.rollkit.v1.EventR\x06events\x12\x12\n" +
+	"\x04code\x18\x06 \x01(\rR\x04code\x12\x10\n" +
+	"\x03log\x18\a \x01(\tR\x03log\x12\x19\n" +
+	"\bgas_used\x18\b \x01(\x04R\agasUsedB0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_txindex_proto_rawDescOnce sync.Once
+	file_rollkit_v1_txindex_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_txindex_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_txindex_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_txindex_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_txindex_proto_rawDesc), len(file_rollkit_v1_txindex_proto_rawDesc)))
+	})
+	return file_rollkit_v1_txindex_proto_rawDescData
+}
+
+var file_rollkit_v1_txindex_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_rollkit_v1_txindex_proto_goTypes = []any{
+	(*EventAttribute)(nil), // 0: rollkit.v1.EventAttribute
+	(*Event)(nil),          // 1: rollkit.v1.Event
+	(*TxResult)(nil),       // 2: rollkit.v1.TxResult
+}
+var file_rollkit_v1_txindex_proto_depIdxs = []int32{
+	0, // 0: rollkit.v1.Event.attributes:type_name -> rollkit.v1.EventAttribute
+	1, // 1: rollkit.v1.TxResult.events:type_name -> rollkit.v1.Event
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_txindex_proto_init() }
+func file_rollkit_v1_txindex_proto_init() {
+	if File_rollkit_v1_txindex_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_txindex_proto_rawDesc), len(file_rollkit_v1_txindex_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rollkit_v1_txindex_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_txindex_proto_depIdxs,
+		MessageInfos:      file_rollkit_v1_txindex_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_txindex_proto = out.File
+	file_rollkit_v1_txindex_proto_goTypes = nil
+	file_rollkit_v1_txindex_proto_depIdxs = nil
+}
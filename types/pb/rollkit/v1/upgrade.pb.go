@@ -0,0 +1,246 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: rollkit/v1/upgrade.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Upgrade is posted to the DA layer by the sequencer to schedule a chain upgrade: from the given height on,
+// headers must follow the protocol parameters set by the upgrade. Parameters left empty are not changed.
+// Nodes whose binary does not support the block version of an upgrade halt at its height until their
+// binary is swapped. Field numbers start at 48 so that upgrades are never decoded as headers, batches,
+// lease claims or key rotations sharing the same namespace.
+type Upgrade struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChainId string                 `protobuf:"bytes,48,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// Name of the upgrade, identifying it to operators
+	Name string `protobuf:"bytes,49,opt,name=name,proto3" json:"name,omitempty"`
+	// First block height whose header must follow the upgrade
+	Height uint64 `protobuf:"varint,50,opt,name=height,proto3" json:"height,omitempty"`
+	// Block protocol version of the headers from the upgrade height on
+	BlockVersion uint64 `protobuf:"varint,51,opt,name=block_version,json=blockVersion,proto3" json:"block_version,omitempty"`
+	// Hex encoded DA namespace of the headers from the upgrade height on
+	Namespace string `protobuf:"bytes,52,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// Signature scheme of the sequencer key from the upgrade height on
+	SignatureScheme string `protobuf:"bytes,53,opt,name=signature_scheme,json=signatureScheme,proto3" json:"signature_scheme,omitempty"`
+	// Public key of the sequencer signing the upgrade
+	PubKey        []byte `protobuf:"bytes,54,opt,name=pub_key,json=pubKey,proto3" json:"pub_key,omitempty"`
+	Signature     []byte `protobuf:"bytes,55,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Upgrade) Reset() {
+	*x = Upgrade{}
+	mi := &file_rollkit_v1_upgrade_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Upgrade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upgrade) ProtoMessage() {}
+
+func (x *Upgrade) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_upgrade_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upgrade.ProtoReflect.Descriptor instead.
+func (*Upgrade) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_upgrade_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Upgrade) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *Upgrade) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Upgrade) GetHeight() uint64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Upgrade) GetBlockVersion() uint64 {
+	if x != nil {
+		return x.BlockVersion
+	}
+	return 0
+}
+
+func (x *Upgrade) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Upgrade) GetSignatureScheme() string {
+	if x != nil {
+		return x.SignatureScheme
+	}
+	return ""
+}
+
+func (x *Upgrade) GetPubKey() []byte {
+	if x != nil {
+		return x.PubKey
+	}
+	return nil
+}
+
+func (x *Upgrade) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// Upgrades lists the upgrades scheduled on a node, ordered by height.
+type Upgrades struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Upgrades      []*Upgrade             `protobuf:"bytes,1,rep,name=upgrades,proto3" json:"upgrades,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Upgrades) Reset() {
+	*x = Upgrades{}
+	mi := &file_rollkit_v1_upgrade_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Upgrades) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upgrades) ProtoMessage() {}
+
+func (x *Upgrades) ProtoReflect() protoreflect.Message {
+	mi := &file_rollkit_v1_upgrade_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Upgrades.ProtoReflect.Descriptor instead.
+func (*Upgrades) Descriptor() ([]byte, []int) {
+	return file_rollkit_v1_upgrade_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Upgrades) GetUpgrades() []*Upgrade {
+	if x != nil {
+		return x.Upgrades
+	}
+	return nil
+}
+
+var File_rollkit_v1_upgrade_proto protoreflect.FileDescriptor
+
+const file_rollkit_v1_upgrade_proto_rawDesc = "" +
+	"\n" +
+	"\x18rollkit/v1/upgrade.proto\x12\n" +
+	"rollkit.v1\"\xf5\x01\n" +
+	"\aUpgrade\x12\x19\n" +
+	"\bchain_id\x180 \x01(\tR\achainId\x12\x12\n" +
+	"\x04name\x181 \x01(\tR\x04name\x12\x16\n" +
+	"\x06height\x182 \x01(\x04R\x06height\x12#\n" +
+	"\rblock_version\x183 \x01(\x04R\fblockVersion\x12\x1c\n" +
+	"\tnamespace\x184 \x01(\tR\tnamespace\x12)\n" +
+	"\x10signature_scheme\x185 \x01(\tR\x0fsignatureScheme\x12\x17\n" +
+	"\apub_key\x186 \x01(\fR\x06pubKey\x12\x1c\n" +
+	"\tsignature\x187 \x01(\fR\tsignature\";\n" +
+	"\bUpgrades\x12/\n" +
+	"\bupgrades\x18\x01 \x03(\v2\x13.rollkit.v1.UpgradeR\bupgradesB0Z.github.com/rollkit/rollkit/types/pb/rollkit/v1b\x06proto3"
+
+var (
+	file_rollkit_v1_upgrade_proto_rawDescOnce sync.Once
+	file_rollkit_v1_upgrade_proto_rawDescData []byte
+)
+
+func file_rollkit_v1_upgrade_proto_rawDescGZIP() []byte {
+	file_rollkit_v1_upgrade_proto_rawDescOnce.Do(func() {
+		file_rollkit_v1_upgrade_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rollkit_v1_upgrade_proto_rawDesc), len(file_rollkit_v1_upgrade_proto_rawDesc)))
+	})
+	return file_rollkit_v1_upgrade_proto_rawDescData
+}
+
+var file_rollkit_v1_upgrade_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rollkit_v1_upgrade_proto_goTypes = []any{
+	(*Upgrade)(nil),  // 0: rollkit.v1.Upgrade
+	(*Upgrades)(nil), // 1: rollkit.v1.Upgrades
+}
+var file_rollkit_v1_upgrade_proto_depIdxs = []int32{
+	0, // 0: rollkit.v1.Upgrades.upgrades:type_name -> rollkit.v1.Upgrade
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rollkit_v1_upgrade_proto_init() }
+func file_rollkit_v1_upgrade_proto_init() {
+	if File_rollkit_v1_upgrade_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rollkit_v1_upgrade_proto_rawDesc), len(file_rollkit_v1_upgrade_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rollkit_v1_upgrade_proto_goTypes,
+		DependencyIndexes: file_rollkit_v1_upgrade_proto_depIdxs,
+		MessageInfos:      file_rollkit_v1_upgrade_proto_msgTypes,
+	}.Build()
+	File_rollkit_v1_upgrade_proto = out.File
+	file_rollkit_v1_upgrade_proto_goTypes = nil
+	file_rollkit_v1_upgrade_proto_depIdxs = nil
+}
@@ -0,0 +1,459 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: rollkit/v1/admin.proto
+
+package v1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// AdminServiceName is the fully-qualified name of the AdminService service.
+	AdminServiceName = "rollkit.v1.AdminService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// AdminServiceGetLogLevelsProcedure is the fully-qualified name of the AdminService's GetLogLevels
+	// RPC.
+	AdminServiceGetLogLevelsProcedure = "/rollkit.v1.AdminService/GetLogLevels"
+	// AdminServiceSetLogLevelProcedure is the fully-qualified name of the AdminService's SetLogLevel
+	// RPC.
+	AdminServiceSetLogLevelProcedure = "/rollkit.v1.AdminService/SetLogLevel"
+	// AdminServiceCompactStoreProcedure is the fully-qualified name of the AdminService's CompactStore
+	// RPC.
+	AdminServiceCompactStoreProcedure = "/rollkit.v1.AdminService/CompactStore"
+	// AdminServiceGetStoreUsageProcedure is the fully-qualified name of the AdminService's
+	// GetStoreUsage RPC.
+	AdminServiceGetStoreUsageProcedure = "/rollkit.v1.AdminService/GetStoreUsage"
+	// AdminServiceGetConfigProcedure is the fully-qualified name of the AdminService's GetConfig RPC.
+	AdminServiceGetConfigProcedure = "/rollkit.v1.AdminService/GetConfig"
+	// AdminServiceUpdateConfigProcedure is the fully-qualified name of the AdminService's UpdateConfig
+	// RPC.
+	AdminServiceUpdateConfigProcedure = "/rollkit.v1.AdminService/UpdateConfig"
+	// AdminServiceRotateProposerKeyProcedure is the fully-qualified name of the AdminService's
+	// RotateProposerKey RPC.
+	AdminServiceRotateProposerKeyProcedure = "/rollkit.v1.AdminService/RotateProposerKey"
+	// AdminServiceScheduleUpgradeProcedure is the fully-qualified name of the AdminService's
+	// ScheduleUpgrade RPC.
+	AdminServiceScheduleUpgradeProcedure = "/rollkit.v1.AdminService/ScheduleUpgrade"
+	// AdminServiceSwitchModeProcedure is the fully-qualified name of the AdminService's SwitchMode RPC.
+	AdminServiceSwitchModeProcedure = "/rollkit.v1.AdminService/SwitchMode"
+	// AdminServiceCaptureDebugBundleProcedure is the fully-qualified name of the AdminService's
+	// CaptureDebugBundle RPC.
+	AdminServiceCaptureDebugBundleProcedure = "/rollkit.v1.AdminService/CaptureDebugBundle"
+	// AdminServicePauseSequencerProcedure is the fully-qualified name of the AdminService's
+	// PauseSequencer RPC.
+	AdminServicePauseSequencerProcedure = "/rollkit.v1.AdminService/PauseSequencer"
+	// AdminServiceResumeSequencerProcedure is the fully-qualified name of the AdminService's
+	// ResumeSequencer RPC.
+	AdminServiceResumeSequencerProcedure = "/rollkit.v1.AdminService/ResumeSequencer"
+)
+
+// AdminServiceClient is a client for the rollkit.v1.AdminService service.
+type AdminServiceClient interface {
+	// GetLogLevels returns the log levels of the node
+	GetLogLevels(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.LogLevelsResponse], error)
+	// SetLogLevel changes the log levels of the node without restarting it
+	SetLogLevel(context.Context, *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.LogLevelsResponse], error)
+	// CompactStore runs the compaction of the datastore, reclaiming the space of deleted and overwritten entries
+	CompactStore(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.StoreUsageResponse], error)
+	// GetStoreUsage returns the disk usage of the datastore
+	GetStoreUsage(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.StoreUsageResponse], error)
+	// GetConfig returns the runtime parameters of the node
+	GetConfig(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.RuntimeConfig], error)
+	// UpdateConfig changes runtime parameters of the node without restarting it, and persists them to the config file
+	UpdateConfig(context.Context, *connect.Request[v1.UpdateConfigRequest]) (*connect.Response[v1.RuntimeConfig], error)
+	// RotateProposerKey rotates the signing key of the sequencer from a block height on, posting a key rotation
+	// signed by the current key of the aggregator to the DA layer
+	RotateProposerKey(context.Context, *connect.Request[v1.RotateProposerKeyRequest]) (*connect.Response[v1.RotateProposerKeyResponse], error)
+	// ScheduleUpgrade schedules a chain upgrade at a block height, posting an upgrade signed by the key of the
+	// aggregator to the DA layer
+	ScheduleUpgrade(context.Context, *connect.Request[v1.ScheduleUpgradeRequest]) (*connect.Response[v1.ScheduleUpgradeResponse], error)
+	// SwitchMode switches a dual mode node between light and full mode without restarting it
+	SwitchMode(context.Context, *connect.Request[v1.SwitchModeRequest]) (*connect.Response[v1.SwitchModeResponse], error)
+	// CaptureDebugBundle captures a debug bundle of the node: a gzipped tarball holding the goroutine dump, the heap
+	// profile, the runtime metrics and the recent logs of the node
+	CaptureDebugBundle(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.DebugBundleResponse], error)
+	// PauseSequencer pauses block production of the aggregator, e.g. for a maintenance window. It returns once the
+	// block being produced is published and the blocks produced before are submitted to the DA layer
+	PauseSequencer(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.SequencerStateResponse], error)
+	// ResumeSequencer resumes block production of the aggregator paused by PauseSequencer
+	ResumeSequencer(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.SequencerStateResponse], error)
+}
+
+// NewAdminServiceClient constructs a client for the rollkit.v1.AdminService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewAdminServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) AdminServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	adminServiceMethods := v1.File_rollkit_v1_admin_proto.Services().ByName("AdminService").Methods()
+	return &adminServiceClient{
+		getLogLevels: connect.NewClient[emptypb.Empty, v1.LogLevelsResponse](
+			httpClient,
+			baseURL+AdminServiceGetLogLevelsProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("GetLogLevels")),
+			connect.WithClientOptions(opts...),
+		),
+		setLogLevel: connect.NewClient[v1.SetLogLevelRequest, v1.LogLevelsResponse](
+			httpClient,
+			baseURL+AdminServiceSetLogLevelProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SetLogLevel")),
+			connect.WithClientOptions(opts...),
+		),
+		compactStore: connect.NewClient[emptypb.Empty, v1.StoreUsageResponse](
+			httpClient,
+			baseURL+AdminServiceCompactStoreProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("CompactStore")),
+			connect.WithClientOptions(opts...),
+		),
+		getStoreUsage: connect.NewClient[emptypb.Empty, v1.StoreUsageResponse](
+			httpClient,
+			baseURL+AdminServiceGetStoreUsageProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("GetStoreUsage")),
+			connect.WithClientOptions(opts...),
+		),
+		getConfig: connect.NewClient[emptypb.Empty, v1.RuntimeConfig](
+			httpClient,
+			baseURL+AdminServiceGetConfigProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("GetConfig")),
+			connect.WithClientOptions(opts...),
+		),
+		updateConfig: connect.NewClient[v1.UpdateConfigRequest, v1.RuntimeConfig](
+			httpClient,
+			baseURL+AdminServiceUpdateConfigProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("UpdateConfig")),
+			connect.WithClientOptions(opts...),
+		),
+		rotateProposerKey: connect.NewClient[v1.RotateProposerKeyRequest, v1.RotateProposerKeyResponse](
+			httpClient,
+			baseURL+AdminServiceRotateProposerKeyProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("RotateProposerKey")),
+			connect.WithClientOptions(opts...),
+		),
+		scheduleUpgrade: connect.NewClient[v1.ScheduleUpgradeRequest, v1.ScheduleUpgradeResponse](
+			httpClient,
+			baseURL+AdminServiceScheduleUpgradeProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ScheduleUpgrade")),
+			connect.WithClientOptions(opts...),
+		),
+		switchMode: connect.NewClient[v1.SwitchModeRequest, v1.SwitchModeResponse](
+			httpClient,
+			baseURL+AdminServiceSwitchModeProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("SwitchMode")),
+			connect.WithClientOptions(opts...),
+		),
+		captureDebugBundle: connect.NewClient[emptypb.Empty, v1.DebugBundleResponse](
+			httpClient,
+			baseURL+AdminServiceCaptureDebugBundleProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("CaptureDebugBundle")),
+			connect.WithClientOptions(opts...),
+		),
+		pauseSequencer: connect.NewClient[emptypb.Empty, v1.SequencerStateResponse](
+			httpClient,
+			baseURL+AdminServicePauseSequencerProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("PauseSequencer")),
+			connect.WithClientOptions(opts...),
+		),
+		resumeSequencer: connect.NewClient[emptypb.Empty, v1.SequencerStateResponse](
+			httpClient,
+			baseURL+AdminServiceResumeSequencerProcedure,
+			connect.WithSchema(adminServiceMethods.ByName("ResumeSequencer")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// adminServiceClient implements AdminServiceClient.
+type adminServiceClient struct {
+	getLogLevels       *connect.Client[emptypb.Empty, v1.LogLevelsResponse]
+	setLogLevel        *connect.Client[v1.SetLogLevelRequest, v1.LogLevelsResponse]
+	compactStore       *connect.Client[emptypb.Empty, v1.StoreUsageResponse]
+	getStoreUsage      *connect.Client[emptypb.Empty, v1.StoreUsageResponse]
+	getConfig          *connect.Client[emptypb.Empty, v1.RuntimeConfig]
+	updateConfig       *connect.Client[v1.UpdateConfigRequest, v1.RuntimeConfig]
+	rotateProposerKey  *connect.Client[v1.RotateProposerKeyRequest, v1.RotateProposerKeyResponse]
+	scheduleUpgrade    *connect.Client[v1.ScheduleUpgradeRequest, v1.ScheduleUpgradeResponse]
+	switchMode         *connect.Client[v1.SwitchModeRequest, v1.SwitchModeResponse]
+	captureDebugBundle *connect.Client[emptypb.Empty, v1.DebugBundleResponse]
+	pauseSequencer     *connect.Client[emptypb.Empty, v1.SequencerStateResponse]
+	resumeSequencer    *connect.Client[emptypb.Empty, v1.SequencerStateResponse]
+}
+
+// GetLogLevels calls rollkit.v1.AdminService.GetLogLevels.
+func (c *adminServiceClient) GetLogLevels(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.LogLevelsResponse], error) {
+	return c.getLogLevels.CallUnary(ctx, req)
+}
+
+// SetLogLevel calls rollkit.v1.AdminService.SetLogLevel.
+func (c *adminServiceClient) SetLogLevel(ctx context.Context, req *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.LogLevelsResponse], error) {
+	return c.setLogLevel.CallUnary(ctx, req)
+}
+
+// CompactStore calls rollkit.v1.AdminService.CompactStore.
+func (c *adminServiceClient) CompactStore(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.StoreUsageResponse], error) {
+	return c.compactStore.CallUnary(ctx, req)
+}
+
+// GetStoreUsage calls rollkit.v1.AdminService.GetStoreUsage.
+func (c *adminServiceClient) GetStoreUsage(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.StoreUsageResponse], error) {
+	return c.getStoreUsage.CallUnary(ctx, req)
+}
+
+// GetConfig calls rollkit.v1.AdminService.GetConfig.
+func (c *adminServiceClient) GetConfig(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.RuntimeConfig], error) {
+	return c.getConfig.CallUnary(ctx, req)
+}
+
+// UpdateConfig calls rollkit.v1.AdminService.UpdateConfig.
+func (c *adminServiceClient) UpdateConfig(ctx context.Context, req *connect.Request[v1.UpdateConfigRequest]) (*connect.Response[v1.RuntimeConfig], error) {
+	return c.updateConfig.CallUnary(ctx, req)
+}
+
+// RotateProposerKey calls rollkit.v1.AdminService.RotateProposerKey.
+func (c *adminServiceClient) RotateProposerKey(ctx context.Context, req *connect.Request[v1.RotateProposerKeyRequest]) (*connect.Response[v1.RotateProposerKeyResponse], error) {
+	return c.rotateProposerKey.CallUnary(ctx, req)
+}
+
+// ScheduleUpgrade calls rollkit.v1.AdminService.ScheduleUpgrade.
+func (c *adminServiceClient) ScheduleUpgrade(ctx context.Context, req *connect.Request[v1.ScheduleUpgradeRequest]) (*connect.Response[v1.ScheduleUpgradeResponse], error) {
+	return c.scheduleUpgrade.CallUnary(ctx, req)
+}
+
+// SwitchMode calls rollkit.v1.AdminService.SwitchMode.
+func (c *adminServiceClient) SwitchMode(ctx context.Context, req *connect.Request[v1.SwitchModeRequest]) (*connect.Response[v1.SwitchModeResponse], error) {
+	return c.switchMode.CallUnary(ctx, req)
+}
+
+// CaptureDebugBundle calls rollkit.v1.AdminService.CaptureDebugBundle.
+func (c *adminServiceClient) CaptureDebugBundle(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.DebugBundleResponse], error) {
+	return c.captureDebugBundle.CallUnary(ctx, req)
+}
+
+// PauseSequencer calls rollkit.v1.AdminService.PauseSequencer.
+func (c *adminServiceClient) PauseSequencer(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.SequencerStateResponse], error) {
+	return c.pauseSequencer.CallUnary(ctx, req)
+}
+
+// ResumeSequencer calls rollkit.v1.AdminService.ResumeSequencer.
+func (c *adminServiceClient) ResumeSequencer(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.SequencerStateResponse], error) {
+	return c.resumeSequencer.CallUnary(ctx, req)
+}
+
+// AdminServiceHandler is an implementation of the rollkit.v1.AdminService service.
+type AdminServiceHandler interface {
+	// GetLogLevels returns the log levels of the node
+	GetLogLevels(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.LogLevelsResponse], error)
+	// SetLogLevel changes the log levels of the node without restarting it
+	SetLogLevel(context.Context, *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.LogLevelsResponse], error)
+	// CompactStore runs the compaction of the datastore, reclaiming the space of deleted and overwritten entries
+	CompactStore(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.StoreUsageResponse], error)
+	// GetStoreUsage returns the disk usage of the datastore
+	GetStoreUsage(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.StoreUsageResponse], error)
+	// GetConfig returns the runtime parameters of the node
+	GetConfig(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.RuntimeConfig], error)
+	// UpdateConfig changes runtime parameters of the node without restarting it, and persists them to the config file
+	UpdateConfig(context.Context, *connect.Request[v1.UpdateConfigRequest]) (*connect.Response[v1.RuntimeConfig], error)
+	// RotateProposerKey rotates the signing key of the sequencer from a block height on, posting a key rotation
+	// signed by the current key of the aggregator to the DA layer
+	RotateProposerKey(context.Context, *connect.Request[v1.RotateProposerKeyRequest]) (*connect.Response[v1.RotateProposerKeyResponse], error)
+	// ScheduleUpgrade schedules a chain upgrade at a block height, posting an upgrade signed by the key of the
+	// aggregator to the DA layer
+	ScheduleUpgrade(context.Context, *connect.Request[v1.ScheduleUpgradeRequest]) (*connect.Response[v1.ScheduleUpgradeResponse], error)
+	// SwitchMode switches a dual mode node between light and full mode without restarting it
+	SwitchMode(context.Context, *connect.Request[v1.SwitchModeRequest]) (*connect.Response[v1.SwitchModeResponse], error)
+	// CaptureDebugBundle captures a debug bundle of the node: a gzipped tarball holding the goroutine dump, the heap
+	// profile, the runtime metrics and the recent logs of the node
+	CaptureDebugBundle(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.DebugBundleResponse], error)
+	// PauseSequencer pauses block production of the aggregator, e.g. for a maintenance window. It returns once the
+	// block being produced is published and the blocks produced before are submitted to the DA layer
+	PauseSequencer(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.SequencerStateResponse], error)
+	// ResumeSequencer resumes block production of the aggregator paused by PauseSequencer
+	ResumeSequencer(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.SequencerStateResponse], error)
+}
+
+// NewAdminServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewAdminServiceHandler(svc AdminServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	adminServiceMethods := v1.File_rollkit_v1_admin_proto.Services().ByName("AdminService").Methods()
+	adminServiceGetLogLevelsHandler := connect.NewUnaryHandler(
+		AdminServiceGetLogLevelsProcedure,
+		svc.GetLogLevels,
+		connect.WithSchema(adminServiceMethods.ByName("GetLogLevels")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSetLogLevelHandler := connect.NewUnaryHandler(
+		AdminServiceSetLogLevelProcedure,
+		svc.SetLogLevel,
+		connect.WithSchema(adminServiceMethods.ByName("SetLogLevel")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceCompactStoreHandler := connect.NewUnaryHandler(
+		AdminServiceCompactStoreProcedure,
+		svc.CompactStore,
+		connect.WithSchema(adminServiceMethods.ByName("CompactStore")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceGetStoreUsageHandler := connect.NewUnaryHandler(
+		AdminServiceGetStoreUsageProcedure,
+		svc.GetStoreUsage,
+		connect.WithSchema(adminServiceMethods.ByName("GetStoreUsage")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceGetConfigHandler := connect.NewUnaryHandler(
+		AdminServiceGetConfigProcedure,
+		svc.GetConfig,
+		connect.WithSchema(adminServiceMethods.ByName("GetConfig")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceUpdateConfigHandler := connect.NewUnaryHandler(
+		AdminServiceUpdateConfigProcedure,
+		svc.UpdateConfig,
+		connect.WithSchema(adminServiceMethods.ByName("UpdateConfig")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceRotateProposerKeyHandler := connect.NewUnaryHandler(
+		AdminServiceRotateProposerKeyProcedure,
+		svc.RotateProposerKey,
+		connect.WithSchema(adminServiceMethods.ByName("RotateProposerKey")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceScheduleUpgradeHandler := connect.NewUnaryHandler(
+		AdminServiceScheduleUpgradeProcedure,
+		svc.ScheduleUpgrade,
+		connect.WithSchema(adminServiceMethods.ByName("ScheduleUpgrade")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceSwitchModeHandler := connect.NewUnaryHandler(
+		AdminServiceSwitchModeProcedure,
+		svc.SwitchMode,
+		connect.WithSchema(adminServiceMethods.ByName("SwitchMode")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceCaptureDebugBundleHandler := connect.NewUnaryHandler(
+		AdminServiceCaptureDebugBundleProcedure,
+		svc.CaptureDebugBundle,
+		connect.WithSchema(adminServiceMethods.ByName("CaptureDebugBundle")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServicePauseSequencerHandler := connect.NewUnaryHandler(
+		AdminServicePauseSequencerProcedure,
+		svc.PauseSequencer,
+		connect.WithSchema(adminServiceMethods.ByName("PauseSequencer")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adminServiceResumeSequencerHandler := connect.NewUnaryHandler(
+		AdminServiceResumeSequencerProcedure,
+		svc.ResumeSequencer,
+		connect.WithSchema(adminServiceMethods.ByName("ResumeSequencer")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/rollkit.v1.AdminService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case AdminServiceGetLogLevelsProcedure:
+			adminServiceGetLogLevelsHandler.ServeHTTP(w, r)
+		case AdminServiceSetLogLevelProcedure:
+			adminServiceSetLogLevelHandler.ServeHTTP(w, r)
+		case AdminServiceCompactStoreProcedure:
+			adminServiceCompactStoreHandler.ServeHTTP(w, r)
+		case AdminServiceGetStoreUsageProcedure:
+			adminServiceGetStoreUsageHandler.ServeHTTP(w, r)
+		case AdminServiceGetConfigProcedure:
+			adminServiceGetConfigHandler.ServeHTTP(w, r)
+		case AdminServiceUpdateConfigProcedure:
+			adminServiceUpdateConfigHandler.ServeHTTP(w, r)
+		case AdminServiceRotateProposerKeyProcedure:
+			adminServiceRotateProposerKeyHandler.ServeHTTP(w, r)
+		case AdminServiceScheduleUpgradeProcedure:
+			adminServiceScheduleUpgradeHandler.ServeHTTP(w, r)
+		case AdminServiceSwitchModeProcedure:
+			adminServiceSwitchModeHandler.ServeHTTP(w, r)
+		case AdminServiceCaptureDebugBundleProcedure:
+			adminServiceCaptureDebugBundleHandler.ServeHTTP(w, r)
+		case AdminServicePauseSequencerProcedure:
+			adminServicePauseSequencerHandler.ServeHTTP(w, r)
+		case AdminServiceResumeSequencerProcedure:
+			adminServiceResumeSequencerHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedAdminServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedAdminServiceHandler struct{}
+
+func (UnimplementedAdminServiceHandler) GetLogLevels(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.LogLevelsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.AdminService.GetLogLevels is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SetLogLevel(context.Context, *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.LogLevelsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.AdminService.SetLogLevel is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) CompactStore(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.StoreUsageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.AdminService.CompactStore is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) GetStoreUsage(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.StoreUsageResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.AdminService.GetStoreUsage is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) GetConfig(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.RuntimeConfig], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.AdminService.GetConfig is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) UpdateConfig(context.Context, *connect.Request[v1.UpdateConfigRequest]) (*connect.Response[v1.RuntimeConfig], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.AdminService.UpdateConfig is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) RotateProposerKey(context.Context, *connect.Request[v1.RotateProposerKeyRequest]) (*connect.Response[v1.RotateProposerKeyResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.AdminService.RotateProposerKey is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ScheduleUpgrade(context.Context, *connect.Request[v1.ScheduleUpgradeRequest]) (*connect.Response[v1.ScheduleUpgradeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.AdminService.ScheduleUpgrade is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) SwitchMode(context.Context, *connect.Request[v1.SwitchModeRequest]) (*connect.Response[v1.SwitchModeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.AdminService.SwitchMode is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) CaptureDebugBundle(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.DebugBundleResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.AdminService.CaptureDebugBundle is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) PauseSequencer(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.SequencerStateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.AdminService.PauseSequencer is not implemented"))
+}
+
+func (UnimplementedAdminServiceHandler) ResumeSequencer(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.SequencerStateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.AdminService.ResumeSequencer is not implemented"))
+}
@@ -0,0 +1,140 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: rollkit/v1/dac.proto
+
+package v1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// DACServiceName is the fully-qualified name of the DACService service.
+	DACServiceName = "rollkit.v1.DACService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// DACServiceStoreDataProcedure is the fully-qualified name of the DACService's StoreData RPC.
+	DACServiceStoreDataProcedure = "/rollkit.v1.DACService/StoreData"
+	// DACServiceGetDataProcedure is the fully-qualified name of the DACService's GetData RPC.
+	DACServiceGetDataProcedure = "/rollkit.v1.DACService/GetData"
+)
+
+// DACServiceClient is a client for the rollkit.v1.DACService service.
+type DACServiceClient interface {
+	// StoreData stores the data of a block and returns the signature of the member attesting it
+	StoreData(context.Context, *connect.Request[v1.StoreDataRequest]) (*connect.Response[v1.StoreDataResponse], error)
+	// GetData returns the data of a block stored by the member
+	GetData(context.Context, *connect.Request[v1.GetDataRequest]) (*connect.Response[v1.GetDataResponse], error)
+}
+
+// NewDACServiceClient constructs a client for the rollkit.v1.DACService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewDACServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) DACServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	dACServiceMethods := v1.File_rollkit_v1_dac_proto.Services().ByName("DACService").Methods()
+	return &dACServiceClient{
+		storeData: connect.NewClient[v1.StoreDataRequest, v1.StoreDataResponse](
+			httpClient,
+			baseURL+DACServiceStoreDataProcedure,
+			connect.WithSchema(dACServiceMethods.ByName("StoreData")),
+			connect.WithClientOptions(opts...),
+		),
+		getData: connect.NewClient[v1.GetDataRequest, v1.GetDataResponse](
+			httpClient,
+			baseURL+DACServiceGetDataProcedure,
+			connect.WithSchema(dACServiceMethods.ByName("GetData")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// dACServiceClient implements DACServiceClient.
+type dACServiceClient struct {
+	storeData *connect.Client[v1.StoreDataRequest, v1.StoreDataResponse]
+	getData   *connect.Client[v1.GetDataRequest, v1.GetDataResponse]
+}
+
+// StoreData calls rollkit.v1.DACService.StoreData.
+func (c *dACServiceClient) StoreData(ctx context.Context, req *connect.Request[v1.StoreDataRequest]) (*connect.Response[v1.StoreDataResponse], error) {
+	return c.storeData.CallUnary(ctx, req)
+}
+
+// GetData calls rollkit.v1.DACService.GetData.
+func (c *dACServiceClient) GetData(ctx context.Context, req *connect.Request[v1.GetDataRequest]) (*connect.Response[v1.GetDataResponse], error) {
+	return c.getData.CallUnary(ctx, req)
+}
+
+// DACServiceHandler is an implementation of the rollkit.v1.DACService service.
+type DACServiceHandler interface {
+	// StoreData stores the data of a block and returns the signature of the member attesting it
+	StoreData(context.Context, *connect.Request[v1.StoreDataRequest]) (*connect.Response[v1.StoreDataResponse], error)
+	// GetData returns the data of a block stored by the member
+	GetData(context.Context, *connect.Request[v1.GetDataRequest]) (*connect.Response[v1.GetDataResponse], error)
+}
+
+// NewDACServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewDACServiceHandler(svc DACServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	dACServiceMethods := v1.File_rollkit_v1_dac_proto.Services().ByName("DACService").Methods()
+	dACServiceStoreDataHandler := connect.NewUnaryHandler(
+		DACServiceStoreDataProcedure,
+		svc.StoreData,
+		connect.WithSchema(dACServiceMethods.ByName("StoreData")),
+		connect.WithHandlerOptions(opts...),
+	)
+	dACServiceGetDataHandler := connect.NewUnaryHandler(
+		DACServiceGetDataProcedure,
+		svc.GetData,
+		connect.WithSchema(dACServiceMethods.ByName("GetData")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/rollkit.v1.DACService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case DACServiceStoreDataProcedure:
+			dACServiceStoreDataHandler.ServeHTTP(w, r)
+		case DACServiceGetDataProcedure:
+			dACServiceGetDataHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedDACServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedDACServiceHandler struct{}
+
+func (UnimplementedDACServiceHandler) StoreData(context.Context, *connect.Request[v1.StoreDataRequest]) (*connect.Response[v1.StoreDataResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.DACService.StoreData is not implemented"))
+}
+
+func (UnimplementedDACServiceHandler) GetData(context.Context, *connect.Request[v1.GetDataRequest]) (*connect.Response[v1.GetDataResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.DACService.GetData is not implemented"))
+}
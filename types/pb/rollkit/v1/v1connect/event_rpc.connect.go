@@ -0,0 +1,110 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: rollkit/v1/event_rpc.proto
+
+package v1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// EventServiceName is the fully-qualified name of the EventService service.
+	EventServiceName = "rollkit.v1.EventService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// EventServiceSubscribeProcedure is the fully-qualified name of the EventService's Subscribe RPC.
+	EventServiceSubscribeProcedure = "/rollkit.v1.EventService/Subscribe"
+)
+
+// EventServiceClient is a client for the rollkit.v1.EventService service.
+type EventServiceClient interface {
+	// Subscribe streams node events until the client cancels the stream
+	Subscribe(context.Context, *connect.Request[v1.SubscribeRequest]) (*connect.ServerStreamForClient[v1.NodeEvent], error)
+}
+
+// NewEventServiceClient constructs a client for the rollkit.v1.EventService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewEventServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) EventServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	eventServiceMethods := v1.File_rollkit_v1_event_rpc_proto.Services().ByName("EventService").Methods()
+	return &eventServiceClient{
+		subscribe: connect.NewClient[v1.SubscribeRequest, v1.NodeEvent](
+			httpClient,
+			baseURL+EventServiceSubscribeProcedure,
+			connect.WithSchema(eventServiceMethods.ByName("Subscribe")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// eventServiceClient implements EventServiceClient.
+type eventServiceClient struct {
+	subscribe *connect.Client[v1.SubscribeRequest, v1.NodeEvent]
+}
+
+// Subscribe calls rollkit.v1.EventService.Subscribe.
+func (c *eventServiceClient) Subscribe(ctx context.Context, req *connect.Request[v1.SubscribeRequest]) (*connect.ServerStreamForClient[v1.NodeEvent], error) {
+	return c.subscribe.CallServerStream(ctx, req)
+}
+
+// EventServiceHandler is an implementation of the rollkit.v1.EventService service.
+type EventServiceHandler interface {
+	// Subscribe streams node events until the client cancels the stream
+	Subscribe(context.Context, *connect.Request[v1.SubscribeRequest], *connect.ServerStream[v1.NodeEvent]) error
+}
+
+// NewEventServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewEventServiceHandler(svc EventServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	eventServiceMethods := v1.File_rollkit_v1_event_rpc_proto.Services().ByName("EventService").Methods()
+	eventServiceSubscribeHandler := connect.NewServerStreamHandler(
+		EventServiceSubscribeProcedure,
+		svc.Subscribe,
+		connect.WithSchema(eventServiceMethods.ByName("Subscribe")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/rollkit.v1.EventService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case EventServiceSubscribeProcedure:
+			eventServiceSubscribeHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedEventServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedEventServiceHandler struct{}
+
+func (UnimplementedEventServiceHandler) Subscribe(context.Context, *connect.Request[v1.SubscribeRequest], *connect.ServerStream[v1.NodeEvent]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.EventService.Subscribe is not implemented"))
+}
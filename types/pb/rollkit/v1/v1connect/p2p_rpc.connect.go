@@ -38,6 +38,14 @@ const (
 	P2PServiceGetPeerInfoProcedure = "/rollkit.v1.P2PService/GetPeerInfo"
 	// P2PServiceGetNetInfoProcedure is the fully-qualified name of the P2PService's GetNetInfo RPC.
 	P2PServiceGetNetInfoProcedure = "/rollkit.v1.P2PService/GetNetInfo"
+	// P2PServiceNetPeersProcedure is the fully-qualified name of the P2PService's NetPeers RPC.
+	P2PServiceNetPeersProcedure = "/rollkit.v1.P2PService/NetPeers"
+	// P2PServiceBanPeerProcedure is the fully-qualified name of the P2PService's BanPeer RPC.
+	P2PServiceBanPeerProcedure = "/rollkit.v1.P2PService/BanPeer"
+	// P2PServiceUnbanPeerProcedure is the fully-qualified name of the P2PService's UnbanPeer RPC.
+	P2PServiceUnbanPeerProcedure = "/rollkit.v1.P2PService/UnbanPeer"
+	// P2PServiceNetStatsProcedure is the fully-qualified name of the P2PService's NetStats RPC.
+	P2PServiceNetStatsProcedure = "/rollkit.v1.P2PService/NetStats"
 )
 
 // P2PServiceClient is a client for the rollkit.v1.P2PService service.
@@ -46,6 +54,15 @@ type P2PServiceClient interface {
 	GetPeerInfo(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetPeerInfoResponse], error)
 	// GetNetInfo returns network information
 	GetNetInfo(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetNetInfoResponse], error)
+	// NetPeers returns the connected peers, and the peers recently penalized or banned, with their scores
+	NetPeers(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.NetPeersResponse], error)
+	// BanPeer disconnects a peer and bans it until it is unbanned
+	BanPeer(context.Context, *connect.Request[v1.BanPeerRequest]) (*connect.Response[emptypb.Empty], error)
+	// UnbanPeer lifts the ban of a peer and resets its score
+	UnbanPeer(context.Context, *connect.Request[v1.UnbanPeerRequest]) (*connect.Response[emptypb.Empty], error)
+	// NetStats returns the bandwidth used with every connected peer, and the gossip traffic and mesh health of
+	// every topic
+	NetStats(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.NetStatsResponse], error)
 }
 
 // NewP2PServiceClient constructs a client for the rollkit.v1.P2PService service. By default, it
@@ -71,6 +88,30 @@ func NewP2PServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...
 			connect.WithSchema(p2PServiceMethods.ByName("GetNetInfo")),
 			connect.WithClientOptions(opts...),
 		),
+		netPeers: connect.NewClient[emptypb.Empty, v1.NetPeersResponse](
+			httpClient,
+			baseURL+P2PServiceNetPeersProcedure,
+			connect.WithSchema(p2PServiceMethods.ByName("NetPeers")),
+			connect.WithClientOptions(opts...),
+		),
+		banPeer: connect.NewClient[v1.BanPeerRequest, emptypb.Empty](
+			httpClient,
+			baseURL+P2PServiceBanPeerProcedure,
+			connect.WithSchema(p2PServiceMethods.ByName("BanPeer")),
+			connect.WithClientOptions(opts...),
+		),
+		unbanPeer: connect.NewClient[v1.UnbanPeerRequest, emptypb.Empty](
+			httpClient,
+			baseURL+P2PServiceUnbanPeerProcedure,
+			connect.WithSchema(p2PServiceMethods.ByName("UnbanPeer")),
+			connect.WithClientOptions(opts...),
+		),
+		netStats: connect.NewClient[emptypb.Empty, v1.NetStatsResponse](
+			httpClient,
+			baseURL+P2PServiceNetStatsProcedure,
+			connect.WithSchema(p2PServiceMethods.ByName("NetStats")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -78,6 +119,10 @@ func NewP2PServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...
 type p2PServiceClient struct {
 	getPeerInfo *connect.Client[emptypb.Empty, v1.GetPeerInfoResponse]
 	getNetInfo  *connect.Client[emptypb.Empty, v1.GetNetInfoResponse]
+	netPeers    *connect.Client[emptypb.Empty, v1.NetPeersResponse]
+	banPeer     *connect.Client[v1.BanPeerRequest, emptypb.Empty]
+	unbanPeer   *connect.Client[v1.UnbanPeerRequest, emptypb.Empty]
+	netStats    *connect.Client[emptypb.Empty, v1.NetStatsResponse]
 }
 
 // GetPeerInfo calls rollkit.v1.P2PService.GetPeerInfo.
@@ -90,12 +135,41 @@ func (c *p2PServiceClient) GetNetInfo(ctx context.Context, req *connect.Request[
 	return c.getNetInfo.CallUnary(ctx, req)
 }
 
+// NetPeers calls rollkit.v1.P2PService.NetPeers.
+func (c *p2PServiceClient) NetPeers(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.NetPeersResponse], error) {
+	return c.netPeers.CallUnary(ctx, req)
+}
+
+// BanPeer calls rollkit.v1.P2PService.BanPeer.
+func (c *p2PServiceClient) BanPeer(ctx context.Context, req *connect.Request[v1.BanPeerRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.banPeer.CallUnary(ctx, req)
+}
+
+// UnbanPeer calls rollkit.v1.P2PService.UnbanPeer.
+func (c *p2PServiceClient) UnbanPeer(ctx context.Context, req *connect.Request[v1.UnbanPeerRequest]) (*connect.Response[emptypb.Empty], error) {
+	return c.unbanPeer.CallUnary(ctx, req)
+}
+
+// NetStats calls rollkit.v1.P2PService.NetStats.
+func (c *p2PServiceClient) NetStats(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.NetStatsResponse], error) {
+	return c.netStats.CallUnary(ctx, req)
+}
+
 // P2PServiceHandler is an implementation of the rollkit.v1.P2PService service.
 type P2PServiceHandler interface {
 	// GetPeerInfo returns information about the connected peers
 	GetPeerInfo(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetPeerInfoResponse], error)
 	// GetNetInfo returns network information
 	GetNetInfo(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetNetInfoResponse], error)
+	// NetPeers returns the connected peers, and the peers recently penalized or banned, with their scores
+	NetPeers(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.NetPeersResponse], error)
+	// BanPeer disconnects a peer and bans it until it is unbanned
+	BanPeer(context.Context, *connect.Request[v1.BanPeerRequest]) (*connect.Response[emptypb.Empty], error)
+	// UnbanPeer lifts the ban of a peer and resets its score
+	UnbanPeer(context.Context, *connect.Request[v1.UnbanPeerRequest]) (*connect.Response[emptypb.Empty], error)
+	// NetStats returns the bandwidth used with every connected peer, and the gossip traffic and mesh health of
+	// every topic
+	NetStats(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.NetStatsResponse], error)
 }
 
 // NewP2PServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -117,12 +191,44 @@ func NewP2PServiceHandler(svc P2PServiceHandler, opts ...connect.HandlerOption)
 		connect.WithSchema(p2PServiceMethods.ByName("GetNetInfo")),
 		connect.WithHandlerOptions(opts...),
 	)
+	p2PServiceNetPeersHandler := connect.NewUnaryHandler(
+		P2PServiceNetPeersProcedure,
+		svc.NetPeers,
+		connect.WithSchema(p2PServiceMethods.ByName("NetPeers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	p2PServiceBanPeerHandler := connect.NewUnaryHandler(
+		P2PServiceBanPeerProcedure,
+		svc.BanPeer,
+		connect.WithSchema(p2PServiceMethods.ByName("BanPeer")),
+		connect.WithHandlerOptions(opts...),
+	)
+	p2PServiceUnbanPeerHandler := connect.NewUnaryHandler(
+		P2PServiceUnbanPeerProcedure,
+		svc.UnbanPeer,
+		connect.WithSchema(p2PServiceMethods.ByName("UnbanPeer")),
+		connect.WithHandlerOptions(opts...),
+	)
+	p2PServiceNetStatsHandler := connect.NewUnaryHandler(
+		P2PServiceNetStatsProcedure,
+		svc.NetStats,
+		connect.WithSchema(p2PServiceMethods.ByName("NetStats")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/rollkit.v1.P2PService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case P2PServiceGetPeerInfoProcedure:
 			p2PServiceGetPeerInfoHandler.ServeHTTP(w, r)
 		case P2PServiceGetNetInfoProcedure:
 			p2PServiceGetNetInfoHandler.ServeHTTP(w, r)
+		case P2PServiceNetPeersProcedure:
+			p2PServiceNetPeersHandler.ServeHTTP(w, r)
+		case P2PServiceBanPeerProcedure:
+			p2PServiceBanPeerHandler.ServeHTTP(w, r)
+		case P2PServiceUnbanPeerProcedure:
+			p2PServiceUnbanPeerHandler.ServeHTTP(w, r)
+		case P2PServiceNetStatsProcedure:
+			p2PServiceNetStatsHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -139,3 +245,19 @@ func (UnimplementedP2PServiceHandler) GetPeerInfo(context.Context, *connect.Requ
 func (UnimplementedP2PServiceHandler) GetNetInfo(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetNetInfoResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.P2PService.GetNetInfo is not implemented"))
 }
+
+func (UnimplementedP2PServiceHandler) NetPeers(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.NetPeersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.P2PService.NetPeers is not implemented"))
+}
+
+func (UnimplementedP2PServiceHandler) BanPeer(context.Context, *connect.Request[v1.BanPeerRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.P2PService.BanPeer is not implemented"))
+}
+
+func (UnimplementedP2PServiceHandler) UnbanPeer(context.Context, *connect.Request[v1.UnbanPeerRequest]) (*connect.Response[emptypb.Empty], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.P2PService.UnbanPeer is not implemented"))
+}
+
+func (UnimplementedP2PServiceHandler) NetStats(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.NetStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.P2PService.NetStats is not implemented"))
+}
@@ -0,0 +1,173 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: rollkit/v1/sequencer.proto
+
+package v1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// SequencerServiceName is the fully-qualified name of the SequencerService service.
+	SequencerServiceName = "rollkit.v1.SequencerService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// SequencerServiceSubmitBatchTxsProcedure is the fully-qualified name of the SequencerService's
+	// SubmitBatchTxs RPC.
+	SequencerServiceSubmitBatchTxsProcedure = "/rollkit.v1.SequencerService/SubmitBatchTxs"
+	// SequencerServiceGetNextBatchProcedure is the fully-qualified name of the SequencerService's
+	// GetNextBatch RPC.
+	SequencerServiceGetNextBatchProcedure = "/rollkit.v1.SequencerService/GetNextBatch"
+	// SequencerServiceVerifyBatchProcedure is the fully-qualified name of the SequencerService's
+	// VerifyBatch RPC.
+	SequencerServiceVerifyBatchProcedure = "/rollkit.v1.SequencerService/VerifyBatch"
+)
+
+// SequencerServiceClient is a client for the rollkit.v1.SequencerService service.
+type SequencerServiceClient interface {
+	// SubmitBatchTxs submits a batch of transactions of a rollup to the sequencer
+	SubmitBatchTxs(context.Context, *connect.Request[v1.SubmitBatchTxsRequest]) (*connect.Response[v1.SubmitBatchTxsResponse], error)
+	// GetNextBatch returns the next batch of transactions ordered by the sequencer for a rollup
+	GetNextBatch(context.Context, *connect.Request[v1.GetNextBatchRequest]) (*connect.Response[v1.GetNextBatchResponse], error)
+	// VerifyBatch verifies a batch of transactions received from the sequencer
+	VerifyBatch(context.Context, *connect.Request[v1.VerifyBatchRequest]) (*connect.Response[v1.VerifyBatchResponse], error)
+}
+
+// NewSequencerServiceClient constructs a client for the rollkit.v1.SequencerService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewSequencerServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) SequencerServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	sequencerServiceMethods := v1.File_rollkit_v1_sequencer_proto.Services().ByName("SequencerService").Methods()
+	return &sequencerServiceClient{
+		submitBatchTxs: connect.NewClient[v1.SubmitBatchTxsRequest, v1.SubmitBatchTxsResponse](
+			httpClient,
+			baseURL+SequencerServiceSubmitBatchTxsProcedure,
+			connect.WithSchema(sequencerServiceMethods.ByName("SubmitBatchTxs")),
+			connect.WithClientOptions(opts...),
+		),
+		getNextBatch: connect.NewClient[v1.GetNextBatchRequest, v1.GetNextBatchResponse](
+			httpClient,
+			baseURL+SequencerServiceGetNextBatchProcedure,
+			connect.WithSchema(sequencerServiceMethods.ByName("GetNextBatch")),
+			connect.WithClientOptions(opts...),
+		),
+		verifyBatch: connect.NewClient[v1.VerifyBatchRequest, v1.VerifyBatchResponse](
+			httpClient,
+			baseURL+SequencerServiceVerifyBatchProcedure,
+			connect.WithSchema(sequencerServiceMethods.ByName("VerifyBatch")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// sequencerServiceClient implements SequencerServiceClient.
+type sequencerServiceClient struct {
+	submitBatchTxs *connect.Client[v1.SubmitBatchTxsRequest, v1.SubmitBatchTxsResponse]
+	getNextBatch   *connect.Client[v1.GetNextBatchRequest, v1.GetNextBatchResponse]
+	verifyBatch    *connect.Client[v1.VerifyBatchRequest, v1.VerifyBatchResponse]
+}
+
+// SubmitBatchTxs calls rollkit.v1.SequencerService.SubmitBatchTxs.
+func (c *sequencerServiceClient) SubmitBatchTxs(ctx context.Context, req *connect.Request[v1.SubmitBatchTxsRequest]) (*connect.Response[v1.SubmitBatchTxsResponse], error) {
+	return c.submitBatchTxs.CallUnary(ctx, req)
+}
+
+// GetNextBatch calls rollkit.v1.SequencerService.GetNextBatch.
+func (c *sequencerServiceClient) GetNextBatch(ctx context.Context, req *connect.Request[v1.GetNextBatchRequest]) (*connect.Response[v1.GetNextBatchResponse], error) {
+	return c.getNextBatch.CallUnary(ctx, req)
+}
+
+// VerifyBatch calls rollkit.v1.SequencerService.VerifyBatch.
+func (c *sequencerServiceClient) VerifyBatch(ctx context.Context, req *connect.Request[v1.VerifyBatchRequest]) (*connect.Response[v1.VerifyBatchResponse], error) {
+	return c.verifyBatch.CallUnary(ctx, req)
+}
+
+// SequencerServiceHandler is an implementation of the rollkit.v1.SequencerService service.
+type SequencerServiceHandler interface {
+	// SubmitBatchTxs submits a batch of transactions of a rollup to the sequencer
+	SubmitBatchTxs(context.Context, *connect.Request[v1.SubmitBatchTxsRequest]) (*connect.Response[v1.SubmitBatchTxsResponse], error)
+	// GetNextBatch returns the next batch of transactions ordered by the sequencer for a rollup
+	GetNextBatch(context.Context, *connect.Request[v1.GetNextBatchRequest]) (*connect.Response[v1.GetNextBatchResponse], error)
+	// VerifyBatch verifies a batch of transactions received from the sequencer
+	VerifyBatch(context.Context, *connect.Request[v1.VerifyBatchRequest]) (*connect.Response[v1.VerifyBatchResponse], error)
+}
+
+// NewSequencerServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewSequencerServiceHandler(svc SequencerServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	sequencerServiceMethods := v1.File_rollkit_v1_sequencer_proto.Services().ByName("SequencerService").Methods()
+	sequencerServiceSubmitBatchTxsHandler := connect.NewUnaryHandler(
+		SequencerServiceSubmitBatchTxsProcedure,
+		svc.SubmitBatchTxs,
+		connect.WithSchema(sequencerServiceMethods.ByName("SubmitBatchTxs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	sequencerServiceGetNextBatchHandler := connect.NewUnaryHandler(
+		SequencerServiceGetNextBatchProcedure,
+		svc.GetNextBatch,
+		connect.WithSchema(sequencerServiceMethods.ByName("GetNextBatch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	sequencerServiceVerifyBatchHandler := connect.NewUnaryHandler(
+		SequencerServiceVerifyBatchProcedure,
+		svc.VerifyBatch,
+		connect.WithSchema(sequencerServiceMethods.ByName("VerifyBatch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/rollkit.v1.SequencerService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SequencerServiceSubmitBatchTxsProcedure:
+			sequencerServiceSubmitBatchTxsHandler.ServeHTTP(w, r)
+		case SequencerServiceGetNextBatchProcedure:
+			sequencerServiceGetNextBatchHandler.ServeHTTP(w, r)
+		case SequencerServiceVerifyBatchProcedure:
+			sequencerServiceVerifyBatchHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedSequencerServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedSequencerServiceHandler struct{}
+
+func (UnimplementedSequencerServiceHandler) SubmitBatchTxs(context.Context, *connect.Request[v1.SubmitBatchTxsRequest]) (*connect.Response[v1.SubmitBatchTxsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.SequencerService.SubmitBatchTxs is not implemented"))
+}
+
+func (UnimplementedSequencerServiceHandler) GetNextBatch(context.Context, *connect.Request[v1.GetNextBatchRequest]) (*connect.Response[v1.GetNextBatchResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.SequencerService.GetNextBatch is not implemented"))
+}
+
+func (UnimplementedSequencerServiceHandler) VerifyBatch(context.Context, *connect.Request[v1.VerifyBatchRequest]) (*connect.Response[v1.VerifyBatchResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.SequencerService.VerifyBatch is not implemented"))
+}
@@ -41,6 +41,18 @@ const (
 	// StoreServiceGetMetadataProcedure is the fully-qualified name of the StoreService's GetMetadata
 	// RPC.
 	StoreServiceGetMetadataProcedure = "/rollkit.v1.StoreService/GetMetadata"
+	// StoreServiceGetDAMetadataProcedure is the fully-qualified name of the StoreService's
+	// GetDAMetadata RPC.
+	StoreServiceGetDAMetadataProcedure = "/rollkit.v1.StoreService/GetDAMetadata"
+	// StoreServiceTxByHashProcedure is the fully-qualified name of the StoreService's TxByHash RPC.
+	StoreServiceTxByHashProcedure = "/rollkit.v1.StoreService/TxByHash"
+	// StoreServiceTxSearchProcedure is the fully-qualified name of the StoreService's TxSearch RPC.
+	StoreServiceTxSearchProcedure = "/rollkit.v1.StoreService/TxSearch"
+	// StoreServiceGetTxReceiptProcedure is the fully-qualified name of the StoreService's GetTxReceipt
+	// RPC.
+	StoreServiceGetTxReceiptProcedure = "/rollkit.v1.StoreService/GetTxReceipt"
+	// StoreServiceQueryProcedure is the fully-qualified name of the StoreService's Query RPC.
+	StoreServiceQueryProcedure = "/rollkit.v1.StoreService/Query"
 )
 
 // StoreServiceClient is a client for the rollkit.v1.StoreService service.
@@ -51,6 +63,16 @@ type StoreServiceClient interface {
 	GetState(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetStateResponse], error)
 	// GetMetadata returns metadata for a specific key
 	GetMetadata(context.Context, *connect.Request[v1.GetMetadataRequest]) (*connect.Response[v1.GetMetadataResponse], error)
+	// GetDAMetadata returns the DA blobs including a DA included block
+	GetDAMetadata(context.Context, *connect.Request[v1.GetDAMetadataRequest]) (*connect.Response[v1.GetDAMetadataResponse], error)
+	// TxByHash returns an indexed transaction by hash
+	TxByHash(context.Context, *connect.Request[v1.TxByHashRequest]) (*connect.Response[v1.TxByHashResponse], error)
+	// TxSearch returns the indexed transactions matching a query
+	TxSearch(context.Context, *connect.Request[v1.TxSearchRequest]) (*connect.Response[v1.TxSearchResponse], error)
+	// GetTxReceipt returns a transaction with its execution result by hash or by height and index
+	GetTxReceipt(context.Context, *connect.Request[v1.GetTxReceiptRequest]) (*connect.Response[v1.GetTxReceiptResponse], error)
+	// Query queries the execution state at a height, passed through to the execution client
+	Query(context.Context, *connect.Request[v1.QueryRequest]) (*connect.Response[v1.QueryResponse], error)
 }
 
 // NewStoreServiceClient constructs a client for the rollkit.v1.StoreService service. By default, it
@@ -82,14 +104,49 @@ func NewStoreServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(storeServiceMethods.ByName("GetMetadata")),
 			connect.WithClientOptions(opts...),
 		),
+		getDAMetadata: connect.NewClient[v1.GetDAMetadataRequest, v1.GetDAMetadataResponse](
+			httpClient,
+			baseURL+StoreServiceGetDAMetadataProcedure,
+			connect.WithSchema(storeServiceMethods.ByName("GetDAMetadata")),
+			connect.WithClientOptions(opts...),
+		),
+		txByHash: connect.NewClient[v1.TxByHashRequest, v1.TxByHashResponse](
+			httpClient,
+			baseURL+StoreServiceTxByHashProcedure,
+			connect.WithSchema(storeServiceMethods.ByName("TxByHash")),
+			connect.WithClientOptions(opts...),
+		),
+		txSearch: connect.NewClient[v1.TxSearchRequest, v1.TxSearchResponse](
+			httpClient,
+			baseURL+StoreServiceTxSearchProcedure,
+			connect.WithSchema(storeServiceMethods.ByName("TxSearch")),
+			connect.WithClientOptions(opts...),
+		),
+		getTxReceipt: connect.NewClient[v1.GetTxReceiptRequest, v1.GetTxReceiptResponse](
+			httpClient,
+			baseURL+StoreServiceGetTxReceiptProcedure,
+			connect.WithSchema(storeServiceMethods.ByName("GetTxReceipt")),
+			connect.WithClientOptions(opts...),
+		),
+		query: connect.NewClient[v1.QueryRequest, v1.QueryResponse](
+			httpClient,
+			baseURL+StoreServiceQueryProcedure,
+			connect.WithSchema(storeServiceMethods.ByName("Query")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // storeServiceClient implements StoreServiceClient.
 type storeServiceClient struct {
-	getBlock    *connect.Client[v1.GetBlockRequest, v1.GetBlockResponse]
-	getState    *connect.Client[emptypb.Empty, v1.GetStateResponse]
-	getMetadata *connect.Client[v1.GetMetadataRequest, v1.GetMetadataResponse]
+	getBlock      *connect.Client[v1.GetBlockRequest, v1.GetBlockResponse]
+	getState      *connect.Client[emptypb.Empty, v1.GetStateResponse]
+	getMetadata   *connect.Client[v1.GetMetadataRequest, v1.GetMetadataResponse]
+	getDAMetadata *connect.Client[v1.GetDAMetadataRequest, v1.GetDAMetadataResponse]
+	txByHash      *connect.Client[v1.TxByHashRequest, v1.TxByHashResponse]
+	txSearch      *connect.Client[v1.TxSearchRequest, v1.TxSearchResponse]
+	getTxReceipt  *connect.Client[v1.GetTxReceiptRequest, v1.GetTxReceiptResponse]
+	query         *connect.Client[v1.QueryRequest, v1.QueryResponse]
 }
 
 // GetBlock calls rollkit.v1.StoreService.GetBlock.
@@ -107,6 +164,31 @@ func (c *storeServiceClient) GetMetadata(ctx context.Context, req *connect.Reque
 	return c.getMetadata.CallUnary(ctx, req)
 }
 
+// GetDAMetadata calls rollkit.v1.StoreService.GetDAMetadata.
+func (c *storeServiceClient) GetDAMetadata(ctx context.Context, req *connect.Request[v1.GetDAMetadataRequest]) (*connect.Response[v1.GetDAMetadataResponse], error) {
+	return c.getDAMetadata.CallUnary(ctx, req)
+}
+
+// TxByHash calls rollkit.v1.StoreService.TxByHash.
+func (c *storeServiceClient) TxByHash(ctx context.Context, req *connect.Request[v1.TxByHashRequest]) (*connect.Response[v1.TxByHashResponse], error) {
+	return c.txByHash.CallUnary(ctx, req)
+}
+
+// TxSearch calls rollkit.v1.StoreService.TxSearch.
+func (c *storeServiceClient) TxSearch(ctx context.Context, req *connect.Request[v1.TxSearchRequest]) (*connect.Response[v1.TxSearchResponse], error) {
+	return c.txSearch.CallUnary(ctx, req)
+}
+
+// GetTxReceipt calls rollkit.v1.StoreService.GetTxReceipt.
+func (c *storeServiceClient) GetTxReceipt(ctx context.Context, req *connect.Request[v1.GetTxReceiptRequest]) (*connect.Response[v1.GetTxReceiptResponse], error) {
+	return c.getTxReceipt.CallUnary(ctx, req)
+}
+
+// Query calls rollkit.v1.StoreService.Query.
+func (c *storeServiceClient) Query(ctx context.Context, req *connect.Request[v1.QueryRequest]) (*connect.Response[v1.QueryResponse], error) {
+	return c.query.CallUnary(ctx, req)
+}
+
 // StoreServiceHandler is an implementation of the rollkit.v1.StoreService service.
 type StoreServiceHandler interface {
 	// GetBlock returns a block by height or hash
@@ -115,6 +197,16 @@ type StoreServiceHandler interface {
 	GetState(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetStateResponse], error)
 	// GetMetadata returns metadata for a specific key
 	GetMetadata(context.Context, *connect.Request[v1.GetMetadataRequest]) (*connect.Response[v1.GetMetadataResponse], error)
+	// GetDAMetadata returns the DA blobs including a DA included block
+	GetDAMetadata(context.Context, *connect.Request[v1.GetDAMetadataRequest]) (*connect.Response[v1.GetDAMetadataResponse], error)
+	// TxByHash returns an indexed transaction by hash
+	TxByHash(context.Context, *connect.Request[v1.TxByHashRequest]) (*connect.Response[v1.TxByHashResponse], error)
+	// TxSearch returns the indexed transactions matching a query
+	TxSearch(context.Context, *connect.Request[v1.TxSearchRequest]) (*connect.Response[v1.TxSearchResponse], error)
+	// GetTxReceipt returns a transaction with its execution result by hash or by height and index
+	GetTxReceipt(context.Context, *connect.Request[v1.GetTxReceiptRequest]) (*connect.Response[v1.GetTxReceiptResponse], error)
+	// Query queries the execution state at a height, passed through to the execution client
+	Query(context.Context, *connect.Request[v1.QueryRequest]) (*connect.Response[v1.QueryResponse], error)
 }
 
 // NewStoreServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -142,6 +234,36 @@ func NewStoreServiceHandler(svc StoreServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(storeServiceMethods.ByName("GetMetadata")),
 		connect.WithHandlerOptions(opts...),
 	)
+	storeServiceGetDAMetadataHandler := connect.NewUnaryHandler(
+		StoreServiceGetDAMetadataProcedure,
+		svc.GetDAMetadata,
+		connect.WithSchema(storeServiceMethods.ByName("GetDAMetadata")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storeServiceTxByHashHandler := connect.NewUnaryHandler(
+		StoreServiceTxByHashProcedure,
+		svc.TxByHash,
+		connect.WithSchema(storeServiceMethods.ByName("TxByHash")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storeServiceTxSearchHandler := connect.NewUnaryHandler(
+		StoreServiceTxSearchProcedure,
+		svc.TxSearch,
+		connect.WithSchema(storeServiceMethods.ByName("TxSearch")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storeServiceGetTxReceiptHandler := connect.NewUnaryHandler(
+		StoreServiceGetTxReceiptProcedure,
+		svc.GetTxReceipt,
+		connect.WithSchema(storeServiceMethods.ByName("GetTxReceipt")),
+		connect.WithHandlerOptions(opts...),
+	)
+	storeServiceQueryHandler := connect.NewUnaryHandler(
+		StoreServiceQueryProcedure,
+		svc.Query,
+		connect.WithSchema(storeServiceMethods.ByName("Query")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/rollkit.v1.StoreService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case StoreServiceGetBlockProcedure:
@@ -150,6 +272,16 @@ func NewStoreServiceHandler(svc StoreServiceHandler, opts ...connect.HandlerOpti
 			storeServiceGetStateHandler.ServeHTTP(w, r)
 		case StoreServiceGetMetadataProcedure:
 			storeServiceGetMetadataHandler.ServeHTTP(w, r)
+		case StoreServiceGetDAMetadataProcedure:
+			storeServiceGetDAMetadataHandler.ServeHTTP(w, r)
+		case StoreServiceTxByHashProcedure:
+			storeServiceTxByHashHandler.ServeHTTP(w, r)
+		case StoreServiceTxSearchProcedure:
+			storeServiceTxSearchHandler.ServeHTTP(w, r)
+		case StoreServiceGetTxReceiptProcedure:
+			storeServiceGetTxReceiptHandler.ServeHTTP(w, r)
+		case StoreServiceQueryProcedure:
+			storeServiceQueryHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -170,3 +302,23 @@ func (UnimplementedStoreServiceHandler) GetState(context.Context, *connect.Reque
 func (UnimplementedStoreServiceHandler) GetMetadata(context.Context, *connect.Request[v1.GetMetadataRequest]) (*connect.Response[v1.GetMetadataResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StoreService.GetMetadata is not implemented"))
 }
+
+func (UnimplementedStoreServiceHandler) GetDAMetadata(context.Context, *connect.Request[v1.GetDAMetadataRequest]) (*connect.Response[v1.GetDAMetadataResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StoreService.GetDAMetadata is not implemented"))
+}
+
+func (UnimplementedStoreServiceHandler) TxByHash(context.Context, *connect.Request[v1.TxByHashRequest]) (*connect.Response[v1.TxByHashResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StoreService.TxByHash is not implemented"))
+}
+
+func (UnimplementedStoreServiceHandler) TxSearch(context.Context, *connect.Request[v1.TxSearchRequest]) (*connect.Response[v1.TxSearchResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StoreService.TxSearch is not implemented"))
+}
+
+func (UnimplementedStoreServiceHandler) GetTxReceipt(context.Context, *connect.Request[v1.GetTxReceiptRequest]) (*connect.Response[v1.GetTxReceiptResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StoreService.GetTxReceipt is not implemented"))
+}
+
+func (UnimplementedStoreServiceHandler) Query(context.Context, *connect.Request[v1.QueryRequest]) (*connect.Response[v1.QueryResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StoreService.Query is not implemented"))
+}
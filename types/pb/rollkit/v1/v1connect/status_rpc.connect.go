@@ -0,0 +1,419 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: rollkit/v1/status_rpc.proto
+
+package v1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// StatusServiceName is the fully-qualified name of the StatusService service.
+	StatusServiceName = "rollkit.v1.StatusService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// StatusServiceGetStatusProcedure is the fully-qualified name of the StatusService's GetStatus RPC.
+	StatusServiceGetStatusProcedure = "/rollkit.v1.StatusService/GetStatus"
+	// StatusServiceGetLeaderProcedure is the fully-qualified name of the StatusService's GetLeader RPC.
+	StatusServiceGetLeaderProcedure = "/rollkit.v1.StatusService/GetLeader"
+	// StatusServiceGetBlockConfirmationStatusProcedure is the fully-qualified name of the
+	// StatusService's GetBlockConfirmationStatus RPC.
+	StatusServiceGetBlockConfirmationStatusProcedure = "/rollkit.v1.StatusService/GetBlockConfirmationStatus"
+	// StatusServiceGetDAGasPriceProcedure is the fully-qualified name of the StatusService's
+	// GetDAGasPrice RPC.
+	StatusServiceGetDAGasPriceProcedure = "/rollkit.v1.StatusService/GetDAGasPrice"
+	// StatusServiceGetDAFeesProcedure is the fully-qualified name of the StatusService's GetDAFees RPC.
+	StatusServiceGetDAFeesProcedure = "/rollkit.v1.StatusService/GetDAFees"
+	// StatusServiceGetDAVerificationProcedure is the fully-qualified name of the StatusService's
+	// GetDAVerification RPC.
+	StatusServiceGetDAVerificationProcedure = "/rollkit.v1.StatusService/GetDAVerification"
+	// StatusServiceGetDAInclusionProofProcedure is the fully-qualified name of the StatusService's
+	// GetDAInclusionProof RPC.
+	StatusServiceGetDAInclusionProofProcedure = "/rollkit.v1.StatusService/GetDAInclusionProof"
+	// StatusServiceGetLightClientUpdateProcedure is the fully-qualified name of the StatusService's
+	// GetLightClientUpdate RPC.
+	StatusServiceGetLightClientUpdateProcedure = "/rollkit.v1.StatusService/GetLightClientUpdate"
+	// StatusServiceGetHeadersRangeProcedure is the fully-qualified name of the StatusService's
+	// GetHeadersRange RPC.
+	StatusServiceGetHeadersRangeProcedure = "/rollkit.v1.StatusService/GetHeadersRange"
+	// StatusServiceGetDAChainStatsProcedure is the fully-qualified name of the StatusService's
+	// GetDAChainStats RPC.
+	StatusServiceGetDAChainStatsProcedure = "/rollkit.v1.StatusService/GetDAChainStats"
+	// StatusServiceDryRunDASubmissionProcedure is the fully-qualified name of the StatusService's
+	// DryRunDASubmission RPC.
+	StatusServiceDryRunDASubmissionProcedure = "/rollkit.v1.StatusService/DryRunDASubmission"
+)
+
+// StatusServiceClient is a client for the rollkit.v1.StatusService service.
+type StatusServiceClient interface {
+	// GetStatus returns the sync progress of the node
+	GetStatus(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetStatusResponse], error)
+	// GetLeader returns the active aggregator elected by leader election
+	GetLeader(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetLeaderResponse], error)
+	// GetBlockConfirmationStatus returns the confirmation tier of a block
+	GetBlockConfirmationStatus(context.Context, *connect.Request[v1.GetBlockConfirmationStatusRequest]) (*connect.Response[v1.GetBlockConfirmationStatusResponse], error)
+	// GetDAGasPrice returns the effective gas price used for DA submissions
+	GetDAGasPrice(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAGasPriceResponse], error)
+	// GetDAFees returns the DA fees paid by the node and its DA fee budget
+	GetDAFees(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAFeesResponse], error)
+	// GetDAVerification returns the progress of the verification of headers against the DA layer
+	GetDAVerification(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAVerificationResponse], error)
+	// GetDAInclusionProof returns the DA blobs including a DA included block, with their inclusion proofs
+	GetDAInclusionProof(context.Context, *connect.Request[v1.GetDAInclusionProofRequest]) (*connect.Response[v1.GetDAInclusionProofResponse], error)
+	// GetLightClientUpdate returns the header update of a DA included block, for light clients of the rollup
+	GetLightClientUpdate(context.Context, *connect.Request[v1.GetLightClientUpdateRequest]) (*connect.Response[v1.GetLightClientUpdateResponse], error)
+	// GetHeadersRange returns a range of verified headers received over p2p, for downstream header verifiers
+	GetHeadersRange(context.Context, *connect.Request[v1.GetHeadersRangeRequest]) (*connect.Response[v1.GetHeadersRangeResponse], error)
+	// GetDAChainStats returns the statistics of the blobs retrieved from the DA layer per chain
+	GetDAChainStats(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAChainStatsResponse], error)
+	// DryRunDASubmission simulates the DA submission of a block without broadcasting it, estimating its DA fees
+	DryRunDASubmission(context.Context, *connect.Request[v1.DryRunDASubmissionRequest]) (*connect.Response[v1.DryRunDASubmissionResponse], error)
+}
+
+// NewStatusServiceClient constructs a client for the rollkit.v1.StatusService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewStatusServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) StatusServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	statusServiceMethods := v1.File_rollkit_v1_status_rpc_proto.Services().ByName("StatusService").Methods()
+	return &statusServiceClient{
+		getStatus: connect.NewClient[emptypb.Empty, v1.GetStatusResponse](
+			httpClient,
+			baseURL+StatusServiceGetStatusProcedure,
+			connect.WithSchema(statusServiceMethods.ByName("GetStatus")),
+			connect.WithClientOptions(opts...),
+		),
+		getLeader: connect.NewClient[emptypb.Empty, v1.GetLeaderResponse](
+			httpClient,
+			baseURL+StatusServiceGetLeaderProcedure,
+			connect.WithSchema(statusServiceMethods.ByName("GetLeader")),
+			connect.WithClientOptions(opts...),
+		),
+		getBlockConfirmationStatus: connect.NewClient[v1.GetBlockConfirmationStatusRequest, v1.GetBlockConfirmationStatusResponse](
+			httpClient,
+			baseURL+StatusServiceGetBlockConfirmationStatusProcedure,
+			connect.WithSchema(statusServiceMethods.ByName("GetBlockConfirmationStatus")),
+			connect.WithClientOptions(opts...),
+		),
+		getDAGasPrice: connect.NewClient[emptypb.Empty, v1.GetDAGasPriceResponse](
+			httpClient,
+			baseURL+StatusServiceGetDAGasPriceProcedure,
+			connect.WithSchema(statusServiceMethods.ByName("GetDAGasPrice")),
+			connect.WithClientOptions(opts...),
+		),
+		getDAFees: connect.NewClient[emptypb.Empty, v1.GetDAFeesResponse](
+			httpClient,
+			baseURL+StatusServiceGetDAFeesProcedure,
+			connect.WithSchema(statusServiceMethods.ByName("GetDAFees")),
+			connect.WithClientOptions(opts...),
+		),
+		getDAVerification: connect.NewClient[emptypb.Empty, v1.GetDAVerificationResponse](
+			httpClient,
+			baseURL+StatusServiceGetDAVerificationProcedure,
+			connect.WithSchema(statusServiceMethods.ByName("GetDAVerification")),
+			connect.WithClientOptions(opts...),
+		),
+		getDAInclusionProof: connect.NewClient[v1.GetDAInclusionProofRequest, v1.GetDAInclusionProofResponse](
+			httpClient,
+			baseURL+StatusServiceGetDAInclusionProofProcedure,
+			connect.WithSchema(statusServiceMethods.ByName("GetDAInclusionProof")),
+			connect.WithClientOptions(opts...),
+		),
+		getLightClientUpdate: connect.NewClient[v1.GetLightClientUpdateRequest, v1.GetLightClientUpdateResponse](
+			httpClient,
+			baseURL+StatusServiceGetLightClientUpdateProcedure,
+			connect.WithSchema(statusServiceMethods.ByName("GetLightClientUpdate")),
+			connect.WithClientOptions(opts...),
+		),
+		getHeadersRange: connect.NewClient[v1.GetHeadersRangeRequest, v1.GetHeadersRangeResponse](
+			httpClient,
+			baseURL+StatusServiceGetHeadersRangeProcedure,
+			connect.WithSchema(statusServiceMethods.ByName("GetHeadersRange")),
+			connect.WithClientOptions(opts...),
+		),
+		getDAChainStats: connect.NewClient[emptypb.Empty, v1.GetDAChainStatsResponse](
+			httpClient,
+			baseURL+StatusServiceGetDAChainStatsProcedure,
+			connect.WithSchema(statusServiceMethods.ByName("GetDAChainStats")),
+			connect.WithClientOptions(opts...),
+		),
+		dryRunDASubmission: connect.NewClient[v1.DryRunDASubmissionRequest, v1.DryRunDASubmissionResponse](
+			httpClient,
+			baseURL+StatusServiceDryRunDASubmissionProcedure,
+			connect.WithSchema(statusServiceMethods.ByName("DryRunDASubmission")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// statusServiceClient implements StatusServiceClient.
+type statusServiceClient struct {
+	getStatus                  *connect.Client[emptypb.Empty, v1.GetStatusResponse]
+	getLeader                  *connect.Client[emptypb.Empty, v1.GetLeaderResponse]
+	getBlockConfirmationStatus *connect.Client[v1.GetBlockConfirmationStatusRequest, v1.GetBlockConfirmationStatusResponse]
+	getDAGasPrice              *connect.Client[emptypb.Empty, v1.GetDAGasPriceResponse]
+	getDAFees                  *connect.Client[emptypb.Empty, v1.GetDAFeesResponse]
+	getDAVerification          *connect.Client[emptypb.Empty, v1.GetDAVerificationResponse]
+	getDAInclusionProof        *connect.Client[v1.GetDAInclusionProofRequest, v1.GetDAInclusionProofResponse]
+	getLightClientUpdate       *connect.Client[v1.GetLightClientUpdateRequest, v1.GetLightClientUpdateResponse]
+	getHeadersRange            *connect.Client[v1.GetHeadersRangeRequest, v1.GetHeadersRangeResponse]
+	getDAChainStats            *connect.Client[emptypb.Empty, v1.GetDAChainStatsResponse]
+	dryRunDASubmission         *connect.Client[v1.DryRunDASubmissionRequest, v1.DryRunDASubmissionResponse]
+}
+
+// GetStatus calls rollkit.v1.StatusService.GetStatus.
+func (c *statusServiceClient) GetStatus(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetStatusResponse], error) {
+	return c.getStatus.CallUnary(ctx, req)
+}
+
+// GetLeader calls rollkit.v1.StatusService.GetLeader.
+func (c *statusServiceClient) GetLeader(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetLeaderResponse], error) {
+	return c.getLeader.CallUnary(ctx, req)
+}
+
+// GetBlockConfirmationStatus calls rollkit.v1.StatusService.GetBlockConfirmationStatus.
+func (c *statusServiceClient) GetBlockConfirmationStatus(ctx context.Context, req *connect.Request[v1.GetBlockConfirmationStatusRequest]) (*connect.Response[v1.GetBlockConfirmationStatusResponse], error) {
+	return c.getBlockConfirmationStatus.CallUnary(ctx, req)
+}
+
+// GetDAGasPrice calls rollkit.v1.StatusService.GetDAGasPrice.
+func (c *statusServiceClient) GetDAGasPrice(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAGasPriceResponse], error) {
+	return c.getDAGasPrice.CallUnary(ctx, req)
+}
+
+// GetDAFees calls rollkit.v1.StatusService.GetDAFees.
+func (c *statusServiceClient) GetDAFees(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAFeesResponse], error) {
+	return c.getDAFees.CallUnary(ctx, req)
+}
+
+// GetDAVerification calls rollkit.v1.StatusService.GetDAVerification.
+func (c *statusServiceClient) GetDAVerification(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAVerificationResponse], error) {
+	return c.getDAVerification.CallUnary(ctx, req)
+}
+
+// GetDAInclusionProof calls rollkit.v1.StatusService.GetDAInclusionProof.
+func (c *statusServiceClient) GetDAInclusionProof(ctx context.Context, req *connect.Request[v1.GetDAInclusionProofRequest]) (*connect.Response[v1.GetDAInclusionProofResponse], error) {
+	return c.getDAInclusionProof.CallUnary(ctx, req)
+}
+
+// GetLightClientUpdate calls rollkit.v1.StatusService.GetLightClientUpdate.
+func (c *statusServiceClient) GetLightClientUpdate(ctx context.Context, req *connect.Request[v1.GetLightClientUpdateRequest]) (*connect.Response[v1.GetLightClientUpdateResponse], error) {
+	return c.getLightClientUpdate.CallUnary(ctx, req)
+}
+
+// GetHeadersRange calls rollkit.v1.StatusService.GetHeadersRange.
+func (c *statusServiceClient) GetHeadersRange(ctx context.Context, req *connect.Request[v1.GetHeadersRangeRequest]) (*connect.Response[v1.GetHeadersRangeResponse], error) {
+	return c.getHeadersRange.CallUnary(ctx, req)
+}
+
+// GetDAChainStats calls rollkit.v1.StatusService.GetDAChainStats.
+func (c *statusServiceClient) GetDAChainStats(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAChainStatsResponse], error) {
+	return c.getDAChainStats.CallUnary(ctx, req)
+}
+
+// DryRunDASubmission calls rollkit.v1.StatusService.DryRunDASubmission.
+func (c *statusServiceClient) DryRunDASubmission(ctx context.Context, req *connect.Request[v1.DryRunDASubmissionRequest]) (*connect.Response[v1.DryRunDASubmissionResponse], error) {
+	return c.dryRunDASubmission.CallUnary(ctx, req)
+}
+
+// StatusServiceHandler is an implementation of the rollkit.v1.StatusService service.
+type StatusServiceHandler interface {
+	// GetStatus returns the sync progress of the node
+	GetStatus(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetStatusResponse], error)
+	// GetLeader returns the active aggregator elected by leader election
+	GetLeader(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetLeaderResponse], error)
+	// GetBlockConfirmationStatus returns the confirmation tier of a block
+	GetBlockConfirmationStatus(context.Context, *connect.Request[v1.GetBlockConfirmationStatusRequest]) (*connect.Response[v1.GetBlockConfirmationStatusResponse], error)
+	// GetDAGasPrice returns the effective gas price used for DA submissions
+	GetDAGasPrice(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAGasPriceResponse], error)
+	// GetDAFees returns the DA fees paid by the node and its DA fee budget
+	GetDAFees(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAFeesResponse], error)
+	// GetDAVerification returns the progress of the verification of headers against the DA layer
+	GetDAVerification(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAVerificationResponse], error)
+	// GetDAInclusionProof returns the DA blobs including a DA included block, with their inclusion proofs
+	GetDAInclusionProof(context.Context, *connect.Request[v1.GetDAInclusionProofRequest]) (*connect.Response[v1.GetDAInclusionProofResponse], error)
+	// GetLightClientUpdate returns the header update of a DA included block, for light clients of the rollup
+	GetLightClientUpdate(context.Context, *connect.Request[v1.GetLightClientUpdateRequest]) (*connect.Response[v1.GetLightClientUpdateResponse], error)
+	// GetHeadersRange returns a range of verified headers received over p2p, for downstream header verifiers
+	GetHeadersRange(context.Context, *connect.Request[v1.GetHeadersRangeRequest]) (*connect.Response[v1.GetHeadersRangeResponse], error)
+	// GetDAChainStats returns the statistics of the blobs retrieved from the DA layer per chain
+	GetDAChainStats(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAChainStatsResponse], error)
+	// DryRunDASubmission simulates the DA submission of a block without broadcasting it, estimating its DA fees
+	DryRunDASubmission(context.Context, *connect.Request[v1.DryRunDASubmissionRequest]) (*connect.Response[v1.DryRunDASubmissionResponse], error)
+}
+
+// NewStatusServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewStatusServiceHandler(svc StatusServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	statusServiceMethods := v1.File_rollkit_v1_status_rpc_proto.Services().ByName("StatusService").Methods()
+	statusServiceGetStatusHandler := connect.NewUnaryHandler(
+		StatusServiceGetStatusProcedure,
+		svc.GetStatus,
+		connect.WithSchema(statusServiceMethods.ByName("GetStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	statusServiceGetLeaderHandler := connect.NewUnaryHandler(
+		StatusServiceGetLeaderProcedure,
+		svc.GetLeader,
+		connect.WithSchema(statusServiceMethods.ByName("GetLeader")),
+		connect.WithHandlerOptions(opts...),
+	)
+	statusServiceGetBlockConfirmationStatusHandler := connect.NewUnaryHandler(
+		StatusServiceGetBlockConfirmationStatusProcedure,
+		svc.GetBlockConfirmationStatus,
+		connect.WithSchema(statusServiceMethods.ByName("GetBlockConfirmationStatus")),
+		connect.WithHandlerOptions(opts...),
+	)
+	statusServiceGetDAGasPriceHandler := connect.NewUnaryHandler(
+		StatusServiceGetDAGasPriceProcedure,
+		svc.GetDAGasPrice,
+		connect.WithSchema(statusServiceMethods.ByName("GetDAGasPrice")),
+		connect.WithHandlerOptions(opts...),
+	)
+	statusServiceGetDAFeesHandler := connect.NewUnaryHandler(
+		StatusServiceGetDAFeesProcedure,
+		svc.GetDAFees,
+		connect.WithSchema(statusServiceMethods.ByName("GetDAFees")),
+		connect.WithHandlerOptions(opts...),
+	)
+	statusServiceGetDAVerificationHandler := connect.NewUnaryHandler(
+		StatusServiceGetDAVerificationProcedure,
+		svc.GetDAVerification,
+		connect.WithSchema(statusServiceMethods.ByName("GetDAVerification")),
+		connect.WithHandlerOptions(opts...),
+	)
+	statusServiceGetDAInclusionProofHandler := connect.NewUnaryHandler(
+		StatusServiceGetDAInclusionProofProcedure,
+		svc.GetDAInclusionProof,
+		connect.WithSchema(statusServiceMethods.ByName("GetDAInclusionProof")),
+		connect.WithHandlerOptions(opts...),
+	)
+	statusServiceGetLightClientUpdateHandler := connect.NewUnaryHandler(
+		StatusServiceGetLightClientUpdateProcedure,
+		svc.GetLightClientUpdate,
+		connect.WithSchema(statusServiceMethods.ByName("GetLightClientUpdate")),
+		connect.WithHandlerOptions(opts...),
+	)
+	statusServiceGetHeadersRangeHandler := connect.NewUnaryHandler(
+		StatusServiceGetHeadersRangeProcedure,
+		svc.GetHeadersRange,
+		connect.WithSchema(statusServiceMethods.ByName("GetHeadersRange")),
+		connect.WithHandlerOptions(opts...),
+	)
+	statusServiceGetDAChainStatsHandler := connect.NewUnaryHandler(
+		StatusServiceGetDAChainStatsProcedure,
+		svc.GetDAChainStats,
+		connect.WithSchema(statusServiceMethods.ByName("GetDAChainStats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	statusServiceDryRunDASubmissionHandler := connect.NewUnaryHandler(
+		StatusServiceDryRunDASubmissionProcedure,
+		svc.DryRunDASubmission,
+		connect.WithSchema(statusServiceMethods.ByName("DryRunDASubmission")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/rollkit.v1.StatusService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case StatusServiceGetStatusProcedure:
+			statusServiceGetStatusHandler.ServeHTTP(w, r)
+		case StatusServiceGetLeaderProcedure:
+			statusServiceGetLeaderHandler.ServeHTTP(w, r)
+		case StatusServiceGetBlockConfirmationStatusProcedure:
+			statusServiceGetBlockConfirmationStatusHandler.ServeHTTP(w, r)
+		case StatusServiceGetDAGasPriceProcedure:
+			statusServiceGetDAGasPriceHandler.ServeHTTP(w, r)
+		case StatusServiceGetDAFeesProcedure:
+			statusServiceGetDAFeesHandler.ServeHTTP(w, r)
+		case StatusServiceGetDAVerificationProcedure:
+			statusServiceGetDAVerificationHandler.ServeHTTP(w, r)
+		case StatusServiceGetDAInclusionProofProcedure:
+			statusServiceGetDAInclusionProofHandler.ServeHTTP(w, r)
+		case StatusServiceGetLightClientUpdateProcedure:
+			statusServiceGetLightClientUpdateHandler.ServeHTTP(w, r)
+		case StatusServiceGetHeadersRangeProcedure:
+			statusServiceGetHeadersRangeHandler.ServeHTTP(w, r)
+		case StatusServiceGetDAChainStatsProcedure:
+			statusServiceGetDAChainStatsHandler.ServeHTTP(w, r)
+		case StatusServiceDryRunDASubmissionProcedure:
+			statusServiceDryRunDASubmissionHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedStatusServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedStatusServiceHandler struct{}
+
+func (UnimplementedStatusServiceHandler) GetStatus(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StatusService.GetStatus is not implemented"))
+}
+
+func (UnimplementedStatusServiceHandler) GetLeader(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetLeaderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StatusService.GetLeader is not implemented"))
+}
+
+func (UnimplementedStatusServiceHandler) GetBlockConfirmationStatus(context.Context, *connect.Request[v1.GetBlockConfirmationStatusRequest]) (*connect.Response[v1.GetBlockConfirmationStatusResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StatusService.GetBlockConfirmationStatus is not implemented"))
+}
+
+func (UnimplementedStatusServiceHandler) GetDAGasPrice(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAGasPriceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StatusService.GetDAGasPrice is not implemented"))
+}
+
+func (UnimplementedStatusServiceHandler) GetDAFees(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAFeesResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StatusService.GetDAFees is not implemented"))
+}
+
+func (UnimplementedStatusServiceHandler) GetDAVerification(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAVerificationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StatusService.GetDAVerification is not implemented"))
+}
+
+func (UnimplementedStatusServiceHandler) GetDAInclusionProof(context.Context, *connect.Request[v1.GetDAInclusionProofRequest]) (*connect.Response[v1.GetDAInclusionProofResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StatusService.GetDAInclusionProof is not implemented"))
+}
+
+func (UnimplementedStatusServiceHandler) GetLightClientUpdate(context.Context, *connect.Request[v1.GetLightClientUpdateRequest]) (*connect.Response[v1.GetLightClientUpdateResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StatusService.GetLightClientUpdate is not implemented"))
+}
+
+func (UnimplementedStatusServiceHandler) GetHeadersRange(context.Context, *connect.Request[v1.GetHeadersRangeRequest]) (*connect.Response[v1.GetHeadersRangeResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StatusService.GetHeadersRange is not implemented"))
+}
+
+func (UnimplementedStatusServiceHandler) GetDAChainStats(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.GetDAChainStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StatusService.GetDAChainStats is not implemented"))
+}
+
+func (UnimplementedStatusServiceHandler) DryRunDASubmission(context.Context, *connect.Request[v1.DryRunDASubmissionRequest]) (*connect.Response[v1.DryRunDASubmissionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.StatusService.DryRunDASubmission is not implemented"))
+}
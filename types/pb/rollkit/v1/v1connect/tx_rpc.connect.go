@@ -0,0 +1,268 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: rollkit/v1/tx_rpc.proto
+
+package v1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	v1 "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// TxServiceName is the fully-qualified name of the TxService service.
+	TxServiceName = "rollkit.v1.TxService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// TxServiceSubmitTxProcedure is the fully-qualified name of the TxService's SubmitTx RPC.
+	TxServiceSubmitTxProcedure = "/rollkit.v1.TxService/SubmitTx"
+	// TxServiceBroadcastTxAsyncProcedure is the fully-qualified name of the TxService's
+	// BroadcastTxAsync RPC.
+	TxServiceBroadcastTxAsyncProcedure = "/rollkit.v1.TxService/BroadcastTxAsync"
+	// TxServiceBroadcastTxCommitProcedure is the fully-qualified name of the TxService's
+	// BroadcastTxCommit RPC.
+	TxServiceBroadcastTxCommitProcedure = "/rollkit.v1.TxService/BroadcastTxCommit"
+	// TxServiceUnconfirmedTxsProcedure is the fully-qualified name of the TxService's UnconfirmedTxs
+	// RPC.
+	TxServiceUnconfirmedTxsProcedure = "/rollkit.v1.TxService/UnconfirmedTxs"
+	// TxServiceNumUnconfirmedTxsProcedure is the fully-qualified name of the TxService's
+	// NumUnconfirmedTxs RPC.
+	TxServiceNumUnconfirmedTxsProcedure = "/rollkit.v1.TxService/NumUnconfirmedTxs"
+	// TxServiceVerifyPreconfirmationProcedure is the fully-qualified name of the TxService's
+	// VerifyPreconfirmation RPC.
+	TxServiceVerifyPreconfirmationProcedure = "/rollkit.v1.TxService/VerifyPreconfirmation"
+)
+
+// TxServiceClient is a client for the rollkit.v1.TxService service.
+type TxServiceClient interface {
+	// SubmitTx submits a transaction to the mempool for inclusion in a block, and returns once the mempool
+	// accepted it
+	SubmitTx(context.Context, *connect.Request[v1.SubmitTxRequest]) (*connect.Response[v1.SubmitTxResponse], error)
+	// BroadcastTxAsync submits a transaction to the mempool without waiting for the mempool to accept it
+	BroadcastTxAsync(context.Context, *connect.Request[v1.SubmitTxRequest]) (*connect.Response[v1.SubmitTxResponse], error)
+	// BroadcastTxCommit submits a transaction to the mempool, and waits until the block including it is confirmed
+	BroadcastTxCommit(context.Context, *connect.Request[v1.BroadcastTxCommitRequest]) (*connect.Response[v1.BroadcastTxCommitResponse], error)
+	// UnconfirmedTxs returns the transactions waiting in the mempool, ordered by priority
+	UnconfirmedTxs(context.Context, *connect.Request[v1.UnconfirmedTxsRequest]) (*connect.Response[v1.UnconfirmedTxsResponse], error)
+	// NumUnconfirmedTxs returns the number and total size of the transactions waiting in the mempool
+	NumUnconfirmedTxs(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.NumUnconfirmedTxsResponse], error)
+	// VerifyPreconfirmation verifies the signature of a preconfirmation and whether the sequencer kept it
+	VerifyPreconfirmation(context.Context, *connect.Request[v1.VerifyPreconfirmationRequest]) (*connect.Response[v1.VerifyPreconfirmationResponse], error)
+}
+
+// NewTxServiceClient constructs a client for the rollkit.v1.TxService service. By default, it uses
+// the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewTxServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) TxServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	txServiceMethods := v1.File_rollkit_v1_tx_rpc_proto.Services().ByName("TxService").Methods()
+	return &txServiceClient{
+		submitTx: connect.NewClient[v1.SubmitTxRequest, v1.SubmitTxResponse](
+			httpClient,
+			baseURL+TxServiceSubmitTxProcedure,
+			connect.WithSchema(txServiceMethods.ByName("SubmitTx")),
+			connect.WithClientOptions(opts...),
+		),
+		broadcastTxAsync: connect.NewClient[v1.SubmitTxRequest, v1.SubmitTxResponse](
+			httpClient,
+			baseURL+TxServiceBroadcastTxAsyncProcedure,
+			connect.WithSchema(txServiceMethods.ByName("BroadcastTxAsync")),
+			connect.WithClientOptions(opts...),
+		),
+		broadcastTxCommit: connect.NewClient[v1.BroadcastTxCommitRequest, v1.BroadcastTxCommitResponse](
+			httpClient,
+			baseURL+TxServiceBroadcastTxCommitProcedure,
+			connect.WithSchema(txServiceMethods.ByName("BroadcastTxCommit")),
+			connect.WithClientOptions(opts...),
+		),
+		unconfirmedTxs: connect.NewClient[v1.UnconfirmedTxsRequest, v1.UnconfirmedTxsResponse](
+			httpClient,
+			baseURL+TxServiceUnconfirmedTxsProcedure,
+			connect.WithSchema(txServiceMethods.ByName("UnconfirmedTxs")),
+			connect.WithClientOptions(opts...),
+		),
+		numUnconfirmedTxs: connect.NewClient[emptypb.Empty, v1.NumUnconfirmedTxsResponse](
+			httpClient,
+			baseURL+TxServiceNumUnconfirmedTxsProcedure,
+			connect.WithSchema(txServiceMethods.ByName("NumUnconfirmedTxs")),
+			connect.WithClientOptions(opts...),
+		),
+		verifyPreconfirmation: connect.NewClient[v1.VerifyPreconfirmationRequest, v1.VerifyPreconfirmationResponse](
+			httpClient,
+			baseURL+TxServiceVerifyPreconfirmationProcedure,
+			connect.WithSchema(txServiceMethods.ByName("VerifyPreconfirmation")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// txServiceClient implements TxServiceClient.
+type txServiceClient struct {
+	submitTx              *connect.Client[v1.SubmitTxRequest, v1.SubmitTxResponse]
+	broadcastTxAsync      *connect.Client[v1.SubmitTxRequest, v1.SubmitTxResponse]
+	broadcastTxCommit     *connect.Client[v1.BroadcastTxCommitRequest, v1.BroadcastTxCommitResponse]
+	unconfirmedTxs        *connect.Client[v1.UnconfirmedTxsRequest, v1.UnconfirmedTxsResponse]
+	numUnconfirmedTxs     *connect.Client[emptypb.Empty, v1.NumUnconfirmedTxsResponse]
+	verifyPreconfirmation *connect.Client[v1.VerifyPreconfirmationRequest, v1.VerifyPreconfirmationResponse]
+}
+
+// SubmitTx calls rollkit.v1.TxService.SubmitTx.
+func (c *txServiceClient) SubmitTx(ctx context.Context, req *connect.Request[v1.SubmitTxRequest]) (*connect.Response[v1.SubmitTxResponse], error) {
+	return c.submitTx.CallUnary(ctx, req)
+}
+
+// BroadcastTxAsync calls rollkit.v1.TxService.BroadcastTxAsync.
+func (c *txServiceClient) BroadcastTxAsync(ctx context.Context, req *connect.Request[v1.SubmitTxRequest]) (*connect.Response[v1.SubmitTxResponse], error) {
+	return c.broadcastTxAsync.CallUnary(ctx, req)
+}
+
+// BroadcastTxCommit calls rollkit.v1.TxService.BroadcastTxCommit.
+func (c *txServiceClient) BroadcastTxCommit(ctx context.Context, req *connect.Request[v1.BroadcastTxCommitRequest]) (*connect.Response[v1.BroadcastTxCommitResponse], error) {
+	return c.broadcastTxCommit.CallUnary(ctx, req)
+}
+
+// UnconfirmedTxs calls rollkit.v1.TxService.UnconfirmedTxs.
+func (c *txServiceClient) UnconfirmedTxs(ctx context.Context, req *connect.Request[v1.UnconfirmedTxsRequest]) (*connect.Response[v1.UnconfirmedTxsResponse], error) {
+	return c.unconfirmedTxs.CallUnary(ctx, req)
+}
+
+// NumUnconfirmedTxs calls rollkit.v1.TxService.NumUnconfirmedTxs.
+func (c *txServiceClient) NumUnconfirmedTxs(ctx context.Context, req *connect.Request[emptypb.Empty]) (*connect.Response[v1.NumUnconfirmedTxsResponse], error) {
+	return c.numUnconfirmedTxs.CallUnary(ctx, req)
+}
+
+// VerifyPreconfirmation calls rollkit.v1.TxService.VerifyPreconfirmation.
+func (c *txServiceClient) VerifyPreconfirmation(ctx context.Context, req *connect.Request[v1.VerifyPreconfirmationRequest]) (*connect.Response[v1.VerifyPreconfirmationResponse], error) {
+	return c.verifyPreconfirmation.CallUnary(ctx, req)
+}
+
+// TxServiceHandler is an implementation of the rollkit.v1.TxService service.
+type TxServiceHandler interface {
+	// SubmitTx submits a transaction to the mempool for inclusion in a block, and returns once the mempool
+	// accepted it
+	SubmitTx(context.Context, *connect.Request[v1.SubmitTxRequest]) (*connect.Response[v1.SubmitTxResponse], error)
+	// BroadcastTxAsync submits a transaction to the mempool without waiting for the mempool to accept it
+	BroadcastTxAsync(context.Context, *connect.Request[v1.SubmitTxRequest]) (*connect.Response[v1.SubmitTxResponse], error)
+	// BroadcastTxCommit submits a transaction to the mempool, and waits until the block including it is confirmed
+	BroadcastTxCommit(context.Context, *connect.Request[v1.BroadcastTxCommitRequest]) (*connect.Response[v1.BroadcastTxCommitResponse], error)
+	// UnconfirmedTxs returns the transactions waiting in the mempool, ordered by priority
+	UnconfirmedTxs(context.Context, *connect.Request[v1.UnconfirmedTxsRequest]) (*connect.Response[v1.UnconfirmedTxsResponse], error)
+	// NumUnconfirmedTxs returns the number and total size of the transactions waiting in the mempool
+	NumUnconfirmedTxs(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.NumUnconfirmedTxsResponse], error)
+	// VerifyPreconfirmation verifies the signature of a preconfirmation and whether the sequencer kept it
+	VerifyPreconfirmation(context.Context, *connect.Request[v1.VerifyPreconfirmationRequest]) (*connect.Response[v1.VerifyPreconfirmationResponse], error)
+}
+
+// NewTxServiceHandler builds an HTTP handler from the service implementation. It returns the path
+// on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewTxServiceHandler(svc TxServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	txServiceMethods := v1.File_rollkit_v1_tx_rpc_proto.Services().ByName("TxService").Methods()
+	txServiceSubmitTxHandler := connect.NewUnaryHandler(
+		TxServiceSubmitTxProcedure,
+		svc.SubmitTx,
+		connect.WithSchema(txServiceMethods.ByName("SubmitTx")),
+		connect.WithHandlerOptions(opts...),
+	)
+	txServiceBroadcastTxAsyncHandler := connect.NewUnaryHandler(
+		TxServiceBroadcastTxAsyncProcedure,
+		svc.BroadcastTxAsync,
+		connect.WithSchema(txServiceMethods.ByName("BroadcastTxAsync")),
+		connect.WithHandlerOptions(opts...),
+	)
+	txServiceBroadcastTxCommitHandler := connect.NewUnaryHandler(
+		TxServiceBroadcastTxCommitProcedure,
+		svc.BroadcastTxCommit,
+		connect.WithSchema(txServiceMethods.ByName("BroadcastTxCommit")),
+		connect.WithHandlerOptions(opts...),
+	)
+	txServiceUnconfirmedTxsHandler := connect.NewUnaryHandler(
+		TxServiceUnconfirmedTxsProcedure,
+		svc.UnconfirmedTxs,
+		connect.WithSchema(txServiceMethods.ByName("UnconfirmedTxs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	txServiceNumUnconfirmedTxsHandler := connect.NewUnaryHandler(
+		TxServiceNumUnconfirmedTxsProcedure,
+		svc.NumUnconfirmedTxs,
+		connect.WithSchema(txServiceMethods.ByName("NumUnconfirmedTxs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	txServiceVerifyPreconfirmationHandler := connect.NewUnaryHandler(
+		TxServiceVerifyPreconfirmationProcedure,
+		svc.VerifyPreconfirmation,
+		connect.WithSchema(txServiceMethods.ByName("VerifyPreconfirmation")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/rollkit.v1.TxService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case TxServiceSubmitTxProcedure:
+			txServiceSubmitTxHandler.ServeHTTP(w, r)
+		case TxServiceBroadcastTxAsyncProcedure:
+			txServiceBroadcastTxAsyncHandler.ServeHTTP(w, r)
+		case TxServiceBroadcastTxCommitProcedure:
+			txServiceBroadcastTxCommitHandler.ServeHTTP(w, r)
+		case TxServiceUnconfirmedTxsProcedure:
+			txServiceUnconfirmedTxsHandler.ServeHTTP(w, r)
+		case TxServiceNumUnconfirmedTxsProcedure:
+			txServiceNumUnconfirmedTxsHandler.ServeHTTP(w, r)
+		case TxServiceVerifyPreconfirmationProcedure:
+			txServiceVerifyPreconfirmationHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedTxServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedTxServiceHandler struct{}
+
+func (UnimplementedTxServiceHandler) SubmitTx(context.Context, *connect.Request[v1.SubmitTxRequest]) (*connect.Response[v1.SubmitTxResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.TxService.SubmitTx is not implemented"))
+}
+
+func (UnimplementedTxServiceHandler) BroadcastTxAsync(context.Context, *connect.Request[v1.SubmitTxRequest]) (*connect.Response[v1.SubmitTxResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.TxService.BroadcastTxAsync is not implemented"))
+}
+
+func (UnimplementedTxServiceHandler) BroadcastTxCommit(context.Context, *connect.Request[v1.BroadcastTxCommitRequest]) (*connect.Response[v1.BroadcastTxCommitResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.TxService.BroadcastTxCommit is not implemented"))
+}
+
+func (UnimplementedTxServiceHandler) UnconfirmedTxs(context.Context, *connect.Request[v1.UnconfirmedTxsRequest]) (*connect.Response[v1.UnconfirmedTxsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.TxService.UnconfirmedTxs is not implemented"))
+}
+
+func (UnimplementedTxServiceHandler) NumUnconfirmedTxs(context.Context, *connect.Request[emptypb.Empty]) (*connect.Response[v1.NumUnconfirmedTxsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.TxService.NumUnconfirmedTxs is not implemented"))
+}
+
+func (UnimplementedTxServiceHandler) VerifyPreconfirmation(context.Context, *connect.Request[v1.VerifyPreconfirmationRequest]) (*connect.Response[v1.VerifyPreconfirmationResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("rollkit.v1.TxService.VerifyPreconfirmation is not implemented"))
+}
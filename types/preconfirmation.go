@@ -0,0 +1,128 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rollkit/rollkit/pkg/signer"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// ErrInvalidPreconfirmation is returned for preconfirmations which are malformed or not signed by the sequencer.
+var ErrInvalidPreconfirmation = errors.New("invalid preconfirmation")
+
+// Preconfirmation is a commitment signed by the sequencer when it accepts a transaction, promising to include the
+// transaction in a block at or below MaxHeight. Users and bridges hold the sequencer accountable to it: a
+// preconfirmation whose transaction is not included by MaxHeight proves that the sequencer broke its promise.
+type Preconfirmation struct {
+	ChainID string
+	TxHash  []byte
+	// Height is the height of the last block produced when the transaction was accepted
+	Height    uint64
+	MaxHeight uint64
+	Timestamp time.Time
+	Signer    Signer
+	Signature Signature
+}
+
+// SignBytes returns the bytes of the preconfirmation covered by its signature.
+func (p *Preconfirmation) SignBytes() ([]byte, error) {
+	pp, err := p.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	pp.Signature = nil
+	return proto.MarshalOptions{Deterministic: true}.Marshal(pp)
+}
+
+// ValidateBasic checks that the preconfirmation is well formed and signed. It does not check that the signer is
+// the proposer, which depends on the key rotations.
+func (p *Preconfirmation) ValidateBasic() error {
+	if p.ChainID == "" {
+		return fmt.Errorf("%w: chain ID is empty", ErrInvalidPreconfirmation)
+	}
+	if len(p.TxHash) != 32 {
+		return fmt.Errorf("%w: invalid transaction hash length %d", ErrInvalidPreconfirmation, len(p.TxHash))
+	}
+	if p.MaxHeight <= p.Height {
+		return fmt.Errorf("%w: max height %d is not above height %d", ErrInvalidPreconfirmation, p.MaxHeight, p.Height)
+	}
+	if p.Signer.PubKey == nil {
+		return fmt.Errorf("%w: missing public key", ErrInvalidPreconfirmation)
+	}
+	if len(p.Signature) == 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidPreconfirmation, ErrSignatureEmpty)
+	}
+	bz, err := p.SignBytes()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPreconfirmation, err)
+	}
+	verified, err := p.Signer.PubKey.Verify(bz, p.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidPreconfirmation, err)
+	}
+	if !verified {
+		return fmt.Errorf("%w: %w", ErrInvalidPreconfirmation, ErrSignatureVerificationFailed)
+	}
+	return nil
+}
+
+// ToProto converts Preconfirmation into protobuf representation and returns it.
+func (p *Preconfirmation) ToProto() (*pb.Preconfirmation, error) {
+	pp := &pb.Preconfirmation{
+		ChainId:   p.ChainID,
+		TxHash:    p.TxHash,
+		Height:    p.Height,
+		MaxHeight: p.MaxHeight,
+		Timestamp: timestamppb.New(p.Timestamp),
+		Signature: p.Signature[:],
+	}
+	if p.Signer.PubKey != nil {
+		var err error
+		if pp.PubKey, err = signer.MarshalPublicKey(p.Signer.PubKey); err != nil {
+			return nil, err
+		}
+	}
+	return pp, nil
+}
+
+// FromProto fills Preconfirmation with data from its protobuf representation.
+func (p *Preconfirmation) FromProto(other *pb.Preconfirmation) error {
+	if other == nil {
+		return errors.New("preconfirmation is nil")
+	}
+	pubKey, err := signer.UnmarshalPublicKey(other.PubKey)
+	if err != nil {
+		return err
+	}
+	p.ChainID = other.ChainId
+	p.TxHash = other.TxHash
+	p.Height = other.Height
+	p.MaxHeight = other.MaxHeight
+	p.Timestamp = other.Timestamp.AsTime()
+	p.Signer = Signer{PubKey: pubKey, Address: KeyAddress(pubKey)}
+	p.Signature = other.Signature
+	return nil
+}
+
+// MarshalBinary encodes Preconfirmation into binary form and returns it.
+func (p *Preconfirmation) MarshalBinary() ([]byte, error) {
+	pp, err := p.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(pp)
+}
+
+// UnmarshalBinary decodes binary form of Preconfirmation into object.
+func (p *Preconfirmation) UnmarshalBinary(data []byte) error {
+	var pp pb.Preconfirmation
+	if err := proto.Unmarshal(data, &pp); err != nil {
+		return err
+	}
+	return p.FromProto(&pp)
+}
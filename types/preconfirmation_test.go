@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreconfirmation(t *testing.T) {
+	s := newTestSigner(t)
+	txHash := GetRandomBytes(32)
+	preconf, err := GetPreconfirmation(s, Preconfirmation{
+		ChainID:   "test",
+		TxHash:    txHash,
+		Height:    10,
+		MaxHeight: 15,
+		Timestamp: time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC),
+	})
+	require.NoError(t, err)
+	require.NoError(t, preconf.ValidateBasic())
+
+	bz, err := preconf.MarshalBinary()
+	require.NoError(t, err)
+	var decoded Preconfirmation
+	require.NoError(t, decoded.UnmarshalBinary(bz))
+	require.NoError(t, decoded.ValidateBasic())
+	assert.Equal(t, *preconf, decoded)
+
+	tampered := decoded
+	tampered.MaxHeight = 20
+	unsigned := decoded
+	unsigned.Signature = nil
+	for name, invalid := range map[string]Preconfirmation{
+		"tampered":         tampered,
+		"unsigned":         unsigned,
+		"no chain ID":      {TxHash: txHash, Height: 10, MaxHeight: 15},
+		"invalid tx hash":  {ChainID: "test", TxHash: txHash[:8], Height: 10, MaxHeight: 15},
+		"no height margin": {ChainID: "test", TxHash: txHash, Height: 10, MaxHeight: 10},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if len(invalid.Signature) == 0 && name != "unsigned" {
+				signed, err := GetPreconfirmation(s, invalid)
+				require.NoError(t, err)
+				invalid = *signed
+			}
+			assert.ErrorIs(t, invalid.ValidateBasic(), ErrInvalidPreconfirmation)
+		})
+	}
+}
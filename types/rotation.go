@@ -0,0 +1,138 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rollkit/rollkit/pkg/signer"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// ErrInvalidKeyRotation is returned for key rotations which are malformed or not signed by the key they replace.
+var ErrInvalidKeyRotation = errors.New("invalid key rotation")
+
+// KeyRotation rotates the signing key of the sequencer: headers from Height on must be signed by NewKey.
+//
+// The rotation is signed by the key it replaces. It is posted to the DA layer, and carried by the headers
+// signed by the new key so that nodes following the header chain alone can verify the change of proposer.
+type KeyRotation struct {
+	ChainID   string
+	Height    uint64
+	NewKey    crypto.PubKey
+	Signer    Signer
+	Signature Signature
+}
+
+// NewProposer returns the proposer address of the new key.
+func (r *KeyRotation) NewProposer() []byte {
+	return KeyAddress(r.NewKey)
+}
+
+// SignBytes returns the bytes of the rotation covered by its signature.
+func (r *KeyRotation) SignBytes() ([]byte, error) {
+	rp, err := r.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	rp.Signature = nil
+	return proto.MarshalOptions{Deterministic: true}.Marshal(rp)
+}
+
+// ValidateBasic checks that the rotation is well formed and signed by the key it replaces. It does not check that
+// the replaced key is the proposer at the rotation height, which depends on the previous rotations.
+func (r *KeyRotation) ValidateBasic() error {
+	if r.ChainID == "" {
+		return fmt.Errorf("%w: chain ID is empty", ErrInvalidKeyRotation)
+	}
+	if r.Height == 0 {
+		return fmt.Errorf("%w: height is zero", ErrInvalidKeyRotation)
+	}
+	if r.NewKey == nil || r.Signer.PubKey == nil {
+		return fmt.Errorf("%w: missing public key", ErrInvalidKeyRotation)
+	}
+	if !bytes.Equal(r.Signer.Address, KeyAddress(r.Signer.PubKey)) {
+		return fmt.Errorf("%w: signer address does not match its public key", ErrInvalidKeyRotation)
+	}
+	if r.NewKey.Equals(r.Signer.PubKey) {
+		return fmt.Errorf("%w: new key is the signing key", ErrInvalidKeyRotation)
+	}
+	if len(r.Signature) == 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidKeyRotation, ErrSignatureEmpty)
+	}
+	bz, err := r.SignBytes()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidKeyRotation, err)
+	}
+	verified, err := r.Signer.PubKey.Verify(bz, r.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidKeyRotation, err)
+	}
+	if !verified {
+		return fmt.Errorf("%w: %w", ErrInvalidKeyRotation, ErrSignatureVerificationFailed)
+	}
+	return nil
+}
+
+// ToProto converts KeyRotation into protobuf representation and returns it.
+func (r *KeyRotation) ToProto() (*pb.KeyRotation, error) {
+	rp := &pb.KeyRotation{
+		ChainId:   r.ChainID,
+		Height:    r.Height,
+		Signature: r.Signature[:],
+	}
+	var err error
+	if r.NewKey != nil {
+		if rp.NewPubKey, err = signer.MarshalPublicKey(r.NewKey); err != nil {
+			return nil, err
+		}
+	}
+	if r.Signer.PubKey != nil {
+		if rp.PubKey, err = signer.MarshalPublicKey(r.Signer.PubKey); err != nil {
+			return nil, err
+		}
+	}
+	return rp, nil
+}
+
+// FromProto fills KeyRotation with data from its protobuf representation.
+func (r *KeyRotation) FromProto(other *pb.KeyRotation) error {
+	if other == nil {
+		return errors.New("key rotation is nil")
+	}
+	newKey, err := signer.UnmarshalPublicKey(other.NewPubKey)
+	if err != nil {
+		return err
+	}
+	pubKey, err := signer.UnmarshalPublicKey(other.PubKey)
+	if err != nil {
+		return err
+	}
+	r.ChainID = other.ChainId
+	r.Height = other.Height
+	r.NewKey = newKey
+	r.Signer = Signer{PubKey: pubKey, Address: KeyAddress(pubKey)}
+	r.Signature = other.Signature
+	return nil
+}
+
+// MarshalBinary encodes KeyRotation into binary form and returns it.
+func (r *KeyRotation) MarshalBinary() ([]byte, error) {
+	rp, err := r.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(rp)
+}
+
+// UnmarshalBinary decodes binary form of KeyRotation into object.
+func (r *KeyRotation) UnmarshalBinary(data []byte) error {
+	var rp pb.KeyRotation
+	if err := proto.Unmarshal(data, &rp); err != nil {
+		return err
+	}
+	return r.FromProto(&rp)
+}
@@ -0,0 +1,121 @@
+package types
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+)
+
+func newTestSigner(t *testing.T) signer.Signer {
+	t.Helper()
+	pk, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	s, err := noop.NewNoopSigner(pk)
+	require.NoError(t, err)
+	return s
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldSigner, newSigner := newTestSigner(t), newTestSigner(t)
+	newKey, err := newSigner.GetPublic()
+	require.NoError(t, err)
+
+	rotation, err := GetKeyRotation(oldSigner, newKey, "test", 10)
+	require.NoError(t, err)
+	require.NoError(t, rotation.ValidateBasic())
+	newAddress, err := newSigner.GetAddress()
+	require.NoError(t, err)
+	assert.Equal(t, newAddress, rotation.NewProposer())
+
+	bz, err := rotation.MarshalBinary()
+	require.NoError(t, err)
+	var decoded KeyRotation
+	require.NoError(t, decoded.UnmarshalBinary(bz))
+	require.NoError(t, decoded.ValidateBasic())
+	assert.Equal(t, rotation.Signer.Address, decoded.Signer.Address)
+	assert.Equal(t, rotation.Height, decoded.Height)
+
+	oldKey, err := oldSigner.GetPublic()
+	require.NoError(t, err)
+	sameKey, err := GetKeyRotation(oldSigner, oldKey, "test", 10)
+	require.NoError(t, err)
+	tampered := decoded
+	tampered.Height = 11
+	unsigned := decoded
+	unsigned.Signature = nil
+	noChainID, err := GetKeyRotation(oldSigner, newKey, "", 10)
+	require.NoError(t, err)
+
+	for name, invalid := range map[string]*KeyRotation{
+		"same key":      sameKey,
+		"tampered":      &tampered,
+		"unsigned":      &unsigned,
+		"no chain ID":   noChainID,
+		"no height":     {ChainID: "test", NewKey: newKey, Signer: rotation.Signer, Signature: rotation.Signature},
+		"no public key": {ChainID: "test", Height: 10, Signer: rotation.Signer, Signature: rotation.Signature},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, invalid.ValidateBasic(), ErrInvalidKeyRotation)
+		})
+	}
+}
+
+func TestSignedHeaderVerifyKeyRotation(t *testing.T) {
+	oldSigner, newSigner := newTestSigner(t), newTestSigner(t)
+	newKey, err := newSigner.GetPublic()
+	require.NoError(t, err)
+	trusted, err := GetFirstSignedHeader(oldSigner, "test")
+	require.NoError(t, err)
+
+	// next header signed by the new key, carrying the given rotation
+	nextHeader := func(rotation *KeyRotation) *SignedHeader {
+		sig, err := NewSigner(newKey)
+		require.NoError(t, err)
+		untrusted := &SignedHeader{Header: GetRandomNextHeader(trusted.Header, "test"), Signer: sig, Rotation: rotation}
+		untrusted.ProposerAddress = sig.Address
+		untrusted.Signature, err = GetSignature(untrusted.Header, newSigner)
+		require.NoError(t, err)
+		return untrusted
+	}
+
+	rotation, err := GetKeyRotation(oldSigner, newKey, "test", 2)
+	require.NoError(t, err)
+	untrusted := nextHeader(rotation)
+	require.NoError(t, untrusted.ValidateBasic())
+	require.NoError(t, trusted.Verify(untrusted))
+
+	// the rotation survives serialization
+	bz, err := untrusted.MarshalBinary()
+	require.NoError(t, err)
+	var decoded SignedHeader
+	require.NoError(t, decoded.UnmarshalBinary(bz))
+	require.NoError(t, decoded.ValidateBasic())
+	require.NoError(t, trusted.Verify(&decoded))
+
+	// the proposer cannot change without a rotation signed by the trusted proposer
+	assert.Error(t, trusted.Verify(nextHeader(nil)))
+	otherRotation, err := GetKeyRotation(newTestSigner(t), newKey, "test", 2)
+	require.NoError(t, err)
+	assert.Error(t, trusted.Verify(nextHeader(otherRotation)))
+
+	// the rotation must take effect after the trusted header and at or before the untrusted header
+	early, err := GetKeyRotation(oldSigner, newKey, "test", 1)
+	require.NoError(t, err)
+	assert.Error(t, trusted.Verify(nextHeader(early)))
+	late, err := GetKeyRotation(oldSigner, newKey, "test", 3)
+	require.NoError(t, err)
+	assert.ErrorIs(t, nextHeader(late).ValidateBasic(), ErrRotationMismatch)
+
+	// the rotation must authorize the signer of the header
+	otherKey, err := newTestSigner(t).GetPublic()
+	require.NoError(t, err)
+	mismatch, err := GetKeyRotation(oldSigner, otherKey, "test", 2)
+	require.NoError(t, err)
+	assert.ErrorIs(t, nextHeader(mismatch).ValidateBasic(), ErrRotationMismatch)
+}
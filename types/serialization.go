@@ -3,10 +3,10 @@ package types
 import (
 	"errors"
 
-	"github.com/libp2p/go-libp2p/core/crypto"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/rollkit/rollkit/pkg/signer"
 	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
 )
 
@@ -59,15 +59,34 @@ func (d *Data) UnmarshalBinary(data []byte) error {
 
 // ToProto converts SignedHeader into protobuf representation and returns it.
 func (sh *SignedHeader) ToProto() (*pb.SignedHeader, error) {
+	var rotation *pb.KeyRotation
+	if sh.Rotation != nil {
+		var err error
+		if rotation, err = sh.Rotation.ToProto(); err != nil {
+			return nil, err
+		}
+	}
+	var certificate []*pb.DACAttestation
+	for i := range sh.DACertificate {
+		attestation, err := sh.DACertificate[i].ToProto()
+		if err != nil {
+			return nil, err
+		}
+		certificate = append(certificate, attestation)
+	}
+
 	if sh.Signer.PubKey == nil {
 		return &pb.SignedHeader{
-			Header:    sh.Header.ToProto(),
-			Signature: sh.Signature[:],
-			Signer:    &pb.Signer{},
+			Header:          sh.Header.ToProto(),
+			Signature:       sh.Signature[:],
+			Signer:          &pb.Signer{},
+			ProofCommitment: sh.ProofCommitment,
+			Rotation:        rotation,
+			DacCertificate:  certificate,
 		}, nil
 	}
 
-	pubKey, err := crypto.MarshalPublicKey(sh.Signer.PubKey)
+	pubKey, err := signer.MarshalPublicKey(sh.Signer.PubKey)
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +97,9 @@ func (sh *SignedHeader) ToProto() (*pb.SignedHeader, error) {
 			Address: sh.Signer.Address,
 			PubKey:  pubKey,
 		},
+		ProofCommitment: sh.ProofCommitment,
+		Rotation:        rotation,
+		DacCertificate:  certificate,
 	}, nil
 }
 
@@ -92,9 +114,25 @@ func (sh *SignedHeader) FromProto(other *pb.SignedHeader) error {
 		return err
 	}
 	sh.Signature = other.Signature
+	sh.ProofCommitment = other.ProofCommitment
+	sh.Rotation = nil
+	if other.Rotation != nil {
+		sh.Rotation = new(KeyRotation)
+		if err := sh.Rotation.FromProto(other.Rotation); err != nil {
+			return err
+		}
+	}
+	sh.DACertificate = nil
+	for _, attestation := range other.DacCertificate {
+		var a DACAttestation
+		if err := a.FromProto(attestation); err != nil {
+			return err
+		}
+		sh.DACertificate = append(sh.DACertificate, a)
+	}
 
 	if len(other.Signer.PubKey) > 0 {
-		pubKey, err := crypto.UnmarshalPublicKey(other.Signer.PubKey)
+		pubKey, err := signer.UnmarshalPublicKey(other.Signer.PubKey)
 		if err != nil {
 			return err
 		}
@@ -147,6 +185,10 @@ func (h *Header) ToProto() *pb.Header {
 		ProposerAddress: h.ProposerAddress[:],
 		ChainId:         h.BaseHeader.ChainID,
 		ValidatorHash:   h.ValidatorHash,
+		ExecutionLag:    h.ExecutionLag,
+		LanesHash:       h.LanesHash,
+		DaBlockHeight:   h.DABlockHeight,
+		DaBlockHash:     h.DABlockHash,
 	}
 }
 
@@ -167,6 +209,10 @@ func (h *Header) FromProto(other *pb.Header) error {
 	h.AppHash = other.AppHash
 	h.LastResultsHash = other.LastResultsHash
 	h.ValidatorHash = other.ValidatorHash
+	h.ExecutionLag = other.ExecutionLag
+	h.LanesHash = other.LanesHash
+	h.DABlockHeight = other.DaBlockHeight
+	h.DABlockHash = other.DaBlockHash
 	if len(other.ProposerAddress) > 0 {
 		h.ProposerAddress = make([]byte, len(other.ProposerAddress))
 		copy(h.ProposerAddress, other.ProposerAddress)
@@ -168,3 +168,34 @@ func TestTxsRoundtrip(t *testing.T) {
 		assert.Equal(t, txs[i], newTxs[i])
 	}
 }
+
+func TestHeaderDABlock(t *testing.T) {
+	header, _, _ := GenerateRandomBlockCustom(&BlockConfig{Height: 5, NTxs: 1}, "test")
+	header.DABlockHeight = 42
+	header.DABlockHash = GetRandomBytes(32)
+	require.NoError(t, header.Header.ValidateBasic())
+
+	// the DA block is part of the signed header
+	bz, err := header.MarshalBinary()
+	require.NoError(t, err)
+	var decoded SignedHeader
+	require.NoError(t, decoded.UnmarshalBinary(bz))
+	assert.Equal(t, header.DABlockHeight, decoded.DABlockHeight)
+	assert.Equal(t, header.DABlockHash, decoded.DABlockHash)
+	assert.Equal(t, header.Hash(), decoded.Hash())
+
+	for name, tc := range map[string]struct {
+		height uint64
+		hash   Hash
+	}{
+		"hash without height": {0, GetRandomBytes(32)},
+		"height without hash": {42, nil},
+		"hash too long":       {42, GetRandomBytes(MaxDABlockHashSize + 1)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			invalid := header.Header
+			invalid.DABlockHeight, invalid.DABlockHash = tc.height, tc.hash
+			assert.ErrorIs(t, invalid.ValidateBasic(), ErrInvalidDABlock)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"runtime"
+	"sync"
+)
+
+// verifiedSignaturesCacheSize bounds the number of verified header signatures cached.
+const verifiedSignaturesCacheSize = 10_000
+
+// verifiedSignatures caches the header signatures verified, so that the signature of a header verified in a batch
+// by VerifySignatures, or validated before, is not verified again when the header is validated by the sync loop.
+var verifiedSignatures = newSignatureCache(verifiedSignaturesCacheSize)
+
+// VerifySignatures verifies the signatures of the headers, split in batches verified concurrently on all cores,
+// and returns the error of the verification of every header, nil if its signature is valid. The signatures
+// verified are cached, so that validating the headers afterwards does not verify them again: a node catching
+// up verifies the signatures of the headers it received in a batch before validating them one at a time.
+func VerifySignatures(ctx context.Context, headers []*SignedHeader) []error {
+	errs := make([]error, len(headers))
+	if len(headers) == 0 {
+		return errs
+	}
+	workers := min(runtime.GOMAXPROCS(0), len(headers))
+	batchSize := (len(headers) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for offset := 0; offset < len(headers); offset += batchSize {
+		batch := headers[offset:min(offset+batchSize, len(headers))]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j, header := range batch {
+				if err := ctx.Err(); err != nil {
+					errs[offset+j] = err
+					continue
+				}
+				errs[offset+j] = header.verifySignature()
+			}
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// verifySignature verifies the signature of the header by its signer, unless it was verified already.
+func (sh *SignedHeader) verifySignature() error {
+	if sh.Signer.PubKey == nil {
+		return ErrSignatureVerificationFailed
+	}
+	bz, err := sh.Header.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	key, err := signatureKey(sh, bz)
+	if err != nil {
+		return err
+	}
+	if verifiedSignatures.contains(key) {
+		return nil
+	}
+	verified, err := sh.Signer.PubKey.Verify(bz, sh.Signature)
+	if err != nil {
+		return err
+	}
+	if !verified {
+		return ErrSignatureVerificationFailed
+	}
+	verifiedSignatures.add(key)
+	return nil
+}
+
+// signatureKey returns the key of the signature of the header in the cache of verified signatures, the digest of
+// the public key of the signer, the signed header and the signature.
+func signatureKey(sh *SignedHeader, bz []byte) ([sha256.Size]byte, error) {
+	pubKey, err := sh.Signer.PubKey.Raw()
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	h := sha256.New()
+	for _, field := range [][]byte{pubKey, bz, sh.Signature} {
+		h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(field)))) //nolint:gosec // fields are far below 4 GiB
+		h.Write(field)
+	}
+	var key [sha256.Size]byte
+	h.Sum(key[:0])
+	return key, nil
+}
+
+// signatureCache is a bounded set of verified signatures, evicting the oldest first.
+type signatureCache struct {
+	mu    sync.Mutex
+	size  int
+	keys  map[[sha256.Size]byte]struct{}
+	order [][sha256.Size]byte
+}
+
+func newSignatureCache(size int) *signatureCache {
+	return &signatureCache{
+		size: size,
+		keys: make(map[[sha256.Size]byte]struct{}, size),
+	}
+}
+
+func (c *signatureCache) contains(key [sha256.Size]byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.keys[key]
+	return ok
+}
+
+func (c *signatureCache) add(key [sha256.Size]byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.keys[key]; ok {
+		return
+	}
+	c.keys[key] = struct{}{}
+	c.order = append(c.order, key)
+	for len(c.order) > c.size {
+		delete(c.keys, c.order[0])
+		c.order = c.order[1:]
+	}
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+)
+
+func TestVerifySignatures(t *testing.T) {
+	chainID := "TestVerifySignatures"
+	first, privKey, err := GetRandomSignedHeader(chainID)
+	require.NoError(t, err)
+	signer, err := noop.NewNoopSigner(privKey)
+	require.NoError(t, err)
+	headers := []*SignedHeader{first}
+	for range 9 {
+		next, err := GetRandomNextSignedHeader(headers[len(headers)-1], signer, chainID)
+		require.NoError(t, err)
+		next.Signature, err = GetSignature(next.Header, signer)
+		require.NoError(t, err)
+		headers = append(headers, next)
+	}
+
+	// a header whose content does not match its signature fails verification
+	forged := *headers[4]
+	forged.AppHash = GetRandomBytes(32)
+	headers[4] = &forged
+
+	errs := VerifySignatures(t.Context(), headers)
+	require.Len(t, errs, len(headers))
+	for i, err := range errs {
+		if i == 4 {
+			assert.ErrorIs(t, err, ErrSignatureVerificationFailed)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+
+	// verified signatures are cached, forged ones are not
+	for i, header := range headers {
+		bz, err := header.Header.MarshalBinary()
+		require.NoError(t, err)
+		key, err := signatureKey(header, bz)
+		require.NoError(t, err)
+		assert.Equal(t, i != 4, verifiedSignatures.contains(key))
+	}
+	assert.ErrorIs(t, headers[4].ValidateBasic(), ErrSignatureVerificationFailed)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	for _, err := range VerifySignatures(ctx, headers) {
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+	assert.Empty(t, VerifySignatures(t.Context(), nil))
+}
+
+func TestSignatureCache(t *testing.T) {
+	c := newSignatureCache(2)
+	keys := [][32]byte{{1}, {2}, {3}}
+	for _, key := range keys {
+		c.add(key)
+	}
+	assert.False(t, c.contains(keys[0]), "the oldest signature is evicted")
+	assert.True(t, c.contains(keys[1]))
+	assert.True(t, c.contains(keys[2]))
+}
@@ -24,6 +24,15 @@ type SignedHeader struct {
 	// Note: This is backwards compatible as ABCI exported types are not affected.
 	Signature Signature
 	Signer    Signer
+	// ProofCommitment is the commitment to the validity proof of the block, attached by the proposer before
+	// submitting the header to the DA layer. It is not signed, as the proof is generated after the block.
+	ProofCommitment []byte
+	// Rotation is the rotation of the sequencer key authorizing the signer, set on headers signed by a rotated
+	// key. It is signed by the replaced key, so it is not covered by the header signature.
+	Rotation *KeyRotation
+	// DACertificate holds the attestations of the data availability committee members storing the data of the
+	// block, set in commitments-only mode. The members sign the header, so it is not covered by the header signature.
+	DACertificate []DACAttestation
 }
 
 // New creates a new SignedHeader.
@@ -39,9 +48,17 @@ func (sh *SignedHeader) IsZero() bool {
 // Verify verifies the signed header.
 func (sh *SignedHeader) Verify(untrstH *SignedHeader) error {
 	// go-header ensures untrustH already passed ValidateBasic.
-	if err := sh.Header.Verify(&untrstH.Header); err != nil {
+	// the signature scheme is set in genesis, and can only be changed by a key rotation scheduled by an upgrade
+	if sh.Signer.PubKey != nil && sh.Signer.PubKey.Type() != untrstH.Signer.PubKey.Type() && !sh.isRotatedBy(untrstH) {
 		return &header.VerifyError{
-			Reason: err,
+			Reason: ErrSignatureSchemeMismatch,
+		}
+	}
+	if !sh.isRotatedBy(untrstH) {
+		if err := sh.Header.Verify(&untrstH.Header); err != nil {
+			return &header.VerifyError{
+				Reason: err,
+			}
 		}
 	}
 
@@ -54,6 +71,15 @@ func (sh *SignedHeader) Verify(untrstH *SignedHeader) error {
 	return nil
 }
 
+// isRotatedBy reports whether the untrusted header is signed by a key the proposer of this header rotated to
+// after this header. Only a single rotation between both headers can be verified.
+func (sh *SignedHeader) isRotatedBy(untrstH *SignedHeader) bool {
+	rotation := untrstH.Rotation
+	return rotation != nil &&
+		rotation.Height > sh.Height() &&
+		bytes.Equal(rotation.Signer.Address, sh.ProposerAddress)
+}
+
 // verifyHeaderHash verifies the header hash.
 func (sh *SignedHeader) verifyHeaderHash(untrstH *SignedHeader) error {
 	hash := sh.Hash()
@@ -89,6 +115,13 @@ var (
 
 	// ErrSignatureEmpty is returned when signature is empty
 	ErrSignatureEmpty = errors.New("signature is empty")
+
+	// ErrRotationMismatch is returned when the key rotation carried by a signed header does not authorize its signer
+	ErrRotationMismatch = errors.New("key rotation in SignedHeader does not authorize its signer")
+
+	// ErrSignatureSchemeMismatch is returned when a signed header is signed with a key of another signature scheme
+	// than the trusted header
+	ErrSignatureSchemeMismatch = errors.New("signature scheme of SignedHeader does not match the trusted header")
 )
 
 // ValidateBasic performs basic validation of a signed header.
@@ -106,24 +139,29 @@ func (sh *SignedHeader) ValidateBasic() error {
 		return ErrProposerAddressMismatch
 	}
 
-	bz, err := sh.Header.MarshalBinary()
-	if err != nil {
+	if err := sh.verifySignature(); err != nil {
 		return err
 	}
 
-	verified, err := sh.Signer.PubKey.Verify(bz, sh.Signature)
-	if err != nil {
-		return err
-	}
-	if !verified {
-		return ErrSignatureVerificationFailed
+	if sh.Rotation != nil {
+		if err := sh.Rotation.ValidateBasic(); err != nil {
+			return err
+		}
+		if sh.Rotation.ChainID != sh.ChainID() || sh.Rotation.Height > sh.Height() ||
+			!bytes.Equal(sh.Rotation.NewProposer(), sh.ProposerAddress) {
+			return ErrRotationMismatch
+		}
 	}
 	return nil
 }
 
-// Validate performs basic validation of a signed header.
+// Validate performs basic validation of a signed header, and runs the custom validators registered in
+// HeaderValidators.
 func (sh *SignedHeader) Validate() error {
-	return sh.ValidateBasic()
+	if err := sh.ValidateBasic(); err != nil {
+		return err
+	}
+	return HeaderValidators.Validate(sh)
 }
 
 var _ header.Header[*SignedHeader] = &SignedHeader{}
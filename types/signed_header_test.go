@@ -1,6 +1,7 @@
 package types
 
 import (
+	"crypto/rand"
 	"fmt"
 	"testing"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rollkit/rollkit/pkg/signer"
 	"github.com/rollkit/rollkit/pkg/signer/noop"
 )
 
@@ -246,3 +248,41 @@ func testValidateBasic(t *testing.T, untrustedAdj *SignedHeader, privKey crypto.
 		})
 	}
 }
+
+func TestSignedHeaderSignatureSchemes(t *testing.T) {
+	chainID := "TestSignedHeaderSignatureSchemes"
+	for _, scheme := range []string{signer.SchemeSecp256k1, signer.SchemeBLS} {
+		t.Run(scheme, func(t *testing.T) {
+			privKey, _, err := signer.GenerateKeyPair(scheme, rand.Reader)
+			require.NoError(t, err)
+			noopSigner, err := noop.NewNoopSigner(privKey)
+			require.NoError(t, err)
+			trusted, err := GetRandomSignedHeaderCustom(&HeaderConfig{Height: 1, Signer: noopSigner}, chainID)
+			require.NoError(t, err)
+			require.NoError(t, trusted.ValidateBasic())
+
+			// the key survives the serialization of the header
+			bz, err := trusted.MarshalBinary()
+			require.NoError(t, err)
+			var decoded SignedHeader
+			require.NoError(t, decoded.UnmarshalBinary(bz))
+			require.NoError(t, decoded.ValidateBasic())
+			assert.True(t, trusted.Signer.PubKey.Equals(decoded.Signer.PubKey))
+
+			// headers signed with a key of another scheme are rejected
+			edKey, _, err := crypto.GenerateEd25519Key(rand.Reader)
+			require.NoError(t, err)
+			edSigner, err := noop.NewNoopSigner(edKey)
+			require.NoError(t, err)
+			untrusted, err := GetRandomNextSignedHeader(trusted, edSigner, chainID)
+			require.NoError(t, err)
+			untrusted.Signer, err = NewSigner(edKey.GetPublic())
+			require.NoError(t, err)
+			untrusted.ProposerAddress = untrusted.Signer.Address
+			untrusted.Signature, err = GetSignature(untrusted.Header, edSigner)
+			require.NoError(t, err)
+			require.NoError(t, untrusted.ValidateBasic())
+			assert.Equal(t, &header.VerifyError{Reason: ErrSignatureSchemeMismatch}, trusted.Verify(untrusted))
+		})
+	}
+}
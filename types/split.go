@@ -0,0 +1,174 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// blobPartPrefix marks the parts of a blob split across several DA blobs, it is followed by the SHA-256 digest
+// of the split blob, the index of the part and the number of parts as unsigned varints, and the payload of the
+// part. Like blobEnvelopePrefix, the prefix starts with a zero byte, so a part is never mistaken for a single
+// header or batch.
+var blobPartPrefix = []byte{0x00, 'r', 'k', 'p'}
+
+const (
+	// maxBlobPartOverhead is the maximum size of the envelope of a blob part
+	maxBlobPartOverhead = 4 + sha256.Size + 2*binary.MaxVarintLen64
+
+	// MaxBlobParts is the maximum number of parts a blob is split into.
+	MaxBlobParts = 4096
+
+	// maxPendingSplitBlobs is the maximum number of split blobs reassembled at once by a BlobAssembler, the
+	// oldest incomplete blob is dropped beyond it
+	maxPendingSplitBlobs = 16
+)
+
+var (
+	// ErrInvalidBlobPart is returned for blob parts whose envelope cannot be decoded.
+	ErrInvalidBlobPart = errors.New("invalid blob part")
+	// ErrMaxBlobSizeTooSmall is returned when splitting a blob into parts smaller than their envelope.
+	ErrMaxBlobSizeTooSmall = errors.New("maximum blob size too small to split blob")
+	// ErrTooManyBlobParts is returned when splitting a blob into more than MaxBlobParts parts.
+	ErrTooManyBlobParts = errors.New("too many blob parts")
+)
+
+// BlobPart is a part of a blob split across several DA blobs.
+type BlobPart struct {
+	// Digest is the SHA-256 digest of the split blob, identifying the parts of the same blob
+	Digest [sha256.Size]byte
+	Index  uint64
+	Total  uint64
+	// Payload is the slice of the split blob held by the part
+	Payload []byte
+}
+
+// SplitBlob splits a blob larger than maxSize into parts of at most maxSize bytes, each wrapped in an envelope
+// identifying the blob and the position of the part, so that blobs exceeding the maximum blob size of the DA
+// layer can be submitted. Blobs of at most maxSize bytes, or any blob if maxSize is 0, are returned unchanged.
+func SplitBlob(blob []byte, maxSize uint64) ([][]byte, error) {
+	if maxSize == 0 || uint64(len(blob)) <= maxSize {
+		return [][]byte{blob}, nil
+	}
+	if maxSize <= maxBlobPartOverhead {
+		return nil, fmt.Errorf("%w: %d bytes", ErrMaxBlobSizeTooSmall, maxSize)
+	}
+	partSize := maxSize - maxBlobPartOverhead
+	total := (uint64(len(blob)) + partSize - 1) / partSize
+	if total > MaxBlobParts {
+		return nil, fmt.Errorf("%w: %d parts of %d bytes", ErrTooManyBlobParts, total, maxSize)
+	}
+	digest := sha256.Sum256(blob)
+	parts := make([][]byte, 0, total)
+	for index := uint64(0); index < total; index++ {
+		payload := blob[index*partSize : min((index+1)*partSize, uint64(len(blob)))]
+		part := make([]byte, 0, maxBlobPartOverhead+len(payload))
+		part = append(part, blobPartPrefix...)
+		part = append(part, digest[:]...)
+		part = binary.AppendUvarint(part, index)
+		part = binary.AppendUvarint(part, total)
+		parts = append(parts, append(part, payload...))
+	}
+	return parts, nil
+}
+
+// ParseBlobPart decodes a blob part created by SplitBlob. It returns false if the blob is not a blob part.
+func ParseBlobPart(blob []byte) (BlobPart, bool, error) {
+	if !bytes.HasPrefix(blob, blobPartPrefix) {
+		return BlobPart{}, false, nil
+	}
+	rest := blob[len(blobPartPrefix):]
+	var part BlobPart
+	if len(rest) < sha256.Size {
+		return BlobPart{}, true, ErrInvalidBlobPart
+	}
+	copy(part.Digest[:], rest)
+	rest = rest[sha256.Size:]
+	index, n := binary.Uvarint(rest)
+	if n <= 0 {
+		return BlobPart{}, true, ErrInvalidBlobPart
+	}
+	rest = rest[n:]
+	total, n := binary.Uvarint(rest)
+	if n <= 0 || index >= total || total > MaxBlobParts {
+		return BlobPart{}, true, ErrInvalidBlobPart
+	}
+	part.Index, part.Total, part.Payload = index, total, rest[n:]
+	return part, true, nil
+}
+
+// BlobAssembler reassembles the blobs split by SplitBlob from their parts, received in any order. Reassembled
+// blobs are limited to MaxDecompressedBlobSize. Its zero value is ready to use.
+type BlobAssembler struct {
+	mu      sync.Mutex
+	pending map[[sha256.Size]byte]*splitBlob
+	// order holds the digests of the pending blobs, oldest first
+	order [][sha256.Size]byte
+}
+
+// splitBlob holds the parts of a blob received so far.
+type splitBlob struct {
+	parts    [][]byte
+	received uint64
+	size     uint64
+}
+
+// Add adds a part, and returns the reassembled blob and true once all parts of the blob were added. Parts whose
+// blob exceeds MaxDecompressedBlobSize or does not match its digest are dropped with an error.
+func (a *BlobAssembler) Add(part BlobPart) ([]byte, bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.pending == nil {
+		a.pending = make(map[[sha256.Size]byte]*splitBlob)
+	}
+	blob, ok := a.pending[part.Digest]
+	if !ok {
+		if len(a.order) == maxPendingSplitBlobs {
+			delete(a.pending, a.order[0])
+			a.order = a.order[1:]
+		}
+		blob = &splitBlob{parts: make([][]byte, part.Total)}
+		a.pending[part.Digest] = blob
+		a.order = append(a.order, part.Digest)
+	}
+	if part.Total != uint64(len(blob.parts)) {
+		// the blob was split again into another number of parts, e.g. after its parts were rejected as too
+		// big, and the parts of the previous split are superseded
+		*blob = splitBlob{parts: make([][]byte, part.Total)}
+	}
+	if blob.parts[part.Index] != nil {
+		// duplicate part, e.g. resubmitted
+		return nil, false, nil
+	}
+	blob.size += uint64(len(part.Payload))
+	if blob.size > MaxDecompressedBlobSize {
+		a.remove(part.Digest)
+		return nil, false, ErrBlobTooLarge
+	}
+	blob.parts[part.Index] = bytes.Clone(part.Payload)
+	blob.received++
+	if blob.received < part.Total {
+		return nil, false, nil
+	}
+
+	a.remove(part.Digest)
+	full := bytes.Join(blob.parts, nil)
+	if sha256.Sum256(full) != part.Digest {
+		return nil, false, fmt.Errorf("%w: digest mismatch", ErrInvalidBlobPart)
+	}
+	return full, true, nil
+}
+
+// remove drops the parts of a pending blob. It must be called with mu held.
+func (a *BlobAssembler) remove(digest [sha256.Size]byte) {
+	delete(a.pending, digest)
+	for i, d := range a.order {
+		if d == digest {
+			a.order = append(a.order[:i], a.order[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitBlob(t *testing.T) {
+	blob := GetRandomBytes(1000)
+
+	// blobs within the maximum size are not split
+	parts, err := SplitBlob(blob, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{blob}, parts)
+	parts, err = SplitBlob(blob, 0)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{blob}, parts)
+
+	parts, err = SplitBlob(blob, 200)
+	require.NoError(t, err)
+	require.Greater(t, len(parts), 5)
+	var assembler BlobAssembler
+	// parts are reassembled in any order, duplicates are ignored
+	for i := len(parts) - 1; i > 0; i-- {
+		assert.LessOrEqual(t, len(parts[i]), 200)
+		part, ok, err := ParseBlobPart(parts[i])
+		require.NoError(t, err)
+		require.True(t, ok)
+		_, done, err := assembler.Add(part)
+		require.NoError(t, err)
+		require.False(t, done)
+		_, done, err = assembler.Add(part)
+		require.NoError(t, err)
+		require.False(t, done)
+	}
+	part, _, err := ParseBlobPart(parts[0])
+	require.NoError(t, err)
+	assembled, done, err := assembler.Add(part)
+	require.NoError(t, err)
+	require.True(t, done)
+	assert.Equal(t, blob, assembled)
+
+	_, err = SplitBlob(blob, maxBlobPartOverhead)
+	assert.ErrorIs(t, err, ErrMaxBlobSizeTooSmall)
+	_, err = SplitBlob(make([]byte, 2*MaxBlobParts), maxBlobPartOverhead+1)
+	assert.ErrorIs(t, err, ErrTooManyBlobParts)
+}
+
+func TestParseBlobPart(t *testing.T) {
+	// other blobs are not parts
+	_, ok, err := ParseBlobPart([]byte{0x0a, 0x01, 0x02})
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	parts, err := SplitBlob(GetRandomBytes(1000), 300)
+	require.NoError(t, err)
+	_, ok, err = ParseBlobPart(parts[0][:len(blobPartPrefix)+10])
+	assert.True(t, ok)
+	assert.ErrorIs(t, err, ErrInvalidBlobPart)
+
+	// parts of tampered blobs are rejected once reassembled
+	var assembler BlobAssembler
+	for i, bz := range parts {
+		part, _, err := ParseBlobPart(bz)
+		require.NoError(t, err)
+		if i == 0 {
+			part.Payload = append([]byte{}, part.Payload...)
+			part.Payload[0] ^= 0xff
+		}
+		_, done, err := assembler.Add(part)
+		if i < len(parts)-1 {
+			require.NoError(t, err)
+			continue
+		}
+		assert.False(t, done)
+		assert.ErrorIs(t, err, ErrInvalidBlobPart)
+	}
+}
+
+func TestBlobAssemblerSplitAgain(t *testing.T) {
+	blob := GetRandomBytes(1000)
+	small, err := SplitBlob(blob, 200)
+	require.NoError(t, err)
+	large, err := SplitBlob(blob, 400)
+	require.NoError(t, err)
+	require.NotEqual(t, len(small), len(large))
+
+	// the parts of a blob split again supersede the parts of the previous split
+	var assembler BlobAssembler
+	for _, bz := range append(small[:2:2], large...) {
+		part, _, err := ParseBlobPart(bz)
+		require.NoError(t, err)
+		assembled, done, err := assembler.Add(part)
+		require.NoError(t, err)
+		if done {
+			assert.Equal(t, blob, assembled)
+			return
+		}
+	}
+	t.Fatal("blob not reassembled")
+}
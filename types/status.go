@@ -0,0 +1,45 @@
+package types
+
+// Modes of nodes reported in NodeStatus.
+const (
+	// NodeModeAggregator is the mode of nodes producing blocks.
+	NodeModeAggregator = "aggregator"
+	// NodeModeFull is the mode of full nodes syncing blocks produced by an aggregator.
+	NodeModeFull = "full"
+	// NodeModeBased is the mode of full nodes deriving blocks from the batches posted to the DA layer.
+	NodeModeBased = "based"
+	// NodeModeLight is the mode of light nodes, syncing headers only.
+	NodeModeLight = "light"
+)
+
+// NodeStatus is a snapshot of the sync progress of a node.
+type NodeStatus struct {
+	// Mode is the mode of the node, one of the NodeMode constants
+	Mode string
+	// Height is the height of the last block in the store, or of the last header for light nodes
+	Height uint64
+	// DAIncludedHeight is the height up to which blocks are included in the DA layer
+	DAIncludedHeight uint64
+	// DAHeight is the next DA height to retrieve
+	DAHeight uint64
+	// DAHeadHeight is the latest DA height seen by the node, 0 if none was seen yet
+	DAHeadHeight uint64
+	// PendingHeaders is the number of headers produced but not submitted to the DA layer yet
+	PendingHeaders uint64
+	// PendingBatches is the number of batches queued for submission to the DA layer
+	PendingBatches uint64
+	// Peers is the number of connected p2p peers
+	Peers int
+	// CatchingUp is true while the node is behind the DA layer or its p2p peers
+	CatchingUp bool
+	// ExecutorError is the reason the executor is unhealthy, empty if it is healthy or does not report its health
+	ExecutorError string
+	// DAError is the reason the DA layer is unreachable, empty if it is reachable or the node has no DA client
+	DAError string
+	// P2PListening is true if the node listens for p2p connections
+	P2PListening bool
+	// SyncLag is the number of blocks by which the node trails the latest block known from its p2p peers
+	SyncLag uint64
+	// Paused is true while block production of the aggregator is paused through the AdminService
+	Paused bool
+}
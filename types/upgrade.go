@@ -0,0 +1,151 @@
+package types
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rollkit/rollkit/pkg/signer"
+	pb "github.com/rollkit/rollkit/types/pb/rollkit/v1"
+)
+
+// MaxBlockVersion is the latest block protocol version supported by this binary. Nodes halt at the height of
+// an upgrade to a later version until their binary is swapped.
+const MaxBlockVersion uint64 = 1
+
+// ErrInvalidUpgrade is returned for upgrades which are malformed or not signed by the sequencer.
+var ErrInvalidUpgrade = errors.New("invalid upgrade")
+
+// Upgrade schedules a chain upgrade: headers from Height on must follow the protocol parameters set by the
+// upgrade. Parameters left empty are not changed by the upgrade.
+//
+// The upgrade is signed by the sequencer and posted to the DA layer, so that all nodes switch protocol
+// parameters at the same height and reject the headers of a sequencer which did not.
+type Upgrade struct {
+	ChainID string
+	// Name identifies the upgrade to operators
+	Name   string
+	Height uint64
+	// BlockVersion is the block protocol version of the headers from Height on, 0 to keep the current version
+	BlockVersion uint64
+	// Namespace is the hex encoded DA namespace of the headers from Height on, empty to keep the current one
+	Namespace string
+	// SignatureScheme is the signature scheme of the sequencer key from Height on, empty to keep the current
+	// one. The sequencer switches to a key of the new scheme with a key rotation at Height.
+	SignatureScheme string
+	Signer          Signer
+	Signature       Signature
+}
+
+// SignBytes returns the bytes of the upgrade covered by its signature.
+func (u *Upgrade) SignBytes() ([]byte, error) {
+	up, err := u.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	up.Signature = nil
+	return proto.MarshalOptions{Deterministic: true}.Marshal(up)
+}
+
+// ValidateBasic checks that the upgrade is well formed and signed. It does not check that the signer is the
+// proposer, which depends on the key rotations.
+func (u *Upgrade) ValidateBasic() error {
+	if u.ChainID == "" {
+		return fmt.Errorf("%w: chain ID is empty", ErrInvalidUpgrade)
+	}
+	if u.Name == "" {
+		return fmt.Errorf("%w: name is empty", ErrInvalidUpgrade)
+	}
+	if u.Height == 0 {
+		return fmt.Errorf("%w: height is zero", ErrInvalidUpgrade)
+	}
+	if u.BlockVersion == 0 && u.Namespace == "" && u.SignatureScheme == "" {
+		return fmt.Errorf("%w: no protocol parameter is changed", ErrInvalidUpgrade)
+	}
+	if _, err := hex.DecodeString(u.Namespace); err != nil {
+		return fmt.Errorf("%w: invalid namespace: %w", ErrInvalidUpgrade, err)
+	}
+	if u.SignatureScheme != "" {
+		if err := signer.ValidateScheme(u.SignatureScheme); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidUpgrade, err)
+		}
+	}
+	if u.Signer.PubKey == nil {
+		return fmt.Errorf("%w: missing public key", ErrInvalidUpgrade)
+	}
+	if len(u.Signature) == 0 {
+		return fmt.Errorf("%w: %w", ErrInvalidUpgrade, ErrSignatureEmpty)
+	}
+	bz, err := u.SignBytes()
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidUpgrade, err)
+	}
+	verified, err := u.Signer.PubKey.Verify(bz, u.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidUpgrade, err)
+	}
+	if !verified {
+		return fmt.Errorf("%w: %w", ErrInvalidUpgrade, ErrSignatureVerificationFailed)
+	}
+	return nil
+}
+
+// ToProto converts Upgrade into protobuf representation and returns it.
+func (u *Upgrade) ToProto() (*pb.Upgrade, error) {
+	up := &pb.Upgrade{
+		ChainId:         u.ChainID,
+		Name:            u.Name,
+		Height:          u.Height,
+		BlockVersion:    u.BlockVersion,
+		Namespace:       u.Namespace,
+		SignatureScheme: u.SignatureScheme,
+		Signature:       u.Signature[:],
+	}
+	if u.Signer.PubKey != nil {
+		var err error
+		if up.PubKey, err = signer.MarshalPublicKey(u.Signer.PubKey); err != nil {
+			return nil, err
+		}
+	}
+	return up, nil
+}
+
+// FromProto fills Upgrade with data from its protobuf representation.
+func (u *Upgrade) FromProto(other *pb.Upgrade) error {
+	if other == nil {
+		return errors.New("upgrade is nil")
+	}
+	pubKey, err := signer.UnmarshalPublicKey(other.PubKey)
+	if err != nil {
+		return err
+	}
+	u.ChainID = other.ChainId
+	u.Name = other.Name
+	u.Height = other.Height
+	u.BlockVersion = other.BlockVersion
+	u.Namespace = other.Namespace
+	u.SignatureScheme = other.SignatureScheme
+	u.Signer = Signer{PubKey: pubKey, Address: KeyAddress(pubKey)}
+	u.Signature = other.Signature
+	return nil
+}
+
+// MarshalBinary encodes Upgrade into binary form and returns it.
+func (u *Upgrade) MarshalBinary() ([]byte, error) {
+	up, err := u.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(up)
+}
+
+// UnmarshalBinary decodes binary form of Upgrade into object.
+func (u *Upgrade) UnmarshalBinary(data []byte) error {
+	var up pb.Upgrade
+	if err := proto.Unmarshal(data, &up); err != nil {
+		return err
+	}
+	return u.FromProto(&up)
+}
@@ -0,0 +1,77 @@
+package types
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/pkg/signer"
+	"github.com/rollkit/rollkit/pkg/signer/noop"
+)
+
+func TestUpgrade(t *testing.T) {
+	s := newTestSigner(t)
+	upgrade, err := GetUpgrade(s, Upgrade{ChainID: "test", Name: "v2", Height: 10, BlockVersion: 2, Namespace: "0a0b", SignatureScheme: signer.SchemeBLS})
+	require.NoError(t, err)
+	require.NoError(t, upgrade.ValidateBasic())
+
+	bz, err := upgrade.MarshalBinary()
+	require.NoError(t, err)
+	var decoded Upgrade
+	require.NoError(t, decoded.UnmarshalBinary(bz))
+	require.NoError(t, decoded.ValidateBasic())
+	assert.Equal(t, upgrade.Signer.Address, decoded.Signer.Address)
+	assert.Equal(t, *upgrade, decoded)
+
+	tampered := decoded
+	tampered.BlockVersion = 3
+	unsigned := decoded
+	unsigned.Signature = nil
+	for name, invalid := range map[string]Upgrade{
+		"tampered":          tampered,
+		"unsigned":          unsigned,
+		"no chain ID":       {Name: "v2", Height: 10, BlockVersion: 2},
+		"no name":           {ChainID: "test", Height: 10, BlockVersion: 2},
+		"no height":         {ChainID: "test", Name: "v2", BlockVersion: 2},
+		"no change":         {ChainID: "test", Name: "v2", Height: 10},
+		"invalid namespace": {ChainID: "test", Name: "v2", Height: 10, Namespace: "xyz"},
+		"unknown scheme":    {ChainID: "test", Name: "v2", Height: 10, SignatureScheme: "rsa"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if len(invalid.Signature) == 0 && name != "unsigned" {
+				signed, err := GetUpgrade(s, invalid)
+				require.NoError(t, err)
+				invalid = *signed
+			}
+			assert.ErrorIs(t, invalid.ValidateBasic(), ErrInvalidUpgrade)
+		})
+	}
+}
+
+func TestSignedHeaderVerifySchemeUpgrade(t *testing.T) {
+	oldSigner := newTestSigner(t)
+	secpKey, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	newSigner, err := noop.NewNoopSigner(secpKey)
+	require.NoError(t, err)
+	trusted, err := GetFirstSignedHeader(oldSigner, "test")
+	require.NoError(t, err)
+
+	// the sequencer switches to a key of another scheme with a key rotation signed by its previous key
+	rotation, err := GetKeyRotation(oldSigner, secpKey.GetPublic(), "test", 2)
+	require.NoError(t, err)
+	sig, err := NewSigner(secpKey.GetPublic())
+	require.NoError(t, err)
+	untrusted := &SignedHeader{Header: GetRandomNextHeader(trusted.Header, "test"), Signer: sig, Rotation: rotation}
+	untrusted.ProposerAddress = sig.Address
+	untrusted.Signature, err = GetSignature(untrusted.Header, newSigner)
+	require.NoError(t, err)
+	require.NoError(t, untrusted.ValidateBasic())
+	require.NoError(t, trusted.Verify(untrusted))
+
+	untrusted.Rotation = nil
+	assert.Error(t, trusted.Verify(untrusted))
+}
@@ -250,6 +250,85 @@ func GetFirstSignedHeader(signer signer.Signer, chainID string) (*SignedHeader,
 	return &signedHeader, nil
 }
 
+// GetKeyRotation returns a key rotation to newKey at the given height, signed by the given signer.
+func GetKeyRotation(signer signer.Signer, newKey crypto.PubKey, chainID string, height uint64) (*KeyRotation, error) {
+	pk, err := signer.GetPublic()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := NewSigner(pk)
+	if err != nil {
+		return nil, err
+	}
+	rotation := &KeyRotation{ChainID: chainID, Height: height, NewKey: newKey, Signer: sig}
+	bz, err := rotation.SignBytes()
+	if err != nil {
+		return nil, err
+	}
+	if rotation.Signature, err = signer.Sign(bz); err != nil {
+		return nil, err
+	}
+	return rotation, nil
+}
+
+// GetUpgrade returns the upgrade signed by the signer.
+func GetUpgrade(signer signer.Signer, upgrade Upgrade) (*Upgrade, error) {
+	pk, err := signer.GetPublic()
+	if err != nil {
+		return nil, err
+	}
+	if upgrade.Signer, err = NewSigner(pk); err != nil {
+		return nil, err
+	}
+	bz, err := upgrade.SignBytes()
+	if err != nil {
+		return nil, err
+	}
+	if upgrade.Signature, err = signer.Sign(bz); err != nil {
+		return nil, err
+	}
+	return &upgrade, nil
+}
+
+// GetPreconfirmation returns the preconfirmation signed by the given signer.
+func GetPreconfirmation(signer signer.Signer, preconf Preconfirmation) (*Preconfirmation, error) {
+	pk, err := signer.GetPublic()
+	if err != nil {
+		return nil, err
+	}
+	if preconf.Signer, err = NewSigner(pk); err != nil {
+		return nil, err
+	}
+	bz, err := preconf.SignBytes()
+	if err != nil {
+		return nil, err
+	}
+	if preconf.Signature, err = signer.Sign(bz); err != nil {
+		return nil, err
+	}
+	return &preconf, nil
+}
+
+// GetDACAttestation returns the attestation of the given signer that it stores the data of the block of the header.
+func GetDACAttestation(signer signer.Signer, header *Header) (DACAttestation, error) {
+	var attestation DACAttestation
+	pk, err := signer.GetPublic()
+	if err != nil {
+		return attestation, err
+	}
+	if attestation.Signer, err = NewSigner(pk); err != nil {
+		return attestation, err
+	}
+	bz, err := DACSignBytes(header)
+	if err != nil {
+		return attestation, err
+	}
+	if attestation.Signature, err = signer.Sign(bz); err != nil {
+		return attestation, err
+	}
+	return attestation, nil
+}
+
 // GetGenesisWithPrivkey returns a genesis state and a private key
 func GetGenesisWithPrivkey(chainID string) (genesis.Genesis, crypto.PrivKey, crypto.PubKey) {
 	privKey, pubKey, err := crypto.GenerateEd25519Key(nil)